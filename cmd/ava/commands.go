@@ -0,0 +1,362 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func writeJSON(out io.Writer, v any) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// validateCmd validates one venue synchronously, as the Validate button does.
+func validateCmd(c func() *client) *cobra.Command {
+	var mode string
+	cmd := &cobra.Command{
+		Use:   "validate <venue-id>",
+		Short: "Validate one venue now",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil || id <= 0 {
+				return fmt.Errorf("invalid venue id %q", args[0])
+			}
+			path := fmt.Sprintf("/api/v1/venues/%d/validate", id)
+			if mode != "" {
+				path += "?mode=" + url.QueryEscape(mode)
+			}
+			var resp map[string]any
+			if err := c().do("POST", path, nil, &resp); err != nil {
+				return err
+			}
+			return writeJSON(cmd.OutOrStdout(), resp)
+		},
+	}
+	cmd.Flags().StringVar(&mode, "mode", "", "processing mode: score_only (default), auto_decide or dry_run")
+	return cmd
+}
+
+// requeueCmd queues the pending venues matching filters, like POST /api/v1/validate/by-filter.
+// Venues whose processing failed stay pending for review, so requeuing them by score, prompt
+// version or submission date is how failed runs are retried.
+func requeueCmd(c func() *client) *cobra.Command {
+	var body struct {
+		PathPrefix     string `json:"path_prefix,omitempty"`
+		Category       *int   `json:"category,omitempty"`
+		MinScore       int    `json:"min_score,omitempty"`
+		MaxScore       int    `json:"max_score,omitempty"`
+		SubmittedAfter string `json:"submitted_after,omitempty"`
+		PromptVersion  string `json:"prompt_version,omitempty"`
+		Mode           string `json:"mode,omitempty"`
+		Limit          int    `json:"limit,omitempty"`
+	}
+	var category int
+	cmd := &cobra.Command{
+		Use:   "requeue",
+		Short: "Requeue pending venues matching filters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed("category") {
+				body.Category = &category
+			}
+			return queueByFilter(c(), cmd.OutOrStdout(), body)
+		},
+	}
+	f := cmd.Flags()
+	f.StringVar(&body.PathPrefix, "path-prefix", "", "venues whose location path starts with this")
+	f.IntVar(&category, "category", 0, "venue category")
+	f.IntVar(&body.MinScore, "min-score", 0, "lowest last validation score")
+	f.IntVar(&body.MaxScore, "max-score", 0, "highest last validation score")
+	f.StringVar(&body.SubmittedAfter, "submitted-after", "", "date (2006-01-02) or RFC 3339 time")
+	f.StringVar(&body.PromptVersion, "prompt-version", "", "venues last scored under this prompt version")
+	f.StringVar(&body.Mode, "mode", "", "processing mode: score_only (default), auto_decide or dry_run")
+	f.IntVar(&body.Limit, "limit", 0, "most venues to queue (server default 1000)")
+	return cmd
+}
+
+// requeueDeadLettersCmd queues the dead letters: pending venues whose latest validation
+// failed, like POST /api/v1/validate/by-filter with "failed": true.
+func requeueDeadLettersCmd(c func() *client) *cobra.Command {
+	var body struct {
+		Failed bool   `json:"failed"`
+		Mode   string `json:"mode,omitempty"`
+		Limit  int    `json:"limit,omitempty"`
+	}
+	cmd := &cobra.Command{
+		Use:   "requeue-dead-letters",
+		Short: "Requeue pending venues whose latest validation failed",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			body.Failed = true
+			return queueByFilter(c(), cmd.OutOrStdout(), body)
+		},
+	}
+	f := cmd.Flags()
+	f.StringVar(&body.Mode, "mode", "", "processing mode: score_only (default), auto_decide or dry_run")
+	f.IntVar(&body.Limit, "limit", 0, "most venues to queue (server default 1000)")
+	return cmd
+}
+
+// queueByFilter posts body to /api/v1/validate/by-filter and prints what was queued.
+func queueByFilter(c *client, out io.Writer, body any) error {
+	var resp struct {
+		Status    string `json:"status"`
+		Queued    int    `json:"queued"`
+		Mode      string `json:"mode"`
+		Truncated bool   `json:"truncated"`
+		RunID     int64  `json:"run_id"`
+		Reason    string `json:"reason"`
+	}
+	if err := c.do("POST", "/api/v1/validate/by-filter", body, &resp); err != nil {
+		return err
+	}
+	if resp.Queued == 0 {
+		fmt.Fprintf(out, "Nothing queued: %s\n", resp.Reason)
+		return nil
+	}
+	fmt.Fprintf(out, "Queued %d venues (%s)", resp.Queued, resp.Mode)
+	if resp.RunID != 0 {
+		fmt.Fprintf(out, " as run %d", resp.RunID)
+	}
+	fmt.Fprintln(out)
+	if resp.Truncated {
+		fmt.Fprintln(out, "More venues match; run again or raise --limit")
+	}
+	return nil
+}
+
+type migration struct {
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	AppliedAt *time.Time `json:"applied_at"`
+}
+
+// migrateCmd applies the pending schema migrations of db_changes.md, or with --status lists
+// them all. Applying needs a superadmin's token.
+func migrateCmd(c func() *client) *cobra.Command {
+	var status bool
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply pending database migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+			if status {
+				var resp struct {
+					Migrations []migration `json:"migrations"`
+					Pending    int         `json:"pending"`
+				}
+				if err := c().do("GET", "/api/v1/migrations", nil, &resp); err != nil {
+					return err
+				}
+				for _, m := range resp.Migrations {
+					state := "pending"
+					if m.AppliedAt != nil {
+						state = "applied " + m.AppliedAt.Local().Format("2006-01-02 15:04:05")
+					}
+					fmt.Fprintf(out, "%3d  %-34s %s\n", m.Version, m.Name, state)
+				}
+				fmt.Fprintf(out, "%d pending\n", resp.Pending)
+				return nil
+			}
+
+			cl := c()
+			// Building an index on a large table takes a while
+			cl.http.Timeout = 0
+			var resp struct {
+				Applied []migration `json:"applied"`
+			}
+			if err := cl.do("POST", "/api/v1/migrations/apply", nil, &resp); err != nil {
+				return err
+			}
+			if len(resp.Applied) == 0 {
+				fmt.Fprintln(out, "Nothing to apply")
+			}
+			for _, m := range resp.Applied {
+				fmt.Fprintf(out, "Applied %d %s\n", m.Version, m.Name)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&status, "status", false, "list migrations instead of applying them")
+	return cmd
+}
+
+type feedbackItem struct {
+	ID            int64     `json:"id"`
+	VenueID       int64     `json:"venue_id"`
+	VenueName     string    `json:"venue_name"`
+	PromptVersion *string   `json:"prompt_version,omitempty"`
+	FeedbackType  string    `json:"feedback_type"`
+	Comment       *string   `json:"comment,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// feedbackCmd exports all editor feedback, newest first, as CSV or JSON lines.
+func feedbackCmd(c func() *client) *cobra.Command {
+	var format string
+	cmd := &cobra.Command{
+		Use:   "feedback",
+		Short: "Export all editor feedback",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "csv" && format != "json" {
+				return fmt.Errorf("--format must be csv or json")
+			}
+			out := cmd.OutOrStdout()
+			cw := csv.NewWriter(out)
+			enc := json.NewEncoder(out)
+			if format == "csv" {
+				_ = cw.Write([]string{"id", "venue_id", "venue_name", "feedback_type", "prompt_version", "comment", "created_at"})
+			}
+			const limit = 500
+			for page, seen := 1, 0; ; page++ {
+				var resp struct {
+					Items []feedbackItem `json:"items"`
+					Total int            `json:"total"`
+				}
+				if err := c().do("GET", fmt.Sprintf("/api/v1/feedback?page=%d&limit=%d", page, limit), nil, &resp); err != nil {
+					return err
+				}
+				for _, f := range resp.Items {
+					if format == "json" {
+						if err := enc.Encode(f); err != nil {
+							return err
+						}
+						continue
+					}
+					_ = cw.Write([]string{strconv.FormatInt(f.ID, 10), strconv.FormatInt(f.VenueID, 10), f.VenueName,
+						f.FeedbackType, deref(f.PromptVersion), deref(f.Comment), f.CreatedAt.Format(time.RFC3339)})
+				}
+				seen += len(resp.Items)
+				if len(resp.Items) < limit || seen >= resp.Total {
+					break
+				}
+			}
+			cw.Flush()
+			return cw.Error()
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", "csv", "csv or json (one object per line)")
+	return cmd
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// statsCmd prints the engine statistics of /api/stats.
+func statsCmd(c func() *client) *cobra.Command {
+	var raw bool
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show processing engine statistics",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+			if raw {
+				var resp map[string]any
+				if err := c().do("GET", "/api/stats", nil, &resp); err != nil {
+					return err
+				}
+				return writeJSON(out, resp)
+			}
+			var st struct {
+				TotalJobs, CompletedJobs, SuccessfulJobs, FailedJobs int64
+				AutoApproved, ManualReview, AutoRejected             int64
+				QueueSize                                            int64
+				WorkerCount                                          int
+				TotalCostUSD, GoogleCostUSD                          float64
+				StartTime, LastActivity                              time.Time
+				Latency                                              struct{ P50Ms, P95Ms, P99Ms float64 }
+			}
+			if err := c().do("GET", "/api/stats", nil, &st); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Workers:   %d, %d jobs queued\n", st.WorkerCount, st.QueueSize)
+			fmt.Fprintf(out, "Jobs:      %d completed of %d (%d succeeded, %d failed)\n", st.CompletedJobs, st.TotalJobs, st.SuccessfulJobs, st.FailedJobs)
+			fmt.Fprintf(out, "Decisions: %d approved, %d manual review, %d rejected\n", st.AutoApproved, st.ManualReview, st.AutoRejected)
+			fmt.Fprintf(out, "Latency:   p50 %.0fms, p95 %.0fms, p99 %.0fms\n", st.Latency.P50Ms, st.Latency.P95Ms, st.Latency.P99Ms)
+			fmt.Fprintf(out, "Cost:      $%.2f OpenAI, $%.2f Google\n", st.TotalCostUSD, st.GoogleCostUSD)
+			fmt.Fprintf(out, "Running since %s, last activity %s\n", st.StartTime.Format(time.RFC3339), st.LastActivity.Format(time.RFC3339))
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&raw, "json", false, "print the full /api/stats response")
+	return cmd
+}
+
+type eventEntry struct {
+	Seq     int64     `json:"seq"`
+	VenueID int64     `json:"venue_id"`
+	Type    string    `json:"type"`
+	Ts      time.Time `json:"ts"`
+	Admin   *string   `json:"admin,omitempty"`
+	Summary string    `json:"summary"`
+}
+
+// eventsCmd prints the latest events and, with -f, keeps polling for new ones.
+func eventsCmd(c func() *client) *cobra.Command {
+	var (
+		n        int
+		follow   bool
+		interval time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "Show or follow the event stream",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if n < 1 || n > 1000 {
+				return fmt.Errorf("-n must be between 1 and 1000")
+			}
+			out := cmd.OutOrStdout()
+			path := fmt.Sprintf("/api/v1/events?limit=%d", n)
+			for {
+				var resp struct {
+					Events []eventEntry `json:"events"`
+					Next   int64        `json:"next"`
+				}
+				if err := c().do("GET", path, nil, &resp); err != nil {
+					return err
+				}
+				for _, e := range resp.Events {
+					actor := ""
+					if e.Admin != nil {
+						actor = " by " + *e.Admin
+					}
+					fmt.Fprintf(out, "%s  #%d  venue %d  %s%s\n", e.Ts.Local().Format("2006-01-02 15:04:05"), e.Seq, e.VenueID, e.Summary, actor)
+				}
+				if !follow {
+					return nil
+				}
+				// Drain a backlog without waiting; poll once caught up
+				if len(resp.Events) < 1000 {
+					select {
+					case <-cmd.Context().Done():
+						return nil
+					case <-time.After(interval):
+					}
+				}
+				path = fmt.Sprintf("/api/v1/events?after=%d&limit=1000", resp.Next)
+			}
+		},
+	}
+	f := cmd.Flags()
+	f.IntVarP(&n, "lines", "n", 20, "latest events to show first")
+	f.BoolVarP(&follow, "follow", "f", false, "keep printing new events")
+	f.DurationVar(&interval, "interval", 2*time.Second, "poll interval with -f")
+	return cmd
+}
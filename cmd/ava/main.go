@@ -0,0 +1,57 @@
+// Command ava runs operational tasks against a running service over its JSON API, for
+// operators who would rather not click through the admin UI:
+//
+//	ava validate 4711                      validate one venue now (--mode dry_run to preview)
+//	ava requeue --prompt-version v3        requeue pending venues matching filters
+//	ava requeue-dead-letters               requeue pending venues whose validation failed
+//	ava feedback --format csv > fb.csv     export all editor feedback
+//	ava migrate                            apply pending database migrations (--status lists)
+//	ava stats                              engine statistics
+//	ava events -f                          follow the event stream
+//
+// The service URL comes from --url or AVA_URL and the API token from --token or AVA_TOKEN.
+// Tokens are created under Settings > API tokens; validate and the requeue commands need
+// the validate scope, migrate a superadmin's token with the admin scope, the rest read.
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err := newRootCmd().ExecuteContext(ctx)
+	stop()
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+// newRootCmd builds the ava command tree. Subcommands get their client from the persistent
+// --url and --token flags.
+func newRootCmd() *cobra.Command {
+	var url, token string
+	root := &cobra.Command{
+		Use:          "ava",
+		Short:        "Operate the assisted venue approval service from a terminal",
+		SilenceUsage: true,
+	}
+	root.PersistentFlags().StringVar(&url, "url", envOr("AVA_URL", "http://localhost:8080"), "service URL (AVA_URL)")
+	root.PersistentFlags().StringVar(&token, "token", os.Getenv("AVA_TOKEN"), "API token (AVA_TOKEN)")
+	c := func() *client { return newClient(url, token) }
+
+	root.AddCommand(validateCmd(c), requeueCmd(c), requeueDeadLettersCmd(c), migrateCmd(c), feedbackCmd(c), statsCmd(c), eventsCmd(c))
+	return root
+}
+
+func envOr(key, def string) string {
+	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+		return v
+	}
+	return def
+}
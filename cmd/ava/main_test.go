@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakeService records the requests ava makes and answers them like the service does.
+func fakeService(t *testing.T, seen *[]string) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/venues/{id}/validate", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") == "404" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"status":"error","message":"Failed to load venue: not found"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"status":"success","venueId":%s,"mode":%q}`, r.PathValue("id"), r.URL.Query().Get("mode"))
+	})
+	mux.HandleFunc("POST /api/v1/validate/by-filter", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		*seen = append(*seen, fmt.Sprint(body))
+		fmt.Fprint(w, `{"status":"queued","queued":3,"mode":"score_only","truncated":true,"run_id":9}`)
+	})
+	mux.HandleFunc("GET /api/v1/migrations", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"migrations":[{"version":36,"name":"audit_status_duplicate_rejected","applied_at":"2026-01-02T03:04:05Z"},
+			{"version":37,"name":"fulltext_search"}],"pending":1}`)
+	})
+	mux.HandleFunc("POST /api/v1/migrations/apply", func(w http.ResponseWriter, r *http.Request) {
+		*seen = append(*seen, r.Method+" "+r.URL.Path)
+		fmt.Fprint(w, `{"applied":[{"version":37,"name":"fulltext_search","applied_at":"2026-01-03T00:00:00Z"}]}`)
+	})
+	mux.HandleFunc("GET /api/v1/feedback", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "1" {
+			fmt.Fprint(w, `{"items":[],"total":2}`)
+			return
+		}
+		fmt.Fprint(w, `{"items":[{"id":2,"venue_id":5,"venue_name":"Tofu, Inc","feedback_type":"thumbs_down","comment":"wrong","created_at":"2026-01-02T03:04:05Z"},
+			{"id":1,"venue_id":6,"venue_name":"Leaf","feedback_type":"thumbs_up","created_at":"2026-01-01T00:00:00Z"}],"total":2}`)
+	})
+	mux.HandleFunc("GET /api/v1/events", func(w http.ResponseWriter, r *http.Request) {
+		*seen = append(*seen, r.URL.RawQuery)
+		fmt.Fprint(w, `{"events":[{"seq":41,"venue_id":5,"type":"venue.approved","ts":"2026-01-02T03:04:05Z","admin":"ana","summary":"Approved"}],"next":41}`)
+	})
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer ava_test" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+}
+
+func TestCommands(t *testing.T) {
+	var seen []string
+	srv := fakeService(t, &seen)
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		args    []string
+		fails   bool
+		want    []string // substrings of stdout, or of stderr when it fails
+		wantReq string   // substring of the recorded request, if any
+	}{
+		{"validate", []string{"validate", "--mode", "dry_run", "4711"}, false, []string{`"venueId": 4711`, `"mode": "dry_run"`}, ""},
+		{"validate error", []string{"validate", "404"}, true, []string{"404 Not Found: Failed to load venue: not found"}, ""},
+		{"validate bad id", []string{"validate", "x"}, true, []string{`invalid venue id "x"`}, ""},
+		{"requeue", []string{"requeue", "--prompt-version", "v3", "--category", "0"}, false,
+			[]string{"Queued 3 venues (score_only) as run 9", "More venues match"}, "category:0"},
+		{"requeue dead letters", []string{"requeue-dead-letters", "--limit", "50"}, false,
+			[]string{"Queued 3 venues (score_only) as run 9"}, "failed:true limit:50"},
+		{"feedback csv", []string{"feedback"}, false, []string{
+			"id,venue_id,venue_name,feedback_type,prompt_version,comment,created_at",
+			`2,5,"Tofu, Inc",thumbs_down,,wrong,2026-01-02T03:04:05Z`,
+			"1,6,Leaf,thumbs_up,,,2026-01-01T00:00:00Z"}, ""},
+		{"feedback json", []string{"feedback", "--format", "json"}, false, []string{`{"id":1,"venue_id":6,"venue_name":"Leaf"`}, ""},
+		{"events", []string{"events", "-n", "5"}, false, []string{"#41  venue 5  Approved by ana"}, "limit=5"},
+		{"migrate", []string{"migrate"}, false, []string{"Applied 37 fulltext_search"}, "POST /api/v1/migrations/apply"},
+		{"migrate status", []string{"migrate", "--status"}, false, []string{"audit_status_duplicate_rejected", " 37  fulltext_search", "pending\n1 pending"}, ""},
+		{"unknown command", []string{"rollback"}, true, []string{`unknown command "rollback"`}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen = nil
+			out, errOut, err := execute(append([]string{"--url", srv.URL, "--token", "ava_test"}, tt.args...)...)
+			if (err != nil) != tt.fails {
+				t.Fatalf("err = %v, want failure %v; stderr: %s", err, tt.fails, errOut)
+			}
+			got := out
+			if tt.fails {
+				got = errOut
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("output missing %q:\n%s", w, got)
+				}
+			}
+			if tt.wantReq != "" && (len(seen) == 0 || !strings.Contains(seen[0], tt.wantReq)) {
+				t.Errorf("requests = %q, want one with %q", seen, tt.wantReq)
+			}
+		})
+	}
+}
+
+func TestMissingToken(t *testing.T) {
+	var seen []string
+	srv := fakeService(t, &seen)
+	defer srv.Close()
+	if _, errOut, err := execute("--url", srv.URL, "--token", "", "stats"); err == nil || !strings.Contains(errOut, "401") {
+		t.Fatalf("err = %v, stderr %q", err, errOut)
+	}
+}
+
+// execute runs ava with args and returns what it wrote to stdout and stderr.
+func execute(args ...string) (string, string, error) {
+	var out, errOut strings.Builder
+	cmd := newRootCmd()
+	cmd.SetArgs(args)
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	err := cmd.Execute()
+	return out.String(), errOut.String(), err
+}
@@ -0,0 +1,145 @@
+package admin
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/database"
+)
+
+// Default report window when no range is given.
+const adminActivityDefaultDays = 30
+
+// AdminActivityHandler handles GET /analytics/admins?from=YYYY-MM-DD&to=YYYY-MM-DD
+// Per-reviewer decisions, overrides of the AI, handling time and batch usage.
+func AdminActivityHandler(db *database.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		from, to, err := parseDateRange(r.URL.Query(), time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		rows, err := db.GetAdminActivityCtx(r.Context(), from, to)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("admin activity error: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		var totals models.AdminActivity
+		for _, a := range rows {
+			totals.Approvals += a.Approvals
+			totals.Rejections += a.Rejections
+			totals.Overrides += a.Overrides
+			totals.BatchActions += a.BatchActions
+		}
+		data := struct {
+			Rows   []models.AdminActivity
+			Totals models.AdminActivity
+			From   string
+			To     string
+		}{
+			Rows:   rows,
+			Totals: totals,
+			From:   from.Format(time.DateOnly),
+			To:     to.AddDate(0, 0, -1).Format(time.DateOnly),
+		}
+		if err := ExecuteTemplate(w, "admin_activity.tmpl", data); err != nil {
+			http.Error(w, fmt.Sprintf("template error: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// APIAdminActivityHandler handles GET /api/analytics/admins?from=&to=&format=json|csv
+func APIAdminActivityHandler(db *database.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		from, to, err := parseDateRange(q, time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		rows, err := db.GetAdminActivityCtx(r.Context(), from, to)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("admin activity error: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		last := to.AddDate(0, 0, -1).Format(time.DateOnly)
+		if strings.EqualFold(q.Get("format"), "csv") {
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="admin-activity-%s-%s.csv"`, from.Format(time.DateOnly), last))
+			_ = writeAdminActivityCSV(w, rows)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"from":   from.Format(time.DateOnly),
+			"to":     last,
+			"admins": rows,
+		})
+	}
+}
+
+// parseDateRange reads inclusive from/to dates (YYYY-MM-DD, UTC) and returns [from, to+1d).
+// Missing bounds default to the last adminActivityDefaultDays days ending today.
+func parseDateRange(q map[string][]string, now time.Time) (time.Time, time.Time, error) {
+	get := func(k string) string {
+		if v := q[k]; len(v) > 0 {
+			return strings.TrimSpace(v[0])
+		}
+		return ""
+	}
+	today := now.UTC().Truncate(24 * time.Hour)
+	to := today
+	if s := get("to"); s != "" {
+		t, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid to date %q (want YYYY-MM-DD)", s)
+		}
+		to = t
+	}
+	from := to.AddDate(0, 0, -(adminActivityDefaultDays - 1))
+	if s := get("from"); s != "" {
+		t, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid from date %q (want YYYY-MM-DD)", s)
+		}
+		from = t
+	}
+	if from.After(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("from %s is after to %s", from.Format(time.DateOnly), to.Format(time.DateOnly))
+	}
+	return from, to.AddDate(0, 0, 1), nil
+}
+
+func writeAdminActivityCSV(w io.Writer, rows []models.AdminActivity) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"admin_id", "username", "approvals", "rejections", "total", "overrides", "override_rate_pct",
+		"batch_actions", "batch_rate_pct", "avg_handling_seconds", "first_action", "last_action"})
+	for _, a := range rows {
+		_ = cw.Write([]string{
+			strconv.Itoa(a.AdminID),
+			a.Username,
+			strconv.Itoa(a.Approvals),
+			strconv.Itoa(a.Rejections),
+			strconv.Itoa(a.Total()),
+			strconv.Itoa(a.Overrides),
+			strconv.FormatFloat(a.OverrideRate(), 'f', 1, 64),
+			strconv.Itoa(a.BatchActions),
+			strconv.FormatFloat(a.BatchRate(), 'f', 1, 64),
+			strconv.FormatFloat(a.AvgHandlingSeconds, 'f', 0, 64),
+			a.FirstAction.UTC().Format(time.RFC3339),
+			a.LastAction.UTC().Format(time.RFC3339),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,88 @@
+package admin
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+)
+
+func TestParseDateRange(t *testing.T) {
+	now := time.Date(2024, 5, 20, 15, 0, 0, 0, time.UTC)
+	day := func(m time.Month, d int) time.Time { return time.Date(2024, m, d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name     string
+		query    string
+		from, to time.Time
+		wantErr  bool
+	}{
+		{"default last 30 days", "", day(4, 21), day(5, 21), false},
+		{"explicit range, to inclusive", "from=2024-05-01&to=2024-05-07", day(5, 1), day(5, 8), false},
+		{"only to", "to=2024-03-31", day(3, 2), day(4, 1), false},
+		{"bad date", "from=May", time.Time{}, time.Time{}, true},
+		{"reversed", "from=2024-05-10&to=2024-05-01", time.Time{}, time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, _ := url.ParseQuery(tt.query)
+			from, to, err := parseDateRange(q, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v", err)
+			}
+			if !from.Equal(tt.from) || !to.Equal(tt.to) {
+				t.Fatalf("range = [%s, %s), want [%s, %s)", from, to, tt.from, tt.to)
+			}
+		})
+	}
+}
+
+func TestWriteAdminActivityCSV(t *testing.T) {
+	ts := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	rows := []models.AdminActivity{{
+		AdminID: 7, Username: "lead, team", Approvals: 3, Rejections: 1, Overrides: 1, BatchActions: 2,
+		AvgHandlingSeconds: 90.4, FirstAction: ts, LastAction: ts,
+	}}
+	var buf bytes.Buffer
+	if err := writeAdminActivityCSV(&buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("lines = %q", lines)
+	}
+	want := `7,"lead, team",3,1,4,1,25.0,2,50.0,90,2024-05-01T09:30:00Z,2024-05-01T09:30:00Z`
+	if lines[1] != want {
+		t.Fatalf("row = %s\nwant  %s", lines[1], want)
+	}
+}
+
+func TestAdminActivityTemplate(t *testing.T) {
+	if err := LoadTemplates(os.DirFS("../../web/templates")); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { adminTemplates = nil }()
+
+	ts := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	row := models.AdminActivity{AdminID: 7, Approvals: 2, Rejections: 2, Overrides: 1, AvgHandlingSeconds: 3600, FirstAction: ts, LastAction: ts}
+	data := struct {
+		Rows     []models.AdminActivity
+		Totals   models.AdminActivity
+		From, To string
+	}{[]models.AdminActivity{row}, row, "2024-05-01", "2024-05-07"}
+
+	rec := httptest.NewRecorder()
+	if err := ExecuteTemplate(rec, "admin_activity.tmpl", data); err != nil {
+		t.Fatal(err)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{"#7", "25.0%", "1h0m0s", "format=csv"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("page missing %q", want)
+		}
+	}
+}
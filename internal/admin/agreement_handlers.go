@@ -0,0 +1,46 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"assisted-venue-approval/internal/analytics"
+	"assisted-venue-approval/internal/processor"
+	"assisted-venue-approval/pkg/database"
+)
+
+// APIAgreementHandler handles GET /api/v1/analytics/agreement?from=YYYY-MM-DD&to=YYYY-MM-DD
+// Compares each venue's final admin decision with the AI verdict it was made on, overall
+// and by prompt version, category, region and chain (per the decision rules' chain list).
+func APIAgreementHandler(db *database.DB, engine *processor.ProcessingEngine) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		from, to, err := parseDateRange(r.URL.Query(), time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		pairs, err := db.GetDecisionPairsCtx(r.Context(), from, to)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("agreement error: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		rules := engine.DecisionRules()
+		for i := range pairs {
+			pairs[i].Chain = rules.ChainForName(pairs[i].Name)
+		}
+		report := analytics.Agreement(pairs)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+			analytics.AgreementReport
+		}{
+			From:            from.Format(time.DateOnly),
+			To:              to.AddDate(0, 0, -1).Format(time.DateOnly),
+			AgreementReport: report,
+		})
+	}
+}
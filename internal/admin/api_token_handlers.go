@@ -0,0 +1,150 @@
+package admin
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/database"
+
+	"github.com/gorilla/mux"
+)
+
+// maxAPITokenDays caps token lifetime; 0 in the form means no expiry.
+const maxAPITokenDays = 365
+
+type apiTokensPage struct {
+	Tokens   []models.APIToken
+	Scopes   []string
+	NewToken string // plaintext, shown once right after creation
+	Created  *models.APIToken
+	Error    string
+	Now      time.Time
+}
+
+// APITokensHandler handles GET /settings/api-tokens
+func APITokensHandler(db *database.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		renderAPITokens(w, r, db, apiTokensPage{})
+	}
+}
+
+// CreateAPITokenHandler handles POST /settings/api-tokens
+// Form: name, scope (repeated), expires_days (0 = never).
+func CreateAPITokenHandler(db *database.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		adminID, ok := auth.GetAdminIDFromContext(r.Context())
+		if !ok {
+			http.Error(w, "Admin ID not found in context", http.StatusForbidden)
+			return
+		}
+		if _, viaToken := auth.GetAPITokenFromContext(r.Context()); viaToken {
+			http.Error(w, "API tokens cannot manage API tokens", http.StatusForbidden)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "invalid form", http.StatusBadRequest)
+			return
+		}
+
+		tok, errMsg := apiTokenFromForm(r, adminID, time.Now())
+		if errMsg != "" {
+			renderAPITokens(w, r, db, apiTokensPage{Error: errMsg})
+			return
+		}
+		plaintext, prefix, hash, err := auth.GenerateAPIToken()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to generate token: %v", err), http.StatusInternalServerError)
+			return
+		}
+		tok.Prefix, tok.TokenHash = prefix, hash
+		if err := db.CreateAPITokenCtx(r.Context(), tok); err != nil {
+			http.Error(w, fmt.Sprintf("failed to save token: %v", err), http.StatusInternalServerError)
+			return
+		}
+		log.Printf("admin %d created API token %d (%s, scopes %s)", adminID, tok.ID, tok.Prefix, strings.Join(tok.Scopes, ","))
+		renderAPITokens(w, r, db, apiTokensPage{NewToken: plaintext, Created: tok})
+	}
+}
+
+// RevokeAPITokenHandler handles POST /settings/api-tokens/{id}/revoke
+func RevokeAPITokenHandler(db *database.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		adminID, ok := auth.GetAdminIDFromContext(r.Context())
+		if !ok {
+			http.Error(w, "Admin ID not found in context", http.StatusForbidden)
+			return
+		}
+		if _, viaToken := auth.GetAPITokenFromContext(r.Context()); viaToken {
+			http.Error(w, "API tokens cannot manage API tokens", http.StatusForbidden)
+			return
+		}
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			http.Error(w, "invalid token id", http.StatusBadRequest)
+			return
+		}
+		found, err := db.RevokeAPITokenCtx(r.Context(), id, time.Now())
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to revoke token: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if !found {
+			http.Error(w, "token not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("admin %d revoked API token %d", adminID, id)
+		http.Redirect(w, r, basePath+"settings/api-tokens", http.StatusSeeOther)
+	}
+}
+
+// apiTokenFromForm validates the create form. The returned message is empty on success.
+func apiTokenFromForm(r *http.Request, adminID int, now time.Time) (*models.APIToken, string) {
+	name := strings.TrimSpace(r.PostFormValue("name"))
+	if name == "" || len(name) > 100 {
+		return nil, "name is required (max 100 characters)"
+	}
+	var scopes []string
+	for _, s := range r.PostForm["scope"] {
+		if !auth.ValidScope(s) {
+			return nil, fmt.Sprintf("unknown scope %q", s)
+		}
+		scopes = append(scopes, s)
+	}
+	if len(scopes) == 0 {
+		return nil, "select at least one scope"
+	}
+	tok := &models.APIToken{Name: name, Scopes: scopes, AdminID: adminID, CreatedAt: now}
+	if v := strings.TrimSpace(r.PostFormValue("expires_days")); v != "" && v != "0" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 0 || days > maxAPITokenDays {
+			return nil, fmt.Sprintf("expires_days must be 0-%d", maxAPITokenDays)
+		}
+		exp := now.AddDate(0, 0, days)
+		tok.ExpiresAt = &exp
+	}
+	return tok, ""
+}
+
+func renderAPITokens(w http.ResponseWriter, r *http.Request, db *database.DB, page apiTokensPage) {
+	tokens, err := db.ListAPITokensCtx(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error fetching API tokens: %v", err), http.StatusInternalServerError)
+		return
+	}
+	page.Tokens = tokens
+	page.Scopes = auth.Scopes
+	page.Now = time.Now()
+	if page.Error != "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	if err := ExecuteTemplate(w, "api_tokens.tmpl", page); err != nil {
+		http.Error(w, fmt.Sprintf("template error: %v", err), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,65 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"assisted-venue-approval/internal/archive"
+)
+
+// HistoryArchiveHandler handles POST /api/history/archive
+// Body (optional): {"older_than_months": 12, "dry_run": true}. Months defaults to
+// HISTORY_RETENTION_MONTHS. A dry run responds 200 with the number of rows that would move;
+// a real run starts in the background and responds 202 (poll GET /api/history/archive).
+func HistoryArchiveHandler(arch *archive.Archiver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Months int  `json:"older_than_months"`
+			DryRun bool `json:"dry_run"`
+		}
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				http.Error(w, "invalid JSON body", http.StatusBadRequest)
+				return
+			}
+		}
+		if body.Months == 0 {
+			body.Months = arch.RetentionMonths()
+		}
+		if body.Months <= 0 {
+			http.Error(w, "older_than_months is required when HISTORY_RETENTION_MONTHS is 0", http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if body.DryRun {
+			run, err := arch.Preview(r.Context(), body.Months)
+			if err != nil {
+				WriteError(w, r, "Archival preview failed", err)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(run)
+			return
+		}
+
+		run, err := arch.Start(body.Months)
+		if err != nil {
+			WriteError(w, r, "Archival not started", err)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(run)
+	}
+}
+
+// HistoryArchiveStatusHandler handles GET /api/history/archive
+func HistoryArchiveStatusHandler(arch *archive.Archiver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp := map[string]interface{}{"retention_months": arch.RetentionMonths()}
+		if run, ok := arch.Last(); ok {
+			resp["last_run"] = run
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
@@ -0,0 +1,253 @@
+package admin
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+)
+
+const (
+	auditPerPage       = 100
+	auditExportChunk   = 1000
+	auditExportMaxRows = 50000
+)
+
+// auditActions are the statuses written to venue_validation_audit_logs, for the action filter.
+var auditActions = []string{"approved", "rejected", "reverted", "place_relinked", "change_applied", "change_rejected", "duplicate_rejected", "notified", "notify_failed"}
+
+// auditRow is an audit log entry with its data replacements as field changes.
+type auditRow struct {
+	domain.AuditLogEntry
+	Changes []domain.FieldChange
+	RawData string // data_replacements when it could not be parsed
+}
+
+func (a auditRow) Admin() string {
+	switch {
+	case a.AdminID == nil:
+		return "system"
+	case a.AdminUsername != "":
+		return fmt.Sprintf("%s #%d", a.AdminUsername, *a.AdminID)
+	}
+	return fmt.Sprintf("#%d", *a.AdminID)
+}
+
+func toAuditRows(entries []domain.AuditLogEntry) []auditRow {
+	rows := make([]auditRow, len(entries))
+	for i, e := range entries {
+		rows[i].AuditLogEntry = e
+		vdr, err := domain.ParseVenueDataReplacement(e.DataReplacements)
+		if err != nil {
+			rows[i].RawData = *e.DataReplacements
+			continue
+		}
+		rows[i].Changes = vdr.FieldChanges()
+	}
+	return rows
+}
+
+// parseAuditFilter reads admin, action, from/to (see parseDateRange) and has_replacements.
+// The returned values repeat the applied filters for pager and export links.
+func parseAuditFilter(q url.Values, now time.Time) (domain.AuditLogFilter, url.Values, error) {
+	var f domain.AuditLogFilter
+	keep := url.Values{}
+	if s := strings.TrimSpace(q.Get("admin")); s != "" {
+		id, err := strconv.Atoi(s)
+		if err != nil || id <= 0 {
+			return f, nil, fmt.Errorf("invalid admin %q", s)
+		}
+		f.AdminID = id
+		keep.Set("admin", s)
+	}
+	if s := strings.TrimSpace(q.Get("action")); s != "" {
+		if !slices.Contains(auditActions, s) {
+			return f, nil, fmt.Errorf("invalid action %q (want one of %s)", s, strings.Join(auditActions, ", "))
+		}
+		f.Status = s
+		keep.Set("action", s)
+	}
+	from, to, err := parseDateRange(q, now)
+	if err != nil {
+		return f, nil, err
+	}
+	f.From, f.To = from, to
+	keep.Set("from", from.Format(time.DateOnly))
+	keep.Set("to", to.AddDate(0, 0, -1).Format(time.DateOnly))
+	if s := q.Get("has_replacements"); s != "" {
+		f.HasReplacements, err = strconv.ParseBool(s)
+		if err != nil {
+			return f, nil, fmt.Errorf("invalid has_replacements %q", s)
+		}
+		if f.HasReplacements {
+			keep.Set("has_replacements", "true")
+		}
+	}
+	return f, keep, nil
+}
+
+// AuditLogHandler handles GET /audit?admin=&action=&from=&to=&has_replacements=&page=
+// Audit log entries across all venues, newest first, with replaced venue data as field diffs.
+func AuditLogHandler(store domain.AuditStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f, keep, err := parseAuditFilter(r.URL.Query(), time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < 1 {
+			page = 1
+		}
+		f.Limit, f.Offset = auditPerPage, (page-1)*auditPerPage
+		entries, total, err := store.ListAuditLogsCtx(r.Context(), f)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to load audit logs: %v", err), http.StatusInternalServerError)
+			return
+		}
+		data := struct {
+			Rows       []auditRow
+			Actions    []string
+			Filter     domain.AuditLogFilter
+			From, To   string
+			Query      string
+			Total      int
+			Page       int
+			TotalPages int
+		}{
+			Rows:       toAuditRows(entries),
+			Actions:    auditActions,
+			Filter:     f,
+			From:       keep.Get("from"),
+			To:         keep.Get("to"),
+			Query:      keep.Encode(),
+			Total:      total,
+			Page:       page,
+			TotalPages: (total + auditPerPage - 1) / auditPerPage,
+		}
+		if err := ExecuteTemplate(w, "audit.tmpl", data); err != nil {
+			http.Error(w, fmt.Sprintf("template error: %v", err), http.StatusInternalServerError)
+		}
+	}
+}
+
+// APIAuditLogHandler handles GET /api/audit?admin=&action=&from=&to=&has_replacements=&page=&format=json|csv
+// JSON is paged like the page; CSV exports every match (up to auditExportMaxRows) for compliance reviews.
+func APIAuditLogHandler(store domain.AuditStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		f, keep, err := parseAuditFilter(q, time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if strings.EqualFold(q.Get("format"), "csv") {
+			var rows []auditRow
+			for f.Offset < auditExportMaxRows {
+				f.Limit = min(auditExportChunk, auditExportMaxRows-f.Offset)
+				entries, _, err := store.ListAuditLogsCtx(r.Context(), f)
+				if err != nil {
+					http.Error(w, fmt.Sprintf("Failed to load audit logs: %v", err), http.StatusInternalServerError)
+					return
+				}
+				rows = append(rows, toAuditRows(entries)...)
+				if len(entries) < f.Limit {
+					break
+				}
+				f.Offset += len(entries)
+			}
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="audit-%s-%s.csv"`, keep.Get("from"), keep.Get("to")))
+			_ = writeAuditCSV(w, rows)
+			return
+		}
+
+		page, _ := strconv.Atoi(q.Get("page"))
+		if page < 1 {
+			page = 1
+		}
+		f.Limit, f.Offset = auditPerPage, (page-1)*auditPerPage
+		entries, total, err := store.ListAuditLogsCtx(r.Context(), f)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to load audit logs: %v", err), http.StatusInternalServerError)
+			return
+		}
+		type item struct {
+			ID        int64                `json:"id"`
+			VenueID   int64                `json:"venue_id"`
+			VenueName string               `json:"venue_name"`
+			HistoryID *int64               `json:"history_id"`
+			AdminID   *int                 `json:"admin_id"`
+			Admin     string               `json:"admin"`
+			Action    string               `json:"action"`
+			Reason    *string              `json:"reason"`
+			Changes   []domain.FieldChange `json:"changes,omitempty"`
+			CreatedAt time.Time            `json:"created_at"`
+		}
+		items := make([]item, 0, len(entries))
+		for _, a := range toAuditRows(entries) {
+			items = append(items, item{
+				ID: a.ID, VenueID: a.VenueID, VenueName: a.VenueName, HistoryID: a.HistoryID,
+				AdminID: a.AdminID, Admin: a.Admin(), Action: a.Status, Reason: a.Reason,
+				Changes: a.Changes, CreatedAt: a.CreatedAt,
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"from":    keep.Get("from"),
+			"to":      keep.Get("to"),
+			"total":   total,
+			"page":    page,
+			"entries": items,
+		})
+	}
+}
+
+func writeAuditCSV(w io.Writer, rows []auditRow) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"id", "created_at", "venue_id", "venue_name", "admin_id", "admin_username", "action",
+		"reason", "history_id", "changes"})
+	for _, a := range rows {
+		adminID, historyID, reason := "", "", ""
+		if a.AdminID != nil {
+			adminID = strconv.Itoa(*a.AdminID)
+		}
+		if a.HistoryID != nil {
+			historyID = strconv.FormatInt(*a.HistoryID, 10)
+		}
+		if a.Reason != nil {
+			reason = *a.Reason
+		}
+		changes := a.RawData
+		if len(a.Changes) > 0 {
+			parts := make([]string, len(a.Changes))
+			for i, c := range a.Changes {
+				parts[i] = fmt.Sprintf("%s: %q -> %q", c.Field, c.From, c.To)
+			}
+			changes = strings.Join(parts, "; ")
+		}
+		_ = cw.Write([]string{
+			strconv.FormatInt(a.ID, 10),
+			a.CreatedAt.UTC().Format(time.RFC3339),
+			strconv.FormatInt(a.VenueID, 10),
+			a.VenueName,
+			adminID,
+			a.AdminUsername,
+			a.Status,
+			reason,
+			historyID,
+			changes,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,74 @@
+package admin
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+	testutil "assisted-venue-approval/internal/testing"
+)
+
+func TestAuditLogHandlers(t *testing.T) {
+	if err := LoadTemplates(os.DirFS("../../web/templates")); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { adminTemplates = nil }()
+
+	admin := 7
+	reason := "Fixed the address"
+	data := `{"original":{"address":"1 Old St"},"replacement":{"address":"1 New St","vegan":1}}`
+	at := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	entries := []domain.AuditLogEntry{
+		{VenueValidationAuditLog: domain.VenueValidationAuditLog{ID: 3, VenueID: 42, AdminID: &admin, Status: "approved",
+			Reason: &reason, DataReplacements: &data, CreatedAt: at}, VenueName: "Leaf Cafe", AdminUsername: "lead"},
+		{VenueValidationAuditLog: domain.VenueValidationAuditLog{ID: 2, VenueID: 42, Status: "notified", CreatedAt: at}, VenueName: "Leaf Cafe"},
+	}
+	var got domain.AuditLogFilter
+	store := &testutil.AuditStore{
+		ListAuditLogsCtxFunc: func(_ context.Context, f domain.AuditLogFilter) ([]domain.AuditLogEntry, int, error) {
+			got = f
+			if f.Offset > 0 {
+				return nil, len(entries), nil
+			}
+			return entries, len(entries), nil
+		},
+	}
+
+	rec := httptest.NewRecorder()
+	AuditLogHandler(store)(rec, httptest.NewRequest("GET", "/audit?admin=7&action=approved&from=2024-05-01&to=2024-05-31&has_replacements=true", nil))
+	if rec.Code != 200 {
+		t.Fatalf("page status = %d: %s", rec.Code, rec.Body)
+	}
+	if got.AdminID != 7 || got.Status != "approved" || !got.HasReplacements || got.Limit != auditPerPage {
+		t.Fatalf("filter = %+v", got)
+	}
+	if want := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC); !got.To.Equal(want) {
+		t.Fatalf("to = %s, want %s", got.To, want)
+	}
+	for _, s := range []string{"Leaf Cafe", "lead #7", "<del>1 Old St</del>", "<ins>1 New St</ins>", "system"} {
+		if !strings.Contains(rec.Body.String(), s) {
+			t.Fatalf("page missing %q", s)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	APIAuditLogHandler(store)(rec, httptest.NewRequest("GET", "/api/audit?from=2024-05-01&to=2024-05-31&format=csv", nil))
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("csv lines = %q", lines)
+	}
+	want := `3,2024-05-01T09:30:00Z,42,Leaf Cafe,7,lead,approved,Fixed the address,,"address: ""1 Old St"" -> ""1 New St""; vegan: """" -> ""1"""`
+	if lines[1] != want {
+		t.Fatalf("row = %s\nwant  %s", lines[1], want)
+	}
+
+	rec = httptest.NewRecorder()
+	APIAuditLogHandler(store)(rec, httptest.NewRequest("GET", "/api/audit?action=deleted", nil))
+	if rec.Code != 400 {
+		t.Fatalf("unknown action status = %d", rec.Code)
+	}
+}
@@ -0,0 +1,110 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// Records approved categories against the suggestions. Set from main; nil disables recording.
+var categoryReviews domain.CategoryReviewStore
+
+func SetCategoryReviewStore(s domain.CategoryReviewStore) { categoryReviews = s }
+
+// categorySuggestionKey is where the processor stores the category suggestion in ai_output_data.
+const categorySuggestionKey = "category_suggestion"
+
+var mCategoryReviews = metrics.Default.CounterVec("category_reviews_total", "Approvals of venues with a category suggestion, by outcome: accepted or overridden", "outcome")
+
+// categorySuggestionOf returns the category suggestion stored with a validation, or nil.
+func categorySuggestionOf(h *models.ValidationHistory) *models.CategorySuggestion {
+	if h == nil || h.AIOutputData == nil || *h.AIOutputData == "" {
+		return nil
+	}
+	var out struct {
+		Suggestion *models.CategorySuggestion `json:"category_suggestion"`
+	}
+	if err := json.Unmarshal([]byte(*h.AIOutputData), &out); err != nil {
+		return nil
+	}
+	return out.Suggestion
+}
+
+// recordCategoryReview stores the category a venue was approved with next to the suggestion
+// of the validation it was approved on. The approval is already committed, so a failure is
+// only logged.
+func recordCategoryReview(ctx context.Context, venue *models.Venue, latest *models.ValidationHistory, ad *domain.ApprovalData, adminID int) {
+	if categoryReviews == nil {
+		return
+	}
+	s := categorySuggestionOf(latest)
+	if s == nil {
+		return
+	}
+	final := venue.Category
+	if ad != nil && ad.Category != nil {
+		final = *ad.Category
+	}
+	outcome := models.CategoryReviewOverridden
+	if final == s.Suggested {
+		outcome = models.CategoryReviewAccepted
+	}
+	histID := latest.ID
+	r := &models.CategoryReview{
+		VenueID:    venue.ID,
+		HistoryID:  &histID,
+		Submitted:  s.Submitted,
+		Suggested:  s.Suggested,
+		Final:      final,
+		Confidence: s.Confidence,
+		Mismatch:   s.Mismatch,
+		Source:     s.Source,
+		Outcome:    outcome,
+		AdminID:    adminID,
+		ReviewedAt: time.Now(),
+	}
+	if err := categoryReviews.RecordCategoryReviewCtx(ctx, r); err != nil {
+		log.Printf("record category review for venue %d: %v", venue.ID, err)
+		return
+	}
+	mCategoryReviews.With(outcome).Inc()
+}
+
+// APICategoryReviewsHandler handles GET /api/v1/analytics/category-suggestions?from=YYYY-MM-DD&to=YYYY-MM-DD
+// Reports how often admins kept the suggested category, by classifier source, confidence and
+// whether the suggestion disagreed with the submitted category.
+func APICategoryReviewsHandler(store domain.CategoryReviewStore) http.HandlerFunc {
+	type row struct {
+		models.CategoryReviewStat
+		AcceptRate float64 `json:"accept_rate"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		from, to, err := parseDateRange(r.URL.Query(), time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		stats, err := store.CategoryReviewStatsCtx(r.Context(), from, to)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("category review stats error: %v", err), http.StatusInternalServerError)
+			return
+		}
+		rows := make([]row, len(stats))
+		for i, s := range stats {
+			rows[i] = row{CategoryReviewStat: s, AcceptRate: s.AcceptRate()}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"from":  from.Format(time.DateOnly),
+			"to":    to.AddDate(0, 0, -1).Format(time.DateOnly),
+			"stats": rows,
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+)
+
+func TestRecordCategoryReview(t *testing.T) {
+	var got []*models.CategoryReview
+	SetCategoryReviewStore(&testutil.CategoryReviewStore{
+		RecordCategoryReviewCtxFunc: func(_ context.Context, r *models.CategoryReview) error {
+			got = append(got, r)
+			return nil
+		},
+	})
+	defer SetCategoryReviewStore(nil)
+
+	out := `{"scoring":{"score":90},"category_suggestion":{"submitted":0,"suggested":3,"confidence":"high","mismatch":true,"source":"heuristic@v1"}}`
+	latest := &models.ValidationHistory{ID: 11, AIOutputData: &out}
+	venue := &models.Venue{ID: 7, Category: 0}
+	bakery := 3
+
+	recordCategoryReview(context.Background(), venue, latest, &domain.ApprovalData{Category: &bakery}, 5)
+	recordCategoryReview(context.Background(), venue, latest, &domain.ApprovalData{}, 5)
+	// Validations without a suggestion are not recorded
+	recordCategoryReview(context.Background(), venue, &models.ValidationHistory{ID: 12}, &domain.ApprovalData{}, 5)
+
+	if len(got) != 2 {
+		t.Fatalf("recorded %d reviews, want 2", len(got))
+	}
+	if r := got[0]; r.Outcome != models.CategoryReviewAccepted || r.Final != 3 || r.Suggested != 3 || !r.Mismatch || *r.HistoryID != 11 || r.Source != "heuristic@v1" {
+		t.Fatalf("accepted review = %+v", r)
+	}
+	if r := got[1]; r.Outcome != models.CategoryReviewOverridden || r.Final != 0 {
+		t.Fatalf("overridden review = %+v", r)
+	}
+}
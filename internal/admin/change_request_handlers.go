@@ -0,0 +1,343 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/decision"
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+	"assisted-venue-approval/pkg/events"
+	"assisted-venue-approval/pkg/metrics"
+
+	"github.com/gorilla/mux"
+)
+
+// Audit log statuses of decided change requests.
+const (
+	auditStatusChangeApplied  = "change_applied"
+	auditStatusChangeRejected = "change_rejected"
+)
+
+const changeRequestsPageLimit = 500
+
+var mChangeRequests = metrics.Default.CounterVec("change_requests_total", "Venue change requests by outcome: submitted, validated, applied or rejected", "outcome")
+
+// ChangeReviewer scores the edited fields of a change request. Implemented by *scorer.ChangeReviewer.
+type ChangeReviewer interface {
+	ReviewChanges(ctx context.Context, venue models.Venue, editor models.User, changes []domain.FieldChange, note string) (*models.ChangeAssessment, error)
+}
+
+// ChangeDecider evaluates a decision without persisting or publishing it. Implemented by
+// *processor.ProcessingEngine.
+type ChangeDecider interface {
+	DryRunDecision(ctx context.Context, vu models.VenueWithUser, vr *models.ValidationResult, candidate *decision.Rules) (*decision.DecisionResult, error)
+	DecisionRules() *decision.Rules
+}
+
+// changeRequestRow is a change request with its proposed values formatted for display.
+type changeRequestRow struct {
+	domain.VenueChangeRequest
+	Proposed []domain.FieldChange
+}
+
+// proposedFields lists the submitted values of a request; From is not known without the venue.
+func proposedFields(cr domain.VenueChangeRequest) []domain.FieldChange {
+	vdr := &domain.VenueDataReplacement{Original: &domain.VenueFieldData{}, Replacement: &cr.Proposed}
+	return vdr.FieldChanges()
+}
+
+// ChangeRequestsHandler handles GET /change-requests: pending change requests, oldest first.
+func ChangeRequestsHandler(store domain.ChangeRequestStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requests, err := store.ListPendingChangeRequestsCtx(r.Context(), changeRequestsPageLimit)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to load change requests: %v", err), http.StatusInternalServerError)
+			return
+		}
+		rows := make([]changeRequestRow, len(requests))
+		for i, cr := range requests {
+			rows[i] = changeRequestRow{VenueChangeRequest: cr, Proposed: proposedFields(cr)}
+		}
+		data := struct {
+			Requests []changeRequestRow
+		}{Requests: rows}
+		if err := ExecuteTemplate(w, "change_requests.tmpl", data); err != nil {
+			http.Error(w, fmt.Sprintf("template error: %v", err), http.StatusInternalServerError)
+		}
+	}
+}
+
+// APIChangeRequestsHandler handles GET /api/v1/change-requests
+func APIChangeRequestsHandler(store domain.ChangeRequestStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requests, err := store.ListPendingChangeRequestsCtx(r.Context(), changeRequestsPageLimit)
+		if err != nil {
+			WriteError(w, r, "Failed to load change requests", err)
+			return
+		}
+		if requests == nil {
+			requests = []domain.VenueChangeRequest{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"change_requests": requests})
+	}
+}
+
+// submitChangeRequest is the body of POST /api/v1/venues/{id}/change-requests.
+type submitChangeRequest struct {
+	UserID  uint                  `json:"user_id"`
+	Note    string                `json:"note"`
+	Changes domain.VenueFieldData `json:"changes"`
+}
+
+// SubmitChangeRequestHandler handles POST /api/v1/venues/{id}/change-requests
+// Body: {"user_id": 42, "note": "...", "changes": {"phone": "...", "openhours": "..."}} with
+// changes in the field names of the audit log data replacements.
+func SubmitChangeRequestHandler(repo domain.Repository, store domain.ChangeRequestStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			WriteError(w, r, "Invalid venue ID", errs.NewValidation("SubmitChangeRequest", "id must be a number", err))
+			return
+		}
+		var body submitChangeRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10)).Decode(&body); err != nil {
+			WriteError(w, r, "Invalid change request", errs.NewValidation("SubmitChangeRequest", "body must be JSON", err))
+			return
+		}
+
+		vu, err := repo.GetVenueWithUserByIDCtx(ctx, id)
+		if err != nil {
+			WriteError(w, r, "Error fetching venue", err)
+			return
+		}
+		if a := vu.Venue.Active; a == nil || *a != 1 {
+			WriteError(w, r, "Cannot submit change request", errs.NewConflict("SubmitChangeRequest", "venue is not approved; pending venues are edited during review", nil))
+			return
+		}
+		cr := &domain.VenueChangeRequest{
+			VenueID:   id,
+			Editor:    models.User{ID: body.UserID},
+			Proposed:  body.Changes,
+			Note:      strings.TrimSpace(body.Note),
+			CreatedAt: time.Now(),
+		}
+		if cr.ApprovalData(&vu.Venue, 0, "") == nil {
+			WriteError(w, r, "Cannot submit change request", errs.NewValidation("SubmitChangeRequest", "changes match the venue's current values", nil))
+			return
+		}
+		if err := store.CreateChangeRequestCtx(ctx, cr); err != nil {
+			WriteError(w, r, "Error storing change request", err)
+			return
+		}
+		mChangeRequests.With("submitted").Inc()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": "success", "id": cr.ID})
+	}
+}
+
+// loadChangeRequest returns a pending change request and its venue, which must still be approved.
+func loadChangeRequest(ctx context.Context, repo domain.Repository, store domain.ChangeRequestStore, idVar string) (*domain.VenueChangeRequest, *models.VenueWithUser, error) {
+	id, err := strconv.ParseInt(idVar, 10, 64)
+	if err != nil {
+		return nil, nil, errs.NewValidation("loadChangeRequest", "invalid change request ID", err)
+	}
+	cr, err := store.GetChangeRequestCtx(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cr.Status != domain.ChangeRequestPending {
+		return nil, nil, errs.NewConflict("loadChangeRequest", fmt.Sprintf("change request was already %s", cr.Status), nil)
+	}
+	vu, err := repo.GetVenueWithUserByIDCtx(ctx, cr.VenueID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if a := vu.Venue.Active; a == nil || *a != 1 {
+		return nil, nil, errs.NewConflict("loadChangeRequest", "venue is no longer approved", nil)
+	}
+	return cr, vu, nil
+}
+
+// ValidateChangeRequestHandler handles POST /api/v1/change-requests/{id}/validate
+// The AI scores only the fields that differ from the venue, then the decision engine
+// evaluates the venue with the new values as if the editor had submitted it. Its status is
+// stored as the recommendation; nothing is applied.
+func ValidateChangeRequestHandler(repo domain.Repository, store domain.ChangeRequestStore, reviewer ChangeReviewer, decider ChangeDecider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		cr, vu, err := loadChangeRequest(ctx, repo, store, mux.Vars(r)["id"])
+		if err != nil {
+			WriteError(w, r, "Cannot validate change request", err)
+			return
+		}
+		ad := cr.ApprovalData(&vu.Venue, 0, "")
+		if ad == nil {
+			WriteError(w, r, "Cannot validate change request", errs.NewConflict("ValidateChangeRequest", "the venue already has the proposed values", nil))
+			return
+		}
+		changes := ad.Replacements.FieldChanges()
+
+		assessment, err := reviewer.ReviewChanges(ctx, vu.Venue, cr.Editor, changes, cr.Note)
+		if err != nil {
+			WriteError(w, r, "Error scoring change request", err)
+			return
+		}
+		result, err := recommendChange(ctx, decider, vu.Venue, cr.Editor, ad, assessment)
+		if err != nil {
+			WriteError(w, r, "Error evaluating change request", err)
+			return
+		}
+		if err := store.SaveChangeAssessmentCtx(ctx, cr.ID, assessment, result.FinalStatus, result.DecisionReason); err != nil {
+			WriteError(w, r, "Error storing change assessment", err)
+			return
+		}
+		mChangeRequests.With("validated").Inc()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":     "success",
+			"id":         cr.ID,
+			"changes":    changes,
+			"assessment": assessment,
+			"decision":   result,
+		})
+	}
+}
+
+// recommendChange runs a scored change through the active decision rules, with the venue
+// as it would be after the change and the editor as its submitter. A change with a field the
+// AI rejected is never recommended for approval.
+func recommendChange(ctx context.Context, decider ChangeDecider, venue models.Venue, editor models.User, ad *domain.ApprovalData, a *models.ChangeAssessment) (*decision.DecisionResult, error) {
+	ad.Replacements.Replacement.ApplyTo(&venue)
+	vr := &models.ValidationResult{VenueID: venue.ID, Score: a.Score, Notes: a.Notes}
+	result, err := decider.DryRunDecision(ctx, models.VenueWithUser{Venue: venue, User: editor, IsVenueAdmin: editor.IsVenueAdmin}, vr, decider.DecisionRules())
+	if err != nil {
+		return nil, err
+	}
+	if rejected := a.RejectedFields(); len(rejected) > 0 && result.FinalStatus == "approved" {
+		result.FinalStatus = "manual_review"
+		result.RequiresManualReview = true
+		result.DecisionReason = "AI rejected changed fields: " + strings.Join(rejected, ", ")
+	}
+	return result, nil
+}
+
+// ApplyChangeRequestHandler handles POST /api/v1/change-requests/{id}/apply
+// Writes the fields that still differ from the venue, with an audit log of their old and new
+// values and a venue.change.applied event. Optional form value: reason.
+func ApplyChangeRequestHandler(repo domain.Repository, store domain.ChangeRequestStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		adminID, ok := auth.GetAdminIDFromContext(ctx)
+		if !ok {
+			http.Error(w, "Admin ID not found in context", http.StatusForbidden)
+			return
+		}
+		cr, vu, err := loadChangeRequest(ctx, repo, store, mux.Vars(r)["id"])
+		if err != nil {
+			WriteError(w, r, "Cannot apply change request", err)
+			return
+		}
+
+		reviewer := fmt.Sprintf("admin_%d", adminID)
+		reason := fmt.Sprintf("Change request #%d applied by %s", cr.ID, reviewer)
+		if rr := strings.TrimSpace(r.FormValue("reason")); rr != "" {
+			reason += ": " + rr
+		}
+		ad := cr.ApprovalData(&vu.Venue, adminID, reason)
+		if ad == nil {
+			WriteError(w, r, "Cannot apply change request", errs.NewConflict("ApplyChangeRequest", "the venue already has the proposed values; reject the request instead", nil))
+			return
+		}
+		replacementsJSON, err := ad.Replacements.ToJSON()
+		if err != nil {
+			WriteError(w, r, "Cannot apply change request", err)
+			return
+		}
+		fields := ad.Replacements.ReplacedFields()
+
+		err = commitDecision(ctx, repo, func(dw decisionWriter) error {
+			if err := dw.ApplyVenueChangesCtx(ctx, ad); err != nil {
+				return err
+			}
+			return dw.CreateAuditLogCtx(ctx, domain.NewAuditLogWithReplacements(cr.VenueID, nil, &adminID, auditStatusChangeApplied, &reason, &replacementsJSON))
+		}, events.VenueChangeApplied{
+			Base:      events.Base{Ts: time.Now(), VID: cr.VenueID, Adm: &reviewer},
+			RequestID: cr.ID,
+			Reason:    reason,
+			Score:     cr.Score,
+			Fields:    fields,
+		})
+		if err != nil {
+			WriteError(w, r, "Error applying change request", err)
+			return
+		}
+		// The venue is updated; a request decided concurrently only loses its status here
+		if decided, err := store.DecideChangeRequestCtx(ctx, cr.ID, adminID, domain.ChangeRequestApplied, reason); err != nil || !decided {
+			log.Printf("[change-requests] request %d applied but not marked: decided=%v err=%v", cr.ID, decided, err)
+		}
+		mChangeRequests.With("applied").Inc()
+		log.Printf("[change-requests] request %d applied to venue %d by %s: %v", cr.ID, cr.VenueID, reviewer, fields)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": "applied", "fields": fields})
+	}
+}
+
+// RejectChangeRequestHandler handles POST /api/v1/change-requests/{id}/reject
+// Form: reason (required). The venue is left unchanged.
+func RejectChangeRequestHandler(repo domain.Repository, store domain.ChangeRequestStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		adminID, ok := auth.GetAdminIDFromContext(ctx)
+		if !ok {
+			http.Error(w, "Admin ID not found in context", http.StatusForbidden)
+			return
+		}
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			WriteError(w, r, "Invalid change request ID", errs.NewValidation("RejectChangeRequest", "id must be a number", err))
+			return
+		}
+		rr := strings.TrimSpace(r.FormValue("reason"))
+		if rr == "" {
+			WriteError(w, r, "Cannot reject change request", errs.NewValidation("RejectChangeRequest", "reason is required", nil))
+			return
+		}
+		cr, err := store.GetChangeRequestCtx(ctx, id)
+		if err != nil {
+			WriteError(w, r, "Cannot reject change request", err)
+			return
+		}
+		reason := fmt.Sprintf("Change request #%d rejected by admin_%d: %s", id, adminID, rr)
+		decided, err := store.DecideChangeRequestCtx(ctx, id, adminID, domain.ChangeRequestRejected, reason)
+		if err != nil {
+			WriteError(w, r, "Error rejecting change request", err)
+			return
+		}
+		if !decided {
+			WriteError(w, r, "Cannot reject change request", errs.NewConflict("RejectChangeRequest", fmt.Sprintf("change request was already %s", cr.Status), nil))
+			return
+		}
+		if err := repo.CreateAuditLogCtx(ctx, domain.NewAuditLog(cr.VenueID, nil, &adminID, auditStatusChangeRejected, &reason)); err != nil {
+			log.Printf("Failed to create audit log for change request %d rejection: %v", id, err)
+		}
+		mChangeRequests.With("rejected").Inc()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "rejected"})
+	}
+}
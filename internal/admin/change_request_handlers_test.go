@@ -0,0 +1,148 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/decision"
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+
+	"github.com/gorilla/mux"
+)
+
+type fakeChangeReviewer struct {
+	got []domain.FieldChange
+	ca  models.ChangeAssessment
+}
+
+func (f *fakeChangeReviewer) ReviewChanges(_ context.Context, _ models.Venue, _ models.User, changes []domain.FieldChange, _ string) (*models.ChangeAssessment, error) {
+	f.got = changes
+	ca := f.ca
+	return &ca, nil
+}
+
+type fakeChangeDecider struct{ venue models.Venue }
+
+func (f *fakeChangeDecider) DryRunDecision(_ context.Context, vu models.VenueWithUser, vr *models.ValidationResult, _ *decision.Rules) (*decision.DecisionResult, error) {
+	f.venue = vu.Venue
+	status := "manual_review"
+	if vr.Score >= 80 {
+		status = "approved"
+	}
+	return &decision.DecisionResult{VenueID: vu.Venue.ID, FinalStatus: status, FinalScore: vr.Score, DecisionReason: "score"}, nil
+}
+
+func (f *fakeChangeDecider) DecisionRules() *decision.Rules { return nil }
+
+// changeRequestFixture is an approved venue with a pending request to change its phone and
+// hours, one of which (openhours) the venue already has.
+func changeRequestFixture() (*testutil.Repository, *testutil.ChangeRequestStore, *domain.VenueChangeRequest) {
+	active := 1
+	phone, hours := "+1 555 0100", "Mon-Fri 9-17"
+	newPhone := "+1 555 0199"
+	repo := &testutil.Repository{
+		GetVenueWithUserByIDCtxFunc: func(_ context.Context, id int64) (*models.VenueWithUser, error) {
+			return &models.VenueWithUser{Venue: models.Venue{ID: id, Name: "Green Bowl", Active: &active, Phone: &phone, OpenHours: &hours}}, nil
+		},
+	}
+	cr := &domain.VenueChangeRequest{
+		ID: 3, VenueID: 7, Status: domain.ChangeRequestPending,
+		Proposed: domain.VenueFieldData{Phone: &newPhone, OpenHours: &hours},
+	}
+	store := &testutil.ChangeRequestStore{
+		GetChangeRequestCtxFunc: func(_ context.Context, id int64) (*domain.VenueChangeRequest, error) {
+			c := *cr
+			return &c, nil
+		},
+	}
+	return repo, store, cr
+}
+
+func postChangeRequest(h http.HandlerFunc, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(""))
+	req = mux.SetURLVars(req, map[string]string{"id": "3"})
+	req = req.WithContext(context.WithValue(req.Context(), auth.AdminIDKey, 5))
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	return rec
+}
+
+func TestValidateChangeRequestHandler(t *testing.T) {
+	repo, store, _ := changeRequestFixture()
+	var saved string
+	store.SaveChangeAssessmentCtxFunc = func(_ context.Context, id int64, a *models.ChangeAssessment, recommendation, reason string) error {
+		saved = recommendation
+		return nil
+	}
+	reviewer := &fakeChangeReviewer{ca: models.ChangeAssessment{Score: 90, Fields: map[string]string{"phone": models.ChangeFieldReject}}}
+	decider := &fakeChangeDecider{}
+
+	rec := postChangeRequest(ValidateChangeRequestHandler(repo, store, reviewer, decider), "/api/v1/change-requests/3/validate")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	// Only the field that differs is scored, and the engine sees the venue with it applied
+	if len(reviewer.got) != 1 || reviewer.got[0].Field != "phone" || reviewer.got[0].From != "+1 555 0100" {
+		t.Fatalf("scored changes = %+v", reviewer.got)
+	}
+	if decider.venue.Phone == nil || *decider.venue.Phone != "+1 555 0199" {
+		t.Fatalf("decision venue phone = %v", decider.venue.Phone)
+	}
+	// A high score does not approve a change with a rejected field
+	if saved != "manual_review" {
+		t.Fatalf("recommendation = %q, want manual_review", saved)
+	}
+}
+
+func TestApplyChangeRequestHandler(t *testing.T) {
+	repo, store, _ := changeRequestFixture()
+	var applied *domain.ApprovalData
+	var audit *domain.VenueValidationAuditLog
+	repo.ApplyVenueChangesCtxFunc = func(_ context.Context, changes *domain.ApprovalData) error {
+		applied = changes
+		return nil
+	}
+	repo.CreateAuditLogCtxFunc = func(_ context.Context, l *domain.VenueValidationAuditLog) error {
+		audit = l
+		return nil
+	}
+	var decided string
+	store.DecideChangeRequestCtxFunc = func(_ context.Context, id int64, adminID int, status, reason string) (bool, error) {
+		decided = status
+		return true, nil
+	}
+
+	rec := postChangeRequest(ApplyChangeRequestHandler(repo, store), "/api/v1/change-requests/3/apply")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	if applied == nil || applied.VenueID != 7 || applied.Phone == nil || applied.OpenHours != nil {
+		t.Fatalf("applied = %+v", applied)
+	}
+	if audit == nil || audit.Status != auditStatusChangeApplied || audit.DataReplacements == nil || audit.HistoryID != nil {
+		t.Fatalf("audit log = %+v", audit)
+	}
+	if decided != domain.ChangeRequestApplied {
+		t.Fatalf("request marked %q", decided)
+	}
+	var body struct{ Fields []string }
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || len(body.Fields) != 1 || body.Fields[0] != "phone" {
+		t.Fatalf("response = %s", rec.Body)
+	}
+}
+
+func TestApplyChangeRequestHandler_Decided(t *testing.T) {
+	repo, store, cr := changeRequestFixture()
+	cr.Status = domain.ChangeRequestRejected
+	rec := postChangeRequest(ApplyChangeRequestHandler(repo, store), "/api/v1/change-requests/3/apply")
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want 409", rec.Code)
+	}
+}
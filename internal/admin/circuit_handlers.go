@@ -0,0 +1,76 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/pkg/circuit"
+
+	"github.com/gorilla/mux"
+)
+
+// CircuitsHandler handles GET /api/v1/circuits: state and recent transitions of every
+// circuit breaker (googlemaps, openai).
+func CircuitsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		statuses := circuitStatuses()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"circuits": statuses})
+	}
+}
+
+// CircuitTripHandler handles POST /api/v1/circuits/{name}/trip
+// Body (optional): {"reason": "Google incident #123"}
+// Holds the breaker open until it is reset; callers fail fast instead of probing.
+func CircuitTripHandler() http.HandlerFunc {
+	return circuitAction("trip", func(b *circuit.Breaker, reason string) { b.Trip(reason) })
+}
+
+// CircuitResetHandler handles POST /api/v1/circuits/{name}/reset
+// Body (optional): {"reason": "..."}. Closes the breaker and clears its failure window.
+func CircuitResetHandler() http.HandlerFunc {
+	return circuitAction("reset", func(b *circuit.Breaker, reason string) { b.Reset(reason) })
+}
+
+func circuitAction(action string, apply func(b *circuit.Breaker, reason string)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := mux.Vars(r)["name"]
+		b := circuit.Lookup(name)
+		if b == nil {
+			http.Error(w, fmt.Sprintf("unknown circuit %q", name), http.StatusNotFound)
+			return
+		}
+		var body struct {
+			Reason string `json:"reason"`
+		}
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				http.Error(w, "invalid JSON body", http.StatusBadRequest)
+				return
+			}
+		}
+
+		adminID, _ := auth.GetAdminIDFromContext(r.Context())
+		reason := fmt.Sprintf("manual %s by admin %d", action, adminID)
+		if body.Reason != "" {
+			reason += ": " + body.Reason
+		}
+		apply(b, reason)
+		log.Printf("circuit %s: %s", name, reason)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(b.Status())
+	}
+}
+
+func circuitStatuses() []circuit.Status {
+	breakers := circuit.All()
+	out := make([]circuit.Status, 0, len(breakers))
+	for _, b := range breakers {
+		out = append(out, b.Status())
+	}
+	return out
+}
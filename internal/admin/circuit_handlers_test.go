@@ -0,0 +1,84 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/pkg/circuit"
+
+	"github.com/gorilla/mux"
+)
+
+func TestCircuitHandlers(t *testing.T) {
+	b := circuit.New(circuit.Config{Name: "admin_test_circuit"}, nil)
+	supers := auth.NewSuperadmins([]int{1})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/circuits", CircuitsHandler()).Methods("GET")
+	router.Handle("/api/v1/circuits/{name}/trip", supers.Wrap(CircuitTripHandler())).Methods("POST")
+	router.Handle("/api/v1/circuits/{name}/reset", supers.Wrap(CircuitResetHandler())).Methods("POST")
+
+	do := func(method, path string, adminID int, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req = req.WithContext(context.WithValue(req.Context(), auth.AdminIDKey, adminID))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		adminID    int
+		body       string
+		wantStatus int
+		wantState  circuit.State
+	}{
+		{"regular admin cannot trip", "/api/v1/circuits/admin_test_circuit/trip", 2, "", http.StatusForbidden, circuit.Closed},
+		{"unknown circuit", "/api/v1/circuits/nope/trip", 1, "", http.StatusNotFound, circuit.Closed},
+		{"bad body", "/api/v1/circuits/admin_test_circuit/trip", 1, "{", http.StatusBadRequest, circuit.Closed},
+		{"superadmin trips", "/api/v1/circuits/admin_test_circuit/trip", 1, `{"reason":"provider incident"}`, http.StatusOK, circuit.Open},
+		{"regular admin cannot reset", "/api/v1/circuits/admin_test_circuit/reset", 2, "", http.StatusForbidden, circuit.Open},
+		{"superadmin resets", "/api/v1/circuits/admin_test_circuit/reset", 1, "", http.StatusOK, circuit.Closed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := do("POST", tt.path, tt.adminID, tt.body)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if got := b.Status().State; got != tt.wantState {
+				t.Fatalf("state = %s, want %s", got, tt.wantState)
+			}
+		})
+	}
+
+	rec := do("GET", "/api/v1/circuits", 2, "")
+	var resp struct {
+		Circuits []struct {
+			Name        string `json:"name"`
+			State       string `json:"state"`
+			Transitions []struct {
+				Reason string `json:"reason"`
+			} `json:"transitions"`
+		} `json:"circuits"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range resp.Circuits {
+		if c.Name != "admin_test_circuit" {
+			continue
+		}
+		if c.State != "closed" || len(c.Transitions) != 2 || c.Transitions[1].Reason != "manual trip by admin 1: provider incident" {
+			t.Fatalf("circuit = %+v", c)
+		}
+		return
+	}
+	t.Fatalf("circuit missing from %+v", resp.Circuits)
+}
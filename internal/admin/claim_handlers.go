@@ -0,0 +1,97 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/pkg/metrics"
+
+	"github.com/gorilla/mux"
+)
+
+var mVenueClaims = metrics.Default.CounterVec("venue_claims_total",
+	"Review claim requests, by result (claimed, taken, released)", "result")
+
+// ClaimVenueHandler handles POST /venues/{id}/claim
+// Claims the venue for the current admin, or refreshes their claim. Returns 409 with the
+// current claim when another admin is reviewing the venue.
+func ClaimVenueHandler(store domain.ClaimStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		writeErr := func(status int, msg string) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]string{"status": "error", "message": msg})
+		}
+
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			writeErr(http.StatusBadRequest, "Invalid venue ID")
+			return
+		}
+		adminID, ok := auth.GetAdminIDFromContext(ctx)
+		if !ok {
+			writeErr(http.StatusForbidden, "Admin ID not found in context")
+			return
+		}
+		claim, mine, err := store.ClaimVenueCtx(ctx, id, adminID)
+		if err != nil {
+			writeErr(http.StatusInternalServerError, fmt.Sprintf("Error claiming venue: %v", err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if !mine {
+			mVenueClaims.With("taken").Inc()
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"status":  "error",
+				"message": fmt.Sprintf("Venue is being reviewed by admin #%d", claim.AdminID),
+				"claim":   claim,
+			})
+			return
+		}
+		mVenueClaims.With("claimed").Inc()
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": "success", "claim": claim})
+	}
+}
+
+// UnclaimVenueHandler handles POST /venues/{id}/unclaim
+// Releases the current admin's claim; other admins' claims are left alone.
+func UnclaimVenueHandler(store domain.ClaimStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		writeErr := func(status int, msg string) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]string{"status": "error", "message": msg})
+		}
+
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			writeErr(http.StatusBadRequest, "Invalid venue ID")
+			return
+		}
+		adminID, ok := auth.GetAdminIDFromContext(ctx)
+		if !ok {
+			writeErr(http.StatusForbidden, "Admin ID not found in context")
+			return
+		}
+		released, err := store.ReleaseClaimCtx(ctx, id, adminID)
+		if err != nil {
+			writeErr(http.StatusInternalServerError, fmt.Sprintf("Error releasing claim: %v", err))
+			return
+		}
+		if !released {
+			writeErr(http.StatusNotFound, "You have not claimed this venue")
+			return
+		}
+		mVenueClaims.With("released").Inc()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+	}
+}
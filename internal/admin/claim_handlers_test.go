@@ -0,0 +1,70 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestClaimVenueHandler(t *testing.T) {
+	now := time.Now()
+	// Venue 1 is free, venue 2 is being reviewed by admin 3
+	claims := map[int64]int{2: 3}
+	store := &testutil.ClaimStore{
+		ClaimVenueCtxFunc: func(_ context.Context, venueID int64, adminID int) (*models.VenueClaim, bool, error) {
+			holder, ok := claims[venueID]
+			if !ok {
+				claims[venueID], holder = adminID, adminID
+			}
+			return &models.VenueClaim{VenueID: venueID, AdminID: holder, ClaimedAt: now, LastActiveAt: now}, holder == adminID, nil
+		},
+		ReleaseClaimCtxFunc: func(_ context.Context, venueID int64, adminID int) (bool, error) {
+			if claims[venueID] != adminID {
+				return false, nil
+			}
+			delete(claims, venueID)
+			return true, nil
+		},
+	}
+	call := func(h http.HandlerFunc, id string, adminID int) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/venues/"+id+"/claim", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		req = req.WithContext(context.WithValue(req.Context(), auth.AdminIDKey, adminID))
+		rec := httptest.NewRecorder()
+		h(rec, req)
+		return rec
+	}
+	claim, unclaim := ClaimVenueHandler(store), UnclaimVenueHandler(store)
+
+	if rec := call(claim, "1", 5); rec.Code != http.StatusOK || claims[1] != 5 {
+		t.Fatalf("claim free venue: status = %d, claims %v: %s", rec.Code, claims, rec.Body)
+	}
+	rec := call(claim, "2", 5)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("claim taken venue: status = %d: %s", rec.Code, rec.Body)
+	}
+	var body struct {
+		Claim models.VenueClaim `json:"claim"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil || body.Claim.AdminID != 3 {
+		t.Fatalf("conflict body = %+v, err %v", body, err)
+	}
+	if rec := call(unclaim, "2", 5); rec.Code != http.StatusNotFound || claims[2] != 3 {
+		t.Fatalf("unclaim other's venue: status = %d, claims %v", rec.Code, claims)
+	}
+	if rec := call(unclaim, "1", 5); rec.Code != http.StatusOK {
+		t.Fatalf("unclaim own venue: status = %d: %s", rec.Code, rec.Body)
+	}
+	if _, ok := claims[1]; ok {
+		t.Fatalf("claim on venue 1 not released: %v", claims)
+	}
+}
@@ -0,0 +1,92 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+
+	"github.com/gorilla/mux"
+)
+
+const closureReviewsPageLimit = 500
+
+// ClosureReviewsHandler handles GET /venues/closures: open closure reviews, oldest first.
+func ClosureReviewsHandler(store domain.ClosureStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reviews, err := store.ListOpenClosureReviewsCtx(r.Context(), closureReviewsPageLimit)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to load closure reviews: %v", err), http.StatusInternalServerError)
+			return
+		}
+		data := struct {
+			Reviews []models.ClosureReview
+		}{Reviews: reviews}
+		if err := ExecuteTemplate(w, "closures.tmpl", data); err != nil {
+			http.Error(w, fmt.Sprintf("template error: %v", err), http.StatusInternalServerError)
+		}
+	}
+}
+
+// APIClosureReviewsHandler handles GET /api/v1/closure-reviews
+func APIClosureReviewsHandler(store domain.ClosureStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reviews, err := store.ListOpenClosureReviewsCtx(r.Context(), closureReviewsPageLimit)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to load closure reviews: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if reviews == nil {
+			reviews = []models.ClosureReview{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"closure_reviews": reviews})
+	}
+}
+
+// ResolveClosureReviewHandler handles POST /api/v1/closure-reviews/{id}/resolve
+// Form: resolution (confirmed | dismissed).
+func ResolveClosureReviewHandler(store domain.ClosureStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		writeErr := func(status int, msg string) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]string{"status": "error", "message": msg})
+		}
+
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			writeErr(http.StatusBadRequest, "Invalid closure review ID")
+			return
+		}
+		adminID, ok := auth.GetAdminIDFromContext(ctx)
+		if !ok {
+			writeErr(http.StatusForbidden, "Admin ID not found in context")
+			return
+		}
+		resolution := r.FormValue("resolution")
+		if resolution != models.ClosureConfirmed && resolution != models.ClosureDismissed {
+			writeErr(http.StatusBadRequest, "Resolution must be confirmed or dismissed")
+			return
+		}
+		resolved, err := store.ResolveClosureReviewCtx(ctx, id, adminID, resolution)
+		if err != nil {
+			writeErr(http.StatusInternalServerError, fmt.Sprintf("Error resolving closure review: %v", err))
+			return
+		}
+		if !resolved {
+			writeErr(http.StatusNotFound, "Closure review not found or already resolved")
+			return
+		}
+		log.Printf("[closures] review %d %s by admin_%d", id, resolution, adminID)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+	}
+}
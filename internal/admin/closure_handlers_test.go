@@ -0,0 +1,52 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestResolveClosureReviewHandler(t *testing.T) {
+	open := map[int64]bool{3: true}
+	var got string
+	store := &testutil.ClosureStore{
+		ResolveClosureReviewCtxFunc: func(_ context.Context, id int64, adminID int, resolution string) (bool, error) {
+			if !open[id] {
+				return false, nil
+			}
+			open[id] = false
+			got = resolution
+			return adminID == 5, nil
+		},
+	}
+	h := ResolveClosureReviewHandler(store)
+	post := func(id, resolution string) *httptest.ResponseRecorder {
+		form := url.Values{"resolution": {resolution}}
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/closure-reviews/"+id+"/resolve", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		req = req.WithContext(context.WithValue(req.Context(), auth.AdminIDKey, 5))
+		rec := httptest.NewRecorder()
+		h(rec, req)
+		return rec
+	}
+
+	if rec := post("3", "closed"); rec.Code != http.StatusBadRequest || got != "" {
+		t.Fatalf("bad resolution: status = %d, resolved %q", rec.Code, got)
+	}
+	if rec := post("3", models.ClosureConfirmed); rec.Code != http.StatusOK || got != models.ClosureConfirmed {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	if rec := post("3", models.ClosureDismissed); rec.Code != http.StatusNotFound {
+		t.Fatalf("resolved twice: status = %d", rec.Code)
+	}
+}
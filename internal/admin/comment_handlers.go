@@ -0,0 +1,163 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+	"assisted-venue-approval/pkg/events"
+
+	"github.com/gorilla/mux"
+)
+
+const maxCommentLen = 4000
+
+var mentionPattern = regexp.MustCompile(`@(?:admin_)?(\d+)\b`)
+
+// commentsView is the comment thread shown on the venue page.
+type commentsView struct {
+	VenueID        int64
+	Threads        []*models.VenueComment
+	Count          int
+	CurrentAdminID int
+}
+
+// parseMentions returns the admin IDs mentioned in body as @12 or @admin_12, once each, in
+// order of appearance.
+func parseMentions(body string) []int {
+	var out []int
+	seen := map[int]bool{}
+	for _, m := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		id, err := strconv.Atoi(m[1])
+		if err != nil || id <= 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		out = append(out, id)
+	}
+	return out
+}
+
+// threadComments nests comments, given oldest first, under the comments they reply to.
+// Replies whose parent is missing stay top-level. The input is not modified.
+func threadComments(comments []models.VenueComment) []*models.VenueComment {
+	nodes := make([]*models.VenueComment, len(comments))
+	byID := make(map[int64]*models.VenueComment, len(comments))
+	for i := range comments {
+		c := comments[i]
+		c.Replies = nil
+		nodes[i] = &c
+		byID[c.ID] = &c
+	}
+	var roots []*models.VenueComment
+	for _, c := range nodes {
+		if c.ParentID != nil {
+			if p, ok := byID[*c.ParentID]; ok && p != c {
+				p.Replies = append(p.Replies, c)
+				continue
+			}
+		}
+		roots = append(roots, c)
+	}
+	return roots
+}
+
+// SubmitCommentHandler handles POST /venues/{id}/comments
+// Form: body, parent_id (optional, the comment replied to).
+func SubmitCommentHandler(store domain.CommentStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		writeErr := func(status int, msg string) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]string{"status": "error", "message": msg})
+		}
+
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			writeErr(http.StatusBadRequest, "Invalid venue ID")
+			return
+		}
+		adminID, ok := auth.GetAdminIDFromContext(ctx)
+		if !ok {
+			writeErr(http.StatusForbidden, "Admin ID not found in context")
+			return
+		}
+		body := strings.TrimSpace(r.FormValue("body"))
+		if body == "" {
+			writeErr(http.StatusBadRequest, "Comment is empty")
+			return
+		}
+		if len(body) > maxCommentLen {
+			writeErr(http.StatusBadRequest, fmt.Sprintf("Comment must be at most %d characters", maxCommentLen))
+			return
+		}
+		c := &models.VenueComment{VenueID: id, AdminID: adminID, Body: body, Mentions: parseMentions(body), CreatedAt: time.Now()}
+		if p := r.FormValue("parent_id"); p != "" {
+			parentID, err := strconv.ParseInt(p, 10, 64)
+			if err != nil {
+				writeErr(http.StatusBadRequest, "Invalid parent_id")
+				return
+			}
+			c.ParentID = &parentID
+		}
+
+		if err := store.CreateVenueCommentCtx(ctx, c); err != nil {
+			var ve *errs.ValidationError
+			if errors.As(err, &ve) {
+				writeErr(http.StatusBadRequest, ve.Message())
+				return
+			}
+			writeErr(http.StatusInternalServerError, fmt.Sprintf("Error saving comment: %v", err))
+			return
+		}
+
+		reviewer := fmt.Sprintf("admin_%d", adminID)
+		appendEvent(ctx, events.VenueCommentAdded{
+			Base:      events.Base{Ts: c.CreatedAt, VID: id, Adm: &reviewer},
+			CommentID: c.ID,
+			ParentID:  c.ParentID,
+			Body:      c.Body,
+			Mentions:  c.Mentions,
+		})
+		if len(c.Mentions) > 0 {
+			log.Printf("[comments] venue %d comment %d by %s mentions %v", id, c.ID, reviewer, c.Mentions)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": "success", "comment": c})
+	}
+}
+
+// VenueCommentsHandler handles GET /venues/{id}/comments: the venue's comment threads.
+func VenueCommentsHandler(store domain.CommentStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid venue ID", http.StatusBadRequest)
+			return
+		}
+		comments, err := store.ListVenueCommentsCtx(r.Context(), id)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to load comments: %v", err), http.StatusInternalServerError)
+			return
+		}
+		threads := threadComments(comments)
+		if threads == nil {
+			threads = []*models.VenueComment{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"total": len(comments), "comments": threads})
+	}
+}
@@ -0,0 +1,87 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+	errs "assisted-venue-approval/pkg/errors"
+
+	"github.com/gorilla/mux"
+)
+
+func TestParseMentions(t *testing.T) {
+	got := parseMentions("@12 can you check? cc @admin_7, @12 and me@example.com")
+	if want := []int{12, 7}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("mentions = %v, want %v", got, want)
+	}
+	if got := parseMentions("no mentions, @0 and @abc"); got != nil {
+		t.Fatalf("mentions = %v, want none", got)
+	}
+}
+
+func TestThreadComments(t *testing.T) {
+	id := func(n int64) *int64 { return &n }
+	flat := []models.VenueComment{
+		{ID: 1, Body: "root"},
+		{ID: 2, ParentID: id(1), Body: "reply"},
+		{ID: 3, ParentID: id(2), Body: "nested reply"},
+		{ID: 4, ParentID: id(99), Body: "orphan"},
+		{ID: 5, Body: "second root"},
+	}
+	roots := threadComments(flat)
+	if len(roots) != 3 || roots[0].ID != 1 || roots[1].ID != 4 || roots[2].ID != 5 {
+		t.Fatalf("roots = %+v", roots)
+	}
+	if len(roots[0].Replies) != 1 || roots[0].Replies[0].ID != 2 || len(roots[0].Replies[0].Replies) != 1 {
+		t.Fatalf("thread of 1 = %+v", roots[0].Replies)
+	}
+	if flat[0].Replies != nil {
+		t.Fatal("input comments modified")
+	}
+}
+
+func TestSubmitCommentHandler(t *testing.T) {
+	var saved *models.VenueComment
+	store := &testutil.CommentStore{
+		CreateVenueCommentCtxFunc: func(_ context.Context, c *models.VenueComment) error {
+			if c.ParentID != nil && *c.ParentID == 99 {
+				return errs.NewValidation("CreateVenueCommentCtx", "comment 99 is not on venue 4", nil)
+			}
+			c.ID = 21
+			saved = c
+			return nil
+		},
+	}
+	h := SubmitCommentHandler(store)
+	post := func(form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/venues/4/comments", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req = mux.SetURLVars(req, map[string]string{"id": "4"})
+		req = req.WithContext(context.WithValue(req.Context(), auth.AdminIDKey, 8))
+		rec := httptest.NewRecorder()
+		h(rec, req)
+		return rec
+	}
+
+	if rec := post(url.Values{"body": {" @3 please double-check the hours "}, "parent_id": {"5"}}); rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	if saved == nil || saved.VenueID != 4 || saved.AdminID != 8 || saved.Body != "@3 please double-check the hours" ||
+		saved.ParentID == nil || *saved.ParentID != 5 || !reflect.DeepEqual(saved.Mentions, []int{3}) {
+		t.Fatalf("saved = %+v", saved)
+	}
+	if rec := post(url.Values{"body": {"   "}}); rec.Code != http.StatusBadRequest {
+		t.Fatalf("empty body: status = %d", rec.Code)
+	}
+	if rec := post(url.Values{"body": {"reply"}, "parent_id": {"99"}}); rec.Code != http.StatusBadRequest {
+		t.Fatalf("foreign parent: status = %d", rec.Code)
+	}
+}
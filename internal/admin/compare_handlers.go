@@ -0,0 +1,341 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/drafts"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/utils"
+)
+
+const auditStatusDuplicateRejected = "duplicate_rejected"
+
+// Venues shown side by side on the compare page.
+const (
+	minCompareVenues = 2
+	maxCompareVenues = 3
+)
+
+// compareHistoryLimit is how many validations are listed per venue, newest first.
+const compareHistoryLimit = 5
+
+// compareVenue is one column of the compare page.
+type compareVenue struct {
+	VenueWithUser models.VenueWithUser
+	Google        *models.GooglePlaceData
+	History       []models.ValidationHistory
+	Status        string   // pending, approved or rejected
+	Distance      *float64 // meters from the first venue
+}
+
+// compareRow is one field across the compared venues.
+type compareRow struct {
+	Label   string
+	Values  []string
+	Differs bool
+}
+
+// comparePin places a venue on the compare map.
+type comparePin struct {
+	ID   int64   `json:"id"`
+	Name string  `json:"name"`
+	Lat  float64 `json:"lat"`
+	Lng  float64 `json:"lng"`
+}
+
+// reDuplicateNote finds the venue a duplicate early exit points at, in the validation notes
+// written by processor.DuplicateVenue and processor.NearDuplicateText.
+var reDuplicateNote = regexp.MustCompile(`Possible duplicate venue found: .*? \(ID: (\d+)\)|nearly identical to venue (\d+)`)
+
+// duplicateCompareURL links a venue flagged as a possible duplicate to the compare page with
+// the venues its validation notes name. Returns "" when the notes name none.
+func duplicateCompareURL(venueID int64, notes string) string {
+	ids := []string{strconv.FormatInt(venueID, 10)}
+	for _, m := range reDuplicateNote.FindAllStringSubmatch(notes, -1) {
+		id := m[1] + m[2]
+		if len(ids) < maxCompareVenues && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) < minCompareVenues {
+		return ""
+	}
+	return basePath + "venues/compare?ids=" + strings.Join(ids, ",")
+}
+
+// parseCompareIDs reads ?ids=1,2[,3]: two or three distinct venue IDs, in the order given.
+func parseCompareIDs(raw string) ([]int64, error) {
+	var ids []int64
+	seen := map[int64]bool{}
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid venue ID %q", part)
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) < minCompareVenues || len(ids) > maxCompareVenues {
+		return nil, fmt.Errorf("ids must list %d or %d different venues", minCompareVenues, maxCompareVenues)
+	}
+	return ids, nil
+}
+
+// venueStatus names a venue's active value.
+func venueStatus(active *int) string {
+	switch {
+	case active == nil || *active == 0:
+		return "pending"
+	case *active > 0:
+		return "approved"
+	default:
+		return "rejected"
+	}
+}
+
+func strOr(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return strings.TrimSpace(*s)
+}
+
+// compareRows lines up the submitted fields and the Google data of the venues.
+func compareRows(venues []compareVenue) (fields, google []compareRow) {
+	row := func(label string, value func(v compareVenue) string) compareRow {
+		r := compareRow{Label: label}
+		for _, v := range venues {
+			r.Values = append(r.Values, value(v))
+		}
+		for _, val := range r.Values[1:] {
+			if !strings.EqualFold(val, r.Values[0]) {
+				r.Differs = true
+				break
+			}
+		}
+		return r
+	}
+	fields = []compareRow{
+		row("Name", func(v compareVenue) string { return v.VenueWithUser.Venue.Name }),
+		row("Location", func(v compareVenue) string { return v.VenueWithUser.Venue.Location }),
+		row("Zipcode", func(v compareVenue) string { return strOr(v.VenueWithUser.Venue.Zipcode) }),
+		row("Path", func(v compareVenue) string { return strOr(v.VenueWithUser.Venue.Path) }),
+		row("Coordinates", func(v compareVenue) string {
+			if v.VenueWithUser.Venue.Lat == nil || v.VenueWithUser.Venue.Lng == nil {
+				return ""
+			}
+			return fmt.Sprintf("%.6f, %.6f", *v.VenueWithUser.Venue.Lat, *v.VenueWithUser.Venue.Lng)
+		}),
+		row("Phone", func(v compareVenue) string { return strOr(v.VenueWithUser.Venue.Phone) }),
+		row("Website", func(v compareVenue) string { return strOr(v.VenueWithUser.Venue.URL) }),
+		row("Email", func(v compareVenue) string { return strOr(v.VenueWithUser.Venue.Email) }),
+		row("Vegan status", func(v compareVenue) string { return models.SubmittedVeganStatus(v.VenueWithUser.Venue) }),
+		row("Category", func(v compareVenue) string {
+			return models.CategoryLabel(v.VenueWithUser.Venue.EntryType, v.VenueWithUser.Venue.Category)
+		}),
+		row("Description", func(v compareVenue) string { return strings.TrimSpace(v.VenueWithUser.Venue.VDetails) }),
+		row("Submitter", func(v compareVenue) string { return v.VenueWithUser.User.Username }),
+	}
+	googleRow := func(label string, value func(g *models.GooglePlaceData) string) compareRow {
+		return row(label, func(v compareVenue) string {
+			if v.Google == nil {
+				return ""
+			}
+			return value(v.Google)
+		})
+	}
+	google = []compareRow{
+		googleRow("Place ID", func(g *models.GooglePlaceData) string { return g.PlaceID }),
+		googleRow("Name", func(g *models.GooglePlaceData) string { return g.Name }),
+		googleRow("Address", func(g *models.GooglePlaceData) string { return g.FormattedAddress }),
+		googleRow("Phone", func(g *models.GooglePlaceData) string { return g.FormattedPhone }),
+		googleRow("Website", func(g *models.GooglePlaceData) string { return g.Website }),
+		googleRow("Business status", func(g *models.GooglePlaceData) string { return g.BusinessStatus }),
+	}
+	return fields, google
+}
+
+// loadCompareVenue gathers one venue's column: the venue, its Google place and its latest
+// validations.
+func loadCompareVenue(r *http.Request, repo domain.Repository, id int64) (*compareVenue, error) {
+	vw, err := repo.GetVenueWithUserByIDCtx(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	cv := &compareVenue{VenueWithUser: *vw, Status: venueStatus(vw.Venue.Active)}
+	history, err := repo.GetVenueValidationHistoryCtx(r.Context(), id)
+	if err != nil {
+		log.Printf("compare: history for venue %d: %v", id, err)
+	}
+	sort.Slice(history, func(i, j int) bool { return history[i].ProcessedAt.After(history[j].ProcessedAt) })
+	if len(history) > compareHistoryLimit {
+		history = history[:compareHistoryLimit]
+	}
+	cv.History = history
+	if gd, err := repo.GetCachedGooglePlaceDataCtx(r.Context(), id); err == nil && gd != nil {
+		cv.Google = gd
+	} else if len(history) > 0 && history[0].GooglePlaceData != nil {
+		cv.Google = history[0].GooglePlaceData
+	}
+	return cv, nil
+}
+
+// CompareVenuesHandler handles GET /venues/compare?ids=1,2[,3]
+// Shows two or three venues side by side with their Google data, map pins and latest
+// validations, to decide whether one duplicates another.
+func CompareVenuesHandler(repo domain.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ids, err := parseCompareIDs(r.URL.Query().Get("ids"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		venues := make([]compareVenue, 0, len(ids))
+		for _, id := range ids {
+			cv, err := loadCompareVenue(r, repo, id)
+			if err != nil {
+				WriteError(w, r, fmt.Sprintf("Failed to load venue %d", id), err)
+				return
+			}
+			venues = append(venues, *cv)
+		}
+		pins := []comparePin{}
+		for i := range venues {
+			v := venues[i].VenueWithUser.Venue
+			if v.Lat == nil || v.Lng == nil || suspectLocation(*v.Lat, *v.Lng) != "" {
+				continue
+			}
+			pins = append(pins, comparePin{ID: v.ID, Name: v.Name, Lat: *v.Lat, Lng: *v.Lng})
+			if i > 0 && pins[0].ID == venues[0].VenueWithUser.Venue.ID {
+				d := utils.HaversineMeters(pins[0].Lat, pins[0].Lng, *v.Lat, *v.Lng)
+				venues[i].Distance = &d
+			}
+		}
+		fields, google := compareRows(venues)
+
+		data := struct {
+			Venues     []compareVenue
+			Fields     []compareRow
+			GoogleRows []compareRow
+			Pins       []comparePin
+		}{
+			Venues:     venues,
+			Fields:     fields,
+			GoogleRows: google,
+			Pins:       pins,
+		}
+		if err := ExecuteTemplate(w, "venue_compare.tmpl", data); err != nil {
+			http.Error(w, fmt.Sprintf("template error: %v", err), http.StatusInternalServerError)
+		}
+	}
+}
+
+// MergeDuplicateHandler handles POST /venues/compare/merge
+// Body: {"duplicate_id": 2, "keep_id": 1, "reason": "..."}. Rejects the pending duplicate
+// with a reason naming the venue it duplicates, and records it in the audit log as
+// duplicate_rejected. The kept venue is left as it is.
+func MergeDuplicateHandler(repo domain.Repository, draftStore *drafts.DraftStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		writeErr := func(status int, msg string) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]string{"status": "error", "message": msg})
+		}
+
+		adminID, ok := auth.GetAdminIDFromContext(ctx)
+		if !ok {
+			writeErr(http.StatusForbidden, "Admin ID not found in context")
+			return
+		}
+		var body struct {
+			DuplicateID int64  `json:"duplicate_id"`
+			KeepID      int64  `json:"keep_id"`
+			Reason      string `json:"reason"`
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4<<10)).Decode(&body); err != nil {
+			writeErr(http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+		if body.DuplicateID <= 0 || body.KeepID <= 0 || body.DuplicateID == body.KeepID {
+			writeErr(http.StatusBadRequest, "duplicate_id and keep_id must be two different venues")
+			return
+		}
+
+		dup, err := repo.GetVenueWithUserByIDCtx(ctx, body.DuplicateID)
+		if err != nil {
+			WriteError(w, r, "Failed to load duplicate venue", err)
+			return
+		}
+		keep, err := repo.GetVenueWithUserByIDCtx(ctx, body.KeepID)
+		if err != nil {
+			WriteError(w, r, "Failed to load kept venue", err)
+			return
+		}
+		if s := venueStatus(dup.Venue.Active); s != "pending" {
+			writeErr(http.StatusConflict, fmt.Sprintf("Venue %d is already %s", dup.Venue.ID, s))
+			return
+		}
+		if venueStatus(keep.Venue.Active) == "rejected" {
+			writeErr(http.StatusConflict, fmt.Sprintf("Venue %d is rejected; keep a venue that is pending or approved", keep.Venue.ID))
+			return
+		}
+
+		reviewer := fmt.Sprintf("admin_%d", adminID)
+		rawReason := duplicateReason(keep.Venue, body.Reason)
+		reason := fmt.Sprintf("Manually rejected by %s: %s", reviewer, rawReason)
+
+		var latest *models.ValidationHistory
+		var histID *int64
+		if history, err := repo.GetVenueValidationHistoryCtx(ctx, dup.Venue.ID); err == nil && len(history) > 0 {
+			latest = latestOf(history)
+			histID = &latest.ID
+		}
+		err = commitDecision(ctx, repo, func(dw decisionWriter) error {
+			if err := dw.UpdateVenueStatusCtx(ctx, dup.Venue.ID, -1, reason, &reviewer); err != nil {
+				return err
+			}
+			return dw.CreateAuditLogCtx(ctx, domain.NewAuditLog(dup.Venue.ID, histID, &adminID, auditStatusDuplicateRejected, &reason))
+		}, rejectedEvent(dup.Venue.ID, reviewer, reason, latest))
+		mAdminRejected.Inc(1)
+		if err != nil {
+			writeErr(http.StatusInternalServerError, fmt.Sprintf("Error updating venue: %v", err))
+			return
+		}
+		log.Printf("[compare] venue %d rejected by %s as duplicate of %d", dup.Venue.ID, reviewer, keep.Venue.ID)
+
+		if draftStore != nil {
+			if err := draftStore.Delete(ctx, dup.Venue.ID, drafts.AnyVersion); err != nil {
+				log.Printf("[compare] failed to delete draft for venue %d: %v", dup.Venue.ID, err)
+			}
+		}
+		notifySubmitter(repo, nil, dup.Venue.ID, adminID, "rejected", rawReason)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": "rejected", "duplicate_id": dup.Venue.ID, "keep_id": keep.Venue.ID})
+	}
+}
+
+func duplicateReason(keep models.Venue, note string) string {
+	reason := fmt.Sprintf("Duplicate of venue %d (%s)", keep.ID, keep.Name)
+	if note = strings.TrimSpace(note); note != "" {
+		reason += ": " + note
+	}
+	return reason
+}
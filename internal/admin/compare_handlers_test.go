@@ -0,0 +1,157 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+)
+
+func TestParseCompareIDs(t *testing.T) {
+	ids, err := parseCompareIDs("3, 5,3")
+	if err != nil || len(ids) != 2 || ids[0] != 3 || ids[1] != 5 {
+		t.Fatalf("ids = %v, %v; want [3 5]", ids, err)
+	}
+	for _, raw := range []string{"", "3", "1,2,3,4", "1,x", "1,-2"} {
+		if _, err := parseCompareIDs(raw); err == nil {
+			t.Errorf("parseCompareIDs(%q) accepted", raw)
+		}
+	}
+}
+
+func TestCompareRowsMarkDifferences(t *testing.T) {
+	a, b := "+1 555 0100", "+1 555 0100"
+	venues := []compareVenue{
+		{VenueWithUser: models.VenueWithUser{Venue: models.Venue{ID: 1, Name: "Green Bowl", Location: "Berlin", Phone: &a}}},
+		{VenueWithUser: models.VenueWithUser{Venue: models.Venue{ID: 2, Name: "Green Bowl Cafe", Location: "Berlin", Phone: &b}}},
+	}
+	fields, _ := compareRows(venues)
+	differs := map[string]bool{}
+	for _, row := range fields {
+		differs[row.Label] = row.Differs
+	}
+	if !differs["Name"] {
+		t.Errorf("Name should differ: %+v", fields)
+	}
+	if differs["Phone"] {
+		t.Errorf("Phone should not differ: %+v", fields)
+	}
+}
+
+func TestDuplicateCompareURL(t *testing.T) {
+	old := basePath
+	basePath = "/"
+	defer func() { basePath = old }()
+
+	notes := "Possible duplicate venue found: 'Green Bowl' (ID: 12) at the same address. Description nearly identical to venue 15."
+	if got := duplicateCompareURL(7, notes); got != "/venues/compare?ids=7,12,15" {
+		t.Errorf("url = %q", got)
+	}
+	if got := duplicateCompareURL(7, "Score 80, looks fine"); got != "" {
+		t.Errorf("url = %q, want none", got)
+	}
+}
+
+func TestCompareVenuesHandler(t *testing.T) {
+	if err := LoadTemplates(os.DirFS("../../web/templates")); err != nil {
+		t.Fatalf("LoadTemplates: %v", err)
+	}
+	lat, lng := 52.52, 13.405
+	repo := &testutil.Repository{
+		GetVenueWithUserByIDCtxFunc: func(_ context.Context, id int64) (*models.VenueWithUser, error) {
+			return &models.VenueWithUser{Venue: models.Venue{ID: id, Name: "Green Bowl", Lat: &lat, Lng: &lng}}, nil
+		},
+		GetVenueValidationHistoryCtxFunc: func(context.Context, int64) ([]models.ValidationHistory, error) {
+			return nil, nil
+		},
+		GetCachedGooglePlaceDataCtxFunc: func(context.Context, int64) (*models.GooglePlaceData, error) {
+			return nil, nil
+		},
+	}
+	rec := httptest.NewRecorder()
+	CompareVenuesHandler(repo)(rec, httptest.NewRequest(http.MethodGet, "/venues/compare?ids=4,9", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if body := rec.Body.String(); !strings.Contains(body, "Reject as duplicate of #4") || !strings.Contains(body, "Reject as duplicate of #9") {
+		t.Fatalf("page missing merge action:\n%s", body)
+	}
+
+	rec = httptest.NewRecorder()
+	CompareVenuesHandler(repo)(rec, httptest.NewRequest(http.MethodGet, "/venues/compare?ids=4", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", rec.Code)
+	}
+}
+
+func postMerge(h http.HandlerFunc, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/venues/compare/merge", strings.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), auth.AdminIDKey, 5))
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	return rec
+}
+
+func TestMergeDuplicateHandler(t *testing.T) {
+	approved := 1
+	var status int
+	var notes string
+	var audit *domain.VenueValidationAuditLog
+	repo := &testutil.Repository{
+		GetVenueWithUserByIDCtxFunc: func(_ context.Context, id int64) (*models.VenueWithUser, error) {
+			v := models.Venue{ID: id, Name: "Green Bowl"}
+			if id == 1 {
+				v.Active = &approved
+			}
+			return &models.VenueWithUser{Venue: v}, nil
+		},
+		GetVenueValidationHistoryCtxFunc: func(_ context.Context, id int64) ([]models.ValidationHistory, error) {
+			return []models.ValidationHistory{{ID: 40, VenueID: id}}, nil
+		},
+		UpdateVenueStatusCtxFunc: func(_ context.Context, id int64, active int, n string, _ *string) error {
+			status, notes = active, n
+			return nil
+		},
+		CreateAuditLogCtxFunc: func(_ context.Context, l *domain.VenueValidationAuditLog) error {
+			audit = l
+			return nil
+		},
+	}
+	h := MergeDuplicateHandler(repo, nil)
+
+	rec := postMerge(h, `{"duplicate_id": 2, "keep_id": 1, "reason": "same address"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp map[string]interface{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp["status"] != "rejected" {
+		t.Errorf("response = %v", resp)
+	}
+	if status != -1 || notes != "Manually rejected by admin_5: Duplicate of venue 1 (Green Bowl): same address" {
+		t.Errorf("venue status %d, notes %q", status, notes)
+	}
+	if audit == nil || audit.Status != auditStatusDuplicateRejected || audit.HistoryID == nil || *audit.HistoryID != 40 {
+		t.Errorf("audit = %+v", audit)
+	}
+
+	// an approved venue cannot be rejected as a duplicate
+	status = 0
+	if rec := postMerge(h, `{"duplicate_id": 1, "keep_id": 2}`); rec.Code != http.StatusConflict {
+		t.Errorf("status = %d, want 409", rec.Code)
+	}
+	if status != 0 {
+		t.Error("approved venue was updated")
+	}
+	if rec := postMerge(h, `{"duplicate_id": 2, "keep_id": 2}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", rec.Code)
+	}
+}
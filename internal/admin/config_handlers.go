@@ -0,0 +1,214 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/config"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// configHistoryLimit is how many past changes the config page and API show.
+const configHistoryLimit = 50
+
+// RuntimeConfigurer reads and changes the runtime settings. Implemented by the config watcher.
+type RuntimeConfigurer interface {
+	Current() *config.Config
+	Set(updates map[string]string) (config.Change, error)
+}
+
+// runtimeSettingView is a runtime setting with its current value.
+type runtimeSettingView struct {
+	config.RuntimeSetting
+	Value string `json:"value"`
+}
+
+type configPage struct {
+	Settings []runtimeSettingView
+	History  []models.ConfigChange
+	Error    string
+	Saved    []models.ConfigChange
+}
+
+// ConfigPageHandler handles GET /settings/config
+func ConfigPageHandler(store domain.ConfigChangeStore, rc RuntimeConfigurer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		renderConfigPage(w, r, store, rc, configPage{})
+	}
+}
+
+// UpdateConfigFormHandler handles POST /settings/config
+// Form: one field per runtime setting, named by its env var; unchanged values are skipped.
+func UpdateConfigFormHandler(store domain.ConfigChangeStore, rc RuntimeConfigurer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		adminID, ok := auth.GetAdminIDFromContext(r.Context())
+		if !ok {
+			http.Error(w, "Admin ID not found in context", http.StatusForbidden)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "invalid form", http.StatusBadRequest)
+			return
+		}
+		updates := map[string]string{}
+		for _, s := range config.RuntimeSettings {
+			if v, ok := r.PostForm[s.Key]; ok && len(v) > 0 {
+				updates[s.Key] = v[0]
+			}
+		}
+		saved, status, msg := updateConfig(r, store, rc, updates, adminID)
+		if msg != "" {
+			if status == http.StatusInternalServerError {
+				http.Error(w, msg, status)
+				return
+			}
+			renderConfigPage(w, r, store, rc, configPage{Error: msg})
+			return
+		}
+		renderConfigPage(w, r, store, rc, configPage{Saved: saved})
+	}
+}
+
+// APIConfigHandler handles GET /api/v1/config
+func APIConfigHandler(store domain.ConfigChangeStore, rc RuntimeConfigurer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		history, err := store.ListConfigChangesCtx(r.Context(), configHistoryLimit)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to load config history: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if history == nil {
+			history = []models.ConfigChange{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"settings": runtimeSettingViews(rc.Current()),
+			"history":  history,
+		})
+	}
+}
+
+// APIUpdateConfigHandler handles PUT /api/v1/config
+// Body: {"WORKER_COUNT": 8, "ONLY_AMBASSADORS": true, "PHOTO_CHECK_DAILY_BUDGET_USD": "2.5"}.
+// Settings left out keep their value; the update is applied in full or not at all.
+func APIUpdateConfigHandler(store domain.ConfigChangeStore, rc RuntimeConfigurer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		adminID, ok := auth.GetAdminIDFromContext(r.Context())
+		if !ok {
+			http.Error(w, "Admin ID not found in context", http.StatusForbidden)
+			return
+		}
+		var body map[string]interface{}
+		dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4<<10))
+		dec.UseNumber()
+		if err := dec.Decode(&body); err != nil {
+			http.Error(w, "invalid JSON body", http.StatusBadRequest)
+			return
+		}
+		updates := make(map[string]string, len(body))
+		for k, v := range body {
+			switch v := v.(type) {
+			case string:
+				updates[k] = v
+			case json.Number:
+				updates[k] = v.String()
+			case bool:
+				updates[k] = strconv.FormatBool(v)
+			default:
+				http.Error(w, k+" must be a number, boolean or string", http.StatusBadRequest)
+				return
+			}
+		}
+		saved, status, msg := updateConfig(r, store, rc, updates, adminID)
+		if msg != "" {
+			http.Error(w, msg, status)
+			return
+		}
+		if saved == nil {
+			saved = []models.ConfigChange{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"changed":  saved,
+			"settings": runtimeSettingViews(rc.Current()),
+		})
+	}
+}
+
+// updateConfig applies the updates that differ from the current values and records them.
+// The returned message is empty on success.
+func updateConfig(r *http.Request, store domain.ConfigChangeStore, rc RuntimeConfigurer, updates map[string]string, adminID int) ([]models.ConfigChange, int, string) {
+	if len(updates) == 0 {
+		return nil, http.StatusBadRequest, "no settings given"
+	}
+	before := rc.Current().RuntimeValues()
+	normalized, err := config.NormalizeRuntimeValues(updates)
+	if err == nil {
+		for k, v := range normalized {
+			if before[k] == v {
+				delete(normalized, k)
+			}
+		}
+		if len(normalized) == 0 {
+			return nil, http.StatusOK, ""
+		}
+		_, err = rc.Set(normalized)
+	}
+	if err != nil {
+		var ve *errs.ValidationError
+		if errors.As(err, &ve) {
+			return nil, http.StatusBadRequest, ve.Message()
+		}
+		return nil, http.StatusInternalServerError, fmt.Sprintf("failed to apply settings: %v", err)
+	}
+
+	after := rc.Current().RuntimeValues()
+	now := time.Now()
+	var changes []models.ConfigChange
+	for _, s := range config.RuntimeSettings {
+		if _, ok := normalized[s.Key]; ok && before[s.Key] != after[s.Key] {
+			changes = append(changes, models.ConfigChange{AdminID: adminID, Setting: s.Key,
+				OldValue: before[s.Key], NewValue: after[s.Key], ChangedAt: now})
+			log.Printf("admin %d changed %s: %s -> %s", adminID, s.Key, before[s.Key], after[s.Key])
+		}
+	}
+	// The settings are live already; a failed write only loses the history entry
+	if err := store.AddConfigChangesCtx(r.Context(), changes); err != nil {
+		log.Printf("Failed to record config changes by admin %d: %v", adminID, err)
+	}
+	return changes, http.StatusOK, ""
+}
+
+func runtimeSettingViews(cfg *config.Config) []runtimeSettingView {
+	values := cfg.RuntimeValues()
+	out := make([]runtimeSettingView, len(config.RuntimeSettings))
+	for i, s := range config.RuntimeSettings {
+		out[i] = runtimeSettingView{RuntimeSetting: s, Value: values[s.Key]}
+	}
+	return out
+}
+
+func renderConfigPage(w http.ResponseWriter, r *http.Request, store domain.ConfigChangeStore, rc RuntimeConfigurer, page configPage) {
+	history, err := store.ListConfigChangesCtx(r.Context(), configHistoryLimit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error fetching config history: %v", err), http.StatusInternalServerError)
+		return
+	}
+	page.History = history
+	page.Settings = runtimeSettingViews(rc.Current())
+	if page.Error != "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	if err := ExecuteTemplate(w, "config.tmpl", page); err != nil {
+		http.Error(w, fmt.Sprintf("template error: %v", err), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,95 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+	"assisted-venue-approval/pkg/config"
+)
+
+// fakeConfigurer applies the two settings the test changes.
+type fakeConfigurer struct {
+	cfg  config.Config
+	sets []map[string]string
+}
+
+func (f *fakeConfigurer) Current() *config.Config { return &f.cfg }
+
+func (f *fakeConfigurer) Set(updates map[string]string) (config.Change, error) {
+	f.sets = append(f.sets, updates)
+	for k, v := range updates {
+		switch k {
+		case "WORKER_COUNT":
+			f.cfg.WorkerCount, _ = strconv.Atoi(v)
+		case "ONLY_AMBASSADORS":
+			f.cfg.OnlyAmbassadors, _ = strconv.ParseBool(v)
+		}
+	}
+	return config.Change{}, nil
+}
+
+func TestAPIUpdateConfig(t *testing.T) {
+	var recorded []models.ConfigChange
+	store := &testutil.ConfigChangeStore{
+		AddConfigChangesCtxFunc: func(_ context.Context, changes []models.ConfigChange) error {
+			recorded = append(recorded, changes...)
+			return nil
+		},
+	}
+	rc := &fakeConfigurer{cfg: config.Config{WorkerCount: 4, ApprovalThreshold: 75}}
+	h := APIUpdateConfigHandler(store, rc)
+	put := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/config", strings.NewReader(body))
+		req = req.WithContext(context.WithValue(req.Context(), auth.AdminIDKey, 9))
+		rec := httptest.NewRecorder()
+		h(rec, req)
+		return rec
+	}
+
+	rec := put(`{"WORKER_COUNT": 8, "ONLY_AMBASSADORS": "TRUE", "APPROVAL_THRESHOLD": 75}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	if len(rc.sets) != 1 || len(rc.sets[0]) != 2 || rc.sets[0]["ONLY_AMBASSADORS"] != "true" {
+		t.Fatalf("applied %v, want only the changed settings, normalized", rc.sets)
+	}
+	if len(recorded) != 2 || recorded[0].Setting != "WORKER_COUNT" || recorded[0].OldValue != "4" ||
+		recorded[0].NewValue != "8" || recorded[0].AdminID != 9 {
+		t.Fatalf("recorded = %+v", recorded)
+	}
+	var resp struct {
+		Changed  []models.ConfigChange `json:"changed"`
+		Settings []runtimeSettingView  `json:"settings"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || len(resp.Changed) != 2 ||
+		resp.Settings[0].Key != "WORKER_COUNT" || resp.Settings[0].Value != "8" {
+		t.Fatalf("response = %s", rec.Body)
+	}
+
+	if rec := put(`{"WORKER_COUNT": 8}`); rec.Code != http.StatusOK || len(rc.sets) != 1 {
+		t.Fatalf("unchanged value applied: status %d, sets %d", rec.Code, len(rc.sets))
+	}
+	for _, body := range []string{
+		`{"WORKER_COUNT": 0}`,
+		`{"APPROVAL_THRESHOLD": 7.5}`,
+		`{"DATABASE_URL": "x"}`,
+		`{"ONLY_AMBASSADORS": "maybe"}`,
+		`{"WORKER_COUNT": [1]}`,
+		`{}`,
+	} {
+		if rec := put(body); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", body, rec.Code)
+		}
+	}
+	if len(rc.sets) != 1 || len(recorded) != 2 {
+		t.Fatalf("rejected updates were applied: sets %d, recorded %d", len(rc.sets), len(recorded))
+	}
+}
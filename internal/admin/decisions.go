@@ -0,0 +1,315 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/approval"
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/drafts"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/internal/trust"
+	"assisted-venue-approval/pkg/config"
+	"assisted-venue-approval/pkg/events"
+)
+
+// Starts the transactions admin decisions are written in. Set from main; nil falls back
+// to plain repository writes with a best-effort event append.
+var uowFactory domain.UnitOfWorkFactory
+
+func SetUnitOfWorkFactory(f domain.UnitOfWorkFactory) { uowFactory = f }
+
+// decisionWriter is what an admin decision writes besides its event.
+// Both domain.Repository and domain.UnitOfWork satisfy it.
+type decisionWriter interface {
+	UpdateVenueStatusCtx(ctx context.Context, venueID int64, active int, notes string, reviewer *string) error
+	ApproveVenueWithDataReplacement(ctx context.Context, approvalData *domain.ApprovalData) error
+	RevertVenueApprovalCtx(ctx context.Context, venueID int64, replacements *domain.VenueDataReplacement, notes string) error
+	ApplyVenueChangesCtx(ctx context.Context, changes *domain.ApprovalData) error
+	CreateAuditLogCtx(ctx context.Context, log *domain.VenueValidationAuditLog) error
+}
+
+// commitDecision runs write and enqueues ev in the outbox of the same transaction, so a
+// committed decision always gets its event and a failed one never does.
+func commitDecision(ctx context.Context, repo domain.Repository, write func(decisionWriter) error, ev events.Event) error {
+	if uowFactory == nil {
+		if err := write(repo); err != nil {
+			return err
+		}
+		if eventSink != nil {
+			if err := eventSink.Append(ctx, ev); err != nil {
+				log.Printf("append %s event for venue %d: %v", ev.Type(), ev.VenueID(), err)
+			}
+		}
+		return nil
+	}
+
+	uow, err := uowFactory.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer uow.Rollback()
+	if err := write(uow); err != nil {
+		return err
+	}
+	if err := events.Enqueue(ctx, uow, ev); err != nil {
+		return fmt.Errorf("enqueue %s event: %w", ev.Type(), err)
+	}
+	if err := uow.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	return nil
+}
+
+// DecisionError is a failed ApproveVenue or RejectVenue. Status is the HTTP status the admin
+// API answers with; Violations is set when an unacknowledged description breaks the style guide.
+type DecisionError struct {
+	Status     int
+	Message    string
+	Violations []approval.StyleViolation
+}
+
+func (e *DecisionError) Error() string { return e.Message }
+
+func decisionErr(status int, format string, args ...any) error {
+	return &DecisionError{Status: status, Message: fmt.Sprintf(format, args...)}
+}
+
+// writeDecisionError answers a failed decision as JSON; style violations get their own status
+// so the UI can offer "approve anyway".
+func writeDecisionError(w http.ResponseWriter, err error) {
+	de := &DecisionError{Status: http.StatusInternalServerError, Message: err.Error()}
+	errors.As(err, &de)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(de.Status)
+	if len(de.Violations) > 0 {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":     "style_violations",
+			"message":    de.Message,
+			"violations": de.Violations,
+		})
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]string{
+		"status":  "error",
+		"message": de.Message,
+	})
+}
+
+// ApproveVenue approves venue id on behalf of adminID, applying the editor's draft, and
+// notifies the submitter. The venue's latest validation must be an approval at or above the
+// threshold. A description that breaks the style guide needs acknowledgeStyle. notes is the
+// admin's optional comment.
+func ApproveVenue(ctx context.Context, repo domain.Repository, cfg *config.Config, draftStore *drafts.DraftStore, id int64, adminID int, notes string, acknowledgeStyle bool) error {
+	var draft *drafts.VenueDraft
+	if draftStore != nil {
+		d, exists, err := draftStore.Get(ctx, id)
+		if err != nil {
+			// Approving without the draft would silently drop the editor's changes
+			return decisionErr(http.StatusInternalServerError, "Error loading draft: %v", err)
+		}
+		if exists {
+			draft = d
+			log.Printf("Loaded draft for venue %d with %d modified fields", id, len(draft.Fields))
+		}
+	}
+
+	reviewer := fmt.Sprintf("admin_%d", adminID)
+	rawNotes := strings.TrimSpace(notes)
+	if rawNotes == "" {
+		notes = "Manually approved by " + reviewer
+	} else {
+		notes = fmt.Sprintf("Manually approved by %s: %s", reviewer, rawNotes)
+	}
+
+	// Venue can only be approved if there's already a validation history with status='approved' and score >= threshold
+	if err := repo.ValidateApprovalEligibility(id, cfg.ApprovalThreshold); err != nil {
+		return decisionErr(http.StatusBadRequest, "Cannot approve venue: %v", err)
+	}
+
+	history, err := repo.GetVenueValidationHistoryCtx(ctx, id)
+	if err != nil || len(history) == 0 {
+		return decisionErr(http.StatusBadRequest, "Cannot approve venue: no validation history found")
+	}
+	latestHistory := *latestOf(history)
+
+	// Additional check: ensure latest status is "approved"
+	if latestHistory.ValidationStatus != "approved" {
+		return decisionErr(http.StatusBadRequest, "Cannot approve venue: latest validation status is '%s' (not 'approved')", latestHistory.ValidationStatus)
+	}
+
+	venueWithUser, err := repo.GetVenueWithUserByIDCtx(ctx, id)
+	if err != nil {
+		return decisionErr(http.StatusInternalServerError, "Error fetching venue: %v", err)
+	}
+
+	venue := venueWithUser.Venue
+	assessment := trust.NewDefault().Assess(venueWithUser.User, venue.Location)
+	mergeResult, err := approval.Assemble(approval.MergeInput{
+		Venue:         venue,
+		User:          venueWithUser.User,
+		TrustScore:    assessment.Trust,
+		LatestHistory: &latestHistory,
+		Draft:         draft,
+		Repo:          repo,
+	})
+	if err != nil {
+		log.Printf("failed to assemble approval data for venue %d: %v", id, err)
+		return decisionErr(http.StatusInternalServerError, "Failed to prepare approval payload")
+	}
+
+	// A description that breaks the style guide is written only once the admin acknowledged it
+	if vs := mergeResult.StyleViolations; len(vs) > 0 {
+		if !acknowledgeStyle {
+			return &DecisionError{
+				Status:     http.StatusConflict,
+				Message:    "The description breaks the style guide. Fix it or approve anyway.",
+				Violations: vs,
+			}
+		}
+		notes = fmt.Sprintf("%s (style rules acknowledged: %s)", notes, approval.StyleRuleNames(vs))
+	}
+
+	approvalData := approval.BuildApprovalData(mergeResult, &venue, adminID, notes)
+	if approvalData == nil {
+		log.Printf("approval data assembly returned nil for venue %d", id)
+		return decisionErr(http.StatusInternalServerError, "Failed to prepare approval payload")
+	}
+
+	// Audit log with data replacements
+	histID := latestHistory.ID
+	var auditLog *domain.VenueValidationAuditLog
+	if approvalData.Replacements != nil && approvalData.Replacements.HasReplacements() {
+		replacementsJSON, err := approvalData.Replacements.ToJSON()
+		if err != nil {
+			log.Printf("Failed to serialize data replacements: %v", err)
+			auditLog = domain.NewAuditLog(id, &histID, &adminID, "approved", &notes)
+		} else {
+			auditLog = domain.NewAuditLogWithReplacements(id, &histID, &adminID, "approved", &notes, &replacementsJSON)
+		}
+	} else {
+		auditLog = domain.NewAuditLog(id, &histID, &adminID, "approved", &notes)
+	}
+
+	// Approve venue, audit log and event in one transaction
+	err = commitDecision(ctx, repo, func(dw decisionWriter) error {
+		if err := dw.ApproveVenueWithDataReplacement(ctx, approvalData); err != nil {
+			return err
+		}
+		if err := dw.CreateAuditLogCtx(ctx, auditLog); err != nil {
+			log.Printf("Failed to create audit log for venue approval: %v", err)
+		}
+		return nil
+	}, events.VenueApproved{
+		Base:   events.Base{Ts: time.Now(), VID: id, Adm: &reviewer},
+		Reason: notes,
+		Score:  latestHistory.ValidationScore,
+	})
+	if err != nil {
+		return decisionErr(http.StatusInternalServerError, "Error approving venue: %v", err)
+	}
+
+	if draftStore != nil && draft != nil {
+		if err := draftStore.Delete(ctx, id, drafts.AnyVersion); err != nil {
+			log.Printf("[approval] failed to delete draft for venue %d: %v", id, err)
+		} else {
+			log.Printf("[approval] ✓ Deleted draft for venue %d after approval", id)
+		}
+	}
+
+	recordCategoryReview(ctx, &venue, &latestHistory, approvalData, adminID)
+	mAdminApproved.Inc(1)
+	notifySubmitter(repo, venueWithUser, id, adminID, "approved", rawNotes)
+	return nil
+}
+
+// RejectVenue rejects venue id on behalf of adminID, discards its draft and notifies the
+// submitter. reason is required.
+func RejectVenue(ctx context.Context, repo domain.Repository, draftStore *drafts.DraftStore, id int64, adminID int, reason string) error {
+	rawReason := strings.TrimSpace(reason)
+	if rawReason == "" {
+		return decisionErr(http.StatusBadRequest, "Rejection reason is required")
+	}
+	reviewer := fmt.Sprintf("admin_%d", adminID)
+	reason = fmt.Sprintf("Manually rejected by %s: %s", reviewer, rawReason)
+
+	// Latest validation history, for the audit log and event score
+	var latestHistory *models.ValidationHistory
+	if history, err := repo.GetVenueValidationHistoryCtx(ctx, id); err == nil && len(history) > 0 {
+		latestHistory = latestOf(history)
+	}
+
+	// Update venue status, audit log and event in one transaction
+	err := commitDecision(ctx, repo, func(dw decisionWriter) error {
+		return writeRejection(ctx, dw, id, adminID, reviewer, reason, latestHistory)
+	}, rejectedEvent(id, reviewer, reason, latestHistory))
+	mAdminRejected.Inc(1)
+	if err != nil {
+		return decisionErr(http.StatusInternalServerError, "Error updating venue: %v", err)
+	}
+
+	if draftStore != nil {
+		if err := draftStore.Delete(ctx, id, drafts.AnyVersion); err != nil {
+			log.Printf("[rejection] failed to delete draft for venue %d: %v", id, err)
+		} else {
+			log.Printf("[rejection] ✓ Deleted draft for venue %d after rejection", id)
+		}
+	}
+
+	notifySubmitter(repo, nil, id, adminID, "rejected", rawReason)
+	return nil
+}
+
+// writeRejection sets the venue to rejected and, when there is a validation history, logs it.
+func writeRejection(ctx context.Context, dw decisionWriter, venueID int64, adminID int, reviewer, reason string, latest *models.ValidationHistory) error {
+	if err := dw.UpdateVenueStatusCtx(ctx, venueID, -1, reason, &reviewer); err != nil {
+		return err
+	}
+	if latest != nil {
+		histID := latest.ID
+		if err := dw.CreateAuditLogCtx(ctx, domain.NewAuditLog(venueID, &histID, &adminID, "rejected", &reason)); err != nil {
+			log.Printf("Failed to create audit log for venue %d rejection: %v", venueID, err)
+		}
+	}
+	return nil
+}
+
+func rejectedEvent(venueID int64, reviewer, reason string, latest *models.ValidationHistory) events.VenueRejected {
+	score := 0
+	if latest != nil {
+		score = latest.ValidationScore
+	}
+	return events.VenueRejected{
+		Base:   events.Base{Ts: time.Now(), VID: venueID, Adm: &reviewer},
+		Reason: reason,
+		Score:  score,
+	}
+}
+
+// latestOf returns the most recently processed history entry; history must not be empty.
+func latestOf(history []models.ValidationHistory) *models.ValidationHistory {
+	latest := &history[0]
+	for i := range history {
+		if history[i].ProcessedAt.After(latest.ProcessedAt) {
+			latest = &history[i]
+		}
+	}
+	return latest
+}
+
+// appendEvent records an event that goes with a change outside commitDecision, such as a
+// hold or a comment. The change is already stored, so a failed append is only logged.
+func appendEvent(ctx context.Context, ev events.Event) {
+	if eventSink == nil {
+		return
+	}
+	if err := eventSink.Append(ctx, ev); err != nil {
+		log.Printf("append %s event for venue %d: %v", ev.Type(), ev.VenueID(), err)
+	}
+}
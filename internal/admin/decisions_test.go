@@ -0,0 +1,96 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/events"
+)
+
+// fakeUoW records calls; methods not overridden panic via the nil embedded interface.
+type fakeUoW struct {
+	domain.UnitOfWork
+	updateErr  error
+	updated    bool
+	audits     int
+	enqueued   []domain.OutboxEvent
+	committed  bool
+	rolledBack bool
+}
+
+func (f *fakeUoW) UpdateVenueStatusCtx(context.Context, int64, int, string, *string) error {
+	f.updated = true
+	return f.updateErr
+}
+func (f *fakeUoW) CreateAuditLogCtx(context.Context, *domain.VenueValidationAuditLog) error {
+	f.audits++
+	return nil
+}
+func (f *fakeUoW) EnqueueEventCtx(_ context.Context, ev domain.OutboxEvent) error {
+	f.enqueued = append(f.enqueued, ev)
+	return nil
+}
+func (f *fakeUoW) Commit() error   { f.committed = true; return nil }
+func (f *fakeUoW) Rollback() error { f.rolledBack = true; return nil }
+
+type fakeUoWFactory struct{ uow *fakeUoW }
+
+func (f fakeUoWFactory) Begin(context.Context) (domain.UnitOfWork, error) { return f.uow, nil }
+
+func TestCommitDecision(t *testing.T) {
+	defer SetUnitOfWorkFactory(nil)
+	latest := &models.ValidationHistory{ID: 5, ValidationScore: 40}
+
+	tests := []struct {
+		name       string
+		updateErr  error
+		history    *models.ValidationHistory
+		wantAudits int
+		wantCommit bool
+	}{
+		{"rejection with history", nil, latest, 1, true},
+		{"rejection without history", nil, nil, 0, true},
+		{"update fails", errors.New("boom"), latest, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow := &fakeUoW{updateErr: tt.updateErr}
+			SetUnitOfWorkFactory(fakeUoWFactory{uow})
+			ctx := context.Background()
+			err := commitDecision(ctx, nil, func(dw decisionWriter) error {
+				return writeRejection(ctx, dw, 9, 1, "admin_1", "spam", tt.history)
+			}, rejectedEvent(9, "admin_1", "spam", tt.history))
+
+			if (err != nil) == tt.wantCommit {
+				t.Fatalf("err = %v", err)
+			}
+			if uow.committed != tt.wantCommit || !uow.rolledBack {
+				t.Fatalf("committed=%v rolledBack=%v", uow.committed, uow.rolledBack)
+			}
+			if uow.audits != tt.wantAudits {
+				t.Fatalf("audits = %d, want %d", uow.audits, tt.wantAudits)
+			}
+			if !tt.wantCommit {
+				if len(uow.enqueued) != 0 {
+					t.Fatalf("event enqueued for a failed decision")
+				}
+				return
+			}
+			if len(uow.enqueued) != 1 || uow.enqueued[0].Type != events.TypeRejected || uow.enqueued[0].VenueID != 9 {
+				t.Fatalf("enqueued = %+v", uow.enqueued)
+			}
+		})
+	}
+}
+
+func TestLatestOf(t *testing.T) {
+	t0 := time.Now()
+	h := []models.ValidationHistory{{ID: 1, ProcessedAt: t0}, {ID: 2, ProcessedAt: t0.Add(time.Hour)}, {ID: 3, ProcessedAt: t0.Add(-time.Hour)}}
+	if got := latestOf(h); got.ID != 2 {
+		t.Fatalf("latestOf = %d, want 2", got.ID)
+	}
+}
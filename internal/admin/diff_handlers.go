@@ -0,0 +1,68 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"assisted-venue-approval/internal/approval"
+	"assisted-venue-approval/internal/drafts"
+	"assisted-venue-approval/pkg/database"
+
+	"github.com/gorilla/mux"
+)
+
+// VenueDiffHandler handles GET /venues/{id}/diff
+// Returns submitter vs Google/AI suggestion vs editor draft for name, address, hours,
+// phone, website and description. "Accept suggestion" in the venue page writes
+// suggestion_value to draft_field via PUT /venues/{id}/draft/fields/{field}.
+func VenueDiffHandler(db *database.DB, draftStore *drafts.DraftStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid venue ID", http.StatusBadRequest)
+			return
+		}
+
+		venue, err := db.GetVenueWithUserByIDCtx(r.Context(), id)
+		if err != nil {
+			WriteError(w, r, "Failed to load venue", err)
+			return
+		}
+		history, err := db.GetVenueValidationHistoryCtx(r.Context(), id)
+		if err != nil {
+			log.Printf("Error fetching validation history: %v", err)
+		}
+		googleData, err := db.GetCachedGooglePlaceDataCtx(r.Context(), id)
+		if err != nil {
+			log.Printf("Error fetching cached Google data: %v", err)
+		}
+
+		in := approval.DiffInput{
+			Venue:         venue.Venue,
+			GoogleData:    googleData,
+			LatestHistory: latestValidation(history),
+		}
+		draftVersion := 0
+		if draftStore != nil {
+			d, exists, err := draftStore.Get(r.Context(), id)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Error loading draft: %v", err), http.StatusInternalServerError)
+				return
+			}
+			if exists {
+				in.Draft = d
+				draftVersion = d.Version
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"venue_id":      id,
+			"draft_version": draftVersion,
+			"fields":        approval.Diff(in),
+		})
+	}
+}
@@ -2,10 +2,12 @@ package admin
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"assisted-venue-approval/internal/auth"
 	"assisted-venue-approval/internal/drafts"
@@ -15,8 +17,12 @@ import (
 	"github.com/gorilla/mux"
 )
 
+// Drafts use optimistic concurrency: GET returns the draft version (also as ETag) and every
+// field's version. Whole-draft writes send the draft version in If-Match, field writes send
+// the field version in the body; a stale version gets 409 with the current draft.
+
 // SaveVenueDraftHandler handles POST /venues/{id}/draft
-// Saves editor modifications to in-memory store
+// Replaces the whole draft. If-Match is optional for older clients (last write wins).
 func SaveVenueDraftHandler(store *drafts.DraftStore, db *database.DB) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -37,12 +43,16 @@ func SaveVenueDraftHandler(store *drafts.DraftStore, db *database.DB) http.Handl
 			return
 		}
 
+		ifVersion, err := parseIfMatch(r)
+		if err != nil {
+			writeDraftJSON(w, http.StatusBadRequest, map[string]interface{}{"success": false, "message": err.Error()})
+			return
+		}
+
 		// Parse draft fields from JSON body
 		var draftFields map[string]drafts.DraftField
 		if err := json.NewDecoder(r.Body).Decode(&draftFields); err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]interface{}{
+			writeDraftJSON(w, http.StatusBadRequest, map[string]interface{}{
 				"success": false,
 				"message": "Invalid JSON: " + err.Error(),
 			})
@@ -52,9 +62,7 @@ func SaveVenueDraftHandler(store *drafts.DraftStore, db *database.DB) http.Handl
 		// Validate all fields
 		validationErrors := validation.ValidateVenueDraft(draftFields)
 		if len(validationErrors) > 0 {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]interface{}{
+			writeDraftJSON(w, http.StatusBadRequest, map[string]interface{}{
 				"success": false,
 				"message": "Validation failed",
 				"errors":  validationErrors,
@@ -62,24 +70,20 @@ func SaveVenueDraftHandler(store *drafts.DraftStore, db *database.DB) http.Handl
 			return
 		}
 
-		// Save to in-memory store
-		if err := store.Save(venueID, adminID, draftFields); err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			json.NewEncoder(w).Encode(map[string]interface{}{
-				"success": false,
-				"message": "Failed to save draft: " + err.Error(),
-			})
+		draft, err := store.Save(ctx, venueID, adminID, draftFields, ifVersion)
+		if err != nil {
+			writeDraftError(w, "save draft", err)
 			return
 		}
 
-		log.Printf("Draft saved for venue %d by admin %d (%d fields modified)", venueID, adminID, len(draftFields))
+		log.Printf("Draft saved for venue %d by admin %d (%d fields modified, version %d)", venueID, adminID, len(draftFields), draft.Version)
 
-		// Return success
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": true,
-			"message": "Draft saved successfully",
+		w.Header().Set("ETag", draftETag(draft.Version))
+		writeDraftJSON(w, http.StatusOK, map[string]interface{}{
+			"success":    true,
+			"message":    "Draft saved successfully",
+			"version":    draft.Version,
+			"draft_data": draft.Fields,
 		})
 	}
 }
@@ -98,36 +102,27 @@ func GetVenueDraftHandler(store *drafts.DraftStore, db *database.DB) http.Handle
 		}
 
 		// Get draft from store
-		draft, exists := store.Get(venueID)
-
-		// Get editor info if draft exists
-		var editorName string
-		if exists {
-			// Simplified: just use admin ID for now
-			// TODO: Look up actual admin name from database if needed
-			editorName = fmt.Sprintf("Admin #%d", draft.EditorID)
+		draft, exists, err := store.Get(r.Context(), venueID)
+		if err != nil {
+			writeDraftError(w, "load draft", err)
+			return
 		}
 
 		// Return JSON response
-		w.Header().Set("Content-Type", "application/json")
 		if exists {
-			json.NewEncoder(w).Encode(map[string]interface{}{
-				"has_draft":   true,
-				"draft_data":  draft.Fields,
-				"editor_id":   draft.EditorID,
-				"editor_name": editorName,
-				"updated_at":  draft.UpdatedAt,
-			})
+			w.Header().Set("ETag", draftETag(draft.Version))
+			writeDraftJSON(w, http.StatusOK, draftResponse(draft))
 		} else {
-			json.NewEncoder(w).Encode(map[string]interface{}{
+			writeDraftJSON(w, http.StatusOK, map[string]interface{}{
 				"has_draft": false,
+				"version":   0,
 			})
 		}
 	}
 }
 
 // ClearVenueDraftHandler handles DELETE /venues/{id}/draft
-// Removes draft from store
+// Removes the draft; If-Match is optional.
 func ClearVenueDraftHandler(store *drafts.DraftStore) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -148,16 +143,183 @@ func ClearVenueDraftHandler(store *drafts.DraftStore) http.HandlerFunc {
 			return
 		}
 
-		// Delete draft
-		store.Delete(venueID)
+		ifVersion, err := parseIfMatch(r)
+		if err != nil {
+			writeDraftJSON(w, http.StatusBadRequest, map[string]interface{}{"success": false, "message": err.Error()})
+			return
+		}
+
+		if err := store.Delete(ctx, venueID, ifVersion); err != nil {
+			writeDraftError(w, "clear draft", err)
+			return
+		}
 
 		log.Printf("Draft cleared for venue %d by admin %d", venueID, adminID)
 
-		// Return success
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
+		writeDraftJSON(w, http.StatusOK, map[string]interface{}{
 			"success": true,
 			"message": "Draft cleared successfully",
 		})
 	}
 }
+
+// SetDraftFieldHandler handles PUT /venues/{id}/draft/fields/{field}
+// Body: {"value": ..., "original_source": "user", "version": 3}; version is the field
+// version the edit is based on (0 for a field not in the draft yet).
+func SetDraftFieldHandler(store *drafts.DraftStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		venueID, field, adminID, ok := draftFieldRequest(w, r)
+		if !ok {
+			return
+		}
+
+		var body struct {
+			Value          interface{} `json:"value"`
+			OriginalSource string      `json:"original_source"`
+			Version        *int        `json:"version"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeDraftJSON(w, http.StatusBadRequest, map[string]interface{}{"success": false, "message": "Invalid JSON: " + err.Error()})
+			return
+		}
+		if body.Version == nil || *body.Version < 0 {
+			writeDraftJSON(w, http.StatusBadRequest, map[string]interface{}{"success": false, "message": "version is required (0 for a new field)"})
+			return
+		}
+
+		f := drafts.DraftField{Value: body.Value, OriginalSource: body.OriginalSource}
+		if errs := validation.ValidateVenueDraft(map[string]drafts.DraftField{field: f}); len(errs) > 0 {
+			writeDraftJSON(w, http.StatusBadRequest, map[string]interface{}{
+				"success": false,
+				"message": "Validation failed",
+				"errors":  errs,
+			})
+			return
+		}
+
+		draft, err := store.SetField(r.Context(), venueID, adminID, field, f, *body.Version)
+		if err != nil {
+			writeDraftError(w, "save draft field", err)
+			return
+		}
+		log.Printf("Draft field %s of venue %d set by admin %d (version %d)", field, venueID, adminID, draft.Fields[field].Version)
+
+		w.Header().Set("ETag", draftETag(draft.Version))
+		writeDraftJSON(w, http.StatusOK, draftResponse(draft))
+	}
+}
+
+// DeleteDraftFieldHandler handles DELETE /venues/{id}/draft/fields/{field}?version=3
+// Removing the last field deletes the draft.
+func DeleteDraftFieldHandler(store *drafts.DraftStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		venueID, field, adminID, ok := draftFieldRequest(w, r)
+		if !ok {
+			return
+		}
+
+		version, err := strconv.Atoi(r.URL.Query().Get("version"))
+		if err != nil || version < 0 {
+			writeDraftJSON(w, http.StatusBadRequest, map[string]interface{}{"success": false, "message": "version query parameter is required"})
+			return
+		}
+
+		draft, err := store.DeleteField(r.Context(), venueID, adminID, field, version)
+		if err != nil {
+			writeDraftError(w, "delete draft field", err)
+			return
+		}
+		log.Printf("Draft field %s of venue %d removed by admin %d", field, venueID, adminID)
+
+		if draft == nil {
+			writeDraftJSON(w, http.StatusOK, map[string]interface{}{"has_draft": false, "version": 0})
+			return
+		}
+		w.Header().Set("ETag", draftETag(draft.Version))
+		writeDraftJSON(w, http.StatusOK, draftResponse(draft))
+	}
+}
+
+func draftFieldRequest(w http.ResponseWriter, r *http.Request) (venueID int64, field string, adminID int, ok bool) {
+	vars := mux.Vars(r)
+	venueID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid venue ID", http.StatusBadRequest)
+		return 0, "", 0, false
+	}
+	field = strings.TrimSpace(vars["field"])
+	if field == "" {
+		http.Error(w, "Invalid field", http.StatusBadRequest)
+		return 0, "", 0, false
+	}
+	adminID, ok = auth.GetAdminIDFromContext(r.Context())
+	if !ok || adminID == 0 {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return 0, "", 0, false
+	}
+	return venueID, field, adminID, true
+}
+
+func draftResponse(d *drafts.VenueDraft) map[string]interface{} {
+	return map[string]interface{}{
+		"has_draft":   true,
+		"version":     d.Version,
+		"draft_data":  d.Fields,
+		"editor_id":   d.EditorID,
+		"editor_name": fmt.Sprintf("Admin #%d", d.EditorID),
+		"updated_at":  d.UpdatedAt,
+	}
+}
+
+// writeDraftError maps a store error to 409 (with the current draft) or 500.
+func writeDraftError(w http.ResponseWriter, action string, err error) {
+	var conflict *drafts.ConflictError
+	if !errors.As(err, &conflict) {
+		writeDraftJSON(w, http.StatusInternalServerError, map[string]interface{}{
+			"success": false,
+			"message": fmt.Sprintf("Failed to %s: %v", action, err),
+		})
+		return
+	}
+	resp := map[string]interface{}{
+		"success":   false,
+		"conflict":  true,
+		"message":   conflict.Error() + "; reload the draft and reapply your changes",
+		"field":     conflict.Field,
+		"has_draft": conflict.Current != nil,
+		"version":   0,
+	}
+	if c := conflict.Current; c != nil {
+		resp["version"] = c.Version
+		resp["draft_data"] = c.Fields
+		resp["editor_id"] = c.EditorID
+		resp["editor_name"] = fmt.Sprintf("Admin #%d", c.EditorID)
+		resp["updated_at"] = c.UpdatedAt
+	}
+	writeDraftJSON(w, http.StatusConflict, resp)
+}
+
+func writeDraftJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// parseIfMatch reads the draft version from If-Match ("3", "\"3\"" or W/"3").
+// No header means drafts.AnyVersion.
+func parseIfMatch(r *http.Request) (int, error) {
+	v := strings.TrimSpace(r.Header.Get("If-Match"))
+	if v == "" || v == "*" {
+		return drafts.AnyVersion, nil
+	}
+	v = strings.Trim(strings.TrimPrefix(v, "W/"), `"`)
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid If-Match %q: want the draft version", r.Header.Get("If-Match"))
+	}
+	return n, nil
+}
+
+func draftETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
@@ -0,0 +1,39 @@
+package admin
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	errs "assisted-venue-approval/pkg/errors"
+	"assisted-venue-approval/pkg/logging"
+)
+
+// ErrorResponse is the status and JSON body for a failed API call: the status and "code"
+// follow the error type (errs.CodeOf) and "request_id" ties the response to the server log.
+// Server-side failures (5xx) are logged with the request ID so a reviewer's report can be traced.
+func ErrorResponse(r *http.Request, msg string, err error) (int, map[string]interface{}) {
+	code := errs.CodeOf(err)
+	reqID := logging.RequestID(r.Context())
+	if err != nil {
+		msg += ": " + err.Error()
+	}
+	status := code.HTTPStatus()
+	if status >= http.StatusInternalServerError {
+		log.Printf("[%s] %s %s: %s", reqID, r.Method, r.URL.Path, msg)
+	}
+	return status, map[string]interface{}{
+		"status":     "error",
+		"code":       code,
+		"message":    msg,
+		"request_id": reqID,
+	}
+}
+
+// WriteError writes ErrorResponse as JSON.
+func WriteError(w http.ResponseWriter, r *http.Request, msg string, err error) {
+	status, body := ErrorResponse(r, msg, err)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,30 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "assisted-venue-approval/pkg/errors"
+	"assisted-venue-approval/pkg/logging"
+)
+
+func TestWriteError(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/venues/7/diff", nil)
+	req = req.WithContext(logging.WithRequestID(req.Context(), "req-1"))
+	rec := httptest.NewRecorder()
+
+	WriteError(rec, req, "Failed to load venue", errs.NewNotFound("database.GetVenueWithUserByIDCtx", "venue 7 not found", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", rec.Code)
+	}
+	var body map[string]string
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body["code"] != "not_found" || body["request_id"] != "req-1" {
+		t.Fatalf("body = %v", body)
+	}
+}
@@ -0,0 +1,54 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// WantsEventStream reports whether the client asked for server-sent events (Accept:
+// text/event-stream) rather than a single JSON reply.
+func WantsEventStream(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
+
+// EventStream writes server-sent events, each a named event with a JSON payload, flushing
+// every one so the client sees it at once. It is safe for concurrent use.
+type EventStream struct {
+	mu sync.Mutex
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+// NewEventStream starts an event stream response with status 200. It fails when the writer
+// cannot flush, before anything is written, so the caller can still reply with plain JSON.
+func NewEventStream(w http.ResponseWriter) (*EventStream, error) {
+	rc := http.NewResponseController(w)
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("X-Accel-Buffering", "no") // nginx would otherwise hold events back
+	if err := rc.Flush(); err != nil {
+		h.Del("Content-Type")
+		h.Del("Cache-Control")
+		h.Del("X-Accel-Buffering")
+		return nil, fmt.Errorf("event stream: %w", err)
+	}
+	return &EventStream{w: w, rc: rc}, nil
+}
+
+// Send writes one event. Errors mean the client has gone away; later events are dropped too.
+func (s *EventStream) Send(event string, data interface{}) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", event, b); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
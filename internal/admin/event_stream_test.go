@@ -0,0 +1,38 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type noFlushWriter struct{ http.ResponseWriter }
+
+func TestEventStream(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/venues/1/validate", nil)
+	if WantsEventStream(req) {
+		t.Error("no Accept header wants an event stream")
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if !WantsEventStream(req) {
+		t.Error("Accept: text/event-stream not recognised")
+	}
+
+	rec := httptest.NewRecorder()
+	es, err := NewEventStream(rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	es.Send("delta", map[string]string{"text": "line\nbreak"})
+	es.Send("result", map[string]interface{}{"status": "success"})
+	want := "event: delta\ndata: {\"text\":\"line\\nbreak\"}\n\nevent: result\ndata: {\"status\":\"success\"}\n\n"
+	if rec.Header().Get("Content-Type") != "text/event-stream" || !rec.Flushed || rec.Body.String() != want {
+		t.Errorf("headers %v flushed %v body %q", rec.Header(), rec.Flushed, rec.Body.String())
+	}
+
+	// A writer that cannot flush is refused before anything is sent
+	plain := httptest.NewRecorder()
+	if _, err := NewEventStream(noFlushWriter{plain}); err == nil || plain.Header().Get("Content-Type") != "" {
+		t.Errorf("err = %v, Content-Type %q", err, plain.Header().Get("Content-Type"))
+	}
+}
@@ -9,13 +9,15 @@ import (
 	"strings"
 
 	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/internal/privacy"
 	"assisted-venue-approval/pkg/database"
 
 	"github.com/gorilla/mux"
 )
 
 // SubmitFeedbackHandler handles POST /venues/{id}/feedback
-func SubmitFeedbackHandler(db *database.DB) http.HandlerFunc {
+// The client IP is stored as ipPolicy allows (FEEDBACK_IP_MODE).
+func SubmitFeedbackHandler(db *database.DB, ipPolicy privacy.IPPolicy) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
 		id, err := strconv.ParseInt(vars["id"], 10, 64)
@@ -41,7 +43,7 @@ func SubmitFeedbackHandler(db *database.DB) http.HandlerFunc {
 		}
 		var pv *string
 		if p := strings.TrimSpace(r.FormValue("prompt_version")); p != "" {
-			if len(p) > 32 {
+			if len(p) > models.MaxPromptVersionLen {
 				http.Error(w, "prompt_version too long", http.StatusBadRequest)
 				return
 			}
@@ -52,8 +54,7 @@ func SubmitFeedbackHandler(db *database.DB) http.HandlerFunc {
 			cmt = &c
 		}
 
-		ip := clientIP(r)
-		ipb := models.IPToBytes(ip)
+		ipb := ipPolicy.Store(clientIP(r))
 
 		// UPSERT handles duplicate prevention: one feedback per (venue_id, ip)
 		rec := &models.EditorFeedback{VenueID: id, PromptVersion: pv, FeedbackType: ftype, Comment: cmt, IP: ipb}
@@ -100,7 +101,7 @@ func APIFeedbackStatsHandler(db *database.DB) http.HandlerFunc {
 		q := r.URL.Query()
 		var pv *string
 		if p := strings.TrimSpace(q.Get("prompt_version")); p != "" {
-			if len(p) > 32 {
+			if len(p) > models.MaxPromptVersionLen {
 				http.Error(w, "prompt_version too long", http.StatusBadRequest)
 				return
 			}
@@ -161,6 +162,37 @@ func EditorialFeedbackListHandler(db *database.DB) http.HandlerFunc {
 	}
 }
 
+// APIEditorialFeedbackHandler handles GET /api/v1/feedback?page=&limit=
+// Pages through all editor feedback, newest first, for exports; limit is at most 500.
+func APIEditorialFeedbackHandler(db *database.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		page, _ := strconv.Atoi(q.Get("page"))
+		if page < 1 {
+			page = 1
+		}
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		if limit < 1 || limit > 500 {
+			limit = 100
+		}
+		list, total, err := db.GetAllEditorFeedbackPaginatedCtx(r.Context(), limit, (page-1)*limit)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error fetching feedback: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if list == nil {
+			list = []models.EditorFeedbackWithVenue{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"items": list,
+			"total": total,
+			"page":  page,
+			"limit": limit,
+		})
+	}
+}
+
 // clientIP extracts the first client IP from common headers or RemoteAddr.
 func clientIP(r *http.Request) net.IP {
 	// X-Forwarded-For can have multiple IPs, use the first
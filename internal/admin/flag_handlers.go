@@ -0,0 +1,186 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/flags"
+	"assisted-venue-approval/internal/models"
+
+	"github.com/gorilla/mux"
+)
+
+type featureFlagsPage struct {
+	Flags []flags.State
+	Error string
+	Saved string
+}
+
+// featureFlagInput is the update request, from the form or as JSON.
+type featureFlagInput struct {
+	Enabled        bool `json:"enabled"`
+	RolloutPercent *int `json:"rollout_percent"` // nil for 100
+}
+
+// RequireFlag answers 404 to admins the flag is off for, keyed by admin ID.
+func RequireFlag(ff *flags.Service, name string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		adminID, _ := auth.GetAdminIDFromContext(r.Context())
+		if !ff.Enabled(name, int64(adminID), true) {
+			http.NotFound(w, r)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// FeatureFlagsHandler handles GET /settings/feature-flags
+func FeatureFlagsHandler(ff *flags.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		renderFeatureFlags(w, ff, featureFlagsPage{})
+	}
+}
+
+// UpdateFeatureFlagFormHandler handles POST /settings/feature-flags/{name}
+// Form: enabled (on when checked), rollout_percent (0-100), or reset=1 to drop the stored flag.
+func UpdateFeatureFlagFormHandler(store domain.FeatureFlagStore, ff *flags.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "invalid form", http.StatusBadRequest)
+			return
+		}
+		name := mux.Vars(r)["name"]
+		if r.PostFormValue("reset") != "" {
+			if status, msg := resetFeatureFlag(r, store, ff, name); msg != "" && status != http.StatusNotFound {
+				http.Error(w, msg, status)
+				return
+			}
+			renderFeatureFlags(w, ff, featureFlagsPage{Saved: name + " reset to its environment setting"})
+			return
+		}
+		in := featureFlagInput{Enabled: r.PostFormValue("enabled") != ""}
+		if v := strings.TrimSpace(r.PostFormValue("rollout_percent")); v != "" {
+			pct, err := strconv.Atoi(v)
+			if err != nil {
+				renderFeatureFlags(w, ff, featureFlagsPage{Error: "rollout_percent must be a whole number"})
+				return
+			}
+			in.RolloutPercent = &pct
+		}
+		f, status, msg := setFeatureFlag(r, store, ff, name, in)
+		if msg != "" {
+			if status == http.StatusInternalServerError {
+				http.Error(w, msg, status)
+				return
+			}
+			renderFeatureFlags(w, ff, featureFlagsPage{Error: msg})
+			return
+		}
+		renderFeatureFlags(w, ff, featureFlagsPage{Saved: fmt.Sprintf("%s %s at %d%%", f.Name, onOff(f.Enabled), f.RolloutPercent)})
+	}
+}
+
+// APIFeatureFlagsHandler handles GET /api/v1/feature-flags
+func APIFeatureFlagsHandler(ff *flags.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"flags": ff.States()})
+	}
+}
+
+// APISetFeatureFlagHandler handles PUT /api/v1/feature-flags/{name}
+// Body: {"enabled": true, "rollout_percent": 10}; rollout_percent defaults to 100.
+func APISetFeatureFlagHandler(store domain.FeatureFlagStore, ff *flags.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var in featureFlagInput
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4<<10)).Decode(&in); err != nil {
+			http.Error(w, "invalid JSON body", http.StatusBadRequest)
+			return
+		}
+		f, status, msg := setFeatureFlag(r, store, ff, mux.Vars(r)["name"], in)
+		if msg != "" {
+			http.Error(w, msg, status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(f)
+	}
+}
+
+// APIDeleteFeatureFlagHandler handles DELETE /api/v1/feature-flags/{name}; the capability
+// goes back to its environment setting.
+func APIDeleteFeatureFlagHandler(store domain.FeatureFlagStore, ff *flags.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if status, msg := resetFeatureFlag(r, store, ff, mux.Vars(r)["name"]); msg != "" {
+			http.Error(w, msg, status)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func setFeatureFlag(r *http.Request, store domain.FeatureFlagStore, ff *flags.Service, name string, in featureFlagInput) (*models.FeatureFlag, int, string) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		return nil, http.StatusForbidden, "Admin ID not found in context"
+	}
+	if !flags.IsKnown(name) {
+		return nil, http.StatusNotFound, "unknown feature flag"
+	}
+	pct := 100
+	if in.RolloutPercent != nil {
+		pct = *in.RolloutPercent
+	}
+	if pct < 0 || pct > 100 {
+		return nil, http.StatusBadRequest, "rollout_percent must be between 0 and 100"
+	}
+	f := models.FeatureFlag{Name: name, Enabled: in.Enabled, RolloutPercent: pct, UpdatedBy: adminID, UpdatedAt: time.Now()}
+	if err := store.SetFeatureFlagCtx(r.Context(), f); err != nil {
+		return nil, http.StatusInternalServerError, fmt.Sprintf("failed to save feature flag: %v", err)
+	}
+	ff.Invalidate()
+	log.Printf("admin %d set feature flag %s %s at %d%%", adminID, name, onOff(f.Enabled), pct)
+	return &f, http.StatusOK, ""
+}
+
+func resetFeatureFlag(r *http.Request, store domain.FeatureFlagStore, ff *flags.Service, name string) (int, string) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		return http.StatusForbidden, "Admin ID not found in context"
+	}
+	found, err := store.DeleteFeatureFlagCtx(r.Context(), name)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Sprintf("failed to delete feature flag: %v", err)
+	}
+	if !found {
+		return http.StatusNotFound, "feature flag not stored"
+	}
+	ff.Invalidate()
+	log.Printf("admin %d reset feature flag %s", adminID, name)
+	return http.StatusOK, ""
+}
+
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
+
+func renderFeatureFlags(w http.ResponseWriter, ff *flags.Service, page featureFlagsPage) {
+	page.Flags = ff.States()
+	if page.Error != "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	if err := ExecuteTemplate(w, "feature_flags.tmpl", page); err != nil {
+		http.Error(w, fmt.Sprintf("template error: %v", err), http.StatusInternalServerError)
+	}
+}
@@ -3,6 +3,7 @@ package admin
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
 	"log"
@@ -13,11 +14,14 @@ import (
 
 	"assisted-venue-approval/internal/approval"
 	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/decision"
 	"assisted-venue-approval/internal/domain"
 	"assisted-venue-approval/internal/drafts"
+	"assisted-venue-approval/internal/flags"
 	"assisted-venue-approval/internal/models"
 	"assisted-venue-approval/internal/processor"
 	"assisted-venue-approval/internal/trust"
+	"assisted-venue-approval/pkg/circuit"
 	"assisted-venue-approval/pkg/config"
 	"assisted-venue-approval/pkg/database"
 	"assisted-venue-approval/pkg/events"
@@ -90,7 +94,7 @@ func HomeHandler(repo domain.Repository, engine *processor.ProcessingEngine) htt
 		pendingTotal := len(venuesWithUser)
 
 		// Count pending venues that already have AVA review results (validation history)
-		_, _, assistedTotal, err := repo.GetManualReviewVenuesCtx(r.Context(), "", 0, false, "created_at", 1, 0)
+		_, _, assistedTotal, err := repo.GetManualReviewVenuesCtx(r.Context(), models.ManualReviewFilter{Sort: "created_at"}, 1, 0)
 		if err != nil {
 			log.Printf("Error fetching manual review count: %v", err)
 			assistedTotal = 0
@@ -119,34 +123,42 @@ func HomeHandler(repo domain.Repository, engine *processor.ProcessingEngine) htt
 
 func PendingVenuesHandler(db *database.DB) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// Parse query parameters (only search and pagination; status is always pending)
+		if redirectToDefaultFilter(w, r, db, models.SavedFilterPending) {
+			return
+		}
+		// Parse query parameters (search, region and cursor; status is always pending)
 		search := r.URL.Query().Get("search")
-		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
-		if page < 1 {
-			page = 1
+		pathPrefix := strings.TrimSpace(r.URL.Query().Get("path_prefix"))
+		cursor := r.URL.Query().Get("cursor")
+		limit := uiPreferences(r).RowsPerPage
+
+		// Always fetch pending venues only, newest first
+		venues, pages, total, err := db.GetVenuesFilteredKeysetCtx(r.Context(), "pending", search, pathPrefix, cursor, limit)
+		if errors.Is(err, database.ErrInvalidCursor) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		limit := 50
-		offset := (page - 1) * limit
-
-		// Always fetch pending venues only
-		venues, total, err := db.GetVenuesFilteredCtx(r.Context(), "pending", search, limit, offset)
 		if err != nil {
 			http.Error(w, fmt.Sprintf("Error fetching venues: %v", err), http.StatusInternalServerError)
 			return
 		}
 
 		data := struct {
-			Venues     []models.VenueWithUser
-			Total      int
-			Page       int
-			TotalPages int
-			Search     string
+			Venues       []models.VenueWithUser
+			Total        int
+			Pages        models.PageCursors
+			Paged        bool
+			Search       string
+			PathPrefix   string
+			SavedFilters *savedFiltersView
 		}{
-			Venues:     venues,
-			Total:      total,
-			Page:       page,
-			TotalPages: (total + limit - 1) / limit,
-			Search:     search,
+			Venues:       venues,
+			Total:        total,
+			Pages:        pages,
+			Paged:        cursor != "",
+			Search:       search,
+			PathPrefix:   pathPrefix,
+			SavedFilters: loadSavedFilters(r, db, models.SavedFilterPending, filterQuery(models.SavedFilterPending, r.URL.Query())),
 		}
 
 		if err := ExecuteTemplate(w, "pending.tmpl", data); err != nil {
@@ -159,69 +171,136 @@ func PendingVenuesHandler(db *database.DB) http.HandlerFunc {
 // ManualReviewHandler lists venues pending manual review (those with validation history and still active=0)
 func ManualReviewHandler(db *database.DB) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		search := r.URL.Query().Get("search")
-		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if redirectToDefaultFilter(w, r, db, models.SavedFilterManualReview) {
+			return
+		}
+		q := r.URL.Query()
+		search := q.Get("search")
+		pathPrefix := strings.TrimSpace(q.Get("path_prefix"))
+		page, _ := strconv.Atoi(q.Get("page"))
 		if page < 1 {
 			page = 1
 		}
-		limit := 50
+		prefs := uiPreferences(r)
+		limit := prefs.RowsPerPage
 		offset := (page - 1) * limit
 
 		// Check if "high scores only" filter is enabled
-		highScoresOnly := r.URL.Query().Get("high_scores_only") == "true"
-		minScore := 0
+		highScoresOnly := q.Get("high_scores_only") == "true"
+		minScore, _ := strconv.Atoi(q.Get("min_score"))
+		maxScore, _ := strconv.Atoi(q.Get("max_score"))
 		cfg := config.Load()
-		if highScoresOnly {
+		if highScoresOnly && minScore < cfg.ApprovalThreshold {
 			minScore = cfg.ApprovalThreshold
 		}
 
 		// Check if "trusted users only" filter is enabled
-		trustedOnly := r.URL.Query().Get("trusted_only") == "true"
+		trustedOnly := q.Get("trusted_only") == "true"
 
-		// Get sort parameter (default: last_updated)
-		sort := r.URL.Query().Get("sort")
-		if sort == "" {
-			sort = "last_updated"
+		var category *int
+		if c, err := strconv.Atoi(q.Get("category")); err == nil {
+			category = &c
+		}
+
+		// "My queue": venues the current admin has claimed
+		adminID, _ := auth.GetAdminIDFromContext(r.Context())
+		myQueue := claimTimeout > 0 && q.Get("my_queue") == "true"
+		claimedBy := 0
+		if myQueue {
+			claimedBy = adminID
 		}
 
-		venues, scores, total, err := db.GetManualReviewVenuesCtx(r.Context(), search, minScore, trustedOnly, sort, limit, offset)
+		// Get sort parameter (default: the admin's preferred sort)
+		sort := q.Get("sort")
+		if sort == "" {
+			sort = prefs.DefaultSort
+		}
+
+		venues, scores, total, err := db.GetManualReviewVenuesCtx(r.Context(), models.ManualReviewFilter{
+			Search:      search,
+			PathPrefix:  pathPrefix,
+			MinScore:    minScore,
+			MaxScore:    maxScore,
+			TrustedOnly: trustedOnly,
+			ClaimedBy:   claimedBy,
+			Category:    category,
+			Sort:        sort,
+		}, limit, offset)
 		if err != nil {
 			http.Error(w, fmt.Sprintf("Error fetching manual review venues: %v", err), http.StatusInternalServerError)
 			return
 		}
 		// update gauge
-		gManualPending.SetFloat64(float64(total))
+		filtered := myQueue || category != nil || maxScore > 0 || pathPrefix != ""
+		if !filtered {
+			gManualPending.SetFloat64(float64(total))
+		}
+
+		claims := map[int64]models.VenueClaim{}
+		if claimTimeout > 0 {
+			ids := make([]int64, len(venues))
+			for i := range venues {
+				ids[i] = venues[i].Venue.ID
+			}
+			if claims, err = db.GetActiveClaimsCtx(r.Context(), ids); err != nil {
+				log.Printf("Error fetching venue claims: %v", err)
+			}
+		}
 
 		// Build a view model combining scores with venues for the template
 		type Item struct {
 			VenueWithUser models.VenueWithUser
 			Score         int
+			Claim         *models.VenueClaim
 		}
 		items := make([]Item, 0, len(venues))
 		for i := range venues {
-			items = append(items, Item{VenueWithUser: venues[i], Score: scores[i]})
+			item := Item{VenueWithUser: venues[i], Score: scores[i]}
+			if c, ok := claims[venues[i].Venue.ID]; ok {
+				item.Claim = &c
+			}
+			items = append(items, item)
 		}
 
+		fq := filterQuery(models.SavedFilterManualReview, q)
 		data := struct {
 			Items             []Item
 			Total             int
 			Page              int
 			TotalPages        int
 			Search            string
+			PathPrefix        string
 			HighScoresOnly    bool
 			TrustedOnly       bool
+			MyQueue           bool
+			CurrentAdminID    int
 			ApprovalThreshold int
 			Sort              string
+			MinScore          string
+			MaxScore          string
+			Category          string
+			Categories        []models.CategoryOption
+			FilterQuery       template.URL
+			SavedFilters      *savedFiltersView
 		}{
 			Items:             items,
 			Total:             total,
 			Page:              page,
 			TotalPages:        (total + limit - 1) / limit,
 			Search:            search,
+			PathPrefix:        pathPrefix,
 			HighScoresOnly:    highScoresOnly,
 			TrustedOnly:       trustedOnly,
+			MyQueue:           myQueue,
+			CurrentAdminID:    adminID,
 			ApprovalThreshold: cfg.ApprovalThreshold,
 			Sort:              sort,
+			MinScore:          q.Get("min_score"),
+			MaxScore:          q.Get("max_score"),
+			Category:          q.Get("category"),
+			Categories:        models.StoreCategoryOptions(),
+			FilterQuery:       template.URL(fq),
+			SavedFilters:      loadSavedFilters(r, db, models.SavedFilterManualReview, fq),
 		}
 
 		if err := ExecuteTemplate(w, "manual_review.tmpl", data); err != nil {
@@ -248,148 +327,13 @@ func ApproveVenueHandler(repo domain.Repository, cfg *config.Config, draftStore
 			return
 		}
 
-		var draft *drafts.VenueDraft
-		if draftStore != nil {
-			if d, exists := draftStore.Get(id); exists {
-				draft = d
-				log.Printf("Loaded draft for venue %d with %d modified fields", id, len(draft.Fields))
-			}
-		}
-
-		reviewer := fmt.Sprintf("admin_%d", adminID)
-		rawNotes := strings.TrimSpace(r.FormValue("notes"))
-		notes := rawNotes
-		if notes == "" {
-			notes = "Manually approved by " + reviewer
-		} else {
-			notes = fmt.Sprintf("Manually approved by %s: %s", reviewer, rawNotes)
-		}
-
-		// Validate that venue has a valid validation history before approval
-		// Venue can only be approved if there's already a validation history with status='approved' and score >= threshold
-		approvalThreshold := cfg.ApprovalThreshold
-		if err := repo.ValidateApprovalEligibility(id, approvalThreshold); err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]string{
-				"status":  "error",
-				"message": fmt.Sprintf("Cannot approve venue: %v", err),
-			})
-			return
-		}
-
-		// Get validation history
-		history, err := repo.GetVenueValidationHistoryCtx(r.Context(), id)
-		if err != nil || len(history) == 0 {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]string{
-				"status":  "error",
-				"message": "Cannot approve venue: no validation history found",
-			})
-			return
-		}
-
-		// Find the most recent history entry
-		latestHistory := history[0]
-		for _, h := range history {
-			if h.ProcessedAt.After(latestHistory.ProcessedAt) {
-				latestHistory = h
-			}
-		}
-
-		// Additional check: ensure latest status is "approved"
-		if latestHistory.ValidationStatus != "approved" {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]string{
-				"status":  "error",
-				"message": fmt.Sprintf("Cannot approve venue: latest validation status is '%s' (not 'approved')", latestHistory.ValidationStatus),
-			})
-			return
-		}
-
-		venueWithUser, err := repo.GetVenueWithUserByIDCtx(r.Context(), id)
+		err := ApproveVenue(r.Context(), repo, cfg, draftStore, id, adminID,
+			strings.TrimSpace(r.FormValue("notes")), r.FormValue("acknowledge_style") == "true")
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			json.NewEncoder(w).Encode(map[string]string{
-				"status":  "error",
-				"message": fmt.Sprintf("Error fetching venue: %v", err),
-			})
-			return
-		}
-
-		venue := venueWithUser.Venue
-		tc := trust.NewDefault()
-		assessment := tc.Assess(venueWithUser.User, venue.Location)
-		mergeResult, err := approval.Assemble(approval.MergeInput{
-			Venue:         venue,
-			User:          venueWithUser.User,
-			TrustScore:    assessment.Trust,
-			LatestHistory: &latestHistory,
-			Draft:         draft,
-			Repo:          repo,
-		})
-		if err != nil {
-			log.Printf("failed to assemble approval data for venue %d: %v", id, err)
-			http.Error(w, "Failed to prepare approval payload", http.StatusInternalServerError)
-			return
-		}
-
-		approvalData := approval.BuildApprovalData(mergeResult, &venue, adminID, notes)
-		if approvalData == nil {
-			log.Printf("approval data assembly returned nil for venue %d", id)
-			http.Error(w, "Failed to prepare approval payload", http.StatusInternalServerError)
-			return
-		}
-
-		// Approve venue
-		if err := repo.ApproveVenueWithDataReplacement(r.Context(), approvalData); err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			json.NewEncoder(w).Encode(map[string]string{
-				"status":  "error",
-				"message": fmt.Sprintf("Error approving venue: %v", err),
-			})
+			writeDecisionError(w, err)
 			return
 		}
 
-		if draftStore != nil && draft != nil {
-			draftStore.Delete(id)
-			log.Printf("[approval] ✓ Deleted draft for venue %d after approval", id)
-		}
-
-		// metrics
-		mAdminApproved.Inc(1)
-
-		// Create audit log with data replacements
-		histID := latestHistory.ID
-		var auditLog *domain.VenueValidationAuditLog
-		if approvalData.Replacements != nil && approvalData.Replacements.HasReplacements() {
-			replacementsJSON, err := approvalData.Replacements.ToJSON()
-			if err != nil {
-				log.Printf("Failed to serialize data replacements: %v", err)
-				auditLog = domain.NewAuditLog(id, &histID, &adminID, "approved", &notes)
-			} else {
-				auditLog = domain.NewAuditLogWithReplacements(id, &histID, &adminID, "approved", &notes, &replacementsJSON)
-			}
-		} else {
-			auditLog = domain.NewAuditLog(id, &histID, &adminID, "approved", &notes)
-		}
-		if err := repo.CreateAuditLogCtx(r.Context(), auditLog); err != nil {
-			log.Printf("Failed to create audit log for venue approval: %v", err)
-		}
-
-		// Publish event
-		if eventSink != nil {
-			_ = eventSink.Append(r.Context(), events.VenueApproved{
-				Base:   events.Base{Ts: time.Now(), VID: id, Adm: &reviewer},
-				Reason: notes,
-				Score:  latestHistory.ValidationScore,
-			})
-		}
-
 		// Always return JSON
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]string{"status": "approved"})
@@ -413,75 +357,11 @@ func RejectVenueHandler(repo domain.Repository, draftStore *drafts.DraftStore) h
 			return
 		}
 
-		// Get reviewer info from session/auth
-		reviewer := fmt.Sprintf("admin_%d", adminID)
-		reason := strings.TrimSpace(r.FormValue("reason"))
-
-		// Rejection reason is required
-		if reason == "" {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]string{
-				"status":  "error",
-				"message": "Rejection reason is required",
-			})
-			return
-		}
-
-		reason = fmt.Sprintf("Manually rejected by %s: %s", reviewer, reason)
-
-		// Update venue status
-		err := repo.UpdateVenueStatusCtx(r.Context(), id, -1, reason, &reviewer)
-		// metrics
-		mAdminRejected.Inc(1)
-		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			json.NewEncoder(w).Encode(map[string]string{
-				"status":  "error",
-				"message": fmt.Sprintf("Error updating venue: %v", err),
-			})
+		if err := RejectVenue(r.Context(), repo, draftStore, id, adminID, r.FormValue("reason")); err != nil {
+			writeDecisionError(w, err)
 			return
 		}
 
-		// Delete draft after successful rejection
-		if draftStore != nil {
-			draftStore.Delete(id)
-			log.Printf("[rejection] ✓ Deleted draft for venue %d after rejection", id)
-		}
-
-		// Get the validation history ID to create audit log
-		history, err := repo.GetVenueValidationHistoryCtx(r.Context(), id)
-		if err == nil && len(history) > 0 {
-			// Find the most recent history entry
-			latestHistory := history[0]
-			for _, h := range history {
-				if h.ProcessedAt.After(latestHistory.ProcessedAt) {
-					latestHistory = h
-				}
-			}
-
-			// Create audit log entry
-			histID := latestHistory.ID
-			auditLog := domain.NewAuditLog(id, &histID, &adminID, "rejected", &reason)
-			if err := repo.CreateAuditLogCtx(r.Context(), auditLog); err != nil {
-				log.Printf("Failed to create audit log for venue rejection: %v", err)
-			}
-		}
-
-		// Publish event
-		if eventSink != nil {
-			score := 0
-			if len(history) > 0 {
-				score = history[0].ValidationScore
-			}
-			_ = eventSink.Append(r.Context(), events.VenueRejected{
-				Base:   events.Base{Ts: time.Now(), VID: id, Adm: &reviewer},
-				Reason: reason,
-				Score:  score,
-			})
-		}
-
 		// Always return JSON
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]string{"status": "rejected"})
@@ -539,24 +419,48 @@ func VenueDetailHandler(db *database.DB, draftStore *drafts.DraftStore) http.Han
 		tc := trust.NewDefault()
 		assessment := tc.Assess(venue.User, venue.Venue.Location)
 
-		var latestHistory *models.ValidationHistory
-		if len(history) > 0 {
-			idx := 0
-			for i := range history {
-				if history[i].ProcessedAt.After(history[idx].ProcessedAt) {
-					idx = i
-				}
-			}
-			latestHistory = &history[idx]
-		}
+		latestHistory := latestValidation(history)
 
 		var draft *drafts.VenueDraft
 		if draftStore != nil {
-			if d, exists := draftStore.Get(id); exists {
+			d, exists, err := draftStore.Get(r.Context(), id)
+			if err != nil {
+				log.Printf("venue %d: failed to load draft: %v", id, err)
+			} else if exists {
 				draft = d
 			}
 		}
 
+		// Viewing a venue counts as activity on the viewer's claim
+		var claim *models.VenueClaim
+		if claimTimeout > 0 {
+			if err := db.TouchClaimCtx(r.Context(), id, adminID); err != nil {
+				log.Printf("venue %d: failed to refresh claim: %v", id, err)
+			}
+			claims, err := db.GetActiveClaimsCtx(r.Context(), []int64{id})
+			if err != nil {
+				log.Printf("venue %d: failed to load claim: %v", id, err)
+			} else if c, ok := claims[id]; ok {
+				claim = &c
+			}
+		}
+
+		var comments *commentsView
+		if commentsEnabled {
+			list, err := db.ListVenueCommentsCtx(r.Context(), id)
+			if err != nil {
+				log.Printf("venue %d: failed to load comments: %v", id, err)
+			}
+			comments = &commentsView{VenueID: id, Threads: threadComments(list), Count: len(list), CurrentAdminID: adminID}
+		}
+
+		var hold *models.VenueHold
+		if holdsEnabled {
+			if hold, err = db.GetActiveHoldCtx(r.Context(), id); err != nil {
+				log.Printf("venue %d: failed to load hold: %v", id, err)
+			}
+		}
+
 		mergeResult, err := approval.Assemble(approval.MergeInput{
 			Venue:         venue.Venue,
 			User:          venue.User,
@@ -569,6 +473,8 @@ func VenueDetailHandler(db *database.DB, draftStore *drafts.DraftStore) http.Han
 
 		var combined models.CombinedInfo
 		var suggestions *models.AISuggestions
+		var descriptionIssues []approval.DescriptionIssue
+		var styleViolations []approval.StyleViolation
 		if err != nil {
 			log.Printf("combined info warning: failed to assemble venue detail for %d: %v", id, err)
 			// Fallback: no suggested path available when assembler fails
@@ -576,6 +482,8 @@ func VenueDetailHandler(db *database.DB, draftStore *drafts.DraftStore) http.Han
 			suggestions = &models.AISuggestions{}
 		} else {
 			combined = mergeResult.Combined
+			descriptionIssues = mergeResult.DescriptionIssues
+			styleViolations = mergeResult.StyleViolations
 			if mergeResult.AISuggestions != nil {
 				suggestions = mergeResult.AISuggestions
 			} else {
@@ -663,15 +571,25 @@ func VenueDetailHandler(db *database.DB, draftStore *drafts.DraftStore) http.Han
 			AIOutputNotes      string
 			AIOutputRestPretty string
 			AIOutputFullPretty string
+			Explanation        *decision.Explanation
+			WebsiteCheck       *models.WebsiteCheck
+			SocialChecks       []models.SocialCheck
+			Translation        *models.VenueTranslation
+			CategorySuggestion *models.CategorySuggestion
+			VeganStatus        *models.VeganStatusAssessment
+			DuplicateCompare   string // compare page with the venues the AI review says it duplicates
 			// NEW: Classification data for templates
 			VenueTypeLabel      string
 			VeganStatusLabel    string
 			CategoryLabel       string
 			CategoryOptions     []models.CategoryOption
 			CategoryOptionsJSON template.JS
+			HoursGridJSON       template.JS
 			TypeMismatchAlert   bool
 			// Quality suggestions fields
 			DescriptionSuggestion string
+			DescriptionIssues     []approval.DescriptionIssue // Removed from the suggestion before approval writes it
+			StyleViolations       []approval.StyleViolation   // Style rules the description approval writes breaks
 			NameSuggestion        string
 			ClosedDaysSuggestion  string
 			ApprovalHoursNote     string
@@ -691,6 +609,13 @@ func VenueDetailHandler(db *database.DB, draftStore *drafts.DraftStore) http.Han
 			DraftEditorName string
 			DraftUpdatedAt  string
 			CurrentAdminID  int
+			// Hold fields
+			Hold    *models.VenueHold
+			HoldDue bool
+			// Claim fields
+			Claim            *models.VenueClaim
+			ClaimHeartbeatMs int64
+			Comments         *commentsView
 		}{
 			Venue:          *venue,
 			History:        history,
@@ -707,8 +632,11 @@ func VenueDetailHandler(db *database.DB, draftStore *drafts.DraftStore) http.Han
 			CategoryLabel:         combined.Category,
 			CategoryOptions:       categoryOptions,
 			CategoryOptionsJSON:   categoryOptionsJSON,
+			HoursGridJSON:         hoursGridJSON(combined, googleData, venue.Venue.Timezone),
 			TypeMismatchAlert:     combined.TypeMismatch,
 			DescriptionSuggestion: suggestions.DescriptionSuggestion,
+			DescriptionIssues:     descriptionIssues,
+			StyleViolations:       styleViolations,
 			NameSuggestion:        suggestions.NameSuggestion,
 			ClosedDaysSuggestion:  suggestions.ClosedDays,
 			ApprovalHoursNote:     approvalHoursNote,
@@ -724,12 +652,19 @@ func VenueDetailHandler(db *database.DB, draftStore *drafts.DraftStore) http.Han
 			DraftEditorName: draftEditorName,
 			DraftUpdatedAt:  draftUpdatedAt,
 			CurrentAdminID:  adminID,
+			Hold:            hold,
+			HoldDue:         hold != nil && hold.Due(time.Now()),
+			Claim:           claim,
+			// Refresh the claim well within the timeout while the page stays open
+			ClaimHeartbeatMs: (claimTimeout / 3).Milliseconds(),
+			Comments:         comments,
 		}
 
 		// Prepare latest history and AI review fields
 		if latestHistory != nil {
 			data.LatestHist = latestHistory
 			data.AIReviewNote = latestHistory.ValidationNotes
+			data.DuplicateCompare = duplicateCompareURL(id, latestHistory.ValidationNotes)
 			data.AIScore = latestHistory.ValidationScore
 			data.AIScoreFormatted = fmt.Sprintf("%.2f", float64(latestHistory.ValidationScore))
 			if latestHistory.ScoreBreakdown != nil {
@@ -747,7 +682,7 @@ func VenueDetailHandler(db *database.DB, draftStore *drafts.DraftStore) http.Han
 					}
 					if qualityMap, ok := raw["quality"].(map[string]interface{}); ok {
 						if desc, ok := qualityMap["description"].(string); ok && data.DescriptionSuggestion == "" {
-							data.DescriptionSuggestion = desc
+							data.DescriptionSuggestion, data.DescriptionIssues = approval.SanitizeDescription(strings.TrimSpace(desc))
 						}
 						if name, ok := qualityMap["name"].(string); ok && data.NameSuggestion == "" {
 							data.NameSuggestion = name
@@ -767,6 +702,48 @@ func VenueDetailHandler(db *database.DB, draftStore *drafts.DraftStore) http.Han
 							}
 						}
 					}
+					if ex, ok := raw["explanation"]; ok {
+						// Round-trip through JSON to get the typed struct for the template
+						if b, err := json.Marshal(ex); err == nil {
+							var exp decision.Explanation
+							if err := json.Unmarshal(b, &exp); err == nil {
+								data.Explanation = &exp
+							}
+						}
+					}
+					if wc, ok := raw["website_check"]; ok {
+						if b, err := json.Marshal(wc); err == nil {
+							var check models.WebsiteCheck
+							if err := json.Unmarshal(b, &check); err == nil {
+								data.WebsiteCheck = &check
+							}
+						}
+					}
+					if sc, ok := raw["social_check"]; ok {
+						if b, err := json.Marshal(sc); err == nil {
+							var checks []models.SocialCheck
+							if err := json.Unmarshal(b, &checks); err == nil {
+								data.SocialChecks = checks
+							}
+						}
+					}
+					if tr, ok := raw["translation"]; ok {
+						if b, err := json.Marshal(tr); err == nil {
+							var t models.VenueTranslation
+							if err := json.Unmarshal(b, &t); err == nil {
+								data.Translation = &t
+							}
+						}
+					}
+					data.CategorySuggestion = categorySuggestionOf(latestHistory)
+					if vs, ok := raw["vegan_status"]; ok {
+						if b, err := json.Marshal(vs); err == nil {
+							var va models.VeganStatusAssessment
+							if err := json.Unmarshal(b, &va); err == nil {
+								data.VeganStatus = &va
+							}
+						}
+					}
 					if rb, err := json.MarshalIndent(raw, "", "  "); err == nil {
 						data.AIOutputFullPretty = string(rb)
 					}
@@ -781,6 +758,20 @@ func VenueDetailHandler(db *database.DB, draftStore *drafts.DraftStore) http.Han
 	}
 }
 
+// latestValidation returns the most recently processed history row, or nil.
+func latestValidation(history []models.ValidationHistory) *models.ValidationHistory {
+	if len(history) == 0 {
+		return nil
+	}
+	idx := 0
+	for i := range history {
+		if history[i].ProcessedAt.After(history[idx].ProcessedAt) {
+			idx = i
+		}
+	}
+	return &history[idx]
+}
+
 func extractDraftMeta(draft *drafts.VenueDraft) (map[string]interface{}, bool, int, string, string) {
 	if draft == nil {
 		return nil, false, 0, "", ""
@@ -983,6 +974,10 @@ func processBatchApproval(ctx context.Context, repo domain.Repository, cfg *conf
 	if err != nil {
 		return fmt.Errorf("failed to assemble approval data: %w", err)
 	}
+	// Batches cannot acknowledge style violations; such venues are approved one by one
+	if vs := mergeResult.StyleViolations; len(vs) > 0 {
+		return fmt.Errorf("description breaks the style guide (%s); approve it individually", approval.StyleRuleNames(vs))
+	}
 
 	// Build approval data with batch notes
 	notes := fmt.Sprintf("Batch approval by %s", reviewer)
@@ -994,12 +989,7 @@ func processBatchApproval(ctx context.Context, repo domain.Repository, cfg *conf
 	// Build data replacements for audit trail
 	approvalData.Replacements = domain.BuildVenueDataReplacements(&venue, approvalData)
 
-	// Approve venue with data replacement
-	if err := repo.ApproveVenueWithDataReplacement(ctx, approvalData); err != nil {
-		return fmt.Errorf("error approving venue: %v", err)
-	}
-
-	// Create audit log with data replacements
+	// Audit log with data replacements
 	histID := latestHistory.ID
 	var auditLog *domain.VenueValidationAuditLog
 	if approvalData.Replacements != nil && approvalData.Replacements.HasReplacements() {
@@ -1013,19 +1003,28 @@ func processBatchApproval(ctx context.Context, repo domain.Repository, cfg *conf
 	} else {
 		auditLog = domain.NewAuditLog(venueID, &histID, &adminID, "approved", &notes)
 	}
-	if err := repo.CreateAuditLogCtx(ctx, auditLog); err != nil {
-		log.Printf("Failed to create audit log for batch approval venue %d: %v", venueID, err)
-	}
 
-	// Publish event
-	if eventSink != nil {
-		_ = eventSink.Append(ctx, events.VenueApproved{
-			Base:   events.Base{Ts: time.Now(), VID: venueID, Adm: &reviewer},
-			Reason: notes,
-			Score:  latestHistory.ValidationScore,
-		})
+	// Approve venue, audit log and event in one transaction
+	err = commitDecision(ctx, repo, func(dw decisionWriter) error {
+		if err := dw.ApproveVenueWithDataReplacement(ctx, approvalData); err != nil {
+			return err
+		}
+		if err := dw.CreateAuditLogCtx(ctx, auditLog); err != nil {
+			log.Printf("Failed to create audit log for batch approval venue %d: %v", venueID, err)
+		}
+		return nil
+	}, events.VenueApproved{
+		Base:   events.Base{Ts: time.Now(), VID: venueID, Adm: &reviewer},
+		Reason: notes,
+		Score:  latestHistory.ValidationScore,
+	})
+	if err != nil {
+		return fmt.Errorf("error approving venue: %v", err)
 	}
 
+	recordCategoryReview(ctx, &venue, &latestHistory, approvalData, adminID)
+	notifySubmitter(repo, venueWithUser, venueID, adminID, "approved", "")
+
 	return nil
 }
 
@@ -1035,69 +1034,52 @@ func processBatchRejection(ctx context.Context, repo domain.Repository, venueID
 	// Format rejection reason
 	fullReason := fmt.Sprintf("Batch rejection by %s: %s", reviewer, reason)
 
-	// Update venue status
-	if err := repo.UpdateVenueStatusCtx(ctx, venueID, -1, fullReason, &reviewer); err != nil {
-		return fmt.Errorf("error updating venue: %v", err)
+	// Latest validation history, for the audit log and event score
+	var latestHistory *models.ValidationHistory
+	if history, err := repo.GetVenueValidationHistoryCtx(ctx, venueID); err == nil && len(history) > 0 {
+		latestHistory = latestOf(history)
 	}
 
-	// Get the validation history ID to create audit log
-	history, err := repo.GetVenueValidationHistoryCtx(ctx, venueID)
-	if err == nil && len(history) > 0 {
-		// Find the most recent history entry
-		latestHistory := history[0]
-		for _, h := range history {
-			if h.ProcessedAt.After(latestHistory.ProcessedAt) {
-				latestHistory = h
-			}
-		}
-
-		// Create audit log entry
-		histID := latestHistory.ID
-		auditLog := domain.NewAuditLog(venueID, &histID, &adminID, "rejected", &fullReason)
-		if err := repo.CreateAuditLogCtx(ctx, auditLog); err != nil {
-			log.Printf("Failed to create audit log for batch rejection venue %d: %v", venueID, err)
-		}
-
-		// Publish event
-		if eventSink != nil {
-			_ = eventSink.Append(ctx, events.VenueRejected{
-				Base:   events.Base{Ts: time.Now(), VID: venueID, Adm: &reviewer},
-				Reason: fullReason,
-				Score:  latestHistory.ValidationScore,
-			})
-		}
+	// Update venue status, audit log and event in one transaction
+	err := commitDecision(ctx, repo, func(dw decisionWriter) error {
+		return writeRejection(ctx, dw, venueID, adminID, reviewer, fullReason, latestHistory)
+	}, rejectedEvent(venueID, reviewer, fullReason, latestHistory))
+	if err != nil {
+		return fmt.Errorf("error updating venue: %v", err)
 	}
 
+	notifySubmitter(repo, nil, venueID, adminID, "rejected", reason)
+
 	return nil
 }
 
 // ValidationHistoryHandler shows comprehensive validation history
 func ValidationHistoryHandler(db *database.DB) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
-		if page < 1 {
-			page = 1
-		}
+		cursor := r.URL.Query().Get("cursor")
 		limit := 100
-		offset := (page - 1) * limit
 
-		// Get validation history with pagination
-		history, total, err := db.GetValidationHistoryPaginatedCtx(r.Context(), limit, offset)
+		// Get validation history, one keyset page at a time
+		history, pages, total, err := db.GetValidationHistoryKeysetCtx(r.Context(), cursor, limit)
+		if errors.Is(err, database.ErrInvalidCursor) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		if err != nil {
 			http.Error(w, fmt.Sprintf("Error fetching history: %v", err), http.StatusInternalServerError)
 			return
 		}
 
 		data := struct {
-			History    []models.ValidationHistory
-			Total      int
-			Page       int
-			TotalPages int
+			History []models.ValidationHistory
+			Total   int
+			Pages   models.PageCursors
+			Paged   bool
 		}{
-			History:    history,
-			Total:      total,
-			Page:       page,
-			TotalPages: (total + limit - 1) / limit,
+			History: history,
+			Total:   total,
+			Pages:   pages,
+			Paged:   cursor != "",
 		}
 
 		if err := ExecuteTemplate(w, "history.tmpl", data); err != nil {
@@ -1108,7 +1090,7 @@ func ValidationHistoryHandler(db *database.DB) http.HandlerFunc {
 }
 
 // AnalyticsHandler provides analytics and reporting
-func AnalyticsHandler(db *database.DB, engine *processor.ProcessingEngine) http.HandlerFunc {
+func AnalyticsHandler(db *database.DB, engine *processor.ProcessingEngine, supers *auth.Superadmins) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Get processing statistics
 		stats := engine.GetStats()
@@ -1131,8 +1113,14 @@ func AnalyticsHandler(db *database.DB, engine *processor.ProcessingEngine) http.
 			VenueStats      *models.VenueStats
 			AutomationRate  float64
 			CostPerVenue    float64
+			StageOrder      []string
+			Circuits        []circuit.Status
+			Superadmin      bool
 		}{
 			ProcessingStats: stats,
+			Circuits:        circuitStatuses(),
+			Superadmin:      supers.Is(r.Context()),
+			StageOrder:      []string{processor.StageGoogle, processor.StageOpenAI, processor.StageQuality, processor.StageDecision, processor.StageDB},
 			VenueStats:      venueStats,
 			AutomationRate:  automationRate,
 			CostPerVenue:    stats.TotalCostUSD / float64(max(stats.TotalJobs, 1)),
@@ -1155,10 +1143,17 @@ func AnalyticsHandler(db *database.DB, engine *processor.ProcessingEngine) http.
 	}
 }
 
-// APIStatsHandler provides real-time statistics via JSON API
-func APIStatsHandler(db *database.DB, engine *processor.ProcessingEngine) http.HandlerFunc {
+// APIStatsHandler provides real-time statistics via JSON API, plus the feature flag states
+// when flags are on.
+func APIStatsHandler(db *database.DB, engine *processor.ProcessingEngine, ff *flags.Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		stats := engine.GetStats()
+		stats := struct {
+			processor.ProcessingStats
+			FeatureFlags []flags.State `json:",omitempty"`
+		}{ProcessingStats: engine.GetStats()}
+		if ff != nil {
+			stats.FeatureFlags = ff.States()
+		}
 
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(stats)
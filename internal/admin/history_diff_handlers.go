@@ -0,0 +1,277 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+
+	"github.com/gorilla/mux"
+)
+
+// historyRun is one side of a validation history diff.
+type historyRun struct {
+	ID            int64     `json:"id"`
+	ProcessedAt   time.Time `json:"processed_at"`
+	Score         int       `json:"score"`
+	Status        string    `json:"status"`
+	PromptVersion string    `json:"prompt_version,omitempty"`
+	RunID         *int64    `json:"run_id,omitempty"`
+	GoogleFound   bool      `json:"google_found"`
+	GooglePlaceID string    `json:"google_place_id,omitempty"`
+	GoogleName    string    `json:"google_name,omitempty"`
+	Notes         string    `json:"notes"`
+}
+
+// breakdownDelta is one score breakdown component of both runs. From or To is nil when
+// the component is missing from that run, as happens when the prompt changed; Delta then
+// counts the missing side as 0.
+type breakdownDelta struct {
+	Key     string `json:"key"`
+	From    *int   `json:"from"`
+	To      *int   `json:"to"`
+	Delta   int    `json:"delta"`
+	Changed bool   `json:"changed"`
+}
+
+// historyDiff is what changed between two validations of a venue, from the older to the newer.
+type historyDiff struct {
+	VenueID       int64            `json:"venue_id"`
+	From          historyRun       `json:"from"`
+	To            historyRun       `json:"to"`
+	ScoreDelta    int              `json:"score_delta"`
+	StatusChanged bool             `json:"status_changed"`
+	PromptChanged bool             `json:"prompt_changed"`
+	GoogleChanged bool             `json:"google_changed"`
+	NotesChanged  bool             `json:"notes_changed"`
+	Breakdown     []breakdownDelta `json:"breakdown"`
+	Summary       []string         `json:"summary"` // the changes in words, most decisive first
+}
+
+func newHistoryRun(h models.ValidationHistory) historyRun {
+	run := historyRun{
+		ID:            h.ID,
+		ProcessedAt:   h.ProcessedAt,
+		Score:         h.ValidationScore,
+		Status:        h.ValidationStatus,
+		PromptVersion: strOr(h.PromptVersion),
+		RunID:         h.RunID,
+		GoogleFound:   h.GooglePlaceFound,
+		GooglePlaceID: strOr(h.GooglePlaceID),
+		Notes:         h.ValidationNotes,
+	}
+	if h.GooglePlaceData != nil {
+		run.GoogleName = h.GooglePlaceData.Name
+		if run.GooglePlaceID == "" {
+			run.GooglePlaceID = h.GooglePlaceData.PlaceID
+		}
+		run.GoogleFound = run.GoogleFound || h.GooglePlaceData.PlaceID != ""
+	}
+	return run
+}
+
+// diffHistories compares two validations; from should be the older one.
+func diffHistories(from, to models.ValidationHistory) historyDiff {
+	d := historyDiff{
+		VenueID: to.VenueID,
+		From:    newHistoryRun(from),
+		To:      newHistoryRun(to),
+	}
+	d.ScoreDelta = d.To.Score - d.From.Score
+	d.StatusChanged = d.From.Status != d.To.Status
+	d.PromptChanged = d.From.PromptVersion != d.To.PromptVersion
+	d.GoogleChanged = d.From.GoogleFound != d.To.GoogleFound || d.From.GooglePlaceID != d.To.GooglePlaceID
+	d.NotesChanged = d.From.Notes != d.To.Notes
+
+	keys := map[string]bool{}
+	for k := range from.ScoreBreakdown {
+		keys[k] = true
+	}
+	for k := range to.ScoreBreakdown {
+		keys[k] = true
+	}
+	d.Breakdown = []breakdownDelta{}
+	for k := range keys {
+		bd := breakdownDelta{Key: k}
+		if v, ok := from.ScoreBreakdown[k]; ok {
+			bd.From = &v
+		}
+		if v, ok := to.ScoreBreakdown[k]; ok {
+			bd.To = &v
+		}
+		// A component only one run scored counts as 0 in the other
+		from, to := 0, 0
+		if bd.From != nil {
+			from = *bd.From
+		}
+		if bd.To != nil {
+			to = *bd.To
+		}
+		bd.Delta = to - from
+		bd.Changed = bd.Delta != 0 || (bd.From == nil) != (bd.To == nil)
+		d.Breakdown = append(d.Breakdown, bd)
+	}
+	// Biggest moves first, so the component that flipped the decision leads
+	sort.Slice(d.Breakdown, func(i, j int) bool {
+		ai, aj := absInt(d.Breakdown[i].Delta), absInt(d.Breakdown[j].Delta)
+		if ai != aj {
+			return ai > aj
+		}
+		return d.Breakdown[i].Key < d.Breakdown[j].Key
+	})
+
+	d.Summary = []string{}
+	if d.StatusChanged {
+		d.Summary = append(d.Summary, fmt.Sprintf("Status changed from %s to %s", orNone(d.From.Status), orNone(d.To.Status)))
+	}
+	if d.ScoreDelta != 0 {
+		d.Summary = append(d.Summary, fmt.Sprintf("Score %+d (%d → %d)", d.ScoreDelta, d.From.Score, d.To.Score))
+	}
+	if d.PromptChanged {
+		d.Summary = append(d.Summary, fmt.Sprintf("Prompt version changed from %s to %s", orNone(d.From.PromptVersion), orNone(d.To.PromptVersion)))
+	}
+	switch {
+	case d.From.GoogleFound && !d.To.GoogleFound:
+		d.Summary = append(d.Summary, "Google place no longer found")
+	case !d.From.GoogleFound && d.To.GoogleFound:
+		d.Summary = append(d.Summary, fmt.Sprintf("Google place found: %s", orNone(d.To.GoogleName)))
+	case d.GoogleChanged:
+		d.Summary = append(d.Summary, fmt.Sprintf("Matched a different Google place: %s → %s", orNone(d.From.GoogleName), orNone(d.To.GoogleName)))
+	}
+	for _, bd := range d.Breakdown {
+		switch {
+		case bd.From == nil:
+			d.Summary = append(d.Summary, fmt.Sprintf("%s added (%d)", bd.Key, *bd.To))
+		case bd.To == nil:
+			d.Summary = append(d.Summary, fmt.Sprintf("%s removed (was %d)", bd.Key, *bd.From))
+		case bd.Changed:
+			d.Summary = append(d.Summary, fmt.Sprintf("%s %+d", bd.Key, bd.Delta))
+		}
+	}
+	return d
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// loadHistoryDiff picks the validations to compare from ?from= and ?to= (history IDs) and
+// diffs them. to defaults to the latest validation and from to the one before to. Returns the
+// venue's history, newest first, with the diff, or the status and message of a failure.
+func loadHistoryDiff(r *http.Request, repo domain.HistoryStore) ([]models.ValidationHistory, *historyDiff, int, string) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		return nil, nil, http.StatusBadRequest, "Invalid venue ID"
+	}
+	history, err := repo.GetVenueValidationHistoryCtx(r.Context(), id)
+	if err != nil {
+		return nil, nil, http.StatusInternalServerError, fmt.Sprintf("Failed to load validation history: %v", err)
+	}
+	if len(history) < 2 {
+		return history, nil, http.StatusNotFound, "venue has fewer than two validations to compare"
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		if !history[i].ProcessedAt.Equal(history[j].ProcessedAt) {
+			return history[i].ProcessedAt.After(history[j].ProcessedAt)
+		}
+		return history[i].ID > history[j].ID
+	})
+
+	find := func(param string) (int, error) {
+		s := r.URL.Query().Get(param)
+		if s == "" {
+			return -1, nil
+		}
+		hid, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return -1, fmt.Errorf("invalid %s", param)
+		}
+		for i := range history {
+			if history[i].ID == hid {
+				return i, nil
+			}
+		}
+		return -1, fmt.Errorf("validation %d is not in venue %d's history", hid, id)
+	}
+	to, err := find("to")
+	if err != nil {
+		return history, nil, http.StatusBadRequest, err.Error()
+	}
+	if to < 0 {
+		to = 0
+	}
+	from, err := find("from")
+	if err != nil {
+		return history, nil, http.StatusBadRequest, err.Error()
+	}
+	if from < 0 {
+		from = to + 1
+		if from == len(history) {
+			return history, nil, http.StatusBadRequest, fmt.Sprintf("validation %d is the venue's first; pick a later one", history[to].ID)
+		}
+	}
+	if from == to {
+		return history, nil, http.StatusBadRequest, "from and to must be different validations"
+	}
+	if from < to { // newest first: a smaller index is the newer run
+		from, to = to, from
+	}
+	d := diffHistories(history[from], history[to])
+	d.VenueID = id
+	return history, &d, http.StatusOK, ""
+}
+
+// HistoryDiffHandler handles GET /venues/{id}/history/diff?from=&to=
+func HistoryDiffHandler(repo domain.HistoryStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		history, d, status, msg := loadHistoryDiff(r, repo)
+		if msg != "" && status != http.StatusBadRequest && status != http.StatusNotFound {
+			http.Error(w, msg, status)
+			return
+		}
+		data := struct {
+			VenueID int64
+			History []models.ValidationHistory
+			Diff    *historyDiff
+			Error   string
+		}{History: history, Diff: d, Error: msg}
+		data.VenueID, _ = strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if msg != "" {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.WriteHeader(status)
+		}
+		if err := ExecuteTemplate(w, "history_diff.tmpl", data); err != nil {
+			http.Error(w, fmt.Sprintf("template error: %v", err), http.StatusInternalServerError)
+		}
+	}
+}
+
+// APIHistoryDiffHandler handles GET /api/v1/venues/{id}/history/diff?from=&to=
+// from and to are validation history IDs; to defaults to the latest validation and from to
+// the one before it. Returns score, breakdown, status, prompt version and Google match changes.
+func APIHistoryDiffHandler(repo domain.HistoryStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, d, status, msg := loadHistoryDiff(r, repo)
+		if msg != "" {
+			http.Error(w, msg, status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(d)
+	}
+}
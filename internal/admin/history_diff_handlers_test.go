@@ -0,0 +1,133 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+
+	"github.com/gorilla/mux"
+)
+
+// historyDiffFixture is a venue validated three times: sent to review, re-run with a new
+// prompt that found the Google place and approved it, then re-run again unchanged.
+func historyDiffFixture() []models.ValidationHistory {
+	base := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	v1, v2 := "v1", "v2"
+	place := "ChIJ123"
+	return []models.ValidationHistory{
+		{ID: 12, VenueID: 7, ValidationScore: 88, ValidationStatus: "approved", PromptVersion: &v2, GooglePlaceID: &place, GooglePlaceFound: true,
+			GooglePlaceData: &models.GooglePlaceData{PlaceID: place, Name: "Green Bowl"},
+			ScoreBreakdown:  map[string]int{"venue_legitimacy": 30, "vegan_relevance": 38, "google_match": 20},
+			ProcessedAt:     base.Add(48 * time.Hour)},
+		{ID: 10, VenueID: 7, ValidationScore: 62, ValidationStatus: "manual_review", PromptVersion: &v1,
+			ScoreBreakdown: map[string]int{"venue_legitimacy": 24, "vegan_relevance": 38},
+			ProcessedAt:    base},
+		{ID: 11, VenueID: 7, ValidationScore: 88, ValidationStatus: "approved", PromptVersion: &v2, GooglePlaceID: &place, GooglePlaceFound: true,
+			GooglePlaceData: &models.GooglePlaceData{PlaceID: place, Name: "Green Bowl"},
+			ScoreBreakdown:  map[string]int{"venue_legitimacy": 30, "vegan_relevance": 38, "google_match": 20},
+			ProcessedAt:     base.Add(24 * time.Hour)},
+	}
+}
+
+func TestDiffHistories(t *testing.T) {
+	h := historyDiffFixture()
+	d := diffHistories(h[1], h[2])
+	if d.ScoreDelta != 26 || !d.StatusChanged || !d.PromptChanged || !d.GoogleChanged {
+		t.Fatalf("diff = %+v", d)
+	}
+	if first := d.Breakdown[0]; first.Key != "google_match" || first.From != nil || first.To == nil || !first.Changed {
+		t.Errorf("first breakdown = %+v, want google_match added", first)
+	}
+	if second := d.Breakdown[1]; second.Key != "venue_legitimacy" || second.Delta != 6 {
+		t.Errorf("second breakdown = %+v", second)
+	}
+	want := []string{
+		"Status changed from manual_review to approved",
+		"Score +26 (62 → 88)",
+		"Prompt version changed from v1 to v2",
+		"Google place found: Green Bowl",
+		"google_match added (20)",
+		"venue_legitimacy +6",
+	}
+	if strings.Join(d.Summary, "|") != strings.Join(want, "|") {
+		t.Errorf("summary = %q", d.Summary)
+	}
+
+	if same := diffHistories(h[2], h[0]); len(same.Summary) != 0 || same.StatusChanged {
+		t.Errorf("unchanged re-run diff = %+v", same)
+	}
+}
+
+func getHistoryDiff(h http.HandlerFunc, query string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/venues/7/history/diff"+query, nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "7"})
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	return rec
+}
+
+func TestAPIHistoryDiffHandler(t *testing.T) {
+	repo := &testutil.Repository{
+		GetVenueValidationHistoryCtxFunc: func(context.Context, int64) ([]models.ValidationHistory, error) {
+			return historyDiffFixture(), nil
+		},
+	}
+	h := APIHistoryDiffHandler(repo)
+
+	// Defaults: the latest validation against the one before it
+	rec := getHistoryDiff(h, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var d historyDiff
+	_ = json.Unmarshal(rec.Body.Bytes(), &d)
+	if d.From.ID != 11 || d.To.ID != 12 {
+		t.Errorf("compared %d → %d, want 11 → 12", d.From.ID, d.To.ID)
+	}
+
+	// Passed newest first, still diffed oldest to newest
+	rec = getHistoryDiff(h, "?from=12&to=10")
+	_ = json.Unmarshal(rec.Body.Bytes(), &d)
+	if rec.Code != http.StatusOK || d.From.ID != 10 || d.To.ID != 12 || d.ScoreDelta != 26 {
+		t.Errorf("status %d, diff %d → %d (%+d)", rec.Code, d.From.ID, d.To.ID, d.ScoreDelta)
+	}
+
+	for _, q := range []string{"?to=10", "?from=11&to=11", "?from=99"} {
+		if rec := getHistoryDiff(h, q); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", q, rec.Code)
+		}
+	}
+
+	repo.GetVenueValidationHistoryCtxFunc = func(context.Context, int64) ([]models.ValidationHistory, error) {
+		return historyDiffFixture()[:1], nil
+	}
+	if rec := getHistoryDiff(h, ""); rec.Code != http.StatusNotFound {
+		t.Errorf("single validation: status = %d, want 404", rec.Code)
+	}
+}
+
+func TestHistoryDiffHandler(t *testing.T) {
+	if err := LoadTemplates(os.DirFS("../../web/templates")); err != nil {
+		t.Fatalf("LoadTemplates: %v", err)
+	}
+	repo := &testutil.Repository{
+		GetVenueValidationHistoryCtxFunc: func(context.Context, int64) ([]models.ValidationHistory, error) {
+			return historyDiffFixture(), nil
+		},
+	}
+	rec := getHistoryDiff(HistoryDiffHandler(repo), "?from=10&to=11")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if body := rec.Body.String(); !strings.Contains(body, "Status changed from manual_review to approved") || !strings.Contains(body, "google_match") {
+		t.Fatalf("page missing diff:\n%s", body)
+	}
+}
@@ -0,0 +1,185 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/events"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	maxHoldReasonLen = 500
+	maxHoldDays      = 365
+	holdsPageLimit   = 500
+)
+
+// holdFromForm validates a hold request. remindAt is empty or a YYYY-MM-DD date after today;
+// the hold ends at the start of that day. The returned message is empty on success.
+func holdFromForm(venueID int64, adminID int, reason, remindAt string, now time.Time) (*models.VenueHold, string) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, "A reason is required"
+	}
+	if len(reason) > maxHoldReasonLen {
+		return nil, fmt.Sprintf("Reason must be at most %d characters", maxHoldReasonLen)
+	}
+	h := &models.VenueHold{VenueID: venueID, AdminID: adminID, Reason: reason, CreatedAt: now}
+	if remindAt = strings.TrimSpace(remindAt); remindAt != "" {
+		day, err := time.ParseInLocation("2006-01-02", remindAt, now.Location())
+		if err != nil {
+			return nil, "Reminder date must be YYYY-MM-DD"
+		}
+		if !day.After(now) {
+			return nil, "Reminder date must be after today"
+		}
+		if day.After(now.AddDate(0, 0, maxHoldDays)) {
+			return nil, fmt.Sprintf("Reminder date must be within %d days", maxHoldDays)
+		}
+		h.RemindAt = &day
+	}
+	return h, ""
+}
+
+// PlaceHoldHandler handles POST /venues/{id}/hold
+// Form: reason, remind_at (optional, YYYY-MM-DD). Replaces the venue's current hold.
+func PlaceHoldHandler(repo domain.VenueReader, store domain.HoldStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		writeErr := func(status int, msg string) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]string{"status": "error", "message": msg})
+		}
+
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			writeErr(http.StatusBadRequest, "Invalid venue ID")
+			return
+		}
+		adminID, ok := auth.GetAdminIDFromContext(ctx)
+		if !ok {
+			writeErr(http.StatusForbidden, "Admin ID not found in context")
+			return
+		}
+		hold, msg := holdFromForm(id, adminID, r.FormValue("reason"), r.FormValue("remind_at"), time.Now())
+		if msg != "" {
+			writeErr(http.StatusBadRequest, msg)
+			return
+		}
+		vw, err := repo.GetVenueWithUserByIDCtx(ctx, id)
+		if err != nil || vw == nil {
+			writeErr(http.StatusNotFound, fmt.Sprintf("Venue not found: %v", err))
+			return
+		}
+		if a := vw.Venue.Active; a != nil && *a != 0 {
+			writeErr(http.StatusConflict, "Only pending venues can be put on hold")
+			return
+		}
+		if err := store.PlaceHoldCtx(ctx, hold); err != nil {
+			writeErr(http.StatusInternalServerError, fmt.Sprintf("Error placing hold: %v", err))
+			return
+		}
+
+		reviewer := fmt.Sprintf("admin_%d", adminID)
+		appendEvent(ctx, events.VenueHeld{
+			Base:     events.Base{Ts: hold.CreatedAt, VID: id, Adm: &reviewer},
+			Reason:   hold.Reason,
+			RemindAt: hold.RemindAt,
+		})
+		log.Printf("[holds] venue %d put on hold by %s", id, reviewer)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": "success", "hold": hold})
+	}
+}
+
+// ReleaseHoldHandler handles POST /venues/{id}/hold/release
+func ReleaseHoldHandler(store domain.HoldStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		writeErr := func(status int, msg string) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]string{"status": "error", "message": msg})
+		}
+
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			writeErr(http.StatusBadRequest, "Invalid venue ID")
+			return
+		}
+		adminID, ok := auth.GetAdminIDFromContext(ctx)
+		if !ok {
+			writeErr(http.StatusForbidden, "Admin ID not found in context")
+			return
+		}
+		released, err := store.ReleaseHoldCtx(ctx, id, adminID)
+		if err != nil {
+			writeErr(http.StatusInternalServerError, fmt.Sprintf("Error releasing hold: %v", err))
+			return
+		}
+		if !released {
+			writeErr(http.StatusNotFound, "Venue is not on hold")
+			return
+		}
+
+		reviewer := fmt.Sprintf("admin_%d", adminID)
+		appendEvent(ctx, events.VenueHoldReleased{Base: events.Base{Ts: time.Now(), VID: id, Adm: &reviewer}})
+		log.Printf("[holds] venue %d hold released by %s", id, reviewer)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+	}
+}
+
+// HoldsHandler handles GET /venues/holds: active holds, earliest reminder first.
+func HoldsHandler(store domain.HoldStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		holds, err := store.ListActiveHoldsCtx(r.Context(), holdsPageLimit)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to load holds: %v", err), http.StatusInternalServerError)
+			return
+		}
+		now := time.Now()
+		due := 0
+		for _, h := range holds {
+			if h.Due(now) {
+				due++
+			}
+		}
+		data := struct {
+			Holds []models.VenueHold
+			Due   int
+			Now   time.Time
+		}{Holds: holds, Due: due, Now: now}
+		if err := ExecuteTemplate(w, "holds.tmpl", data); err != nil {
+			http.Error(w, fmt.Sprintf("template error: %v", err), http.StatusInternalServerError)
+		}
+	}
+}
+
+// APIHoldsHandler handles GET /api/v1/holds
+func APIHoldsHandler(store domain.HoldStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		holds, err := store.ListActiveHoldsCtx(r.Context(), holdsPageLimit)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to load holds: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if holds == nil {
+			holds = []models.VenueHold{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"holds": holds})
+	}
+}
@@ -0,0 +1,102 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestHoldFromForm(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		reason     string
+		remindAt   string
+		wantRemind string
+		wantErr    string
+	}{
+		{"no reminder", " called owner ", "", "", ""},
+		{"reminder", "awaiting photos", "2026-03-17", "2026-03-17", ""},
+		{"missing reason", "  ", "", "", "reason is required"},
+		{"reminder today", "x", "2026-03-10", "", "after today"},
+		{"reminder too far", "x", "2027-06-01", "", "within"},
+		{"bad date", "x", "17/03/2026", "", "YYYY-MM-DD"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, msg := holdFromForm(9, 4, tt.reason, tt.remindAt, now)
+			if tt.wantErr != "" {
+				if !strings.Contains(msg, tt.wantErr) {
+					t.Fatalf("msg = %q, want %q", msg, tt.wantErr)
+				}
+				return
+			}
+			if msg != "" || h.VenueID != 9 || h.AdminID != 4 || h.Reason != strings.TrimSpace(tt.reason) {
+				t.Fatalf("hold = %+v, msg %q", h, msg)
+			}
+			got := ""
+			if h.RemindAt != nil {
+				got = h.RemindAt.Format("2006-01-02")
+			}
+			if got != tt.wantRemind {
+				t.Fatalf("remind_at = %q, want %q", got, tt.wantRemind)
+			}
+		})
+	}
+}
+
+func TestPlaceHoldHandler(t *testing.T) {
+	active := 0
+	approved := 1
+	venues := map[int64]*models.VenueWithUser{
+		1: {Venue: models.Venue{ID: 1, Active: &active}},
+		2: {Venue: models.Venue{ID: 2, Active: &approved}},
+	}
+	repo := &testutil.VenueReader{
+		GetVenueWithUserByIDCtxFunc: func(_ context.Context, id int64) (*models.VenueWithUser, error) {
+			return venues[id], nil
+		},
+	}
+	var saved *models.VenueHold
+	store := &testutil.HoldStore{
+		PlaceHoldCtxFunc: func(_ context.Context, h *models.VenueHold) error {
+			saved = h
+			return nil
+		},
+	}
+	h := PlaceHoldHandler(repo, store)
+	post := func(id, reason string) *httptest.ResponseRecorder {
+		form := url.Values{"reason": {reason}}
+		req := httptest.NewRequest(http.MethodPost, "/venues/"+id+"/hold", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		req = req.WithContext(context.WithValue(req.Context(), auth.AdminIDKey, 5))
+		rec := httptest.NewRecorder()
+		h(rec, req)
+		return rec
+	}
+
+	if rec := post("1", "waiting on owner"); rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	if saved == nil || saved.VenueID != 1 || saved.AdminID != 5 || saved.Reason != "waiting on owner" {
+		t.Fatalf("saved = %+v", saved)
+	}
+	saved = nil
+	if rec := post("2", "waiting on owner"); rec.Code != http.StatusConflict || saved != nil {
+		t.Fatalf("approved venue: status = %d, saved %+v", rec.Code, saved)
+	}
+	if rec := post("1", ""); rec.Code != http.StatusBadRequest || saved != nil {
+		t.Fatalf("no reason: status = %d, saved %+v", rec.Code, saved)
+	}
+}
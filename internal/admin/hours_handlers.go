@@ -0,0 +1,96 @@
+package admin
+
+import (
+	"encoding/json"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+
+	"assisted-venue-approval/internal/approval"
+	"assisted-venue-approval/internal/models"
+)
+
+const maxParseHoursLines = 50
+
+// hoursGrid is what the opening hours editor starts from: the week of ranges, the lines it
+// could not place in it and what approvers should check about the hours.
+type hoursGrid struct {
+	Hours    models.NormalizedHours `json:"hours"`
+	Lines    []string               `json:"lines"` // Hours as stored on approval
+	Unparsed []string               `json:"unparsed"`
+	Timezone string                 `json:"timezone"` // Venue's time zone field, "" if unset
+	Located  string                 `json:"located"`  // Time zone inferred from the venue's location
+	Issues   []approval.HoursIssue  `json:"issues"`
+}
+
+func newHoursGrid(hours models.NormalizedHours, unparsed []string, timezone, located string) hoursGrid {
+	lines := hours.Lines()
+	if lines == nil {
+		lines = []string{}
+	}
+	if unparsed == nil {
+		unparsed = []string{}
+	}
+	issues := approval.CheckHours(hours, timezone, located)
+	if issues == nil {
+		issues = []approval.HoursIssue{}
+	}
+	return hoursGrid{Hours: hours, Lines: lines, Unparsed: unparsed, Timezone: timezone, Located: located, Issues: issues}
+}
+
+// venueHoursGrid builds the editor's starting hours. Hours taken from Google are read from
+// its periods, which keep every range of a day; other hours are parsed from their lines.
+// The hours are checked against the venue's time zone and the one its location suggests.
+func venueHoursGrid(combined models.CombinedInfo, gd *models.GooglePlaceData, timezone *string) hoursGrid {
+	zone := ""
+	if timezone != nil {
+		zone = strings.TrimSpace(*timezone)
+	}
+	located := approval.LocationTimezone(combined.Path, combined.Lat, combined.Lng)
+	if combined.Sources["hours"] == "google" && gd != nil && gd.OpeningHours != nil && len(gd.OpeningHours.Periods) > 0 {
+		return newHoursGrid(models.HoursFromGooglePeriods(gd.OpeningHours.Periods), nil, zone, located)
+	}
+	hours, unparsed := approval.ParseHours(combined.Hours)
+	return newHoursGrid(hours, unparsed, zone, located)
+}
+
+// hoursGridJSON is venueHoursGrid for embedding in the venue page script.
+func hoursGridJSON(combined models.CombinedInfo, gd *models.GooglePlaceData, timezone *string) template.JS {
+	b, err := json.Marshal(venueHoursGrid(combined, gd, timezone))
+	if err != nil {
+		log.Printf("failed to marshal hours grid: %v", err)
+		return template.JS("null")
+	}
+	return template.JS(string(b))
+}
+
+// ParseHoursHandler handles POST /api/v1/hours/parse
+// Body: {"lines": ["Monday: 9:00 AM – 5:00 PM", ...], "timezone": "", "located": ""}. Returns
+// the hours as the editor grid and the stored lines approval would write, with the lines it
+// could not read. The optional venue and location time zones are used to check the hours.
+func ParseHoursHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeErr := func(status int, msg string) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]string{"status": "error", "message": msg})
+		}
+		var body struct {
+			Lines    []string `json:"lines"`
+			Timezone string   `json:"timezone"`
+			Located  string   `json:"located"`
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10)).Decode(&body); err != nil {
+			writeErr(http.StatusBadRequest, "invalid JSON body")
+			return
+		}
+		if len(body.Lines) > maxParseHoursLines {
+			writeErr(http.StatusBadRequest, "too many lines")
+			return
+		}
+		hours, unparsed := approval.ParseHours(body.Lines)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(newHoursGrid(hours, unparsed, strings.TrimSpace(body.Timezone), strings.TrimSpace(body.Located)))
+	}
+}
@@ -0,0 +1,81 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"assisted-venue-approval/internal/approval"
+	"assisted-venue-approval/internal/models"
+)
+
+func TestVenueHoursGrid(t *testing.T) {
+	gd := &models.GooglePlaceData{OpeningHours: &models.GoogleOpeningHours{
+		WeekdayText: []string{"Monday: 9:00 AM – 2:00 PM, 5:00 – 10:00 PM"},
+		Periods: []models.GooglePeriod{
+			{Open: models.GoogleTime{Day: 1, Time: "0900"}, Close: models.GoogleTime{Day: 1, Time: "1400"}},
+			{Open: models.GoogleTime{Day: 1, Time: "1700"}, Close: models.GoogleTime{Day: 1, Time: "2200"}},
+		},
+	}}
+	google := models.CombinedInfo{Hours: gd.OpeningHours.WeekdayText, Sources: map[string]string{"hours": "google"}}
+	if got := strings.Join(venueHoursGrid(google, gd, nil).Lines, ","); got != "Mon-09:00-14:00,Mon-17:00-22:00" {
+		t.Fatalf("google grid lines = %q", got)
+	}
+
+	user := models.CombinedInfo{Hours: []string{"Tue-10:00-18:00", "ask at the door"}, Sources: map[string]string{"hours": "user"}}
+	grid := venueHoursGrid(user, gd, nil)
+	if len(grid.Hours.Tuesday) != 1 || len(grid.Hours.Monday) != 0 || len(grid.Unparsed) != 1 || grid.Unparsed[0] != "ask at the door" {
+		t.Fatalf("user grid = %+v", grid)
+	}
+
+	lat, lng := 52.52, 13.4
+	berlin := models.CombinedInfo{Hours: []string{"Sat-20:00-03:00"}, Path: "europe|germany|berlin", Lat: &lat, Lng: &lng}
+	zone := "America/New_York"
+	grid = venueHoursGrid(berlin, nil, &zone)
+	if grid.Located != "Europe/Berlin" || len(grid.Issues) != 2 ||
+		grid.Issues[0].Flag != approval.HoursFlagTimezoneMismatch || grid.Issues[1].Flag != approval.HoursFlagOvernight {
+		t.Fatalf("berlin grid = %+v", grid)
+	}
+}
+
+func TestParseHoursHandler(t *testing.T) {
+	h := ParseHoursHandler()
+	post := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h(rec, httptest.NewRequest(http.MethodPost, "/api/v1/hours/parse", strings.NewReader(body)))
+		return rec
+	}
+
+	rec := post(`{"lines": ["Mon-Fri: 9am-5pm", "Saturday: Closed", "Sun 10-4"]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var grid hoursGrid
+	if err := json.Unmarshal(rec.Body.Bytes(), &grid); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(grid.Lines) != 5 || grid.Lines[4] != "Fri-09:00-17:00" || len(grid.Hours.Saturday) != 0 {
+		t.Fatalf("lines = %v", grid.Lines)
+	}
+	if len(grid.Unparsed) != 1 || grid.Unparsed[0] != "Sun 10-4" {
+		t.Fatalf("unparsed = %v", grid.Unparsed)
+	}
+
+	rec = post(`{"lines": ["Mon-00:00-24:00"], "timezone": "Nowhere/Town"}`)
+	grid = hoursGrid{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &grid); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(grid.Issues) != 1 || grid.Issues[0].Flag != approval.HoursFlagInvalidTimezone {
+		t.Fatalf("issues = %+v", grid.Issues)
+	}
+
+	if rec := post(`{"lines": "Mon"}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("invalid body: status = %d", rec.Code)
+	}
+	if rec := post(`{"lines": [` + strings.TrimSuffix(strings.Repeat(`"x",`, maxParseHoursLines+1), ",") + `]}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("too many lines: status = %d", rec.Code)
+	}
+}
@@ -0,0 +1,65 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// InstanceLister lists the instances sharing the processing queue. distributed is false when
+// this instance processes on its own.
+type InstanceLister interface {
+	ProcessingInstances(ctx context.Context) (instances []models.ProcessingInstance, distributed bool, err error)
+}
+
+// ProcessingInstancesHandler handles GET /api/v1/processing/instances
+// Lists the replicas pulling from the shared queue with their last heartbeat and claimed jobs.
+func ProcessingInstancesHandler(src InstanceLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		instances, distributed, err := src.ProcessingInstances(r.Context())
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to load instances: %v", err), http.StatusInternalServerError)
+			return
+		}
+		coordination := "local"
+		if distributed {
+			coordination = "db"
+		}
+		if instances == nil {
+			instances = []models.ProcessingInstance{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"coordination": coordination, "instances": instances})
+	}
+}
+
+// ScoringBatchLister lists recent OpenAI Batch API jobs. enabled is false when this instance
+// does not submit batch scoring calls.
+type ScoringBatchLister interface {
+	ScoringBatches(ctx context.Context, limit int) (batches []models.ScoringBatch, enabled bool, err error)
+}
+
+// ScoringBatchesHandler handles GET /api/v1/processing/batches?limit=
+// Lists the batch jobs of mode=batch runs, newest first, with their OpenAI status.
+func ScoringBatchesHandler(src ScoringBatchLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := 50
+		if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 && l <= 500 {
+			limit = l
+		}
+		batches, enabled, err := src.ScoringBatches(r.Context(), limit)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to load batches: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if batches == nil {
+			batches = []models.ScoringBatch{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"enabled": enabled, "batches": batches})
+	}
+}
@@ -0,0 +1,83 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/database"
+)
+
+// APIVenuesHandler handles GET /api/venues?status=pending&search=&path_prefix=&cursor=&limit=50
+// Keyset-paginated, newest venue first; follow "next"/"prev" cursors to move between pages.
+// path_prefix keeps venues whose region path starts with it, e.g. "europe|germany".
+func APIVenuesHandler(db *database.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		status := q.Get("status")
+		switch status {
+		case "", "pending", "approved", "rejected":
+		default:
+			http.Error(w, "status must be pending, approved or rejected", http.StatusBadRequest)
+			return
+		}
+		venues, pages, total, err := db.GetVenuesFilteredKeysetCtx(r.Context(), status, q.Get("search"), strings.TrimSpace(q.Get("path_prefix")), q.Get("cursor"), listLimit(q.Get("limit")))
+		if errors.Is(err, database.ErrInvalidCursor) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(w, fmt.Sprintf("venues error: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if venues == nil {
+			venues = []models.VenueWithUser{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"venues": venues,
+			"total":  total,
+			"next":   pages.Next,
+			"prev":   pages.Prev,
+		})
+	}
+}
+
+// APIHistoryHandler handles GET /api/history?cursor=&limit=100
+// Keyset-paginated validation history, newest first.
+func APIHistoryHandler(db *database.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		history, pages, total, err := db.GetValidationHistoryKeysetCtx(r.Context(), q.Get("cursor"), listLimit(q.Get("limit")))
+		if errors.Is(err, database.ErrInvalidCursor) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(w, fmt.Sprintf("history error: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if history == nil {
+			history = []models.ValidationHistory{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"history": history,
+			"total":   total,
+			"next":    pages.Next,
+			"prev":    pages.Prev,
+		})
+	}
+}
+
+func listLimit(s string) int {
+	limit, _ := strconv.Atoi(s)
+	if limit <= 0 || limit > 500 {
+		return 100
+	}
+	return limit
+}
@@ -0,0 +1,139 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/config"
+)
+
+const (
+	defaultMapLimit = 2000
+	maxMapLimit     = 10000
+)
+
+// VenueMapSource lists the pending venues placed on the review map.
+type VenueMapSource interface {
+	GetPendingVenueLocationsCtx(ctx context.Context, pathPrefix string, limit int) ([]models.VenueMapPoint, error)
+}
+
+// Reasons a venue's coordinates are flagged on the map.
+const (
+	suspectNullIsland = "null_island"  // 0,0: coordinates never set
+	suspectOutOfRange = "out_of_range" // not a valid latitude/longitude
+)
+
+type geoJSONGeometry struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"` // longitude, latitude
+}
+
+type venueMapProperties struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Path     string `json:"path,omitempty"`
+	Location string `json:"location"`
+	Status   string `json:"status"`
+	Score    *int   `json:"score"`
+	AIStatus string `json:"ai_status,omitempty"`
+	Suspect  string `json:"suspect,omitempty"`
+}
+
+type geoJSONFeature struct {
+	Type       string             `json:"type"`
+	Geometry   geoJSONGeometry    `json:"geometry"`
+	Properties venueMapProperties `json:"properties"`
+}
+
+type geoJSONFeatureCollection struct {
+	Type      string           `json:"type"`
+	Features  []geoJSONFeature `json:"features"`
+	Truncated bool             `json:"truncated"` // more pending venues than the limit
+}
+
+// suspectLocation reports why coordinates are obviously wrong, or "" if they look usable.
+func suspectLocation(lat, lng float64) string {
+	switch {
+	case math.IsNaN(lat) || math.IsNaN(lng) || math.Abs(lat) > 90 || math.Abs(lng) > 180:
+		return suspectOutOfRange
+	case math.Abs(lat) < 1e-6 && math.Abs(lng) < 1e-6:
+		return suspectNullIsland
+	}
+	return ""
+}
+
+// venueMapGeoJSON turns map points into a GeoJSON FeatureCollection of points.
+func venueMapGeoJSON(points []models.VenueMapPoint, truncated bool) geoJSONFeatureCollection {
+	fc := geoJSONFeatureCollection{Type: "FeatureCollection", Features: make([]geoJSONFeature, 0, len(points)), Truncated: truncated}
+	for _, p := range points {
+		fc.Features = append(fc.Features, geoJSONFeature{
+			Type:     "Feature",
+			Geometry: geoJSONGeometry{Type: "Point", Coordinates: [2]float64{p.Lng, p.Lat}},
+			Properties: venueMapProperties{
+				ID:       p.VenueID,
+				Name:     p.Name,
+				Path:     p.Path,
+				Location: p.Location,
+				Status:   p.Status,
+				Score:    p.Score,
+				AIStatus: p.AIStatus,
+				Suspect:  suspectLocation(p.Lat, p.Lng),
+			},
+		})
+	}
+	return fc
+}
+
+// VenueMapHandler handles GET /venues/map
+// The page loads its venues from the GeoJSON API.
+func VenueMapHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data := struct {
+			PathPrefix        string
+			ApprovalThreshold int
+		}{
+			PathPrefix:        strings.TrimSpace(r.URL.Query().Get("path_prefix")),
+			ApprovalThreshold: config.Load().ApprovalThreshold,
+		}
+		if err := ExecuteTemplate(w, "venue_map.tmpl", data); err != nil {
+			http.Error(w, fmt.Sprintf("template error: %v", err), http.StatusInternalServerError)
+		}
+	}
+}
+
+// APIVenueMapHandler handles GET /api/v1/venues/map?path_prefix=&limit=2000
+// Returns pending venues with coordinates as a GeoJSON FeatureCollection, newest first.
+// Each point carries the venue's review status, latest AI score and, for coordinates that
+// cannot be right, a "suspect" reason.
+func APIVenueMapHandler(src VenueMapSource) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		limit := defaultMapLimit
+		if s := q.Get("limit"); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil || n <= 0 || n > maxMapLimit {
+				http.Error(w, fmt.Sprintf("limit must be between 1 and %d", maxMapLimit), http.StatusBadRequest)
+				return
+			}
+			limit = n
+		}
+		// One extra row tells whether the map is missing venues
+		points, err := src.GetPendingVenueLocationsCtx(r.Context(), strings.TrimSpace(q.Get("path_prefix")), limit+1)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to load venue locations: %v", err), http.StatusInternalServerError)
+			return
+		}
+		truncated := len(points) > limit
+		if truncated {
+			points = points[:limit]
+		}
+		w.Header().Set("Content-Type", "application/geo+json")
+		_ = json.NewEncoder(w).Encode(venueMapGeoJSON(points, truncated))
+	}
+}
@@ -0,0 +1,118 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"assisted-venue-approval/internal/models"
+)
+
+type fakeMapSource struct {
+	points     []models.VenueMapPoint
+	pathPrefix string
+	limit      int
+}
+
+func (f *fakeMapSource) GetPendingVenueLocationsCtx(_ context.Context, pathPrefix string, limit int) ([]models.VenueMapPoint, error) {
+	f.pathPrefix, f.limit = pathPrefix, limit
+	if len(f.points) > limit {
+		return f.points[:limit], nil
+	}
+	return f.points, nil
+}
+
+func TestSuspectLocation(t *testing.T) {
+	tests := []struct {
+		lat, lng float64
+		want     string
+	}{
+		{52.52, 13.405, ""},
+		{0, 0, suspectNullIsland},
+		{0, 13.4, ""},
+		{91, 10, suspectOutOfRange},
+		{10, -181, suspectOutOfRange},
+	}
+	for _, tt := range tests {
+		if got := suspectLocation(tt.lat, tt.lng); got != tt.want {
+			t.Errorf("suspectLocation(%v, %v) = %q, want %q", tt.lat, tt.lng, got, tt.want)
+		}
+	}
+}
+
+func TestAPIVenueMapHandler(t *testing.T) {
+	score := 72
+	src := &fakeMapSource{points: []models.VenueMapPoint{
+		{VenueID: 3, Name: "Cafe", Path: "europe|germany|berlin", Lat: 52.52, Lng: 13.405, Status: models.MapStatusManualReview, Score: &score, AIStatus: "manual_review"},
+		{VenueID: 2, Name: "Nowhere", Lat: 0, Lng: 0, Status: models.MapStatusNew},
+		{VenueID: 1, Name: "Older", Lat: 1, Lng: 1, Status: models.MapStatusNew},
+	}}
+	h := APIVenueMapHandler(src)
+
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodGet, "/api/v1/venues/map?path_prefix=+europe|germany+&limit=2", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if src.pathPrefix != "europe|germany" || src.limit != 3 {
+		t.Fatalf("queried prefix %q limit %d", src.pathPrefix, src.limit)
+	}
+	var fc struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Geometry struct {
+				Type        string     `json:"type"`
+				Coordinates [2]float64 `json:"coordinates"`
+			} `json:"geometry"`
+			Properties struct {
+				ID      int64  `json:"id"`
+				Score   *int   `json:"score"`
+				Status  string `json:"status"`
+				Suspect string `json:"suspect"`
+			} `json:"properties"`
+		} `json:"features"`
+		Truncated bool `json:"truncated"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &fc); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if fc.Type != "FeatureCollection" || len(fc.Features) != 2 || !fc.Truncated {
+		t.Fatalf("collection = %+v", fc)
+	}
+	first := fc.Features[0]
+	if first.Geometry.Type != "Point" || first.Geometry.Coordinates != [2]float64{13.405, 52.52} {
+		t.Fatalf("geometry = %+v, want [lng, lat]", first.Geometry)
+	}
+	if first.Properties.ID != 3 || first.Properties.Score == nil || *first.Properties.Score != 72 || first.Properties.Suspect != "" {
+		t.Fatalf("first properties = %+v", first.Properties)
+	}
+	if p := fc.Features[1].Properties; p.Score != nil || p.Status != models.MapStatusNew || p.Suspect != suspectNullIsland {
+		t.Fatalf("second properties = %+v", p)
+	}
+
+	for _, limit := range []string{"0", "abc", "10001"} {
+		rec := httptest.NewRecorder()
+		h(rec, httptest.NewRequest(http.MethodGet, "/api/v1/venues/map?limit="+limit, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("limit=%s: status = %d, want 400", limit, rec.Code)
+		}
+	}
+}
+
+func TestVenueMapHandler(t *testing.T) {
+	if err := LoadTemplates(os.DirFS("../../web/templates")); err != nil {
+		t.Fatalf("LoadTemplates: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	VenueMapHandler()(rec, httptest.NewRequest(http.MethodGet, "/venues/map?path_prefix=asia|japan", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if body := rec.Body.String(); !strings.Contains(body, `value="asia|japan"`) || !strings.Contains(body, "api/v1/venues/map") {
+		t.Fatalf("page missing filter or API call:\n%s", body)
+	}
+}
@@ -0,0 +1,62 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// Migrator lists and applies the schema migrations of db_changes.md.
+type Migrator interface {
+	MigrationsCtx(ctx context.Context) ([]models.Migration, error)
+	MigrateCtx(ctx context.Context) ([]models.Migration, error)
+}
+
+// MigrationsHandler handles GET /api/v1/migrations
+// Lists every migration with when it was applied, and how many are pending.
+func MigrationsHandler(m Migrator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		list, err := m.MigrationsCtx(r.Context())
+		if err != nil {
+			WriteError(w, r, "Failed to load migrations", err)
+			return
+		}
+		pending := 0
+		for _, mm := range list {
+			if mm.AppliedAt == nil {
+				pending++
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"migrations": list, "pending": pending})
+	}
+}
+
+// MigrateHandler handles POST /api/v1/migrations/apply
+// Applies the pending migrations in order and returns the ones applied. A failure is
+// answered with the migrations applied before it under "applied".
+func MigrateHandler(m Migrator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// A client giving up must not cut a migration off between its statements
+		applied, err := m.MigrateCtx(context.WithoutCancel(r.Context()))
+		if applied == nil {
+			applied = []models.Migration{}
+		}
+		for _, mm := range applied {
+			log.Printf("applied migration %d (%s)", mm.Version, mm.Name)
+		}
+		if err != nil {
+			status, body := ErrorResponse(r, "Failed to apply migrations", err)
+			body["applied"] = applied
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			_ = json.NewEncoder(w).Encode(body)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"applied": applied})
+	}
+}
@@ -0,0 +1,85 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+type fakeMigrator struct {
+	list    []models.Migration
+	applied []models.Migration
+	err     error
+	ctxErr  error // the context's error when MigrateCtx was called
+}
+
+func (f *fakeMigrator) MigrationsCtx(ctx context.Context) ([]models.Migration, error) {
+	return f.list, nil
+}
+
+func (f *fakeMigrator) MigrateCtx(ctx context.Context) ([]models.Migration, error) {
+	f.ctxErr = ctx.Err()
+	return f.applied, f.err
+}
+
+func TestMigrationsHandler(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	m := &fakeMigrator{list: []models.Migration{{Version: 1, Name: "prompt_version", AppliedAt: &at}, {Version: 37, Name: "fulltext_search"}}}
+
+	rec := httptest.NewRecorder()
+	MigrationsHandler(m)(rec, httptest.NewRequest("GET", "/api/v1/migrations", nil))
+	var resp struct {
+		Migrations []models.Migration `json:"migrations"`
+		Pending    int                `json:"pending"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Pending != 1 || len(resp.Migrations) != 2 || resp.Migrations[1].AppliedAt != nil {
+		t.Fatalf("response = %+v", resp)
+	}
+}
+
+func TestMigrateHandler(t *testing.T) {
+	at := time.Now()
+	m := &fakeMigrator{applied: []models.Migration{{Version: 36, Name: "audit_status_duplicate_rejected", AppliedAt: &at}}}
+
+	// The migration outlives a client that gave up
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec := httptest.NewRecorder()
+	MigrateHandler(m)(rec, httptest.NewRequest("POST", "/api/v1/migrations/apply", nil).WithContext(ctx))
+	if rec.Code != http.StatusOK || m.ctxErr != nil {
+		t.Fatalf("status = %d, ctx err = %v", rec.Code, m.ctxErr)
+	}
+
+	// A failure reports what was applied before it
+	m.err = errs.NewDB("MigrateCtx", "migration 37 (fulltext_search) failed", errors.New("lock wait timeout"))
+	rec = httptest.NewRecorder()
+	MigrateHandler(m)(rec, httptest.NewRequest("POST", "/api/v1/migrations/apply", nil))
+	var resp struct {
+		Message string             `json:"message"`
+		Applied []models.Migration `json:"applied"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusInternalServerError || len(resp.Applied) != 1 || resp.Applied[0].Version != 36 {
+		t.Fatalf("status = %d, response = %+v", rec.Code, resp)
+	}
+
+	// Another replica holding the lock is a conflict
+	m.applied, m.err = nil, errs.NewConflict("MigrateCtx", "another instance is applying migrations", nil)
+	rec = httptest.NewRecorder()
+	MigrateHandler(m)(rec, httptest.NewRequest("POST", "/api/v1/migrations/apply", nil))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want 409", rec.Code)
+	}
+}
@@ -0,0 +1,47 @@
+package admin
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/internal/notify"
+)
+
+// Submitter notifier for admin decisions. Set from main; nil disables emails.
+var notifier *notify.Notifier
+
+func SetNotifier(n *notify.Notifier) { notifier = n }
+
+// notifySubmitter emails the submitting member in the background so SMTP latency
+// never holds up the admin request. vu may be nil; it is then loaded from repo.
+func notifySubmitter(repo domain.VenueReader, vu *models.VenueWithUser, venueID int64, adminID int, status, note string) {
+	if !notifier.Enabled() {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
+		defer cancel()
+		if vu == nil {
+			v, err := repo.GetVenueWithUserByIDCtx(ctx, venueID)
+			if err != nil {
+				log.Printf("notify: load venue %d: %v", venueID, err)
+				return
+			}
+			vu = v
+		}
+		err := notifier.NotifyDecision(ctx, notify.Decision{
+			VenueID: venueID,
+			AdminID: adminID,
+			Status:  status,
+			Note:    note,
+			Venue:   vu.Venue,
+			User:    vu.User,
+		})
+		if err != nil {
+			log.Printf("notify: venue %d %s email failed: %v", venueID, status, err)
+		}
+	}()
+}
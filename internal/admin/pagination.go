@@ -0,0 +1,88 @@
+package admin
+
+import (
+	"html/template"
+	"net/url"
+	"strconv"
+
+	"assisted-venue-approval/internal/models"
+)
+
+func init() {
+	registerTemplateFuncs(template.FuncMap{
+		"pageNumbers": newPageNumbers,
+		"pageCursors": newPageCursors,
+	})
+}
+
+// pageNumbers feeds the "page_numbers" partial: numbered pages of an offset-paged list.
+type pageNumbers struct {
+	Page  int
+	Total int
+	path  string
+	query template.URL
+}
+
+// URL links to page n, keeping the list's filter query.
+func (p pageNumbers) URL(n int) string {
+	u := basePath + p.path + "?page=" + strconv.Itoa(n)
+	if p.query != "" {
+		u += "&" + string(p.query)
+	}
+	return u
+}
+
+// Window lists the page numbers to link: the first and last three pages and the neighbours
+// of the current one.
+func (p pageNumbers) Window() []int {
+	var out []int
+	for i := 1; i <= p.Total; i++ {
+		if i <= 3 || i >= p.Total-2 || (i >= p.Page-1 && i <= p.Page+1) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// pageCursors feeds the "page_cursors" partial: newest/newer/older links of a keyset-paged list.
+type pageCursors struct {
+	Paged bool // not on the first page
+	Prev  string
+	Next  string
+	path  string
+	query url.Values
+}
+
+// URL links to the page starting at cursor; an empty cursor is the first page.
+func (p pageCursors) URL(cursor string) string {
+	q := url.Values{}
+	for k, v := range p.query {
+		q[k] = v
+	}
+	if cursor != "" {
+		q.Set("cursor", cursor)
+	}
+	u := basePath + p.path
+	if enc := q.Encode(); enc != "" {
+		u += "?" + enc
+	}
+	return u
+}
+
+// newPageNumbers is the "pageNumbers" template func: path is relative to basePath and query
+// is appended to every link.
+func newPageNumbers(path string, query template.URL, page, total int) pageNumbers {
+	return pageNumbers{Page: page, Total: total, path: path, query: query}
+}
+
+// newPageCursors is the "pageCursors" template func. kv are query parameter name/value pairs
+// kept on every link; empty values are left out.
+func newPageCursors(path string, paged bool, pages models.PageCursors, kv ...string) pageCursors {
+	q := url.Values{}
+	for i := 0; i+1 < len(kv); i += 2 {
+		if kv[i+1] != "" {
+			q.Set(kv[i], kv[i+1])
+		}
+	}
+	return pageCursors{Paged: paged, Prev: pages.Prev, Next: pages.Next, path: path, query: q}
+}
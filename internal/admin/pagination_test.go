@@ -0,0 +1,75 @@
+package admin
+
+import (
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"assisted-venue-approval/internal/models"
+)
+
+func TestPageNumbers(t *testing.T) {
+	p := newPageNumbers("venues/manual-review", "search=cafe", 6, 12)
+	if got, want := p.Window(), []int{1, 2, 3, 5, 6, 7, 10, 11, 12}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Window() = %v, want %v", got, want)
+	}
+	if got := p.URL(7); got != "/venues/manual-review?page=7&search=cafe" {
+		t.Fatalf("URL(7) = %q", got)
+	}
+	if got := newPageNumbers("editorial-feedback", "", 1, 2).URL(2); got != "/editorial-feedback?page=2" {
+		t.Fatalf("URL(2) = %q", got)
+	}
+}
+
+func TestPageCursors(t *testing.T) {
+	p := newPageCursors("venues/pending", true, models.PageCursors{Next: "n1"}, "search", "a&b", "path_prefix", "")
+	if got := p.URL(""); got != "/venues/pending?search=a%26b" {
+		t.Fatalf("URL(\"\") = %q", got)
+	}
+	if got := p.URL(p.Next); got != "/venues/pending?cursor=n1&search=a%26b" {
+		t.Fatalf("URL(next) = %q", got)
+	}
+}
+
+func TestPaginationPartials(t *testing.T) {
+	if err := LoadTemplates(os.DirFS("../../web/templates")); err != nil {
+		t.Fatalf("LoadTemplates: %v", err)
+	}
+	defer func() { adminTemplates = nil }()
+
+	var b strings.Builder
+	if err := adminTemplates.ExecuteTemplate(&b, "page_cursors", newPageCursors("validation/history", false, models.PageCursors{Next: "n1"})); err != nil {
+		t.Fatal(err)
+	}
+	if out := b.String(); !strings.Contains(out, `href="/validation/history?cursor=n1"`) || strings.Contains(out, "Newest") {
+		t.Fatalf("page_cursors = %s", out)
+	}
+
+	b.Reset()
+	if err := adminTemplates.ExecuteTemplate(&b, "page_numbers", newPageNumbers("venues/manual-review", "search=cafe", 1, 2)); err != nil {
+		t.Fatal(err)
+	}
+	if out := b.String(); !strings.Contains(out, `href="/venues/manual-review?page=2&amp;search=cafe">Next`) || strings.Contains(out, "Previous") {
+		t.Fatalf("page_numbers = %s", out)
+	}
+}
+
+func TestTemplateDirReload(t *testing.T) {
+	if err := LoadTemplates(os.DirFS("../../web/templates")); err != nil {
+		t.Fatalf("LoadTemplates: %v", err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(dir+"/unauthorized.tmpl", []byte(`edited {{.IP}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	SetTemplateDir(dir)
+	defer func() { adminTemplates = nil; SetTemplateDir("") }()
+
+	rec := httptest.NewRecorder()
+	RenderUnauthorized(rec, "10.0.0.1")
+	if got := rec.Body.String(); got != "edited 10.0.0.1" {
+		t.Fatalf("body = %q", got)
+	}
+}
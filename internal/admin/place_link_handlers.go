@@ -0,0 +1,142 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/internal/processor"
+
+	"github.com/gorilla/mux"
+)
+
+const auditStatusPlaceRelinked = "place_relinked"
+
+// PlaceSearcher finds Google places for a reviewer-entered query, ranked against the venue.
+type PlaceSearcher interface {
+	SearchPlaceCandidates(ctx context.Context, venue models.Venue, query string) ([]models.PlaceCandidate, error)
+}
+
+// VenueRevalidator re-runs the validation pipeline for one venue.
+type VenueRevalidator interface {
+	ProcessSingleVenueSync(ctx context.Context, venueWithUser models.VenueWithUser, mode processor.Mode) (*processor.ProcessingResult, error)
+}
+
+// PlaceSearchHandler handles GET /api/venues/{id}/places?q=...
+// Returns Google places matching q (default: the venue's name and location), ranked like
+// the automatic match.
+func PlaceSearchHandler(repo domain.Repository, places PlaceSearcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid venue ID", http.StatusBadRequest)
+			return
+		}
+		venueWithUser, err := repo.GetVenueWithUserByIDCtx(r.Context(), id)
+		if err != nil {
+			WriteError(w, r, "Failed to load venue", err)
+			return
+		}
+		candidates, err := places.SearchPlaceCandidates(r.Context(), venueWithUser.Venue, r.URL.Query().Get("q"))
+		if err != nil {
+			WriteError(w, r, "Google search failed", err)
+			return
+		}
+		if candidates == nil {
+			candidates = []models.PlaceCandidate{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"candidates": candidates})
+	}
+}
+
+// RelinkPlaceHandler handles POST /venues/{id}/google-place
+// Body: {"place_id": "...", "reason": "..."}. Re-scores the venue against the chosen place
+// (score_only, so the venue status is left alone) and records the override in the audit log.
+func RelinkPlaceHandler(repo domain.Repository, eng VenueRevalidator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		writeErr := func(status int, msg string) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]string{"status": "error", "message": msg})
+		}
+
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			writeErr(http.StatusBadRequest, "Invalid venue ID")
+			return
+		}
+		adminID, ok := auth.GetAdminIDFromContext(ctx)
+		if !ok {
+			writeErr(http.StatusForbidden, "Admin ID not found in context")
+			return
+		}
+		var body struct {
+			PlaceID string `json:"place_id"`
+			Reason  string `json:"reason"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeErr(http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+		body.PlaceID = strings.TrimSpace(body.PlaceID)
+		if body.PlaceID == "" {
+			writeErr(http.StatusBadRequest, "place_id is required")
+			return
+		}
+
+		venueWithUser, err := repo.GetVenueWithUserByIDCtx(ctx, id)
+		if err != nil {
+			WriteError(w, r, "Failed to load venue", err)
+			return
+		}
+		previous := "none"
+		if gd, err := repo.GetCachedGooglePlaceDataCtx(ctx, id); err == nil && gd != nil && gd.PlaceID != "" {
+			previous = gd.PlaceID
+		}
+
+		venueWithUser.Venue.GooglePlaceID = body.PlaceID
+		result, err := eng.ProcessSingleVenueSync(ctx, *venueWithUser, processor.ModeScoreOnly)
+		if err == nil && !result.Success {
+			err = result.Error
+			if err == nil {
+				err = fmt.Errorf("processing failed")
+			}
+		}
+		if err != nil {
+			writeErr(http.StatusBadGateway, fmt.Sprintf("Re-scoring against %s failed: %v", body.PlaceID, err))
+			return
+		}
+
+		reason := relinkReason(adminID, previous, body.PlaceID, body.Reason)
+		if err := repo.CreateAuditLogCtx(ctx, domain.NewAuditLog(id, nil, &adminID, auditStatusPlaceRelinked, &reason)); err != nil {
+			// The new history row is already written; the override just goes unrecorded
+			log.Printf("[relink] venue %d: failed to write audit log: %v", id, err)
+		}
+		log.Printf("[relink] venue %d: %s", id, reason)
+
+		resp := map[string]interface{}{"status": "success", "place_id": body.PlaceID, "previous_place_id": previous}
+		if vr := result.ValidationResult; vr != nil {
+			resp["score"] = vr.Score
+			resp["ai_status"] = vr.Status
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func relinkReason(adminID int, from, to, note string) string {
+	reason := fmt.Sprintf("Google place relinked by admin_%d from %s to %s", adminID, from, to)
+	if note = strings.TrimSpace(note); note != "" {
+		reason += ": " + note
+	}
+	return reason
+}
@@ -0,0 +1,87 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/internal/processor"
+	testutil "assisted-venue-approval/internal/testing"
+
+	"github.com/gorilla/mux"
+)
+
+type fakeRevalidator struct {
+	pinned string
+	mode   processor.Mode
+	err    error
+}
+
+func (f *fakeRevalidator) ProcessSingleVenueSync(ctx context.Context, vu models.VenueWithUser, mode processor.Mode) (*processor.ProcessingResult, error) {
+	f.pinned, f.mode = vu.Venue.GooglePlaceID, mode
+	if f.err != nil {
+		return &processor.ProcessingResult{Error: f.err}, nil
+	}
+	return &processor.ProcessingResult{Success: true, ValidationResult: &models.ValidationResult{Score: 88, Status: "manual_review"}}, nil
+}
+
+func TestRelinkPlaceHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		processErr error
+		wantStatus int
+		wantAudit  string
+	}{
+		{"missing place", `{"reason":"x"}`, nil, http.StatusBadRequest, ""},
+		{"relinked", `{"place_id":"new","reason":"wrong branch"}`, nil, http.StatusOK, "Google place relinked by admin_7 from old to new: wrong branch"},
+		{"processing failed", `{"place_id":"new"}`, errors.New("google down"), http.StatusBadGateway, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var audit *domain.VenueValidationAuditLog
+			repo := &testutil.Repository{
+				GetVenueWithUserByIDCtxFunc: func(ctx context.Context, id int64) (*models.VenueWithUser, error) {
+					return &models.VenueWithUser{Venue: models.Venue{ID: id, Name: "Leaf"}}, nil
+				},
+				GetCachedGooglePlaceDataCtxFunc: func(ctx context.Context, id int64) (*models.GooglePlaceData, error) {
+					return &models.GooglePlaceData{PlaceID: "old"}, nil
+				},
+				CreateAuditLogCtxFunc: func(ctx context.Context, l *domain.VenueValidationAuditLog) error {
+					audit = l
+					return nil
+				},
+			}
+			eng := &fakeRevalidator{err: tt.processErr}
+			router := mux.NewRouter()
+			router.HandleFunc("/venues/{id}/google-place", RelinkPlaceHandler(repo, eng)).Methods("POST")
+
+			req := httptest.NewRequest(http.MethodPost, "/venues/5/google-place", strings.NewReader(tt.body))
+			req = req.WithContext(context.WithValue(req.Context(), auth.AdminIDKey, 7))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantAudit == "" {
+				if audit != nil {
+					t.Errorf("unexpected audit log %+v", audit)
+				}
+				return
+			}
+			if eng.pinned != "new" || eng.mode != processor.ModeScoreOnly {
+				t.Errorf("processed with place %q in mode %s", eng.pinned, eng.mode)
+			}
+			if audit == nil || audit.Status != auditStatusPlaceRelinked || audit.Reason == nil || *audit.Reason != tt.wantAudit {
+				t.Fatalf("audit log = %+v", audit)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/domain"
+	errs "assisted-venue-approval/pkg/errors"
+
+	"github.com/gorilla/mux"
+)
+
+func memberIDVar(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, errs.NewValidation("admin.memberIDVar", "invalid member ID", err)
+	}
+	return id, nil
+}
+
+// MemberDataExportHandler handles GET /api/v1/members/{id}/data
+// Downloads everything stored about the member as JSON, for a data subject access request.
+func MemberDataExportHandler(store domain.PrivacyStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := memberIDVar(r)
+		if err != nil {
+			WriteError(w, r, "Invalid member ID", err)
+			return
+		}
+		export, err := store.ExportMemberDataCtx(r.Context(), id)
+		if err != nil {
+			WriteError(w, r, "Failed to export member data", err)
+			return
+		}
+		adminID, _ := auth.GetAdminIDFromContext(r.Context())
+		log.Printf("privacy: member %d data exported by admin %d", id, adminID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="member-%d-data.json"`, id))
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(export)
+	}
+}
+
+// MemberEraseHandler handles POST /api/v1/members/{id}/erase
+// Pseudonymizes the member's data held by this application: feedback IPs on their venues are
+// replaced by a keyed hash and their email is redacted from validation histories. Without
+// an ipKey (PRIVACY_HASH_KEY) the request is refused with 503.
+func MemberEraseHandler(store domain.PrivacyStore, ipKey []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := memberIDVar(r)
+		if err != nil {
+			WriteError(w, r, "Invalid member ID", err)
+			return
+		}
+		if len(ipKey) == 0 {
+			// A deployment gap rather than a server fault: the operator has to set the key
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "error", "message": "PRIVACY_HASH_KEY is not configured"})
+			return
+		}
+		res, err := store.PseudonymizeMemberDataCtx(r.Context(), id, ipKey)
+		if err != nil {
+			WriteError(w, r, "Failed to erase member data", err)
+			return
+		}
+		adminID, _ := auth.GetAdminIDFromContext(r.Context())
+		log.Printf("privacy: member %d pseudonymized by admin %d (%d feedback IPs, %d histories, %d archived)",
+			id, adminID, res.FeedbackIPsHashed, res.HistoriesRedacted, res.ArchivedRedacted)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(res)
+	}
+}
@@ -0,0 +1,91 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+	errs "assisted-venue-approval/pkg/errors"
+
+	"github.com/gorilla/mux"
+)
+
+func TestPrivacyHandlers(t *testing.T) {
+	var gotKey []byte
+	store := &testutil.PrivacyStore{
+		ExportMemberDataCtxFunc: func(ctx context.Context, memberID int64) (*models.MemberDataExport, error) {
+			if memberID != 7 {
+				return nil, errs.NewNotFound("test", fmt.Sprintf("member %d", memberID), nil)
+			}
+			return &models.MemberDataExport{MemberID: 7, Username: "alice", Email: "alice@example.com"}, nil
+		},
+		PseudonymizeMemberDataCtxFunc: func(ctx context.Context, memberID int64, ipKey []byte) (*models.MemberErasure, error) {
+			gotKey = ipKey
+			return &models.MemberErasure{MemberID: memberID, FeedbackIPsHashed: 3, HistoriesRedacted: 2}, nil
+		},
+	}
+	supers := auth.NewSuperadmins([]int{1})
+	router := mux.NewRouter()
+	router.Handle("/api/v1/members/{id}/data", supers.Wrap(MemberDataExportHandler(store))).Methods("GET")
+	router.Handle("/api/v1/members/{id}/erase", supers.Wrap(MemberEraseHandler(store, []byte("k")))).Methods("POST")
+
+	do := func(method, path string, adminID int) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req = req.WithContext(context.WithValue(req.Context(), auth.AdminIDKey, adminID))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		adminID    int
+		wantStatus int
+		wantBody   string
+	}{
+		{"regular admin cannot export", "GET", "/api/v1/members/7/data", 2, http.StatusForbidden, ""},
+		{"export", "GET", "/api/v1/members/7/data", 1, http.StatusOK, `"email": "alice@example.com"`},
+		{"unknown member", "GET", "/api/v1/members/8/data", 1, http.StatusNotFound, `"code":"not_found"`},
+		{"invalid id", "GET", "/api/v1/members/x/data", 1, http.StatusBadRequest, `"code":"validation_failed"`},
+		{"regular admin cannot erase", "POST", "/api/v1/members/7/erase", 2, http.StatusForbidden, ""},
+		{"erase", "POST", "/api/v1/members/7/erase", 1, http.StatusOK, `"feedback_ips_hashed":3`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := do(tt.method, tt.path, tt.adminID)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Fatalf("body = %s, want %s", rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+	if string(gotKey) != "k" {
+		t.Fatalf("ip key = %q, want k", gotKey)
+	}
+	if cd := do("GET", "/api/v1/members/7/data", 1).Header().Get("Content-Disposition"); !strings.Contains(cd, "member-7-data.json") {
+		t.Fatalf("Content-Disposition = %q", cd)
+	}
+	var res models.MemberErasure
+	if err := json.NewDecoder(do("POST", "/api/v1/members/7/erase", 1).Body).Decode(&res); err != nil || res.HistoriesRedacted != 2 {
+		t.Fatalf("erase response = %+v, %v", res, err)
+	}
+
+	// Without PRIVACY_HASH_KEY nothing is erased
+	gotKey = nil
+	rec := httptest.NewRecorder()
+	MemberEraseHandler(store, nil)(rec, mux.SetURLVars(httptest.NewRequest("POST", "/api/v1/members/7/erase", nil), map[string]string{"id": "7"}))
+	if rec.Code != http.StatusServiceUnavailable || gotKey != nil || !strings.Contains(rec.Body.String(), "PRIVACY_HASH_KEY is not configured") {
+		t.Fatalf("erase without a key: status %d, body %s", rec.Code, rec.Body.String())
+	}
+}
@@ -1,217 +1,111 @@
 package admin
 
 import (
-	"encoding/json"
 	"fmt"
 	"html/template"
+	"io"
 	"io/fs"
 	"net/http"
-	"strconv"
+	"os"
 	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/flags"
 )
 
 // adminTemplates holds the parsed templates for the admin UI.
-var adminTemplates *template.Template
+var adminTemplates *templateSet
+
+// templateDir, when set, re-parses templates from disk on every render (TEMPLATE_DIR)
+var templateDir string
 
 // basePath holds the base path for URLs in templates
 var basePath = "/"
 
-// funcMap provides template helper functions used across templates.
-var funcMap = template.FuncMap{
-	"add": func(a, b interface{}) interface{} {
-		switch a := a.(type) {
-		case int:
-			switch b := b.(type) {
-			case int:
-				return a + b
-			case float64:
-				return float64(a) + b
-			}
-		case float64:
-			switch b := b.(type) {
-			case int:
-				return a + float64(b)
-			case float64:
-				return a + b
-			}
-		}
-		return 0
-	},
-	"mul": func(a, b interface{}) interface{} {
-		switch a := a.(type) {
-		case int:
-			switch b := b.(type) {
-			case int:
-				return a * b
-			case float64:
-				return float64(a) * b
-			}
-		case float64:
-			switch b := b.(type) {
-			case int:
-				return a * float64(b)
-			case float64:
-				return a * b
-			}
-		}
-		return 0
-	},
-	"div": func(a, b interface{}) interface{} {
-		switch a := a.(type) {
-		case int:
-			switch b := b.(type) {
-			case int:
-				if b == 0 {
-					return float64(0)
-				}
-				return float64(a) / float64(b)
-			case float64:
-				if b == 0 {
-					return float64(0)
-				}
-				return float64(a) / b
-			}
-		case float64:
-			switch b := b.(type) {
-			case int:
-				if b == 0 {
-					return float64(0)
-				}
-				return a / float64(b)
-			case float64:
-				if b == 0 {
-					return float64(0)
-				}
-				return a / b
-			}
-		}
-		return 0
-	},
-	"seq": func(start, end int) []int {
-		var s []int
-		for i := start; i <= end; i++ {
-			s = append(s, i)
-		}
-		return s
-	},
-	"intVal": func(i interface{}, def int) int {
-		switch v := i.(type) {
-		case *int:
-			if v == nil {
-				return def
-			}
-			return *v
-		case int:
-			return v
-		case int64:
-			return int(v)
-		case *int64:
-			if v == nil {
-				return def
-			}
-			return int(*v)
-		default:
-			return def
-		}
-	},
-	"fmtFloat": func(f *float64) string {
-		if f == nil {
-			return ""
-		}
-		return fmt.Sprintf("%.6f", *f)
-	},
-	"basePath": func() string {
-		return basePath
-	},
-	"formatHourEntry": formatHourEntry,
-	"parseOpenHoursJSON": func(input *string) map[string]interface{} {
-		if input == nil || *input == "" {
-			return nil
-		}
+// submitterRulesEnabled shows the submitter rules page in the navigation
+var submitterRulesEnabled bool
 
-		var parsed struct {
-			OpenHours []string `json:"openhours"`
-			Note      string   `json:"note"`
-		}
+// configPageEnabled shows the runtime config page in the navigation
+var configPageEnabled bool
 
-		if err := json.Unmarshal([]byte(*input), &parsed); err != nil {
-			return nil // fallback to raw display
-		}
+// featureFlags hides UI features whose flag is stored as off; nil when flags are off
+var featureFlags *flags.Service
 
-		// Convert "Mon-09:00-17:00" to "Monday: 9:00 AM - 5:00 PM"
-		formatted := make([]string, len(parsed.OpenHours))
-		for i, h := range parsed.OpenHours {
-			formatted[i] = formatHourEntry(h)
-		}
+// holdsEnabled shows the holds dashboard in the navigation and hold controls on venues
+var holdsEnabled bool
 
-		return map[string]interface{}{
-			"Hours": formatted,
-			"Note":  parsed.Note,
-		}
-	},
-}
+// closuresEnabled lists the closure review queue in the navigation
+var closuresEnabled bool
 
-// formatHourEntry converts "Mon-09:00-17:00" to "Monday: 9:00 AM - 5:00 PM"
-func formatHourEntry(entry string) string {
-	parts := strings.Split(entry, "-")
-	if len(parts) != 3 {
-		return entry // return as-is if format is unexpected
-	}
+// changeRequestsEnabled links Venue Updates in the navigation to the change request queue
+var changeRequestsEnabled bool
 
-	day := expandDay(parts[0])
-	start := formatTime(parts[1])
-	end := formatTime(parts[2])
+// commentsEnabled shows reviewer comment threads on venues
+var commentsEnabled bool
 
-	return fmt.Sprintf("%s: %s - %s", day, start, end)
-}
+// savedFiltersEnabled shows saved filters on the venue lists and applies default views
+var savedFiltersEnabled bool
 
-// expandDay converts day abbreviation to full name
-func expandDay(abbr string) string {
-	days := map[string]string{
-		"Mon": "Monday",
-		"Tue": "Tuesday",
-		"Wed": "Wednesday",
-		"Thu": "Thursday",
-		"Fri": "Friday",
-		"Sat": "Saturday",
-		"Sun": "Sunday",
-	}
-	if full, ok := days[abbr]; ok {
-		return full
-	}
-	return abbr
+// uiPreferencesEnabled lists the preferences page in the navigation
+var uiPreferencesEnabled bool
+
+// searchEnabled lists full-text search in the navigation
+var searchEnabled bool
+
+// claimTimeout is how long a review claim lasts without activity; 0 hides claim controls
+var claimTimeout time.Duration
+
+// templateSet holds the admin templates. Files starting with an underscore hold the layout and
+// partials shared by every page; each page is parsed on its own copy of them, so pages can all
+// fill the layout's "title", "head", "content" and "scripts" blocks.
+type templateSet struct {
+	partials *template.Template
+	pages    map[string]*template.Template
 }
 
-// formatTime converts 24-hour time to 12-hour with AM/PM
-func formatTime(time24 string) string {
-	parts := strings.Split(time24, ":")
-	if len(parts) != 2 {
-		return time24
+// parseTemplates parses the layout and partials of fsys, then each page on top of them.
+func parseTemplates(fsys fs.FS) (*templateSet, error) {
+	partials := template.New("").Funcs(templateFuncs)
+	shared, err := fs.Glob(fsys, "_*.tmpl")
+	if err != nil {
+		return nil, err
 	}
-
-	hour, err := strconv.Atoi(parts[0])
+	if len(shared) > 0 {
+		if partials, err = partials.ParseFS(fsys, shared...); err != nil {
+			return nil, err
+		}
+	}
+	files, err := fs.Glob(fsys, "*.tmpl")
 	if err != nil {
-		return time24
+		return nil, err
 	}
-
-	minute := parts[1]
-	period := "AM"
-
-	if hour == 0 {
-		hour = 12
-	} else if hour == 12 {
-		period = "PM"
-	} else if hour > 12 {
-		hour -= 12
-		period = "PM"
+	set := &templateSet{partials: partials, pages: make(map[string]*template.Template)}
+	for _, name := range files {
+		if strings.HasPrefix(name, "_") {
+			continue
+		}
+		page, err := partials.Clone()
+		if err != nil {
+			return nil, err
+		}
+		if set.pages[name], err = page.ParseFS(fsys, name); err != nil {
+			return nil, err
+		}
 	}
+	return set, nil
+}
 
-	return fmt.Sprintf("%d:%s %s", hour, minute, period)
+// ExecuteTemplate renders the page file name, or a partial defined in the shared files.
+func (s *templateSet) ExecuteTemplate(w io.Writer, name string, data interface{}) error {
+	if page, ok := s.pages[name]; ok {
+		return page.ExecuteTemplate(w, name, data)
+	}
+	return s.partials.ExecuteTemplate(w, name, data)
 }
 
 // LoadTemplates parses all admin templates from the provided filesystem. It should be called at application startup.
 func LoadTemplates(fsys fs.FS) error {
-	t, err := template.New("").Funcs(funcMap).ParseFS(fsys, "*.tmpl")
+	t, err := parseTemplates(fsys)
 	if err != nil {
 		return err
 	}
@@ -219,18 +113,88 @@ func LoadTemplates(fsys fs.FS) error {
 	return nil
 }
 
+// SetTemplateDir re-parses templates from dir on every render, so template edits show up
+// without a rebuild. For development only; empty uses the templates from LoadTemplates.
+func SetTemplateDir(dir string) {
+	templateDir = dir
+}
+
 // SetBasePath sets the base path for URLs in templates.
 func SetBasePath(path string) {
 	basePath = path
 }
 
+// SetSubmitterRulesEnabled lists the submitter rules page in the navigation.
+func SetSubmitterRulesEnabled(enabled bool) {
+	submitterRulesEnabled = enabled
+}
+
+// SetConfigPageEnabled lists the runtime config page in the navigation.
+func SetConfigPageEnabled(enabled bool) {
+	configPageEnabled = enabled
+}
+
+// SetFeatureFlags lists the feature flags page in the navigation and hides UI features whose
+// flag is off for everyone.
+func SetFeatureFlags(ff *flags.Service) {
+	featureFlags = ff
+}
+
+// SetHoldsEnabled shows venue hold controls and lists the holds dashboard in the navigation.
+func SetHoldsEnabled(enabled bool) {
+	holdsEnabled = enabled
+}
+
+// SetClosuresEnabled lists the closure review queue in the navigation.
+func SetClosuresEnabled(enabled bool) {
+	closuresEnabled = enabled
+}
+
+// SetChangeRequestsEnabled links Venue Updates in the navigation to the change request queue.
+func SetChangeRequestsEnabled(enabled bool) {
+	changeRequestsEnabled = enabled
+}
+
+// SetCommentsEnabled shows reviewer comment threads on the venue page.
+func SetCommentsEnabled(enabled bool) {
+	commentsEnabled = enabled
+}
+
+// SetSavedFiltersEnabled shows saved filters on the pending and manual review lists.
+func SetSavedFiltersEnabled(enabled bool) {
+	savedFiltersEnabled = enabled
+}
+
+// SetUIPreferencesEnabled lists the per-admin preferences page in the navigation.
+func SetUIPreferencesEnabled(enabled bool) {
+	uiPreferencesEnabled = enabled
+}
+
+// SetSearchEnabled lists full-text search in the navigation.
+func SetSearchEnabled(enabled bool) {
+	searchEnabled = enabled
+}
+
+// SetClaimTimeout shows review claims on venues; claims lapse after timeout without
+// activity. Zero turns claims off.
+func SetClaimTimeout(timeout time.Duration) {
+	claimTimeout = timeout
+}
+
 // ExecuteTemplate renders a named template to the ResponseWriter.
 func ExecuteTemplate(w http.ResponseWriter, name string, data interface{}) error {
 	if adminTemplates == nil {
 		return fmt.Errorf("templates not loaded: call admin.LoadTemplates at startup")
 	}
+	t := adminTemplates
+	if templateDir != "" {
+		var err error
+		if t, err = parseTemplates(os.DirFS(templateDir)); err != nil {
+			return fmt.Errorf("reload templates from %s: %w", templateDir, err)
+		}
+	}
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	return adminTemplates.ExecuteTemplate(w, name, data)
+	return t.ExecuteTemplate(w, name, data)
 }
 
 // RenderUnauthorized renders the unauthorized access page
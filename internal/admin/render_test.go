@@ -0,0 +1,75 @@
+package admin
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+)
+
+func TestPagesShareLayout(t *testing.T) {
+	fsys := fstest.MapFS{
+		"page.tmpl":  {Data: []byte(`{{template "layout" .}}{{define "title"}}Page{{end}}{{define "content"}}<p>{{.}}</p>{{end}}`)},
+		"other.tmpl": {Data: []byte(`{{template "layout" .}}{{define "title"}}Other{{end}}`)},
+	}
+	for _, name := range []string{"_layout.tmpl", "_nav.tmpl"} {
+		b, err := os.ReadFile("../../web/templates/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fsys[name] = &fstest.MapFile{Data: b}
+	}
+	set, err := parseTemplates(fsys)
+	if err != nil {
+		t.Fatalf("parseTemplates: %v", err)
+	}
+
+	var b strings.Builder
+	if err := set.ExecuteTemplate(&b, "page.tmpl", "hello"); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	if !strings.HasPrefix(out, "<!DOCTYPE html>") || !strings.Contains(out, "<title>Page</title>") ||
+		!strings.Contains(out, `class="layout-nav"`) || !strings.Contains(out, "<p>hello</p>") {
+		t.Fatalf("page.tmpl = %s", out)
+	}
+
+	// Each page fills the blocks on its own copy of the layout
+	b.Reset()
+	if err := set.ExecuteTemplate(&b, "other.tmpl", "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if out := b.String(); !strings.Contains(out, "<title>Other</title>") || strings.Contains(out, "<p>") {
+		t.Fatalf("other.tmpl = %s", out)
+	}
+}
+
+func TestVenueRowPartial(t *testing.T) {
+	if err := LoadTemplates(os.DirFS("../../web/templates")); err != nil {
+		t.Fatalf("LoadTemplates: %v", err)
+	}
+	defer func() { adminTemplates = nil }()
+
+	v := models.VenueWithUser{Venue: models.Venue{ID: 12, Name: "Kale & Co"}, User: models.User{Username: "sam", Trusted: true}}
+	claim := &models.VenueClaim{VenueID: 12, AdminID: 3, ClaimedAt: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)}
+
+	var b strings.Builder
+	if err := adminTemplates.ExecuteTemplate(&b, "venue_row", newVenueRow(v, claim, 3)); err != nil {
+		t.Fatal(err)
+	}
+	if out := b.String(); !strings.Contains(out, `value="12"`) || !strings.Contains(out, "Kale &amp; Co") ||
+		!strings.Contains(out, "👤 You") || !strings.Contains(out, "Trusted") || strings.Contains(out, "Regular") {
+		t.Fatalf("venue_row = %s", out)
+	}
+
+	b.Reset()
+	if err := adminTemplates.ExecuteTemplate(&b, "venue_row", newVenueRow(v, claim, 4)); err != nil {
+		t.Fatal(err)
+	}
+	if out := b.String(); !strings.Contains(out, "👤 #3") {
+		t.Fatalf("venue_row claimed by another admin = %s", out)
+	}
+}
@@ -0,0 +1,78 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/pkg/events"
+
+	"github.com/gorilla/mux"
+)
+
+// EventReplayHandler handles POST /api/v1/events/replay
+// Body: {"projections": ["venue_timeline", ...], "from": "2024-05-01T00:00:00Z"}
+// Both fields are optional: no projections means all of them, no from means the whole store.
+// "from" also accepts a plain date (UTC). Responds 202 with the job to poll.
+func EventReplayHandler(proj *events.Projector) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Projections []string `json:"projections"`
+			From        string   `json:"from"`
+		}
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				http.Error(w, "invalid JSON body", http.StatusBadRequest)
+				return
+			}
+		}
+		from, err := parseReplayFrom(body.From)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		job, err := proj.StartReplay(body.Projections, from)
+		switch {
+		case errors.Is(err, events.ErrUnknownProjection):
+			WriteError(w, r, "Replay not started (have "+strings.Join(proj.Projections(), ", ")+")", err)
+			return
+		case err != nil:
+			WriteError(w, r, "Replay not started", err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(job)
+	}
+}
+
+// EventReplayStatusHandler handles GET /api/v1/events/replay/{id}
+func EventReplayStatusHandler(proj *events.Projector) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		job, ok := proj.ReplayStatus(mux.Vars(r)["id"])
+		if !ok {
+			http.Error(w, "replay job not found", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(job)
+	}
+}
+
+func parseReplayFrom(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid from %q (want RFC3339 or YYYY-MM-DD)", s)
+}
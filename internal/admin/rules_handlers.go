@@ -0,0 +1,100 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"assisted-venue-approval/internal/decision"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/internal/processor"
+	"assisted-venue-approval/pkg/database"
+)
+
+// maxRulesBody caps dry-run uploads; a rules file is a few KB at most.
+const maxRulesBody = 256 << 10
+
+// DecisionRulesHandler handles GET /api/decision/rules
+// Returns the effective decision policy (env thresholds merged with the rules file).
+func DecisionRulesHandler(engine *processor.ProcessingEngine) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(engine.DecisionSummary())
+	}
+}
+
+// DecisionRulesDryRunHandler handles POST /api/decision/rules/dry-run?venue_id=N
+// Body is a candidate rules YAML. Evaluates the venue's latest stored score against
+// both the active and the candidate rules; nothing is persisted.
+func DecisionRulesDryRunHandler(db *database.DB, engine *processor.ProcessingEngine) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, err := strconv.ParseInt(r.URL.Query().Get("venue_id"), 10, 64)
+		if err != nil || id <= 0 {
+			http.Error(w, "invalid venue_id", http.StatusBadRequest)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxRulesBody))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+		candidate, err := decision.ParseRules(body)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "error", "message": err.Error()})
+			return
+		}
+
+		vu, err := db.GetVenueWithUserByIDCtx(ctx, id)
+		if err != nil {
+			WriteError(w, r, "Failed to load venue", err)
+			return
+		}
+		hist, err := db.GetVenueValidationHistoryCtx(ctx, id)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("history error: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if len(hist) == 0 {
+			http.Error(w, "venue has no validation history to evaluate", http.StatusConflict)
+			return
+		}
+
+		// History is ordered newest first
+		h := hist[0]
+		vr := &models.ValidationResult{
+			VenueID:        h.VenueID,
+			Score:          h.ValidationScore,
+			Status:         h.ValidationStatus,
+			Notes:          h.ValidationNotes,
+			ScoreBreakdown: h.ScoreBreakdown,
+		}
+		vu.Venue.ValidationDetails = &models.ValidationDetails{GooglePlaceFound: h.GooglePlaceData != nil}
+
+		current, err := engine.DryRunDecision(ctx, *vu, vr, engine.DecisionRules())
+		if err != nil {
+			http.Error(w, fmt.Sprintf("evaluate current rules: %v", err), http.StatusInternalServerError)
+			return
+		}
+		proposed, err := engine.DryRunDecision(ctx, *vu, vr, candidate)
+		if err != nil {
+			// Candidate thresholds that invert once merged with the engine's are a 400
+			WriteError(w, r, "Failed to evaluate candidate rules", err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"venue_id":   id,
+			"history_id": h.ID,
+			"current":    current,
+			"candidate":  proposed,
+			"changed":    current.FinalStatus != proposed.FinalStatus,
+		})
+	}
+}
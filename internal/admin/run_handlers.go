@@ -0,0 +1,105 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+
+	"github.com/gorilla/mux"
+)
+
+const runsPerPage = 50
+
+// RunProgress reports live outcome counts of runs that are still processing.
+type RunProgress interface {
+	ActiveRun(id int64) (models.ProcessingRun, bool)
+}
+
+// RunsHandler handles GET /runs?page=N
+// Lists processing runs newest first; running runs show their live counts.
+func RunsHandler(repo domain.RunStore, live RunProgress) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < 1 {
+			page = 1
+		}
+		runs, total, err := repo.ListProcessingRunsCtx(r.Context(), runsPerPage, (page-1)*runsPerPage)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to load runs: %v", err), http.StatusInternalServerError)
+			return
+		}
+		for i := range runs {
+			runs[i] = withLiveProgress(runs[i], live)
+		}
+		data := struct {
+			Runs       []models.ProcessingRun
+			Total      int
+			Page       int
+			TotalPages int
+			Now        time.Time
+		}{
+			Runs:       runs,
+			Total:      total,
+			Page:       page,
+			TotalPages: (total + runsPerPage - 1) / runsPerPage,
+			Now:        time.Now(),
+		}
+		if err := ExecuteTemplate(w, "runs.tmpl", data); err != nil {
+			http.Error(w, fmt.Sprintf("template error: %v", err), http.StatusInternalServerError)
+		}
+	}
+}
+
+// RunDetailHandler handles GET /runs/{id}
+// Shows a run's summary and the validation history rows it wrote.
+func RunDetailHandler(repo domain.RunStore, live RunProgress) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid run ID", http.StatusBadRequest)
+			return
+		}
+		run, err := repo.GetProcessingRunCtx(r.Context(), id)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to load run: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if run == nil {
+			http.Error(w, "Run not found", http.StatusNotFound)
+			return
+		}
+		histories, err := repo.GetRunValidationHistoryCtx(r.Context(), id)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to load run results: %v", err), http.StatusInternalServerError)
+			return
+		}
+		data := struct {
+			Run       models.ProcessingRun
+			Histories []models.ValidationHistory
+			Now       time.Time
+		}{
+			Run:       withLiveProgress(*run, live),
+			Histories: histories,
+			Now:       time.Now(),
+		}
+		if err := ExecuteTemplate(w, "run_detail.tmpl", data); err != nil {
+			http.Error(w, fmt.Sprintf("template error: %v", err), http.StatusInternalServerError)
+		}
+	}
+}
+
+// withLiveProgress replaces the stored counts of a running run with the engine's; the row
+// itself is only updated when the run completes.
+func withLiveProgress(run models.ProcessingRun, live RunProgress) models.ProcessingRun {
+	if run.Status != models.RunStatusRunning || live == nil {
+		return run
+	}
+	if active, ok := live.ActiveRun(run.ID); ok {
+		return active
+	}
+	return run
+}
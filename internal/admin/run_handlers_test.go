@@ -0,0 +1,87 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+
+	"github.com/gorilla/mux"
+)
+
+type fakeRunProgress map[int64]models.ProcessingRun
+
+func (f fakeRunProgress) ActiveRun(id int64) (models.ProcessingRun, bool) {
+	run, ok := f[id]
+	return run, ok
+}
+
+func TestRunHandlers(t *testing.T) {
+	if err := LoadTemplates(os.DirFS("../../web/templates")); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { adminTemplates = nil }()
+
+	admin := 7
+	started := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	finished := started.Add(90 * time.Second)
+	done := models.ProcessingRun{ID: 1, TriggeredBy: &admin, Mode: "auto_decide", Filters: `{"source":"pending"}`,
+		VenueCount: 3, Status: models.RunStatusCompleted, StartedAt: started, FinishedAt: &finished,
+		Approved: 2, Failed: 1, EstimatedCostUSD: 0.12, OpenAICostUSD: 0.01}
+	running := models.ProcessingRun{ID: 2, Mode: "score_only", Filters: "{}", VenueCount: 4, Status: models.RunStatusRunning, StartedAt: started}
+	live := running
+	live.ManualReview = 3
+
+	repo := &testutil.Repository{
+		ListProcessingRunsCtxFunc: func(_ context.Context, limit, offset int) ([]models.ProcessingRun, int, error) {
+			return []models.ProcessingRun{running, done}, 2, nil
+		},
+		GetProcessingRunCtxFunc: func(_ context.Context, id int64) (*models.ProcessingRun, error) {
+			if id == 1 {
+				return &done, nil
+			}
+			return nil, nil
+		},
+		GetRunValidationHistoryCtxFunc: func(_ context.Context, runID int64) ([]models.ValidationHistory, error) {
+			return []models.ValidationHistory{{VenueID: 55, VenueName: "Green Leaf", ValidationStatus: "approved", ValidationScore: 91, ProcessedAt: finished}}, nil
+		},
+	}
+	progress := fakeRunProgress{2: live}
+
+	rec := httptest.NewRecorder()
+	RunsHandler(repo, progress)(rec, httptest.NewRequest(http.MethodGet, "/runs", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list status = %d: %s", rec.Code, rec.Body)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{"runs/1", "admin #7", "3 / 3", "3 / 4", "1m30s", "$0.12"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("list missing %q", want)
+		}
+	}
+
+	detail := func(id string) *httptest.ResponseRecorder {
+		req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/runs/"+id, nil), map[string]string{"id": id})
+		rec := httptest.NewRecorder()
+		RunDetailHandler(repo, progress)(rec, req)
+		return rec
+	}
+	rec = detail("1")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("detail status = %d: %s", rec.Code, rec.Body)
+	}
+	for _, want := range []string{"Run #1", "venues/55", "Green Leaf", "pending"} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("detail missing %q", want)
+		}
+	}
+	if rec = detail("9"); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown run status = %d, want 404", rec.Code)
+	}
+}
@@ -0,0 +1,49 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// SandboxReader lists dry-run results; venueID 0 means all venues.
+type SandboxReader interface {
+	GetSandboxResultsCtx(ctx context.Context, venueID int64, limit int) ([]models.ValidationHistory, error)
+}
+
+// SandboxResultsHandler handles GET /api/validate/sandbox?venue_id=&limit=
+// Lists dry-run results so they can be compared with the real validation history. limit
+// defaults to 100; values outside 1-500 fall back to it.
+func SandboxResultsHandler(db SandboxReader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		var venueID int64
+		if v := q.Get("venue_id"); v != "" {
+			id, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || id <= 0 {
+				http.Error(w, "invalid venue_id", http.StatusBadRequest)
+				return
+			}
+			venueID = id
+		}
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		if limit <= 0 || limit > 500 {
+			limit = 100
+		}
+
+		results, err := db.GetSandboxResultsCtx(r.Context(), venueID, limit)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("sandbox error: %v", err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"results": results,
+			"count":   len(results),
+		})
+	}
+}
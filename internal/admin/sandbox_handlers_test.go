@@ -0,0 +1,60 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"assisted-venue-approval/internal/models"
+)
+
+type fakeSandbox struct {
+	venueID int64
+	limit   int
+}
+
+func (f *fakeSandbox) GetSandboxResultsCtx(_ context.Context, venueID int64, limit int) ([]models.ValidationHistory, error) {
+	f.venueID, f.limit = venueID, limit
+	return []models.ValidationHistory{{VenueID: venueID}}, nil
+}
+
+func TestSandboxResultsHandler(t *testing.T) {
+	tests := []struct {
+		query     string
+		status    int
+		wantVenue int64
+		wantLimit int
+	}{
+		{"", http.StatusOK, 0, 100},
+		{"?venue_id=42&limit=20", http.StatusOK, 42, 20},
+		{"?limit=500", http.StatusOK, 0, 500},
+		{"?limit=501", http.StatusOK, 0, 100},
+		{"?limit=-5", http.StatusOK, 0, 100},
+		{"?limit=lots", http.StatusOK, 0, 100},
+		{"?venue_id=0", http.StatusBadRequest, 0, 0},
+		{"?venue_id=abc", http.StatusBadRequest, 0, 0},
+	}
+	for _, tt := range tests {
+		src := &fakeSandbox{}
+		rec := httptest.NewRecorder()
+		SandboxResultsHandler(src)(rec, httptest.NewRequest("GET", "/api/validate/sandbox"+tt.query, nil))
+		if rec.Code != tt.status {
+			t.Errorf("%q: status %d, want %d", tt.query, rec.Code, tt.status)
+			continue
+		}
+		if src.venueID != tt.wantVenue || src.limit != tt.wantLimit {
+			t.Errorf("%q: queried venue %d limit %d, want %d and %d", tt.query, src.venueID, src.limit, tt.wantVenue, tt.wantLimit)
+		}
+		if tt.status != http.StatusOK {
+			continue
+		}
+		var resp struct {
+			Count int `json:"count"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil || resp.Count != 1 {
+			t.Errorf("%q: count %d, err %v", tt.query, resp.Count, err)
+		}
+	}
+}
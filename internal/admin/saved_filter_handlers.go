@@ -0,0 +1,263 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	maxSavedFilterName  = 100
+	maxSavedFilterQuery = 1000
+)
+
+// savedFilterParams are the query parameters a saved filter keeps, by list. Paging is left
+// out so a saved view always opens on its first page.
+var savedFilterParams = map[string][]string{
+	models.SavedFilterPending:      {"search", "path_prefix"},
+	models.SavedFilterManualReview: {"search", "path_prefix", "high_scores_only", "min_score", "max_score", "trusted_only", "my_queue", "category", "sort"},
+}
+
+// savedFilterPaths are the pages of the lists, relative to basePath.
+var savedFilterPaths = map[string]string{
+	models.SavedFilterPending:      "venues/pending",
+	models.SavedFilterManualReview: "venues/manual-review",
+}
+
+// savedFiltersView is the saved filter dropdown shown above a list.
+type savedFiltersView struct {
+	List    string
+	Query   string // the list's current filters
+	Filters []models.SavedFilter
+	Current *models.SavedFilter // the saved filter matching Query, if any
+}
+
+// URL is the list page showing f.
+func (v *savedFiltersView) URL(f models.SavedFilter) string {
+	return listURL(v.List, f.Query)
+}
+
+// filterQuery returns the list's filter parameters set in q, encoded in a stable order so
+// equal filters compare equal.
+func filterQuery(list string, q url.Values) string {
+	out := url.Values{}
+	for _, name := range savedFilterParams[list] {
+		if v := strings.TrimSpace(q.Get(name)); v != "" {
+			out.Set(name, v)
+		}
+	}
+	return out.Encode()
+}
+
+// listURL is the list page showing query. An empty query is sent as view=all so the
+// admin's default view does not replace it.
+func listURL(list, query string) string {
+	if query == "" {
+		query = "view=all"
+	}
+	return basePath + savedFilterPaths[list] + "?" + query
+}
+
+// loadSavedFilters returns the admin's saved filter dropdown for a list, or nil when saved
+// filters are off. A failed lookup only hides the saved filters.
+func loadSavedFilters(r *http.Request, store domain.SavedFilterStore, list, query string) *savedFiltersView {
+	if !savedFiltersEnabled {
+		return nil
+	}
+	view := &savedFiltersView{List: list, Query: query}
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		return view
+	}
+	filters, err := store.ListSavedFiltersCtx(r.Context(), adminID, list)
+	if err != nil {
+		log.Printf("Error fetching saved filters: %v", err)
+		return view
+	}
+	view.Filters = filters
+	for i := range filters {
+		if filters[i].Query == query {
+			view.Current = &filters[i]
+			break
+		}
+	}
+	return view
+}
+
+// redirectToDefaultFilter sends an admin who opens a list without any query to their
+// default view of it, and reports whether it did.
+func redirectToDefaultFilter(w http.ResponseWriter, r *http.Request, store domain.SavedFilterStore, list string) bool {
+	if !savedFiltersEnabled || r.URL.RawQuery != "" {
+		return false
+	}
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		return false
+	}
+	filters, err := store.ListSavedFiltersCtx(r.Context(), adminID, list)
+	if err != nil {
+		log.Printf("Error fetching saved filters: %v", err)
+		return false
+	}
+	for _, f := range filters {
+		if f.IsDefault {
+			http.Redirect(w, r, listURL(list, f.Query), http.StatusSeeOther)
+			return true
+		}
+	}
+	return false
+}
+
+// SaveFilterHandler handles POST /saved-filters
+// Form: list (pending|manual_review), name, query (the list's query string), default.
+// A filter with the same name is replaced. Redirects to the list showing the filter.
+func SaveFilterHandler(store domain.SavedFilterStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		adminID, ok := auth.GetAdminIDFromContext(r.Context())
+		if !ok {
+			http.Error(w, "Admin ID not found in context", http.StatusForbidden)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "invalid form", http.StatusBadRequest)
+			return
+		}
+		list := r.PostFormValue("list")
+		if _, ok := savedFilterParams[list]; !ok {
+			http.Error(w, "list must be pending or manual_review", http.StatusBadRequest)
+			return
+		}
+		name := strings.TrimSpace(r.PostFormValue("name"))
+		if name == "" {
+			http.Error(w, "name is required", http.StatusBadRequest)
+			return
+		}
+		if len([]rune(name)) > maxSavedFilterName {
+			http.Error(w, fmt.Sprintf("name must be at most %d characters", maxSavedFilterName), http.StatusBadRequest)
+			return
+		}
+		q, err := url.ParseQuery(r.PostFormValue("query"))
+		if err != nil {
+			http.Error(w, "invalid query", http.StatusBadRequest)
+			return
+		}
+		query := filterQuery(list, q)
+		if len(query) > maxSavedFilterQuery {
+			http.Error(w, "filters are too long to save", http.StatusBadRequest)
+			return
+		}
+		f := &models.SavedFilter{
+			AdminID:   adminID,
+			List:      list,
+			Name:      name,
+			Query:     query,
+			IsDefault: r.PostFormValue("default") == "true",
+			CreatedAt: time.Now(),
+		}
+		if err := store.SaveFilterCtx(r.Context(), f); err != nil {
+			http.Error(w, fmt.Sprintf("Failed to save filter: %v", err), http.StatusInternalServerError)
+			return
+		}
+		http.Redirect(w, r, listURL(list, f.Query), http.StatusSeeOther)
+	}
+}
+
+// DeleteSavedFilterHandler handles POST /saved-filters/{id}/delete
+// Form: list. Redirects to the list without filters.
+func DeleteSavedFilterHandler(store domain.SavedFilterStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		adminID, ok := auth.GetAdminIDFromContext(r.Context())
+		if !ok {
+			http.Error(w, "Admin ID not found in context", http.StatusForbidden)
+			return
+		}
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			http.Error(w, "invalid saved filter id", http.StatusBadRequest)
+			return
+		}
+		found, err := store.DeleteSavedFilterCtx(r.Context(), adminID, id)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to delete filter: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if !found {
+			http.Error(w, "saved filter not found", http.StatusNotFound)
+			return
+		}
+		list := r.PostFormValue("list")
+		if _, ok := savedFilterPaths[list]; !ok {
+			list = models.SavedFilterManualReview
+		}
+		http.Redirect(w, r, listURL(list, ""), http.StatusSeeOther)
+	}
+}
+
+// DefaultSavedFilterHandler handles POST /saved-filters/{id}/default
+// Form: list. Makes the filter the admin's landing view of the list; id 0 clears it.
+func DefaultSavedFilterHandler(store domain.SavedFilterStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		adminID, ok := auth.GetAdminIDFromContext(r.Context())
+		if !ok {
+			http.Error(w, "Admin ID not found in context", http.StatusForbidden)
+			return
+		}
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil || id < 0 {
+			http.Error(w, "invalid saved filter id", http.StatusBadRequest)
+			return
+		}
+		list := r.PostFormValue("list")
+		if _, ok := savedFilterPaths[list]; !ok {
+			http.Error(w, "list must be pending or manual_review", http.StatusBadRequest)
+			return
+		}
+		found, err := store.SetDefaultSavedFilterCtx(r.Context(), adminID, list, id)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to set default filter: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if !found {
+			http.Error(w, "saved filter not found", http.StatusNotFound)
+			return
+		}
+		http.Redirect(w, r, basePath+savedFilterPaths[list], http.StatusSeeOther)
+	}
+}
+
+// APISavedFiltersHandler handles GET /api/v1/saved-filters?list=manual_review
+func APISavedFiltersHandler(store domain.SavedFilterStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		adminID, ok := auth.GetAdminIDFromContext(r.Context())
+		if !ok {
+			http.Error(w, "Admin ID not found in context", http.StatusForbidden)
+			return
+		}
+		list := r.URL.Query().Get("list")
+		if _, ok := savedFilterParams[list]; !ok {
+			http.Error(w, "list must be pending or manual_review", http.StatusBadRequest)
+			return
+		}
+		filters, err := store.ListSavedFiltersCtx(r.Context(), adminID, list)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to load saved filters: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if filters == nil {
+			filters = []models.SavedFilter{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"filters": filters})
+	}
+}
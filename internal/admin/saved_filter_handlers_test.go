@@ -0,0 +1,149 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+)
+
+func TestFilterQuery(t *testing.T) {
+	q := url.Values{
+		"sort":       {"score_desc"},
+		"search":     {" vegan "},
+		"page":       {"3"},
+		"min_score":  {"60"},
+		"category":   {"0"},
+		"my_queue":   {""},
+		"view":       {"all"},
+		"unexpected": {"x"},
+	}
+	if got, want := filterQuery(models.SavedFilterManualReview, q), "category=0&min_score=60&search=vegan&sort=score_desc"; got != want {
+		t.Fatalf("manual review query = %q, want %q", got, want)
+	}
+	if got, want := filterQuery(models.SavedFilterPending, q), "search=vegan"; got != want {
+		t.Fatalf("pending query = %q, want %q", got, want)
+	}
+	if got := listURL(models.SavedFilterPending, ""); got != basePath+"venues/pending?view=all" {
+		t.Fatalf("empty list URL = %q", got)
+	}
+}
+
+func TestSaveFilterHandler(t *testing.T) {
+	var saved *models.SavedFilter
+	store := &testutil.SavedFilterStore{
+		SaveFilterCtxFunc: func(_ context.Context, f *models.SavedFilter) error {
+			saved = f
+			f.ID = 5
+			return nil
+		},
+	}
+	h := SaveFilterHandler(store)
+	post := func(form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/saved-filters", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req = req.WithContext(context.WithValue(req.Context(), auth.AdminIDKey, 4))
+		rec := httptest.NewRecorder()
+		h(rec, req)
+		return rec
+	}
+
+	rec := post(url.Values{"list": {"manual_review"}, "name": {" Mine "}, "default": {"true"},
+		"query": {"page=2&trusted_only=true&search=cafe&bogus=1"}})
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if saved == nil || saved.AdminID != 4 || saved.Name != "Mine" || !saved.IsDefault ||
+		saved.Query != "search=cafe&trusted_only=true" {
+		t.Fatalf("saved = %+v", saved)
+	}
+	if loc := rec.Header().Get("Location"); loc != basePath+"venues/manual-review?search=cafe&trusted_only=true" {
+		t.Fatalf("Location = %q", loc)
+	}
+
+	saved = nil
+	for _, form := range []url.Values{
+		{"list": {"approved"}, "name": {"x"}},
+		{"list": {"pending"}, "name": {"  "}},
+		{"list": {"pending"}, "name": {strings.Repeat("n", maxSavedFilterName+1)}},
+		{"list": {"pending"}, "name": {"x"}, "query": {"search=" + strings.Repeat("a", maxSavedFilterQuery)}},
+	} {
+		if rec := post(form); rec.Code != http.StatusBadRequest {
+			t.Fatalf("%v: status = %d, want 400", form, rec.Code)
+		}
+	}
+	if saved != nil {
+		t.Fatalf("invalid filter saved: %+v", saved)
+	}
+}
+
+func TestRedirectToDefaultFilter(t *testing.T) {
+	prev := savedFiltersEnabled
+	savedFiltersEnabled = true
+	defer func() { savedFiltersEnabled = prev }()
+
+	store := &testutil.SavedFilterStore{
+		ListSavedFiltersCtxFunc: func(_ context.Context, adminID int, list string) ([]models.SavedFilter, error) {
+			if adminID != 4 || list != models.SavedFilterManualReview {
+				return nil, nil
+			}
+			return []models.SavedFilter{
+				{ID: 1, Name: "A", Query: "sort=score_desc"},
+				{ID: 2, Name: "B", Query: "my_queue=true", IsDefault: true},
+			}, nil
+		},
+	}
+	open := func(target string, adminID int) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req = req.WithContext(context.WithValue(req.Context(), auth.AdminIDKey, adminID))
+		rec := httptest.NewRecorder()
+		if !redirectToDefaultFilter(rec, req, store, models.SavedFilterManualReview) {
+			rec.Code = 0
+		}
+		return rec
+	}
+
+	rec := open("/venues/manual-review", 4)
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != basePath+"venues/manual-review?my_queue=true" {
+		t.Fatalf("default view: status %d, Location %q", rec.Code, rec.Header().Get("Location"))
+	}
+	if rec := open("/venues/manual-review?view=all", 4); rec.Code != 0 {
+		t.Fatalf("explicit view redirected: %d", rec.Code)
+	}
+	if rec := open("/venues/manual-review", 7); rec.Code != 0 {
+		t.Fatalf("admin without default redirected: %d", rec.Code)
+	}
+}
+
+func TestSavedFiltersTemplate(t *testing.T) {
+	if err := LoadTemplates(os.DirFS("../../web/templates")); err != nil {
+		t.Fatalf("LoadTemplates: %v", err)
+	}
+	filters := []models.SavedFilter{
+		{ID: 1, Name: "High scores", Query: "min_score=80&search=caf%C3%A9"},
+		{ID: 2, Name: "Mine", Query: "my_queue=true", IsDefault: true},
+	}
+	view := &savedFiltersView{List: models.SavedFilterManualReview, Query: "my_queue=true", Filters: filters, Current: &filters[1]}
+	rec := httptest.NewRecorder()
+	if err := ExecuteTemplate(rec, "saved_filters", view); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		`value="` + basePath + `venues/manual-review?min_score=80&amp;search=caf%C3%A9"`,
+		`Mine (default)</option>`,
+		`saved-filters/0/default`,
+		`saved-filters/2/delete`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("rendered filters missing %q:\n%s", want, body)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"assisted-venue-approval/internal/models"
+)
+
+const (
+	defaultSearchLimit = 50
+	maxSearchLimit     = 200
+	maxSearchQuery     = 200 // runes
+	searchSnippetRunes = 160
+)
+
+// VenueSearcher runs full-text searches over venues and their validation notes.
+type VenueSearcher interface {
+	SearchVenuesCtx(ctx context.Context, query string, limit int) ([]models.SearchHit, error)
+}
+
+// searchResult is a search hit with the fields it matched in and an excerpt around the first
+// match outside the name and location.
+type searchResult struct {
+	models.SearchHit
+	Status    string   `json:"status"` // pending, approved or rejected
+	MatchedIn []string `json:"matched_in"`
+	Snippet   string   `json:"snippet,omitempty"`
+}
+
+// searchTerms splits q into the lower-cased words full-text search matches on. Words shorter
+// than InnoDB's default minimum token size (3) are not indexed and are left out.
+func searchTerms(q string) []string {
+	var terms []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if utf8.RuneCountInString(w) >= 3 {
+			terms = append(terms, w)
+		}
+	}
+	return terms
+}
+
+// newSearchResult works out where hit matched the terms. MySQL stems nothing and matches whole
+// words, so a substring check finds the fields that contributed to the relevance.
+func newSearchResult(hit models.SearchHit, terms []string) searchResult {
+	res := searchResult{SearchHit: hit, Status: venueStatus(&hit.Active), MatchedIn: []string{}}
+	fields := []struct{ name, text string }{
+		{"name", hit.Name},
+		{"location", hit.Location},
+		{"description", hit.Description},
+		{"additional_info", hit.AdditionalInfo},
+		{"admin_note", hit.AdminNote},
+		{"ai_notes", hit.AINotes},
+	}
+	for _, f := range fields {
+		lower := strings.ToLower(f.text)
+		for _, t := range terms {
+			i := strings.Index(lower, t)
+			if i < 0 {
+				continue
+			}
+			res.MatchedIn = append(res.MatchedIn, f.name)
+			if res.Snippet == "" && f.name != "name" && f.name != "location" {
+				res.Snippet = searchSnippet(f.text, i)
+			}
+			break
+		}
+	}
+	return res
+}
+
+// searchSnippet cuts about searchSnippetRunes runes of text around the byte offset at,
+// marking cut ends with an ellipsis. at comes from the lower-cased text, whose byte length can
+// differ, so it is clamped to a rune boundary of text.
+func searchSnippet(text string, at int) string {
+	at = min(at, len(text))
+	for at > 0 && at < len(text) && !utf8.RuneStart(text[at]) {
+		at--
+	}
+	runes := []rune(text)
+	pos := utf8.RuneCountInString(text[:at])
+	start := pos - searchSnippetRunes/3
+	if start < 0 {
+		start = 0
+	}
+	end := start + searchSnippetRunes
+	if end > len(runes) {
+		end = len(runes)
+	}
+	snippet := strings.Join(strings.Fields(string(runes[start:end])), " ")
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(runes) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+// runSearch reads q and limit from the request and runs the search. Returns the status and
+// message of a failure.
+func runSearch(r *http.Request, src VenueSearcher) (string, []searchResult, int, string) {
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	if q == "" {
+		return "", nil, http.StatusBadRequest, "q is required"
+	}
+	if utf8.RuneCountInString(q) > maxSearchQuery {
+		return q, nil, http.StatusBadRequest, fmt.Sprintf("q must be at most %d characters", maxSearchQuery)
+	}
+	limit := defaultSearchLimit
+	if s := r.URL.Query().Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n <= 0 || n > maxSearchLimit {
+			return q, nil, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxSearchLimit)
+		}
+		limit = n
+	}
+	hits, err := src.SearchVenuesCtx(r.Context(), q, limit)
+	if err != nil {
+		return q, nil, http.StatusInternalServerError, fmt.Sprintf("Search failed: %v", err)
+	}
+	terms := searchTerms(q)
+	results := make([]searchResult, 0, len(hits))
+	for _, h := range hits {
+		results = append(results, newSearchResult(h, terms))
+	}
+	return q, results, http.StatusOK, ""
+}
+
+// SearchHandler handles GET /search?q=
+func SearchHandler(src VenueSearcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data := struct {
+			Query   string
+			Results []searchResult
+			Error   string
+		}{Query: strings.TrimSpace(r.URL.Query().Get("q"))}
+		if data.Query != "" {
+			q, results, status, msg := runSearch(r, src)
+			if status == http.StatusInternalServerError {
+				http.Error(w, msg, status)
+				return
+			}
+			data.Query, data.Results, data.Error = q, results, msg
+		}
+		if err := ExecuteTemplate(w, "search.tmpl", data); err != nil {
+			http.Error(w, fmt.Sprintf("template error: %v", err), http.StatusInternalServerError)
+		}
+	}
+}
+
+// APISearchHandler handles GET /api/v1/search?q=&limit=50
+// Returns venues matching q in their name, location, description, admin note or AI
+// validation notes, most relevant first, with the fields each matched in.
+func APISearchHandler(src VenueSearcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q, results, status, msg := runSearch(r, src)
+		if msg != "" {
+			http.Error(w, msg, status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"query":   q,
+			"count":   len(results),
+			"results": results,
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"assisted-venue-approval/internal/models"
+)
+
+type fakeSearcher struct {
+	query string
+	limit int
+	hits  []models.SearchHit
+}
+
+func (f *fakeSearcher) SearchVenuesCtx(_ context.Context, query string, limit int) ([]models.SearchHit, error) {
+	f.query, f.limit = query, limit
+	return f.hits, nil
+}
+
+func TestSearchTerms(t *testing.T) {
+	got := searchTerms("Tofu-Burger in Café, NY!")
+	want := []string{"tofu", "burger", "café"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("terms = %v, want %v", got, want)
+	}
+}
+
+func TestNewSearchResult(t *testing.T) {
+	hit := models.SearchHit{
+		VenueID:     3,
+		Name:        "Green Bowl",
+		Location:    "Berlin",
+		Description: "Plant-based bowls. " + strings.Repeat("Fresh food daily. ", 20) + "Closed permanently since May.",
+		AINotes:     "Website says the venue is closed permanently.",
+	}
+	res := newSearchResult(hit, searchTerms("closed permanently"))
+	if !reflect.DeepEqual(res.MatchedIn, []string{"description", "ai_notes"}) {
+		t.Errorf("matched in %v", res.MatchedIn)
+	}
+	if res.Status != "pending" {
+		t.Errorf("status = %q", res.Status)
+	}
+	if !strings.HasPrefix(res.Snippet, "…") || !strings.Contains(res.Snippet, "Closed permanently") {
+		t.Errorf("snippet = %q", res.Snippet)
+	}
+}
+
+func TestAPISearchHandler(t *testing.T) {
+	src := &fakeSearcher{hits: []models.SearchHit{{VenueID: 9, Name: "Tofu House", Active: 1, Relevance: 2.5}}}
+	rec := httptest.NewRecorder()
+	APISearchHandler(src)(rec, httptest.NewRequest(http.MethodGet, "/api/v1/search?q=+tofu+&limit=10", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if src.query != "tofu" || src.limit != 10 {
+		t.Errorf("searched %q limit %d", src.query, src.limit)
+	}
+	var body struct {
+		Count   int `json:"count"`
+		Results []struct {
+			VenueID   int64    `json:"venue_id"`
+			Status    string   `json:"status"`
+			MatchedIn []string `json:"matched_in"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Count != 1 || body.Results[0].VenueID != 9 || body.Results[0].Status != "approved" || len(body.Results[0].MatchedIn) != 1 {
+		t.Errorf("body = %+v", body)
+	}
+
+	for _, path := range []string{"/api/v1/search", "/api/v1/search?q=tofu&limit=500"} {
+		rec := httptest.NewRecorder()
+		APISearchHandler(src)(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", path, rec.Code)
+		}
+	}
+}
+
+func TestSearchHandler(t *testing.T) {
+	if err := LoadTemplates(os.DirFS("../../web/templates")); err != nil {
+		t.Fatalf("LoadTemplates: %v", err)
+	}
+	src := &fakeSearcher{hits: []models.SearchHit{{VenueID: 9, Name: "Tofu House", AdminNote: "Owner called about tofu menu"}}}
+	rec := httptest.NewRecorder()
+	SearchHandler(src)(rec, httptest.NewRequest(http.MethodGet, "/search?q=tofu", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if body := rec.Body.String(); !strings.Contains(body, "venues/9") || !strings.Contains(body, "Owner called about tofu menu") {
+		t.Fatalf("page missing result:\n%s", body)
+	}
+}
@@ -0,0 +1,218 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+
+	"github.com/gorilla/mux"
+)
+
+// SubmitterRulesInvalidator drops the engine's cached copy of the submitter rules.
+type SubmitterRulesInvalidator interface {
+	InvalidateSubmitterRules()
+}
+
+type submitterRulesPage struct {
+	Rules   []models.SubmitterRule
+	Error   string
+	Created *models.SubmitterRule
+}
+
+// submitterRuleInput is the create request, from the form or as JSON.
+type submitterRuleInput struct {
+	Kind   string `json:"kind"`
+	Value  string `json:"value"`
+	Action string `json:"action"`
+	Note   string `json:"note"`
+}
+
+// SubmitterRulesHandler handles GET /settings/submitter-rules
+func SubmitterRulesHandler(store domain.SubmitterRuleStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		renderSubmitterRules(w, r, store, submitterRulesPage{})
+	}
+}
+
+// CreateSubmitterRuleHandler handles POST /settings/submitter-rules
+// Form: kind (user|email_domain), value, action (reject|manual_review|always_ava), note.
+func CreateSubmitterRuleHandler(store domain.SubmitterRuleStore, eng SubmitterRulesInvalidator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		adminID, ok := auth.GetAdminIDFromContext(r.Context())
+		if !ok {
+			http.Error(w, "Admin ID not found in context", http.StatusForbidden)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "invalid form", http.StatusBadRequest)
+			return
+		}
+		in := submitterRuleInput{
+			Kind:   r.PostFormValue("kind"),
+			Value:  r.PostFormValue("value"),
+			Action: r.PostFormValue("action"),
+			Note:   r.PostFormValue("note"),
+		}
+		rule, status, msg := createSubmitterRule(r, store, eng, in, adminID)
+		if msg != "" {
+			if status == http.StatusInternalServerError {
+				http.Error(w, msg, status)
+				return
+			}
+			renderSubmitterRules(w, r, store, submitterRulesPage{Error: msg})
+			return
+		}
+		renderSubmitterRules(w, r, store, submitterRulesPage{Created: rule})
+	}
+}
+
+// DeleteSubmitterRuleHandler handles POST /settings/submitter-rules/{id}/delete
+func DeleteSubmitterRuleHandler(store domain.SubmitterRuleStore, eng SubmitterRulesInvalidator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if status, msg := deleteSubmitterRule(r, store, eng); msg != "" {
+			http.Error(w, msg, status)
+			return
+		}
+		http.Redirect(w, r, basePath+"settings/submitter-rules", http.StatusSeeOther)
+	}
+}
+
+// APISubmitterRulesHandler handles GET /api/v1/submitter-rules
+func APISubmitterRulesHandler(store domain.SubmitterRuleStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rules, err := store.ListSubmitterRulesCtx(r.Context())
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to load submitter rules: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if rules == nil {
+			rules = []models.SubmitterRule{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"rules": rules})
+	}
+}
+
+// APICreateSubmitterRuleHandler handles POST /api/v1/submitter-rules
+// Body: {"kind": "email_domain", "value": "example.com", "action": "reject", "note": "..."}.
+func APICreateSubmitterRuleHandler(store domain.SubmitterRuleStore, eng SubmitterRulesInvalidator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		adminID, ok := auth.GetAdminIDFromContext(r.Context())
+		if !ok {
+			http.Error(w, "Admin ID not found in context", http.StatusForbidden)
+			return
+		}
+		var in submitterRuleInput
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4<<10)).Decode(&in); err != nil {
+			http.Error(w, "invalid JSON body", http.StatusBadRequest)
+			return
+		}
+		rule, status, msg := createSubmitterRule(r, store, eng, in, adminID)
+		if msg != "" {
+			http.Error(w, msg, status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(rule)
+	}
+}
+
+// APIDeleteSubmitterRuleHandler handles DELETE /api/v1/submitter-rules/{id}
+func APIDeleteSubmitterRuleHandler(store domain.SubmitterRuleStore, eng SubmitterRulesInvalidator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if status, msg := deleteSubmitterRule(r, store, eng); msg != "" {
+			http.Error(w, msg, status)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// submitterRuleFromInput validates a create request. The returned message is empty on success.
+func submitterRuleFromInput(in submitterRuleInput, adminID int, now time.Time) (*models.SubmitterRule, string) {
+	kind := strings.TrimSpace(in.Kind)
+	if !models.ValidSubmitterRuleKind(kind) {
+		return nil, "kind must be user or email_domain"
+	}
+	action := strings.TrimSpace(in.Action)
+	if !models.ValidSubmitterAction(action) {
+		return nil, "action must be reject, manual_review or always_ava"
+	}
+	value, ok := models.NormalizeSubmitterRuleValue(kind, in.Value)
+	if !ok {
+		if kind == models.SubmitterRuleUser {
+			return nil, "value must be a member ID"
+		}
+		return nil, "value must be an email domain such as example.com"
+	}
+	note := strings.TrimSpace(in.Note)
+	if len(note) > 255 {
+		return nil, "note is limited to 255 characters"
+	}
+	return &models.SubmitterRule{Kind: kind, Value: value, Action: action, Note: note, AdminID: adminID, CreatedAt: now}, ""
+}
+
+func createSubmitterRule(r *http.Request, store domain.SubmitterRuleStore, eng SubmitterRulesInvalidator, in submitterRuleInput, adminID int) (*models.SubmitterRule, int, string) {
+	rule, msg := submitterRuleFromInput(in, adminID, time.Now())
+	if msg != "" {
+		return nil, http.StatusBadRequest, msg
+	}
+	if err := store.CreateSubmitterRuleCtx(r.Context(), rule); err != nil {
+		var ve *errs.ValidationError
+		if errors.As(err, &ve) {
+			return nil, http.StatusConflict, ve.Message()
+		}
+		return nil, http.StatusInternalServerError, fmt.Sprintf("failed to save rule: %v", err)
+	}
+	eng.InvalidateSubmitterRules()
+	log.Printf("admin %d added submitter rule %d: %s %s -> %s", adminID, rule.ID, rule.Kind, rule.Value, rule.Action)
+	return rule, http.StatusCreated, ""
+}
+
+func deleteSubmitterRule(r *http.Request, store domain.SubmitterRuleStore, eng SubmitterRulesInvalidator) (int, string) {
+	adminID, ok := auth.GetAdminIDFromContext(r.Context())
+	if !ok {
+		return http.StatusForbidden, "Admin ID not found in context"
+	}
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		return http.StatusBadRequest, "invalid rule id"
+	}
+	found, err := store.DeleteSubmitterRuleCtx(r.Context(), id)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Sprintf("failed to delete rule: %v", err)
+	}
+	if !found {
+		return http.StatusNotFound, "rule not found"
+	}
+	eng.InvalidateSubmitterRules()
+	log.Printf("admin %d removed submitter rule %d", adminID, id)
+	return http.StatusOK, ""
+}
+
+func renderSubmitterRules(w http.ResponseWriter, r *http.Request, store domain.SubmitterRuleStore, page submitterRulesPage) {
+	rules, err := store.ListSubmitterRulesCtx(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error fetching submitter rules: %v", err), http.StatusInternalServerError)
+		return
+	}
+	page.Rules = rules
+	if page.Error != "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	if err := ExecuteTemplate(w, "submitter_rules.tmpl", page); err != nil {
+		http.Error(w, fmt.Sprintf("template error: %v", err), http.StatusInternalServerError)
+	}
+}
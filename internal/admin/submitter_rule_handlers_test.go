@@ -0,0 +1,89 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+type fakeRulesInvalidator struct{ n int }
+
+func (f *fakeRulesInvalidator) InvalidateSubmitterRules() { f.n++ }
+
+func TestSubmitterRuleFromInput(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		in        submitterRuleInput
+		wantValue string
+		wantErr   string
+	}{
+		{"member id", submitterRuleInput{Kind: "user", Value: " 0042 ", Action: "reject"}, "42", ""},
+		{"domain normalised", submitterRuleInput{Kind: "email_domain", Value: "@Spam.Example.", Action: "manual_review"}, "spam.example", ""},
+		{"bad member id", submitterRuleInput{Kind: "user", Value: "abc", Action: "reject"}, "", "member ID"},
+		{"address instead of domain", submitterRuleInput{Kind: "email_domain", Value: "a@b.com", Action: "reject"}, "", "email domain"},
+		{"unknown kind", submitterRuleInput{Kind: "ip", Value: "1.2.3.4", Action: "reject"}, "", "kind must be"},
+		{"unknown action", submitterRuleInput{Kind: "user", Value: "1", Action: "ban"}, "", "action must be"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, msg := submitterRuleFromInput(tt.in, 3, now)
+			if tt.wantErr != "" {
+				if !strings.Contains(msg, tt.wantErr) {
+					t.Fatalf("msg = %q, want %q", msg, tt.wantErr)
+				}
+				return
+			}
+			if msg != "" || r.Value != tt.wantValue || r.AdminID != 3 {
+				t.Fatalf("rule = %+v, msg %q", r, msg)
+			}
+		})
+	}
+}
+
+func TestAPICreateSubmitterRule(t *testing.T) {
+	var saved *models.SubmitterRule
+	store := &testutil.SubmitterRuleStore{
+		CreateSubmitterRuleCtxFunc: func(_ context.Context, r *models.SubmitterRule) error {
+			if r.Value == "taken.org" {
+				return errs.NewValidation("CreateSubmitterRuleCtx", "a rule for this email_domain already exists", nil)
+			}
+			r.ID = 11
+			saved = r
+			return nil
+		},
+	}
+	inv := &fakeRulesInvalidator{}
+	h := APICreateSubmitterRuleHandler(store, inv)
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/submitter-rules", strings.NewReader(body))
+		req = req.WithContext(context.WithValue(req.Context(), auth.AdminIDKey, 5))
+		rec := httptest.NewRecorder()
+		h(rec, req)
+		return rec
+	}
+
+	if rec := post(`{"kind":"email_domain","value":"Partner.org","action":"always_ava"}`); rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	if saved == nil || saved.Value != "partner.org" || saved.AdminID != 5 || inv.n != 1 {
+		t.Fatalf("saved = %+v, invalidations %d", saved, inv.n)
+	}
+	if rec := post(`{"kind":"email_domain","value":"taken.org","action":"reject"}`); rec.Code != http.StatusConflict {
+		t.Fatalf("duplicate status = %d", rec.Code)
+	}
+	if rec := post(`{"kind":"user","value":"x","action":"reject"}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("invalid status = %d", rec.Code)
+	}
+	if inv.n != 1 {
+		t.Fatalf("failed creates invalidated the cache")
+	}
+}
@@ -0,0 +1,273 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strconv"
+	"strings"
+)
+
+// templateFuncs is the registry of helpers every admin template can call. Each group adds
+// itself with registerTemplateFuncs from an init func, before LoadTemplates parses anything.
+var templateFuncs = template.FuncMap{}
+
+// registerTemplateFuncs adds funcs to the registry. Names are global to all templates, so
+// registering one twice is a programming error.
+func registerTemplateFuncs(funcs template.FuncMap) {
+	for name, fn := range funcs {
+		if _, dup := templateFuncs[name]; dup {
+			panic(fmt.Sprintf("admin: template func %q registered twice", name))
+		}
+		templateFuncs[name] = fn
+	}
+}
+
+func init() {
+	registerTemplateFuncs(mathFuncs)
+	registerTemplateFuncs(formatFuncs)
+	registerTemplateFuncs(navFuncs)
+}
+
+// venuePathPattern is the HTML pattern for a venue region path such as europe|germany|berlin.
+const venuePathPattern = `(?:[0-9]|[A-Za-z]|_|-)+(?:\|(?:[0-9]|[A-Za-z]|_|-)+)*`
+
+// mathFuncs do arithmetic on the mixed int and float64 values handlers pass in.
+var mathFuncs = template.FuncMap{
+	"add": func(a, b interface{}) interface{} {
+		switch a := a.(type) {
+		case int:
+			switch b := b.(type) {
+			case int:
+				return a + b
+			case float64:
+				return float64(a) + b
+			}
+		case float64:
+			switch b := b.(type) {
+			case int:
+				return a + float64(b)
+			case float64:
+				return a + b
+			}
+		}
+		return 0
+	},
+	"mul": func(a, b interface{}) interface{} {
+		switch a := a.(type) {
+		case int:
+			switch b := b.(type) {
+			case int:
+				return a * b
+			case float64:
+				return float64(a) * b
+			}
+		case float64:
+			switch b := b.(type) {
+			case int:
+				return a * float64(b)
+			case float64:
+				return a * b
+			}
+		}
+		return 0
+	},
+	"div": func(a, b interface{}) interface{} {
+		switch a := a.(type) {
+		case int:
+			switch b := b.(type) {
+			case int:
+				if b == 0 {
+					return float64(0)
+				}
+				return float64(a) / float64(b)
+			case float64:
+				if b == 0 {
+					return float64(0)
+				}
+				return float64(a) / b
+			}
+		case float64:
+			switch b := b.(type) {
+			case int:
+				if b == 0 {
+					return float64(0)
+				}
+				return a / float64(b)
+			case float64:
+				if b == 0 {
+					return float64(0)
+				}
+				return a / b
+			}
+		}
+		return 0
+	},
+	"seq": func(start, end int) []int {
+		var s []int
+		for i := start; i <= end; i++ {
+			s = append(s, i)
+		}
+		return s
+	},
+}
+
+// formatFuncs format venue fields for display.
+var formatFuncs = template.FuncMap{
+	"intVal": func(i interface{}, def int) int {
+		switch v := i.(type) {
+		case *int:
+			if v == nil {
+				return def
+			}
+			return *v
+		case int:
+			return v
+		case int64:
+			return int(v)
+		case *int64:
+			if v == nil {
+				return def
+			}
+			return int(*v)
+		default:
+			return def
+		}
+	},
+	"fmtFloat": func(f *float64) string {
+		if f == nil {
+			return ""
+		}
+		return fmt.Sprintf("%.6f", *f)
+	},
+	"fmtMeters": func(f *float64) string {
+		if f == nil {
+			return "N/A"
+		}
+		return fmt.Sprintf("%.0f m", *f)
+	},
+	"formatHourEntry": formatHourEntry,
+	"parseOpenHoursJSON": func(input *string) map[string]interface{} {
+		if input == nil || *input == "" {
+			return nil
+		}
+
+		var parsed struct {
+			OpenHours []string `json:"openhours"`
+			Note      string   `json:"note"`
+		}
+
+		if err := json.Unmarshal([]byte(*input), &parsed); err != nil {
+			return nil // fallback to raw display
+		}
+
+		// Convert "Mon-09:00-17:00" to "Monday: 9:00 AM - 5:00 PM"
+		formatted := make([]string, len(parsed.OpenHours))
+		for i, h := range parsed.OpenHours {
+			formatted[i] = formatHourEntry(h)
+		}
+
+		return map[string]interface{}{
+			"Hours": formatted,
+			"Note":  parsed.Note,
+		}
+	},
+	"pathPattern": func() string {
+		return venuePathPattern
+	},
+}
+
+// navFuncs tell the layout and pages which optional pages and features are on.
+var navFuncs = template.FuncMap{
+	"basePath": func() string {
+		return basePath
+	},
+	"submitterRulesEnabled": func() bool {
+		return submitterRulesEnabled
+	},
+	"configPageEnabled": func() bool {
+		return configPageEnabled
+	},
+	"featureFlagsEnabled": func() bool {
+		return featureFlags != nil
+	},
+	"flagOff": func(name string) bool {
+		return featureFlags.Off(name)
+	},
+	"holdsEnabled": func() bool {
+		return holdsEnabled
+	},
+	"closuresEnabled": func() bool {
+		return closuresEnabled
+	},
+	"changeRequestsEnabled": func() bool {
+		return changeRequestsEnabled
+	},
+	"uiPreferencesEnabled": func() bool {
+		return uiPreferencesEnabled
+	},
+	"searchEnabled": func() bool {
+		return searchEnabled
+	},
+	"claimsEnabled": func() bool {
+		return claimTimeout > 0
+	},
+}
+
+// formatHourEntry converts "Mon-09:00-17:00" to "Monday: 9:00 AM - 5:00 PM"
+func formatHourEntry(entry string) string {
+	parts := strings.Split(entry, "-")
+	if len(parts) != 3 {
+		return entry // return as-is if format is unexpected
+	}
+
+	day := expandDay(parts[0])
+	start := formatTime(parts[1])
+	end := formatTime(parts[2])
+
+	return fmt.Sprintf("%s: %s - %s", day, start, end)
+}
+
+// expandDay converts day abbreviation to full name
+func expandDay(abbr string) string {
+	days := map[string]string{
+		"Mon": "Monday",
+		"Tue": "Tuesday",
+		"Wed": "Wednesday",
+		"Thu": "Thursday",
+		"Fri": "Friday",
+		"Sat": "Saturday",
+		"Sun": "Sunday",
+	}
+	if full, ok := days[abbr]; ok {
+		return full
+	}
+	return abbr
+}
+
+// formatTime converts 24-hour time to 12-hour with AM/PM
+func formatTime(time24 string) string {
+	parts := strings.Split(time24, ":")
+	if len(parts) != 2 {
+		return time24
+	}
+
+	hour, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return time24
+	}
+
+	minute := parts[1]
+	period := "AM"
+
+	if hour == 0 {
+		hour = 12
+	} else if hour == 12 {
+		period = "PM"
+	} else if hour > 12 {
+		hour -= 12
+		period = "PM"
+	}
+
+	return fmt.Sprintf("%d:%s %s", hour, minute, period)
+}
@@ -0,0 +1,154 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/database"
+	"assisted-venue-approval/pkg/events"
+
+	"github.com/gorilla/mux"
+)
+
+// TimelineItem is one line of a venue's merged audit timeline.
+type TimelineItem struct {
+	Ts      time.Time `json:"ts"`
+	Kind    string    `json:"kind"` // event, validation, audit, feedback
+	Type    string    `json:"type"`
+	Actor   string    `json:"actor,omitempty"`
+	Summary string    `json:"summary"`
+	Score   *int      `json:"score,omitempty"`
+	RefID   int64     `json:"ref_id"`
+}
+
+// VenueTimelineHandler handles GET /api/v1/venues/{id}/timeline
+// Merges projected events, validation history, audit logs and editor feedback, oldest first.
+func VenueTimelineHandler(db *database.DB, proj *events.Projector) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil || id <= 0 {
+			http.Error(w, "invalid venue id", http.StatusBadRequest)
+			return
+		}
+		ctx := r.Context()
+
+		evts, err := proj.Timeline(ctx, id)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("timeline error: %v", err), http.StatusInternalServerError)
+			return
+		}
+		history, err := db.GetVenueValidationHistoryCtx(ctx, id)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("history error: %v", err), http.StatusInternalServerError)
+			return
+		}
+		audits, err := db.GetAuditLogsByVenueIDCtx(ctx, id)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("audit error: %v", err), http.StatusInternalServerError)
+			return
+		}
+		feedback, _, _, err := db.GetVenueFeedbackCtx(ctx, id, 500)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("feedback error: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		items := buildTimeline(evts, history, audits, feedback)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"venue_id": id,
+			"items":    items,
+			"count":    len(items),
+		})
+	}
+}
+
+// EventsHandler handles GET /api/v1/events?after=&limit=
+// Lists events across venues, oldest first: the latest limit events without after, or the
+// ones after that seq. "next" is the seq to pass as after to follow the stream.
+func EventsHandler(proj *events.Projector) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		if limit < 1 || limit > 1000 {
+			limit = 100
+		}
+		after := int64(-1)
+		if s := q.Get("after"); s != "" {
+			n, err := strconv.ParseInt(s, 10, 64)
+			if err != nil || n < 0 {
+				http.Error(w, "after must be an event seq", http.StatusBadRequest)
+				return
+			}
+			after = n
+		}
+		evts, err := proj.Events(r.Context(), after, limit)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("events error: %v", err), http.StatusInternalServerError)
+			return
+		}
+		next := max(after, 0)
+		if len(evts) > 0 {
+			next = evts[len(evts)-1].Seq
+		} else if evts == nil {
+			evts = []events.TimelineEntry{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"events": evts,
+			"next":   next,
+		})
+	}
+}
+
+// buildTimeline flattens the four sources into one list sorted by time. Ties keep
+// source order (events, validations, audits, feedback) so a decision event precedes
+// the audit row written alongside it.
+func buildTimeline(evts []events.TimelineEntry, history []models.ValidationHistory,
+	audits []domain.VenueValidationAuditLog, feedback []models.EditorFeedback) []TimelineItem {
+	items := make([]TimelineItem, 0, len(evts)+len(history)+len(audits)+len(feedback))
+	for _, e := range evts {
+		it := TimelineItem{Ts: e.Ts, Kind: "event", Type: e.Type, Summary: e.Summary, Score: e.Score, RefID: e.Seq}
+		if e.Admin != nil {
+			it.Actor = *e.Admin
+		}
+		items = append(items, it)
+	}
+	for _, h := range history {
+		score := h.ValidationScore
+		summary := fmt.Sprintf("Validated: %s, score %d", h.ValidationStatus, h.ValidationScore)
+		if h.PromptVersion != nil && *h.PromptVersion != "" {
+			summary += " (prompt " + *h.PromptVersion + ")"
+		}
+		items = append(items, TimelineItem{Ts: h.ProcessedAt, Kind: "validation", Type: h.ValidationStatus,
+			Actor: "system", Summary: summary, Score: &score, RefID: h.ID})
+	}
+	for _, a := range audits {
+		actor := "system"
+		if a.AdminID != nil {
+			actor = "admin:" + strconv.Itoa(*a.AdminID)
+		}
+		summary := a.Status
+		if a.Reason != nil && *a.Reason != "" {
+			summary += ": " + *a.Reason
+		}
+		items = append(items, TimelineItem{Ts: a.CreatedAt, Kind: "audit", Type: a.Status,
+			Actor: actor, Summary: summary, RefID: a.ID})
+	}
+	for _, f := range feedback {
+		summary := "Editor feedback: " + string(f.FeedbackType)
+		if f.Comment != nil && *f.Comment != "" {
+			summary += ": " + *f.Comment
+		}
+		items = append(items, TimelineItem{Ts: f.CreatedAt, Kind: "feedback", Type: string(f.FeedbackType),
+			Actor: "editor", Summary: summary, RefID: f.ID})
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Ts.Before(items[j].Ts) })
+	return items
+}
@@ -0,0 +1,50 @@
+package admin
+
+import (
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/events"
+)
+
+func TestBuildTimeline(t *testing.T) {
+	t0 := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	admin := "alice"
+	adminID := 3
+	reason := "looks good"
+	comment := "wrong hours"
+
+	evts := []events.TimelineEntry{
+		{Seq: 1, Ts: t0, Type: events.TypeValidationStarted, Summary: "Validation started"},
+		{Seq: 2, Ts: t0.Add(2 * time.Minute), Type: events.TypeApproved, Admin: &admin, Summary: "Approved"},
+	}
+	history := []models.ValidationHistory{{ID: 10, ProcessedAt: t0.Add(time.Minute), ValidationStatus: "manual_review", ValidationScore: 71}}
+	audits := []domain.VenueValidationAuditLog{{ID: 20, CreatedAt: t0.Add(2 * time.Minute), AdminID: &adminID, Status: "approved", Reason: &reason}}
+	feedback := []models.EditorFeedback{{ID: 30, CreatedAt: t0.Add(-time.Hour), FeedbackType: models.FeedbackThumbsDown, Comment: &comment}}
+
+	items := buildTimeline(evts, history, audits, feedback)
+	want := []struct {
+		kind, actor, summary string
+		ref                  int64
+	}{
+		{"feedback", "editor", "Editor feedback: thumbs_down: wrong hours", 30},
+		{"event", "", "Validation started", 1},
+		{"validation", "system", "Validated: manual_review, score 71", 10},
+		{"event", "alice", "Approved", 2},
+		{"audit", "admin:3", "approved: looks good", 20},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("got %d items, want %d", len(items), len(want))
+	}
+	for i, w := range want {
+		it := items[i]
+		if it.Kind != w.kind || it.Actor != w.actor || it.Summary != w.summary || it.RefID != w.ref {
+			t.Errorf("item %d = %+v, want %+v", i, it, w)
+		}
+	}
+	if items[2].Score == nil || *items[2].Score != 71 {
+		t.Errorf("validation score not carried: %v", items[2].Score)
+	}
+}
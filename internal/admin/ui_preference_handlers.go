@@ -0,0 +1,224 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+)
+
+// uiCookieName carries the theme and compact tables setting to the layout script, which
+// applies them before the page paints. It mirrors the stored preferences; it is not read back.
+const uiCookieName = "ava_ui"
+
+type uiPreferencesKey struct{}
+
+type uiPreferencesPage struct {
+	Prefs models.UIPreferences
+	Min   int
+	Max   int
+	Error string
+	Saved bool
+}
+
+// UIPreferencesMiddleware loads the admin's UI preferences into the request context for admin
+// pages (GET requests outside /api/ and static files) and keeps the ava_ui cookie in step with
+// them. A failed lookup falls back to the defaults.
+func UIPreferencesMiddleware(store domain.UIPreferenceStore) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet || strings.HasPrefix(r.URL.Path, "/api/") || strings.HasPrefix(r.URL.Path, basePath+"static/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+			adminID, ok := auth.GetAdminIDFromContext(r.Context())
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			prefs, err := loadUIPreferences(r, store, adminID)
+			if err != nil {
+				log.Printf("Error fetching UI preferences for admin %d: %v", adminID, err)
+			}
+			if c, err := r.Cookie(uiCookieName); err != nil || c.Value != uiCookieValue(prefs) {
+				setUICookie(w, r, prefs)
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), uiPreferencesKey{}, prefs)))
+		})
+	}
+}
+
+// uiPreferences returns the preferences UIPreferencesMiddleware loaded, or the defaults.
+func uiPreferences(r *http.Request) models.UIPreferences {
+	if p, ok := r.Context().Value(uiPreferencesKey{}).(models.UIPreferences); ok {
+		return p
+	}
+	return models.DefaultUIPreferences()
+}
+
+func uiCookieValue(p models.UIPreferences) string {
+	v := url.Values{"theme": {p.Theme}}
+	if p.CompactTables {
+		v.Set("compact", "1")
+	}
+	return v.Encode()
+}
+
+func setUICookie(w http.ResponseWriter, r *http.Request, p models.UIPreferences) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     uiCookieName,
+		Value:    uiCookieValue(p),
+		Path:     basePath,
+		MaxAge:   int((365 * 24 * time.Hour).Seconds()),
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// loadUIPreferences returns the admin's stored preferences, or the defaults for the admin
+// when none are stored or the lookup fails.
+func loadUIPreferences(r *http.Request, store domain.UIPreferenceStore, adminID int) (models.UIPreferences, error) {
+	prefs := models.DefaultUIPreferences()
+	prefs.AdminID = adminID
+	stored, err := store.GetUIPreferencesCtx(r.Context(), adminID)
+	if err != nil || stored == nil {
+		return prefs, err
+	}
+	return *stored, nil
+}
+
+// saveUIPreferences validates and stores p for the admin and refreshes the ava_ui cookie.
+// Returns the status and message of a failure.
+func saveUIPreferences(w http.ResponseWriter, r *http.Request, store domain.UIPreferenceStore, p *models.UIPreferences) (int, string) {
+	if err := p.Validate(); err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	p.UpdatedAt = time.Now()
+	if err := store.SaveUIPreferencesCtx(r.Context(), *p); err != nil {
+		return http.StatusInternalServerError, fmt.Sprintf("failed to save preferences: %v", err)
+	}
+	setUICookie(w, r, *p)
+	return http.StatusOK, ""
+}
+
+// UIPreferencesHandler handles GET /settings/preferences
+func UIPreferencesHandler(store domain.UIPreferenceStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		adminID, ok := auth.GetAdminIDFromContext(r.Context())
+		if !ok {
+			http.Error(w, "Admin ID not found in context", http.StatusForbidden)
+			return
+		}
+		prefs, err := loadUIPreferences(r, store, adminID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to load preferences: %v", err), http.StatusInternalServerError)
+			return
+		}
+		renderUIPreferences(w, uiPreferencesPage{Prefs: prefs})
+	}
+}
+
+// SaveUIPreferencesFormHandler handles POST /settings/preferences
+// Form: theme, rows_per_page, default_sort, compact_tables (on when checked).
+func SaveUIPreferencesFormHandler(store domain.UIPreferenceStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		adminID, ok := auth.GetAdminIDFromContext(r.Context())
+		if !ok {
+			http.Error(w, "Admin ID not found in context", http.StatusForbidden)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "invalid form", http.StatusBadRequest)
+			return
+		}
+		p := models.UIPreferences{
+			AdminID:       adminID,
+			Theme:         r.PostFormValue("theme"),
+			DefaultSort:   r.PostFormValue("default_sort"),
+			CompactTables: r.PostFormValue("compact_tables") != "",
+		}
+		rows, err := strconv.Atoi(strings.TrimSpace(r.PostFormValue("rows_per_page")))
+		if err != nil {
+			renderUIPreferences(w, uiPreferencesPage{Prefs: p, Error: "rows_per_page must be a whole number"})
+			return
+		}
+		p.RowsPerPage = rows
+		if status, msg := saveUIPreferences(w, r, store, &p); msg != "" {
+			if status == http.StatusInternalServerError {
+				http.Error(w, msg, status)
+				return
+			}
+			renderUIPreferences(w, uiPreferencesPage{Prefs: p, Error: msg})
+			return
+		}
+		log.Printf("admin %d saved UI preferences", adminID)
+		renderUIPreferences(w, uiPreferencesPage{Prefs: p, Saved: true})
+	}
+}
+
+// APIUIPreferencesHandler handles GET /api/v1/ui-preferences
+func APIUIPreferencesHandler(store domain.UIPreferenceStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		adminID, ok := auth.GetAdminIDFromContext(r.Context())
+		if !ok {
+			http.Error(w, "Admin ID not found in context", http.StatusForbidden)
+			return
+		}
+		prefs, err := loadUIPreferences(r, store, adminID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to load preferences: %v", err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(prefs)
+	}
+}
+
+// APISetUIPreferencesHandler handles PUT /api/v1/ui-preferences
+// Body: {"theme": "dark", "rows_per_page": 100, "default_sort": "score_desc", "compact_tables": true};
+// settings left out keep their current value.
+func APISetUIPreferencesHandler(store domain.UIPreferenceStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		adminID, ok := auth.GetAdminIDFromContext(r.Context())
+		if !ok {
+			http.Error(w, "Admin ID not found in context", http.StatusForbidden)
+			return
+		}
+		p, err := loadUIPreferences(r, store, adminID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to load preferences: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4<<10)).Decode(&p); err != nil {
+			http.Error(w, "invalid JSON body", http.StatusBadRequest)
+			return
+		}
+		p.AdminID = adminID
+		if status, msg := saveUIPreferences(w, r, store, &p); msg != "" {
+			http.Error(w, msg, status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(p)
+	}
+}
+
+func renderUIPreferences(w http.ResponseWriter, page uiPreferencesPage) {
+	page.Min, page.Max = models.MinRowsPerPage, models.MaxRowsPerPage
+	if page.Error != "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	if err := ExecuteTemplate(w, "ui_preferences.tmpl", page); err != nil {
+		http.Error(w, fmt.Sprintf("template error: %v", err), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,149 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+)
+
+func TestUIPreferencesMiddleware(t *testing.T) {
+	store := &testutil.UIPreferenceStore{
+		GetUIPreferencesCtxFunc: func(_ context.Context, adminID int) (*models.UIPreferences, error) {
+			switch adminID {
+			case 4:
+				return &models.UIPreferences{AdminID: 4, Theme: models.ThemeDark, RowsPerPage: 100, DefaultSort: "score_desc", CompactTables: true}, nil
+			case 5:
+				return nil, errors.New("db down")
+			}
+			return nil, nil
+		},
+	}
+	var seen models.UIPreferences
+	h := UIPreferencesMiddleware(store)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = uiPreferences(r)
+	}))
+	open := func(target string, adminID int, cookie string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req = req.WithContext(context.WithValue(req.Context(), auth.AdminIDKey, adminID))
+		if cookie != "" {
+			req.AddCookie(&http.Cookie{Name: uiCookieName, Value: cookie})
+		}
+		seen = models.UIPreferences{}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := open("/venues/manual-review", 4, "")
+	if seen.RowsPerPage != 100 || seen.DefaultSort != "score_desc" {
+		t.Fatalf("preferences = %+v", seen)
+	}
+	if c := rec.Header().Get("Set-Cookie"); !strings.Contains(c, "ava_ui=compact=1&theme=dark") {
+		t.Fatalf("Set-Cookie = %q", c)
+	}
+	// An up-to-date cookie is not sent again
+	if rec := open("/venues/manual-review", 4, "compact=1&theme=dark"); rec.Header().Get("Set-Cookie") != "" {
+		t.Fatalf("cookie sent again: %q", rec.Header().Get("Set-Cookie"))
+	}
+	// Failed lookups and admins without preferences get the defaults
+	for _, id := range []int{5, 6} {
+		open("/venues/pending", id, "")
+		if seen.RowsPerPage != 50 || seen.Theme != models.ThemeLight {
+			t.Fatalf("admin %d preferences = %+v", id, seen)
+		}
+	}
+	// API requests are not touched
+	if rec := open("/api/v1/venues", 4, ""); seen.RowsPerPage != 50 || rec.Header().Get("Set-Cookie") != "" {
+		t.Fatalf("API request loaded preferences: %+v", seen)
+	}
+}
+
+func TestAPISetUIPreferencesHandler(t *testing.T) {
+	var saved *models.UIPreferences
+	store := &testutil.UIPreferenceStore{
+		GetUIPreferencesCtxFunc: func(context.Context, int) (*models.UIPreferences, error) {
+			return &models.UIPreferences{AdminID: 4, Theme: models.ThemeDark, RowsPerPage: 25, DefaultSort: "created_at"}, nil
+		},
+		SaveUIPreferencesCtxFunc: func(_ context.Context, p models.UIPreferences) error {
+			saved = &p
+			return nil
+		},
+	}
+	h := APISetUIPreferencesHandler(store)
+	put := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/ui-preferences", strings.NewReader(body))
+		req = req.WithContext(context.WithValue(req.Context(), auth.AdminIDKey, 4))
+		rec := httptest.NewRecorder()
+		h(rec, req)
+		return rec
+	}
+
+	rec := put(`{"rows_per_page": 100, "compact_tables": true, "admin_id": 9}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if saved == nil || saved.AdminID != 4 || saved.Theme != models.ThemeDark || saved.RowsPerPage != 100 ||
+		saved.DefaultSort != "created_at" || !saved.CompactTables || saved.UpdatedAt.IsZero() {
+		t.Fatalf("saved = %+v", saved)
+	}
+
+	saved = nil
+	for _, body := range []string{`{"theme": "neon"}`, `{"rows_per_page": 5000}`, `{"default_sort": "name"}`, `{`} {
+		if rec := put(body); rec.Code != http.StatusBadRequest {
+			t.Fatalf("%s: status = %d, want 400", body, rec.Code)
+		}
+	}
+	if saved != nil {
+		t.Fatalf("invalid preferences saved: %+v", saved)
+	}
+}
+
+func TestSaveUIPreferencesFormHandler(t *testing.T) {
+	if err := LoadTemplates(os.DirFS("../../web/templates")); err != nil {
+		t.Fatalf("LoadTemplates: %v", err)
+	}
+	defer func() { adminTemplates = nil }()
+
+	var saved *models.UIPreferences
+	store := &testutil.UIPreferenceStore{
+		SaveUIPreferencesCtxFunc: func(_ context.Context, p models.UIPreferences) error {
+			saved = &p
+			return nil
+		},
+	}
+	h := SaveUIPreferencesFormHandler(store)
+	post := func(form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/settings/preferences", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req = req.WithContext(context.WithValue(req.Context(), auth.AdminIDKey, 4))
+		rec := httptest.NewRecorder()
+		h(rec, req)
+		return rec
+	}
+
+	rec := post(url.Values{"theme": {"system"}, "rows_per_page": {"25"}, "default_sort": {"score_asc"}})
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Preferences saved.") {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if saved == nil || saved.Theme != models.ThemeSystem || saved.RowsPerPage != 25 || saved.CompactTables {
+		t.Fatalf("saved = %+v", saved)
+	}
+	if c := rec.Header().Get("Set-Cookie"); !strings.Contains(c, "ava_ui=theme=system") {
+		t.Fatalf("Set-Cookie = %q", c)
+	}
+
+	saved = nil
+	rec = post(url.Values{"theme": {"light"}, "rows_per_page": {"many"}, "default_sort": {"score_asc"}})
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "rows_per_page must be a whole number") || saved != nil {
+		t.Fatalf("status = %d, saved = %+v", rec.Code, saved)
+	}
+}
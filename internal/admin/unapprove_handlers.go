@@ -0,0 +1,143 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/pkg/config"
+	"assisted-venue-approval/pkg/events"
+	"assisted-venue-approval/pkg/metrics"
+
+	"github.com/gorilla/mux"
+)
+
+const auditStatusReverted = "reverted"
+
+var mAdminReverted = metrics.Default.Counter("admin_unapproved_total", "Admin approvals reverted within the grace window")
+
+var (
+	errNoApproval      = errors.New("venue has no approval to revert")
+	errAlreadyReverted = errors.New("latest approval was already reverted")
+	errWindowExpired   = errors.New("grace window for undoing this approval has expired")
+	errChangedSince    = errors.New("venue was edited by a change request after the approval")
+)
+
+// revertableApproval picks the approval audit log an undo applies to. logs are newest first
+// (as GetAuditLogsByVenueIDCtx returns them); notification rows are skipped.
+func revertableApproval(logs []domain.VenueValidationAuditLog, now time.Time, window time.Duration) (*domain.VenueValidationAuditLog, error) {
+	for i := range logs {
+		switch logs[i].Status {
+		case auditStatusReverted:
+			return nil, errAlreadyReverted
+		case "rejected":
+			return nil, errNoApproval
+		case auditStatusChangeApplied:
+			// Restoring the pre-approval values would also undo the later edit
+			return nil, errChangedSince
+		case "approved":
+			if now.Sub(logs[i].CreatedAt) > window {
+				return nil, errWindowExpired
+			}
+			return &logs[i], nil
+		}
+	}
+	return nil, errNoApproval
+}
+
+// UnapproveVenueHandler handles POST /venues/{id}/unapprove
+// Reverts an approval made within cfg.UnapproveWindow: fields the approval replaced get
+// their original values back and the venue returns to pending. Optional form value: reason.
+func UnapproveVenueHandler(repo domain.Repository, cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		writeErr := func(status int, msg string) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]string{"status": "error", "message": msg})
+		}
+
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			writeErr(http.StatusBadRequest, "Invalid venue ID")
+			return
+		}
+		adminID, ok := auth.GetAdminIDFromContext(ctx)
+		if !ok {
+			writeErr(http.StatusForbidden, "Admin ID not found in context")
+			return
+		}
+		if cfg.UnapproveWindow <= 0 {
+			writeErr(http.StatusForbidden, "Undoing approvals is disabled (UNAPPROVE_WINDOW=0)")
+			return
+		}
+
+		venueWithUser, err := repo.GetVenueWithUserByIDCtx(ctx, id)
+		if err != nil {
+			writeErr(http.StatusInternalServerError, fmt.Sprintf("Error fetching venue: %v", err))
+			return
+		}
+		if a := venueWithUser.Venue.Active; a == nil || *a != 1 {
+			writeErr(http.StatusConflict, "Venue is not approved")
+			return
+		}
+
+		logs, err := repo.GetAuditLogsByVenueIDCtx(ctx, id)
+		if err != nil {
+			writeErr(http.StatusInternalServerError, fmt.Sprintf("Error loading audit logs: %v", err))
+			return
+		}
+		approvalLog, err := revertableApproval(logs, time.Now(), cfg.UnapproveWindow)
+		if err != nil {
+			writeErr(http.StatusConflict, fmt.Sprintf("Cannot undo approval: %v", err))
+			return
+		}
+		replacements, err := domain.ParseVenueDataReplacement(approvalLog.DataReplacements)
+		if err != nil {
+			// Without the originals the venue would stay with the approved values
+			writeErr(http.StatusInternalServerError, fmt.Sprintf("Cannot undo approval: %v", err))
+			return
+		}
+
+		reviewer := fmt.Sprintf("admin_%d", adminID)
+		reason := fmt.Sprintf("Approval reverted by %s", reviewer)
+		if rr := strings.TrimSpace(r.FormValue("reason")); rr != "" {
+			reason += ": " + rr
+		}
+		restored := replacements.ReplacedFields()
+
+		err = commitDecision(ctx, repo, func(dw decisionWriter) error {
+			if err := dw.RevertVenueApprovalCtx(ctx, id, replacements, reason); err != nil {
+				return err
+			}
+			// The revert audit row is what stops a second undo of the same approval
+			return dw.CreateAuditLogCtx(ctx, domain.NewAuditLog(id, approvalLog.HistoryID, &adminID, auditStatusReverted, &reason))
+		}, events.VenueApprovalReverted{
+			Base:       events.Base{Ts: time.Now(), VID: id, Adm: &reviewer},
+			Reason:     reason,
+			ApprovedAt: approvalLog.CreatedAt,
+			AuditLogID: approvalLog.ID,
+			Restored:   restored,
+		})
+		if err != nil {
+			writeErr(http.StatusInternalServerError, fmt.Sprintf("Error reverting approval: %v", err))
+			return
+		}
+
+		mAdminReverted.Inc(1)
+		log.Printf("[unapprove] venue %d approval (audit log %d) reverted by %s, restored %v", id, approvalLog.ID, reviewer, restored)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":   "reverted",
+			"restored": restored,
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package admin
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+)
+
+func TestRevertableApproval(t *testing.T) {
+	now := time.Now()
+	entry := func(id int64, status string, age time.Duration) domain.VenueValidationAuditLog {
+		return domain.VenueValidationAuditLog{ID: id, Status: status, CreatedAt: now.Add(-age)}
+	}
+	window := 15 * time.Minute
+
+	tests := []struct {
+		name    string
+		logs    []domain.VenueValidationAuditLog
+		wantID  int64
+		wantErr error
+	}{
+		{"recent approval", []domain.VenueValidationAuditLog{entry(3, "approved", time.Minute)}, 3, nil},
+		{"notification rows skipped", []domain.VenueValidationAuditLog{entry(4, "notified", 0), entry(3, "approved", time.Minute)}, 3, nil},
+		{"window expired", []domain.VenueValidationAuditLog{entry(3, "approved", time.Hour)}, 0, errWindowExpired},
+		{"already reverted", []domain.VenueValidationAuditLog{entry(4, auditStatusReverted, 0), entry(3, "approved", time.Minute)}, 0, errAlreadyReverted},
+		{"latest is a rejection", []domain.VenueValidationAuditLog{entry(4, "rejected", 0), entry(3, "approved", time.Minute)}, 0, errNoApproval},
+		{"edited since", []domain.VenueValidationAuditLog{entry(4, auditStatusChangeApplied, 0), entry(3, "approved", time.Minute)}, 0, errChangedSince},
+		{"no logs", nil, 0, errNoApproval},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := revertableApproval(tt.logs, now, window)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (got == nil || got.ID != tt.wantID) {
+				t.Fatalf("got %+v, want audit log %d", got, tt.wantID)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package admin
+
+import (
+	"html/template"
+
+	"assisted-venue-approval/internal/models"
+)
+
+func init() {
+	registerTemplateFuncs(template.FuncMap{
+		"venueRow": newVenueRow,
+	})
+}
+
+// venueRow feeds the "venue_row" partial: the selection, venue, submitter and authority cells
+// every venue list starts its rows with.
+type venueRow struct {
+	models.VenueWithUser
+	Claim       *models.VenueClaim
+	ClaimedByMe bool
+}
+
+// newVenueRow is the "venueRow" template func. claim is nil on lists without review claims;
+// adminID is the admin viewing the list.
+func newVenueRow(v models.VenueWithUser, claim *models.VenueClaim, adminID int) venueRow {
+	return venueRow{VenueWithUser: v, Claim: claim, ClaimedByMe: claim != nil && claim.AdminID == adminID}
+}
@@ -0,0 +1,172 @@
+// Package analytics computes reporting views over validation history and admin decisions.
+package analytics
+
+import (
+	"sort"
+	"strings"
+
+	"assisted-venue-approval/pkg/geography"
+)
+
+// DecisionPair is the AI's latest verdict on a venue next to the admin's final decision.
+type DecisionPair struct {
+	VenueID       int64  `json:"venue_id"`
+	AIStatus      string `json:"ai_status"` // approved, rejected or manual_review
+	AIScore       int    `json:"ai_score"`
+	PromptVersion string `json:"prompt_version"`
+	Category      string `json:"category"`
+	Path          string `json:"-"`
+	Name          string `json:"-"`
+	Chain         string `json:"-"`     // chain rule the venue name matches, set by the caller
+	Human         string `json:"human"` // approved or rejected
+}
+
+// AgreementStats counts how often admins confirmed the AI.
+// Venues the AI sent to manual review have no AI verdict and are counted as Deferred only.
+type AgreementStats struct {
+	Total        int `json:"total"`
+	Deferred     int `json:"deferred"`
+	Agreed       int `json:"agreed"`
+	AIApproved   int `json:"ai_approved"`
+	AIRejected   int `json:"ai_rejected"`
+	FalseApprove int `json:"false_approve"` // AI approved, admin rejected
+	FalseReject  int `json:"false_reject"`  // AI rejected, admin approved
+
+	AgreementRate    float64 `json:"agreement_rate"`     // agreed / AI verdicts
+	FalseApproveRate float64 `json:"false_approve_rate"` // of AI approvals
+	FalseRejectRate  float64 `json:"false_reject_rate"`  // of AI rejections
+	AvgScoreAgreed   float64 `json:"avg_score_agreed"`
+	AvgScoreOverride float64 `json:"avg_score_override"`
+
+	scoreAgreed, scoreOverride int
+}
+
+// Breakdown is AgreementStats for one prompt version, category, region or chain.
+type Breakdown struct {
+	Key string `json:"key"`
+	AgreementStats
+}
+
+// AgreementReport is the payload of /api/v1/analytics/agreement.
+type AgreementReport struct {
+	Overall         AgreementStats `json:"overall"`
+	ByPromptVersion []Breakdown    `json:"by_prompt_version"`
+	ByCategory      []Breakdown    `json:"by_category"`
+	ByRegion        []Breakdown    `json:"by_region"`
+	ByChain         []Breakdown    `json:"by_chain,omitempty"` // venues matching a chain rule only
+}
+
+// Agreement aggregates decision pairs overall and per prompt version, category, region and chain.
+// Breakdowns are ordered by volume, largest first.
+func Agreement(pairs []DecisionPair) AgreementReport {
+	var overall AgreementStats
+	byPV := map[string]*AgreementStats{}
+	byCat := map[string]*AgreementStats{}
+	byRegion := map[string]*AgreementStats{}
+	byChain := map[string]*AgreementStats{}
+	for _, p := range pairs {
+		overall.add(p)
+		bucket(byPV, orUnknown(p.PromptVersion)).add(p)
+		bucket(byCat, orUnknown(p.Category)).add(p)
+		bucket(byRegion, Region(p.Path)).add(p)
+		if p.Chain != "" {
+			bucket(byChain, p.Chain).add(p)
+		}
+	}
+	overall.finish()
+	return AgreementReport{
+		Overall:         overall,
+		ByPromptVersion: breakdowns(byPV),
+		ByCategory:      breakdowns(byCat),
+		ByRegion:        breakdowns(byRegion),
+		ByChain:         breakdowns(byChain),
+	}
+}
+
+// Region groups venues by continent, taken from the country in the path or else its first segment.
+func Region(path string) string {
+	if c := geography.CountryFromPath(path); c != "" {
+		if cont := geography.GetContinent(c); cont != "" {
+			return cont
+		}
+	}
+	if i := strings.Index(path, "|"); i > 0 {
+		return strings.ToLower(strings.TrimSpace(path[:i]))
+	}
+	return "unknown"
+}
+
+func (s *AgreementStats) add(p DecisionPair) {
+	s.Total++
+	switch p.AIStatus {
+	case "approved":
+		s.AIApproved++
+		if p.Human == "rejected" {
+			s.FalseApprove++
+			s.scoreOverride += p.AIScore
+			return
+		}
+	case "rejected":
+		s.AIRejected++
+		if p.Human == "approved" {
+			s.FalseReject++
+			s.scoreOverride += p.AIScore
+			return
+		}
+	default:
+		s.Deferred++
+		return
+	}
+	s.Agreed++
+	s.scoreAgreed += p.AIScore
+}
+
+func (s *AgreementStats) finish() {
+	s.AgreementRate = pct(s.Agreed, s.AIApproved+s.AIRejected)
+	s.FalseApproveRate = pct(s.FalseApprove, s.AIApproved)
+	s.FalseRejectRate = pct(s.FalseReject, s.AIRejected)
+	if s.Agreed > 0 {
+		s.AvgScoreAgreed = float64(s.scoreAgreed) / float64(s.Agreed)
+	}
+	if n := s.FalseApprove + s.FalseReject; n > 0 {
+		s.AvgScoreOverride = float64(s.scoreOverride) / float64(n)
+	}
+}
+
+func bucket(m map[string]*AgreementStats, key string) *AgreementStats {
+	s, ok := m[key]
+	if !ok {
+		s = &AgreementStats{}
+		m[key] = s
+	}
+	return s
+}
+
+func breakdowns(m map[string]*AgreementStats) []Breakdown {
+	out := make([]Breakdown, 0, len(m))
+	for k, s := range m {
+		s.finish()
+		out = append(out, Breakdown{Key: k, AgreementStats: *s})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Total != out[j].Total {
+			return out[i].Total > out[j].Total
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+func pct(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d) * 100
+}
+
+func orUnknown(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "unknown"
+	}
+	return s
+}
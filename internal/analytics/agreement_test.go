@@ -0,0 +1,81 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAgreement(t *testing.T) {
+	pairs := []DecisionPair{
+		{VenueID: 1, AIStatus: "approved", AIScore: 90, PromptVersion: "v2", Category: "Vegan", Path: "europe|germany|berlin", Chain: "Veganz", Human: "approved"},
+		{VenueID: 2, AIStatus: "approved", AIScore: 80, PromptVersion: "v2", Category: "Vegan", Path: "europe|germany|berlin", Chain: "Veganz", Human: "rejected"},
+		{VenueID: 3, AIStatus: "rejected", AIScore: 20, PromptVersion: "v1", Category: "Veg-friendly", Path: "asia|japan|tokyo", Human: "approved"},
+		{VenueID: 4, AIStatus: "rejected", AIScore: 10, PromptVersion: "v1", Category: "Veg-friendly", Path: "asia|japan|tokyo", Human: "rejected"},
+		{VenueID: 5, AIStatus: "manual_review", AIScore: 60, PromptVersion: "", Category: "", Path: "", Human: "approved"},
+	}
+	r := Agreement(pairs)
+
+	o := r.Overall
+	if o.Total != 5 || o.Deferred != 1 || o.Agreed != 2 || o.FalseApprove != 1 || o.FalseReject != 1 {
+		t.Fatalf("overall counts: %+v", o)
+	}
+	if o.AgreementRate != 50 || o.FalseApproveRate != 50 || o.FalseRejectRate != 50 {
+		t.Fatalf("overall rates: agree=%v fa=%v fr=%v", o.AgreementRate, o.FalseApproveRate, o.FalseRejectRate)
+	}
+	if o.AvgScoreAgreed != 50 || o.AvgScoreOverride != 50 {
+		t.Fatalf("avg scores: agreed=%v override=%v", o.AvgScoreAgreed, o.AvgScoreOverride)
+	}
+
+	byKey := func(bs []Breakdown) map[string]Breakdown {
+		m := map[string]Breakdown{}
+		for _, b := range bs {
+			m[b.Key] = b
+		}
+		return m
+	}
+	pv := byKey(r.ByPromptVersion)
+	if b := pv["v2"]; b.Total != 2 || b.FalseApproveRate != 50 || b.FalseRejectRate != 0 {
+		t.Fatalf("v2: %+v", b)
+	}
+	if b := pv["unknown"]; b.Total != 1 || b.Deferred != 1 || b.AgreementRate != 0 {
+		t.Fatalf("unknown prompt version: %+v", b)
+	}
+	region := byKey(r.ByRegion)
+	if region["europe"].Total != 2 || region["asia"].Total != 2 || region["unknown"].Total != 1 {
+		t.Fatalf("regions: %+v", r.ByRegion)
+	}
+	if len(r.ByChain) != 1 || r.ByChain[0].Key != "Veganz" || r.ByChain[0].Total != 2 || r.ByChain[0].Agreed != 1 {
+		t.Fatalf("chains: %+v", r.ByChain)
+	}
+	if len(r.ByCategory) != 3 {
+		t.Fatalf("categories: %+v", r.ByCategory)
+	}
+	// Ties on volume are ordered by key.
+	if r.ByCategory[0].Key != "Veg-friendly" || r.ByCategory[2].Key != "unknown" {
+		t.Fatalf("category order: %+v", r.ByCategory)
+	}
+}
+
+func TestAgreement_Empty(t *testing.T) {
+	r := Agreement(nil)
+	if r.Overall.Total != 0 || math.IsNaN(r.Overall.AgreementRate) || len(r.ByRegion) != 0 {
+		t.Fatalf("empty report: %+v", r)
+	}
+}
+
+func TestRegion(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"europe|germany|berlin", "europe"},
+		{"asia|japan|tokyo", "asia"},
+		{"Atlantis|lost city", "atlantis"},
+		{"", "unknown"},
+	}
+	for _, tt := range tests {
+		if got := Region(tt.path); got != tt.want {
+			t.Errorf("Region(%q)=%q want %q", tt.path, got, tt.want)
+		}
+	}
+}
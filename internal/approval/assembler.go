@@ -24,7 +24,7 @@ type MergeInput struct {
 	GoogleData    *models.GooglePlaceData
 	LatestHistory *models.ValidationHistory
 	Draft         *drafts.VenueDraft
-	Repo          domain.Repository // For venue count check in path replacement logic
+	Repo          domain.VenueReader // For venue count check in path replacement logic
 }
 
 // MergeResult returns the merged view used by both the UI and persistence layers.
@@ -33,6 +33,10 @@ type MergeResult struct {
 	ApprovalFields *models.ApprovalFieldData
 	AISuggestions  *models.AISuggestions
 	DraftApplied   bool
+	// What was scrubbed from the AI-suggested description before it was used
+	DescriptionIssues []DescriptionIssue
+	// Style rules the final description breaks; approval needs them fixed or acknowledged
+	StyleViolations []StyleViolation
 }
 
 // Assemble merges venue, Google, AI, and editor inputs into a cohesive representation.
@@ -101,6 +105,10 @@ func Assemble(input MergeInput) (*MergeResult, error) {
 	}
 
 	aiSuggestions := parseAISuggestions(input.LatestHistory)
+	var descIssues []DescriptionIssue
+	if aiSuggestions.DescriptionSuggestion != "" {
+		aiSuggestions.DescriptionSuggestion, descIssues = SanitizeDescription(aiSuggestions.DescriptionSuggestion)
+	}
 
 	approvalFields, err := models.GetApprovalFieldData(venue, input.User, input.TrustScore, aiSuggestions, draftMap)
 	if err != nil {
@@ -108,10 +116,12 @@ func Assemble(input MergeInput) (*MergeResult, error) {
 	}
 
 	return &MergeResult{
-		Combined:       combined,
-		ApprovalFields: approvalFields,
-		AISuggestions:  aiSuggestions,
-		DraftApplied:   draftMap != nil && len(draftMap) > 0,
+		Combined:          combined,
+		ApprovalFields:    approvalFields,
+		AISuggestions:     aiSuggestions,
+		DraftApplied:      draftMap != nil && len(draftMap) > 0,
+		DescriptionIssues: descIssues,
+		StyleViolations:   CheckStyle(approvalFields.Description, ActiveStyleRules()),
 	}, nil
 }
 
@@ -196,6 +206,21 @@ func BuildApprovalData(result *MergeResult, venue *models.Venue, adminID int, no
 		}
 	}
 
+	// Fill in a missing or unusable time zone from the approved location
+	path, lat, lng := venue.Path, venue.Lat, venue.Lng
+	if data.Path != nil {
+		path = data.Path
+	}
+	if data.Lat != nil {
+		lat = data.Lat
+	}
+	if data.Lng != nil {
+		lng = data.Lng
+	}
+	if zone, inferred := VenueTimezone(*venue, deref(path), lat, lng); inferred && zone != "" {
+		data.Timezone = &zone
+	}
+
 	data.Replacements = domain.BuildVenueDataReplacements(venue, data)
 	return data
 }
@@ -97,6 +97,26 @@ func TestAssembleAppliesDraftAndAISuggestions(t *testing.T) {
 	}
 }
 
+func TestAssembleSanitizesAIDescription(t *testing.T) {
+	out := `{"quality":{"description":"Amazing vegan cafe! Call 030 1234567 for bookings."}}`
+	venue := models.Venue{ID: 43, Name: "Cafe", Location: "1 Main St", AdditionalInfo: strPtr("Old description")}
+	result, err := Assemble(MergeInput{Venue: venue, LatestHistory: &models.ValidationHistory{AIOutputData: &out}})
+	if err != nil {
+		t.Fatalf("Assemble returned error: %v", err)
+	}
+	want := "Vegan cafe. For bookings."
+	if got := result.AISuggestions.DescriptionSuggestion; got != want {
+		t.Fatalf("suggestion = %q, want %q", got, want)
+	}
+	if len(result.DescriptionIssues) != 3 {
+		t.Fatalf("issues = %+v", result.DescriptionIssues)
+	}
+	data := BuildApprovalData(result, &venue, 1, "")
+	if data.Description == nil || *data.Description != want {
+		t.Fatalf("approval description = %v, want the sanitized suggestion", data.Description)
+	}
+}
+
 func TestBuildApprovalDataSkipsUnchangedFields(t *testing.T) {
 	venue := models.Venue{
 		ID:             10,
@@ -138,6 +158,70 @@ func TestBuildApprovalDataSkipsUnchangedFields(t *testing.T) {
 	}
 }
 
+func TestEditorFieldEditsReachReplacements(t *testing.T) {
+	venue := models.Venue{
+		ID:       11,
+		Name:     "Green Leaf",
+		Location: "1 High St",
+		Phone:    strPtr("020 7946 0000"),
+		URL:      strPtr("http://old.example.com"),
+	}
+	draft := &drafts.VenueDraft{
+		VenueID:  venue.ID,
+		EditorID: 3,
+		Fields: map[string]drafts.DraftField{
+			"phone":      {Value: "+44 20 7946 0958", OriginalSource: "user"},
+			"website":    {Value: "https://greenleaf.example.com", OriginalSource: "user"},
+			"open_hours": {Value: []interface{}{"Mon-09:00-17:00", "Tuesday: Closed"}, OriginalSource: "user"},
+		},
+		UpdatedAt: time.Now(),
+	}
+
+	result, err := Assemble(MergeInput{Venue: venue, User: models.User{ID: 7}, Draft: draft})
+	if err != nil {
+		t.Fatalf("Assemble returned error: %v", err)
+	}
+	data := BuildApprovalData(result, &venue, 3, "")
+	if data == nil || data.Replacements == nil {
+		t.Fatalf("expected replacements, got %+v", data)
+	}
+
+	orig, repl := data.Replacements.Original, data.Replacements.Replacement
+	if repl.Phone == nil || *repl.Phone != "+442079460958" || orig.Phone == nil || *orig.Phone != "020 7946 0000" {
+		t.Fatalf("phone replacement = %+v -> %+v", data.Replacements.Original, data.Replacements.Replacement)
+	}
+	if repl.Website == nil || *repl.Website != "https://greenleaf.example.com" {
+		t.Fatalf("website replacement = %v", repl.Website)
+	}
+	if repl.OpenHours == nil || *repl.OpenHours != `{"openhours":["Mon-09:00-17:00"],"note":""}` {
+		t.Fatalf("open hours replacement = %v", repl.OpenHours)
+	}
+}
+
 func floatPtr(v float64) *float64 {
 	return &v
 }
+
+func TestBuildApprovalDataInfersTimezone(t *testing.T) {
+	venue := models.Venue{
+		ID:   11,
+		Name: "Venue",
+		Path: strPtr("north_america|usa|illinois|chicago"),
+		Lat:  floatPtr(41.88),
+		Lng:  floatPtr(-87.63),
+	}
+	result := &MergeResult{ApprovalFields: &models.ApprovalFieldData{Name: "Venue"}}
+
+	data := BuildApprovalData(result, &venue, 1, "")
+	if data.Timezone == nil || *data.Timezone != "America/Chicago" {
+		t.Fatalf("expected inferred America/Chicago, got %v", data.Timezone)
+	}
+	if data.Replacements == nil || data.Replacements.Replacement.Timezone == nil || data.Replacements.Original.Timezone != nil {
+		t.Fatalf("expected timezone replacement recorded against a NULL original: %+v", data.Replacements)
+	}
+
+	venue.Timezone = strPtr("America/New_York")
+	if data := BuildApprovalData(result, &venue, 1, ""); data.Timezone != nil {
+		t.Fatalf("expected a valid venue timezone to be kept, got %q", *data.Timezone)
+	}
+}
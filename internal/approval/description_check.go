@@ -0,0 +1,123 @@
+package approval
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Description flags raised by SanitizeDescription.
+const (
+	DescriptionFlagProfanity   = "profanity"
+	DescriptionFlagPhone       = "phone"       // a phone number in the prose
+	DescriptionFlagEmail       = "email"       // an email address in the prose
+	DescriptionFlagPromotional = "promotional" // wording config/description_guidelines.md bans
+)
+
+// DescriptionIssue is something SanitizeDescription removed from a description.
+type DescriptionIssue struct {
+	Flag    string `json:"flag"`
+	Match   string `json:"match"`
+	Message string `json:"message"`
+}
+
+// contactLeadIn swallows "call us at", "email:" and the like in front of a phone number or
+// email address, so removing the contact does not leave a dangling phrase.
+const contactLeadIn = `(?i:\b(?:call|phone|tel|text|whatsapp|e-?mail|contact|reach)\b(?:\s+us)?(?:\s+(?:at|on|via))?\s*:?\s*)?`
+
+var (
+	reDescEmail = regexp.MustCompile(contactLeadIn + `([A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,})`)
+	// At least seven digits, so years, prices and hours ("10:00-22:00") are left alone
+	reDescPhone = regexp.MustCompile(contactLeadIn + `(\+?\(?\d(?:[\s().-]*\d){6,})`)
+
+	reDescProfanity   = wordList("fuck", "fucking", "fucked", "shit", "shitty", "bullshit", "crap", "crappy", "damn", "bitch", "bastard", "asshole", "ass", "piss", "pissed", "dick", "wtf")
+	reDescPromotional = wordList(
+		// Quality words
+		"great food", "extremely tasty", "delicious", "tasty", "yummy", "excellent", "amazing", "awesome",
+		"wonderful", "fantastic", "superb", "outstanding", "incredible", "mouthwatering", "mouth-watering",
+		// Value words
+		"great prices", "great price", "best value", "affordable", "cheap",
+		// Endorsements
+		"the best", "best", "the only one", "highly recommended", "must-try", "must try",
+		"come check us out", "check us out", "favorite", "favourite", "world-famous", "world famous",
+	)
+)
+
+// wordList matches any of words as whole words, case-insensitively, with a comma that follows
+// so lists of adjectives go together. Longer phrases are tried first so "extremely tasty" is
+// removed whole rather than leaving "extremely".
+func wordList(words ...string) *regexp.Regexp {
+	sorted := append([]string(nil), words...)
+	sort.SliceStable(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+	quoted := make([]string, len(sorted))
+	for i, w := range sorted {
+		quoted[i] = regexp.QuoteMeta(w)
+	}
+	return regexp.MustCompile(`(?i)\b(` + strings.Join(quoted, "|") + `)\b(?:\s*,)?`)
+}
+
+var (
+	reDescSpaces      = regexp.MustCompile(`[ \t]{2,}`)
+	reDescSpacePunct  = regexp.MustCompile(`\s+([,.;:!?])`)
+	reDescRepeatPunct = regexp.MustCompile(`([,;:])(?:\s*[,;:])+|,\s*\.`)
+	reDescDots        = regexp.MustCompile(`\.(?:\s*\.)+`)
+	reDescEmptyParens = regexp.MustCompile(`\(\s*\)`)
+	reDescSentence    = regexp.MustCompile(`[.?]\s+\p{Ll}`)
+)
+
+// SanitizeDescription removes profanity, phone numbers, email addresses and promotional
+// wording from an AI-suggested description and reports each removal. Exclamation marks,
+// which the guidelines also ban, become full stops. Text without violations comes back
+// unchanged.
+func SanitizeDescription(text string) (string, []DescriptionIssue) {
+	var issues []DescriptionIssue
+	scrub := func(s string, re *regexp.Regexp, flag, format string) string {
+		return re.ReplaceAllStringFunc(s, func(m string) string {
+			match := strings.TrimSpace(m)
+			// Report the word or contact itself, without a lead-in or comma
+			if sub := re.FindStringSubmatch(m); len(sub) > 1 {
+				match = sub[1]
+			}
+			issues = append(issues, DescriptionIssue{Flag: flag, Match: match, Message: fmt.Sprintf(format, match)})
+			return ""
+		})
+	}
+
+	out := scrub(text, reDescEmail, DescriptionFlagEmail, "Removed email address %s")
+	out = scrub(out, reDescPhone, DescriptionFlagPhone, "Removed phone number %s")
+	out = scrub(out, reDescProfanity, DescriptionFlagProfanity, "Removed profanity %q")
+	out = scrub(out, reDescPromotional, DescriptionFlagPromotional, "Removed promotional wording %q")
+	if strings.Contains(out, "!") {
+		issues = append(issues, DescriptionIssue{Flag: DescriptionFlagPromotional, Match: "!", Message: "Replaced exclamation marks with full stops"})
+		out = strings.ReplaceAll(out, "!", ".")
+	}
+	if len(issues) == 0 {
+		return text, nil
+	}
+	return tidyDescription(out), issues
+}
+
+// tidyDescription cleans up the spaces and punctuation removals leave behind, and capitalises
+// sentences that lost their first word.
+func tidyDescription(s string) string {
+	s = reDescEmptyParens.ReplaceAllString(s, "")
+	s = reDescSpaces.ReplaceAllString(s, " ")
+	s = reDescSpacePunct.ReplaceAllString(s, "$1")
+	s = reDescRepeatPunct.ReplaceAllStringFunc(s, func(m string) string {
+		if strings.HasSuffix(m, ".") {
+			return "."
+		}
+		return m[:1]
+	})
+	s = reDescDots.ReplaceAllString(s, ".")
+	s = strings.TrimLeftFunc(s, func(r rune) bool { return unicode.IsSpace(r) || strings.ContainsRune(",.;:", r) })
+	s = strings.TrimSpace(s)
+	s = reDescSentence.ReplaceAllStringFunc(s, strings.ToUpper)
+	if r, size := utf8.DecodeRuneInString(s); unicode.IsLower(r) {
+		s = string(unicode.ToUpper(r)) + s[size:]
+	}
+	return s
+}
@@ -0,0 +1,59 @@
+package approval
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSanitizeDescription(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		want  string
+		flags []string
+	}{
+		{
+			name: "clean text is unchanged",
+			in:   "Vegan restaurant serving burgers and bowls since 2015. Open 10:00-22:00.",
+			want: "Vegan restaurant serving burgers and bowls since 2015. Open 10:00-22:00.",
+		},
+		{
+			name:  "contacts with their lead-in",
+			in:    "Vegan bakery with sourdough bread. Call us at +1 (555) 010-0199. Email: hello@rise.example",
+			want:  "Vegan bakery with sourdough bread.",
+			flags: []string{DescriptionFlagEmail, DescriptionFlagPhone},
+		},
+		{
+			name:  "promotional wording and exclamation marks",
+			in:    "The best vegan tacos in town! Serves delicious, extremely tasty burritos.",
+			want:  "Vegan tacos in town. Serves burritos.",
+			flags: []string{DescriptionFlagPromotional, DescriptionFlagPromotional, DescriptionFlagPromotional, DescriptionFlagPromotional},
+		},
+		{
+			name:  "profanity as whole words only",
+			in:    "Damn good vegan cafe with a class assortment of cakes.",
+			want:  "Good vegan cafe with a class assortment of cakes.",
+			flags: []string{DescriptionFlagProfanity},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, issues := SanitizeDescription(tt.in)
+			if got != tt.want {
+				t.Errorf("SanitizeDescription = %q, want %q", got, tt.want)
+			}
+			var flags []string
+			for _, i := range issues {
+				flags = append(flags, i.Flag)
+			}
+			if !reflect.DeepEqual(flags, tt.flags) {
+				t.Errorf("flags = %v, want %v (%+v)", flags, tt.flags, issues)
+			}
+		})
+	}
+
+	_, issues := SanitizeDescription("Reach us on 030 1234567")
+	if len(issues) != 1 || issues[0].Match != "030 1234567" {
+		t.Fatalf("issues = %+v, want the number without its lead-in", issues)
+	}
+}
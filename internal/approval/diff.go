@@ -0,0 +1,161 @@
+package approval
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"assisted-venue-approval/internal/drafts"
+	"assisted-venue-approval/internal/models"
+)
+
+// FieldDiff compares one field across the three sources an editor chooses between.
+// Values are display strings (hours joined by newlines); SuggestionValue is what
+// "accept suggestion" writes to the draft field DraftField.
+type FieldDiff struct {
+	Field            string      `json:"field"`
+	Label            string      `json:"label"`
+	DraftField       string      `json:"draft_field"`
+	Submitted        string      `json:"submitted"`
+	Suggested        string      `json:"suggested"`
+	SuggestionSource string      `json:"suggestion_source,omitempty"` // "ai" or "google"
+	SuggestionValue  interface{} `json:"suggestion_value,omitempty"`
+	Draft            string      `json:"draft"`
+	HasDraft         bool        `json:"has_draft"`
+	DraftVersion     int         `json:"draft_version"` // field version to send when writing it
+	// SuggestionDiffers is true when the suggestion differs from the submitted value and
+	// the draft does not already hold it.
+	SuggestionDiffers bool `json:"suggestion_differs"`
+}
+
+// DiffInput carries the sources for Diff. GoogleData falls back to the latest history row.
+type DiffInput struct {
+	Venue         models.Venue
+	GoogleData    *models.GooglePlaceData
+	LatestHistory *models.ValidationHistory
+	Draft         *drafts.VenueDraft
+}
+
+// Diff lines up submitter, Google/AI and editor draft values for the fields editors
+// most often correct. AI suggestions win over Google where both exist (name, description).
+func Diff(in DiffInput) []FieldDiff {
+	v := in.Venue
+	gd := in.GoogleData
+	if gd == nil && in.LatestHistory != nil {
+		gd = in.LatestHistory.GooglePlaceData
+	}
+	ai := parseAISuggestions(in.LatestHistory)
+
+	var g models.GooglePlaceData
+	if gd != nil {
+		g = *gd
+	}
+	var googleHours []string
+	if g.OpeningHours != nil {
+		googleHours = g.OpeningHours.WeekdayText
+	}
+
+	out := []FieldDiff{
+		textDiff("name", "Name", v.Name, firstSuggestion(ai.NameSuggestion, "ai", g.Name, "google")),
+		textDiff("address", "Address", v.Location, firstSuggestion("", "", models.ExtractStreetAddress(gd), "google")),
+		hoursDiff(models.ParseUserHours(v.OpenHours), googleHours),
+		textDiff("phone", "Phone", deref(v.Phone), firstSuggestion("", "", g.FormattedPhone, "google")),
+		textDiff("website", "Website", deref(v.URL), firstSuggestion("", "", g.Website, "google")),
+		textDiff("description", "Description", deref(v.AdditionalInfo), firstSuggestion(ai.DescriptionSuggestion, "ai", "", "")),
+	}
+	for i := range out {
+		applyDraft(&out[i], in.Draft)
+	}
+	return out
+}
+
+type suggestion struct {
+	value  string
+	source string
+}
+
+func firstSuggestion(a, aSource, b, bSource string) suggestion {
+	if s := strings.TrimSpace(a); s != "" {
+		return suggestion{s, aSource}
+	}
+	if s := strings.TrimSpace(b); s != "" {
+		return suggestion{s, bSource}
+	}
+	return suggestion{}
+}
+
+func textDiff(field, label, submitted string, s suggestion) FieldDiff {
+	d := FieldDiff{
+		Field:            field,
+		Label:            label,
+		DraftField:       field,
+		Submitted:        strings.TrimSpace(submitted),
+		Suggested:        s.value,
+		SuggestionSource: s.source,
+	}
+	if s.value != "" {
+		d.SuggestionValue = s.value
+		d.SuggestionDiffers = !strings.EqualFold(d.Submitted, s.value)
+	}
+	return d
+}
+
+func hoursDiff(submitted, google []string) FieldDiff {
+	d := FieldDiff{
+		Field:      "hours",
+		Label:      "Hours",
+		DraftField: "open_hours",
+		Submitted:  strings.Join(submitted, "\n"),
+		Suggested:  strings.Join(google, "\n"),
+	}
+	if len(google) > 0 {
+		d.SuggestionSource = "google"
+		d.SuggestionValue = google
+		d.SuggestionDiffers = !reflect.DeepEqual(submitted, google)
+	}
+	return d
+}
+
+// applyDraft fills the draft column. A draft that already holds the suggestion clears
+// SuggestionDiffers so the accept button is hidden.
+func applyDraft(d *FieldDiff, draft *drafts.VenueDraft) {
+	if draft == nil {
+		return
+	}
+	f, ok := draft.Fields[d.DraftField]
+	if !ok {
+		return
+	}
+	d.HasDraft = true
+	d.DraftVersion = f.Version
+	d.Draft = displayValue(f.Value)
+	if d.SuggestionValue != nil && d.Draft == d.Suggested {
+		d.SuggestionDiffers = false
+	}
+}
+
+func displayValue(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(t)
+	case []string:
+		return strings.Join(t, "\n")
+	case []interface{}:
+		parts := make([]string, 0, len(t))
+		for _, p := range t {
+			parts = append(parts, fmt.Sprint(p))
+		}
+		return strings.Join(parts, "\n")
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
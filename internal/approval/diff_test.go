@@ -0,0 +1,87 @@
+package approval
+
+import (
+	"testing"
+
+	"assisted-venue-approval/internal/drafts"
+	"assisted-venue-approval/internal/models"
+)
+
+func TestDiff(t *testing.T) {
+	aiJSON := `{"quality": {"name": "Green Leaf Cafe", "description": "Vegan cafe with a garden."}}`
+	hoursJSON := `{"openhours":["Mon-09:00-17:00"],"note":""}`
+	venue := models.Venue{
+		ID:             1,
+		Name:           "green leaf",
+		Location:       "1 Main St",
+		Phone:          strPtr("+1 555 0100"),
+		OpenHours:      &hoursJSON,
+		AdditionalInfo: strPtr("vegan cafe"),
+	}
+	google := &models.GooglePlaceData{
+		Name:           "Green Leaf",
+		FormattedPhone: "+1 555 0100",
+		Website:        "https://greenleaf.example",
+		OpeningHours:   &models.GoogleOpeningHours{WeekdayText: []string{"Monday: 9:00 AM – 5:00 PM"}},
+	}
+	draft := &drafts.VenueDraft{Version: 3, Fields: map[string]drafts.DraftField{
+		"website":    {Value: "https://greenleaf.example", Version: 2},
+		"open_hours": {Value: []interface{}{"Mon-10:00-18:00"}, Version: 1},
+	}}
+
+	got := Diff(DiffInput{
+		Venue:         venue,
+		GoogleData:    google,
+		LatestHistory: &models.ValidationHistory{AIOutputData: &aiJSON},
+		Draft:         draft,
+	})
+	byField := make(map[string]FieldDiff, len(got))
+	for _, d := range got {
+		byField[d.Field] = d
+	}
+	if len(got) != 6 {
+		t.Fatalf("got %d fields", len(got))
+	}
+
+	tests := []struct {
+		field     string
+		submitted string
+		suggested string
+		source    string
+		draft     string
+		differs   bool
+		version   int
+	}{
+		// AI name wins over Google's
+		{"name", "green leaf", "Green Leaf Cafe", "ai", "", true, 0},
+		// Same phone: nothing to accept
+		{"phone", "+1 555 0100", "+1 555 0100", "google", "", false, 0},
+		// Draft already holds the suggestion
+		{"website", "", "https://greenleaf.example", "google", "https://greenleaf.example", false, 2},
+		{"hours", "Mon-09:00-17:00", "Monday: 9:00 AM – 5:00 PM", "google", "Mon-10:00-18:00", true, 1},
+		{"description", "vegan cafe", "Vegan cafe with a garden.", "ai", "", true, 0},
+		// No Google address components: no suggestion
+		{"address", "1 Main St", "", "", "", false, 0},
+	}
+	for _, tt := range tests {
+		d := byField[tt.field]
+		if d.Submitted != tt.submitted || d.Suggested != tt.suggested || d.SuggestionSource != tt.source ||
+			d.Draft != tt.draft || d.SuggestionDiffers != tt.differs || d.DraftVersion != tt.version {
+			t.Errorf("%s: %+v", tt.field, d)
+		}
+	}
+	if v, ok := byField["hours"].SuggestionValue.([]string); !ok || len(v) != 1 {
+		t.Errorf("hours suggestion value = %#v", byField["hours"].SuggestionValue)
+	}
+	if byField["hours"].DraftField != "open_hours" {
+		t.Errorf("hours draft field = %q", byField["hours"].DraftField)
+	}
+}
+
+func TestDiff_NoSources(t *testing.T) {
+	for _, d := range Diff(DiffInput{Venue: models.Venue{Name: "X"}}) {
+		if d.SuggestionValue != nil || d.HasDraft || d.SuggestionDiffers {
+			t.Errorf("%s: %+v", d.Field, d)
+		}
+	}
+}
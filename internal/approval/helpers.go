@@ -4,11 +4,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
+	"unicode"
+
+	"assisted-venue-approval/internal/models"
 )
 
 // FormatOpenHoursFromCombined converts the Combined.Hours slice into the JSON structure
 // expected by downstream systems. Returns an empty string when no parsable hours exist.
+// Lines are read with ParseHours, so every form it accepts round-trips to the stored
+// "Mon-09:00-17:00" entries.
 func FormatOpenHoursFromCombined(hours []string) (string, error) {
 	if len(hours) == 0 {
 		return "", nil
@@ -19,57 +25,184 @@ func FormatOpenHoursFromCombined(hours []string) (string, error) {
 		Note      string   `json:"note"`
 	}
 
+	parsed, _ := ParseHours(hours)
+	if parsed.IsEmpty() {
+		return "", nil
+	}
+
 	result := openHoursFormat{
-		OpenHours: []string{},
+		OpenHours: parsed.Lines(),
 		Note:      "",
 	}
 
-	dayAliases := map[string]string{
-		"mon":       "Mon",
-		"monday":    "Mon",
-		"tue":       "Tue",
-		"tues":      "Tue",
-		"tuesday":   "Tue",
-		"wed":       "Wed",
-		"weds":      "Wed",
-		"wednesday": "Wed",
-		"thu":       "Thu",
-		"thur":      "Thu",
-		"thurs":     "Thu",
-		"thursday":  "Thu",
-		"fri":       "Fri",
-		"friday":    "Fri",
-		"sat":       "Sat",
-		"saturday":  "Sat",
-		"sun":       "Sun",
-		"sunday":    "Sun",
-	}
-
-	for _, line := range hours {
-		line = strings.TrimSpace(line)
+	bytes, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal open hours: %w", err)
+	}
+	return string(bytes), nil
+}
+
+var (
+	// storedHoursLineRegex matches the stored form, "Mon-09:00-17:00".
+	storedHoursLineRegex = regexp.MustCompile(`(?i)^([a-z]{3})\s*-\s*(\d{1,2}):(\d{2})\s*-\s*(\d{1,2}):(\d{2})$`)
+	// dayHoursLineRegex matches a day or day range and its hours, as in Google's
+	// "Monday: 9:00 AM – 5:00 PM" or a submitter's "Mon-Fri: 9am-5pm".
+	dayHoursLineRegex = regexp.MustCompile(`^(\p{L}+)(?:\s*[-–]\s*(\p{L}+))?\s*:\s*(.+)$`)
+	// timeRangeRegex matches one lowercased time range, e.g. "9:00 am – 5:00 pm", "9am-5pm"
+	// or "09:00-17:00".
+	timeRangeRegex = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?\s*(am|pm)?\s*(?:-|–|—|to)\s*(\d{1,2})(?::(\d{2}))?\s*(am|pm)?$`)
+)
+
+// ParseHours reads opening hours lines into a week of time ranges. It accepts the stored
+// "Mon-09:00-17:00" form, Google's "Monday: 9:00 AM – 12:00 PM, 2:00 – 6:00 PM" (also
+// "Closed" and "Open 24 hours") and day ranges such as "Mon-Fri: 9am-5pm". Lines it cannot
+// read are returned in unparsed, trimmed, and left out of the hours.
+func ParseHours(lines []string) (hours models.NormalizedHours, unparsed []string) {
+	days := hours.Days()
+	for _, line := range lines {
+		line = strings.TrimSpace(strings.Map(func(r rune) rune {
+			// Google separates times with narrow no-break and thin spaces
+			if unicode.IsSpace(r) {
+				return ' '
+			}
+			return r
+		}, line))
 		if line == "" {
 			continue
 		}
+		lineDays, ranges, ok := parseHoursLine(line)
+		if !ok {
+			unparsed = append(unparsed, line)
+			continue
+		}
+		for _, d := range lineDays {
+			for _, r := range ranges {
+				if !containsRange(*days[d], r) {
+					*days[d] = append(*days[d], r)
+				}
+			}
+		}
+	}
+	return hours, unparsed
+}
+
+// parseHoursLine returns the days (indexes into models.HoursDays) a line is about and their
+// ranges; a closed day has none.
+func parseHoursLine(line string) (days []int, ranges []models.TimeRange, ok bool) {
+	if m := storedHoursLineRegex.FindStringSubmatch(line); m != nil {
+		day := dayIndex(m[1])
+		open := clock(m[2], m[3], "", false)
+		close := clock(m[4], m[5], "", true)
+		if day < 0 || open == "" || close == "" {
+			return nil, nil, false
+		}
+		return []int{day}, []models.TimeRange{{Open: open, Close: close}}, true
+	}
+
+	m := dayHoursLineRegex.FindStringSubmatch(line)
+	if m == nil {
+		return nil, nil, false
+	}
+	from, to := dayIndex(m[1]), dayIndex(m[1])
+	if m[2] != "" {
+		to = dayIndex(m[2])
+	}
+	if from < 0 || to < 0 {
+		return nil, nil, false
+	}
+	for d := from; ; d = (d + 1) % 7 {
+		days = append(days, d)
+		if d == to {
+			break
+		}
+	}
 
-		if normalized := parseNormalizedHourLine(line, dayAliases); normalized != "" {
-			result.OpenHours = append(result.OpenHours, normalized)
+	text := strings.ToLower(strings.TrimSpace(m[3]))
+	if strings.Contains(text, "closed") {
+		return days, nil, true
+	}
+	if strings.Contains(text, "24 hours") || strings.Contains(text, "open 24") {
+		return days, []models.TimeRange{{Open: "00:00", Close: "24:00"}}, true
+	}
+	for _, part := range strings.Split(text, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
 			continue
 		}
+		r, ok := parseTimeRange(part)
+		if !ok {
+			return nil, nil, false
+		}
+		ranges = append(ranges, r)
+	}
+	return days, ranges, len(ranges) > 0
+}
 
-		if normalized := parseLegacyHourLine(line, dayAliases); normalized != "" {
-			result.OpenHours = append(result.OpenHours, normalized)
+// parseTimeRange reads one lowercased range. An opening time without am/pm takes the
+// closing time's, as in Google's "2:00 – 6:00 pm"; a time with neither minutes nor am/pm
+// is ambiguous.
+func parseTimeRange(text string) (models.TimeRange, bool) {
+	m := timeRangeRegex.FindStringSubmatch(text)
+	if m == nil {
+		return models.TimeRange{}, false
+	}
+	openPeriod, closePeriod := m[3], m[6]
+	if openPeriod == "" && closePeriod != "" {
+		openPeriod = closePeriod
+		// "11:00 – 2:00 pm" opens at 11am; taking pm would open after closing
+		if open := atoi(m[1]); open != 12 && open > atoi(m[4]) {
+			openPeriod = "am"
 		}
 	}
+	if (m[2] == "" && openPeriod == "") || (m[5] == "" && closePeriod == "") {
+		return models.TimeRange{}, false
+	}
+	open := clock(m[1], m[2], openPeriod, false)
+	close := clock(m[4], m[5], closePeriod, true)
+	if open == "" || close == "" {
+		return models.TimeRange{}, false
+	}
+	return models.TimeRange{Open: open, Close: close}, true
+}
 
-	if len(result.OpenHours) == 0 {
-		return "", nil
+// clock returns "HH:MM" for an hour, optional minute and optional am/pm, or "" if it is
+// not a time of day. A closing time may be "24:00".
+func clock(hourStr, minuteStr, period string, closing bool) string {
+	if minuteStr == "" {
+		minuteStr = "00"
+	}
+	hour, err1 := strconv.Atoi(hourStr)
+	minute, err2 := strconv.Atoi(minuteStr)
+	if err1 != nil || err2 != nil || minute < 0 || minute > 59 {
+		return ""
+	}
+	if period != "" {
+		if hour < 1 || hour > 12 {
+			return ""
+		}
+		return convertTo24Hour(hourStr, minuteStr, period)
 	}
+	if closing && hour == 24 && minute == 0 {
+		return "24:00"
+	}
+	if hour < 0 || hour > 23 {
+		return ""
+	}
+	return fmt.Sprintf("%02d:%02d", hour, minute)
+}
 
-	bytes, err := json.Marshal(result)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal open hours: %w", err)
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func containsRange(ranges []models.TimeRange, r models.TimeRange) bool {
+	for _, existing := range ranges {
+		if existing == r {
+			return true
+		}
 	}
-	return string(bytes), nil
+	return false
 }
 
 // convertTo24Hour converts 12-hour time components into HH:MM (24-hour) format.
@@ -90,54 +223,37 @@ func convertTo24Hour(hourStr, minuteStr, period string) string {
 	return fmt.Sprintf("%02d:%02d", hour, minute)
 }
 
-func parseNormalizedHourLine(line string, dayAliases map[string]string) string {
-	normalizedPattern := regexp.MustCompile(`(?i)^([A-Za-z]{3})\s*-\s*(\d{1,2}):(\d{2})\s*-\s*(\d{1,2}):(\d{2})$`)
-	matches := normalizedPattern.FindStringSubmatch(line)
-	if len(matches) != 6 {
-		return ""
-	}
-
-	shortDay := normalizeDay(matches[1], dayAliases)
-	if shortDay == "" {
-		return ""
-	}
-
-	openTime := normalize24Hour(matches[2], matches[3])
-	closeTime := normalize24Hour(matches[4], matches[5])
-	if openTime == "" || closeTime == "" {
-		return ""
-	}
-
-	return fmt.Sprintf("%s-%s-%s", shortDay, openTime, closeTime)
+// dayAliases maps day names and abbreviations, lowercased, to their short form.
+var dayAliases = map[string]string{
+	"mon":       "Mon",
+	"monday":    "Mon",
+	"tue":       "Tue",
+	"tues":      "Tue",
+	"tuesday":   "Tue",
+	"wed":       "Wed",
+	"weds":      "Wed",
+	"wednesday": "Wed",
+	"thu":       "Thu",
+	"thur":      "Thu",
+	"thurs":     "Thu",
+	"thursday":  "Thu",
+	"fri":       "Fri",
+	"friday":    "Fri",
+	"sat":       "Sat",
+	"saturday":  "Sat",
+	"sun":       "Sun",
+	"sunday":    "Sun",
 }
 
-func parseLegacyHourLine(line string, dayAliases map[string]string) string {
-	hoursRegex := regexp.MustCompile(`^([\p{L}]+):\s*(.+)$`)
-	matches := hoursRegex.FindStringSubmatch(line)
-	if len(matches) != 3 {
-		return ""
-	}
-
-	shortDay := normalizeDay(matches[1], dayAliases)
-	if shortDay == "" {
-		return ""
-	}
-
-	hoursText := strings.TrimSpace(matches[2])
-	lowered := strings.ToLower(hoursText)
-	if strings.Contains(lowered, "closed") {
-		return ""
-	}
-	if strings.Contains(lowered, "24 hours") || strings.Contains(lowered, "open 24") {
-		return fmt.Sprintf("%s-00:00-24:00", shortDay)
-	}
-
-	openTime, closeTime := parseTimeRange(hoursText)
-	if openTime == "" || closeTime == "" {
-		return ""
+// dayIndex returns the index of a day name in models.HoursDays, or -1.
+func dayIndex(day string) int {
+	short := normalizeDay(day, dayAliases)
+	for i, d := range models.HoursDays {
+		if d == short {
+			return i
+		}
 	}
-
-	return fmt.Sprintf("%s-%s-%s", shortDay, openTime, closeTime)
+	return -1
 }
 
 func normalizeDay(day string, dayAliases map[string]string) string {
@@ -155,36 +271,3 @@ func normalizeDay(day string, dayAliases map[string]string) string {
 	}
 	return ""
 }
-
-func normalize24Hour(hourStr, minuteStr string) string {
-	hour := 0
-	minute := 0
-	if _, err := fmt.Sscanf(hourStr, "%d", &hour); err != nil {
-		return ""
-	}
-	if _, err := fmt.Sscanf(minuteStr, "%d", &minute); err != nil {
-		return ""
-	}
-	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
-		return ""
-	}
-	return fmt.Sprintf("%02d:%02d", hour, minute)
-}
-
-func parseTimeRange(text string) (string, string) {
-	ampmRegex := regexp.MustCompile(`(?i)(\d{1,2}):(\d{2})\s*(am|pm)`)
-	if matches := ampmRegex.FindAllStringSubmatch(text, -1); len(matches) >= 2 {
-		open := convertTo24Hour(matches[0][1], matches[0][2], matches[0][3])
-		close := convertTo24Hour(matches[1][1], matches[1][2], matches[1][3])
-		return open, close
-	}
-
-	twentyFourRegex := regexp.MustCompile(`(\d{1,2}):(\d{2})`)
-	if matches := twentyFourRegex.FindAllStringSubmatch(text, -1); len(matches) >= 2 {
-		open := normalize24Hour(matches[0][1], matches[0][2])
-		close := normalize24Hour(matches[1][1], matches[1][2])
-		return open, close
-	}
-
-	return "", ""
-}
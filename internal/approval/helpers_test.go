@@ -2,7 +2,11 @@ package approval
 
 import (
 	"encoding/json"
+	"reflect"
+	"strings"
 	"testing"
+
+	"assisted-venue-approval/internal/models"
 )
 
 func TestFormatOpenHoursFromCombined(t *testing.T) {
@@ -306,6 +310,75 @@ func TestConvertTo24Hour(t *testing.T) {
 	}
 }
 
+func TestParseHours(t *testing.T) {
+	hours, unparsed := ParseHours([]string{
+		"Mon-09:00-17:00",
+		" tue - 9:00 - 17:30 ",
+		"Wednesday: 11:00\u202fAM – 3:00\u202fPM, 6:00 – 10:30\u202fPM",
+		"Thu-Sat: 9am-5pm",
+		"Thursday: 9:00 AM – 5:00 PM", // repeats Thu from the range
+		"Sunday: Closed",
+		"Holiday: Closed",
+		"Weekdays 9-5",
+		"Mon-25:00-17:00",
+		"",
+	})
+	want := []string{
+		"Mon-09:00-17:00",
+		"Tue-09:00-17:30",
+		"Wed-11:00-15:00",
+		"Wed-18:00-22:30",
+		"Thu-09:00-17:00",
+		"Fri-09:00-17:00",
+		"Sat-09:00-17:00",
+	}
+	if got := hours.Lines(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("Lines() = %v, want %v", got, want)
+	}
+	if strings.Join(unparsed, "|") != "Holiday: Closed|Weekdays 9-5|Mon-25:00-17:00" {
+		t.Fatalf("unparsed = %q", unparsed)
+	}
+
+	for _, tt := range []struct{ line, want string }{
+		{"Fri: 10 – 2pm", "Fri-10:00-14:00"},
+		{"Fri: 12:00 – 2:00 PM", "Fri-12:00-14:00"},
+		{"Fri: 6pm to 2am", "Fri-18:00-02:00"},
+		{"Friday: Open 24 hours", "Fri-00:00-24:00"},
+		{"Sun-00:00-24:00", "Sun-00:00-24:00"},
+	} {
+		hours, _ := ParseHours([]string{tt.line})
+		if got := strings.Join(hours.Lines(), ","); got != tt.want {
+			t.Errorf("ParseHours(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestOpenHoursRoundTrip(t *testing.T) {
+	google := models.HoursFromGooglePeriods([]models.GooglePeriod{
+		{Open: models.GoogleTime{Day: 1, Time: "0900"}, Close: models.GoogleTime{Day: 1, Time: "1400"}},
+		{Open: models.GoogleTime{Day: 1, Time: "1700"}, Close: models.GoogleTime{Day: 1, Time: "2200"}},
+		{Open: models.GoogleTime{Day: 5, Time: "1800"}, Close: models.GoogleTime{Day: 6, Time: "0000"}},
+		{Open: models.GoogleTime{Day: 0, Time: "1000"}, Close: models.GoogleTime{Day: 0, Time: "1600"}},
+	})
+	stored, err := FormatOpenHoursFromCombined(google.Lines())
+	if err != nil {
+		t.Fatalf("FormatOpenHoursFromCombined() error = %v", err)
+	}
+	if want := `{"openhours":["Mon-09:00-14:00","Mon-17:00-22:00","Fri-18:00-24:00","Sun-10:00-16:00"],"note":""}`; stored != want {
+		t.Fatalf("stored = %s, want %s", stored, want)
+	}
+
+	reread, unparsed := ParseHours(models.ParseUserHours(&stored))
+	if len(unparsed) > 0 || !reflect.DeepEqual(reread, google) {
+		t.Fatalf("round trip = %+v (unparsed %v), want %+v", reread, unparsed, google)
+	}
+
+	allDay := models.HoursFromGooglePeriods([]models.GooglePeriod{{Open: models.GoogleTime{Day: 0, Time: "0000"}}})
+	if got := allDay.Lines(); len(got) != 7 || got[6] != "Sun-00:00-24:00" {
+		t.Fatalf("open 24/7 = %v", got)
+	}
+}
+
 func isValidHourFormat(hour string) bool {
 	if len(hour) < 11 {
 		return false
@@ -0,0 +1,135 @@
+package approval
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/geography"
+)
+
+// Hours flags raised by CheckHours.
+const (
+	HoursFlagOpen247          = "open_24_7"         // open around the clock every day
+	HoursFlagOvernight        = "overnight"         // a range closes after midnight
+	HoursFlagEmptyRange       = "empty_range"       // a range opens and closes at the same time
+	HoursFlagOverlap          = "overlap"           // ranges of one day overlap
+	HoursFlagNightOpening     = "night_opening"     // opens in the small hours; hours may be in another zone
+	HoursFlagNoTimezone       = "no_timezone"       // the venue has no time zone and none can be inferred
+	HoursFlagInvalidTimezone  = "invalid_timezone"  // the venue's time zone is not an IANA zone
+	HoursFlagTimezoneMismatch = "timezone_mismatch" // the venue's zone keeps a different clock than its location's
+)
+
+// HoursIssue is something about a venue's hours an approver should look at.
+type HoursIssue struct {
+	Flag    string `json:"flag"`
+	Day     string `json:"day,omitempty"` // short day name, for issues of one day
+	Message string `json:"message"`
+}
+
+// VenueTimezone returns the venue's time zone, or the zone inferred from the country in
+// its path and its coordinates when the field is empty or not a valid zone. inferred
+// reports the latter; "" means neither is known.
+func VenueTimezone(venue models.Venue, path string, lat, lng *float64) (zone string, inferred bool) {
+	if venue.Timezone != nil && geography.ValidTimezone(*venue.Timezone) {
+		return strings.TrimSpace(*venue.Timezone), false
+	}
+	return LocationTimezone(path, lat, lng), true
+}
+
+// LocationTimezone infers the time zone of a venue at path and coordinates; lat/lng may be
+// nil. Returns "" if it cannot be told.
+func LocationTimezone(path string, lat, lng *float64) string {
+	hasCoords := lat != nil && lng != nil && (*lat != 0 || *lng != 0)
+	var la, ln float64
+	if hasCoords {
+		la, ln = *lat, *lng
+	}
+	return geography.InferTimezone(geography.CountryFromPath(path), la, ln, hasCoords)
+}
+
+// CheckHours reviews a venue's hours against its time zone field. located is the zone
+// the venue's location suggests ("" if unknown); a venue zone keeping a different clock
+// means the hours will be shown at the wrong local time.
+func CheckHours(hours models.NormalizedHours, timezone, located string) []HoursIssue {
+	var issues []HoursIssue
+	add := func(flag, day, format string, args ...interface{}) {
+		issues = append(issues, HoursIssue{Flag: flag, Day: day, Message: fmt.Sprintf(format, args...)})
+	}
+
+	timezone = strings.TrimSpace(timezone)
+	switch {
+	case timezone == "" && located == "":
+		add(HoursFlagNoTimezone, "", "No time zone is set and none could be inferred from the location")
+	case timezone != "" && !geography.ValidTimezone(timezone):
+		add(HoursFlagInvalidTimezone, "", "Time zone %q is not a valid IANA zone", timezone)
+	case timezone != "" && located != "" && !sameClock(timezone, located):
+		add(HoursFlagTimezoneMismatch, "", "Time zone %s does not match the location's %s", timezone, located)
+	}
+
+	if hours.IsEmpty() {
+		return issues
+	}
+	allDay := true
+	for i, day := range hours.Days() {
+		name := models.HoursDays[i]
+		ranges := *day
+		if len(ranges) != 1 || ranges[0] != (models.TimeRange{Open: "00:00", Close: "24:00"}) {
+			allDay = false
+		}
+		for j, r := range ranges {
+			switch {
+			case r.Open == r.Close:
+				add(HoursFlagEmptyRange, name, "%s opens and closes at %s", name, r.Open)
+			case r.Close < r.Open:
+				add(HoursFlagOvernight, name, "%s %s–%s runs past midnight", name, r.Open, r.Close)
+			}
+			if r.Open >= "01:00" && r.Open < "05:00" {
+				add(HoursFlagNightOpening, name, "%s opens at %s local time; check the hours are not in another time zone", name, r.Open)
+			}
+			for _, other := range ranges[j+1:] {
+				if overlaps(r, other) {
+					add(HoursFlagOverlap, name, "%s %s–%s overlaps %s–%s", name, r.Open, r.Close, other.Open, other.Close)
+				}
+			}
+		}
+	}
+	if allDay {
+		// One issue for the week instead of seven
+		issues = append(issues, HoursIssue{Flag: HoursFlagOpen247, Message: "Open 24 hours every day"})
+	}
+	return issues
+}
+
+// overlaps reports whether two same-day ranges share time. Overnight ranges count up to
+// midnight.
+func overlaps(a, b models.TimeRange) bool {
+	end := func(r models.TimeRange) string {
+		if r.Close < r.Open {
+			return "24:00"
+		}
+		return r.Close
+	}
+	return a.Open < end(b) && b.Open < end(a)
+}
+
+// sameClock reports whether two zones show the same local time in both halves of the
+// year, so aliases and neighbours keeping the same time are not told apart.
+func sameClock(a, b string) bool {
+	la, errA := time.LoadLocation(a)
+	lb, errB := time.LoadLocation(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	year := time.Now().Year()
+	for _, month := range []time.Month{time.January, time.July} {
+		t := time.Date(year, month, 15, 12, 0, 0, 0, time.UTC)
+		_, offA := t.In(la).Zone()
+		_, offB := t.In(lb).Zone()
+		if offA != offB {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+package approval
+
+import (
+	"testing"
+
+	"assisted-venue-approval/internal/models"
+)
+
+func issueFlags(issues []HoursIssue) []string {
+	flags := make([]string, 0, len(issues))
+	for _, i := range issues {
+		flags = append(flags, i.Flag)
+	}
+	return flags
+}
+
+func TestCheckHours(t *testing.T) {
+	allWeek := func(r models.TimeRange) models.NormalizedHours {
+		var h models.NormalizedHours
+		for _, day := range h.Days() {
+			*day = []models.TimeRange{r}
+		}
+		return h
+	}
+	tests := []struct {
+		name     string
+		hours    models.NormalizedHours
+		timezone string
+		located  string
+		want     []string
+	}{
+		{"regular hours", allWeek(models.TimeRange{Open: "09:00", Close: "17:00"}), "Europe/Berlin", "Europe/Berlin", []string{}},
+		{"same clock alias", models.NormalizedHours{}, "Europe/Zurich", "Europe/Berlin", []string{}},
+		{"mismatch", models.NormalizedHours{}, "America/New_York", "Europe/Berlin", []string{HoursFlagTimezoneMismatch}},
+		{"invalid zone", models.NormalizedHours{}, "Mars/Olympus", "", []string{HoursFlagInvalidTimezone}},
+		{"no zone", models.NormalizedHours{}, "", "", []string{HoursFlagNoTimezone}},
+		{"inferred only", models.NormalizedHours{}, "", "Europe/Berlin", []string{}},
+		{"open 24/7", allWeek(models.TimeRange{Open: "00:00", Close: "24:00"}), "Europe/Berlin", "", []string{HoursFlagOpen247}},
+		{"overnight", models.NormalizedHours{Friday: []models.TimeRange{{Open: "20:00", Close: "02:00"}}}, "Europe/Berlin", "", []string{HoursFlagOvernight}},
+		{"empty range", models.NormalizedHours{Monday: []models.TimeRange{{Open: "09:00", Close: "09:00"}}}, "Europe/Berlin", "", []string{HoursFlagEmptyRange}},
+		{"overlap", models.NormalizedHours{Monday: []models.TimeRange{{Open: "09:00", Close: "14:00"}, {Open: "12:00", Close: "18:00"}}}, "Europe/Berlin", "", []string{HoursFlagOverlap}},
+		{"night opening", models.NormalizedHours{Sunday: []models.TimeRange{{Open: "03:00", Close: "11:00"}}}, "Europe/Berlin", "", []string{HoursFlagNightOpening}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := issueFlags(CheckHours(tt.hours, tt.timezone, tt.located))
+			if len(got) != len(tt.want) {
+				t.Fatalf("flags = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("flags = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestVenueTimezone(t *testing.T) {
+	venue := models.Venue{Timezone: strPtr("Not/AZone")}
+	zone, inferred := VenueTimezone(venue, "europe|germany|berlin", nil, nil)
+	if zone != "Europe/Berlin" || !inferred {
+		t.Fatalf("got %q inferred=%v, want Europe/Berlin inferred", zone, inferred)
+	}
+
+	venue.Timezone = strPtr("Europe/Berlin")
+	if zone, inferred := VenueTimezone(venue, "", nil, nil); zone != "Europe/Berlin" || inferred {
+		t.Fatalf("got %q inferred=%v, want the venue's zone", zone, inferred)
+	}
+
+	// Multi-zone countries need coordinates
+	if zone, _ := VenueTimezone(models.Venue{}, "north_america|usa", nil, nil); zone != "" {
+		t.Fatalf("expected no zone without coordinates, got %q", zone)
+	}
+}
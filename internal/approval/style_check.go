@@ -0,0 +1,222 @@
+package approval
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// Style rules reported by CheckStyle, named as in the rules file.
+const (
+	StyleRuleMaxLength    = "max_length"
+	StyleRuleMinLength    = "min_length"
+	StyleRuleFirstPerson  = "no_first_person"
+	StyleRulePricing      = "no_pricing"
+	StyleRuleSentenceCase = "sentence_case"
+	StyleRuleAllCaps      = "no_all_caps"
+)
+
+// minAllCapsLetters keeps acronyms such as "BBQ" or "NYC" out of the all-caps rule.
+const minAllCapsLetters = 4
+
+// StyleRules is the editorial style guide checked against a venue's final description
+// (STYLE_RULES_FILE). Defaults follow config/description_guidelines.md; a file only lists
+// the rules it changes.
+type StyleRules struct {
+	MaxLength    int      `yaml:"max_length" json:"max_length"` // characters; 0 = no limit
+	MinLength    int      `yaml:"min_length" json:"min_length"` // characters; 0 = no limit
+	FirstPerson  bool     `yaml:"no_first_person" json:"no_first_person"`
+	Pricing      bool     `yaml:"no_pricing" json:"no_pricing"`
+	SentenceCase bool     `yaml:"sentence_case" json:"sentence_case"` // every sentence starts with a capital
+	AllCaps      bool     `yaml:"no_all_caps" json:"no_all_caps"`     // no shouted words
+	AllowedCaps  []string `yaml:"allowed_caps,omitempty" json:"allowed_caps,omitempty"`
+}
+
+// StyleViolation is a style rule the description breaks.
+type StyleViolation struct {
+	Rule    string `json:"rule"`
+	Match   string `json:"match,omitempty"`
+	Message string `json:"message"`
+}
+
+// DefaultStyleRules is the built-in style guide.
+func DefaultStyleRules() StyleRules {
+	return StyleRules{
+		MaxLength:    300,
+		MinLength:    50,
+		FirstPerson:  true,
+		Pricing:      true,
+		SentenceCase: true,
+		AllCaps:      true,
+	}
+}
+
+var activeStyle atomic.Pointer[StyleRules]
+
+// ActiveStyleRules returns the rules Assemble checks descriptions against.
+func ActiveStyleRules() StyleRules {
+	if r := activeStyle.Load(); r != nil {
+		return *r
+	}
+	return DefaultStyleRules()
+}
+
+// SetStyleRules swaps the active rules. nil restores DefaultStyleRules.
+func SetStyleRules(r *StyleRules) {
+	if r == nil {
+		activeStyle.Store(nil)
+		return
+	}
+	cp := *r
+	activeStyle.Store(&cp)
+}
+
+// LoadStyleRules reads and validates a style rules file. Empty path returns (nil, nil).
+func LoadStyleRules(path string) (*StyleRules, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("read style rules: %w", err)
+	}
+	return ParseStyleRules(data)
+}
+
+// ParseStyleRules decodes YAML over DefaultStyleRules and validates the result. Unknown keys
+// are rejected so a misspelt rule does not silently stay at its default.
+func ParseStyleRules(data []byte) (*StyleRules, error) {
+	r := DefaultStyleRules()
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&r); err != nil && !errors.Is(err, io.EOF) {
+		return nil, errs.NewValidation("approval.ParseStyleRules", "invalid yaml", err)
+	}
+	if r.MaxLength < 0 || r.MinLength < 0 {
+		return nil, errs.NewValidation("approval.ParseStyleRules", "max_length and min_length must not be negative", nil)
+	}
+	if r.MaxLength > 0 && r.MinLength > r.MaxLength {
+		return nil, errs.NewValidation("approval.ParseStyleRules", fmt.Sprintf("min_length %d is above max_length %d", r.MinLength, r.MaxLength), nil)
+	}
+	return &r, nil
+}
+
+var (
+	// "I" and "us" only in these cases, so a roman numeral or "US" do not count
+	reStyleFirstPerson     = regexp.MustCompile(`\b(?i:i'm|i've|i'll|i'd|me|my|mine|myself|we|we're|we've|we'll|we'd|our|ours|ourselves)\b|\bI\b|\bus\b`)
+	reStylePriceAmount     = regexp.MustCompile(`[$€£¥₹]\s?\d|\d(?:[.,]\d+)?\s?(?:[$€£¥₹]|(?i:usd|eur|gbp|dollars?|euros?|pounds?)\b)`)
+	reStylePriceClaim      = regexp.MustCompile(`(?i)\b(?:cheap|cheapest|affordable|inexpensive|expensive|budget[- ]friendly|low[- ]cost|low prices?|reasonably priced|reasonable prices?|great value|best value|good value|value for money|bargain)\b`)
+	reStyleSentenceStart   = regexp.MustCompile(`(?:^|[.?!]\s+)(\p{Ll}\S*)`)
+	reStyleUpperWord       = regexp.MustCompile(`\b\p{Lu}[\p{Lu}'’-]*\b`)
+	reStyleSentenceAbbrevs = regexp.MustCompile(`(?i)\b(?:e\.g|i\.e|etc|approx|incl|st|no)\.\s+$`)
+)
+
+// CheckStyle returns the rules desc breaks. An empty description is not checked.
+func CheckStyle(desc string, rules StyleRules) []StyleViolation {
+	desc = strings.TrimSpace(desc)
+	if desc == "" {
+		return nil
+	}
+	var out []StyleViolation
+	add := func(rule, match, format string, a ...any) {
+		out = append(out, StyleViolation{Rule: rule, Match: match, Message: fmt.Sprintf(format, a...)})
+	}
+
+	n := utf8.RuneCountInString(desc)
+	if rules.MaxLength > 0 && n > rules.MaxLength {
+		add(StyleRuleMaxLength, "", "%d characters, at most %d allowed", n, rules.MaxLength)
+	}
+	if rules.MinLength > 0 && n < rules.MinLength {
+		add(StyleRuleMinLength, "", "%d characters, at least %d expected", n, rules.MinLength)
+	}
+	if rules.FirstPerson {
+		if m := uniqueMatches(reStyleFirstPerson, desc); len(m) > 0 {
+			add(StyleRuleFirstPerson, strings.Join(m, ", "), "Written in the first person (%s); describe the venue in the third person", strings.Join(m, ", "))
+		}
+	}
+	if rules.Pricing {
+		m := uniqueMatches(reStylePriceClaim, desc)
+		if reStylePriceAmount.MatchString(desc) {
+			m = append(m, "price amount")
+		}
+		if len(m) > 0 {
+			add(StyleRulePricing, strings.Join(m, ", "), "Makes pricing claims (%s)", strings.Join(m, ", "))
+		}
+	}
+	if rules.SentenceCase {
+		var lower []string
+		for _, loc := range reStyleSentenceStart.FindAllStringSubmatchIndex(desc, -1) {
+			if reStyleSentenceAbbrevs.MatchString(desc[:loc[2]]) {
+				continue
+			}
+			lower = append(lower, strings.TrimRight(desc[loc[2]:loc[3]], ",.;:!?"))
+		}
+		if len(lower) > 0 {
+			add(StyleRuleSentenceCase, strings.Join(lower, ", "), "Sentences start with a lower-case letter (%s)", strings.Join(lower, ", "))
+		}
+	}
+	if rules.AllCaps {
+		allowed := make(map[string]bool, len(rules.AllowedCaps))
+		for _, w := range rules.AllowedCaps {
+			allowed[strings.ToUpper(strings.TrimSpace(w))] = true
+		}
+		var shouted []string
+		seen := map[string]bool{}
+		for _, w := range reStyleUpperWord.FindAllString(desc, -1) {
+			if countLetters(w) < minAllCapsLetters || allowed[w] || seen[w] {
+				continue
+			}
+			seen[w] = true
+			shouted = append(shouted, w)
+		}
+		if len(shouted) > 0 {
+			add(StyleRuleAllCaps, strings.Join(shouted, ", "), "Words in capitals (%s)", strings.Join(shouted, ", "))
+		}
+	}
+	return out
+}
+
+// StyleRuleNames lists the rules of vs, for audit notes.
+func StyleRuleNames(vs []StyleViolation) string {
+	names := make([]string, len(vs))
+	for i, v := range vs {
+		names[i] = v.Rule
+	}
+	return strings.Join(names, ", ")
+}
+
+// uniqueMatches returns the distinct matches of re in s, in lower case, in order of appearance.
+func uniqueMatches(re *regexp.Regexp, s string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, m := range re.FindAllString(s, -1) {
+		m = strings.ToLower(m)
+		if !seen[m] {
+			seen[m] = true
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func countLetters(s string) int {
+	n := 0
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,67 @@
+package approval
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckStyle(t *testing.T) {
+	rules := DefaultStyleRules()
+	rules.AllowedCaps = []string{"IKEA"}
+	tests := []struct {
+		name string
+		desc string
+		want []string
+	}{
+		{"clean", "Vegan restaurant in the US serving bowls and wraps, e.g. tofu, tempeh and seitan. Located next to IKEA.", nil},
+		{"empty", "  ", nil},
+		{"too short", "Vegan cafe.", []string{StyleRuleMinLength}},
+		{"first person", "We serve vegan burgers and fries. Our kitchen is fully plant-based and nut free.", []string{StyleRuleFirstPerson}},
+		{"pricing", "Vegan bakery with affordable cakes and coffee, lunch menu from €9.50 on weekdays.", []string{StyleRulePricing}},
+		{"capitalization", "vegan diner serving burgers and shakes. open late on FRIDAYS and weekends for takeaway.", []string{StyleRuleSentenceCase, StyleRuleAllCaps}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, v := range CheckStyle(tt.desc, rules) {
+				got = append(got, v.Rule)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("CheckStyle rules = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	vs := CheckStyle("vegan diner serving burgers and shakes. open late on weekends for takeaway.", rules)
+	if len(vs) != 1 || vs[0].Match != "vegan, open" {
+		t.Fatalf("violations = %+v", vs)
+	}
+}
+
+func TestParseStyleRules(t *testing.T) {
+	r, err := ParseStyleRules([]byte("max_length: 500\nno_pricing: false\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Rules left out keep their defaults
+	if r.MaxLength != 500 || r.Pricing || r.MinLength != 50 || !r.FirstPerson {
+		t.Fatalf("rules = %+v", r)
+	}
+	for _, bad := range []string{"max_lenght: 500\n", "min_length: 400\nmax_length: 300\n", "min_length: -1\n"} {
+		if _, err := ParseStyleRules([]byte(bad)); err == nil {
+			t.Errorf("ParseStyleRules(%q) accepted an invalid file", bad)
+		}
+	}
+}
+
+func TestStyleRulesDist(t *testing.T) {
+	r, err := LoadStyleRules("../../style_rules.yaml.dist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := DefaultStyleRules()
+	want.AllowedCaps = []string{"IKEA"}
+	if !reflect.DeepEqual(*r, want) {
+		t.Fatalf("style_rules.yaml.dist = %+v, want the built-in rules", *r)
+	}
+}
@@ -0,0 +1,165 @@
+// Package archive moves old validation histories out of the hot table.
+package archive
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	errs "assisted-venue-approval/pkg/errors"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// runTimeout bounds one archival run; the next scheduled run picks up where it stopped.
+const runTimeout = 2 * time.Hour
+
+// ErrRunning is returned when an archival run is already in progress in this process.
+var ErrRunning = errs.NewConflict("archive.Start", "history archival already running", nil)
+
+var mArchived = metrics.Default.Counter("history_archived_total", "Validation histories moved to the archive table")
+
+// Store is the database side of archival (implemented by *database.DB).
+type Store interface {
+	ArchiveValidationHistoriesCtx(ctx context.Context, before time.Time, limit int) (int, error)
+	CountArchivableValidationHistoriesCtx(ctx context.Context, before time.Time) (int, error)
+}
+
+// Run describes one archival pass. Archived grows while the run is in progress.
+type Run struct {
+	Months     int        `json:"older_than_months"`
+	Cutoff     time.Time  `json:"cutoff"`
+	DryRun     bool       `json:"dry_run"`
+	Trigger    string     `json:"trigger"` // schedule|manual
+	Archived   int        `json:"archived"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Running    bool       `json:"running"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// Archiver moves validation histories older than the retention period to the archive table
+// in batches, on a schedule and on demand. Each venue's latest history is always kept.
+type Archiver struct {
+	store           Store
+	retentionMonths int
+	batch           int
+	now             func() time.Time
+
+	mu   sync.Mutex
+	last *Run
+}
+
+// New returns an archiver; retentionMonths 0 disables scheduled runs.
+func New(store Store, retentionMonths, batch int) *Archiver {
+	if batch <= 0 {
+		batch = 1000
+	}
+	return &Archiver{store: store, retentionMonths: retentionMonths, batch: batch, now: time.Now}
+}
+
+// RetentionMonths reports the configured retention (0 = scheduled archival off).
+func (a *Archiver) RetentionMonths() int { return a.retentionMonths }
+
+// Cutoff returns the processed_at bound for rows older than months.
+func (a *Archiver) Cutoff(months int) time.Time { return a.now().AddDate(0, -months, 0) }
+
+// Run archives every interval until ctx is cancelled. No-op when retention is 0.
+func (a *Archiver) Run(ctx context.Context, interval time.Duration) {
+	if a.retentionMonths <= 0 {
+		return
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		run, err := a.begin(a.retentionMonths, "schedule")
+		if err == nil {
+			a.archive(ctx, run)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// Preview counts the rows a run for months would move, without moving them.
+func (a *Archiver) Preview(ctx context.Context, months int) (Run, error) {
+	cutoff := a.Cutoff(months)
+	n, err := a.store.CountArchivableValidationHistoriesCtx(ctx, cutoff)
+	if err != nil {
+		return Run{}, err
+	}
+	now := a.now()
+	return Run{Months: months, Cutoff: cutoff, DryRun: true, Trigger: "manual", Archived: n, StartedAt: now, FinishedAt: &now}, nil
+}
+
+// Start begins a manual run in the background and returns it; poll with Last.
+func (a *Archiver) Start(months int) (Run, error) {
+	run, err := a.begin(months, "manual")
+	if err != nil {
+		return Run{}, err
+	}
+	snap := *run
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), runTimeout)
+		defer cancel()
+		a.archive(ctx, run)
+	}()
+	return snap, nil
+}
+
+// Last returns the most recent run, finished or not.
+func (a *Archiver) Last() (Run, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.last == nil {
+		return Run{}, false
+	}
+	return *a.last, true
+}
+
+func (a *Archiver) begin(months int, trigger string) (*Run, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.last != nil && a.last.Running {
+		return nil, ErrRunning
+	}
+	a.last = &Run{Months: months, Cutoff: a.Cutoff(months), Trigger: trigger, StartedAt: a.now(), Running: true}
+	return a.last, nil
+}
+
+// archive moves batches until one comes back short, ctx ends or the store fails.
+func (a *Archiver) archive(ctx context.Context, run *Run) {
+	var err error
+	for {
+		var n int
+		if n, err = a.store.ArchiveValidationHistoriesCtx(ctx, run.Cutoff, a.batch); err != nil {
+			break
+		}
+		mArchived.Inc(int64(n))
+		a.mu.Lock()
+		run.Archived += n
+		a.mu.Unlock()
+		if n < a.batch {
+			break
+		}
+		if err = ctx.Err(); err != nil {
+			break
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := a.now()
+	run.FinishedAt, run.Running = &now, false
+	if err != nil {
+		run.Error = err.Error()
+		log.Printf("archive: %s run stopped after %d histories: %v", run.Trigger, run.Archived, err)
+		return
+	}
+	if run.Archived > 0 {
+		log.Printf("archive: %s run moved %d histories processed before %s", run.Trigger, run.Archived, run.Cutoff.Format(time.RFC3339))
+	}
+}
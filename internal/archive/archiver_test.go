@@ -0,0 +1,103 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeStore struct {
+	mu        sync.Mutex
+	remaining int
+	failAfter int // fail on this call (1-based); 0 never
+	calls     int
+	before    time.Time
+}
+
+func (f *fakeStore) ArchiveValidationHistoriesCtx(_ context.Context, before time.Time, limit int) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	f.before = before
+	if f.failAfter > 0 && f.calls == f.failAfter {
+		return 0, errors.New("lock wait timeout")
+	}
+	n := limit
+	if f.remaining < n {
+		n = f.remaining
+	}
+	f.remaining -= n
+	return n, nil
+}
+
+func (f *fakeStore) CountArchivableValidationHistoriesCtx(_ context.Context, before time.Time) (int, error) {
+	return f.remaining, nil
+}
+
+func TestArchive(t *testing.T) {
+	now := time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		remaining int
+		failAfter int
+		wantCalls int
+		wantMoved int
+		wantErr   bool
+	}{
+		{"nothing to do", 0, 0, 1, 0, false},
+		{"partial batch", 7, 0, 1, 7, false},
+		{"exact batches need one empty pass", 20, 0, 3, 20, false},
+		{"several batches", 25, 0, 3, 25, false},
+		{"store error keeps progress", 25, 2, 2, 10, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := &fakeStore{remaining: tt.remaining, failAfter: tt.failAfter}
+			a := New(st, 6, 10)
+			a.now = func() time.Time { return now }
+
+			run, err := a.begin(6, "manual")
+			if err != nil {
+				t.Fatal(err)
+			}
+			a.archive(context.Background(), run)
+
+			got, _ := a.Last()
+			if got.Archived != tt.wantMoved || st.calls != tt.wantCalls || (got.Error != "") != tt.wantErr {
+				t.Fatalf("archived=%d calls=%d err=%q, want %d, %d, err=%v", got.Archived, st.calls, got.Error, tt.wantMoved, tt.wantCalls, tt.wantErr)
+			}
+			if got.Running || got.FinishedAt == nil {
+				t.Fatalf("run not finished: %+v", got)
+			}
+			if want := time.Date(2025, 12, 30, 12, 0, 0, 0, time.UTC); !st.before.Equal(want) {
+				t.Fatalf("cutoff = %v, want %v", st.before, want)
+			}
+		})
+	}
+}
+
+func TestStart_RejectsConcurrentRun(t *testing.T) {
+	a := New(&fakeStore{}, 0, 10)
+	if _, err := a.begin(3, "schedule"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Start(3); !errors.Is(err, ErrRunning) {
+		t.Fatalf("Start while running = %v, want ErrRunning", err)
+	}
+}
+
+func TestPreview(t *testing.T) {
+	a := New(&fakeStore{remaining: 42}, 0, 10)
+	run, err := a.Preview(context.Background(), 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !run.DryRun || run.Archived != 42 || run.Months != 12 {
+		t.Fatalf("preview = %+v", run)
+	}
+	if _, ok := a.Last(); ok {
+		t.Fatal("preview must not be recorded as a run")
+	}
+}
@@ -0,0 +1,216 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// APITokenKey is the context key for the *models.APIToken that authenticated the request
+const APITokenKey contextKey = "api_token"
+
+// API token scopes. Safe methods need read; replays, validation runs and any other
+// state change each need their own scope. Superadmin controls need admin and member data
+// requests privacy, which neither read nor write imply.
+const (
+	ScopeRead     = "read"
+	ScopeWrite    = "write"
+	ScopeValidate = "validate"
+	ScopeReplay   = "events:replay"
+	ScopeAdmin    = "admin"
+	ScopePrivacy  = "privacy"
+)
+
+// Scopes lists every scope in display order.
+var Scopes = []string{ScopeRead, ScopeWrite, ScopeValidate, ScopeReplay, ScopeAdmin, ScopePrivacy}
+
+const (
+	apiTokenPrefix    = "ava_"
+	apiTokenBytes     = 32
+	apiTokenShownLen  = 12 // "ava_" plus 8 characters of the secret
+	apiTokenTouchStep = time.Minute
+)
+
+var mAPITokenAuth = metrics.Default.CounterVec("api_token_auth_total", "API token authentication attempts", "result")
+
+// APITokenStore looks tokens up by hash. A missing token is (nil, nil).
+type APITokenStore interface {
+	GetAPITokenByHashCtx(ctx context.Context, hash string) (*models.APIToken, error)
+	TouchAPITokenCtx(ctx context.Context, id int64, at time.Time) error
+}
+
+// GenerateAPIToken returns a new plaintext token, the prefix shown in the admin UI and the
+// hash to store. The plaintext is only ever shown once, right after creation.
+func GenerateAPIToken() (plaintext, prefix, hash string, err error) {
+	b := make([]byte, apiTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", "", err
+	}
+	plaintext = apiTokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return plaintext, plaintext[:apiTokenShownLen], HashAPIToken(plaintext), nil
+}
+
+// HashAPIToken returns the hex SHA-256 of a plaintext token. Tokens are 256 random bits,
+// so a fast unsalted hash is enough to make a leaked table useless.
+func HashAPIToken(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+// ValidScope reports whether s is a known scope.
+func ValidScope(s string) bool {
+	return slices.Contains(Scopes, s)
+}
+
+// RequiredScope returns the scope a request needs. GraphQL queries are POSTed but only read;
+// a member's personal data needs privacy even to read.
+func RequiredScope(r *http.Request) string {
+	if strings.Contains(r.URL.Path, "/members/") {
+		return ScopePrivacy
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return ScopeRead
+	}
+	if strings.HasSuffix(r.URL.Path, "/graphql") {
+		return ScopeRead
+	}
+	switch {
+	case strings.Contains(r.URL.Path, "/circuits/") || strings.Contains(r.URL.Path, "/migrations/"):
+		return ScopeAdmin
+	case strings.Contains(r.URL.Path, "/events/replay"):
+		return ScopeReplay
+	case strings.HasSuffix(r.URL.Path, "/validate") || strings.Contains(r.URL.Path, "/validate/") ||
+		strings.HasSuffix(r.URL.Path, "/revalidate"):
+		return ScopeValidate
+	}
+	return ScopeWrite
+}
+
+// TokenAuthenticator authenticates "Authorization: Bearer <token>" requests on the
+// configured path prefixes, so CI jobs and partner integrations do not depend on
+// IP-based admin resolution. Other paths ignore the header and use IP auth as before.
+type TokenAuthenticator struct {
+	store    APITokenStore
+	prefixes []string
+	now      func() time.Time
+
+	mu      sync.Mutex
+	touched map[int64]time.Time
+}
+
+// NewTokenAuthenticator accepts tokens on paths starting with one of prefixes.
+func NewTokenAuthenticator(store APITokenStore, prefixes []string) *TokenAuthenticator {
+	return &TokenAuthenticator{
+		store:    store,
+		prefixes: prefixes,
+		now:      time.Now,
+		touched:  make(map[int64]time.Time),
+	}
+}
+
+// bearer returns the token when the request carries one on a token path.
+func (t *TokenAuthenticator) bearer(r *http.Request) (string, bool) {
+	h := r.Header.Get("Authorization")
+	if len(h) < 7 || !strings.EqualFold(h[:7], "bearer ") {
+		return "", false
+	}
+	for _, p := range t.prefixes {
+		if p != "" && strings.HasPrefix(r.URL.Path, p) {
+			return strings.TrimSpace(h[7:]), true
+		}
+	}
+	return "", false
+}
+
+// TokenError is a failed Authenticate. Status is the HTTP status to answer with: 401 for a
+// bad token, 403 for a missing scope and 503 when the lookup failed.
+type TokenError struct {
+	Status int
+	Msg    string
+}
+
+func (e *TokenError) Error() string { return e.Msg }
+
+// Authenticate checks plaintext and that it grants scope, and returns ctx carrying the token
+// and its creator's admin ID. Failures are *TokenError.
+func (t *TokenAuthenticator) Authenticate(ctx context.Context, plaintext, scope string) (context.Context, error) {
+	if !strings.HasPrefix(plaintext, apiTokenPrefix) {
+		return nil, t.fail(http.StatusUnauthorized, "malformed", "invalid API token")
+	}
+	tok, err := t.store.GetAPITokenByHashCtx(ctx, HashAPIToken(plaintext))
+	if err != nil {
+		log.Printf("api token lookup failed: %v", err)
+		return nil, t.fail(http.StatusServiceUnavailable, "error", "API token lookup failed")
+	}
+	now := t.now()
+	if tok == nil || !tok.Active(now) {
+		return nil, t.fail(http.StatusUnauthorized, "invalid", "invalid, expired or revoked API token")
+	}
+	if !tok.HasScope(scope) {
+		return nil, t.fail(http.StatusForbidden, "scope", "API token lacks scope "+scope)
+	}
+	mAPITokenAuth.With("ok").Inc()
+	t.touch(ctx, tok.ID, now)
+
+	ctx = context.WithValue(ctx, APITokenKey, tok)
+	return context.WithValue(ctx, AdminIDKey, tok.AdminID), nil
+}
+
+func (t *TokenAuthenticator) fail(status int, reason, msg string) error {
+	mAPITokenAuth.With(reason).Inc()
+	return &TokenError{Status: status, Msg: msg}
+}
+
+// serve authenticates the request and calls next with the token and its creator's admin ID
+// in the context. Invalid credentials never fall back to IP auth.
+func (t *TokenAuthenticator) serve(w http.ResponseWriter, r *http.Request, plaintext, clientIP string, next http.Handler) {
+	ctx, err := t.Authenticate(r.Context(), plaintext, RequiredScope(r))
+	if err != nil {
+		t.reject(w, err.(*TokenError))
+		return
+	}
+	ctx = context.WithValue(ctx, ClientIPKey, clientIP)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// touch records last use at most once a minute per token.
+func (t *TokenAuthenticator) touch(ctx context.Context, id int64, now time.Time) {
+	t.mu.Lock()
+	if last, ok := t.touched[id]; ok && now.Sub(last) < apiTokenTouchStep {
+		t.mu.Unlock()
+		return
+	}
+	t.touched[id] = now
+	t.mu.Unlock()
+	if err := t.store.TouchAPITokenCtx(ctx, id, now); err != nil {
+		log.Printf("api token %d: failed to record last use: %v", id, err)
+	}
+}
+
+func (t *TokenAuthenticator) reject(w http.ResponseWriter, e *TokenError) {
+	if e.Status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="ava"`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.Status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": e.Msg})
+}
+
+// GetAPITokenFromContext returns the token that authenticated the request, if any
+func GetAPITokenFromContext(ctx context.Context) (*models.APIToken, bool) {
+	tok, ok := ctx.Value(APITokenKey).(*models.APIToken)
+	return tok, ok
+}
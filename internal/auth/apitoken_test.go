@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+)
+
+type fakeTokenStore struct {
+	tokens  map[string]*models.APIToken
+	touches int
+}
+
+func (f *fakeTokenStore) GetAPITokenByHashCtx(_ context.Context, hash string) (*models.APIToken, error) {
+	return f.tokens[hash], nil
+}
+
+func (f *fakeTokenStore) TouchAPITokenCtx(context.Context, int64, time.Time) error {
+	f.touches++
+	return nil
+}
+
+func TestGenerateAPIToken(t *testing.T) {
+	plain, prefix, hash, err := GenerateAPIToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(plain, "ava_") || !strings.HasPrefix(plain, prefix) || len(prefix) != 12 {
+		t.Fatalf("plain=%q prefix=%q", plain, prefix)
+	}
+	if hash != HashAPIToken(plain) || len(hash) != 64 || strings.Contains(hash, plain) {
+		t.Fatalf("hash=%q", hash)
+	}
+	other, _, _, _ := GenerateAPIToken()
+	if other == plain {
+		t.Fatal("tokens repeat")
+	}
+}
+
+func TestRequiredScope(t *testing.T) {
+	tests := []struct {
+		method, path, want string
+	}{
+		{"GET", "/api/v1/venues/1/timeline", ScopeRead},
+		{"POST", "/api/v1/events/replay", ScopeReplay},
+		{"POST", "/validate", ScopeValidate},
+		{"POST", "/validate/batch", ScopeValidate},
+		{"POST", "/api/v1/validate/by-filter", ScopeValidate},
+		{"POST", "/venues/7/validate", ScopeValidate},
+		{"POST", "/venues/7/revalidate", ScopeValidate},
+		{"POST", "/api/decision/rules/dry-run", ScopeWrite},
+		{"POST", "/api/v1/graphql", ScopeRead},
+		{"DELETE", "/venues/7/draft", ScopeWrite},
+		{"POST", "/api/v1/circuits/openai/trip", ScopeAdmin},
+		{"POST", "/api/v1/migrations/apply", ScopeAdmin},
+		{"GET", "/api/v1/migrations", ScopeRead},
+		{"GET", "/api/v1/members/7/data", ScopePrivacy},
+		{"POST", "/api/v1/members/7/erase", ScopePrivacy},
+	}
+	for _, tt := range tests {
+		if got := RequiredScope(httptest.NewRequest(tt.method, tt.path, nil)); got != tt.want {
+			t.Errorf("%s %s: got %q want %q", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestAdminAuthMiddleware_APITokens(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	store := &fakeTokenStore{tokens: map[string]*models.APIToken{
+		HashAPIToken("ava_read"):    {ID: 1, AdminID: 42, Scopes: []string{ScopeRead}},
+		HashAPIToken("ava_replay"):  {ID: 2, AdminID: 43, Scopes: []string{ScopeRead, ScopeReplay}, ExpiresAt: &future},
+		HashAPIToken("ava_revoked"): {ID: 3, AdminID: 42, Scopes: []string{ScopeRead}, RevokedAt: &past},
+		HashAPIToken("ava_expired"): {ID: 4, AdminID: 42, Scopes: []string{ScopeRead}, ExpiresAt: &past},
+	}}
+	tokens := NewTokenAuthenticator(store, []string{"/api/"})
+	tokens.now = func() time.Time { return now }
+
+	// No admins.yaml: everything without a valid token is unauthorized
+	resolver := &AdminResolver{ipToID: map[string]int{}}
+	m := NewAdminAuthMiddleware(resolver, func(w http.ResponseWriter, ip string) { w.WriteHeader(http.StatusUnauthorized) })
+	m.UseTokens(tokens)
+
+	var gotAdmin int
+	var gotToken int64
+	h := m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAdmin, _ = GetAdminIDFromContext(r.Context())
+		tok, _ := GetAPITokenFromContext(r.Context())
+		gotToken = tok.ID
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		auth      string
+		want      int
+		wantAdmin int
+	}{
+		{"read token GET", "GET", "/api/v1/venues/1/timeline", "Bearer ava_read", http.StatusNoContent, 42},
+		{"lowercase scheme", "GET", "/api/stats", "bearer ava_read", http.StatusNoContent, 42},
+		{"missing scope", "POST", "/api/v1/events/replay", "Bearer ava_read", http.StatusForbidden, 0},
+		{"replay scope", "POST", "/api/v1/events/replay", "Bearer ava_replay", http.StatusNoContent, 43},
+		{"read token cannot export member data", "GET", "/api/v1/members/7/data", "Bearer ava_read", http.StatusForbidden, 0},
+		{"revoked", "GET", "/api/stats", "Bearer ava_revoked", http.StatusUnauthorized, 0},
+		{"expired", "GET", "/api/stats", "Bearer ava_expired", http.StatusUnauthorized, 0},
+		{"unknown", "GET", "/api/stats", "Bearer ava_nope", http.StatusUnauthorized, 0},
+		{"malformed", "GET", "/api/stats", "Bearer secret", http.StatusUnauthorized, 0},
+		{"outside token paths falls back to IP", "GET", "/venues/pending", "Bearer ava_read", http.StatusUnauthorized, 0},
+		{"no token falls back to IP", "GET", "/api/stats", "", http.StatusUnauthorized, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotAdmin = 0
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.auth != "" {
+				r.Header.Set("Authorization", tt.auth)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != tt.want || gotAdmin != tt.wantAdmin {
+				t.Fatalf("code=%d admin=%d, want %d/%d (%s)", w.Code, gotAdmin, tt.want, tt.wantAdmin, w.Body.String())
+			}
+		})
+	}
+	if gotToken != 2 {
+		t.Errorf("last token in context = %d", gotToken)
+	}
+	if store.touches != 2 {
+		t.Errorf("touches = %d, want one per token within a minute", store.touches)
+	}
+}
+
+func TestCSRFMiddleware_SkipsAPITokenRequests(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	h := NewCSRFMiddleware(CSRFConfig{}).Handler(ok)
+	r := httptest.NewRequest(http.MethodPost, "/validate", nil)
+	r = r.WithContext(context.WithValue(r.Context(), APITokenKey, &models.APIToken{ID: 1}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("code=%d", w.Code)
+	}
+}
@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"assisted-venue-approval/pkg/metrics"
+)
+
+const (
+	// CSRFCookieName holds the per-browser token. It is readable by page scripts on purpose:
+	// the layout copies it into the X-CSRF-Token header (double-submit cookie).
+	CSRFCookieName = "ava_csrf"
+	// CSRFHeaderName is checked on fetch/XHR requests.
+	CSRFHeaderName = "X-CSRF-Token"
+	// CSRFFormField is checked on plain form posts.
+	CSRFFormField = "csrf_token"
+
+	csrfTokenBytes = 32
+)
+
+var mCSRFRejected = metrics.Default.CounterVec("http_csrf_rejected_total", "State-changing requests rejected by the CSRF check", "reason")
+
+// CSRFConfig configures CSRFMiddleware.
+type CSRFConfig struct {
+	// CookiePath should be the public base path so the cookie is sent to every admin page.
+	CookiePath string
+	// Secure marks the cookie Secure. Requests arriving over TLS always get a Secure cookie.
+	Secure bool
+	// ExemptPrefixes skip the check for requests that carry an Authorization header, e.g.
+	// "/api/" for scripts using token auth. Browsers never attach that header cross-site,
+	// so these requests cannot be forged by another origin.
+	ExemptPrefixes []string
+}
+
+// CSRFMiddleware issues a random token cookie (SameSite=Strict) and requires it to be echoed
+// in a header or form field on every POST/PUT/PATCH/DELETE. Admin auth is IP-based, so
+// without this any page open in an admin's browser could approve or reject venues.
+type CSRFMiddleware struct {
+	cfg CSRFConfig
+}
+
+func NewCSRFMiddleware(cfg CSRFConfig) *CSRFMiddleware {
+	if cfg.CookiePath == "" {
+		cfg.CookiePath = "/"
+	}
+	return &CSRFMiddleware{cfg: cfg}
+}
+
+// Handler wraps the router. Safe methods only ensure the cookie exists.
+func (m *CSRFMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := ""
+		if c, err := r.Cookie(CSRFCookieName); err == nil && validCSRFToken(c.Value) {
+			token = c.Value
+		}
+
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+			if token == "" {
+				m.issue(w, r)
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if m.exempt(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if reason := m.check(r, token); reason != "" {
+			mCSRFRejected.With(reason).Inc()
+			if token == "" {
+				m.issue(w, r) // so a reload can recover
+			}
+			http.Error(w, "CSRF check failed ("+reason+"); reload the page and try again", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// check returns why the request fails, or "" when it passes.
+func (m *CSRFMiddleware) check(r *http.Request, token string) string {
+	if origin := r.Header.Get("Origin"); origin != "" && origin != "null" {
+		u, err := url.Parse(origin)
+		if err != nil || !(strings.EqualFold(u.Host, r.Host) || strings.EqualFold(u.Host, r.Header.Get("X-Forwarded-Host"))) {
+			return "cross_origin"
+		}
+	}
+	if token == "" {
+		return "missing_cookie"
+	}
+	sent := r.Header.Get(CSRFHeaderName)
+	if sent == "" {
+		sent = formToken(r)
+	}
+	if sent == "" {
+		return "missing_token"
+	}
+	if subtle.ConstantTimeCompare([]byte(sent), []byte(token)) != 1 {
+		return "token_mismatch"
+	}
+	return ""
+}
+
+func (m *CSRFMiddleware) exempt(r *http.Request) bool {
+	if _, ok := GetAPITokenFromContext(r.Context()); ok {
+		return true // bearer token already checked by the auth middleware
+	}
+	if r.Header.Get("Authorization") == "" {
+		return false
+	}
+	for _, p := range m.cfg.ExemptPrefixes {
+		if p != "" && strings.HasPrefix(r.URL.Path, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *CSRFMiddleware) issue(w http.ResponseWriter, r *http.Request) {
+	b := make([]byte, csrfTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     CSRFCookieName,
+		Value:    base64.RawURLEncoding.EncodeToString(b),
+		Path:     m.cfg.CookiePath,
+		Secure:   m.cfg.Secure || r.TLS != nil,
+		HttpOnly: false,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+// formToken reads the token from url-encoded or multipart form bodies only; JSON bodies
+// must use the header.
+func formToken(r *http.Request) string {
+	ct := r.Header.Get("Content-Type")
+	if !strings.HasPrefix(ct, "application/x-www-form-urlencoded") && !strings.HasPrefix(ct, "multipart/form-data") {
+		return ""
+	}
+	return r.PostFormValue(CSRFFormField)
+}
+
+func validCSRFToken(s string) bool {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	return err == nil && len(b) == csrfTokenBytes
+}
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestCSRFMiddleware(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	h := NewCSRFMiddleware(CSRFConfig{CookiePath: "/ava/", ExemptPrefixes: []string{"/api/"}}).Handler(ok)
+
+	// A GET issues the cookie
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/venues/1", nil))
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != CSRFCookieName || cookies[0].SameSite != http.SameSiteStrictMode || cookies[0].Path != "/ava/" {
+		t.Fatalf("cookie=%+v", cookies)
+	}
+	token := cookies[0].Value
+
+	form := url.Values{CSRFFormField: {token}}.Encode()
+	tests := []struct {
+		name   string
+		path   string
+		cookie string
+		header string
+		auth   string
+		origin string
+		form   bool
+		want   int
+	}{
+		{"header token", "/venues/1/approve", token, token, "", "", false, http.StatusNoContent},
+		{"form token", "/venues/1/approve", token, "", "", "", true, http.StatusNoContent},
+		{"same origin", "/venues/1/approve", token, token, "", "http://example.com", false, http.StatusNoContent},
+		{"no token", "/venues/1/approve", token, "", "", "", false, http.StatusForbidden},
+		{"wrong token", "/venues/1/approve", token, strings.Repeat("A", 43), "", "", false, http.StatusForbidden},
+		{"no cookie", "/venues/1/approve", "", token, "", "", false, http.StatusForbidden},
+		{"cross origin", "/venues/1/approve", token, token, "", "https://evil.example", false, http.StatusForbidden},
+		{"api with authorization", "/api/v1/events/replay", "", "", "Bearer x", "", false, http.StatusNoContent},
+		{"api without authorization", "/api/v1/events/replay", "", "", "", "", false, http.StatusForbidden},
+		{"authorization outside exempt paths", "/venues/1/approve", "", "", "Bearer x", "", false, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var r *http.Request
+			if tt.form {
+				r = httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(form))
+				r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			} else {
+				r = httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(`{}`))
+			}
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: CSRFCookieName, Value: tt.cookie})
+			}
+			if tt.header != "" {
+				r.Header.Set(CSRFHeaderName, tt.header)
+			}
+			if tt.auth != "" {
+				r.Header.Set("Authorization", tt.auth)
+			}
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Fatalf("code=%d want %d (%s)", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+}
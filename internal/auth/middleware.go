@@ -20,6 +20,7 @@ const (
 type AdminAuthMiddleware struct {
 	resolver           *AdminResolver
 	renderUnauthorized func(w http.ResponseWriter, ip string)
+	tokens             *TokenAuthenticator
 }
 
 // NewAdminAuthMiddleware creates a new admin authentication middleware
@@ -30,12 +31,25 @@ func NewAdminAuthMiddleware(resolver *AdminResolver, renderUnauthorized func(w h
 	}
 }
 
+// UseTokens lets bearer-token requests on the authenticator's paths skip IP resolution
+func (m *AdminAuthMiddleware) UseTokens(t *TokenAuthenticator) {
+	m.tokens = t
+}
+
 // Handler wraps an HTTP handler with admin authentication
 func (m *AdminAuthMiddleware) Handler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Always get client IP first to display on unauthorized page
 		clientIP := m.resolver.GetClientIP(r)
 
+		// Machine clients authenticate with an API token instead of their IP
+		if m.tokens != nil {
+			if plaintext, ok := m.tokens.bearer(r); ok {
+				m.tokens.serve(w, r, plaintext, clientIP, next)
+				return
+			}
+		}
+
 		// Check if config is loaded
 		if !m.resolver.IsLoaded() {
 			m.renderUnauthorized(w, clientIP)
@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// idleBucketTTL is how long an untouched bucket is kept before it is dropped. A full bucket
+// carries no state worth keeping, so anything idle longer than a refill is safe to forget.
+const idleBucketTTL = 10 * time.Minute
+
+var (
+	mRateLimited = metrics.Default.CounterVec("http_rate_limited_total", "Requests rejected with 429 by the rate limiter", "limiter")
+	mRateAllowed = metrics.Default.CounterVec("http_rate_allowed_total", "Requests admitted by the rate limiter", "limiter")
+)
+
+// RateLimiter is a per-caller token bucket for expensive endpoints. Callers are keyed by
+// API token, then admin ID when the auth middleware resolved one, otherwise by client IP.
+// Why: a double-clicked "Validate all" or a looping script can queue thousands of paid API calls.
+type RateLimiter struct {
+	name    string
+	rate    float64 // tokens per second
+	burst   float64
+	now     func() time.Time
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+}
+
+type bucket struct {
+	tokens float64
+	seen   time.Time
+}
+
+// NewRateLimiter allows perMinute requests per caller with bursts of up to burst.
+// perMinute <= 0 returns nil, and a nil limiter lets everything through.
+func NewRateLimiter(name string, perMinute, burst int) *RateLimiter {
+	if perMinute <= 0 {
+		return nil
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+	return &RateLimiter{
+		name:    name,
+		rate:    float64(perMinute) / 60,
+		burst:   float64(burst),
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Allow takes a token for key. When the bucket is empty it returns false and how long
+// until the next token.
+func (l *RateLimiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.sweepLocked(now)
+
+	if l.rate <= 0 {
+		return true, 0
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, seen: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.seen).Seconds()*l.rate)
+	b.seen = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+	return false, wait
+}
+
+// SetLimit changes the limit at runtime; perMinute <= 0 lets everything through. Buckets
+// keep their tokens, capped at the new burst. A nil limiter stays nil: a limit that was off
+// at startup needs a restart.
+func (l *RateLimiter) SetLimit(perMinute, burst int) {
+	if l == nil {
+		return
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rate = math.Max(0, float64(perMinute)/60)
+	l.burst = float64(burst)
+	for _, b := range l.buckets {
+		b.tokens = math.Min(b.tokens, l.burst)
+	}
+}
+
+func (l *RateLimiter) sweepLocked(now time.Time) {
+	if now.Sub(l.swept) < idleBucketTTL {
+		return
+	}
+	l.swept = now
+	for k, b := range l.buckets {
+		if now.Sub(b.seen) > idleBucketTTL {
+			delete(l.buckets, k)
+		}
+	}
+}
+
+// Wrap rejects over-limit requests with 429 and a Retry-After header. Must run after the
+// admin auth middleware so the admin ID is in the request context.
+func (l *RateLimiter) Wrap(next http.Handler) http.Handler {
+	if l == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ok, wait := l.Allow(callerKey(r))
+		if !ok {
+			mRateLimited.With(l.name).Inc()
+			secs := int(math.Ceil(wait.Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(secs))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"error":               fmt.Sprintf("rate limit exceeded for %s, retry in %ds", l.name, secs),
+				"retry_after_seconds": secs,
+			})
+			return
+		}
+		mRateAllowed.With(l.name).Inc()
+		next.ServeHTTP(w, r)
+	})
+}
+
+func callerKey(r *http.Request) string {
+	if tok, ok := GetAPITokenFromContext(r.Context()); ok {
+		return "token:" + strconv.FormatInt(tok.ID, 10)
+	}
+	if id, ok := GetAdminIDFromContext(r.Context()); ok {
+		return "admin:" + strconv.Itoa(id)
+	}
+	if ip, ok := GetClientIPFromContext(r.Context()); ok && ip != "" {
+		return "ip:" + ip
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return "ip:" + host
+	}
+	return "ip:" + r.RemoteAddr
+}
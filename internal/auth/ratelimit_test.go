@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Allow(t *testing.T) {
+	l := NewRateLimiter("test", 60, 2) // one token per second
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.Allow("admin:1"); !ok {
+			t.Fatalf("burst request %d rejected", i)
+		}
+	}
+	ok, wait := l.Allow("admin:1")
+	if ok || wait != time.Second {
+		t.Fatalf("third request: ok=%v wait=%v, want rejected with 1s", ok, wait)
+	}
+	if ok, _ := l.Allow("admin:2"); !ok {
+		t.Fatalf("other admins have their own bucket")
+	}
+
+	now = now.Add(1500 * time.Millisecond)
+	if ok, _ := l.Allow("admin:1"); !ok {
+		t.Fatalf("token should have refilled")
+	}
+	if ok, _ := l.Allow("admin:1"); ok {
+		t.Fatalf("only one token should have refilled")
+	}
+
+	now = now.Add(time.Hour)
+	l.Allow("admin:3")
+	if _, ok := l.buckets["admin:1"]; ok {
+		t.Fatalf("idle bucket should have been swept")
+	}
+}
+
+func TestRateLimiter_SetLimit(t *testing.T) {
+	l := NewRateLimiter("test", 60, 3)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+	l.Allow("admin:1")
+
+	l.SetLimit(60, 1)
+	if ok, _ := l.Allow("admin:1"); !ok {
+		t.Fatal("first request after lowering the burst rejected")
+	}
+	if ok, _ := l.Allow("admin:1"); ok {
+		t.Fatal("bucket kept more tokens than the new burst")
+	}
+
+	l.SetLimit(0, 1)
+	for i := 0; i < 5; i++ {
+		if ok, _ := l.Allow("admin:1"); !ok {
+			t.Fatalf("request %d rejected without a limit", i)
+		}
+	}
+
+	var off *RateLimiter
+	off.SetLimit(10, 1) // no-op
+}
+
+func TestRateLimiter_Wrap(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusAccepted) })
+	h := NewRateLimiter("validate", 1, 1).Wrap(next)
+
+	req := func(adminID int) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/validate", nil)
+		r = r.WithContext(context.WithValue(r.Context(), AdminIDKey, adminID))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+	if w := req(1); w.Code != http.StatusAccepted {
+		t.Fatalf("first request code=%d", w.Code)
+	}
+	w := req(1)
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") != "60" {
+		t.Fatalf("second request code=%d retry-after=%q", w.Code, w.Header().Get("Retry-After"))
+	}
+	if w := req(2); w.Code != http.StatusAccepted {
+		t.Fatalf("other admin code=%d", w.Code)
+	}
+
+	disabled := NewRateLimiter("off", 0, 0)
+	if disabled != nil {
+		t.Fatalf("perMinute 0 should disable the limiter")
+	}
+	w = httptest.NewRecorder()
+	disabled.Wrap(next).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/validate", nil))
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("disabled limiter blocked a request: %d", w.Code)
+	}
+}
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// Superadmins is the set of admins allowed to use incident controls (SUPERADMIN_IDS).
+// Requests made with an API token act as the token's creator, so a superadmin's token may
+// use them too, but only on paths RequiredScope gives the admin or privacy scope: a leaked
+// write token cannot trip circuits, run migrations or export a member's data.
+type Superadmins struct {
+	ids map[int]bool
+}
+
+// NewSuperadmins builds the set from admin IDs; an empty list grants nobody.
+func NewSuperadmins(ids []int) *Superadmins {
+	s := &Superadmins{ids: make(map[int]bool, len(ids))}
+	for _, id := range ids {
+		s.ids[id] = true
+	}
+	return s
+}
+
+// Is reports whether the admin resolved for ctx is a superadmin.
+func (s *Superadmins) Is(ctx context.Context) bool {
+	if s == nil {
+		return false
+	}
+	id, ok := GetAdminIDFromContext(ctx)
+	return ok && s.ids[id]
+}
+
+// Wrap rejects requests from anyone but a superadmin with 403. Token requests are also
+// rejected unless the token authenticated against the admin or privacy scope, which the token
+// middleware has checked by then; any other path wrapped here is closed to tokens. Must run
+// after the admin auth middleware so the admin ID is in the request context.
+func (s *Superadmins) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.Is(r.Context()) {
+			forbidden(w, "superadmin role required")
+			return
+		}
+		if tok, ok := GetAPITokenFromContext(r.Context()); ok {
+			if scope := RequiredScope(r); (scope != ScopeAdmin && scope != ScopePrivacy) || !tok.HasScope(scope) {
+				forbidden(w, "API tokens need the admin or privacy scope for superadmin controls")
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func forbidden(w http.ResponseWriter, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"assisted-venue-approval/internal/models"
+)
+
+func TestSuperadmins_Wrap(t *testing.T) {
+	supers := NewSuperadmins([]int{1})
+	h := supers.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	do := func(path string, adminID int, scopes ...string) int {
+		ctx := context.WithValue(context.Background(), AdminIDKey, adminID)
+		if scopes != nil {
+			ctx = context.WithValue(ctx, APITokenKey, &models.APIToken{AdminID: adminID, Scopes: scopes})
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, nil).WithContext(ctx))
+		return rec.Code
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		adminID int
+		scopes  []string
+		want    int
+	}{
+		{"superadmin by IP", "/api/v1/circuits/openai/trip", 1, nil, http.StatusOK},
+		{"other admin", "/api/v1/circuits/openai/trip", 2, nil, http.StatusForbidden},
+		{"superadmin token with admin scope", "/api/v1/migrations/apply", 1, []string{ScopeAdmin}, http.StatusOK},
+		{"superadmin token with write scope", "/api/v1/migrations/apply", 1, []string{ScopeWrite}, http.StatusForbidden},
+		{"admin scope on a path without it", "/api/v1/other", 1, []string{ScopeAdmin}, http.StatusForbidden},
+		{"privacy token erasing", "/api/v1/members/7/erase", 1, []string{ScopePrivacy}, http.StatusOK},
+		{"write token erasing", "/api/v1/members/7/erase", 1, []string{ScopeWrite}, http.StatusForbidden},
+		{"admin token erasing", "/api/v1/members/7/erase", 1, []string{ScopeAdmin}, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		if got := do(tt.path, tt.adminID, tt.scopes...); got != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
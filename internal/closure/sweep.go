@@ -0,0 +1,141 @@
+// Package closure watches approved venues for closures Google reports after approval.
+package closure
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+var (
+	mChecks = metrics.Default.CounterVec("closure_checks_total", "Approved venues checked for a permanent closure, by source of the status", "source")
+	mOpened = metrics.Default.Counter("closure_reviews_opened_total", "Closure reviews opened for approved venues Google reports as permanently closed")
+)
+
+// Store is the database side of the sweep (implemented by *database.DB).
+type Store interface {
+	ListClosureCandidatesCtx(ctx context.Context, checkedBefore time.Time, limit int) ([]models.ClosureCandidate, error)
+	RecordClosureCheckCtx(ctx context.Context, venueID int64, placeID, status string, checkedAt time.Time) error
+	// OpenClosureReviewCtx opens r unless the venue already has a review for the place.
+	OpenClosureReviewCtx(ctx context.Context, r *models.ClosureReview) (bool, error)
+}
+
+// StatusLookup returns Google's current business status of a place.
+type StatusLookup interface {
+	PlaceBusinessStatus(ctx context.Context, placeID string) (string, error)
+}
+
+// Result counts what one sweep did.
+type Result struct {
+	Checked int // venues whose status was read
+	Lookups int // of which from Google rather than the latest validation
+	Opened  int // closure reviews opened
+}
+
+// Sweeper re-checks the business status of approved venues every recheck period and opens a
+// closure review when Google reports the place as permanently closed. A status stored by a
+// validation newer than the period is used as is, so recently validated venues cost nothing.
+type Sweeper struct {
+	store   Store
+	places  StatusLookup
+	recheck time.Duration
+	batch   int
+	now     func() time.Time
+}
+
+// NewSweeper returns a sweeper that checks each approved venue once per recheckDays, batch
+// venues per query; recheckDays 0 disables it.
+func NewSweeper(store Store, places StatusLookup, recheckDays, batch int) *Sweeper {
+	if batch <= 0 {
+		batch = 200
+	}
+	return &Sweeper{store: store, places: places, recheck: time.Duration(recheckDays) * 24 * time.Hour, batch: batch, now: time.Now}
+}
+
+// Run sweeps every interval until ctx is cancelled. No-op when disabled.
+func (s *Sweeper) Run(ctx context.Context, interval time.Duration) {
+	if s.recheck <= 0 || s.places == nil {
+		return
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		res, err := s.Sweep(ctx)
+		if err != nil {
+			log.Printf("closure: sweep stopped after %d venues: %v", res.Checked, err)
+		}
+		if res.Checked > 0 {
+			log.Printf("closure: checked %d approved venues (%d Google lookups), opened %d closure reviews",
+				res.Checked, res.Lookups, res.Opened)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// Sweep checks every approved venue not checked within the recheck period. It stops at the
+// first Google error that is not about the place itself (rate limit, outage, open circuit);
+// the remaining venues are picked up by the next sweep.
+func (s *Sweeper) Sweep(ctx context.Context) (Result, error) {
+	var res Result
+	for {
+		now := s.now()
+		cutoff := now.Add(-s.recheck)
+		cands, err := s.store.ListClosureCandidatesCtx(ctx, cutoff, s.batch)
+		if err != nil {
+			return res, err
+		}
+		for _, c := range cands {
+			status, source := c.StoredStatus, "cache"
+			if c.StoredAt.Before(cutoff) {
+				status, err = s.places.PlaceBusinessStatus(ctx, c.PlaceID)
+				source = "google"
+				res.Lookups++
+				if err != nil {
+					if errs.Classify(err) != errs.ClassClient {
+						mChecks.With("error").Inc()
+						return res, err
+					}
+					// Google rejects the place ID itself (e.g. removed); record the check so the
+					// venue waits for the next period instead of being retried every sweep.
+					log.Printf("closure: venue %d place %s: %v", c.VenueID, c.PlaceID, err)
+					status, source = "", "error"
+				}
+			}
+			mChecks.With(source).Inc()
+			res.Checked++
+			if err := s.store.RecordClosureCheckCtx(ctx, c.VenueID, c.PlaceID, status, now); err != nil {
+				return res, err
+			}
+			if status != models.GoogleStatusClosedPermanently {
+				continue
+			}
+			opened, err := s.store.OpenClosureReviewCtx(ctx, &models.ClosureReview{
+				VenueID:        c.VenueID,
+				PlaceID:        c.PlaceID,
+				BusinessStatus: status,
+				CreatedAt:      now,
+			})
+			if err != nil {
+				return res, err
+			}
+			if opened {
+				mOpened.Inc(1)
+				res.Opened++
+			}
+		}
+		if len(cands) < s.batch {
+			return res, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+	}
+}
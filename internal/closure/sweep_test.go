@@ -0,0 +1,112 @@
+package closure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+type fakeStore struct {
+	cands   []models.ClosureCandidate
+	checked map[int64]string
+	reviews map[int64]string // venue -> place of its review
+	cutoff  time.Time
+}
+
+func (f *fakeStore) ListClosureCandidatesCtx(_ context.Context, before time.Time, limit int) ([]models.ClosureCandidate, error) {
+	f.cutoff = before
+	var out []models.ClosureCandidate
+	for _, c := range f.cands {
+		if _, done := f.checked[c.VenueID]; !done && len(out) < limit {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeStore) RecordClosureCheckCtx(_ context.Context, venueID int64, _, status string, _ time.Time) error {
+	f.checked[venueID] = status
+	return nil
+}
+
+func (f *fakeStore) OpenClosureReviewCtx(_ context.Context, r *models.ClosureReview) (bool, error) {
+	if f.reviews[r.VenueID] == r.PlaceID {
+		return false, nil
+	}
+	f.reviews[r.VenueID] = r.PlaceID
+	return true, nil
+}
+
+type fakeLookup struct {
+	status map[string]string
+	errs   map[string]error
+	calls  []string
+}
+
+func (f *fakeLookup) PlaceBusinessStatus(_ context.Context, placeID string) (string, error) {
+	f.calls = append(f.calls, placeID)
+	return f.status[placeID], f.errs[placeID]
+}
+
+func TestSweep(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	recent, old := now.AddDate(0, 0, -5), now.AddDate(0, -6, 0)
+	store := &fakeStore{
+		cands: []models.ClosureCandidate{
+			{VenueID: 1, PlaceID: "p1", StoredStatus: "OPERATIONAL", StoredAt: old},
+			{VenueID: 2, PlaceID: "p2", StoredStatus: "CLOSED_PERMANENTLY", StoredAt: recent},
+			{VenueID: 3, PlaceID: "p3", StoredStatus: "OPERATIONAL", StoredAt: recent},
+			{VenueID: 4, PlaceID: "gone", StoredAt: old},
+			{VenueID: 5, PlaceID: "p5", StoredStatus: "OPERATIONAL", StoredAt: old},
+		},
+		checked: map[int64]string{},
+		reviews: map[int64]string{5: "p5"},
+	}
+	places := &fakeLookup{
+		status: map[string]string{"p1": "CLOSED_PERMANENTLY", "p5": "CLOSED_PERMANENTLY"},
+		errs:   map[string]error{"gone": errs.NewExternalStatus("test", "google", 404, 0, nil)},
+	}
+	s := NewSweeper(store, places, 30, 2)
+	s.now = func() time.Time { return now }
+
+	res, err := s.Sweep(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Checked != 5 || res.Lookups != 3 || res.Opened != 2 {
+		t.Fatalf("result = %+v", res)
+	}
+	if want := now.AddDate(0, 0, -30); !store.cutoff.Equal(want) {
+		t.Fatalf("cutoff = %s, want %s", store.cutoff, want)
+	}
+	// Venue 2 used the status of its recent validation; venue 5 already had a review.
+	if store.reviews[1] != "p1" || store.reviews[2] != "p2" || store.reviews[3] != "" {
+		t.Fatalf("reviews = %v", store.reviews)
+	}
+	if st, ok := store.checked[4]; !ok || st != "" {
+		t.Fatalf("unknown place should be recorded without status, got %q (%v)", st, ok)
+	}
+}
+
+func TestSweep_StopsOnOutage(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	store := &fakeStore{
+		cands: []models.ClosureCandidate{
+			{VenueID: 1, PlaceID: "p1", StoredAt: now.AddDate(-1, 0, 0)},
+			{VenueID: 2, PlaceID: "p2", StoredAt: now.AddDate(-1, 0, 0)},
+		},
+		checked: map[int64]string{},
+		reviews: map[int64]string{},
+	}
+	places := &fakeLookup{errs: map[string]error{"p1": errs.NewExternalStatus("test", "google", 429, 0, nil)}}
+	s := NewSweeper(store, places, 30, 10)
+	s.now = func() time.Time { return now }
+
+	res, err := s.Sweep(context.Background())
+	if err == nil || res.Checked != 0 || len(places.calls) != 1 || len(store.checked) != 0 {
+		t.Fatalf("Sweep = %+v, %v after %v; checked %v", res, err, places.calls, store.checked)
+	}
+}
@@ -7,8 +7,9 @@ import "time"
 
 const (
 	// Database
-	DBReadTimeoutDefault  = 8 * time.Second
-	DBWriteTimeoutDefault = 6 * time.Second
+	DBReadTimeoutDefault        = 8 * time.Second
+	DBWriteTimeoutDefault       = 6 * time.Second
+	DBSlowQueryThresholdDefault = 500 * time.Millisecond
 
 	// Google Maps
 	GoogleMapsOperationTimeout  = 10 * time.Second
@@ -21,6 +22,7 @@ const (
 	AIScorerOperationTimeout  = 50 * time.Second
 	AIScorerOpenFor           = 45 * time.Second
 	AIScorerSlowCallThreshold = 20 * time.Second
+	AIScorerContextBudget     = 4000 // tokens per scoring call, prompt and reply
 
 	// Health
 	HealthTimeoutDefault = 30 * time.Second
@@ -37,6 +39,15 @@ const (
 
 	// Events store SQL operations
 	EventsSQLTimeoutDefault = 5 * time.Second
+	// How often the outbox dispatcher moves committed events to venue_events
+	EventsOutboxIntervalDefault = 2 * time.Second
+	// How often projections catch up with venue_events
+	EventsProjectionIntervalDefault = 15 * time.Second
+
+	// How often editor feedback IPs past FEEDBACK_IP_RETENTION_DAYS are cleared
+	FeedbackIPScrubIntervalDefault = 24 * time.Hour
+	// How often approved venues due under CLOSURE_RECHECK_DAYS are checked for closures
+	ClosureSweepIntervalDefault = 24 * time.Hour
 
 	// Monitoring
 	MonitoringIntervalDefault = 5 * time.Second
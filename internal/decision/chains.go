@@ -0,0 +1,99 @@
+package decision
+
+import (
+	"strings"
+	"unicode"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// Chain review policies.
+const (
+	ChainAutoApprove  = "auto_approve"  // approve with complete data and a Google match
+	ChainManualReview = "manual_review" // always send to an editor
+	ChainDefault      = "default"       // decide as usual; only tag the venue
+)
+
+// ChainRule names a chain or franchise and how its venues are decided. A venue belongs to
+// the chain when its name or its Google place name contains Name or an alias as whole words;
+// with GoogleTypes set, the Google place must also have one of those types.
+type ChainRule struct {
+	Name        string   `yaml:"name" json:"name"`
+	Aliases     []string `yaml:"aliases,omitempty" json:"aliases,omitempty"`
+	GoogleTypes []string `yaml:"google_types,omitempty" json:"google_types,omitempty"`
+	Policy      string   `yaml:"policy,omitempty" json:"policy,omitempty"` // empty = default
+	Reason      string   `yaml:"reason,omitempty" json:"reason,omitempty"`
+}
+
+var knownChainPolicies = map[string]bool{"": true, ChainAutoApprove: true, ChainManualReview: true, ChainDefault: true}
+
+// normalizeChainName lowercases s and turns punctuation into spaces, with single spaces
+// around it so whole-word containment is a plain substring test.
+func normalizeChainName(s string) string {
+	f := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '&'
+	})
+	if len(f) == 0 {
+		return ""
+	}
+	return " " + strings.Join(f, " ") + " "
+}
+
+func (c *ChainRule) matchesName(name string) bool {
+	n := normalizeChainName(name)
+	if n == "" {
+		return false
+	}
+	for _, a := range append([]string{c.Name}, c.Aliases...) {
+		if a := normalizeChainName(a); a != "" && strings.Contains(n, a) {
+			return true
+		}
+	}
+	return false
+}
+
+// chainFor returns the first chain rule the venue matches, using its Google data when present.
+func (r *Rules) chainFor(venue models.Venue) *ChainRule {
+	if r == nil {
+		return nil
+	}
+	var gName string
+	var gTypes []string
+	if venue.GoogleData != nil {
+		gName, gTypes = venue.GoogleData.Name, venue.GoogleData.Types
+	}
+	for i := range r.Chains {
+		c := &r.Chains[i]
+		if !c.matchesName(venue.Name) && (gName == "" || !c.matchesName(gName)) {
+			continue
+		}
+		if len(c.GoogleTypes) > 0 && !anyIn(c.GoogleTypes, gTypes) {
+			continue
+		}
+		return c
+	}
+	return nil
+}
+
+// ChainForName returns the chain a venue name belongs to by name alone, or "" when none.
+// Used by reports, which do not have the Google data the decision saw.
+func (r *Rules) ChainForName(name string) string {
+	if r == nil {
+		return ""
+	}
+	for i := range r.Chains {
+		if r.Chains[i].matchesName(name) {
+			return r.Chains[i].Name
+		}
+	}
+	return ""
+}
+
+func anyIn(want, have []string) bool {
+	for _, w := range want {
+		if containsString(have, w) {
+			return true
+		}
+	}
+	return false
+}
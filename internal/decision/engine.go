@@ -2,27 +2,34 @@ package decision
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
+	"sync"
 	"time"
 
-	"assisted-venue-approval/internal/constants"
 	"assisted-venue-approval/internal/domain/specs"
 	"assisted-venue-approval/internal/models"
 	"assisted-venue-approval/internal/trust"
+	errs "assisted-venue-approval/pkg/errors"
 	"assisted-venue-approval/pkg/events"
 )
 
 // DecisionEngine handles venue approval/rejection logic with special case handling
 type DecisionEngine struct {
+	mu                  sync.RWMutex
 	approvalThreshold   int
 	rejectionThreshold  int
 	enableSpecialCases  bool
 	enableAuthorityMode bool
+	geofenceReview      bool
 	eventStore          events.EventStore
 	approvalSpec        specs.Specification[models.Venue]
 	tc                  *trust.Calculator
+	rules               *Rules // optional declarative overrides; nil = built-in behaviour
 }
 
 // DecisionConfig configures the decision engine behavior
@@ -31,6 +38,7 @@ type DecisionConfig struct {
 	RejectionThreshold  int  // Score threshold for auto-rejection (default: 50)
 	EnableSpecialCases  bool // Enable Korean/Chinese venue special handling
 	EnableAuthorityMode bool // Enable venue owner/ambassador authority rules
+	GeofenceForceReview bool // Send venues whose coordinates are outside the path country to manual review
 }
 
 // DecisionResult contains the final decision with detailed reasoning
@@ -46,6 +54,8 @@ type DecisionResult struct {
 	ProcessedAt          time.Time                `json:"processed_at"`
 	RequiresManualReview bool                     `json:"requires_manual_review"`
 	ReviewReason         string                   `json:"review_reason,omitempty"`
+	Chain                string                   `json:"chain,omitempty"` // matched chain rule name
+	Explanation          *Explanation             `json:"explanation,omitempty"`
 }
 
 // AuthorityInfo tracks user authority for decision making
@@ -75,6 +85,7 @@ func DefaultDecisionConfig() DecisionConfig {
 		RejectionThreshold:  50,
 		EnableSpecialCases:  true,
 		EnableAuthorityMode: true,
+		GeofenceForceReview: true,
 	}
 }
 
@@ -85,16 +96,126 @@ func NewDecisionEngine(config DecisionConfig) *DecisionEngine {
 		rejectionThreshold:  config.RejectionThreshold,
 		enableSpecialCases:  config.EnableSpecialCases,
 		enableAuthorityMode: config.EnableAuthorityMode,
+		geofenceReview:      config.GeofenceForceReview,
 		approvalSpec:        specs.BuildApprovalSpecFromEnv(),
 		tc:                  trust.NewDefault(),
 	}
 }
 
-// ApplyConfig allows runtime updates of thresholds.
-func (de *DecisionEngine) ApplyConfig(approvalThreshold int) {
-	if approvalThreshold > 0 && approvalThreshold <= 100 {
-		de.approvalThreshold = approvalThreshold
+// ApplyConfig allows runtime updates of thresholds. Values outside 1-100 are ignored; one
+// that would invert the policy merged with the active rules is refused.
+func (de *DecisionEngine) ApplyConfig(approvalThreshold int) error {
+	if approvalThreshold <= 0 || approvalThreshold > 100 {
+		return nil
 	}
+	de.mu.Lock()
+	defer de.mu.Unlock()
+	if err := mergePolicy(approvalThreshold, de.rejectionThreshold, de.rules).validate(); err != nil {
+		return err
+	}
+	de.approvalThreshold = approvalThreshold
+	return nil
+}
+
+// ApplyRules swaps the active rules file. Callers must validate first (LoadRules does);
+// ApplyRules only rejects rules whose thresholds, merged with the engine's, would invert.
+// Passing nil reverts to built-in behaviour.
+func (de *DecisionEngine) ApplyRules(r *Rules) error {
+	de.mu.Lock()
+	defer de.mu.Unlock()
+	if err := mergePolicy(de.approvalThreshold, de.rejectionThreshold, r).validate(); err != nil {
+		return err
+	}
+	de.rules = r
+	return nil
+}
+
+// Rules returns the active rules, or nil when none are loaded.
+func (de *DecisionEngine) Rules() *Rules {
+	de.mu.RLock()
+	defer de.mu.RUnlock()
+	return de.rules
+}
+
+// Version identifies the engine's thresholds, switches and rules: it changes whenever
+// they would decide differently. Short hex, stable across restarts.
+func (de *DecisionEngine) Version() string {
+	de.mu.RLock()
+	cfg := struct {
+		Approval  int    `json:"approval"`
+		Rejection int    `json:"rejection"`
+		Special   bool   `json:"special_cases"`
+		Authority bool   `json:"authority_mode"`
+		Geofence  bool   `json:"geofence_review"`
+		Rules     *Rules `json:"rules"`
+	}{de.approvalThreshold, de.rejectionThreshold, de.enableSpecialCases, de.enableAuthorityMode, de.geofenceReview, de.rules}
+	de.mu.RUnlock()
+	b, _ := json.Marshal(cfg)
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:8])
+}
+
+// EligibilityThreshold is the minimum history score required before a venue may go active.
+func (de *DecisionEngine) EligibilityThreshold() int {
+	return de.Rules().eligibility()
+}
+
+// policy is the effective rule set for one evaluation: engine config merged with rules.
+type policy struct {
+	approval  int
+	rejection int
+	trustGate float64
+	rules     *Rules
+}
+
+func (de *DecisionEngine) policyFor(r *Rules) policy {
+	de.mu.RLock()
+	defer de.mu.RUnlock()
+	return mergePolicy(de.approvalThreshold, de.rejectionThreshold, r)
+}
+
+// mergePolicy overlays the thresholds r sets on the engine's.
+func mergePolicy(approval, rejection int, r *Rules) policy {
+	p := policy{approval: approval, rejection: rejection, rules: r}
+	if r != nil {
+		if r.Thresholds.Approval > 0 {
+			p.approval = r.Thresholds.Approval
+		}
+		if r.Thresholds.Rejection > 0 {
+			p.rejection = r.Thresholds.Rejection
+		}
+	}
+	p.trustGate = r.trustGate()
+	return p
+}
+
+// validate checks the merged thresholds. Rules.Validate can only compare thresholds the file
+// sets; one that sets only approval may still fall to or below the engine's rejection. A
+// category approval threshold below eligibility would approve venues that are then held
+// for manual review when written, so it is refused as well.
+func (p policy) validate() error {
+	var problems []string
+	if p.rejection >= p.approval {
+		problems = append(problems, fmt.Sprintf("effective rejection threshold (%d) must be below the effective approval threshold (%d)", p.rejection, p.approval))
+	}
+	if p.rules != nil {
+		eligibility := p.rules.eligibility()
+		for i, c := range p.rules.Categories {
+			if c.Approval <= 0 {
+				continue
+			}
+			if c.Approval < eligibility {
+				problems = append(problems, fmt.Sprintf("categories[%d].approval (%d) must be at least the eligibility threshold (%d)", i, c.Approval, eligibility))
+			}
+			if c.Approval <= p.rejection {
+				problems = append(problems, fmt.Sprintf("categories[%d].approval (%d) must be above the effective rejection threshold (%d)", i, c.Approval, p.rejection))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return errs.NewValidation("decision.policy.validate", strings.Join(problems, "; "), nil)
+	}
+	return nil
 }
 
 // SetEventStore wires an EventStore for publishing decisions.
@@ -102,6 +223,41 @@ func (de *DecisionEngine) SetEventStore(es events.EventStore) { de.eventStore =
 
 // MakeDecision processes a venue with user information and returns a final decision
 func (de *DecisionEngine) MakeDecision(ctx context.Context, venue models.Venue, user models.User, validationResult *models.ValidationResult) *DecisionResult {
+	result := de.Decide(ctx, venue, user, validationResult)
+
+	// TODO: consider retries/backoff here if event store is flaky
+	if de.eventStore != nil {
+		de.publish(ctx, venue.ID, result)
+	}
+
+	return result
+}
+
+// Decide makes the decision MakeDecision makes without publishing its event, for dry runs
+// that leave no trace in the event log.
+func (de *DecisionEngine) Decide(ctx context.Context, venue models.Venue, user models.User, validationResult *models.ValidationResult) *DecisionResult {
+	result := de.evaluate(ctx, venue, user, validationResult, de.policyFor(de.Rules()))
+
+	log.Printf("Decision for venue %d: %s (score: %d→%d) - %s",
+		venue.ID, result.FinalStatus, validationResult.Score, result.FinalScore, result.DecisionReason)
+	return result
+}
+
+// DryRun evaluates a decision against candidate rules without logging or publishing events.
+// Use it to preview a rules change before writing the file.
+func (de *DecisionEngine) DryRun(ctx context.Context, venue models.Venue, user models.User, validationResult *models.ValidationResult, candidate *Rules) (*DecisionResult, error) {
+	if err := candidate.Validate(); err != nil {
+		return nil, err
+	}
+	p := de.policyFor(candidate)
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	return de.evaluate(ctx, venue, user, validationResult, p), nil
+}
+
+// evaluate is the side-effect free core of MakeDecision.
+func (de *DecisionEngine) evaluate(ctx context.Context, venue models.Venue, user models.User, validationResult *models.ValidationResult, p policy) *DecisionResult {
 	startTime := time.Now()
 
 	result := &DecisionResult{
@@ -111,7 +267,26 @@ func (de *DecisionEngine) MakeDecision(ctx context.Context, venue models.Venue,
 		FinalScore:       validationResult.Score,
 	}
 
+	var fired []FiredRule
 	assess := de.tc.Assess(user, venue.Location)
+	if o := p.rules.trustOverride(assess.Authority); o != nil {
+		if o.Bonus != nil {
+			fired = append(fired, FiredRule{
+				Rule:   "rules.trust_override." + assess.Authority,
+				Effect: "bonus",
+				Detail: fmt.Sprintf("bonus %d -> %d", assess.Bonus, *o.Bonus),
+			})
+			assess.Bonus = *o.Bonus
+		}
+		if o.MinTrust != nil && assess.Trust < *o.MinTrust {
+			fired = append(fired, FiredRule{
+				Rule:   "rules.trust_override." + assess.Authority,
+				Effect: "trust",
+				Detail: fmt.Sprintf("trust %.2f -> %.2f", assess.Trust, *o.MinTrust),
+			})
+			assess.Trust = *o.MinTrust
+		}
+	}
 
 	authority := &AuthorityInfo{
 		UserID:          user.ID,
@@ -128,7 +303,6 @@ func (de *DecisionEngine) MakeDecision(ctx context.Context, venue models.Venue,
 		enhancedScore = 100
 	}
 	result.FinalScore = enhancedScore
-	fmt.Printf("decision: id=%d score=%d\n", venue.ID, enhancedScore) // debug
 
 	specialCases := de.detectSpecialCases(venue)
 	qualityFlags := de.detectQualityFlags(venue, validationResult, authority)
@@ -136,47 +310,73 @@ func (de *DecisionEngine) MakeDecision(ctx context.Context, venue models.Venue,
 	result.SpecialCaseFlags = specialCases
 	result.QualityFlags = qualityFlags
 
-	decision := de.determineStatus(ctx, venue, user, enhancedScore, authority, specialCases, qualityFlags)
+	chain := p.rules.chainFor(venue)
+	if chain != nil {
+		result.Chain = chain.Name
+	}
+
+	decision := de.determineStatus(ctx, venue, user, enhancedScore, authority, specialCases, qualityFlags, chain, p)
 	result.FinalStatus = decision.Status
 	result.DecisionReason = decision.Reason
 	result.RequiresManualReview = decision.RequiresReview
 	result.ReviewReason = decision.ReviewReason
 
-	log.Printf("Decision for venue %d: %s (score: %d→%d) - %s",
-		venue.ID, result.FinalStatus, validationResult.Score, enhancedScore, result.DecisionReason)
-
-	// TODO: consider retries/backoff here if event store is flaky
-	if de.eventStore != nil {
-		flags := append([]string{}, result.SpecialCaseFlags...)
-		flags = append(flags, result.QualityFlags...)
-		switch result.FinalStatus {
-		case "approved":
-			_ = de.eventStore.Append(ctx, events.VenueApproved{
-				Base:   events.Base{Ts: time.Now(), VID: venue.ID},
-				Reason: result.DecisionReason,
-				Score:  result.FinalScore,
-				Flags:  flags,
-			})
-		case "rejected":
-			_ = de.eventStore.Append(ctx, events.VenueRejected{
-				Base:   events.Base{Ts: time.Now(), VID: venue.ID},
-				Reason: result.DecisionReason,
-				Score:  result.FinalScore,
-				Flags:  flags,
-			})
-		case "manual_review":
-			_ = de.eventStore.Append(ctx, events.VenueRequiresManualReview{
-				Base:   events.Base{Ts: time.Now(), VID: venue.ID},
-				Reason: result.ReviewReason,
-				Score:  result.FinalScore,
-				Flags:  flags,
-			})
-		}
+	fired = append(fired, decision.Modifiers...)
+	fired = append(fired, FiredRule{Rule: decision.Rule, Effect: decision.Status, Detail: decision.Reason})
+	flags := append(append([]string{}, specialCases...), qualityFlags...)
+	if chain != nil {
+		flags = append(flags, "chain:"+chain.Name)
+	}
+	result.Explanation = &Explanation{
+		Outcome:    decision.Status,
+		DecidedBy:  decision.Rule,
+		RulesFired: fired,
+		BaseScore:  validationResult.Score,
+		FinalScore: enhancedScore,
+		Thresholds: ExplainThresholds{Approval: p.approval, Rejection: p.rejection, TrustGate: p.trustGate},
+		Trust: ExplainTrust{
+			Authority: assess.Authority,
+			Level:     assess.Trust,
+			Bonus:     assess.Bonus,
+			Reason:    assess.Reason,
+		},
+		Google:      explainGoogle(venue),
+		AISubScores: explainSubScores(validationResult),
+		Flags:       flags,
 	}
 
 	return result
 }
 
+// publish appends the decision event matching the final status.
+func (de *DecisionEngine) publish(ctx context.Context, venueID int64, result *DecisionResult) {
+	flags := append([]string{}, result.SpecialCaseFlags...)
+	flags = append(flags, result.QualityFlags...)
+	switch result.FinalStatus {
+	case "approved":
+		_ = de.eventStore.Append(ctx, events.VenueApproved{
+			Base:   events.Base{Ts: time.Now(), VID: venueID},
+			Reason: result.DecisionReason,
+			Score:  result.FinalScore,
+			Flags:  flags,
+		})
+	case "rejected":
+		_ = de.eventStore.Append(ctx, events.VenueRejected{
+			Base:   events.Base{Ts: time.Now(), VID: venueID},
+			Reason: result.DecisionReason,
+			Score:  result.FinalScore,
+			Flags:  flags,
+		})
+	case "manual_review":
+		_ = de.eventStore.Append(ctx, events.VenueRequiresManualReview{
+			Base:   events.Base{Ts: time.Now(), VID: venueID},
+			Reason: result.ReviewReason,
+			Score:  result.FinalScore,
+			Flags:  flags,
+		})
+	}
+}
+
 // detectSpecialCases identifies venues requiring special handling
 func (de *DecisionEngine) detectSpecialCases(venue models.Venue) []string {
 	var flags []string
@@ -234,6 +434,15 @@ func (de *DecisionEngine) detectSpecialCases(venue models.Venue) []string {
 	return flags
 }
 
+func appendOnce(flags []string, flag string) []string {
+	for _, f := range flags {
+		if f == flag {
+			return flags
+		}
+	}
+	return append(flags, flag)
+}
+
 // detectQualityFlags identifies data quality issues
 func (de *DecisionEngine) detectQualityFlags(venue models.Venue, validation *models.ValidationResult, authority *AuthorityInfo) []string {
 	var flags []string
@@ -253,6 +462,47 @@ func (de *DecisionEngine) detectQualityFlags(venue models.Venue, validation *mod
 			// This flag is mainly for cases that somehow bypass the AI scorer check
 			flags = append(flags, "location_mismatch")
 		}
+
+		// Social profiles: a dead link is a strong signal the venue is gone or made up;
+		// a handle that doesn't resemble the name is only informational.
+		for _, sc := range venue.ValidationDetails.Social {
+			switch {
+			case sc.Status == models.SocialNotFound:
+				flags = appendOnce(flags, "social_profile_not_found")
+			case sc.Status == models.SocialOK && sc.Handle != "" && !sc.NameMatches:
+				flags = appendOnce(flags, "social_name_mismatch")
+			}
+		}
+
+		if gf := venue.ValidationDetails.Geofence; gf != nil {
+			if !gf.CountryMatch {
+				flags = append(flags, "path_country_mismatch")
+			} else if gf.RegionMatch != nil && !*gf.RegionMatch {
+				flags = append(flags, "path_region_mismatch")
+			}
+		}
+
+		if pc := venue.ValidationDetails.Phone; pc != nil {
+			if pc.Mismatch() {
+				flags = append(flags, "phone_country_mismatch")
+			} else if pc.Error != "" {
+				flags = append(flags, "phone_invalid")
+			}
+		}
+
+		if pm := venue.ValidationDetails.PlaceMatch; pm != nil && pm.Confidence == models.MatchConfidenceLow {
+			flags = append(flags, "ambiguous_place_match")
+		}
+
+		// Informational: category rules are keyed on the submitted category
+		if cs := venue.ValidationDetails.Category; cs != nil && cs.Mismatch && cs.Confidence == models.CategoryConfidenceHigh {
+			flags = append(flags, "category_mismatch")
+		}
+
+		// Informational: the admin sees the evidence on the venue page
+		if vs := venue.ValidationDetails.VeganStatus; vs != nil && vs.Disagrees && vs.Confidence == models.CategoryConfidenceHigh {
+			flags = append(flags, "vegan_status_mismatch")
+		}
 	}
 
 	// Score distribution analysis
@@ -294,25 +544,71 @@ type DecisionOutcome struct {
 	Reason         string
 	RequiresReview bool
 	ReviewReason   string
+	Rule           string      // id of the rule that produced the outcome
+	Modifiers      []FiredRule // rules that adjusted inputs without deciding
 }
 
 // determineStatus makes the final approval/rejection decision
-func (de *DecisionEngine) determineStatus(ctx context.Context, venue models.Venue, user models.User, score int, authority *AuthorityInfo, specialCases, qualityFlags []string) DecisionOutcome {
+func (de *DecisionEngine) determineStatus(ctx context.Context, venue models.Venue, user models.User, score int, authority *AuthorityInfo, specialCases, qualityFlags []string, chain *ChainRule, p policy) DecisionOutcome {
+	var mods []FiredRule
 
 	// Authority-based auto-approval rules (highest priority)
 	if de.enableAuthorityMode {
-		if authority.AuthorityLevel == "venue_admin" && de.hasCompleteCriticalData(ctx, venue) {
-			return DecisionOutcome{
-				Status: "approved",
-				Reason: fmt.Sprintf("Auto-approved: Venue admin with complete data (score: %d)", score),
+		autoApprove := authority.AuthorityLevel == "venue_admin" || authority.AuthorityLevel == "high_ambassador"
+		if o := p.rules.trustOverride(authority.AuthorityLevel); o != nil && o.AutoApprove != nil {
+			autoApprove = *o.AutoApprove
+		}
+		if autoApprove && de.hasCompleteCriticalData(ctx, venue) {
+			switch authority.AuthorityLevel {
+			case "venue_admin":
+				return DecisionOutcome{
+					Status: "approved",
+					Reason: fmt.Sprintf("Auto-approved: Venue admin with complete data (score: %d)", score),
+					Rule:   "authority.venue_admin",
+				}
+			case "high_ambassador":
+				return DecisionOutcome{
+					Status: "approved",
+					Reason: fmt.Sprintf("Auto-approved: High-ranking regional ambassador with complete data (score: %d)", score),
+					Rule:   "authority.high_ambassador",
+				}
+			default:
+				return DecisionOutcome{
+					Status: "approved",
+					Reason: fmt.Sprintf("Auto-approved: %s trust override with complete data (score: %d)", authority.AuthorityLevel, score),
+					Rule:   "rules.trust_override." + authority.AuthorityLevel,
+				}
 			}
 		}
+	}
 
-		if authority.AuthorityLevel == "high_ambassador" && de.hasCompleteCriticalData(ctx, venue) {
-			return DecisionOutcome{
-				Status: "approved",
-				Reason: fmt.Sprintf("Auto-approved: High-ranking regional ambassador with complete data (score: %d)", score),
-			}
+	// Chains that always need an editor, regardless of score
+	if chain != nil && chain.Policy == ChainManualReview {
+		reason := chain.Reason
+		if reason == "" {
+			reason = "Chain venue requires manual validation"
+		}
+		return DecisionOutcome{
+			Status:         "manual_review",
+			Reason:         fmt.Sprintf("Manual review required: Chain %s (score: %d)", chain.Name, score),
+			RequiresReview: true,
+			ReviewReason:   reason,
+			Rule:           "rules.chain",
+		}
+	}
+
+	// Region restrictions from rules file
+	if rg := p.rules.regionRule(venue, authority.AuthorityLevel); rg != nil {
+		reason := rg.Reason
+		if reason == "" {
+			reason = "Region requires manual validation"
+		}
+		return DecisionOutcome{
+			Status:         "manual_review",
+			Reason:         fmt.Sprintf("Manual review required: Region rule (score: %d)", score),
+			RequiresReview: true,
+			ReviewReason:   reason,
+			Rule:           "rules.region",
 		}
 	}
 
@@ -327,6 +623,7 @@ func (de *DecisionEngine) determineStatus(ctx context.Context, venue models.Venu
 						Reason:         fmt.Sprintf("Manual review required: %s venue (language barriers)", strings.Title(strings.TrimSuffix(flag, "_venue"))),
 						RequiresReview: true,
 						ReviewReason:   "Korean/Chinese venue requires manual validation unless submitted by venue admin",
+						Rule:           "special_case." + flag,
 					}
 				}
 			}
@@ -342,6 +639,7 @@ func (de *DecisionEngine) determineStatus(ctx context.Context, venue models.Venu
 				Reason:         fmt.Sprintf("Manual review required: No Google data found (score: %d)", score),
 				RequiresReview: true,
 				ReviewReason:   "Unable to verify venue information through Google Places",
+				Rule:           "quality.no_google_data",
 			}
 		case "multiple_conflicts":
 			return DecisionOutcome{
@@ -349,6 +647,7 @@ func (de *DecisionEngine) determineStatus(ctx context.Context, venue models.Venu
 				Reason:         fmt.Sprintf("Manual review required: Multiple data conflicts (score: %d)", score),
 				RequiresReview: true,
 				ReviewReason:   "Significant discrepancies between submitted and Google data",
+				Rule:           "quality.multiple_conflicts",
 			}
 		case "location_mismatch":
 			return DecisionOutcome{
@@ -356,6 +655,7 @@ func (de *DecisionEngine) determineStatus(ctx context.Context, venue models.Venu
 				Reason:         fmt.Sprintf("Manual review required: Location mismatch >500m (score: %d)", score),
 				RequiresReview: true,
 				ReviewReason:   "Venue location significantly different from Google Places data",
+				Rule:           "quality.location_mismatch",
 			}
 		case "suspicious_content":
 			return DecisionOutcome{
@@ -363,37 +663,117 @@ func (de *DecisionEngine) determineStatus(ctx context.Context, venue models.Venu
 				Reason:         fmt.Sprintf("Manual review required: Suspicious content detected (score: %d)", score),
 				RequiresReview: true,
 				ReviewReason:   "Venue submission contains potentially suspicious content",
+				Rule:           "quality.suspicious_content",
+			}
+		case "social_profile_not_found":
+			return DecisionOutcome{
+				Status:         "manual_review",
+				Reason:         fmt.Sprintf("Manual review required: Social profile not found (score: %d)", score),
+				RequiresReview: true,
+				ReviewReason:   "Submitted Facebook/Instagram profile does not exist",
+				Rule:           "quality.social_profile_not_found",
+			}
+		case "path_country_mismatch":
+			if !de.geofenceReview {
+				continue
+			}
+			gf := venue.ValidationDetails.Geofence
+			return DecisionOutcome{
+				Status:         "manual_review",
+				Reason:         fmt.Sprintf("Manual review required: Coordinates outside path country (score: %d)", score),
+				RequiresReview: true,
+				ReviewReason:   fmt.Sprintf("Path says %s but the venue coordinates are in %s", gf.PathCountry, gf.PlaceCountry),
+				Rule:           "quality.path_country_mismatch",
+			}
+		case "phone_country_mismatch":
+			pc := venue.ValidationDetails.Phone
+			return DecisionOutcome{
+				Status:         "manual_review",
+				Reason:         fmt.Sprintf("Manual review required: Phone country code mismatch (score: %d)", score),
+				RequiresReview: true,
+				ReviewReason:   fmt.Sprintf("Phone %s does not use the country code of %s (+%s)", pc.E164, pc.Country, pc.ExpectedCode),
+				Rule:           "quality.phone_country_mismatch",
+			}
+		case "ambiguous_place_match":
+			return DecisionOutcome{
+				Status:         "manual_review",
+				Reason:         fmt.Sprintf("Manual review required: Google place match is uncertain (score: %d)", score),
+				RequiresReview: true,
+				ReviewReason:   "Confirm the Google place: " + venue.ValidationDetails.PlaceMatch.Reason,
+				Rule:           "quality.ambiguous_place_match",
 			}
 		}
 	}
 
+	// Known chains are approved once Google confirmed the place and nothing above objected
+	if chain != nil && chain.Policy == ChainAutoApprove && score >= p.rejection &&
+		venue.ValidationDetails != nil && venue.ValidationDetails.GooglePlaceFound && de.hasCompleteCriticalData(ctx, venue) {
+		return DecisionOutcome{
+			Status: "approved",
+			Reason: fmt.Sprintf("Auto-approved: Known chain %s with complete data (score: %d)", chain.Name, score),
+			Rule:   "rules.chain",
+		}
+	}
+
+	// Category-specific rules
+	approval := p.approval
+	if cr := p.rules.categoryRule(venue.Category); cr != nil {
+		if cr.ManualReview {
+			reason := cr.Reason
+			if reason == "" {
+				reason = "Category requires manual validation"
+			}
+			return DecisionOutcome{
+				Status:         "manual_review",
+				Reason:         fmt.Sprintf("Manual review required: Category rule (score: %d)", score),
+				RequiresReview: true,
+				ReviewReason:   reason,
+				Rule:           "rules.category",
+			}
+		}
+		if cr.Approval > 0 {
+			approval = cr.Approval
+			mods = append(mods, FiredRule{
+				Rule:   "rules.category",
+				Effect: "threshold",
+				Detail: fmt.Sprintf("approval threshold %d for category %d", cr.Approval, venue.Category),
+			})
+		}
+	}
+
 	// Special case flags that require review
 	for _, flag := range specialCases {
 		switch flag {
 		case "new_business":
-			if score < de.approvalThreshold {
+			if score < approval {
 				return DecisionOutcome{
 					Status:         "manual_review",
 					Reason:         fmt.Sprintf("Manual review required: New business with moderate score (score: %d)", score),
 					RequiresReview: true,
 					ReviewReason:   "New businesses require additional verification",
+					Rule:           "special_case.new_business",
+					Modifiers:      mods,
 				}
 			}
 		}
 	}
 
 	// Score-based decision (final fallback)
-	if score >= de.approvalThreshold {
+	if score >= approval {
 		return DecisionOutcome{
-			Status: "approved",
-			Reason: fmt.Sprintf("Auto-approved: High confidence score (score: %d)", score),
+			Status:    "approved",
+			Reason:    fmt.Sprintf("Auto-approved: High confidence score (score: %d)", score),
+			Rule:      "score.approve",
+			Modifiers: mods,
 		}
-	} else if score < de.rejectionThreshold {
+	} else if score < p.rejection {
 		// Only auto-reject if no special circumstances
-		if len(specialCases) == 0 && authority.TrustLevel < constants.DecisionTrustGate {
+		if len(specialCases) == 0 && authority.TrustLevel < p.trustGate {
 			return DecisionOutcome{
-				Status: "rejected",
-				Reason: fmt.Sprintf("Auto-rejected: Low confidence score (score: %d)", score),
+				Status:    "rejected",
+				Reason:    fmt.Sprintf("Auto-rejected: Low confidence score (score: %d)", score),
+				Rule:      "score.reject",
+				Modifiers: mods,
 			}
 		} else {
 			return DecisionOutcome{
@@ -401,6 +781,8 @@ func (de *DecisionEngine) determineStatus(ctx context.Context, venue models.Venu
 				Reason:         fmt.Sprintf("Manual review required: Low score with special circumstances (score: %d)", score),
 				RequiresReview: true,
 				ReviewReason:   "Low score but special circumstances prevent auto-rejection",
+				Rule:           "score.reject_gated",
+				Modifiers:      mods,
 			}
 		}
 	} else {
@@ -410,6 +792,8 @@ func (de *DecisionEngine) determineStatus(ctx context.Context, venue models.Venu
 			Reason:         fmt.Sprintf("Manual review required: Medium confidence score (score: %d)", score),
 			RequiresReview: true,
 			ReviewReason:   "Score in manual review range",
+			Rule:           "score.manual_review",
+			Modifiers:      mods,
 		}
 	}
 }
@@ -425,20 +809,25 @@ func (de *DecisionEngine) hasCompleteCriticalData(ctx context.Context, venue mod
 
 // GetDecisionSummary returns a human-readable summary of the decision logic
 func (de *DecisionEngine) GetDecisionSummary() map[string]interface{} {
+	p := de.policyFor(de.Rules())
 	return map[string]interface{}{
-		"approval_threshold":     de.approvalThreshold,
-		"rejection_threshold":    de.rejectionThreshold,
+		"approval_threshold":     p.approval,
+		"rejection_threshold":    p.rejection,
+		"eligibility_threshold":  p.rules.eligibility(),
+		"trust_gate":             p.trustGate,
+		"rules":                  p.rules,
 		"special_cases_enabled":  de.enableSpecialCases,
 		"authority_mode_enabled": de.enableAuthorityMode,
 		"decision_rules": map[string]string{
 			"venue_admin_complete":     "Auto-approve venue admins with complete critical data",
 			"high_ambassador_regional": "Auto-approve high-ranking regional ambassadors with complete data",
 			"korean_chinese_special":   "Korean/Chinese venues require manual review unless venue admin",
+			"chains":                   "Chain venues follow their chain policy (auto_approve, manual_review or default)",
 			"no_google_data":           "Manual review if no Google Places data found",
 			"multiple_conflicts":       "Manual review if >3 data conflicts with Google",
 			"location_mismatch":        "Manual review if venue >500m from Google location",
-			"score_based_approval":     fmt.Sprintf("Auto-approve if score >= %d", de.approvalThreshold),
-			"score_based_rejection":    fmt.Sprintf("Auto-reject if score < %d (with conditions)", de.rejectionThreshold),
+			"score_based_approval":     fmt.Sprintf("Auto-approve if score >= %d", p.approval),
+			"score_based_rejection":    fmt.Sprintf("Auto-reject if score < %d (with conditions)", p.rejection),
 			"default":                  "Manual review for medium scores or special circumstances",
 		},
 	}
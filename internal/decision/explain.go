@@ -0,0 +1,91 @@
+package decision
+
+import (
+	"sort"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// Explanation is a structured "why" for a decision. It is persisted under the
+// "explanation" key of ai_output_data and rendered on the venue detail page.
+// Keep it flat and JSON-friendly; it is read back without the decision package types in mind.
+type Explanation struct {
+	Outcome     string            `json:"outcome"`
+	DecidedBy   string            `json:"decided_by"` // rule id that produced the outcome
+	RulesFired  []FiredRule       `json:"rules_fired"`
+	BaseScore   int               `json:"base_score"`
+	FinalScore  int               `json:"final_score"`
+	Thresholds  ExplainThresholds `json:"thresholds"`
+	Trust       ExplainTrust      `json:"trust"`
+	Google      ExplainGoogle     `json:"google"`
+	AISubScores []SubScore        `json:"ai_sub_scores,omitempty"`
+	Flags       []string          `json:"flags,omitempty"`
+}
+
+// FiredRule records a rule that influenced the decision, in evaluation order.
+type FiredRule struct {
+	Rule   string `json:"rule"`
+	Effect string `json:"effect"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// ExplainThresholds are the effective gates used for this decision.
+type ExplainThresholds struct {
+	Approval  int     `json:"approval"`
+	Rejection int     `json:"rejection"`
+	TrustGate float64 `json:"trust_gate"`
+}
+
+// ExplainTrust is the submitter's contribution to the score.
+type ExplainTrust struct {
+	Authority string  `json:"authority"`
+	Level     float64 `json:"level"`
+	Bonus     int     `json:"bonus"`
+	Reason    string  `json:"reason,omitempty"`
+}
+
+// ExplainGoogle summarises how well the submission matched Google Places.
+type ExplainGoogle struct {
+	Found          bool     `json:"found"`
+	DistanceMeters float64  `json:"distance_meters"`
+	Conflicts      int      `json:"conflicts"`
+	ConflictFields []string `json:"conflict_fields,omitempty"`
+}
+
+// SubScore is a single AI score component; a slice keeps display order stable.
+type SubScore struct {
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+}
+
+// explainGoogle extracts the Google match summary from validation details.
+func explainGoogle(venue models.Venue) ExplainGoogle {
+	g := ExplainGoogle{}
+	if venue.ValidationDetails == nil {
+		return g
+	}
+	g.Found = venue.ValidationDetails.GooglePlaceFound
+	g.DistanceMeters = venue.ValidationDetails.DistanceMeters
+	g.Conflicts = len(venue.ValidationDetails.Conflicts)
+	for _, c := range venue.ValidationDetails.Conflicts {
+		g.ConflictFields = append(g.ConflictFields, c.Field)
+	}
+	return g
+}
+
+// explainSubScores sorts the AI breakdown by name, skipping decision metadata keys.
+func explainSubScores(vr *models.ValidationResult) []SubScore {
+	if vr == nil || len(vr.ScoreBreakdown) == 0 {
+		return nil
+	}
+	out := make([]SubScore, 0, len(vr.ScoreBreakdown))
+	for k, v := range vr.ScoreBreakdown {
+		switch k {
+		case "authority_bonus", "quality_flags", "trust_pct":
+			continue
+		}
+		out = append(out, SubScore{Name: k, Score: v})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
@@ -0,0 +1,266 @@
+package decision
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"assisted-venue-approval/internal/constants"
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// DefaultEligibilityThreshold is the minimum history score required before a venue
+// may be flipped to active. Mirrors the value previously hardcoded in the processor.
+const DefaultEligibilityThreshold = 75
+
+// Rules is the declarative decision policy loaded from YAML (DECISION_RULES_FILE).
+// Zero values mean "keep engine defaults" so a partial file is valid.
+// Why: thresholds and regional exceptions change more often than code does.
+type Rules struct {
+	Thresholds     Thresholds      `yaml:"thresholds" json:"thresholds"`
+	Categories     []CategoryRule  `yaml:"categories,omitempty" json:"categories,omitempty"`
+	Regions        []RegionRule    `yaml:"regions,omitempty" json:"regions,omitempty"`
+	TrustOverrides []TrustOverride `yaml:"trust_overrides,omitempty" json:"trust_overrides,omitempty"`
+	Chains         []ChainRule     `yaml:"chains,omitempty" json:"chains,omitempty"`
+}
+
+// Thresholds are global score/trust gates. Scores are 0-100, trust is 0.0-1.0.
+type Thresholds struct {
+	Approval    int     `yaml:"approval,omitempty" json:"approval,omitempty"`
+	Rejection   int     `yaml:"rejection,omitempty" json:"rejection,omitempty"`
+	Eligibility int     `yaml:"eligibility,omitempty" json:"eligibility,omitempty"`
+	TrustGate   float64 `yaml:"trust_gate,omitempty" json:"trust_gate,omitempty"`
+}
+
+// CategoryRule tunes decisions for specific HappyCow category IDs.
+type CategoryRule struct {
+	Categories   []int  `yaml:"categories" json:"categories"`
+	Approval     int    `yaml:"approval,omitempty" json:"approval,omitempty"`
+	ManualReview bool   `yaml:"manual_review,omitempty" json:"manual_review,omitempty"`
+	Reason       string `yaml:"reason,omitempty" json:"reason,omitempty"`
+}
+
+// RegionRule forces manual review for venues in a region unless the submitter
+// holds one of the exempt authority levels.
+// A venue matches if its path has PathPrefix or its location contains any of LocationContains.
+type RegionRule struct {
+	PathPrefix        string   `yaml:"path_prefix,omitempty" json:"path_prefix,omitempty"`
+	LocationContains  []string `yaml:"location_contains,omitempty" json:"location_contains,omitempty"`
+	ExemptAuthorities []string `yaml:"exempt_authorities,omitempty" json:"exempt_authorities,omitempty"`
+	Reason            string   `yaml:"reason,omitempty" json:"reason,omitempty"`
+}
+
+// TrustOverride replaces calculator output for an authority level.
+// Bonus nil keeps the calculator bonus; AutoApprove nil keeps built-in behaviour.
+type TrustOverride struct {
+	Authority   string   `yaml:"authority" json:"authority"`
+	Bonus       *int     `yaml:"bonus,omitempty" json:"bonus,omitempty"`
+	MinTrust    *float64 `yaml:"min_trust,omitempty" json:"min_trust,omitempty"`
+	AutoApprove *bool    `yaml:"auto_approve,omitempty" json:"auto_approve,omitempty"`
+}
+
+var knownAuthorities = map[string]bool{
+	"venue_admin":     true,
+	"high_ambassador": true,
+	"ambassador":      true,
+	"trusted":         true,
+	"regular":         true,
+}
+
+// LoadRules reads and validates a rules file. Empty path returns (nil, nil).
+func LoadRules(path string) (*Rules, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("read decision rules: %w", err)
+	}
+	return ParseRules(data)
+}
+
+// ParseRules decodes YAML and validates it. Unknown keys are rejected so typos
+// surface on reload instead of silently doing nothing.
+func ParseRules(data []byte) (*Rules, error) {
+	var r Rules
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&r); err != nil && !errors.Is(err, io.EOF) {
+		return nil, errs.NewValidation("decision.ParseRules", "invalid yaml", err)
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Validate checks ranges and references. It collects all problems so the
+// operator can fix the file in one pass.
+func (r *Rules) Validate() error {
+	if r == nil {
+		return nil
+	}
+	var problems []string
+	add := func(format string, a ...any) { problems = append(problems, fmt.Sprintf(format, a...)) }
+
+	t := r.Thresholds
+	if t.Approval < 0 || t.Approval > 100 {
+		add("thresholds.approval must be 0-100, got %d", t.Approval)
+	}
+	if t.Rejection < 0 || t.Rejection > 100 {
+		add("thresholds.rejection must be 0-100, got %d", t.Rejection)
+	}
+	if t.Eligibility < 0 || t.Eligibility > 100 {
+		add("thresholds.eligibility must be 0-100, got %d", t.Eligibility)
+	}
+	if t.Approval > 0 && t.Rejection > 0 && t.Rejection >= t.Approval {
+		add("thresholds.rejection (%d) must be below thresholds.approval (%d)", t.Rejection, t.Approval)
+	}
+	if t.TrustGate < 0 || t.TrustGate > 1 {
+		add("thresholds.trust_gate must be 0.0-1.0, got %.2f", t.TrustGate)
+	}
+
+	for i, c := range r.Categories {
+		if len(c.Categories) == 0 {
+			add("categories[%d]: at least one category id is required", i)
+		}
+		if c.Approval < 0 || c.Approval > 100 {
+			add("categories[%d].approval must be 0-100, got %d", i, c.Approval)
+		}
+		if c.Approval == 0 && !c.ManualReview {
+			add("categories[%d]: set approval or manual_review", i)
+		}
+	}
+	for i, rg := range r.Regions {
+		if rg.PathPrefix == "" && len(rg.LocationContains) == 0 {
+			add("regions[%d]: path_prefix or location_contains is required", i)
+		}
+		for _, a := range rg.ExemptAuthorities {
+			if !knownAuthorities[a] {
+				add("regions[%d]: unknown authority %q", i, a)
+			}
+		}
+	}
+	seen := map[string]bool{}
+	for i, o := range r.TrustOverrides {
+		if !knownAuthorities[o.Authority] {
+			add("trust_overrides[%d]: unknown authority %q", i, o.Authority)
+		}
+		if seen[o.Authority] {
+			add("trust_overrides[%d]: duplicate authority %q", i, o.Authority)
+		}
+		seen[o.Authority] = true
+		if o.Bonus != nil && (*o.Bonus < 0 || *o.Bonus > 100) {
+			add("trust_overrides[%d].bonus must be 0-100, got %d", i, *o.Bonus)
+		}
+		if o.MinTrust != nil && (*o.MinTrust < 0 || *o.MinTrust > 1) {
+			add("trust_overrides[%d].min_trust must be 0.0-1.0, got %.2f", i, *o.MinTrust)
+		}
+	}
+	chains := map[string]bool{}
+	for i, c := range r.Chains {
+		name := strings.ToLower(strings.TrimSpace(c.Name))
+		if normalizeChainName(c.Name) == "" {
+			add("chains[%d]: name is required", i)
+		} else if chains[name] {
+			add("chains[%d]: duplicate chain %q", i, c.Name)
+		}
+		chains[name] = true
+		if !knownChainPolicies[c.Policy] {
+			add("chains[%d]: unknown policy %q (want auto_approve, manual_review or default)", i, c.Policy)
+		}
+	}
+
+	if len(problems) > 0 {
+		return errs.NewValidation("decision.Rules.Validate", strings.Join(problems, "; "), nil)
+	}
+	return nil
+}
+
+// eligibility returns the configured eligibility threshold or the default.
+func (r *Rules) eligibility() int {
+	if r == nil || r.Thresholds.Eligibility <= 0 {
+		return DefaultEligibilityThreshold
+	}
+	return r.Thresholds.Eligibility
+}
+
+// trustGate returns the configured auto-reject trust gate or the default.
+func (r *Rules) trustGate() float64 {
+	if r == nil || r.Thresholds.TrustGate <= 0 {
+		return constants.DecisionTrustGate
+	}
+	return r.Thresholds.TrustGate
+}
+
+// categoryRule returns the first rule matching the venue category, if any.
+func (r *Rules) categoryRule(category int) *CategoryRule {
+	if r == nil {
+		return nil
+	}
+	for i := range r.Categories {
+		for _, c := range r.Categories[i].Categories {
+			if c == category {
+				return &r.Categories[i]
+			}
+		}
+	}
+	return nil
+}
+
+// regionRule returns the first region rule the venue falls under for this
+// authority level. Exempt authorities never match.
+func (r *Rules) regionRule(venue models.Venue, authority string) *RegionRule {
+	if r == nil {
+		return nil
+	}
+	loc := strings.ToLower(venue.Location)
+	path := ""
+	if venue.Path != nil {
+		path = strings.ToLower(*venue.Path)
+	}
+	for i := range r.Regions {
+		rg := &r.Regions[i]
+		if containsString(rg.ExemptAuthorities, authority) {
+			continue
+		}
+		if rg.PathPrefix != "" && path != "" && strings.HasPrefix(path, strings.ToLower(rg.PathPrefix)) {
+			return rg
+		}
+		for _, s := range rg.LocationContains {
+			if s != "" && strings.Contains(loc, strings.ToLower(s)) {
+				return rg
+			}
+		}
+	}
+	return nil
+}
+
+// trustOverride returns the override for an authority level, if any.
+func (r *Rules) trustOverride(authority string) *TrustOverride {
+	if r == nil {
+		return nil
+	}
+	for i := range r.TrustOverrides {
+		if r.TrustOverrides[i].Authority == authority {
+			return &r.TrustOverrides[i]
+		}
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
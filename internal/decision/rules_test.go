@@ -0,0 +1,423 @@
+package decision
+
+import (
+	"context"
+	"testing"
+
+	"assisted-venue-approval/internal/models"
+)
+
+func TestParseRules_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr bool
+	}{
+		{"empty", "", false},
+		{"thresholds only", "thresholds:\n  approval: 80\n  rejection: 40\n", false},
+		{"approval out of range", "thresholds:\n  approval: 120\n", true},
+		{"rejection above approval", "thresholds:\n  approval: 60\n  rejection: 70\n", true},
+		{"unknown key", "threshold:\n  approval: 80\n", true},
+		{"category without action", "categories:\n  - categories: [1]\n", true},
+		{"region without matcher", "regions:\n  - reason: x\n", true},
+		{"unknown authority", "trust_overrides:\n  - authority: boss\n    bonus: 5\n", true},
+		{"duplicate override", "trust_overrides:\n  - authority: trusted\n    bonus: 5\n  - authority: trusted\n    bonus: 6\n", true},
+		{"chain", "chains:\n  - name: Veganz\n    policy: auto_approve\n", false},
+		{"chain without name", "chains:\n  - policy: manual_review\n", true},
+		{"duplicate chain", "chains:\n  - name: Veganz\n  - name: veganz\n", true},
+		{"unknown chain policy", "chains:\n  - name: Veganz\n    policy: reject\n", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRules([]byte(tt.yaml))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRules() err=%v, wantErr=%v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDryRun_RulesChangeOutcome(t *testing.T) {
+	de := NewDecisionEngine(DefaultDecisionConfig())
+	lat, lng := 35.0, 139.0
+	venue := models.Venue{
+		ID:                1,
+		Name:              "Green Leaf",
+		Location:          "Shibuya, Tokyo, Japan",
+		Path:              sptr("asia|japan|tokyo"),
+		Category:          1,
+		Phone:             sptr("+81000000"),
+		Lat:               &lat,
+		Lng:               &lng,
+		ValidationDetails: &models.ValidationDetails{GooglePlaceFound: true},
+	}
+	user := models.User{ID: 7}
+	vr := &models.ValidationResult{VenueID: 1, Score: 80, ScoreBreakdown: map[string]int{
+		"venue_name_match": 20, "address_accuracy": 20, "geolocation_accuracy": 15, "vegan_relevance": 5,
+	}}
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"defaults", "", "manual_review"},
+		{"lower approval", "thresholds:\n  approval: 75\n  rejection: 40\n", "approved"},
+		{"region restriction", "thresholds:\n  approval: 75\n  rejection: 40\nregions:\n  - path_prefix: asia|japan\n", "manual_review"},
+		{"category forces review", "thresholds:\n  approval: 75\n  rejection: 40\ncategories:\n  - categories: [1]\n    manual_review: true\n", "manual_review"},
+		{"trust bonus", "trust_overrides:\n  - authority: regular\n    bonus: 10\n", "approved"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRules([]byte(tt.yaml))
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			res, err := de.DryRun(ctx, venue, user, vr, r)
+			if err != nil {
+				t.Fatalf("dry run: %v", err)
+			}
+			if res.FinalStatus != tt.want {
+				t.Fatalf("status=%s want=%s (%s)", res.FinalStatus, tt.want, res.DecisionReason)
+			}
+		})
+	}
+
+	if de.Rules() != nil {
+		t.Fatalf("dry run must not change active rules")
+	}
+}
+
+func TestRules_OneSidedThresholdsInvert(t *testing.T) {
+	// The file alone is valid, but approval 40 falls below the engine's rejection of 50
+	de := NewDecisionEngine(DefaultDecisionConfig())
+	r, err := ParseRules([]byte("thresholds:\n  approval: 40\n"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if err := de.ApplyRules(r); err == nil {
+		t.Fatal("rules with approval below the engine rejection were applied")
+	}
+	if de.Rules() != nil {
+		t.Fatal("refused rules replaced the active ones")
+	}
+	if _, err := de.DryRun(context.Background(), models.Venue{ID: 1}, models.User{ID: 7}, &models.ValidationResult{Score: 45}, r); err == nil {
+		t.Fatal("dry run accepted rules with approval below the engine rejection")
+	}
+
+	// Lowering rejection with it makes the merged policy valid
+	r, _ = ParseRules([]byte("thresholds:\n  approval: 40\n  rejection: 30\n"))
+	if err := de.ApplyRules(r); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+}
+
+func TestApplyConfig_RefusesInvertingThreshold(t *testing.T) {
+	// The rules set only rejection 60; an approval threshold of 55 would fall below it
+	de := NewDecisionEngine(DefaultDecisionConfig())
+	if err := de.ApplyRules(&Rules{Thresholds: Thresholds{Rejection: 60}}); err != nil {
+		t.Fatalf("apply rules: %v", err)
+	}
+	if err := de.ApplyConfig(55); err == nil {
+		t.Fatal("approval threshold below the rules' rejection was applied")
+	}
+	if got := de.policyFor(de.Rules()).approval; got != 85 {
+		t.Fatalf("approval = %d, want the previous 85", got)
+	}
+	if err := de.ApplyConfig(70); err != nil {
+		t.Fatalf("apply config: %v", err)
+	}
+}
+
+func TestRules_CategoryApprovalBounds(t *testing.T) {
+	de := NewDecisionEngine(DefaultDecisionConfig())
+	tests := []struct {
+		name string
+		yaml string
+		ok   bool
+	}{
+		{"at eligibility", "categories:\n  - categories: [1]\n    approval: 75\n", true},
+		{"below default eligibility", "categories:\n  - categories: [1]\n    approval: 60\n", false},
+		{"below configured eligibility", "thresholds:\n  eligibility: 80\ncategories:\n  - categories: [1]\n    approval: 78\n", false},
+		{"at rejection", "thresholds:\n  eligibility: 50\ncategories:\n  - categories: [1]\n    approval: 50\n", false},
+		{"lowered eligibility and rejection", "thresholds:\n  eligibility: 50\n  rejection: 40\ncategories:\n  - categories: [1]\n    approval: 55\n", true},
+	}
+	for _, tt := range tests {
+		r, err := ParseRules([]byte(tt.yaml))
+		if err != nil {
+			t.Fatalf("%s: parse: %v", tt.name, err)
+		}
+		if err := de.ApplyRules(r); (err == nil) != tt.ok {
+			t.Errorf("%s: apply err = %v, want ok %v", tt.name, err, tt.ok)
+		}
+	}
+}
+
+func TestEligibilityThreshold(t *testing.T) {
+	de := NewDecisionEngine(DefaultDecisionConfig())
+	if got := de.EligibilityThreshold(); got != DefaultEligibilityThreshold {
+		t.Fatalf("default eligibility=%d", got)
+	}
+	de.ApplyRules(&Rules{Thresholds: Thresholds{Eligibility: 60}})
+	if got := de.EligibilityThreshold(); got != 60 {
+		t.Fatalf("eligibility=%d want 60", got)
+	}
+}
+
+func TestVersion(t *testing.T) {
+	de := NewDecisionEngine(DefaultDecisionConfig())
+	base := de.Version()
+	if base != NewDecisionEngine(DefaultDecisionConfig()).Version() {
+		t.Fatal("same config gave different versions")
+	}
+	de.ApplyConfig(90)
+	if de.Version() == base {
+		t.Fatal("threshold change kept the version")
+	}
+	de.ApplyConfig(85)
+	de.ApplyRules(&Rules{Regions: []RegionRule{{PathPrefix: "asia|japan"}}})
+	if de.Version() == base {
+		t.Fatal("rules change kept the version")
+	}
+	de.ApplyRules(nil)
+	if de.Version() != base {
+		t.Fatal("reverting config did not restore the version")
+	}
+}
+
+func TestMakeDecision_Explanation(t *testing.T) {
+	de := NewDecisionEngine(DefaultDecisionConfig())
+	bonus := 10
+	de.ApplyRules(&Rules{TrustOverrides: []TrustOverride{{Authority: "regular", Bonus: &bonus}}})
+	lat, lng := 1.0, 2.0
+	venue := models.Venue{
+		ID: 2, Name: "Leaf", Location: "Berlin", Phone: sptr("+49"), Lat: &lat, Lng: &lng,
+		ValidationDetails: &models.ValidationDetails{GooglePlaceFound: true, DistanceMeters: 12},
+	}
+	vr := &models.ValidationResult{Score: 80, ScoreBreakdown: map[string]int{
+		"venue_name_match": 20, "address_accuracy": 20, "geolocation_accuracy": 15, "vegan_relevance": 5, "authority_bonus": 0,
+	}}
+
+	res := de.MakeDecision(context.Background(), venue, models.User{}, vr)
+	ex := res.Explanation
+	if ex == nil {
+		t.Fatalf("expected explanation")
+	}
+	if ex.DecidedBy != "score.approve" || ex.Outcome != res.FinalStatus {
+		t.Fatalf("unexpected outcome: %+v", ex)
+	}
+	if ex.BaseScore != 80 || ex.FinalScore != 90 || ex.Trust.Bonus != 10 {
+		t.Fatalf("unexpected scores: %+v", ex)
+	}
+	if len(ex.RulesFired) != 2 || ex.RulesFired[0].Rule != "rules.trust_override.regular" {
+		t.Fatalf("unexpected rules fired: %+v", ex.RulesFired)
+	}
+	if len(ex.AISubScores) != 4 || !ex.Google.Found {
+		t.Fatalf("unexpected sub-scores/google: %+v", ex)
+	}
+}
+
+func TestMakeDecision_SocialFlags(t *testing.T) {
+	de := NewDecisionEngine(DefaultDecisionConfig())
+	lat, lng := 1.0, 2.0
+	vr := &models.ValidationResult{Score: 95, ScoreBreakdown: map[string]int{
+		"venue_name_match": 25, "address_accuracy": 25, "geolocation_accuracy": 20, "vegan_relevance": 25,
+	}}
+	tests := []struct {
+		name     string
+		social   []models.SocialCheck
+		wantFlag string
+		wantRule string
+	}{
+		{"dead profile", []models.SocialCheck{{Platform: "instagram", Status: models.SocialNotFound}}, "social_profile_not_found", "quality.social_profile_not_found"},
+		{"handle mismatch", []models.SocialCheck{{Platform: "facebook", Status: models.SocialOK, Handle: "pizzahut"}}, "social_name_mismatch", "score.approve"},
+		{"login wall", []models.SocialCheck{{Platform: "instagram", Status: models.SocialLoginWall, Handle: "pizzahut"}}, "", "score.approve"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			venue := models.Venue{
+				ID: 3, Name: "Leaf", Location: "Berlin", Phone: sptr("+49"), Lat: &lat, Lng: &lng,
+				ValidationDetails: &models.ValidationDetails{GooglePlaceFound: true, Social: tt.social},
+			}
+			res := de.MakeDecision(context.Background(), venue, models.User{}, vr)
+			found := false
+			for _, f := range res.QualityFlags {
+				found = found || f == tt.wantFlag
+			}
+			if tt.wantFlag != "" && !found {
+				t.Fatalf("flags=%v, want %s", res.QualityFlags, tt.wantFlag)
+			}
+			if res.Explanation == nil || res.Explanation.DecidedBy != tt.wantRule {
+				t.Fatalf("decided by %+v, want %s", res.Explanation, tt.wantRule)
+			}
+		})
+	}
+}
+
+func TestMakeDecision_PhoneCountryMismatch(t *testing.T) {
+	de := NewDecisionEngine(DefaultDecisionConfig())
+	lat, lng := 52.5, 13.4
+	vr := &models.ValidationResult{Score: 95, ScoreBreakdown: map[string]int{
+		"venue_name_match": 25, "address_accuracy": 25, "geolocation_accuracy": 20, "vegan_relevance": 25,
+	}}
+	tests := []struct {
+		name  string
+		phone string
+		want  string
+	}{
+		{"matching national", "030 1234567", "score.approve"},
+		{"matching international", "+49 30 1234567", "score.approve"},
+		{"foreign code", "+33 1 42 68 53 00", "quality.phone_country_mismatch"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			venue := models.Venue{
+				ID: 4, Name: "Leaf", Location: "Berlin", Path: sptr("europe|germany|berlin"), Phone: sptr(tt.phone), Lat: &lat, Lng: &lng,
+			}
+			venue.ValidationDetails = &models.ValidationDetails{GooglePlaceFound: true, Phone: models.CheckVenuePhone(venue)}
+			res := de.MakeDecision(context.Background(), venue, models.User{}, vr)
+			if res.Explanation == nil || res.Explanation.DecidedBy != tt.want {
+				t.Fatalf("decided by %+v, want %s", res.Explanation, tt.want)
+			}
+		})
+	}
+}
+
+func TestMakeDecision_Geofence(t *testing.T) {
+	lat, lng := 48.2, 16.4
+	vr := &models.ValidationResult{Score: 95, ScoreBreakdown: map[string]int{
+		"venue_name_match": 25, "address_accuracy": 25, "geolocation_accuracy": 20, "vegan_relevance": 25,
+	}}
+	no := false
+	tests := []struct {
+		name        string
+		forceReview bool
+		gf          *models.GeofenceCheck
+		want        string
+	}{
+		{"country mismatch", true, &models.GeofenceCheck{PathCountry: "germany", PlaceCountry: "austria"}, "quality.path_country_mismatch"},
+		{"country mismatch, review off", false, &models.GeofenceCheck{PathCountry: "germany", PlaceCountry: "austria"}, "score.approve"},
+		{"region mismatch only", true, &models.GeofenceCheck{PathCountry: "austria", PlaceCountry: "austria", CountryMatch: true, RegionMatch: &no}, "score.approve"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultDecisionConfig()
+			cfg.GeofenceForceReview = tt.forceReview
+			de := NewDecisionEngine(cfg)
+			venue := models.Venue{
+				ID: 5, Name: "Leaf", Location: "Vienna", Phone: sptr("+43"), Lat: &lat, Lng: &lng,
+				ValidationDetails: &models.ValidationDetails{GooglePlaceFound: true, Geofence: tt.gf},
+			}
+			res := de.MakeDecision(context.Background(), venue, models.User{}, vr)
+			if res.Explanation == nil || res.Explanation.DecidedBy != tt.want {
+				t.Fatalf("decided by %+v, want %s", res.Explanation, tt.want)
+			}
+		})
+	}
+}
+
+func TestMakeDecision_AmbiguousPlaceMatch(t *testing.T) {
+	de := NewDecisionEngine(DefaultDecisionConfig())
+	lat, lng := 52.5, 13.4
+	vr := &models.ValidationResult{Score: 95, ScoreBreakdown: map[string]int{
+		"venue_name_match": 25, "address_accuracy": 25, "geolocation_accuracy": 20, "vegan_relevance": 25,
+	}}
+	tests := []struct {
+		name       string
+		confidence string
+		want       string
+	}{
+		{"high", models.MatchConfidenceHigh, "score.approve"},
+		{"confirmed", models.MatchConfidenceConfirmed, "score.approve"},
+		{"low", models.MatchConfidenceLow, "quality.ambiguous_place_match"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			venue := models.Venue{
+				ID: 6, Name: "Leaf", Location: "Berlin", Phone: sptr("+49"), Lat: &lat, Lng: &lng,
+				ValidationDetails: &models.ValidationDetails{GooglePlaceFound: true, PlaceMatch: &models.PlaceMatch{Confidence: tt.confidence}},
+			}
+			res := de.MakeDecision(context.Background(), venue, models.User{}, vr)
+			if res.Explanation == nil || res.Explanation.DecidedBy != tt.want {
+				t.Fatalf("decided by %+v, want %s", res.Explanation, tt.want)
+			}
+		})
+	}
+}
+
+func TestMakeDecision_Chains(t *testing.T) {
+	de := NewDecisionEngine(DefaultDecisionConfig())
+	lat, lng := 52.5, 13.4
+	venue := models.Venue{
+		ID:                1,
+		Name:              "Veganz Berlin-Friedrichshain",
+		Location:          "Warschauer Str. 33, Berlin, Germany",
+		Path:              sptr("europe|germany|berlin"),
+		Category:          1,
+		Phone:             sptr("+4930000000"),
+		Email:             sptr("berlin@veganz.example"),
+		VegOnly:           1,
+		Lat:               &lat,
+		Lng:               &lng,
+		ValidationDetails: &models.ValidationDetails{GooglePlaceFound: true},
+		GoogleData:        &models.GooglePlaceData{PlaceID: "p1", Name: "Veganz", Types: []string{"supermarket", "store"}},
+	}
+	user := models.User{ID: 7}
+	vr := &models.ValidationResult{VenueID: 1, Score: 70, ScoreBreakdown: map[string]int{
+		"venue_name_match": 20, "address_accuracy": 20, "geolocation_accuracy": 15, "vegan_relevance": 5,
+	}}
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		yaml      string
+		want      string
+		wantChain string
+	}{
+		{"no chains", "", "manual_review", ""},
+		{"known vegan chain", "chains:\n  - name: Veganz\n    policy: auto_approve\n", "approved", "Veganz"},
+		{"alias", "chains:\n  - name: Veganz Group\n    aliases: [veganz]\n    policy: auto_approve\n", "approved", "Veganz Group"},
+		{"always manual", "thresholds:\n  approval: 60\nchains:\n  - name: Veganz\n    policy: manual_review\n", "manual_review", "Veganz"},
+		{"default only tags", "chains:\n  - name: Veganz\n", "manual_review", "Veganz"},
+		{"google type mismatch", "chains:\n  - name: Veganz\n    google_types: [restaurant]\n    policy: auto_approve\n", "manual_review", ""},
+		{"partial word", "chains:\n  - name: Vegan\n    policy: auto_approve\n", "manual_review", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRules([]byte(tt.yaml))
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			res, err := de.DryRun(ctx, venue, user, vr, r)
+			if err != nil {
+				t.Fatalf("dry run: %v", err)
+			}
+			if res.FinalStatus != tt.want || res.Chain != tt.wantChain {
+				t.Fatalf("status=%s chain=%q want %s %q (%s)", res.FinalStatus, res.Chain, tt.want, tt.wantChain, res.DecisionReason)
+			}
+		})
+	}
+}
+
+func TestChainForName(t *testing.T) {
+	r := &Rules{Chains: []ChainRule{{Name: "Loving Hut"}, {Name: "Plant Power", Aliases: []string{"PPFF"}}}}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Loving Hut - Taipei Main Station", "Loving Hut"},
+		{"LOVING-HUT", "Loving Hut"},
+		{"PPFF Long Beach", "Plant Power"},
+		{"Lovingly Hutted", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := r.ChainForName(tt.name); got != tt.want {
+			t.Errorf("ChainForName(%q)=%q want %q", tt.name, got, tt.want)
+		}
+	}
+	if got := (*Rules)(nil).ChainForName("Loving Hut"); got != "" {
+		t.Errorf("nil rules matched %q", got)
+	}
+}
@@ -3,6 +3,7 @@ package domain
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"assisted-venue-approval/internal/models"
@@ -19,6 +20,12 @@ type VenueFieldData struct {
 	Website       *string  `json:"website,omitempty"`
 	OpenHours     *string  `json:"openhours,omitempty"`
 	OpenHoursNote *string  `json:"openhours_note,omitempty"`
+	Timezone      *string  `json:"timezone,omitempty"`
+	EntryType     *int     `json:"entrytype,omitempty"`
+	Path          *string  `json:"path,omitempty"`
+	VegOnly       *int     `json:"vegonly,omitempty"`
+	Vegan         *int     `json:"vegan,omitempty"`
+	Category      *int     `json:"category,omitempty"`
 }
 
 // VenueDataReplacement tracks original vs replaced values for audit purposes
@@ -68,6 +75,7 @@ type ApprovalData struct {
 	Website       *string  // Final website URL
 	OpenHours     *string  // Final open hours JSON
 	OpenHoursNote *string  // Final hours note (closed days)
+	Timezone      *string  // IANA time zone inferred from the location, when the venue has none
 	EntryType     *int     // Final entry type (1=Restaurant, 2=Store)
 	Path          *string  // Final location path (e.g., north_america|usa|chicago)
 	VegOnly       *int     // Final vegetarian-only flag (0 or 1)
@@ -195,6 +203,32 @@ func BuildVenueDataReplacements(venue *models.Venue, approvalData *ApprovalData)
 		hasChanges = true
 	}
 
+	// Timezone: set only when inferred for a venue without a valid one
+	if approvalData.Timezone != nil && strDiffers(venue.Timezone, approvalData.Timezone) {
+		original.Timezone = venue.Timezone
+		replacement.Timezone = approvalData.Timezone
+		hasChanges = true
+	}
+
+	// Classification fields are non-null ints; record them so an approval can be reverted
+	intChange := func(orig int, next *int, o, r **int) {
+		if next != nil && *next != orig {
+			origCopy := orig
+			*o, *r = &origCopy, next
+			hasChanges = true
+		}
+	}
+	intChange(venue.EntryType, approvalData.EntryType, &original.EntryType, &replacement.EntryType)
+	intChange(venue.VegOnly, approvalData.VegOnly, &original.VegOnly, &replacement.VegOnly)
+	intChange(venue.Vegan, approvalData.Vegan, &original.Vegan, &replacement.Vegan)
+	intChange(venue.Category, approvalData.Category, &original.Category, &replacement.Category)
+
+	if approvalData.Path != nil && strDiffers(venue.Path, approvalData.Path) {
+		original.Path = venue.Path
+		replacement.Path = approvalData.Path
+		hasChanges = true
+	}
+
 	// Return nil if no changes detected
 	if !hasChanges {
 		return nil
@@ -205,3 +239,109 @@ func BuildVenueDataReplacements(venue *models.Venue, approvalData *ApprovalData)
 		Replacement: replacement,
 	}
 }
+
+// ParseVenueDataReplacement reads the JSON stored in an audit log. Empty input returns nil.
+func ParseVenueDataReplacement(raw *string) (*VenueDataReplacement, error) {
+	if raw == nil || strings.TrimSpace(*raw) == "" {
+		return nil, nil
+	}
+	var vdr VenueDataReplacement
+	if err := json.Unmarshal([]byte(*raw), &vdr); err != nil {
+		return nil, fmt.Errorf("failed to parse venue data replacement: %w", err)
+	}
+	return &vdr, nil
+}
+
+// ReplacedFields lists the venue columns an approval replaced, in the JSON names used
+// by VenueFieldData. A replaced field whose Original is nil was NULL before the approval.
+func (vdr *VenueDataReplacement) ReplacedFields() []string {
+	if !vdr.HasReplacements() {
+		return nil
+	}
+	r := vdr.Replacement
+	var out []string
+	add := func(set bool, name string) {
+		if set {
+			out = append(out, name)
+		}
+	}
+	add(r.Name != nil, "name")
+	add(r.Address != nil, "address")
+	add(r.Description != nil, "description")
+	add(r.Lat != nil, "lat")
+	add(r.Lng != nil, "lng")
+	add(r.Phone != nil, "phone")
+	add(r.Website != nil, "website")
+	add(r.OpenHours != nil, "openhours")
+	add(r.OpenHoursNote != nil, "openhours_note")
+	add(r.Timezone != nil, "timezone")
+	add(r.EntryType != nil, "entrytype")
+	add(r.Path != nil, "path")
+	add(r.VegOnly != nil, "vegonly")
+	add(r.Vegan != nil, "vegan")
+	add(r.Category != nil, "category")
+	return out
+}
+
+// FieldChange is one replaced venue field with its values before and after, formatted
+// for display. An empty From means the column was NULL or blank.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// FieldChanges lists the replaced fields in ReplacedFields order.
+func (vdr *VenueDataReplacement) FieldChanges() []FieldChange {
+	if !vdr.HasReplacements() {
+		return nil
+	}
+	o, r := vdr.Original, vdr.Replacement
+	var out []FieldChange
+	str := func(name string, from, to *string) {
+		if to != nil {
+			out = append(out, FieldChange{Field: name, From: derefStr(from), To: *to})
+		}
+	}
+	num := func(name string, from, to *float64) {
+		if to != nil {
+			f := ""
+			if from != nil {
+				f = strconv.FormatFloat(*from, 'f', -1, 64)
+			}
+			out = append(out, FieldChange{Field: name, From: f, To: strconv.FormatFloat(*to, 'f', -1, 64)})
+		}
+	}
+	integer := func(name string, from, to *int) {
+		if to != nil {
+			f := ""
+			if from != nil {
+				f = strconv.Itoa(*from)
+			}
+			out = append(out, FieldChange{Field: name, From: f, To: strconv.Itoa(*to)})
+		}
+	}
+	str("name", o.Name, r.Name)
+	str("address", o.Address, r.Address)
+	str("description", o.Description, r.Description)
+	num("lat", o.Lat, r.Lat)
+	num("lng", o.Lng, r.Lng)
+	str("phone", o.Phone, r.Phone)
+	str("website", o.Website, r.Website)
+	str("openhours", o.OpenHours, r.OpenHours)
+	str("openhours_note", o.OpenHoursNote, r.OpenHoursNote)
+	str("timezone", o.Timezone, r.Timezone)
+	integer("entrytype", o.EntryType, r.EntryType)
+	str("path", o.Path, r.Path)
+	integer("vegonly", o.VegOnly, r.VegOnly)
+	integer("vegan", o.Vegan, r.Vegan)
+	integer("category", o.Category, r.Category)
+	return out
+}
+
+func derefStr(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -340,3 +340,108 @@ func TestBuildVenueDataReplacements_WhitespaceHandling(t *testing.T) {
 		t.Error("Expected replacement address to be tracked")
 	}
 }
+
+func TestBuildVenueDataReplacements_Classification(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	venue := &models.Venue{EntryType: 1, VegOnly: 0, Vegan: 0, Category: 2, Path: strPtr("north-america|usa|ny")}
+	approvalData := &ApprovalData{
+		EntryType: intPtr(1), // unchanged
+		VegOnly:   intPtr(1),
+		Vegan:     intPtr(1),
+		Category:  intPtr(2), // unchanged
+		Path:      strPtr("north-america|usa|nyc"),
+	}
+
+	result := BuildVenueDataReplacements(venue, approvalData)
+	if result == nil {
+		t.Fatal("Expected replacements for changed classification")
+	}
+	if result.Original.EntryType != nil || result.Original.Category != nil {
+		t.Error("Unchanged entrytype/category should not be recorded")
+	}
+	if result.Original.VegOnly == nil || *result.Original.VegOnly != 0 || *result.Replacement.VegOnly != 1 {
+		t.Errorf("vegonly not recorded: %+v -> %+v", result.Original.VegOnly, result.Replacement.VegOnly)
+	}
+	if result.Original.Path == nil || *result.Original.Path != "north-america|usa|ny" {
+		t.Errorf("Original path = %v", result.Original.Path)
+	}
+
+	want := []string{"path", "vegonly", "vegan"}
+	got := result.ReplacedFields()
+	if len(got) != len(want) {
+		t.Fatalf("ReplacedFields = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ReplacedFields = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestParseVenueDataReplacement(t *testing.T) {
+	orig := &VenueDataReplacement{
+		Original:    &VenueFieldData{Phone: nil},
+		Replacement: &VenueFieldData{Phone: strPtr("+456"), Name: strPtr("New")},
+	}
+	raw, err := orig.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	empty := "  "
+	bad := "{"
+
+	tests := []struct {
+		name    string
+		raw     *string
+		fields  []string
+		wantErr bool
+	}{
+		{"nil", nil, nil, false},
+		{"blank", &empty, nil, false},
+		{"round trip", &raw, []string{"name", "phone"}, false},
+		{"invalid", &bad, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vdr, err := ParseVenueDataReplacement(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			got := vdr.ReplacedFields()
+			if len(got) != len(tt.fields) {
+				t.Fatalf("ReplacedFields = %v, want %v", got, tt.fields)
+			}
+			for i := range got {
+				if got[i] != tt.fields[i] {
+					t.Fatalf("ReplacedFields = %v, want %v", got, tt.fields)
+				}
+			}
+		})
+	}
+}
+
+func TestFieldChanges(t *testing.T) {
+	raw := `{"original":{"name":"Old","lat":40.5},"replacement":{"name":"New","lat":40.75,"phone":"+1 555","category":3}}`
+	vdr, err := ParseVenueDataReplacement(&raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := vdr.FieldChanges()
+	want := []FieldChange{
+		{Field: "name", From: "Old", To: "New"},
+		{Field: "lat", From: "40.5", To: "40.75"},
+		{Field: "phone", From: "", To: "+1 555"},
+		{Field: "category", From: "", To: "3"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("changes = %+v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("change %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if (&VenueDataReplacement{}).FieldChanges() != nil {
+		t.Fatalf("empty replacement should have no changes")
+	}
+}
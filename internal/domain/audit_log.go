@@ -8,7 +8,7 @@ type VenueValidationAuditLog struct {
 	VenueID          int64
 	HistoryID        *int64 // nullable - can be NULL
 	AdminID          *int   // nullable - NULL for automated validations
-	Status           string // "approved" or "rejected"
+	Status           string // "approved", "rejected", "reverted", "notified", "notify_failed", "place_relinked" or "duplicate_rejected"
 	Reason           *string
 	DataReplacements *string // JSON string tracking original vs replaced venue data
 	CreatedAt        time.Time
@@ -38,3 +38,19 @@ func NewAuditLogWithReplacements(venueID int64, historyID *int64, adminID *int,
 		CreatedAt:        time.Now(),
 	}
 }
+
+// AuditLogFilter narrows the cross-venue audit log browser. Zero values match everything.
+type AuditLogFilter struct {
+	AdminID         int       // 0 = any admin, including automated entries
+	Status          string    // audit action, e.g. "approved" or "reverted"
+	From, To        time.Time // created_at in [From, To)
+	HasReplacements bool      // only entries that replaced venue data
+	Limit, Offset   int
+}
+
+// AuditLogEntry is an audit log row with the names the browser shows next to the IDs.
+type AuditLogEntry struct {
+	VenueValidationAuditLog
+	VenueName     string
+	AdminUsername string
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"time"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// Change request statuses.
+const (
+	ChangeRequestPending  = "pending"
+	ChangeRequestApplied  = "applied"
+	ChangeRequestRejected = "rejected"
+)
+
+// VenueChangeRequest is a proposed edit to an approved venue. Proposed holds the submitted
+// values; only those that differ from the venue when it is validated or applied are a change,
+// so a request stays correct when the venue is edited in between.
+type VenueChangeRequest struct {
+	ID        int64          `json:"id"`
+	VenueID   int64          `json:"venue_id"`
+	VenueName string         `json:"venue_name,omitempty"`
+	Editor    models.User    `json:"editor"` // member who proposed the edit; ID, Username, Trusted and IsVenueAdmin are loaded
+	Proposed  VenueFieldData `json:"proposed"`
+	Note      string         `json:"note,omitempty"`
+	Status    string         `json:"status"`
+	CreatedAt time.Time      `json:"created_at"`
+
+	// Set by validation: the AI score of the edited fields and the decision engine's status for it
+	Score                *int                     `json:"score,omitempty"`
+	Assessment           *models.ChangeAssessment `json:"assessment,omitempty"`
+	Recommendation       string                   `json:"recommendation,omitempty"`
+	RecommendationReason string                   `json:"recommendation_reason,omitempty"`
+	ValidatedAt          *time.Time               `json:"validated_at,omitempty"`
+
+	DecidedBy      *int       `json:"decided_by,omitempty"`
+	DecidedAt      *time.Time `json:"decided_at,omitempty"`
+	DecisionReason string     `json:"decision_reason,omitempty"`
+}
+
+// ApprovalData returns the data that applies the request to venue, with Replacements holding
+// the current and proposed value of every field that changes. Fields equal to the venue's are
+// left out. Returns nil when nothing would change.
+func (cr *VenueChangeRequest) ApprovalData(venue *models.Venue, adminID int, notes string) *ApprovalData {
+	p := cr.Proposed
+	proposed := NewApprovalData(cr.VenueID, adminID, notes)
+	proposed.Name, proposed.Address, proposed.Description = p.Name, p.Address, p.Description
+	proposed.Lat, proposed.Lng = p.Lat, p.Lng
+	proposed.Phone, proposed.Website = p.Phone, p.Website
+	proposed.OpenHours, proposed.OpenHoursNote, proposed.Timezone = p.OpenHours, p.OpenHoursNote, p.Timezone
+	proposed.EntryType, proposed.Path = p.EntryType, p.Path
+	proposed.VegOnly, proposed.Vegan, proposed.Category = p.VegOnly, p.Vegan, p.Category
+
+	vdr := BuildVenueDataReplacements(venue, proposed)
+	if vdr == nil {
+		return nil
+	}
+	r := vdr.Replacement
+	ad := NewApprovalData(cr.VenueID, adminID, notes)
+	ad.Replacements = vdr
+	ad.Name, ad.Address, ad.Description = r.Name, r.Address, r.Description
+	ad.Lat, ad.Lng = r.Lat, r.Lng
+	ad.Phone, ad.Website = r.Phone, r.Website
+	ad.OpenHours, ad.OpenHoursNote, ad.Timezone = r.OpenHours, r.OpenHoursNote, r.Timezone
+	ad.EntryType, ad.Path = r.EntryType, r.Path
+	ad.VegOnly, ad.Vegan, ad.Category = r.VegOnly, r.Vegan, r.Category
+	return ad
+}
+
+// ApplyTo sets the fields present in d on venue, e.g. to evaluate a venue as it would be
+// after a change request.
+func (d *VenueFieldData) ApplyTo(venue *models.Venue) {
+	if d == nil {
+		return
+	}
+	if d.Name != nil {
+		venue.Name = *d.Name
+	}
+	if d.Address != nil {
+		venue.Location = *d.Address
+	}
+	if d.Description != nil {
+		venue.AdditionalInfo = d.Description
+	}
+	if d.Lat != nil {
+		venue.Lat = d.Lat
+	}
+	if d.Lng != nil {
+		venue.Lng = d.Lng
+	}
+	if d.Phone != nil {
+		venue.Phone = d.Phone
+	}
+	if d.Website != nil {
+		venue.URL = d.Website
+	}
+	if d.OpenHours != nil {
+		venue.OpenHours = d.OpenHours
+	}
+	if d.OpenHoursNote != nil {
+		venue.OpenHoursNote = d.OpenHoursNote
+	}
+	if d.Timezone != nil {
+		venue.Timezone = d.Timezone
+	}
+	if d.EntryType != nil {
+		venue.EntryType = *d.EntryType
+	}
+	if d.Path != nil {
+		venue.Path = d.Path
+	}
+	if d.VegOnly != nil {
+		venue.VegOnly = *d.VegOnly
+	}
+	if d.Vegan != nil {
+		venue.Vegan = *d.Vegan
+	}
+	if d.Category != nil {
+		venue.Category = *d.Category
+	}
+}
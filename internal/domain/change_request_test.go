@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"testing"
+
+	"assisted-venue-approval/internal/models"
+)
+
+func TestVenueChangeRequest_ApprovalData(t *testing.T) {
+	one := 1
+	venue := &models.Venue{ID: 7, Name: "Green Bowl", Phone: strPtr("+1 555 0100"), VegOnly: 1}
+	cr := &VenueChangeRequest{
+		VenueID: 7,
+		Proposed: VenueFieldData{
+			Name:    strPtr(" Green Bowl "), // same after trimming
+			Phone:   strPtr("+1 555 0199"),
+			Website: strPtr("https://greenbowl.example"),
+			VegOnly: &one,
+		},
+	}
+
+	ad := cr.ApprovalData(venue, 5, "applied")
+	if ad == nil {
+		t.Fatal("expected changes")
+	}
+	if ad.VenueID != 7 || ad.AdminID != 5 || ad.Notes != "applied" {
+		t.Fatalf("approval data = %+v", ad)
+	}
+	if ad.Name != nil || ad.VegOnly != nil {
+		t.Fatalf("unchanged fields are set: name=%v vegonly=%v", ad.Name, ad.VegOnly)
+	}
+	if ad.Phone == nil || *ad.Phone != "+1 555 0199" || ad.Website == nil {
+		t.Fatalf("changed fields missing: phone=%v website=%v", ad.Phone, ad.Website)
+	}
+	if got := ad.Replacements.ReplacedFields(); len(got) != 2 || got[0] != "phone" || got[1] != "website" {
+		t.Fatalf("replaced fields = %v", got)
+	}
+	if o := ad.Replacements.Original; o.Phone == nil || *o.Phone != "+1 555 0100" || o.Website != nil {
+		t.Fatalf("originals = %+v", o)
+	}
+
+	applied := *venue
+	ad.Replacements.Replacement.ApplyTo(&applied)
+	if *applied.Phone != "+1 555 0199" || applied.URL == nil || applied.Name != "Green Bowl" {
+		t.Fatalf("venue after ApplyTo = %+v", applied)
+	}
+
+	venue.Phone, venue.URL = strPtr("+1 555 0199"), strPtr("https://greenbowl.example")
+	if ad := cr.ApprovalData(venue, 5, ""); ad != nil {
+		t.Fatalf("request matching the venue should change nothing, got %+v", ad.Replacements)
+	}
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// OutboxEvent is an event recorded in the same transaction as the change it describes.
+// A dispatcher later copies it to the event store, so a committed decision always ends up
+// with its event and a rolled-back one never does.
+type OutboxEvent struct {
+	VenueID int64
+	Type    string
+	Ts      time.Time
+	Admin   *string
+	Payload []byte // JSON
+}
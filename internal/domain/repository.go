@@ -2,36 +2,65 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"assisted-venue-approval/internal/models"
 )
 
-// VenueRepository defines data access for venues and related views.
-type VenueRepository interface {
+// The repository is split by concern so consumers can depend on (and tests can mock) only
+// what they use. Repository composes all of them for code that needs the whole store.
+//
+//go:generate go run ../testing/mockgen -src . -out ../testing/repository_mocks.go -pkg testutil VenueReader VenueWriter VenueRepository HistoryStore FeedbackStore AuditStore SandboxRepository RunStore JobQueue CheckpointStore BatchScoringStore RescoreStore ReputationStore SubmitterRuleStore ConfigChangeStore FeatureFlagStore EmbeddingStore HoldStore ClaimStore CommentStore SavedFilterStore UIPreferenceStore PrivacyStore ClosureStore ChangeRequestStore CategoryReviewStore Repository UnitOfWork UnitOfWorkFactory
+
+// VenueReader defines read access to venues and related views.
+type VenueReader interface {
 	GetPendingVenuesWithUserCtx(ctx context.Context) ([]models.VenueWithUser, error)
-	GetVenuesFilteredCtx(ctx context.Context, status string, search string, limit int, offset int) ([]models.VenueWithUser, int, error)
+	GetVenuesFilteredCtx(ctx context.Context, status, search, pathPrefix string, limit int, offset int) ([]models.VenueWithUser, int, error)
+	GetVenuesFilteredKeysetCtx(ctx context.Context, status, search, pathPrefix, after string, limit int) ([]models.VenueWithUser, models.PageCursors, int, error)
 	GetVenueWithUserByIDCtx(ctx context.Context, venueID int64) (*models.VenueWithUser, error)
 	GetSimilarVenuesCtx(ctx context.Context, venue models.Venue, limit int) ([]models.Venue, error)
-	GetManualReviewVenuesCtx(ctx context.Context, search string, minScore int, trustedOnly bool, sort string, limit int, offset int) ([]models.VenueWithUser, []int, int, error)
+	GetManualReviewVenuesCtx(ctx context.Context, f models.ManualReviewFilter, limit int, offset int) ([]models.VenueWithUser, []int, int, error)
 	GetVenueStatisticsCtx(ctx context.Context) (*models.VenueStats, error)
 	CountVenuesByPathCtx(ctx context.Context, path string, excludeVenueID int64) (int, error)
 	FindDuplicateVenuesByNameAndLocation(ctx context.Context, name string, lat, lng float64, radiusMeters int, excludeVenueID int64) ([]models.Venue, error)
+	CountRecentVenuesByUserAndNameCtx(ctx context.Context, userID uint, name string, since time.Time, beforeVenueID int64) (int, error)
+}
 
+// VenueWriter defines venue status changes and approvals.
+type VenueWriter interface {
 	UpdateVenueStatusCtx(ctx context.Context, venueID int64, active int, notes string, reviewer *string) error
 	UpdateVenueActiveCtx(ctx context.Context, venueID int64, active int) error
 	ApproveVenueWithDataReplacement(ctx context.Context, approvalData *ApprovalData) error
+	RevertVenueApprovalCtx(ctx context.Context, venueID int64, replacements *VenueDataReplacement, notes string) error
+	// ApplyVenueChangesCtx writes the fields of an accepted change request to an approved venue.
+	ApplyVenueChangesCtx(ctx context.Context, changes *ApprovalData) error
 }
 
-// ValidationRepository defines access for validation history and caches.
-type ValidationRepository interface {
+// VenueRepository defines data access for venues and related views.
+type VenueRepository interface {
+	VenueReader
+	VenueWriter
+}
+
+// HistoryStore defines access for validation history and caches.
+type HistoryStore interface {
 	SaveValidationResultCtx(ctx context.Context, result *models.ValidationResult) error
 	SaveValidationResultWithGoogleDataCtx(ctx context.Context, result *models.ValidationResult, googleData *models.GooglePlaceData) error
 	GetRecentValidationResultsCtx(ctx context.Context, limit int) ([]models.ValidationResult, error)
 	GetVenueValidationHistoryCtx(ctx context.Context, venueID int64) ([]models.ValidationHistory, error)
 	GetValidationHistoryPaginatedCtx(ctx context.Context, limit int, offset int) ([]models.ValidationHistory, int, error)
+	GetValidationHistoryKeysetCtx(ctx context.Context, after string, limit int) ([]models.ValidationHistory, models.PageCursors, int, error)
 	GetCachedGooglePlaceDataCtx(ctx context.Context, venueID int64) (*models.GooglePlaceData, error)
 	HasAnyValidationHistory(venueID int64) (bool, error)
 	ValidateApprovalEligibility(venueID int64, threshold int) error
+	// SaveValidationResultsCtx writes all records in one multi-row INSERT.
+	SaveValidationResultsCtx(ctx context.Context, records []HistoryRecord) error
+}
+
+// HistoryRecord is one validation history row of a batched write; GoogleData may be nil.
+type HistoryRecord struct {
+	Result     *models.ValidationResult
+	GoogleData *models.GooglePlaceData
 }
 
 // UserRepository defines user-related data access. Not yet used by services here.
@@ -42,25 +71,221 @@ type UserRepository interface {
 	Delete(ctx context.Context, id uint) error
 }
 
-// Repository aggregates the repos commonly required by services.
-type FeedbackRepository interface {
+// FeedbackStore defines editor feedback data access.
+type FeedbackStore interface {
 	CreateFeedbackCtx(ctx context.Context, f *models.EditorFeedback) error
 	GetFeedbackByVenueCtx(ctx context.Context, venueID int64, limit int) ([]models.EditorFeedback, int, int, error)
 	GetFeedbackStatsCtx(ctx context.Context, promptVersion *string) (*models.FeedbackStats, error)
+	// GetAllEditorFeedbackPaginatedCtx lists feedback across venues, newest first, with the total.
+	GetAllEditorFeedbackPaginatedCtx(ctx context.Context, limit, offset int) ([]models.EditorFeedbackWithVenue, int, error)
 }
 
-// AuditLogRepository defines audit log data access for venue validations.
-type AuditLogRepository interface {
+// AuditStore defines audit log data access for venue validations.
+type AuditStore interface {
 	CreateAuditLogCtx(ctx context.Context, log *VenueValidationAuditLog) error
 	GetAuditLogsByHistoryIDCtx(ctx context.Context, historyID int64) ([]VenueValidationAuditLog, error)
 	GetAuditLogsByAdminIDCtx(ctx context.Context, adminID int, limit int, offset int) ([]VenueValidationAuditLog, int, error)
 	GetAuditLogsByVenueIDCtx(ctx context.Context, venueID int64) ([]VenueValidationAuditLog, error)
+	// ListAuditLogsCtx lists audit logs across venues, newest first, with the match count.
+	ListAuditLogsCtx(ctx context.Context, f AuditLogFilter) ([]AuditLogEntry, int, error)
+}
+
+// SandboxRepository stores dry-run validation results apart from the real history.
+type SandboxRepository interface {
+	SaveSandboxResultCtx(ctx context.Context, result *models.ValidationResult, googleData *models.GooglePlaceData) error
+	GetSandboxResultsCtx(ctx context.Context, venueID int64, limit int) ([]models.ValidationHistory, error)
+}
+
+// RunStore persists processing runs: batch validations and their outcome counts.
+type RunStore interface {
+	CreateProcessingRunCtx(ctx context.Context, run *models.ProcessingRun) error
+	UpdateProcessingRunCtx(ctx context.Context, run *models.ProcessingRun) error
+	ListProcessingRunsCtx(ctx context.Context, limit, offset int) ([]models.ProcessingRun, int, error)
+	GetProcessingRunCtx(ctx context.Context, id int64) (*models.ProcessingRun, error)
+	GetRunValidationHistoryCtx(ctx context.Context, runID int64) ([]models.ValidationHistory, error)
+	RecordRunOutcomeCtx(ctx context.Context, runID int64, outcome string) error
+	SetRunVenueCountCtx(ctx context.Context, runID int64, venueCount int) error
+}
+
+// CheckpointStore keeps the progress of jobs interrupted by a shutdown.
+type CheckpointStore interface {
+	SaveCheckpointsCtx(ctx context.Context, cps []models.JobCheckpoint) error
+	GetCheckpointCtx(ctx context.Context, venueID int64, maxAge time.Duration) (*models.JobCheckpoint, error)
+	DeleteCheckpointCtx(ctx context.Context, venueID int64) error
+	PruneCheckpointsCtx(ctx context.Context, maxAge time.Duration) (int, error)
+}
+
+// BatchScoringStore keeps the scoring calls of batch-mode venues and the OpenAI Batch API
+// jobs they were submitted in.
+type BatchScoringStore interface {
+	AddBatchItemCtx(ctx context.Context, item *models.BatchScoringItem) error
+	ListPendingBatchItemsCtx(ctx context.Context, limit int) ([]models.BatchScoringItem, error)
+	// CreateScoringBatchCtx records b and marks the items submitted in it.
+	CreateScoringBatchCtx(ctx context.Context, b *models.ScoringBatch, itemIDs []int64) error
+	UpdateScoringBatchCtx(ctx context.Context, b *models.ScoringBatch) error
+	// ListScoringBatchesCtx returns batches newest first; open limits it to those not yet ingested.
+	ListScoringBatchesCtx(ctx context.Context, open bool, limit int) ([]models.ScoringBatch, error)
+	GetBatchItemsCtx(ctx context.Context, batchID int64) ([]models.BatchScoringItem, error)
+	FinishBatchItemCtx(ctx context.Context, id int64, status, errMsg string) error
+}
+
+// RescoreStore tracks the scoring logic in use and finds pending venues scored with older
+// logic.
+type RescoreStore interface {
+	// SetScoringVersionCtx records version as the current one of kind and returns since when
+	// it has been. The first version recorded for a kind is the baseline and returns the zero time.
+	SetScoringVersionCtx(ctx context.Context, kind, version string) (time.Time, error)
+	// ListStaleScoredVenuesCtx returns up to limit pending venue IDs matching q, least
+	// recently validated first.
+	ListStaleScoredVenuesCtx(ctx context.Context, q models.StaleScoreQuery, limit int) ([]int64, error)
+}
+
+// ReputationStore reports how a member's earlier submissions were decided.
+type ReputationStore interface {
+	GetSubmissionHistoryCtx(ctx context.Context, userID uint, recent int) (*models.SubmissionHistory, error)
+}
+
+// SubmitterRuleStore holds the admin-managed submitter block and allow lists.
+type SubmitterRuleStore interface {
+	ListSubmitterRulesCtx(ctx context.Context) ([]models.SubmitterRule, error)
+	CreateSubmitterRuleCtx(ctx context.Context, r *models.SubmitterRule) error
+	DeleteSubmitterRuleCtx(ctx context.Context, id int64) (bool, error)
+}
+
+// ConfigChangeStore records runtime setting changes made through the config API.
+type ConfigChangeStore interface {
+	AddConfigChangesCtx(ctx context.Context, changes []models.ConfigChange) error
+	// ListConfigChangesCtx returns up to limit changes, newest first.
+	ListConfigChangesCtx(ctx context.Context, limit int) ([]models.ConfigChange, error)
+}
+
+// FeatureFlagStore holds the stored feature flag settings.
+type FeatureFlagStore interface {
+	ListFeatureFlagsCtx(ctx context.Context) ([]models.FeatureFlag, error)
+	// SetFeatureFlagCtx creates or replaces the flag named f.Name.
+	SetFeatureFlagCtx(ctx context.Context, f models.FeatureFlag) error
+	// DeleteFeatureFlagCtx removes a flag; false when it was not stored.
+	DeleteFeatureFlagCtx(ctx context.Context, name string) (bool, error)
+}
+
+// HoldStore keeps the holds reviewers put on venues awaiting outside information.
+type HoldStore interface {
+	// GetActiveHoldCtx returns the venue's unreleased hold, or nil when there is none.
+	GetActiveHoldCtx(ctx context.Context, venueID int64) (*models.VenueHold, error)
+	// PlaceHoldCtx stores h, releasing the venue's previous hold if it has one.
+	PlaceHoldCtx(ctx context.Context, h *models.VenueHold) error
+	// ReleaseHoldCtx ends the venue's hold; false when it had none.
+	ReleaseHoldCtx(ctx context.Context, venueID int64, adminID int) (bool, error)
+	// ListActiveHoldsCtx returns unreleased holds of pending venues, earliest reminder first.
+	ListActiveHoldsCtx(ctx context.Context, limit int) ([]models.VenueHold, error)
+}
+
+// ClaimStore records which admin is reviewing a pending venue. Claims idle for longer
+// than the store's timeout are treated as released.
+type ClaimStore interface {
+	// ClaimVenueCtx claims the venue for adminID unless another admin holds an active claim.
+	// It returns the venue's current claim and whether it is adminID's.
+	ClaimVenueCtx(ctx context.Context, venueID int64, adminID int) (*models.VenueClaim, bool, error)
+	// TouchClaimCtx keeps adminID's claim on the venue active; it does nothing otherwise.
+	TouchClaimCtx(ctx context.Context, venueID int64, adminID int) error
+	// ReleaseClaimCtx drops adminID's claim on the venue; false when adminID held none.
+	ReleaseClaimCtx(ctx context.Context, venueID int64, adminID int) (bool, error)
+	// GetActiveClaimsCtx returns the active claims on the given venues by venue ID.
+	GetActiveClaimsCtx(ctx context.Context, venueIDs []int64) (map[int64]models.VenueClaim, error)
+}
+
+// CommentStore keeps reviewers' internal comment threads on venues.
+type CommentStore interface {
+	// CreateVenueCommentCtx stores c and sets its ID. A reply must answer a comment on the
+	// same venue.
+	CreateVenueCommentCtx(ctx context.Context, c *models.VenueComment) error
+	// ListVenueCommentsCtx returns the venue's comments, oldest first.
+	ListVenueCommentsCtx(ctx context.Context, venueID int64) ([]models.VenueComment, error)
+}
+
+// SavedFilterStore keeps admins' named filter sets for the venue lists.
+type SavedFilterStore interface {
+	// ListSavedFiltersCtx returns the admin's filters for a list, by name.
+	ListSavedFiltersCtx(ctx context.Context, adminID int, list string) ([]models.SavedFilter, error)
+	// SaveFilterCtx stores f, replacing the admin's filter of the same name on the list.
+	SaveFilterCtx(ctx context.Context, f *models.SavedFilter) error
+	DeleteSavedFilterCtx(ctx context.Context, adminID int, id int64) (bool, error)
+	// SetDefaultSavedFilterCtx makes filter id the admin's default for the list; 0 clears it.
+	SetDefaultSavedFilterCtx(ctx context.Context, adminID int, list string, id int64) (bool, error)
+}
+
+// UIPreferenceStore keeps each admin's admin UI settings.
+type UIPreferenceStore interface {
+	// GetUIPreferencesCtx returns nil when the admin never saved preferences.
+	GetUIPreferencesCtx(ctx context.Context, adminID int) (*models.UIPreferences, error)
+	SaveUIPreferencesCtx(ctx context.Context, p models.UIPreferences) error
+}
+
+// PrivacyStore answers data subject requests for a member's stored data.
+type PrivacyStore interface {
+	ExportMemberDataCtx(ctx context.Context, memberID int64) (*models.MemberDataExport, error)
+	// PseudonymizeMemberDataCtx hashes feedback IPs with ipKey and redacts the member's email
+	// from validation histories. Repeating it changes nothing further.
+	PseudonymizeMemberDataCtx(ctx context.Context, memberID int64, ipKey []byte) (*models.MemberErasure, error)
+}
+
+// ClosureStore is the editor queue of approved venues Google reports as permanently closed.
+type ClosureStore interface {
+	// ListOpenClosureReviewsCtx returns unresolved reviews of venues still approved, oldest first.
+	ListOpenClosureReviewsCtx(ctx context.Context, limit int) ([]models.ClosureReview, error)
+	// ResolveClosureReviewCtx records the editor's resolution; false when the review is not open.
+	ResolveClosureReviewCtx(ctx context.Context, id int64, adminID int, resolution string) (bool, error)
+}
+
+// ChangeRequestStore keeps proposed edits to approved venues until an editor applies or rejects them.
+type ChangeRequestStore interface {
+	CreateChangeRequestCtx(ctx context.Context, cr *VenueChangeRequest) error
+	GetChangeRequestCtx(ctx context.Context, id int64) (*VenueChangeRequest, error)
+	// ListPendingChangeRequestsCtx returns undecided requests of approved venues, oldest first.
+	ListPendingChangeRequestsCtx(ctx context.Context, limit int) ([]VenueChangeRequest, error)
+	// SaveChangeAssessmentCtx stores the AI assessment and recommendation of a pending request.
+	SaveChangeAssessmentCtx(ctx context.Context, id int64, a *models.ChangeAssessment, recommendation, reason string) error
+	// DecideChangeRequestCtx closes a pending request; false when it is not pending.
+	DecideChangeRequestCtx(ctx context.Context, id int64, adminID int, status, reason string) (bool, error)
+}
+
+// CategoryReviewStore records the category admins approve venues with against the suggested
+// one, for evaluating the category classifier.
+type CategoryReviewStore interface {
+	RecordCategoryReviewCtx(ctx context.Context, r *models.CategoryReview) error
+	// CategoryReviewStatsCtx counts reviews in [from, to) by source, confidence and mismatch.
+	CategoryReviewStatsCtx(ctx context.Context, from, to time.Time) ([]models.CategoryReviewStat, error)
+}
+
+// EmbeddingStore keeps venue text embeddings for the near-duplicate and templated text checks.
+type EmbeddingStore interface {
+	// GetVenueEmbeddingCtx returns the venue's stored vector for model, or nil when there is none.
+	GetVenueEmbeddingCtx(ctx context.Context, venueID int64, model string) (*models.VenueEmbedding, error)
+	SaveVenueEmbeddingCtx(ctx context.Context, emb *models.VenueEmbedding) error
+	// ListVenueEmbeddingsCtx returns up to limit vectors for model updated after since, newest first.
+	ListVenueEmbeddingsCtx(ctx context.Context, model string, since time.Time, limit int) ([]models.VenueEmbedding, error)
+}
+
+// JobQueue is the processing queue shared by all instances in distributed mode, with the
+// instance heartbeats used to find jobs whose instance died.
+type JobQueue interface {
+	EnqueueJobsCtx(ctx context.Context, jobs []models.QueuedJob) (int, error)
+	ClaimJobsCtx(ctx context.Context, instanceID string, limit int) ([]models.QueuedJob, error)
+	CompleteJobCtx(ctx context.Context, id int64) error
+	CountWaitingJobsCtx(ctx context.Context) (int, error)
+	HeartbeatCtx(ctx context.Context, instanceID, hostname string) error
+	RecoverOrphanedJobsCtx(ctx context.Context, staleAfter time.Duration) (int, error)
+	ReleaseInstanceCtx(ctx context.Context, instanceID string) error
+	ListProcessingInstancesCtx(ctx context.Context) ([]models.ProcessingInstance, error)
 }
 
 // Repository aggregates the repos commonly required by services.
 type Repository interface {
-	VenueRepository
-	ValidationRepository
-	FeedbackRepository
-	AuditLogRepository
+	VenueReader
+	VenueWriter
+	HistoryStore
+	FeedbackStore
+	AuditStore
+	SandboxRepository
+	RunStore
 }
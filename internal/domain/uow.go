@@ -16,8 +16,7 @@ import "context"
 //
 // NOTE: Keep the transaction as short as possible.
 // TODO: consider adding a helper CommitOrRollback pattern.
-//
-//go:generate mockgen -destination=../../mocks/mock_uow.go -package=mocks assisted-venue-approval/internal/domain UnitOfWork,UnitOfWorkFactory
+// Mocks are generated with the repository ones (see repository.go).
 
 type UnitOfWork interface {
 	// Transaction controls
@@ -27,7 +26,11 @@ type UnitOfWork interface {
 
 	// Repository access (embed to expose methods)
 	VenueRepository
-	ValidationRepository
+	HistoryStore
+
+	// Written with the same transaction: the audit trail and the outbox event of a decision
+	CreateAuditLogCtx(ctx context.Context, log *VenueValidationAuditLog) error
+	EnqueueEventCtx(ctx context.Context, ev OutboxEvent) error
 }
 
 // UnitOfWorkFactory starts new UnitOfWork instances.
@@ -1,20 +1,65 @@
 package drafts
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
 	"sync"
 	"time"
+
+	errs "assisted-venue-approval/pkg/errors"
 )
 
-// DraftStore provides thread-safe in-memory storage for venue editor drafts
+// AnyVersion skips the version check (legacy clients that send no If-Match).
+const AnyVersion = -1
+
+// ErrVersionConflict is returned by a Backend when the stored version is not the expected one.
+var ErrVersionConflict = errs.NewConflict("drafts", "draft version conflict", nil)
+
+// ConflictError reports that another editor changed the draft (or field) since the caller
+// read it. Current is the draft as it is now, nil when it was deleted.
+type ConflictError struct {
+	VenueID int64
+	Field   string // empty for whole-draft operations
+	Current *VenueDraft
+}
+
+func (e *ConflictError) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("draft field %q of venue %d was modified by another editor", e.Field, e.VenueID)
+	}
+	return fmt.Sprintf("draft of venue %d was modified by another editor", e.VenueID)
+}
+
+func (e *ConflictError) Is(target error) bool { return target == ErrVersionConflict }
+
+// Unwrap lets errs.CodeOf report a conflict.
+func (e *ConflictError) Unwrap() error { return ErrVersionConflict }
+
+// Backend persists drafts. Writes are conditional on the stored version so two app
+// instances cannot overwrite each other; expectVersion 0 means the draft must not exist.
+type Backend interface {
+	GetDraftCtx(ctx context.Context, venueID int64) (*VenueDraft, error) // nil, nil when none
+	SaveDraftCtx(ctx context.Context, d *VenueDraft, expectVersion int) error
+	DeleteDraftCtx(ctx context.Context, venueID int64, expectVersion int) error
+}
+
+// DraftStore holds venue editor drafts with optimistic concurrency: every change bumps the
+// draft version and the changed fields' versions, and callers pass the version they read.
+// Drafts live in memory unless a Backend is set.
 type DraftStore struct {
-	mu     sync.RWMutex
-	drafts map[int64]*VenueDraft
+	mu      sync.Mutex
+	drafts  map[int64]*VenueDraft
+	backend Backend
+	now     func() time.Time
 }
 
 // VenueDraft represents editor modifications to a venue
 type VenueDraft struct {
 	VenueID   int64                 `json:"venue_id"`
-	EditorID  int                   `json:"editor_id"`
+	EditorID  int                   `json:"editor_id"` // last editor
+	Version   int                   `json:"version"`
 	Fields    map[string]DraftField `json:"fields"`
 	UpdatedAt time.Time             `json:"updated_at"`
 }
@@ -23,64 +68,204 @@ type VenueDraft struct {
 type DraftField struct {
 	Value          interface{} `json:"value"`
 	OriginalSource string      `json:"original_source"`
+	Version        int         `json:"version,omitempty"`
+	EditorID       int         `json:"editor_id,omitempty"`
+	UpdatedAt      time.Time   `json:"updated_at"`
 }
 
 // NewDraftStore creates a new in-memory draft store
 func NewDraftStore() *DraftStore {
 	return &DraftStore{
 		drafts: make(map[int64]*VenueDraft),
+		now:    time.Now,
 	}
 }
 
-// Save stores or updates a draft for a venue
-func (s *DraftStore) Save(venueID int64, editorID int, fields map[string]DraftField) error {
+// NewPersistentDraftStore creates a draft store backed by b, so drafts survive restarts
+func NewPersistentDraftStore(b Backend) *DraftStore {
+	s := NewDraftStore()
+	s.backend = b
+	return s
+}
+
+// Get retrieves a draft for a venue if it exists
+func (s *DraftStore) Get(ctx context.Context, venueID int64) (*VenueDraft, bool, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.drafts[venueID] = &VenueDraft{
-		VenueID:   venueID,
-		EditorID:  editorID,
-		Fields:    fields,
-		UpdatedAt: time.Now(),
+	d, err := s.load(ctx, venueID)
+	return d, d != nil, err
+}
+
+// Save replaces the whole draft. ifVersion is the draft version the editor started from
+// (0 = no draft yet, AnyVersion = skip the check). Fields whose value did not change keep
+// their version and author.
+func (s *DraftStore) Save(ctx context.Context, venueID int64, editorID int, fields map[string]DraftField, ifVersion int) (*VenueDraft, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cur, err := s.load(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	if ifVersion != AnyVersion && ifVersion != versionOf(cur) {
+		return nil, &ConflictError{VenueID: venueID, Current: cur}
 	}
 
-	return nil
+	now := s.now()
+	next := &VenueDraft{VenueID: venueID, EditorID: editorID, Fields: make(map[string]DraftField, len(fields)), UpdatedAt: now}
+	for name, f := range fields {
+		if cur != nil {
+			if old, ok := cur.Fields[name]; ok && sameField(old, f) {
+				next.Fields[name] = old
+				continue
+			}
+		}
+		next.Fields[name] = stamp(f, fieldVersion(cur, name)+1, editorID, now)
+	}
+	return next, s.store(ctx, cur, next, "")
 }
 
-// Get retrieves a draft for a venue if it exists
-func (s *DraftStore) Get(venueID int64) (*VenueDraft, bool) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+// SetField creates or updates one field. ifVersion is the field version the editor started
+// from (0 = new field, AnyVersion = skip the check); edits to other fields never conflict.
+func (s *DraftStore) SetField(ctx context.Context, venueID int64, editorID int, name string, f DraftField, ifVersion int) (*VenueDraft, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cur, err := s.load(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	if ifVersion != AnyVersion && ifVersion != fieldVersion(cur, name) {
+		return nil, &ConflictError{VenueID: venueID, Field: name, Current: cur}
+	}
 
-	draft, exists := s.drafts[venueID]
-	return draft, exists
+	now := s.now()
+	next := clone(cur, venueID)
+	next.EditorID, next.UpdatedAt = editorID, now
+	next.Fields[name] = stamp(f, fieldVersion(cur, name)+1, editorID, now)
+	return next, s.store(ctx, cur, next, name)
 }
 
-// Delete removes a draft from the store
-func (s *DraftStore) Delete(venueID int64) {
+// DeleteField removes one field; the draft is deleted with its last field.
+// Deleting a field that is not there is a no-op.
+func (s *DraftStore) DeleteField(ctx context.Context, venueID int64, editorID int, name string, ifVersion int) (*VenueDraft, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	delete(s.drafts, venueID)
+	cur, err := s.load(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	if ifVersion != AnyVersion && ifVersion != fieldVersion(cur, name) {
+		return nil, &ConflictError{VenueID: venueID, Field: name, Current: cur}
+	}
+	if cur == nil {
+		return nil, nil
+	}
+	if _, ok := cur.Fields[name]; !ok {
+		return cur, nil
+	}
+	if len(cur.Fields) == 1 {
+		return nil, s.remove(ctx, cur, name)
+	}
+
+	next := clone(cur, venueID)
+	next.EditorID, next.UpdatedAt = editorID, s.now()
+	delete(next.Fields, name)
+	return next, s.store(ctx, cur, next, name)
+}
+
+// Delete removes a draft. ifVersion works as in Save; deleting a missing draft is a no-op.
+func (s *DraftStore) Delete(ctx context.Context, venueID int64, ifVersion int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cur, err := s.load(ctx, venueID)
+	if err != nil {
+		return err
+	}
+	if ifVersion != AnyVersion && ifVersion != versionOf(cur) {
+		return &ConflictError{VenueID: venueID, Current: cur}
+	}
+	if cur == nil {
+		return nil
+	}
+	return s.remove(ctx, cur, "")
+}
+
+func (s *DraftStore) load(ctx context.Context, venueID int64) (*VenueDraft, error) {
+	if s.backend != nil {
+		return s.backend.GetDraftCtx(ctx, venueID)
+	}
+	return s.drafts[venueID], nil
+}
+
+// store writes next over cur. A backend conflict means another instance wrote in between;
+// field names the field being changed, if any, for the conflict report.
+func (s *DraftStore) store(ctx context.Context, cur, next *VenueDraft, field string) error {
+	next.Version = versionOf(cur) + 1
+	if s.backend == nil {
+		s.drafts[next.VenueID] = next
+		return nil
+	}
+	if err := s.backend.SaveDraftCtx(ctx, next, versionOf(cur)); err != nil {
+		return s.conflict(ctx, next.VenueID, field, err)
+	}
+	return nil
+}
+
+func (s *DraftStore) remove(ctx context.Context, cur *VenueDraft, field string) error {
+	if s.backend == nil {
+		delete(s.drafts, cur.VenueID)
+		return nil
+	}
+	if err := s.backend.DeleteDraftCtx(ctx, cur.VenueID, cur.Version); err != nil {
+		return s.conflict(ctx, cur.VenueID, field, err)
+	}
+	return nil
+}
+
+func (s *DraftStore) conflict(ctx context.Context, venueID int64, field string, err error) error {
+	if !errors.Is(err, ErrVersionConflict) {
+		return err
+	}
+	latest, lerr := s.backend.GetDraftCtx(ctx, venueID)
+	if lerr != nil {
+		return err
+	}
+	return &ConflictError{VenueID: venueID, Field: field, Current: latest}
 }
 
-// GetEditorInfo returns information about who last edited a draft
-func (s *DraftStore) GetEditorInfo(venueID int64) (editorID int, updatedAt time.Time, exists bool) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+func versionOf(d *VenueDraft) int {
+	if d == nil {
+		return 0
+	}
+	return d.Version
+}
 
-	draft, exists := s.drafts[venueID]
-	if !exists {
-		return 0, time.Time{}, false
+func fieldVersion(d *VenueDraft, name string) int {
+	if d == nil {
+		return 0
 	}
+	return d.Fields[name].Version
+}
 
-	return draft.EditorID, draft.UpdatedAt, true
+func clone(d *VenueDraft, venueID int64) *VenueDraft {
+	out := &VenueDraft{VenueID: venueID, Fields: make(map[string]DraftField)}
+	if d != nil {
+		for k, v := range d.Fields {
+			out.Fields[k] = v
+		}
+	}
+	return out
 }
 
-// Count returns the total number of drafts in the store
-func (s *DraftStore) Count() int {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+func stamp(f DraftField, version, editorID int, now time.Time) DraftField {
+	f.Version, f.EditorID, f.UpdatedAt = version, editorID, now
+	return f
+}
 
-	return len(s.drafts)
+func sameField(a, b DraftField) bool {
+	return a.OriginalSource == b.OriginalSource && reflect.DeepEqual(a.Value, b.Value)
 }
@@ -0,0 +1,133 @@
+package drafts
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func field(v interface{}) DraftField { return DraftField{Value: v, OriginalSource: "user"} }
+
+func TestDraftStore_SaveVersions(t *testing.T) {
+	ctx := context.Background()
+	s := NewDraftStore()
+
+	d, err := s.Save(ctx, 1, 10, map[string]DraftField{"name": field("A"), "phone": field("1")}, 0)
+	if err != nil || d.Version != 1 || d.Fields["name"].Version != 1 {
+		t.Fatalf("first save: %+v %v", d, err)
+	}
+
+	// Stale version from a second editor conflicts and returns the current draft
+	_, err = s.Save(ctx, 1, 20, map[string]DraftField{"name": field("B")}, 0)
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) || !errors.Is(err, ErrVersionConflict) || conflict.Current.Version != 1 {
+		t.Fatalf("want conflict, got %v", err)
+	}
+
+	// Unchanged fields keep their version and author
+	d, err = s.Save(ctx, 1, 20, map[string]DraftField{"name": field("A"), "phone": field("2")}, 1)
+	if err != nil || d.Version != 2 {
+		t.Fatalf("second save: %+v %v", d, err)
+	}
+	if n := d.Fields["name"]; n.Version != 1 || n.EditorID != 10 {
+		t.Errorf("name = %+v", n)
+	}
+	if p := d.Fields["phone"]; p.Version != 2 || p.EditorID != 20 {
+		t.Errorf("phone = %+v", p)
+	}
+
+	if _, err := s.Save(ctx, 1, 30, map[string]DraftField{"name": field("C")}, AnyVersion); err != nil {
+		t.Fatalf("AnyVersion: %v", err)
+	}
+}
+
+func TestDraftStore_Fields(t *testing.T) {
+	ctx := context.Background()
+	s := NewDraftStore()
+
+	if _, err := s.SetField(ctx, 1, 10, "name", field("A"), 0); err != nil {
+		t.Fatal(err)
+	}
+	// Editing another field never conflicts on the name field's version
+	d, err := s.SetField(ctx, 1, 20, "phone", field("1"), 0)
+	if err != nil || d.Version != 2 || len(d.Fields) != 2 {
+		t.Fatalf("phone: %+v %v", d, err)
+	}
+	// Both editors started from name@1; the second write loses
+	if _, err := s.SetField(ctx, 1, 10, "name", field("B"), 1); err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.SetField(ctx, 1, 20, "name", field("C"), 1)
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) || conflict.Field != "name" || conflict.Current.Fields["name"].Value != "B" {
+		t.Fatalf("want name conflict, got %v", err)
+	}
+
+	if _, err := s.DeleteField(ctx, 1, 20, "name", 1); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("stale delete: %v", err)
+	}
+	if d, err := s.DeleteField(ctx, 1, 20, "name", 2); err != nil || len(d.Fields) != 1 {
+		t.Fatalf("delete name: %+v %v", d, err)
+	}
+	// The last field takes the draft with it
+	if d, err := s.DeleteField(ctx, 1, 20, "phone", 1); err != nil || d != nil {
+		t.Fatalf("delete phone: %+v %v", d, err)
+	}
+	if _, ok, _ := s.Get(ctx, 1); ok {
+		t.Fatal("draft should be gone")
+	}
+}
+
+func TestDraftStore_Delete(t *testing.T) {
+	ctx := context.Background()
+	s := NewDraftStore()
+	if err := s.Delete(ctx, 1, 0); err != nil {
+		t.Fatalf("missing draft: %v", err)
+	}
+	_, _ = s.Save(ctx, 1, 10, map[string]DraftField{"name": field("A")}, 0)
+	if err := s.Delete(ctx, 1, 0); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("stale delete: %v", err)
+	}
+	if err := s.Delete(ctx, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// racyBackend simulates another instance writing between our read and our write.
+type racyBackend struct {
+	d     *VenueDraft
+	races int
+}
+
+func (b *racyBackend) GetDraftCtx(context.Context, int64) (*VenueDraft, error) { return b.d, nil }
+
+func (b *racyBackend) SaveDraftCtx(_ context.Context, d *VenueDraft, expect int) error {
+	if b.races > 0 {
+		b.races--
+		b.d = &VenueDraft{VenueID: d.VenueID, EditorID: 99, Version: expect + 1, Fields: map[string]DraftField{"name": {Value: "theirs", Version: 1}}}
+		return ErrVersionConflict
+	}
+	b.d = d
+	return nil
+}
+
+func (b *racyBackend) DeleteDraftCtx(context.Context, int64, int) error {
+	b.d = nil
+	return nil
+}
+
+func TestDraftStore_BackendConflict(t *testing.T) {
+	ctx := context.Background()
+	b := &racyBackend{races: 1}
+	s := NewPersistentDraftStore(b)
+
+	_, err := s.SetField(ctx, 1, 10, "name", field("mine"), 0)
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) || conflict.Field != "name" || conflict.Current == nil || conflict.Current.EditorID != 99 {
+		t.Fatalf("want conflict with the other instance's draft, got %v", err)
+	}
+	d, err := s.SetField(ctx, 1, 10, "name", field("mine"), 1)
+	if err != nil || d.Version != 2 || b.d.Fields["name"].Value != "mine" {
+		t.Fatalf("retry: %+v %v", d, err)
+	}
+}
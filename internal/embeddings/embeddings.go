@@ -0,0 +1,101 @@
+// Package embeddings turns venue text into vectors and finds venues whose text is nearly the
+// same, to catch resubmitted venues and text templated across many venues that name and
+// location matching miss.
+package embeddings
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// maxTextRunes bounds the embedded text; longer descriptions are cut, which keeps the call
+// well inside the model's input limit.
+const maxTextRunes = 4000
+
+var (
+	mEmbeddingRequests = metrics.Default.CounterVec("openai_embedding_requests_total", "OpenAI embedding calls, by outcome", "model", "outcome")
+	mEmbeddingTokens   = metrics.Default.CounterVec("openai_embedding_tokens_total", "OpenAI tokens used for embeddings", "model")
+)
+
+// Embedder returns the vector of a text.
+type Embedder interface {
+	Embed(ctx context.Context, text string) ([]float32, error)
+	// Model names the vector space; vectors of different models must not be compared.
+	Model() string
+}
+
+// OpenAI embeds text with the OpenAI embeddings API.
+type OpenAI struct {
+	client     *openai.Client
+	model      string
+	dimensions int
+	timeout    time.Duration
+}
+
+// NewOpenAI returns an embedder for model. dimensions > 0 shortens the vectors, which the
+// text-embedding-3 models support with little loss; 0 keeps the model's size.
+func NewOpenAI(apiKey, model string, dimensions int, timeout time.Duration) *OpenAI {
+	return newOpenAI(openai.NewClient(apiKey), model, dimensions, timeout)
+}
+
+func newOpenAI(client *openai.Client, model string, dimensions int, timeout time.Duration) *OpenAI {
+	if model == "" {
+		model = string(openai.SmallEmbedding3)
+	}
+	return &OpenAI{client: client, model: model, dimensions: dimensions, timeout: timeout}
+}
+
+// Model is the model name, with the dimensions when they were shortened.
+func (o *OpenAI) Model() string {
+	if o.dimensions > 0 {
+		return fmt.Sprintf("%s/%d", o.model, o.dimensions)
+	}
+	return o.model
+}
+
+// Embed returns the vector of text.
+func (o *OpenAI) Embed(ctx context.Context, text string) ([]float32, error) {
+	ctx, cancel := context.WithTimeout(ctx, o.timeout)
+	defer cancel()
+
+	resp, err := o.client.CreateEmbeddings(ctx, openai.EmbeddingRequestStrings{
+		Input:      []string{text},
+		Model:      openai.EmbeddingModel(o.model),
+		Dimensions: o.dimensions,
+	})
+	if err != nil {
+		mEmbeddingRequests.With(o.model, "error").Inc()
+		return nil, fmt.Errorf("embedding: %w", err)
+	}
+	mEmbeddingRequests.With(o.model, "ok").Inc()
+	mEmbeddingTokens.With(o.model).Add(float64(resp.Usage.PromptTokens))
+	if len(resp.Data) == 0 || len(resp.Data[0].Embedding) == 0 {
+		return nil, fmt.Errorf("embedding: empty response")
+	}
+	return resp.Data[0].Embedding, nil
+}
+
+// VenueText is the text embedded for a venue: its name and description, with whitespace
+// collapsed so reformatted copies embed the same.
+func VenueText(v models.Venue) string {
+	text := strings.Join(strings.Fields(v.Name), " ") + "\n" + strings.Join(strings.Fields(v.VDetails), " ")
+	if r := []rune(text); len(r) > maxTextRunes {
+		text = string(r[:maxTextRunes])
+	}
+	return text
+}
+
+// TextHash identifies an embedded text, so an unchanged venue reuses its stored vector.
+func TextHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,54 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+
+	"assisted-venue-approval/internal/models"
+)
+
+func TestOpenAIEmbed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input      []string `json:"input"`
+			Model      string   `json:"model"`
+			Dimensions int      `json:"dimensions"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Input) != 1 || req.Model != "text-embedding-3-small" || req.Dimensions != 3 {
+			t.Errorf("request=%+v", req)
+		}
+		_, _ = w.Write([]byte(`{"object":"list","data":[{"object":"embedding","index":0,"embedding":[0.1,0.2,0.3]}],"usage":{"prompt_tokens":5,"total_tokens":5}}`))
+	}))
+	defer srv.Close()
+
+	cfg := openai.DefaultConfig("k")
+	cfg.BaseURL = srv.URL
+	o := newOpenAI(openai.NewClientWithConfig(cfg), "", 3, time.Second)
+	if o.Model() != "text-embedding-3-small/3" {
+		t.Fatalf("model=%q", o.Model())
+	}
+	vec, err := o.Embed(context.Background(), "Green Leaf\nVegan bowls")
+	if err != nil || len(vec) != 3 || vec[2] != 0.3 {
+		t.Fatalf("got %v %v", vec, err)
+	}
+}
+
+func TestVenueText(t *testing.T) {
+	a := VenueText(models.Venue{Name: " Green  Leaf ", VDetails: "Vegan bowls\n\nand  smoothies."})
+	b := VenueText(models.Venue{Name: "Green Leaf", VDetails: "Vegan bowls and smoothies."})
+	if a != b || TextHash(a) != TextHash(b) {
+		t.Fatalf("whitespace changes the text: %q vs %q", a, b)
+	}
+	long := VenueText(models.Venue{Name: "X", VDetails: strings.Repeat("é", 2*maxTextRunes)})
+	if n := len([]rune(long)); n != maxTextRunes {
+		t.Fatalf("text has %d runes, want %d", n, maxTextRunes)
+	}
+}
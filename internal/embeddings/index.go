@@ -0,0 +1,126 @@
+package embeddings
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Match is a venue whose vector is close to the one searched for.
+type Match struct {
+	VenueID    int64   `json:"venue_id"`
+	Similarity float64 `json:"similarity"` // cosine similarity, 1 for identical text
+}
+
+type entry struct {
+	vec []float32 // unit length
+	at  time.Time
+}
+
+// Index holds the vectors of recent venues in memory and searches them exhaustively. At a
+// few hundred dimensions a search over tens of thousands of venues takes milliseconds, which
+// is small next to the API calls that follow it.
+type Index struct {
+	mu      sync.RWMutex
+	entries map[int64]entry
+}
+
+func NewIndex() *Index {
+	return &Index{entries: map[int64]entry{}}
+}
+
+// Add stores or replaces the vector of a venue, updated at at.
+func (ix *Index) Add(venueID int64, vec []float32, at time.Time) {
+	unit := normalize(vec)
+	if unit == nil {
+		return
+	}
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	if old, ok := ix.entries[venueID]; ok && old.at.After(at) {
+		return
+	}
+	ix.entries[venueID] = entry{vec: unit, at: at}
+}
+
+// Prune drops vectors updated before cutoff, then the oldest ones beyond max.
+func (ix *Index) Prune(cutoff time.Time, max int) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	for id, e := range ix.entries {
+		if e.at.Before(cutoff) {
+			delete(ix.entries, id)
+		}
+	}
+	if len(ix.entries) <= max {
+		return
+	}
+	ids := make([]int64, 0, len(ix.entries))
+	for id := range ix.entries {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ix.entries[ids[i]].at.After(ix.entries[ids[j]].at) })
+	for _, id := range ids[max:] {
+		delete(ix.entries, id)
+	}
+}
+
+// Len returns the number of vectors held.
+func (ix *Index) Len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.entries)
+}
+
+// Similar returns the venues other than exclude whose similarity to vec is at least min,
+// most similar first. Vectors of another size never match.
+func (ix *Index) Similar(vec []float32, min float64, exclude int64) []Match {
+	unit := normalize(vec)
+	if unit == nil {
+		return nil
+	}
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	var out []Match
+	for id, e := range ix.entries {
+		if id == exclude || len(e.vec) != len(unit) {
+			continue
+		}
+		if sim := dot(unit, e.vec); sim >= min {
+			out = append(out, Match{VenueID: id, Similarity: sim})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Similarity != out[j].Similarity {
+			return out[i].Similarity > out[j].Similarity
+		}
+		return out[i].VenueID < out[j].VenueID
+	})
+	return out
+}
+
+// normalize returns vec scaled to unit length, or nil for a zero vector.
+func normalize(vec []float32) []float32 {
+	var sum float64
+	for _, f := range vec {
+		sum += float64(f) * float64(f)
+	}
+	if sum == 0 {
+		return nil
+	}
+	n := math.Sqrt(sum)
+	out := make([]float32, len(vec))
+	for i, f := range vec {
+		out[i] = float32(float64(f) / n)
+	}
+	return out
+}
+
+func dot(a, b []float32) float64 {
+	var s float64
+	for i := range a {
+		s += float64(a[i]) * float64(b[i])
+	}
+	return s
+}
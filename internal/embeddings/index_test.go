@@ -0,0 +1,60 @@
+package embeddings
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIndexSimilar(t *testing.T) {
+	now := time.Now()
+	ix := NewIndex()
+	ix.Add(1, []float32{1, 0, 0}, now)
+	ix.Add(2, []float32{2, 0.2, 0}, now) // same direction as 1, scaled
+	ix.Add(3, []float32{0, 1, 0}, now)
+	ix.Add(4, []float32{1, 0}, now) // other size
+	ix.Add(5, []float32{0, 0, 0}, now)
+
+	got := ix.Similar([]float32{3, 0, 0}, 0.9, 1)
+	if len(got) != 1 || got[0].VenueID != 2 || got[0].Similarity < 0.99 {
+		t.Fatalf("got %+v, want only venue 2", got)
+	}
+	got = ix.Similar([]float32{1, 0.1, 0}, 0, 0)
+	if len(got) != 3 || got[0].Similarity < got[1].Similarity || got[2].VenueID != 3 {
+		t.Fatalf("got %+v, want 1 and 2 before 3", got)
+	}
+	if ix.Len() != 4 {
+		t.Fatalf("zero vector stored: %d entries", ix.Len())
+	}
+}
+
+func TestIndexAddKeepsNewer(t *testing.T) {
+	now := time.Now()
+	ix := NewIndex()
+	ix.Add(1, []float32{1, 0}, now)
+	ix.Add(1, []float32{0, 1}, now.Add(-time.Hour))
+	if got := ix.Similar([]float32{1, 0}, 0.99, 0); len(got) != 1 {
+		t.Fatalf("older vector replaced a newer one: %+v", got)
+	}
+}
+
+func TestIndexPrune(t *testing.T) {
+	now := time.Now()
+	ix := NewIndex()
+	for i := int64(1); i <= 5; i++ {
+		ix.Add(i, []float32{1, float32(i)}, now.Add(-time.Duration(i)*time.Hour))
+	}
+	ix.Prune(now.Add(-4*time.Hour-time.Minute), 10)
+	if ix.Len() != 4 {
+		t.Fatalf("len=%d after cutoff, want 4", ix.Len())
+	}
+	ix.Prune(now.Add(-24*time.Hour), 2)
+	got := ix.Similar([]float32{1, 1}, -1, 0)
+	if len(got) != 2 {
+		t.Fatalf("len=%d after cap, want 2", len(got))
+	}
+	for _, m := range got {
+		if m.VenueID > 2 {
+			t.Errorf("kept older venue %d", m.VenueID)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// Package flags gates new capabilities behind admin-managed feature flags. Flags are stored
+// in the database and cached per instance; a flag without a stored row leaves the capability
+// to its environment setting, so flags only need to exist while a rollout is under way.
+package flags
+
+import (
+	"context"
+	"hash/fnv"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+)
+
+// Flags checked by the application.
+const (
+	PlacesAPINew = "places_api_new" // keyed by venue ID
+	PhotoCheck   = "photo_check"    // keyed by venue ID
+	BatchMode    = "batch_mode"     // keyed by admin ID
+	VenueMap     = "venue_map"      // keyed by admin ID
+)
+
+// Flag describes a flag the application checks.
+type Flag struct {
+	Name        string `json:"name"`
+	Key         string `json:"key"` // what the rollout percentage splits on
+	Description string `json:"description"`
+}
+
+// Known lists the flags in display order.
+var Known = []Flag{
+	{PlacesAPINew, "venue", "Look venues up with the Places API (New); overrides PLACES_API_NEW_PERCENT"},
+	{PhotoCheck, "venue", "Vision check of Google photos; overrides PHOTO_CHECK_ENABLED"},
+	{BatchMode, "admin", "Allow mode=batch runs; needs BATCH_SCORING_ENABLED"},
+	{VenueMap, "admin", "Pending venues map page"},
+}
+
+// IsKnown reports whether name is in Known.
+func IsKnown(name string) bool {
+	for _, f := range Known {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// State is a known flag with its stored setting, if any.
+type State struct {
+	Flag
+	Stored         bool       `json:"stored"` // false: the environment setting applies
+	Enabled        bool       `json:"enabled"`
+	RolloutPercent int        `json:"rollout_percent"`
+	UpdatedBy      int        `json:"updated_by,omitempty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+}
+
+// Service caches the stored flags. They are read on hot paths, so they are reloaded at most
+// once per refresh interval; edits made through this instance invalidate the cache. A nil
+// Service has no flags.
+type Service struct {
+	store   domain.FeatureFlagStore
+	refresh time.Duration
+
+	mu       sync.Mutex
+	flags    map[string]models.FeatureFlag
+	loadedAt time.Time
+}
+
+// New returns a Service over store. Other instances see edits within refresh.
+func New(store domain.FeatureFlagStore, refresh time.Duration) *Service {
+	return &Service{store: store, refresh: refresh, flags: map[string]models.FeatureFlag{}}
+}
+
+// Bucket places key in one of 100 rollout buckets for name. The split differs per flag, so
+// the same venues are not always the first to get every new capability.
+func Bucket(name string, key int64) int {
+	h := fnv.New32a()
+	h.Write([]byte(name + ":" + strconv.FormatInt(key, 10)))
+	return int(h.Sum32() % 100)
+}
+
+// Enabled reports whether name is on for key. Without a stored flag it returns fallback.
+func (s *Service) Enabled(name string, key int64, fallback bool) bool {
+	f, ok := s.get(name)
+	if !ok {
+		return fallback
+	}
+	return f.Enabled && Bucket(name, key) < f.RolloutPercent
+}
+
+// Off reports whether name is stored as off for every key.
+func (s *Service) Off(name string) bool {
+	f, ok := s.get(name)
+	return ok && (!f.Enabled || f.RolloutPercent <= 0)
+}
+
+// States returns every known flag with its stored setting.
+func (s *Service) States() []State {
+	out := make([]State, len(Known))
+	for i, k := range Known {
+		out[i] = State{Flag: k}
+		if f, ok := s.get(k.Name); ok {
+			updatedAt := f.UpdatedAt
+			out[i].Stored, out[i].Enabled, out[i].RolloutPercent = true, f.Enabled, f.RolloutPercent
+			out[i].UpdatedBy, out[i].UpdatedAt = f.UpdatedBy, &updatedAt
+		}
+	}
+	return out
+}
+
+// Invalidate makes the next check reload the flags.
+func (s *Service) Invalidate() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+}
+
+func (s *Service) get(name string) (models.FeatureFlag, bool) {
+	if s == nil {
+		return models.FeatureFlag{}, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if time.Since(s.loadedAt) >= s.refresh {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		list, err := s.store.ListFeatureFlagsCtx(ctx)
+		cancel()
+		if err != nil {
+			log.Printf("[flags] reload failed, keeping %d flags: %v", len(s.flags), err)
+		} else {
+			s.flags = make(map[string]models.FeatureFlag, len(list))
+			for _, f := range list {
+				s.flags[f.Name] = f
+			}
+		}
+		// Also after a failure, so a database outage does not add a query to every check
+		s.loadedAt = time.Now()
+	}
+	f, ok := s.flags[name]
+	return f, ok
+}
@@ -0,0 +1,69 @@
+package flags
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+)
+
+func TestServiceEnabled(t *testing.T) {
+	stored := []models.FeatureFlag{
+		{Name: PhotoCheck, Enabled: true, RolloutPercent: 30},
+		{Name: VenueMap, Enabled: false, RolloutPercent: 100},
+	}
+	loads := 0
+	store := &testutil.FeatureFlagStore{
+		ListFeatureFlagsCtxFunc: func(context.Context) ([]models.FeatureFlag, error) {
+			loads++
+			return stored, nil
+		},
+	}
+	s := New(store, time.Hour)
+
+	on := 0
+	for id := int64(1); id <= 1000; id++ {
+		got := s.Enabled(PhotoCheck, id, false)
+		if got != (Bucket(PhotoCheck, id) < 30) {
+			t.Fatalf("venue %d: enabled = %v in bucket %d", id, got, Bucket(PhotoCheck, id))
+		}
+		if got {
+			on++
+		}
+	}
+	if on < 250 || on > 350 {
+		t.Fatalf("%d of 1000 venues on at 30%%", on)
+	}
+	if s.Enabled(VenueMap, 1, true) || !s.Off(VenueMap) || s.Off(PhotoCheck) {
+		t.Fatal("disabled flag not off for everyone")
+	}
+	if !s.Enabled(BatchMode, 1, true) || s.Enabled(BatchMode, 1, false) || s.Off(BatchMode) {
+		t.Fatal("flag without a row should use the fallback")
+	}
+	if loads != 1 {
+		t.Fatalf("loaded %d times within the refresh interval", loads)
+	}
+
+	// A failed reload keeps the cached flags
+	store.ListFeatureFlagsCtxFunc = func(context.Context) ([]models.FeatureFlag, error) {
+		return nil, errors.New("db down")
+	}
+	s.Invalidate()
+	if !s.Off(VenueMap) {
+		t.Fatal("flags dropped after a failed reload")
+	}
+	states := s.States()
+	if len(states) != len(Known) || states[0].Name != PlacesAPINew || states[0].Stored ||
+		!states[1].Stored || states[1].RolloutPercent != 30 {
+		t.Fatalf("states = %+v", states)
+	}
+
+	var none *Service
+	if !none.Enabled(PhotoCheck, 1, true) || none.Off(VenueMap) || len(none.States()) != len(Known) {
+		t.Fatal("nil service should fall back for every flag")
+	}
+	none.Invalidate()
+}
@@ -0,0 +1,76 @@
+// Package graphqlapi serves a read-only GraphQL API over venues, validations, feedback, audit
+// logs and statistics, so dashboards can fetch exactly the fields they need in one request.
+// The schema is schema.graphql; it has no mutations.
+package graphqlapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/processor"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// Nested venue fields cost a query per venue. Pages hold at most maxPageSize venues and at
+// most maxParallelism resolvers run at once, so one dashboard query cannot take over the
+// connection pool.
+const (
+	maxParallelism = 4
+	maxBodyBytes   = 1 << 20
+)
+
+// Store is the data the schema reads.
+type Store interface {
+	domain.VenueReader
+	domain.HistoryStore
+	domain.FeedbackStore
+	domain.AuditStore
+}
+
+// StatsSource supplies the engine statistics; *processor.ProcessingEngine satisfies it.
+type StatsSource interface {
+	GetStats() processor.ProcessingStats
+}
+
+// Handler handles GET and POST /api/v1/graphql. POST takes the usual JSON body
+// {"query", "operationName", "variables"}; GET takes the same as query parameters, with
+// variables JSON-encoded. engine may be nil, which makes stats.engine null.
+func Handler(store Store, engine StatsSource) http.HandlerFunc {
+	schema := graphql.MustParseSchema(schemaSDL, &resolver{store: store, engine: engine},
+		graphql.MaxParallelism(maxParallelism))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query         string                 `json:"query"`
+			OperationName string                 `json:"operationName"`
+			Variables     map[string]interface{} `json:"variables"`
+		}
+		if r.Method == http.MethodGet {
+			q := r.URL.Query()
+			req.Query, req.OperationName = q.Get("query"), q.Get("operationName")
+			if v := q.Get("variables"); v != "" {
+				if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+					http.Error(w, "variables must be a JSON object", http.StatusBadRequest)
+					return
+				}
+			}
+		} else if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes)).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON body", http.StatusBadRequest)
+			return
+		}
+		if req.Query == "" {
+			http.Error(w, "query is required", http.StatusBadRequest)
+			return
+		}
+
+		resp := schema.Exec(r.Context(), req.Query, req.OperationName, req.Variables)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
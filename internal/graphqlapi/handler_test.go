@@ -0,0 +1,212 @@
+package graphqlapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/internal/processor"
+	testutil "assisted-venue-approval/internal/testing"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+type fakeStats processor.ProcessingStats
+
+func (f fakeStats) GetStats() processor.ProcessingStats { return processor.ProcessingStats(f) }
+
+var t0 = time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+
+func testStore(t *testing.T) *testutil.Repository {
+	active, approved := 0, 1
+	path := "europe|germany"
+	version := "v3"
+	comment := "wrong cuisine"
+	admin := 7
+	venues := map[int64]models.VenueWithUser{
+		1: {Venue: models.Venue{ID: 1, Name: "Green Leaf", Location: "Berlin", Path: &path, Active: &approved}, User: models.User{Username: "alice"}},
+		2: {Venue: models.Venue{ID: 2, Name: "Tofu Hut", Location: "Hamburg", Active: &active}},
+	}
+	return &testutil.Repository{
+		GetVenuesFilteredCtxFunc: func(_ context.Context, status, search, pathPrefix string, limit, offset int) ([]models.VenueWithUser, int, error) {
+			if status != "approved" || limit != 100 || offset != 0 {
+				t.Errorf("GetVenuesFiltered(%q, %q, %q, %d, %d), want approved venues, first capped at 100", status, search, pathPrefix, limit, offset)
+			}
+			return []models.VenueWithUser{venues[1]}, 1, nil
+		},
+		GetVenueWithUserByIDCtxFunc: func(_ context.Context, id int64) (*models.VenueWithUser, error) {
+			vu, ok := venues[id]
+			if !ok {
+				return nil, errs.NewNotFound("test", "venue not found", nil)
+			}
+			return &vu, nil
+		},
+		GetVenueValidationHistoryCtxFunc: func(_ context.Context, id int64) ([]models.ValidationHistory, error) {
+			if id != 1 {
+				return nil, nil
+			}
+			return []models.ValidationHistory{
+				{ID: 10, VenueID: 1, ValidationScore: 60, ValidationStatus: "manual_review", ProcessedAt: t0},
+				{ID: 11, VenueID: 1, ValidationScore: 91, ValidationStatus: "approved", PromptVersion: &version,
+					ScoreBreakdown: map[string]int{"vegan": 30, "hours": 10}, ProcessedAt: t0.Add(time.Hour)},
+			}, nil
+		},
+		GetFeedbackByVenueCtxFunc: func(_ context.Context, id int64, limit int) ([]models.EditorFeedback, int, int, error) {
+			return []models.EditorFeedback{{ID: 3, VenueID: id, FeedbackType: models.FeedbackThumbsDown, Comment: &comment, CreatedAt: t0}}, 0, 1, nil
+		},
+		GetAuditLogsByVenueIDCtxFunc: func(_ context.Context, id int64) ([]domain.VenueValidationAuditLog, error) {
+			return []domain.VenueValidationAuditLog{{ID: 4, VenueID: id, AdminID: &admin, Status: "approved", CreatedAt: t0}}, nil
+		},
+		GetValidationHistoryPaginatedCtxFunc: func(_ context.Context, limit, offset int) ([]models.ValidationHistory, int, error) {
+			return []models.ValidationHistory{{ID: 11, VenueID: 1, VenueName: "Green Leaf", ValidationScore: 91, ValidationStatus: "approved", ProcessedAt: t0}}, 40, nil
+		},
+		GetAllEditorFeedbackPaginatedCtxFunc: func(_ context.Context, limit, offset int) ([]models.EditorFeedbackWithVenue, int, error) {
+			return []models.EditorFeedbackWithVenue{{EditorFeedback: models.EditorFeedback{ID: 3, VenueID: 1, FeedbackType: models.FeedbackThumbsUp, CreatedAt: t0}, VenueName: "Green Leaf"}}, 1, nil
+		},
+		ListAuditLogsCtxFunc: func(_ context.Context, f domain.AuditLogFilter) ([]domain.AuditLogEntry, int, error) {
+			if f.AdminID != 7 || f.Status != "rejected" || !f.From.Equal(t0) || f.Limit != 5 || f.Offset != 10 {
+				t.Errorf("ListAuditLogs(%+v), want admin 7's rejections from t0, 5 from 10", f)
+			}
+			return []domain.AuditLogEntry{{VenueValidationAuditLog: domain.VenueValidationAuditLog{ID: 5, VenueID: 2, AdminID: &admin, Status: "rejected", CreatedAt: t0}, VenueName: "Tofu Hut", AdminUsername: "bob"}}, 12, nil
+		},
+		GetVenueStatisticsCtxFunc: func(context.Context) (*models.VenueStats, error) {
+			return &models.VenueStats{Pending: 3, Approved: 5, Rejected: 1, Total: 9}, nil
+		},
+		GetFeedbackStatsCtxFunc: func(context.Context, *string) (*models.FeedbackStats, error) {
+			return &models.FeedbackStats{Total: 4, ThumbsUp: 3, ThumbsDown: 1}, nil
+		},
+	}
+}
+
+// query posts q and returns the decoded data, failing on any GraphQL error.
+func query(t *testing.T, h http.Handler, q string, vars map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	body, _ := json.Marshal(map[string]interface{}{"query": q, "variables": vars})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/graphql", strings.NewReader(string(body))))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	var resp struct {
+		Data   map[string]interface{}   `json:"data"`
+		Errors []map[string]interface{} `json:"errors"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Errors) > 0 {
+		t.Fatalf("errors: %v", resp.Errors)
+	}
+	return resp.Data
+}
+
+func jsonOf(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func TestVenueQueries(t *testing.T) {
+	h := Handler(testStore(t), nil)
+
+	data := query(t, h, `{
+		venues(status: "approved", first: 500) {
+			total
+			items {
+				id name path status submitter
+				latestValidation { id score status promptVersion breakdown { name points } }
+				validations { id }
+				feedback { type comment venueName }
+				auditLogs { status adminId }
+			}
+		}
+	}`, nil)
+	got := jsonOf(data)
+	want := `{"venues":{"items":[{"auditLogs":[{"adminId":7,"status":"approved"}],` +
+		`"feedback":[{"comment":"wrong cuisine","type":"thumbs_down","venueName":"Green Leaf"}],` +
+		`"id":"1","latestValidation":{"breakdown":[{"name":"hours","points":10},{"name":"vegan","points":30}],` +
+		`"id":"11","promptVersion":"v3","score":91,"status":"approved"},"name":"Green Leaf","path":"europe|germany",` +
+		`"status":"approved","submitter":"alice","validations":[{"id":"11"},{"id":"10"}]}],"total":1}}`
+	if got != want {
+		t.Errorf("venues =\n%s\nwant\n%s", got, want)
+	}
+
+	data = query(t, h, `query($id: ID!) { venue(id: $id) { name status submitter latestValidation { id } } missing: venue(id: "99") { name } }`,
+		map[string]interface{}{"id": "2"})
+	if got, want := jsonOf(data), `{"missing":null,"venue":{"latestValidation":null,"name":"Tofu Hut","status":"pending","submitter":null}}`; got != want {
+		t.Errorf("venue = %s, want %s", got, want)
+	}
+}
+
+func TestListQueries(t *testing.T) {
+	h := Handler(testStore(t), fakeStats{WorkerCount: 8, CompletedJobs: 120, TotalCostUSD: 1.5, StartTime: t0,
+		Latency: processor.LatencySummary{P95Ms: 2300}})
+
+	data := query(t, h, `{
+		validations(first: 1) { total items { venueName score } }
+		feedback { total items { type venueName } }
+		auditLogs(adminId: 7, status: "rejected", from: "2024-05-01T09:00:00Z", first: 5, offset: 10) {
+			total items { venueName adminUsername status }
+		}
+		stats {
+			venues { pending approved rejected total }
+			feedback { thumbsUp thumbsDown }
+			engine { workerCount completedJobs openaiCostUsd latencyP95Ms lastActivity }
+		}
+	}`, nil)
+	got := jsonOf(data)
+	for _, want := range []string{
+		`"validations":{"items":[{"score":91,"venueName":"Green Leaf"}],"total":40}`,
+		`"feedback":{"items":[{"type":"thumbs_up","venueName":"Green Leaf"}],"total":1}`,
+		`"auditLogs":{"items":[{"adminUsername":"bob","status":"rejected","venueName":"Tofu Hut"}],"total":12}`,
+		`"venues":{"approved":5,"pending":3,"rejected":1,"total":9}`,
+		`"engine":{"completedJobs":120,"lastActivity":null,"latencyP95Ms":2300,"openaiCostUsd":1.5,"workerCount":8}`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("response missing %s\n%s", want, got)
+		}
+	}
+
+	// Without an engine, stats.engine is null
+	data = query(t, Handler(testStore(t), nil), `{ stats { engine { workerCount } } }`, nil)
+	if got := jsonOf(data); got != `{"stats":{"engine":null}}` {
+		t.Errorf("stats = %s", got)
+	}
+}
+
+func TestHandlerRequests(t *testing.T) {
+	h := Handler(testStore(t), nil)
+
+	// GET with variables
+	q := url.Values{"query": {`query($id: ID!) { venue(id: $id) { name } }`}, "variables": {`{"id":"1"}`}}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/graphql?"+q.Encode(), nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"name":"Green Leaf"`) {
+		t.Fatalf("GET = %d %s", rec.Code, rec.Body)
+	}
+
+	for name, req := range map[string]*http.Request{
+		"no query":      httptest.NewRequest(http.MethodPost, "/api/v1/graphql", strings.NewReader(`{}`)),
+		"bad body":      httptest.NewRequest(http.MethodPost, "/api/v1/graphql", strings.NewReader(`{`)),
+		"bad variables": httptest.NewRequest(http.MethodGet, "/api/v1/graphql?query=%7Bstats%7Bvenues%7Btotal%7D%7D%7D&variables=x", nil),
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", name, rec.Code)
+		}
+	}
+
+	// The schema has no mutations
+	body, _ := json.Marshal(map[string]string{"query": `mutation { venue(id: "1") { name } }`})
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/graphql", strings.NewReader(string(body))))
+	if !strings.Contains(rec.Body.String(), `"errors"`) {
+		t.Errorf("mutation succeeded: %s", rec.Body)
+	}
+}
@@ -0,0 +1,448 @@
+package graphqlapi
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/internal/processor"
+	errs "assisted-venue-approval/pkg/errors"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+const maxPageSize = 100
+
+// resolver is the Query root.
+type resolver struct {
+	store  Store
+	engine StatsSource
+}
+
+// pageArgs are the first and offset arguments of lists; the schema supplies their defaults.
+type pageArgs struct {
+	First  int32
+	Offset int32
+}
+
+// page returns the limit and offset of args, capping first at maxPageSize.
+func (a pageArgs) page() (int, int) {
+	return clampPage(a.First), max(int(a.Offset), 0)
+}
+
+func clampPage(first int32) int {
+	return min(max(int(first), 0), maxPageSize)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func id(n int64) graphql.ID { return graphql.ID(strconv.FormatInt(n, 10)) }
+
+func optTime(t *time.Time) *graphql.Time {
+	if t == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *t}
+}
+
+// Venues
+
+type venuePage struct {
+	total int
+	items []*venueResolver
+}
+
+func (p venuePage) Total() int32            { return int32(p.total) }
+func (p venuePage) Items() []*venueResolver { return p.items }
+
+func (r *resolver) Venues(ctx context.Context, args struct {
+	Status     *string
+	Search     *string
+	PathPrefix *string
+	pageArgs
+}) (venuePage, error) {
+	limit, offset := args.page()
+	list, total, err := r.store.GetVenuesFilteredCtx(ctx, deref(args.Status), deref(args.Search), deref(args.PathPrefix), limit, offset)
+	if err != nil {
+		return venuePage{}, err
+	}
+	p := venuePage{total: total, items: make([]*venueResolver, len(list))}
+	for i := range list {
+		p.items[i] = &venueResolver{vu: list[i], store: r.store}
+	}
+	return p, nil
+}
+
+func (r *resolver) Venue(ctx context.Context, args struct{ ID graphql.ID }) (*venueResolver, error) {
+	venueID, err := strconv.ParseInt(string(args.ID), 10, 64)
+	if err != nil {
+		return nil, errs.NewValidation("graphql.Venue", "invalid venue id", err)
+	}
+	vu, err := r.store.GetVenueWithUserByIDCtx(ctx, venueID)
+	if errs.CodeOf(err) == errs.CodeNotFound {
+		return nil, nil
+	}
+	if err != nil || vu == nil {
+		return nil, err
+	}
+	return &venueResolver{vu: *vu, store: r.store}, nil
+}
+
+type venueResolver struct {
+	vu    models.VenueWithUser
+	store Store
+
+	// Loaded once for latestValidation and validations
+	history []models.ValidationHistory
+	loaded  bool
+}
+
+func (v *venueResolver) ID() graphql.ID           { return id(v.vu.Venue.ID) }
+func (v *venueResolver) Name() string             { return v.vu.Venue.Name }
+func (v *venueResolver) Location() string         { return v.vu.Venue.Location }
+func (v *venueResolver) Path() *string            { return v.vu.Venue.Path }
+func (v *venueResolver) Category() int32          { return int32(v.vu.Venue.Category) }
+func (v *venueResolver) CreatedAt() *graphql.Time { return optTime(v.vu.Venue.CreatedAt) }
+
+func (v *venueResolver) Status() string {
+	switch a := v.vu.Venue.Active; {
+	case a == nil || *a == 0:
+		return "pending"
+	case *a > 0:
+		return "approved"
+	default:
+		return "rejected"
+	}
+}
+
+func (v *venueResolver) Submitter() *string {
+	if v.vu.User.Username == "" {
+		return nil
+	}
+	return &v.vu.User.Username
+}
+
+// loadHistory returns the venue's validations, newest first.
+func (v *venueResolver) loadHistory(ctx context.Context) ([]models.ValidationHistory, error) {
+	if v.loaded {
+		return v.history, nil
+	}
+	h, err := v.store.GetVenueValidationHistoryCtx(ctx, v.vu.Venue.ID)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(h, func(i, j int) bool { return h[i].ProcessedAt.After(h[j].ProcessedAt) })
+	v.history, v.loaded = h, true
+	return h, nil
+}
+
+func (v *venueResolver) LatestValidation(ctx context.Context) (*validationResolver, error) {
+	h, err := v.loadHistory(ctx)
+	if err != nil || len(h) == 0 {
+		return nil, err
+	}
+	return &validationResolver{h: h[0]}, nil
+}
+
+func (v *venueResolver) Validations(ctx context.Context) ([]*validationResolver, error) {
+	h, err := v.loadHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*validationResolver, len(h))
+	for i := range h {
+		out[i] = &validationResolver{h: h[i]}
+	}
+	return out, nil
+}
+
+func (v *venueResolver) Feedback(ctx context.Context, args struct{ First int32 }) ([]*feedbackResolver, error) {
+	list, _, _, err := v.store.GetFeedbackByVenueCtx(ctx, v.vu.Venue.ID, clampPage(args.First))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*feedbackResolver, len(list))
+	for i := range list {
+		out[i] = &feedbackResolver{f: models.EditorFeedbackWithVenue{EditorFeedback: list[i], VenueName: v.vu.Venue.Name}}
+	}
+	return out, nil
+}
+
+func (v *venueResolver) AuditLogs(ctx context.Context) ([]*auditLogResolver, error) {
+	logs, err := v.store.GetAuditLogsByVenueIDCtx(ctx, v.vu.Venue.ID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*auditLogResolver, len(logs))
+	for i := range logs {
+		out[i] = &auditLogResolver{e: domain.AuditLogEntry{VenueValidationAuditLog: logs[i], VenueName: v.vu.Venue.Name}}
+	}
+	return out, nil
+}
+
+// Validations
+
+type validationPage struct {
+	total int
+	items []*validationResolver
+}
+
+func (p validationPage) Total() int32                 { return int32(p.total) }
+func (p validationPage) Items() []*validationResolver { return p.items }
+
+func (r *resolver) Validations(ctx context.Context, args pageArgs) (validationPage, error) {
+	limit, offset := args.page()
+	list, total, err := r.store.GetValidationHistoryPaginatedCtx(ctx, limit, offset)
+	if err != nil {
+		return validationPage{}, err
+	}
+	p := validationPage{total: total, items: make([]*validationResolver, len(list))}
+	for i := range list {
+		p.items[i] = &validationResolver{h: list[i]}
+	}
+	return p, nil
+}
+
+type validationResolver struct {
+	h models.ValidationHistory
+}
+
+func (v *validationResolver) ID() graphql.ID            { return id(v.h.ID) }
+func (v *validationResolver) VenueID() graphql.ID       { return id(v.h.VenueID) }
+func (v *validationResolver) Score() int32              { return int32(v.h.ValidationScore) }
+func (v *validationResolver) Status() string            { return v.h.ValidationStatus }
+func (v *validationResolver) Notes() string             { return v.h.ValidationNotes }
+func (v *validationResolver) PromptVersion() *string    { return v.h.PromptVersion }
+func (v *validationResolver) GooglePlaceFound() bool    { return v.h.GooglePlaceFound }
+func (v *validationResolver) ProcessedAt() graphql.Time { return graphql.Time{Time: v.h.ProcessedAt} }
+
+func (v *validationResolver) VenueName() *string {
+	if v.h.VenueName == "" {
+		return nil
+	}
+	return &v.h.VenueName
+}
+
+func (v *validationResolver) RunID() *graphql.ID {
+	if v.h.RunID == nil {
+		return nil
+	}
+	rid := id(*v.h.RunID)
+	return &rid
+}
+
+type scoreComponent struct {
+	name   string
+	points int
+}
+
+func (c scoreComponent) Name() string  { return c.name }
+func (c scoreComponent) Points() int32 { return int32(c.points) }
+
+// Breakdown lists the score components by name, so responses are stable.
+func (v *validationResolver) Breakdown() []scoreComponent {
+	out := make([]scoreComponent, 0, len(v.h.ScoreBreakdown))
+	for name, points := range v.h.ScoreBreakdown {
+		out = append(out, scoreComponent{name: name, points: points})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].name < out[j].name })
+	return out
+}
+
+// Feedback
+
+type feedbackPage struct {
+	total int
+	items []*feedbackResolver
+}
+
+func (p feedbackPage) Total() int32               { return int32(p.total) }
+func (p feedbackPage) Items() []*feedbackResolver { return p.items }
+
+func (r *resolver) Feedback(ctx context.Context, args pageArgs) (feedbackPage, error) {
+	limit, offset := args.page()
+	list, total, err := r.store.GetAllEditorFeedbackPaginatedCtx(ctx, limit, offset)
+	if err != nil {
+		return feedbackPage{}, err
+	}
+	p := feedbackPage{total: total, items: make([]*feedbackResolver, len(list))}
+	for i := range list {
+		p.items[i] = &feedbackResolver{f: list[i]}
+	}
+	return p, nil
+}
+
+type feedbackResolver struct {
+	f models.EditorFeedbackWithVenue
+}
+
+func (f *feedbackResolver) ID() graphql.ID          { return id(f.f.ID) }
+func (f *feedbackResolver) VenueID() graphql.ID     { return id(f.f.VenueID) }
+func (f *feedbackResolver) Type() string            { return string(f.f.FeedbackType) }
+func (f *feedbackResolver) PromptVersion() *string  { return f.f.PromptVersion }
+func (f *feedbackResolver) Comment() *string        { return f.f.Comment }
+func (f *feedbackResolver) CreatedAt() graphql.Time { return graphql.Time{Time: f.f.CreatedAt} }
+
+func (f *feedbackResolver) VenueName() *string {
+	if f.f.VenueName == "" {
+		return nil
+	}
+	return &f.f.VenueName
+}
+
+// Audit logs
+
+type auditLogPage struct {
+	total int
+	items []*auditLogResolver
+}
+
+func (p auditLogPage) Total() int32               { return int32(p.total) }
+func (p auditLogPage) Items() []*auditLogResolver { return p.items }
+
+func (r *resolver) AuditLogs(ctx context.Context, args struct {
+	AdminID *int32
+	Status  *string
+	From    *graphql.Time
+	To      *graphql.Time
+	pageArgs
+}) (auditLogPage, error) {
+	limit, offset := args.page()
+	f := domain.AuditLogFilter{Status: deref(args.Status), Limit: limit, Offset: offset}
+	if args.AdminID != nil {
+		f.AdminID = int(*args.AdminID)
+	}
+	if args.From != nil {
+		f.From = args.From.Time
+	}
+	if args.To != nil {
+		f.To = args.To.Time
+	}
+	list, total, err := r.store.ListAuditLogsCtx(ctx, f)
+	if err != nil {
+		return auditLogPage{}, err
+	}
+	p := auditLogPage{total: total, items: make([]*auditLogResolver, len(list))}
+	for i := range list {
+		p.items[i] = &auditLogResolver{e: list[i]}
+	}
+	return p, nil
+}
+
+type auditLogResolver struct {
+	e domain.AuditLogEntry
+}
+
+func (a *auditLogResolver) ID() graphql.ID          { return id(a.e.ID) }
+func (a *auditLogResolver) VenueID() graphql.ID     { return id(a.e.VenueID) }
+func (a *auditLogResolver) Status() string          { return a.e.Status }
+func (a *auditLogResolver) Reason() *string         { return a.e.Reason }
+func (a *auditLogResolver) CreatedAt() graphql.Time { return graphql.Time{Time: a.e.CreatedAt} }
+
+func (a *auditLogResolver) VenueName() *string {
+	if a.e.VenueName == "" {
+		return nil
+	}
+	return &a.e.VenueName
+}
+
+func (a *auditLogResolver) HistoryID() *graphql.ID {
+	if a.e.HistoryID == nil {
+		return nil
+	}
+	hid := id(*a.e.HistoryID)
+	return &hid
+}
+
+func (a *auditLogResolver) AdminID() *int32 {
+	if a.e.AdminID == nil {
+		return nil
+	}
+	n := int32(*a.e.AdminID)
+	return &n
+}
+
+func (a *auditLogResolver) AdminUsername() *string {
+	if a.e.AdminUsername == "" {
+		return nil
+	}
+	return &a.e.AdminUsername
+}
+
+// Stats
+
+type statsResolver struct {
+	store  Store
+	engine StatsSource
+}
+
+func (r *resolver) Stats() *statsResolver { return &statsResolver{store: r.store, engine: r.engine} }
+
+func (s *statsResolver) Venues(ctx context.Context) (*venueStatsResolver, error) {
+	st, err := s.store.GetVenueStatisticsCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &venueStatsResolver{st: *st}, nil
+}
+
+func (s *statsResolver) Feedback(ctx context.Context) (*feedbackStatsResolver, error) {
+	st, err := s.store.GetFeedbackStatsCtx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &feedbackStatsResolver{st: *st}, nil
+}
+
+func (s *statsResolver) Engine() *engineStatsResolver {
+	if s.engine == nil {
+		return nil
+	}
+	return &engineStatsResolver{st: s.engine.GetStats()}
+}
+
+type venueStatsResolver struct{ st models.VenueStats }
+
+func (v *venueStatsResolver) Pending() int32  { return int32(v.st.Pending) }
+func (v *venueStatsResolver) Approved() int32 { return int32(v.st.Approved) }
+func (v *venueStatsResolver) Rejected() int32 { return int32(v.st.Rejected) }
+func (v *venueStatsResolver) Total() int32    { return int32(v.st.Total) }
+
+type feedbackStatsResolver struct{ st models.FeedbackStats }
+
+func (f *feedbackStatsResolver) Total() int32      { return int32(f.st.Total) }
+func (f *feedbackStatsResolver) ThumbsUp() int32   { return int32(f.st.ThumbsUp) }
+func (f *feedbackStatsResolver) ThumbsDown() int32 { return int32(f.st.ThumbsDown) }
+
+type engineStatsResolver struct{ st processor.ProcessingStats }
+
+func (e *engineStatsResolver) WorkerCount() int32      { return int32(e.st.WorkerCount) }
+func (e *engineStatsResolver) QueueSize() int32        { return int32(e.st.QueueSize) }
+func (e *engineStatsResolver) TotalJobs() int32        { return int32(e.st.TotalJobs) }
+func (e *engineStatsResolver) CompletedJobs() int32    { return int32(e.st.CompletedJobs) }
+func (e *engineStatsResolver) SuccessfulJobs() int32   { return int32(e.st.SuccessfulJobs) }
+func (e *engineStatsResolver) FailedJobs() int32       { return int32(e.st.FailedJobs) }
+func (e *engineStatsResolver) AutoApproved() int32     { return int32(e.st.AutoApproved) }
+func (e *engineStatsResolver) ManualReview() int32     { return int32(e.st.ManualReview) }
+func (e *engineStatsResolver) AutoRejected() int32     { return int32(e.st.AutoRejected) }
+func (e *engineStatsResolver) OpenaiCostUsd() float64  { return e.st.TotalCostUSD }
+func (e *engineStatsResolver) GoogleCostUsd() float64  { return e.st.GoogleCostUSD }
+func (e *engineStatsResolver) LatencyP50Ms() float64   { return e.st.Latency.P50Ms }
+func (e *engineStatsResolver) LatencyP95Ms() float64   { return e.st.Latency.P95Ms }
+func (e *engineStatsResolver) LatencyP99Ms() float64   { return e.st.Latency.P99Ms }
+func (e *engineStatsResolver) StartTime() graphql.Time { return graphql.Time{Time: e.st.StartTime} }
+
+func (e *engineStatsResolver) LastActivity() *graphql.Time {
+	if e.st.LastActivity.IsZero() {
+		return nil
+	}
+	return &graphql.Time{Time: e.st.LastActivity}
+}
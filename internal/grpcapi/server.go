@@ -0,0 +1,297 @@
+// Package grpcapi serves the validation operations of proto/ava/v1 over gRPC, so the platform
+// backend can submit venues, read results, follow runs and record decisions without going
+// through the admin UI or the JSON API. Calls authenticate with API tokens like the JSON API.
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"assisted-venue-approval/internal/admin"
+	"assisted-venue-approval/internal/approval"
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/drafts"
+	"assisted-venue-approval/internal/grpcapi/avapb"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/internal/processor"
+	"assisted-venue-approval/pkg/config"
+	errs "assisted-venue-approval/pkg/errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxSubmitVenues caps one SubmitValidation call, as maxFilterRunLimit does for the JSON API.
+const maxSubmitVenues = 5000
+
+// Engine is the part of the processing engine the service drives.
+type Engine interface {
+	Start()
+	StartRun(ctx context.Context, venues []models.VenueWithUser, mode processor.Mode, run models.ProcessingRun) (models.ProcessingRun, error)
+	ActiveRun(id int64) (models.ProcessingRun, bool)
+}
+
+// Server implements avapb.ValidationServiceServer.
+type Server struct {
+	avapb.UnimplementedValidationServiceServer
+
+	repo   domain.Repository
+	engine Engine
+	cfg    *config.Config
+	drafts *drafts.DraftStore
+
+	// How often StreamProgress checks a run for new counts
+	pollInterval time.Duration
+}
+
+// NewServer returns the service; draftStore may be nil, as for the admin handlers.
+func NewServer(repo domain.Repository, engine Engine, cfg *config.Config, draftStore *drafts.DraftStore) *Server {
+	return &Server{repo: repo, engine: engine, cfg: cfg, drafts: draftStore, pollInterval: 2 * time.Second}
+}
+
+// SubmitValidation queues the venues as one run. Like POST /validate/batch, venues with
+// validation history are skipped unless forced and unknown IDs are ignored.
+func (s *Server) SubmitValidation(ctx context.Context, req *avapb.SubmitValidationRequest) (*avapb.SubmitValidationResponse, error) {
+	if len(req.VenueIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no venue_ids provided")
+	}
+	if len(req.VenueIds) > maxSubmitVenues {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d venue_ids per call", maxSubmitVenues)
+	}
+	mode, err := processor.ParseMode(req.Mode)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if mode == processor.ModeBatch {
+		return nil, status.Error(codes.InvalidArgument, "batch mode is not available over gRPC")
+	}
+
+	var queue []models.VenueWithUser
+	for _, id := range req.VenueIds {
+		vu, err := s.repo.GetVenueWithUserByIDCtx(ctx, id)
+		if err != nil || vu == nil {
+			continue
+		}
+		if !req.Force {
+			hasHist, err := s.repo.HasAnyValidationHistory(id)
+			if err != nil {
+				log.Printf("error checking validation history for %d: %v", id, err)
+			}
+			if hasHist {
+				continue
+			}
+		}
+		queue = append(queue, *vu)
+	}
+	if len(queue) == 0 {
+		return &avapb.SubmitValidationResponse{Mode: string(mode), Reason: "nothing to queue (already has history or invalid IDs)"}, nil
+	}
+
+	s.engine.Start()
+	run, err := s.engine.StartRun(ctx, queue, mode, newRun(ctx, req))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to queue venues: %v", err)
+	}
+	return &avapb.SubmitValidationResponse{Queued: int32(len(queue)), Mode: string(mode), RunId: run.ID}, nil
+}
+
+// newRun describes a run submitted over gRPC, recorded like the JSON API's.
+func newRun(ctx context.Context, req *avapb.SubmitValidationRequest) models.ProcessingRun {
+	run := models.ProcessingRun{Filters: "{}"}
+	if adminID, ok := auth.GetAdminIDFromContext(ctx); ok {
+		run.TriggeredBy = &adminID
+	}
+	filters := map[string]interface{}{"source": "grpc", "venue_ids": req.VenueIds, "force": req.Force}
+	if b, err := json.Marshal(filters); err == nil {
+		run.Filters = string(b)
+	}
+	return run
+}
+
+// GetResult returns the venue's status with its most recent validation.
+func (s *Server) GetResult(ctx context.Context, req *avapb.GetResultRequest) (*avapb.ValidationResult, error) {
+	vu, err := s.repo.GetVenueWithUserByIDCtx(ctx, req.VenueId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if vu == nil {
+		return nil, status.Errorf(codes.NotFound, "venue %d not found", req.VenueId)
+	}
+	res := &avapb.ValidationResult{VenueId: req.VenueId, VenueStatus: venueStatus(vu.Venue.Active)}
+
+	history, err := s.repo.GetVenueValidationHistoryCtx(ctx, req.VenueId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if len(history) == 0 {
+		return res, nil
+	}
+	latest := history[0]
+	for _, h := range history {
+		if h.ProcessedAt.After(latest.ProcessedAt) {
+			latest = h
+		}
+	}
+	res.Validated = true
+	res.Score = int32(latest.ValidationScore)
+	res.ValidationStatus = latest.ValidationStatus
+	res.Notes = latest.ValidationNotes
+	res.ProcessedAt = timestamppb.New(latest.ProcessedAt)
+	if len(latest.ScoreBreakdown) > 0 {
+		res.ScoreBreakdown = make(map[string]int32, len(latest.ScoreBreakdown))
+		for k, v := range latest.ScoreBreakdown {
+			res.ScoreBreakdown[k] = int32(v)
+		}
+	}
+	if latest.PromptVersion != nil {
+		res.PromptVersion = *latest.PromptVersion
+	}
+	if latest.RunID != nil {
+		res.RunId = *latest.RunID
+	}
+	return res, nil
+}
+
+func venueStatus(active *int) string {
+	switch {
+	case active == nil || *active == 0:
+		return "pending"
+	case *active > 0:
+		return "approved"
+	default:
+		return "rejected"
+	}
+}
+
+// StreamProgress sends the run's counts now and whenever they change, and returns after
+// sending the completed run.
+func (s *Server) StreamProgress(req *avapb.StreamProgressRequest, stream avapb.ValidationService_StreamProgressServer) error {
+	ctx := stream.Context()
+	var last *avapb.RunProgress
+	for {
+		run, err := s.repo.GetProcessingRunCtx(ctx, req.RunId)
+		if err != nil {
+			return toStatus(err)
+		}
+		if run == nil {
+			return status.Errorf(codes.NotFound, "run %d not found", req.RunId)
+		}
+		if run.Status == models.RunStatusRunning {
+			// The row is only updated when the run completes
+			if active, ok := s.engine.ActiveRun(run.ID); ok {
+				*run = active
+			}
+		}
+		p := runProgress(*run)
+		if last == nil || !sameProgress(last, p) {
+			if err := stream.Send(p); err != nil {
+				return err
+			}
+			last = p
+		}
+		if run.Status != models.RunStatusRunning {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-time.After(s.pollInterval):
+		}
+	}
+}
+
+func runProgress(run models.ProcessingRun) *avapb.RunProgress {
+	p := &avapb.RunProgress{
+		RunId:        run.ID,
+		Status:       run.Status,
+		Mode:         run.Mode,
+		VenueCount:   int32(run.VenueCount),
+		Approved:     int32(run.Approved),
+		Rejected:     int32(run.Rejected),
+		ManualReview: int32(run.ManualReview),
+		Failed:       int32(run.Failed),
+		StartedAt:    timestamppb.New(run.StartedAt),
+	}
+	if run.FinishedAt != nil {
+		p.FinishedAt = timestamppb.New(*run.FinishedAt)
+	}
+	return p
+}
+
+func sameProgress(a, b *avapb.RunProgress) bool {
+	return a.Status == b.Status && a.VenueCount == b.VenueCount && a.Approved == b.Approved &&
+		a.Rejected == b.Rejected && a.ManualReview == b.ManualReview && a.Failed == b.Failed
+}
+
+// ApproveVenue approves on behalf of the token's admin, as the admin Approve button does.
+func (s *Server) ApproveVenue(ctx context.Context, req *avapb.ApproveVenueRequest) (*avapb.DecisionResponse, error) {
+	adminID, ok := auth.GetAdminIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "no admin for this call")
+	}
+	if err := admin.ApproveVenue(ctx, s.repo, s.cfg, s.drafts, req.VenueId, adminID, req.Notes, req.AcknowledgeStyle); err != nil {
+		return nil, toStatus(err)
+	}
+	return &avapb.DecisionResponse{Status: "approved"}, nil
+}
+
+// RejectVenue rejects on behalf of the token's admin, as the admin Reject button does.
+func (s *Server) RejectVenue(ctx context.Context, req *avapb.RejectVenueRequest) (*avapb.DecisionResponse, error) {
+	adminID, ok := auth.GetAdminIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "no admin for this call")
+	}
+	if err := admin.RejectVenue(ctx, s.repo, s.drafts, req.VenueId, adminID, req.Reason); err != nil {
+		return nil, toStatus(err)
+	}
+	return &avapb.DecisionResponse{Status: "rejected"}, nil
+}
+
+// toStatus maps an error to the gRPC status matching the HTTP status the JSON API would
+// answer with.
+func toStatus(err error) error {
+	httpStatus := errs.CodeOf(err).HTTPStatus()
+	msg := err.Error()
+	var de *admin.DecisionError
+	var te *auth.TokenError
+	switch {
+	case errors.As(err, &de):
+		httpStatus = de.Status
+		if len(de.Violations) > 0 {
+			msg = fmt.Sprintf("%s (rules: %s; set acknowledge_style to approve anyway)", msg, approval.StyleRuleNames(de.Violations))
+		}
+	case errors.As(err, &te):
+		httpStatus = te.Status
+	}
+	return status.Error(codeForHTTP(httpStatus), msg)
+}
+
+func codeForHTTP(s int) codes.Code {
+	switch s {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.FailedPrecondition
+	case http.StatusPaymentRequired:
+		return codes.ResourceExhausted
+	case http.StatusBadGateway, http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
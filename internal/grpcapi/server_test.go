@@ -0,0 +1,322 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/grpcapi/avapb"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/internal/processor"
+	testutil "assisted-venue-approval/internal/testing"
+	"assisted-venue-approval/pkg/config"
+	errs "assisted-venue-approval/pkg/errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	readToken = "ava_read"
+	fullToken = "ava_full"
+)
+
+type fakeTokens map[string]*models.APIToken
+
+func (f fakeTokens) GetAPITokenByHashCtx(_ context.Context, hash string) (*models.APIToken, error) {
+	return f[hash], nil
+}
+func (f fakeTokens) TouchAPITokenCtx(context.Context, int64, time.Time) error { return nil }
+
+type fakeEngine struct {
+	mu      sync.Mutex
+	started []models.ProcessingRun
+	queued  []int64
+	active  []models.ProcessingRun // returned by successive ActiveRun calls, the last one repeated
+}
+
+func (e *fakeEngine) Start() {}
+
+func (e *fakeEngine) StartRun(_ context.Context, venues []models.VenueWithUser, mode processor.Mode, run models.ProcessingRun) (models.ProcessingRun, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, v := range venues {
+		e.queued = append(e.queued, v.Venue.ID)
+	}
+	run.ID = 42
+	run.Mode = string(mode)
+	e.started = append(e.started, run)
+	return run, nil
+}
+
+func (e *fakeEngine) ActiveRun(int64) (models.ProcessingRun, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.active) == 0 {
+		return models.ProcessingRun{}, false
+	}
+	run := e.active[0]
+	if len(e.active) > 1 {
+		e.active = e.active[1:]
+	}
+	return run, true
+}
+
+// dial serves srv over an in-memory listener and returns a client.
+func dial(t *testing.T, srv *Server) avapb.ValidationServiceClient {
+	t.Helper()
+	tokens := auth.NewTokenAuthenticator(fakeTokens{
+		auth.HashAPIToken(readToken): {ID: 1, AdminID: 7, Scopes: []string{auth.ScopeRead}},
+		auth.HashAPIToken(fullToken): {ID: 2, AdminID: 7, Scopes: []string{auth.ScopeRead, auth.ScopeWrite, auth.ScopeValidate}},
+	}, nil)
+	lis := bufconn.Listen(1 << 20)
+	gs := NewGRPCServer(srv, tokens)
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return avapb.NewValidationServiceClient(conn)
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func wantCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+	if got := status.Code(err); got != want {
+		t.Fatalf("code = %v (%v), want %v", got, err, want)
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	active := 0
+	repo := &testutil.Repository{
+		GetVenueWithUserByIDCtxFunc: func(_ context.Context, id int64) (*models.VenueWithUser, error) {
+			return &models.VenueWithUser{Venue: models.Venue{ID: id, Active: &active}}, nil
+		},
+		GetVenueValidationHistoryCtxFunc: func(context.Context, int64) ([]models.ValidationHistory, error) { return nil, nil },
+	}
+	c := dial(t, NewServer(repo, &fakeEngine{}, &config.Config{}, nil))
+
+	_, err := c.GetResult(context.Background(), &avapb.GetResultRequest{VenueId: 1})
+	wantCode(t, err, codes.Unauthenticated)
+	_, err = c.GetResult(withToken("ava_unknown"), &avapb.GetResultRequest{VenueId: 1})
+	wantCode(t, err, codes.Unauthenticated)
+	_, err = c.SubmitValidation(withToken(readToken), &avapb.SubmitValidationRequest{VenueIds: []int64{1}})
+	wantCode(t, err, codes.PermissionDenied)
+	_, err = c.RejectVenue(withToken(readToken), &avapb.RejectVenueRequest{VenueId: 1, Reason: "spam"})
+	wantCode(t, err, codes.PermissionDenied)
+
+	res, err := c.GetResult(withToken(readToken), &avapb.GetResultRequest{VenueId: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.VenueStatus != "pending" || res.Validated {
+		t.Fatalf("result = %+v, want pending and not validated", res)
+	}
+}
+
+func TestGetResult(t *testing.T) {
+	version := "v3"
+	runID := int64(9)
+	older := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	repo := &testutil.Repository{
+		GetVenueWithUserByIDCtxFunc: func(_ context.Context, id int64) (*models.VenueWithUser, error) {
+			if id != 5 {
+				return nil, errs.NewNotFound("test", "venue not found", nil)
+			}
+			return &models.VenueWithUser{Venue: models.Venue{ID: id}}, nil
+		},
+		GetVenueValidationHistoryCtxFunc: func(context.Context, int64) ([]models.ValidationHistory, error) {
+			return []models.ValidationHistory{
+				{ValidationScore: 40, ValidationStatus: "rejected", ProcessedAt: older},
+				{ValidationScore: 91, ValidationStatus: "approved", ValidationNotes: "looks good",
+					ScoreBreakdown: map[string]int{"vegan": 30}, PromptVersion: &version, RunID: &runID, ProcessedAt: older.Add(time.Hour)},
+			}, nil
+		},
+	}
+	c := dial(t, NewServer(repo, &fakeEngine{}, &config.Config{}, nil))
+
+	res, err := c.GetResult(withToken(readToken), &avapb.GetResultRequest{VenueId: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Validated || res.Score != 91 || res.ValidationStatus != "approved" || res.Notes != "looks good" ||
+		res.ScoreBreakdown["vegan"] != 30 || res.PromptVersion != "v3" || res.RunId != 9 ||
+		!res.ProcessedAt.AsTime().Equal(older.Add(time.Hour)) {
+		t.Fatalf("result = %+v, want the latest validation", res)
+	}
+
+	_, err = c.GetResult(withToken(readToken), &avapb.GetResultRequest{VenueId: 6})
+	wantCode(t, err, codes.NotFound)
+}
+
+func TestSubmitValidation(t *testing.T) {
+	repo := &testutil.Repository{
+		GetVenueWithUserByIDCtxFunc: func(_ context.Context, id int64) (*models.VenueWithUser, error) {
+			if id == 3 {
+				return nil, errs.NewNotFound("test", "venue not found", nil)
+			}
+			return &models.VenueWithUser{Venue: models.Venue{ID: id}}, nil
+		},
+		HasAnyValidationHistoryFunc: func(id int64) (bool, error) { return id == 2, nil },
+	}
+	eng := &fakeEngine{}
+	c := dial(t, NewServer(repo, eng, &config.Config{}, nil))
+	ctx := withToken(fullToken)
+
+	resp, err := c.SubmitValidation(ctx, &avapb.SubmitValidationRequest{VenueIds: []int64{1, 2, 3}, Mode: "auto_decide"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Queued != 1 || resp.RunId != 42 || resp.Mode != "auto_decide" {
+		t.Fatalf("response = %+v, want 1 venue queued as run 42", resp)
+	}
+	if len(eng.started) != 1 || eng.started[0].TriggeredBy == nil || *eng.started[0].TriggeredBy != 7 {
+		t.Fatalf("runs = %+v, want one triggered by the token's admin", eng.started)
+	}
+
+	// Forced, the venue with history is queued too
+	resp, err = c.SubmitValidation(ctx, &avapb.SubmitValidationRequest{VenueIds: []int64{2}, Force: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Queued != 1 || resp.Mode != string(processor.DefaultMode) {
+		t.Fatalf("forced response = %+v", resp)
+	}
+
+	resp, err = c.SubmitValidation(ctx, &avapb.SubmitValidationRequest{VenueIds: []int64{2, 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Queued != 0 || resp.Reason == "" {
+		t.Fatalf("response = %+v, want nothing queued with a reason", resp)
+	}
+
+	for _, req := range []*avapb.SubmitValidationRequest{
+		{},
+		{VenueIds: []int64{1}, Mode: "bogus"},
+		{VenueIds: []int64{1}, Mode: "batch"},
+	} {
+		_, err := c.SubmitValidation(ctx, req)
+		wantCode(t, err, codes.InvalidArgument)
+	}
+}
+
+func TestStreamProgress(t *testing.T) {
+	started := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	finished := started.Add(time.Minute)
+	running := models.ProcessingRun{ID: 42, Mode: "score_only", VenueCount: 3, Status: models.RunStatusRunning, StartedAt: started}
+
+	var mu sync.Mutex
+	polls := 0
+	repo := &testutil.Repository{
+		GetProcessingRunCtxFunc: func(_ context.Context, id int64) (*models.ProcessingRun, error) {
+			if id != 42 {
+				return nil, nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			polls++
+			run := running
+			if polls > 4 {
+				run.Status = models.RunStatusCompleted
+				run.Approved, run.ManualReview = 2, 1
+				run.FinishedAt = &finished
+			}
+			return &run, nil
+		},
+	}
+	live := func(approved int) models.ProcessingRun {
+		r := running
+		r.Approved = approved
+		return r
+	}
+	// The second poll has no new counts, so it sends nothing
+	eng := &fakeEngine{active: []models.ProcessingRun{live(0), live(0), live(1), live(2)}}
+	srv := NewServer(repo, eng, &config.Config{}, nil)
+	srv.pollInterval = time.Millisecond
+	c := dial(t, srv)
+
+	stream, err := c.StreamProgress(withToken(readToken), &avapb.StreamProgressRequest{RunId: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []*avapb.RunProgress
+	for {
+		p, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, p)
+	}
+	if len(got) != 4 {
+		t.Fatalf("got %d updates, want 4: %v", len(got), got)
+	}
+	for i, want := range []int32{0, 1, 2} {
+		if got[i].Status != models.RunStatusRunning || got[i].Approved != want {
+			t.Errorf("update %d = %+v, want running with %d approved", i, got[i], want)
+		}
+	}
+	last := got[3]
+	if last.Status != models.RunStatusCompleted || last.Approved != 2 || last.ManualReview != 1 || last.FinishedAt == nil {
+		t.Errorf("last update = %+v, want the completed run", last)
+	}
+
+	stream, err = c.StreamProgress(withToken(readToken), &avapb.StreamProgressRequest{RunId: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.Recv()
+	wantCode(t, err, codes.NotFound)
+}
+
+func TestDecisions(t *testing.T) {
+	var rejected []string
+	repo := &testutil.Repository{
+		GetVenueValidationHistoryCtxFunc: func(context.Context, int64) ([]models.ValidationHistory, error) { return nil, nil },
+		UpdateVenueStatusCtxFunc: func(_ context.Context, id int64, active int, notes string, reviewer *string) error {
+			if active != -1 || reviewer == nil || *reviewer != "admin_7" {
+				t.Errorf("UpdateVenueStatus(%d, %d, %q, %v), want a rejection by admin_7", id, active, notes, reviewer)
+			}
+			rejected = append(rejected, notes)
+			return nil
+		},
+		ValidateApprovalEligibilityFunc: func(int64, int) error { return errors.New("no approved validation") },
+	}
+	c := dial(t, NewServer(repo, &fakeEngine{}, &config.Config{ApprovalThreshold: 75}, nil))
+	ctx := withToken(fullToken)
+
+	_, err := c.RejectVenue(ctx, &avapb.RejectVenueRequest{VenueId: 5, Reason: "  "})
+	wantCode(t, err, codes.InvalidArgument)
+
+	resp, err := c.RejectVenue(ctx, &avapb.RejectVenueRequest{VenueId: 5, Reason: "closed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != "rejected" || len(rejected) != 1 || rejected[0] != "Manually rejected by admin_7: closed" {
+		t.Fatalf("response %+v, rejections %q", resp, rejected)
+	}
+
+	_, err = c.ApproveVenue(ctx, &avapb.ApproveVenueRequest{VenueId: 5})
+	wantCode(t, err, codes.InvalidArgument)
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// AddBatchItemCtx stores a venue's scoring call for the next batch submission.
+func (r *SQLRepository) AddBatchItemCtx(ctx context.Context, item *models.BatchScoringItem) error {
+	return r.db.AddBatchItemCtx(ctx, item)
+}
+
+// ListPendingBatchItemsCtx returns items not yet submitted, oldest first.
+func (r *SQLRepository) ListPendingBatchItemsCtx(ctx context.Context, limit int) ([]models.BatchScoringItem, error) {
+	return r.db.ListPendingBatchItemsCtx(ctx, limit)
+}
+
+// CreateScoringBatchCtx records a submitted batch and marks its items submitted.
+func (r *SQLRepository) CreateScoringBatchCtx(ctx context.Context, b *models.ScoringBatch, itemIDs []int64) error {
+	return r.db.CreateScoringBatchCtx(ctx, b, itemIDs)
+}
+
+// UpdateScoringBatchCtx stores a batch's status and output files.
+func (r *SQLRepository) UpdateScoringBatchCtx(ctx context.Context, b *models.ScoringBatch) error {
+	return r.db.UpdateScoringBatchCtx(ctx, b)
+}
+
+// ListScoringBatchesCtx returns batches newest first, optionally only those not yet ingested.
+func (r *SQLRepository) ListScoringBatchesCtx(ctx context.Context, open bool, limit int) ([]models.ScoringBatch, error) {
+	return r.db.ListScoringBatchesCtx(ctx, open, limit)
+}
+
+// GetBatchItemsCtx returns the items submitted in a batch.
+func (r *SQLRepository) GetBatchItemsCtx(ctx context.Context, batchID int64) ([]models.BatchScoringItem, error) {
+	return r.db.GetBatchItemsCtx(ctx, batchID)
+}
+
+// FinishBatchItemCtx records how an item's reply was handled.
+func (r *SQLRepository) FinishBatchItemCtx(ctx context.Context, id int64, status, errMsg string) error {
+	return r.db.FinishBatchItemCtx(ctx, id, status, errMsg)
+}
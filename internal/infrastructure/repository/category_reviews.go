@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// RecordCategoryReviewCtx stores how an admin treated a category suggestion.
+func (r *SQLRepository) RecordCategoryReviewCtx(ctx context.Context, cr *models.CategoryReview) error {
+	return r.db.RecordCategoryReviewCtx(ctx, cr)
+}
+
+// CategoryReviewStatsCtx counts category reviews in [from, to).
+func (r *SQLRepository) CategoryReviewStatsCtx(ctx context.Context, from, to time.Time) ([]models.CategoryReviewStat, error) {
+	return r.db.CategoryReviewStatsCtx(ctx, from, to)
+}
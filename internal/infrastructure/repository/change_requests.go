@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+)
+
+// CreateChangeRequestCtx stores a pending change request.
+func (r *SQLRepository) CreateChangeRequestCtx(ctx context.Context, cr *domain.VenueChangeRequest) error {
+	return r.db.CreateChangeRequestCtx(ctx, cr)
+}
+
+// GetChangeRequestCtx returns a change request with its editor.
+func (r *SQLRepository) GetChangeRequestCtx(ctx context.Context, id int64) (*domain.VenueChangeRequest, error) {
+	return r.db.GetChangeRequestCtx(ctx, id)
+}
+
+// ListPendingChangeRequestsCtx returns the undecided change requests, oldest first.
+func (r *SQLRepository) ListPendingChangeRequestsCtx(ctx context.Context, limit int) ([]domain.VenueChangeRequest, error) {
+	return r.db.ListPendingChangeRequestsCtx(ctx, limit)
+}
+
+// SaveChangeAssessmentCtx stores the validation of a pending change request.
+func (r *SQLRepository) SaveChangeAssessmentCtx(ctx context.Context, id int64, a *models.ChangeAssessment, recommendation, reason string) error {
+	return r.db.SaveChangeAssessmentCtx(ctx, id, a, recommendation, reason)
+}
+
+// DecideChangeRequestCtx closes a pending change request.
+func (r *SQLRepository) DecideChangeRequestCtx(ctx context.Context, id int64, adminID int, status, reason string) (bool, error) {
+	return r.db.DecideChangeRequestCtx(ctx, id, adminID, status, reason)
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// SaveCheckpointsCtx stores the progress of interrupted jobs.
+func (r *SQLRepository) SaveCheckpointsCtx(ctx context.Context, cps []models.JobCheckpoint) error {
+	return r.db.SaveCheckpointsCtx(ctx, cps)
+}
+
+// GetCheckpointCtx returns a venue's checkpoint younger than maxAge, or nil.
+func (r *SQLRepository) GetCheckpointCtx(ctx context.Context, venueID int64, maxAge time.Duration) (*models.JobCheckpoint, error) {
+	return r.db.GetCheckpointCtx(ctx, venueID, maxAge)
+}
+
+// DeleteCheckpointCtx removes a venue's checkpoint.
+func (r *SQLRepository) DeleteCheckpointCtx(ctx context.Context, venueID int64) error {
+	return r.db.DeleteCheckpointCtx(ctx, venueID)
+}
+
+// PruneCheckpointsCtx deletes expired checkpoints.
+func (r *SQLRepository) PruneCheckpointsCtx(ctx context.Context, maxAge time.Duration) (int, error) {
+	return r.db.PruneCheckpointsCtx(ctx, maxAge)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// ClaimVenueCtx claims the venue for adminID unless another admin holds an active claim.
+func (r *SQLRepository) ClaimVenueCtx(ctx context.Context, venueID int64, adminID int) (*models.VenueClaim, bool, error) {
+	return r.db.ClaimVenueCtx(ctx, venueID, adminID)
+}
+
+// TouchClaimCtx keeps adminID's claim on the venue active.
+func (r *SQLRepository) TouchClaimCtx(ctx context.Context, venueID int64, adminID int) error {
+	return r.db.TouchClaimCtx(ctx, venueID, adminID)
+}
+
+// ReleaseClaimCtx drops adminID's claim on the venue.
+func (r *SQLRepository) ReleaseClaimCtx(ctx context.Context, venueID int64, adminID int) (bool, error) {
+	return r.db.ReleaseClaimCtx(ctx, venueID, adminID)
+}
+
+// GetActiveClaimsCtx returns the active claims on the given venues by venue ID.
+func (r *SQLRepository) GetActiveClaimsCtx(ctx context.Context, venueIDs []int64) (map[int64]models.VenueClaim, error) {
+	return r.db.GetActiveClaimsCtx(ctx, venueIDs)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// ListOpenClosureReviewsCtx returns the open closure reviews, oldest first.
+func (r *SQLRepository) ListOpenClosureReviewsCtx(ctx context.Context, limit int) ([]models.ClosureReview, error) {
+	return r.db.ListOpenClosureReviewsCtx(ctx, limit)
+}
+
+// ResolveClosureReviewCtx records an editor's resolution of a closure review.
+func (r *SQLRepository) ResolveClosureReviewCtx(ctx context.Context, id int64, adminID int, resolution string) (bool, error) {
+	return r.db.ResolveClosureReviewCtx(ctx, id, adminID, resolution)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// CreateVenueCommentCtx stores a reviewer comment on a venue.
+func (r *SQLRepository) CreateVenueCommentCtx(ctx context.Context, c *models.VenueComment) error {
+	return r.db.CreateVenueCommentCtx(ctx, c)
+}
+
+// ListVenueCommentsCtx returns the venue's comments, oldest first.
+func (r *SQLRepository) ListVenueCommentsCtx(ctx context.Context, venueID int64) ([]models.VenueComment, error) {
+	return r.db.ListVenueCommentsCtx(ctx, venueID)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// AddConfigChangesCtx records runtime setting changes.
+func (r *SQLRepository) AddConfigChangesCtx(ctx context.Context, changes []models.ConfigChange) error {
+	return r.db.AddConfigChangesCtx(ctx, changes)
+}
+
+// ListConfigChangesCtx returns the most recent runtime setting changes.
+func (r *SQLRepository) ListConfigChangesCtx(ctx context.Context, limit int) ([]models.ConfigChange, error) {
+	return r.db.ListConfigChangesCtx(ctx, limit)
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// GetVenueEmbeddingCtx returns the venue's stored vector for model, or nil.
+func (r *SQLRepository) GetVenueEmbeddingCtx(ctx context.Context, venueID int64, model string) (*models.VenueEmbedding, error) {
+	return r.db.GetVenueEmbeddingCtx(ctx, venueID, model)
+}
+
+// SaveVenueEmbeddingCtx stores a venue's vector.
+func (r *SQLRepository) SaveVenueEmbeddingCtx(ctx context.Context, emb *models.VenueEmbedding) error {
+	return r.db.SaveVenueEmbeddingCtx(ctx, emb)
+}
+
+// ListVenueEmbeddingsCtx returns recent vectors for model, newest first.
+func (r *SQLRepository) ListVenueEmbeddingsCtx(ctx context.Context, model string, since time.Time, limit int) ([]models.VenueEmbedding, error) {
+	return r.db.ListVenueEmbeddingsCtx(ctx, model, since, limit)
+}
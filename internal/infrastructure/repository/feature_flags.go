@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// ListFeatureFlagsCtx returns the stored feature flags.
+func (r *SQLRepository) ListFeatureFlagsCtx(ctx context.Context) ([]models.FeatureFlag, error) {
+	return r.db.ListFeatureFlagsCtx(ctx)
+}
+
+// SetFeatureFlagCtx creates or replaces a feature flag.
+func (r *SQLRepository) SetFeatureFlagCtx(ctx context.Context, f models.FeatureFlag) error {
+	return r.db.SetFeatureFlagCtx(ctx, f)
+}
+
+// DeleteFeatureFlagCtx removes a feature flag.
+func (r *SQLRepository) DeleteFeatureFlagCtx(ctx context.Context, name string) (bool, error) {
+	return r.db.DeleteFeatureFlagCtx(ctx, name)
+}
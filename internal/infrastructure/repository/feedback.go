@@ -20,3 +20,8 @@ func (r *SQLRepository) GetFeedbackByVenueCtx(ctx context.Context, venueID int64
 func (r *SQLRepository) GetFeedbackStatsCtx(ctx context.Context, promptVersion *string) (*models.FeedbackStats, error) {
 	return r.db.GetFeedbackStatsCtx(ctx, promptVersion)
 }
+
+// GetAllEditorFeedbackPaginatedCtx lists feedback across venues, newest first.
+func (r *SQLRepository) GetAllEditorFeedbackPaginatedCtx(ctx context.Context, limit, offset int) ([]models.EditorFeedbackWithVenue, int, error) {
+	return r.db.GetAllEditorFeedbackPaginatedCtx(ctx, limit, offset)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// GetActiveHoldCtx returns the venue's unreleased hold, or nil when there is none.
+func (r *SQLRepository) GetActiveHoldCtx(ctx context.Context, venueID int64) (*models.VenueHold, error) {
+	return r.db.GetActiveHoldCtx(ctx, venueID)
+}
+
+// PlaceHoldCtx stores a hold, releasing the venue's previous one.
+func (r *SQLRepository) PlaceHoldCtx(ctx context.Context, h *models.VenueHold) error {
+	return r.db.PlaceHoldCtx(ctx, h)
+}
+
+// ReleaseHoldCtx ends the venue's active hold.
+func (r *SQLRepository) ReleaseHoldCtx(ctx context.Context, venueID int64, adminID int) (bool, error) {
+	return r.db.ReleaseHoldCtx(ctx, venueID, adminID)
+}
+
+// ListActiveHoldsCtx returns unreleased holds of pending venues, earliest reminder first.
+func (r *SQLRepository) ListActiveHoldsCtx(ctx context.Context, limit int) ([]models.VenueHold, error) {
+	return r.db.ListActiveHoldsCtx(ctx, limit)
+}
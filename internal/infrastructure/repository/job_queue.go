@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// EnqueueJobsCtx adds venues to the shared processing queue, skipping queued ones.
+func (r *SQLRepository) EnqueueJobsCtx(ctx context.Context, jobs []models.QueuedJob) (int, error) {
+	return r.db.EnqueueJobsCtx(ctx, jobs)
+}
+
+// ClaimJobsCtx claims waiting jobs for an instance.
+func (r *SQLRepository) ClaimJobsCtx(ctx context.Context, instanceID string, limit int) ([]models.QueuedJob, error) {
+	return r.db.ClaimJobsCtx(ctx, instanceID, limit)
+}
+
+// CompleteJobCtx removes a processed job.
+func (r *SQLRepository) CompleteJobCtx(ctx context.Context, id int64) error {
+	return r.db.CompleteJobCtx(ctx, id)
+}
+
+// CountWaitingJobsCtx counts unclaimed jobs.
+func (r *SQLRepository) CountWaitingJobsCtx(ctx context.Context) (int, error) {
+	return r.db.CountWaitingJobsCtx(ctx)
+}
+
+// HeartbeatCtx registers or refreshes an instance.
+func (r *SQLRepository) HeartbeatCtx(ctx context.Context, instanceID, hostname string) error {
+	return r.db.HeartbeatCtx(ctx, instanceID, hostname)
+}
+
+// RecoverOrphanedJobsCtx releases jobs held by instances that stopped heartbeating.
+func (r *SQLRepository) RecoverOrphanedJobsCtx(ctx context.Context, staleAfter time.Duration) (int, error) {
+	return r.db.RecoverOrphanedJobsCtx(ctx, staleAfter)
+}
+
+// ReleaseInstanceCtx releases an instance's jobs and unregisters it.
+func (r *SQLRepository) ReleaseInstanceCtx(ctx context.Context, instanceID string) error {
+	return r.db.ReleaseInstanceCtx(ctx, instanceID)
+}
+
+// ListProcessingInstancesCtx lists registered instances.
+func (r *SQLRepository) ListProcessingInstancesCtx(ctx context.Context) ([]models.ProcessingInstance, error) {
+	return r.db.ListProcessingInstancesCtx(ctx)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// ExportMemberDataCtx collects a member's stored data for an access request.
+func (r *SQLRepository) ExportMemberDataCtx(ctx context.Context, memberID int64) (*models.MemberDataExport, error) {
+	return r.db.ExportMemberDataCtx(ctx, memberID)
+}
+
+// PseudonymizeMemberDataCtx hashes feedback IPs and redacts the member's email for an erasure request.
+func (r *SQLRepository) PseudonymizeMemberDataCtx(ctx context.Context, memberID int64, ipKey []byte) (*models.MemberErasure, error) {
+	return r.db.PseudonymizeMemberDataCtx(ctx, memberID, ipKey)
+}
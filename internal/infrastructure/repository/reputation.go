@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// GetSubmissionHistoryCtx returns how a member's earlier venue submissions were decided.
+func (r *SQLRepository) GetSubmissionHistoryCtx(ctx context.Context, userID uint, recent int) (*models.SubmissionHistory, error) {
+	return r.db.GetSubmissionHistoryCtx(ctx, userID, recent)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+)
+
+var _ domain.RescoreStore = (*SQLRepository)(nil)
+
+func (r *SQLRepository) SetScoringVersionCtx(ctx context.Context, kind, version string) (time.Time, error) {
+	return r.db.SetScoringVersionCtx(ctx, kind, version)
+}
+
+func (r *SQLRepository) ListStaleScoredVenuesCtx(ctx context.Context, q models.StaleScoreQuery, limit int) ([]int64, error) {
+	return r.db.ListStaleScoredVenuesCtx(ctx, q, limit)
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// CreateProcessingRunCtx records the start of a batch run.
+func (r *SQLRepository) CreateProcessingRunCtx(ctx context.Context, run *models.ProcessingRun) error {
+	return r.db.CreateProcessingRunCtx(ctx, run)
+}
+
+// UpdateProcessingRunCtx stores a run's progress or final outcome.
+func (r *SQLRepository) UpdateProcessingRunCtx(ctx context.Context, run *models.ProcessingRun) error {
+	return r.db.UpdateProcessingRunCtx(ctx, run)
+}
+
+// ListProcessingRunsCtx lists runs newest first.
+func (r *SQLRepository) ListProcessingRunsCtx(ctx context.Context, limit, offset int) ([]models.ProcessingRun, int, error) {
+	return r.db.ListProcessingRunsCtx(ctx, limit, offset)
+}
+
+// GetProcessingRunCtx returns one run, or nil when it does not exist.
+func (r *SQLRepository) GetProcessingRunCtx(ctx context.Context, id int64) (*models.ProcessingRun, error) {
+	return r.db.GetProcessingRunCtx(ctx, id)
+}
+
+// GetRunValidationHistoryCtx lists the validation histories written by a run.
+func (r *SQLRepository) GetRunValidationHistoryCtx(ctx context.Context, runID int64) ([]models.ValidationHistory, error) {
+	return r.db.GetRunValidationHistoryCtx(ctx, runID)
+}
+
+// RecordRunOutcomeCtx counts one venue outcome against a run stored in the database.
+func (r *SQLRepository) RecordRunOutcomeCtx(ctx context.Context, runID int64, outcome string) error {
+	return r.db.RecordRunOutcomeCtx(ctx, runID, outcome)
+}
+
+// SetRunVenueCountCtx corrects a run's venue count after a partial enqueue.
+func (r *SQLRepository) SetRunVenueCountCtx(ctx context.Context, runID int64, venueCount int) error {
+	return r.db.SetRunVenueCountCtx(ctx, runID, venueCount)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// SaveSandboxResultCtx stores a dry-run result outside the validation history.
+func (r *SQLRepository) SaveSandboxResultCtx(ctx context.Context, result *models.ValidationResult, googleData *models.GooglePlaceData) error {
+	return r.db.SaveSandboxResultCtx(ctx, result, googleData)
+}
+
+// GetSandboxResultsCtx lists recent dry-run results; venueID 0 means all venues.
+func (r *SQLRepository) GetSandboxResultsCtx(ctx context.Context, venueID int64, limit int) ([]models.ValidationHistory, error) {
+	return r.db.GetSandboxResultsCtx(ctx, venueID, limit)
+}
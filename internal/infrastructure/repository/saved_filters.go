@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// ListSavedFiltersCtx returns the admin's saved filters for a list.
+func (r *SQLRepository) ListSavedFiltersCtx(ctx context.Context, adminID int, list string) ([]models.SavedFilter, error) {
+	return r.db.ListSavedFiltersCtx(ctx, adminID, list)
+}
+
+// SaveFilterCtx stores a saved filter, replacing one of the same name.
+func (r *SQLRepository) SaveFilterCtx(ctx context.Context, f *models.SavedFilter) error {
+	return r.db.SaveFilterCtx(ctx, f)
+}
+
+// DeleteSavedFilterCtx removes one of the admin's saved filters.
+func (r *SQLRepository) DeleteSavedFilterCtx(ctx context.Context, adminID int, id int64) (bool, error) {
+	return r.db.DeleteSavedFilterCtx(ctx, adminID, id)
+}
+
+// SetDefaultSavedFilterCtx sets or clears the admin's default filter for a list.
+func (r *SQLRepository) SetDefaultSavedFilterCtx(ctx context.Context, adminID int, list string, id int64) (bool, error) {
+	return r.db.SetDefaultSavedFilterCtx(ctx, adminID, list, id)
+}
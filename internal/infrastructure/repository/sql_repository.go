@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"assisted-venue-approval/internal/domain"
 	"assisted-venue-approval/internal/domain/specs"
@@ -27,8 +28,12 @@ func (r *SQLRepository) GetPendingVenuesWithUserCtx(ctx context.Context) ([]mode
 	return r.db.GetPendingVenuesWithUserCtx(ctx)
 }
 
-func (r *SQLRepository) GetVenuesFilteredCtx(ctx context.Context, status string, search string, limit int, offset int) ([]models.VenueWithUser, int, error) {
-	return r.db.GetVenuesFilteredCtx(ctx, status, search, limit, offset)
+func (r *SQLRepository) GetVenuesFilteredCtx(ctx context.Context, status, search, pathPrefix string, limit int, offset int) ([]models.VenueWithUser, int, error) {
+	return r.db.GetVenuesFilteredCtx(ctx, status, search, pathPrefix, limit, offset)
+}
+
+func (r *SQLRepository) GetVenuesFilteredKeysetCtx(ctx context.Context, status, search, pathPrefix, after string, limit int) ([]models.VenueWithUser, models.PageCursors, int, error) {
+	return r.db.GetVenuesFilteredKeysetCtx(ctx, status, search, pathPrefix, after, limit)
 }
 
 func (r *SQLRepository) GetVenueWithUserByIDCtx(ctx context.Context, venueID int64) (*models.VenueWithUser, error) {
@@ -39,8 +44,8 @@ func (r *SQLRepository) GetSimilarVenuesCtx(ctx context.Context, venue models.Ve
 	return r.db.GetSimilarVenuesCtx(ctx, venue, limit)
 }
 
-func (r *SQLRepository) GetManualReviewVenuesCtx(ctx context.Context, search string, minScore int, trustedOnly bool, sort string, limit int, offset int) ([]models.VenueWithUser, []int, int, error) {
-	return r.db.GetManualReviewVenuesCtx(ctx, search, minScore, trustedOnly, sort, limit, offset)
+func (r *SQLRepository) GetManualReviewVenuesCtx(ctx context.Context, f models.ManualReviewFilter, limit int, offset int) ([]models.VenueWithUser, []int, int, error) {
+	return r.db.GetManualReviewVenuesCtx(ctx, f, limit, offset)
 }
 
 func (r *SQLRepository) GetVenueStatisticsCtx(ctx context.Context) (*models.VenueStats, error) {
@@ -63,11 +68,23 @@ func (r *SQLRepository) FindDuplicateVenuesByNameAndLocation(ctx context.Context
 	return r.db.FindDuplicateVenuesByNameAndLocation(ctx, name, lat, lng, radiusMeters, excludeVenueID)
 }
 
+func (r *SQLRepository) CountRecentVenuesByUserAndNameCtx(ctx context.Context, userID uint, name string, since time.Time, beforeVenueID int64) (int, error) {
+	return r.db.CountRecentVenuesByUserAndNameCtx(ctx, userID, name, since, beforeVenueID)
+}
+
 func (r *SQLRepository) ApproveVenueWithDataReplacement(ctx context.Context, approvalData *domain.ApprovalData) error {
 	return r.db.ApproveVenueWithDataReplacementCtx(ctx, approvalData)
 }
 
-// ValidationRepository methods
+func (r *SQLRepository) RevertVenueApprovalCtx(ctx context.Context, venueID int64, replacements *domain.VenueDataReplacement, notes string) error {
+	return r.db.RevertVenueApprovalCtx(ctx, venueID, replacements, notes)
+}
+
+func (r *SQLRepository) ApplyVenueChangesCtx(ctx context.Context, changes *domain.ApprovalData) error {
+	return r.db.ApplyVenueChangesCtx(ctx, changes)
+}
+
+// HistoryStore methods
 func (r *SQLRepository) SaveValidationResultCtx(ctx context.Context, result *models.ValidationResult) error {
 	return r.db.SaveValidationResultCtx(ctx, result)
 }
@@ -88,6 +105,10 @@ func (r *SQLRepository) GetValidationHistoryPaginatedCtx(ctx context.Context, li
 	return r.db.GetValidationHistoryPaginatedCtx(ctx, limit, offset)
 }
 
+func (r *SQLRepository) GetValidationHistoryKeysetCtx(ctx context.Context, after string, limit int) ([]models.ValidationHistory, models.PageCursors, int, error) {
+	return r.db.GetValidationHistoryKeysetCtx(ctx, after, limit)
+}
+
 func (r *SQLRepository) GetCachedGooglePlaceDataCtx(ctx context.Context, venueID int64) (*models.GooglePlaceData, error) {
 	return r.db.GetCachedGooglePlaceDataCtx(ctx, venueID)
 }
@@ -100,7 +121,11 @@ func (r *SQLRepository) ValidateApprovalEligibility(venueID int64, threshold int
 	return r.db.ValidateApprovalEligibility(venueID, threshold)
 }
 
-// AuditLogRepository methods
+func (r *SQLRepository) SaveValidationResultsCtx(ctx context.Context, records []domain.HistoryRecord) error {
+	return r.db.SaveValidationResultsCtx(ctx, records)
+}
+
+// AuditStore methods
 func (r *SQLRepository) CreateAuditLogCtx(ctx context.Context, log *domain.VenueValidationAuditLog) error {
 	return r.db.CreateAuditLogCtx(ctx, log)
 }
@@ -117,6 +142,10 @@ func (r *SQLRepository) GetAuditLogsByVenueIDCtx(ctx context.Context, venueID in
 	return r.db.GetAuditLogsByVenueIDCtx(ctx, venueID)
 }
 
+func (r *SQLRepository) ListAuditLogsCtx(ctx context.Context, f domain.AuditLogFilter) ([]domain.AuditLogEntry, int, error) {
+	return r.db.ListAuditLogsCtx(ctx, f)
+}
+
 // FilterPendingBySpecCtx fetches pending venues and filters them using a Specification.
 // Note: This applies the spec in-memory. For large datasets, consider adding SQL translations.
 func (r *SQLRepository) FilterPendingBySpecCtx(ctx context.Context, s specs.Specification[models.Venue]) ([]models.VenueWithUser, error) {
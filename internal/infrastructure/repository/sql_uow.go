@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"assisted-venue-approval/internal/domain"
 	"assisted-venue-approval/internal/models"
@@ -77,8 +78,10 @@ func (u *SQLUnitOfWork) Rollback() error {
 
 // VenueRepository methods (writes go through the transaction when present)
 func (u *SQLUnitOfWork) UpdateVenueStatusCtx(ctx context.Context, venueID int64, active int, notes string, reviewer *string) error {
-	// NOTE: status update is simple; not critical to be in the same tx for our use, but we keep consistency by using non-tx method.
-	return u.db.UpdateVenueStatusCtx(ctx, venueID, active, notes, reviewer)
+	if u.tx == nil {
+		return fmt.Errorf("uow: no active transaction for UpdateVenueStatusCtx")
+	}
+	return u.db.UpdateVenueStatusTx(ctx, u.tx, venueID, active, notes)
 }
 
 func (u *SQLUnitOfWork) UpdateVenueActiveCtx(ctx context.Context, venueID int64, active int) error {
@@ -92,8 +95,12 @@ func (u *SQLUnitOfWork) UpdateVenueActiveCtx(ctx context.Context, venueID int64,
 func (u *SQLUnitOfWork) GetPendingVenuesWithUserCtx(ctx context.Context) ([]models.VenueWithUser, error) {
 	return u.db.GetPendingVenuesWithUserCtx(ctx)
 }
-func (u *SQLUnitOfWork) GetVenuesFilteredCtx(ctx context.Context, status string, search string, limit int, offset int) ([]models.VenueWithUser, int, error) {
-	return u.db.GetVenuesFilteredCtx(ctx, status, search, limit, offset)
+func (u *SQLUnitOfWork) GetVenuesFilteredCtx(ctx context.Context, status, search, pathPrefix string, limit int, offset int) ([]models.VenueWithUser, int, error) {
+	return u.db.GetVenuesFilteredCtx(ctx, status, search, pathPrefix, limit, offset)
+}
+
+func (u *SQLUnitOfWork) GetVenuesFilteredKeysetCtx(ctx context.Context, status, search, pathPrefix, after string, limit int) ([]models.VenueWithUser, models.PageCursors, int, error) {
+	return u.db.GetVenuesFilteredKeysetCtx(ctx, status, search, pathPrefix, after, limit)
 }
 func (u *SQLUnitOfWork) GetVenueWithUserByIDCtx(ctx context.Context, venueID int64) (*models.VenueWithUser, error) {
 	return u.db.GetVenueWithUserByIDCtx(ctx, venueID)
@@ -101,8 +108,8 @@ func (u *SQLUnitOfWork) GetVenueWithUserByIDCtx(ctx context.Context, venueID int
 func (u *SQLUnitOfWork) GetSimilarVenuesCtx(ctx context.Context, venue models.Venue, limit int) ([]models.Venue, error) {
 	return u.db.GetSimilarVenuesCtx(ctx, venue, limit)
 }
-func (u *SQLUnitOfWork) GetManualReviewVenuesCtx(ctx context.Context, search string, minScore int, trustedOnly bool, sort string, limit int, offset int) ([]models.VenueWithUser, []int, int, error) {
-	return u.db.GetManualReviewVenuesCtx(ctx, search, minScore, trustedOnly, sort, limit, offset)
+func (u *SQLUnitOfWork) GetManualReviewVenuesCtx(ctx context.Context, f models.ManualReviewFilter, limit int, offset int) ([]models.VenueWithUser, []int, int, error) {
+	return u.db.GetManualReviewVenuesCtx(ctx, f, limit, offset)
 }
 func (u *SQLUnitOfWork) GetVenueStatisticsCtx(ctx context.Context) (*models.VenueStats, error) {
 	return u.db.GetVenueStatisticsCtx(ctx)
@@ -115,11 +122,32 @@ func (u *SQLUnitOfWork) FindDuplicateVenuesByNameAndLocation(ctx context.Context
 	return u.db.FindDuplicateVenuesByNameAndLocation(ctx, name, lat, lng, radiusMeters, excludeVenueID)
 }
 
+func (u *SQLUnitOfWork) CountRecentVenuesByUserAndNameCtx(ctx context.Context, userID uint, name string, since time.Time, beforeVenueID int64) (int, error) {
+	return u.db.CountRecentVenuesByUserAndNameCtx(ctx, userID, name, since, beforeVenueID)
+}
+
 func (u *SQLUnitOfWork) ApproveVenueWithDataReplacement(ctx context.Context, approvalData *domain.ApprovalData) error {
-	return u.db.ApproveVenueWithDataReplacementCtx(ctx, approvalData)
+	if u.tx == nil {
+		return fmt.Errorf("uow: no active transaction for ApproveVenueWithDataReplacement")
+	}
+	return u.db.ApproveVenueWithDataReplacementTx(ctx, u.tx, approvalData)
+}
+
+func (u *SQLUnitOfWork) RevertVenueApprovalCtx(ctx context.Context, venueID int64, replacements *domain.VenueDataReplacement, notes string) error {
+	if u.tx == nil {
+		return fmt.Errorf("uow: no active transaction for RevertVenueApprovalCtx")
+	}
+	return u.db.RevertVenueApprovalTx(ctx, u.tx, venueID, replacements, notes)
+}
+
+func (u *SQLUnitOfWork) ApplyVenueChangesCtx(ctx context.Context, changes *domain.ApprovalData) error {
+	if u.tx == nil {
+		return fmt.Errorf("uow: no active transaction for ApplyVenueChangesCtx")
+	}
+	return u.db.ApplyVenueChangesTx(ctx, u.tx, changes)
 }
 
-// ValidationRepository methods (writes via tx)
+// HistoryStore methods (writes via tx)
 func (u *SQLUnitOfWork) SaveValidationResultCtx(ctx context.Context, result *models.ValidationResult) error {
 	if u.tx == nil {
 		return fmt.Errorf("uow: no active transaction for SaveValidationResultCtx")
@@ -134,6 +162,13 @@ func (u *SQLUnitOfWork) SaveValidationResultWithGoogleDataCtx(ctx context.Contex
 	return u.db.SaveValidationResultWithGoogleDataTx(ctx, u.tx, result, googleData)
 }
 
+func (u *SQLUnitOfWork) SaveValidationResultsCtx(ctx context.Context, records []domain.HistoryRecord) error {
+	if u.tx == nil {
+		return fmt.Errorf("uow: no active transaction for SaveValidationResultsCtx")
+	}
+	return u.db.SaveValidationResultsTx(ctx, u.tx, records)
+}
+
 func (u *SQLUnitOfWork) GetRecentValidationResultsCtx(ctx context.Context, limit int) ([]models.ValidationResult, error) {
 	return u.db.GetRecentValidationResultsCtx(ctx, limit)
 }
@@ -143,6 +178,10 @@ func (u *SQLUnitOfWork) GetVenueValidationHistoryCtx(ctx context.Context, venueI
 func (u *SQLUnitOfWork) GetValidationHistoryPaginatedCtx(ctx context.Context, limit int, offset int) ([]models.ValidationHistory, int, error) {
 	return u.db.GetValidationHistoryPaginatedCtx(ctx, limit, offset)
 }
+
+func (u *SQLUnitOfWork) GetValidationHistoryKeysetCtx(ctx context.Context, after string, limit int) ([]models.ValidationHistory, models.PageCursors, int, error) {
+	return u.db.GetValidationHistoryKeysetCtx(ctx, after, limit)
+}
 func (u *SQLUnitOfWork) GetCachedGooglePlaceDataCtx(ctx context.Context, venueID int64) (*models.GooglePlaceData, error) {
 	return u.db.GetCachedGooglePlaceDataCtx(ctx, venueID)
 }
@@ -152,3 +191,18 @@ func (u *SQLUnitOfWork) HasAnyValidationHistory(venueID int64) (bool, error) {
 func (u *SQLUnitOfWork) ValidateApprovalEligibility(venueID int64, threshold int) error {
 	return u.db.ValidateApprovalEligibility(venueID, threshold)
 }
+
+// Audit log and outbox (writes via tx)
+func (u *SQLUnitOfWork) CreateAuditLogCtx(ctx context.Context, log *domain.VenueValidationAuditLog) error {
+	if u.tx == nil {
+		return fmt.Errorf("uow: no active transaction for CreateAuditLogCtx")
+	}
+	return u.db.CreateAuditLogTx(ctx, u.tx, log)
+}
+
+func (u *SQLUnitOfWork) EnqueueEventCtx(ctx context.Context, ev domain.OutboxEvent) error {
+	if u.tx == nil {
+		return fmt.Errorf("uow: no active transaction for EnqueueEventCtx")
+	}
+	return u.db.EnqueueEventTx(ctx, u.tx, ev)
+}
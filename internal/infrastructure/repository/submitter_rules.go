@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// ListSubmitterRulesCtx returns the submitter block and allow entries.
+func (r *SQLRepository) ListSubmitterRulesCtx(ctx context.Context) ([]models.SubmitterRule, error) {
+	return r.db.ListSubmitterRulesCtx(ctx)
+}
+
+// CreateSubmitterRuleCtx stores a submitter rule.
+func (r *SQLRepository) CreateSubmitterRuleCtx(ctx context.Context, rule *models.SubmitterRule) error {
+	return r.db.CreateSubmitterRuleCtx(ctx, rule)
+}
+
+// DeleteSubmitterRuleCtx removes a submitter rule.
+func (r *SQLRepository) DeleteSubmitterRuleCtx(ctx context.Context, id int64) (bool, error) {
+	return r.db.DeleteSubmitterRuleCtx(ctx, id)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// GetUIPreferencesCtx returns the admin's UI preferences, or nil when none are stored.
+func (r *SQLRepository) GetUIPreferencesCtx(ctx context.Context, adminID int) (*models.UIPreferences, error) {
+	return r.db.GetUIPreferencesCtx(ctx, adminID)
+}
+
+// SaveUIPreferencesCtx stores the admin's UI preferences.
+func (r *SQLRepository) SaveUIPreferencesCtx(ctx context.Context, p models.UIPreferences) error {
+	return r.db.SaveUIPreferencesCtx(ctx, p)
+}
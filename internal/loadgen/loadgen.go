@@ -0,0 +1,328 @@
+// Package loadgen drives the processing engine with synthetic pending venues and fake Google
+// and OpenAI providers, and reports throughput, latency and queue behaviour. It answers
+// capacity questions ("how long would a 50k backlog take with 30 workers?") without
+// production data, API keys or a database.
+package loadgen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/decision"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/internal/processor"
+	testutil "assisted-venue-approval/internal/testing"
+)
+
+// Mix weighs the scenarios synthetic venues play; weights need not sum to 1.
+type Mix map[testutil.Scenario]float64
+
+// DefaultMix is roughly what a real backlog looks like: mostly clear approvals, some venues
+// for review and a few provider errors.
+func DefaultMix() Mix {
+	return Mix{
+		testutil.ScenarioApprove:         70,
+		testutil.ScenarioReject:          10,
+		testutil.ScenarioNoMatch:         10,
+		testutil.ScenarioMalformedOutput: 5,
+		testutil.ScenarioAPIError:        3,
+		testutil.ScenarioBudgetExceeded:  2,
+	}
+}
+
+var scenarios = []testutil.Scenario{
+	testutil.ScenarioApprove, testutil.ScenarioReject, testutil.ScenarioNoMatch,
+	testutil.ScenarioAPIError, testutil.ScenarioMalformedOutput, testutil.ScenarioBudgetExceeded,
+}
+
+// ParseMix parses "approve=70,no_match=10,..." into a Mix.
+func ParseMix(s string) (Mix, error) {
+	m := Mix{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, weight, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("mix entry %q is not scenario=weight", part)
+		}
+		sc := testutil.Scenario(strings.TrimSpace(name))
+		if !knownScenario(sc) {
+			return nil, fmt.Errorf("unknown scenario %q", sc)
+		}
+		w, err := strconv.ParseFloat(strings.TrimSpace(weight), 64)
+		if err != nil || w < 0 {
+			return nil, fmt.Errorf("weight of %s must be a non-negative number", sc)
+		}
+		m[sc] = w
+	}
+	if m.total() == 0 {
+		return nil, fmt.Errorf("mix has no positive weight")
+	}
+	return m, nil
+}
+
+func knownScenario(sc testutil.Scenario) bool {
+	for _, s := range scenarios {
+		if s == sc {
+			return true
+		}
+	}
+	return false
+}
+
+func (m Mix) total() float64 {
+	var t float64
+	for _, w := range m {
+		t += w
+	}
+	return t
+}
+
+// pick returns the scenario r (in [0,1)) falls on.
+func (m Mix) pick(r float64) testutil.Scenario {
+	r *= m.total()
+	for _, sc := range scenarios {
+		if r < m[sc] {
+			return sc
+		}
+		r -= m[sc]
+	}
+	return testutil.ScenarioApprove
+}
+
+var (
+	nameAdjectives = []string{"Green", "Leafy", "Golden", "Happy", "Wild", "Sunny", "Little", "Urban", "Rooted", "Velvet"}
+	nameNouns      = []string{"Bowl", "Kitchen", "Garden", "Bakery", "Bistro", "Table", "Sprout", "Deli", "Cantina", "Cafe"}
+	cities         = []string{"Berlin", "London", "Lisbon", "Portland", "Melbourne", "Toronto", "Taipei", "Mexico City"}
+)
+
+// Generate returns n synthetic pending venues with IDs from 1 and the scenario each plays,
+// drawn from mix with seed. Names are unique so duplicate detection leaves them alone, and
+// submitters are spread over a few hundred trusted members.
+func Generate(n int, mix Mix, seed uint64) ([]models.VenueWithUser, map[int64]testutil.Scenario) {
+	rng := rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+	venues := make([]models.VenueWithUser, 0, n)
+	plays := make(map[int64]testutil.Scenario, n)
+	for i := 1; i <= n; i++ {
+		id := int64(i)
+		name := fmt.Sprintf("%s %s %d", nameAdjectives[rng.IntN(len(nameAdjectives))], nameNouns[rng.IntN(len(nameNouns))], id)
+		vu := testutil.PipelineVenue(id, name)
+		vu.Venue.Location = fmt.Sprintf("%d Main Street, %s", 1+rng.IntN(200), cities[rng.IntN(len(cities))])
+		vu.Venue.UserID = uint(1 + rng.IntN(300))
+		vu.User.ID = vu.Venue.UserID
+		venues = append(venues, vu)
+		plays[id] = mix.pick(rng.Float64())
+	}
+	return venues, plays
+}
+
+// Config is one load test.
+type Config struct {
+	Venues  int
+	Workers int
+	Mix     Mix
+	Seed    uint64
+
+	// Typical provider round trips; calls take between half and one and a half times as long
+	GoogleLatency  time.Duration
+	ScorerLatency  time.Duration
+	QualityLatency time.Duration
+
+	// Rate limits as in production; 0 lifts the limit to measure the engine alone
+	GoogleRPS int
+	OpenAIRPS int
+
+	QueueSize   int           // job queue size; the generator refills it as workers drain it
+	SampleEvery time.Duration // queue sampling interval
+}
+
+// DefaultConfig is the production engine setup with provider latencies typical of Google
+// Places and gpt-4o-mini.
+func DefaultConfig() Config {
+	pc := processor.DefaultProcessingConfig()
+	return Config{
+		Venues:         1000,
+		Workers:        pc.WorkerCount,
+		Mix:            DefaultMix(),
+		Seed:           1,
+		GoogleLatency:  300 * time.Millisecond,
+		ScorerLatency:  2 * time.Second,
+		QualityLatency: 1500 * time.Millisecond,
+		GoogleRPS:      pc.GoogleRPS,
+		OpenAIRPS:      pc.OpenAIRPS,
+		QueueSize:      pc.QueueSize,
+		SampleEvery:    time.Second,
+	}
+}
+
+// Sample is the state of the engine's queues at one point of the run.
+type Sample struct {
+	Elapsed   time.Duration `json:"elapsed"`
+	Queued    int           `json:"queued"`    // venues generated but not yet queued
+	Jobs      int           `json:"jobs"`      // in the job queue
+	Results   int           `json:"results"`   // in the result queue
+	Completed int64         `json:"completed"` // venues with a written result
+}
+
+// Report is the outcome of a load test. Latency percentiles cover the last 1024 venues, as
+// on /api/stats, so a long run reports its steady state.
+type Report struct {
+	Venues     int                                 `json:"venues"`
+	Workers    int                                 `json:"workers"`
+	Duration   time.Duration                       `json:"duration"`
+	Throughput float64                             `json:"throughput_per_sec"`
+	Approved   int64                               `json:"approved"`
+	Rejected   int64                               `json:"rejected"`
+	Manual     int64                               `json:"manual_review"`
+	Failed     int64                               `json:"failed"`
+	Latency    processor.LatencySummary            `json:"latency"`
+	Stages     map[string]processor.LatencySummary `json:"stages"`
+	PeakJobs   int                                 `json:"peak_jobs"`
+	PeakResult int                                 `json:"peak_results"`
+	Samples    []Sample                            `json:"samples"`
+}
+
+// Estimate projects how long n venues take at the measured throughput.
+func (r *Report) Estimate(n int) time.Duration {
+	if r.Throughput <= 0 {
+		return 0
+	}
+	return time.Duration(float64(n) / r.Throughput * float64(time.Second))
+}
+
+// Run generates cfg.Venues venues, processes them in auto-decide mode against fake providers
+// and an in-memory store, and reports once every venue has a result or ctx is done.
+func Run(ctx context.Context, cfg Config) (*Report, error) {
+	if cfg.Venues <= 0 || cfg.Workers <= 0 {
+		return nil, fmt.Errorf("venues and workers must be positive")
+	}
+	if cfg.Mix == nil {
+		cfg.Mix = DefaultMix()
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = processor.DefaultProcessingConfig().QueueSize
+	}
+	if cfg.SampleEvery <= 0 {
+		cfg.SampleEvery = time.Second
+	}
+
+	venues, plays := Generate(cfg.Venues, cfg.Mix, cfg.Seed)
+	script := testutil.NewScript()
+	for id, sc := range plays {
+		script.Set(id, sc)
+	}
+	store := testutil.NewMemoryStore(venues...)
+
+	pc := processor.DefaultProcessingConfig()
+	pc.WorkerCount = cfg.Workers
+	pc.QueueSize = cfg.QueueSize
+	pc.GoogleRPS, pc.OpenAIRPS = cfg.GoogleRPS, cfg.OpenAIRPS
+	e := processor.NewProcessingEngine(store.Repository(), store.UnitOfWorkFactory(),
+		&testutil.FakeScraper{Script: script, Latency: cfg.GoogleLatency},
+		&testutil.FakeScorer{Script: script, Latency: cfg.ScorerLatency},
+		&testutil.FakeQualityReviewer{Script: script, Latency: cfg.QualityLatency},
+		pc, decision.DefaultDecisionConfig())
+	e.Start()
+	defer e.Stop(30 * time.Second)
+
+	rep := &Report{Venues: cfg.Venues, Workers: cfg.Workers}
+	start := time.Now()
+	sample := time.NewTicker(cfg.SampleEvery)
+	defer sample.Stop()
+	poll := time.NewTicker(10 * time.Millisecond)
+	defer poll.Stop()
+
+	next := 0 // first venue not yet queued
+	for {
+		// Keep the job queue topped up, as an admin queuing the backlog page by page would
+		jobs, results := queueLens(e)
+		if free := cfg.QueueSize - jobs; next < len(venues) && free > 0 {
+			end := min(next+free, len(venues))
+			if err := e.ProcessVenuesWithMode(venues[next:end], processor.ModeAutoDecide); err != nil {
+				return nil, fmt.Errorf("queue venues: %w", err)
+			}
+			next = end
+		}
+		rep.PeakJobs = max(rep.PeakJobs, jobs)
+		rep.PeakResult = max(rep.PeakResult, results)
+
+		completed := e.GetStats().CompletedJobs
+		done := completed >= int64(cfg.Venues)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-sample.C:
+			rep.Samples = append(rep.Samples, Sample{Elapsed: time.Since(start), Queued: len(venues) - next, Jobs: jobs, Results: results, Completed: completed})
+		case <-poll.C:
+		}
+		if done {
+			break
+		}
+	}
+
+	rep.Duration = time.Since(start)
+	rep.Samples = append(rep.Samples, Sample{Elapsed: rep.Duration, Completed: int64(cfg.Venues)})
+	st := e.GetStats()
+	rep.Throughput = float64(st.CompletedJobs) / rep.Duration.Seconds()
+	rep.Approved, rep.Rejected, rep.Manual, rep.Failed = st.AutoApproved, st.AutoRejected, st.ManualReview, st.FailedJobs
+	rep.Latency, rep.Stages = st.Latency, st.Stages
+	return rep, nil
+}
+
+func queueLens(e *processor.ProcessingEngine) (jobs, results int) {
+	for _, q := range e.QueueStats() {
+		switch q.Name {
+		case "jobs":
+			jobs = q.Len
+		case "results":
+			results = q.Len
+		}
+	}
+	return jobs, results
+}
+
+// WriteText writes r for a terminal, projecting the run onto a backlog of backlog venues.
+func (r *Report) WriteText(w io.Writer, backlog int) {
+	fmt.Fprintf(w, "Processed %d venues with %d workers in %s: %.1f venues/s\n",
+		r.Venues, r.Workers, r.Duration.Round(time.Millisecond), r.Throughput)
+	fmt.Fprintf(w, "Outcomes: %d approved, %d rejected, %d manual review, %d failed\n",
+		r.Approved, r.Rejected, r.Manual, r.Failed)
+	fmt.Fprintf(w, "Latency per venue: p50 %.0fms, p95 %.0fms, p99 %.0fms (mean %.0fms)\n",
+		r.Latency.P50Ms, r.Latency.P95Ms, r.Latency.P99Ms, r.Latency.MeanMs)
+	stages := make([]string, 0, len(r.Stages))
+	for name := range r.Stages {
+		stages = append(stages, name)
+	}
+	sort.Strings(stages)
+	for _, name := range stages {
+		s := r.Stages[name]
+		if s.Count == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "  %-15s p50 %6.0fms  p95 %6.0fms  p99 %6.0fms\n", name, s.P50Ms, s.P95Ms, s.P99Ms)
+	}
+	fmt.Fprintf(w, "Queues: peak %d jobs, %d results\n", r.PeakJobs, r.PeakResult)
+	fmt.Fprintf(w, "  %8s %8s %8s %8s %10s\n", "elapsed", "waiting", "jobs", "results", "completed")
+	for _, s := range r.Samples {
+		fmt.Fprintf(w, "  %8s %8d %8d %8d %10d\n", s.Elapsed.Round(time.Second), s.Queued, s.Jobs, s.Results, s.Completed)
+	}
+	if backlog > 0 {
+		fmt.Fprintf(w, "A backlog of %d venues would take about %s\n", backlog, r.Estimate(backlog).Round(time.Minute))
+	}
+}
+
+// WriteJSON writes r as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
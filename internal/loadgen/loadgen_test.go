@@ -0,0 +1,75 @@
+package loadgen
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	testutil "assisted-venue-approval/internal/testing"
+)
+
+func TestParseMix(t *testing.T) {
+	m, err := ParseMix("approve=3, no_match=1")
+	if err != nil || m[testutil.ScenarioApprove] != 3 || m[testutil.ScenarioNoMatch] != 1 || len(m) != 2 {
+		t.Fatalf("ParseMix = %v, %v", m, err)
+	}
+	for _, bad := range []string{"", "approve", "approve=x", "approve=-1", "teleport=1", "approve=0"} {
+		if _, err := ParseMix(bad); err == nil {
+			t.Errorf("ParseMix(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	venues, plays := Generate(500, Mix{testutil.ScenarioApprove: 1, testutil.ScenarioNoMatch: 1}, 42)
+	again, _ := Generate(500, Mix{testutil.ScenarioApprove: 1, testutil.ScenarioNoMatch: 1}, 42)
+	if len(venues) != 500 || len(plays) != 500 {
+		t.Fatalf("got %d venues, %d scenarios", len(venues), len(plays))
+	}
+	names := map[string]bool{}
+	counts := map[testutil.Scenario]int{}
+	for i, vu := range venues {
+		if names[vu.Venue.Name] {
+			t.Fatalf("duplicate name %q", vu.Venue.Name)
+		}
+		names[vu.Venue.Name] = true
+		counts[plays[vu.Venue.ID]]++
+		if again[i].Venue.Name != vu.Venue.Name || again[i].Venue.Location != vu.Venue.Location {
+			t.Fatalf("venue %d differs between runs with the same seed", vu.Venue.ID)
+		}
+	}
+	if counts[testutil.ScenarioApprove] < 200 || counts[testutil.ScenarioNoMatch] < 200 {
+		t.Errorf("scenario counts = %v, want about half each", counts)
+	}
+}
+
+func TestRun(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Venues = 300
+	cfg.Workers = 8
+	cfg.GoogleLatency, cfg.ScorerLatency, cfg.QualityLatency = time.Millisecond, 2*time.Millisecond, time.Millisecond
+	cfg.GoogleRPS, cfg.OpenAIRPS = 0, 0
+	cfg.Mix = Mix{testutil.ScenarioApprove: 8, testutil.ScenarioNoMatch: 1, testutil.ScenarioBudgetExceeded: 1}
+	cfg.QueueSize = 50 // smaller than the run, so the generator has to refill it
+	cfg.SampleEvery = 20 * time.Millisecond
+
+	rep, err := Run(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := rep.Approved + rep.Rejected + rep.Manual + rep.Failed; got < 300 {
+		t.Errorf("outcomes add up to %d, want all 300 venues", got)
+	}
+	if rep.Approved == 0 || rep.Failed == 0 || rep.Throughput <= 0 || rep.Latency.P99Ms <= 0 {
+		t.Errorf("report = %+v", rep)
+	}
+	if rep.PeakJobs > 50 || len(rep.Samples) == 0 {
+		t.Errorf("peak jobs %d, %d samples", rep.PeakJobs, len(rep.Samples))
+	}
+	var out strings.Builder
+	rep.WriteText(&out, 50000)
+	if !strings.Contains(out.String(), "A backlog of 50000 venues would take about") {
+		t.Errorf("text report:\n%s", out.String())
+	}
+}
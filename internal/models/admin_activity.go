@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// AdminActivity aggregates one admin's manual decisions over a date range (from audit logs).
+type AdminActivity struct {
+	AdminID      int    `json:"admin_id"`
+	Username     string `json:"username,omitempty"`
+	Approvals    int    `json:"approvals"`
+	Rejections   int    `json:"rejections"`
+	Overrides    int    `json:"overrides"`     // decision opposite to the AI's approved/rejected status
+	BatchActions int    `json:"batch_actions"` // decisions made via batch operations
+	// AvgHandlingSeconds is the mean time from the AI validation to the admin decision.
+	AvgHandlingSeconds float64   `json:"avg_handling_seconds"`
+	FirstAction        time.Time `json:"first_action"`
+	LastAction         time.Time `json:"last_action"`
+}
+
+// Total returns the number of decisions.
+func (a AdminActivity) Total() int { return a.Approvals + a.Rejections }
+
+// OverrideRate returns the share of decisions that overrode the AI, in percent.
+func (a AdminActivity) OverrideRate() float64 {
+	if a.Total() == 0 {
+		return 0
+	}
+	return float64(a.Overrides) / float64(a.Total()) * 100
+}
+
+// BatchRate returns the share of decisions made in batch operations, in percent.
+func (a AdminActivity) BatchRate() float64 {
+	if a.Total() == 0 {
+		return 0
+	}
+	return float64(a.BatchActions) / float64(a.Total()) * 100
+}
+
+// AvgHandling returns AvgHandlingSeconds as a duration rounded to the minute (seconds below one).
+func (a AdminActivity) AvgHandling() time.Duration {
+	d := time.Duration(a.AvgHandlingSeconds * float64(time.Second))
+	if d < time.Minute {
+		return d.Round(time.Second)
+	}
+	return d.Round(time.Minute)
+}
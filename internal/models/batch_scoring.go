@@ -0,0 +1,74 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// States of a venue's scoring call in batch mode.
+const (
+	BatchItemPending   = "pending"   // waiting for the next batch submission
+	BatchItemSubmitted = "submitted" // part of a batch job at OpenAI
+	BatchItemIngested  = "ingested"  // reply turned into validation history
+	BatchItemFailed    = "failed"    // no usable reply; the venue went to manual review
+)
+
+// States of a scoring batch. The OpenAI Batch API statuses (validating, in_progress,
+// finalizing, completed, failed, expired, cancelling, cancelled) are stored as reported;
+// BatchIngested follows once the replies have been processed.
+const (
+	BatchCompleted = "completed"
+	BatchFailed    = "failed"
+	BatchExpired   = "expired"
+	BatchCancelled = "cancelled"
+	BatchIngested  = "ingested"
+)
+
+// ScoringBatch is one OpenAI Batch API job carrying the scoring calls of batch-mode venues.
+type ScoringBatch struct {
+	ID            int64      `json:"id"`
+	OpenAIBatchID string     `json:"openai_batch_id"`
+	InputFileID   string     `json:"input_file_id"`
+	OutputFileID  *string    `json:"output_file_id,omitempty"`
+	ErrorFileID   *string    `json:"error_file_id,omitempty"`
+	Status        string     `json:"status"`
+	ItemCount     int        `json:"item_count"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	IngestedAt    *time.Time `json:"ingested_at,omitempty"`
+}
+
+// Finished reports whether OpenAI is done with the batch, successfully or not, so its
+// replies can be ingested. Expired and cancelled batches may still have partial output.
+func (b ScoringBatch) Finished() bool {
+	switch b.Status {
+	case BatchCompleted, BatchFailed, BatchExpired, BatchCancelled:
+		return true
+	}
+	return false
+}
+
+// BatchScoringItem is a batch-mode venue whose AI scoring call waits for, or went out in, a
+// ScoringBatch. The enriched venue is kept so the reply can finish the pipeline without
+// calling Google again.
+type BatchScoringItem struct {
+	ID            int64
+	BatchID       *int64 // nil while pending
+	VenueID       int64
+	RunID         int64 // 0 for none
+	Status        string
+	Request       json.RawMessage // chat completion request body
+	PromptVersion string
+	TokenUsage    *TokenUsage // prompt estimate; completed from the reply
+	EnrichedVenue json.RawMessage
+	Translation   json.RawMessage // VenueTranslation when the venue was scored in translation
+	Error         string
+	CreatedAt     time.Time
+}
+
+// BatchReply is the outcome of one request in a finished batch: the chat completion
+// response body, or why there is none.
+type BatchReply struct {
+	Body  json.RawMessage
+	Error string
+}
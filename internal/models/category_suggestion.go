@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+// Category suggestion confidence values.
+const (
+	CategoryConfidenceHigh   = "high"
+	CategoryConfidenceMedium = "medium"
+	CategoryConfidenceLow    = "low"
+)
+
+// Category review outcomes: whether the category a venue was approved with is the suggested one.
+const (
+	CategoryReviewAccepted   = "accepted"
+	CategoryReviewOverridden = "overridden"
+)
+
+// CategorySuggestion is the category a venue most likely belongs to, worked out from its
+// Google place types, name and description, next to the category it was submitted with.
+// Stored under ai_output_data["category_suggestion"].
+type CategorySuggestion struct {
+	Submitted  int      `json:"submitted"`
+	Suggested  int      `json:"suggested"`
+	Confidence string   `json:"confidence"`        // high|medium|low
+	Signals    []string `json:"signals,omitempty"` // e.g. "google:bakery", "name:bakery"
+	Mismatch   bool     `json:"mismatch"`          // Suggested differs from Submitted
+	Source     string   `json:"source"`            // classifier and version, e.g. heuristic@v1
+}
+
+// SubmittedLabel is the display label of the submitted category.
+func (s *CategorySuggestion) SubmittedLabel() string { return CategoryLabel(0, s.Submitted) }
+
+// SuggestedLabel is the display label of the suggested category.
+func (s *CategorySuggestion) SuggestedLabel() string { return CategoryLabel(0, s.Suggested) }
+
+// CategoryReview records the category an admin approved a venue with against the suggestion
+// for it, so the classifier can be evaluated on real decisions.
+type CategoryReview struct {
+	ID         int64     `json:"id"`
+	VenueID    int64     `json:"venue_id"`
+	HistoryID  *int64    `json:"history_id,omitempty"`
+	Submitted  int       `json:"submitted"`
+	Suggested  int       `json:"suggested"`
+	Final      int       `json:"final"`
+	Confidence string    `json:"confidence"`
+	Mismatch   bool      `json:"mismatch"`
+	Source     string    `json:"source"`
+	Outcome    string    `json:"outcome"` // accepted|overridden
+	AdminID    int       `json:"admin_id"`
+	ReviewedAt time.Time `json:"reviewed_at"`
+}
+
+// CategoryReviewStat counts reviews of one classifier source, confidence and mismatch state
+// by outcome.
+type CategoryReviewStat struct {
+	Source     string `json:"source"`
+	Confidence string `json:"confidence"`
+	Mismatch   bool   `json:"mismatch"`
+	Accepted   int    `json:"accepted"`
+	Overridden int    `json:"overridden"`
+}
+
+// AcceptRate is the share of reviews that kept the suggested category, 0 without reviews.
+func (s CategoryReviewStat) AcceptRate() float64 {
+	total := s.Accepted + s.Overridden
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Accepted) / float64(total)
+}
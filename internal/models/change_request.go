@@ -0,0 +1,34 @@
+package models
+
+import "sort"
+
+// Per-field verdicts of a change assessment.
+const (
+	ChangeFieldAccept = "accept" // the new value looks right
+	ChangeFieldReject = "reject" // the new value looks wrong, spam or vandalism
+	ChangeFieldUnsure = "unsure" // cannot tell from the data given
+)
+
+// ChangeAssessment is the AI's read of an edit to an approved venue. Only the edited fields
+// are scored: Score is 0-100 for the edit as a whole, Fields holds a verdict per edited field.
+type ChangeAssessment struct {
+	Score   int               `json:"score"`
+	Fields  map[string]string `json:"fields"`
+	Notes   string            `json:"notes"`
+	CostUSD float64           `json:"cost_usd,omitempty"`
+}
+
+// RejectedFields lists the fields the assessment rejected, sorted.
+func (ca *ChangeAssessment) RejectedFields() []string {
+	if ca == nil {
+		return nil
+	}
+	var out []string
+	for f, v := range ca.Fields {
+		if v == ChangeFieldReject {
+			out = append(out, f)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Checkpoint stages, in processing order.
+const (
+	CheckpointEnriched = "enriched" // Google enrichment done
+	CheckpointScored   = "scored"   // AI scoring and the optional checks done; decision pending
+)
+
+// JobCheckpoint is the progress of a job interrupted by a shutdown, kept so the venue's next
+// processing skips the stages already paid for. Payloads are JSON snapshots taken when the
+// stage finished.
+type JobCheckpoint struct {
+	VenueID          int64
+	Stage            string
+	VenueUpdated     *time.Time      // venue's date_updated at the time; an edit invalidates the checkpoint
+	EnrichedVenue    json.RawMessage // Venue after Google enrichment
+	ValidationResult json.RawMessage // ValidationResult before the decision; nil before CheckpointScored
+	CreatedAt        time.Time
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// VenueClaim marks a pending venue as being reviewed by an admin, so other reviewers can
+// leave it alone. A claim lapses once the admin has been inactive on the venue for the
+// configured claim timeout.
+type VenueClaim struct {
+	VenueID      int64     `json:"venue_id"`
+	AdminID      int       `json:"admin_id"`
+	ClaimedAt    time.Time `json:"claimed_at"`
+	LastActiveAt time.Time `json:"last_active_at"`
+}
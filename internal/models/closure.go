@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// GoogleStatusClosedPermanently is the Google Places business status of a closed business.
+const GoogleStatusClosedPermanently = "CLOSED_PERMANENTLY"
+
+// Closure review resolutions.
+const (
+	ClosureConfirmed = "confirmed" // the venue is closed; the listing needs to be closed on the site
+	ClosureDismissed = "dismissed" // the venue is still open; Google is wrong or the place moved
+)
+
+// ClosureCandidate is an approved venue due for a closure check, with the Google place and
+// business status its latest validation stored.
+type ClosureCandidate struct {
+	VenueID      int64
+	PlaceID      string
+	StoredStatus string    // business status at the latest validation, may be empty
+	StoredAt     time.Time // when the latest validation ran
+}
+
+// ClosureReview asks an editor to confirm that an approved venue has closed, after Google
+// reported its place as permanently closed. A venue gets one review per Google place.
+type ClosureReview struct {
+	ID             int64      `json:"id"`
+	VenueID        int64      `json:"venue_id"`
+	VenueName      string     `json:"venue_name,omitempty"` // filled when listing reviews
+	PlaceID        string     `json:"place_id"`
+	BusinessStatus string     `json:"business_status"`
+	CreatedAt      time.Time  `json:"created_at"`
+	Resolution     string     `json:"resolution,omitempty"` // empty while open
+	ResolvedBy     *int       `json:"resolved_by,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+}
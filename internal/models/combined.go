@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"assisted-venue-approval/pkg/geography"
 )
 
 // CombinedInfo is a standardized, merged view of venue data from user and Google
@@ -134,36 +136,7 @@ func GetCombinedVenueInfo(v Venue, u User, trust float64, suggestedPath string)
 
 	// Hours - use Google data if user left empty
 	// Parse JSON format if present: {"openhours":[...],"note":""}
-	var userHours []string
-	if v.OpenHours != nil && strings.TrimSpace(*v.OpenHours) != "" {
-		hoursStr := strings.TrimSpace(*v.OpenHours)
-
-		// Try to parse as JSON (new format after approval)
-		var hoursData struct {
-			OpenHours []string `json:"openhours"`
-			Note      string   `json:"note"`
-		}
-		if err := json.Unmarshal([]byte(hoursStr), &hoursData); err == nil && len(hoursData.OpenHours) > 0 {
-			// Successfully parsed JSON format
-			userHours = hoursData.OpenHours
-		} else {
-			// Legacy format or plain text - treat as single entry
-			userHours = []string{hoursStr}
-		}
-
-		// Handle cases where JSON failed to parse initially but the stored value is JSON
-		if len(userHours) == 1 {
-			if trimmed := strings.TrimSpace(userHours[0]); strings.HasPrefix(trimmed, "{") {
-				var retry struct {
-					OpenHours []string `json:"openhours"`
-					Note      string   `json:"note"`
-				}
-				if err := json.Unmarshal([]byte(trimmed), &retry); err == nil && len(retry.OpenHours) > 0 {
-					userHours = retry.OpenHours
-				}
-			}
-		}
-	}
+	userHours := ParseUserHours(v.OpenHours)
 	var googleHours []string
 	if gd != nil && gd.OpeningHours != nil && len(gd.OpeningHours.WeekdayText) > 0 {
 		googleHours = gd.OpeningHours.WeekdayText
@@ -459,6 +432,13 @@ func GetApprovalFieldData(
 		data.Sources = make(map[string]string)
 	}
 
+	// Store phones in E.164; the final path decides the country for national numbers
+	country := geography.CountryFromPath(data.Path)
+	if country == "" {
+		country = VenueCountry(venue)
+	}
+	data.Phone = NormalizePhone(data.Phone, country)
+
 	if draftMap != nil {
 		if fieldData, ok := draftMap["hours_note"].(map[string]interface{}); ok {
 			if noteVal, ok := fieldData["value"].(string); ok {
@@ -498,6 +478,42 @@ type AISuggestions struct {
 	ClosedDays            string
 }
 
+// ParseUserHours reads a venue's stored hours: the JSON form {"openhours":[...],"note":""}
+// written on approval, or legacy plain text as a single entry.
+func ParseUserHours(raw *string) []string {
+	var userHours []string
+	if raw != nil && strings.TrimSpace(*raw) != "" {
+		hoursStr := strings.TrimSpace(*raw)
+
+		// Try to parse as JSON (new format after approval)
+		var hoursData struct {
+			OpenHours []string `json:"openhours"`
+			Note      string   `json:"note"`
+		}
+		if err := json.Unmarshal([]byte(hoursStr), &hoursData); err == nil && len(hoursData.OpenHours) > 0 {
+			// Successfully parsed JSON format
+			userHours = hoursData.OpenHours
+		} else {
+			// Legacy format or plain text - treat as single entry
+			userHours = []string{hoursStr}
+		}
+
+		// Handle cases where JSON failed to parse initially but the stored value is JSON
+		if len(userHours) == 1 {
+			if trimmed := strings.TrimSpace(userHours[0]); strings.HasPrefix(trimmed, "{") {
+				var retry struct {
+					OpenHours []string `json:"openhours"`
+					Note      string   `json:"note"`
+				}
+				if err := json.Unmarshal([]byte(trimmed), &retry); err == nil && len(retry.OpenHours) > 0 {
+					userHours = retry.OpenHours
+				}
+			}
+		}
+	}
+	return userHours
+}
+
 // ApplyEditorDrafts overlays editor modifications onto combined info
 // Uses type assertion to handle the draft interface
 func ApplyEditorDrafts(combined *CombinedInfo, draft interface{}) {
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// VenueComment is an internal note reviewers leave on a venue. Replies point at the comment
+// they answer; top-level comments have no parent.
+type VenueComment struct {
+	ID        int64           `json:"id"`
+	VenueID   int64           `json:"venue_id"`
+	ParentID  *int64          `json:"parent_id,omitempty"`
+	AdminID   int             `json:"admin_id"`
+	Body      string          `json:"body"`
+	Mentions  []int           `json:"mentions,omitempty"` // admin IDs mentioned as @12 or @admin_12
+	CreatedAt time.Time       `json:"created_at"`
+	Replies   []*VenueComment `json:"replies,omitempty"` // filled when comments are threaded
+}
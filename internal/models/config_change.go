@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// ConfigChange is one runtime setting changed through the config API.
+type ConfigChange struct {
+	ID        int64     `json:"id"`
+	AdminID   int       `json:"admin_id"`
+	Setting   string    `json:"setting"` // env var name
+	OldValue  string    `json:"old_value"`
+	NewValue  string    `json:"new_value"`
+	ChangedAt time.Time `json:"changed_at"`
+}
@@ -14,6 +14,9 @@ const (
 	FeedbackThumbsDown FeedbackType = "thumbs_down"
 )
 
+// MaxPromptVersionLen is the width of the prompt_version columns (VARCHAR(96)).
+const MaxPromptVersionLen = 96
+
 // EditorFeedback maps to editor_feedback table.
 type EditorFeedback struct {
 	ID            int64        `json:"id"`
@@ -38,7 +41,7 @@ func (e *EditorFeedback) Validate() error {
 	}
 	if e.PromptVersion != nil {
 		pv := *e.PromptVersion
-		if len(pv) == 0 || len(pv) > 32 {
+		if len(pv) == 0 || len(pv) > MaxPromptVersionLen {
 			return errors.New("invalid prompt_version")
 		}
 	}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// VenueEmbedding is the vector of a venue's name and description for one embedding model.
+// TextHash identifies the text it was computed from, so an unchanged venue is not embedded
+// again.
+type VenueEmbedding struct {
+	VenueID   int64
+	Model     string
+	TextHash  string
+	Vector    []float32
+	UpdatedAt time.Time
+}
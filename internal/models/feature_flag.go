@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// FeatureFlag is the stored setting of a feature flag. RolloutPercent (0-100) of the keys
+// see the capability while Enabled is set.
+type FeatureFlag struct {
+	Name           string    `json:"name"`
+	Enabled        bool      `json:"enabled"`
+	RolloutPercent int       `json:"rollout_percent"`
+	UpdatedBy      int       `json:"updated_by"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
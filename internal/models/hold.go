@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// VenueHold parks a pending venue outside the manual review queue while a reviewer waits
+// for outside information, such as a reply from the owner. The hold ends when it is
+// released or, if it has one, on its reminder date.
+type VenueHold struct {
+	ID         int64      `json:"id"`
+	VenueID    int64      `json:"venue_id"`
+	VenueName  string     `json:"venue_name,omitempty"` // filled when listing holds
+	AdminID    int        `json:"admin_id"`
+	Reason     string     `json:"reason"`
+	RemindAt   *time.Time `json:"remind_at,omitempty"` // nil holds until released
+	CreatedAt  time.Time  `json:"created_at"`
+	ReleasedAt *time.Time `json:"released_at,omitempty"`
+	ReleasedBy *int       `json:"released_by,omitempty"`
+}
+
+// Due reports whether the hold's reminder date has passed, putting the venue back in the
+// manual review queue.
+func (h VenueHold) Due(now time.Time) bool {
+	return h.RemindAt != nil && !h.RemindAt.After(now)
+}
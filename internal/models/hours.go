@@ -0,0 +1,105 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// HoursDays are the short day names used in stored opening hours, Monday first.
+var HoursDays = [7]string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+// NormalizedHours is a week of opening hours as time ranges per day. A day without ranges
+// is closed.
+type NormalizedHours struct {
+	Monday    []TimeRange `json:"monday"`
+	Tuesday   []TimeRange `json:"tuesday"`
+	Wednesday []TimeRange `json:"wednesday"`
+	Thursday  []TimeRange `json:"thursday"`
+	Friday    []TimeRange `json:"friday"`
+	Saturday  []TimeRange `json:"saturday"`
+	Sunday    []TimeRange `json:"sunday"`
+}
+
+type TimeRange struct {
+	Open  string `json:"open"`  // "HH:MM" format
+	Close string `json:"close"` // "HH:MM" format; "24:00" is midnight at the end of the day
+}
+
+// Days returns the days' ranges in HoursDays order, for reading or appending.
+func (h *NormalizedHours) Days() [7]*[]TimeRange {
+	return [7]*[]TimeRange{&h.Monday, &h.Tuesday, &h.Wednesday, &h.Thursday, &h.Friday, &h.Saturday, &h.Sunday}
+}
+
+// IsEmpty reports whether the venue is closed every day, i.e. no hours are known.
+func (h NormalizedHours) IsEmpty() bool {
+	for _, day := range h.Days() {
+		if len(*day) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Lines returns the hours as stored "Mon-09:00-17:00" lines, one per range, by day and
+// opening time. Closed days have no line.
+func (h NormalizedHours) Lines() []string {
+	var lines []string
+	for i, day := range h.Days() {
+		ranges := append([]TimeRange(nil), *day...)
+		sort.SliceStable(ranges, func(a, b int) bool { return ranges[a].Open < ranges[b].Open })
+		for _, r := range ranges {
+			lines = append(lines, fmt.Sprintf("%s-%s-%s", HoursDays[i], r.Open, r.Close))
+		}
+	}
+	return lines
+}
+
+// HoursFromGooglePeriods converts Google opening periods. A period without a close time
+// means open around the clock; a period closing at midnight closes at "24:00".
+func HoursFromGooglePeriods(periods []GooglePeriod) NormalizedHours {
+	var hours NormalizedHours
+	days := hours.Days()
+	for _, p := range periods {
+		if p.Open.Day < 0 || p.Open.Day > 6 {
+			continue
+		}
+		open := googleClock(p.Open.Time)
+		if open == "" {
+			continue
+		}
+		if p.Close.Time == "" {
+			if len(periods) == 1 && open == "00:00" {
+				for _, day := range days {
+					*day = []TimeRange{{Open: "00:00", Close: "24:00"}}
+				}
+				return hours
+			}
+			continue
+		}
+		close := googleClock(p.Close.Time)
+		if close == "" {
+			continue
+		}
+		if close == "00:00" && p.Close.Day != p.Open.Day {
+			close = "24:00"
+		}
+		// Google counts from Sunday, stored hours from Monday
+		day := days[(p.Open.Day+6)%7]
+		*day = append(*day, TimeRange{Open: open, Close: close})
+	}
+	return hours
+}
+
+// googleClock turns Google's "HHMM" into "HH:MM", or "" if it is not a time of day.
+func googleClock(t string) string {
+	if len(t) != 4 {
+		return ""
+	}
+	hour, err1 := strconv.Atoi(t[:2])
+	minute, err2 := strconv.Atoi(t[2:])
+	if err1 != nil || err2 != nil || hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return ""
+	}
+	return fmt.Sprintf("%02d:%02d", hour, minute)
+}
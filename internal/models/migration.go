@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// Migration is one schema change of db_changes.md, numbered as its section there.
+type Migration struct {
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"` // nil while pending
+}
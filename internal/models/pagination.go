@@ -0,0 +1,8 @@
+package models
+
+// PageCursors holds opaque keyset cursors for the pages around a result; empty when there
+// is no such page. Pass one back as ?cursor= to fetch that page.
+type PageCursors struct {
+	Next string `json:"next,omitempty"`
+	Prev string `json:"prev,omitempty"`
+}
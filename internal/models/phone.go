@@ -0,0 +1,78 @@
+package models
+
+import (
+	"strings"
+
+	"assisted-venue-approval/pkg/geography"
+	"assisted-venue-approval/pkg/utils"
+)
+
+// PhoneCheck is the E.164 form of venue.Phone and whether its calling code agrees with
+// the venue's country. CountryMatch is only meaningful when both E164 and Country are set.
+type PhoneCheck struct {
+	Raw          string `json:"raw"`
+	E164         string `json:"e164,omitempty"`
+	Country      string `json:"country,omitempty"`       // venue country the number was checked against
+	ExpectedCode string `json:"expected_code,omitempty"` // calling code of Country
+	CallingCode  string `json:"calling_code,omitempty"`  // calling code found in the number
+	CountryMatch bool   `json:"country_match"`
+	Error        string `json:"error,omitempty"`
+}
+
+// Mismatch reports a valid number whose calling code belongs to another country.
+func (p *PhoneCheck) Mismatch() bool {
+	return p != nil && p.E164 != "" && p.ExpectedCode != "" && !p.CountryMatch
+}
+
+// VenueCountry returns the venue's country from its path, falling back to the country
+// of the matched Google place (which is located by lat/lng).
+func VenueCountry(v Venue) string {
+	if v.Path != nil {
+		if c := geography.CountryFromPath(*v.Path); c != "" {
+			return c
+		}
+	}
+	if v.GoogleData != nil {
+		for _, comp := range v.GoogleData.AddressComponents {
+			for _, t := range comp.Types {
+				if t == "country" && geography.GetCallingCode(comp.LongName) != "" {
+					return strings.ToLower(comp.LongName)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// CheckVenuePhone normalises venue.Phone and compares its calling code with the venue country.
+// Returns nil when the venue has no phone.
+func CheckVenuePhone(v Venue) *PhoneCheck {
+	if v.Phone == nil || strings.TrimSpace(*v.Phone) == "" {
+		return nil
+	}
+	pc := &PhoneCheck{Raw: strings.TrimSpace(*v.Phone), Country: VenueCountry(v)}
+	pc.ExpectedCode = geography.GetCallingCode(pc.Country)
+
+	e164, err := utils.NormalizeE164(pc.Raw, pc.ExpectedCode)
+	if err != nil {
+		pc.Error = err.Error()
+		return pc
+	}
+	pc.E164 = e164
+	pc.CallingCode = geography.CallingCodeOf(e164)
+	pc.CountryMatch = pc.ExpectedCode != "" && strings.HasPrefix(strings.TrimPrefix(e164, "+"), pc.ExpectedCode)
+	return pc
+}
+
+// NormalizePhone returns phone in E.164 using country for national numbers, or the input
+// unchanged when it cannot be normalised (editors may enter free-form numbers).
+func NormalizePhone(phone, country string) string {
+	if strings.TrimSpace(phone) == "" {
+		return phone
+	}
+	e164, err := utils.NormalizeE164(phone, geography.GetCallingCode(country))
+	if err != nil {
+		return phone
+	}
+	return e164
+}
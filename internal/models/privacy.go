@@ -0,0 +1,82 @@
+package models
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"time"
+)
+
+// RedactedPlaceholder replaces personal data removed from stored text.
+const RedactedPlaceholder = "[redacted]"
+
+// MemberDataExport is everything this application stores about a member, for a data
+// subject access request. Member and venue rows belong to the main site; they are
+// included as read from its tables.
+type MemberDataExport struct {
+	MemberID          int64                  `json:"member_id"`
+	Username          string                 `json:"username"`
+	Email             string                 `json:"email"`
+	GeneratedAt       time.Time              `json:"generated_at"`
+	Venues            []MemberVenue          `json:"venues"`
+	Histories         []ValidationHistory    `json:"validation_histories"`
+	ArchivedHistories []ValidationHistory    `json:"archived_validation_histories"`
+	Feedback          []MemberFeedbackRecord `json:"editor_feedback"`
+}
+
+// MemberVenue is a venue the member submitted, with the personal fields it carries.
+type MemberVenue struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Location  string     `json:"location"`
+	Phone     *string    `json:"phone,omitempty"`
+	URL       *string    `json:"url,omitempty"`
+	Email     *string    `json:"email,omitempty"`
+	OwnerName *string    `json:"ownername,omitempty"`
+	SentBy    *string    `json:"sentby,omitempty"`
+	Active    int        `json:"active"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// MemberFeedbackRecord is reviewer feedback left on one of the member's venues.
+// IP is the stored address, or the hex of its hash once pseudonymized.
+type MemberFeedbackRecord struct {
+	ID           int64        `json:"id"`
+	VenueID      int64        `json:"venue_id"`
+	FeedbackType FeedbackType `json:"feedback_type"`
+	Comment      *string      `json:"comment,omitempty"`
+	IP           string       `json:"ip,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+// MemberErasure reports what a pseudonymization request changed.
+type MemberErasure struct {
+	MemberID          int64 `json:"member_id"`
+	FeedbackIPsHashed int64 `json:"feedback_ips_hashed"`
+	HistoriesRedacted int64 `json:"histories_redacted"`
+	ArchivedRedacted  int64 `json:"archived_histories_redacted"`
+}
+
+// PseudonymizedIPLen is the length of a hashed feedback IP. Stored addresses are 4 or 16
+// bytes, so the length alone tells hashed rows apart and erasure can be repeated safely.
+const PseudonymizedIPLen = 12
+
+// PseudonymizeIP returns a keyed hash of ip that fits the VARBINARY(16) column. The same
+// address hashes the same under one key, so one-vote-per-IP still holds among hashed rows.
+func PseudonymizeIP(key, ip []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(ip)
+	return mac.Sum(nil)[:PseudonymizedIPLen]
+}
+
+// FormatStoredIP renders a feedback IP column: the address, or "hashed:<hex>" once pseudonymized.
+func FormatStoredIP(b []byte) string {
+	switch len(b) {
+	case 0:
+		return ""
+	case PseudonymizedIPLen:
+		return "hashed:" + hex.EncodeToString(b)
+	}
+	return net.IP(b).String()
+}
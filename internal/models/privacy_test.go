@@ -0,0 +1,37 @@
+package models
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestPseudonymizeIP(t *testing.T) {
+	v4 := IPToBytes(net.ParseIP("203.0.113.9"))
+	v6 := IPToBytes(net.ParseIP("2001:db8::1"))
+
+	a := PseudonymizeIP([]byte("key"), v4)
+	if len(a) != PseudonymizedIPLen || len(a) == len(v4) || len(a) == len(v6) {
+		t.Fatalf("hash length %d must differ from stored IP lengths", len(a))
+	}
+	if !bytes.Equal(a, PseudonymizeIP([]byte("key"), v4)) {
+		t.Fatal("same key and IP must hash the same")
+	}
+	if bytes.Equal(a, PseudonymizeIP([]byte("other"), v4)) {
+		t.Fatal("different keys must hash differently")
+	}
+
+	if got := FormatStoredIP(v4); got != "203.0.113.9" {
+		t.Fatalf("FormatStoredIP(v4) = %q", got)
+	}
+	if got := FormatStoredIP(v6); got != "2001:db8::1" {
+		t.Fatalf("FormatStoredIP(v6) = %q", got)
+	}
+	if got := FormatStoredIP(a); !strings.HasPrefix(got, "hashed:") {
+		t.Fatalf("FormatStoredIP(hash) = %q", got)
+	}
+	if got := FormatStoredIP(nil); got != "" {
+		t.Fatalf("FormatStoredIP(nil) = %q", got)
+	}
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Levels of a ProcessingLogEntry.
+const (
+	LogLevelInfo  = "info"
+	LogLevelWarn  = "warn"
+	LogLevelError = "error"
+)
+
+// ProcessingLogEntry is one log line the engine wrote while validating a venue: early exit
+// reasons, retries, failed API calls and checks skipped on error. Stored in order under
+// ai_output_data["processing_log"].
+type ProcessingLogEntry struct {
+	At      time.Time `json:"at"`
+	Level   string    `json:"level"`
+	Message string    `json:"message"`
+}
+
+// ProcessingLog returns the log lines stored with this validation, or nil when the engine
+// logged nothing for it or the row predates processing logs.
+func (h ValidationHistory) ProcessingLog() []ProcessingLogEntry {
+	if h.AIOutputData == nil {
+		return nil
+	}
+	var out struct {
+		Log []ProcessingLogEntry `json:"processing_log"`
+	}
+	if err := json.Unmarshal([]byte(*h.AIOutputData), &out); err != nil {
+		return nil
+	}
+	return out.Log
+}
@@ -0,0 +1,42 @@
+package models
+
+import "encoding/json"
+
+// ProcessingFailedKey is the score_breakdown key of the history row written for a validation
+// that failed before gathering anything. The row only shows the failure on the venue page: the
+// venue still counts as pending, so the next run (forced or not) picks it up again.
+const ProcessingFailedKey = "processing_failed"
+
+// PipelineProgress is how far a failed validation got: the stages that completed, the stage
+// that failed and why. Stored under ai_output_data["progress"] of the history row written for
+// the failure, which also carries what the completed stages gathered (the Google data).
+type PipelineProgress struct {
+	Completed   []string `json:"completed"`    // in processing order: google, openai, ...
+	FailedStage string   `json:"failed_stage"` // google, openai, ...
+	Error       string   `json:"error"`
+	// A checkpoint of the completed stages was kept, so the next run of the venue starts
+	// after them (CHECKPOINT_ENABLED)
+	Resumable bool `json:"resumable,omitempty"`
+}
+
+// Reviewed reports whether the row records a result, rather than a failure that gathered
+// nothing (ProcessingFailedKey). Only reviewed rows take a venue out of the pending venues.
+func (h ValidationHistory) Reviewed() bool {
+	_, failed := h.ScoreBreakdown[ProcessingFailedKey]
+	return !failed
+}
+
+// Progress returns the progress stored with a failed validation, or nil for validations that
+// completed and rows written before progress was recorded.
+func (h ValidationHistory) Progress() *PipelineProgress {
+	if h.AIOutputData == nil {
+		return nil
+	}
+	var out struct {
+		Progress *PipelineProgress `json:"progress"`
+	}
+	if err := json.Unmarshal([]byte(*h.AIOutputData), &out); err != nil {
+		return nil
+	}
+	return out.Progress
+}
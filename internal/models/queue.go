@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// QueuedJob is a venue waiting in, or claimed from, the shared processing queue used when
+// several instances process together.
+type QueuedJob struct {
+	ID        int64
+	VenueID   int64
+	Mode      string
+	RunID     int64 // 0 for none
+	Priority  int
+	ClaimedBy string // instance ID; empty while waiting
+	Attempts  int    // times the job has been claimed
+	CreatedAt time.Time
+}
+
+// ProcessingInstance is a replica taking part in distributed processing.
+type ProcessingInstance struct {
+	ID            string    `json:"id"`
+	Hostname      string    `json:"hostname"`
+	StartedAt     time.Time `json:"started_at"`
+	LastHeartbeat time.Time `json:"last_heartbeat"`
+	ClaimedJobs   int       `json:"claimed_jobs"`
+}
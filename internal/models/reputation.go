@@ -0,0 +1,22 @@
+package models
+
+// SubmissionHistory summarises how a member's earlier venue submissions were decided.
+// Approved and Rejected count venues by their current status. RejectionStreak counts the
+// member's most recent decisions that were rejections, newest first; a reverted decision
+// is skipped.
+type SubmissionHistory struct {
+	Approved        int `json:"approved"`
+	Rejected        int `json:"rejected"`
+	RejectionStreak int `json:"rejection_streak"`
+}
+
+// Decided returns the number of submissions that were approved or rejected.
+func (h SubmissionHistory) Decided() int { return h.Approved + h.Rejected }
+
+// RejectionRatio returns the share of decided submissions that were rejected, 0 when none were decided.
+func (h SubmissionHistory) RejectionRatio() float64 {
+	if h.Decided() == 0 {
+		return 0
+	}
+	return float64(h.Rejected) / float64(h.Decided())
+}
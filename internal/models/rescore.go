@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Scoring logic tracked for automatic re-scoring, as stored in scoring_versions.kind.
+const (
+	ScoringVersionPrompts  = "prompts"
+	ScoringVersionDecision = "decision"
+)
+
+// StaleScoreQuery selects pending venues whose latest validation was made with scoring logic
+// that has since changed. A zero time means that logic has not changed since it was first
+// recorded.
+type StaleScoreQuery struct {
+	PromptsSince  time.Time // when the loaded prompt templates last changed
+	SystemPrompts []string  // versions of the system templates loaded now, e.g. "system.bakery@v2"
+	UserPrompts   []string  // versions of the user templates loaded now
+	DecisionSince time.Time // when the decision thresholds or rules last changed
+}
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// Processing run states.
+const (
+	RunStatusRunning   = "running"
+	RunStatusCompleted = "completed"
+)
+
+// Venue outcomes counted against a run.
+const (
+	RunOutcomeApproved     = "approved"
+	RunOutcomeRejected     = "rejected"
+	RunOutcomeManualReview = "manual_review"
+	RunOutcomeFailed       = "failed"
+)
+
+// ProcessingRun is one batch validation: the venues queued together by POST /validate or
+// /validate/batch. Outcome counts and OpenAICostUSD are filled in when the last venue finishes.
+type ProcessingRun struct {
+	ID               int64      `json:"id"`
+	TriggeredBy      *int       `json:"triggered_by,omitempty"` // admin ID; nil for token or system runs without one
+	Mode             string     `json:"mode"`
+	Filters          string     `json:"filters"` // JSON description of how venues were selected
+	VenueCount       int        `json:"venue_count"`
+	Status           string     `json:"status"`
+	StartedAt        time.Time  `json:"started_at"`
+	FinishedAt       *time.Time `json:"finished_at,omitempty"`
+	Approved         int        `json:"approved"`
+	Rejected         int        `json:"rejected"`
+	ManualReview     int        `json:"manual_review"`
+	Failed           int        `json:"failed"`
+	EstimatedCostUSD float64    `json:"estimated_cost_usd"` // from the run estimate at start
+	OpenAICostUSD    float64    `json:"openai_cost_usd"`    // OpenAI spend observed while the run was active
+}
+
+// Processed is the number of venues with an outcome so far.
+func (r ProcessingRun) Processed() int {
+	return r.Approved + r.Rejected + r.ManualReview + r.Failed
+}
+
+// Duration is how long the run took, or has been running.
+func (r ProcessingRun) Duration(now time.Time) time.Duration {
+	end := now
+	if r.FinishedAt != nil {
+		end = *r.FinishedAt
+	}
+	return end.Sub(r.StartedAt).Round(time.Second)
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// Lists a saved filter can belong to.
+const (
+	SavedFilterPending      = "pending"
+	SavedFilterManualReview = "manual_review"
+)
+
+// ManualReviewFilter narrows the manual review queue. Zero values do not filter.
+type ManualReviewFilter struct {
+	Search      string
+	PathPrefix  string // region path starting with this, e.g. "europe|germany"
+	MinScore    int    // latest validation score at least this
+	MaxScore    int    // latest validation score at most this
+	TrustedOnly bool   // submitters marked trusted
+	ClaimedBy   int    // venues this admin has an active claim on
+	Category    *int   // nil for any; 0 is a category of its own
+	Sort        string
+}
+
+// PendingVenueFilter selects pending venues to queue for validation again. Zero values do
+// not filter; score and prompt version bounds leave out venues never validated.
+type PendingVenueFilter struct {
+	PathPrefix     string    // region path starting with this, e.g. "europe|germany"
+	Category       *int      // nil for any; 0 is a category of its own
+	MinScore       int       // latest validation score at least this
+	MaxScore       int       // latest validation score at most this
+	SubmittedAfter time.Time // created at or after this
+	PromptVersion  string    // latest validation used this prompt version
+	Failed         bool      // latest validation failed (it carries a PipelineProgress)
+}
+
+// IsEmpty reports whether the filter matches every pending venue.
+func (f PendingVenueFilter) IsEmpty() bool {
+	return f.PathPrefix == "" && f.Category == nil && f.MinScore == 0 && f.MaxScore == 0 &&
+		f.SubmittedAfter.IsZero() && f.PromptVersion == "" && !f.Failed
+}
+
+// SavedFilter is an admin's named set of list filters, stored as the list page's query
+// string. The default filter of a list is applied when the admin opens it without one.
+type SavedFilter struct {
+	ID        int64     `json:"id"`
+	AdminID   int       `json:"admin_id"`
+	List      string    `json:"list"`
+	Name      string    `json:"name"`
+	Query     string    `json:"query"`
+	IsDefault bool      `json:"is_default"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,15 @@
+package models
+
+// SearchHit is a venue matched by full-text search, with the text it matched in.
+type SearchHit struct {
+	VenueID        int64   `json:"venue_id"`
+	Name           string  `json:"name"`
+	Location       string  `json:"location"`
+	Path           string  `json:"path,omitempty"`
+	Active         int     `json:"active"`
+	Relevance      float64 `json:"relevance"` // venue fields and AI notes relevance, summed
+	Description    string  `json:"-"`
+	AdditionalInfo string  `json:"-"`
+	AdminNote      string  `json:"-"`
+	AINotes        string  `json:"-"` // best matching validation notes; empty when none matched
+}
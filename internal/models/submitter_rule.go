@@ -0,0 +1,106 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Submitter rule kinds: what Value holds.
+const (
+	SubmitterRuleUser        = "user"         // member ID
+	SubmitterRuleEmailDomain = "email_domain" // matches the domain and its subdomains
+)
+
+// Submitter rule actions, from strongest to weakest.
+const (
+	SubmitterActionReject       = "reject"        // auto-reject without API calls
+	SubmitterActionManualReview = "manual_review" // send to an editor without API calls
+	SubmitterActionAlwaysAVA    = "always_ava"    // skip the submitter checks (points, trust, history)
+)
+
+// SubmitterRule is an admin-managed block or allow entry for venue submitters.
+type SubmitterRule struct {
+	ID        int64     `json:"id"`
+	Kind      string    `json:"kind"`
+	Value     string    `json:"value"`
+	Action    string    `json:"action"`
+	Note      string    `json:"note,omitempty"`
+	AdminID   int       `json:"admin_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ValidSubmitterRuleKind reports whether k is a known rule kind.
+func ValidSubmitterRuleKind(k string) bool {
+	return k == SubmitterRuleUser || k == SubmitterRuleEmailDomain
+}
+
+// ValidSubmitterAction reports whether a is a known rule action.
+func ValidSubmitterAction(a string) bool {
+	return submitterActionRank(a) > 0
+}
+
+func submitterActionRank(a string) int {
+	switch a {
+	case SubmitterActionReject:
+		return 3
+	case SubmitterActionManualReview:
+		return 2
+	case SubmitterActionAlwaysAVA:
+		return 1
+	}
+	return 0
+}
+
+// NormalizeSubmitterRuleValue returns value in its stored form: a member ID without
+// leading zeros, or a lower-case domain without a leading "@". ok is false when value
+// does not fit kind.
+func NormalizeSubmitterRuleValue(kind, value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	switch kind {
+	case SubmitterRuleUser:
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || id == 0 {
+			return "", false
+		}
+		return strconv.FormatUint(id, 10), true
+	case SubmitterRuleEmailDomain:
+		d := strings.Trim(strings.ToLower(strings.TrimPrefix(value, "@")), ".")
+		if d == "" || !strings.Contains(d, ".") || strings.ContainsAny(d, "@ /") {
+			return "", false
+		}
+		return d, true
+	}
+	return "", false
+}
+
+// Matches reports whether the rule applies to user.
+func (r SubmitterRule) Matches(user User) bool {
+	switch r.Kind {
+	case SubmitterRuleUser:
+		return user.ID != 0 && r.Value == strconv.FormatUint(uint64(user.ID), 10)
+	case SubmitterRuleEmailDomain:
+		at := strings.LastIndexByte(user.Email, '@')
+		if at < 0 {
+			return false
+		}
+		d := strings.ToLower(strings.TrimSpace(user.Email[at+1:]))
+		return d == r.Value || strings.HasSuffix(d, "."+r.Value)
+	}
+	return false
+}
+
+// MatchSubmitterRule returns the strongest rule matching user, or nil. A block wins over an
+// allow entry, so a partner domain cannot shield a blocked member.
+func MatchSubmitterRule(rules []SubmitterRule, user User) *SubmitterRule {
+	var best *SubmitterRule
+	for i := range rules {
+		if !rules[i].Matches(user) {
+			continue
+		}
+		if best == nil || submitterActionRank(rules[i].Action) > submitterActionRank(best.Action) {
+			best = &rules[i]
+		}
+	}
+	return best
+}
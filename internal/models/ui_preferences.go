@@ -0,0 +1,55 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Admin UI themes. ThemeSystem follows the browser's light or dark setting.
+const (
+	ThemeLight  = "light"
+	ThemeDark   = "dark"
+	ThemeSystem = "system"
+)
+
+// Bounds of UIPreferences.RowsPerPage.
+const (
+	MinRowsPerPage = 10
+	MaxRowsPerPage = 200
+)
+
+// ManualReviewSorts are the sort orders of the manual review list, as its ?sort= values.
+var ManualReviewSorts = []string{"created_at", "last_updated", "venue_id_asc", "venue_id_desc", "score_desc", "score_asc"}
+
+// UIPreferences are an admin's settings for the admin UI.
+type UIPreferences struct {
+	AdminID       int       `json:"admin_id"`
+	Theme         string    `json:"theme"`
+	RowsPerPage   int       `json:"rows_per_page"` // venues per page of the pending and manual review lists
+	DefaultSort   string    `json:"default_sort"`  // manual review sort when the URL has none
+	CompactTables bool      `json:"compact_tables"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// DefaultUIPreferences are the settings of an admin who never saved any.
+func DefaultUIPreferences() UIPreferences {
+	return UIPreferences{Theme: ThemeLight, RowsPerPage: 50, DefaultSort: "last_updated"}
+}
+
+// Validate reports the first setting out of range.
+func (p UIPreferences) Validate() error {
+	switch p.Theme {
+	case ThemeLight, ThemeDark, ThemeSystem:
+	default:
+		return fmt.Errorf("theme must be %s, %s or %s", ThemeLight, ThemeDark, ThemeSystem)
+	}
+	if p.RowsPerPage < MinRowsPerPage || p.RowsPerPage > MaxRowsPerPage {
+		return fmt.Errorf("rows_per_page must be between %d and %d", MinRowsPerPage, MaxRowsPerPage)
+	}
+	for _, s := range ManualReviewSorts {
+		if p.DefaultSort == s {
+			return nil
+		}
+	}
+	return fmt.Errorf("default_sort %q is not a manual review sort", p.DefaultSort)
+}
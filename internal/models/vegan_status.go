@@ -0,0 +1,76 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Vegan status values of a classification, matching the labels of VeganStatusLabel in lower case.
+const (
+	VeganStatusVegan      = "vegan"
+	VeganStatusVegetarian = "vegetarian"
+	VeganStatusVegOptions = "veg-options"
+	VeganStatusUnknown    = "unknown" // the sources say too little to tell
+)
+
+// Evidence sources of a vegan status classification.
+const (
+	VeganSourceDescription = "description"
+	VeganSourceWebsite     = "website"
+	VeganSourceReview      = "review"
+)
+
+// PlaceReview is one Google review of a place.
+type PlaceReview struct {
+	Author string  `json:"author,omitempty"`
+	Rating float64 `json:"rating"`
+	Text   string  `json:"text"`
+	When   string  `json:"when,omitempty"` // relative, e.g. "2 months ago"
+}
+
+// VeganSources is the text a vegan status classification reads. Website is an excerpt of the
+// venue's own site; both it and Reviews may be empty.
+type VeganSources struct {
+	Description string
+	Website     string
+	Reviews     []PlaceReview
+}
+
+// VeganEvidence is a quote from one of the sources that supports the classified status.
+// Review is the 1-based number of the quoted review in VeganSources.Reviews.
+type VeganEvidence struct {
+	Source string `json:"source"` // description|website|review
+	Review int    `json:"review,omitempty"`
+	Quote  string `json:"quote"`
+}
+
+// SourceLabel names the quoted source for display, e.g. "Review 2".
+func (e VeganEvidence) SourceLabel() string {
+	switch e.Source {
+	case VeganSourceReview:
+		return fmt.Sprintf("Review %d", e.Review)
+	case VeganSourceWebsite:
+		return "Website"
+	default:
+		return "Description"
+	}
+}
+
+// VeganStatusAssessment is the vegan status inferred from a venue's description, website and
+// Google reviews, with the quotes it rests on, against the submitted vegan/vegonly flags.
+// Stored under ai_output_data["vegan_status"].
+type VeganStatusAssessment struct {
+	Status     string          `json:"status"`     // vegan|vegetarian|veg-options|unknown
+	Confidence string          `json:"confidence"` // high|medium|low
+	Evidence   []VeganEvidence `json:"evidence,omitempty"`
+	Notes      string          `json:"notes,omitempty"`
+	Submitted  string          `json:"submitted"`
+	Disagrees  bool            `json:"disagrees"` // a known Status that differs from Submitted
+	Reviews    int             `json:"reviews"`   // Google reviews read
+	CostUSD    float64         `json:"cost_usd"`
+}
+
+// SubmittedVeganStatus is the status the venue's vegan/vegonly flags stand for.
+func SubmittedVeganStatus(v Venue) string {
+	return strings.ToLower(VeganStatusLabel(v.EntryType, v.VegOnly, v.Vegan))
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -70,6 +71,8 @@ type ValidationResult struct {
 	ScoreBreakdown map[string]int `json:"score_breakdown"`
 	AIOutputData   *string        `json:"ai_output_data,omitempty"`
 	PromptVersion  *string        `json:"prompt_version,omitempty"`
+	RunID          *int64         `json:"run_id,omitempty"` // processing run that produced it, if any
+	TokenUsage     *TokenUsage    `json:"token_usage,omitempty"`
 
 	// Extended validation fields (parsed from ai_output_data JSON)
 	DescriptionReview *DescriptionReview `json:"description_review,omitempty"`
@@ -112,26 +115,55 @@ type PathValidation struct {
 }
 
 type ValidationDetails struct {
-	ScoreBreakdown     ScoreBreakdown `json:"score_breakdown"`
-	GooglePlaceFound   bool           `json:"google_place_found"`
-	DistanceMeters     float64        `json:"distance_meters"`
-	Conflicts          []DataConflict `json:"conflicts,omitempty"`
-	AutoDecisionReason string         `json:"auto_decision_reason"`
-	ProcessingTimeMs   int64          `json:"processing_time_ms"`
-	SuggestedPath      *string        `json:"suggested_path,omitempty"` // Generated path from Google Places address
+	ScoreBreakdown     ScoreBreakdown         `json:"score_breakdown"`
+	GooglePlaceFound   bool                   `json:"google_place_found"`
+	DistanceMeters     float64                `json:"distance_meters"`
+	Conflicts          []DataConflict         `json:"conflicts,omitempty"`
+	AutoDecisionReason string                 `json:"auto_decision_reason"`
+	ProcessingTimeMs   int64                  `json:"processing_time_ms"`
+	SuggestedPath      *string                `json:"suggested_path,omitempty"` // Generated path from Google Places address
+	Social             []SocialCheck          `json:"social,omitempty"`         // Facebook/Instagram profile checks
+	Phone              *PhoneCheck            `json:"phone,omitempty"`          // E.164 normalisation and country check
+	Geofence           *GeofenceCheck         `json:"geofence,omitempty"`       // Coordinates vs path region
+	PlaceMatch         *PlaceMatch            `json:"place_match,omitempty"`    // How the Google place was chosen
+	Category           *CategorySuggestion    `json:"category,omitempty"`       // Category suggested from Google types and text
+	VeganStatus        *VeganStatusAssessment `json:"vegan_status,omitempty"`   // Status inferred from description, website and reviews
 }
 
+// PlaceMatch records how confidently the Google place was picked among the search candidates.
+type PlaceMatch struct {
+	Confidence string  `json:"confidence"`
+	Reason     string  `json:"reason,omitempty"`
+	Candidates int     `json:"candidates"`
+	BestScore  float64 `json:"best_score,omitempty"`
+}
+
+// GeofenceCheck records whether the venue coordinates fall inside the region named by its path.
+// Source is "google_place" (components of the matched place) or "reverse_geocode".
+type GeofenceCheck struct {
+	Source       string   `json:"source"`
+	PathCountry  string   `json:"path_country,omitempty"`
+	PlaceCountry string   `json:"place_country,omitempty"`
+	CountryMatch bool     `json:"country_match"`
+	RegionMatch  *bool    `json:"region_match,omitempty"` // nil when there was no region to compare
+	PathRegions  []string `json:"path_regions,omitempty"`
+	PlaceRegions []string `json:"place_regions,omitempty"`
+}
+
+// ScoreBreakdown is the Google match score per field. Point ranges below are the
+// default weight profile; SCORE_WEIGHTS_FILE can change them.
 type ScoreBreakdown struct {
-	VenueNameMatch      int `json:"venue_name_match"`     // 0-25 points
-	AddressAccuracy     int `json:"address_accuracy"`     // 0-20 points
-	GeolocationAccuracy int `json:"geolocation_accuracy"` // 0-15 points
-	PhoneVerification   int `json:"phone_verification"`   // 0-10 points
-	BusinessHours       int `json:"business_hours"`       // 0-10 points
-	WebsiteVerification int `json:"website_verification"` // 0-5 points
-	BusinessStatus      int `json:"business_status"`      // 0-5 points
-	PostalCode          int `json:"postal_code"`          // 0-5 points
-	VeganRelevance      int `json:"vegan_relevance"`      // 0-5 points
-	Total               int `json:"total"`                // Sum of all scores
+	VenueNameMatch      int    `json:"venue_name_match"`         // 0-25 points
+	AddressAccuracy     int    `json:"address_accuracy"`         // 0-20 points
+	GeolocationAccuracy int    `json:"geolocation_accuracy"`     // 0-15 points
+	PhoneVerification   int    `json:"phone_verification"`       // 0-10 points
+	BusinessHours       int    `json:"business_hours"`           // 0-10 points
+	WebsiteVerification int    `json:"website_verification"`     // 0-5 points
+	BusinessStatus      int    `json:"business_status"`          // 0-5 points
+	PostalCode          int    `json:"postal_code"`              // 0-5 points
+	VeganRelevance      int    `json:"vegan_relevance"`          // 0-5 points
+	Total               int    `json:"total"`                    // Sum of all scores
+	WeightProfile       string `json:"weight_profile,omitempty"` // Weights table that produced the scores
 }
 
 type DataConflict struct {
@@ -154,7 +186,167 @@ type GooglePlaceData struct {
 	Types             []string            `json:"types"`
 	Rating            float64             `json:"rating"`
 	UserRatingsTotal  int                 `json:"user_ratings_total"`
+	Photos            []GooglePhoto       `json:"photos,omitempty"`
 	FetchedAt         time.Time           `json:"fetched_at"`
+	// Candidates are the ranked search results the place was picked from, best first.
+	// Empty when the place was pinned by a reviewer.
+	Candidates      []PlaceCandidate `json:"candidates,omitempty"`
+	MatchConfidence string           `json:"match_confidence,omitempty"` // high, low or confirmed
+}
+
+// Place match confidence values.
+const (
+	MatchConfidenceHigh      = "high"
+	MatchConfidenceLow       = "low"
+	MatchConfidenceConfirmed = "confirmed" // picked by a reviewer
+)
+
+// PlaceCandidate is one Google search result scored against the submitted venue. Scores
+// are in [0,1]; DistanceMeters is nil when the venue has no coordinates.
+type PlaceCandidate struct {
+	PlaceID        string   `json:"place_id"`
+	Name           string   `json:"name"`
+	Address        string   `json:"address,omitempty"`
+	Lat            float64  `json:"lat"`
+	Lng            float64  `json:"lng"`
+	Types          []string `json:"types,omitempty"`
+	NameScore      float64  `json:"name_score"`
+	DistanceMeters *float64 `json:"distance_meters,omitempty"`
+	TypeScore      float64  `json:"type_score"`
+	Score          float64  `json:"score"`
+}
+
+// GooglePhoto is a Place photo reference; image bytes are fetched separately and never stored.
+type GooglePhoto struct {
+	Reference    string   `json:"photo_reference"`
+	Width        int      `json:"width"`
+	Height       int      `json:"height"`
+	Attributions []string `json:"html_attributions,omitempty"`
+}
+
+// PlacesSKUUsage is the estimated Google Places spend on one billing SKU since start.
+type PlacesSKUUsage struct {
+	SKU      string  `json:"sku"`
+	Requests int64   `json:"requests"`
+	CostUSD  float64 `json:"cost_usd"`
+}
+
+// GoogleKeyUsage is the use of one Google API key since start, for attributing the bill.
+type GoogleKeyUsage struct {
+	Name          string     `json:"name"`
+	Requests      int64      `json:"requests"`
+	RequestsToday int64      `json:"requests_today"` // Pacific day, as Google counts quota
+	DailyQuota    int64      `json:"daily_quota,omitempty"`
+	RPS           int        `json:"rps,omitempty"`
+	CostUSD       float64    `json:"cost_usd"`
+	Available     bool       `json:"available"`
+	BenchedUntil  *time.Time `json:"benched_until,omitempty"`
+	BenchReason   string     `json:"bench_reason,omitempty"`
+}
+
+// WebsiteCheck is the result of fetching a venue's own website. Evidence holds short
+// text snippets around matched keywords so reviewers can see what was found.
+type WebsiteCheck struct {
+	URL        string         `json:"url"`
+	FinalURL   string         `json:"final_url,omitempty"`
+	StatusCode int            `json:"status_code,omitempty"`
+	Live       bool           `json:"live"`
+	Parked     bool           `json:"parked"`
+	HasMenu    bool           `json:"has_menu"`
+	Keywords   map[string]int `json:"keywords,omitempty"`
+	Evidence   []string       `json:"evidence,omitempty"`
+	Score      int            `json:"score"` // contribution to the venue score (website_verification)
+	Error      string         `json:"error,omitempty"`
+	CheckedAt  time.Time      `json:"checked_at"`
+	// Excerpt is the start of the page text for the vegan status check; not stored
+	Excerpt string `json:"-"`
+}
+
+// URLThreat is a submitted link that a URL scanner flagged.
+type URLThreat struct {
+	URL        string `json:"url"`
+	ThreatType string `json:"threat_type"` // e.g. MALWARE, SOCIAL_ENGINEERING, BLOCKLISTED
+	Source     string `json:"source"`      // safe_browsing|blocklist
+}
+
+// TextTranslation is one free-text venue field translated to English before scoring.
+type TextTranslation struct {
+	Original   string `json:"original"`
+	Translated string `json:"translated"`
+	SourceLang string `json:"source_lang"`
+}
+
+// VenueTranslation holds the English versions the AI scored instead of the submitted text.
+// Stored under ai_output_data["translation"]; the venue itself keeps the original text.
+type VenueTranslation struct {
+	Provider       string           `json:"provider"`
+	AdditionalInfo *TextTranslation `json:"additionalinfo,omitempty"`
+	VDetails       *TextTranslation `json:"vdetails,omitempty"`
+}
+
+// StageTimings records how long each processing stage took for one validation, in
+// milliseconds. Stored under ai_output_data["timings"]; stages that did not run are zero.
+type StageTimings struct {
+	GoogleMs        int64 `json:"google_ms"`
+	GoogleCached    bool  `json:"google_cached,omitempty"` // Google data reused from an earlier validation
+	ScoringMs       int64 `json:"scoring_ms,omitempty"`
+	QualityReviewMs int64 `json:"quality_review_ms,omitempty"`
+	DecisionMs      int64 `json:"decision_ms,omitempty"`
+	TotalMs         int64 `json:"total_ms"` // whole validation, rate limiter waits included
+	// Stages left out to meet the deadline of a single-venue review, e.g. quality_review
+	Skipped []string `json:"skipped,omitempty"`
+}
+
+// TokenUsage is what an AI scoring call cost in tokens, repair requests included. Estimated
+// is the pre-call estimate of the prompt; Truncated names the venue fields cut to fit the
+// context budget.
+type TokenUsage struct {
+	PromptTokens     int      `json:"prompt_tokens"`
+	CompletionTokens int      `json:"completion_tokens"`
+	Estimated        int      `json:"estimated_prompt_tokens"`
+	Truncated        []string `json:"truncated,omitempty"`
+}
+
+// Social profile check statuses.
+const (
+	SocialOK        = "ok"         // profile page loaded
+	SocialNotFound  = "not_found"  // platform says the profile does not exist
+	SocialLoginWall = "login_wall" // platform hid the page behind a login; existence unknown
+	SocialError     = "error"      // network or unexpected response
+)
+
+// SocialCheck is the result of resolving a venue's Facebook or Instagram profile.
+// Active is nil when the page exposed no post dates (common behind login walls).
+type SocialCheck struct {
+	Platform    string     `json:"platform"` // "facebook" or "instagram"
+	URL         string     `json:"url"`
+	Handle      string     `json:"handle,omitempty"`
+	Status      string     `json:"status"`
+	StatusCode  int        `json:"status_code,omitempty"`
+	NameMatch   float64    `json:"name_match"` // handle vs venue name similarity, 0-1
+	NameMatches bool       `json:"name_matches"`
+	LastPostAt  *time.Time `json:"last_post_at,omitempty"`
+	Active      *bool      `json:"active,omitempty"`
+	Error       string     `json:"error,omitempty"`
+}
+
+// Inactive reports whether post dates were found and none are recent.
+func (c SocialCheck) Inactive() bool { return c.Active != nil && !*c.Active }
+
+// VenuePhoto is a downloaded image passed to the vision check.
+type VenuePhoto struct {
+	Data        []byte
+	ContentType string
+}
+
+// PhotoAssessment is the vision model's read of a venue's Google photos.
+type PhotoAssessment struct {
+	FoodVenue     bool    `json:"food_venue"`
+	VeganSignage  bool    `json:"vegan_signage"`
+	Confidence    float64 `json:"confidence"`
+	Notes         string  `json:"notes"`
+	PhotosChecked int     `json:"photos_checked"`
+	CostUSD       float64 `json:"cost_usd"`
 }
 
 type GoogleGeometry struct {
@@ -207,6 +399,30 @@ type User struct {
 	AmbassadorLevel    *int    `json:"ambassador_level,omitempty"`
 	AmbassadorPoints   *int    `json:"ambassador_points,omitempty"`
 	AmbassadorRegion   *string `json:"ambassador_region,omitempty"`
+	// History is loaded by the processor when reputation is enabled; nil otherwise
+	History *SubmissionHistory `json:"submission_history,omitempty"`
+}
+
+// venueCategoryNames maps HappyCow category IDs to the display names used in prompts.
+var venueCategoryNames = map[int]string{
+	1:  "Restaurant",
+	2:  "Health Food Store",
+	3:  "Bakery",
+	4:  "Caterer",
+	5:  "Juice Bar",
+	6:  "Market/Co-op",
+	7:  "Ice Cream Shop",
+	8:  "Organization",
+	9:  "B&B/Hotel",
+	10: "Food Truck",
+}
+
+// VenueCategoryName returns the display name of a category ID, "Restaurant" for unknown IDs.
+func VenueCategoryName(category int) string {
+	if name, ok := venueCategoryNames[category]; ok {
+		return name
+	}
+	return "Restaurant" // Default fallback
 }
 
 // VenueWithUser combines venue and user information
@@ -229,6 +445,7 @@ type ValidationHistory struct {
 	ScoreBreakdown   map[string]int `json:"score_breakdown"`
 	AIOutputData     *string        `json:"ai_output_data,omitempty"`
 	PromptVersion    *string        `json:"prompt_version,omitempty"`
+	RunID            *int64         `json:"run_id,omitempty"`
 
 	// Google Places API data
 	GooglePlaceID    *string          `json:"google_place_id,omitempty"`
@@ -239,6 +456,36 @@ type ValidationHistory struct {
 	VenueName   string    `json:"venue_name,omitempty"`
 }
 
+// Timings returns the stage timings stored with this validation, or nil for rows written
+// before timings were recorded.
+func (h ValidationHistory) Timings() *StageTimings {
+	if h.AIOutputData == nil {
+		return nil
+	}
+	var out struct {
+		Timings *StageTimings `json:"timings"`
+	}
+	if err := json.Unmarshal([]byte(*h.AIOutputData), &out); err != nil {
+		return nil
+	}
+	return out.Timings
+}
+
+// TokenUsage returns the scoring token counts stored with this validation, or nil when no
+// AI call was made or the row predates token tracking.
+func (h ValidationHistory) TokenUsage() *TokenUsage {
+	if h.AIOutputData == nil {
+		return nil
+	}
+	var out struct {
+		TokenUsage *TokenUsage `json:"token_usage"`
+	}
+	if err := json.Unmarshal([]byte(*h.AIOutputData), &out); err != nil {
+		return nil
+	}
+	return out.TokenUsage
+}
+
 // VenueStats contains processing statistics
 type VenueStats struct {
 	Pending  int `json:"pending"`
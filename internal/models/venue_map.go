@@ -0,0 +1,20 @@
+package models
+
+// Review states of a venue on the map.
+const (
+	MapStatusNew          = "new"           // waiting for its first AI review
+	MapStatusManualReview = "manual_review" // reviewed by AI, waiting for a human decision
+)
+
+// VenueMapPoint is a pending venue with coordinates, as placed on the review map.
+type VenueMapPoint struct {
+	VenueID  int64
+	Name     string
+	Path     string
+	Location string
+	Lat      float64
+	Lng      float64
+	Status   string
+	Score    *int   // latest AI score; nil before the first review
+	AIStatus string // latest AI validation status; empty before the first review
+}
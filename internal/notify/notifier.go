@@ -0,0 +1,231 @@
+// Package notify emails venue submitters about editorial decisions.
+// Delivery is opt-in (NOTIFY_ENABLED) and every attempt is recorded in the
+// venue audit trail so editors can see whether the member was told.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/mail"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+//go:embed templates/*/*.txt.tmpl
+var templatesFS embed.FS
+
+// Audit statuses written to venue_validation_audit_logs.
+const (
+	AuditNotified     = "notified"
+	AuditNotifyFailed = "notify_failed"
+)
+
+var (
+	mSent   = metrics.Default.Counter("notify_emails_sent_total", "Decision emails delivered to submitters")
+	mFailed = metrics.Default.Counter("notify_emails_failed_total", "Decision emails that failed to send")
+	mSkip   = metrics.Default.Counter("notify_emails_skipped_total", "Decision emails skipped (disabled or no address)")
+)
+
+// Config controls delivery. Zero value is disabled.
+type Config struct {
+	Enabled     bool
+	From        string
+	DefaultLang string
+	SendTimeout time.Duration
+}
+
+// Message is a rendered email ready for a Sender.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender abstracts the transport so tests and dry environments can swap it.
+type Sender interface {
+	Send(ctx context.Context, from string, msg Message) error
+}
+
+// Decision describes what happened to a venue; Note is the admin note shown to the member.
+type Decision struct {
+	VenueID int64
+	AdminID int
+	Status  string // "approved" or "rejected"
+	Note    string
+	Venue   models.Venue
+	User    models.User
+}
+
+// Notifier renders per-language templates and sends them.
+type Notifier struct {
+	cfg    Config
+	sender Sender
+	audit  domain.AuditStore
+	tmpl   map[string]map[string]*template.Template // lang -> status -> template
+}
+
+// New builds a Notifier from the embedded templates. audit may be nil.
+func New(cfg Config, sender Sender, audit domain.AuditStore) (*Notifier, error) {
+	if cfg.DefaultLang == "" {
+		cfg.DefaultLang = "en"
+	}
+	if cfg.SendTimeout <= 0 {
+		cfg.SendTimeout = 20 * time.Second
+	}
+	tm, err := loadTemplates(templatesFS)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := tm[cfg.DefaultLang]; !ok {
+		return nil, errs.NewValidation("notify.New", fmt.Sprintf("no templates for default language %q", cfg.DefaultLang), nil)
+	}
+	return &Notifier{cfg: cfg, sender: sender, audit: audit, tmpl: tm}, nil
+}
+
+// loadTemplates parses templates/<lang>/<status>.txt.tmpl. Each file defines
+// "subject" and "body", so files are parsed separately to keep names from colliding.
+func loadTemplates(fsys fs.FS) (map[string]map[string]*template.Template, error) {
+	files, err := fs.Glob(fsys, "templates/*/*.txt.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("list notify templates: %w", err)
+	}
+	out := map[string]map[string]*template.Template{}
+	for _, f := range files {
+		lang := path.Base(path.Dir(f))
+		status := strings.TrimSuffix(path.Base(f), ".txt.tmpl")
+		t, err := template.ParseFS(fsys, f)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", f, err)
+		}
+		if out[lang] == nil {
+			out[lang] = map[string]*template.Template{}
+		}
+		out[lang][status] = t
+	}
+	return out, nil
+}
+
+// Enabled reports whether emails will be attempted.
+func (n *Notifier) Enabled() bool { return n != nil && n.cfg.Enabled && n.sender != nil }
+
+// NotifyDecision renders and sends the decision email, then records the attempt.
+// Errors are returned for logging only; callers should not fail the admin action on them.
+func (n *Notifier) NotifyDecision(ctx context.Context, d Decision) error {
+	if !n.Enabled() {
+		mSkip.Inc(1)
+		return nil
+	}
+	to := strings.TrimSpace(d.User.Email)
+	if _, err := mail.ParseAddress(to); to == "" || err != nil {
+		mSkip.Inc(1)
+		return nil
+	}
+
+	lang := n.langFor(d.Venue)
+	msg, err := n.Render(lang, d)
+	if err != nil {
+		mFailed.Inc(1)
+		n.record(ctx, d, AuditNotifyFailed, fmt.Sprintf("email %s/%s render failed: %v", d.Status, lang, err))
+		return err
+	}
+	msg.To = to
+
+	sctx, cancel := context.WithTimeout(ctx, n.cfg.SendTimeout)
+	defer cancel()
+	if err := n.sender.Send(sctx, n.cfg.From, msg); err != nil {
+		mFailed.Inc(1)
+		n.record(ctx, d, AuditNotifyFailed, fmt.Sprintf("email %s/%s to %s failed: %v", d.Status, lang, maskEmail(to), err))
+		return errs.NewExternal("notify.NotifyDecision", "smtp", "send failed", err)
+	}
+	mSent.Inc(1)
+	n.record(ctx, d, AuditNotified, fmt.Sprintf("email %s/%s sent to %s", d.Status, lang, maskEmail(to)))
+	return nil
+}
+
+// Render produces subject/body for a decision in the given language,
+// falling back to the default language when it has no templates.
+func (n *Notifier) Render(lang string, d Decision) (Message, error) {
+	set, ok := n.tmpl[lang]
+	if !ok {
+		set = n.tmpl[n.cfg.DefaultLang]
+	}
+	t, ok := set[d.Status]
+	if !ok {
+		return Message{}, errs.NewValidation("notify.Render", fmt.Sprintf("no template for status %q", d.Status), nil)
+	}
+	data := struct {
+		VenueName string
+		Username  string
+		Note      string
+	}{
+		VenueName: d.Venue.Name,
+		Username:  d.User.Username,
+		Note:      strings.TrimSpace(d.Note),
+	}
+
+	var subj, body bytes.Buffer
+	if err := t.ExecuteTemplate(&subj, "subject", data); err != nil {
+		return Message{}, err
+	}
+	if err := t.ExecuteTemplate(&body, "body", data); err != nil {
+		return Message{}, err
+	}
+	return Message{Subject: strings.TrimSpace(subj.String()), Body: strings.TrimSpace(body.String()) + "\n"}, nil
+}
+
+// record writes the delivery attempt to the audit trail (best-effort).
+func (n *Notifier) record(ctx context.Context, d Decision, status, reason string) {
+	if n.audit == nil {
+		return
+	}
+	var adminID *int
+	if d.AdminID > 0 {
+		a := d.AdminID
+		adminID = &a
+	}
+	if err := n.audit.CreateAuditLogCtx(ctx, domain.NewAuditLog(d.VenueID, nil, adminID, status, &reason)); err != nil {
+		log.Printf("notify: failed to audit delivery for venue %d: %v", d.VenueID, err)
+	}
+}
+
+// pathLang maps the country segment of a venue path to a template language.
+// Members have no stored language yet, so the venue's country is the best signal we have.
+var pathLang = map[string]string{
+	"germany": "de", "austria": "de", "switzerland": "de", "liechtenstein": "de",
+	"spain": "es", "mexico": "es", "argentina": "es", "chile": "es", "colombia": "es", "peru": "es", "uruguay": "es",
+	"france": "fr", "belgium": "fr", "luxembourg": "fr", "monaco": "fr",
+}
+
+func (n *Notifier) langFor(v models.Venue) string {
+	if v.Path != nil {
+		parts := strings.Split(strings.ToLower(*v.Path), "|")
+		if len(parts) > 1 {
+			if l, ok := pathLang[parts[1]]; ok {
+				if _, has := n.tmpl[l]; has {
+					return l
+				}
+			}
+		}
+	}
+	return n.cfg.DefaultLang
+}
+
+// maskEmail keeps audit rows free of full addresses: jane@example.com -> j***@example.com
+func maskEmail(s string) string {
+	at := strings.LastIndex(s, "@")
+	if at <= 0 {
+		return "***"
+	}
+	return s[:1] + "***" + s[at:]
+}
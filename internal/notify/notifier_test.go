@@ -0,0 +1,112 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+)
+
+type fakeSender struct {
+	sent []Message
+	err  error
+}
+
+func (f *fakeSender) Send(_ context.Context, _ string, msg Message) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+// fakeAudit records created audit rows; other audit calls panic.
+func fakeAudit(logs *[]*domain.VenueValidationAuditLog) *testutil.AuditStore {
+	return &testutil.AuditStore{
+		CreateAuditLogCtxFunc: func(_ context.Context, l *domain.VenueValidationAuditLog) error {
+			*logs = append(*logs, l)
+			return nil
+		},
+	}
+}
+
+func sptr(s string) *string { return &s }
+
+func TestNotifyDecision(t *testing.T) {
+	tests := []struct {
+		name        string
+		enabled     bool
+		email       string
+		path        *string
+		status      string
+		sendErr     error
+		wantSent    bool
+		wantAudit   string
+		wantSubject string
+		wantBody    string
+	}{
+		{"disabled", false, "a@example.com", nil, "approved", nil, false, "", "", ""},
+		{"no email", true, "", nil, "approved", nil, false, "", "", ""},
+		{"approved en", true, "a@example.com", sptr("europe|uk|london"), "approved", nil, true, AuditNotified, "now live", "Great coffee"},
+		{"rejected de", true, "a@example.com", sptr("europe|germany|berlin"), "rejected", nil, true, AuditNotified, "Eintrag", "Great coffee"},
+		{"smtp failure", true, "a@example.com", nil, "rejected", errors.New("relay down"), false, AuditNotifyFailed, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &fakeSender{err: tt.sendErr}
+			var logs []*domain.VenueValidationAuditLog
+			n, err := New(Config{Enabled: tt.enabled, From: "noreply@example.com"}, s, fakeAudit(&logs))
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			err = n.NotifyDecision(context.Background(), Decision{
+				VenueID: 9, AdminID: 3, Status: tt.status, Note: "Great coffee",
+				Venue: models.Venue{Name: "Leaf", Path: tt.path},
+				User:  models.User{Username: "jane", Email: tt.email},
+			})
+			if (err != nil) != (tt.sendErr != nil) {
+				t.Fatalf("err=%v", err)
+			}
+			if got := len(s.sent) == 1; got != tt.wantSent {
+				t.Fatalf("sent=%d want %v", len(s.sent), tt.wantSent)
+			}
+			if tt.wantAudit == "" {
+				if len(logs) != 0 {
+					t.Fatalf("unexpected audit rows: %d", len(logs))
+				}
+				return
+			}
+			if len(logs) != 1 || logs[0].Status != tt.wantAudit {
+				t.Fatalf("audit=%+v want %s", logs, tt.wantAudit)
+			}
+			if strings.Contains(*logs[0].Reason, tt.email) {
+				t.Fatalf("audit reason leaks address: %s", *logs[0].Reason)
+			}
+			if tt.wantSent {
+				m := s.sent[0]
+				if m.To != tt.email || !strings.Contains(m.Subject, tt.wantSubject) || !strings.Contains(m.Body, tt.wantBody) {
+					t.Fatalf("unexpected message: %+v", m)
+				}
+			}
+		})
+	}
+}
+
+func TestTemplatesCoverAllLanguages(t *testing.T) {
+	n, err := New(Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	for lang := range n.tmpl {
+		for _, status := range []string{"approved", "rejected"} {
+			m, err := n.Render(lang, Decision{Status: status, Venue: models.Venue{Name: "Leaf"}})
+			if err != nil || m.Subject == "" || m.Body == "" {
+				t.Fatalf("%s/%s: err=%v msg=%+v", lang, status, err, m)
+			}
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package notify
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildMessage_LineEndings(t *testing.T) {
+	for _, body := range []string{"Hello,\nyour venue was approved.\n", "Hello,\r\nyour venue was approved.\r\n"} {
+		raw := string(buildMessage("ava@example.com", Message{To: "a@example.com", Subject: "Approved", Body: body}))
+		_, got, ok := strings.Cut(raw, "\r\n\r\n")
+		if !ok || got != "Hello,\r\nyour venue was approved.\r\n" {
+			t.Errorf("body %q: sent %q", body, got)
+		}
+	}
+}
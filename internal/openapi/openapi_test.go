@@ -0,0 +1,196 @@
+package openapi
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// routeVar matches a mux path variable, with or without a pattern: {id} or {id:[0-9]+}.
+var routeVar = regexp.MustCompile(`\{([a-zA-Z_]+)(:[^}]*)?\}`)
+
+// registeredRoutes returns "METHOD /path" for every router.Handle(Func)(path, …).Methods(…)
+// call in main.go, with path variable patterns dropped.
+func registeredRoutes(t *testing.T) map[string]bool {
+	t.Helper()
+	f, err := parser.ParseFile(token.NewFileSet(), "../../main.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	routes := map[string]bool{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Methods" {
+			return true
+		}
+		inner, ok := sel.X.(*ast.CallExpr)
+		if !ok || len(inner.Args) == 0 {
+			return true
+		}
+		handle, ok := inner.Fun.(*ast.SelectorExpr)
+		if !ok || (handle.Sel.Name != "HandleFunc" && handle.Sel.Name != "Handle") {
+			return true
+		}
+		lit, ok := inner.Args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		path, _ := strconv.Unquote(lit.Value)
+		path = routeVar.ReplaceAllString(path, "{$1}")
+		for _, m := range call.Args {
+			if ml, ok := m.(*ast.BasicLit); ok && ml.Kind == token.STRING {
+				method, _ := strconv.Unquote(ml.Value)
+				routes[method+" "+path] = true
+			}
+		}
+		return true
+	})
+	if len(routes) == 0 {
+		t.Fatal("found no routes in main.go")
+	}
+	return routes
+}
+
+var httpMethods = []string{"get", "put", "post", "delete", "patch"}
+
+func specOperations(t *testing.T) (map[string]map[string]interface{}, map[string]interface{}) {
+	t.Helper()
+	spec, err := Spec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ops := map[string]map[string]interface{}{}
+	for path, item := range spec["paths"].(map[string]interface{}) {
+		for _, m := range httpMethods {
+			if op, ok := item.(map[string]interface{})[m]; ok {
+				ops[strings.ToUpper(m)+" "+path] = op.(map[string]interface{})
+			}
+		}
+	}
+	return ops, spec
+}
+
+func TestSpecCoversAPIRoutes(t *testing.T) {
+	routes := registeredRoutes(t)
+	ops, _ := specOperations(t)
+
+	var missing, unknown []string
+	for r := range routes {
+		path := r[strings.Index(r, " ")+1:]
+		if strings.HasPrefix(path, "/api/") && path != "/api/docs" && ops[r] == nil {
+			missing = append(missing, r)
+		}
+	}
+	for op := range ops {
+		if !routes[op] {
+			unknown = append(unknown, op)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(unknown)
+	for _, r := range missing {
+		t.Errorf("%s is registered in main.go but not described in openapi.yaml", r)
+	}
+	for _, op := range unknown {
+		t.Errorf("openapi.yaml describes %s, which main.go does not register", op)
+	}
+}
+
+func TestSpecIsConsistent(t *testing.T) {
+	ops, spec := specOperations(t)
+	components := spec["components"].(map[string]interface{})
+	params := components["parameters"].(map[string]interface{})
+
+	// paramNames returns the path parameters in a parameter list, resolving references.
+	paramNames := func(list interface{}) []string {
+		var names []string
+		items, _ := list.([]interface{})
+		for _, p := range items {
+			pm := p.(map[string]interface{})
+			if ref, ok := pm["$ref"].(string); ok {
+				pm = params[strings.TrimPrefix(ref, "#/components/parameters/")].(map[string]interface{})
+			}
+			if pm["in"] == "path" {
+				names = append(names, pm["name"].(string))
+			}
+		}
+		return names
+	}
+
+	ids := map[string]string{}
+	for key, op := range ops {
+		id, _ := op["operationId"].(string)
+		if id == "" {
+			t.Errorf("%s has no operationId", key)
+		} else if other, dup := ids[id]; dup {
+			t.Errorf("operationId %s is used by %s and %s", id, other, key)
+		}
+		ids[id] = key
+		if resp, _ := op["responses"].(map[string]interface{}); len(resp) == 0 {
+			t.Errorf("%s has no responses", key)
+		}
+
+		path := key[strings.Index(key, " ")+1:]
+		item := spec["paths"].(map[string]interface{})[path].(map[string]interface{})
+		declared := map[string]bool{}
+		for _, n := range append(paramNames(item["parameters"]), paramNames(op["parameters"])...) {
+			declared[n] = true
+		}
+		for _, m := range routeVar.FindAllStringSubmatch(path, -1) {
+			if !declared[m[1]] {
+				t.Errorf("%s does not declare path parameter %s", key, m[1])
+			}
+		}
+	}
+
+	// Every reference resolves
+	body, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range regexp.MustCompile(`"\$ref":"#/components/([a-zA-Z]+)/([a-zA-Z0-9]+)"`).FindAllStringSubmatch(string(body), -1) {
+		section, _ := components[m[1]].(map[string]interface{})
+		if _, ok := section[m[2]]; !ok {
+			t.Errorf("unresolved reference #/components/%s/%s", m[1], m[2])
+		}
+	}
+}
+
+func TestHandlers(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Handler("/admin/")(rec, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	var doc struct {
+		OpenAPI string `json:"openapi"`
+		Servers []struct {
+			URL string `json:"url"`
+		} `json:"servers"`
+		Paths map[string]interface{} `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") || len(doc.Servers) != 1 || doc.Servers[0].URL != "/admin/" || doc.Paths["/api/stats"] == nil {
+		t.Errorf("spec = openapi %q, servers %+v, %d paths", doc.OpenAPI, doc.Servers, len(doc.Paths))
+	}
+
+	rec = httptest.NewRecorder()
+	DocsHandler()(rec, httptest.NewRequest(http.MethodGet, "/api/docs", nil))
+	if body := rec.Body.String(); !strings.Contains(body, `url: "openapi.json"`) || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/html") {
+		t.Errorf("docs page = %s", body)
+	}
+}
@@ -0,0 +1,113 @@
+// Package privacy limits how much personal data the app keeps about the people using it.
+package privacy
+
+import (
+	"context"
+	"log"
+	"net"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// How feedback IPs are stored (FEEDBACK_IP_MODE); any other mode stores them raw.
+const (
+	IPModeRaw      = "raw"      // full address
+	IPModeTruncate = "truncate" // IPv4 /24, IPv6 /48
+	IPModeHash     = "hash"     // keyed hash, see models.PseudonymizeIP
+)
+
+var mScrubbed = metrics.Default.Counter("feedback_ips_scrubbed_total", "Editor feedback IPs cleared after the retention period")
+
+// IPPolicy turns a client address into the bytes stored with editor feedback. Feedback is
+// limited to one per stored value and venue, so truncation makes a whole /24 (or /48) share
+// one vote, while hashing keeps one vote per address as long as the key stays the same.
+type IPPolicy struct {
+	Mode string
+	Key  []byte // for IPModeHash
+}
+
+// Store returns ip as it should be stored, or nil when there is no address.
+func (p IPPolicy) Store(ip net.IP) []byte {
+	b := models.IPToBytes(ip)
+	if b == nil {
+		return nil
+	}
+	switch p.Mode {
+	case IPModeTruncate:
+		if len(b) == net.IPv4len {
+			return append(b[:3:3], 0)
+		}
+		out := make([]byte, net.IPv6len)
+		copy(out, b[:6])
+		return out
+	case IPModeHash:
+		return models.PseudonymizeIP(p.Key, b)
+	}
+	return b
+}
+
+// ScrubStore is the database side of feedback IP retention (implemented by *database.DB).
+type ScrubStore interface {
+	// ScrubFeedbackIPsCtx clears the IP of up to limit feedback rows created before the cutoff.
+	ScrubFeedbackIPsCtx(ctx context.Context, before time.Time, limit int) (int, error)
+}
+
+// Scrubber clears stored feedback IPs once they are older than the retention period. Rows
+// are kept, so feedback counts and statistics do not change.
+type Scrubber struct {
+	store         ScrubStore
+	retentionDays int
+	batch         int
+	now           func() time.Time
+}
+
+// NewScrubber returns a scrubber; retentionDays 0 keeps IPs forever.
+func NewScrubber(store ScrubStore, retentionDays, batch int) *Scrubber {
+	if batch <= 0 {
+		batch = 1000
+	}
+	return &Scrubber{store: store, retentionDays: retentionDays, batch: batch, now: time.Now}
+}
+
+// Run scrubs every interval until ctx is cancelled. No-op when retention is 0.
+func (s *Scrubber) Run(ctx context.Context, interval time.Duration) {
+	if s.retentionDays <= 0 {
+		return
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		if n, err := s.Scrub(ctx); err != nil {
+			log.Printf("privacy: feedback IP scrub stopped after %d rows: %v", n, err)
+		} else if n > 0 {
+			log.Printf("privacy: cleared %d feedback IPs older than %d days", n, s.retentionDays)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// Scrub clears IPs older than the retention period in batches and returns how many it cleared.
+func (s *Scrubber) Scrub(ctx context.Context) (int, error) {
+	cutoff := s.now().AddDate(0, 0, -s.retentionDays)
+	total := 0
+	for {
+		n, err := s.store.ScrubFeedbackIPsCtx(ctx, cutoff, s.batch)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		mScrubbed.Inc(int64(n))
+		if n < s.batch {
+			return total, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package privacy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+)
+
+func TestIPPolicyStore(t *testing.T) {
+	key := []byte("k")
+	tests := []struct {
+		mode string
+		ip   string
+		want []byte
+	}{
+		{IPModeRaw, "203.0.113.9", []byte{203, 0, 113, 9}},
+		{IPModeTruncate, "203.0.113.9", []byte{203, 0, 113, 0}},
+		{IPModeTruncate, "2001:db8:aa:bb::1", net.ParseIP("2001:db8:aa::")},
+		{IPModeHash, "203.0.113.9", models.PseudonymizeIP(key, []byte{203, 0, 113, 9})},
+		{IPModeHash, "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode+" "+tt.ip, func(t *testing.T) {
+			got := IPPolicy{Mode: tt.mode, Key: key}.Store(net.ParseIP(tt.ip))
+			if !bytes.Equal(got, tt.want) {
+				t.Fatalf("Store(%s) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+type fakeScrubStore struct {
+	remaining int
+	failOn    int
+	calls     int
+	before    time.Time
+}
+
+func (f *fakeScrubStore) ScrubFeedbackIPsCtx(_ context.Context, before time.Time, limit int) (int, error) {
+	f.calls++
+	f.before = before
+	if f.calls == f.failOn {
+		return 0, errors.New("lock wait timeout")
+	}
+	n := min(limit, f.remaining)
+	f.remaining -= n
+	return n, nil
+}
+
+func TestScrub(t *testing.T) {
+	now := time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		remaining int
+		failOn    int
+		wantCalls int
+		want      int
+		wantErr   bool
+	}{
+		{"nothing to do", 0, 0, 1, 0, false},
+		{"several batches", 25, 0, 3, 25, false},
+		{"store error keeps progress", 25, 2, 2, 10, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := &fakeScrubStore{remaining: tt.remaining, failOn: tt.failOn}
+			s := NewScrubber(st, 90, 10)
+			s.now = func() time.Time { return now }
+			n, err := s.Scrub(context.Background())
+			if (err != nil) != tt.wantErr || n != tt.want || st.calls != tt.wantCalls {
+				t.Fatalf("Scrub = %d, %v after %d calls; want %d (err %v) after %d", n, err, st.calls, tt.want, tt.wantErr, tt.wantCalls)
+			}
+			if want := now.AddDate(0, 0, -90); !st.before.Equal(want) {
+				t.Fatalf("cutoff = %s, want %s", st.before, want)
+			}
+		})
+	}
+}
@@ -16,7 +16,7 @@ type Engine interface {
 	Start()
 	Stop(timeout time.Duration) error
 	ProcessVenuesWithUsers(venuesWithUser []models.VenueWithUser) error
-	SetScoreOnly(scoreOnly bool)
+	ProcessVenuesWithMode(venuesWithUser []models.VenueWithUser, mode Mode) error
 	SetEventStore(es events.EventStore)
 }
 
@@ -0,0 +1,129 @@
+package processor
+
+import (
+	"log"
+	"sync/atomic"
+	"time"
+
+	errs "assisted-venue-approval/pkg/errors"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// AutoscaleConfig sizes the worker pool from load instead of a fixed WorkerCount.
+type AutoscaleConfig struct {
+	Enabled  bool
+	Min, Max int
+	Interval time.Duration // how often the pool is re-sized
+	// Above this share of failed Google/OpenAI calls the pool shrinks, whatever the queue
+	// depth: more workers would only hit a throttled or failing API harder.
+	MaxErrorRate float64
+}
+
+const (
+	// Queued jobs per worker above which the pool grows.
+	autoscaleDepthPerWorker = 2
+	// Fewer API calls than this in an interval are too few to judge the error rate.
+	autoscaleMinCalls = 5
+)
+
+var (
+	mWorkers       = metrics.Default.Gauge("processing_workers", "Current processing worker count")
+	mScalingEvents = metrics.Default.CounterVec("worker_scaling_events_total", "Worker pool resizes by the autoscaler", "direction", "reason")
+)
+
+// apiWindow counts external API calls and transient failures since the last autoscale tick.
+type apiWindow struct {
+	calls  atomic.Int64
+	errors atomic.Int64
+}
+
+// observe records one Google or OpenAI call. Only throttling and outages count as errors;
+// a bad request or auth failure does not get better with fewer workers.
+func (w *apiWindow) observe(err error) {
+	w.calls.Add(1)
+	if err != nil && errs.Classify(err).Transient() {
+		w.errors.Add(1)
+	}
+}
+
+// take returns and resets the counts.
+func (w *apiWindow) take() (calls, errors int64) {
+	return w.calls.Swap(0), w.errors.Swap(0)
+}
+
+// scaleDecision picks the next pool size and the reason for it ("" when unchanged).
+// Errors win over backlog: the pool shrinks by a quarter while the error rate is too high,
+// grows by half while the queue holds more than autoscaleDepthPerWorker jobs per worker and
+// drops one worker per interval while idle.
+func scaleDecision(cfg AutoscaleConfig, workers int, depth, calls, errors int64) (int, string) {
+	target, reason := workers, ""
+	switch {
+	case calls >= autoscaleMinCalls && float64(errors)/float64(calls) > cfg.MaxErrorRate:
+		target, reason = workers-max(1, workers/4), "error_rate"
+	case depth > int64(workers*autoscaleDepthPerWorker):
+		target, reason = workers+max(1, workers/2), "queue_depth"
+	case depth == 0:
+		target, reason = workers-1, "idle"
+	}
+	target = min(max(target, cfg.Min), cfg.Max)
+	if target == workers {
+		return workers, ""
+	}
+	return target, reason
+}
+
+// SetAutoscale enables the autoscaler; call before Start. The initial pool is clamped into
+// [Min, Max].
+func (e *ProcessingEngine) SetAutoscale(cfg AutoscaleConfig) {
+	e.autoscale = cfg
+	if cfg.Enabled {
+		e.workerCount = min(max(e.workerCount, cfg.Min), cfg.Max)
+	}
+}
+
+// autoscaleLoop re-sizes the pool every interval until shutdown.
+func (e *ProcessingEngine) autoscaleLoop() {
+	defer e.wg.Done()
+	t := time.NewTicker(e.autoscale.Interval)
+	defer t.Stop()
+	log.Printf("[autoscale] enabled: %d-%d workers, every %s", e.autoscale.Min, e.autoscale.Max, e.autoscale.Interval)
+
+	for {
+		select {
+		case <-t.C:
+			e.autoscaleOnce()
+		case <-e.shutdown:
+			return
+		}
+	}
+}
+
+func (e *ProcessingEngine) autoscaleOnce() {
+	e.workersMu.Lock()
+	workers := len(e.workerStops)
+	e.workersMu.Unlock()
+
+	depth := e.stats.queue.Load()
+	if e.dist != nil {
+		// Workers only hold a few claimed jobs; the backlog is in the shared queue
+		depth += e.dist.waiting.Load()
+	}
+	calls, errors := e.apiWindow.take()
+	target, reason := scaleDecision(e.autoscale, workers, depth, calls, errors)
+	if reason == "" {
+		return
+	}
+
+	direction := "up"
+	if target < workers {
+		direction = "down"
+	}
+	errorRate := 0.0
+	if calls > 0 {
+		errorRate = float64(errors) / float64(calls)
+	}
+	log.Printf("[autoscale] workers %d -> %d (%s: queue depth %d, API error rate %.2f over %d calls)",
+		workers, target, reason, depth, errorRate, calls)
+	mScalingEvents.With(direction, reason).Inc()
+	e.resizeWorkers(target)
+}
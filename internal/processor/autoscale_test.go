@@ -0,0 +1,53 @@
+package processor
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+func TestScaleDecision(t *testing.T) {
+	cfg := AutoscaleConfig{Enabled: true, Min: 2, Max: 10, MaxErrorRate: 0.2}
+	tests := []struct {
+		name          string
+		workers       int
+		depth         int64
+		calls, errors int64
+		want          int
+		wantReason    string
+	}{
+		{"backlog grows by half", 4, 20, 10, 0, 6, "queue_depth"},
+		{"growth capped at max", 8, 100, 10, 0, 10, "queue_depth"},
+		{"at max stays", 10, 100, 10, 0, 10, ""},
+		{"steady load unchanged", 4, 5, 10, 1, 4, ""},
+		{"idle drops one", 4, 0, 0, 0, 3, "idle"},
+		{"idle at min stays", 2, 0, 0, 0, 2, ""},
+		{"errors shrink despite backlog", 8, 100, 20, 10, 6, "error_rate"},
+		{"too few calls to judge errors", 4, 20, 4, 4, 6, "queue_depth"},
+		{"error shrink floored at min", 2, 100, 20, 20, 2, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := scaleDecision(cfg, tt.workers, tt.depth, tt.calls, tt.errors)
+			if got != tt.want || reason != tt.wantReason {
+				t.Fatalf("scaleDecision = %d, %q; want %d, %q", got, reason, tt.want, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestAPIWindow_CountsTransientErrorsOnly(t *testing.T) {
+	var w apiWindow
+	w.observe(nil)
+	w.observe(errs.NewExternalStatus("score", "openai", http.StatusTooManyRequests, 0, errors.New("slow down")))
+	w.observe(errs.NewExternalStatus("score", "openai", http.StatusUnauthorized, 0, errors.New("bad key")))
+	calls, failed := w.take()
+	if calls != 3 || failed != 1 {
+		t.Fatalf("take = %d calls, %d errors; want 3, 1", calls, failed)
+	}
+	if calls, _ := w.take(); calls != 0 {
+		t.Fatalf("window not reset: %d calls", calls)
+	}
+}
@@ -0,0 +1,294 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/internal/trust"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// BatchScorer prepares AI scoring calls for the OpenAI Batch API and turns the replies into
+// results.
+type BatchScorer interface {
+	// PrepareBatchScoring fills item's request for venue, or returns the result right away
+	// when the venue is not sent to the model.
+	PrepareBatchScoring(venue models.Venue, user models.User, item *models.BatchScoringItem) (*models.ValidationResult, error)
+	SubmitScoringBatch(ctx context.Context, items []models.BatchScoringItem) (*models.ScoringBatch, error)
+	RefreshScoringBatch(ctx context.Context, b *models.ScoringBatch) error
+	// ScoringBatchReplies returns the replies of a finished batch by item ID.
+	ScoringBatchReplies(ctx context.Context, b *models.ScoringBatch) (map[int64]models.BatchReply, error)
+	BatchScoringResult(ctx context.Context, item models.BatchScoringItem, body json.RawMessage) (*models.ValidationResult, error)
+}
+
+// BatchScoringConfig controls how ModeBatch scoring calls are sent and collected.
+type BatchScoringConfig struct {
+	PollInterval time.Duration // how often stored calls are submitted and open jobs checked
+	MaxRequests  int           // scoring calls per batch job
+}
+
+var (
+	mBatchItems = metrics.Default.CounterVec("openai_batch_items_total",
+		"Batch-mode scoring calls, by stage (pending, submitted, ingested, failed)", "stage")
+	mBatchJobs = metrics.Default.CounterVec("openai_batch_jobs_total",
+		"Batch API jobs ingested, by final OpenAI status", "status")
+)
+
+// errScoringDeferred reports that a venue's AI scoring call was stored for the next batch job.
+var errScoringDeferred = errors.New("AI scoring deferred to a batch job")
+
+// openBatchLimit bounds the batches checked per poll; more than this stay for the next one.
+const openBatchLimit = 100
+
+type batchScoring struct {
+	cfg    BatchScoringConfig
+	store  domain.BatchScoringStore
+	scorer BatchScorer
+}
+
+// EnableBatchScoring lets runs use ModeBatch; call before Start. Jobs in that mode are
+// enriched as usual, then their scoring call is stored instead of made. Every PollInterval
+// the stored calls are submitted as Batch API jobs, and finished jobs are ingested: each
+// reply goes through the optional checks and the decision like a synchronous score and is
+// recorded in validation history. Only one instance should have it enabled, since each
+// submits every stored call; instances without it score ModeBatch jobs synchronously.
+func (e *ProcessingEngine) EnableBatchScoring(store domain.BatchScoringStore, s BatchScorer, cfg BatchScoringConfig) {
+	e.batch = &batchScoring{cfg: cfg, store: store, scorer: s}
+}
+
+// BatchScoringEnabled reports whether this instance submits ModeBatch scoring calls.
+func (e *ProcessingEngine) BatchScoringEnabled() bool { return e.batch != nil }
+
+// ScoringBatches lists the most recent batch jobs; enabled is false when batch scoring is off.
+func (e *ProcessingEngine) ScoringBatches(ctx context.Context, limit int) ([]models.ScoringBatch, bool, error) {
+	if e.batch == nil {
+		return nil, false, nil
+	}
+	batches, err := e.batch.store.ListScoringBatchesCtx(ctx, false, limit)
+	return batches, true, err
+}
+
+// deferScoring stores the scoring call of an enriched venue for the next batch job and
+// returns errScoringDeferred. Venues the scorer does not send to the model get their
+// result right away.
+func (e *ProcessingEngine) deferScoring(ctx context.Context, job *ProcessingJob, enhancedVenue *models.Venue, user models.User) (*models.ValidationResult, error) {
+	b := e.batch
+	scoringVenue, translation := e.translateVenue(ctx, *enhancedVenue)
+	item := models.BatchScoringItem{VenueID: enhancedVenue.ID, RunID: job.RunID}
+	result, err := b.scorer.PrepareBatchScoring(scoringVenue, user, &item)
+	if err != nil || result != nil {
+		return result, err
+	}
+	if item.EnrichedVenue, err = json.Marshal(enhancedVenue); err != nil {
+		return nil, fmt.Errorf("failed to encode enriched venue: %w", err)
+	}
+	if translation != nil {
+		if item.Translation, err = json.Marshal(translation); err != nil {
+			return nil, fmt.Errorf("failed to encode translation: %w", err)
+		}
+	}
+	if err := b.store.AddBatchItemCtx(ctx, &item); err != nil {
+		return nil, fmt.Errorf("failed to store batch scoring call: %w", err)
+	}
+	mBatchItems.With(models.BatchItemPending).Inc()
+	return nil, errScoringDeferred
+}
+
+// batchLoop submits stored scoring calls and ingests finished batch jobs.
+func (e *ProcessingEngine) batchLoop() {
+	defer e.wg.Done()
+	t := time.NewTicker(e.batch.cfg.PollInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+		case <-e.shutdown:
+			return
+		}
+		e.submitBatches(e.ctx)
+		e.pollBatches(e.ctx)
+	}
+}
+
+// submitBatches sends the pending scoring calls to the Batch API, MaxRequests per job. Calls
+// that fail to go out stay pending for the next poll.
+func (e *ProcessingEngine) submitBatches(ctx context.Context) {
+	b := e.batch
+	for ctx.Err() == nil {
+		items, err := b.store.ListPendingBatchItemsCtx(ctx, b.cfg.MaxRequests)
+		if err != nil {
+			log.Printf("[batch] failed to load pending scoring calls: %v", err)
+			return
+		}
+		if len(items) == 0 {
+			return
+		}
+		sb, err := b.scorer.SubmitScoringBatch(ctx, items)
+		if err != nil {
+			log.Printf("[batch] failed to submit %d scoring calls: %v", len(items), err)
+			return
+		}
+		ids := make([]int64, len(items))
+		for i, it := range items {
+			ids[i] = it.ID
+		}
+		if err := b.store.CreateScoringBatchCtx(ctx, sb, ids); err != nil {
+			// The job runs at OpenAI unrecorded; its calls stay pending and go out again
+			log.Printf("[batch] failed to record batch %s: %v", sb.OpenAIBatchID, err)
+			return
+		}
+		mBatchItems.With(models.BatchItemSubmitted).Add(float64(len(items)))
+		log.Printf("[batch] submitted %d scoring calls as batch %s", len(items), sb.OpenAIBatchID)
+		if len(items) < b.cfg.MaxRequests {
+			return
+		}
+	}
+}
+
+// pollBatches refreshes the status of open batch jobs and ingests the finished ones.
+func (e *ProcessingEngine) pollBatches(ctx context.Context) {
+	b := e.batch
+	batches, err := b.store.ListScoringBatchesCtx(ctx, true, openBatchLimit)
+	if err != nil {
+		log.Printf("[batch] failed to load open batches: %v", err)
+		return
+	}
+	for i := range batches {
+		sb := &batches[i]
+		// A finished batch still open here was interrupted during ingestion
+		if !sb.Finished() {
+			before := sb.Status
+			if err := b.scorer.RefreshScoringBatch(ctx, sb); err != nil {
+				log.Printf("[batch] failed to check batch %s: %v", sb.OpenAIBatchID, err)
+				continue
+			}
+			if sb.Status != before {
+				if err := b.store.UpdateScoringBatchCtx(ctx, sb); err != nil {
+					log.Printf("[batch] failed to store status of batch %s: %v", sb.OpenAIBatchID, err)
+				}
+			}
+		}
+		if sb.Finished() {
+			e.ingestBatch(ctx, sb)
+		}
+	}
+}
+
+// ingestBatch hands the replies of a finished batch job to the result processor, which
+// records them like any other result. Items without a usable reply fail to manual review.
+func (e *ProcessingEngine) ingestBatch(ctx context.Context, sb *models.ScoringBatch) {
+	b := e.batch
+	replies, err := b.scorer.ScoringBatchReplies(ctx, sb)
+	if err != nil {
+		log.Printf("[batch] failed to download replies of batch %s: %v", sb.OpenAIBatchID, err)
+		return
+	}
+	items, err := b.store.GetBatchItemsCtx(ctx, sb.ID)
+	if err != nil {
+		log.Printf("[batch] failed to load items of batch %s: %v", sb.OpenAIBatchID, err)
+		return
+	}
+
+	failed := 0
+	for _, item := range items {
+		if item.Status != models.BatchItemSubmitted {
+			continue // handled before an interruption
+		}
+		reply, ok := replies[item.ID]
+		if !ok {
+			reply.Error = "no reply, batch " + sb.Status
+		}
+		result := e.batchResult(ctx, item, reply)
+		status, msg := models.BatchItemIngested, ""
+		if !result.Success {
+			status, msg = models.BatchItemFailed, result.Error.Error()
+			failed++
+		}
+		select {
+		case e.resultChan <- result:
+		case <-e.shutdown:
+			putProcessingResult(result)
+			return
+		}
+		if err := b.store.FinishBatchItemCtx(ctx, item.ID, status, msg); err != nil {
+			log.Printf("[batch] failed to mark batch item %d %s: %v", item.ID, status, err)
+		}
+		mBatchItems.With(status).Inc()
+	}
+
+	mBatchJobs.With(sb.Status).Inc()
+	final := sb.Status
+	sb.Status = models.BatchIngested
+	if err := b.store.UpdateScoringBatchCtx(ctx, sb); err != nil {
+		log.Printf("[batch] failed to mark batch %s ingested: %v", sb.OpenAIBatchID, err)
+	}
+	log.Printf("[batch] ingested batch %s (%s): %d items, %d failed", sb.OpenAIBatchID, final, len(items), failed)
+}
+
+// batchResult finishes the pipeline of a batch-scored venue from its stored enrichment: the
+// reply is checked and parsed, then the optional checks and the decision run as they do
+// after a synchronous score.
+func (e *ProcessingEngine) batchResult(ctx context.Context, item models.BatchScoringItem, reply models.BatchReply) *ProcessingResult {
+	start := time.Now()
+	result := getProcessingResult()
+	result.VenueID = item.VenueID
+	result.Mode = ModeBatch
+	result.RunID = item.RunID
+
+	var enhanced *models.Venue
+	if err := json.Unmarshal(item.EnrichedVenue, &enhanced); err != nil || enhanced == nil {
+		result.Error = fmt.Errorf("unreadable enriched venue in batch item %d: %v", item.ID, err)
+		return result
+	}
+	result.GoogleData = enhanced.GoogleData
+	if reply.Error != "" {
+		result.Error = failedIn(StageOpenAI, fmt.Errorf("batch scoring failed: %s", reply.Error))
+		return result
+	}
+
+	vw, err := e.repo.GetVenueWithUserByIDCtx(ctx, item.VenueID)
+	if err != nil || vw == nil {
+		result.Error = fmt.Errorf("failed to load venue for its batch reply: %v", err)
+		return result
+	}
+	user := vw.User
+	var trustAssessment *trust.Assessment
+	if user.ID > 0 {
+		user.History = e.submissionHistory(ctx, user.ID)
+		assessment := e.trustCalc.Assess(user, vw.Venue.Location)
+		trustAssessment = &assessment
+	}
+
+	validationResult, err := e.batch.scorer.BatchScoringResult(ctx, item, reply.Body)
+	if err != nil {
+		result.Error = failedIn(StageOpenAI, err)
+		return result
+	}
+	e.stats.openAI.Add(1)
+	mApiOpenAI.Inc(1)
+
+	var translation *models.VenueTranslation
+	if len(item.Translation) > 0 {
+		if err := json.Unmarshal(item.Translation, &translation); err != nil {
+			log.Printf("[batch] unreadable translation in batch item %d: %v", item.ID, err)
+		}
+	}
+	timings := &models.StageTimings{}
+	e.reviewScored(ctx, vw.Venue, enhanced, user, trustAssessment, validationResult, translation, timings)
+	e.decide(ctx, ModeBatch, enhanced, user, validationResult, timings)
+	attachTrust(validationResult, trustAssessment)
+	attachTimings(validationResult, timings, start)
+	appendCompleted(ctx, e.eventStore, item.VenueID, validationResult, result.GoogleData)
+
+	result.Success = true
+	result.ValidationResult = validationResult
+	result.ProcessingTimeMs = time.Since(start).Milliseconds()
+	return result
+}
@@ -0,0 +1,178 @@
+package processor
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+const (
+	categoryOutputKey = "category_suggestion"
+	// categorySource names the classifier in stored suggestions; bump it when the signals
+	// or weights change so reviews of the old and new versions are counted apart
+	categorySource = "heuristic@v1"
+)
+
+// Signal weights: a Google place type outweighs the name, which outweighs the description.
+const (
+	categoryWeightGoogle      = 3
+	categoryWeightName        = 2
+	categoryWeightDescription = 1
+)
+
+var mCategorySuggestions = metrics.Default.CounterVec("category_suggestions_total", "Category suggestions by result: match, mismatch or none", "result")
+
+// categorySignals are the Google place types and name/description keywords pointing at each
+// category (IDs as in models.StoreCategoryOptions). Keywords match whole words.
+var categorySignals = map[int]struct {
+	types    []string
+	keywords []string
+}{
+	0:  {[]string{"restaurant", "meal_takeaway", "vegan_restaurant", "vegetarian_restaurant", "fast_food_restaurant"}, []string{"restaurant", "bistro", "eatery", "diner", "brasserie", "trattoria", "canteen", "buffet", "dine in"}},
+	1:  {[]string{"health_food_store", "drugstore", "pharmacy"}, []string{"health food", "health store", "supplements", "vitamins", "natural foods"}},
+	2:  {[]string{"grocery_store", "grocery_or_supermarket", "supermarket", "convenience_store", "butcher_shop"}, []string{"grocery", "grocer", "supermarket", "vegan shop", "vegan store", "vegan butcher"}},
+	3:  {[]string{"bakery"}, []string{"bakery", "bakeries", "bakehouse", "patisserie", "boulangerie", "pastries", "sourdough", "donuts", "doughnuts"}},
+	4:  {[]string{"lodging", "bed_and_breakfast", "guest_house", "hotel", "hostel"}, []string{"b b", "bed and breakfast", "guesthouse", "guest house", "hotel", "hostel"}},
+	5:  {[]string{"meal_delivery"}, []string{"delivery only", "meal delivery", "meal prep", "meal kits"}},
+	6:  {[]string{"catering_service"}, []string{"catering", "caterer", "caterers"}},
+	7:  {[]string{"non_profit_organization"}, []string{"society", "association", "non profit", "nonprofit", "charity", "sanctuary"}},
+	8:  {[]string{"farmers_market"}, []string{"farmers market", "farmer s market", "farmers markets"}},
+	10: {[]string{"food_truck"}, []string{"food truck", "food cart", "food trailer"}},
+	11: {nil, []string{"market stall", "stall", "market vendor"}},
+	12: {[]string{"ice_cream_shop", "dessert_shop"}, []string{"ice cream", "gelato", "gelateria", "sorbet", "soft serve", "nice cream"}},
+	13: {[]string{"juice_shop"}, []string{"juice", "juices", "juice bar", "smoothie", "smoothies", "cold pressed", "acai"}},
+	14: {[]string{"doctor", "dentist", "physiotherapist", "lawyer", "accounting"}, []string{"nutritionist", "dietitian", "clinic", "coaching", "therapist"}},
+	15: {[]string{"cafe", "coffee_shop", "tea_house"}, []string{"coffee", "espresso", "roastery", "cafe", "café", "tea house", "teahouse", "latte"}},
+	16: {[]string{"spa", "beauty_salon", "massage"}, []string{"spa", "massage", "salon", "wellness"}},
+}
+
+// EnableCategorySuggestions classifies every scored venue from its Google types, name and
+// description and flags a category that differs from the submitted one. Call before Start.
+func (e *ProcessingEngine) EnableCategorySuggestions() {
+	e.categoryCheck = true
+}
+
+// suggestCategory classifies a scored venue when enabled and exposes the suggestion to the
+// decision engine through venue.ValidationDetails.Category.
+func (e *ProcessingEngine) suggestCategory(venue *models.Venue) *models.CategorySuggestion {
+	if !e.categoryCheck {
+		return nil
+	}
+	s := SuggestCategory(*venue)
+	switch {
+	case s == nil:
+		mCategorySuggestions.With("none").Inc()
+		return nil
+	case s.Mismatch:
+		mCategorySuggestions.With("mismatch").Inc()
+	default:
+		mCategorySuggestions.With("match").Inc()
+	}
+	if venue.ValidationDetails != nil {
+		venue.ValidationDetails.Category = s
+	}
+	return s
+}
+
+// SuggestCategory scores each category by the venue's Google place types and the keywords
+// in its name and description, and returns the best one. The submitted category wins ties,
+// so a venue is only flagged when the evidence clearly points elsewhere. Returns nil when
+// nothing points at any category.
+func SuggestCategory(venue models.Venue) *models.CategorySuggestion {
+	var googleTypes []string
+	if venue.GoogleData != nil {
+		googleTypes = venue.GoogleData.Types
+	}
+	name := categoryText(venue.Name)
+	desc := categoryText(venue.VDetails)
+	if venue.AdditionalInfo != nil {
+		desc += categoryText(*venue.AdditionalInfo)
+	}
+
+	scores := map[int]int{}
+	signals := map[int][]string{}
+	hasGoogle := map[int]bool{}
+	for id, sig := range categorySignals {
+		for _, t := range sig.types {
+			for _, gt := range googleTypes {
+				if gt == t {
+					scores[id] += categoryWeightGoogle
+					signals[id] = append(signals[id], "google:"+t)
+					hasGoogle[id] = true
+				}
+			}
+		}
+		for _, kw := range sig.keywords {
+			k := categoryText(kw)
+			if strings.Contains(name, k) {
+				scores[id] += categoryWeightName
+				signals[id] = append(signals[id], "name:"+kw)
+			} else if strings.Contains(desc, k) {
+				scores[id] += categoryWeightDescription
+				signals[id] = append(signals[id], "description:"+kw)
+			}
+		}
+	}
+	if len(scores) == 0 {
+		return nil
+	}
+
+	// Rank by score, then by ID so equal scores give the same answer every time
+	ids := make([]int, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	best := ids[0]
+	if scores[venue.Category] == scores[best] {
+		best = venue.Category
+	}
+	runnerUp := 0
+	for _, id := range ids {
+		if id != best {
+			runnerUp = scores[id]
+			break
+		}
+	}
+
+	// Confidence rests on the margin over the runner-up and on Google agreeing with the text
+	lead := scores[best] - runnerUp
+	confidence := models.CategoryConfidenceLow
+	switch {
+	case lead >= categoryWeightGoogle && hasGoogle[best] && len(signals[best]) > 1:
+		confidence = models.CategoryConfidenceHigh
+	case lead >= categoryWeightName:
+		confidence = models.CategoryConfidenceMedium
+	}
+
+	sig := signals[best]
+	sort.Strings(sig)
+	return &models.CategorySuggestion{
+		Submitted:  venue.Category,
+		Suggested:  best,
+		Confidence: confidence,
+		Signals:    sig,
+		Mismatch:   best != venue.Category,
+		Source:     categorySource,
+	}
+}
+
+// categoryText lowercases s and reduces it to space-separated words with a space at each
+// end, so keywords can be matched as whole words with strings.Contains.
+func categoryText(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return ""
+	}
+	return " " + strings.Join(words, " ") + " "
+}
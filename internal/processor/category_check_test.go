@@ -0,0 +1,67 @@
+package processor
+
+import (
+	"testing"
+
+	"assisted-venue-approval/internal/models"
+)
+
+func TestSuggestCategory(t *testing.T) {
+	info := "Fresh cold-pressed juices and smoothies"
+	tests := []struct {
+		name       string
+		venue      models.Venue
+		want       int
+		confidence string
+		mismatch   bool
+	}{
+		{
+			name: "bakery submitted as restaurant",
+			venue: models.Venue{Name: "Rise Vegan Bakery", Category: 0, VDetails: "Sourdough and pastries baked daily.",
+				GoogleData: &models.GooglePlaceData{Types: []string{"bakery", "food", "store"}}},
+			want: 3, confidence: models.CategoryConfidenceHigh, mismatch: true,
+		},
+		{
+			name:  "juice bar from text only",
+			venue: models.Venue{Name: "Green Press", Category: 0, AdditionalInfo: &info},
+			want:  13, confidence: models.CategoryConfidenceMedium, mismatch: true,
+		},
+		{
+			name: "submitted category wins a tie",
+			venue: models.Venue{Name: "Bean Corner", Category: 0,
+				GoogleData: &models.GooglePlaceData{Types: []string{"restaurant", "cafe"}}},
+			want: 0, confidence: models.CategoryConfidenceLow,
+		},
+		{
+			name: "matching category",
+			venue: models.Venue{Name: "Gelateria Verde", Category: 12, VDetails: "Dairy-free gelato and sorbet.",
+				GoogleData: &models.GooglePlaceData{Types: []string{"ice_cream_shop"}}},
+			want: 12, confidence: models.CategoryConfidenceHigh,
+		},
+		{
+			// "spa" must not match inside "spaghetti"
+			name:  "keywords match whole words",
+			venue: models.Venue{Name: "Spaghetti House", Category: 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := SuggestCategory(tt.venue)
+			if tt.want == 0 && tt.confidence == "" {
+				if s != nil {
+					t.Fatalf("expected no suggestion, got %+v", s)
+				}
+				return
+			}
+			if s == nil {
+				t.Fatal("expected a suggestion")
+			}
+			if s.Suggested != tt.want || s.Confidence != tt.confidence || s.Mismatch != tt.mismatch {
+				t.Fatalf("suggestion = %+v, want category %d (%s) mismatch=%v", s, tt.want, tt.confidence, tt.mismatch)
+			}
+			if s.Source != categorySource || len(s.Signals) == 0 {
+				t.Fatalf("suggestion = %+v", s)
+			}
+		})
+	}
+}
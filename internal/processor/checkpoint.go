@@ -0,0 +1,204 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+var mCheckpointsResumed = metrics.Default.CounterVec("processing_checkpoints_resumed_total", "Jobs resumed from a shutdown checkpoint", "stage")
+
+// checkpointer tracks the finished stages of in-flight jobs. Nothing is written while the
+// engine runs; on shutdown the jobs that did not finish are stored, and the venue's next
+// processing picks up after the last stored stage instead of paying for Google and OpenAI again.
+type checkpointer struct {
+	store  domain.CheckpointStore
+	maxAge time.Duration
+
+	mu       sync.Mutex
+	inflight map[int64]models.JobCheckpoint
+	stored   map[int64]bool // venues being processed whose checkpoint is in the store
+}
+
+// resumed is a checkpoint decoded for processing.
+type resumed struct {
+	stage  string
+	venue  *models.Venue
+	result *models.ValidationResult // nil before models.CheckpointScored
+}
+
+// EnableCheckpoints keeps interrupted jobs' progress in store; call before Start. Checkpoints
+// older than maxAge are not resumed, since the Google data may be out of date.
+func (e *ProcessingEngine) EnableCheckpoints(store domain.CheckpointStore, maxAge time.Duration) {
+	e.checkpoints = &checkpointer{
+		store:    store,
+		maxAge:   maxAge,
+		inflight: map[int64]models.JobCheckpoint{},
+		stored:   map[int64]bool{},
+	}
+}
+
+// resumeCheckpoint returns the stored progress of venue's interrupted job, or nil when there
+// is none or the venue was edited since.
+func (e *ProcessingEngine) resumeCheckpoint(ctx context.Context, venue models.Venue) *resumed {
+	c := e.checkpoints
+	if c == nil {
+		return nil
+	}
+	cp, err := c.store.GetCheckpointCtx(ctx, venue.ID, c.maxAge)
+	if err != nil {
+		log.Printf("[checkpoint] failed to load checkpoint for venue %d: %v", venue.ID, err)
+		return nil
+	}
+	if cp == nil {
+		return nil
+	}
+	// Removed with the job's result, whether or not it is used
+	c.mu.Lock()
+	c.stored[venue.ID] = true
+	c.mu.Unlock()
+
+	if !sameTime(cp.VenueUpdated, venue.DateUpdated) {
+		log.Printf("[checkpoint] venue %d was edited since its checkpoint, processing from the start", venue.ID)
+		return nil
+	}
+	r := &resumed{stage: cp.Stage}
+	if err := json.Unmarshal(cp.EnrichedVenue, &r.venue); err != nil || r.venue == nil {
+		log.Printf("[checkpoint] unreadable checkpoint for venue %d: %v", venue.ID, err)
+		return nil
+	}
+	if cp.Stage == models.CheckpointScored {
+		if err := json.Unmarshal(cp.ValidationResult, &r.result); err != nil || r.result == nil {
+			log.Printf("[checkpoint] unreadable score in checkpoint for venue %d, rescoring: %v", venue.ID, err)
+			r.stage, r.result = models.CheckpointEnriched, nil
+		}
+	}
+	log.Printf("[checkpoint] resuming venue %d after stage %s", venue.ID, r.stage)
+	mCheckpointsResumed.With(r.stage).Inc()
+	// Still the job's progress should it fail or be interrupted again
+	cp.Stage = r.stage
+	if r.result == nil {
+		cp.ValidationResult = nil
+	}
+	c.mu.Lock()
+	c.inflight[venue.ID] = *cp
+	c.mu.Unlock()
+	return r
+}
+
+// checkpoint records that venue's job finished stage. The venue and result are copied as
+// JSON, so later stages changing them do not alter the checkpoint.
+func (e *ProcessingEngine) checkpoint(venue models.Venue, stage string, enriched *models.Venue, vr *models.ValidationResult) {
+	c := e.checkpoints
+	if c == nil {
+		return
+	}
+	cp := models.JobCheckpoint{VenueID: venue.ID, Stage: stage, VenueUpdated: venue.DateUpdated, CreatedAt: time.Now()}
+	var err error
+	if cp.EnrichedVenue, err = json.Marshal(enriched); err == nil && vr != nil {
+		cp.ValidationResult, err = json.Marshal(vr)
+	}
+	if err != nil {
+		log.Printf("[checkpoint] failed to encode checkpoint for venue %d: %v", venue.ID, err)
+		return
+	}
+	c.mu.Lock()
+	c.inflight[venue.ID] = cp
+	c.mu.Unlock()
+}
+
+// finishCheckpoint forgets a venue's checkpoint once its result has been handled.
+func (e *ProcessingEngine) finishCheckpoint(venueID int64) {
+	c := e.checkpoints
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	delete(c.inflight, venueID)
+	wasStored := c.stored[venueID]
+	delete(c.stored, venueID)
+	c.mu.Unlock()
+	if !wasStored {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := c.store.DeleteCheckpointCtx(ctx, venueID); err != nil {
+		log.Printf("[checkpoint] failed to delete checkpoint for venue %d: %v", venueID, err)
+	}
+}
+
+// keepCheckpoint stores the progress of a venue whose job failed after finishing a stage, so
+// the next run resumes after it rather than paying for the stage again. It reports whether a
+// checkpoint was kept; finishCheckpoint then leaves it in the store.
+func (e *ProcessingEngine) keepCheckpoint(ctx context.Context, venueID int64) bool {
+	c := e.checkpoints
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	cp, ok := c.inflight[venueID]
+	delete(c.inflight, venueID)
+	c.mu.Unlock()
+	if !ok {
+		return false
+	}
+	if err := c.store.SaveCheckpointsCtx(ctx, []models.JobCheckpoint{cp}); err != nil {
+		log.Printf("[checkpoint] failed to keep checkpoint of failed venue %d: %v", venueID, err)
+		return false
+	}
+	c.mu.Lock()
+	delete(c.stored, venueID)
+	c.mu.Unlock()
+	log.Printf("[checkpoint] kept venue %d after stage %s for its next run", venueID, cp.Stage)
+	return true
+}
+
+// persistCheckpoints stores the progress of jobs still unfinished at shutdown.
+func (e *ProcessingEngine) persistCheckpoints() {
+	c := e.checkpoints
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	cps := make([]models.JobCheckpoint, 0, len(c.inflight))
+	for _, cp := range c.inflight {
+		cps = append(cps, cp)
+	}
+	c.mu.Unlock()
+	if len(cps) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := c.store.SaveCheckpointsCtx(ctx, cps); err != nil {
+		log.Printf("[checkpoint] failed to store %d checkpoints: %v", len(cps), err)
+		return
+	}
+	log.Printf("[checkpoint] stored %d interrupted jobs for resumption", len(cps))
+}
+
+// pruneCheckpoints drops checkpoints too old to resume.
+func (e *ProcessingEngine) pruneCheckpoints() {
+	c := e.checkpoints
+	ctx, cancel := context.WithTimeout(e.ctx, 10*time.Second)
+	defer cancel()
+	if n, err := c.store.PruneCheckpointsCtx(ctx, c.maxAge); err != nil {
+		log.Printf("[checkpoint] failed to prune checkpoints: %v", err)
+	} else if n > 0 {
+		log.Printf("[checkpoint] pruned %d expired checkpoints", n)
+	}
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
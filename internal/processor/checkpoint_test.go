@@ -0,0 +1,186 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/decision"
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+)
+
+func TestCheckpoint_SnapshotIsPersistedOnShutdown(t *testing.T) {
+	var saved []models.JobCheckpoint
+	store := &testutil.CheckpointStore{
+		SaveCheckpointsCtxFunc: func(_ context.Context, cps []models.JobCheckpoint) error {
+			saved = cps
+			return nil
+		},
+	}
+	e := &ProcessingEngine{}
+	e.EnableCheckpoints(store, time.Hour)
+
+	venue := models.Venue{ID: 3, Name: "Green Bowl"}
+	vr := &models.ValidationResult{VenueID: 3, Score: 72, ScoreBreakdown: map[string]int{"ai": 72}}
+	e.checkpoint(venue, models.CheckpointScored, &venue, vr)
+	// The decision stage changes the result after the checkpoint
+	vr.Score = 90
+	vr.ScoreBreakdown["authority_bonus"] = 18
+
+	e.persistCheckpoints()
+	if len(saved) != 1 || saved[0].VenueID != 3 || saved[0].Stage != models.CheckpointScored {
+		t.Fatalf("saved = %+v", saved)
+	}
+	var got models.ValidationResult
+	if err := json.Unmarshal(saved[0].ValidationResult, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Score != 72 || len(got.ScoreBreakdown) != 1 {
+		t.Fatalf("checkpointed result = %+v, want the pre-decision score", got)
+	}
+
+	// A handled result leaves nothing to persist
+	saved = nil
+	e.finishCheckpoint(3)
+	e.persistCheckpoints()
+	if saved != nil {
+		t.Fatalf("persisted %d checkpoints after the job finished", len(saved))
+	}
+}
+
+func TestProcessVenue_ResumesFromScoredCheckpoint(t *testing.T) {
+	updated := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	lat, lng := 52.5, 13.4
+	venue := models.Venue{ID: 5, Name: "Tofu Haus", DateUpdated: &updated}
+	enriched := venue
+	enriched.Lat, enriched.Lng = &lat, &lng
+	enrichedJSON, _ := json.Marshal(enriched)
+	scoreJSON, _ := json.Marshal(models.ValidationResult{VenueID: 5, Score: 80, Status: "approved", ScoreBreakdown: map[string]int{"ai": 80}})
+
+	var deleted []int64
+	store := &testutil.CheckpointStore{
+		GetCheckpointCtxFunc: func(_ context.Context, venueID int64, _ time.Duration) (*models.JobCheckpoint, error) {
+			return &models.JobCheckpoint{
+				VenueID: venueID, Stage: models.CheckpointScored, VenueUpdated: &updated,
+				EnrichedVenue: enrichedJSON, ValidationResult: scoreJSON,
+			}, nil
+		},
+		DeleteCheckpointCtxFunc: func(_ context.Context, venueID int64) error {
+			deleted = append(deleted, venueID)
+			return nil
+		},
+	}
+	// Google and OpenAI fail: only a resumed job gets through
+	scraper := testutil.NewMockScraper()
+	scraper.Err[5] = errors.New("google should not be called")
+	scorer := testutil.NewMockScorer()
+	scorer.Err[5] = errors.New("openai should not be called")
+	e := NewProcessingEngine(&testutil.Repository{}, nil, scraper, scorer, nil, DefaultProcessingConfig(), decision.DefaultDecisionConfig())
+	e.EnableCheckpoints(store, time.Hour)
+
+	vr, _, err := e.processVenueWithRateLimit(context.Background(), venue, models.User{ID: 1}, nil, &ProcessingJob{Mode: ModeScoreOnly})
+	if err != nil {
+		t.Fatalf("resumed job failed: %v", err)
+	}
+	if vr.VenueID != 5 || vr.ScoreBreakdown["ai"] != 80 {
+		t.Fatalf("result = %+v", vr)
+	}
+
+	e.finishCheckpoint(5)
+	if len(deleted) != 1 || deleted[0] != 5 {
+		t.Fatalf("deleted = %v, want the used checkpoint removed", deleted)
+	}
+}
+
+func TestResumeCheckpoint_IgnoresEditedVenue(t *testing.T) {
+	before := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	after := before.Add(time.Hour)
+	store := &testutil.CheckpointStore{
+		GetCheckpointCtxFunc: func(_ context.Context, venueID int64, _ time.Duration) (*models.JobCheckpoint, error) {
+			return &models.JobCheckpoint{VenueID: venueID, Stage: models.CheckpointEnriched, VenueUpdated: &before, EnrichedVenue: []byte(`{"id":9}`)}, nil
+		},
+	}
+	e := &ProcessingEngine{}
+	e.EnableCheckpoints(store, time.Hour)
+
+	if res := e.resumeCheckpoint(context.Background(), models.Venue{ID: 9, DateUpdated: &after}); res != nil {
+		t.Fatalf("resumed %+v for an edited venue", res)
+	}
+	if !e.checkpoints.stored[9] {
+		t.Fatal("stale checkpoint not marked for deletion")
+	}
+}
+
+func TestNoteFailure_KeepsCheckpointForNextRun(t *testing.T) {
+	var saved []models.JobCheckpoint
+	var deleted []int64
+	store := &testutil.CheckpointStore{
+		SaveCheckpointsCtxFunc: func(_ context.Context, cps []models.JobCheckpoint) error {
+			saved = append(saved, cps...)
+			return nil
+		},
+		DeleteCheckpointCtxFunc: func(_ context.Context, venueID int64) error {
+			deleted = append(deleted, venueID)
+			return nil
+		},
+	}
+	e := &ProcessingEngine{}
+	e.EnableCheckpoints(store, time.Hour)
+
+	venue := models.Venue{ID: 4, Name: "Seitan Bar"}
+	e.checkpoint(venue, models.CheckpointEnriched, &venue, nil)
+	result := &ProcessingResult{VenueID: 4, Mode: ModeScoreOnly, Error: failedIn(StageOpenAI, errors.New("openai down"))}
+	e.noteFailure(context.Background(), result)
+	e.finishCheckpoint(4)
+
+	p := result.Progress
+	if p == nil || p.FailedStage != StageOpenAI || len(p.Completed) != 1 || p.Completed[0] != StageGoogle || !p.Resumable || p.Error != "openai down" {
+		t.Fatalf("progress = %+v", p)
+	}
+	if len(saved) != 1 || saved[0].Stage != models.CheckpointEnriched || len(deleted) != 0 {
+		t.Fatalf("saved %+v, deleted %v; want the enriched checkpoint kept", saved, deleted)
+	}
+
+	// Dry runs leave nothing behind
+	saved = nil
+	e.checkpoint(venue, models.CheckpointEnriched, &venue, nil)
+	dry := &ProcessingResult{VenueID: 4, Mode: ModeDryRun, Error: failedIn(StageOpenAI, errors.New("openai down"))}
+	e.noteFailure(context.Background(), dry)
+	if dry.Progress.Resumable || saved != nil {
+		t.Fatalf("dry run kept a checkpoint: %+v", dry.Progress)
+	}
+}
+
+func TestProcessVenue_DryRunAndSyncJobsSkipCheckpoints(t *testing.T) {
+	lat, lng := 52.5, 13.4
+	venue := models.Venue{ID: 6, Name: "Oat Corner", Lat: &lat, Lng: &lng}
+	var loads int
+	store := &testutil.CheckpointStore{
+		GetCheckpointCtxFunc: func(_ context.Context, _ int64, _ time.Duration) (*models.JobCheckpoint, error) {
+			loads++
+			return nil, nil
+		},
+	}
+	e := NewProcessingEngine(&testutil.Repository{}, nil, testutil.NewMockScraper(), testutil.NewMockScorer(), nil, DefaultProcessingConfig(), decision.DefaultDecisionConfig())
+	e.EnableCheckpoints(store, time.Hour)
+
+	for _, job := range []*ProcessingJob{{Mode: ModeDryRun}, {Mode: ModeScoreOnly, sync: true}} {
+		if _, _, err := e.processVenueWithRateLimit(context.Background(), venue, models.User{ID: 1}, nil, job); err != nil {
+			t.Fatalf("mode %s sync %v: %v", job.Mode, job.sync, err)
+		}
+	}
+	if loads != 0 || len(e.checkpoints.inflight) != 0 {
+		t.Fatalf("loaded %d checkpoints, %d in flight; want none", loads, len(e.checkpoints.inflight))
+	}
+
+	// A queued real run is checkpointed
+	if _, _, err := e.processVenueWithRateLimit(context.Background(), venue, models.User{ID: 1}, nil, &ProcessingJob{Mode: ModeScoreOnly}); err != nil {
+		t.Fatal(err)
+	}
+	if loads != 1 || e.checkpoints.inflight[6].Stage != models.CheckpointScored {
+		t.Fatalf("loaded %d checkpoints, in flight %+v", loads, e.checkpoints.inflight)
+	}
+}
@@ -0,0 +1,166 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// ErrDeadlineBudget is returned when too little of the caller's deadline is left for a stage
+// the result cannot do without (Google enrichment, AI scoring). It is not retried.
+var ErrDeadlineBudget = fmt.Errorf("request deadline budget spent: %w", context.DeadlineExceeded)
+
+var mStageSkipped = metrics.Default.CounterVec("stage_budget_skipped_total", "Stages of synchronous reviews skipped because they would not fit the request deadline", "stage")
+
+// budgetStages are the stages a synchronous review's deadline is split between, in order.
+var budgetStages = []string{StageGoogle, StageOpenAI, StageQuality, StageDB}
+
+// DefaultStageWeights split a deadline 30/40/20/10 between Google, AI scoring, the checks
+// after scoring (photos, website, social, quality review) and the database write.
+var DefaultStageWeights = map[string]float64{StageGoogle: 3, StageOpenAI: 4, StageQuality: 2, StageDB: 1}
+
+// stageMinimum is the least time a stage is worth starting with; a shorter share skips it.
+var stageMinimum = map[string]time.Duration{
+	StageGoogle:  2 * time.Second,
+	StageOpenAI:  3 * time.Second,
+	StageQuality: 2 * time.Second,
+	StageDB:      500 * time.Millisecond,
+}
+
+// ParseStageWeights parses "google=3,openai=4,quality_review=2,db=1". Stages left out keep
+// their default weight.
+func ParseStageWeights(spec string) (map[string]float64, error) {
+	const op = "processor.ParseStageWeights"
+	weights := make(map[string]float64, len(DefaultStageWeights))
+	for k, v := range DefaultStageWeights {
+		weights[k] = v
+	}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		stage, val, ok := strings.Cut(item, "=")
+		stage = strings.TrimSpace(stage)
+		w, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+		if _, known := DefaultStageWeights[stage]; !ok || !known || err != nil || w <= 0 {
+			return nil, errs.NewValidation(op, fmt.Sprintf("%q: want <stage>=<positive weight> with stage one of %s", item, strings.Join(budgetStages, ", ")), nil)
+		}
+		weights[stage] = w
+	}
+	return weights, nil
+}
+
+// deadlineBudget splits what is left of a caller's deadline between the stages still to run.
+// Each stage gets its weight's share of the remaining time, counting only itself and later
+// stages, so time a stage does not use (or a stage that does not run, like Google for cached
+// data) goes to the ones after it, and the last stages keep their share however slow the
+// first ones are.
+type deadlineBudget struct {
+	deadline time.Time
+	weights  map[string]float64
+
+	mu      sync.Mutex
+	skipped []string
+}
+
+type deadlineBudgetKey struct{}
+
+func newDeadlineBudget(deadline time.Time, weights map[string]float64) *deadlineBudget {
+	if weights == nil {
+		weights = DefaultStageWeights
+	}
+	return &deadlineBudget{deadline: deadline, weights: weights}
+}
+
+func withDeadlineBudget(ctx context.Context, b *deadlineBudget) context.Context {
+	return context.WithValue(ctx, deadlineBudgetKey{}, b)
+}
+
+func deadlineBudgetFrom(ctx context.Context) *deadlineBudget {
+	b, _ := ctx.Value(deadlineBudgetKey{}).(*deadlineBudget)
+	return b
+}
+
+// allot is the time stage may take when it starts at now.
+func (b *deadlineBudget) allot(stage string, now time.Time) time.Duration {
+	remaining := b.deadline.Sub(now)
+	var rest float64 // weight of stage and the stages after it
+	seen := false
+	for _, s := range budgetStages {
+		seen = seen || s == stage
+		if seen {
+			rest += b.weights[s]
+		}
+	}
+	if rest <= 0 || remaining <= 0 {
+		return remaining
+	}
+	return time.Duration(float64(remaining) * b.weights[stage] / rest)
+}
+
+func (b *deadlineBudget) skip(stage string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, s := range b.skipped {
+		if s == stage {
+			return
+		}
+	}
+	b.skipped = append(b.skipped, stage)
+	mStageSkipped.With(stage).Inc()
+}
+
+// Skipped lists the stages left out so far.
+func (b *deadlineBudget) Skipped() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.skipped...)
+}
+
+// stageContext bounds ctx to stage's share of the deadline budget on ctx. ok is false, and
+// the stage is recorded as skipped, when the share is below what the stage needs; the caller
+// then leaves the stage out. Without a budget ctx is returned as is.
+func stageContext(ctx context.Context, stage string) (context.Context, context.CancelFunc, bool) {
+	b := deadlineBudgetFrom(ctx)
+	if b == nil {
+		return ctx, func() {}, true
+	}
+	d := b.allot(stage, time.Now())
+	if d < stageMinimum[stage] {
+		b.skip(stage)
+		logf(ctx, models.LogLevelWarn, "Skipping %s: %v of the request deadline left for it, needs %v", stage, d.Round(time.Millisecond), stageMinimum[stage])
+		return ctx, func() {}, false
+	}
+	sctx, cancel := context.WithTimeout(ctx, d)
+	return sctx, cancel, true
+}
+
+// stageFits reports whether ctx has at least stage's minimum time left, for a step late in a
+// stage that is not worth starting otherwise. A miss is recorded as skipping the stage.
+func stageFits(ctx context.Context, stage string) bool {
+	b := deadlineBudgetFrom(ctx)
+	if b == nil {
+		return true
+	}
+	if d, ok := ctx.Deadline(); ok && time.Until(d) < stageMinimum[stage] {
+		b.skip(stage)
+		return false
+	}
+	return true
+}
+
+// skippedStages lists the stages the budget on ctx has skipped; nil without a budget.
+func skippedStages(ctx context.Context) []string {
+	if b := deadlineBudgetFrom(ctx); b != nil {
+		return b.Skipped()
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+func TestParseStageWeights(t *testing.T) {
+	w, err := ParseStageWeights("openai=6, db=0.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w[StageOpenAI] != 6 || w[StageDB] != 0.5 || w[StageGoogle] != DefaultStageWeights[StageGoogle] {
+		t.Errorf("weights = %v", w)
+	}
+	if DefaultStageWeights[StageOpenAI] != 4 {
+		t.Fatal("parsing changed the defaults")
+	}
+	for _, spec := range []string{"openai", "openai=0", "decision=1", "google=x"} {
+		if _, err := ParseStageWeights(spec); err == nil || !strings.Contains(err.Error(), "want <stage>=<positive weight>") {
+			t.Errorf("%q: err = %v", spec, err)
+		}
+	}
+}
+
+func TestDeadlineBudget_Allot(t *testing.T) {
+	now := time.Now()
+	b := newDeadlineBudget(now.Add(100*time.Second), nil)
+
+	// Shares of what is left, counted over the stage and those after it
+	for stage, want := range map[string]time.Duration{
+		StageGoogle:  30 * time.Second,
+		StageOpenAI:  100 * time.Second * 4 / 7,
+		StageQuality: 100 * time.Second * 2 / 3,
+		StageDB:      100 * time.Second,
+	} {
+		if got := b.allot(stage, now); got.Round(time.Millisecond) != want.Round(time.Millisecond) {
+			t.Errorf("%s: %v, want %v", stage, got, want)
+		}
+	}
+
+	// Time Google did not use goes to the later stages
+	if got := b.allot(StageOpenAI, now.Add(10*time.Second)); got.Round(time.Millisecond) != (90 * time.Second * 4 / 7).Round(time.Millisecond) {
+		t.Errorf("openai after a quick Google stage: %v", got)
+	}
+	if got := b.allot(StageDB, now.Add(101*time.Second)); got > 0 {
+		t.Errorf("past the deadline: %v", got)
+	}
+}
+
+func TestStageContext_SkipsBelowMinimum(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	d, _ := ctx.Deadline()
+	ctx = withDeadlineBudget(ctx, newDeadlineBudget(d, nil))
+
+	// 3s * 3/10 is below Google's 2s minimum; the write's whole 3s is not
+	if _, _, ok := stageContext(ctx, StageGoogle); ok {
+		t.Error("google ran with less than its minimum")
+	}
+	dbCtx, dbCancel, ok := stageContext(ctx, StageDB)
+	defer dbCancel()
+	if !ok {
+		t.Fatal("db skipped")
+	}
+	if dl, _ := dbCtx.Deadline(); dl.After(d) {
+		t.Error("stage deadline past the request's")
+	}
+	if got := skippedStages(ctx); len(got) != 1 || got[0] != StageGoogle {
+		t.Errorf("skipped = %v", got)
+	}
+
+	// Without a budget nothing is bounded or skipped
+	plain := context.Background()
+	if sctx, _, ok := stageContext(plain, StageQuality); !ok || sctx != plain || !stageFits(plain, StageQuality) {
+		t.Error("stage limited without a budget")
+	}
+}
+
+func TestErrDeadlineBudget(t *testing.T) {
+	err := fmt.Errorf("google enrichment: %w", ErrDeadlineBudget)
+	if !errors.Is(err, context.DeadlineExceeded) || errs.CodeOf(err) != errs.CodeDeadlineExceeded {
+		t.Errorf("code = %s, want deadline_exceeded", errs.CodeOf(err))
+	}
+}
@@ -0,0 +1,240 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// DistributedConfig lets several instances share one processing queue in the database.
+// Each instance claims jobs with row locks, heartbeats while it runs, and returns the jobs
+// of instances that stopped heartbeating to the queue.
+type DistributedConfig struct {
+	InstanceID        string // unique per replica
+	Hostname          string
+	HeartbeatInterval time.Duration
+	StaleAfter        time.Duration // heartbeat age after which an instance's jobs are recovered
+	PollInterval      time.Duration // how often idle workers look for new jobs
+	// A job claimed this many times without finishing took its instance down each time;
+	// it is failed to manual review instead of being handed to the next instance.
+	MaxAttempts int
+}
+
+var (
+	mJobsClaimed   = metrics.Default.Counter("processing_queue_claimed_total", "Jobs claimed from the shared processing queue")
+	mJobsRecovered = metrics.Default.Counter("processing_queue_recovered_total", "Jobs returned to the shared queue from stale instances")
+	mJobsAbandoned = metrics.Default.Counter("processing_queue_abandoned_total", "Jobs failed after reaching the claim attempt limit")
+)
+
+type distributed struct {
+	cfg     DistributedConfig
+	queue   domain.JobQueue
+	wake    chan struct{}
+	waiting atomic.Int64 // unclaimed jobs in the shared queue at the last poll
+}
+
+// EnableDistributed switches the engine to the shared queue; call before Start. Queued venues
+// are written to q instead of the in-memory queue, and workers are fed from claimed jobs.
+func (e *ProcessingEngine) EnableDistributed(q domain.JobQueue, cfg DistributedConfig) {
+	e.dist = &distributed{cfg: cfg, queue: q, wake: make(chan struct{}, 1)}
+}
+
+// enqueueShared writes venues to the shared queue and returns how many were added; venues
+// another instance already queued are skipped.
+func (e *ProcessingEngine) enqueueShared(venuesWithUser []models.VenueWithUser, mode Mode, runID int64) (int, error) {
+	jobs := make([]models.QueuedJob, len(venuesWithUser))
+	for i, vw := range venuesWithUser {
+		jobs[i] = models.QueuedJob{
+			VenueID:  vw.Venue.ID,
+			Mode:     string(mode),
+			RunID:    runID,
+			Priority: e.calculatePriorityWithUser(vw.Venue, vw.User),
+		}
+	}
+	n, err := e.dist.queue.EnqueueJobsCtx(e.ctx, jobs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to queue venues: %w", err)
+	}
+	mProcQueued.Inc(int64(n))
+	if skipped := len(jobs) - n; skipped > 0 {
+		log.Printf("Queued %d venues in the shared queue (%s), %d already queued", n, mode, skipped)
+	} else {
+		log.Printf("Queued %d venues in the shared queue (%s)", n, mode)
+	}
+	select {
+	case e.dist.wake <- struct{}{}:
+	default:
+	}
+	return n, nil
+}
+
+// heartbeatLoop keeps this instance registered and recovers the jobs of stale ones.
+func (e *ProcessingEngine) heartbeatLoop() {
+	defer e.wg.Done()
+	d := e.dist
+	t := time.NewTicker(d.cfg.HeartbeatInterval)
+	defer t.Stop()
+
+	for {
+		if err := d.queue.HeartbeatCtx(e.ctx, d.cfg.InstanceID, d.cfg.Hostname); err != nil {
+			log.Printf("[distributed] heartbeat failed: %v", err)
+		}
+		if n, err := d.queue.RecoverOrphanedJobsCtx(e.ctx, d.cfg.StaleAfter); err != nil {
+			log.Printf("[distributed] orphaned job recovery failed: %v", err)
+		} else if n > 0 {
+			log.Printf("[distributed] returned %d jobs of stale instances to the queue", n)
+			mJobsRecovered.Inc(int64(n))
+		}
+		select {
+		case <-t.C:
+		case <-e.shutdown:
+			return
+		}
+	}
+}
+
+// claimLoop keeps the local job queue topped up with jobs claimed from the shared queue.
+// Only about two jobs per worker are held locally, so idle instances can take the rest.
+func (e *ProcessingEngine) claimLoop() {
+	defer e.wg.Done()
+	d := e.dist
+	t := time.NewTicker(d.cfg.PollInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+		case <-d.wake:
+		case <-e.shutdown:
+			return
+		}
+		e.claimOnce()
+	}
+}
+
+func (e *ProcessingEngine) claimOnce() {
+	d := e.dist
+	if n, err := d.queue.CountWaitingJobsCtx(e.ctx); err == nil {
+		d.waiting.Store(int64(n))
+	}
+
+	e.workersMu.Lock()
+	workers := len(e.workerStops)
+	e.workersMu.Unlock()
+	free := workers*autoscaleDepthPerWorker - int(e.stats.queue.Load())
+	if free <= 0 || d.waiting.Load() == 0 {
+		return
+	}
+
+	jobs, err := d.queue.ClaimJobsCtx(e.ctx, d.cfg.InstanceID, free)
+	if err != nil {
+		log.Printf("[distributed] claiming jobs failed: %v", err)
+		return
+	}
+	mJobsClaimed.Inc(int64(len(jobs)))
+	for _, qj := range jobs {
+		if err := e.startClaimed(qj); err != nil {
+			return
+		}
+	}
+}
+
+// startClaimed loads a claimed job's venue and hands it to the workers. A job over the
+// attempt limit goes straight to the failed-result path; a job whose venue cannot be loaded
+// is dropped. Errors only on shutdown, when the remaining claims are released instead.
+func (e *ProcessingEngine) startClaimed(qj models.QueuedJob) error {
+	if qj.Attempts > e.dist.cfg.MaxAttempts {
+		mJobsAbandoned.Inc(1)
+		result := getProcessingResult()
+		result.VenueID = qj.VenueID
+		result.Error = fmt.Errorf("abandoned after %d claims without finishing", qj.Attempts-1)
+		result.Mode = Mode(qj.Mode)
+		result.RunID = qj.RunID
+		result.QueueID = qj.ID
+		select {
+		case e.resultChan <- result:
+			return nil
+		case <-e.ctx.Done():
+			putProcessingResult(result)
+			return fmt.Errorf("processing engine is shutting down")
+		}
+	}
+
+	vw, err := e.repo.GetVenueWithUserByIDCtx(e.ctx, qj.VenueID)
+	if err != nil || vw == nil {
+		// The venue is left as it is; a later batch can queue it again
+		log.Printf("[distributed] dropping job %d: venue %d could not be loaded (err: %v)", qj.ID, qj.VenueID, err)
+		if err := e.dist.queue.CompleteJobCtx(e.ctx, qj.ID); err != nil {
+			log.Printf("[distributed] failed to drop job %d: %v", qj.ID, err)
+		}
+		if qj.RunID != 0 {
+			e.recordSharedRunOutcome(qj.RunID, models.RunOutcomeFailed)
+		}
+		return nil
+	}
+
+	job := getProcessingJob()
+	job.Venue = vw.Venue
+	job.User = vw.User
+	job.Priority = qj.Priority
+	job.Mode = Mode(qj.Mode)
+	job.RunID = qj.RunID
+	job.QueueID = qj.ID
+	select {
+	case e.jobQueue <- job:
+		e.stats.queue.Add(1)
+		mQueueGauge.SetFloat64(float64(e.stats.queue.Load()))
+		return nil
+	case <-e.ctx.Done():
+		putProcessingJob(job)
+		return fmt.Errorf("processing engine is shutting down")
+	}
+}
+
+// completeShared removes a handled job from the shared queue and counts it against its run.
+func (e *ProcessingEngine) completeShared(result *ProcessingResult) {
+	// e.ctx is cancelled during shutdown while the last results are still handled
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := e.dist.queue.CompleteJobCtx(ctx, result.QueueID); err != nil {
+		log.Printf("[distributed] failed to complete job %d (venue %d): %v", result.QueueID, result.VenueID, err)
+	}
+	if result.RunID != 0 && !result.Deferred {
+		e.recordSharedRunOutcome(result.RunID, runOutcome(result))
+	}
+}
+
+func (e *ProcessingEngine) recordSharedRunOutcome(runID int64, outcome string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := e.repo.RecordRunOutcomeCtx(ctx, runID, outcome); err != nil {
+		log.Printf("[distributed] failed to record outcome of run %d: %v", runID, err)
+	}
+}
+
+// releaseShared hands this instance's claimed jobs back to the queue on shutdown.
+func (e *ProcessingEngine) releaseShared() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := e.dist.queue.ReleaseInstanceCtx(ctx, e.dist.cfg.InstanceID); err != nil {
+		log.Printf("[distributed] failed to release claimed jobs: %v", err)
+		return
+	}
+	log.Printf("[distributed] instance %s released its jobs", e.dist.cfg.InstanceID)
+}
+
+// ProcessingInstances lists the instances sharing the queue; distributed is false when the
+// engine processes locally.
+func (e *ProcessingEngine) ProcessingInstances(ctx context.Context) ([]models.ProcessingInstance, bool, error) {
+	if e.dist == nil {
+		return nil, false, nil
+	}
+	instances, err := e.dist.queue.ListProcessingInstancesCtx(ctx)
+	return instances, true, err
+}
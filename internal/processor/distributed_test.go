@@ -0,0 +1,142 @@
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+	"assisted-venue-approval/internal/trust"
+)
+
+func newDistributedTestEngine(repo *testutil.Repository, q *testutil.JobQueue) *ProcessingEngine {
+	e := &ProcessingEngine{
+		repo:       repo,
+		scraper:    testutil.NewMockScraper(),
+		scorer:     testutil.NewMockScorer(),
+		trustCalc:  trust.NewDefault(),
+		jobQueue:   make(chan *ProcessingJob, 4),
+		resultChan: make(chan *ProcessingResult, 4),
+		ctx:        context.Background(),
+		stats:      newEngineStats(1),
+		runs:       newRunTracker(),
+	}
+	e.EnableDistributed(q, DistributedConfig{InstanceID: "a-1", MaxAttempts: 2})
+	return e
+}
+
+func TestStartRun_Distributed_CorrectsCountForAlreadyQueued(t *testing.T) {
+	var setCount int
+	repo := &testutil.Repository{
+		CreateProcessingRunCtxFunc: func(_ context.Context, run *models.ProcessingRun) error {
+			run.ID = 21
+			return nil
+		},
+		SetRunVenueCountCtxFunc: func(_ context.Context, runID int64, n int) error {
+			setCount = n
+			return nil
+		},
+	}
+	var queued []models.QueuedJob
+	q := &testutil.JobQueue{
+		EnqueueJobsCtxFunc: func(_ context.Context, jobs []models.QueuedJob) (int, error) {
+			queued = jobs
+			return len(jobs) - 1, nil // one venue was queued by another instance
+		},
+	}
+	e := newDistributedTestEngine(repo, q)
+
+	venues := []models.VenueWithUser{{Venue: models.Venue{ID: 1}}, {Venue: models.Venue{ID: 2}}, {Venue: models.Venue{ID: 3}}}
+	run, err := e.StartRun(context.Background(), venues, ModeAutoDecide, models.ProcessingRun{Filters: "{}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queued) != 3 || queued[0].RunID != 21 || queued[0].Mode != string(ModeAutoDecide) {
+		t.Fatalf("queued = %+v", queued)
+	}
+	if run.VenueCount != 2 || setCount != 2 {
+		t.Fatalf("venue count = %d, stored %d; want 2", run.VenueCount, setCount)
+	}
+	if len(e.jobQueue) != 0 {
+		t.Fatal("distributed run put jobs on the local queue")
+	}
+	if _, ok := e.ActiveRun(21); ok {
+		t.Fatal("distributed run tracked locally")
+	}
+}
+
+func TestStartClaimed(t *testing.T) {
+	var outcomes []string
+	var completed []int64
+	repo := &testutil.Repository{
+		GetVenueWithUserByIDCtxFunc: func(_ context.Context, id int64) (*models.VenueWithUser, error) {
+			if id == 404 {
+				return nil, nil
+			}
+			return &models.VenueWithUser{Venue: models.Venue{ID: id}}, nil
+		},
+		RecordRunOutcomeCtxFunc: func(_ context.Context, _ int64, outcome string) error {
+			outcomes = append(outcomes, outcome)
+			return nil
+		},
+	}
+	q := &testutil.JobQueue{
+		CompleteJobCtxFunc: func(_ context.Context, id int64) error {
+			completed = append(completed, id)
+			return nil
+		},
+	}
+	e := newDistributedTestEngine(repo, q)
+
+	// Regular job goes to the workers
+	if err := e.startClaimed(models.QueuedJob{ID: 1, VenueID: 7, Mode: string(ModeScoreOnly), RunID: 5, Attempts: 1}); err != nil {
+		t.Fatal(err)
+	}
+	job := <-e.jobQueue
+	if job.Venue.ID != 7 || job.QueueID != 1 || job.RunID != 5 || job.Mode != ModeScoreOnly {
+		t.Fatalf("job = %+v", job)
+	}
+
+	// Over the attempt limit: failed without processing
+	if err := e.startClaimed(models.QueuedJob{ID: 2, VenueID: 8, RunID: 5, Attempts: 3}); err != nil {
+		t.Fatal(err)
+	}
+	result := <-e.resultChan
+	if result.Success || result.Error == nil || result.QueueID != 2 || result.VenueID != 8 {
+		t.Fatalf("result = %+v", result)
+	}
+
+	// Missing venue: dropped and counted as failed
+	if err := e.startClaimed(models.QueuedJob{ID: 3, VenueID: 404, RunID: 5, Attempts: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if len(completed) != 1 || completed[0] != 3 || len(outcomes) != 1 || outcomes[0] != models.RunOutcomeFailed {
+		t.Fatalf("completed = %v, outcomes = %v", completed, outcomes)
+	}
+	if len(e.jobQueue) != 0 {
+		t.Fatal("dropped job reached the workers")
+	}
+}
+
+func TestCompleteShared_CountsRunOutcome(t *testing.T) {
+	var outcome string
+	var completed int64
+	repo := &testutil.Repository{
+		RecordRunOutcomeCtxFunc: func(_ context.Context, runID int64, o string) error {
+			outcome = o
+			return nil
+		},
+	}
+	q := &testutil.JobQueue{
+		CompleteJobCtxFunc: func(_ context.Context, id int64) error {
+			completed = id
+			return nil
+		},
+	}
+	e := newDistributedTestEngine(repo, q)
+
+	e.completeShared(&ProcessingResult{QueueID: 9, RunID: 5, Success: true, ValidationResult: &models.ValidationResult{Status: "rejected"}})
+	if completed != 9 || outcome != models.RunOutcomeRejected {
+		t.Fatalf("completed %d, outcome %q", completed, outcome)
+	}
+}
@@ -3,10 +3,11 @@ package processor
 import (
 	"context"
 	"fmt"
-	"math"
+	"strconv"
 	"strings"
 
 	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/embeddings"
 	"assisted-venue-approval/internal/models"
 	"assisted-venue-approval/internal/trust"
 	"assisted-venue-approval/pkg/utils"
@@ -23,6 +24,8 @@ const (
 type EarlyExitReason struct {
 	Code        string
 	Description string
+	// Reject auto-rejects the venue instead of sending it to manual review
+	Reject bool
 }
 
 // String returns the description for logging/display
@@ -85,12 +88,50 @@ var (
 		}
 	}
 
+	PoorSubmissionHistory = func(h *models.SubmissionHistory) EarlyExitReason {
+		desc := "Submitter's recent submissions were mostly rejected - requires manual review"
+		if h != nil {
+			desc = fmt.Sprintf("Submitter has a poor submission history (%d of %d rejected, last %d rejected) - requires manual review",
+				h.Rejected, h.Decided(), h.RejectionStreak)
+		}
+		return EarlyExitReason{Code: "poor_submission_history", Description: desc}
+	}
+
+	SubmitterRuleMatched = func(r *models.SubmitterRule) EarlyExitReason {
+		desc := fmt.Sprintf("Submitter matches %s rule #%d (%s %s)", r.Action, r.ID, r.Kind, r.Value)
+		if r.Note != "" {
+			desc += ": " + r.Note
+		}
+		if r.Action == models.SubmitterActionReject {
+			return EarlyExitReason{Code: "submitter_blocked", Description: desc, Reject: true}
+		}
+		return EarlyExitReason{Code: "submitter_manual_review", Description: desc + " - requires manual review"}
+	}
+
 	DuplicateVenue = func(duplicateID int64, duplicateName string, distanceMeters int, similarity float64) EarlyExitReason {
 		return EarlyExitReason{
 			Code:        "duplicate_venue",
 			Description: fmt.Sprintf("Possible duplicate venue found: '%s' (ID: %d) within %dm (%.0f%% name match) - requires manual review", duplicateName, duplicateID, distanceMeters, similarity*100),
 		}
 	}
+
+	NearDuplicateText = func(m embeddings.Match) EarlyExitReason {
+		return EarlyExitReason{
+			Code:        "near_duplicate_text",
+			Description: fmt.Sprintf("Name and description nearly identical to venue %d (%.0f%% similar) - requires manual review", m.VenueID, m.Similarity*100),
+		}
+	}
+
+	TemplatedText = func(matches []embeddings.Match) EarlyExitReason {
+		ids := make([]string, 0, 3)
+		for i := 0; i < len(matches) && i < 3; i++ {
+			ids = append(ids, strconv.FormatInt(matches[i].VenueID, 10))
+		}
+		return EarlyExitReason{
+			Code:        "templated_text",
+			Description: fmt.Sprintf("Description shared with %d other venues (e.g. %s), likely templated spam - requires manual review", len(matches), strings.Join(ids, ", ")),
+		}
+	}
 )
 
 // checkMinimumPoints verifies user meets minimum contributions requirement
@@ -109,6 +150,14 @@ func checkMinimumPoints(user *models.User, minUserPoints int) (skip bool, reason
 	return false, EarlyExitReason{}
 }
 
+// checkSubmitterRule applies an admin block entry for the submitter; allow entries never skip.
+func checkSubmitterRule(rule *models.SubmitterRule) (skip bool, reason EarlyExitReason) {
+	if rule == nil || rule.Action == models.SubmitterActionAlwaysAVA {
+		return false, EarlyExitReason{}
+	}
+	return true, SubmitterRuleMatched(rule)
+}
+
 // checkTrustLevel verifies user has sufficient trust for automated review
 func checkTrustLevel(trustAssessment *trust.Assessment) (skip bool, reason EarlyExitReason) {
 	if trustAssessment == nil {
@@ -121,6 +170,11 @@ func checkTrustLevel(trustAssessment *trust.Assessment) (skip bool, reason Early
 		return true, NonTrustedUser(trustAssessment.Trust, trustAssessment.Authority)
 	}
 
+	// Repeat low-quality submitters go to an editor without spending on Google and OpenAI
+	if trustAssessment.PoorHistory {
+		return true, PoorSubmissionHistory(trustAssessment.Inputs.History)
+	}
+
 	return false, EarlyExitReason{}
 }
 
@@ -167,7 +221,7 @@ func checkRestaurantCategory(venue *models.Venue) (skip bool, reason EarlyExitRe
 }
 
 // checkDuplicateVenue searches for potential duplicate venues by name and location
-func checkDuplicateVenue(ctx context.Context, repo domain.Repository, venue *models.Venue) (skip bool, reason EarlyExitReason) {
+func checkDuplicateVenue(ctx context.Context, repo domain.VenueReader, venue *models.Venue) (skip bool, reason EarlyExitReason) {
 	// Skip check if venue doesn't have location data
 	if venue.Lat == nil || venue.Lng == nil || *venue.Lat == 0.0 || *venue.Lng == 0.0 {
 		return false, EarlyExitReason{}
@@ -196,7 +250,7 @@ func checkDuplicateVenue(ctx context.Context, repo domain.Repository, venue *mod
 			continue
 		}
 
-		distance := calculateHaversineDistance(*venue.Lat, *venue.Lng, *dup.Lat, *dup.Lng)
+		distance := utils.HaversineMeters(*venue.Lat, *venue.Lng, *dup.Lat, *dup.Lng)
 
 		// Calculate name similarity
 		similarity := utils.CalculateStringSimilarity(
@@ -212,22 +266,3 @@ func checkDuplicateVenue(ctx context.Context, repo domain.Repository, venue *mod
 
 	return false, EarlyExitReason{}
 }
-
-// calculateHaversineDistance computes the great-circle distance between two lat/lng points in meters
-func calculateHaversineDistance(lat1, lng1, lat2, lng2 float64) float64 {
-	const earthRadiusMeters = 6371000
-
-	// Convert to radians
-	lat1Rad := lat1 * (math.Pi / 180)
-	lat2Rad := lat2 * (math.Pi / 180)
-	deltaLatRad := (lat2 - lat1) * (math.Pi / 180)
-	deltaLngRad := (lng2 - lng1) * (math.Pi / 180)
-
-	// Haversine formula
-	a := math.Sin(deltaLatRad/2)*math.Sin(deltaLatRad/2) +
-		math.Cos(lat1Rad)*math.Cos(lat2Rad)*
-			math.Sin(deltaLngRad/2)*math.Sin(deltaLngRad/2)
-	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
-
-	return earthRadiusMeters * c
-}
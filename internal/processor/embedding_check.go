@@ -0,0 +1,151 @@
+package processor
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/embeddings"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+var mEmbeddingChecks = metrics.Default.CounterVec("embedding_checks_total",
+	"Embedding checks, by outcome (clear, near_duplicate, templated, short_text, error)", "outcome")
+
+// minEmbeddingDescription is the shortest description worth comparing: names alone, or
+// with a line of text, match too many unrelated venues.
+const minEmbeddingDescription = 40
+
+// EmbeddingCheckConfig tunes the near-duplicate and templated text check.
+type EmbeddingCheckConfig struct {
+	DuplicateThreshold float64       // similarity to one venue that makes a near-duplicate
+	SpamThreshold      float64       // similarity counted towards templated text
+	SpamMinMatches     int           // venues at SpamThreshold that make text templated
+	Lookback           time.Duration // how far back venues are compared
+	IndexLimit         int           // most vectors held in memory
+	Refresh            time.Duration // how often other instances' vectors are loaded
+}
+
+// embeddingCheck compares each venue's text with the recent venues held in index. The index
+// is filled from the store on first use and then topped up with vectors written since.
+type embeddingCheck struct {
+	cfg      EmbeddingCheckConfig
+	store    domain.EmbeddingStore
+	embedder embeddings.Embedder
+	index    *embeddings.Index
+
+	mu       sync.Mutex
+	loadedAt time.Time // last load attempt
+	latest   time.Time // newest vector loaded from the store
+}
+
+// EnableEmbeddingCheck sends venues whose name and description nearly repeat a recent
+// venue's, or that are shared with several recent venues, to manual review before any
+// Google or scoring call. Call before Start.
+func (e *ProcessingEngine) EnableEmbeddingCheck(store domain.EmbeddingStore, emb embeddings.Embedder, cfg EmbeddingCheckConfig) {
+	e.embeddingCheck = &embeddingCheck{cfg: cfg, store: store, embedder: emb, index: embeddings.NewIndex()}
+}
+
+// checkSimilarText embeds the venue's text and compares it with recent venues. Templated
+// text is reported before a near-duplicate. Failures never hold a venue back.
+func (e *ProcessingEngine) checkSimilarText(ctx context.Context, venue *models.Venue) (bool, EarlyExitReason) {
+	c := e.embeddingCheck
+	if c == nil {
+		return false, EarlyExitReason{}
+	}
+	if utf8.RuneCountInString(strings.TrimSpace(venue.VDetails)) < minEmbeddingDescription {
+		mEmbeddingChecks.With("short_text").Inc()
+		return false, EarlyExitReason{}
+	}
+	vec, err := c.vector(ctx, venue)
+	if err != nil {
+		logf(ctx, models.LogLevelWarn, "[embeddings] venue %d not checked: %v", venue.ID, err)
+		mEmbeddingChecks.With("error").Inc()
+		return false, EarlyExitReason{}
+	}
+	c.refresh(ctx)
+
+	min := c.cfg.SpamThreshold
+	if c.cfg.DuplicateThreshold < min {
+		min = c.cfg.DuplicateThreshold
+	}
+	matches := c.index.Similar(vec, min, venue.ID)
+	c.index.Add(venue.ID, vec, time.Now())
+
+	var templated []embeddings.Match
+	for _, m := range matches {
+		if m.Similarity >= c.cfg.SpamThreshold {
+			templated = append(templated, m)
+		}
+	}
+	switch {
+	case len(templated) >= c.cfg.SpamMinMatches:
+		mEmbeddingChecks.With("templated").Inc()
+		return true, TemplatedText(templated)
+	case len(matches) > 0 && matches[0].Similarity >= c.cfg.DuplicateThreshold:
+		mEmbeddingChecks.With("near_duplicate").Inc()
+		return true, NearDuplicateText(matches[0])
+	}
+	mEmbeddingChecks.With("clear").Inc()
+	return false, EarlyExitReason{}
+}
+
+// vector returns the venue's stored vector when its text is unchanged, or embeds and stores it.
+func (c *embeddingCheck) vector(ctx context.Context, venue *models.Venue) ([]float32, error) {
+	text := embeddings.VenueText(*venue)
+	hash := embeddings.TextHash(text)
+	model := c.embedder.Model()
+	stored, err := c.store.GetVenueEmbeddingCtx(ctx, venue.ID, model)
+	if err != nil {
+		logf(ctx, models.LogLevelWarn, "[embeddings] failed to load stored vector of venue %d: %v", venue.ID, err)
+	} else if stored != nil && stored.TextHash == hash {
+		return stored.Vector, nil
+	}
+
+	vec, err := c.embedder.Embed(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	emb := &models.VenueEmbedding{VenueID: venue.ID, Model: model, TextHash: hash, Vector: vec}
+	if err := c.store.SaveVenueEmbeddingCtx(ctx, emb); err != nil {
+		// Still compared here; other instances will not see it
+		logf(ctx, models.LogLevelWarn, "[embeddings] failed to store vector of venue %d: %v", venue.ID, err)
+	}
+	return vec, nil
+}
+
+// refresh loads the vectors stored since the last load, at most once per Refresh, and drops
+// those that fell out of the lookback window. A failed load keeps the index as it is.
+func (c *embeddingCheck) refresh(ctx context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if time.Since(c.loadedAt) < c.cfg.Refresh {
+		return
+	}
+	c.loadedAt = time.Now()
+
+	cutoff := time.Now().Add(-c.cfg.Lookback)
+	since := cutoff
+	// Overlap by a minute: updated_at has one-second precision and concurrent writes commit
+	// out of order. Vectors loaded twice just replace themselves.
+	if overlap := c.latest.Add(-time.Minute); overlap.After(since) {
+		since = overlap
+	}
+	list, err := c.store.ListVenueEmbeddingsCtx(ctx, c.embedder.Model(), since, c.cfg.IndexLimit)
+	if err != nil {
+		log.Printf("[embeddings] reload failed, keeping %d vectors: %v", c.index.Len(), err)
+		return
+	}
+	for _, emb := range list {
+		c.index.Add(emb.VenueID, emb.Vector, emb.UpdatedAt)
+		if emb.UpdatedAt.After(c.latest) {
+			c.latest = emb.UpdatedAt
+		}
+	}
+	c.index.Prune(cutoff, c.cfg.IndexLimit)
+}
@@ -0,0 +1,121 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+)
+
+type fakeEmbedder struct {
+	vectors map[string][]float32 // by description
+	calls   int
+}
+
+func (f *fakeEmbedder) Model() string { return "fake/3" }
+
+func (f *fakeEmbedder) Embed(_ context.Context, text string) ([]float32, error) {
+	f.calls++
+	for desc, v := range f.vectors {
+		if strings.HasSuffix(text, desc) {
+			return v, nil
+		}
+	}
+	return nil, errors.New("unknown text")
+}
+
+func TestCheckSimilarText(t *testing.T) {
+	const (
+		template = "Best vegan food in town, order now at our website for discounts!!"
+		original = "Family-run ramen bar with a fully plant-based menu and house-made noodles."
+		unique   = "Small bakery selling sourdough, vegan croissants and seasonal fruit tarts."
+		other    = "Another description entirely, about a juice bar near the central station."
+	)
+	now := time.Now()
+	stored := []models.VenueEmbedding{
+		{VenueID: 11, Vector: []float32{1, 0, 0}, UpdatedAt: now},
+		{VenueID: 12, Vector: []float32{1, 0.05, 0}, UpdatedAt: now},
+		{VenueID: 13, Vector: []float32{1, 0, 0.05}, UpdatedAt: now},
+		{VenueID: 20, Vector: []float32{0, 1, 0}, UpdatedAt: now},
+	}
+	var saved []models.VenueEmbedding
+	store := &testutil.EmbeddingStore{
+		GetVenueEmbeddingCtxFunc: func(_ context.Context, venueID int64, _ string) (*models.VenueEmbedding, error) {
+			if venueID == 4 {
+				return &models.VenueEmbedding{VenueID: 4, TextHash: "stale", Vector: []float32{0, 0, 1}}, nil
+			}
+			return nil, nil
+		},
+		SaveVenueEmbeddingCtxFunc: func(_ context.Context, emb *models.VenueEmbedding) error {
+			saved = append(saved, *emb)
+			return nil
+		},
+		ListVenueEmbeddingsCtxFunc: func(context.Context, string, time.Time, int) ([]models.VenueEmbedding, error) {
+			return stored, nil
+		},
+	}
+	emb := &fakeEmbedder{vectors: map[string][]float32{
+		template: {1, 0.02, 0.02},
+		original: {0.02, 1, 0},
+		unique:   {0, 0, 1},
+		other:    {0, 0.7, 0.7},
+	}}
+	e := &ProcessingEngine{}
+	e.EnableEmbeddingCheck(store, emb, EmbeddingCheckConfig{
+		DuplicateThreshold: 0.95,
+		SpamThreshold:      0.9,
+		SpamMinMatches:     3,
+		Lookback:           24 * time.Hour,
+		IndexLimit:         100,
+		Refresh:            time.Minute,
+	})
+
+	tests := []struct {
+		name     string
+		venue    models.Venue
+		wantCode string
+	}{
+		{"templated", models.Venue{ID: 1, Name: "Promo", VDetails: template}, "templated_text"},
+		{"near duplicate", models.Venue{ID: 2, Name: "Ramen", VDetails: original}, "near_duplicate_text"},
+		{"short description", models.Venue{ID: 3, Name: "Cafe", VDetails: "Vegan cafe."}, ""},
+		{"changed text is embedded again", models.Venue{ID: 4, Name: "Bakery", VDetails: unique}, ""},
+		{"unrelated", models.Venue{ID: 5, Name: "Juice", VDetails: other}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skip, reason := e.checkSimilarText(context.Background(), &tt.venue)
+			if skip != (tt.wantCode != "") || reason.Code != tt.wantCode || reason.Reject {
+				t.Fatalf("got skip=%v %+v, want code %q", skip, reason, tt.wantCode)
+			}
+		})
+	}
+	if emb.calls != 4 || len(saved) != 4 {
+		t.Fatalf("embedded %d and saved %d texts, want 4 each", emb.calls, len(saved))
+	}
+
+	// A vector stored for the same text is reused, and a venue never matches itself
+	store.GetVenueEmbeddingCtxFunc = func(context.Context, int64, string) (*models.VenueEmbedding, error) {
+		return &saved[2], nil
+	}
+	if skip, _ := e.checkSimilarText(context.Background(), &models.Venue{ID: 4, Name: "Bakery", VDetails: unique}); skip || emb.calls != 4 {
+		t.Fatalf("re-check: skip=%v calls=%d", skip, emb.calls)
+	}
+}
+
+func TestCheckSimilarText_EmbedFailureLetsVenueThrough(t *testing.T) {
+	store := &testutil.EmbeddingStore{
+		GetVenueEmbeddingCtxFunc: func(context.Context, int64, string) (*models.VenueEmbedding, error) {
+			return nil, errors.New("db down")
+		},
+	}
+	e := &ProcessingEngine{}
+	e.EnableEmbeddingCheck(store, &fakeEmbedder{}, EmbeddingCheckConfig{DuplicateThreshold: 0.95, SpamThreshold: 0.9, SpamMinMatches: 3})
+	venue := models.Venue{ID: 1, Name: "Leaf", VDetails: strings.Repeat("vegan ", 20)}
+	if skip, reason := e.checkSimilarText(context.Background(), &venue); skip {
+		t.Fatalf("venue held back on failure: %+v", reason)
+	}
+}
@@ -3,6 +3,7 @@ package processor
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -12,10 +13,13 @@ import (
 
 	"assisted-venue-approval/internal/decision"
 	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/flags"
 	"assisted-venue-approval/internal/models"
 	"assisted-venue-approval/internal/trust"
+	errs "assisted-venue-approval/pkg/errors"
 	"assisted-venue-approval/pkg/events"
 	"assisted-venue-approval/pkg/metrics"
+	"assisted-venue-approval/pkg/monitoring"
 )
 
 // ProcessingJob represents a venue processing job
@@ -24,6 +28,19 @@ type ProcessingJob struct {
 	User     models.User // User who submitted the venue
 	Priority int         // Higher values = higher priority
 	Retry    int         // Retry attempt count
+	Mode     Mode        // What to do with the result; set per run
+	RunID    int64       // processing run the job belongs to; 0 for none
+	QueueID  int64       // shared queue row in distributed mode; 0 for local jobs
+
+	values context.Context // caller's context values (e.g. a score stream) for sync jobs
+	sync   bool            // run by ProcessSingleVenueSync rather than a worker
+}
+
+// checkpointed reports whether the job's progress is checkpointed. Dry runs leave no trace
+// outside the sandbox, and an interrupted sync review is retried by the reviewer, so neither
+// reads, records nor deletes checkpoints.
+func (j *ProcessingJob) checkpointed() bool {
+	return j.Mode.persists() && !j.sync
 }
 
 // ProcessingResult represents the result of processing a venue
@@ -35,6 +52,14 @@ type ProcessingResult struct {
 	Error            error
 	ProcessingTimeMs int64
 	Retries          int
+	Mode             Mode
+	RunID            int64
+	QueueID          int64
+	Deferred         bool                     // AI scoring went to a batch job; the result arrives when it is ingested
+	Skipped          []string                 // stages (Stage*) left out to meet a synchronous caller's deadline
+	Progress         *models.PipelineProgress // how far a failed result got; set when it is handled
+
+	jobLog *jobLog // log lines of the job, stored with its result
 }
 
 // Reset clears a ProcessingJob for reuse
@@ -43,6 +68,10 @@ func (j *ProcessingJob) Reset() {
 	j.User = models.User{}
 	j.Priority = 0
 	j.Retry = 0
+	j.Mode = ""
+	j.RunID = 0
+	j.QueueID = 0
+	j.values = nil
 }
 
 // Reset clears a ProcessingResult for reuse
@@ -54,6 +83,13 @@ func (r *ProcessingResult) Reset() {
 	r.Error = nil
 	r.ProcessingTimeMs = 0
 	r.Retries = 0
+	r.Mode = ""
+	r.RunID = 0
+	r.QueueID = 0
+	r.Deferred = false
+	r.Skipped = nil
+	r.Progress = nil
+	r.jobLog = nil
 }
 
 // Pools and stats for hot-path objects
@@ -83,6 +119,7 @@ var (
 	mQueueGauge       = metrics.Default.Gauge("venue_processing_queue_size", "Current processing queue size")
 	mApiGoogle        = metrics.Default.Counter("google_api_calls_total", "Google Maps API calls")
 	mApiOpenAI        = metrics.Default.Counter("openai_api_calls_total", "OpenAI API calls")
+	mGoogleCacheHits  = metrics.Default.Counter("google_cache_reused_total", "Validations that reused cached Google data instead of calling Places")
 	mDecisionAutoAppr = metrics.Default.Counter("decision_auto_approved_total", "Auto-approved venues")
 	mDecisionAutoRej  = metrics.Default.Counter("decision_auto_rejected_total", "Auto-rejected venues")
 	mDecisionManual   = metrics.Default.Counter("decision_manual_review_total", "Venues sent to manual review")
@@ -116,117 +153,22 @@ func putProcessingResult(r *ProcessingResult) {
 	resultPool.Put(r)
 }
 
-// ProcessingStats tracks processing statistics
-type ProcessingStats struct {
-	TotalJobs      int64
-	CompletedJobs  int64
-	SuccessfulJobs int64
-	FailedJobs     int64
-	AutoApproved   int64
-	ManualReview   int64
-	AutoRejected   int64
-	AverageTimeMs  int64
-	StartTime      time.Time
-	LastActivity   time.Time
-	WorkerCount    int
-	QueueSize      int64
-	APICallsGoogle int64
-	APICallsOpenAI int64
-	TotalCostUSD   float64
-
-	// Pool stats (hot paths)
-	JobPoolGets      int64
-	JobPoolPuts      int64
-	JobPoolMisses    int64
-	ResultPoolGets   int64
-	ResultPoolPuts   int64
-	ResultPoolMisses int64
-	BufferPoolGets   int64
-	BufferPoolPuts   int64
-	BufferPoolMisses int64
-}
-
-// RateLimiter implements token bucket rate limiting
-type RateLimiter struct {
-	tokens   chan struct{}
-	interval time.Duration
-	capacity int
-	ticker   *time.Ticker
-	mu       sync.Mutex
-	running  bool
-}
-
-func NewRateLimiter(rps int, burst int) *RateLimiter {
-	if rps <= 0 {
-		rps = 1
-	}
-	if burst <= 0 {
-		burst = rps
-	}
-
-	rl := &RateLimiter{
-		tokens:   make(chan struct{}, burst),
-		interval: time.Duration(1000000000/rps) * time.Nanosecond,
-		capacity: burst,
-	}
-
-	// Fill initial tokens
-	for i := 0; i < burst; i++ {
-		rl.tokens <- struct{}{}
-	}
-
-	return rl
-}
-
-func (rl *RateLimiter) Start() {
-	rl.mu.Lock()
-	defer rl.mu.Unlock()
-
-	if rl.running {
-		return
-	}
-
-	rl.ticker = time.NewTicker(rl.interval)
-	rl.running = true
-
-	go func() {
-		for range rl.ticker.C {
-			select {
-			case rl.tokens <- struct{}{}:
-			default:
-				// Bucket is full, drop token
-			}
-		}
-	}()
-}
-
-func (rl *RateLimiter) Stop() {
-	rl.mu.Lock()
-	defer rl.mu.Unlock()
-
-	if !rl.running {
-		return
-	}
-
-	rl.ticker.Stop()
-	rl.running = false
-}
-
-func (rl *RateLimiter) Wait(ctx context.Context) error {
-	select {
-	case <-rl.tokens:
-		return nil
-	case <-ctx.Done():
-		return ctx.Err()
-	}
-}
-
 // ProcessingEngine handles concurrent venue processing with rate limiting and error recovery
 // GoogleScraper abstracts the Google Maps integration used by the engine.
 type GoogleScraper interface {
 	EnhanceVenueWithValidation(ctx context.Context, venue models.Venue) (*models.Venue, error)
 }
 
+// GoogleCostReporter is implemented by scrapers that estimate their Google Places spend.
+type GoogleCostReporter interface {
+	PlacesUsage() []models.PlacesSKUUsage
+}
+
+// GoogleKeyReporter is implemented by scrapers that spread requests over several API keys.
+type GoogleKeyReporter interface {
+	KeyUsage() []models.GoogleKeyUsage
+}
+
 // VenueScorer abstracts the AI scoring used by the engine.
 type VenueScorer interface {
 	ScoreVenue(ctx context.Context, venue models.Venue, user models.User) (*models.ValidationResult, error)
@@ -250,20 +192,37 @@ type ProcessingEngine struct {
 	eventStore      events.EventStore
 
 	// Configuration
-	workerCount int
-	maxRetries  int
-	retryDelay  time.Duration
-	jobTimeout  time.Duration
+	workerCount  int
+	retry        RetryPolicy
+	jobTimeout   time.Duration
+	stageWeights map[string]float64
 	// AVA qualification configuration
 	avaConfigMu         sync.RWMutex
 	minUserPointsForAVA int
 	onlyAmbassadors     bool
-
-	// Mode flags
-	scoreOnly bool
+	// Auto-reject pre-filter rules (guarded by avaConfigMu)
+	prefilter PrefilterConfig
+	// Optional vision check on Google photos (guarded by avaConfigMu)
+	photoReviewer PhotoReviewer
+	photoCfg      PhotoCheckConfig
+	photoBudget   photoBudget
+	// When the quality reviewer runs; nil reviews every scored venue (guarded by avaConfigMu)
+	qualityCfg *QualityReviewConfig
+	// Optional website verification (guarded by avaConfigMu)
+	websiteChecker WebsiteChecker
+	// Optional Facebook/Instagram profile checks (guarded by avaConfigMu)
+	socialVerifier SocialVerifier
+	// Optional translation of non-English descriptions before scoring (guarded by avaConfigMu)
+	translator          Translator
+	translationProvider string
+
+	// Batched result writes; nil writes each result on its own
+	batcher       *resultBatcher
+	flushInterval time.Duration
 
 	// Rate limiters
 	googleRateLimit *RateLimiter
+	photoRateLimit  *RateLimiter
 	openAIRateLimit *RateLimiter
 
 	// Processing control
@@ -277,10 +236,41 @@ type ProcessingEngine struct {
 	workersMu    sync.Mutex
 	workerStops  []chan struct{}
 	nextWorkerID int
+	// Optional load-based pool sizing, fed by the outcomes of external API calls
+	autoscale AutoscaleConfig
+	apiWindow apiWindow
+	// Shared database queue when several instances process together; nil for local only
+	dist *distributed
+	// Progress of in-flight jobs, stored on shutdown; nil when checkpointing is off
+	checkpoints *checkpointer
+	// Cached submitter histories for trust assessment; nil when reputation is off
+	reputation *reputationCache
+	// Admin-managed submitter block and allow lists; nil when off
+	submitterRules *submitterRules
+	// OpenAI Batch API scoring for ModeBatch; nil scores those jobs synchronously
+	batch *batchScoring
+	// Stored feature flags; nil leaves every capability to its config
+	flags *flags.Service
+	// Re-scoring of venues scored under older prompts or decision config; nil when off
+	rescore *rescorer
+	// Near-duplicate and templated text detection by embeddings; nil when off
+	embeddingCheck *embeddingCheck
+	// Malware/phishing scanning of submitted links; nil when off
+	urlScan *urlScan
+	// Category suggestions from Google types, name and description
+	categoryCheck bool
+	// Vegan status classification from description, website and reviews; nil when off
+	veganClassifier VeganClassifier
+	// Google reviews for the vegan status check; nil leaves reviews out
+	reviewFetcher ReviewFetcher
 
 	// Statistics
-	stats   ProcessingStats
-	statsMu sync.RWMutex
+	stats *engineStats
+	// Processing runs with venues still outstanding
+	runs *runTracker
+
+	// Start runs once; handlers call it before every batch
+	startOnce sync.Once
 
 	// Shutdown control
 	shutdown     chan struct{}
@@ -290,34 +280,50 @@ type ProcessingEngine struct {
 // ProcessingConfig holds configuration for the processing engine
 type ProcessingConfig struct {
 	WorkerCount int
-	MaxRetries  int
-	RetryDelay  time.Duration
+	Retry       RetryPolicy // per-error-class retry budgets and backoff
 	JobTimeout  time.Duration
-	GoogleRPS   int // Google Places API requests per second
-	GoogleBurst int // Google Places API burst capacity
-	OpenAIRPS   int // OpenAI API requests per second
-	OpenAIBurst int // OpenAI API burst capacity
-	QueueSize   int // Job queue buffer size
+	GoogleRPS   int // Google Places tokens per second (Text Search takes 2, Place Details 1); 0 = unlimited
+	GoogleBurst int // Google Places burst capacity
+	PhotoRPS    int // Google photo downloads per second; 0 = unlimited
+	PhotoBurst  int // Google photo burst capacity
+	OpenAIRPS   int // OpenAI tokens per second (scoring takes 1, a photo review 2); 0 = unlimited
+	OpenAIBurst int // OpenAI burst capacity
+	// Shares of the rates above, and of daily quotas, per region; no partitions shares nothing
+	Quota     QuotaConfig
+	QueueSize int // Job queue buffer size
 	// Automatic Venue Approval (AVA) qualification requirements
 	MinUserPointsForAVA int  // Minimum ambassador points required for automated reviews (0 = disabled)
 	OnlyAmbassadors     bool // If true, only ambassadors can submit for automated review
+	// Auto-reject pre-filter for obviously invalid submissions
+	Prefilter PrefilterConfig
+	// Results written per transaction (1 = each result on its own) and the longest a result
+	// waits for its batch to fill
+	ResultBatchSize     int
+	ResultFlushInterval time.Duration
+	// How a synchronous review's deadline is split between stages (Stage*); nil is
+	// DefaultStageWeights
+	StageWeights map[string]float64
 }
 
 // DefaultProcessingConfig returns a sensible default configuration optimized for cost efficiency
 func DefaultProcessingConfig() ProcessingConfig {
 	return ProcessingConfig{
 		WorkerCount: 15, // Increased workers for better throughput
-		MaxRetries:  3,
-		RetryDelay:  5 * time.Second,
+		Retry:       DefaultRetryPolicy(),
 		JobTimeout:  90 * time.Second, // Increased timeout for complex venues
 		GoogleRPS:   15,               // Optimized rate for Google Places API (within quota limits)
 		GoogleBurst: 30,               // Higher burst for peak processing
+		PhotoRPS:    5,                // Photo check fetches up to a few photos per venue
+		PhotoBurst:  10,               // Two venues' worth of photos
 		OpenAIRPS:   8,                // Optimized rate for OpenAI API (cost-conscious)
 		OpenAIBurst: 15,               // Controlled burst to manage costs
 		QueueSize:   2000,             // Larger queue for batch processing
 		// AVA qualification defaults - cost-optimized
 		MinUserPointsForAVA: 150,
 		OnlyAmbassadors:     false,
+		Prefilter:           DefaultPrefilterConfig(),
+		ResultBatchSize:     50,
+		ResultFlushInterval: 2 * time.Second,
 	}
 }
 
@@ -337,44 +343,98 @@ func NewProcessingEngine(repo domain.Repository, uowFactory domain.UnitOfWorkFac
 		decisionEngine:      decisionEngine,
 		trustCalc:           trust.NewDefault(),
 		workerCount:         config.WorkerCount,
-		maxRetries:          config.MaxRetries,
-		retryDelay:          config.RetryDelay,
+		retry:               config.Retry,
 		jobTimeout:          config.JobTimeout,
+		stageWeights:        config.StageWeights,
 		minUserPointsForAVA: config.MinUserPointsForAVA,
 		onlyAmbassadors:     config.OnlyAmbassadors,
-		googleRateLimit:     NewRateLimiter(config.GoogleRPS, config.GoogleBurst),
-		openAIRateLimit:     NewRateLimiter(config.OpenAIRPS, config.OpenAIBurst),
+		prefilter:           config.Prefilter,
+		googleRateLimit:     NewRateLimiter(EndpointGooglePlaces, config.GoogleRPS, config.GoogleBurst),
+		photoRateLimit:      NewRateLimiter(EndpointGooglePhotos, config.PhotoRPS, config.PhotoBurst),
+		openAIRateLimit:     NewRateLimiter(EndpointOpenAI, config.OpenAIRPS, config.OpenAIBurst),
 		jobQueue:            make(chan *ProcessingJob, config.QueueSize),
 		resultChan:          make(chan *ProcessingResult, config.QueueSize),
 		ctx:                 ctx,
 		cancel:              cancel,
 		shutdown:            make(chan struct{}),
-		scoreOnly:           false,
-		stats: ProcessingStats{
-			StartTime:    time.Now(),
-			LastActivity: time.Now(),
-			WorkerCount:  config.WorkerCount,
-		},
+		stats:               newEngineStats(config.WorkerCount),
+		runs:                newRunTracker(),
+	}
+	engine.googleRateLimit.Partition(config.Quota, config.GoogleRPS, config.GoogleBurst)
+	engine.photoRateLimit.Partition(config.Quota, config.PhotoRPS, config.PhotoBurst)
+	engine.openAIRateLimit.Partition(config.Quota, config.OpenAIRPS, config.OpenAIBurst)
+
+	if config.ResultBatchSize > 1 && uowFactory != nil {
+		engine.batcher = newResultBatcher(uowFactory, config.ResultBatchSize)
+		engine.batcher.onWrite = func(n int, took time.Duration) {
+			// Each result's share of the batch
+			for i := 0; i < n; i++ {
+				engine.stats.observeStage(StageDB, took/time.Duration(n))
+			}
+		}
+		engine.flushInterval = config.ResultFlushInterval
+		if engine.flushInterval <= 0 {
+			engine.flushInterval = 2 * time.Second
+		}
 	}
 
 	return engine
 }
 
 // ApplyConfig applies runtime-configurable changes safely.
-// Supports resizing worker pool and updating AVA qualification settings.
-func (e *ProcessingEngine) ApplyConfig(newWorkers int, approvalThreshold int) {
+// Supports resizing worker pool and updating AVA qualification settings. An approval
+// threshold the decision engine refuses is returned as an error; the workers are resized
+// regardless.
+func (e *ProcessingEngine) ApplyConfig(newWorkers int, approvalThreshold int) error {
 	// Resize workers if needed
 	if newWorkers > 0 {
 		e.resizeWorkers(newWorkers)
 	}
 	// Forward to decision engine for threshold update if provided (>0)
 	if e.decisionEngine != nil && approvalThreshold > 0 {
-		// best-effort; decision engine will clamp values as needed
-		type applier interface{ ApplyConfig(approvalThreshold int) }
+		// decision engine ignores out-of-range values and refuses inverting ones
+		type applier interface {
+			ApplyConfig(approvalThreshold int) error
+		}
 		if a, ok := interface{}(e.decisionEngine).(applier); ok {
-			a.ApplyConfig(approvalThreshold)
+			return a.ApplyConfig(approvalThreshold)
 		}
 	}
+	return nil
+}
+
+// ApplyDecisionRules swaps the decision rules used for new decisions. nil reverts to defaults.
+// Rules whose thresholds would invert the engine's are refused and the previous ones kept.
+func (e *ProcessingEngine) ApplyDecisionRules(r *decision.Rules) error {
+	if e.decisionEngine == nil {
+		return nil
+	}
+	return e.decisionEngine.ApplyRules(r)
+}
+
+// DecisionRules returns the active decision rules, or nil when none are loaded.
+func (e *ProcessingEngine) DecisionRules() *decision.Rules {
+	if e.decisionEngine == nil {
+		return nil
+	}
+	return e.decisionEngine.Rules()
+}
+
+// DryRunDecision evaluates candidate rules for a venue using its latest stored score.
+// Nothing is persisted or published.
+func (e *ProcessingEngine) DryRunDecision(ctx context.Context, vu models.VenueWithUser, vr *models.ValidationResult, candidate *decision.Rules) (*decision.DecisionResult, error) {
+	if e.decisionEngine == nil {
+		return nil, fmt.Errorf("decision engine not configured")
+	}
+	return e.decisionEngine.DryRun(ctx, vu.Venue, vu.User, vr, candidate)
+}
+
+// DecisionSummary exposes the effective decision policy for admin views.
+func (e *ProcessingEngine) DecisionSummary() map[string]interface{} {
+	if e.decisionEngine == nil {
+		return nil
+	}
+	return e.decisionEngine.GetDecisionSummary()
 }
 
 // ApplyAVAConfig updates AVA qualification requirements at runtime with thread safety.
@@ -394,6 +454,37 @@ func (e *ProcessingEngine) ApplyAVAConfig(minUserPoints int, onlyAmbassadors boo
 	}
 }
 
+// ApplyPrefilterConfig swaps the auto-reject pre-filter rules at runtime.
+func (e *ProcessingEngine) ApplyPrefilterConfig(cfg PrefilterConfig) {
+	e.avaConfigMu.Lock()
+	defer e.avaConfigMu.Unlock()
+	e.prefilter = cfg
+}
+
+// SetRateLimit changes the rate limit of an external endpoint (Endpoint*); unknown
+// endpoints are ignored. A rate of 0 or less is unlimited.
+func (e *ProcessingEngine) SetRateLimit(endpoint string, rps, burst int) {
+	switch endpoint {
+	case EndpointGooglePlaces:
+		e.googleRateLimit.SetLimit(rps, burst)
+	case EndpointGooglePhotos:
+		e.photoRateLimit.SetLimit(rps, burst)
+	case EndpointOpenAI:
+		e.openAIRateLimit.SetLimit(rps, burst)
+	}
+}
+
+// ApplyBudgets updates the daily spend caps of the photo check and re-scoring at runtime;
+// 0 is unlimited. Spend already recorded today still counts.
+func (e *ProcessingEngine) ApplyBudgets(photoUSD, rescoreUSD float64) {
+	e.avaConfigMu.Lock()
+	defer e.avaConfigMu.Unlock()
+	e.photoCfg.DailyBudgetUSD = photoUSD
+	if e.rescore != nil {
+		e.rescore.cfg.DailyBudgetUSD = rescoreUSD
+	}
+}
+
 func (e *ProcessingEngine) resizeWorkers(target int) {
 	e.workersMu.Lock()
 	defer e.workersMu.Unlock()
@@ -412,9 +503,8 @@ func (e *ProcessingEngine) resizeWorkers(target int) {
 			e.wg.Add(1)
 			go e.worker(id, stopCh)
 		}
-		e.statsMu.Lock()
-		e.stats.WorkerCount = target
-		e.statsMu.Unlock()
+		e.stats.workers.Store(int64(target))
+		mWorkers.SetFloat64(float64(target))
 		log.Printf("Scaled workers up: %d -> %d", cur, target)
 		return
 	}
@@ -425,12 +515,16 @@ func (e *ProcessingEngine) resizeWorkers(target int) {
 		close(e.workerStops[idx])
 		e.workerStops = e.workerStops[:idx]
 	}
-	e.statsMu.Lock()
-	e.stats.WorkerCount = target
-	e.statsMu.Unlock()
+	e.stats.workers.Store(int64(target))
+	mWorkers.SetFloat64(float64(target))
 	log.Printf("Scaled workers down: %d -> %d", cur, target)
 }
 
+// SetFeatureFlags lets stored feature flags gate the photo check.
+func (e *ProcessingEngine) SetFeatureFlags(f *flags.Service) {
+	e.flags = f
+}
+
 // SetEventStore wires an EventStore for audit trail publishing.
 func (e *ProcessingEngine) SetEventStore(es events.EventStore) {
 	e.eventStore = es
@@ -443,13 +537,14 @@ func (e *ProcessingEngine) SetEventStore(es events.EventStore) {
 	}
 }
 
-// Start begins the processing engine with workers and rate limiters
+// Start begins the processing engine with workers and rate limiters. Later calls do nothing,
+// so the pool keeps the size set by ApplyConfig or the autoscaler.
 func (e *ProcessingEngine) Start() {
-	log.Printf("Starting processing engine with %d workers", e.workerCount)
+	e.startOnce.Do(e.start)
+}
 
-	// Start rate limiters
-	e.googleRateLimit.Start()
-	e.openAIRateLimit.Start()
+func (e *ProcessingEngine) start() {
+	log.Printf("Starting processing engine with %d workers", e.workerCount)
 
 	// Start workers
 	e.workersMu.Lock()
@@ -461,12 +556,35 @@ func (e *ProcessingEngine) Start() {
 		e.wg.Add(1)
 		go e.worker(id, stopCh)
 	}
+	mWorkers.SetFloat64(float64(len(e.workerStops)))
 	e.workersMu.Unlock()
 
 	// Start result processor
 	e.wg.Add(1)
 	go e.resultProcessor()
 
+	if e.autoscale.Enabled {
+		e.wg.Add(1)
+		go e.autoscaleLoop()
+	}
+	if e.checkpoints != nil {
+		e.pruneCheckpoints()
+	}
+	if e.dist != nil {
+		log.Printf("[distributed] instance %s processing from the shared queue", e.dist.cfg.InstanceID)
+		e.wg.Add(2)
+		go e.heartbeatLoop()
+		go e.claimLoop()
+	}
+	if e.batch != nil {
+		e.wg.Add(1)
+		go e.batchLoop()
+	}
+	if e.rescore != nil {
+		e.wg.Add(1)
+		go e.rescoreLoop()
+	}
+
 	log.Println("Processing engine started successfully")
 }
 
@@ -481,8 +599,11 @@ func (e *ProcessingEngine) Stop(timeout time.Duration) error {
 		close(e.shutdown)
 		e.cancel()
 
-		// Stop accepting new jobs
-		close(e.jobQueue)
+		// Stop accepting new jobs. The claim loop may still be feeding the queue in distributed
+		// mode; workers exit on the cancelled context there instead.
+		if e.dist == nil {
+			close(e.jobQueue)
+		}
 
 		// Wait for workers to finish with timeout
 		done := make(chan struct{})
@@ -499,9 +620,11 @@ func (e *ProcessingEngine) Stop(timeout time.Duration) error {
 			err = fmt.Errorf("shutdown timeout exceeded")
 		}
 
-		// Stop rate limiters
-		e.googleRateLimit.Stop()
-		e.openAIRateLimit.Stop()
+		// Checkpoints first: another instance may claim the released jobs right away
+		e.persistCheckpoints()
+		if e.dist != nil {
+			e.releaseShared()
+		}
 
 		// Close result channel
 		close(e.resultChan)
@@ -512,46 +635,69 @@ func (e *ProcessingEngine) Stop(timeout time.Duration) error {
 	return err
 }
 
-// ProcessVenuesWithUsers adds venues with user data to the processing queue
+// ProcessVenuesWithUsers adds venues with user data to the processing queue in auto-decide mode
 func (e *ProcessingEngine) ProcessVenuesWithUsers(venuesWithUser []models.VenueWithUser) error {
-	e.statsMu.Lock()
-	e.stats.TotalJobs = int64(len(venuesWithUser))
-	e.statsMu.Unlock()
+	return e.ProcessVenuesWithMode(venuesWithUser, ModeAutoDecide)
+}
 
-	log.Printf("Queuing %d venues with user data for processing", len(venuesWithUser))
+// ProcessVenuesWithMode queues venues for a run in the given mode. Jobs from runs in
+// different modes may be in the queue at the same time.
+func (e *ProcessingEngine) ProcessVenuesWithMode(venuesWithUser []models.VenueWithUser, mode Mode) error {
+	_, err := e.queueVenues(venuesWithUser, mode, 0)
+	return err
+}
 
-	for _, vw := range venuesWithUser {
+// queueVenues queues venues as jobs of runID and returns how many were queued before any error.
+func (e *ProcessingEngine) queueVenues(venuesWithUser []models.VenueWithUser, mode Mode, runID int64) (int, error) {
+	e.stats.total.Store(int64(len(venuesWithUser)))
+	if e.dist != nil {
+		return e.enqueueShared(venuesWithUser, mode, runID)
+	}
+
+	log.Printf("Queuing %d venues with user data for processing (%s)", len(venuesWithUser), mode)
+
+	for i, vw := range venuesWithUser {
 		priority := e.calculatePriorityWithUser(vw.Venue, vw.User)
 		job := getProcessingJob()
 		job.Venue = vw.Venue
 		job.User = vw.User
 		job.Priority = priority
 		job.Retry = 0
+		job.Mode = mode
+		job.RunID = runID
 
 		select {
 		case e.jobQueue <- job:
-			atomic.AddInt64(&e.stats.QueueSize, 1)
+			e.stats.queue.Add(1)
 			mProcQueued.Inc(1)
-			mQueueGauge.SetFloat64(float64(atomic.LoadInt64(&e.stats.QueueSize)))
+			mQueueGauge.SetFloat64(float64(e.stats.queue.Load()))
 		case <-e.ctx.Done():
 			// return job to pool if we can't enqueue
 			putProcessingJob(job)
-			return fmt.Errorf("processing engine is shutting down")
+			return i, fmt.Errorf("processing engine is shutting down")
 		default:
 			putProcessingJob(job)
-			return fmt.Errorf("job queue is full")
+			return i, fmt.Errorf("job queue is full")
 		}
 	}
 
 	log.Printf("Successfully queued %d venues with user data", len(venuesWithUser))
-	return nil
+	return len(venuesWithUser), nil
 }
 
 // ProcessSingleVenueSync processes a single venue synchronously without using the job queue.
 // This is intended for UI-triggered single venue reviews where immediate feedback is needed.
 // For batch operations and automated tasks, use ProcessVenuesWithUsers instead.
-func (e *ProcessingEngine) ProcessSingleVenueSync(ctx context.Context, venueWithUser models.VenueWithUser) (*ProcessingResult, error) {
-	log.Printf("Starting synchronous processing for venue %d", venueWithUser.Venue.ID)
+func (e *ProcessingEngine) ProcessSingleVenueSync(ctx context.Context, venueWithUser models.VenueWithUser, mode Mode) (*ProcessingResult, error) {
+	log.Printf("Starting synchronous processing for venue %d (%s)", venueWithUser.Venue.ID, mode)
+
+	// The caller's deadline is shared out between the stages, late ones being skipped
+	// rather than overrunning it
+	var budget *deadlineBudget
+	if d, ok := ctx.Deadline(); ok {
+		budget = newDeadlineBudget(d, e.stageWeights)
+		ctx = withDeadlineBudget(ctx, budget)
+	}
 
 	// Create a job struct for processing (not using pool since we're not queuing)
 	job := &ProcessingJob{
@@ -559,15 +705,45 @@ func (e *ProcessingEngine) ProcessSingleVenueSync(ctx context.Context, venueWith
 		User:     venueWithUser.User,
 		Priority: e.calculatePriorityWithUser(venueWithUser.Venue, venueWithUser.User),
 		Retry:    0,
+		Mode:     mode,
+		values:   ctx,
+		sync:     true,
 	}
 
-	// Process the job directly
+	// Process the job directly; the reviewer retries an interrupted review, so no checkpoint is kept
 	result := e.processJob(job)
+	defer e.finishCheckpoint(job.Venue.ID)
+
+	// The write gets what is left of its share; a result that cannot be written in time is
+	// returned unsaved
+	dbCtx, dbCancel, dbFits := stageContext(ctx, StageDB)
+	defer dbCancel()
+	if budget != nil {
+		result.Skipped = budget.Skipped()
+	}
+	ctx = dbCtx
+
+	// A failure is stored like one from the queue, with what the completed stages gathered
+	if !result.Success && !result.Deferred {
+		e.noteFailure(ctx, result)
+		if dbFits && mode.persists() {
+			if err := e.saveFailure(ctx, result); err != nil {
+				log.Printf("Failed to save failed result for venue %d: %v", result.VenueID, err)
+			}
+		}
+	}
+
+	// Dry runs return the result and keep a copy in the sandbox table only
+	if dbFits && result.Success && result.ValidationResult != nil && !mode.persists() {
+		if err := e.repo.SaveSandboxResultCtx(ctx, result.ValidationResult, result.GoogleData); err != nil {
+			log.Printf("Failed to save sandbox result for venue %d: %v", result.VenueID, err)
+		}
+	}
 
 	// Persist the result to database
-	if result.Success && result.ValidationResult != nil {
+	if dbFits && result.Success && result.ValidationResult != nil && mode.persists() {
 		// In score-only mode, just save validation result with Google data (no venue status update)
-		if e.scoreOnly {
+		if !mode.updatesVenue() {
 			if err := e.repo.SaveValidationResultWithGoogleDataCtx(ctx, result.ValidationResult, result.GoogleData); err != nil {
 				log.Printf("Failed to save validation history for venue %d: %v", result.VenueID, err)
 				result.Error = fmt.Errorf("failed to save validation result: %w", err)
@@ -626,48 +802,41 @@ func (e *ProcessingEngine) ProcessSingleVenueSync(ctx context.Context, venueWith
 	}
 
 	// Update stats
-	e.statsMu.Lock()
-	e.stats.CompletedJobs++
+	e.stats.completed.Add(1)
 	if result.Success {
-		e.stats.SuccessfulJobs++
+		e.stats.successful.Add(1)
 	} else {
-		e.stats.FailedJobs++
+		e.stats.failed.Add(1)
 	}
 	if result.ValidationResult != nil {
-		switch result.ValidationResult.Status {
-		case "approved":
-			e.stats.AutoApproved++
-		case "rejected":
-			e.stats.AutoRejected++
-		case "manual_review":
-			e.stats.ManualReview++
-		}
+		e.stats.countDecision(result.ValidationResult.Status)
 	}
-	e.stats.LastActivity = time.Now()
-	e.statsMu.Unlock()
+	e.stats.touch()
 
 	log.Printf("Synchronous processing completed for venue %d: success=%v", result.VenueID, result.Success)
 
 	return result, nil
 }
 
-// GetStats returns current processing statistics
-func (e *ProcessingEngine) SetScoreOnly(scoreOnly bool) {
-	e.statsMu.Lock()
-	e.scoreOnly = scoreOnly
-	e.statsMu.Unlock()
-}
-
+// GetStats returns a snapshot of the processing statistics.
 func (e *ProcessingEngine) GetStats() ProcessingStats {
-	e.statsMu.RLock()
-	defer e.statsMu.RUnlock()
+	stats := e.stats.snapshot()
 
 	// Get cost stats from AI scorer
 	_, _, costUSD, _ := e.scorer.GetCostStats()
-
-	stats := e.stats
 	stats.TotalCostUSD = costUSD
-	stats.QueueSize = atomic.LoadInt64(&e.stats.QueueSize)
+	if r, ok := e.scraper.(GoogleCostReporter); ok {
+		stats.GoogleSKUs = r.PlacesUsage()
+		for _, u := range stats.GoogleSKUs {
+			stats.GoogleCostUSD += u.CostUSD
+		}
+	}
+	if r, ok := e.scraper.(GoogleKeyReporter); ok {
+		stats.GoogleKeys = r.KeyUsage()
+	}
+	for _, rl := range []*RateLimiter{e.googleRateLimit, e.photoRateLimit, e.openAIRateLimit} {
+		stats.Quota = append(stats.Quota, rl.QuotaUsage()...)
+	}
 
 	// Pool stats snapshot
 	stats.JobPoolGets = atomic.LoadInt64(&jobPoolGets)
@@ -687,6 +856,14 @@ func (e *ProcessingEngine) GetStats() ProcessingStats {
 	return stats
 }
 
+// QueueStats reports the job and result queues to the resource watchdog.
+func (e *ProcessingEngine) QueueStats() []monitoring.QueueStat {
+	return []monitoring.QueueStat{
+		{Name: "jobs", Len: len(e.jobQueue), Cap: cap(e.jobQueue), Taken: e.stats.jobsTaken.Load()},
+		{Name: "results", Len: len(e.resultChan), Cap: cap(e.resultChan), Taken: e.stats.resultsTaken.Load()},
+	}
+}
+
 // calculatePriorityWithUser determines job priority based on venue and user characteristics
 func (e *ProcessingEngine) calculatePriorityWithUser(venue models.Venue, user models.User) int {
 	priority := 0
@@ -722,12 +899,20 @@ func (e *ProcessingEngine) requiresManualReviewEarly(ctx context.Context, venue
 	minUserPointsForAVA := e.minUserPointsForAVA
 	e.avaConfigMu.RUnlock()
 
+	// Submitter lists come first: blocked members never reach the API calls, and partner
+	// accounts skip the checks on the submitter below
+	rule := e.submitterRule(ctx, user)
+	if skip, reason := checkSubmitterRule(rule); skip {
+		return true, reason
+	}
+	alwaysAVA := rule != nil && rule.Action == models.SubmitterActionAlwaysAVA
+
 	// Run all early exit checks using helper functions
-	if skip, reason := checkMinimumPoints(user, minUserPointsForAVA); skip {
+	if skip, reason := checkMinimumPoints(user, minUserPointsForAVA); skip && !alwaysAVA {
 		return true, reason
 	}
 
-	if skip, reason := checkTrustLevel(trustAssessment); skip {
+	if skip, reason := checkTrustLevel(trustAssessment); skip && !alwaysAVA {
 		return true, reason
 	}
 
@@ -748,12 +933,24 @@ func (e *ProcessingEngine) requiresManualReviewEarly(ctx context.Context, venue
 	return false, EarlyExitReason{}
 }
 
+// manualReviewKey is the score breakdown key for a models.ShouldRequireManualReview reason.
+func manualReviewKey(reason string) string {
+	switch {
+	case strings.Contains(reason, "Admin"):
+		return "admin_note_block"
+	case strings.Contains(reason, "Asian"):
+		return "asian_venue_block"
+	}
+	return "manual_review"
+}
+
 // worker processes jobs from the queue
 func (e *ProcessingEngine) worker(id int, stopCh <-chan struct{}) {
 	defer e.wg.Done()
 
 	log.Printf("Worker %d started", id)
 	defer log.Printf("Worker %d stopped", id)
+	defer e.recoverWorker(id, stopCh)
 
 	for {
 		select {
@@ -764,8 +961,9 @@ func (e *ProcessingEngine) worker(id int, stopCh <-chan struct{}) {
 				return // Queue closed, worker should exit
 			}
 
-			atomic.AddInt64(&e.stats.QueueSize, -1)
-			mQueueGauge.SetFloat64(float64(atomic.LoadInt64(&e.stats.QueueSize)))
+			e.stats.queue.Add(-1)
+			e.stats.jobsTaken.Add(1)
+			mQueueGauge.SetFloat64(float64(e.stats.queue.Load()))
 			result := e.processJob(job)
 
 			select {
@@ -785,8 +983,22 @@ func (e *ProcessingEngine) worker(id int, stopCh <-chan struct{}) {
 	}
 }
 
+// valuesContext is a job context that also carries the values of the caller's context, whose
+// cancellation it does not share.
+type valuesContext struct {
+	context.Context
+	values context.Context
+}
+
+func (c valuesContext) Value(key any) any {
+	if v := c.values.Value(key); v != nil {
+		return v
+	}
+	return c.Context.Value(key)
+}
+
 // processJob processes a single venue with error recovery
-func (e *ProcessingEngine) processJob(job *ProcessingJob) *ProcessingResult {
+func (e *ProcessingEngine) processJob(job *ProcessingJob) (result *ProcessingResult) {
 	startTime := time.Now()
 	venue := job.Venue
 	user := job.User
@@ -794,50 +1006,100 @@ func (e *ProcessingEngine) processJob(job *ProcessingJob) *ProcessingResult {
 	// Create job-specific context with timeout
 	jobCtx, cancel := context.WithTimeout(e.ctx, e.jobTimeout)
 	defer cancel()
+	if job.values != nil {
+		// A synchronous caller's deadline bounds the job too
+		if d, ok := job.values.Deadline(); ok {
+			var cancelCaller context.CancelFunc
+			jobCtx, cancelCaller = context.WithDeadline(jobCtx, d)
+			defer cancelCaller()
+		}
+		jobCtx = valuesContext{Context: jobCtx, values: job.values}
+	}
 
-	result := getProcessingResult()
+	result = getProcessingResult()
 	result.VenueID = venue.ID
 	result.Success = false
 	result.ProcessingTimeMs = 0
 	result.Retries = job.Retry
+	result.Mode = job.Mode
+	result.RunID = job.RunID
+	result.QueueID = job.QueueID
+
+	// Log lines of this job are kept with its result for the venue page
+	result.jobLog = &jobLog{}
+	jobCtx = withJobLog(jobCtx, result.jobLog)
+	defer func() { attachProcessingLog(result.ValidationResult, result.jobLog) }()
+	defer e.recoverJob(result, startTime)
+
+	// Dry runs leave no trace outside the sandbox table, including the event log
+	eventStore := e.eventStore
+	if !job.Mode.persists() {
+		eventStore = nil
+	}
 
 	// Centralized manual review checks (admin notes, region restrictions)
 	// This check runs early to prevent API costs for venues with admin notes or Asian region restrictions
 	if skip, reason := models.ShouldRequireManualReview(job.Venue); skip {
-		log.Printf("[Early Exit] Venue %d: %s", venue.ID, reason)
+		logf(jobCtx, models.LogLevelInfo, "[Early Exit] Venue %d: %s", venue.ID, reason)
 
-		key := "manual_review"
-		if strings.Contains(reason, "Admin") {
-			key = "admin_note_block"
-		} else if strings.Contains(reason, "Asian") {
-			key = "asian_venue_block"
-		}
 		result.ValidationResult = &models.ValidationResult{
 			VenueID:        job.Venue.ID,
 			Score:          0,
 			Status:         "manual_review",
 			Notes:          reason,
-			ScoreBreakdown: map[string]int{key: 0},
+			ScoreBreakdown: map[string]int{manualReviewKey(reason): 0},
 		}
 		result.Success = true
 
 		// Publish early exit event for consistency
-		if e.eventStore != nil {
-			if err := e.eventStore.Append(jobCtx, events.VenueRequiresManualReview{
+		if eventStore != nil {
+			if err := eventStore.Append(jobCtx, events.VenueRequiresManualReview{
 				Base:   events.Base{Ts: time.Now(), VID: venue.ID},
 				Reason: reason,
 			}); err != nil {
-				log.Printf("[Warning] Failed to append manual review event for venue %d: %v", venue.ID, err)
+				logf(jobCtx, models.LogLevelWarn, "[Warning] Failed to append manual review event for venue %d: %v", venue.ID, err)
 			}
 		}
 
 		// Update metrics
-		atomic.AddInt64(&e.stats.ManualReview, 1)
+		e.stats.manual.Add(1)
 		mDecisionManual.Inc(1)
 
 		return result
 	}
 
+	// Auto-reject obvious spam before trust assessment and any API call
+	e.avaConfigMu.RLock()
+	prefilter := e.prefilter
+	e.avaConfigMu.RUnlock()
+	if reject, reason := autoRejectPrefilter(jobCtx, e.repo, &venue, prefilter); reject {
+		logf(jobCtx, models.LogLevelInfo, "[Pre-filter] Venue %d auto-rejected: %s", venue.ID, reason.String())
+
+		result.ValidationResult = &models.ValidationResult{
+			VenueID:        venue.ID,
+			Score:          0,
+			Status:         "rejected",
+			Notes:          reason.String(),
+			ScoreBreakdown: map[string]int{reason.Code: 0},
+		}
+		result.Success = true
+		if c := mPrefilter[reason.Code]; c != nil {
+			c.Inc(1)
+		}
+
+		if eventStore != nil {
+			if err := eventStore.Append(jobCtx, events.VenueRejected{
+				Base:   events.Base{Ts: time.Now(), VID: venue.ID},
+				Reason: reason.String(),
+			}); err != nil {
+				logf(jobCtx, models.LogLevelWarn, "[Warning] Failed to append pre-filter rejection event for venue %d: %v", venue.ID, err)
+			}
+		}
+
+		// Counted as auto-rejected in handleSuccessfulResult
+		return result
+	}
+
 	// No need to check any longer, we overwrite the path with google suggested one if user submitted one has no
 	// active venues within it.
 	// ---
@@ -892,18 +1154,28 @@ func (e *ProcessingEngine) processJob(job *ProcessingJob) *ProcessingResult {
 	// User might be empty for some venues, handle gracefully
 	var trustAssessment *trust.Assessment
 	if user.ID > 0 {
+		// Also seen by scoring and the decision engine, which assess the user again
+		user.History = e.submissionHistory(jobCtx, user.ID)
 		assessment := e.trustCalc.Assess(user, venue.Location)
 		trustAssessment = &assessment
 	} else {
 		// No user data available - venue will likely require manual review
-		log.Printf("[Warning] Venue %d has no associated user data", venue.ID)
+		logf(jobCtx, models.LogLevelWarn, "[Warning] Venue %d has no associated user data", venue.ID)
 	}
 
 	// Early exit check - bypass API calls if venue should go directly to manual review
 	// This prevents unnecessary costs for venues that don't meet automated review criteria
-	requiresEarlyReview, exitReason := e.requiresManualReviewEarly(jobCtx, &venue, &user, trustAssessment)
+	// Unsafe links are flagged first so they show up even when the venue would need review anyway
+	requiresEarlyReview, exitReason := e.checkURLs(jobCtx, &venue)
+	if !requiresEarlyReview {
+		requiresEarlyReview, exitReason = e.requiresManualReviewEarly(jobCtx, &venue, &user, trustAssessment)
+	}
+	if !requiresEarlyReview {
+		// Kept out of requiresManualReviewEarly so run estimates make no embedding calls
+		requiresEarlyReview, exitReason = e.checkSimilarText(jobCtx, &venue)
+	}
 	if requiresEarlyReview {
-		log.Printf("[Early Exit] Venue %d bypassing API calls: %s", venue.ID, exitReason.String())
+		logf(jobCtx, models.LogLevelInfo, "[Early Exit] Venue %d bypassing API calls: %s", venue.ID, exitReason.String())
 
 		// Create validation result for manual review
 		result.ValidationResult = &models.ValidationResult{
@@ -914,46 +1186,61 @@ func (e *ProcessingEngine) processJob(job *ProcessingJob) *ProcessingResult {
 			ScoreBreakdown: map[string]int{exitReason.Code: 0},
 		}
 		result.Success = true
+		attachTrust(result.ValidationResult, trustAssessment)
+
+		if exitReason.Reject {
+			result.ValidationResult.Status = "rejected"
+			if eventStore != nil {
+				if err := eventStore.Append(jobCtx, events.VenueRejected{
+					Base:   events.Base{Ts: time.Now(), VID: venue.ID},
+					Reason: exitReason.String(),
+				}); err != nil {
+					logf(jobCtx, models.LogLevelWarn, "[Warning] Failed to append early rejection event for venue %d: %v", venue.ID, err)
+				}
+			}
+			// Counted as auto-rejected in handleSuccessfulResult
+			return result
+		}
 
 		// Publish early exit event
-		if e.eventStore != nil {
-			if err := e.eventStore.Append(jobCtx, events.VenueRequiresManualReview{
+		if eventStore != nil {
+			if err := eventStore.Append(jobCtx, events.VenueRequiresManualReview{
 				Base:   events.Base{Ts: time.Now(), VID: venue.ID},
 				Reason: exitReason.String(),
 			}); err != nil {
-				log.Printf("[Warning] Failed to append early exit event for venue %d: %v", venue.ID, err)
+				logf(jobCtx, models.LogLevelWarn, "[Warning] Failed to append early exit event for venue %d: %v", venue.ID, err)
 			}
 		}
 
 		// Update metrics
-		atomic.AddInt64(&e.stats.ManualReview, 1)
+		e.stats.manual.Add(1)
 		mDecisionManual.Inc(1)
 
 		return result
 	}
 
 	// Publish start event
-	if e.eventStore != nil {
+	if eventStore != nil {
 		uid := user.ID
-		if err := e.eventStore.Append(jobCtx, events.VenueValidationStarted{
+		if err := eventStore.Append(jobCtx, events.VenueValidationStarted{
 			Base:      events.Base{Ts: time.Now(), VID: venue.ID},
 			UserID:    &uid,
 			Triggered: "system",
 		}); err != nil {
-			log.Printf("[Warning] Failed to append validation started event for venue %d: %v", venue.ID, err)
+			logf(jobCtx, models.LogLevelWarn, "[Warning] Failed to append validation started event for venue %d: %v", venue.ID, err)
 		}
 	}
 
-	// Process venue with exponential backoff retry logic
+	// Process venue, retrying failures per the retry policy's error class budgets
 	var err error
 	var validationResult *models.ValidationResult
 	var googleData *models.GooglePlaceData
+	var delay time.Duration
+	retriesUsed := map[errs.Class]int{}
 
-	for attempt := 0; attempt <= e.maxRetries; attempt++ {
+	for attempt := 0; attempt < e.retry.maxAttempts(); attempt++ {
 		if attempt > 0 {
-			// Exponential backoff delay
-			delay := time.Duration(attempt*attempt) * e.retryDelay
-			log.Printf("Retrying venue %d (attempt %d) after %v delay", venue.ID, attempt+1, delay)
+			logf(jobCtx, models.LogLevelInfo, "Retrying venue %d (attempt %d) after %v delay", venue.ID, attempt+1, delay)
 
 			select {
 			case <-time.After(delay):
@@ -965,32 +1252,21 @@ func (e *ProcessingEngine) processJob(job *ProcessingJob) *ProcessingResult {
 		}
 
 		// Process the venue
-		validationResult, googleData, err = e.processVenueWithRateLimit(jobCtx, venue, user, trustAssessment)
+		validationResult, googleData, err = e.processVenueWithRateLimit(jobCtx, venue, user, trustAssessment, job)
+		if errors.Is(err, errScoringDeferred) {
+			// Finished by the batch poller once the Batch API reply is in
+			result.Success = true
+			result.Deferred = true
+			result.GoogleData = googleData
+			err = nil
+			break
+		}
 		if err == nil {
 			result.Success = true
 			result.ValidationResult = validationResult
 			result.GoogleData = googleData
-			// Publish completion event with summary details
-			if e.eventStore != nil && validationResult != nil {
-				gdFound := false
-				gpID := ""
-				if googleData != nil {
-					gdFound = true
-					gpID = googleData.PlaceID
-				}
-				if err := e.eventStore.Append(jobCtx, events.VenueValidationCompleted{
-					Base:           events.Base{Ts: time.Now(), VID: venue.ID},
-					Score:          validationResult.Score,
-					Status:         map[string]int{"approved": 1, "rejected": -1, "manual_review": 0}[validationResult.Status],
-					Notes:          validationResult.Notes,
-					ScoreBreakdown: validationResult.ScoreBreakdown,
-					GoogleFound:    gdFound,
-					GooglePlaceID:  gpID,
-					Conflicts:      nil,
-				}); err != nil {
-					log.Printf("[Warning] Failed to append validation completed event for venue %d: %v", venue.ID, err)
-				}
-			}
+			attachTrust(validationResult, trustAssessment)
+			appendCompleted(jobCtx, eventStore, venue.ID, validationResult, googleData)
 			break
 		}
 
@@ -999,13 +1275,17 @@ func (e *ProcessingEngine) processJob(job *ProcessingJob) *ProcessingResult {
 			result.GoogleData = googleData
 		}
 
-		// Check if error is retryable
-		if !e.isRetryableError(err) {
-			log.Printf("Non-retryable error for venue %d: %v", venue.ID, err)
+		if errors.Is(err, ErrDeadlineBudget) {
+			logf(jobCtx, models.LogLevelWarn, "Not retrying venue %d: %v", venue.ID, err)
+			break
+		}
+		var retry bool
+		if delay, retry = e.retry.next(err, retriesUsed, attempt+1); !retry {
+			logf(jobCtx, models.LogLevelError, "Not retrying venue %d (%s error): %v", venue.ID, errs.Classify(err), err)
 			break
 		}
 
-		log.Printf("Retryable error for venue %d (attempt %d): %v", venue.ID, attempt+1, err)
+		logf(jobCtx, models.LogLevelWarn, "Retryable %s error for venue %d (attempt %d): %v", errs.Classify(err), venue.ID, attempt+1, err)
 	}
 
 	result.Error = err
@@ -1014,22 +1294,89 @@ func (e *ProcessingEngine) processJob(job *ProcessingJob) *ProcessingResult {
 	return result
 }
 
-// processVenueWithRateLimit processes a venue with proper rate limiting and user context
-func (e *ProcessingEngine) processVenueWithRateLimit(ctx context.Context, venue models.Venue, user models.User, trustAssessment *trust.Assessment) (*models.ValidationResult, *models.GooglePlaceData, error) {
-	// Rate limit Google Maps API call
-	if err := e.googleRateLimit.Wait(ctx); err != nil {
-		return nil, nil, fmt.Errorf("google rate limit wait cancelled: %w", err)
+// appendCompleted publishes the completion event of a validation with summary details.
+func appendCompleted(ctx context.Context, eventStore events.EventStore, venueID int64, vr *models.ValidationResult, googleData *models.GooglePlaceData) {
+	if eventStore == nil || vr == nil {
+		return
+	}
+	gdFound := false
+	gpID := ""
+	if googleData != nil {
+		gdFound = true
+		gpID = googleData.PlaceID
+	}
+	if err := eventStore.Append(ctx, events.VenueValidationCompleted{
+		Base:           events.Base{Ts: time.Now(), VID: venueID},
+		Score:          vr.Score,
+		Status:         map[string]int{"approved": 1, "rejected": -1, "manual_review": 0}[vr.Status],
+		Notes:          vr.Notes,
+		ScoreBreakdown: vr.ScoreBreakdown,
+		GoogleFound:    gdFound,
+		GooglePlaceID:  gpID,
+		Conflicts:      nil,
+	}); err != nil {
+		logf(ctx, models.LogLevelWarn, "[Warning] Failed to append validation completed event for venue %d: %v", venueID, err)
 	}
+}
 
-	// Enhance venue with Google Maps data
-	enhancedVenue, err := e.scraper.EnhanceVenueWithValidation(ctx, venue)
-	if err != nil {
-		atomic.AddInt64(&e.stats.APICallsGoogle, 1)
-		mApiGoogle.Inc(1)
-		return nil, nil, fmt.Errorf("failed to enhance venue: %w", err)
+// processVenueWithRateLimit processes a venue with proper rate limiting and user context.
+// In ModeBatch the AI scoring call is stored for the next batch job and errScoringDeferred
+// is returned with the Google data.
+func (e *ProcessingEngine) processVenueWithRateLimit(ctx context.Context, venue models.Venue, user models.User, trustAssessment *trust.Assessment, job *ProcessingJob) (*models.ValidationResult, *models.GooglePlaceData, error) {
+	start := time.Now()
+	timings := &models.StageTimings{}
+
+	// A job interrupted by a shutdown resumes after its last finished stage
+	var res *resumed
+	if job.checkpointed() {
+		res = e.resumeCheckpoint(ctx, venue)
+	}
+
+	var enhancedVenue *models.Venue
+	if res != nil {
+		enhancedVenue = res.venue
+		timings.GoogleCached = true
+	} else {
+		// Venues carrying cached Google data (revalidate?use_cache=true) make no Places calls
+		timings.GoogleCached = venue.GoogleData != nil
+		gctx := ctx // the rate limit wait and the lookup share the Google stage's time
+		if !timings.GoogleCached {
+			// A pinned place skips the search and only needs Place Details
+			cost := costTextSearch + costPlaceDetails
+			if venue.GooglePlaceID != "" {
+				cost = costPlaceDetails
+			}
+			var gcancel context.CancelFunc
+			var ok bool
+			gctx, gcancel, ok = stageContext(ctx, StageGoogle)
+			defer gcancel()
+			if !ok {
+				return nil, nil, failedIn(StageGoogle, fmt.Errorf("google enrichment: %w", ErrDeadlineBudget))
+			}
+			if err := e.googleRateLimit.WaitFor(gctx, venuePath(venue), cost); err != nil {
+				return nil, nil, failedIn(StageGoogle, fmt.Errorf("google rate limit wait: %w", err))
+			}
+		}
+
+		// Enhance venue with Google Maps data
+		googleStart := time.Now()
+		var err error
+		enhancedVenue, err = e.scraper.EnhanceVenueWithValidation(gctx, venue)
+		if timings.GoogleCached {
+			mGoogleCacheHits.Inc(1)
+		} else {
+			timings.GoogleMs = e.timeStage(StageGoogle, googleStart)
+			e.stats.google.Add(1)
+			mApiGoogle.Inc(1)
+			e.apiWindow.observe(err)
+		}
+		if err != nil {
+			return nil, nil, failedIn(StageGoogle, fmt.Errorf("failed to enhance venue: %w", err))
+		}
+		if job.checkpointed() {
+			e.checkpoint(venue, models.CheckpointEnriched, enhancedVenue, nil)
+		}
 	}
-	atomic.AddInt64(&e.stats.APICallsGoogle, 1)
-	mApiGoogle.Inc(1)
 
 	// Prepare Google data (if any) early so we can return it even on AI failure
 	var gData *models.GooglePlaceData
@@ -1047,26 +1394,135 @@ func (e *ProcessingEngine) processVenueWithRateLimit(ctx context.Context, venue
 			Notes:          "No location coordinates available - manual review required",
 			ScoreBreakdown: map[string]int{"no_location": 0},
 		}
+		attachTimings(vr, timings, start)
 		return vr, gData, nil
 	}
 
+	var validationResult *models.ValidationResult
+	switch {
+	case res != nil && res.result != nil:
+		validationResult = res.result
+	case job.Mode == ModeBatch && e.batch != nil:
+		var err error
+		validationResult, err = e.deferScoring(ctx, job, enhancedVenue, user)
+		if err != nil {
+			return nil, gData, failedIn(StageOpenAI, err)
+		}
+	default:
+		var err error
+		validationResult, err = e.scoreEnriched(ctx, venue, enhancedVenue, user, trustAssessment, timings)
+		if err != nil {
+			return nil, gData, failedIn(StageOpenAI, err)
+		}
+		if job.checkpointed() {
+			e.checkpoint(venue, models.CheckpointScored, enhancedVenue, validationResult)
+		}
+	}
+
+	e.decide(ctx, job.Mode, enhancedVenue, user, validationResult, timings)
+	timings.Skipped = skippedStages(ctx)
+	attachTimings(validationResult, timings, start)
+	return validationResult, gData, nil
+}
+
+// decide runs the decision engine on a scored venue and applies its outcome to the result,
+// with the decision explanation and token usage attached to ai_output_data. Dry runs do not
+// publish the decision event.
+func (e *ProcessingEngine) decide(ctx context.Context, mode Mode, enhancedVenue *models.Venue, user models.User, validationResult *models.ValidationResult, timings *models.StageTimings) {
+	// Use decision engine to make final decision with user context
+	decisionStart := time.Now()
+	var decisionResult *decision.DecisionResult
+	if mode.persists() {
+		decisionResult = e.decisionEngine.MakeDecision(ctx, *enhancedVenue, user, validationResult)
+	} else {
+		decisionResult = e.decisionEngine.Decide(ctx, *enhancedVenue, user, validationResult)
+	}
+	timings.DecisionMs = e.timeStage(StageDecision, decisionStart)
+
+	// Override validation result with decision engine output
+	validationResult.Status = decisionResult.FinalStatus
+	validationResult.Notes = decisionResult.DecisionReason
+	validationResult.Score = decisionResult.FinalScore
+
+	// Add decision metadata to score breakdown
+	if validationResult.ScoreBreakdown == nil {
+		validationResult.ScoreBreakdown = make(map[string]int)
+	}
+	if decisionResult.Authority != nil {
+		validationResult.ScoreBreakdown["authority_bonus"] = decisionResult.Authority.BonusPoints
+	}
+	validationResult.ScoreBreakdown["quality_flags"] = len(decisionResult.QualityFlags)
+	if decisionResult.Authority != nil {
+		validationResult.ScoreBreakdown["trust_pct"] = int(decisionResult.Authority.TrustLevel * 100)
+	}
+
+	// Persist the decision explanation alongside AI output for the "Why this decision" panel
+	if decisionResult.Explanation != nil {
+		out := attachExplanation(validationResult, decisionResult.Explanation)
+		validationResult.AIOutputData = &out
+	}
+	if validationResult.TokenUsage != nil {
+		out := attachOutput(validationResult, tokenUsageOutputKey, validationResult.TokenUsage)
+		validationResult.AIOutputData = &out
+	}
+}
+
+// scoreEnriched scores an enriched venue with AI and runs the optional photo, website, social
+// and quality checks, attaching their output. The decision is left to the caller.
+func (e *ProcessingEngine) scoreEnriched(ctx context.Context, venue models.Venue, enhancedVenue *models.Venue, user models.User, trustAssessment *trust.Assessment, timings *models.StageTimings) (*models.ValidationResult, error) {
+	// The rate limit wait, translation and scoring share the AI stage's time
+	sctx, cancel, ok := stageContext(ctx, StageOpenAI)
+	defer cancel()
+	if !ok {
+		return nil, fmt.Errorf("AI scoring: %w", ErrDeadlineBudget)
+	}
+
 	// Rate limit OpenAI API call (only if needed for basic venues or vegan relevance)
 	if enhancedVenue.ValidationDetails == nil || !enhancedVenue.ValidationDetails.GooglePlaceFound {
-		if err := e.openAIRateLimit.Wait(ctx); err != nil {
-			return nil, gData, fmt.Errorf("openai rate limit wait cancelled: %w", err)
+		if err := e.openAIRateLimit.WaitFor(sctx, venuePath(venue), costScore); err != nil {
+			return nil, fmt.Errorf("openai rate limit wait: %w", err)
 		}
 	}
 
-	// Score venue with AI
-	validationResult, err := e.scorer.ScoreVenue(ctx, *enhancedVenue, user)
+	// Score venue with AI; non-English descriptions are scored in translation when enabled
+	scoringVenue, translation := e.translateVenue(sctx, *enhancedVenue)
+	aiStart := time.Now()
+	validationResult, err := e.scorer.ScoreVenue(sctx, scoringVenue, user)
+	timings.ScoringMs = e.timeStage(StageOpenAI, aiStart)
+	e.apiWindow.observe(err)
 	if err != nil {
-		atomic.AddInt64(&e.stats.APICallsOpenAI, 1)
+		e.stats.openAI.Add(1)
 		mApiOpenAI.Inc(1)
-		return nil, gData, fmt.Errorf("failed to score venue: %w", err)
+		return nil, fmt.Errorf("failed to score venue: %w", err)
 	}
-	atomic.AddInt64(&e.stats.APICallsOpenAI, 1)
+	e.stats.openAI.Add(1)
 	mApiOpenAI.Inc(1)
 
+	e.reviewScored(ctx, venue, enhancedVenue, user, trustAssessment, validationResult, translation, timings)
+	return validationResult, nil
+}
+
+// reviewScored runs the optional photo, website, social, vegan status and quality checks on a scored venue
+// and attaches their output, with the translation the venue was scored in, to the result.
+func (e *ProcessingEngine) reviewScored(ctx context.Context, venue models.Venue, enhancedVenue *models.Venue, user models.User, trustAssessment *trust.Assessment, validationResult *models.ValidationResult, translation *models.VenueTranslation, timings *models.StageTimings) {
+	// The checks share the quality review stage's time; without enough of it they are left
+	// out and the result is returned without them
+	ctx, cancel, checks := stageContext(ctx, StageQuality)
+	defer cancel()
+
+	// Optional, budget-gated vision check; adjusts the score before the decision is made
+	var photoAssessment *models.PhotoAssessment
+	var websiteCheck *models.WebsiteCheck
+	var socialChecks []models.SocialCheck
+	var veganStatus *models.VeganStatusAssessment
+	if checks {
+		photoAssessment = e.checkPhotos(ctx, *enhancedVenue, enhancedVenue.GoogleData, validationResult)
+		websiteCheck = e.checkWebsite(ctx, *enhancedVenue, validationResult)
+		socialChecks = e.checkSocial(ctx, enhancedVenue, validationResult)
+		veganStatus = e.checkVeganStatus(ctx, enhancedVenue, websiteCheck)
+	}
+	categorySuggestion := e.suggestCategory(enhancedVenue)
+
 	// Use trust assessment calculated earlier (or calculate if not provided)
 	var trustLevel float64
 	if trustAssessment != nil {
@@ -1079,13 +1535,25 @@ func (e *ProcessingEngine) processVenueWithRateLimit(ctx context.Context, venue
 
 	// Run quality review (separate API call) - optional, doesn't fail scoring
 	var qualitySuggestions *models.QualitySuggestions
+	var qualityRun *qualityReviewRun
 	if e.qualityReviewer != nil {
-		category := getCategoryFromVenue(*enhancedVenue)
-		qualitySuggestions, err = e.qualityReviewer.ReviewQuality(ctx, *enhancedVenue, user, category, trustLevel)
-		if err != nil {
-			log.Printf("quality review failed for venue %d: %v (continuing without quality data)", venue.ID, err)
-			// Don't fail the whole process, continue without quality data
+		qualityRun = e.planQualityReview(enhancedVenue.ID, validationResult.Score)
+		if qualityRun.Reviewed && (!checks || !stageFits(ctx, StageQuality)) {
+			qualityRun.Reviewed, qualityRun.Reason = false, qualityDeadline
+		}
+		if qualityRun.Reviewed {
+			category := getCategoryFromVenue(*enhancedVenue)
+			reviewStart := time.Now()
+			var err error
+			qualitySuggestions, err = e.qualityReviewer.ReviewQuality(ctx, *enhancedVenue, user, category, trustLevel)
+			timings.QualityReviewMs = e.timeStage(StageQuality, reviewStart)
+			if err != nil {
+				logf(ctx, models.LogLevelWarn, "quality review failed for venue %d: %v (continuing without quality data)", venue.ID, err)
+				// Don't fail the whole process, continue without quality data
+				qualityRun.Reviewed, qualityRun.Reason = false, qualityFailed
+			}
 		}
+		mQualityReviews.With(qualityRun.Reason).Inc()
 	}
 
 	// Combine scoring + quality suggestions into ai_output_data JSON
@@ -1093,54 +1561,51 @@ func (e *ProcessingEngine) processVenueWithRateLimit(ctx context.Context, venue
 		combinedJSON := buildCombinedOutput(validationResult, qualitySuggestions)
 		validationResult.AIOutputData = &combinedJSON
 	}
+	if qualityRun != nil {
+		out := attachOutput(validationResult, qualityReviewOutputKey, qualityRun)
+		validationResult.AIOutputData = &out
+	}
 
-	// Use decision engine to make final decision with user context
-	decisionResult := e.decisionEngine.MakeDecision(ctx, *enhancedVenue, user, validationResult)
-
-	// Override validation result with decision engine output
-	validationResult.Status = decisionResult.FinalStatus
-	validationResult.Notes = decisionResult.DecisionReason
-	validationResult.Score = decisionResult.FinalScore
-
-	// Add decision metadata to score breakdown
-	if validationResult.ScoreBreakdown == nil {
-		validationResult.ScoreBreakdown = make(map[string]int)
+	if photoAssessment != nil {
+		out := attachOutput(validationResult, photoOutputKey, photoAssessment)
+		validationResult.AIOutputData = &out
 	}
-	if decisionResult.Authority != nil {
-		validationResult.ScoreBreakdown["authority_bonus"] = decisionResult.Authority.BonusPoints
+	if websiteCheck != nil {
+		out := attachOutput(validationResult, websiteOutputKey, websiteCheck)
+		validationResult.AIOutputData = &out
 	}
-	validationResult.ScoreBreakdown["quality_flags"] = len(decisionResult.QualityFlags)
-
-	return validationResult, gData, nil
-}
-
-// isRetryableError determines if an error should trigger a retry
-func (e *ProcessingEngine) isRetryableError(err error) bool {
-	if err == nil {
-		return false
+	if len(socialChecks) > 0 {
+		out := attachOutput(validationResult, socialOutputKey, socialChecks)
+		validationResult.AIOutputData = &out
 	}
-
-	errStr := err.Error()
-
-	// Retryable errors
-	retryableErrors := []string{
-		"timeout",
-		"rate limit",
-		"quota exceeded",
-		"service unavailable",
-		"internal server error",
-		"connection refused",
-		"connection reset",
-		"temporary failure",
+	if translation != nil {
+		out := attachOutput(validationResult, translationOutputKey, translation)
+		validationResult.AIOutputData = &out
 	}
-
-	for _, retryable := range retryableErrors {
-		if containsAny(errStr, []string{retryable}) {
-			return true
-		}
+	if categorySuggestion != nil {
+		out := attachOutput(validationResult, categoryOutputKey, categorySuggestion)
+		validationResult.AIOutputData = &out
 	}
+	if veganStatus != nil {
+		out := attachOutput(validationResult, veganOutputKey, veganStatus)
+		validationResult.AIOutputData = &out
+	}
+}
 
-	return false
+// timeStage records a stage started at start in the engine stats and returns its duration
+// in milliseconds for the venue's StageTimings.
+func (e *ProcessingEngine) timeStage(stage string, start time.Time) int64 {
+	took := time.Since(start)
+	e.stats.observeStage(stage, took)
+	return took.Milliseconds()
+}
+
+// attachTimings completes timings with the total since start and stores them under
+// "timings" in ai_output_data.
+func attachTimings(vr *models.ValidationResult, timings *models.StageTimings, start time.Time) {
+	timings.TotalMs = time.Since(start).Milliseconds()
+	out := attachOutput(vr, timingsOutputKey, timings)
+	vr.AIOutputData = &out
 }
 
 // resultProcessor handles processing results and database updates
@@ -1150,6 +1615,19 @@ func (e *ProcessingEngine) resultProcessor() {
 	log.Println("Result processor started")
 	defer log.Println("Result processor stopped")
 
+	var flushTick <-chan time.Time
+	if e.batcher != nil {
+		t := time.NewTicker(e.flushInterval)
+		defer t.Stop()
+		flushTick = t.C
+		defer func() {
+			// e.ctx is already cancelled when stopping; give the last batch its own deadline
+			ctx, cancel := context.WithTimeout(context.Background(), finalFlushTimeout)
+			defer cancel()
+			e.batcher.flush(ctx)
+		}()
+	}
+
 	for {
 		select {
 		case result, ok := <-e.resultChan:
@@ -1157,9 +1635,13 @@ func (e *ProcessingEngine) resultProcessor() {
 				return // Result channel closed
 			}
 
+			e.stats.resultsTaken.Add(1)
 			e.handleResult(result)
 			putProcessingResult(result)
 
+		case <-flushTick:
+			e.batcher.flush(e.ctx)
+
 		case <-e.ctx.Done():
 			return
 		}
@@ -1168,34 +1650,52 @@ func (e *ProcessingEngine) resultProcessor() {
 
 // handleResult processes a venue processing result
 func (e *ProcessingEngine) handleResult(result *ProcessingResult) {
+	if !result.Success && e.ctx.Err() != nil {
+		// Cut off by shutdown rather than failed: keep the checkpoint and leave the venue
+		// (and its shared queue row) for the next start
+		log.Printf("Venue %d interrupted by shutdown: %v", result.VenueID, result.Error)
+		return
+	}
+	defer e.finishCheckpoint(result.VenueID)
+
+	if result.Deferred {
+		// Counted when the batch reply is ingested; only the shared queue row is done
+		log.Printf("Venue %d waits for batch scoring", result.VenueID)
+		if result.QueueID != 0 {
+			e.completeShared(result)
+		}
+		return
+	}
+
 	// metrics first
 	mProcCompleted.Inc(1)
 	mProcDuration.Observe(float64(result.ProcessingTimeMs) / 1000.0)
 
-	e.statsMu.Lock()
-	e.stats.CompletedJobs++
-	e.stats.LastActivity = time.Now()
-
-	// Update processing time average
-	if e.stats.CompletedJobs == 1 {
-		e.stats.AverageTimeMs = result.ProcessingTimeMs
-	} else {
-		e.stats.AverageTimeMs = (e.stats.AverageTimeMs + result.ProcessingTimeMs) / 2
-	}
-	e.statsMu.Unlock()
+	e.stats.completed.Add(1)
+	e.stats.touch()
+	e.stats.latency.observe(time.Duration(result.ProcessingTimeMs) * time.Millisecond)
 
 	if result.Success && result.ValidationResult != nil {
 		mProcSuccess.Inc(1)
+		result.ValidationResult.RunID = result.runIDPtr()
 		e.handleSuccessfulResult(result)
 	} else {
 		mProcFailed.Inc(1)
 		e.handleFailedResult(result)
 	}
+	switch {
+	case result.QueueID != 0:
+		e.completeShared(result)
+	case result.RunID != 0 && result.Mode == ModeBatch:
+		e.recordSharedRunOutcome(result.RunID, runOutcome(result))
+	case result.RunID != 0:
+		e.recordRunOutcome(result)
+	}
 }
 
 // handleSuccessfulResult processes a successful validation result
 func (e *ProcessingEngine) handleSuccessfulResult(result *ProcessingResult) {
-	atomic.AddInt64(&e.stats.SuccessfulJobs, 1)
+	e.stats.successful.Add(1)
 
 	validationResult := result.ValidationResult
 	var dbStatus int
@@ -1204,32 +1704,56 @@ func (e *ProcessingEngine) handleSuccessfulResult(result *ProcessingResult) {
 	switch validationResult.Status {
 	case "approved":
 		dbStatus = 1
-		atomic.AddInt64(&e.stats.AutoApproved, 1)
+		e.stats.approved.Add(1)
 		mDecisionAutoAppr.Inc(1)
 		log.Printf("Auto-approved venue %d with score %d (Decision engine)", result.VenueID, validationResult.Score)
 
 	case "rejected":
 		dbStatus = -1
-		atomic.AddInt64(&e.stats.AutoRejected, 1)
+		e.stats.rejected.Add(1)
 		mDecisionAutoRej.Inc(1)
 		log.Printf("Auto-rejected venue %d with score %d (Decision engine)", result.VenueID, validationResult.Score)
 
 	default: // manual_review
 		dbStatus = 0
-		atomic.AddInt64(&e.stats.ManualReview, 1)
+		e.stats.manual.Add(1)
 		mDecisionManual.Inc(1)
 		log.Printf("Venue %d requires manual review (score: %d) (Decision engine)", result.VenueID, validationResult.Score)
 	}
 
-	if e.scoreOnly {
+	switch {
+	case !result.Mode.persists():
+		log.Printf("Dry run: venue %d would be %s (score %d)", result.VenueID, validationResult.Status, validationResult.Score)
+		if err := e.repo.SaveSandboxResultCtx(e.ctx, validationResult, result.GoogleData); err != nil {
+			log.Printf("Failed to save sandbox result for venue %d: %v", result.VenueID, err)
+		}
+		return
+	case !result.Mode.updatesVenue():
 		// Score-only mode: do not update venue status, only record history with Google data
+		if e.batcher != nil {
+			e.batcher.add(e.ctx, pendingWrite{venueID: result.VenueID, history: &domain.HistoryRecord{Result: validationResult, GoogleData: result.GoogleData}})
+			return
+		}
 		if err := e.repo.SaveValidationResultWithGoogleDataCtx(e.ctx, validationResult, result.GoogleData); err != nil {
 			log.Printf("Failed to save validation history for venue %d: %v", result.VenueID, err)
 		}
 		return
 	}
 
+	if e.batcher != nil {
+		// The batch writes history and status together, so check the result itself
+		if dbStatus == 1 {
+			if err := approvalEligible(validationResult, e.eligibilityThreshold()); err != nil {
+				log.Printf("Cannot approve venue %d: %v", result.VenueID, err)
+				dbStatus = 0
+			}
+		}
+		e.batcher.add(e.ctx, pendingWrite{venueID: result.VenueID, history: &domain.HistoryRecord{Result: validationResult}, status: &dbStatus})
+		return
+	}
+
 	// Normal mode: perform both writes atomically via UnitOfWork
+	defer e.observeDB(time.Now())
 	uow, err := e.uowFactory.Begin(e.ctx)
 	if err != nil {
 		log.Printf("Failed to begin unit of work for venue %d: %v", result.VenueID, err)
@@ -1246,8 +1770,7 @@ func (e *ProcessingEngine) handleSuccessfulResult(result *ProcessingResult) {
 	// For approvals, validate that the venue has a valid validation history before updating status
 	// Venue can only be approved if there's a validation history with status='approved' and score >= threshold
 	if dbStatus == 1 { // Approval
-		const approvalThreshold = 75
-		if err := e.repo.ValidateApprovalEligibility(result.VenueID, approvalThreshold); err != nil {
+		if err := e.repo.ValidateApprovalEligibility(result.VenueID, e.eligibilityThreshold()); err != nil {
 			log.Printf("Cannot approve venue %d: %v", result.VenueID, err)
 			// Set to manual review instead
 			dbStatus = 0
@@ -1266,76 +1789,101 @@ func (e *ProcessingEngine) handleSuccessfulResult(result *ProcessingResult) {
 
 // handleFailedResult processes a failed processing result
 func (e *ProcessingEngine) handleFailedResult(result *ProcessingResult) {
-	atomic.AddInt64(&e.stats.FailedJobs, 1)
-	atomic.AddInt64(&e.stats.ManualReview, 1)
+	e.stats.failed.Add(1)
+	e.stats.manual.Add(1)
 
-	// Do not write error details into venues.admin_note; set active to manual review only
-	uow, err := e.uowFactory.Begin(e.ctx)
-	if err != nil {
-		log.Printf("Failed to begin unit of work for failed result %d: %v", result.VenueID, err)
+	if !result.Mode.persists() {
+		log.Printf("Dry run: venue %d failed: %v", result.VenueID, result.Error)
 		return
 	}
-	defer uow.Rollback()
 
-	if err := uow.UpdateVenueActiveCtx(e.ctx, result.VenueID, 0); err != nil {
-		log.Printf("Failed to set venue %d to manual review: %v", result.VenueID, err)
+	result.jobLog.printf(models.LogLevelError, "Failed to process venue %d after %d retries: %v", result.VenueID, result.Retries, result.Error)
+	e.noteFailure(e.ctx, result)
+
+	// Do not write error details into venues.admin_note; set active to manual review only
+	if e.batcher != nil {
+		w := pendingWrite{venueID: result.VenueID, history: &domain.HistoryRecord{Result: failedResult(result), GoogleData: result.GoogleData}}
+		if result.Mode.updatesVenue() {
+			manual := 0
+			w.status = &manual
+		}
+		e.batcher.add(e.ctx, w)
 		return
 	}
+	if err := e.saveFailure(e.ctx, result); err != nil {
+		log.Printf("Failed to save failed result for venue %d: %v", result.VenueID, err)
+	}
+}
 
-	// If we have Google Places data, persist it to validation history even when AI scoring failed
-	if result.GoogleData != nil {
-		vr := &models.ValidationResult{
-			VenueID:        result.VenueID,
-			Score:          0,
-			Status:         "manual_review",
-			Notes:          "AI scoring failed; saved Google data for manual review",
-			ScoreBreakdown: map[string]int{"google_data_only": 1},
-		}
-		if err := uow.SaveValidationResultWithGoogleDataCtx(e.ctx, vr, result.GoogleData); err != nil {
-			log.Printf("Failed to save Google data on failure for venue %d: %v", result.VenueID, err)
-			return
+// saveFailure stores the history row of a failed result, with whatever its completed stages
+// gathered, and sets the venue to manual review when the mode decides venues.
+func (e *ProcessingEngine) saveFailure(ctx context.Context, result *ProcessingResult) error {
+	defer e.observeDB(time.Now())
+	uow, err := e.uowFactory.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer uow.Rollback()
+
+	if result.Mode.updatesVenue() {
+		if err := uow.UpdateVenueActiveCtx(ctx, result.VenueID, 0); err != nil {
+			return fmt.Errorf("failed to set venue to manual review: %w", err)
 		}
 	}
-	if err := uow.Commit(); err != nil {
-		log.Printf("Failed to commit failed-result unit of work for venue %d: %v", result.VenueID, err)
+	if err := uow.SaveValidationResultWithGoogleDataCtx(ctx, failedResult(result), result.GoogleData); err != nil {
+		return fmt.Errorf("failed to save validation history: %w", err)
 	}
+	return uow.Commit()
+}
 
-	log.Printf("Failed to process venue %d after %d retries: %v", result.VenueID, result.Retries, result.Error)
+// failedResult is the history row of a failed job: the googleOnlyResult when Google data was
+// gathered, with the job's progress and log attached so the venue page shows how far it got
+// and why it failed.
+func failedResult(result *ProcessingResult) *models.ValidationResult {
+	vr := googleOnlyResult(result.VenueID, result.runIDPtr())
+	if result.GoogleData == nil {
+		vr.Notes = "Processing failed before any data was gathered; manual review required"
+		vr.ScoreBreakdown = map[string]int{models.ProcessingFailedKey: 0}
+	}
+	if result.Progress != nil {
+		out := attachOutput(vr, progressOutputKey, result.Progress)
+		vr.AIOutputData = &out
+	}
+	attachProcessingLog(vr, result.jobLog)
+	return vr
 }
 
-// Utility functions
+// googleOnlyResult is the history row kept for a venue whose AI scoring failed, so the
+// Google data is still there for manual review.
+func googleOnlyResult(venueID int64, runID *int64) *models.ValidationResult {
+	return &models.ValidationResult{
+		VenueID:        venueID,
+		RunID:          runID,
+		Score:          0,
+		Status:         "manual_review",
+		Notes:          "AI scoring failed; saved Google data for manual review",
+		ScoreBreakdown: map[string]int{"google_data_only": 1},
+	}
+}
 
-// containsAny checks if a string contains any of the given substrings
-func containsAny(s string, substrings []string) bool {
-	s = strings.ToLower(s)
-	for _, substring := range substrings {
-		if strings.Contains(s, strings.ToLower(substring)) {
-			return true
-		}
+// observeDB records the time since start as one result's database write.
+func (e *ProcessingEngine) observeDB(start time.Time) {
+	e.stats.observeStage(StageDB, time.Since(start))
+}
+
+// eligibilityThreshold is the minimum score an auto-approval's history must have.
+func (e *ProcessingEngine) eligibilityThreshold() int {
+	if e.decisionEngine != nil {
+		return e.decisionEngine.EligibilityThreshold()
 	}
-	return false
+	return decision.DefaultEligibilityThreshold
 }
 
+// Utility functions
+
 // getCategoryFromVenue extracts category name from venue
 func getCategoryFromVenue(venue models.Venue) string {
-	// Map HappyCow category IDs to display names
-	categoryMap := map[int]string{
-		1:  "Restaurant",
-		2:  "Health Food Store",
-		3:  "Bakery",
-		4:  "Caterer",
-		5:  "Juice Bar",
-		6:  "Market/Co-op",
-		7:  "Ice Cream Shop",
-		8:  "Organization",
-		9:  "B&B/Hotel",
-		10: "Food Truck",
-	}
-
-	if category, ok := categoryMap[venue.Category]; ok {
-		return category
-	}
-	return "Restaurant" // Default fallback
+	return models.VenueCategoryName(venue.Category)
 }
 
 // buildCombinedOutput combines scoring and quality suggestions into JSON for ai_output_data field
@@ -1355,3 +1903,56 @@ func buildCombinedOutput(scoring *models.ValidationResult, quality *models.Quali
 	data, _ := json.Marshal(combined)
 	return string(data)
 }
+
+// timingsOutputKey is where the per-stage timings of a validation go in ai_output_data.
+const timingsOutputKey = "timings"
+
+// tokenUsageOutputKey is where the scoring call's token counts go in ai_output_data.
+const tokenUsageOutputKey = "token_usage"
+
+// trustOutputKey is where the submitter's trust assessment, with the rules version and
+// inputs it was computed from, goes in ai_output_data.
+const trustOutputKey = "trust"
+
+// attachTrust records the trust assessment used for a decision in ai_output_data.
+func attachTrust(vr *models.ValidationResult, a *trust.Assessment) {
+	if vr == nil || a == nil {
+		return
+	}
+	out := attachOutput(vr, trustOutputKey, a)
+	vr.AIOutputData = &out
+}
+
+// attachExplanation adds the decision explanation under "explanation" in ai_output_data.
+func attachExplanation(vr *models.ValidationResult, exp *decision.Explanation) string {
+	return attachOutput(vr, "explanation", exp)
+}
+
+// attachOutput adds v under key in ai_output_data. Existing JSON objects (raw AI response
+// or combined output) keep their keys; anything else is wrapped in the combined "scoring"
+// shape with the original text under "raw".
+func attachOutput(vr *models.ValidationResult, key string, v interface{}) string {
+	payload := map[string]interface{}{}
+	var raw string
+	if vr.AIOutputData != nil && *vr.AIOutputData != "" {
+		if err := json.Unmarshal([]byte(*vr.AIOutputData), &payload); err != nil || payload == nil {
+			// Fallback-parsed responses are not JSON; keep the text for debugging
+			payload = map[string]interface{}{}
+			raw = *vr.AIOutputData
+		}
+	}
+	if len(payload) == 0 {
+		if raw != "" {
+			payload["raw"] = raw
+		}
+		payload["scoring"] = map[string]interface{}{
+			"score":     vr.Score,
+			"notes":     vr.Notes,
+			"breakdown": vr.ScoreBreakdown,
+		}
+	}
+	payload[key] = v
+
+	data, _ := json.Marshal(payload)
+	return string(data)
+}
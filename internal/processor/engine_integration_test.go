@@ -49,12 +49,11 @@ func TestProcessingEngine_EndToEnd(t *testing.T) {
 	cfg.QueueSize = 10
 	cfg.GoogleRPS = 100
 	cfg.OpenAIRPS = 100
-	cfg.RetryDelay = 10 * time.Millisecond
+	cfg.Retry.BaseDelay = 10 * time.Millisecond
 	cfg.JobTimeout = 2 * time.Second
 
 	decCfg := decision.DecisionConfig{ApprovalThreshold: 75}
-	eng := processor.NewProcessingEngine(repo, uowf, ms, msc, cfg, decCfg)
-	eng.SetScoreOnly(false)
+	eng := processor.NewProcessingEngine(repo, uowf, ms, msc, nil, cfg, decCfg)
 	eng.Start()
 	defer func() { _ = eng.Stop(2 * time.Second) }()
 
@@ -178,9 +177,9 @@ func BenchmarkProcessingEngine(b *testing.B) {
 	cfg.QueueSize = 100
 	cfg.GoogleRPS = 1000
 	cfg.OpenAIRPS = 1000
-	cfg.RetryDelay = 0
+	cfg.Retry.BaseDelay = 0
 	cfg.JobTimeout = 2 * time.Second
-	eng := processor.NewProcessingEngine(repo, uowf, ms, msc, cfg, decision.DecisionConfig{ApprovalThreshold: 75})
+	eng := processor.NewProcessingEngine(repo, uowf, ms, msc, nil, cfg, decision.DecisionConfig{ApprovalThreshold: 75})
 	eng.Start()
 	defer func() { _ = eng.Stop(2 * time.Second) }()
 
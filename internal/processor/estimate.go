@@ -0,0 +1,92 @@
+package processor
+
+import (
+	"context"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/internal/trust"
+)
+
+// defaultOpenAICallCostUSD prices an OpenAI call before any have been made since start
+// (roughly 2K prompt and 400 completion tokens on gpt-4o-mini).
+const defaultOpenAICallCostUSD = 0.0006
+
+// GoogleCostEstimator is implemented by scrapers that can price one venue lookup.
+type GoogleCostEstimator interface {
+	LookupCostUSD(venueID int64) float64
+}
+
+// RunEstimate is the expected API usage of processing a set of venues. Early exits make no
+// API calls; every other venue costs one Google lookup plus one OpenAI call per enabled AI
+// stage. Translation and photo checks run only for some venues and are not included.
+type RunEstimate struct {
+	Venues            int            `json:"venues"`
+	EarlyExits        map[string]int `json:"early_exits"` // by reason code
+	EarlyExitTotal    int            `json:"early_exit_total"`
+	GoogleLookups     int            `json:"google_lookups"`
+	OpenAICalls       int            `json:"openai_calls"`
+	GoogleCostUSD     float64        `json:"google_cost_usd"`
+	OpenAICostUSD     float64        `json:"openai_cost_usd"`
+	TotalCostUSD      float64        `json:"total_cost_usd"`
+	OpenAICallCostUSD float64        `json:"openai_call_cost_usd"` // price per call used for the estimate
+	OpenAIPerVenue    int            `json:"openai_calls_per_venue"`
+	OpenAIPriceBasis  string         `json:"openai_price_basis"` // "observed" average or "default"
+}
+
+// EstimateRun predicts the API calls and cost of processing venues without making any.
+// Early exits are predicted with the same checks processJob runs before calling Google.
+func (e *ProcessingEngine) EstimateRun(ctx context.Context, venues []models.VenueWithUser) RunEstimate {
+	est := RunEstimate{Venues: len(venues), EarlyExits: map[string]int{}}
+
+	est.OpenAIPerVenue = 1
+	if e.qualityReviewer != nil {
+		est.OpenAIPerVenue++
+	}
+	est.OpenAICallCostUSD, est.OpenAIPriceBasis = defaultOpenAICallCostUSD, "default"
+	if _, requests, cost, _ := e.scorer.GetCostStats(); requests > 0 && cost > 0 {
+		est.OpenAICallCostUSD, est.OpenAIPriceBasis = cost/float64(requests), "observed"
+	}
+	pricer, _ := e.scraper.(GoogleCostEstimator)
+
+	for _, vw := range venues {
+		if code := e.predictEarlyExit(ctx, vw.Venue, vw.User); code != "" {
+			est.EarlyExits[code]++
+			est.EarlyExitTotal++
+			continue
+		}
+		est.GoogleLookups++
+		est.OpenAICalls += est.OpenAIPerVenue
+		if pricer != nil {
+			est.GoogleCostUSD += pricer.LookupCostUSD(vw.Venue.ID)
+		}
+	}
+	est.OpenAICostUSD = float64(est.OpenAICalls) * est.OpenAICallCostUSD
+	est.TotalCostUSD = est.GoogleCostUSD + est.OpenAICostUSD
+	return est
+}
+
+// predictEarlyExit returns the score breakdown key processJob would record for a venue that
+// exits before any API call, or "" when the venue would be looked up and scored. Keep the
+// order in step with processJob.
+func (e *ProcessingEngine) predictEarlyExit(ctx context.Context, venue models.Venue, user models.User) string {
+	if skip, reason := models.ShouldRequireManualReview(venue); skip {
+		return manualReviewKey(reason)
+	}
+
+	e.avaConfigMu.RLock()
+	prefilter := e.prefilter
+	e.avaConfigMu.RUnlock()
+	if reject, reason := autoRejectPrefilter(ctx, e.repo, &venue, prefilter); reject {
+		return reason.Code
+	}
+
+	var trustAssessment *trust.Assessment
+	if user.ID > 0 {
+		assessment := e.trustCalc.Assess(user, venue.Location)
+		trustAssessment = &assessment
+	}
+	if skip, reason := e.requiresManualReviewEarly(ctx, &venue, &user, trustAssessment); skip {
+		return reason.Code
+	}
+	return ""
+}
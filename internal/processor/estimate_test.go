@@ -0,0 +1,60 @@
+package processor
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+	"assisted-venue-approval/internal/trust"
+)
+
+// pricedScraper is a MockScraper that prices every lookup at 5 cents.
+type pricedScraper struct{ *testutil.MockScraper }
+
+func (pricedScraper) LookupCostUSD(int64) float64 { return 0.05 }
+
+func TestEstimateRun(t *testing.T) {
+	str := func(s string) *string { return &s }
+	trusted := models.User{ID: 7, Trusted: true}
+	venues := []models.VenueWithUser{
+		{Venue: models.Venue{ID: 1, Name: "Green Leaf Cafe"}, User: trusted},
+		{Venue: models.Venue{ID: 2, Name: "Sprout Kitchen"}, User: trusted},
+		{Venue: models.Venue{ID: 3, Name: "Noted", AdminNote: str("call owner")}, User: trusted},
+		{Venue: models.Venue{ID: 4, Name: "Tofu House", Path: str("asia|china|beijing")}, User: trusted},
+		{Venue: models.Venue{ID: 5, Name: "  "}, User: trusted},
+		{Venue: models.Venue{ID: 6, Name: "Anonymous Cafe"}},
+	}
+
+	e := &ProcessingEngine{
+		repo:      &testutil.Repository{},
+		scraper:   pricedScraper{testutil.NewMockScraper()},
+		scorer:    testutil.NewMockScorer(),
+		trustCalc: trust.NewDefault(),
+		prefilter: PrefilterConfig{EmptyName: true},
+	}
+	est := e.EstimateRun(context.Background(), venues)
+
+	wantExits := map[string]int{
+		"admin_note_block":  1,
+		"asian_venue_block": 1,
+		prefilterEmptyName:  1,
+		NoTrustData.Code:    1,
+	}
+	for code, n := range wantExits {
+		if est.EarlyExits[code] != n {
+			t.Errorf("early exits[%s] = %d, want %d (all: %v)", code, est.EarlyExits[code], n, est.EarlyExits)
+		}
+	}
+	if est.Venues != 6 || est.EarlyExitTotal != 4 || est.GoogleLookups != 2 || est.OpenAICalls != 2 {
+		t.Fatalf("counts = %+v", est)
+	}
+	if est.OpenAIPriceBasis != "default" || est.OpenAICallCostUSD != defaultOpenAICallCostUSD {
+		t.Errorf("price basis = %s %v, want default", est.OpenAIPriceBasis, est.OpenAICallCostUSD)
+	}
+	wantTotal := 2*0.05 + 2*defaultOpenAICallCostUSD
+	if math.Abs(est.TotalCostUSD-wantTotal) > 1e-9 {
+		t.Errorf("total = %v, want %v", est.TotalCostUSD, wantTotal)
+	}
+}
@@ -0,0 +1,94 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// processingLogOutputKey is where a validation's processing log goes in ai_output_data.
+const processingLogOutputKey = "processing_log"
+
+// Bounds of a job log, so a venue stuck in retries cannot bloat its history row.
+const (
+	maxJobLogEntries = 100
+	maxJobLogMessage = 1000 // bytes
+)
+
+// jobLog collects the log lines written while processing one venue. Lines still go to the
+// process log; the job log keeps a copy that is stored with the venue's validation result.
+// A nil *jobLog only writes to the process log.
+type jobLog struct {
+	mu      sync.Mutex
+	entries []models.ProcessingLogEntry
+	dropped int
+}
+
+type jobLogKey struct{}
+
+// withJobLog returns ctx carrying l, for logf calls made while processing the job.
+func withJobLog(ctx context.Context, l *jobLog) context.Context {
+	return context.WithValue(ctx, jobLogKey{}, l)
+}
+
+// logf writes a log line like log.Printf and records it in the job log of ctx, if any.
+func logf(ctx context.Context, level, format string, args ...interface{}) {
+	l, _ := ctx.Value(jobLogKey{}).(*jobLog)
+	l.printf(level, format, args...)
+}
+
+func (l *jobLog) printf(level, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	log.Print(msg)
+	if l == nil {
+		return
+	}
+	if len(msg) > maxJobLogMessage {
+		cut := maxJobLogMessage
+		for cut > 0 && !utf8.RuneStart(msg[cut]) {
+			cut--
+		}
+		msg = msg[:cut] + "…"
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.entries) >= maxJobLogEntries {
+		l.dropped++
+		return
+	}
+	l.entries = append(l.entries, models.ProcessingLogEntry{At: time.Now(), Level: level, Message: msg})
+}
+
+// snapshot returns the recorded lines, ending with a note of how many were dropped.
+func (l *jobLog) snapshot() []models.ProcessingLogEntry {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := append([]models.ProcessingLogEntry(nil), l.entries...)
+	if l.dropped > 0 {
+		out = append(out, models.ProcessingLogEntry{
+			At:      time.Now(),
+			Level:   models.LogLevelWarn,
+			Message: fmt.Sprintf("%d more log lines not kept", l.dropped),
+		})
+	}
+	return out
+}
+
+// attachProcessingLog stores the job log under "processing_log" in ai_output_data. Results
+// of jobs that logged nothing are left as they are.
+func attachProcessingLog(vr *models.ValidationResult, l *jobLog) {
+	entries := l.snapshot()
+	if vr == nil || len(entries) == 0 {
+		return
+	}
+	out := attachOutput(vr, processingLogOutputKey, entries)
+	vr.AIOutputData = &out
+}
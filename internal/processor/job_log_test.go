@@ -0,0 +1,69 @@
+package processor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"assisted-venue-approval/internal/models"
+)
+
+func TestJobLog(t *testing.T) {
+	l := &jobLog{}
+	ctx := withJobLog(context.Background(), l)
+	logf(ctx, models.LogLevelInfo, "[Early Exit] Venue %d: %s", 7, "admin note")
+	logf(ctx, models.LogLevelWarn, "vegan check failed for venue %d", 7)
+	// Lines logged outside a job only reach the process log
+	logf(context.Background(), models.LogLevelError, "not a job line")
+
+	entries := l.snapshot()
+	if len(entries) != 2 {
+		t.Fatalf("entries = %+v", entries)
+	}
+	if entries[0].Level != models.LogLevelInfo || entries[0].Message != "[Early Exit] Venue 7: admin note" || entries[0].At.IsZero() {
+		t.Errorf("first entry = %+v", entries[0])
+	}
+	if entries[1].Level != models.LogLevelWarn {
+		t.Errorf("second entry = %+v", entries[1])
+	}
+}
+
+func TestJobLogBounds(t *testing.T) {
+	l := &jobLog{}
+	l.printf(models.LogLevelError, "%s", strings.Repeat("é", maxJobLogMessage))
+	for i := 0; i < maxJobLogEntries+4; i++ {
+		l.printf(models.LogLevelWarn, "retry %d", i)
+	}
+	entries := l.snapshot()
+	if len(entries) != maxJobLogEntries+1 {
+		t.Fatalf("kept %d entries, want %d", len(entries), maxJobLogEntries+1)
+	}
+	if msg := entries[0].Message; len(msg) > maxJobLogMessage+len("…") || !strings.HasSuffix(msg, "é…") {
+		t.Errorf("long message not cut at a rune: %d bytes, ends %q", len(msg), msg[len(msg)-8:])
+	}
+	if last := entries[len(entries)-1]; last.Message != "5 more log lines not kept" {
+		t.Errorf("last entry = %+v", last)
+	}
+}
+
+func TestAttachProcessingLog(t *testing.T) {
+	out := `{"score": 62}`
+	vr := &models.ValidationResult{VenueID: 7, AIOutputData: &out}
+	attachProcessingLog(vr, &jobLog{})
+	if *vr.AIOutputData != out {
+		t.Errorf("empty log changed ai_output_data: %s", *vr.AIOutputData)
+	}
+	attachProcessingLog(nil, &jobLog{}) // deferred scoring leaves no result
+
+	l := &jobLog{}
+	l.printf(models.LogLevelWarn, "Retryable network error for venue %d (attempt %d): timeout", 7, 1)
+	attachProcessingLog(vr, l)
+	h := models.ValidationHistory{AIOutputData: vr.AIOutputData}
+	got := h.ProcessingLog()
+	if len(got) != 1 || got[0].Level != models.LogLevelWarn || !strings.Contains(got[0].Message, "attempt 1") {
+		t.Errorf("stored log = %+v", got)
+	}
+	if !strings.Contains(*vr.AIOutputData, `"score":62`) {
+		t.Errorf("existing output lost: %s", *vr.AIOutputData)
+	}
+}
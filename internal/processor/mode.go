@@ -0,0 +1,45 @@
+package processor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Mode controls what a processing run does with its results. It travels with each job,
+// so a nightly score-only run and an on-demand auto-decide run can share the engine.
+type Mode string
+
+const (
+	// ModeScoreOnly records validation history (with Google data) but leaves venue status alone.
+	ModeScoreOnly Mode = "score_only"
+	// ModeAutoDecide records history and applies the decision to the venue's status.
+	ModeAutoDecide Mode = "auto_decide"
+	// ModeDryRun runs the full pipeline but only returns the result and copies it to the
+	// venue_validation_sandbox table; venues, history and the event log are untouched.
+	ModeDryRun Mode = "dry_run"
+	// ModeBatch is ModeScoreOnly with the AI scoring calls sent through the OpenAI Batch API,
+	// at half the price. Results reach validation history when the batch job completes,
+	// within 24 hours; see EnableBatchScoring.
+	ModeBatch Mode = "batch"
+)
+
+// DefaultMode is used when a request does not name a mode.
+const DefaultMode = ModeScoreOnly
+
+// ParseMode validates a mode from an API request. Empty selects DefaultMode.
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(strings.ToLower(strings.TrimSpace(s))); m {
+	case "":
+		return DefaultMode, nil
+	case ModeScoreOnly, ModeAutoDecide, ModeDryRun, ModeBatch:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unknown processing mode %q (want score_only, auto_decide, dry_run or batch)", s)
+	}
+}
+
+// updatesVenue reports whether results in this mode change the venue's active status.
+func (m Mode) updatesVenue() bool { return m == ModeAutoDecide }
+
+// persists reports whether results in this mode are written to validation history and events.
+func (m Mode) persists() bool { return m != ModeDryRun }
@@ -0,0 +1,28 @@
+package processor
+
+import "testing"
+
+func TestParseMode(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Mode
+		wantErr bool
+	}{
+		{"", ModeScoreOnly, false},
+		{"score_only", ModeScoreOnly, false},
+		{" Auto_Decide ", ModeAutoDecide, false},
+		{"dry_run", ModeDryRun, false},
+		{"batch", ModeBatch, false},
+		{"approve_all", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMode(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseMode(%q) = %q, %v; want %q, err=%v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+	if !ModeAutoDecide.updatesVenue() || ModeScoreOnly.updatesVenue() || ModeDryRun.persists() ||
+		ModeBatch.updatesVenue() || !ModeBatch.persists() {
+		t.Fatalf("unexpected mode persistence rules")
+	}
+}
@@ -0,0 +1,68 @@
+package processor
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+var mPanics = metrics.Default.CounterVec("processing_panics_total",
+	"Panics recovered while processing venues, by where they were caught: job or worker", "where")
+
+// recoverJob turns a panic in processJob into a failed result carrying the panic and its
+// stack trace in the job log, so one bad venue neither kills the worker nor the process.
+// It must be deferred directly by processJob.
+func (e *ProcessingEngine) recoverJob(result *ProcessingResult, start time.Time) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	mPanics.With("job").Inc()
+	e.stats.panics.Add(1)
+	result.jobLog.printf(models.LogLevelError, "Panic processing venue %d: %v\n%s", result.VenueID, r, panicStack())
+	result.Success = false
+	result.Deferred = false
+	result.ValidationResult = nil
+	result.Error = fmt.Errorf("panic: %v", r)
+	result.ProcessingTimeMs = time.Since(start).Milliseconds()
+}
+
+// recoverWorker replaces worker id after a panic outside processJob. The replacement keeps
+// the id and stop channel, so scaling down still reaches it. It must be deferred directly by
+// worker, after its wg.Done.
+func (e *ProcessingEngine) recoverWorker(id int, stopCh <-chan struct{}) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	mPanics.With("worker").Inc()
+	e.stats.panics.Add(1)
+	log.Printf("Worker %d panicked, starting a replacement: %v\n%s", id, r, panicStack())
+	e.wg.Add(1)
+	go e.worker(id, stopCh)
+}
+
+// panicStack is the stack of the panicking goroutine from the frame that panicked, leaving out
+// the recovery frames above it.
+func panicStack() []byte {
+	stack := debug.Stack()
+	i := bytes.Index(stack, []byte("\npanic("))
+	if i < 0 {
+		return stack
+	}
+	// Skip the panic( line and its file:line
+	rest := stack[i+1:]
+	for n := 0; n < 2; n++ {
+		j := bytes.IndexByte(rest, '\n')
+		if j < 0 {
+			return stack
+		}
+		rest = rest[j+1:]
+	}
+	return rest
+}
@@ -0,0 +1,73 @@
+package processor
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/decision"
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+)
+
+func newPanicTestEngine() *ProcessingEngine {
+	return NewProcessingEngine(&testutil.Repository{}, nil, testutil.NewMockScraper(), testutil.NewMockScorer(), nil, DefaultProcessingConfig(), decision.DefaultDecisionConfig())
+}
+
+// scoreWithNilMap stands in for a scorer bug.
+func scoreWithNilMap() {
+	var breakdown map[string]int
+	breakdown["ai"] = 80
+}
+
+func TestRecoverJob(t *testing.T) {
+	e := newPanicTestEngine()
+	result := &ProcessingResult{VenueID: 7, Success: true, ValidationResult: &models.ValidationResult{VenueID: 7}, jobLog: &jobLog{}}
+	func() {
+		defer e.recoverJob(result, time.Now())
+		scoreWithNilMap()
+	}()
+
+	if result.Success || result.ValidationResult != nil || result.Error == nil || !strings.Contains(result.Error.Error(), "assignment to entry in nil map") {
+		t.Fatalf("result = %+v, want a failed result with the panic", result)
+	}
+	entries := result.jobLog.snapshot()
+	if len(entries) != 1 || entries[0].Level != models.LogLevelError {
+		t.Fatalf("log = %+v", entries)
+	}
+	msg := entries[0].Message
+	if !strings.Contains(msg, "scoreWithNilMap") || strings.Contains(msg, "recoverJob") {
+		t.Errorf("stack trace should start at the panicking frame:\n%s", msg)
+	}
+	if got := e.GetStats().Panics; got != 1 {
+		t.Errorf("panics = %d, want 1", got)
+	}
+}
+
+func TestWorkerReplacedAfterPanic(t *testing.T) {
+	e := newPanicTestEngine()
+	stop := make(chan struct{})
+	e.wg.Add(1)
+	go e.worker(1, stop)
+
+	// A nil job panics before processJob can recover it; each one is taken by a fresh worker
+	for want := int64(1); want <= 2; want++ {
+		e.jobQueue <- nil
+		deadline := time.Now().Add(2 * time.Second)
+		for e.GetStats().Panics < want {
+			if time.Now().After(deadline) {
+				t.Fatalf("panics = %d, want %d: worker not replaced", e.GetStats().Panics, want)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	close(stop)
+	done := make(chan struct{})
+	go func() { e.wg.Wait(); close(done) }()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("replacement worker did not stop")
+	}
+}
@@ -0,0 +1,213 @@
+package processor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"assisted-venue-approval/internal/flags"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// PhotoFetcher downloads Google Place photos. Implemented by the Google scraper.
+type PhotoFetcher interface {
+	FetchPhoto(ctx context.Context, reference string, maxWidth uint) (*models.VenuePhoto, error)
+}
+
+// PhotoReviewer runs the vision check on downloaded photos.
+type PhotoReviewer interface {
+	ReviewPhotos(ctx context.Context, venue models.Venue, photos []models.VenuePhoto) (*models.PhotoAssessment, error)
+}
+
+// PhotoCheckConfig gates the optional vision check. It is off by default because every
+// venue costs extra Place Photo requests plus a vision call.
+type PhotoCheckConfig struct {
+	Enabled        bool
+	MaxPhotos      int     // photos per venue sent to the model
+	MaxWidth       uint    // requested photo width in px
+	DailyBudgetUSD float64 // photo and vision spend cap per UTC day; 0 = unlimited
+}
+
+// DefaultPhotoCheckConfig returns conservative defaults with the check disabled.
+func DefaultPhotoCheckConfig() PhotoCheckConfig {
+	return PhotoCheckConfig{MaxPhotos: 2, MaxWidth: 512, DailyBudgetUSD: 1.0}
+}
+
+// Score adjustments from the photo check, recorded under score_breakdown["photo_verification"].
+const (
+	photoNotFoodPenalty  = -10
+	photoFoodVenueBonus  = 3
+	photoVeganSignBonus  = 2
+	photoNotFoodMinConf  = 0.7
+	photoBreakdownKey    = "photo_verification"
+	photoOutputKey       = "photo_check"
+	photoBudgetDayLayout = "2006-01-02"
+)
+
+// Costs reserved against the daily budget before a check; the actual cost replaces the
+// estimate once the check is done.
+const (
+	placePhotoCostUSD      = 0.007 // Place Photo list price per request, legacy and new API
+	photoReviewEstimateUSD = 0.003 // a vision call on two 512px photos
+)
+
+var (
+	mPhotoChecks  = metrics.Default.Counter("photo_checks_total", "Vision photo checks performed")
+	mPhotoSkipped = metrics.Default.Counter("photo_checks_budget_skipped_total", "Vision photo checks skipped by the daily budget")
+	gPhotoSpend   = metrics.Default.Gauge("photo_check_spend_usd_today", "Estimated photo check spend today, Place Photo requests included (USD)")
+)
+
+// photoBudget tracks estimated photo check spend for the current UTC day.
+type photoBudget struct {
+	mu    sync.Mutex
+	day   string
+	spent float64
+}
+
+// reserve charges cost if it fits under limit (0 = unlimited). Checking and charging in one
+// step keeps concurrent workers from overshooting the limit together.
+func (b *photoBudget) reserve(limit, cost float64, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rollLocked(now)
+	if limit > 0 && b.spent+cost > limit {
+		return false
+	}
+	b.spent += cost
+	return true
+}
+
+// settle replaces a reservation made at reservedAt with the actual cost and returns the
+// day's spend. A reservation made on an earlier day went with that day's spend.
+func (b *photoBudget) settle(reserved, actual float64, reservedAt, now time.Time) float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rollLocked(now)
+	if reservedAt.UTC().Format(photoBudgetDayLayout) == b.day {
+		actual -= reserved
+	}
+	b.spent = max(0, b.spent+actual)
+	return b.spent
+}
+
+func (b *photoBudget) add(cost float64, now time.Time) float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rollLocked(now)
+	b.spent += cost
+	return b.spent
+}
+
+func (b *photoBudget) rollLocked(now time.Time) {
+	if d := now.UTC().Format(photoBudgetDayLayout); d != b.day {
+		b.day = d
+		b.spent = 0
+	}
+}
+
+// SetPhotoCheck wires the vision reviewer and its config. A nil reviewer disables the check.
+func (e *ProcessingEngine) SetPhotoCheck(r PhotoReviewer, cfg PhotoCheckConfig) {
+	e.avaConfigMu.Lock()
+	defer e.avaConfigMu.Unlock()
+	e.photoReviewer = r
+	e.photoCfg = cfg
+}
+
+// checkPhotos runs the vision check when enabled, within budget and when Google returned photos.
+// It adjusts vr.Score and records the delta in the score breakdown. Failures never fail scoring.
+func (e *ProcessingEngine) checkPhotos(ctx context.Context, venue models.Venue, gData *models.GooglePlaceData, vr *models.ValidationResult) *models.PhotoAssessment {
+	e.avaConfigMu.RLock()
+	reviewer, cfg := e.photoReviewer, e.photoCfg
+	e.avaConfigMu.RUnlock()
+
+	if !e.flags.Enabled(flags.PhotoCheck, venue.ID, cfg.Enabled) || reviewer == nil || gData == nil || len(gData.Photos) == 0 || vr == nil {
+		return nil
+	}
+	fetcher, ok := e.scraper.(PhotoFetcher)
+	if !ok {
+		return nil
+	}
+
+	n := cfg.MaxPhotos
+	if n <= 0 || n > len(gData.Photos) {
+		n = len(gData.Photos)
+	}
+	// The whole check is reserved up front and settled at its actual cost
+	reserved, reservedAt := float64(n)*placePhotoCostUSD+photoReviewEstimateUSD, time.Now()
+	if !e.photoBudget.reserve(cfg.DailyBudgetUSD, reserved, reservedAt) {
+		mPhotoSkipped.Inc(1)
+		return nil
+	}
+	spent := 0.0
+	defer func() { gPhotoSpend.SetFloat64(e.photoBudget.settle(reserved, spent, reservedAt, time.Now())) }()
+
+	photos := make([]models.VenuePhoto, 0, n)
+	for _, ref := range gData.Photos[:n] {
+		if err := e.photoRateLimit.WaitFor(ctx, venuePath(venue), costPhoto); err != nil {
+			return nil
+		}
+		p, err := fetcher.FetchPhoto(ctx, ref.Reference, cfg.MaxWidth)
+		e.stats.google.Add(1)
+		mApiGoogle.Inc(1)
+		spent += placePhotoCostUSD
+		if err != nil {
+			logf(ctx, models.LogLevelWarn, "photo check: venue %d: %v", venue.ID, err)
+			continue
+		}
+		photos = append(photos, *p)
+	}
+	if len(photos) == 0 {
+		return nil
+	}
+
+	if err := e.openAIRateLimit.WaitFor(ctx, venuePath(venue), costPhotoReview); err != nil {
+		return nil
+	}
+	pa, err := reviewer.ReviewPhotos(ctx, venue, photos)
+	e.stats.openAI.Add(1)
+	mApiOpenAI.Inc(1)
+	if err != nil {
+		logf(ctx, models.LogLevelWarn, "photo check failed for venue %d: %v (continuing without photo data)", venue.ID, err)
+		return nil
+	}
+	mPhotoChecks.Inc(1)
+	spent += pa.CostUSD
+
+	delta := photoScoreDelta(pa)
+	if vr.ScoreBreakdown == nil {
+		vr.ScoreBreakdown = make(map[string]int)
+	}
+	vr.ScoreBreakdown[photoBreakdownKey] = delta
+	vr.Score = clampScore(vr.Score + delta)
+	return pa
+}
+
+// photoScoreDelta converts an assessment into a score adjustment. A confident "not a food
+// venue" is penalised; positive evidence only adds a small bonus since photos are often stale.
+func photoScoreDelta(pa *models.PhotoAssessment) int {
+	if pa == nil {
+		return 0
+	}
+	if !pa.FoodVenue {
+		if pa.Confidence >= photoNotFoodMinConf {
+			return photoNotFoodPenalty
+		}
+		return 0
+	}
+	delta := photoFoodVenueBonus
+	if pa.VeganSignage {
+		delta += photoVeganSignBonus
+	}
+	return delta
+}
+
+func clampScore(s int) int {
+	if s < 0 {
+		return 0
+	}
+	if s > 100 {
+		return 100
+	}
+	return s
+}
@@ -0,0 +1,148 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/decision"
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+)
+
+func TestPhotoScoreDelta(t *testing.T) {
+	tests := []struct {
+		name string
+		pa   *models.PhotoAssessment
+		want int
+	}{
+		{"nil", nil, 0},
+		{"confident not food", &models.PhotoAssessment{FoodVenue: false, Confidence: 0.9}, photoNotFoodPenalty},
+		{"unsure not food", &models.PhotoAssessment{FoodVenue: false, Confidence: 0.4}, 0},
+		{"food venue", &models.PhotoAssessment{FoodVenue: true, Confidence: 0.8}, photoFoodVenueBonus},
+		{"food venue with signage", &models.PhotoAssessment{FoodVenue: true, VeganSignage: true}, photoFoodVenueBonus + photoVeganSignBonus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := photoScoreDelta(tt.pa); got != tt.want {
+				t.Fatalf("delta=%d want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPhotoBudget(t *testing.T) {
+	var b photoBudget
+	day1 := time.Date(2026, 3, 1, 23, 0, 0, 0, time.UTC)
+	if !b.reserve(0.01, 0.01, day1) {
+		t.Fatalf("fresh budget should allow")
+	}
+	b.add(0.01, day1)
+	if b.reserve(0.01, 0.001, day1) {
+		t.Fatalf("exhausted budget should block")
+	}
+	if !b.reserve(0, 0.001, day1) {
+		t.Fatalf("zero limit means unlimited")
+	}
+	if !b.reserve(0.01, 0.01, day1.Add(2*time.Hour)) {
+		t.Fatalf("budget should reset on a new UTC day")
+	}
+}
+
+func TestPhotoBudget_Reserve(t *testing.T) {
+	var b photoBudget
+	day1 := time.Date(2026, 3, 1, 23, 0, 0, 0, time.UTC)
+
+	// Concurrent checks never reserve past the limit together
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	granted := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if b.reserve(0.1, 0.017, day1) {
+				mu.Lock()
+				granted++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if granted != 5 {
+		t.Fatalf("%d checks reserved, want 5 within the limit", granted)
+	}
+
+	// Settling swaps the estimate for the actual cost, freeing room for another check
+	if got := b.settle(0.017, 0.007, day1, day1); got < 0.0749 || got > 0.0751 {
+		t.Fatalf("spend after settling = %v, want 0.075", got)
+	}
+	if !b.reserve(0.1, 0.017, day1) {
+		t.Fatal("freed budget not reusable")
+	}
+
+	// A reservation from yesterday is not taken off today's spend
+	day2 := day1.Add(2 * time.Hour)
+	if got := b.settle(0.017, 0.01, day1, day2); got != 0.01 {
+		t.Fatalf("spend on the new day = %v, want the actual cost only", got)
+	}
+	if !b.reserve(0, 5, day2) {
+		t.Fatal("zero limit means unlimited")
+	}
+}
+
+type photoScraper struct {
+	*testutil.FakeScraper
+	fail bool
+}
+
+func (s *photoScraper) FetchPhoto(context.Context, string, uint) (*models.VenuePhoto, error) {
+	if s.fail {
+		return nil, errors.New("photo not found")
+	}
+	return &models.VenuePhoto{}, nil
+}
+
+type fixedCostReviewer float64
+
+func (r fixedCostReviewer) ReviewPhotos(context.Context, models.Venue, []models.VenuePhoto) (*models.PhotoAssessment, error) {
+	return &models.PhotoAssessment{FoodVenue: true, CostUSD: float64(r)}, nil
+}
+
+func TestCheckPhotos_ChargesPhotoRequests(t *testing.T) {
+	scraper := &photoScraper{FakeScraper: &testutil.FakeScraper{Script: testutil.NewScript()}}
+	store := testutil.NewMemoryStore()
+	e := NewProcessingEngine(store.Repository(), store.UnitOfWorkFactory(), scraper, &testutil.FakeScorer{}, &testutil.FakeQualityReviewer{},
+		DefaultProcessingConfig(), decision.DefaultDecisionConfig())
+	t.Cleanup(func() { _ = e.Stop(time.Second) })
+	e.SetPhotoCheck(fixedCostReviewer(0.001), PhotoCheckConfig{Enabled: true, MaxPhotos: 2, DailyBudgetUSD: 0.05})
+
+	gData := &models.GooglePlaceData{Photos: []models.GooglePhoto{{Reference: "a"}, {Reference: "b"}, {Reference: "c"}}}
+	spent := func() float64 { return e.photoBudget.add(0, time.Now()) }
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+
+	// Two photos and the vision call, at their actual cost
+	if pa := e.checkPhotos(context.Background(), models.Venue{ID: 1}, gData, &models.ValidationResult{Score: 50}); pa == nil {
+		t.Fatal("photo check skipped")
+	}
+	if got := spent(); !near(got, 2*placePhotoCostUSD+0.001) {
+		t.Fatalf("spend = %v, want two photos and the vision call", got)
+	}
+
+	// Failed photo requests are billed too, though no vision call follows
+	scraper.fail = true
+	e.checkPhotos(context.Background(), models.Venue{ID: 2}, gData, &models.ValidationResult{Score: 50})
+	if got := spent(); !near(got, 4*placePhotoCostUSD+0.001) {
+		t.Fatalf("spend = %v, want the failed requests charged", got)
+	}
+
+	// 0.029 + a 0.017 estimate fits under 0.05; the next estimate would not
+	scraper.fail = false
+	e.checkPhotos(context.Background(), models.Venue{ID: 3}, gData, &models.ValidationResult{Score: 50})
+	if pa := e.checkPhotos(context.Background(), models.Venue{ID: 4}, gData, &models.ValidationResult{Score: 50}); pa != nil {
+		t.Fatalf("check ran past the budget at spend %v", spent())
+	}
+}
@@ -0,0 +1,262 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/decision"
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+	"assisted-venue-approval/pkg/events"
+)
+
+// pipeline runs venues through processJob and handleResult against fake providers and an
+// in-memory store, so each scenario's outcome is deterministic.
+type pipeline struct {
+	script *testutil.Script
+	store  *testutil.MemoryStore
+	engine *ProcessingEngine
+}
+
+func newPipeline(t *testing.T, venues ...models.VenueWithUser) *pipeline {
+	t.Helper()
+	script := testutil.NewScript()
+	store := testutil.NewMemoryStore(venues...)
+	cfg := DefaultProcessingConfig()
+	cfg.Retry.BaseDelay = time.Millisecond
+	e := NewProcessingEngine(store.Repository(), store.UnitOfWorkFactory(),
+		&testutil.FakeScraper{Script: script}, &testutil.FakeScorer{Script: script}, &testutil.FakeQualityReviewer{Script: script},
+		cfg, decision.DefaultDecisionConfig())
+	t.Cleanup(func() { _ = e.Stop(time.Second) })
+	return &pipeline{script: script, store: store, engine: e}
+}
+
+// run processes one venue in mode and writes its result, flushing batched writes.
+func (p *pipeline) run(vu models.VenueWithUser, mode Mode) *ProcessingResult {
+	job := &ProcessingJob{Venue: vu.Venue, User: vu.User, Mode: mode}
+	result := p.engine.processJob(job)
+	p.engine.handleResult(result)
+	if p.engine.batcher != nil {
+		p.engine.batcher.flush(context.Background())
+	}
+	return result
+}
+
+func TestPipelineScenarios(t *testing.T) {
+	tests := []struct {
+		scenario    testutil.Scenario
+		lowTrust    bool // submitted by LowTrustVenue's member instead of a trusted one
+		success     bool
+		active      int
+		status      string // of the stored validation; "" when none is stored
+		googleCalls int
+		scorerCalls int
+		check       func(t *testing.T, h models.ValidationHistory)
+	}{
+		{scenario: testutil.ScenarioApprove, success: true, active: 1, status: "approved", googleCalls: 1, scorerCalls: 1,
+			check: func(t *testing.T, h models.ValidationHistory) {
+				if h.AIOutputData == nil || !strings.Contains(*h.AIOutputData, `"quality_review"`) {
+					t.Errorf("approved history = %+v", h)
+				}
+			}},
+		{scenario: testutil.ScenarioReject, lowTrust: true, success: true, active: -1, status: "rejected", googleCalls: 1, scorerCalls: 1},
+		// Trusted members are at the trust gate, so their low scores go to an editor instead
+		{scenario: testutil.ScenarioReject, success: true, active: 0, status: "manual_review", googleCalls: 1, scorerCalls: 1},
+		{scenario: testutil.ScenarioNoMatch, success: true, active: 0, status: "manual_review", googleCalls: 1, scorerCalls: 0,
+			check: func(t *testing.T, h models.ValidationHistory) {
+				if _, ok := h.ScoreBreakdown["no_location"]; !ok || h.ValidationScore != 0 {
+					t.Errorf("no-match history = %+v", h)
+				}
+				// Unscored: the trust assessment and timings are kept, the AI never ran
+				var out map[string]json.RawMessage
+				if h.AIOutputData == nil || json.Unmarshal([]byte(*h.AIOutputData), &out) != nil {
+					t.Fatalf("no-match ai_output_data = %v", h.AIOutputData)
+				}
+				if got := slices.Sorted(maps.Keys(out)); !slices.Equal(got, []string{"scoring", "timings", "trust"}) {
+					t.Errorf("no-match ai_output_data keys = %v", got)
+				}
+			}},
+		// Google 503s are retried per the server error budget, then the venue goes to review
+		// with a row recording the failed stage
+		{scenario: testutil.ScenarioAPIError, success: false, active: 0, status: "manual_review", googleCalls: 1 + DefaultRetryPolicy().Budgets["server"],
+			check: func(t *testing.T, h models.ValidationHistory) {
+				p := h.Progress()
+				if p == nil || p.FailedStage != StageGoogle || len(p.Completed) != 0 || p.Error == "" || h.GooglePlaceData != nil {
+					t.Errorf("api-error history = %+v, progress %+v", h, p)
+				}
+			}},
+		{scenario: testutil.ScenarioMalformedOutput, success: true, active: 0, status: "manual_review", googleCalls: 1, scorerCalls: 1,
+			check: func(t *testing.T, h models.ValidationHistory) {
+				if h.AIOutputData == nil || !strings.Contains(*h.AIOutputData, `"reason":"failed"`) {
+					t.Errorf("malformed-output history = %+v", h)
+				}
+			}},
+		// An exhausted quota is not retried; the Google data is kept for the reviewer
+		{scenario: testutil.ScenarioBudgetExceeded, success: false, active: 0, status: "manual_review", googleCalls: 1, scorerCalls: 1,
+			check: func(t *testing.T, h models.ValidationHistory) {
+				if h.ScoreBreakdown["google_data_only"] != 1 || len(h.ProcessingLog()) == 0 {
+					t.Errorf("budget-exceeded history = %+v", h)
+				}
+				if p := h.Progress(); p == nil || p.FailedStage != StageOpenAI || len(p.Completed) != 1 || p.Completed[0] != StageGoogle {
+					t.Errorf("budget-exceeded progress = %+v", p)
+				}
+			}},
+	}
+	for i, tt := range tests {
+		t.Run(string(tt.scenario), func(t *testing.T) {
+			vu := testutil.PipelineVenue(int64(100+i), "Green Bowl "+string(tt.scenario))
+			if tt.lowTrust {
+				vu = testutil.LowTrustVenue(vu.Venue.ID, vu.Venue.Name)
+			}
+			p := newPipeline(t, vu)
+			p.script.Set(vu.Venue.ID, tt.scenario)
+
+			result := p.run(vu, ModeAutoDecide)
+			if result.Success != tt.success {
+				t.Fatalf("success = %v (error %v), want %v", result.Success, result.Error, tt.success)
+			}
+			if got := p.script.Calls("google", vu.Venue.ID); got != tt.googleCalls {
+				t.Errorf("google calls = %d, want %d", got, tt.googleCalls)
+			}
+			if got := p.script.Calls("scorer", vu.Venue.ID); got != tt.scorerCalls {
+				t.Errorf("scorer calls = %d, want %d", got, tt.scorerCalls)
+			}
+			if a := p.store.Active(vu.Venue.ID); a == nil || *a != tt.active {
+				t.Errorf("active = %v, want %d", a, tt.active)
+			}
+			history := p.store.History(vu.Venue.ID)
+			if tt.status == "" {
+				if len(history) != 0 {
+					t.Errorf("history = %+v, want none", history)
+				}
+				return
+			}
+			if len(history) != 1 || history[0].ValidationStatus != tt.status {
+				t.Fatalf("history = %+v, want one %s row", history, tt.status)
+			}
+			if tt.check != nil {
+				tt.check(t, history[0])
+			}
+		})
+	}
+}
+
+func TestPipeline_GoogleFailureStaysPending(t *testing.T) {
+	vu := testutil.PipelineVenue(150, "Falafel Corner")
+	p := newPipeline(t, vu)
+	p.script.Set(vu.Venue.ID, testutil.ScenarioAPIError)
+
+	if r := p.run(vu, ModeAutoDecide); r.Success {
+		t.Fatal("api error succeeded")
+	}
+	// The failure is on record for the venue page, but a run without force still takes the venue
+	if h := p.store.History(150); len(h) != 1 || h[0].Reviewed() {
+		t.Fatalf("history = %+v, want one unreviewed failure row", h)
+	}
+	if has, _ := p.store.Repository().HasAnyValidationHistory(150); has {
+		t.Fatal("failure row counted as validation history; the next non-forced run would skip the venue")
+	}
+
+	// Once Google is back the venue is reviewed and leaves the pending venues
+	p.script.Set(vu.Venue.ID, testutil.ScenarioApprove)
+	if r := p.run(vu, ModeAutoDecide); !r.Success {
+		t.Fatalf("rerun failed: %v", r.Error)
+	}
+	if has, _ := p.store.Repository().HasAnyValidationHistory(150); !has {
+		t.Fatal("reviewed venue still counted as pending")
+	}
+}
+
+func TestPipelineModes(t *testing.T) {
+	vu := testutil.PipelineVenue(200, "Tofu Haus")
+	p := newPipeline(t, vu)
+
+	// A dry run leaves the venue and its history alone
+	if r := p.run(vu, ModeDryRun); !r.Success {
+		t.Fatalf("dry run failed: %v", r.Error)
+	}
+	if p.store.Active(200) != nil || len(p.store.History(200)) != 0 || len(p.store.Sandbox(200)) != 1 {
+		t.Fatalf("dry run wrote outside the sandbox")
+	}
+
+	// Score-only records history without touching the venue's status
+	p.run(vu, ModeScoreOnly)
+	if p.store.Active(200) != nil || len(p.store.History(200)) != 1 {
+		t.Fatalf("score-only: active %v, %d history rows", p.store.Active(200), len(p.store.History(200)))
+	}
+}
+
+// recordingEvents is an event store that only counts appends.
+type recordingEvents struct {
+	events.EventStore
+	appended int
+}
+
+func (r *recordingEvents) Append(context.Context, events.Event) error {
+	r.appended++
+	return nil
+}
+
+func TestPipeline_DryRunWritesOnlySandbox(t *testing.T) {
+	approve := testutil.PipelineVenue(210, "Green Bowl")
+	failing := testutil.PipelineVenue(211, "Seitan Shack")
+	p := newPipeline(t, approve, failing)
+	p.script.Set(failing.Venue.ID, testutil.ScenarioAPIError)
+	es := &recordingEvents{}
+	p.engine.SetEventStore(es)
+	var sandbox, history, uows int
+	repo := p.engine.repo.(*testutil.Repository)
+	saveSandbox, saveHistory := repo.SaveSandboxResultCtxFunc, repo.SaveValidationResultWithGoogleDataCtxFunc
+	repo.SaveSandboxResultCtxFunc = func(ctx context.Context, r *models.ValidationResult, gd *models.GooglePlaceData) error {
+		sandbox++
+		return saveSandbox(ctx, r, gd)
+	}
+	repo.SaveValidationResultWithGoogleDataCtxFunc = func(ctx context.Context, r *models.ValidationResult, gd *models.GooglePlaceData) error {
+		history++
+		return saveHistory(ctx, r, gd)
+	}
+	factory := p.engine.uowFactory.(*testutil.UnitOfWorkFactory)
+	begin := factory.BeginFunc
+	factory.BeginFunc = func(ctx context.Context) (domain.UnitOfWork, error) {
+		uows++
+		return begin(ctx)
+	}
+
+	// Queue and sync paths alike, for a result and for a failure
+	p.run(approve, ModeDryRun)
+	p.run(failing, ModeDryRun)
+	if _, err := p.engine.ProcessSingleVenueSync(context.Background(), approve, ModeDryRun); err != nil {
+		t.Fatalf("sync dry run: %v", err)
+	}
+	p.engine.ProcessSingleVenueSync(context.Background(), failing, ModeDryRun)
+
+	if sandbox != 2 {
+		t.Errorf("%d sandbox writes, want one per successful dry run", sandbox)
+	}
+	if history != 0 {
+		t.Errorf("%d history writes", history)
+	}
+	if uows != 0 {
+		t.Errorf("%d units of work begun; venue and history writes go through them", uows)
+	}
+	if es.appended != 0 {
+		t.Errorf("%d events appended", es.appended)
+	}
+	for _, id := range []int64{210, 211} {
+		if p.store.Active(id) != nil || len(p.store.History(id)) != 0 {
+			t.Errorf("venue %d: active %v, %d history rows", id, p.store.Active(id), len(p.store.History(id)))
+		}
+	}
+
+	// The same run in a persisting mode does write, so the counts above mean something
+	p.run(approve, ModeScoreOnly)
+	if history+uows == 0 || es.appended == 0 {
+		t.Fatal("score-only run wrote no history or events")
+	}
+}
@@ -0,0 +1,211 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// PrefilterConfig toggles the auto-reject rules that run before any Google/OpenAI call.
+// Each rule is independent; a disabled rule is never evaluated.
+type PrefilterConfig struct {
+	EmptyName           bool
+	URLOnlyName         bool
+	BlockedDomains      bool
+	BlockedDomainList   []string
+	Profanity           bool
+	ProfanityWords      []string // empty = defaultProfanityWords
+	DuplicateSubmission bool
+	DuplicateWindowDays int
+}
+
+// DefaultPrefilterConfig enables only the rules that need no tuning.
+func DefaultPrefilterConfig() PrefilterConfig {
+	return PrefilterConfig{
+		EmptyName:           true,
+		URLOnlyName:         true,
+		DuplicateWindowDays: 7,
+	}
+}
+
+// Auto-reject reason codes; also used as metric suffixes and score_breakdown keys.
+const (
+	prefilterEmptyName     = "prefilter_empty_name"
+	prefilterURLName       = "prefilter_url_name"
+	prefilterBlockedDomain = "prefilter_blocked_domain"
+	prefilterProfanity     = "prefilter_profanity"
+	prefilterDuplicate     = "prefilter_duplicate_submission"
+)
+
+var mPrefilter = map[string]*metrics.Counter{
+	prefilterEmptyName:     metrics.Default.Counter("prefilter_empty_name_total", "Auto-rejected: empty venue name"),
+	prefilterURLName:       metrics.Default.Counter("prefilter_url_name_total", "Auto-rejected: venue name is a URL"),
+	prefilterBlockedDomain: metrics.Default.Counter("prefilter_blocked_domain_total", "Auto-rejected: blocked domain"),
+	prefilterProfanity:     metrics.Default.Counter("prefilter_profanity_total", "Auto-rejected: profanity"),
+	prefilterDuplicate:     metrics.Default.Counter("prefilter_duplicate_submission_total", "Auto-rejected: duplicate submission by the same user"),
+}
+
+// defaultProfanityWords is intentionally short; operators extend it via PREFILTER_PROFANITY_WORDS.
+var defaultProfanityWords = []string{"fuck", "shit", "cunt", "bitch", "asshole", "porn", "viagra", "casino"}
+
+var (
+	// A URL needs a scheme or www.; names like "b.good" or "Mr.Green" are dotted but not domains
+	urlNameRe = regexp.MustCompile(`(?i)^(https?://|www\.)[a-z0-9-]+(\.[a-z0-9-]+)+(/\S*)?$`)
+	// A bare domain counts only with one of the TLDs spam submissions use
+	bareDomainRe = regexp.MustCompile(`(?i)^[a-z0-9-]+(\.[a-z0-9-]+)*\.(com|net|org|info|biz|io|xyz|online|site|shop|store|top)(/\S*)?$`)
+	wordRe       = regexp.MustCompile(`[\p{L}\p{N}]+`)
+)
+
+// checkEmptyName rejects names that are blank or contain no letters/digits.
+func checkEmptyName(venue *models.Venue) (bool, EarlyExitReason) {
+	if wordRe.MatchString(venue.Name) {
+		return false, EarlyExitReason{}
+	}
+	return true, EarlyExitReason{Code: prefilterEmptyName, Description: "Auto-rejected: venue name is empty"}
+}
+
+// checkURLOnlyName rejects names that are nothing but a URL, or a bare domain with a common
+// TLD.
+func checkURLOnlyName(venue *models.Venue) (bool, EarlyExitReason) {
+	name := strings.TrimSpace(venue.Name)
+	if name == "" || strings.ContainsAny(name, " \t") || !(urlNameRe.MatchString(name) || bareDomainRe.MatchString(name)) {
+		return false, EarlyExitReason{}
+	}
+	return true, EarlyExitReason{
+		Code:        prefilterURLName,
+		Description: fmt.Sprintf("Auto-rejected: venue name is a URL (%q)", name),
+	}
+}
+
+// checkBlockedDomains rejects venues whose website, social links, email or name point at a blocked domain.
+// A blocked entry matches the domain itself and any subdomain.
+func checkBlockedDomains(venue *models.Venue, blocked []string) (bool, EarlyExitReason) {
+	if len(blocked) == 0 {
+		return false, EarlyExitReason{}
+	}
+	var hosts []string
+	for _, u := range []*string{venue.URL, venue.FBUrl, venue.InstagramUrl} {
+		if h := hostOf(u); h != "" {
+			hosts = append(hosts, h)
+		}
+	}
+	if venue.Email != nil {
+		if at := strings.LastIndex(*venue.Email, "@"); at >= 0 {
+			hosts = append(hosts, strings.ToLower(strings.TrimSpace((*venue.Email)[at+1:])))
+		}
+	}
+	name := strings.ToLower(venue.Name)
+	for _, d := range blocked {
+		d = strings.ToLower(strings.TrimSpace(d))
+		if d == "" {
+			continue
+		}
+		for _, h := range hosts {
+			if h == d || strings.HasSuffix(h, "."+d) {
+				return true, EarlyExitReason{Code: prefilterBlockedDomain, Description: fmt.Sprintf("Auto-rejected: blocked domain %s", d)}
+			}
+		}
+		if strings.Contains(name, d) {
+			return true, EarlyExitReason{Code: prefilterBlockedDomain, Description: fmt.Sprintf("Auto-rejected: blocked domain %s in name", d)}
+		}
+	}
+	return false, EarlyExitReason{}
+}
+
+// checkProfanity rejects venues whose name or description contains a listed word.
+// Matching is whole-word so "Scunthorpe" style false positives are avoided.
+func checkProfanity(venue *models.Venue, words []string) (bool, EarlyExitReason) {
+	if len(words) == 0 {
+		words = defaultProfanityWords
+	}
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		if w = strings.ToLower(strings.TrimSpace(w)); w != "" {
+			set[w] = true
+		}
+	}
+	text := venue.Name + " " + venue.VDetails
+	if venue.AdditionalInfo != nil {
+		text += " " + *venue.AdditionalInfo
+	}
+	for _, w := range wordRe.FindAllString(strings.ToLower(text), -1) {
+		if set[w] {
+			return true, EarlyExitReason{Code: prefilterProfanity, Description: "Auto-rejected: submission contains blocked words"}
+		}
+	}
+	return false, EarlyExitReason{}
+}
+
+// checkDuplicateSubmission rejects a venue when the same user submitted a pending or active
+// venue with the same name earlier within the window. Later copies are not counted, so the
+// original passes even when it is processed after (or alongside) its duplicate.
+func checkDuplicateSubmission(ctx context.Context, repo domain.VenueReader, venue *models.Venue, windowDays int) (bool, EarlyExitReason) {
+	if venue.UserID == 0 || windowDays <= 0 || strings.TrimSpace(venue.Name) == "" {
+		return false, EarlyExitReason{}
+	}
+	since := time.Now().AddDate(0, 0, -windowDays)
+	n, err := repo.CountRecentVenuesByUserAndNameCtx(ctx, venue.UserID, venue.Name, since, venue.ID)
+	if err != nil || n == 0 {
+		// DB errors must not auto-reject; the venue continues through normal validation
+		return false, EarlyExitReason{}
+	}
+	return true, EarlyExitReason{
+		Code:        prefilterDuplicate,
+		Description: fmt.Sprintf("Auto-rejected: same user submitted %q %d time(s) in the last %d days", venue.Name, n, windowDays),
+	}
+}
+
+// autoRejectPrefilter runs the enabled rules in cheapest-first order and returns the first hit.
+func autoRejectPrefilter(ctx context.Context, repo domain.VenueReader, venue *models.Venue, cfg PrefilterConfig) (bool, EarlyExitReason) {
+	if cfg.EmptyName {
+		if hit, reason := checkEmptyName(venue); hit {
+			return true, reason
+		}
+	}
+	if cfg.URLOnlyName {
+		if hit, reason := checkURLOnlyName(venue); hit {
+			return true, reason
+		}
+	}
+	if cfg.BlockedDomains {
+		if hit, reason := checkBlockedDomains(venue, cfg.BlockedDomainList); hit {
+			return true, reason
+		}
+	}
+	if cfg.Profanity {
+		if hit, reason := checkProfanity(venue, cfg.ProfanityWords); hit {
+			return true, reason
+		}
+	}
+	if cfg.DuplicateSubmission && repo != nil {
+		if hit, reason := checkDuplicateSubmission(ctx, repo, venue, cfg.DuplicateWindowDays); hit {
+			return true, reason
+		}
+	}
+	return false, EarlyExitReason{}
+}
+
+func hostOf(raw *string) string {
+	if raw == nil {
+		return ""
+	}
+	s := strings.TrimSpace(*raw)
+	if s == "" {
+		return ""
+	}
+	if !strings.Contains(s, "://") {
+		s = "http://" + s
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
@@ -0,0 +1,82 @@
+package processor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+)
+
+func dupRepo(count int) *testutil.VenueReader {
+	return &testutil.VenueReader{
+		CountRecentVenuesByUserAndNameCtxFunc: func(_ context.Context, _ uint, _ string, _ time.Time, _ int64) (int, error) {
+			return count, nil
+		},
+	}
+}
+
+func TestAutoRejectPrefilter(t *testing.T) {
+	str := func(s string) *string { return &s }
+	all := PrefilterConfig{
+		EmptyName: true, URLOnlyName: true,
+		BlockedDomains: true, BlockedDomainList: []string{"spam.example"},
+		Profanity: true, DuplicateSubmission: true, DuplicateWindowDays: 7,
+	}
+
+	tests := []struct {
+		name     string
+		venue    models.Venue
+		cfg      PrefilterConfig
+		dups     int
+		wantCode string
+	}{
+		{"clean venue", models.Venue{ID: 1, Name: "Green Leaf Cafe", UserID: 5}, all, 0, ""},
+		{"blank name", models.Venue{Name: "  ", UserID: 5}, all, 0, prefilterEmptyName},
+		{"punctuation only", models.Venue{Name: "!!!"}, all, 0, prefilterEmptyName},
+		{"blank name rule disabled", models.Venue{Name: ""}, PrefilterConfig{}, 0, ""},
+		{"url name", models.Venue{Name: "https://cheap-pills.example/buy"}, all, 0, prefilterURLName},
+		{"bare domain name", models.Venue{Name: "www.veganfood.example"}, all, 0, prefilterURLName},
+		{"name with dot is fine", models.Venue{Name: "Mr. Green"}, all, 0, ""},
+		{"bare domain with common tld", models.Venue{Name: "cheap-pills.com/buy"}, all, 0, prefilterURLName},
+		{"dotted brand name", models.Venue{Name: "b.good"}, all, 0, ""},
+		{"dotted name without space", models.Venue{Name: "Mr.Green"}, all, 0, ""},
+		{"abbreviated name", models.Venue{Name: "St.Pauli"}, all, 0, ""},
+		{"dotted name with country-like suffix", models.Venue{Name: "Veg.de"}, all, 0, ""},
+		{"blocked website subdomain", models.Venue{Name: "Leaf", URL: str("http://shop.spam.example/x")}, all, 0, prefilterBlockedDomain},
+		{"blocked email", models.Venue{Name: "Leaf", Email: str("x@spam.example")}, all, 0, prefilterBlockedDomain},
+		{"similar domain not blocked", models.Venue{Name: "Leaf", URL: str("notspam.example")}, all, 0, ""},
+		{"profanity in description", models.Venue{Name: "Leaf", VDetails: "best casino in town"}, all, 0, prefilterProfanity},
+		{"profanity substring ignored", models.Venue{Name: "Scunthorpe Vegan"}, all, 0, ""},
+		{"custom profanity list", models.Venue{Name: "Leaf", AdditionalInfo: str("buy crypto")}, PrefilterConfig{Profanity: true, ProfanityWords: []string{"crypto"}}, 0, prefilterProfanity},
+		{"duplicate by same user", models.Venue{ID: 2, Name: "Leaf", UserID: 5}, all, 1, prefilterDuplicate},
+		{"duplicate without user", models.Venue{ID: 2, Name: "Leaf"}, all, 1, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hit, reason := autoRejectPrefilter(context.Background(), dupRepo(tt.dups), &tt.venue, tt.cfg)
+			if hit != (tt.wantCode != "") || reason.Code != tt.wantCode {
+				t.Fatalf("hit=%v code=%q want %q (%s)", hit, reason.Code, tt.wantCode, reason.Description)
+			}
+		})
+	}
+}
+
+func TestAutoRejectPrefilter_DuplicateRejectsOnlyLaterCopy(t *testing.T) {
+	original := testutil.PipelineVenue(300, "Leaf Kitchen")
+	copied := testutil.PipelineVenue(301, "leaf kitchen ")
+	repo := testutil.NewMemoryStore(original, copied).Repository()
+	cfg := PrefilterConfig{DuplicateSubmission: true, DuplicateWindowDays: 7}
+
+	// Processed in submission order, and the other way round (as two workers may)
+	for _, order := range [][]models.VenueWithUser{{original, copied}, {copied, original}} {
+		for _, vu := range order {
+			v := vu.Venue
+			hit, reason := autoRejectPrefilter(context.Background(), repo, &v, cfg)
+			if want := v.ID == copied.Venue.ID; hit != want {
+				t.Errorf("venue %d: rejected = %v (%s), want %v", v.ID, hit, reason.Description, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package processor
+
+import (
+	"context"
+	"errors"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// progressOutputKey holds a failed validation's models.PipelineProgress in ai_output_data.
+const progressOutputKey = "progress"
+
+// pipelineStages are the stages of a validation in order; a failure in one means the ones
+// before it completed.
+var pipelineStages = []string{StageGoogle, StageOpenAI, StageQuality, StageDecision}
+
+// stageError is a pipeline error with the stage (Stage*) it happened in.
+type stageError struct {
+	stage string
+	err   error
+}
+
+func (e *stageError) Error() string { return e.err.Error() }
+func (e *stageError) Unwrap() error { return e.err }
+
+// failedIn marks err as a failure of stage.
+func failedIn(stage string, err error) error {
+	return &stageError{stage: stage, err: err}
+}
+
+// failedStage is the stage result failed in. Errors not marked with failedIn (panics,
+// unreadable batch items) are put down to the first stage whose output is missing.
+func failedStage(result *ProcessingResult) string {
+	var se *stageError
+	if errors.As(result.Error, &se) {
+		return se.stage
+	}
+	if result.GoogleData != nil {
+		return StageOpenAI
+	}
+	return StageGoogle
+}
+
+// noteFailure records in result.Progress how far the failed result got. For results that are
+// stored, the checkpoint of the completed stages is kept so the venue's next run resumes
+// after them.
+func (e *ProcessingEngine) noteFailure(ctx context.Context, result *ProcessingResult) {
+	p := &models.PipelineProgress{FailedStage: failedStage(result), Completed: []string{}}
+	for _, s := range pipelineStages {
+		if s == p.FailedStage {
+			break
+		}
+		p.Completed = append(p.Completed, s)
+	}
+	if result.Error != nil {
+		p.Error = result.Error.Error()
+	}
+	if result.Mode.persists() {
+		p.Resumable = e.keepCheckpoint(ctx, result.VenueID)
+	}
+	result.Progress = p
+}
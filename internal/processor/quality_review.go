@@ -0,0 +1,86 @@
+package processor
+
+import (
+	"hash/fnv"
+	"strconv"
+
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// QualityReviewConfig limits the quality reviewer's extra OpenAI call per venue.
+type QualityReviewConfig struct {
+	Enabled       bool
+	MinScore      int // venues scored below this are not reviewed; 0 reviews all
+	SamplePercent int // share of the remaining venues reviewed, 0-100
+}
+
+// Why a venue's quality review ran or not, recorded in ai_output_data and on
+// quality_reviews_total so missing quality data can be told apart from failures.
+const (
+	qualityReviewed      = "reviewed"
+	qualityDisabled      = "disabled"
+	qualityBelowMinScore = "below_min_score"
+	qualityNotSampled    = "not_sampled"
+	qualityFailed        = "failed"
+	qualityDeadline      = "deadline" // would not fit the synchronous caller's deadline
+)
+
+// qualityReviewOutputKey is where the quality review decision goes in ai_output_data.
+const qualityReviewOutputKey = "quality_review"
+
+var mQualityReviews = metrics.Default.CounterVec("quality_reviews_total",
+	"Quality review decisions for scored venues, by outcome (reviewed, disabled, below_min_score, not_sampled, failed, deadline)", "outcome")
+
+// qualityReviewRun records whether a venue's quality review ran, and the settings that
+// decided it.
+type qualityReviewRun struct {
+	Reviewed      bool   `json:"reviewed"`
+	Reason        string `json:"reason"`
+	MinScore      int    `json:"min_score,omitempty"`
+	SamplePercent int    `json:"sample_percent"`
+}
+
+// SetQualityReview limits when the quality reviewer runs. Without it every scored venue is
+// reviewed.
+func (e *ProcessingEngine) SetQualityReview(cfg QualityReviewConfig) {
+	e.avaConfigMu.Lock()
+	defer e.avaConfigMu.Unlock()
+	e.qualityCfg = &cfg
+}
+
+// planQualityReview decides whether the venue, scored score so far, gets a quality review.
+// Sampling is by venue ID, so re-scoring a venue makes the same choice.
+func (e *ProcessingEngine) planQualityReview(venueID int64, score int) *qualityReviewRun {
+	e.avaConfigMu.RLock()
+	cfg := e.qualityCfg
+	e.avaConfigMu.RUnlock()
+	if cfg == nil {
+		return &qualityReviewRun{Reviewed: true, Reason: qualityReviewed, SamplePercent: 100}
+	}
+
+	run := &qualityReviewRun{MinScore: cfg.MinScore, SamplePercent: cfg.SamplePercent}
+	switch {
+	case !cfg.Enabled:
+		run.Reason = qualityDisabled
+	case score < cfg.MinScore:
+		run.Reason = qualityBelowMinScore
+	case !inSample(venueID, cfg.SamplePercent):
+		run.Reason = qualityNotSampled
+	default:
+		run.Reviewed, run.Reason = true, qualityReviewed
+	}
+	return run
+}
+
+// inSample reports whether venueID falls in a percent sample of venues.
+func inSample(venueID int64, percent int) bool {
+	if percent >= 100 {
+		return true
+	}
+	if percent <= 0 {
+		return false
+	}
+	h := fnv.New32a()
+	h.Write([]byte(strconv.FormatInt(venueID, 10)))
+	return int(h.Sum32()%100) < percent
+}
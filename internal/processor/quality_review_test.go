@@ -0,0 +1,100 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/internal/trust"
+)
+
+type fakeQualityReviewer struct {
+	calls int
+	err   error
+}
+
+func (f *fakeQualityReviewer) ReviewQuality(context.Context, models.Venue, models.User, string, float64) (*models.QualitySuggestions, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &models.QualitySuggestions{}, nil
+}
+
+func TestPlanQualityReview(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        *QualityReviewConfig
+		score      int
+		wantReason string
+	}{
+		{"not configured", nil, 10, qualityReviewed},
+		{"disabled", &QualityReviewConfig{Enabled: false, SamplePercent: 100}, 90, qualityDisabled},
+		{"below min score", &QualityReviewConfig{Enabled: true, MinScore: 70, SamplePercent: 100}, 69, qualityBelowMinScore},
+		{"at min score", &QualityReviewConfig{Enabled: true, MinScore: 70, SamplePercent: 100}, 70, qualityReviewed},
+		{"sampled out", &QualityReviewConfig{Enabled: true, SamplePercent: 0}, 90, qualityNotSampled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &ProcessingEngine{qualityCfg: tt.cfg}
+			run := e.planQualityReview(1, tt.score)
+			if run.Reason != tt.wantReason || run.Reviewed != (tt.wantReason == qualityReviewed) {
+				t.Fatalf("got %+v, want reason %q", run, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestInSample(t *testing.T) {
+	in := 0
+	for id := int64(1); id <= 10000; id++ {
+		if inSample(id, 30) {
+			in++
+		}
+		if inSample(id, 30) != inSample(id, 30) {
+			t.Fatalf("venue %d sampled inconsistently", id)
+		}
+	}
+	if in < 2700 || in > 3300 {
+		t.Fatalf("%d of 10000 venues in a 30%% sample", in)
+	}
+}
+
+func TestReviewScored_RecordsQualityReviewDecision(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       QualityReviewConfig
+		err       error
+		wantCalls int
+		want      qualityReviewRun
+	}{
+		{"reviewed", QualityReviewConfig{Enabled: true, SamplePercent: 100}, nil, 1, qualityReviewRun{Reviewed: true, Reason: qualityReviewed, SamplePercent: 100}},
+		{"skipped below min score", QualityReviewConfig{Enabled: true, MinScore: 80, SamplePercent: 100}, nil, 0, qualityReviewRun{Reason: qualityBelowMinScore, MinScore: 80, SamplePercent: 100}},
+		{"failed", QualityReviewConfig{Enabled: true, SamplePercent: 100}, errors.New("timeout"), 1, qualityReviewRun{Reason: qualityFailed, SamplePercent: 100}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qr := &fakeQualityReviewer{err: tt.err}
+			e := &ProcessingEngine{qualityReviewer: qr, stats: newEngineStats(1)}
+			e.SetQualityReview(tt.cfg)
+			venue := models.Venue{ID: 5, Name: "Leaf"}
+			vr := &models.ValidationResult{VenueID: 5, Score: 60}
+			e.reviewScored(context.Background(), venue, &venue, models.User{}, &trust.Assessment{Trust: 0.5}, vr, nil, &models.StageTimings{})
+
+			if qr.calls != tt.wantCalls {
+				t.Fatalf("quality reviewer called %d times, want %d", qr.calls, tt.wantCalls)
+			}
+			var out struct {
+				QualityReview qualityReviewRun `json:"quality_review"`
+			}
+			if vr.AIOutputData == nil || json.Unmarshal([]byte(*vr.AIOutputData), &out) != nil {
+				t.Fatalf("ai_output_data not JSON: %v", vr.AIOutputData)
+			}
+			if out.QualityReview != tt.want {
+				t.Fatalf("quality_review = %+v, want %+v", out.QualityReview, tt.want)
+			}
+		})
+	}
+}
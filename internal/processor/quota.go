@@ -0,0 +1,264 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+	"assisted-venue-approval/pkg/metrics"
+
+	"golang.org/x/time/rate"
+)
+
+// DefaultQuotaPartition takes the venues no other partition claims.
+const DefaultQuotaPartition = "other"
+
+// ErrQuotaExhausted is returned, classed as a rate limit, once a partition has used its
+// daily share of an endpoint.
+var ErrQuotaExhausted = errors.New("daily quota share used up")
+
+var (
+	mQuotaTokens    = metrics.Default.CounterVec("quota_tokens_total", "Rate limit tokens taken per quota partition; source is own or borrowed from an idle partition", "endpoint", "partition", "source")
+	mQuotaWait      = metrics.Default.HistogramVec("quota_wait_seconds", "Time spent waiting for a partition's share of the rate limit", []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 30}, "endpoint", "partition")
+	mQuotaExhausted = metrics.Default.CounterVec("quota_exhausted_total", "Requests refused because the partition used its daily quota share", "endpoint", "partition")
+)
+
+// QuotaPartition is a share of the outgoing API quota for the venues under a path prefix,
+// e.g. the team moderating one region.
+type QuotaPartition struct {
+	Name       string
+	PathPrefix string  // venue path prefix ("europe", "europe|germany"); "" for the default partition
+	Share      float64 // fraction of each endpoint's rate and daily quota, 0-1
+}
+
+// QuotaConfig splits the endpoint rate limits, and optionally a daily token quota, between
+// partitions.
+type QuotaConfig struct {
+	Partitions []QuotaPartition
+	// Daily is the endpoint's (Endpoint*) tokens per UTC day shared by the partitions; 0 or
+	// missing is unlimited
+	Daily map[string]int64
+	// IdleAfter is how long a partition must go without requests before others may borrow
+	// its tokens (default 30s)
+	IdleAfter time.Duration
+	// ProcessShare is the fraction (0-1) of the limits and daily quotas this process may use
+	// when several processes, such as tenants, draw on one quota; 0 is all of it
+	ProcessShare float64
+}
+
+// ParseQuotaPartitions parses "europe=40,north-america=35,*=25": venue path prefixes with
+// their percentage of the quota. "*" names the default partition; without it the default
+// gets what is left of 100.
+func ParseQuotaPartitions(spec string) ([]QuotaPartition, error) {
+	const op = "processor.ParseQuotaPartitions"
+	var parts []QuotaPartition
+	var total float64
+	seen := map[string]bool{}
+	hasDefault := false
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(item, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		pct, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+		if !ok || key == "" || err != nil || pct <= 0 || pct > 100 {
+			return nil, errs.NewValidation(op, fmt.Sprintf("%q: want <path prefix>=<percent between 0 and 100>", item), nil)
+		}
+		if seen[key] {
+			return nil, errs.NewValidation(op, fmt.Sprintf("%q is listed twice", key), nil)
+		}
+		seen[key] = true
+		total += pct
+		p := QuotaPartition{Name: key, PathPrefix: key, Share: pct / 100}
+		if key == "*" {
+			hasDefault = true
+			p = QuotaPartition{Name: DefaultQuotaPartition, Share: pct / 100}
+		}
+		parts = append(parts, p)
+	}
+	if len(parts) == 0 {
+		return nil, nil
+	}
+	if total > 100.0001 {
+		return nil, errs.NewValidation(op, fmt.Sprintf("shares add up to %g%%, more than 100%%", total), nil)
+	}
+	if !hasDefault {
+		if 100-total < 1 {
+			return nil, errs.NewValidation(op, "shares leave nothing for venues outside the listed paths; add *=<percent>", nil)
+		}
+		parts = append(parts, QuotaPartition{Name: DefaultQuotaPartition, Share: (100 - total) / 100})
+	}
+	return parts, nil
+}
+
+// venuePath is the path a venue's quota partition is chosen by.
+func venuePath(v models.Venue) string {
+	if v.Path == nil {
+		return ""
+	}
+	return *v.Path
+}
+
+// QuotaUsage is one partition's use of one endpoint.
+type QuotaUsage struct {
+	Endpoint   string
+	Partition  string
+	Share      float64
+	RPS        float64 // the share of the endpoint rate, 0 when unlimited
+	UsedToday  int64   // tokens, UTC day
+	DailyLimit int64   // 0 is unlimited
+	Borrowed   int64   // tokens taken from idle partitions since start
+}
+
+type quotaPartition struct {
+	QuotaPartition
+	lim      *rate.Limiter
+	used     photoBudget // tokens, same per-UTC-day accounting as the photo check
+	lastUsed atomic.Int64
+	borrowed atomic.Int64
+}
+
+// idle reports whether p has made no requests for d; its tokens may then be lent out.
+func (p *quotaPartition) idle(now time.Time, d time.Duration) bool {
+	return now.Sub(time.Unix(0, p.lastUsed.Load())) >= d
+}
+
+func (p *quotaPartition) refund(cost float64, at time.Time) {
+	p.used.settle(cost, 0, at, time.Now())
+}
+
+// quotaSplit divides one endpoint's rate between partitions. Each partition has a token
+// bucket at its share of the rate, so a busy region cannot hold back the others. A
+// partition whose bucket is empty borrows from partitions that have been idle for
+// idleAfter; a partition that comes back finds at most one burst lent out, so borrowing
+// never starves it. The endpoint's own limiter still caps the total.
+type quotaSplit struct {
+	endpoint  string
+	parts     []*quotaPartition // longest prefix first, default last
+	daily     int64
+	idleAfter time.Duration
+}
+
+// newQuotaSplit builds the partitions with unlimited rates; the RateLimiter sets them.
+func newQuotaSplit(endpoint string, cfg QuotaConfig) *quotaSplit {
+	q := &quotaSplit{endpoint: endpoint, daily: cfg.Daily[endpoint], idleAfter: cfg.IdleAfter}
+	if cfg.ProcessShare > 0 && q.daily > 0 {
+		q.daily = max(1, int64(float64(q.daily)*cfg.ProcessShare))
+	}
+	if q.idleAfter <= 0 {
+		q.idleAfter = 30 * time.Second
+	}
+	for _, p := range cfg.Partitions {
+		q.parts = append(q.parts, &quotaPartition{QuotaPartition: p, lim: rate.NewLimiter(rate.Inf, 1)})
+	}
+	sort.SliceStable(q.parts, func(i, j int) bool {
+		pi, pj := q.parts[i].PathPrefix, q.parts[j].PathPrefix
+		return pi != "" && (pj == "" || len(pi) > len(pj))
+	})
+	return q
+}
+
+// setLimit gives each partition its share of rps and burst, with room for at least one
+// token so a small share still makes progress.
+func (q *quotaSplit) setLimit(rps float64, burst int) {
+	for _, p := range q.parts {
+		if rps <= 0 {
+			p.lim.SetLimit(rate.Inf)
+			continue
+		}
+		p.lim.SetBurst(max(1, int(math.Round(float64(burst)*p.Share))))
+		p.lim.SetLimit(rate.Limit(rps * p.Share))
+	}
+}
+
+// match returns the partition of a venue path such as "europe|germany|berlin".
+func (q *quotaSplit) match(path string) *quotaPartition {
+	path = strings.ToLower(strings.TrimSpace(path))
+	for _, p := range q.parts {
+		if p.PathPrefix == "" || path == p.PathPrefix || strings.HasPrefix(path, p.PathPrefix+"|") {
+			return p
+		}
+	}
+	return q.parts[len(q.parts)-1]
+}
+
+// wait charges cost to path's partition and blocks until the partition has the tokens or
+// ctx is done. The daily share is charged up front, so concurrent callers cannot overshoot
+// it, and refunded if the wait fails.
+func (q *quotaSplit) wait(ctx context.Context, path string, cost int) error {
+	p := q.match(path)
+	now := time.Now()
+	if !p.used.reserve(float64(q.dailyLimit(p)), float64(cost), now) {
+		mQuotaExhausted.With(q.endpoint, p.Name).Inc()
+		return errs.NewExternalClass("processor.quota", q.endpoint, errs.ClassRateLimit,
+			fmt.Errorf("%s: %w", p.Name, ErrQuotaExhausted))
+	}
+	charged := float64(cost)
+	p.lastUsed.Store(now.UnixNano())
+	if p.lim.Limit() == rate.Inf {
+		return nil
+	}
+	if b := p.lim.Burst(); cost > b {
+		cost = b
+	}
+	if p.lim.AllowN(now, cost) {
+		mQuotaTokens.With(q.endpoint, p.Name, "own").Add(float64(cost))
+		return nil
+	}
+	for _, o := range q.parts {
+		if o != p && o.idle(now, q.idleAfter) && cost <= o.lim.Burst() && o.lim.AllowN(now, cost) {
+			p.borrowed.Add(int64(cost))
+			mQuotaTokens.With(q.endpoint, p.Name, "borrowed").Add(float64(cost))
+			return nil
+		}
+	}
+
+	t := mQuotaWait.With(q.endpoint, p.Name).Start()
+	defer t.Observe()
+	if err := p.lim.WaitN(ctx, cost); err != nil {
+		p.refund(charged, now)
+		return err
+	}
+	mQuotaTokens.With(q.endpoint, p.Name, "own").Add(float64(cost))
+	return nil
+}
+
+// refund gives back the daily share charged at time at for a request that was never made.
+func (q *quotaSplit) refund(path string, cost int, at time.Time) {
+	q.match(path).refund(float64(cost), at)
+}
+
+// dailyLimit is p's share of the daily quota, at least one token; 0 when unlimited.
+func (q *quotaSplit) dailyLimit(p *quotaPartition) int64 {
+	if q.daily <= 0 {
+		return 0
+	}
+	return max(1, int64(float64(q.daily)*p.Share))
+}
+
+func (q *quotaSplit) usage(now time.Time) []QuotaUsage {
+	out := make([]QuotaUsage, 0, len(q.parts))
+	for _, p := range q.parts {
+		u := QuotaUsage{Endpoint: q.endpoint, Partition: p.Name, Share: p.Share, Borrowed: p.borrowed.Load()}
+		if l := p.lim.Limit(); l != rate.Inf {
+			u.RPS = float64(l)
+		}
+		u.DailyLimit = q.dailyLimit(p)
+		p.used.mu.Lock()
+		p.used.rollLocked(now)
+		u.UsedToday = int64(p.used.spent)
+		p.used.mu.Unlock()
+		out = append(out, u)
+	}
+	return out
+}
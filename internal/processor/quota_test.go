@@ -0,0 +1,189 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+func TestParseQuotaPartitions(t *testing.T) {
+	parts, err := ParseQuotaPartitions("Europe=40, europe|germany=10,north-america=35")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parts) != 4 || parts[0].PathPrefix != "europe" || parts[3].Name != DefaultQuotaPartition || parts[3].PathPrefix != "" {
+		t.Fatalf("partitions = %+v", parts)
+	}
+	if s := parts[3].Share; s < 0.149 || s > 0.151 {
+		t.Errorf("default share = %.3f, want the remaining 0.15", s)
+	}
+	if parts, _ := ParseQuotaPartitions(""); parts != nil {
+		t.Errorf("empty spec = %+v, want no partitions", parts)
+	}
+
+	for spec, want := range map[string]string{
+		"europe":              "want <path prefix>=<percent",
+		"europe=0":            "want <path prefix>=<percent",
+		"europe=40,europe=10": "listed twice",
+		"europe=60,*=50":      "more than 100%",
+		"europe=60,asia=40":   "add *=<percent>",
+	} {
+		if _, err := ParseQuotaPartitions(spec); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%q: err = %v, want %q", spec, err, want)
+		}
+	}
+}
+
+func TestQuotaSplit_Match(t *testing.T) {
+	parts, _ := ParseQuotaPartitions("europe=40,europe|germany=10,*=50")
+	q := newQuotaSplit("test_match", QuotaConfig{Partitions: parts})
+	for path, want := range map[string]string{
+		"europe|germany|berlin": "europe|germany",
+		"Europe|France":         "europe",
+		"europe":                "europe",
+		"europeana|x":           DefaultQuotaPartition,
+		"":                      DefaultQuotaPartition,
+	} {
+		if got := q.match(path).Name; got != want {
+			t.Errorf("%q: partition %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestRateLimiter_PartitionShares(t *testing.T) {
+	parts, _ := ParseQuotaPartitions("europe=50,*=50")
+	rl := NewRateLimiter("test_partition", 1, 10)
+	rl.Partition(QuotaConfig{Partitions: parts, IdleAfter: time.Hour}, 1, 10)
+	ctx := context.Background()
+
+	// Both partitions active: europe gets its 5-token burst and no more
+	if err := rl.WaitFor(ctx, "asia|japan", 1); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := rl.WaitFor(ctx, "europe|italy", 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	short, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if err := rl.WaitFor(short, "europe|italy", 1); err == nil {
+		t.Fatal("europe went past its share while the other partition was active")
+	}
+	// ...and cannot hold back the other partition
+	if err := rl.WaitFor(short, "asia|japan", 1); err != nil {
+		t.Fatalf("other partition starved: %v", err)
+	}
+
+	// Rescaling keeps the split
+	rl.SetLimit(100, 40)
+	for _, u := range rl.QuotaUsage() {
+		if u.RPS != 50 {
+			t.Errorf("%s: rps %.1f after SetLimit(100), want 50", u.Partition, u.RPS)
+		}
+	}
+}
+
+func TestRateLimiter_PartitionBorrowsFromIdle(t *testing.T) {
+	parts, _ := ParseQuotaPartitions("europe=50,*=50")
+	rl := NewRateLimiter("test_partition_borrow", 1, 10)
+	rl.Partition(QuotaConfig{Partitions: parts, IdleAfter: time.Hour}, 1, 10)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// The default partition has made no requests, so europe may use its tokens too
+	for i := 0; i < 10; i++ {
+		if err := rl.WaitFor(ctx, "europe", 1); err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+	}
+	for _, u := range rl.QuotaUsage() {
+		if u.Partition == "europe" && (u.Borrowed != 5 || u.UsedToday != 10) {
+			t.Errorf("europe usage = %+v, want 5 of 10 tokens borrowed", u)
+		}
+	}
+}
+
+func TestRateLimiter_DailyQuota(t *testing.T) {
+	parts, _ := ParseQuotaPartitions("europe=50,*=50")
+	rl := NewRateLimiter("test_partition_daily", 0, 0)
+	rl.Partition(QuotaConfig{Partitions: parts, Daily: map[string]int64{"test_partition_daily": 20}, ProcessShare: 0.5}, 0, 0)
+	ctx := context.Background()
+
+	// 20 tokens a day, half for this process, half of that for europe
+	for i := 0; i < 5; i++ {
+		if err := rl.WaitFor(ctx, "europe", 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := rl.WaitFor(ctx, "europe", 1)
+	if !errors.Is(err, ErrQuotaExhausted) || errs.Classify(err) != errs.ClassRateLimit {
+		t.Fatalf("err = %v, want a rate-limit classed ErrQuotaExhausted", err)
+	}
+	if err := rl.WaitFor(ctx, "asia", 1); err != nil {
+		t.Errorf("other partition: %v", err)
+	}
+
+	// A daily quota without partitions applies to the whole endpoint
+	solo := NewRateLimiter("test_partition_solo", 0, 0)
+	solo.Partition(QuotaConfig{Daily: map[string]int64{"test_partition_solo": 1}}, 0, 0)
+	if err := solo.WaitFor(ctx, "", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := solo.WaitFor(ctx, "", 1); !errors.Is(err, ErrQuotaExhausted) {
+		t.Errorf("second request: err = %v, want ErrQuotaExhausted", err)
+	}
+}
+
+func TestRateLimiter_DailyQuotaConcurrent(t *testing.T) {
+	rl := NewRateLimiter("test_partition_daily_race", 0, 0)
+	rl.Partition(QuotaConfig{Daily: map[string]int64{"test_partition_daily_race": 10}}, 0, 0)
+
+	var ok atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if rl.WaitFor(context.Background(), "", 1) == nil {
+				ok.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if ok.Load() != 10 {
+		t.Errorf("%d requests passed a daily quota of 10", ok.Load())
+	}
+}
+
+func TestRateLimiter_DailyQuotaRefundsFailedWait(t *testing.T) {
+	rl := NewRateLimiter("test_partition_refund", 1, 1)
+	rl.Partition(QuotaConfig{Daily: map[string]int64{"test_partition_refund": 2}}, 1, 1)
+	if err := rl.WaitFor(context.Background(), "", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// The bucket is empty and the caller gives up: the token was never taken
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := rl.WaitFor(ctx, "", 1); err == nil {
+		t.Fatal("wait on a cancelled context succeeded")
+	}
+	if u := rl.QuotaUsage()[0]; u.UsedToday != 1 {
+		t.Fatalf("used %d tokens today, want 1 after the refund", u.UsedToday)
+	}
+}
+
+func TestRateLimiter_ProcessShare(t *testing.T) {
+	rl := NewRateLimiter("test_process_share", 1, 10)
+	rl.Partition(QuotaConfig{ProcessShare: 0.3}, 1, 10)
+	if rl.quota != nil || rl.lim.Burst() != 3 || float64(rl.lim.Limit()) != 0.3 {
+		t.Errorf("burst %d rate %.2f, want 3 and 0.3", rl.lim.Burst(), float64(rl.lim.Limit()))
+	}
+}
@@ -0,0 +1,140 @@
+package processor
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"assisted-venue-approval/pkg/metrics"
+
+	"golang.org/x/time/rate"
+)
+
+// External endpoints with their own rate limit.
+const (
+	EndpointGooglePlaces = "google_places" // Text Search and Place Details
+	EndpointGooglePhotos = "google_photos"
+	EndpointOpenAI       = "openai"
+)
+
+// Tokens a request takes from its endpoint's bucket, so a limit of N per second counts
+// requests by what they cost Google or OpenAI rather than one each.
+const (
+	costTextSearch   = 2 // about twice a Place Details request at list price
+	costPlaceDetails = 1
+	costPhoto        = 1
+	costScore        = 1
+	costPhotoReview  = 2 // the images make a vision call several scoring calls' worth of tokens
+)
+
+var mRateLimitWait = metrics.Default.HistogramVec("rate_limit_wait_seconds", "Time spent waiting for a rate limit token, by endpoint", []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 30}, "endpoint")
+
+// RateLimiter is a token bucket for one external endpoint. A limit of 0 or less is unlimited.
+type RateLimiter struct {
+	endpoint   string
+	lim        *rate.Limiter
+	saturation *metrics.Gauge // as of the last request
+	share      float64        // of the configured limits this process may use; 0 is all
+	quota      *quotaSplit    // nil unless the quota is partitioned
+}
+
+// NewRateLimiter allows rps tokens per second on endpoint with bursts of up to burst
+// (default rps).
+func NewRateLimiter(endpoint string, rps, burst int) *RateLimiter {
+	rl := &RateLimiter{
+		endpoint:   endpoint,
+		saturation: metrics.Default.Gauge("rate_limit_saturation_"+endpoint, "Share of the "+endpoint+" burst in use; above 1 callers are queued"),
+		lim:        rate.NewLimiter(rate.Inf, 1),
+	}
+	rl.SetLimit(rps, burst)
+	return rl
+}
+
+// SetLimit changes the rate and burst; waiting callers see the new rate right away. With a
+// process share the limiter allows that share of them.
+func (rl *RateLimiter) SetLimit(rps, burst int) {
+	if burst <= 0 {
+		burst = rps
+	}
+	r := float64(rps)
+	if rl.share > 0 {
+		r *= rl.share
+		burst = max(1, int(math.Round(float64(burst)*rl.share)))
+	}
+	if rl.quota != nil {
+		rl.quota.setLimit(r, burst)
+	}
+	if rps <= 0 {
+		rl.lim.SetLimit(rate.Inf)
+		return
+	}
+	rl.lim.SetBurst(burst)
+	rl.lim.SetLimit(rate.Limit(r))
+}
+
+// Partition applies cfg at the endpoint's current rps and burst: the process share, and the
+// split between partitions with their daily quotas. Later SetLimit calls rescale both. Call
+// it before the limiter is in use.
+func (rl *RateLimiter) Partition(cfg QuotaConfig, rps, burst int) {
+	rl.share = cfg.ProcessShare
+	rl.quota = nil
+	if len(cfg.Partitions) == 0 && cfg.Daily[rl.endpoint] > 0 {
+		// A daily quota alone is enforced as one partition taking everything
+		cfg.Partitions = []QuotaPartition{{Name: DefaultQuotaPartition, Share: 1}}
+	}
+	if len(cfg.Partitions) > 0 {
+		rl.quota = newQuotaSplit(rl.endpoint, cfg)
+	}
+	rl.SetLimit(rps, burst)
+}
+
+// WaitFor is Wait for a request on behalf of a venue at path: with a partitioned quota the
+// venue's partition must have the tokens, and its daily share left, before the endpoint's.
+func (rl *RateLimiter) WaitFor(ctx context.Context, path string, cost int) error {
+	if rl.quota == nil {
+		return rl.Wait(ctx, cost)
+	}
+	start := time.Now()
+	if err := rl.quota.wait(ctx, path, cost); err != nil {
+		return err
+	}
+	if err := rl.Wait(ctx, cost); err != nil {
+		rl.quota.refund(path, cost, start)
+		return err
+	}
+	return nil
+}
+
+// QuotaUsage reports each partition's use of the endpoint; nil when not partitioned.
+func (rl *RateLimiter) QuotaUsage() []QuotaUsage {
+	if rl.quota == nil {
+		return nil
+	}
+	return rl.quota.usage(time.Now())
+}
+
+// Wait blocks until cost tokens are available or ctx is done. A cost above the burst takes
+// the whole burst.
+func (rl *RateLimiter) Wait(ctx context.Context, cost int) error {
+	if b := rl.lim.Burst(); cost > b {
+		cost = b
+	}
+	t := mRateLimitWait.With(rl.endpoint).Start()
+	defer t.Observe()
+	err := rl.lim.WaitN(ctx, cost)
+	rl.saturation.SetFloat64(rl.Saturation())
+	return err
+}
+
+// Saturation is the share of the burst in use: 0 when idle, 1 when the bucket is empty and
+// above 1 while callers are queued for tokens.
+func (rl *RateLimiter) Saturation() float64 {
+	if rl.lim.Limit() == rate.Inf || rl.lim.Burst() <= 0 {
+		return 0
+	}
+	s := 1 - rl.lim.Tokens()/float64(rl.lim.Burst())
+	if s < 0 {
+		return 0
+	}
+	return s
+}
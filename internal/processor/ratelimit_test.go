@@ -0,0 +1,61 @@
+package processor
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_WeightedCost(t *testing.T) {
+	rl := NewRateLimiter("test_weighted", 1, 4)
+	ctx := context.Background()
+	if err := rl.Wait(ctx, costTextSearch+costPlaceDetails); err != nil {
+		t.Fatal(err)
+	}
+	if s := rl.Saturation(); s < 0.7 || s > 0.8 {
+		t.Errorf("saturation after 3 of 4 tokens = %.2f, want 0.75", s)
+	}
+
+	// One token left: a 2-token request must wait, a 1-token one need not
+	short, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if err := rl.Wait(short, costTextSearch); err == nil {
+		t.Fatal("2-token wait with 1 token left should not fit in 100ms at 1/s")
+	}
+	if err := rl.Wait(short, costPlaceDetails); err != nil {
+		t.Fatalf("1-token wait: %v", err)
+	}
+
+	// A cost above the burst takes the whole burst instead of failing
+	rl.SetLimit(1000, 2)
+	if err := rl.Wait(ctx, 5); err != nil {
+		t.Fatalf("cost above burst: %v", err)
+	}
+}
+
+func TestRateLimiter_SetLimit(t *testing.T) {
+	rl := NewRateLimiter("test_set_limit", 1, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := rl.Wait(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// Unlimited: no waiting and no saturation
+	rl.SetLimit(0, 0)
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		if err := rl.Wait(ctx, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if time.Since(start) > 500*time.Millisecond || rl.Saturation() != 0 {
+		t.Errorf("unlimited limiter waited %v, saturation %.2f", time.Since(start), rl.Saturation())
+	}
+
+	// Back to a limit, now with room for a burst
+	rl.SetLimit(10, 5)
+	if err := rl.Wait(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+}
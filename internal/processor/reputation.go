@@ -0,0 +1,83 @@
+package processor
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+var mReputationLookups = metrics.Default.CounterVec("reputation_lookups_total", "Submitter history lookups by result", "result")
+
+const (
+	// reputationRecentDecisions bounds the decisions read for a rejection streak
+	reputationRecentDecisions = 20
+	// reputationMaxEntries bounds the cache; expired entries are dropped when it is full
+	reputationMaxEntries = 5000
+)
+
+// reputationCache keeps each submitter's history for a while, so a batch with many venues
+// from one member queries it once.
+type reputationCache struct {
+	store domain.ReputationStore
+	ttl   time.Duration
+
+	mu      sync.Mutex
+	entries map[uint]cachedHistory
+}
+
+type cachedHistory struct {
+	history models.SubmissionHistory
+	at      time.Time
+}
+
+// EnableReputation adds each submitter's past outcomes from store to their trust assessment;
+// call before Start. Histories are cached for ttl, so decisions made meanwhile show up late.
+func (e *ProcessingEngine) EnableReputation(store domain.ReputationStore, ttl time.Duration) {
+	e.reputation = &reputationCache{store: store, ttl: ttl, entries: map[uint]cachedHistory{}}
+}
+
+// submissionHistory returns the submitter's history, or nil when reputation is off or the
+// lookup failed; the assessment then goes ahead without it.
+func (e *ProcessingEngine) submissionHistory(ctx context.Context, userID uint) *models.SubmissionHistory {
+	c := e.reputation
+	if c == nil || userID == 0 {
+		return nil
+	}
+	now := time.Now()
+	c.mu.Lock()
+	if ch, ok := c.entries[userID]; ok && now.Sub(ch.at) < c.ttl {
+		c.mu.Unlock()
+		mReputationLookups.With("hit").Inc()
+		h := ch.history
+		return &h
+	}
+	c.mu.Unlock()
+
+	h, err := c.store.GetSubmissionHistoryCtx(ctx, userID, reputationRecentDecisions)
+	if err != nil || h == nil {
+		mReputationLookups.With("error").Inc()
+		log.Printf("[reputation] failed to load submission history for user %d: %v", userID, err)
+		return nil
+	}
+	mReputationLookups.With("miss").Inc()
+
+	c.mu.Lock()
+	if len(c.entries) >= reputationMaxEntries {
+		for id, ch := range c.entries {
+			if now.Sub(ch.at) >= c.ttl {
+				delete(c.entries, id)
+			}
+		}
+		if len(c.entries) >= reputationMaxEntries {
+			clear(c.entries)
+		}
+	}
+	c.entries[userID] = cachedHistory{history: *h, at: now}
+	c.mu.Unlock()
+	return h
+}
@@ -0,0 +1,81 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+	"assisted-venue-approval/internal/trust"
+)
+
+func TestSubmissionHistory_CachedPerUser(t *testing.T) {
+	calls := map[uint]int{}
+	store := &testutil.ReputationStore{
+		GetSubmissionHistoryCtxFunc: func(_ context.Context, userID uint, recent int) (*models.SubmissionHistory, error) {
+			calls[userID]++
+			if userID == 9 {
+				return nil, errors.New("db down")
+			}
+			return &models.SubmissionHistory{Approved: 1, Rejected: 3, RejectionStreak: 3}, nil
+		},
+	}
+	e := &ProcessingEngine{}
+	if h := e.submissionHistory(context.Background(), 4); h != nil {
+		t.Fatalf("history without reputation enabled = %+v", h)
+	}
+	e.EnableReputation(store, time.Hour)
+
+	for range 3 {
+		if h := e.submissionHistory(context.Background(), 4); h == nil || h.Rejected != 3 {
+			t.Fatalf("history = %+v", h)
+		}
+	}
+	if calls[4] != 1 {
+		t.Fatalf("store called %d times for one user, want 1", calls[4])
+	}
+	// A failed lookup is not cached and leaves the assessment without history
+	for range 2 {
+		if h := e.submissionHistory(context.Background(), 9); h != nil {
+			t.Fatalf("history after error = %+v", h)
+		}
+	}
+	if calls[9] != 2 {
+		t.Fatalf("store called %d times after errors, want 2", calls[9])
+	}
+
+	e.reputation.ttl = 0 // everything expired
+	e.submissionHistory(context.Background(), 4)
+	if calls[4] != 2 {
+		t.Fatalf("expired entry not reloaded, %d calls", calls[4])
+	}
+}
+
+func TestCheckTrustLevel_PoorHistory(t *testing.T) {
+	calc := trust.NewDefault()
+	tests := []struct {
+		name     string
+		history  *models.SubmissionHistory
+		wantSkip bool
+	}{
+		{"no history loaded", nil, false},
+		{"good record", &models.SubmissionHistory{Approved: 10, Rejected: 2}, false},
+		{"too few decisions for ratio", &models.SubmissionHistory{Approved: 1, Rejected: 2}, false},
+		{"mostly rejected", &models.SubmissionHistory{Approved: 2, Rejected: 3, RejectionStreak: 1}, true},
+		{"rejection streak", &models.SubmissionHistory{Approved: 20, Rejected: 3, RejectionStreak: 3}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := calc.Assess(models.User{ID: 1, Trusted: true, Contributions: 50, History: tt.history}, "")
+			skip, reason := checkTrustLevel(&a)
+			if skip != tt.wantSkip {
+				t.Fatalf("skip = %v (%s), want %v; assessment %+v", skip, reason, tt.wantSkip, a)
+			}
+			if skip && reason.Code != "poor_submission_history" {
+				t.Fatalf("reason = %+v", reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package processor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// PromptVersionSource reports the prompt template versions the scorer currently loads, as
+// "<name>@<version>" in the form recorded in validation history. Implemented by the AI scorer.
+type PromptVersionSource interface {
+	PromptVersions() (system, user []string)
+}
+
+// RescoreConfig controls the background re-scoring of pending venues.
+type RescoreConfig struct {
+	Interval       time.Duration // how often versions are checked and stale venues queued
+	MaxVenues      int           // venues queued per check
+	DailyBudgetUSD float64       // estimated spend cap per UTC day; 0 = unlimited
+}
+
+var (
+	mRescoreQueued  = metrics.Default.Counter("rescore_venues_queued_total", "Pending venues queued for re-scoring after a prompt or decision change")
+	mRescoreSkipped = metrics.Default.Counter("rescore_budget_skipped_total", "Stale venues left for later by the re-scoring daily budget")
+	gRescoreSpend   = metrics.Default.Gauge("rescore_spend_usd_today", "Estimated re-scoring spend today (USD)")
+)
+
+type rescorer struct {
+	cfg     RescoreConfig
+	store   domain.RescoreStore
+	prompts PromptVersionSource
+	budget  photoBudget // same per-UTC-day accounting as the photo check
+	lastRun int64       // run queued by the previous check
+}
+
+// EnableRescore re-scores pending venues scored under an older prompt or decision config;
+// call before Start. Every Interval the current versions are recorded, and up to MaxVenues
+// pending venues whose latest history predates a change are queued as a score-only run, so
+// the manual queue reflects current logic. The first versions recorded are the baseline.
+// A check waits while the previous check's run is still going. Only one instance should
+// have it enabled.
+func (e *ProcessingEngine) EnableRescore(store domain.RescoreStore, prompts PromptVersionSource, cfg RescoreConfig) {
+	e.rescore = &rescorer{cfg: cfg, store: store, prompts: prompts}
+}
+
+// rescoreLoop queues stale venues for re-scoring.
+func (e *ProcessingEngine) rescoreLoop() {
+	defer e.wg.Done()
+	t := time.NewTicker(e.rescore.cfg.Interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+		case <-e.shutdown:
+			return
+		}
+		e.rescoreStale(e.ctx, time.Now())
+	}
+}
+
+// promptsFingerprint identifies a set of loaded prompt versions regardless of order.
+func promptsFingerprint(system, user []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(system, ",") + "|" + strings.Join(user, ",")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// rescoreStale records the current versions and queues the venues scored before the last
+// change. Returns the number of venues queued.
+func (e *ProcessingEngine) rescoreStale(ctx context.Context, now time.Time) int {
+	r := e.rescore
+	if r.lastRun != 0 {
+		run, err := e.repo.GetProcessingRunCtx(ctx, r.lastRun)
+		if err != nil {
+			log.Printf("[rescore] failed to load run %d: %v", r.lastRun, err)
+			return 0
+		}
+		if run != nil && run.Status == models.RunStatusRunning {
+			return 0
+		}
+		r.lastRun = 0
+	}
+
+	var q models.StaleScoreQuery
+	var err error
+	q.SystemPrompts, q.UserPrompts = r.prompts.PromptVersions()
+	if q.PromptsSince, err = r.store.SetScoringVersionCtx(ctx, models.ScoringVersionPrompts,
+		promptsFingerprint(q.SystemPrompts, q.UserPrompts)); err != nil {
+		log.Printf("[rescore] failed to record prompt versions: %v", err)
+		return 0
+	}
+	if e.decisionEngine != nil {
+		if q.DecisionSince, err = r.store.SetScoringVersionCtx(ctx, models.ScoringVersionDecision,
+			e.decisionEngine.Version()); err != nil {
+			log.Printf("[rescore] failed to record decision config version: %v", err)
+			return 0
+		}
+	}
+
+	ids, err := r.store.ListStaleScoredVenuesCtx(ctx, q, r.cfg.MaxVenues)
+	if err != nil {
+		log.Printf("[rescore] failed to list stale venues: %v", err)
+		return 0
+	}
+	if len(ids) == 0 {
+		return 0
+	}
+	venues := make([]models.VenueWithUser, 0, len(ids))
+	for _, id := range ids {
+		vw, err := e.repo.GetVenueWithUserByIDCtx(ctx, id)
+		if err != nil {
+			log.Printf("[rescore] failed to load venue %d: %v", id, err)
+			continue
+		}
+		venues = append(venues, *vw)
+	}
+
+	// Trim to what is left of today's budget
+	e.avaConfigMu.RLock()
+	limit := r.cfg.DailyBudgetUSD
+	e.avaConfigMu.RUnlock()
+	if limit > 0 && len(venues) > 0 {
+		left := limit - r.budget.add(0, now)
+		if cost := e.EstimateRun(ctx, venues).TotalCostUSD; left <= 0 || cost > left {
+			keep := 0
+			if left > 0 {
+				keep = int(left / (cost / float64(len(venues))))
+			}
+			mRescoreSkipped.Inc(int64(len(venues) - keep))
+			venues = venues[:keep]
+		}
+	}
+	if len(venues) == 0 {
+		return 0
+	}
+
+	filters, _ := json.Marshal(map[string]interface{}{
+		"source":         "rescore",
+		"prompts_since":  q.PromptsSince,
+		"decision_since": q.DecisionSince,
+	})
+	run, err := e.StartRun(ctx, venues, ModeScoreOnly, models.ProcessingRun{Filters: string(filters)})
+	if err != nil {
+		log.Printf("[rescore] failed to queue some of %d venues: %v", len(venues), err)
+	}
+	r.lastRun = run.ID
+	gRescoreSpend.SetFloat64(r.budget.add(run.EstimatedCostUSD, now))
+	mRescoreQueued.Inc(int64(run.VenueCount))
+	log.Printf("[rescore] queued %d pending venues scored under older prompts or decision config (run %d)", run.VenueCount, run.ID)
+	return run.VenueCount
+}
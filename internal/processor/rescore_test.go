@@ -0,0 +1,95 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/decision"
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+	"assisted-venue-approval/internal/trust"
+)
+
+type stubPrompts struct{ system, user []string }
+
+func (p stubPrompts) PromptVersions() ([]string, []string) { return p.system, p.user }
+
+func TestRescoreStale(t *testing.T) {
+	since := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	versions := map[string]string{}
+	var query models.StaleScoreQuery
+	store := &testutil.RescoreStore{
+		SetScoringVersionCtxFunc: func(_ context.Context, kind, version string) (time.Time, error) {
+			versions[kind] = version
+			if kind == models.ScoringVersionDecision {
+				return time.Time{}, nil // baseline
+			}
+			return since, nil
+		},
+		ListStaleScoredVenuesCtxFunc: func(_ context.Context, q models.StaleScoreQuery, limit int) ([]int64, error) {
+			query = q
+			return []int64{1, 2, 3}[:limit], nil
+		},
+	}
+	runStatus := models.RunStatusRunning
+	repo := &testutil.Repository{
+		GetVenueWithUserByIDCtxFunc: func(_ context.Context, id int64) (*models.VenueWithUser, error) {
+			return &models.VenueWithUser{Venue: models.Venue{ID: id}}, nil
+		},
+		CreateProcessingRunCtxFunc: func(_ context.Context, run *models.ProcessingRun) error {
+			run.ID = 7
+			return nil
+		},
+		GetProcessingRunCtxFunc: func(_ context.Context, id int64) (*models.ProcessingRun, error) {
+			return &models.ProcessingRun{ID: id, Status: runStatus}, nil
+		},
+	}
+	e := &ProcessingEngine{
+		repo:           repo,
+		scraper:        testutil.NewMockScraper(),
+		scorer:         testutil.NewMockScorer(),
+		trustCalc:      trust.NewDefault(),
+		decisionEngine: decision.NewDecisionEngine(decision.DefaultDecisionConfig()),
+		jobQueue:       make(chan *ProcessingJob, 3),
+		ctx:            context.Background(),
+		stats:          newEngineStats(1),
+		runs:           newRunTracker(),
+	}
+	prompts := stubPrompts{system: []string{"system@v2"}, user: []string{"unified_user@v1"}}
+	e.EnableRescore(store, prompts, RescoreConfig{Interval: time.Minute, MaxVenues: 2})
+
+	now := time.Now()
+	if n := e.rescoreStale(context.Background(), now); n != 2 {
+		t.Fatalf("queued %d venues, want 2", n)
+	}
+	if versions[models.ScoringVersionDecision] != e.decisionEngine.Version() || versions[models.ScoringVersionPrompts] == "" {
+		t.Fatalf("versions = %v", versions)
+	}
+	if !query.PromptsSince.Equal(since) || !query.DecisionSince.IsZero() || query.SystemPrompts[0] != "system@v2" {
+		t.Fatalf("query = %+v", query)
+	}
+	for i := 0; i < 2; i++ {
+		if job := <-e.jobQueue; job.RunID != 7 || job.Mode != ModeScoreOnly {
+			t.Fatalf("job %d = %+v", i, job)
+		}
+	}
+	var filters map[string]interface{}
+	if run, _ := e.ActiveRun(7); json.Unmarshal([]byte(run.Filters), &filters) != nil || filters["source"] != "rescore" {
+		t.Fatalf("run filters = %q", run.Filters)
+	}
+
+	// The next check waits for the run to finish
+	if n := e.rescoreStale(context.Background(), now); n != 0 {
+		t.Fatalf("queued %d venues while the previous run is going", n)
+	}
+
+	// Nothing is left in today's budget
+	runStatus = models.RunStatusCompleted
+	e.rescore.budget.add(0.5, now)
+	e.rescore.cfg.DailyBudgetUSD = 0.5
+	if n := e.rescoreStale(context.Background(), now); n != 0 {
+		t.Fatalf("queued %d venues past the budget", n)
+	}
+}
@@ -0,0 +1,121 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// finalFlushTimeout bounds the flush of pending results when the result processor stops.
+const finalFlushTimeout = 15 * time.Second
+
+var (
+	mResultBatches        = metrics.Default.Counter("venue_result_batches_total", "Batches of validation results committed")
+	mResultBatchFallbacks = metrics.Default.Counter("venue_result_batch_fallbacks_total", "Batches that failed and were written one result at a time")
+	mResultBatchSize      = metrics.Default.Histogram("venue_result_batch_size", "Validation results per committed batch", []float64{1, 5, 10, 25, 50, 100, 250, 500})
+)
+
+// pendingWrite is what one processed result writes: an optional validation history row and
+// an optional venue status change.
+type pendingWrite struct {
+	venueID int64
+	history *domain.HistoryRecord
+	status  *int
+}
+
+// resultBatcher groups result writes so a batch shares one transaction and one multi-row
+// INSERT. It is only used from the result processor goroutine and needs no locking.
+type resultBatcher struct {
+	uow     domain.UnitOfWorkFactory
+	size    int
+	pending []pendingWrite
+	onWrite func(n int, took time.Duration) // optional, after each committed batch
+}
+
+func newResultBatcher(uow domain.UnitOfWorkFactory, size int) *resultBatcher {
+	return &resultBatcher{uow: uow, size: size, pending: make([]pendingWrite, 0, size)}
+}
+
+// add queues w and flushes when the batch is full.
+func (b *resultBatcher) add(ctx context.Context, w pendingWrite) {
+	b.pending = append(b.pending, w)
+	if len(b.pending) >= b.size {
+		b.flush(ctx)
+	}
+}
+
+// flush writes the pending batch. If the batch fails as a whole, each result is retried in a
+// transaction of its own so one bad row does not lose the others.
+func (b *resultBatcher) flush(ctx context.Context) {
+	if len(b.pending) == 0 {
+		return
+	}
+	batch := b.pending
+	b.pending = make([]pendingWrite, 0, b.size)
+
+	start := time.Now()
+	err := b.write(ctx, batch)
+	if err == nil {
+		mResultBatches.Inc(1)
+		mResultBatchSize.Observe(float64(len(batch)))
+		if b.onWrite != nil {
+			b.onWrite(len(batch), time.Since(start))
+		}
+		return
+	}
+	if len(batch) == 1 {
+		log.Printf("Failed to write result for venue %d: %v", batch[0].venueID, err)
+		return
+	}
+	log.Printf("Failed to write batch of %d results, writing them one by one: %v", len(batch), err)
+	mResultBatchFallbacks.Inc(1)
+	for _, w := range batch {
+		if err := b.write(ctx, []pendingWrite{w}); err != nil {
+			log.Printf("Failed to write result for venue %d: %v", w.venueID, err)
+		}
+	}
+}
+
+func (b *resultBatcher) write(ctx context.Context, batch []pendingWrite) error {
+	uow, err := b.uow.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin unit of work: %w", err)
+	}
+	defer uow.Rollback()
+
+	records := make([]domain.HistoryRecord, 0, len(batch))
+	for _, w := range batch {
+		if w.history != nil {
+			records = append(records, *w.history)
+		}
+	}
+	if err := uow.SaveValidationResultsCtx(ctx, records); err != nil {
+		return err
+	}
+	for _, w := range batch {
+		if w.status == nil {
+			continue
+		}
+		if err := uow.UpdateVenueActiveCtx(ctx, w.venueID, *w.status); err != nil {
+			return fmt.Errorf("venue %d: %w", w.venueID, err)
+		}
+	}
+	return uow.Commit()
+}
+
+// approvalEligible is ValidateApprovalEligibility for a history row that is not written yet:
+// in a batch the result being approved is the venue's latest history.
+func approvalEligible(vr *models.ValidationResult, threshold int) error {
+	if vr.Status != "approved" {
+		return fmt.Errorf("validation status is '%s' (not 'approved') - cannot approve venue", vr.Status)
+	}
+	if vr.Score < threshold {
+		return fmt.Errorf("validation score %d is below threshold %d - cannot approve venue", vr.Score, threshold)
+	}
+	return nil
+}
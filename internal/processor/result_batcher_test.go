@@ -0,0 +1,117 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+)
+
+// fakeBatchStore records what each unit of work wrote; saves fail for venues in failFor.
+type fakeBatchStore struct {
+	failFor   map[int64]bool
+	begun     int
+	committed [][]domain.HistoryRecord
+	statuses  map[int64]int
+}
+
+func (s *fakeBatchStore) factory() *testutil.UnitOfWorkFactory {
+	return &testutil.UnitOfWorkFactory{BeginFunc: func(context.Context) (domain.UnitOfWork, error) {
+		s.begun++
+		var records []domain.HistoryRecord
+		statuses := map[int64]int{}
+		return &testutil.UnitOfWork{
+			SaveValidationResultsCtxFunc: func(_ context.Context, rs []domain.HistoryRecord) error {
+				for _, r := range rs {
+					if s.failFor[r.Result.VenueID] {
+						return errors.New("bad row")
+					}
+				}
+				records = rs
+				return nil
+			},
+			UpdateVenueActiveCtxFunc: func(_ context.Context, id int64, active int) error {
+				statuses[id] = active
+				return nil
+			},
+			CommitFunc: func() error {
+				s.committed = append(s.committed, records)
+				for id, st := range statuses {
+					s.statuses[id] = st
+				}
+				return nil
+			},
+			RollbackFunc: func() error { return nil },
+		}, nil
+	}}
+}
+
+func write(id int64, status int) pendingWrite {
+	return pendingWrite{venueID: id, history: &domain.HistoryRecord{Result: &models.ValidationResult{VenueID: id}}, status: &status}
+}
+
+func TestResultBatcher(t *testing.T) {
+	tests := []struct {
+		name        string
+		size        int
+		writes      []pendingWrite
+		failFor     map[int64]bool
+		wantBatches []int // records per committed transaction
+		wantStatus  map[int64]int
+	}{
+		{"flushes when full", 2, []pendingWrite{write(1, 1), write(2, 0), write(3, -1)}, nil, []int{2, 1}, map[int64]int{1: 1, 2: 0, 3: -1}},
+		{"status only", 5, []pendingWrite{{venueID: 4, status: new(int)}, write(5, 1)}, nil, []int{1}, map[int64]int{4: 0, 5: 1}},
+		{"bad row falls back to single writes", 3, []pendingWrite{write(1, 1), write(2, 1), write(3, 1)}, map[int64]bool{2: true}, []int{1, 1}, map[int64]int{1: 1, 3: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &fakeBatchStore{failFor: tt.failFor, statuses: map[int64]int{}}
+			b := newResultBatcher(s.factory(), tt.size)
+			for _, w := range tt.writes {
+				b.add(context.Background(), w)
+			}
+			b.flush(context.Background())
+
+			if len(s.committed) != len(tt.wantBatches) {
+				t.Fatalf("committed %d transactions, want %d", len(s.committed), len(tt.wantBatches))
+			}
+			for i, n := range tt.wantBatches {
+				if len(s.committed[i]) != n {
+					t.Errorf("transaction %d wrote %d records, want %d", i, len(s.committed[i]), n)
+				}
+			}
+			if len(s.statuses) != len(tt.wantStatus) {
+				t.Fatalf("statuses=%v want %v", s.statuses, tt.wantStatus)
+			}
+			for id, st := range tt.wantStatus {
+				if s.statuses[id] != st {
+					t.Errorf("venue %d status=%d want %d", id, s.statuses[id], st)
+				}
+			}
+			if len(b.pending) != 0 {
+				t.Errorf("%d writes left pending after flush", len(b.pending))
+			}
+		})
+	}
+}
+
+func TestApprovalEligible(t *testing.T) {
+	tests := []struct {
+		status string
+		score  int
+		ok     bool
+	}{
+		{"approved", 85, true},
+		{"approved", 84, false},
+		{"manual_review", 95, false},
+	}
+	for _, tt := range tests {
+		err := approvalEligible(&models.ValidationResult{Status: tt.status, Score: tt.score}, 85)
+		if (err == nil) != tt.ok {
+			t.Errorf("approvalEligible(%s, %d) err=%v, want ok=%v", tt.status, tt.score, err, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package processor
+
+import (
+	"math/rand/v2"
+	"time"
+
+	errs "assisted-venue-approval/pkg/errors"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// RetryPolicy decides whether a failed venue is processed again and how long to wait.
+// Each error class has its own budget so, for example, a burst of 429s does not use up
+// the retries meant for a flaky connection. Classes without a budget are never retried.
+type RetryPolicy struct {
+	BaseDelay time.Duration      // first backoff step, doubled per retry
+	MaxDelay  time.Duration      // cap on the computed backoff (Retry-After may exceed it)
+	Budgets   map[errs.Class]int // max retries per error class for one venue
+	rand      func() float64     // jitter source; nil means math/rand
+}
+
+// DefaultRetryPolicy retries throttling more patiently than outages and never retries
+// auth or malformed-request errors.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		BaseDelay: 2 * time.Second,
+		MaxDelay:  30 * time.Second,
+		Budgets: map[errs.Class]int{
+			errs.ClassRateLimit: 5,
+			errs.ClassTimeout:   3,
+			errs.ClassServer:    3,
+			errs.ClassNetwork:   3,
+		},
+	}
+}
+
+// maxAttempts bounds the retry loop: one first attempt plus every class budget.
+func (p RetryPolicy) maxAttempts() int {
+	n := 1
+	for _, b := range p.Budgets {
+		n += b
+	}
+	return n
+}
+
+var mRetries = metrics.Default.CounterVec("venue_processing_retries_total", "Venue processing retries by error class", "class")
+
+// next reports whether err should be retried given the retries already spent per class,
+// and the delay before the next attempt. retry is the 1-based number of the upcoming retry.
+func (p RetryPolicy) next(err error, used map[errs.Class]int, retry int) (time.Duration, bool) {
+	class := errs.Classify(err)
+	if used[class] >= p.Budgets[class] {
+		return 0, false
+	}
+	used[class]++
+	mRetries.With(string(class)).Inc()
+
+	delay := p.backoff(retry)
+	if ra := errs.RetryAfterOf(err); ra > delay {
+		delay = ra
+	}
+	return delay, true
+}
+
+// backoff is exponential with equal jitter: half the step is fixed, half random,
+// so concurrent workers hitting the same limit spread out without retrying instantly.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	if p.BaseDelay <= 0 || retry < 1 {
+		return 0
+	}
+	step := p.BaseDelay
+	for i := 1; i < retry && (p.MaxDelay <= 0 || step < p.MaxDelay); i++ {
+		step *= 2
+	}
+	if p.MaxDelay > 0 && step > p.MaxDelay {
+		step = p.MaxDelay
+	}
+	rnd := p.rand
+	if rnd == nil {
+		rnd = rand.Float64
+	}
+	half := step / 2
+	return half + time.Duration(rnd()*float64(step-half))
+}
@@ -0,0 +1,80 @@
+package processor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+func TestRetryPolicy_Budgets(t *testing.T) {
+	p := RetryPolicy{
+		BaseDelay: time.Second,
+		MaxDelay:  10 * time.Second,
+		Budgets:   map[errs.Class]int{errs.ClassRateLimit: 2, errs.ClassServer: 1},
+		rand:      func() float64 { return 0 },
+	}
+	rateLimited := errs.NewExternalStatus("op", "openai", 429, 0, errors.New("429"))
+	server := errs.NewExternalStatus("op", "openai", 500, 0, errors.New("500"))
+	auth := errs.NewExternalStatus("op", "openai", 401, 0, errors.New("401"))
+
+	used := map[errs.Class]int{}
+	steps := []struct {
+		err  error
+		want bool
+	}{
+		{rateLimited, true},
+		{server, true},
+		{rateLimited, true},
+		{server, false}, // server budget spent
+		{rateLimited, false},
+		{auth, false},
+		{errors.New("timeout"), false}, // unclassified strings are not retried
+	}
+	for i, s := range steps {
+		if _, ok := p.next(s.err, used, i+1); ok != s.want {
+			t.Fatalf("step %d (%v): retry=%v want %v", i, s.err, ok, s.want)
+		}
+	}
+	if got := p.maxAttempts(); got != 4 {
+		t.Fatalf("maxAttempts=%d want 4", got)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	tests := []struct {
+		name  string
+		retry int
+		rnd   float64
+		want  time.Duration
+	}{
+		{"first, no jitter", 1, 0, 500 * time.Millisecond},
+		{"first, full jitter", 1, 1, time.Second},
+		{"third doubles twice", 3, 0, 2 * time.Second},
+		{"capped", 10, 1, 5 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p.rand = func() float64 { return tt.rnd }
+			if got := p.backoff(tt.retry); got != tt.want {
+				t.Fatalf("backoff(%d)=%v want %v", tt.retry, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_RetryAfterWins(t *testing.T) {
+	p := RetryPolicy{
+		BaseDelay: time.Second,
+		MaxDelay:  5 * time.Second,
+		Budgets:   map[errs.Class]int{errs.ClassRateLimit: 1},
+		rand:      func() float64 { return 0 },
+	}
+	err := errs.NewExternalStatus("op", "openai", 429, 20*time.Second, errors.New("429"))
+	delay, ok := p.next(err, map[errs.Class]int{}, 1)
+	if !ok || delay != 20*time.Second {
+		t.Fatalf("delay=%v ok=%v, want Retry-After of 20s", delay, ok)
+	}
+}
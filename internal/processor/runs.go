@@ -0,0 +1,164 @@
+package processor
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// runTracker holds the processing runs that still have venues in flight. Outcome counts are
+// kept here and written to the run row once, when its last venue is done.
+type runTracker struct {
+	mu     sync.Mutex
+	active map[int64]*activeRun
+}
+
+type activeRun struct {
+	run       models.ProcessingRun
+	costStart float64 // scorer spend when the run started
+}
+
+func newRunTracker() *runTracker {
+	return &runTracker{active: map[int64]*activeRun{}}
+}
+
+// StartRun records run and queues venues as its jobs. Mode, venue count, start time and
+// estimated cost are filled in here; the caller sets TriggeredBy and Filters. When the run
+// row cannot be written the venues are still queued, just without a run. Returns the run
+// as created (ID 0 without a row) and any queuing error; on error the run covers only the
+// venues that made it into the queue.
+func (e *ProcessingEngine) StartRun(ctx context.Context, venues []models.VenueWithUser, mode Mode, run models.ProcessingRun) (models.ProcessingRun, error) {
+	run.Mode = string(mode)
+	run.VenueCount = len(venues)
+	run.Status = models.RunStatusRunning
+	run.StartedAt = time.Now()
+	run.EstimatedCostUSD = e.EstimateRun(ctx, venues).TotalCostUSD
+
+	if err := e.repo.CreateProcessingRunCtx(ctx, &run); err != nil {
+		log.Printf("Failed to record processing run, queuing without one: %v", err)
+		run.ID = 0
+	}
+	if run.ID != 0 && (e.dist != nil || mode == ModeBatch) {
+		// Venues may finish on any instance, or after a restart when their batch job
+		// completes, so outcomes are counted in the run row itself
+		queued, err := e.queueVenues(venues, mode, run.ID)
+		if queued != run.VenueCount {
+			run.VenueCount = queued
+			if uerr := e.repo.SetRunVenueCountCtx(ctx, run.ID, queued); uerr != nil {
+				log.Printf("Failed to correct venue count of processing run %d: %v", run.ID, uerr)
+			}
+		}
+		return run, err
+	}
+	if run.ID != 0 {
+		// Register before queuing: results can arrive before queueVenues returns
+		_, _, cost, _ := e.scorer.GetCostStats()
+		e.runs.mu.Lock()
+		e.runs.active[run.ID] = &activeRun{run: run, costStart: cost}
+		e.runs.mu.Unlock()
+	}
+
+	queued, err := e.queueVenues(venues, mode, run.ID)
+	if err != nil && run.ID != 0 {
+		run.VenueCount = queued
+		var done *models.ProcessingRun
+		e.runs.mu.Lock()
+		if ar, ok := e.runs.active[run.ID]; ok {
+			ar.run.VenueCount = queued
+			done = e.runs.finishIfDoneLocked(ar, e.scorer)
+		}
+		e.runs.mu.Unlock()
+		e.storeFinishedRun(done)
+	}
+	return run, err
+}
+
+// ActiveRun returns the live state of a run that still has venues in flight.
+func (e *ProcessingEngine) ActiveRun(id int64) (models.ProcessingRun, bool) {
+	e.runs.mu.Lock()
+	defer e.runs.mu.Unlock()
+	ar, ok := e.runs.active[id]
+	if !ok {
+		return models.ProcessingRun{}, false
+	}
+	return ar.run, true
+}
+
+// recordRunOutcome counts a finished venue against its run.
+func (e *ProcessingEngine) recordRunOutcome(result *ProcessingResult) {
+	e.runs.mu.Lock()
+	ar, ok := e.runs.active[result.RunID]
+	if !ok {
+		e.runs.mu.Unlock()
+		return
+	}
+	switch runOutcome(result) {
+	case models.RunOutcomeFailed:
+		ar.run.Failed++
+	case models.RunOutcomeApproved:
+		ar.run.Approved++
+	case models.RunOutcomeRejected:
+		ar.run.Rejected++
+	default:
+		ar.run.ManualReview++
+	}
+	done := e.runs.finishIfDoneLocked(ar, e.scorer)
+	e.runs.mu.Unlock()
+	e.storeFinishedRun(done)
+}
+
+// runOutcome is the run counter a result falls under.
+func runOutcome(result *ProcessingResult) string {
+	switch {
+	case !result.Success || result.ValidationResult == nil:
+		return models.RunOutcomeFailed
+	case result.ValidationResult.Status == "approved":
+		return models.RunOutcomeApproved
+	case result.ValidationResult.Status == "rejected":
+		return models.RunOutcomeRejected
+	default:
+		return models.RunOutcomeManualReview
+	}
+}
+
+// finishIfDoneLocked completes ar once every venue has an outcome and returns a copy to
+// store, or nil while venues are outstanding. The caller holds t.mu.
+func (t *runTracker) finishIfDoneLocked(ar *activeRun, scorer VenueScorer) *models.ProcessingRun {
+	if ar.run.Processed() < ar.run.VenueCount {
+		return nil
+	}
+	now := time.Now()
+	_, _, cost, _ := scorer.GetCostStats()
+	ar.run.Status = models.RunStatusCompleted
+	ar.run.FinishedAt = &now
+	// Scorer spend is engine-wide, so runs overlapping in time each include the other's calls
+	ar.run.OpenAICostUSD = cost - ar.costStart
+	delete(t.active, ar.run.ID)
+	run := ar.run
+	return &run
+}
+
+func (e *ProcessingEngine) storeFinishedRun(run *models.ProcessingRun) {
+	if run == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := e.repo.UpdateProcessingRunCtx(ctx, run); err != nil {
+		log.Printf("Failed to store outcome of processing run %d: %v", run.ID, err)
+		return
+	}
+	log.Printf("Processing run %d completed: %d approved, %d rejected, %d manual review, %d failed",
+		run.ID, run.Approved, run.Rejected, run.ManualReview, run.Failed)
+}
+
+func (r *ProcessingResult) runIDPtr() *int64 {
+	if r.RunID == 0 {
+		return nil
+	}
+	id := r.RunID
+	return &id
+}
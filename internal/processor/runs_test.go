@@ -0,0 +1,96 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+	"assisted-venue-approval/internal/trust"
+)
+
+func TestStartRun_TracksOutcomesUntilDone(t *testing.T) {
+	var stored []models.ProcessingRun
+	repo := &testutil.Repository{
+		CreateProcessingRunCtxFunc: func(_ context.Context, run *models.ProcessingRun) error {
+			run.ID = 11
+			return nil
+		},
+		UpdateProcessingRunCtxFunc: func(_ context.Context, run *models.ProcessingRun) error {
+			stored = append(stored, *run)
+			return nil
+		},
+	}
+	e := &ProcessingEngine{
+		repo:      repo,
+		scraper:   testutil.NewMockScraper(),
+		scorer:    testutil.NewMockScorer(),
+		trustCalc: trust.NewDefault(),
+		jobQueue:  make(chan *ProcessingJob, 3),
+		ctx:       context.Background(),
+		stats:     newEngineStats(1),
+		runs:      newRunTracker(),
+	}
+	venues := []models.VenueWithUser{{Venue: models.Venue{ID: 1}}, {Venue: models.Venue{ID: 2}}, {Venue: models.Venue{ID: 3}}}
+	run, err := e.StartRun(context.Background(), venues, ModeScoreOnly, models.ProcessingRun{Filters: "{}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.ID != 11 || run.Mode != string(ModeScoreOnly) || run.VenueCount != 3 || run.Status != models.RunStatusRunning {
+		t.Fatalf("run = %+v", run)
+	}
+	for i := 0; i < 3; i++ {
+		if job := <-e.jobQueue; job.RunID != 11 {
+			t.Fatalf("job %d run ID = %d, want 11", i, job.RunID)
+		}
+	}
+
+	outcomes := []*ProcessingResult{
+		{RunID: 11, Success: true, ValidationResult: &models.ValidationResult{Status: "approved"}},
+		{RunID: 11, Success: false, Error: errors.New("timeout")},
+	}
+	for _, r := range outcomes {
+		e.recordRunOutcome(r)
+	}
+	if live, ok := e.ActiveRun(11); !ok || live.Approved != 1 || live.Failed != 1 || len(stored) != 0 {
+		t.Fatalf("after 2 of 3: live=%+v ok=%v stored=%d", live, ok, len(stored))
+	}
+
+	e.recordRunOutcome(&ProcessingResult{RunID: 11, Success: true, ValidationResult: &models.ValidationResult{Status: "manual_review"}})
+	if _, ok := e.ActiveRun(11); ok {
+		t.Fatal("run still active after its last venue")
+	}
+	if len(stored) != 1 {
+		t.Fatalf("stored %d updates, want 1", len(stored))
+	}
+	got := stored[0]
+	if got.Status != models.RunStatusCompleted || got.FinishedAt == nil || got.Approved != 1 || got.ManualReview != 1 || got.Failed != 1 {
+		t.Fatalf("stored run = %+v", got)
+	}
+}
+
+func TestStartRun_QueuesWithoutRunRow(t *testing.T) {
+	repo := &testutil.Repository{
+		CreateProcessingRunCtxFunc: func(context.Context, *models.ProcessingRun) error {
+			return errors.New("table processing_runs doesn't exist")
+		},
+	}
+	e := &ProcessingEngine{
+		repo:      repo,
+		scraper:   testutil.NewMockScraper(),
+		scorer:    testutil.NewMockScorer(),
+		trustCalc: trust.NewDefault(),
+		jobQueue:  make(chan *ProcessingJob, 1),
+		ctx:       context.Background(),
+		stats:     newEngineStats(1),
+		runs:      newRunTracker(),
+	}
+	run, err := e.StartRun(context.Background(), []models.VenueWithUser{{Venue: models.Venue{ID: 1}}}, ModeScoreOnly, models.ProcessingRun{})
+	if err != nil || run.ID != 0 {
+		t.Fatalf("run = %+v, err = %v", run, err)
+	}
+	if job := <-e.jobQueue; job.RunID != 0 || job.Venue.ID != 1 {
+		t.Fatalf("job = %+v", job)
+	}
+}
@@ -0,0 +1,85 @@
+package processor
+
+import (
+	"context"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// SocialVerifier resolves a venue's Facebook/Instagram links. Implemented by scraper.SocialVerifier.
+type SocialVerifier interface {
+	VerifySocial(ctx context.Context, venue models.Venue) []models.SocialCheck
+}
+
+// Social score contributions, recorded under score_breakdown["social_verification"].
+const (
+	socialMatchBonus   = 2 // per resolving profile whose handle matches the name
+	socialMaxBonus     = 4
+	socialDeadPenalty  = -3
+	socialBreakdownKey = "social_verification"
+	socialOutputKey    = "social_check"
+)
+
+var (
+	mSocialChecks   = metrics.Default.Counter("social_checks_total", "Social media profiles checked")
+	mSocialNotFound = metrics.Default.Counter("social_checks_not_found_total", "Social media profiles that do not exist")
+)
+
+// SetSocialVerifier enables Facebook/Instagram profile checks. nil disables them.
+func (e *ProcessingEngine) SetSocialVerifier(v SocialVerifier) {
+	e.avaConfigMu.Lock()
+	defer e.avaConfigMu.Unlock()
+	e.socialVerifier = v
+}
+
+// checkSocial verifies the venue's social links, folds the result into the score and
+// exposes it to the decision engine through venue.ValidationDetails.Social.
+func (e *ProcessingEngine) checkSocial(ctx context.Context, venue *models.Venue, vr *models.ValidationResult) []models.SocialCheck {
+	e.avaConfigMu.RLock()
+	verifier := e.socialVerifier
+	e.avaConfigMu.RUnlock()
+
+	if verifier == nil || vr == nil {
+		return nil
+	}
+	checks := verifier.VerifySocial(ctx, *venue)
+	if len(checks) == 0 {
+		return nil
+	}
+	for _, c := range checks {
+		mSocialChecks.Inc(1)
+		if c.Status == models.SocialNotFound {
+			mSocialNotFound.Inc(1)
+		}
+	}
+	if venue.ValidationDetails != nil {
+		venue.ValidationDetails.Social = checks
+	}
+
+	delta := socialScoreDelta(checks)
+	if vr.ScoreBreakdown == nil {
+		vr.ScoreBreakdown = make(map[string]int)
+	}
+	vr.ScoreBreakdown[socialBreakdownKey] = delta
+	vr.Score = clampScore(vr.Score + delta)
+	return checks
+}
+
+// socialScoreDelta rewards live profiles that look like the venue's own and penalises dead links.
+// Login walls, errors and dormant accounts are neutral: they say nothing about whether the venue is open.
+func socialScoreDelta(checks []models.SocialCheck) int {
+	delta := 0
+	for _, c := range checks {
+		switch {
+		case c.Status == models.SocialNotFound:
+			delta += socialDeadPenalty
+		case c.Status == models.SocialOK && c.NameMatches && (c.Active == nil || *c.Active):
+			delta += socialMatchBonus
+		}
+	}
+	if delta > socialMaxBonus {
+		delta = socialMaxBonus
+	}
+	return delta
+}
@@ -0,0 +1,231 @@
+package processor
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// StatsSchemaVersion is reported in ProcessingStats (and so in /api/stats). Bump it when a
+// field is renamed, removed or changes meaning; adding fields does not need a bump.
+// 2: Latency and Stages added, AverageTimeMs is the mean of the latency window.
+// 3: quality review timed as its own stage; "openai" is AI scoring only.
+const StatsSchemaVersion = 3
+
+// Processing stages timed in ProcessingStats.Stages.
+const (
+	StageGoogle   = "google"         // Google Places enrichment
+	StageOpenAI   = "openai"         // AI scoring
+	StageQuality  = "quality_review" // AI quality review, when enabled
+	StageDecision = "decision"       // decision engine
+	StageDB       = "db"             // writing the result
+)
+
+var stageNames = []string{StageGoogle, StageOpenAI, StageQuality, StageDecision, StageDB}
+
+// latencyWindowSize is how many recent samples percentiles are computed over.
+const latencyWindowSize = 1024
+
+// ProcessingStats is a point-in-time snapshot of the engine's statistics.
+type ProcessingStats struct {
+	SchemaVersion  int
+	TotalJobs      int64
+	CompletedJobs  int64
+	SuccessfulJobs int64
+	FailedJobs     int64
+	Panics         int64 // recovered in workers; panicked jobs also count as failed
+	AutoApproved   int64
+	ManualReview   int64
+	AutoRejected   int64
+	AverageTimeMs  int64
+	StartTime      time.Time
+	LastActivity   time.Time
+	WorkerCount    int
+	QueueSize      int64
+	APICallsGoogle int64
+	APICallsOpenAI int64
+	TotalCostUSD   float64 // OpenAI
+
+	// Estimated Google Maps Platform spend at list price, per billing SKU
+	GoogleCostUSD float64
+	GoogleSKUs    []models.PlacesSKUUsage
+	GoogleKeys    []models.GoogleKeyUsage // per API key, when the scraper rotates keys
+
+	// Use of the partitioned rate limits and daily quotas, per endpoint and partition
+	Quota []QuotaUsage
+
+	// End-to-end processing latency per venue and its breakdown by stage
+	Latency LatencySummary
+	Stages  map[string]LatencySummary
+
+	// Pool stats (hot paths)
+	JobPoolGets      int64
+	JobPoolPuts      int64
+	JobPoolMisses    int64
+	ResultPoolGets   int64
+	ResultPoolPuts   int64
+	ResultPoolMisses int64
+	BufferPoolGets   int64
+	BufferPoolPuts   int64
+	BufferPoolMisses int64
+}
+
+// LatencySummary describes the most recent samples of one latency; Count is all-time.
+type LatencySummary struct {
+	Count  int64
+	MeanMs float64
+	P50Ms  float64
+	P95Ms  float64
+	P99Ms  float64
+}
+
+// engineStats is the live, concurrency-safe side of ProcessingStats: counters are atomics and
+// latencies go to fixed-size windows, so workers never contend on one stats lock.
+type engineStats struct {
+	total      atomic.Int64
+	completed  atomic.Int64
+	successful atomic.Int64
+	failed     atomic.Int64
+	approved   atomic.Int64
+	rejected   atomic.Int64
+	manual     atomic.Int64
+	queue      atomic.Int64
+	google     atomic.Int64
+	openAI     atomic.Int64
+	workers    atomic.Int64
+	panics     atomic.Int64
+
+	// Items taken off the job and result queues, for the resource watchdog
+	jobsTaken    atomic.Int64
+	resultsTaken atomic.Int64
+
+	start        time.Time
+	lastActivity atomic.Int64 // unix nanoseconds
+
+	latency *latencyWindow
+	stages  map[string]*latencyWindow // keys fixed at construction; read-only afterwards
+}
+
+func newEngineStats(workers int) *engineStats {
+	s := &engineStats{start: time.Now(), latency: newLatencyWindow(latencyWindowSize), stages: map[string]*latencyWindow{}}
+	for _, name := range stageNames {
+		s.stages[name] = newLatencyWindow(latencyWindowSize)
+	}
+	s.workers.Store(int64(workers))
+	s.touch()
+	return s
+}
+
+func (s *engineStats) touch() { s.lastActivity.Store(time.Now().UnixNano()) }
+
+// countDecision counts a result under its final status.
+func (s *engineStats) countDecision(status string) {
+	switch status {
+	case "approved":
+		s.approved.Add(1)
+	case "rejected":
+		s.rejected.Add(1)
+	default:
+		s.manual.Add(1)
+	}
+}
+
+// observeStage records how long stage took for one venue; unknown stages are ignored.
+func (s *engineStats) observeStage(stage string, d time.Duration) {
+	if w := s.stages[stage]; w != nil {
+		w.observe(d)
+	}
+}
+
+// snapshot copies the counters and summarises the latency windows.
+func (s *engineStats) snapshot() ProcessingStats {
+	st := ProcessingStats{
+		SchemaVersion:  StatsSchemaVersion,
+		TotalJobs:      s.total.Load(),
+		CompletedJobs:  s.completed.Load(),
+		SuccessfulJobs: s.successful.Load(),
+		FailedJobs:     s.failed.Load(),
+		Panics:         s.panics.Load(),
+		AutoApproved:   s.approved.Load(),
+		AutoRejected:   s.rejected.Load(),
+		ManualReview:   s.manual.Load(),
+		QueueSize:      s.queue.Load(),
+		APICallsGoogle: s.google.Load(),
+		APICallsOpenAI: s.openAI.Load(),
+		WorkerCount:    int(s.workers.Load()),
+		StartTime:      s.start,
+		LastActivity:   time.Unix(0, s.lastActivity.Load()),
+		Latency:        s.latency.summary(),
+		Stages:         make(map[string]LatencySummary, len(s.stages)),
+	}
+	st.AverageTimeMs = int64(math.Round(st.Latency.MeanMs))
+	for name, w := range s.stages {
+		st.Stages[name] = w.summary()
+	}
+	return st
+}
+
+// latencyWindow keeps the last len(samples) observations in a ring buffer.
+type latencyWindow struct {
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+	full    bool
+	count   int64
+}
+
+func newLatencyWindow(size int) *latencyWindow {
+	return &latencyWindow{samples: make([]time.Duration, size)}
+}
+
+func (w *latencyWindow) observe(d time.Duration) {
+	w.mu.Lock()
+	w.samples[w.next] = d
+	w.next++
+	if w.next == len(w.samples) {
+		w.next, w.full = 0, true
+	}
+	w.count++
+	w.mu.Unlock()
+}
+
+func (w *latencyWindow) summary() LatencySummary {
+	w.mu.Lock()
+	n := w.next
+	if w.full {
+		n = len(w.samples)
+	}
+	sorted := append([]time.Duration(nil), w.samples[:n]...)
+	count := w.count
+	w.mu.Unlock()
+
+	sum := LatencySummary{Count: count}
+	if n == 0 {
+		return sum
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	sum.MeanMs = ms(total / time.Duration(n))
+	sum.P50Ms = ms(percentile(sorted, 50))
+	sum.P95Ms = ms(percentile(sorted, 95))
+	sum.P99Ms = ms(percentile(sorted, 99))
+	return sum
+}
+
+// percentile returns the nearest-rank p-th percentile of sorted (ascending, non-empty).
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func ms(d time.Duration) float64 { return float64(d.Microseconds()) / 1000 }
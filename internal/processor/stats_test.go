@@ -0,0 +1,163 @@
+package processor
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/internal/trust"
+)
+
+func TestLatencyWindow_Summary(t *testing.T) {
+	w := newLatencyWindow(100)
+	if got := w.summary(); got != (LatencySummary{}) {
+		t.Fatalf("empty window summary = %+v", got)
+	}
+	for i := 1; i <= 100; i++ {
+		w.observe(time.Duration(i) * time.Millisecond)
+	}
+	got := w.summary()
+	want := LatencySummary{Count: 100, MeanMs: 50.5, P50Ms: 50, P95Ms: 95, P99Ms: 99}
+	if got != want {
+		t.Fatalf("summary = %+v, want %+v", got, want)
+	}
+
+	// The ring keeps only the newest samples; Count stays all-time
+	for i := 0; i < 100; i++ {
+		w.observe(time.Second)
+	}
+	got = w.summary()
+	if got.Count != 200 || got.P50Ms != 1000 || got.P99Ms != 1000 {
+		t.Fatalf("after wrap summary = %+v", got)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []time.Duration{1, 2, 3, 4}
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{{0, 1}, {25, 1}, {50, 2}, {51, 3}, {99, 4}, {100, 4}}
+	for _, tt := range tests {
+		if got := percentile(sorted, tt.p); got != tt.want {
+			t.Errorf("percentile(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestEngineStats_SnapshotConcurrent(t *testing.T) {
+	s := newEngineStats(3)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.completed.Add(1)
+				s.countDecision([]string{"approved", "rejected", "manual_review"}[j%3])
+				s.latency.observe(10 * time.Millisecond)
+				s.observeStage(StageGoogle, 2*time.Millisecond)
+				s.observeStage("unknown", time.Second)
+				_ = s.snapshot()
+			}
+		}()
+	}
+	wg.Wait()
+
+	st := s.snapshot()
+	if st.SchemaVersion != StatsSchemaVersion || st.WorkerCount != 3 {
+		t.Fatalf("snapshot header = v%d workers=%d", st.SchemaVersion, st.WorkerCount)
+	}
+	if st.CompletedJobs != 800 || st.AutoApproved+st.AutoRejected+st.ManualReview != 800 {
+		t.Fatalf("counts: completed=%d decisions=%d", st.CompletedJobs, st.AutoApproved+st.AutoRejected+st.ManualReview)
+	}
+	if st.AverageTimeMs != 10 || st.Latency.Count != 800 {
+		t.Fatalf("latency = %+v avg=%d", st.Latency, st.AverageTimeMs)
+	}
+	if g := st.Stages[StageGoogle]; g.Count != 800 || g.P95Ms != 2 {
+		t.Fatalf("google stage = %+v", g)
+	}
+	if len(st.Stages) != len(stageNames) {
+		t.Fatalf("stages = %v", st.Stages)
+	}
+}
+
+func TestAttachTimings_RoundTrip(t *testing.T) {
+	str := func(s string) *string { return &s }
+	tests := []struct {
+		name     string
+		aiOutput *string
+	}{
+		{"no prior output", nil},
+		{"keeps existing keys", str(`{"scoring":{"score":80},"explanation":{"summary":"ok"}}`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr := &models.ValidationResult{VenueID: 1, Score: 80, AIOutputData: tt.aiOutput}
+			attachTimings(vr, &models.StageTimings{GoogleMs: 120, ScoringMs: 900, DecisionMs: 2}, time.Now().Add(-time.Second))
+
+			h := models.ValidationHistory{AIOutputData: vr.AIOutputData}
+			got := h.Timings()
+			if got == nil {
+				t.Fatalf("Timings() = nil, ai_output_data %s", *vr.AIOutputData)
+			}
+			if got.GoogleMs != 120 || got.ScoringMs != 900 || got.QualityReviewMs != 0 || got.DecisionMs != 2 {
+				t.Errorf("Timings() = %+v", got)
+			}
+			if got.TotalMs < 1000 {
+				t.Errorf("TotalMs = %d, want the time since start", got.TotalMs)
+			}
+			if tt.aiOutput != nil && !strings.Contains(*vr.AIOutputData, `"explanation"`) {
+				t.Errorf("existing keys lost: %s", *vr.AIOutputData)
+			}
+		})
+	}
+
+	if got := (models.ValidationHistory{AIOutputData: str(`{"scoring":{}}`)}).Timings(); got != nil {
+		t.Errorf("Timings() without timings = %+v, want nil", got)
+	}
+}
+
+func TestTokenUsage_RoundTrip(t *testing.T) {
+	vr := &models.ValidationResult{VenueID: 1, Score: 80}
+	vr.TokenUsage = &models.TokenUsage{PromptTokens: 1200, CompletionTokens: 80, Estimated: 1150, Truncated: []string{"description"}}
+	out := attachOutput(vr, tokenUsageOutputKey, vr.TokenUsage)
+
+	got := models.ValidationHistory{AIOutputData: &out}.TokenUsage()
+	if got == nil || got.PromptTokens != 1200 || got.CompletionTokens != 80 || got.Estimated != 1150 || len(got.Truncated) != 1 {
+		t.Fatalf("TokenUsage() = %+v, ai_output_data %s", got, out)
+	}
+	if got := (models.ValidationHistory{}).TokenUsage(); got != nil {
+		t.Errorf("TokenUsage() without output = %+v, want nil", got)
+	}
+}
+
+func TestAttachTrust_RecordsRulesAndInputs(t *testing.T) {
+	raw := `{"scoring":{"score":80},"timings":{"total_ms":5}}`
+	vr := &models.ValidationResult{VenueID: 1, Score: 80, AIOutputData: &raw}
+	a := trust.NewDefault().Assess(models.User{ID: 4, Trusted: true, Contributions: 1200}, "Berlin")
+	attachTrust(vr, &a)
+
+	var out struct {
+		Timings map[string]any   `json:"timings"`
+		Trust   trust.Assessment `json:"trust"`
+	}
+	if err := json.Unmarshal([]byte(*vr.AIOutputData), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Timings == nil {
+		t.Errorf("existing keys lost: %s", *vr.AIOutputData)
+	}
+	if out.Trust.RulesVersion != trust.DefaultVersion || out.Trust.Inputs.UserID != 4 || out.Trust.Inputs.Contributions != 1200 {
+		t.Errorf("trust = %+v", out.Trust)
+	}
+	if len(out.Trust.Adjustments) != 1 || out.Trust.Trust != a.Trust {
+		t.Errorf("trust = %+v, want %+v", out.Trust, a)
+	}
+
+	attachTrust(vr, nil) // users without an account have no assessment
+	attachTrust(nil, &a)
+}
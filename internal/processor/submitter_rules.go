@@ -0,0 +1,69 @@
+package processor
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+var mSubmitterRuleHits = metrics.Default.CounterVec("submitter_rule_matches_total", "Jobs a submitter rule applied to, by action", "action")
+
+// submitterRules caches the admin-managed submitter block and allow lists. The list is small
+// and read for every job, so it is reloaded at most once per refresh interval; edits made
+// through this instance invalidate it right away.
+type submitterRules struct {
+	store   domain.SubmitterRuleStore
+	refresh time.Duration
+
+	mu       sync.Mutex
+	rules    []models.SubmitterRule
+	loadedAt time.Time
+}
+
+// EnableSubmitterRules enforces the submitter rules in store; call before Start. Other
+// instances see edits within refresh.
+func (e *ProcessingEngine) EnableSubmitterRules(store domain.SubmitterRuleStore, refresh time.Duration) {
+	e.submitterRules = &submitterRules{store: store, refresh: refresh}
+}
+
+// InvalidateSubmitterRules makes the next job reload the submitter rules.
+func (e *ProcessingEngine) InvalidateSubmitterRules() {
+	if c := e.submitterRules; c != nil {
+		c.mu.Lock()
+		c.loadedAt = time.Time{}
+		c.mu.Unlock()
+	}
+}
+
+// submitterRule returns the strongest rule for user, or nil when none applies or the rules
+// are off. A failed reload keeps the previous list.
+func (e *ProcessingEngine) submitterRule(ctx context.Context, user *models.User) *models.SubmitterRule {
+	c := e.submitterRules
+	if c == nil || user == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if time.Since(c.loadedAt) >= c.refresh {
+		rules, err := c.store.ListSubmitterRulesCtx(ctx)
+		if err != nil {
+			log.Printf("[submitter-rules] reload failed, keeping %d rules: %v", len(c.rules), err)
+		} else {
+			c.rules = rules
+		}
+		// Also after a failure, so a database outage does not add a query to every job
+		c.loadedAt = time.Now()
+	}
+	r := models.MatchSubmitterRule(c.rules, *user)
+	if r != nil {
+		mSubmitterRuleHits.With(r.Action).Inc()
+		rc := *r
+		return &rc
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	testutil "assisted-venue-approval/internal/testing"
+	"assisted-venue-approval/internal/trust"
+)
+
+func TestRequiresManualReviewEarly_SubmitterRules(t *testing.T) {
+	rules := []models.SubmitterRule{
+		{ID: 1, Kind: models.SubmitterRuleUser, Value: "7", Action: models.SubmitterActionReject, Note: "spam"},
+		{ID: 2, Kind: models.SubmitterRuleEmailDomain, Value: "partner.org", Action: models.SubmitterActionAlwaysAVA},
+		{ID: 3, Kind: models.SubmitterRuleUser, Value: "8", Action: models.SubmitterActionManualReview},
+		{ID: 4, Kind: models.SubmitterRuleUser, Value: "9", Action: models.SubmitterActionReject},
+	}
+	store := &testutil.SubmitterRuleStore{
+		ListSubmitterRulesCtxFunc: func(context.Context) ([]models.SubmitterRule, error) { return rules, nil },
+	}
+	e := &ProcessingEngine{minUserPointsForAVA: 100}
+	e.EnableSubmitterRules(store, time.Minute)
+	calc := trust.NewDefault()
+
+	tests := []struct {
+		name       string
+		user       models.User
+		wantSkip   bool
+		wantCode   string
+		wantReject bool
+	}{
+		{"blocked member", models.User{ID: 7, Contributions: 500, Trusted: true}, true, "submitter_blocked", true},
+		{"member sent to review", models.User{ID: 8, Contributions: 500, Trusted: true}, true, "submitter_manual_review", false},
+		{"partner skips submitter checks", models.User{ID: 5, Email: "team@eu.partner.org"}, false, "", false},
+		{"block wins over partner domain", models.User{ID: 9, Email: "x@partner.org"}, true, "submitter_blocked", true},
+		{"unlisted low-contribution member", models.User{ID: 6, Email: "a@partner.org.example"}, true, "insufficient_contributions", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := calc.Assess(tt.user, "")
+			skip, reason := e.requiresManualReviewEarly(context.Background(), &models.Venue{ID: 1, Name: "Leaf"}, &tt.user, &a)
+			if skip != tt.wantSkip || reason.Code != tt.wantCode || reason.Reject != tt.wantReject {
+				t.Fatalf("got skip=%v %+v, want skip=%v code=%q reject=%v", skip, reason, tt.wantSkip, tt.wantCode, tt.wantReject)
+			}
+		})
+	}
+}
+
+func TestSubmitterRule_ReloadKeepsRulesOnError(t *testing.T) {
+	calls := 0
+	store := &testutil.SubmitterRuleStore{
+		ListSubmitterRulesCtxFunc: func(context.Context) ([]models.SubmitterRule, error) {
+			calls++
+			if calls > 1 {
+				return nil, errors.New("db down")
+			}
+			return []models.SubmitterRule{{ID: 1, Kind: models.SubmitterRuleUser, Value: "7", Action: models.SubmitterActionReject}}, nil
+		},
+	}
+	e := &ProcessingEngine{}
+	e.EnableSubmitterRules(store, time.Hour)
+	user := &models.User{ID: 7}
+
+	if r := e.submitterRule(context.Background(), user); r == nil || r.ID != 1 {
+		t.Fatalf("rule = %+v", r)
+	}
+	e.submitterRule(context.Background(), user)
+	if calls != 1 {
+		t.Fatalf("rules reloaded %d times within the refresh interval", calls)
+	}
+	e.InvalidateSubmitterRules()
+	if r := e.submitterRule(context.Background(), user); r == nil || calls != 2 {
+		t.Fatalf("after failed reload rule = %+v, %d calls", r, calls)
+	}
+}
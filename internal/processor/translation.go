@@ -0,0 +1,106 @@
+package processor
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// Translator turns venue text into English and reports the detected source language.
+// Implemented by scorer.Translator (LLM) and the translate package (DeepL, Google).
+type Translator interface {
+	Translate(ctx context.Context, text string) (translated, sourceLang string, err error)
+}
+
+const translationOutputKey = "translation"
+
+var (
+	mTranslations        = metrics.Default.Counter("translations_total", "Venue text fields translated before scoring")
+	mTranslationFailures = metrics.Default.Counter("translation_failures_total", "Venue text translations that failed (original text scored)")
+)
+
+// englishStopwords are cheap evidence that text is already English, so it is not sent out.
+var englishStopwords = map[string]bool{
+	"the": true, "and": true, "with": true, "for": true, "our": true, "are": true,
+	"we": true, "is": true, "of": true, "in": true, "options": true, "food": true,
+}
+
+// SetTranslator enables translation of AdditionalInfo and VDetails before scoring.
+// provider is recorded with each translation. nil disables it.
+func (e *ProcessingEngine) SetTranslator(t Translator, provider string) {
+	e.avaConfigMu.Lock()
+	defer e.avaConfigMu.Unlock()
+	e.translator = t
+	e.translationProvider = provider
+}
+
+// translateVenue returns the venue to score and the translations applied to it. Only the
+// returned copy carries English text; the caller's venue (and the database) keep the
+// original. A failed field is logged and scored untranslated.
+func (e *ProcessingEngine) translateVenue(ctx context.Context, venue models.Venue) (models.Venue, *models.VenueTranslation) {
+	e.avaConfigMu.RLock()
+	t, provider := e.translator, e.translationProvider
+	e.avaConfigMu.RUnlock()
+	if t == nil {
+		return venue, nil
+	}
+
+	tr := &models.VenueTranslation{Provider: provider}
+	translate := func(field, text string) *models.TextTranslation {
+		if likelyEnglish(text) {
+			return nil
+		}
+		out, lang, err := t.Translate(ctx, text)
+		if err != nil {
+			mTranslationFailures.Inc(1)
+			logf(ctx, models.LogLevelWarn, "translation of %s failed for venue %d: %v (scoring original)", field, venue.ID, err)
+			return nil
+		}
+		if lang == "en" || strings.TrimSpace(out) == "" {
+			return nil
+		}
+		mTranslations.Inc(1)
+		return &models.TextTranslation{Original: text, Translated: out, SourceLang: lang}
+	}
+
+	if venue.AdditionalInfo != nil {
+		if tt := translate("additionalinfo", *venue.AdditionalInfo); tt != nil {
+			tr.AdditionalInfo = tt
+			venue.AdditionalInfo = &tt.Translated
+		}
+	}
+	if tt := translate("vdetails", venue.VDetails); tt != nil {
+		tr.VDetails = tt
+		venue.VDetails = tt.Translated
+	}
+	if tr.AdditionalInfo == nil && tr.VDetails == nil {
+		return venue, nil
+	}
+	return venue, tr
+}
+
+// likelyEnglish reports whether text can skip translation: Latin script that is either too
+// short to matter or has at least two common English words. Anything else goes to the translator,
+// which has the final say through the detected language.
+func likelyEnglish(text string) bool {
+	// Checked first: Japanese or Chinese text has no spaces, so it reads as a single word
+	for _, r := range text {
+		if unicode.IsLetter(r) && !unicode.Is(unicode.Latin, r) {
+			return false
+		}
+	}
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) })
+	if len(words) < 3 {
+		return true
+	}
+	hits := 0
+	for _, w := range words {
+		if englishStopwords[w] {
+			hits++
+		}
+	}
+	return hits >= 2
+}
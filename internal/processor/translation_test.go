@@ -0,0 +1,72 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"assisted-venue-approval/internal/models"
+)
+
+type fakeTranslator struct {
+	calls int
+	err   error
+}
+
+func (f *fakeTranslator) Translate(_ context.Context, text string) (string, string, error) {
+	f.calls++
+	if f.err != nil {
+		return "", "", f.err
+	}
+	return "EN: " + text, "pt", nil
+}
+
+func TestLikelyEnglish(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"", true},
+		{"Vegan cafe", true},
+		{"A small cafe with vegan options and the best cakes", true},
+		{"Restaurante com opções veganas e pratos do dia", false},
+		{"ヴィーガンラーメン", false},
+		{"素食", false},
+	}
+	for _, tt := range tests {
+		if got := likelyEnglish(tt.text); got != tt.want {
+			t.Errorf("likelyEnglish(%q)=%v want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestTranslateVenue(t *testing.T) {
+	info := "Restaurante com opções veganas e pratos do dia"
+	venue := models.Venue{ID: 7, AdditionalInfo: &info, VDetails: "A small cafe with vegan options and the best cakes"}
+
+	e := &ProcessingEngine{}
+	if got, tr := e.translateVenue(context.Background(), venue); tr != nil || *got.AdditionalInfo != info {
+		t.Fatalf("disabled translator changed the venue: %+v", tr)
+	}
+
+	ft := &fakeTranslator{}
+	e.SetTranslator(ft, "deepl")
+	got, tr := e.translateVenue(context.Background(), venue)
+	if tr == nil || tr.Provider != "deepl" || tr.AdditionalInfo == nil {
+		t.Fatalf("translation=%+v", tr)
+	}
+	if tr.AdditionalInfo.Original != info || tr.AdditionalInfo.SourceLang != "pt" || *got.AdditionalInfo != "EN: "+info {
+		t.Fatalf("additionalinfo=%+v scored=%q", tr.AdditionalInfo, *got.AdditionalInfo)
+	}
+	if tr.VDetails != nil || ft.calls != 1 {
+		t.Fatalf("English vdetails should be skipped without a call: vdetails=%+v calls=%d", tr.VDetails, ft.calls)
+	}
+	if *venue.AdditionalInfo != info {
+		t.Fatalf("caller's venue was modified")
+	}
+
+	e.SetTranslator(&fakeTranslator{err: errors.New("quota")}, "google")
+	if got, tr := e.translateVenue(context.Background(), venue); tr != nil || *got.AdditionalInfo != info {
+		t.Fatalf("failed translation should score the original: %+v", tr)
+	}
+}
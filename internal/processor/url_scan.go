@@ -0,0 +1,106 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// URLScanner checks submitted links for malware and phishing. Implemented by
+// scraper.SafeBrowsing and scraper.DomainBlocklist.
+type URLScanner interface {
+	ScanURLs(ctx context.Context, urls []string) ([]models.URLThreat, error)
+}
+
+// urlThreatPrefix prefixes the score_breakdown key of a flagged venue, e.g. url_threat_malware.
+const urlThreatPrefix = "url_threat_"
+
+var mURLScans = metrics.Default.CounterVec("url_scans_total", "Venues whose links were scanned for malware and phishing, by result", "result")
+
+type urlScan struct {
+	scanners []URLScanner
+	reject   bool
+}
+
+// EnableURLScan checks the website, Facebook and Instagram links of every venue with the
+// given scanners before any Google/OpenAI call. Flagged venues are auto-rejected when reject
+// is set and sent to manual review otherwise. Call before Start.
+func (e *ProcessingEngine) EnableURLScan(reject bool, scanners ...URLScanner) {
+	if len(scanners) == 0 {
+		return
+	}
+	e.urlScan = &urlScan{scanners: scanners, reject: reject}
+}
+
+// venueURLs returns the venue's submitted links, with a scheme added where missing.
+func venueURLs(venue *models.Venue) []string {
+	var urls []string
+	for _, u := range []*string{venue.URL, venue.FBUrl, venue.InstagramUrl} {
+		if u == nil {
+			continue
+		}
+		s := strings.TrimSpace(*u)
+		if s == "" {
+			continue
+		}
+		if !strings.Contains(s, "://") {
+			s = "http://" + s
+		}
+		urls = append(urls, s)
+	}
+	return urls
+}
+
+// checkURLs runs the URL scanners on the venue's links. A scanner that fails is skipped:
+// an outage must not hold up or reject venues.
+func (e *ProcessingEngine) checkURLs(ctx context.Context, venue *models.Venue) (bool, EarlyExitReason) {
+	c := e.urlScan
+	if c == nil {
+		return false, EarlyExitReason{}
+	}
+	urls := venueURLs(venue)
+	if len(urls) == 0 {
+		return false, EarlyExitReason{}
+	}
+	var threats []models.URLThreat
+	failed := false
+	for _, s := range c.scanners {
+		found, err := s.ScanURLs(ctx, urls)
+		if err != nil {
+			logf(ctx, models.LogLevelWarn, "[url-scan] venue %d: scanner failed: %v", venue.ID, err)
+			failed = true
+			continue
+		}
+		threats = append(threats, found...)
+	}
+	switch {
+	case len(threats) > 0:
+		mURLScans.With("threat").Inc()
+		return true, URLThreatFound(threats, c.reject)
+	case failed:
+		mURLScans.With("error").Inc()
+	default:
+		mURLScans.With("clean").Inc()
+	}
+	return false, EarlyExitReason{}
+}
+
+// URLThreatFound describes flagged links; the code names the first threat type.
+func URLThreatFound(threats []models.URLThreat, reject bool) EarlyExitReason {
+	parts := make([]string, len(threats))
+	for i, t := range threats {
+		parts[i] = fmt.Sprintf("%s (%s via %s)", t.URL, t.ThreatType, t.Source)
+	}
+	desc := "Manual review: submitted link flagged as unsafe: "
+	if reject {
+		desc = "Auto-rejected: submitted link flagged as unsafe: "
+	}
+	return EarlyExitReason{
+		Code:        urlThreatPrefix + strings.ToLower(threats[0].ThreatType),
+		Description: desc + strings.Join(parts, ", "),
+		Reject:      reject,
+	}
+}
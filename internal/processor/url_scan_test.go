@@ -0,0 +1,71 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"assisted-venue-approval/internal/models"
+)
+
+type fakeURLScanner struct {
+	flag map[string]string // URL -> threat type
+	err  error
+	seen []string
+}
+
+func (f *fakeURLScanner) ScanURLs(_ context.Context, urls []string) ([]models.URLThreat, error) {
+	f.seen = urls
+	if f.err != nil {
+		return nil, f.err
+	}
+	var out []models.URLThreat
+	for _, u := range urls {
+		if tt, ok := f.flag[u]; ok {
+			out = append(out, models.URLThreat{URL: u, ThreatType: tt, Source: "fake"})
+		}
+	}
+	return out, nil
+}
+
+func TestCheckURLs(t *testing.T) {
+	str := func(s string) *string { return &s }
+	bad := &fakeURLScanner{flag: map[string]string{"http://evil.example": "MALWARE"}}
+	broken := &fakeURLScanner{err: errors.New("quota exceeded")}
+
+	tests := []struct {
+		name       string
+		reject     bool
+		venue      models.Venue
+		wantCode   string
+		wantReject bool
+	}{
+		{"no links", true, models.Venue{ID: 1}, "", false},
+		{"clean", true, models.Venue{ID: 2, URL: str("https://vegan.example")}, "", false},
+		{"malware rejected", true, models.Venue{ID: 3, URL: str("vegan.example"), FBUrl: str(" evil.example ")}, "url_threat_malware", true},
+		{"malware flagged", false, models.Venue{ID: 4, InstagramUrl: str("http://evil.example")}, "url_threat_malware", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &ProcessingEngine{}
+			e.EnableURLScan(tt.reject, broken, bad)
+			hit, reason := e.checkURLs(context.Background(), &tt.venue)
+			if hit != (tt.wantCode != "") || reason.Code != tt.wantCode || reason.Reject != tt.wantReject {
+				t.Fatalf("checkURLs = %v, %+v; want code %q reject %v", hit, reason, tt.wantCode, tt.wantReject)
+			}
+			if hit && !strings.Contains(reason.Description, "evil.example (MALWARE via fake)") {
+				t.Fatalf("description = %q", reason.Description)
+			}
+		})
+	}
+	if want := []string{"http://vegan.example", "http://evil.example"}; !slices.Equal(venueURLs(&tests[2].venue), want) {
+		t.Fatalf("venueURLs = %v, want %v", venueURLs(&tests[2].venue), want)
+	}
+
+	var off ProcessingEngine
+	if hit, _ := off.checkURLs(context.Background(), &tests[2].venue); hit {
+		t.Fatal("no scanners must not flag")
+	}
+}
@@ -0,0 +1,78 @@
+package processor
+
+import (
+	"context"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// VeganClassifier infers a venue's vegan status from its text. Implemented by scorer.VeganClassifier.
+type VeganClassifier interface {
+	ClassifyVeganStatus(ctx context.Context, venue models.Venue, src models.VeganSources) (*models.VeganStatusAssessment, error)
+}
+
+// ReviewFetcher loads the Google reviews of a place. Implemented by scraper.GoogleMapsScraper.
+type ReviewFetcher interface {
+	PlaceReviews(ctx context.Context, placeID string) ([]models.PlaceReview, error)
+}
+
+const veganOutputKey = "vegan_status"
+
+var mVeganChecks = metrics.Default.CounterVec("vegan_checks_total", "Vegan status classifications by result: agree, disagree, unknown or error", "result")
+
+// SetVeganClassifier enables the vegan status check. reviews, when not nil, adds the venue's
+// Google reviews to the sources (billed as a Places Atmosphere request). nil disables the check.
+func (e *ProcessingEngine) SetVeganClassifier(c VeganClassifier, reviews ReviewFetcher) {
+	e.avaConfigMu.Lock()
+	defer e.avaConfigMu.Unlock()
+	e.veganClassifier = c
+	e.reviewFetcher = reviews
+}
+
+// checkVeganStatus classifies the venue from its description, the website text fetched by the
+// website check and, when enabled, its Google reviews, and exposes the result to the decision
+// engine through venue.ValidationDetails.VeganStatus. Returns nil when disabled, when there is
+// no text to read or when the call fails.
+func (e *ProcessingEngine) checkVeganStatus(ctx context.Context, venue *models.Venue, wc *models.WebsiteCheck) *models.VeganStatusAssessment {
+	e.avaConfigMu.RLock()
+	classifier, fetcher := e.veganClassifier, e.reviewFetcher
+	e.avaConfigMu.RUnlock()
+
+	if classifier == nil {
+		return nil
+	}
+	src := models.VeganSources{Description: venue.VDetails}
+	if wc != nil && wc.Live && !wc.Parked {
+		src.Website = wc.Excerpt
+	}
+	if fetcher != nil && venue.GoogleData != nil && venue.GoogleData.PlaceID != "" {
+		reviews, err := fetcher.PlaceReviews(ctx, venue.GoogleData.PlaceID)
+		if err != nil {
+			logf(ctx, models.LogLevelWarn, "vegan check: reviews for venue %d: %v (continuing without reviews)", venue.ID, err)
+		}
+		src.Reviews = reviews
+	}
+	if src.Description == "" && src.Website == "" && len(src.Reviews) == 0 {
+		return nil
+	}
+
+	va, err := classifier.ClassifyVeganStatus(ctx, *venue, src)
+	if err != nil {
+		logf(ctx, models.LogLevelWarn, "vegan check failed for venue %d: %v (continuing without it)", venue.ID, err)
+		mVeganChecks.With("error").Inc()
+		return nil
+	}
+	switch {
+	case va.Status == models.VeganStatusUnknown:
+		mVeganChecks.With("unknown").Inc()
+	case va.Disagrees:
+		mVeganChecks.With("disagree").Inc()
+	default:
+		mVeganChecks.With("agree").Inc()
+	}
+	if venue.ValidationDetails != nil {
+		venue.ValidationDetails.VeganStatus = va
+	}
+	return va
+}
@@ -0,0 +1,62 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"assisted-venue-approval/internal/models"
+)
+
+type fakeVeganClassifier struct {
+	status string
+	seen   models.VeganSources
+}
+
+func (f *fakeVeganClassifier) ClassifyVeganStatus(_ context.Context, venue models.Venue, src models.VeganSources) (*models.VeganStatusAssessment, error) {
+	f.seen = src
+	submitted := models.SubmittedVeganStatus(venue)
+	return &models.VeganStatusAssessment{Status: f.status, Submitted: submitted, Disagrees: f.status != submitted, Reviews: len(src.Reviews)}, nil
+}
+
+type fakeReviewFetcher struct{ err error }
+
+func (f fakeReviewFetcher) PlaceReviews(context.Context, string) ([]models.PlaceReview, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []models.PlaceReview{{Rating: 5, Text: "All vegan menu"}}, nil
+}
+
+func TestCheckVeganStatus(t *testing.T) {
+	vc := &fakeVeganClassifier{status: models.VeganStatusVegOptions}
+	e := &ProcessingEngine{}
+	e.SetVeganClassifier(vc, fakeReviewFetcher{})
+
+	venue := &models.Venue{ID: 1, VegOnly: 1, Vegan: 1, VDetails: "Vegan kitchen",
+		GoogleData: &models.GooglePlaceData{PlaceID: "p1"}, ValidationDetails: &models.ValidationDetails{}}
+	wc := &models.WebsiteCheck{Live: true, Excerpt: "Menu: beef burger"}
+	va := e.checkVeganStatus(context.Background(), venue, wc)
+	if va == nil || !va.Disagrees || venue.ValidationDetails.VeganStatus != va {
+		t.Fatalf("assessment = %+v", va)
+	}
+	if vc.seen.Description != "Vegan kitchen" || vc.seen.Website != "Menu: beef burger" || len(vc.seen.Reviews) != 1 {
+		t.Fatalf("sources = %+v", vc.seen)
+	}
+
+	// A parked site is not read; failed review fetches leave reviews out
+	e.SetVeganClassifier(vc, fakeReviewFetcher{err: errors.New("quota exceeded")})
+	e.checkVeganStatus(context.Background(), venue, &models.WebsiteCheck{Live: true, Parked: true, Excerpt: "Buy this domain"})
+	if vc.seen.Website != "" || len(vc.seen.Reviews) != 0 {
+		t.Fatalf("sources = %+v", vc.seen)
+	}
+
+	// Nothing to read
+	if va := e.checkVeganStatus(context.Background(), &models.Venue{ID: 2}, nil); va != nil {
+		t.Fatalf("expected no assessment, got %+v", va)
+	}
+	var off ProcessingEngine
+	if va := off.checkVeganStatus(context.Background(), venue, wc); va != nil {
+		t.Fatalf("disabled check returned %+v", va)
+	}
+}
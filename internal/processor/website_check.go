@@ -0,0 +1,57 @@
+package processor
+
+import (
+	"context"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// WebsiteChecker fetches a venue website and reports evidence. Implemented by scraper.WebsiteScraper.
+type WebsiteChecker interface {
+	CheckWebsite(ctx context.Context, rawURL string) *models.WebsiteCheck
+}
+
+const (
+	websiteBreakdownKey = "website_verification"
+	websiteOutputKey    = "website_check"
+)
+
+var (
+	mWebsiteChecks = metrics.Default.Counter("website_checks_total", "Venue websites fetched for verification")
+	mWebsiteDead   = metrics.Default.Counter("website_checks_dead_total", "Venue websites that were unreachable or parked")
+)
+
+// SetWebsiteChecker enables website verification. nil disables it.
+func (e *ProcessingEngine) SetWebsiteChecker(c WebsiteChecker) {
+	e.avaConfigMu.Lock()
+	defer e.avaConfigMu.Unlock()
+	e.websiteChecker = c
+}
+
+// checkWebsite verifies the venue URL (user-submitted or filled from Google) and folds the
+// result into the score. Returns nil when disabled or the venue has no checkable website.
+func (e *ProcessingEngine) checkWebsite(ctx context.Context, venue models.Venue, vr *models.ValidationResult) *models.WebsiteCheck {
+	e.avaConfigMu.RLock()
+	checker := e.websiteChecker
+	e.avaConfigMu.RUnlock()
+
+	if checker == nil || vr == nil || venue.URL == nil || *venue.URL == "" {
+		return nil
+	}
+	wc := checker.CheckWebsite(ctx, *venue.URL)
+	if wc == nil {
+		return nil
+	}
+	mWebsiteChecks.Inc(1)
+	if !wc.Live || wc.Parked {
+		mWebsiteDead.Inc(1)
+	}
+
+	if vr.ScoreBreakdown == nil {
+		vr.ScoreBreakdown = make(map[string]int)
+	}
+	vr.ScoreBreakdown[websiteBreakdownKey] = wc.Score
+	vr.Score = clampScore(vr.Score + wc.Score)
+	return wc
+}
@@ -0,0 +1,222 @@
+package prompts
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// templateSpec describes how a prompt template is rendered and what it must ask the model for.
+// Variants (system.ja, system.bakery, ...) are checked against the spec of their base name.
+type templateSpec struct {
+	vars      []string // data keys the caller passes; any other .Field renders "<no value>"
+	required  []string // data keys the template must use
+	output    []string // JSON keys the response parser reads; the prompt must name each one
+	maxTokens int      // budget for the template's own text, before data is filled in
+}
+
+var scoringOutput = []string{"score", "notes", "breakdown", "legitimacy", "completeness", "relevance"}
+
+// templateSpecs must follow the data maps built in internal/scorer.
+var templateSpecs = map[string]templateSpec{
+	"system": {output: scoringOutput, maxTokens: 1000},
+	"unified_user": {
+		vars: []string{
+			"CombinedJSON", "VenueJSON", "AdminNote", "AdminHoldEmailNote", "GoogleStatus", "GoogleTypes",
+			"VegOnly", "Vegan", "Category", "TrustLevel", "DataPriority", "NewVenuePolicy", "IsVenueOwner",
+			"VenueType", "VeganStatus", "CategoryDisplay", "TypeMismatch",
+		},
+		required:  []string{"CombinedJSON", "TrustLevel"},
+		output:    scoringOutput,
+		maxTokens: 1500,
+	},
+	"quality_system": {
+		vars:      []string{"DescriptionGuidelines", "NameGuidelines"},
+		required:  []string{"DescriptionGuidelines", "NameGuidelines"},
+		output:    []string{"description", "name", "closed_days"},
+		maxTokens: 3000,
+	},
+	"quality_user": {
+		vars:      []string{"VenueName", "Description", "Category", "VeganStatus", "Hours", "VenuePath", "Lat", "Lng"},
+		required:  []string{"VenueName", "Description"},
+		maxTokens: 600,
+	},
+	"photo_system": {
+		vars:      []string{"VenueName", "VeganStatus"},
+		required:  []string{"VenueName"},
+		output:    []string{"food_venue", "vegan_signage", "confidence", "notes"},
+		maxTokens: 400,
+	},
+	"change_system": {
+		vars:      []string{"VenueName", "VenuePath", "VeganStatus", "EditorRole"},
+		required:  []string{"VenueName"},
+		output:    []string{"score", "fields", "notes"},
+		maxTokens: 600,
+	},
+	"vegan_system": {
+		vars:      []string{"VenueName", "VenuePath", "Category"},
+		required:  []string{"VenueName"},
+		output:    []string{"status", "confidence", "evidence", "notes"},
+		maxTokens: 700,
+	},
+}
+
+// Lint checks every loaded template against its spec and reports all problems at once,
+// naming the file to fix. Templates without a spec are not checked.
+func (m *Manager) Lint() error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := make([]string, 0, len(m.tpls))
+	for name := range m.tpls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []string
+	for _, name := range names {
+		spec, ok := templateSpecs[baseName(name)]
+		if !ok {
+			continue
+		}
+		for _, p := range lintTemplate(m.tpls[name], spec) {
+			problems = append(problems, fmt.Sprintf("%s (%s): %s", name, m.sources[name], p))
+		}
+	}
+	if len(problems) > 0 {
+		return errs.NewValidation("prompts.Lint", strings.Join(problems, "; "), nil)
+	}
+	return nil
+}
+
+func lintTemplate(tpl *template.Template, spec templateSpec) []string {
+	var problems []string
+	refs, text := inspect(tpl)
+
+	known := make(map[string]bool, len(spec.vars))
+	for _, v := range spec.vars {
+		known[v] = true
+	}
+	for _, r := range refs {
+		if !known[r] {
+			if len(spec.vars) == 0 {
+				problems = append(problems, fmt.Sprintf("uses .%s but is rendered without data", r))
+			} else {
+				problems = append(problems, fmt.Sprintf("unknown variable .%s (known: %s)", r, strings.Join(spec.vars, ", ")))
+			}
+		}
+	}
+	used := make(map[string]bool, len(refs))
+	for _, r := range refs {
+		used[r] = true
+	}
+	for _, v := range spec.required {
+		if !used[v] {
+			problems = append(problems, fmt.Sprintf("required variable .%s is not used", v))
+		}
+	}
+	for _, k := range spec.output {
+		if !strings.Contains(text, `"`+k+`"`) {
+			problems = append(problems, fmt.Sprintf("output format does not name the %q key the response parser reads", k))
+		}
+	}
+	if n := EstimateTokens(text); spec.maxTokens > 0 && n > spec.maxTokens {
+		problems = append(problems, fmt.Sprintf("about %d tokens of text, limit is %d", n, spec.maxTokens))
+	}
+	return problems
+}
+
+// inspect returns the top-level data keys a template reads and its literal text.
+// Fields inside range/with blocks are relative to a different dot and are skipped.
+func inspect(tpl *template.Template) (refs []string, text string) {
+	seen := map[string]bool{}
+	var sb strings.Builder
+	ref := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			refs = append(refs, name)
+		}
+	}
+	var walk func(n parse.Node, root bool)
+	walkBranch := func(b *parse.BranchNode, bodyRoot bool) {
+		walk(b.Pipe, true)
+		walk(b.List, bodyRoot)
+		walk(b.ElseList, true)
+	}
+	walk = func(n parse.Node, root bool) {
+		switch n := n.(type) {
+		case nil:
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, c := range n.Nodes {
+				walk(c, root)
+			}
+		case *parse.TextNode:
+			sb.Write(n.Text)
+		case *parse.ActionNode:
+			walk(n.Pipe, root)
+		case *parse.IfNode:
+			walkBranch(&n.BranchNode, root)
+		case *parse.RangeNode:
+			walkBranch(&n.BranchNode, false)
+		case *parse.WithNode:
+			walkBranch(&n.BranchNode, false)
+		case *parse.TemplateNode:
+			walk(n.Pipe, root)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for _, c := range n.Cmds {
+				walk(c, root)
+			}
+		case *parse.CommandNode:
+			for _, a := range n.Args {
+				walk(a, root)
+			}
+		case *parse.ChainNode:
+			walk(n.Node, root)
+		case *parse.FieldNode:
+			if root {
+				ref(n.Ident[0])
+			}
+		case *parse.VariableNode:
+			if len(n.Ident) > 1 && n.Ident[0] == "$" {
+				ref(n.Ident[1])
+			}
+		}
+	}
+	for _, t := range tpl.Templates() {
+		if t.Tree != nil {
+			walk(t.Tree.Root, true)
+		}
+	}
+	return refs, sb.String()
+}
+
+// EstimateTokens roughly sizes prompt text: four ASCII characters per token, and one per
+// other character, since CJK and accented text tokenize far less densely.
+func EstimateTokens(s string) int {
+	ascii, other := 0, 0
+	for _, r := range s {
+		if r < 128 {
+			ascii++
+		} else {
+			other++
+		}
+	}
+	return (ascii+3)/4 + other
+}
+
+// baseName strips locale and category suffixes: "system.bakery.ja" -> "system".
+func baseName(name string) string {
+	if i := strings.IndexByte(name, '.'); i > 0 {
+		return name[:i]
+	}
+	return name
+}
@@ -0,0 +1,105 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestEmbeddedTemplatesPassLint(t *testing.T) {
+	m, err := NewManager("")
+	if err != nil {
+		t.Fatalf("embedded templates: %v", err)
+	}
+	if err := m.Lint(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLintTemplate(t *testing.T) {
+	spec := templateSpec{
+		vars:      []string{"Name", "Items"},
+		required:  []string{"Name"},
+		output:    []string{"score"},
+		maxTokens: 50,
+	}
+	tests := []struct {
+		name, src string
+		want      []string // substrings of expected problems; none means clean
+	}{
+		{"clean", `{{.Name}} {{range .Items}}{{.Label}}{{end}} {"score": X}`, nil},
+		{"root var in range", `{{.Name}} {{range .Items}}{{$.Nmae}}{{end}} {"score": X}`, []string{"unknown variable .Nmae"}},
+		{"unknown in if", `{{if .Nmae}}{{.Name}}{{end}} {"score": X}`, []string{"unknown variable .Nmae"}},
+		{"missing required", `{"score": X}`, []string{"required variable .Name"}},
+		{"missing output key", `{{.Name}} {"notes": ""}`, []string{`"score" key`}},
+		{"too long", `{{.Name}} {"score": X}` + strings.Repeat("word ", 60), []string{"limit is 50"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpl := template.Must(template.New("t").Parse(tt.src))
+			got := strings.Join(lintTemplate(tpl, spec), "; ")
+			if len(tt.want) == 0 && got != "" {
+				t.Fatalf("unexpected problems: %s", got)
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("problems %q missing %q", got, w)
+				}
+			}
+		})
+	}
+}
+
+func TestLintWithoutData(t *testing.T) {
+	tpl := template.Must(template.New("system").Parse(`{{.Trust}} {"score": 0}`))
+	got := strings.Join(lintTemplate(tpl, templateSpec{}), "; ")
+	if !strings.Contains(got, "rendered without data") {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestNewManagerFailsOnBadOverride(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "unified_user.txt.tmpl")
+	if err := os.WriteFile(path, []byte(`Trust {{.TrustLevl}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := NewManager(dir)
+	if err == nil {
+		t.Fatal("expected lint error")
+	}
+	for _, w := range []string{path, "unknown variable .TrustLevl", "required variable .CombinedJSON", `"score" key`} {
+		if !strings.Contains(err.Error(), w) {
+			t.Errorf("error %q missing %q", err, w)
+		}
+	}
+}
+
+func TestReloadKeepsTemplatesOnLintError(t *testing.T) {
+	dir := t.TempDir()
+	m, err := NewManager(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "system.bakery.txt.tmpl"), []byte("no output format"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Reload(dir); err == nil {
+		t.Fatal("expected reload to fail")
+	}
+	out, err := m.Render("system.bakery", nil)
+	if err != nil || strings.Contains(out, "no output format") {
+		t.Fatalf("previous template replaced: %q, %v", out, err)
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	if got := EstimateTokens("abcdefgh"); got != 2 {
+		t.Errorf("ascii: got %d want 2", got)
+	}
+	if got := EstimateTokens("ヴィーガン"); got != 5 {
+		t.Errorf("kana: got %d want 5", got)
+	}
+}
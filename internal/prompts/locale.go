@@ -0,0 +1,105 @@
+package prompts
+
+import (
+	"strings"
+	"unicode"
+
+	"assisted-venue-approval/pkg/geography"
+)
+
+// countryLocales maps path countries to the language their submissions are usually written in.
+// Only languages we may ship prompt variants for are listed; anything else uses the default prompts.
+var countryLocales = map[string]string{
+	"japan":       "ja",
+	"brazil":      "pt",
+	"portugal":    "pt",
+	"spain":       "es",
+	"mexico":      "es",
+	"argentina":   "es",
+	"colombia":    "es",
+	"chile":       "es",
+	"peru":        "es",
+	"germany":     "de",
+	"austria":     "de",
+	"france":      "fr",
+	"italy":       "it",
+	"china":       "zh",
+	"taiwan":      "zh",
+	"south korea": "ko",
+}
+
+// latinStopwords are frequent short words used to tell Latin-script languages apart.
+var latinStopwords = map[string][]string{
+	"pt": {"não", "com", "uma", "para", "são", "também", "comida", "opções", "vegetariano"},
+	"es": {"con", "una", "para", "también", "comida", "opciones", "los", "las", "vegetariana"},
+	"de": {"und", "mit", "der", "die", "das", "nicht", "auch", "ein", "vegetarisch"},
+	"fr": {"avec", "les", "des", "une", "pour", "aussi", "est", "végétarien", "cuisine"},
+	"it": {"con", "per", "anche", "della", "piatti", "cucina", "vegetariano", "gli", "sono"},
+}
+
+// minStopwordHits is how many stopwords must match before Latin text counts as a language.
+const minStopwordHits = 2
+
+// DetectLocale picks a prompt language for a venue: the country in its path first, then
+// the script of its text, then common words. Empty means "use the default prompts".
+func DetectLocale(path, text string) string {
+	if c := geography.CountryFromPath(path); c != "" {
+		if l, ok := countryLocales[strings.ReplaceAll(c, "_", " ")]; ok {
+			return l
+		}
+	}
+	return detectTextLocale(text)
+}
+
+func detectTextLocale(text string) string {
+	var kana, hangul, han int
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana++
+		case unicode.Is(unicode.Hangul, r):
+			hangul++
+		case unicode.Is(unicode.Han, r):
+			han++
+		}
+	}
+	// Japanese mixes kanji with kana, so any kana decides it before Han does
+	switch {
+	case kana > 0:
+		return "ja"
+	case hangul > 0:
+		return "ko"
+	case han > 0:
+		return "zh"
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) })
+	seen := make(map[string]bool, len(words))
+	for _, w := range words {
+		seen[w] = true
+	}
+	best, bestHits := "", 0
+	for _, l := range []string{"pt", "es", "de", "fr", "it"} {
+		hits := 0
+		for _, w := range latinStopwords[l] {
+			if seen[w] {
+				hits++
+			}
+		}
+		if hits > bestHits {
+			best, bestHits = l, hits
+		}
+	}
+	if bestHits < minStopwordHits {
+		return ""
+	}
+	return best
+}
+
+// VariantName returns the template name for a locale, e.g. ("system", "ja") -> "system.ja".
+func VariantName(name, locale string) string {
+	if locale == "" {
+		return name
+	}
+	return name + "." + locale
+}
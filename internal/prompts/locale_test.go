@@ -0,0 +1,52 @@
+package prompts
+
+import "testing"
+
+func TestDetectLocale(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		text string
+		want string
+	}{
+		{"path country", "asia|japan|tokyo", "Green Cafe", "ja"},
+		{"brazil path", "south_america|brazil|sao_paulo", "", "pt"},
+		{"path wins over text", "europe|germany|berlin", "Restaurante com opções veganas", "de"},
+		{"kana text", "", "ヴィーガン ラーメン", "ja"},
+		{"kanji only is chinese", "", "素食餐厅", "zh"},
+		{"hangul", "", "비건 식당", "ko"},
+		{"portuguese words", "north_america|usa", "Comida caseira com opções veganas para todos", "pt"},
+		{"spanish words", "", "Comida casera con opciones veganas para todos", "es"},
+		{"english stays default", "europe|england|london", "Vegan cafe with great cakes", ""},
+		{"single stopword is not enough", "", "Pasta con tomato", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectLocale(tt.path, tt.text); got != tt.want {
+				t.Fatalf("DetectLocale(%q, %q)=%q want %q", tt.path, tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManagerVariant(t *testing.T) {
+	m, err := NewManager("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name, locale, want string
+	}{
+		{"system", "ja", "system.ja"},
+		{"system", "pt", "system.pt"},
+		{"system", "fr", "system"}, // no variant shipped
+		{"unified_user", "ja", "unified_user"},
+		{"system", "", "system"},
+	}
+	for _, tt := range tests {
+		got := m.Variant(tt.name, tt.locale)
+		if got != tt.want {
+			t.Errorf("Variant(%q, %q)=%q want %q", tt.name, tt.locale, got, tt.want)
+		}
+	}
+}
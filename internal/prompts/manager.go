@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"text/template"
@@ -18,15 +19,25 @@ var ConfigFilesFS fs.FS
 
 // Manager loads, compiles and renders prompt templates.
 // Templates are compiled once at startup for performance.
-// Simple and extensible: variants can be added as new files (e.g., unified_user@v2.tmpl).
+// Simple and extensible: locale variants are name.<locale> files (e.g., system.ja.txt.tmpl),
+// category variants name.<category> files (e.g., system.bakery.txt.tmpl); see Variant and
+// Resolve. A file name may end in @<version> (e.g., system.bakery@v2.txt.tmpl): the template
+// is still looked up without it, and Version reports it for prompt_version.
 type Manager struct {
-	mu   sync.RWMutex
-	tpls map[string]*template.Template
+	mu       sync.RWMutex
+	tpls     map[string]*template.Template
+	versions map[string]string // template name -> version, when the file declares one
+	sources  map[string]string // template name -> file it was loaded from, for lint errors
 }
 
 // NewManager loads templates from an optional external directory first, then fills missing ones from embedded templates.
+// It fails when a loaded template does not pass Lint, so a bad override stops startup instead of scoring.
 func NewManager(templatesDir string) (*Manager, error) {
-	m := &Manager{tpls: make(map[string]*template.Template)}
+	m := &Manager{
+		tpls:     make(map[string]*template.Template),
+		versions: make(map[string]string),
+		sources:  make(map[string]string),
+	}
 
 	// 1) Try external directory (optional)
 	if td := strings.TrimSpace(templatesDir); td != "" {
@@ -36,19 +47,34 @@ func NewManager(templatesDir string) (*Manager, error) {
 		} else if !fi.IsDir() {
 			log.Printf("prompts: path '%s' is not a directory (using embedded)", td)
 		} else {
-			for _, name := range []string{"system", "unified_user"} {
+			var names []string
+			for _, base := range []string{"system", "unified_user"} {
+				names = append(names, base)
+				// Locale, category and versioned variants of the same template,
+				// e.g. system.pt.txt.tmpl, system.bakery@v2.txt.tmpl
+				matches, _ := filepath.Glob(filepath.Join(td, base+"[.@]*"+".txt.tmpl"))
+				for _, p := range matches {
+					names = append(names, strings.TrimSuffix(filepath.Base(p), ".txt.tmpl"))
+				}
+			}
+			for _, name := range names {
 				path := filepath.Join(td, PathFor(name))
 				b, rerr := os.ReadFile(path)
 				if rerr != nil {
 					log.Printf("prompts: external template not loaded '%s': %v (will try embedded)", path, rerr)
 					continue
 				}
-				tpl, perr := template.New(name).Parse(string(b))
+				logical, version := splitVersion(name)
+				if _, dup := m.tpls[logical]; dup {
+					log.Printf("prompts: external template '%s' ignored, another version of '%s' is loaded", path, logical)
+					continue
+				}
+				tpl, perr := template.New(logical).Parse(string(b))
 				if perr != nil {
 					log.Printf("prompts: parse error in external template '%s': %v (will use embedded fallback)", path, perr)
 					continue
 				}
-				m.tpls[name] = tpl
+				m.add(logical, version, path, tpl)
 				log.Printf("prompts: loaded external template '%s' from %s", name, path)
 			}
 		}
@@ -71,7 +97,7 @@ func NewManager(templatesDir string) (*Manager, error) {
 			log.Printf("prompts: read embedded template %s failed: %v", p, rerr)
 			return nil
 		}
-		name := strings.TrimSuffix(filepath.Base(p), ".txt.tmpl")
+		name, version := splitVersion(strings.TrimSuffix(filepath.Base(p), ".txt.tmpl"))
 		if _, exists := m.tpls[name]; exists {
 			return nil // external already present
 		}
@@ -80,14 +106,105 @@ func NewManager(templatesDir string) (*Manager, error) {
 			log.Printf("prompts: parse embedded template %s failed: %v", p, perr)
 			return nil
 		}
-		m.tpls[name] = tpl
+		m.add(name, version, "embedded:"+p, tpl)
 		log.Printf("prompts: loaded embedded template '%s'", name)
 		return nil
 	})
 
+	if err := m.Lint(); err != nil {
+		return nil, err
+	}
 	return m, nil
 }
 
+// Reload loads the templates again, e.g. after files in the templates directory changed.
+// The current templates stay in use when the new set fails to load or lint.
+func (m *Manager) Reload(templatesDir string) error {
+	fresh, err := NewManager(templatesDir)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	m.tpls, m.versions, m.sources = fresh.tpls, fresh.versions, fresh.sources
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *Manager) add(name, version, source string, tpl *template.Template) {
+	m.tpls[name] = tpl
+	m.sources[name] = source
+	if version != "" {
+		m.versions[name] = version
+	}
+}
+
+func (m *Manager) has(name string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.tpls[name]
+	return ok
+}
+
+// Variant returns the locale variant of a template (e.g. "system.ja") when one is loaded,
+// otherwise the default name, so callers can always render the result.
+func (m *Manager) Variant(name, locale string) string {
+	if locale == "" {
+		return name
+	}
+	if v := VariantName(name, locale); m.has(v) {
+		return v
+	}
+	return name
+}
+
+// Resolve returns the most specific loaded template for a venue category (a display name
+// such as "B&B/Hotel", see CategoryKey) and locale. It tries name.<category>.<locale>,
+// name.<category>, name.<locale> and finally name.
+func (m *Manager) Resolve(name, category, locale string) string {
+	if key := CategoryKey(category); key != "" {
+		byCategory := VariantName(name, key)
+		if locale != "" {
+			if v := VariantName(byCategory, locale); m.has(v) {
+				return v
+			}
+		}
+		if m.has(byCategory) {
+			return byCategory
+		}
+	}
+	return m.Variant(name, locale)
+}
+
+// Version returns name with the version its file declares, e.g. "system.bakery@v2";
+// templates without one are v1.
+func (m *Manager) Version(name string) string {
+	m.mu.RLock()
+	v := m.versions[name]
+	m.mu.RUnlock()
+	if v == "" {
+		v = "v1"
+	}
+	return name + "@" + v
+}
+
+// Versions returns Version of base and of each loaded variant of it (base.<suffix>), sorted.
+func (m *Manager) Versions(base string) []string {
+	m.mu.RLock()
+	var names []string
+	for name := range m.tpls {
+		if name == base || strings.HasPrefix(name, base+".") {
+			names = append(names, name)
+		}
+	}
+	m.mu.RUnlock()
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		out = append(out, m.Version(name))
+	}
+	sort.Strings(out)
+	return out
+}
+
 // Render executes a named template with data and returns the result string.
 func (m *Manager) Render(name string, data any) (string, error) {
 	m.mu.RLock()
@@ -150,3 +267,31 @@ func LoadGuidelines(descriptionPath, namePath string) (description string, name
 	log.Printf("prompts: loaded guidelines - description: %d bytes, name: %d bytes", len(descBytes), len(nameBytes))
 	return string(descBytes), string(nameBytes), nil
 }
+
+// CategoryKey turns a category display name into the suffix of its template variants:
+// lower case, with every run of other characters replaced by "_" ("B&B/Hotel" -> "b_b_hotel").
+func CategoryKey(category string) string {
+	var b strings.Builder
+	sep := false
+	for _, r := range strings.ToLower(strings.TrimSpace(category)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if sep && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+			sep = false
+			continue
+		}
+		sep = true
+	}
+	return b.String()
+}
+
+// splitVersion splits a template file name into its lookup name and declared version:
+// "system.bakery@v2" -> ("system.bakery", "v2").
+func splitVersion(name string) (string, string) {
+	if i := strings.LastIndexByte(name, '@'); i > 0 {
+		return name[:i], name[i+1:]
+	}
+	return name, ""
+}
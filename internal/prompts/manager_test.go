@@ -0,0 +1,73 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestCategoryKey(t *testing.T) {
+	tests := map[string]string{
+		"Bakery":            "bakery",
+		"B&B/Hotel":         "b_b_hotel",
+		"Health Food Store": "health_food_store",
+		" Market/Co-op ":    "market_co_op",
+		"":                  "",
+	}
+	for in, want := range tests {
+		if got := CategoryKey(in); got != want {
+			t.Errorf("CategoryKey(%q)=%q want %q", in, got, want)
+		}
+	}
+}
+
+func TestManagerResolve(t *testing.T) {
+	m, err := NewManager("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name, category, locale, want string
+	}{
+		{"system", "Bakery", "", "system.bakery"},
+		{"system", "B&B/Hotel", "ja", "system.b_b_hotel"}, // no ja hotel variant, category wins
+		{"system", "Restaurant", "ja", "system.ja"},
+		{"system", "Restaurant", "", "system"},
+		{"unified_user", "Bakery", "pt", "unified_user"},
+	}
+	for _, tt := range tests {
+		if got := m.Resolve(tt.name, tt.category, tt.locale); got != tt.want {
+			t.Errorf("Resolve(%q, %q, %q)=%q want %q", tt.name, tt.category, tt.locale, got, tt.want)
+		}
+	}
+}
+
+func TestManagerVersionFromFileName(t *testing.T) {
+	const bakeryV2 = `bakery v2 {"score": X, "notes": "", "breakdown": {"legitimacy": X, "completeness": X, "relevance": X}}`
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "system.bakery@v2.txt.tmpl"), []byte(bakeryV2), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := NewManager(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := m.Resolve("system", "Bakery", ""); got != "system.bakery" {
+		t.Fatalf("Resolve=%q want system.bakery", got)
+	}
+	if got := m.Version("system.bakery"); got != "system.bakery@v2" {
+		t.Errorf("Version=%q want system.bakery@v2", got)
+	}
+	if got := m.Version("system"); got != "system@v1" {
+		t.Errorf("Version=%q want system@v1", got)
+	}
+	versions := m.Versions("system")
+	if !slices.Contains(versions, "system@v1") || !slices.Contains(versions, "system.bakery@v2") || slices.Contains(versions, "unified_user@v1") {
+		t.Errorf("Versions(system)=%v", versions)
+	}
+	out, err := m.Render("system.bakery", nil)
+	if err != nil || out != bakeryV2 {
+		t.Errorf("Render=%q, %v; want external v2 template", out, err)
+	}
+}
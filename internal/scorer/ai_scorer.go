@@ -5,6 +5,7 @@ import (
 	"crypto/md5"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -209,10 +210,11 @@ type AIScorer struct {
 	pm          *prompts.Manager
 	tc          *trust.Calculator
 	timeout     time.Duration
+	budget      int // context budget in tokens, see SetContextBudget
 }
 
 // generatePromptVersion builds a compact version string based on template names used.
-// If name has no explicit @version suffix, we treat it as v1.
+// Versions come from the template files (e.g. system.bakery@v2.txt.tmpl); without one we treat it as v1.
 func (s *AIScorer) generatePromptVersion(systemName, userName string) string {
 	mk := func(n string) string {
 		if n == "" {
@@ -221,11 +223,23 @@ func (s *AIScorer) generatePromptVersion(systemName, userName string) string {
 		if strings.Contains(n, "@") {
 			return n
 		}
+		if s.pm != nil {
+			return s.pm.Version(n)
+		}
 		return n + "@v1"
 	}
 	return mk(systemName) + "+" + mk(userName)
 }
 
+// PromptVersions lists the versions prompt_version can name for the scoring templates now
+// loaded: the system and user templates with their category and locale variants.
+func (s *AIScorer) PromptVersions() (system, user []string) {
+	if s.pm == nil {
+		return []string{"system@v1"}, []string{"unified_user@v1"}
+	}
+	return s.pm.Versions("system"), s.pm.Versions("unified_user")
+}
+
 // metrics
 var (
 	mScoringDuration = metrics.Default.Histogram("venue_scoring_duration_seconds", "AI scoring duration (seconds)", []float64{0.1, 0.25, 0.5, 1, 2, 5, 10, 20, 60})
@@ -252,7 +266,7 @@ func NewAIScorerWithTimeout(apiKey string, timeout time.Duration) *AIScorer {
 		fmt.Printf("prompts: init failed: %v\n", err)
 	}
 	return &AIScorer{
-		client: openai.NewClient(apiKey),
+		client: newOpenAIClient(apiKey),
 		costTracker: &CostTracker{
 			startTime: time.Now(),
 		},
@@ -261,6 +275,7 @@ func NewAIScorerWithTimeout(apiKey string, timeout time.Duration) *AIScorer {
 		pm:      pm,
 		tc:      trust.NewDefault(),
 		timeout: timeout,
+		budget:  constants.AIScorerContextBudget,
 	}
 }
 
@@ -277,13 +292,14 @@ func NewAIScorerWithTimeoutAndPrompts(apiKey string, timeout time.Duration, pm *
 		SlowCallRate:      constants.OpenAICircuitSlowCallRate,
 	}, nil)
 	return &AIScorer{
-		client:      openai.NewClient(apiKey),
+		client:      newOpenAIClient(apiKey),
 		costTracker: &CostTracker{startTime: time.Now()},
 		cache:       NewVenueCache(),
 		cb:          cb,
 		pm:          pm,
 		tc:          trust.NewDefault(),
 		timeout:     timeout,
+		budget:      constants.AIScorerContextBudget,
 	}
 }
 
@@ -303,22 +319,44 @@ func (s *AIScorer) ScoreVenue(ctx context.Context, venue models.Venue, user mode
 	// Check cache first to avoid duplicate API calls
 	cacheKey := s.cache.generateKey(venue)
 
-	// Determine if this is a venue owner submission
-	isVenueOwner := user.IsVenueOwner || venue.Source == 1 // Adjust source value as needed
-
-	// Update user object with venue owner status for trust calculation
-	if isVenueOwner {
-		user.IsVenueOwner = true
-	}
-
 	// Include submitter trust/user in cache key to avoid cross-user cache collisions
-	assessment := s.tc.Assess(user, venue.Location)
-	trustLevel := assessment.Trust
+	user, trustLevel := s.assessSubmitter(venue, user)
 	cacheKey = fmt.Sprintf("%s|trust=%.2f|uid=%d", cacheKey, trustLevel, user.ID)
 	if cached, found := s.cache.Get(cacheKey); found {
+		cached.TokenUsage = nil // no tokens spent this time
 		return &cached, nil
 	}
 
+	if result := s.precheck(venue, user, trustLevel); result != nil {
+		return result, nil
+	}
+
+	// Unified scoring regardless of Google data presence
+	t := mScoringDuration.Start()
+	result, err := s.scoreUnifiedVenue(ctx, venue, user, trustLevel)
+	t.Observe()
+	if err != nil {
+		return nil, errs.NewExternal("scorer.ScoreVenue", "openai", "AI scoring failed", err)
+	}
+
+	// Cache the result
+	s.cache.Set(cacheKey, *result)
+
+	return result, nil
+}
+
+// assessSubmitter marks venue owner submissions on user and returns the submitter's trust level.
+func (s *AIScorer) assessSubmitter(venue models.Venue, user models.User) (models.User, float64) {
+	// Determine if this is a venue owner submission
+	if user.IsVenueOwner || venue.Source == 1 { // Adjust source value as needed
+		user.IsVenueOwner = true
+	}
+	assessment := s.tc.Assess(user, venue.Location)
+	return user, assessment.Trust
+}
+
+// precheck returns the manual review result for venues that are not sent to the model, or nil.
+func (s *AIScorer) precheck(venue models.Venue, user models.User, trustLevel float64) *models.ValidationResult {
 	// Centralized manual review checks (admin notes, region restrictions)
 	if skip, reason := models.ShouldRequireManualReview(venue); skip {
 		key := "manual_review"
@@ -335,7 +373,7 @@ func (s *AIScorer) ScoreVenue(ctx context.Context, venue models.Venue, user mode
 			Notes:          reason,
 			ScoreBreakdown: map[string]int{key: 0},
 			PromptVersion:  &pv,
-		}, nil
+		}
 	}
 
 	// Check location mismatch BEFORE AI scoring
@@ -348,42 +386,50 @@ func (s *AIScorer) ScoreVenue(ctx context.Context, venue models.Venue, user mode
 			Notes:          reason,
 			ScoreBreakdown: map[string]int{"location_mismatch": 0},
 			PromptVersion:  &pv,
-		}, nil
-	}
-
-	// Unified scoring regardless of Google data presence
-	t := mScoringDuration.Start()
-	result, err := s.scoreUnifiedVenue(ctx, venue, user, trustLevel)
-	t.Observe()
-	if err != nil {
-		return nil, errs.NewExternal("scorer.ScoreVenue", "openai", "AI scoring failed", err)
+		}
 	}
-
-	// Cache the result
-	s.cache.Set(cacheKey, *result)
-
-	return result, nil
+	return nil
 }
 
-// scoreUnifiedVenue uses a single prompt for all venues and enforces JSON response
-func (s *AIScorer) scoreUnifiedVenue(ctx context.Context, venue models.Venue, user models.User, trustLevel float64) (*models.ValidationResult, error) {
-	userName := "unified_user"
-	systemName := "system"
-	userPrompt := s.buildUnifiedPrompt(venue, user, trustLevel)
-	sysPrompt := s.getSystemPrompt()
+// scoringRequest builds the unified scoring call for venue. When a prompt template is missing
+// no call is made and the manual review result is returned instead.
+func (s *AIScorer) scoringRequest(venue models.Venue, user models.User, trustLevel float64) (openai.ChatCompletionRequest, string, *models.TokenUsage, *models.ValidationResult) {
+	userName, systemName := s.promptNames(venue)
+	sysPrompt := s.getSystemPrompt(systemName)
+	userPrompt, usage := s.fitUserPrompt(userName, sysPrompt, venue, user, trustLevel)
 	pv := s.generatePromptVersion(systemName, userName)
 
 	// If either prompt is missing/empty, skip API call and require manual review
 	if strings.TrimSpace(userPrompt) == "" || strings.TrimSpace(sysPrompt) == "" {
 		pvMissing := "missing_templates@v1"
-		return &models.ValidationResult{
+		return openai.ChatCompletionRequest{}, pvMissing, nil, &models.ValidationResult{
 			VenueID:        venue.ID,
 			Score:          0,
 			Status:         "manual_review",
 			Notes:          "Missing prompt templates - manual review required",
 			ScoreBreakdown: map[string]int{"missing_prompts": 0},
 			PromptVersion:  &pvMissing,
-		}, nil
+		}
+	}
+
+	req := openai.ChatCompletionRequest{
+		Model: openai.GPT4oMini,
+		Messages: []openai.ChatCompletionMessage{
+			{Role: openai.ChatMessageRoleSystem, Content: sysPrompt},
+			{Role: openai.ChatMessageRoleUser, Content: userPrompt},
+		},
+		Temperature:    0.1,
+		MaxTokens:      scoringMaxTokens,
+		ResponseFormat: &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject},
+	}
+	return req, pv, usage, nil
+}
+
+// scoreUnifiedVenue uses a single prompt for all venues and enforces JSON response
+func (s *AIScorer) scoreUnifiedVenue(ctx context.Context, venue models.Venue, user models.User, trustLevel float64) (*models.ValidationResult, error) {
+	opReq, pv, usage, skipped := s.scoringRequest(venue, user, trustLevel)
+	if skipped != nil {
+		return skipped, nil
 	}
 
 	// Add per-request timeout for OpenAI call (configurable)
@@ -397,18 +443,9 @@ func (s *AIScorer) scoreUnifiedVenue(ctx context.Context, venue models.Venue, us
 	}
 
 	var resp openai.ChatCompletionResponse
-	opReq := openai.ChatCompletionRequest{
-		Model: openai.GPT4oMini,
-		Messages: []openai.ChatCompletionMessage{
-			{Role: openai.ChatMessageRoleSystem, Content: sysPrompt},
-			{Role: openai.ChatMessageRoleUser, Content: userPrompt},
-		},
-		Temperature:    0.1,
-		MaxTokens:      250,
-		ResponseFormat: &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject},
-	}
-	err := s.cb.Do(ctx, func(ctx context.Context) error {
-		r, e := s.client.CreateChatCompletion(ctx, opReq)
+	raCtx, retryAfter := withRetryAfter(ctx)
+	err := s.cb.Do(raCtx, func(ctx context.Context) error {
+		r, e := s.createChatCompletion(ctx, opReq)
 		if e != nil {
 			return e
 		}
@@ -419,6 +456,13 @@ func (s *AIScorer) scoreUnifiedVenue(ctx context.Context, venue models.Venue, us
 		return cause
 	})
 	if err != nil {
+		// Transient failures go back to the engine, which retries them per its policy; an
+		// exhausted quota goes back too, a fallback score would only hide it
+		if !errors.Is(err, circuit.ErrOpen) {
+			if cerr := classifyOpenAIError("scorer.ScoreVenue", err, *retryAfter); errs.Classify(cerr).Transient() || errs.Is(cerr, errs.ErrBudget) {
+				return nil, cerr
+			}
+		}
 		// Fallback: conservative manual review result
 		fb := models.ValidationResult{
 			VenueID:        venue.ID,
@@ -428,26 +472,96 @@ func (s *AIScorer) scoreUnifiedVenue(ctx context.Context, venue models.Venue, us
 			ScoreBreakdown: map[string]int{"legitimacy": 15, "completeness": 15, "relevance": 20},
 		}
 		fb.PromptVersion = &pv
+		fb.TokenUsage = usage
 		return &fb, nil
 	}
 
 	// Track API usage
 	s.costTracker.AddUsage(resp.Usage.PromptTokens, resp.Usage.CompletionTokens)
+	recordUsage(opReq.Model, pv, callTypeScoring, resp.Usage)
+	return s.resultFromReply(ctx, venue.ID, opReq, pv, usage, resp), nil
+}
 
-	// Parse the structured response
-	result, perr := s.parseStructuredResponse(resp.Choices[0].Message.Content, venue.ID)
-	if perr != nil {
-		// Fallback parsing if structured parsing fails
-		fallback := s.parseResponseFallback(resp.Choices[0].Message.Content, venue.ID)
-		fallback.PromptVersion = &pv
-		return &fallback, nil
+// resultFromReply turns the model's reply to a scoring request into the venue's result. A reply
+// that does not match the scoring schema gets one repair attempt, then goes to manual review.
+func (s *AIScorer) resultFromReply(ctx context.Context, venueID int64, req openai.ChatCompletionRequest, pv string, usage *models.TokenUsage, resp openai.ChatCompletionResponse) *models.ValidationResult {
+	addTokens(usage, resp.Usage)
+	chat := func(ctx context.Context, req openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+		r, err := s.chat(ctx, req)
+		if err == nil {
+			addTokens(usage, r.Usage)
+		}
+		return r, err
+	}
+
+	content := ""
+	if len(resp.Choices) > 0 {
+		content = resp.Choices[0].Message.Content
+	}
+	// Validate against the scoring schema; one repair attempt, then manual review
+	content, verr := enforceSchema(ctx, chat, req, content, scoringSchema, pv, callTypeScoring)
+	var result models.ValidationResult
+	if verr == nil {
+		result, verr = s.parseStructuredResponse(content, venueID)
+	}
+	if verr != nil {
+		fb := models.ValidationResult{
+			VenueID:        venueID,
+			Score:          50,
+			Status:         "manual_review",
+			Notes:          "AI output invalid - manual review",
+			ScoreBreakdown: map[string]int{"legitimacy": 15, "completeness": 15, "relevance": 20},
+			AIOutputData:   &content,
+			TokenUsage:     usage,
+		}
+		fb.PromptVersion = &pv
+		return &fb
 	}
 	result.PromptVersion = &pv
-	return &result, nil
+	result.TokenUsage = usage
+	return &result
+}
+
+// chat sends a follow-up completion (e.g. a repair request) through the breaker and tracks its usage.
+func (s *AIScorer) chat(ctx context.Context, req openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+	var resp openai.ChatCompletionResponse
+	err := s.cb.Do(ctx, func(ctx context.Context) error {
+		r, e := s.client.CreateChatCompletion(ctx, req)
+		if e != nil {
+			return e
+		}
+		resp = r
+		return nil
+	}, func(ctx context.Context, cause error) error {
+		return cause
+	})
+	if err == nil {
+		s.costTracker.AddUsage(resp.Usage.PromptTokens, resp.Usage.CompletionTokens)
+	}
+	return resp, err
+}
+
+// promptNames picks the user and system templates for the venue's category and locale, falling
+// back to the defaults when no variant is loaded. The chosen names end up in prompt_version.
+func (s *AIScorer) promptNames(venue models.Venue) (userName, systemName string) {
+	userName, systemName = "unified_user", "system"
+	if s.pm == nil {
+		return userName, systemName
+	}
+	path, text := "", venue.Name
+	if venue.Path != nil {
+		path = *venue.Path
+	}
+	if venue.AdditionalInfo != nil {
+		text += " " + *venue.AdditionalInfo
+	}
+	locale := prompts.DetectLocale(path, text)
+	category := models.VenueCategoryName(venue.Category)
+	return s.pm.Resolve(userName, category, locale), s.pm.Resolve(systemName, category, locale)
 }
 
 // buildUnifiedPrompt creates a single prompt using centralized combined venue info
-func (s *AIScorer) buildUnifiedPrompt(venue models.Venue, user models.User, trustLevel float64) string {
+func (s *AIScorer) buildUnifiedPrompt(tplName string, venue models.Venue, user models.User, trustLevel float64) string {
 	// Raw venue fields (still useful for context JSON)
 	phone := ""
 	if venue.Phone != nil {
@@ -559,10 +673,10 @@ func (s *AIScorer) buildUnifiedPrompt(venue models.Venue, user models.User, trus
 			"CategoryDisplay": ci.Category,
 			"TypeMismatch":    ci.TypeMismatch,
 		}
-		if out, err := s.pm.Render("unified_user", data); err == nil {
+		if out, err := s.pm.Render(tplName, data); err == nil {
 			return out
 		} else {
-			fmt.Printf("prompts: render unified_user failed: %v\n", err)
+			fmt.Printf("prompts: render %s failed: %v\n", tplName, err)
 		}
 	}
 	return ""
@@ -575,12 +689,12 @@ func escapeForPrompt(s string) string {
 
 // Optimized prompt functions for cost efficiency
 
-func (s *AIScorer) getSystemPrompt() string {
+func (s *AIScorer) getSystemPrompt(tplName string) string {
 	if s.pm != nil {
-		if out, err := s.pm.Render("system", nil); err == nil {
+		if out, err := s.pm.Render(tplName, nil); err == nil {
 			return out
 		} else {
-			fmt.Printf("prompts: render system failed: %v\n", err)
+			fmt.Printf("prompts: render %s failed: %v\n", tplName, err)
 		}
 	}
 	return ""
@@ -623,38 +737,6 @@ func (s *AIScorer) parseStructuredResponse(response string, venueID int64) (mode
 	}, nil
 }
 
-func (s *AIScorer) parseResponseFallback(response string, venueID int64) models.ValidationResult {
-	// Extract score using regex as fallback
-	scoreRegex := regexp.MustCompile(`"?score"?:\s*(\d+)`)
-	matches := scoreRegex.FindStringSubmatch(response)
-
-	score := 50 // Default to manual review
-	if len(matches) > 1 {
-		if parsedScore, err := strconv.Atoi(matches[1]); err == nil && parsedScore >= 0 && parsedScore <= 100 {
-			score = parsedScore
-		}
-	}
-
-	// Determine status
-	status := "manual_review"
-	if score >= 85 {
-		status = "approved"
-	} else if score < 50 {
-		status = "rejected"
-	}
-
-	return models.ValidationResult{
-		VenueID:      venueID,
-		Score:        score,
-		Status:       status,
-		Notes:        "Fallback parsing used - manual review recommended",
-		AIOutputData: &response,
-		ScoreBreakdown: map[string]int{
-			"total": score,
-		},
-	}
-}
-
 func (s *AIScorer) parseVeganRelevanceResponse(response string) (score int, notes string) {
 	// Parse minimal vegan relevance response
 	var parsed struct {
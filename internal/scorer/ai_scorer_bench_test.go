@@ -13,7 +13,7 @@ func BenchmarkBuildUnifiedPrompt(b *testing.B) {
 	trust := 0.7
 	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
-		_ = s.buildUnifiedPrompt(v, u, trust)
+		_ = s.buildUnifiedPrompt("unified_user", v, u, trust)
 	}
 }
 
@@ -0,0 +1,196 @@
+package scorer
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// batchCompletionWindow is the only turnaround the Batch API offers.
+const batchCompletionWindow = "24h"
+
+// maxBatchLine bounds one line of a batch output file; a scoring reply is a few KB.
+const maxBatchLine = 1 << 20
+
+// PrepareBatchScoring fills item with the scoring call ScoreVenue would make for venue, to be
+// sent through the Batch API instead. Venues that are not sent to the model get their result
+// returned right away, as from ScoreVenue.
+func (s *AIScorer) PrepareBatchScoring(venue models.Venue, user models.User, item *models.BatchScoringItem) (*models.ValidationResult, error) {
+	user, trustLevel := s.assessSubmitter(venue, user)
+	if result := s.precheck(venue, user, trustLevel); result != nil {
+		return result, nil
+	}
+	req, pv, usage, skipped := s.scoringRequest(venue, user, trustLevel)
+	if skipped != nil {
+		return skipped, nil
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode scoring request: %w", err)
+	}
+	item.Request, item.PromptVersion, item.TokenUsage = body, pv, usage
+	return nil, nil
+}
+
+// batchLine is one request of a batch input file; the body is sent as stored.
+type batchLine struct {
+	CustomID string               `json:"custom_id"`
+	Method   string               `json:"method"`
+	URL      openai.BatchEndpoint `json:"url"`
+	Body     json.RawMessage      `json:"body"`
+}
+
+func (l batchLine) MarshalBatchLineItem() []byte {
+	b, _ := json.Marshal(l)
+	return b
+}
+
+// SubmitScoringBatch uploads the items' requests as a JSONL file and creates a Batch API job
+// for them. Replies are matched to items by ID.
+func (s *AIScorer) SubmitScoringBatch(ctx context.Context, items []models.BatchScoringItem) (*models.ScoringBatch, error) {
+	req := openai.CreateBatchWithUploadFileRequest{
+		Endpoint:         openai.BatchEndpointChatCompletions,
+		CompletionWindow: batchCompletionWindow,
+		Metadata:         map[string]any{"purpose": "venue_scoring"},
+	}
+	req.FileName = fmt.Sprintf("venue-scoring-%d.jsonl", time.Now().Unix())
+	for _, it := range items {
+		req.Lines = append(req.Lines, batchLine{
+			CustomID: batchCustomID(it.ID),
+			Method:   "POST",
+			URL:      openai.BatchEndpointChatCompletions,
+			Body:     it.Request,
+		})
+	}
+	resp, err := s.client.CreateBatchWithUploadFile(ctx, req)
+	if err != nil {
+		return nil, errs.NewExternal("scorer.SubmitScoringBatch", "openai", "batch submission failed", err)
+	}
+	b := &models.ScoringBatch{ItemCount: len(items)}
+	applyBatch(b, resp.Batch)
+	return b, nil
+}
+
+// RefreshScoringBatch updates b with the job's current status and output files.
+func (s *AIScorer) RefreshScoringBatch(ctx context.Context, b *models.ScoringBatch) error {
+	resp, err := s.client.RetrieveBatch(ctx, b.OpenAIBatchID)
+	if err != nil {
+		return errs.NewExternal("scorer.RefreshScoringBatch", "openai", "batch status check failed", err)
+	}
+	applyBatch(b, resp.Batch)
+	return nil
+}
+
+func applyBatch(b *models.ScoringBatch, ob openai.Batch) {
+	b.OpenAIBatchID = ob.ID
+	b.InputFileID = ob.InputFileID
+	b.Status = ob.Status
+	b.OutputFileID = ob.OutputFileID
+	b.ErrorFileID = ob.ErrorFileID
+}
+
+// ScoringBatchReplies downloads a finished batch's output and error files and returns the
+// reply to each item by item ID. Items missing from both files got no reply.
+func (s *AIScorer) ScoringBatchReplies(ctx context.Context, b *models.ScoringBatch) (map[int64]models.BatchReply, error) {
+	replies := map[int64]models.BatchReply{}
+	for _, fileID := range []*string{b.OutputFileID, b.ErrorFileID} {
+		if fileID == nil || *fileID == "" {
+			continue
+		}
+		content, err := s.client.GetFileContent(ctx, *fileID)
+		if err != nil {
+			return nil, errs.NewExternal("scorer.ScoringBatchReplies", "openai", "batch file download failed", err)
+		}
+		err = parseBatchOutput(content, replies)
+		content.Close()
+		if err != nil {
+			return nil, fmt.Errorf("batch %s file %s: %w", b.OpenAIBatchID, *fileID, err)
+		}
+	}
+	return replies, nil
+}
+
+// batchOutputLine is one line of a batch output or error file.
+type batchOutputLine struct {
+	CustomID string `json:"custom_id"`
+	Response *struct {
+		StatusCode int             `json:"status_code"`
+		Body       json.RawMessage `json:"body"`
+	} `json:"response"`
+	Error *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// parseBatchOutput adds the replies in a batch output or error file to replies. Lines for
+// requests this application did not send are skipped.
+func parseBatchOutput(r io.Reader, replies map[int64]models.BatchReply) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), maxBatchLine)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		var out batchOutputLine
+		if err := json.Unmarshal([]byte(line), &out); err != nil {
+			return fmt.Errorf("unreadable line: %w", err)
+		}
+		id, ok := parseBatchCustomID(out.CustomID)
+		if !ok {
+			continue
+		}
+		var reply models.BatchReply
+		switch {
+		case out.Error != nil:
+			reply.Error = strings.TrimPrefix(out.Error.Code+": "+out.Error.Message, ": ")
+		case out.Response == nil:
+			reply.Error = "no response"
+		case out.Response.StatusCode != 200:
+			reply.Error = fmt.Sprintf("HTTP %d: %s", out.Response.StatusCode, truncateToTokens(string(out.Response.Body), 50))
+		default:
+			reply.Body = out.Response.Body
+		}
+		replies[id] = reply
+	}
+	return sc.Err()
+}
+
+// BatchScoringResult turns a batch reply to item's scoring request into the venue's result,
+// checked like a synchronous reply: one repair attempt, made as a regular call, then manual
+// review. Token usage is recorded at the batch price.
+func (s *AIScorer) BatchScoringResult(ctx context.Context, item models.BatchScoringItem, body json.RawMessage) (*models.ValidationResult, error) {
+	var req openai.ChatCompletionRequest
+	if err := json.Unmarshal(item.Request, &req); err != nil {
+		return nil, fmt.Errorf("unreadable scoring request: %w", err)
+	}
+	var resp openai.ChatCompletionResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("unreadable batch reply: %w", err)
+	}
+	recordBatchUsage(req.Model, item.PromptVersion, resp.Usage)
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	return s.resultFromReply(ctx, item.VenueID, req, item.PromptVersion, item.TokenUsage, resp), nil
+}
+
+func batchCustomID(itemID int64) string {
+	return "item-" + strconv.FormatInt(itemID, 10)
+}
+
+func parseBatchCustomID(s string) (int64, bool) {
+	id, err := strconv.ParseInt(strings.TrimPrefix(s, "item-"), 10, 64)
+	return id, err == nil && strings.HasPrefix(s, "item-")
+}
@@ -0,0 +1,54 @@
+package scorer
+
+import (
+	"strings"
+	"testing"
+
+	"assisted-venue-approval/internal/models"
+)
+
+func TestParseBatchOutput(t *testing.T) {
+	out := strings.Join([]string{
+		`{"custom_id": "item-1", "response": {"status_code": 200, "body": {"id": "chatcmpl-1"}}}`,
+		`{"custom_id": "item-2", "response": {"status_code": 429, "body": {"error": "rate limited"}}}`,
+		``,
+		`{"custom_id": "item-3", "error": {"code": "batch_expired", "message": "not processed in time"}}`,
+		`{"custom_id": "other-4", "response": {"status_code": 200, "body": {}}}`,
+		`{"custom_id": "item-5"}`,
+	}, "\n")
+	replies := map[int64]models.BatchReply{}
+	if err := parseBatchOutput(strings.NewReader(out), replies); err != nil {
+		t.Fatal(err)
+	}
+	if len(replies) != 4 {
+		t.Fatalf("got %d replies, want 4: %+v", len(replies), replies)
+	}
+	if r := replies[1]; r.Error != "" || !strings.Contains(string(r.Body), "chatcmpl-1") {
+		t.Errorf("item 1: %+v", r)
+	}
+	if r := replies[2]; !strings.HasPrefix(r.Error, "HTTP 429") || r.Body != nil {
+		t.Errorf("item 2: %+v", r)
+	}
+	if r := replies[3]; r.Error != "batch_expired: not processed in time" {
+		t.Errorf("item 3: %+v", r)
+	}
+	if r := replies[5]; r.Error != "no response" {
+		t.Errorf("item 5: %+v", r)
+	}
+
+	if err := parseBatchOutput(strings.NewReader("not json\n"), replies); err == nil {
+		t.Error("expected an error for an unreadable line")
+	}
+}
+
+func TestBatchCustomID(t *testing.T) {
+	id, ok := parseBatchCustomID(batchCustomID(42))
+	if !ok || id != 42 {
+		t.Fatalf("round trip: got %d, %v", id, ok)
+	}
+	for _, s := range []string{"42", "item-", "item-x", "req-42"} {
+		if _, ok := parseBatchCustomID(s); ok {
+			t.Errorf("%q parsed as an item ID", s)
+		}
+	}
+}
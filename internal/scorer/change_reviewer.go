@@ -0,0 +1,147 @@
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/internal/prompts"
+)
+
+// changePromptVersion labels change review usage metrics; the user prompt is built in code.
+const changePromptVersion = "change_system@v1"
+
+// ChangeReviewer scores an edit to an approved venue. Only the edited fields are sent, with
+// their old and new values, so a small edit costs a fraction of a full scoring call.
+type ChangeReviewer struct {
+	client  *openai.Client
+	pm      *prompts.Manager
+	model   string
+	timeout time.Duration
+}
+
+func NewChangeReviewer(apiKey string, pm *prompts.Manager, model string, timeout time.Duration) *ChangeReviewer {
+	if model == "" {
+		model = openai.GPT4oMini
+	}
+	return &ChangeReviewer{
+		client:  openai.NewClient(apiKey),
+		pm:      pm,
+		model:   model,
+		timeout: timeout,
+	}
+}
+
+// ReviewChanges asks the model about changes to venue, made by editor with an optional note,
+// and returns a verdict for every changed field with the cost of the call.
+func (cr *ChangeReviewer) ReviewChanges(ctx context.Context, venue models.Venue, editor models.User, changes []domain.FieldChange, note string) (*models.ChangeAssessment, error) {
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("no changes to review")
+	}
+	ctx, cancel := context.WithTimeout(ctx, cr.timeout)
+	defer cancel()
+
+	req := openai.ChatCompletionRequest{
+		Model: cr.model,
+		Messages: []openai.ChatCompletionMessage{
+			{Role: openai.ChatMessageRoleSystem, Content: cr.buildSystemPrompt(venue, editor)},
+			{Role: openai.ChatMessageRoleUser, Content: buildChangePrompt(changes, note)},
+		},
+		Temperature:    0.0,
+		MaxTokens:      300,
+		ResponseFormat: &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject},
+	}
+	resp, err := cr.client.CreateChatCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	cost := recordUsage(cr.model, changePromptVersion, callTypeChangeReview, resp.Usage)
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("change review: empty response")
+	}
+
+	content, err := enforceSchema(ctx, cr.client.CreateChatCompletion, req, resp.Choices[0].Message.Content, changeSchema, changePromptVersion, callTypeChangeReview)
+	if err != nil {
+		return nil, err
+	}
+	ca, err := parseChangeAssessment(content, changes)
+	if err != nil {
+		return nil, err
+	}
+	ca.CostUSD = cost
+	return ca, nil
+}
+
+func (cr *ChangeReviewer) buildSystemPrompt(venue models.Venue, editor models.User) string {
+	veganStatus := "vegan-friendly"
+	if venue.Vegan == 1 {
+		veganStatus = "fully vegan"
+	} else if venue.VegOnly == 1 {
+		veganStatus = "vegetarian"
+	}
+	role := "a member"
+	switch {
+	case editor.IsVenueAdmin:
+		role = "the venue's owner or manager"
+	case editor.Trusted:
+		role = "a trusted member"
+	}
+	path := ""
+	if venue.Path != nil {
+		path = *venue.Path
+	}
+	if cr.pm != nil {
+		data := map[string]any{"VenueName": venue.Name, "VenuePath": path, "VeganStatus": veganStatus, "EditorRole": role}
+		if out, err := cr.pm.Render("change_system", data); err == nil {
+			return out
+		}
+	}
+	return fmt.Sprintf("Review this edit by %s to the approved listing %q (%s, %s). Judge only the edited fields. Reply as JSON with score (0-100), fields (field -> accept, reject or unsure) and notes.",
+		role, venue.Name, path, veganStatus)
+}
+
+// buildChangePrompt lists each changed field with its old and new value.
+func buildChangePrompt(changes []domain.FieldChange, note string) string {
+	var b strings.Builder
+	b.WriteString("Edited fields (old -> new):\n")
+	for _, c := range changes {
+		from := c.From
+		if from == "" {
+			from = "(empty)"
+		} else {
+			from = fmt.Sprintf("%q", from)
+		}
+		fmt.Fprintf(&b, "- %s: %s -> %q\n", c.Field, from, c.To)
+	}
+	if note = strings.TrimSpace(note); note != "" {
+		fmt.Fprintf(&b, "\nNote from the editor: %s\n", note)
+	}
+	return b.String()
+}
+
+// parseChangeAssessment reads a schema-checked reply. Every changed field gets a verdict:
+// fields the model left out or answered with anything else count as unsure, and verdicts
+// for fields that were not changed are dropped.
+func parseChangeAssessment(content string, changes []domain.FieldChange) (*models.ChangeAssessment, error) {
+	var ca models.ChangeAssessment
+	if err := json.Unmarshal([]byte(stripCodeFence(content)), &ca); err != nil {
+		return nil, fmt.Errorf("failed to parse change assessment: %w", err)
+	}
+	fields := make(map[string]string, len(changes))
+	for _, c := range changes {
+		switch v := strings.ToLower(strings.TrimSpace(ca.Fields[c.Field])); v {
+		case models.ChangeFieldAccept, models.ChangeFieldReject:
+			fields[c.Field] = v
+		default:
+			fields[c.Field] = models.ChangeFieldUnsure
+		}
+	}
+	ca.Fields = fields
+	return &ca, nil
+}
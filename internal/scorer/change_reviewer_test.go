@@ -0,0 +1,56 @@
+package scorer
+
+import (
+	"strings"
+	"testing"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+)
+
+func TestBuildChangePrompt(t *testing.T) {
+	got := buildChangePrompt([]domain.FieldChange{
+		{Field: "phone", From: "+1 555 0100", To: "+1 555 0199"},
+		{Field: "website", To: "https://example.org"},
+	}, " moved to a new line ")
+	for _, want := range []string{
+		`- phone: "+1 555 0100" -> "+1 555 0199"`,
+		`- website: (empty) -> "https://example.org"`,
+		"Note from the editor: moved to a new line\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("prompt missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "name") {
+		t.Errorf("prompt lists a field that did not change:\n%s", got)
+	}
+}
+
+func TestParseChangeAssessment(t *testing.T) {
+	changes := []domain.FieldChange{{Field: "phone"}, {Field: "openhours"}, {Field: "name"}}
+	reply := "```json\n" + `{"score": 40, "fields": {"phone": "Accept", "openhours": "reject", "category": "reject"}, "notes": "hours look wrong"}` + "\n```"
+	if problems := changeSchema.Validate(stripCodeFence(reply)); len(problems) > 0 {
+		t.Fatalf("schema problems: %v", problems)
+	}
+
+	ca, err := parseChangeAssessment(reply, changes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"phone": models.ChangeFieldAccept, "openhours": models.ChangeFieldReject, "name": models.ChangeFieldUnsure}
+	if len(ca.Fields) != len(want) {
+		t.Fatalf("fields = %v, want %v", ca.Fields, want)
+	}
+	for f, v := range want {
+		if ca.Fields[f] != v {
+			t.Errorf("fields[%s] = %q, want %q", f, ca.Fields[f], v)
+		}
+	}
+	if ca.Score != 40 || ca.Notes != "hours look wrong" {
+		t.Fatalf("assessment = %+v", ca)
+	}
+	if got := ca.RejectedFields(); len(got) != 1 || got[0] != "openhours" {
+		t.Fatalf("RejectedFields = %v", got)
+	}
+}
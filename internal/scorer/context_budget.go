@@ -0,0 +1,111 @@
+package scorer
+
+import (
+	"log"
+	"strings"
+	"unicode"
+
+	"github.com/sashabaranov/go-openai"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/internal/prompts"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// scoringMaxTokens is the reply size requested from the scoring model; the prompt gets the
+// rest of the context budget.
+const scoringMaxTokens = 250
+
+// truncationMarker ends a description cut to fit the context budget.
+const truncationMarker = " … [truncated]"
+
+// maxFitPasses bounds re-rendering: JSON escaping makes the first cut an estimate.
+const maxFitPasses = 3
+
+var mPromptTruncations = metrics.Default.CounterVec("openai_prompt_truncations_total",
+	"Scoring prompts cut to fit the context budget, by venue field", "field")
+
+// SetContextBudget sets the token budget of one scoring call, prompt and reply together.
+// Values that leave no room for the prompt keep the current budget.
+func (s *AIScorer) SetContextBudget(tokens int) {
+	if tokens > scoringMaxTokens {
+		s.budget = tokens
+	}
+}
+
+// fitUserPrompt renders the user prompt and estimates the prompt size. When system and user
+// prompt leave less than scoringMaxTokens of the budget for the reply, the venue description,
+// the only free-text field of unbounded length, is cut to fit and the cut is logged.
+func (s *AIScorer) fitUserPrompt(tplName, sysPrompt string, venue models.Venue, user models.User, trustLevel float64) (string, *models.TokenUsage) {
+	userPrompt := s.buildUnifiedPrompt(tplName, venue, user, trustLevel)
+	sysTokens := prompts.EstimateTokens(sysPrompt)
+	usage := &models.TokenUsage{Estimated: sysTokens + prompts.EstimateTokens(userPrompt)}
+	limit := s.budget - scoringMaxTokens
+	if usage.Estimated <= limit {
+		return userPrompt, usage
+	}
+
+	before := usage.Estimated
+	desc := ""
+	if venue.AdditionalInfo != nil {
+		desc = *venue.AdditionalInfo
+	}
+	truncated := false
+	for pass := 0; pass < maxFitPasses && usage.Estimated > limit && desc != ""; pass++ {
+		keep := prompts.EstimateTokens(desc) - (usage.Estimated - limit) - prompts.EstimateTokens(truncationMarker)
+		desc = truncateToTokens(desc, keep)
+		if desc != "" {
+			desc += truncationMarker
+		}
+		venue.AdditionalInfo = &desc
+		userPrompt = s.buildUnifiedPrompt(tplName, venue, user, trustLevel)
+		usage.Estimated = sysTokens + prompts.EstimateTokens(userPrompt)
+		truncated = true
+	}
+	if !truncated {
+		log.Printf("scorer: venue %d prompt ~%d tokens exceeds budget %d, nothing to truncate", venue.ID, before, limit)
+		return userPrompt, usage
+	}
+	usage.Truncated = []string{"description"}
+	mPromptTruncations.With("description").Inc()
+	log.Printf("scorer: venue %d prompt ~%d tokens exceeds budget %d, description truncated, prompt now ~%d tokens",
+		venue.ID, before, limit, usage.Estimated)
+	return userPrompt, usage
+}
+
+// truncateToTokens cuts s to about n tokens (see prompts.EstimateTokens), preferring to end
+// at a word boundary close to the cut.
+func truncateToTokens(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	budget := n * 4 // in quarter tokens: ASCII costs 1, anything else 4
+	cut := len(s)
+	for i, r := range s {
+		cost := 4
+		if r < 128 {
+			cost = 1
+		}
+		if budget < cost {
+			cut = i
+			break
+		}
+		budget -= cost
+	}
+	out := s[:cut]
+	if cut < len(s) {
+		if i := strings.LastIndexFunc(out, unicode.IsSpace); i > len(out)*4/5 {
+			out = out[:i]
+		}
+	}
+	return strings.TrimSpace(out)
+}
+
+// addTokens adds the reported usage of one completion to the venue's token counts.
+func addTokens(u *models.TokenUsage, usage openai.Usage) {
+	if u == nil {
+		return
+	}
+	u.PromptTokens += usage.PromptTokens
+	u.CompletionTokens += usage.CompletionTokens
+}
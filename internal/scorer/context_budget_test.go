@@ -0,0 +1,68 @@
+package scorer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/internal/prompts"
+)
+
+func TestTruncateToTokens(t *testing.T) {
+	tests := []struct {
+		in   string
+		n    int
+		want string
+	}{
+		{"short text", 10, "short text"},
+		{"vegan cafe with cakes and coffee", 4, "vegan cafe with"},
+		{"ヴィーガンラーメン", 3, "ヴィー"},
+		{"anything", 0, ""},
+	}
+	for _, tt := range tests {
+		if got := truncateToTokens(tt.in, tt.n); got != tt.want {
+			t.Errorf("truncateToTokens(%q, %d)=%q want %q", tt.in, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestFitUserPrompt(t *testing.T) {
+	s := NewAIScorer("test")
+	s.SetContextBudget(2000)
+	sys := s.getSystemPrompt("system")
+	lat, lng := 52.5, 13.4
+	long := strings.Repeat("Fresh vegan pastries baked every morning. ", 400)
+	venue := models.Venue{ID: 7, Name: "Green Oven", Location: "Berlin", Lat: &lat, Lng: &lng, AdditionalInfo: &long}
+
+	out, usage := s.fitUserPrompt("unified_user", sys, venue, models.User{}, 0.5)
+	if len(usage.Truncated) != 1 || usage.Truncated[0] != "description" {
+		t.Fatalf("Truncated = %v, want [description]", usage.Truncated)
+	}
+	if limit := 2000 - scoringMaxTokens; usage.Estimated > limit {
+		t.Errorf("Estimated = %d, over the prompt limit %d", usage.Estimated, limit)
+	}
+	if got := prompts.EstimateTokens(sys) + prompts.EstimateTokens(out); got != usage.Estimated {
+		t.Errorf("Estimated = %d, prompt is %d", usage.Estimated, got)
+	}
+	if !strings.Contains(out, "[truncated]") || *venue.AdditionalInfo != long {
+		t.Error("description not marked as truncated, or the caller's venue was changed")
+	}
+
+	short := "Vegan bakery."
+	venue.AdditionalInfo = &short
+	if _, usage := s.fitUserPrompt("unified_user", sys, venue, models.User{}, 0.5); usage.Truncated != nil {
+		t.Errorf("short description truncated: %v", usage.Truncated)
+	}
+}
+
+func TestAddTokens(t *testing.T) {
+	u := &models.TokenUsage{Estimated: 900}
+	addTokens(u, openai.Usage{PromptTokens: 850, CompletionTokens: 60})
+	addTokens(u, openai.Usage{PromptTokens: 1000, CompletionTokens: 70}) // repair request
+	if u.PromptTokens != 1850 || u.CompletionTokens != 130 || u.Estimated != 900 {
+		t.Errorf("usage = %+v", u)
+	}
+	addTokens(nil, openai.Usage{PromptTokens: 1})
+}
@@ -0,0 +1,287 @@
+package scorer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// jsonSchema is the subset of JSON Schema used to check model output: types, required and
+// allowed properties, array items, number ranges and string lengths.
+type jsonSchema struct {
+	Type                 []string               `json:"type"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties bool                   `json:"-"` // objects only; see MarshalJSON
+	Minimum              *float64               `json:"minimum,omitempty"`
+	Maximum              *float64               `json:"maximum,omitempty"`
+	MinLength            int                    `json:"minLength,omitempty"`
+	MaxLength            int                    `json:"maxLength,omitempty"`
+}
+
+func intRange(min, max float64) *jsonSchema {
+	return &jsonSchema{Type: []string{"integer"}, Minimum: &min, Maximum: &max}
+}
+
+// scoringSchema is the reply the unified scoring prompt asks for.
+var scoringSchema = &jsonSchema{
+	Type: []string{"object"},
+	Properties: map[string]*jsonSchema{
+		"score": intRange(0, 100),
+		"notes": {Type: []string{"string"}, MinLength: 1, MaxLength: 500},
+		"breakdown": {
+			Type: []string{"object"},
+			Properties: map[string]*jsonSchema{
+				"legitimacy":   intRange(0, 35),
+				"completeness": intRange(0, 30),
+				"relevance":    intRange(0, 35),
+			},
+			Required: []string{"legitimacy", "completeness", "relevance"},
+		},
+	},
+	Required: []string{"score", "notes", "breakdown"},
+}
+
+// qualitySchema is the reply the quality review prompt asks for; see models.QualitySuggestions.
+var qualitySchema = &jsonSchema{
+	Type: []string{"object"},
+	Properties: map[string]*jsonSchema{
+		"description": {Type: []string{"string"}, MinLength: 1},
+		"name":        {Type: []string{"string", "null"}},
+		"closed_days": {Type: []string{"string", "null"}},
+		"pathValidation": {
+			Type: []string{"object", "null"},
+			Properties: map[string]*jsonSchema{
+				"isValid":    {Type: []string{"boolean"}},
+				"issue":      {Type: []string{"string", "null"}},
+				"confidence": {Type: []string{"string", "null"}},
+			},
+			Required: []string{"isValid"},
+		},
+	},
+	Required: []string{"description"},
+}
+
+// changeSchema is the reply the change review prompt asks for; see models.ChangeAssessment.
+// Field verdicts are checked when parsing, since the keys depend on the edit.
+var changeSchema = &jsonSchema{
+	Type: []string{"object"},
+	Properties: map[string]*jsonSchema{
+		"score":  intRange(0, 100),
+		"fields": {Type: []string{"object"}, AdditionalProperties: true},
+		"notes":  {Type: []string{"string"}, MaxLength: 500},
+	},
+	Required: []string{"score", "fields", "notes"},
+}
+
+// veganSchema is the reply the vegan status prompt asks for; see models.VeganStatusAssessment.
+// Status and source values are normalised when parsing.
+var veganSchema = &jsonSchema{
+	Type: []string{"object"},
+	Properties: map[string]*jsonSchema{
+		"status":     {Type: []string{"string"}, MinLength: 1},
+		"confidence": {Type: []string{"string"}, MinLength: 1},
+		"evidence": {
+			Type: []string{"array"},
+			Items: &jsonSchema{
+				Type: []string{"object"},
+				Properties: map[string]*jsonSchema{
+					"source": {Type: []string{"string"}, MinLength: 1},
+					"review": {Type: []string{"integer", "null"}},
+					"quote":  {Type: []string{"string"}, MinLength: 1, MaxLength: 300},
+				},
+				Required: []string{"source", "quote"},
+			},
+		},
+		"notes": {Type: []string{"string"}, MaxLength: 500},
+	},
+	Required: []string{"status", "confidence", "evidence"},
+}
+
+// Validate decodes raw as JSON and checks it against the schema. It returns every problem
+// found, each prefixed with its JSON path, or nil when raw matches.
+func (s *jsonSchema) Validate(raw string) []string {
+	dec := json.NewDecoder(strings.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return []string{"not valid JSON: " + err.Error()}
+	}
+	if dec.More() {
+		return []string{"not valid JSON: unexpected data after the object"}
+	}
+	var problems []string
+	s.check("$", v, &problems)
+	return problems
+}
+
+func (s *jsonSchema) check(path string, v any, problems *[]string) {
+	add := func(format string, a ...any) {
+		*problems = append(*problems, path+": "+fmt.Sprintf(format, a...))
+	}
+	kind := jsonKind(v)
+	if !s.allows(kind) {
+		add("expected %s, got %s", strings.Join(s.Type, " or "), kind)
+		return
+	}
+	switch val := v.(type) {
+	case map[string]any:
+		for _, k := range s.Required {
+			if _, ok := val[k]; !ok {
+				add("missing required key %q", k)
+			}
+		}
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if ps, ok := s.Properties[k]; ok {
+				ps.check(path+"."+k, val[k], problems)
+			} else if !s.AdditionalProperties {
+				add("unexpected key %q", k)
+			}
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range val {
+				s.Items.check(fmt.Sprintf("%s[%d]", path, i), item, problems)
+			}
+		}
+	case json.Number:
+		f, _ := val.Float64()
+		if s.Minimum != nil && f < *s.Minimum {
+			add("%s is below the minimum %g", val, *s.Minimum)
+		}
+		if s.Maximum != nil && f > *s.Maximum {
+			add("%s is above the maximum %g", val, *s.Maximum)
+		}
+	case string:
+		n := len([]rune(val))
+		if n < s.MinLength {
+			add("must not be empty")
+		}
+		if s.MaxLength > 0 && n > s.MaxLength {
+			add("%d characters, at most %d allowed", n, s.MaxLength)
+		}
+	}
+}
+
+// MarshalJSON writes additionalProperties for object schemas only.
+func (s *jsonSchema) MarshalJSON() ([]byte, error) {
+	type plain jsonSchema
+	out := struct {
+		*plain
+		AdditionalProperties *bool `json:"additionalProperties,omitempty"`
+	}{plain: (*plain)(s)}
+	if s.allows("object") {
+		out.AdditionalProperties = &s.AdditionalProperties
+	}
+	return json.Marshal(out)
+}
+
+func (s *jsonSchema) allows(kind string) bool {
+	for _, t := range s.Type {
+		if t == kind || (t == "number" && kind == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonKind(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := val.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// String renders the schema for the repair prompt.
+func (s *jsonSchema) String() string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSpace(buf.String())
+}
+
+// Results of checking a reply against its output schema, the result label of mStructuredOutput.
+const (
+	outputValid    = "valid"
+	outputRepaired = "repaired"
+	outputInvalid  = "invalid"
+)
+
+var mStructuredOutput = metrics.Default.CounterVec("openai_structured_output_total",
+	"AI replies checked against their output schema, by result (valid, repaired, invalid)",
+	"model", "prompt_version", "call_type", "result")
+
+// chatFunc sends one chat completion, e.g. through the caller's circuit breaker.
+type chatFunc func(ctx context.Context, req openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error)
+
+// enforceSchema returns content when it matches schema. Otherwise it asks the model once to
+// correct its reply, showing it the validation errors, and returns the corrected reply when
+// that one matches. The error lists the problems that remain.
+func enforceSchema(ctx context.Context, call chatFunc, req openai.ChatCompletionRequest, content string, schema *jsonSchema, promptVersion, callType string) (string, error) {
+	problems := schema.Validate(stripCodeFence(content))
+	if len(problems) == 0 {
+		mStructuredOutput.With(req.Model, promptVersion, callType, outputValid).Inc()
+		return stripCodeFence(content), nil
+	}
+
+	repair := req
+	repair.Messages = append(append([]openai.ChatCompletionMessage{}, req.Messages...),
+		openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: content},
+		openai.ChatCompletionMessage{Role: openai.ChatMessageRoleUser, Content: repairPrompt(problems, schema)},
+	)
+	resp, err := call(ctx, repair)
+	if err == nil && len(resp.Choices) > 0 {
+		recordUsage(req.Model, promptVersion, callType, resp.Usage)
+		fixed := stripCodeFence(resp.Choices[0].Message.Content)
+		if problems = schema.Validate(fixed); len(problems) == 0 {
+			mStructuredOutput.With(req.Model, promptVersion, callType, outputRepaired).Inc()
+			return fixed, nil
+		}
+		content = fixed
+	} else if err != nil {
+		problems = append(problems, "repair request failed: "+err.Error())
+	}
+	mStructuredOutput.With(req.Model, promptVersion, callType, outputInvalid).Inc()
+	return content, fmt.Errorf("AI reply does not match the output schema: %s", strings.Join(problems, "; "))
+}
+
+func repairPrompt(problems []string, schema *jsonSchema) string {
+	return "Your reply does not match the required JSON format:\n- " + strings.Join(problems, "\n- ") +
+		"\n\nReply again with only the corrected JSON object, matching this JSON Schema:\n" + schema.String()
+}
+
+// stripCodeFence removes a markdown code block around a JSON reply.
+func stripCodeFence(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "```json")
+	s = strings.TrimPrefix(s, "```")
+	s = strings.TrimSuffix(s, "```")
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,93 @@
+package scorer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+func TestScoringSchemaValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want []string // substrings of expected problems; none means valid
+	}{
+		{"valid", `{"score": 90, "notes": "ok", "breakdown": {"legitimacy": 30, "completeness": 30, "relevance": 30}}`, nil},
+		{"code fence is not JSON", "```json\n{}\n```", []string{"not valid JSON"}},
+		{"missing keys", `{"score": 90}`, []string{`missing required key "notes"`, `missing required key "breakdown"`}},
+		{"score out of range", `{"score": 120, "notes": "ok", "breakdown": {"legitimacy": 30, "completeness": 30, "relevance": 30}}`, []string{"$.score: 120 is above the maximum 100"}},
+		{"fractional score", `{"score": 90.5, "notes": "ok", "breakdown": {"legitimacy": 30, "completeness": 30, "relevance": 30}}`, []string{"$.score: expected integer, got number"}},
+		{"string score", `{"score": "90", "notes": "ok", "breakdown": {"legitimacy": 30, "completeness": 30, "relevance": 30}}`, []string{"expected integer, got string"}},
+		{"nested", `{"score": 90, "notes": "", "breakdown": {"legitimacy": 40, "completeness": 30}}`, []string{"$.notes: must not be empty", "$.breakdown.legitimacy: 40 is above", `$.breakdown: missing required key "relevance"`}},
+		{"unexpected key", `{"score": 90, "notes": "ok", "extra": 1, "breakdown": {"legitimacy": 30, "completeness": 30, "relevance": 30}}`, []string{`unexpected key "extra"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(scoringSchema.Validate(tt.raw), "; ")
+			if len(tt.want) == 0 && got != "" {
+				t.Fatalf("unexpected problems: %s", got)
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("problems %q missing %q", got, w)
+				}
+			}
+		})
+	}
+}
+
+func TestQualitySchemaAllowsNulls(t *testing.T) {
+	raw := `{"description": "Vegan bakery.", "name": null, "closed_days": null, "pathValidation": {"isValid": true}}`
+	if p := qualitySchema.Validate(raw); len(p) != 0 {
+		t.Fatalf("unexpected problems: %v", p)
+	}
+}
+
+func TestSchemaString(t *testing.T) {
+	s := scoringSchema.String()
+	for _, w := range []string{`"required":["score","notes","breakdown"]`, `"additionalProperties":false`, `"maximum":35`} {
+		if !strings.Contains(s, w) {
+			t.Errorf("schema %s missing %s", s, w)
+		}
+	}
+	if strings.Contains(s, `"minimum":0,"additionalProperties"`) {
+		t.Errorf("additionalProperties written for a non-object schema: %s", s)
+	}
+}
+
+func TestEnforceSchemaRepair(t *testing.T) {
+	valid := `{"score": 90, "notes": "ok", "breakdown": {"legitimacy": 30, "completeness": 30, "relevance": 30}}`
+	req := openai.ChatCompletionRequest{Model: "gpt-4o-mini", Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "score"}}}
+	reply := func(content string) chatFunc {
+		return func(_ context.Context, r openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+			last := r.Messages[len(r.Messages)-1].Content
+			if len(r.Messages) != 3 || !strings.Contains(last, `missing required key "breakdown"`) {
+				t.Errorf("repair request does not carry the validation errors: %q", last)
+			}
+			return openai.ChatCompletionResponse{Choices: []openai.ChatCompletionChoice{{Message: openai.ChatCompletionMessage{Content: content}}}}, nil
+		}
+	}
+	noCall := func(context.Context, openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+		t.Fatal("valid reply must not be repaired")
+		return openai.ChatCompletionResponse{}, nil
+	}
+
+	if got, err := enforceSchema(context.Background(), noCall, req, "```json\n"+valid+"\n```", scoringSchema, "v", "test"); err != nil || got != valid {
+		t.Fatalf("valid: got %q, %v", got, err)
+	}
+	if got, err := enforceSchema(context.Background(), reply(valid), req, `{"score": 90, "notes": "ok"}`, scoringSchema, "v", "test"); err != nil || got != valid {
+		t.Fatalf("repaired: got %q, %v", got, err)
+	}
+	if _, err := enforceSchema(context.Background(), reply(`{"score": 90, "notes": "still"}`), req, `{"score": 90, "notes": "ok"}`, scoringSchema, "v", "test"); err == nil {
+		t.Fatal("expected error when the repair is still invalid")
+	}
+	failing := func(context.Context, openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+		return openai.ChatCompletionResponse{}, errors.New("boom")
+	}
+	if _, err := enforceSchema(context.Background(), failing, req, `{}`, scoringSchema, "v", "test"); err == nil || !strings.Contains(err.Error(), "repair request failed: boom") {
+		t.Fatalf("got %v", err)
+	}
+}
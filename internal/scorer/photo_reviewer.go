@@ -0,0 +1,121 @@
+package scorer
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/internal/prompts"
+)
+
+// photoPromptVersion labels photo check usage metrics. Image tokens are included in the reported prompt usage.
+const photoPromptVersion = "photo_system@v1"
+
+// PhotoReviewer asks a vision model whether a venue's Google photos look like a food venue.
+type PhotoReviewer struct {
+	client  *openai.Client
+	pm      *prompts.Manager
+	model   string
+	timeout time.Duration
+}
+
+func NewPhotoReviewer(apiKey string, pm *prompts.Manager, model string, timeout time.Duration) *PhotoReviewer {
+	if model == "" {
+		model = openai.GPT4oMini
+	}
+	return &PhotoReviewer{
+		client:  openai.NewClient(apiKey),
+		pm:      pm,
+		model:   model,
+		timeout: timeout,
+	}
+}
+
+// ReviewPhotos sends the photos in one low-detail request and returns the assessment with its cost.
+func (pr *PhotoReviewer) ReviewPhotos(ctx context.Context, venue models.Venue, photos []models.VenuePhoto) (*models.PhotoAssessment, error) {
+	if len(photos) == 0 {
+		return nil, fmt.Errorf("no photos to review")
+	}
+	ctx, cancel := context.WithTimeout(ctx, pr.timeout)
+	defer cancel()
+
+	parts := []openai.ChatMessagePart{{Type: openai.ChatMessagePartTypeText, Text: "Photos:"}}
+	for _, p := range photos {
+		ct := p.ContentType
+		if ct == "" {
+			ct = "image/jpeg"
+		}
+		parts = append(parts, openai.ChatMessagePart{
+			Type: openai.ChatMessagePartTypeImageURL,
+			ImageURL: &openai.ChatMessageImageURL{
+				URL:    "data:" + ct + ";base64," + base64.StdEncoding.EncodeToString(p.Data),
+				Detail: openai.ImageURLDetailLow,
+			},
+		})
+	}
+
+	resp, err := pr.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model: pr.model,
+		Messages: []openai.ChatCompletionMessage{
+			{Role: openai.ChatMessageRoleSystem, Content: pr.buildSystemPrompt(venue)},
+			{Role: openai.ChatMessageRoleUser, MultiContent: parts},
+		},
+		Temperature:    0.0,
+		MaxTokens:      150,
+		ResponseFormat: &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("photo review: empty response")
+	}
+
+	pa, err := parsePhotoAssessment(resp.Choices[0].Message.Content)
+	if err != nil {
+		return nil, err
+	}
+	pa.PhotosChecked = len(photos)
+	pa.CostUSD = recordUsage(pr.model, photoPromptVersion, callTypePhotoCheck, resp.Usage)
+	return pa, nil
+}
+
+func (pr *PhotoReviewer) buildSystemPrompt(venue models.Venue) string {
+	veganStatus := "vegan-friendly"
+	if venue.Vegan == 1 {
+		veganStatus = "fully vegan"
+	} else if venue.VegOnly == 1 {
+		veganStatus = "vegetarian"
+	}
+	if pr.pm != nil {
+		data := map[string]any{"VenueName": venue.Name, "VeganStatus": veganStatus}
+		if out, err := pr.pm.Render("photo_system", data); err == nil {
+			return out
+		}
+	}
+	return fmt.Sprintf("Do these photos of %q (%s) show a food venue and vegan signage? Reply as JSON with food_venue, vegan_signage, confidence, notes.", venue.Name, veganStatus)
+}
+
+func parsePhotoAssessment(response string) (*models.PhotoAssessment, error) {
+	response = strings.TrimSpace(response)
+	response = strings.TrimPrefix(response, "```json")
+	response = strings.TrimPrefix(response, "```")
+	response = strings.TrimSuffix(response, "```")
+
+	var pa models.PhotoAssessment
+	if err := json.Unmarshal([]byte(strings.TrimSpace(response)), &pa); err != nil {
+		return nil, fmt.Errorf("failed to parse photo assessment: %w", err)
+	}
+	if pa.Confidence < 0 {
+		pa.Confidence = 0
+	} else if pa.Confidence > 1 {
+		pa.Confidence = 1
+	}
+	return &pa, nil
+}
@@ -13,6 +13,9 @@ import (
 	"assisted-venue-approval/internal/prompts"
 )
 
+// qualityPromptVersion labels quality review usage metrics; the user prompt is built in code.
+const qualityPromptVersion = "quality_system@v1"
+
 type QualityReviewer struct {
 	client                *openai.Client
 	pm                    *prompts.Manager
@@ -56,7 +59,7 @@ func (qr *QualityReviewer) ReviewQuality(ctx context.Context, venue models.Venue
 	userPrompt := qr.buildUserPrompt(combinedInfo, category)
 
 	// Call OpenAI API (use gpt-4o-mini for better instruction following)
-	resp, err := qr.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+	req := openai.ChatCompletionRequest{
 		Model: openai.GPT4oMini,
 		Messages: []openai.ChatCompletionMessage{
 			{Role: openai.ChatMessageRoleSystem, Content: systemPrompt},
@@ -65,14 +68,23 @@ func (qr *QualityReviewer) ReviewQuality(ctx context.Context, venue models.Venue
 		Temperature:    0.0,
 		MaxTokens:      500,
 		ResponseFormat: &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject},
-	})
+	}
+	resp, err := qr.client.CreateChatCompletion(ctx, req)
 
 	if err != nil {
 		return nil, err
 	}
+	recordUsage(openai.GPT4oMini, qualityPromptVersion, callTypeQualityReview, resp.Usage)
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("quality review returned no choices")
+	}
 
-	// Parse response
-	qs, err := qr.parseResponse(resp.Choices[0].Message.Content)
+	// Validate against the quality schema (one repair attempt), then parse
+	content, err := enforceSchema(ctx, qr.client.CreateChatCompletion, req, resp.Choices[0].Message.Content, qualitySchema, qualityPromptVersion, callTypeQualityReview)
+	if err != nil {
+		return nil, err
+	}
+	qs, err := qr.parseResponse(content)
 	if err != nil {
 		return nil, err
 	}
@@ -128,11 +140,7 @@ func (qr *QualityReviewer) buildUserPrompt(combinedInfo models.CombinedInfo, cat
 
 func (qr *QualityReviewer) parseResponse(response string) (*models.QualitySuggestions, error) {
 	// Clean response (remove markdown code blocks if present)
-	response = strings.TrimSpace(response)
-	response = strings.TrimPrefix(response, "```json")
-	response = strings.TrimPrefix(response, "```")
-	response = strings.TrimSuffix(response, "```")
-	response = strings.TrimSpace(response)
+	response = stripCodeFence(response)
 
 	var qs models.QualitySuggestions
 	if err := json.Unmarshal([]byte(response), &qs); err != nil {
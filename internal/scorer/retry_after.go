@@ -0,0 +1,73 @@
+package scorer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	errs "assisted-venue-approval/pkg/errors"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// go-openai drops response headers from its errors, so Retry-After is captured by the
+// transport into a holder the caller placed on the request context.
+type retryAfterKey struct{}
+
+type retryAfterTransport struct {
+	base http.RoundTripper
+}
+
+func (t retryAfterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		if h, ok := req.Context().Value(retryAfterKey{}).(*time.Duration); ok {
+			*h = retryAfterFromHeader(resp.Header, time.Now())
+		}
+	}
+	return resp, nil
+}
+
+// retryAfterFromHeader prefers OpenAI's millisecond header over the standard one.
+func retryAfterFromHeader(h http.Header, now time.Time) time.Duration {
+	if ms, err := strconv.ParseFloat(h.Get("retry-after-ms"), 64); err == nil && ms > 0 {
+		return time.Duration(ms * float64(time.Millisecond))
+	}
+	return errs.ParseRetryAfter(h.Get("Retry-After"), now)
+}
+
+// newOpenAIClient returns a client whose 429/503 responses record Retry-After (see withRetryAfter).
+func newOpenAIClient(apiKey string) *openai.Client {
+	cfg := openai.DefaultConfig(apiKey)
+	cfg.HTTPClient = &http.Client{Transport: retryAfterTransport{base: http.DefaultTransport}}
+	return openai.NewClientWithConfig(cfg)
+}
+
+// withRetryAfter attaches a holder that the transport fills in on throttled responses.
+func withRetryAfter(ctx context.Context) (context.Context, *time.Duration) {
+	h := new(time.Duration)
+	return context.WithValue(ctx, retryAfterKey{}, h), h
+}
+
+// classifyOpenAIError wraps an OpenAI SDK error in a classified ExternalAPIError.
+// A 429 for an exhausted quota is not a rate limit: waiting will not help, so it is
+// reported as BudgetExceeded around a non-transient client error.
+func classifyOpenAIError(op string, err error, retryAfter time.Duration) error {
+	var apiErr *openai.APIError
+	var reqErr *openai.RequestError
+	switch {
+	case errors.As(err, &apiErr):
+		if apiErr.Code == "insufficient_quota" || apiErr.Type == "insufficient_quota" {
+			return errs.NewBudgetExceeded(op, "openai quota exhausted", errs.NewExternalClass(op, "openai", errs.ClassClient, err))
+		}
+		return errs.NewExternalStatus(op, "openai", apiErr.HTTPStatusCode, retryAfter, err)
+	case errors.As(err, &reqErr):
+		return errs.NewExternalStatus(op, "openai", reqErr.HTTPStatusCode, retryAfter, err)
+	}
+	return errs.NewExternalStatus(op, "openai", 0, retryAfter, err)
+}
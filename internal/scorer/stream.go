@@ -0,0 +1,88 @@
+package scorer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// StreamSink receives the scoring reply while the model writes it, so a reviewer waiting on a
+// single venue sees the rationale forming. The full reply is still validated and parsed as
+// usual once complete.
+type StreamSink interface {
+	// Begin is called when a scoring call starts. A retried call starts again, so text
+	// received before is to be discarded.
+	Begin()
+	// Delta is the next piece of the reply.
+	Delta(text string)
+}
+
+type streamKey struct{}
+
+// WithStream makes scoring calls made with ctx stream their reply to sink.
+func WithStream(ctx context.Context, sink StreamSink) context.Context {
+	return context.WithValue(ctx, streamKey{}, sink)
+}
+
+func streamFrom(ctx context.Context) StreamSink {
+	sink, _ := ctx.Value(streamKey{}).(StreamSink)
+	return sink
+}
+
+// createChatCompletion sends req, streaming the reply to ctx's sink when there is one. The
+// streamed reply is assembled into the response CreateChatCompletion would have returned.
+func (s *AIScorer) createChatCompletion(ctx context.Context, req openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+	sink := streamFrom(ctx)
+	if sink == nil {
+		return s.client.CreateChatCompletion(ctx, req)
+	}
+	return streamChat(ctx, s.client, req, sink)
+}
+
+func streamChat(ctx context.Context, client *openai.Client, req openai.ChatCompletionRequest, sink StreamSink) (openai.ChatCompletionResponse, error) {
+	req.Stream = true
+	req.StreamOptions = &openai.StreamOptions{IncludeUsage: true}
+	stream, err := client.CreateChatCompletionStream(ctx, req)
+	if err != nil {
+		return openai.ChatCompletionResponse{}, err
+	}
+	defer stream.Close()
+
+	sink.Begin()
+	resp := openai.ChatCompletionResponse{Object: "chat.completion"}
+	var content strings.Builder
+	var finish openai.FinishReason
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return openai.ChatCompletionResponse{}, err
+		}
+		resp.ID, resp.Created, resp.Model = chunk.ID, chunk.Created, chunk.Model
+		if chunk.Usage != nil {
+			resp.Usage = *chunk.Usage
+		}
+		for _, c := range chunk.Choices {
+			if c.Index != 0 {
+				continue
+			}
+			if c.Delta.Content != "" {
+				content.WriteString(c.Delta.Content)
+				sink.Delta(c.Delta.Content)
+			}
+			if c.FinishReason != "" {
+				finish = c.FinishReason
+			}
+		}
+	}
+	resp.Choices = []openai.ChatCompletionChoice{{
+		Message:      openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: content.String()},
+		FinishReason: finish,
+	}}
+	return resp, nil
+}
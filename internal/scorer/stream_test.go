@@ -0,0 +1,72 @@
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+type recordingSink struct {
+	begins int
+	deltas []string
+}
+
+func (s *recordingSink) Begin()            { s.begins++ }
+func (s *recordingSink) Delta(text string) { s.deltas = append(s.deltas, text) }
+
+func TestStreamChat(t *testing.T) {
+	var got openai.ChatCompletionRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, chunk := range []string{
+			`{"id":"c1","model":"gpt-4o-mini","choices":[{"index":0,"delta":{"role":"assistant","content":""}}]}`,
+			`{"id":"c1","model":"gpt-4o-mini","choices":[{"index":0,"delta":{"content":"{\"score\": 82, "}}]}`,
+			`{"id":"c1","model":"gpt-4o-mini","choices":[{"index":0,"delta":{"content":"\"notes\": \"Vegan menu\"}"},"finish_reason":"stop"}]}`,
+			`{"id":"c1","model":"gpt-4o-mini","choices":[],"usage":{"prompt_tokens":120,"completion_tokens":14,"total_tokens":134}}`,
+		} {
+			fmt.Fprintf(w, "data: %s\n\n", chunk)
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer srv.Close()
+	cfg := openai.DefaultConfig("test")
+	cfg.BaseURL = srv.URL
+	client := openai.NewClientWithConfig(cfg)
+
+	sink := &recordingSink{}
+	resp, err := streamChat(context.Background(), client, openai.ChatCompletionRequest{Model: openai.GPT4oMini}, sink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Stream || got.StreamOptions == nil || !got.StreamOptions.IncludeUsage {
+		t.Errorf("request did not ask for a stream with usage: %+v", got)
+	}
+	want := `{"score": 82, "notes": "Vegan menu"}`
+	if len(resp.Choices) != 1 || resp.Choices[0].Message.Content != want || resp.Choices[0].FinishReason != openai.FinishReasonStop {
+		t.Errorf("choices = %+v", resp.Choices)
+	}
+	if resp.Usage.PromptTokens != 120 || resp.Usage.CompletionTokens != 14 {
+		t.Errorf("usage = %+v", resp.Usage)
+	}
+	if sink.begins != 1 || strings.Join(sink.deltas, "") != want || len(sink.deltas) != 2 {
+		t.Errorf("sink saw %d begins and deltas %q", sink.begins, sink.deltas)
+	}
+}
+
+func TestStreamFrom(t *testing.T) {
+	ctx := context.Background()
+	if streamFrom(ctx) != nil {
+		t.Fatal("plain context has a stream")
+	}
+	sink := &recordingSink{}
+	if streamFrom(WithStream(ctx, sink)) != sink {
+		t.Error("WithStream sink not found")
+	}
+}
@@ -0,0 +1,72 @@
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// translationPromptVersion labels translation usage metrics; the prompt is built in code.
+const translationPromptVersion = "translation@v1"
+
+const translationSystemPrompt = `You translate restaurant and shop descriptions for a vegan/vegetarian directory into English.
+Detect the source language. Keep dish names recognisable (add the original in parentheses when it helps), keep facts, add nothing.
+Respond with JSON only: {"language": "<ISO 639-1 code>", "translation": "<English text>"}.
+If the text is already English, return it unchanged with "language": "en".`
+
+// Translator translates venue text to English with a chat model.
+type Translator struct {
+	client  *openai.Client
+	model   string
+	timeout time.Duration
+}
+
+func NewTranslator(apiKey, model string, timeout time.Duration) *Translator {
+	if model == "" {
+		model = openai.GPT4oMini
+	}
+	return &Translator{client: openai.NewClient(apiKey), model: model, timeout: timeout}
+}
+
+// Translate returns the English text and the detected source language (ISO 639-1).
+func (t *Translator) Translate(ctx context.Context, text string) (string, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	resp, err := t.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model: t.model,
+		Messages: []openai.ChatCompletionMessage{
+			{Role: openai.ChatMessageRoleSystem, Content: translationSystemPrompt},
+			{Role: openai.ChatMessageRoleUser, Content: text},
+		},
+		Temperature:    0.0,
+		MaxTokens:      1000,
+		ResponseFormat: &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject},
+	})
+	if err != nil {
+		return "", "", err
+	}
+	if len(resp.Choices) == 0 {
+		return "", "", fmt.Errorf("translation: empty response")
+	}
+	recordUsage(t.model, translationPromptVersion, callTypeTranslation, resp.Usage)
+	return parseTranslation(resp.Choices[0].Message.Content)
+}
+
+func parseTranslation(content string) (string, string, error) {
+	var out struct {
+		Language    string `json:"language"`
+		Translation string `json:"translation"`
+	}
+	if err := json.Unmarshal([]byte(content), &out); err != nil {
+		return "", "", fmt.Errorf("translation: invalid JSON: %w", err)
+	}
+	if strings.TrimSpace(out.Translation) == "" {
+		return "", "", fmt.Errorf("translation: empty translation")
+	}
+	return out.Translation, strings.ToLower(strings.TrimSpace(out.Language)), nil
+}
@@ -0,0 +1,74 @@
+package scorer
+
+import (
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// Call types used as the call_type label on OpenAI usage metrics.
+const (
+	callTypeScoring       = "scoring"
+	callTypeQualityReview = "quality_review"
+	callTypePhotoCheck    = "photo_check"
+	callTypeTranslation   = "translation"
+	callTypeBatchScoring  = "batch_scoring"
+	callTypeChangeReview  = "change_review"
+	callTypeVeganStatus   = "vegan_status"
+)
+
+// modelPricing is USD per 1K prompt/completion tokens. Prefix-matched so dated snapshots
+// ("gpt-4o-mini-2024-07-18") share their family's price; longest prefix wins.
+var modelPricing = map[string][2]float64{
+	"gpt-4o-mini":   {0.00015, 0.0006},
+	"gpt-4o":        {0.0025, 0.01},
+	"gpt-4.1-mini":  {0.0004, 0.0016},
+	"gpt-4.1-nano":  {0.0001, 0.0004},
+	"gpt-4.1":       {0.002, 0.008},
+	"gpt-3.5-turbo": {0.0005, 0.0015},
+}
+
+// batchDiscount is the Batch API price relative to synchronous calls.
+const batchDiscount = 0.5
+
+// fallbackPricing is used for models missing from modelPricing (gpt-4o-mini rates).
+var fallbackPricing = [2]float64{0.00015, 0.0006}
+
+var (
+	mOpenAIRequests = metrics.Default.CounterVec("openai_requests_total", "OpenAI chat completion calls", "model", "prompt_version", "call_type")
+	mOpenAITokens   = metrics.Default.CounterVec("openai_tokens_total", "OpenAI tokens used", "model", "prompt_version", "call_type", "kind")
+	mOpenAICost     = metrics.Default.CounterVec("openai_cost_usd_total", "Estimated OpenAI spend (USD)", "model", "prompt_version", "call_type")
+)
+
+// estimateCostUSD prices a completion's token usage for model.
+func estimateCostUSD(model string, u openai.Usage) float64 {
+	price, best := fallbackPricing, ""
+	for prefix, p := range modelPricing {
+		if strings.HasPrefix(model, prefix) && len(prefix) > len(best) {
+			price, best = p, prefix
+		}
+	}
+	return float64(u.PromptTokens)*price[0]/1000 + float64(u.CompletionTokens)*price[1]/1000
+}
+
+// recordUsage exports token usage and estimated cost for one completion, labelled so
+// dashboards can break spend down by model, prompt version and call type. Returns the cost.
+func recordUsage(model, promptVersion, callType string, u openai.Usage) float64 {
+	return exportUsage(model, promptVersion, callType, u, estimateCostUSD(model, u))
+}
+
+// recordBatchUsage is recordUsage for a scoring reply from the Batch API, priced at the
+// batch discount.
+func recordBatchUsage(model, promptVersion string, u openai.Usage) float64 {
+	return exportUsage(model, promptVersion, callTypeBatchScoring, u, estimateCostUSD(model, u)*batchDiscount)
+}
+
+func exportUsage(model, promptVersion, callType string, u openai.Usage, cost float64) float64 {
+	mOpenAIRequests.With(model, promptVersion, callType).Inc()
+	mOpenAITokens.With(model, promptVersion, callType, "prompt").Add(float64(u.PromptTokens))
+	mOpenAITokens.With(model, promptVersion, callType, "completion").Add(float64(u.CompletionTokens))
+	mOpenAICost.With(model, promptVersion, callType).Add(cost)
+	return cost
+}
@@ -0,0 +1,174 @@
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/internal/prompts"
+)
+
+// veganPromptVersion labels vegan status usage metrics; the user prompt is built in code.
+const veganPromptVersion = "vegan_system@v1"
+
+// maxVeganEvidence caps the quotes kept from one reply.
+const maxVeganEvidence = 5
+
+// VeganClassifier infers a venue's vegan status from its description, website text and
+// Google reviews, citing the quotes it relied on.
+type VeganClassifier struct {
+	client  *openai.Client
+	pm      *prompts.Manager
+	model   string
+	timeout time.Duration
+}
+
+func NewVeganClassifier(apiKey string, pm *prompts.Manager, model string, timeout time.Duration) *VeganClassifier {
+	if model == "" {
+		model = openai.GPT4oMini
+	}
+	return &VeganClassifier{
+		client:  openai.NewClient(apiKey),
+		pm:      pm,
+		model:   model,
+		timeout: timeout,
+	}
+}
+
+// ClassifyVeganStatus asks the model which vegan status src supports and compares it with the
+// venue's submitted vegan/vegonly flags. Quotes that do not appear in their source are dropped.
+func (vc *VeganClassifier) ClassifyVeganStatus(ctx context.Context, venue models.Venue, src models.VeganSources) (*models.VeganStatusAssessment, error) {
+	if strings.TrimSpace(src.Description) == "" && strings.TrimSpace(src.Website) == "" && len(src.Reviews) == 0 {
+		return nil, fmt.Errorf("no text to classify")
+	}
+	ctx, cancel := context.WithTimeout(ctx, vc.timeout)
+	defer cancel()
+
+	req := openai.ChatCompletionRequest{
+		Model: vc.model,
+		Messages: []openai.ChatCompletionMessage{
+			{Role: openai.ChatMessageRoleSystem, Content: vc.buildSystemPrompt(venue)},
+			{Role: openai.ChatMessageRoleUser, Content: buildVeganPrompt(src)},
+		},
+		Temperature:    0.0,
+		MaxTokens:      500,
+		ResponseFormat: &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject},
+	}
+	resp, err := vc.client.CreateChatCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	cost := recordUsage(vc.model, veganPromptVersion, callTypeVeganStatus, resp.Usage)
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("vegan status: empty response")
+	}
+
+	content, err := enforceSchema(ctx, vc.client.CreateChatCompletion, req, resp.Choices[0].Message.Content, veganSchema, veganPromptVersion, callTypeVeganStatus)
+	if err != nil {
+		return nil, err
+	}
+	va, err := parseVeganAssessment(content, src, models.SubmittedVeganStatus(venue))
+	if err != nil {
+		return nil, err
+	}
+	va.CostUSD = cost
+	return va, nil
+}
+
+func (vc *VeganClassifier) buildSystemPrompt(venue models.Venue) string {
+	path := ""
+	if venue.Path != nil {
+		path = *venue.Path
+	}
+	category := models.CategoryLabel(venue.EntryType, venue.Category)
+	if vc.pm != nil {
+		data := map[string]any{"VenueName": venue.Name, "VenuePath": path, "Category": category}
+		if out, err := vc.pm.Render("vegan_system", data); err == nil {
+			return out
+		}
+	}
+	return fmt.Sprintf("Decide from the sources whether %q (%s, %s) is vegan, vegetarian or veg-options, or unknown if they do not say. Reply as JSON with status, confidence (high, medium, low), evidence (source, review number, exact quote) and notes.",
+		venue.Name, path, category)
+}
+
+// buildVeganPrompt lists the sources, numbering reviews from 1 so evidence can point at them.
+func buildVeganPrompt(src models.VeganSources) string {
+	var b strings.Builder
+	if d := strings.TrimSpace(src.Description); d != "" {
+		fmt.Fprintf(&b, "Description:\n%s\n\n", d)
+	}
+	if w := strings.TrimSpace(src.Website); w != "" {
+		fmt.Fprintf(&b, "Website:\n%s\n\n", w)
+	}
+	if len(src.Reviews) > 0 {
+		b.WriteString("Google reviews:\n")
+		for i, r := range src.Reviews {
+			fmt.Fprintf(&b, "%d. (%.0f stars) %s\n", i+1, r.Rating, strings.TrimSpace(r.Text))
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// parseVeganAssessment reads a schema-checked reply. An unrecognised status counts as unknown
+// and an unrecognised confidence as low. Evidence is kept only when the quote appears in the
+// source it names, so the detail page never shows a quote the model made up.
+func parseVeganAssessment(content string, src models.VeganSources, submitted string) (*models.VeganStatusAssessment, error) {
+	var va models.VeganStatusAssessment
+	if err := json.Unmarshal([]byte(stripCodeFence(content)), &va); err != nil {
+		return nil, fmt.Errorf("failed to parse vegan status: %w", err)
+	}
+	switch s := strings.ToLower(strings.TrimSpace(va.Status)); s {
+	case models.VeganStatusVegan, models.VeganStatusVegetarian, models.VeganStatusVegOptions:
+		va.Status = s
+	case "veg options", "veg_options":
+		va.Status = models.VeganStatusVegOptions
+	default:
+		va.Status = models.VeganStatusUnknown
+	}
+	switch c := strings.ToLower(strings.TrimSpace(va.Confidence)); c {
+	case models.CategoryConfidenceHigh, models.CategoryConfidenceMedium:
+		va.Confidence = c
+	default:
+		va.Confidence = models.CategoryConfidenceLow
+	}
+
+	evidence := make([]models.VeganEvidence, 0, len(va.Evidence))
+	for _, e := range va.Evidence {
+		e.Source = strings.ToLower(strings.TrimSpace(e.Source))
+		e.Quote = strings.TrimSpace(e.Quote)
+		text := ""
+		switch e.Source {
+		case models.VeganSourceDescription:
+			text, e.Review = src.Description, 0
+		case models.VeganSourceWebsite:
+			text, e.Review = src.Website, 0
+		case models.VeganSourceReview:
+			if e.Review >= 1 && e.Review <= len(src.Reviews) {
+				text = src.Reviews[e.Review-1].Text
+			}
+		}
+		if e.Quote == "" || !containsFold(text, e.Quote) {
+			continue
+		}
+		evidence = append(evidence, e)
+		if len(evidence) == maxVeganEvidence {
+			break
+		}
+	}
+	va.Evidence = evidence
+	va.Submitted = submitted
+	va.Disagrees = va.Status != models.VeganStatusUnknown && va.Status != submitted
+	va.Reviews = len(src.Reviews)
+	return &va, nil
+}
+
+// containsFold reports whether quote appears in text, ignoring case and runs of whitespace.
+func containsFold(text, quote string) bool {
+	norm := func(s string) string { return strings.ToLower(strings.Join(strings.Fields(s), " ")) }
+	return strings.Contains(norm(text), norm(quote))
+}
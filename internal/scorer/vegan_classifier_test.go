@@ -0,0 +1,67 @@
+package scorer
+
+import (
+	"strings"
+	"testing"
+
+	"assisted-venue-approval/internal/models"
+)
+
+func TestBuildVeganPrompt(t *testing.T) {
+	got := buildVeganPrompt(models.VeganSources{
+		Description: " Plant-based kitchen. ",
+		Reviews:     []models.PlaceReview{{Rating: 5, Text: "Great tofu"}, {Rating: 2, Text: "Cold fries"}},
+	})
+	for _, want := range []string{"Description:\nPlant-based kitchen.\n", "1. (5 stars) Great tofu\n", "2. (2 stars) Cold fries"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("prompt missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Website:") {
+		t.Errorf("prompt lists an empty website:\n%s", got)
+	}
+}
+
+func TestParseVeganAssessment(t *testing.T) {
+	src := models.VeganSources{
+		Description: "A fully vegan cafe.",
+		Website:     "Try our   classic Cheese Burger with bacon.",
+		Reviews:     []models.PlaceReview{{Rating: 4, Text: "Loved the beef ramen"}},
+	}
+	reply := "```json\n" + `{"status": "Veg Options", "confidence": "HIGH", "evidence": [
+		{"source": "website", "quote": "classic cheese burger with bacon"},
+		{"source": "review", "review": 1, "quote": "the beef ramen"},
+		{"source": "review", "review": 2, "quote": "the beef ramen"},
+		{"source": "description", "quote": "serves chicken"}
+	], "notes": "description says vegan"}` + "\n```"
+	if problems := veganSchema.Validate(stripCodeFence(reply)); len(problems) > 0 {
+		t.Fatalf("schema problems: %v", problems)
+	}
+
+	va, err := parseVeganAssessment(reply, src, models.VeganStatusVegan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if va.Status != models.VeganStatusVegOptions || va.Confidence != models.CategoryConfidenceHigh || !va.Disagrees || va.Reviews != 1 {
+		t.Fatalf("assessment = %+v", va)
+	}
+	// Out-of-range review numbers and quotes missing from their source are dropped
+	if len(va.Evidence) != 2 || va.Evidence[0].Source != models.VeganSourceWebsite || va.Evidence[1].Review != 1 {
+		t.Fatalf("evidence = %+v", va.Evidence)
+	}
+
+	va, err = parseVeganAssessment(`{"status": "maybe", "confidence": "sure", "evidence": []}`, src, models.VeganStatusVegan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if va.Status != models.VeganStatusUnknown || va.Confidence != models.CategoryConfidenceLow || va.Disagrees {
+		t.Fatalf("assessment = %+v", va)
+	}
+}
+
+func TestVeganSchemaChecksEvidenceItems(t *testing.T) {
+	problems := veganSchema.Validate(`{"status": "vegan", "confidence": "high", "evidence": [{"source": "website"}]}`)
+	if len(problems) != 1 || !strings.Contains(problems[0], "$.evidence[0]") {
+		t.Fatalf("problems = %v", problems)
+	}
+}
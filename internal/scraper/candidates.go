@@ -0,0 +1,139 @@
+package scraper
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/utils"
+
+	"googlemaps.github.io/maps"
+)
+
+// Candidate ranking. Text Search orders results by Google's own relevance, which for short or
+// generic names often puts a neighbouring business first. Candidates are re-ranked by how well
+// they match the submission: name similarity, distance to the submitted coordinates and
+// whether the place is a food business at all.
+const (
+	maxPlaceCandidates = 5
+
+	candidateNameWeight     = 0.5
+	candidateDistanceWeight = 0.3
+	candidateTypeWeight     = 0.2
+
+	// Distance score is 1 within candidateNearMeters and falls to 0 at candidateFarMeters.
+	candidateNearMeters = 100.0
+	candidateFarMeters  = 2000.0
+
+	// A match is low confidence when the best candidate scores below minCandidateScore or
+	// the runner-up is within candidateMargin of it.
+	minCandidateScore = 0.6
+	candidateMargin   = 0.1
+)
+
+// foodPlaceTypes are Google place types that fit a HappyCow listing.
+var foodPlaceTypes = map[string]bool{
+	"restaurant": true, "cafe": true, "bakery": true, "bar": true, "meal_takeaway": true,
+	"meal_delivery": true, "food": true, "grocery_or_supermarket": true, "supermarket": true,
+	"health_food_store": true, "store": true, "coffee_shop": true, "ice_cream_shop": true,
+	"vegan_restaurant": true, "vegetarian_restaurant": true, "food_store": true, "grocery_store": true,
+}
+
+// placeTypeScore is 1 for food and shop types, 0.5 for a generic business and 0 otherwise
+// (localities, transit stops, parks...).
+func placeTypeScore(types []string) float64 {
+	score := 0.0
+	for _, t := range types {
+		if foodPlaceTypes[t] || strings.HasSuffix(t, "_restaurant") {
+			return 1
+		}
+		if t == "establishment" || t == "point_of_interest" {
+			score = 0.5
+		}
+	}
+	return score
+}
+
+func distanceScore(meters float64) float64 {
+	switch {
+	case meters <= candidateNearMeters:
+		return 1
+	case meters >= candidateFarMeters:
+		return 0
+	}
+	return 1 - (meters-candidateNearMeters)/(candidateFarMeters-candidateNearMeters)
+}
+
+// rankCandidates scores candidates against venue and returns at most maxPlaceCandidates of
+// them, best first. Without submitted coordinates the distance weight is spread over the rest.
+func rankCandidates(venue models.Venue, candidates []models.PlaceCandidate) []models.PlaceCandidate {
+	hasCoords := venue.Lat != nil && venue.Lng != nil
+	nameW, typeW := candidateNameWeight, candidateTypeWeight
+	if !hasCoords {
+		total := candidateNameWeight + candidateTypeWeight
+		nameW, typeW = candidateNameWeight/total, candidateTypeWeight/total
+	}
+
+	out := make([]models.PlaceCandidate, len(candidates))
+	copy(out, candidates)
+	for i := range out {
+		c := &out[i]
+		c.NameScore = round2(utils.CalculateStringSimilarity(strings.ToLower(venue.Name), strings.ToLower(c.Name)))
+		c.TypeScore = placeTypeScore(c.Types)
+		score := nameW*c.NameScore + typeW*c.TypeScore
+		if hasCoords {
+			d := calculateDistance(*venue.Lat, *venue.Lng, c.Lat, c.Lng)
+			c.DistanceMeters = &d
+			score += candidateDistanceWeight * distanceScore(d)
+		}
+		c.Score = round2(score)
+	}
+	// Stable so Google's order breaks ties
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+	if len(out) > maxPlaceCandidates {
+		out = out[:maxPlaceCandidates]
+	}
+	return out
+}
+
+// assessPlaceMatch rates the pick of ranked[0]. Pinned places are always confirmed.
+func assessPlaceMatch(ranked []models.PlaceCandidate, pinned bool) models.PlaceMatch {
+	if pinned {
+		return models.PlaceMatch{Confidence: models.MatchConfidenceConfirmed, Reason: "place picked by a reviewer"}
+	}
+	m := models.PlaceMatch{Confidence: models.MatchConfidenceHigh, Candidates: len(ranked)}
+	if len(ranked) == 0 {
+		return m
+	}
+	best := ranked[0]
+	m.BestScore = best.Score
+	switch {
+	case best.Score < minCandidateScore:
+		m.Confidence = models.MatchConfidenceLow
+		m.Reason = fmt.Sprintf("best candidate %q scored %.2f (< %.2f)", best.Name, best.Score, minCandidateScore)
+	case len(ranked) > 1 && best.Score-ranked[1].Score < candidateMargin:
+		m.Confidence = models.MatchConfidenceLow
+		m.Reason = fmt.Sprintf("%q (%.2f) and %q (%.2f) are too close to call", best.Name, best.Score, ranked[1].Name, ranked[1].Score)
+	}
+	return m
+}
+
+func candidatesFromSearch(results []maps.PlacesSearchResult) []models.PlaceCandidate {
+	out := make([]models.PlaceCandidate, 0, len(results))
+	for _, r := range results {
+		out = append(out, models.PlaceCandidate{
+			PlaceID: r.PlaceID,
+			Name:    r.Name,
+			Address: r.FormattedAddress,
+			Lat:     r.Geometry.Location.Lat,
+			Lng:     r.Geometry.Location.Lng,
+			Types:   r.Types,
+		})
+	}
+	return out
+}
+
+func round2(f float64) float64 {
+	return float64(int(f*100+0.5)) / 100
+}
@@ -0,0 +1,101 @@
+package scraper
+
+import (
+	"testing"
+
+	"assisted-venue-approval/internal/models"
+)
+
+func TestRankCandidates(t *testing.T) {
+	lat, lng := 52.5200, 13.4050
+	// Google's first result is a hotel next door; the venue is second
+	candidates := []models.PlaceCandidate{
+		{PlaceID: "hotel", Name: "Hotel Adlon", Lat: 52.5201, Lng: 13.4051, Types: []string{"lodging", "establishment"}},
+		{PlaceID: "venue", Name: "Green Bowl", Lat: 52.5202, Lng: 13.4049, Types: []string{"restaurant", "food"}},
+		{PlaceID: "far", Name: "Green Bowl", Lat: 52.6000, Lng: 13.5000, Types: []string{"restaurant"}},
+	}
+
+	tests := []struct {
+		name    string
+		venue   models.Venue
+		wantTop string
+		wantLen int
+	}{
+		{"with coordinates", models.Venue{Name: "Green Bowl", Lat: &lat, Lng: &lng}, "venue", 3},
+		{"without coordinates", models.Venue{Name: "Green Bowl"}, "venue", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranked := rankCandidates(tt.venue, candidates)
+			if len(ranked) != tt.wantLen || ranked[0].PlaceID != tt.wantTop {
+				t.Fatalf("ranked = %+v", ranked)
+			}
+			if (tt.venue.Lat != nil) != (ranked[0].DistanceMeters != nil) {
+				t.Errorf("distance = %v", ranked[0].DistanceMeters)
+			}
+		})
+	}
+
+	// The nearby copy beats the one 10 km away only when distance is known
+	ranked := rankCandidates(models.Venue{Name: "Green Bowl", Lat: &lat, Lng: &lng}, candidates)
+	if ranked[1].PlaceID != "far" || ranked[0].Score <= ranked[1].Score {
+		t.Errorf("ranked = %+v", ranked)
+	}
+	if candidates[0].Score != 0 {
+		t.Error("rankCandidates modified its input")
+	}
+}
+
+func TestRankCandidates_Limit(t *testing.T) {
+	var many []models.PlaceCandidate
+	for i := 0; i < 8; i++ {
+		many = append(many, models.PlaceCandidate{PlaceID: string(rune('a' + i)), Name: "Leaf"})
+	}
+	if got := rankCandidates(models.Venue{Name: "Leaf"}, many); len(got) != maxPlaceCandidates || got[0].PlaceID != "a" {
+		t.Errorf("got %d candidates, first %q", len(got), got[0].PlaceID)
+	}
+}
+
+func TestAssessPlaceMatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		ranked []models.PlaceCandidate
+		pinned bool
+		want   string
+	}{
+		{"pinned", nil, true, models.MatchConfidenceConfirmed},
+		{"single strong", []models.PlaceCandidate{{Score: 0.9}}, false, models.MatchConfidenceHigh},
+		{"clear winner", []models.PlaceCandidate{{Score: 0.9}, {Score: 0.5}}, false, models.MatchConfidenceHigh},
+		{"weak best", []models.PlaceCandidate{{Score: 0.55}}, false, models.MatchConfidenceLow},
+		{"close runner-up", []models.PlaceCandidate{{Score: 0.85}, {Score: 0.8}}, false, models.MatchConfidenceLow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := assessPlaceMatch(tt.ranked, tt.pinned)
+			if m.Confidence != tt.want {
+				t.Errorf("confidence = %s (%s), want %s", m.Confidence, m.Reason, tt.want)
+			}
+			if m.Confidence == models.MatchConfidenceLow && m.Reason == "" {
+				t.Error("low confidence without a reason")
+			}
+		})
+	}
+}
+
+func TestPlaceTypeScore(t *testing.T) {
+	tests := []struct {
+		types []string
+		want  float64
+	}{
+		{[]string{"restaurant", "food", "establishment"}, 1},
+		{[]string{"thai_restaurant"}, 1},
+		{[]string{"lodging", "point_of_interest", "establishment"}, 0.5},
+		{[]string{"locality", "political"}, 0},
+		{nil, 0},
+	}
+	for _, tt := range tests {
+		if got := placeTypeScore(tt.types); got != tt.want {
+			t.Errorf("placeTypeScore(%v) = %v, want %v", tt.types, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package scraper
+
+import (
+	"context"
+	"strings"
+
+	"assisted-venue-approval/internal/constants"
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/geography"
+
+	"googlemaps.github.io/maps"
+)
+
+const (
+	geofenceSourcePlace   = "google_place"
+	geofenceSourceReverse = "reverse_geocode"
+)
+
+// SetReverseGeocode lets the geo-fence check reverse-geocode user coordinates when no Google
+// place was matched. Each lookup is a billable Geocoding request, so it is off by default.
+func (s *GoogleMapsScraper) SetReverseGeocode(enabled bool) {
+	s.reverseGeocode = enabled
+}
+
+// checkGeofence compares the venue path with the address at the venue coordinates and records
+// mismatches as conflicts. Uses the matched place when available, else an optional reverse geocode.
+func (s *GoogleMapsScraper) checkGeofence(ctx context.Context, venue *models.Venue) {
+	if venue.ValidationDetails == nil || venue.Path == nil || strings.TrimSpace(*venue.Path) == "" {
+		return
+	}
+
+	var comps []maps.AddressComponent
+	source := geofenceSourcePlace
+	if venue.GoogleData != nil {
+		for _, c := range venue.GoogleData.AddressComponents {
+			comps = append(comps, maps.AddressComponent{LongName: c.LongName, ShortName: c.ShortName, Types: c.Types})
+		}
+	} else if s.reverseGeocode && venue.Lat != nil && venue.Lng != nil && !(*venue.Lat == 0 && *venue.Lng == 0) {
+		source = geofenceSourceReverse
+		comps = s.reverseGeocodeComponents(ctx, *venue.Lat, *venue.Lng)
+	}
+	if len(comps) == 0 {
+		return
+	}
+
+	m := geography.CompareRegion(*venue.Path, comps)
+	if m.PathCountry == "" || m.PlaceCountry == "" {
+		return
+	}
+	gc := &models.GeofenceCheck{
+		Source:       source,
+		PathCountry:  m.PathCountry,
+		PlaceCountry: m.PlaceCountry,
+		CountryMatch: m.CountryMatch,
+		PathRegions:  m.PathRegions,
+		PlaceRegions: m.PlaceRegions,
+	}
+	if m.RegionChecked {
+		match := m.RegionMatch
+		gc.RegionMatch = &match
+	}
+	venue.ValidationDetails.Geofence = gc
+
+	if !m.CountryMatch {
+		venue.ValidationDetails.Conflicts = append(venue.ValidationDetails.Conflicts, models.DataConflict{
+			Field:         "path_country",
+			HappyCowValue: m.PathCountry,
+			GoogleValue:   m.PlaceCountry,
+			Resolution:    "manual_review",
+		})
+	} else if m.RegionChecked && !m.RegionMatch {
+		venue.ValidationDetails.Conflicts = append(venue.ValidationDetails.Conflicts, models.DataConflict{
+			Field:         "path_region",
+			HappyCowValue: strings.Join(m.PathRegions, "|"),
+			GoogleValue:   strings.Join(m.PlaceRegions, ", "),
+			Resolution:    "manual_review",
+		})
+	}
+}
+
+// reverseGeocodeComponents returns the address components of the most specific result, or nil on error.
+func (s *GoogleMapsScraper) reverseGeocodeComponents(ctx context.Context, lat, lng float64) []maps.AddressComponent {
+	ctx, cancel := context.WithTimeout(ctx, constants.GoogleMapsRequestTimeout)
+	defer cancel()
+
+	var comps []maps.AddressComponent
+	_ = s.cb.Do(ctx, func(ctx context.Context) error {
+		return s.keys.do(ctx, "scraper.ReverseGeocode", func(k *poolKey) error {
+			res, err := k.client.ReverseGeocode(ctx, &maps.GeocodingRequest{LatLng: &maps.LatLng{Lat: lat, Lng: lng}})
+			if err != nil {
+				return err
+			}
+			s.cost.record(k.Name, SKUGeocoding)
+			if len(res) > 0 {
+				comps = res[0].AddressComponents
+			}
+			return nil
+		})
+	}, nil)
+	return comps
+}
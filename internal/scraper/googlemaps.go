@@ -2,7 +2,9 @@ package scraper
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"math"
 	"regexp"
 	"sort"
@@ -11,8 +13,10 @@ import (
 	"time"
 
 	"assisted-venue-approval/internal/constants"
+	"assisted-venue-approval/internal/flags"
 	"assisted-venue-approval/internal/models"
 	"assisted-venue-approval/pkg/circuit"
+	errs "assisted-venue-approval/pkg/errors"
 	"assisted-venue-approval/pkg/geography"
 	"assisted-venue-approval/pkg/utils"
 
@@ -20,12 +24,19 @@ import (
 )
 
 type GoogleMapsScraper struct {
-	client *maps.Client
-	cb     *circuit.Breaker
+	keys           *keyPool
+	places         *placesNewClient
+	cost           *placesCost
+	cb             *circuit.Breaker
+	reverseGeocode bool
+	placesNewPct   int // share of venues (0-100) looked up with the Places API (New)
+	flags          *flags.Service
 }
 
-func NewGoogleMapsScraper(apiKey string) (*GoogleMapsScraper, error) {
-	client, err := maps.NewClient(maps.WithAPIKey(apiKey))
+// NewGoogleMapsScraper spreads requests over keys (see ParseAPIKeys); a key Google rejects
+// for quota or auth is skipped for a while and the request moves to the next one.
+func NewGoogleMapsScraper(keys []APIKey) (*GoogleMapsScraper, error) {
+	pool, err := newKeyPool(keys)
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +53,73 @@ func NewGoogleMapsScraper(apiKey string) (*GoogleMapsScraper, error) {
 		SlowCallRate:      constants.CircuitSlowCallRate,
 	}, nil)
 
-	return &GoogleMapsScraper{client: client, cb: cb}, nil
+	cost := newPlacesCost()
+	return &GoogleMapsScraper{keys: pool, places: newPlacesNewClient(pool, cost), cost: cost, cb: cb}, nil
+}
+
+// SetPlacesAPINewPercent routes pct percent of venues (0-100) to the Places API (New) instead
+// of the legacy API. The split is by venue ID, so a venue always uses the same API.
+func (s *GoogleMapsScraper) SetPlacesAPINewPercent(pct int) {
+	s.placesNewPct = pct
+}
+
+// SetFeatureFlags lets the places_api_new flag, when stored, decide the split instead.
+func (s *GoogleMapsScraper) SetFeatureFlags(f *flags.Service) {
+	s.flags = f
+}
+
+func (s *GoogleMapsScraper) usePlacesNew(venueID int64) bool {
+	return s.flags.Enabled(flags.PlacesAPINew, venueID, s.placesNewPct > 0 && venueID%100 < int64(s.placesNewPct))
+}
+
+// LookupCostUSD is the list price of looking venueID up (one search plus one Place Details
+// call) on the API it is routed to. Photos are fetched on demand and not included.
+func (s *GoogleMapsScraper) LookupCostUSD(venueID int64) float64 {
+	if s.usePlacesNew(venueID) {
+		return priceUSD(SKUTextSearchPro, placeDetailsSKU(placesDetailsFields))
+	}
+	return priceUSD(legacyTextSearchSKUs...) + priceUSD(legacyDetailsSKUs...)
+}
+
+// PlacesUsage returns the estimated Google Maps Platform spend per SKU since start.
+func (s *GoogleMapsScraper) PlacesUsage() []models.PlacesSKUUsage {
+	return s.cost.usage()
+}
+
+// KeyUsage returns the requests, estimated spend and availability of each API key since start.
+func (s *GoogleMapsScraper) KeyUsage() []models.GoogleKeyUsage {
+	out := s.keys.usage()
+	for i := range out {
+		out[i].CostUSD = s.cost.keyCostUSD(out[i].Name)
+	}
+	return out
+}
+
+// mapsStatusClasses maps the status strings the maps SDK puts in its errors
+// ("maps: OVER_QUERY_LIMIT - ...") to error classes.
+var mapsStatusClasses = map[string]errs.Class{
+	"OVER_QUERY_LIMIT":          errs.ClassRateLimit,
+	"OVER_DAILY_LIMIT":          errs.ClassAuth, // billing/key problem; retrying will not help
+	"REQUEST_DENIED":            errs.ClassAuth,
+	"INVALID_REQUEST":           errs.ClassClient,
+	"NOT_FOUND":                 errs.ClassClient,
+	"MAX_ROUTE_LENGTH_EXCEEDED": errs.ClassClient,
+	"UNKNOWN_ERROR":             errs.ClassServer,
+}
+
+// classifyMapsError wraps a maps SDK error in a classified ExternalAPIError.
+// An open circuit is left alone: the breaker already decided to stop calling Google.
+func classifyMapsError(op string, err error) error {
+	if err == nil || errors.Is(err, circuit.ErrOpen) {
+		return err
+	}
+	if rest, ok := strings.CutPrefix(err.Error(), "maps: "); ok {
+		status, _, _ := strings.Cut(rest, " ")
+		if class, ok := mapsStatusClasses[status]; ok {
+			return errs.NewExternalClass(op, "google", class, err)
+		}
+	}
+	return errs.NewExternalStatus(op, "google", 0, 0, err)
 }
 
 type EnhancedVenueData struct {
@@ -53,6 +130,7 @@ type EnhancedVenueData struct {
 	HasPhone       bool
 	Rating         float32
 	ReviewCount    int
+	Candidates     []models.PlaceCandidate // ranked search results; empty when venue.GooglePlaceID was pinned
 }
 
 func (s *GoogleMapsScraper) EnhanceVenue(ctx context.Context, venue models.Venue) (*EnhancedVenueData, error) {
@@ -67,32 +145,19 @@ func (s *GoogleMapsScraper) EnhanceVenue(ctx context.Context, venue models.Venue
 	default:
 	}
 
-	// Search for the place using name and location
-	searchReq := &maps.TextSearchRequest{
-		Query: venue.Name + " " + venue.Location,
-	}
-
-	var searchResp *maps.PlacesSearchResponse
-	var err error
-	// Use circuit breaker for TextSearch
-	err = s.cb.Do(ctx, func(ctx context.Context) error {
-		resp, e := s.client.TextSearch(ctx, searchReq)
-		if e != nil {
-			return e
+	// A reviewer-pinned place skips the search
+	placeID := venue.GooglePlaceID
+	var candidates []models.PlaceCandidate
+	var searchRating float64
+	if placeID == "" {
+		var err error
+		candidates, searchRating, err = s.searchCandidates(ctx, venue, venue.Name+" "+venue.Location)
+		if err != nil || len(candidates) == 0 {
+			return &EnhancedVenueData{Venue: venue}, err
 		}
-		searchResp = &resp
-		return nil
-	}, func(ctx context.Context, cause error) error {
-		// Fallback: fail soft with minimal data
-		searchResp = nil
-		return nil
-	})
-	if err != nil || searchResp == nil || len(searchResp.Results) == 0 {
-		return &EnhancedVenueData{Venue: venue}, nil
+		placeID = candidates[0].PlaceID
 	}
 
-	placeID := searchResp.Results[0].PlaceID
-
 	// Get detailed information
 	detailsReq := &maps.PlaceDetailsRequest{
 		PlaceID: placeID,
@@ -109,23 +174,31 @@ func (s *GoogleMapsScraper) EnhanceVenue(ctx context.Context, venue models.Venue
 			maps.PlaceDetailsFieldMaskUserRatingsTotal,
 			maps.PlaceDetailsFieldMaskBusinessStatus,
 			maps.PlaceDetailsFieldMaskOpeningHours,
+			maps.PlaceDetailsFieldMaskPhotos, // Basic Data SKU; references only, images are fetched on demand
 		},
 	}
 
 	var details maps.PlaceDetailsResult
-	err = s.cb.Do(ctx, func(ctx context.Context) error {
-		d, e := s.client.PlaceDetails(ctx, detailsReq)
-		if e != nil {
-			return e
-		}
-		details = d
-		return nil
+	err := s.cb.Do(ctx, func(ctx context.Context) error {
+		return s.keys.do(ctx, "scraper.PlaceDetails", func(k *poolKey) error {
+			d, e := k.client.PlaceDetails(ctx, detailsReq)
+			if e != nil {
+				return e
+			}
+			// Phone, website and hours are Contact Data; user_ratings_total is Atmosphere Data
+			s.cost.record(k.Name, legacyDetailsSKUs...)
+			details = d
+			return nil
+		})
 	}, func(ctx context.Context, cause error) error {
+		if cerr := classifyMapsError("scraper.PlaceDetails", cause); errs.Classify(cerr).Transient() {
+			return cerr
+		}
 		// Fallback: return minimal data from search result
 		return nil
 	})
 	if err != nil {
-		return &EnhancedVenueData{Venue: venue}, nil
+		return &EnhancedVenueData{Venue: venue}, err
 	}
 
 	enhanced := &EnhancedVenueData{
@@ -135,13 +208,12 @@ func (s *GoogleMapsScraper) EnhanceVenue(ctx context.Context, venue models.Venue
 		HasPhone:     details.FormattedPhoneNumber != "",
 		Rating:       details.Rating,
 		ReviewCount:  details.UserRatingsTotal,
+		Candidates:   candidates,
 	}
 
 	// Fallback: if rating not returned in details but present in TextSearch results
-	if (enhanced.Rating == 0 || math.IsNaN(float64(enhanced.Rating))) && enhanced.ReviewCount > 0 && len(searchResp.Results) > 0 {
-		if searchResp.Results[0].Rating > 0 {
-			enhanced.Rating = float32(searchResp.Results[0].Rating)
-		}
+	if (enhanced.Rating == 0 || math.IsNaN(float64(enhanced.Rating))) && enhanced.ReviewCount > 0 && searchRating > 0 {
+		enhanced.Rating = float32(searchRating)
 	}
 
 	if enhanced.Rating == 0 && enhanced.ReviewCount > 0 {
@@ -155,26 +227,74 @@ func (s *GoogleMapsScraper) EnhanceVenue(ctx context.Context, venue models.Venue
 	return enhanced, nil
 }
 
-// NormalizedHours Opening hours normalization functions
-type NormalizedHours struct {
-	Monday    []TimeRange `json:"monday"`
-	Tuesday   []TimeRange `json:"tuesday"`
-	Wednesday []TimeRange `json:"wednesday"`
-	Thursday  []TimeRange `json:"thursday"`
-	Friday    []TimeRange `json:"friday"`
-	Saturday  []TimeRange `json:"saturday"`
-	Sunday    []TimeRange `json:"sunday"`
+// searchCandidates runs a Text Search for query and returns the candidates ranked against
+// venue plus the search rating of the best one. Non-transient failures return no candidates.
+func (s *GoogleMapsScraper) searchCandidates(ctx context.Context, venue models.Venue, query string) ([]models.PlaceCandidate, float64, error) {
+	searchReq := &maps.TextSearchRequest{Query: query}
+
+	var searchResp *maps.PlacesSearchResponse
+	var err error
+	// Use circuit breaker for TextSearch
+	err = s.cb.Do(ctx, func(ctx context.Context) error {
+		return s.keys.do(ctx, "scraper.TextSearch", func(k *poolKey) error {
+			resp, e := k.client.TextSearch(ctx, searchReq)
+			if e != nil {
+				return e
+			}
+			// Legacy Text Search returns every field, so it also bills both data SKUs
+			s.cost.record(k.Name, legacyTextSearchSKUs...)
+			searchResp = &resp
+			return nil
+		})
+	}, func(ctx context.Context, cause error) error {
+		// Transient failures are returned so the engine can retry; anything else fails soft
+		if cerr := classifyMapsError("scraper.TextSearch", cause); errs.Classify(cerr).Transient() {
+			return cerr
+		}
+		searchResp = nil
+		return nil
+	})
+	if err != nil || searchResp == nil {
+		return nil, 0, err
+	}
+	ranked := rankCandidates(venue, candidatesFromSearch(searchResp.Results))
+	for _, r := range searchResp.Results {
+		if len(ranked) > 0 && r.PlaceID == ranked[0].PlaceID {
+			return ranked, float64(r.Rating), nil
+		}
+	}
+	return ranked, 0, nil
 }
 
-type TimeRange struct {
-	Open  string `json:"open"`  // "HH:MM" format
-	Close string `json:"close"` // "HH:MM" format
+// SearchPlaceCandidates searches Google for query (the venue's name and location when empty)
+// and ranks the results against venue, for reviewers re-linking a venue to another place.
+func (s *GoogleMapsScraper) SearchPlaceCandidates(ctx context.Context, venue models.Venue, query string) ([]models.PlaceCandidate, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.GoogleMapsRequestTimeout)
+	defer cancel()
+
+	if strings.TrimSpace(query) == "" {
+		query = venue.Name + " " + venue.Location
+	}
+	if !s.usePlacesNew(venue.ID) {
+		ranked, _, err := s.searchCandidates(ctx, venue, query)
+		return ranked, err
+	}
+	var found []models.PlaceCandidate
+	err := s.cb.Do(ctx, func(ctx context.Context) error {
+		c, e := s.places.searchPlaces(ctx, query)
+		found = c
+		return e
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return rankCandidates(venue, found), nil
 }
 
 // ParseGoogleOpeningHours Parse Google Maps opening hours format
-func ParseGoogleOpeningHours(periods []maps.OpeningHoursPeriod) NormalizedHours {
-	hours := NormalizedHours{}
-	dayMap := map[time.Weekday]*[]TimeRange{
+func ParseGoogleOpeningHours(periods []maps.OpeningHoursPeriod) models.NormalizedHours {
+	hours := models.NormalizedHours{}
+	dayMap := map[time.Weekday]*[]models.TimeRange{
 		time.Sunday:    &hours.Sunday,
 		time.Monday:    &hours.Monday,
 		time.Tuesday:   &hours.Tuesday,
@@ -188,7 +308,7 @@ func ParseGoogleOpeningHours(periods []maps.OpeningHoursPeriod) NormalizedHours
 		if period.Open.Day >= 0 && period.Open.Day <= 6 {
 			day := time.Weekday(period.Open.Day)
 			if dayHours, exists := dayMap[day]; exists {
-				timeRange := TimeRange{
+				timeRange := models.TimeRange{
 					Open:  formatGoogleTime(period.Open.Time),
 					Close: formatGoogleTime(period.Close.Time),
 				}
@@ -208,8 +328,8 @@ func ParseGoogleOpeningHours(periods []maps.OpeningHoursPeriod) NormalizedHours
 }
 
 // ParseHappyCowOpeningHours Parse HappyCow opening hours text format
-func ParseHappyCowOpeningHours(hoursText string) NormalizedHours {
-	hours := NormalizedHours{}
+func ParseHappyCowOpeningHours(hoursText string) models.NormalizedHours {
+	hours := models.NormalizedHours{}
 	if hoursText == "" {
 		return hours
 	}
@@ -250,8 +370,8 @@ func ParseHappyCowOpeningHours(hoursText string) NormalizedHours {
 }
 
 // Helper function to parse individual hours line
-func parseHoursLine(line string) map[string][]TimeRange {
-	result := make(map[string][]TimeRange)
+func parseHoursLine(line string) map[string][]models.TimeRange {
+	result := make(map[string][]models.TimeRange)
 
 	// Handle day ranges like "Mon-Fri"
 	dayPattern := regexp.MustCompile(`(?i)(mon|monday|tue|tuesday|wed|wednesday|thu|thursday|fri|friday|sat|saturday|sun|sunday)(?:day)?(?:\s*-\s*(mon|monday|tue|tuesday|wed|wednesday|thu|thursday|fri|friday|sat|saturday|sun|sunday)(?:day)?)?`)
@@ -272,7 +392,7 @@ func parseHoursLine(line string) map[string][]TimeRange {
 		closeTime := normalizeTime(timeMatch[4], timeMatch[5], timeMatch[6])
 
 		if openTime != "" && closeTime != "" {
-			timeRange := []TimeRange{{Open: openTime, Close: closeTime}}
+			timeRange := []models.TimeRange{{Open: openTime, Close: closeTime}}
 
 			// Apply to day range
 			days := expandDayRange(startDay, endDay)
@@ -372,7 +492,7 @@ func findDayIndex(day string) int {
 }
 
 // CompareOpeningHours Compare opening hours
-func CompareOpeningHours(hours1, hours2 NormalizedHours) float64 {
+func CompareOpeningHours(hours1, hours2 models.NormalizedHours) float64 {
 	dayScores := []float64{
 		compareTimeRanges(hours1.Monday, hours2.Monday),
 		compareTimeRanges(hours1.Tuesday, hours2.Tuesday),
@@ -391,7 +511,7 @@ func CompareOpeningHours(hours1, hours2 NormalizedHours) float64 {
 	return total / float64(len(dayScores))
 }
 
-func compareTimeRanges(ranges1, ranges2 []TimeRange) float64 {
+func compareTimeRanges(ranges1, ranges2 []models.TimeRange) float64 {
 	if len(ranges1) == 0 && len(ranges2) == 0 {
 		return 1.0 // Both closed
 	}
@@ -442,16 +562,22 @@ func abs(x int) int {
 }
 
 // CompareVenueData compares HappyCow venue data with Google Places data using normalization
+// and the active weights table (see SetWeights).
 func CompareVenueData(happyCowVenue models.Venue, googleData models.GooglePlaceData) models.ValidationDetails {
+	return CompareVenueDataWith(happyCowVenue, googleData, ActiveWeights())
+}
+
+// CompareVenueDataWith is CompareVenueData with an explicit weights table.
+func CompareVenueDataWith(happyCowVenue models.Venue, googleData models.GooglePlaceData, w Weights) models.ValidationDetails {
 	startTime := time.Now()
 
 	var conflicts []models.DataConflict
-	scoreBreakdown := models.ScoreBreakdown{}
+	scoreBreakdown := models.ScoreBreakdown{WeightProfile: w.Profile}
 
-	// 1. Venue Name Matching (25 points)
+	// 1. Venue Name Matching
 	nameScore := utils.CalculateStringSimilarity(strings.ToLower(happyCowVenue.Name),
 		strings.ToLower(googleData.Name))
-	scoreBreakdown.VenueNameMatch = int(nameScore * 25)
+	scoreBreakdown.VenueNameMatch = int(nameScore * float64(w.Name))
 
 	if nameScore < 0.8 {
 		conflicts = append(conflicts, models.DataConflict{
@@ -462,12 +588,12 @@ func CompareVenueData(happyCowVenue models.Venue, googleData models.GooglePlaceD
 		})
 	}
 
-	// 2. Address Accuracy (20 points)
+	// 2. Address Accuracy
 	addressScore := 0.0
 	if happyCowVenue.Location != "" && googleData.FormattedAddress != "" {
 		addressScore = utils.CompareAddresses(happyCowVenue.Location, googleData.FormattedAddress)
 	}
-	scoreBreakdown.AddressAccuracy = int(addressScore * 20)
+	scoreBreakdown.AddressAccuracy = int(addressScore * float64(w.Address))
 
 	if addressScore < 0.8 {
 		conflicts = append(conflicts, models.DataConflict{
@@ -478,7 +604,7 @@ func CompareVenueData(happyCowVenue models.Venue, googleData models.GooglePlaceD
 		})
 	}
 
-	// 3. Geolocation Accuracy (15 points)
+	// 3. Geolocation Accuracy
 	geoScore := 0.0
 	distanceMeters := 0.0
 	if happyCowVenue.Lat != nil && happyCowVenue.Lng != nil {
@@ -492,9 +618,9 @@ func CompareVenueData(happyCowVenue models.Venue, googleData models.GooglePlaceD
 			geoScore = 1.0 - (distanceMeters-50)/450
 		}
 	}
-	scoreBreakdown.GeolocationAccuracy = int(geoScore * 15)
+	scoreBreakdown.GeolocationAccuracy = int(geoScore * float64(w.Geolocation))
 
-	// 4. Phone Number Verification (10 points)
+	// 4. Phone Number Verification
 	phoneScore := 0.0
 	if happyCowVenue.Phone != nil && googleData.FormattedPhone != "" {
 		phoneScore = utils.ComparePhoneNumbers(*happyCowVenue.Phone, googleData.FormattedPhone)
@@ -503,7 +629,7 @@ func CompareVenueData(happyCowVenue models.Venue, googleData models.GooglePlaceD
 	} else {
 		phoneScore = 0.5 // One missing
 	}
-	scoreBreakdown.PhoneVerification = int(phoneScore * 10)
+	scoreBreakdown.PhoneVerification = int(phoneScore * float64(w.Phone))
 
 	if happyCowVenue.Phone != nil && googleData.FormattedPhone != "" && phoneScore < 0.8 {
 		conflicts = append(conflicts, models.DataConflict{
@@ -514,7 +640,7 @@ func CompareVenueData(happyCowVenue models.Venue, googleData models.GooglePlaceD
 		})
 	}
 
-	// 5. Website Verification (5 points)
+	// 5. Website Verification
 	websiteScore := 0.0
 	if happyCowVenue.URL != nil && googleData.Website != "" {
 		websiteScore = utils.CompareURLs(*happyCowVenue.URL, googleData.Website)
@@ -523,9 +649,9 @@ func CompareVenueData(happyCowVenue models.Venue, googleData models.GooglePlaceD
 	} else {
 		websiteScore = 0.5 // One missing
 	}
-	scoreBreakdown.WebsiteVerification = int(websiteScore * 5)
+	scoreBreakdown.WebsiteVerification = int(websiteScore * float64(w.Website))
 
-	// 6. Business Hours Verification (10 points)
+	// 6. Business Hours Verification
 	hoursScore := 0.0
 	if happyCowVenue.OpenHours != nil && googleData.OpeningHours != nil {
 		happyCowHours := ParseHappyCowOpeningHours(*happyCowVenue.OpenHours)
@@ -550,9 +676,9 @@ func CompareVenueData(happyCowVenue models.Venue, googleData models.GooglePlaceD
 	} else {
 		hoursScore = 0.5 // One missing
 	}
-	scoreBreakdown.BusinessHours = int(hoursScore * 10)
+	scoreBreakdown.BusinessHours = int(hoursScore * float64(w.Hours))
 
-	// 7. Business Status (5 points)
+	// 7. Business Status
 	statusScore := 0.0
 	switch googleData.BusinessStatus {
 	case "OPERATIONAL":
@@ -564,9 +690,9 @@ func CompareVenueData(happyCowVenue models.Venue, googleData models.GooglePlaceD
 	default:
 		statusScore = 0.4 // Unknown status
 	}
-	scoreBreakdown.BusinessStatus = int(statusScore * 5)
+	scoreBreakdown.BusinessStatus = int(statusScore * float64(w.BusinessStatus))
 
-	// 8. Postal Code (5 points) - Extract from Google address components
+	// 8. Postal Code - Extract from Google address components
 	postalScore := 0.0
 	googleZip := extractPostalCodeFromComponents(googleData.AddressComponents)
 	if happyCowVenue.Zipcode != nil && googleZip != "" {
@@ -584,9 +710,9 @@ func CompareVenueData(happyCowVenue models.Venue, googleData models.GooglePlaceD
 	} else {
 		postalScore = 0.4 // One missing
 	}
-	scoreBreakdown.PostalCode = int(postalScore * 5)
+	scoreBreakdown.PostalCode = int(postalScore * float64(w.PostalCode))
 
-	// 9. Vegan/Vegetarian Relevance (5 points) - Basic relevance check
+	// 9. Vegan/Vegetarian Relevance - Basic relevance check
 	veganScore := 1.0 // Assume relevant unless clear indicators suggest otherwise
 	if happyCowVenue.AdditionalInfo != nil {
 		additionalInfo := strings.ToLower(*happyCowVenue.AdditionalInfo)
@@ -594,7 +720,7 @@ func CompareVenueData(happyCowVenue models.Venue, googleData models.GooglePlaceD
 			veganScore = 0.2
 		}
 	}
-	scoreBreakdown.VeganRelevance = int(veganScore * 5)
+	scoreBreakdown.VeganRelevance = int(veganScore * float64(w.VeganRelevance))
 
 	// Calculate total score
 	scoreBreakdown.Total = scoreBreakdown.VenueNameMatch + scoreBreakdown.AddressAccuracy +
@@ -665,45 +791,145 @@ func extractPostalCodeFromComponents(components []models.AddressComponent) strin
 	return ""
 }
 
-// EnhanceVenueWithValidation Enhanced venue enhancement method that includes validation details
+// EnhanceVenueWithValidation Enhanced venue enhancement method that includes validation details.
+// When venue.GoogleData is already set (cached from an earlier validation) no Places call is
+// made; the venue is only compared against that data again.
 func (s *GoogleMapsScraper) EnhanceVenueWithValidation(ctx context.Context, venue models.Venue) (*models.Venue, error) {
-	// Get enhanced venue data
-	enhanced, err := s.EnhanceVenue(ctx, venue)
-	if err != nil {
-		return &venue, err
+	var googleData *models.GooglePlaceData
+	if venue.GoogleData != nil {
+		gd := *venue.GoogleData
+		googleData = &gd
+	} else if s.usePlacesNew(venue.ID) {
+		gd, err := s.lookupPlaceNew(ctx, venue)
+		if err != nil {
+			return &venue, err
+		}
+		googleData = gd
+	} else {
+		enhanced, err := s.EnhanceVenue(ctx, venue)
+		if err != nil {
+			return &venue, err
+		}
+		if enhanced.PlaceDetails != nil {
+			gd := convertToGooglePlaceData(*enhanced.PlaceDetails)
+			// Fallback: patch rating from TextSearch if details rating is absent
+			if gd.Rating == 0 && gd.UserRatingsTotal > 0 && enhanced.Rating > 0 {
+				gd.Rating = float64(enhanced.Rating)
+			}
+			gd.Candidates = enhanced.Candidates
+			googleData = &gd
+		}
 	}
 
 	// If no Google data found, return with minimal validation details
-	if enhanced.PlaceDetails == nil {
+	if googleData == nil {
 		venue.ValidationDetails = &models.ValidationDetails{
 			GooglePlaceFound:   false,
 			AutoDecisionReason: "No matching Google Place found - requires manual review",
 			ProcessingTimeMs:   0,
 		}
+		venue.ValidationDetails.Phone = models.CheckVenuePhone(venue)
+		s.checkGeofence(ctx, &venue)
 		return &venue, nil
 	}
 
-	// Convert Google Places data to our model format
-	googleData := convertToGooglePlaceData(*enhanced.PlaceDetails)
-	// Fallback: patch rating from TextSearch if details rating is absent
-	if googleData.Rating == 0 && googleData.UserRatingsTotal > 0 && enhanced.Rating > 0 {
-		googleData.Rating = float64(enhanced.Rating)
-	}
+	pinned := venue.GooglePlaceID != "" || googleData.MatchConfidence == models.MatchConfidenceConfirmed
+	match := assessPlaceMatch(googleData.Candidates, pinned)
+	googleData.MatchConfidence = match.Confidence
 
 	// Perform detailed comparison
-	validationDetails := CompareVenueData(venue, googleData)
+	validationDetails := CompareVenueData(venue, *googleData)
+	validationDetails.PlaceMatch = &match
 
 	// Add Google data to venue
-	venue.GoogleData = &googleData
-	venue.GooglePlaceID = enhanced.PlaceDetails.PlaceID
+	venue.GoogleData = googleData
+	venue.GooglePlaceID = googleData.PlaceID
 	venue.ValidationDetails = &validationDetails
 
 	// Fill missing venue data from Google where appropriate
-	fillMissingVenueData(&venue, googleData)
+	fillMissingVenueData(&venue, *googleData)
+
+	// Checked after filling so a Google-supplied number is normalised against the venue country too
+	venue.ValidationDetails.Phone = models.CheckVenuePhone(venue)
+	s.checkGeofence(ctx, &venue)
 
 	return &venue, nil
 }
 
+// lookupPlaceNew is EnhanceVenue on the Places API (New): a text search for candidates (skipped
+// when venue.GooglePlaceID is pinned), then one Place Details call. Returns nil data when no
+// place matched; failures follow EnhanceVenue (transient errors are returned for retry,
+// anything else means "not found").
+func (s *GoogleMapsScraper) lookupPlaceNew(ctx context.Context, venue models.Venue) (*models.GooglePlaceData, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.GoogleMapsRequestTimeout)
+	defer cancel()
+
+	softFail := func(ctx context.Context, cause error) error {
+		if errs.Classify(cause).Transient() {
+			return cause
+		}
+		return nil
+	}
+	placeID := venue.GooglePlaceID
+	var ranked []models.PlaceCandidate
+	if placeID == "" {
+		var found []models.PlaceCandidate
+		err := s.cb.Do(ctx, func(ctx context.Context) error {
+			c, e := s.places.searchPlaces(ctx, venue.Name+" "+venue.Location)
+			found = c
+			return e
+		}, softFail)
+		if err != nil || len(found) == 0 {
+			return nil, err
+		}
+		ranked = rankCandidates(venue, found)
+		placeID = ranked[0].PlaceID
+	}
+	var place *placeNew
+	err := s.cb.Do(ctx, func(ctx context.Context) error {
+		p, e := s.places.placeDetails(ctx, placeID)
+		place = p
+		return e
+	}, softFail)
+	if err != nil || place == nil {
+		return nil, err
+	}
+	gd := convertPlaceNew(*place)
+	gd.Candidates = ranked
+	return &gd, nil
+}
+
+// PlaceBusinessStatus returns Google's business status of placeID (OPERATIONAL,
+// CLOSED_TEMPORARILY, CLOSED_PERMANENTLY or empty when Google has none). It always uses the
+// Places API (New) with a one-field mask, the cheapest request that returns the status.
+func (s *GoogleMapsScraper) PlaceBusinessStatus(ctx context.Context, placeID string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.GoogleMapsRequestTimeout)
+	defer cancel()
+
+	var status string
+	err := s.cb.Do(ctx, func(ctx context.Context) error {
+		st, e := s.places.businessStatus(ctx, placeID)
+		status = st
+		return e
+	}, nil)
+	return status, err
+}
+
+// PlaceReviews returns up to five Google reviews of placeID. It always uses the Places API
+// (New) with a one-field mask; reviews bill at the Atmosphere SKU.
+func (s *GoogleMapsScraper) PlaceReviews(ctx context.Context, placeID string) ([]models.PlaceReview, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.GoogleMapsRequestTimeout)
+	defer cancel()
+
+	var reviews []models.PlaceReview
+	err := s.cb.Do(ctx, func(ctx context.Context) error {
+		r, e := s.places.reviews(ctx, placeID)
+		reviews = r
+		return e
+	}, nil)
+	return reviews, err
+}
+
 // Convert Google Places API response to our model format
 func convertToGooglePlaceData(details maps.PlaceDetailsResult) models.GooglePlaceData {
 	googleData := models.GooglePlaceData{
@@ -766,6 +992,15 @@ func convertToGooglePlaceData(details maps.PlaceDetailsResult) models.GooglePlac
 		googleData.OpeningHours = openingHours
 	}
 
+	for _, p := range details.Photos {
+		googleData.Photos = append(googleData.Photos, models.GooglePhoto{
+			Reference:    p.PhotoReference,
+			Width:        p.Width,
+			Height:       p.Height,
+			Attributions: p.HTMLAttributions,
+		})
+	}
+
 	// Convert address components
 	for _, component := range details.AddressComponents {
 		googleData.AddressComponents = append(googleData.AddressComponents, models.AddressComponent{
@@ -870,3 +1105,39 @@ func suggestVenuePath(venue *models.Venue, googleData models.GooglePlaceData) {
 		}
 	}
 }
+
+// maxPhotoBytes caps a downloaded photo; the vision check only needs a small image.
+const maxPhotoBytes = 2 << 20
+
+// FetchPhoto downloads a Place photo by reference. Each call is a billable Place Photo request.
+func (s *GoogleMapsScraper) FetchPhoto(ctx context.Context, reference string, maxWidth uint) (*models.VenuePhoto, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.GoogleMapsRequestTimeout)
+	defer cancel()
+
+	var photo *models.VenuePhoto
+	err := s.cb.Do(ctx, func(ctx context.Context) error {
+		if strings.HasPrefix(reference, "places/") { // photo name from the Places API (New)
+			p, e := s.places.photo(ctx, reference, maxWidth)
+			photo = p
+			return e
+		}
+		return s.keys.do(ctx, "scraper.PlacePhoto", func(k *poolKey) error {
+			resp, e := k.client.PlacePhoto(ctx, &maps.PlacePhotoRequest{PhotoReference: reference, MaxWidth: maxWidth})
+			if e != nil {
+				return e
+			}
+			defer resp.Data.Close()
+			data, e := io.ReadAll(io.LimitReader(resp.Data, maxPhotoBytes))
+			if e != nil {
+				return e
+			}
+			s.cost.record(k.Name, SKULegacyPhoto)
+			photo = &models.VenuePhoto{Data: data, ContentType: resp.ContentType}
+			return nil
+		})
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch place photo: %w", err)
+	}
+	return photo, nil
+}
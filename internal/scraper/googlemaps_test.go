@@ -1,9 +1,13 @@
 package scraper
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/circuit"
+	errs "assisted-venue-approval/pkg/errors"
 )
 
 func TestFillMissingVenueData_CoordinateOverride(t *testing.T) {
@@ -256,3 +260,52 @@ func TestSuggestVenuePath(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestClassifyMapsError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want errs.Class
+	}{
+		{fmt.Errorf("maps: OVER_QUERY_LIMIT - You have exceeded your rate-limit"), errs.ClassRateLimit},
+		{fmt.Errorf("maps: REQUEST_DENIED - The provided API key is invalid."), errs.ClassAuth},
+		{fmt.Errorf("maps: UNKNOWN_ERROR - "), errs.ClassServer},
+		{context.DeadlineExceeded, errs.ClassTimeout},
+		{circuit.ErrOpen, errs.ClassUnknown},
+	}
+	for _, tt := range tests {
+		if got := errs.Classify(classifyMapsError("op", tt.err)); got != tt.want {
+			t.Errorf("classifyMapsError(%v) = %s, want %s", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestEnhanceVenueWithValidation_CachedData(t *testing.T) {
+	// No clients: any Places call would panic
+	s := &GoogleMapsScraper{}
+	lat, lng := 52.52, 13.405
+	tests := []struct {
+		name string
+		gd   models.GooglePlaceData
+		want string
+	}{
+		{"confirmed place stays confirmed", models.GooglePlaceData{PlaceID: "p1", Name: "Green Bowl", MatchConfidence: models.MatchConfidenceConfirmed}, models.MatchConfidenceConfirmed},
+		{"stored candidates are re-assessed", models.GooglePlaceData{PlaceID: "p1", Name: "Green Bowl", Candidates: []models.PlaceCandidate{{PlaceID: "p1", Score: 0.9}, {PlaceID: "p2", Score: 0.85}}}, models.MatchConfidenceLow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gd := tt.gd
+			gd.Geometry.Location = models.GoogleLatLng{Lat: lat, Lng: lng}
+			venue := models.Venue{ID: 1, Name: "Green Bowl", Lat: &lat, Lng: &lng, GoogleData: &gd}
+			got, err := s.EnhanceVenueWithValidation(context.Background(), venue)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.GooglePlaceID != "p1" || got.ValidationDetails == nil || !got.ValidationDetails.GooglePlaceFound {
+				t.Fatalf("venue = %+v", got)
+			}
+			if pm := got.ValidationDetails.PlaceMatch; pm == nil || pm.Confidence != tt.want {
+				t.Errorf("place match = %+v, want %s", pm, tt.want)
+			}
+		})
+	}
+}
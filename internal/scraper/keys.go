@@ -0,0 +1,283 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+	"assisted-venue-approval/pkg/metrics"
+
+	"googlemaps.github.io/maps"
+)
+
+// How long a key is skipped after Google rejects it. Rate limits are usually per second or
+// per minute; an auth failure (revoked key, billing disabled, daily limit) needs someone to
+// act, so the key is only retried now and then.
+const (
+	keyRateLimitBench = time.Minute
+	keyAuthBench      = 15 * time.Minute
+)
+
+var errNoAPIKey = errors.New("no Google API key available")
+
+var (
+	mKeyRequests  = metrics.Default.CounterVec("google_maps_key_requests_total", "Google Maps Platform requests sent per API key", "key")
+	mKeyCost      = metrics.Default.CounterVec("google_maps_key_cost_usd_total", "Estimated Google Maps Platform spend (list price) per API key", "key")
+	mKeyFailovers = metrics.Default.CounterVec("google_maps_key_failovers_total", "Requests moved to another API key, by the key that failed and why", "key", "reason")
+)
+
+// APIKey is one Google Maps Platform key with its own limits.
+type APIKey struct {
+	Name       string // label in metrics and stats; defaults to the last 4 characters of Key
+	Key        string
+	RPS        int   // requests per second; 0 = unlimited
+	DailyQuota int64 // requests per day (Pacific time, as Google counts); 0 = unlimited
+}
+
+// ParseAPIKeys parses a comma-separated list of [name=]key[:rps[:daily_quota]] entries.
+// Entries without rps or daily_quota get the given defaults.
+func ParseAPIKeys(spec string, rps int, dailyQuota int64) ([]APIKey, error) {
+	var out []APIKey
+	names := map[string]bool{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		k := APIKey{RPS: rps, DailyQuota: dailyQuota}
+		if name, rest, ok := strings.Cut(entry, "="); ok {
+			k.Name, entry = strings.TrimSpace(name), rest
+		}
+		parts := strings.Split(entry, ":")
+		if len(parts) > 3 {
+			return nil, fmt.Errorf("google api key %q: want [name=]key[:rps[:daily_quota]]", k.Name)
+		}
+		k.Key = strings.TrimSpace(parts[0])
+		if k.Key == "" {
+			return nil, fmt.Errorf("google api key %q: empty key", k.Name)
+		}
+		if k.Name == "" {
+			k.Name = keySuffix(k.Key)
+		}
+		if len(parts) > 1 {
+			n, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("google api key %q: invalid rps %q", k.Name, parts[1])
+			}
+			k.RPS = n
+		}
+		if len(parts) > 2 {
+			n, err := strconv.ParseInt(strings.TrimSpace(parts[2]), 10, 64)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("google api key %q: invalid daily quota %q", k.Name, parts[2])
+			}
+			k.DailyQuota = n
+		}
+		if names[k.Name] {
+			return nil, fmt.Errorf("google api key %q: duplicate name", k.Name)
+		}
+		names[k.Name] = true
+		out = append(out, k)
+	}
+	if len(out) == 0 {
+		return nil, errors.New("no google api key configured")
+	}
+	return out, nil
+}
+
+// keySuffix names a key by its last 4 characters, as the Cloud console lists them.
+func keySuffix(key string) string {
+	if len(key) > 4 {
+		key = key[len(key)-4:]
+	}
+	return "..." + key
+}
+
+// poolKey is a key with its legacy SDK client and usage.
+type poolKey struct {
+	APIKey
+	client *maps.Client
+
+	// Guarded by keyPool.mu
+	second       time.Time // start of the second sent counts
+	sent         int
+	day          string // Pacific date usedToday counts
+	usedToday    int64
+	requests     int64
+	benchedUntil time.Time
+	benchReason  string
+	benchClass   errs.Class
+}
+
+// keyPool spreads requests over the configured keys round-robin. A key is skipped while it
+// is at its RPS or daily quota, or benched after Google rejected it for quota or auth; the
+// request is then retried on the next key.
+type keyPool struct {
+	mu   sync.Mutex
+	keys []*poolKey
+	next int
+	now  func() time.Time
+}
+
+func newKeyPool(keys []APIKey) (*keyPool, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no google api key configured")
+	}
+	p := &keyPool{now: time.Now}
+	for _, k := range keys {
+		client, err := maps.NewClient(maps.WithAPIKey(k.Key))
+		if err != nil {
+			return nil, fmt.Errorf("google api key %q: %w", k.Name, err)
+		}
+		p.keys = append(p.keys, &poolKey{APIKey: k, client: client})
+	}
+	return p, nil
+}
+
+// pacific is where Google resets daily quotas.
+var pacific = func() *time.Location {
+	if loc, err := time.LoadLocation("America/Los_Angeles"); err == nil {
+		return loc
+	}
+	return time.FixedZone("PST", -8*3600)
+}()
+
+// do runs fn with a key, moving on to the next key when Google rejects one for quota or
+// auth. It returns fn's last error unchanged, or a classified error when no key is usable.
+func (p *keyPool) do(ctx context.Context, op string, fn func(k *poolKey) error) error {
+	tried := make(map[*poolKey]bool, len(p.keys))
+	var lastErr error
+	for {
+		k, wait, class := p.acquire(tried)
+		if k == nil {
+			if lastErr != nil {
+				return lastErr
+			}
+			if wait <= 0 {
+				return errs.NewExternalClass(op, "google", class, errNoAPIKey)
+			}
+			// Every key is at its RPS; the next second frees one up
+			t := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return ctx.Err()
+			case <-t.C:
+			}
+			continue
+		}
+		err := fn(k)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		tried[k] = true
+		if !p.reject(k, op, err) {
+			return err
+		}
+	}
+}
+
+// acquire picks the next usable key that has not been tried and counts a request on it.
+// Without one it returns how long until a key frees up from its RPS limit (0 if none will
+// soon) and the class to report.
+func (p *keyPool) acquire(tried map[*poolKey]bool) (*poolKey, time.Duration, errs.Class) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	second, day := now.Truncate(time.Second), now.In(pacific).Format("2006-01-02")
+	var wait time.Duration
+	class := errs.ClassAuth
+	for i := range p.keys {
+		idx := (p.next + i) % len(p.keys)
+		k := p.keys[idx]
+		if tried[k] {
+			continue
+		}
+		if k.day != day {
+			k.day, k.usedToday = day, 0
+		}
+		if !k.second.Equal(second) {
+			k.second, k.sent = second, 0
+		}
+		switch {
+		case now.Before(k.benchedUntil):
+			if k.benchClass != errs.ClassAuth {
+				class = errs.ClassRateLimit
+			}
+			continue
+		case k.DailyQuota > 0 && k.usedToday >= k.DailyQuota:
+			class = errs.ClassRateLimit
+			continue
+		case k.RPS > 0 && k.sent >= k.RPS:
+			if d := second.Add(time.Second).Sub(now); wait == 0 || d < wait {
+				wait = d
+			}
+			continue
+		}
+		k.sent++
+		k.usedToday++
+		k.requests++
+		p.next = idx + 1
+		mKeyRequests.With(k.Name).Inc()
+		return k, 0, ""
+	}
+	return nil, wait, class
+}
+
+// reject benches k when err says Google refused the key rather than the request, and
+// reports whether another key may succeed.
+func (p *keyPool) reject(k *poolKey, op string, err error) bool {
+	var ex *errs.ExternalAPIError
+	if !errors.As(err, &ex) {
+		err = classifyMapsError(op, err)
+	}
+	class := errs.Classify(err)
+	bench := keyRateLimitBench
+	switch class {
+	case errs.ClassRateLimit:
+		if errors.As(err, &ex) && ex.RetryAfter > bench {
+			bench = ex.RetryAfter
+		}
+	case errs.ClassAuth:
+		bench = keyAuthBench
+	default:
+		return false
+	}
+	p.mu.Lock()
+	k.benchedUntil = p.now().Add(bench)
+	k.benchReason = err.Error()
+	k.benchClass = class
+	p.mu.Unlock()
+	mKeyFailovers.With(k.Name, string(class)).Inc()
+	return true
+}
+
+// usage returns the per-key request counts and bench state, in configured order.
+func (p *keyPool) usage() []models.GoogleKeyUsage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	day := now.In(pacific).Format("2006-01-02")
+	out := make([]models.GoogleKeyUsage, 0, len(p.keys))
+	for _, k := range p.keys {
+		u := models.GoogleKeyUsage{Name: k.Name, Requests: k.requests, DailyQuota: k.DailyQuota, RPS: k.RPS, Available: true}
+		if k.day == day {
+			u.RequestsToday = k.usedToday
+		}
+		if now.Before(k.benchedUntil) {
+			until := k.benchedUntil
+			u.Available, u.BenchedUntil, u.BenchReason = false, &until, k.benchReason
+		} else if k.DailyQuota > 0 && u.RequestsToday >= k.DailyQuota {
+			u.Available, u.BenchReason = false, "daily quota reached"
+		}
+		out = append(out, u)
+	}
+	return out
+}
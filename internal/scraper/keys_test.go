@@ -0,0 +1,155 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+func testKeyPool(t *testing.T, keys ...string) *keyPool {
+	t.Helper()
+	var list []APIKey
+	for _, k := range keys {
+		list = append(list, APIKey{Name: k, Key: k})
+	}
+	p, err := newKeyPool(list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestParseAPIKeys(t *testing.T) {
+	keys, err := ParseAPIKeys("team-a=AIzaAAAA1111, AIzaBBBB2222:5, team-c=AIzaCCCC3333:0:1000", 10, 500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []APIKey{
+		{Name: "team-a", Key: "AIzaAAAA1111", RPS: 10, DailyQuota: 500},
+		{Name: "...2222", Key: "AIzaBBBB2222", RPS: 5, DailyQuota: 500},
+		{Name: "team-c", Key: "AIzaCCCC3333", RPS: 0, DailyQuota: 1000},
+	}
+	if len(keys) != len(want) {
+		t.Fatalf("keys = %+v", keys)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Errorf("key %d = %+v, want %+v", i, keys[i], want[i])
+		}
+	}
+
+	for _, spec := range []string{"", " , ", "a=", "a=k:x", "a=k:1:-2", "a=k:1:2:3", "a=k1,a=k2"} {
+		if _, err := ParseAPIKeys(spec, 0, 0); err == nil {
+			t.Errorf("ParseAPIKeys(%q) should fail", spec)
+		}
+	}
+}
+
+func TestKeyPool_FailsOverOnQuotaAndAuth(t *testing.T) {
+	var seen []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("X-Goog-Api-Key")
+		seen = append(seen, key)
+		switch key {
+		case "exhausted":
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error":{"code":429,"message":"Quota exceeded","status":"RESOURCE_EXHAUSTED"}}`))
+		case "revoked":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":{"code":403,"message":"API key not valid","status":"PERMISSION_DENIED"}}`))
+		default:
+			w.Write([]byte(`{"places": [{"id": "abc"}]}`))
+		}
+	}))
+	defer srv.Close()
+
+	cost := newPlacesCost()
+	c := newPlacesNewClient(testKeyPool(t, "exhausted", "revoked", "good"), cost)
+	c.baseURL = srv.URL + "/"
+
+	if _, err := c.searchPlaces(context.Background(), "x"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.searchPlaces(context.Background(), "y"); err != nil {
+		t.Fatal(err)
+	}
+	// The rejected keys are benched, so the second search goes straight to the good one
+	if got := strings.Join(seen, ","); got != "exhausted,revoked,good,good" {
+		t.Errorf("keys used = %s", got)
+	}
+
+	usage := map[string]bool{}
+	for _, u := range c.keys.usage() {
+		usage[u.Name] = u.Available
+	}
+	if usage["exhausted"] || usage["revoked"] || !usage["good"] {
+		t.Errorf("availability = %v", usage)
+	}
+	if cost.keyCostUSD("good") != 2*priceUSD(SKUTextSearchPro) || cost.keyCostUSD("exhausted") != 0 {
+		t.Errorf("key cost = %v / %v", cost.keyCostUSD("good"), cost.keyCostUSD("exhausted"))
+	}
+}
+
+func TestKeyPool_LimitsAndExhaustion(t *testing.T) {
+	p, err := newKeyPool([]APIKey{{Name: "a", Key: "a", DailyQuota: 1}, {Name: "b", Key: "b", RPS: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	p.now = func() time.Time { return now }
+
+	var used []string
+	call := func() error {
+		return p.do(context.Background(), "op", func(k *poolKey) error {
+			used = append(used, k.Name)
+			return nil
+		})
+	}
+	for i := 0; i < 2; i++ {
+		if err := call(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(used) != 2 || used[0] != "a" || used[1] != "b" {
+		t.Fatalf("used = %v", used)
+	}
+
+	// a is out of quota and b at its RPS: the call waits for the next second
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		p.mu.Lock()
+		now = now.Add(time.Second)
+		p.mu.Unlock()
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := p.do(ctx, "op", func(k *poolKey) error { used = append(used, k.Name); return nil }); err != nil || used[2] != "b" {
+		t.Fatalf("after a second: used = %v, err = %v", used, err)
+	}
+
+	// Both keys revoked: later calls fail fast as auth errors without calling Google
+	denied := func(k *poolKey) error { return errors.New("maps: REQUEST_DENIED - The provided API key is invalid.") }
+	now = now.Add(24 * time.Hour) // a's quota is back
+	if err := p.do(context.Background(), "op", denied); errs.Classify(classifyMapsError("op", err)) != errs.ClassAuth {
+		t.Fatalf("denied err = %v", err)
+	}
+	err = p.do(context.Background(), "op", func(k *poolKey) error {
+		t.Fatal("benched key used")
+		return nil
+	})
+	if !errors.Is(err, errNoAPIKey) || errs.Classify(err) != errs.ClassAuth {
+		t.Fatalf("no key err = %v (%s)", err, errs.Classify(err))
+	}
+
+	// The bench expires
+	now = now.Add(keyAuthBench)
+	if err := call(); err != nil {
+		t.Fatal(err)
+	}
+}
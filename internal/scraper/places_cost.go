@@ -0,0 +1,119 @@
+package scraper
+
+import (
+	"sort"
+	"sync"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+// Google billing SKUs of the requests this package makes. Legacy requests bill a base SKU plus
+// a data SKU per category of fields returned.
+const (
+	SKULegacyTextSearch     = "legacy_text_search"
+	SKULegacyPlaceDetails   = "legacy_place_details"
+	SKULegacyContactData    = "legacy_contact_data"
+	SKULegacyAtmosphereData = "legacy_atmosphere_data"
+	SKULegacyPhoto          = "legacy_place_photo"
+	SKUGeocoding            = "geocoding"
+
+	SKUTextSearchIDsOnly = "text_search_ids_only"
+	SKUTextSearchPro     = "text_search_pro"
+	SKUDetailsEssentials = "place_details_essentials"
+	SKUDetailsPro        = "place_details_pro"
+	SKUDetailsEnterprise = "place_details_enterprise"
+	SKUDetailsAtmosphere = "place_details_enterprise_atmosphere"
+	SKUPhotos            = "place_photos"
+)
+
+// skuPricePer1000 is Google's list price in USD per 1000 requests at the lowest volume tier.
+// Free monthly allowances and volume discounts are ignored, so totals are an upper bound.
+var skuPricePer1000 = map[string]float64{
+	SKULegacyTextSearch:     32,
+	SKULegacyPlaceDetails:   17,
+	SKULegacyContactData:    3,
+	SKULegacyAtmosphereData: 5,
+	SKULegacyPhoto:          7,
+	SKUGeocoding:            5,
+
+	SKUTextSearchIDsOnly: 0,
+	SKUTextSearchPro:     32,
+	SKUDetailsEssentials: 5,
+	SKUDetailsPro:        17,
+	SKUDetailsEnterprise: 20,
+	SKUDetailsAtmosphere: 25,
+	SKUPhotos:            7,
+}
+
+// SKUs billed by each legacy request; Text Search and Place Details with phone, website,
+// hours and ratings bill the base SKU plus both data SKUs.
+var (
+	legacyTextSearchSKUs = []string{SKULegacyTextSearch, SKULegacyContactData, SKULegacyAtmosphereData}
+	legacyDetailsSKUs    = []string{SKULegacyPlaceDetails, SKULegacyContactData, SKULegacyAtmosphereData}
+)
+
+var (
+	mPlacesRequests = metrics.Default.CounterVec("google_places_requests_total", "Billable Google Maps Platform requests by SKU", "sku")
+	mPlacesCost     = metrics.Default.CounterVec("google_places_cost_usd_total", "Estimated Google Maps Platform spend (list price) by SKU", "sku")
+)
+
+// placesCost counts billable requests per SKU and the spend per API key.
+type placesCost struct {
+	mu       sync.Mutex
+	requests map[string]int64
+	byKey    map[string]float64
+}
+
+func newPlacesCost() *placesCost {
+	return &placesCost{requests: map[string]int64{}, byKey: map[string]float64{}}
+}
+
+// record counts one request made with the named key for each SKU it bills.
+func (c *placesCost) record(key string, skus ...string) {
+	price := priceUSD(skus...)
+	c.mu.Lock()
+	for _, sku := range skus {
+		c.requests[sku]++
+	}
+	c.byKey[key] += price
+	c.mu.Unlock()
+	for _, sku := range skus {
+		mPlacesRequests.With(sku).Inc()
+		mPlacesCost.With(sku).Add(skuPricePer1000[sku] / 1000)
+	}
+	mKeyCost.With(key).Add(price)
+}
+
+// keyCostUSD is the estimated spend on the named key.
+func (c *placesCost) keyCostUSD(key string) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.byKey[key]
+}
+
+// priceUSD is the list price of one request billing skus.
+func priceUSD(skus ...string) float64 {
+	total := 0.0
+	for _, sku := range skus {
+		total += skuPricePer1000[sku] / 1000
+	}
+	return total
+}
+
+// usage returns the estimated spend per SKU, most expensive first.
+func (c *placesCost) usage() []models.PlacesSKUUsage {
+	c.mu.Lock()
+	out := make([]models.PlacesSKUUsage, 0, len(c.requests))
+	for sku, n := range c.requests {
+		out = append(out, models.PlacesSKUUsage{SKU: sku, Requests: n, CostUSD: float64(n) * skuPricePer1000[sku] / 1000})
+	}
+	c.mu.Unlock()
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CostUSD != out[j].CostUSD {
+			return out[i].CostUSD > out[j].CostUSD
+		}
+		return out[i].SKU < out[j].SKU
+	})
+	return out
+}
@@ -0,0 +1,381 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/constants"
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// Places API (New) is billed per SKU, and the SKU of a request is the most expensive field in
+// its field mask. The masks below list only what convertPlaceNew reads:
+//   - Text Search asks for the name, address, location and types that rankCandidates needs,
+//     the "Text Search Pro" SKU; contact and rating data comes from one Place Details call.
+//   - Place Details needs phone, website, hours and rating for the comparison, which puts it in
+//     the Enterprise SKU. Do not add fields without checking placeDetailsFieldTiers: one
+//     Atmosphere field (e.g. reviews) moves every request to a higher SKU.
+
+const placesNewBaseURL = "https://places.googleapis.com/v1/"
+
+// placesTextSearchMask returns what candidate ranking needs. Anything beyond places.id bills
+// Text Search Pro; Enterprise fields (rating, phone...) are still left to Place Details.
+const placesTextSearchMask = "places.id,places.displayName,places.formattedAddress,places.location,places.types"
+
+// placesDetailsFields is the Place Details field mask.
+var placesDetailsFields = []string{
+	"id", "formattedAddress", "location", "viewport", "addressComponents", "types", "photos", // Essentials
+	"displayName", "businessStatus", // Pro
+	"nationalPhoneNumber", "websiteUri", "regularOpeningHours", "rating", "userRatingCount", // Enterprise
+}
+
+// placesStatusFields is the Place Details mask of a closure check: the Pro SKU, the cheapest
+// that includes businessStatus.
+var placesStatusFields = []string{"businessStatus"}
+
+// placesReviewFields is the Place Details mask of the vegan status check. Reviews bill at the
+// Atmosphere SKU, which is why they are never part of placesDetailsFields.
+var placesReviewFields = []string{"reviews"}
+
+// placeDetailsFieldTiers maps Place Details fields to the SKU they bill at; fields not listed
+// are treated as Enterprise + Atmosphere, the most expensive tier.
+var placeDetailsFieldTiers = map[string]string{
+	"id": SKUDetailsEssentials, "formattedAddress": SKUDetailsEssentials, "location": SKUDetailsEssentials,
+	"viewport": SKUDetailsEssentials, "addressComponents": SKUDetailsEssentials, "types": SKUDetailsEssentials,
+	"photos": SKUDetailsEssentials, "shortFormattedAddress": SKUDetailsEssentials, "plusCode": SKUDetailsEssentials,
+	"displayName": SKUDetailsPro, "businessStatus": SKUDetailsPro, "primaryType": SKUDetailsPro,
+	"googleMapsUri": SKUDetailsPro, "utcOffsetMinutes": SKUDetailsPro,
+	"nationalPhoneNumber": SKUDetailsEnterprise, "internationalPhoneNumber": SKUDetailsEnterprise,
+	"websiteUri": SKUDetailsEnterprise, "regularOpeningHours": SKUDetailsEnterprise,
+	"currentOpeningHours": SKUDetailsEnterprise, "rating": SKUDetailsEnterprise,
+	"userRatingCount": SKUDetailsEnterprise, "priceLevel": SKUDetailsEnterprise,
+}
+
+var detailsTierOrder = []string{SKUDetailsEssentials, SKUDetailsPro, SKUDetailsEnterprise, SKUDetailsAtmosphere}
+
+// placeDetailsSKU returns the SKU a Place Details request with fields is billed at.
+func placeDetailsSKU(fields []string) string {
+	rank := 0
+	for _, f := range fields {
+		tier, ok := placeDetailsFieldTiers[f]
+		if !ok {
+			tier = SKUDetailsAtmosphere
+		}
+		for i, t := range detailsTierOrder {
+			if t == tier && i > rank {
+				rank = i
+			}
+		}
+	}
+	return detailsTierOrder[rank]
+}
+
+// placesNewClient calls the Places API (New) REST endpoints; the maps SDK only covers the
+// legacy API.
+type placesNewClient struct {
+	http    *http.Client
+	keys    *keyPool
+	baseURL string
+	cost    *placesCost
+}
+
+func newPlacesNewClient(keys *keyPool, cost *placesCost) *placesNewClient {
+	return &placesNewClient{
+		http:    &http.Client{Timeout: constants.GoogleMapsOperationTimeout},
+		keys:    keys,
+		baseURL: placesNewBaseURL,
+		cost:    cost,
+	}
+}
+
+// placeNew is the subset of the Place resource in placesDetailsFields.
+type placeNew struct {
+	ID          string `json:"id"`
+	DisplayName struct {
+		Text string `json:"text"`
+	} `json:"displayName"`
+	FormattedAddress  string       `json:"formattedAddress"`
+	Location          *latLngNew   `json:"location"`
+	Viewport          *viewportNew `json:"viewport"`
+	AddressComponents []struct {
+		LongText  string   `json:"longText"`
+		ShortText string   `json:"shortText"`
+		Types     []string `json:"types"`
+	} `json:"addressComponents"`
+	Types               []string `json:"types"`
+	NationalPhoneNumber string   `json:"nationalPhoneNumber"`
+	WebsiteURI          string   `json:"websiteUri"`
+	Rating              float64  `json:"rating"`
+	UserRatingCount     int      `json:"userRatingCount"`
+	BusinessStatus      string   `json:"businessStatus"`
+	RegularOpeningHours *struct {
+		OpenNow *bool `json:"openNow"`
+		Periods []struct {
+			Open  *pointNew `json:"open"`
+			Close *pointNew `json:"close"`
+		} `json:"periods"`
+		WeekdayDescriptions []string `json:"weekdayDescriptions"`
+	} `json:"regularOpeningHours"`
+	Photos []struct {
+		Name               string `json:"name"`
+		WidthPx            int    `json:"widthPx"`
+		HeightPx           int    `json:"heightPx"`
+		AuthorAttributions []struct {
+			DisplayName string `json:"displayName"`
+			URI         string `json:"uri"`
+		} `json:"authorAttributions"`
+	} `json:"photos"`
+}
+
+type latLngNew struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+type viewportNew struct {
+	Low  latLngNew `json:"low"`
+	High latLngNew `json:"high"`
+}
+
+type pointNew struct {
+	Day    int `json:"day"`
+	Hour   int `json:"hour"`
+	Minute int `json:"minute"`
+}
+
+// searchPlaces returns up to maxPlaceCandidates text matches for query in Google's order
+// (unscored); none when nothing matched.
+func (c *placesNewClient) searchPlaces(ctx context.Context, query string) ([]models.PlaceCandidate, error) {
+	body, _ := json.Marshal(map[string]interface{}{"textQuery": query, "pageSize": maxPlaceCandidates})
+	var resp struct {
+		Places []placeNew `json:"places"`
+	}
+	k, err := c.do(ctx, "scraper.SearchTextNew", http.MethodPost, c.baseURL+"places:searchText", placesTextSearchMask, body, &resp)
+	if err != nil {
+		return nil, err
+	}
+	c.cost.record(k.Name, SKUTextSearchPro)
+	out := make([]models.PlaceCandidate, 0, len(resp.Places))
+	for _, p := range resp.Places {
+		cand := models.PlaceCandidate{PlaceID: p.ID, Name: p.DisplayName.Text, Address: p.FormattedAddress, Types: p.Types}
+		if p.Location != nil {
+			cand.Lat, cand.Lng = p.Location.Latitude, p.Location.Longitude
+		}
+		out = append(out, cand)
+	}
+	return out, nil
+}
+
+// placeDetails fetches placeID with placesDetailsFields.
+func (c *placesNewClient) placeDetails(ctx context.Context, placeID string) (*placeNew, error) {
+	var p placeNew
+	u := c.baseURL + "places/" + url.PathEscape(placeID)
+	k, err := c.do(ctx, "scraper.PlaceDetailsNew", http.MethodGet, u, strings.Join(placesDetailsFields, ","), nil, &p)
+	if err != nil {
+		return nil, err
+	}
+	c.cost.record(k.Name, placeDetailsSKU(placesDetailsFields))
+	return &p, nil
+}
+
+// businessStatus fetches only placeID's business status.
+func (c *placesNewClient) businessStatus(ctx context.Context, placeID string) (string, error) {
+	var p struct {
+		BusinessStatus string `json:"businessStatus"`
+	}
+	u := c.baseURL + "places/" + url.PathEscape(placeID)
+	k, err := c.do(ctx, "scraper.PlaceStatusNew", http.MethodGet, u, strings.Join(placesStatusFields, ","), nil, &p)
+	if err != nil {
+		return "", err
+	}
+	c.cost.record(k.Name, placeDetailsSKU(placesStatusFields))
+	return p.BusinessStatus, nil
+}
+
+// reviews fetches only placeID's reviews, at most five as Google returns them.
+func (c *placesNewClient) reviews(ctx context.Context, placeID string) ([]models.PlaceReview, error) {
+	var p struct {
+		Reviews []struct {
+			Rating float64 `json:"rating"`
+			Text   struct {
+				Text string `json:"text"`
+			} `json:"text"`
+			OriginalText struct {
+				Text string `json:"text"`
+			} `json:"originalText"`
+			RelativePublishTimeDescription string `json:"relativePublishTimeDescription"`
+			AuthorAttribution              struct {
+				DisplayName string `json:"displayName"`
+			} `json:"authorAttribution"`
+		} `json:"reviews"`
+	}
+	u := c.baseURL + "places/" + url.PathEscape(placeID)
+	k, err := c.do(ctx, "scraper.PlaceReviewsNew", http.MethodGet, u, strings.Join(placesReviewFields, ","), nil, &p)
+	if err != nil {
+		return nil, err
+	}
+	c.cost.record(k.Name, placeDetailsSKU(placesReviewFields))
+	out := make([]models.PlaceReview, 0, len(p.Reviews))
+	for _, r := range p.Reviews {
+		// The original wording keeps quotes verifiable; the translation is the fallback
+		text := strings.TrimSpace(r.OriginalText.Text)
+		if text == "" {
+			text = strings.TrimSpace(r.Text.Text)
+		}
+		if text == "" {
+			continue
+		}
+		out = append(out, models.PlaceReview{
+			Author: r.AuthorAttribution.DisplayName,
+			Rating: r.Rating,
+			Text:   text,
+			When:   r.RelativePublishTimeDescription,
+		})
+	}
+	return out, nil
+}
+
+// photo downloads a photo by its resource name ("places/{id}/photos/{ref}").
+func (c *placesNewClient) photo(ctx context.Context, name string, maxWidth uint) (*models.VenuePhoto, error) {
+	u := fmt.Sprintf("%s%s/media?maxWidthPx=%d", c.baseURL, name, maxWidth)
+	var photo *models.VenuePhoto
+	err := c.keys.do(ctx, "scraper.PlacePhotoNew", func(k *poolKey) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("X-Goog-Api-Key", k.Key)
+		resp, err := c.http.Do(req)
+		if err != nil {
+			return errs.NewExternalStatus("scraper.PlacePhotoNew", "google", 0, 0, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode/100 != 2 {
+			return placesNewError("scraper.PlacePhotoNew", resp)
+		}
+		data, err := io.ReadAll(io.LimitReader(resp.Body, maxPhotoBytes))
+		if err != nil {
+			return err
+		}
+		c.cost.record(k.Name, SKUPhotos)
+		photo = &models.VenuePhoto{Data: data, ContentType: resp.Header.Get("Content-Type")}
+		return nil
+	})
+	return photo, err
+}
+
+// do sends the request with the next usable key and returns the key that succeeded.
+func (c *placesNewClient) do(ctx context.Context, op, method, u, fieldMask string, body []byte, out interface{}) (*poolKey, error) {
+	var used *poolKey
+	err := c.keys.do(ctx, op, func(k *poolKey) error {
+		var rd io.Reader
+		if body != nil {
+			rd = bytes.NewReader(body)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, u, rd)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("X-Goog-Api-Key", k.Key)
+		req.Header.Set("X-Goog-FieldMask", fieldMask)
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		resp, err := c.http.Do(req)
+		if err != nil {
+			return errs.NewExternalStatus(op, "google", 0, 0, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode/100 != 2 {
+			return placesNewError(op, resp)
+		}
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return errs.NewExternal(op, "google", "decode response", err)
+		}
+		used = k
+		return nil
+	})
+	return used, err
+}
+
+// placesNewError turns an error response ({"error": {"code", "message", "status"}}) into a
+// classified ExternalAPIError.
+func placesNewError(op string, resp *http.Response) error {
+	var e struct {
+		Error struct {
+			Message string `json:"message"`
+			Status  string `json:"status"`
+		} `json:"error"`
+	}
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	msg := strings.TrimSpace(string(raw))
+	if json.Unmarshal(raw, &e) == nil && e.Error.Message != "" {
+		msg = e.Error.Status + ": " + e.Error.Message
+	}
+	retryAfter := errs.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	return errs.NewExternalStatus(op, "google", resp.StatusCode, retryAfter, fmt.Errorf("places api: %s", msg))
+}
+
+// convertPlaceNew maps a Place (New) to the model shared with the legacy API, so comparison,
+// storage and the UI do not care which API a venue was checked with.
+func convertPlaceNew(p placeNew) models.GooglePlaceData {
+	gd := models.GooglePlaceData{
+		PlaceID:          p.ID,
+		Name:             p.DisplayName.Text,
+		FormattedAddress: p.FormattedAddress,
+		FormattedPhone:   p.NationalPhoneNumber,
+		Website:          p.WebsiteURI,
+		Rating:           p.Rating,
+		UserRatingsTotal: p.UserRatingCount,
+		Types:            p.Types,
+		BusinessStatus:   p.BusinessStatus,
+		FetchedAt:        time.Now(),
+	}
+	if p.Location != nil {
+		gd.Geometry.Location = models.GoogleLatLng{Lat: p.Location.Latitude, Lng: p.Location.Longitude}
+	}
+	if p.Viewport != nil {
+		gd.Geometry.Viewport = models.GoogleBounds{
+			Northeast: models.GoogleLatLng{Lat: p.Viewport.High.Latitude, Lng: p.Viewport.High.Longitude},
+			Southwest: models.GoogleLatLng{Lat: p.Viewport.Low.Latitude, Lng: p.Viewport.Low.Longitude},
+		}
+	}
+	if h := p.RegularOpeningHours; h != nil {
+		oh := &models.GoogleOpeningHours{
+			OpenNow:     h.OpenNow != nil && *h.OpenNow,
+			WeekdayText: h.WeekdayDescriptions,
+		}
+		for _, period := range h.Periods {
+			var gp models.GooglePeriod
+			if period.Open != nil {
+				gp.Open = models.GoogleTime{Day: period.Open.Day, Time: fmt.Sprintf("%02d%02d", period.Open.Hour, period.Open.Minute)}
+			}
+			if period.Close != nil { // absent for places open 24/7
+				gp.Close = models.GoogleTime{Day: period.Close.Day, Time: fmt.Sprintf("%02d%02d", period.Close.Hour, period.Close.Minute)}
+			}
+			oh.Periods = append(oh.Periods, gp)
+		}
+		gd.OpeningHours = oh
+	}
+	for _, ph := range p.Photos {
+		photo := models.GooglePhoto{Reference: ph.Name, Width: ph.WidthPx, Height: ph.HeightPx}
+		for _, a := range ph.AuthorAttributions {
+			// Same shape as the legacy html_attributions
+			photo.Attributions = append(photo.Attributions, fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(a.URI), html.EscapeString(a.DisplayName)))
+		}
+		gd.Photos = append(gd.Photos, photo)
+	}
+	for _, c := range p.AddressComponents {
+		gd.AddressComponents = append(gd.AddressComponents, models.AddressComponent{LongName: c.LongText, ShortName: c.ShortText, Types: c.Types})
+	}
+	return gd
+}
@@ -0,0 +1,211 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+func TestPlaceDetailsSKU(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []string
+		want   string
+	}{
+		{"essentials only", []string{"id", "location", "addressComponents"}, SKUDetailsEssentials},
+		{"one pro field", []string{"id", "displayName"}, SKUDetailsPro},
+		{"one enterprise field", []string{"id", "displayName", "websiteUri"}, SKUDetailsEnterprise},
+		{"unknown field is the top tier", []string{"id", "reviews"}, SKUDetailsAtmosphere},
+		{"configured mask", placesDetailsFields, SKUDetailsEnterprise},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := placeDetailsSKU(tt.fields); got != tt.want {
+				t.Errorf("placeDetailsSKU(%v) = %s, want %s", tt.fields, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlacesNewClient_Lookup(t *testing.T) {
+	var masks = map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Goog-Api-Key") != "key" {
+			t.Errorf("%s: missing API key header", r.URL.Path)
+		}
+		masks[r.URL.Path] = r.Header.Get("X-Goog-FieldMask")
+		switch r.URL.Path {
+		case "/places:searchText":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body["textQuery"] != "Green Bowl Berlin" {
+				t.Errorf("textQuery = %v", body["textQuery"])
+			}
+			if body["pageSize"] != float64(maxPlaceCandidates) {
+				t.Errorf("pageSize = %v", body["pageSize"])
+			}
+			w.Write([]byte(`{"places":[{"id":"abc","displayName":{"text":"Green Bowl"},"location":{"latitude":52.5,"longitude":13.4},"types":["restaurant"]}]}`))
+		case "/places/abc":
+			w.Write([]byte(`{
+				"id": "abc",
+				"displayName": {"text": "Green Bowl"},
+				"formattedAddress": "Main St 1, Berlin",
+				"location": {"latitude": 52.5, "longitude": 13.4},
+				"addressComponents": [{"longText": "10115", "shortText": "10115", "types": ["postal_code"]}],
+				"nationalPhoneNumber": "030 123456",
+				"websiteUri": "https://greenbowl.example",
+				"rating": 4.6,
+				"userRatingCount": 120,
+				"businessStatus": "OPERATIONAL",
+				"regularOpeningHours": {"periods": [{"open": {"day": 1, "hour": 9, "minute": 30}, "close": {"day": 1, "hour": 17, "minute": 0}}], "weekdayDescriptions": ["Monday: 9:30 AM – 5:00 PM"]},
+				"photos": [{"name": "places/abc/photos/p1", "widthPx": 800, "heightPx": 600, "authorAttributions": [{"displayName": "Ann", "uri": "https://maps.example/ann"}]}]
+			}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	cost := newPlacesCost()
+	c := newPlacesNewClient(testKeyPool(t, "key"), cost)
+	c.baseURL = srv.URL + "/"
+
+	found, err := c.searchPlaces(context.Background(), "Green Bowl Berlin")
+	if err != nil || len(found) != 1 || found[0].PlaceID != "abc" || found[0].Name != "Green Bowl" || found[0].Lat != 52.5 {
+		t.Fatalf("searchPlaces = %+v, %v", found, err)
+	}
+	p, err := c.placeDetails(context.Background(), found[0].PlaceID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gd := convertPlaceNew(*p)
+
+	if masks["/places:searchText"] != placesTextSearchMask {
+		t.Errorf("search field mask = %q", masks["/places:searchText"])
+	}
+	if gd.PlaceID != "abc" || gd.Name != "Green Bowl" || gd.FormattedPhone != "030 123456" || gd.Website != "https://greenbowl.example" {
+		t.Errorf("converted place = %+v", gd)
+	}
+	if gd.Rating != 4.6 || gd.UserRatingsTotal != 120 || gd.Geometry.Location.Lat != 52.5 {
+		t.Errorf("rating/location = %v %v %v", gd.Rating, gd.UserRatingsTotal, gd.Geometry.Location)
+	}
+	if extractPostalCodeFromComponents(gd.AddressComponents) != "10115" {
+		t.Errorf("address components = %+v", gd.AddressComponents)
+	}
+	if gd.OpeningHours == nil || len(gd.OpeningHours.Periods) != 1 || gd.OpeningHours.Periods[0].Open.Time != "0930" || gd.OpeningHours.Periods[0].Close.Time != "1700" {
+		t.Errorf("opening hours = %+v", gd.OpeningHours)
+	}
+	if len(gd.Photos) != 1 || gd.Photos[0].Reference != "places/abc/photos/p1" || gd.Photos[0].Attributions[0] != `<a href="https://maps.example/ann">Ann</a>` {
+		t.Errorf("photos = %+v", gd.Photos)
+	}
+
+	usage := map[string]int64{}
+	for _, u := range cost.usage() {
+		usage[u.SKU] = u.Requests
+	}
+	if usage[SKUTextSearchPro] != 1 || usage[SKUDetailsEnterprise] != 1 || len(usage) != 2 {
+		t.Errorf("usage = %v", usage)
+	}
+}
+
+func TestPlacesNewClient_BusinessStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/places/abc" || r.Header.Get("X-Goog-FieldMask") != "businessStatus" {
+			t.Errorf("request %s mask %q", r.URL.Path, r.Header.Get("X-Goog-FieldMask"))
+		}
+		w.Write([]byte(`{"businessStatus": "CLOSED_PERMANENTLY"}`))
+	}))
+	defer srv.Close()
+
+	cost := newPlacesCost()
+	c := newPlacesNewClient(testKeyPool(t, "key"), cost)
+	c.baseURL = srv.URL + "/"
+
+	status, err := c.businessStatus(context.Background(), "abc")
+	if err != nil || status != "CLOSED_PERMANENTLY" {
+		t.Fatalf("businessStatus = %q, %v", status, err)
+	}
+	if u := cost.usage(); len(u) != 1 || u[0].SKU != SKUDetailsPro {
+		t.Errorf("usage = %+v", u)
+	}
+}
+
+func TestPlacesNewClient_Reviews(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/places/abc" || r.Header.Get("X-Goog-FieldMask") != "reviews" {
+			t.Errorf("request %s mask %q", r.URL.Path, r.Header.Get("X-Goog-FieldMask"))
+		}
+		w.Write([]byte(`{"reviews": [
+			{"rating": 5, "text": {"text": "Everything is vegan!"}, "originalText": {"text": "¡Todo es vegano!"},
+			 "relativePublishTimeDescription": "a month ago", "authorAttribution": {"displayName": "Ana"}},
+			{"rating": 4, "text": {"text": "Great cheese toastie"}},
+			{"rating": 3}
+		]}`))
+	}))
+	defer srv.Close()
+
+	cost := newPlacesCost()
+	c := newPlacesNewClient(testKeyPool(t, "key"), cost)
+	c.baseURL = srv.URL + "/"
+
+	reviews, err := c.reviews(context.Background(), "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reviews) != 2 || reviews[0].Text != "¡Todo es vegano!" || reviews[0].Author != "Ana" || reviews[1].Text != "Great cheese toastie" {
+		t.Fatalf("reviews = %+v", reviews)
+	}
+	if u := cost.usage(); len(u) != 1 || u[0].SKU != SKUDetailsAtmosphere {
+		t.Errorf("usage = %+v", u)
+	}
+}
+
+func TestPlacesNewClient_ErrorClass(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		want   errs.Class
+	}{
+		{http.StatusTooManyRequests, `{"error":{"code":429,"message":"Quota exceeded","status":"RESOURCE_EXHAUSTED"}}`, errs.ClassRateLimit},
+		{http.StatusForbidden, `{"error":{"code":403,"message":"API key not valid","status":"PERMISSION_DENIED"}}`, errs.ClassAuth},
+		{http.StatusBadRequest, `not json`, errs.ClassClient},
+		{http.StatusServiceUnavailable, ``, errs.ClassServer},
+	}
+	for _, tt := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			w.Write([]byte(tt.body))
+		}))
+		c := newPlacesNewClient(testKeyPool(t, "key"), newPlacesCost())
+		c.baseURL = srv.URL + "/"
+		_, err := c.searchPlaces(context.Background(), "x")
+		srv.Close()
+		if got := errs.Classify(err); got != tt.want {
+			t.Errorf("status %d: class = %s, want %s (%v)", tt.status, got, tt.want, err)
+		}
+		if len(c.cost.usage()) != 0 {
+			t.Errorf("status %d: failed request was billed", tt.status)
+		}
+	}
+}
+
+func TestUsePlacesNew(t *testing.T) {
+	s := &GoogleMapsScraper{}
+	if s.usePlacesNew(1) {
+		t.Fatal("0% should never use the new API")
+	}
+	s.SetPlacesAPINewPercent(25)
+	for id, want := range map[int64]bool{100: true, 124: true, 125: false, 199: false} {
+		if got := s.usePlacesNew(id); got != want {
+			t.Errorf("usePlacesNew(%d) at 25%% = %v, want %v", id, got, want)
+		}
+	}
+	s.SetPlacesAPINewPercent(100)
+	if !s.usePlacesNew(99) {
+		t.Fatal("100% should always use the new API")
+	}
+}
@@ -0,0 +1,229 @@
+package scraper
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/pkg/utils"
+)
+
+const (
+	maxSocialBytes      = 2 << 20
+	socialNameMatchMin  = 0.6
+	socialActiveWithin  = 180 * 24 * time.Hour
+	socialContainsMinLn = 4
+)
+
+// Reserved first path segments that are not profile handles.
+var (
+	instagramReserved = map[string]bool{"p": true, "reel": true, "reels": true, "explore": true, "stories": true, "accounts": true, "tv": true}
+	facebookReserved  = map[string]bool{"profile.php": true, "groups": true, "events": true, "watch": true, "share": true, "story.php": true, "permalink.php": true, "login": true}
+)
+
+// notFoundPhrases are shown by Facebook/Instagram for deleted or never-existing profiles (served with 200).
+var notFoundPhrases = []string{
+	"sorry, this page isn't available",
+	"the link you followed may be broken",
+	"this content isn't available",
+	"page not found",
+}
+
+var (
+	// Post timestamps embedded in profile page JSON; whichever the platform happens to serve.
+	postUnixRe = regexp.MustCompile(`"(?:taken_at_timestamp|taken_at|publish_time|creation_time)"\s*:\s*(\d{10})`)
+	postISORe  = regexp.MustCompile(`"(?:datePublished|uploadDate)"\s*:\s*"([^"]+)"`)
+	nonAlnumRe = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+)
+
+// SocialVerifier resolves a venue's Facebook and Instagram links and checks that the
+// handle resembles the venue name and that the account posted recently.
+// Both platforms often answer anonymous requests with a login wall; that is reported
+// as such rather than as a failure.
+type SocialVerifier struct {
+	client       *http.Client
+	activeWithin time.Duration
+	now          func() time.Time
+}
+
+func NewSocialVerifier(timeout time.Duration) *SocialVerifier {
+	return &SocialVerifier{client: newPublicHTTPClient(timeout), activeWithin: socialActiveWithin, now: time.Now}
+}
+
+// VerifySocial checks every social link the venue carries. Returns nil when it has none.
+func (s *SocialVerifier) VerifySocial(ctx context.Context, venue models.Venue) []models.SocialCheck {
+	var out []models.SocialCheck
+	for _, l := range []struct {
+		platform string
+		raw      *string
+	}{{"facebook", venue.FBUrl}, {"instagram", venue.InstagramUrl}} {
+		if l.raw == nil || strings.TrimSpace(*l.raw) == "" {
+			continue
+		}
+		out = append(out, s.verify(ctx, l.platform, *l.raw, venue.Name))
+	}
+	return out
+}
+
+func (s *SocialVerifier) verify(ctx context.Context, platform, raw, venueName string) models.SocialCheck {
+	check := models.SocialCheck{Platform: platform, URL: strings.TrimSpace(raw)}
+	u, handle, ok := parseSocialURL(platform, raw)
+	if !ok {
+		check.Status = models.SocialError
+		check.Error = "not a " + platform + " profile URL"
+		return check
+	}
+	check.URL = u.String()
+	check.Handle = handle
+	if handle != "" {
+		check.NameMatch = handleSimilarity(handle, venueName)
+		check.NameMatches = check.NameMatch >= socialNameMatchMin
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, check.URL, nil)
+	if err != nil {
+		check.Status, check.Error = models.SocialError, err.Error()
+		return check
+	}
+	req.Header.Set("User-Agent", websiteUserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		check.Status, check.Error = models.SocialError, err.Error()
+		return check
+	}
+	defer resp.Body.Close()
+	check.StatusCode = resp.StatusCode
+
+	var body string
+	if resp.StatusCode == http.StatusOK {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, maxSocialBytes))
+		body = string(b)
+	}
+	check.Status = classifySocialResponse(resp.StatusCode, resp.Request.URL, body)
+	if check.Status != models.SocialOK {
+		return check
+	}
+
+	if last := latestPost(body); last != nil {
+		check.LastPostAt = last
+		active := s.now().Sub(*last) <= s.activeWithin
+		check.Active = &active
+	}
+	return check
+}
+
+// parseSocialURL normalises a profile link and extracts the handle. Bare handles
+// ("@greenleaf", "greenleaf") are accepted since members often submit them that way.
+// The handle is empty for numeric profile links, which still get resolved.
+func parseSocialURL(platform, raw string) (*url.URL, string, bool) {
+	raw = strings.TrimSpace(raw)
+	host := platform + ".com"
+	if h := strings.TrimPrefix(raw, "@"); h != "" && !strings.ContainsAny(h, "./:") {
+		return &url.URL{Scheme: "https", Host: "www." + host, Path: "/" + h + "/"}, strings.ToLower(h), true
+	}
+	u := normalizeWebsiteURL(raw)
+	if u == nil {
+		return nil, "", false
+	}
+	h := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	h = strings.TrimPrefix(h, "m.")
+	if h != host && !(platform == "facebook" && (h == "fb.com" || h == "web.facebook.com")) {
+		return nil, "", false
+	}
+	u.Scheme = "https"
+	u.Fragment = ""
+
+	segs := strings.FieldsFunc(u.Path, func(r rune) bool { return r == '/' })
+	if len(segs) == 0 {
+		return nil, "", false
+	}
+	first := strings.ToLower(segs[0])
+	switch platform {
+	case "instagram":
+		if instagramReserved[first] {
+			return u, "", true
+		}
+	case "facebook":
+		// /pages/<Page-Name>/<id> and /people/<Name>/<id> carry a readable name
+		if (first == "pages" || first == "people" || first == "pg") && len(segs) > 1 {
+			return u, strings.ToLower(segs[1]), true
+		}
+		if facebookReserved[first] || isDigits(first) {
+			return u, "", true
+		}
+	}
+	return u, first, true
+}
+
+// classifySocialResponse maps the platform response to a SocialCheck status.
+func classifySocialResponse(code int, final *url.URL, body string) string {
+	switch {
+	case code == http.StatusNotFound || code == http.StatusGone:
+		return models.SocialNotFound
+	case final != nil && (strings.Contains(final.Path, "/accounts/login") || strings.HasPrefix(final.Path, "/login")):
+		return models.SocialLoginWall
+	case code != http.StatusOK:
+		return models.SocialError
+	}
+	lower := strings.ToLower(body)
+	for _, p := range notFoundPhrases {
+		if strings.Contains(lower, p) {
+			return models.SocialNotFound
+		}
+	}
+	return models.SocialOK
+}
+
+// latestPost returns the newest post timestamp found in the page, or nil.
+func latestPost(body string) *time.Time {
+	var latest time.Time
+	for _, m := range postUnixRe.FindAllStringSubmatch(body, -1) {
+		if sec, err := strconv.ParseInt(m[1], 10, 64); err == nil {
+			if t := time.Unix(sec, 0).UTC(); t.After(latest) {
+				latest = t
+			}
+		}
+	}
+	for _, m := range postISORe.FindAllStringSubmatch(body, -1) {
+		if t, err := time.Parse(time.RFC3339, m[1]); err == nil && t.After(latest) {
+			latest = t.UTC()
+		}
+	}
+	if latest.IsZero() {
+		return nil
+	}
+	return &latest
+}
+
+// handleSimilarity compares a handle with the venue name ignoring case, punctuation and spaces.
+// Handles are often the name plus a city or suffix ("greenleafberlin"), so containment counts as a match.
+func handleSimilarity(handle, name string) float64 {
+	h := nonAlnumRe.ReplaceAllString(strings.ToLower(handle), "")
+	n := nonAlnumRe.ReplaceAllString(strings.ToLower(name), "")
+	if h == "" || n == "" {
+		return 0
+	}
+	if len(h) >= socialContainsMinLn && len(n) >= socialContainsMinLn && (strings.Contains(h, n) || strings.Contains(n, h)) {
+		return 1
+	}
+	return utils.CalculateStringSimilarity(h, n)
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+package scraper
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+)
+
+func TestParseSocialURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		platform   string
+		raw        string
+		wantOK     bool
+		wantHandle string
+	}{
+		{"instagram url", "instagram", "https://www.instagram.com/GreenLeafBerlin/?hl=en", true, "greenleafberlin"},
+		{"instagram bare handle", "instagram", "@greenleaf", true, "greenleaf"},
+		{"instagram post link", "instagram", "instagram.com/p/Cx12ab/", true, ""},
+		{"facebook vanity", "facebook", "http://m.facebook.com/greenleaf.cafe", true, "greenleaf.cafe"},
+		{"facebook pages", "facebook", "https://www.facebook.com/pages/Green-Leaf-Cafe/123456789", true, "green-leaf-cafe"},
+		{"facebook numeric", "facebook", "https://facebook.com/profile.php?id=1000123", true, ""},
+		{"facebook fb.com", "facebook", "fb.com/greenleaf", true, "greenleaf"},
+		{"wrong platform", "instagram", "https://linktr.ee/greenleaf", false, ""},
+		{"no path", "facebook", "https://facebook.com/", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, handle, ok := parseSocialURL(tt.platform, tt.raw)
+			if ok != tt.wantOK || handle != tt.wantHandle {
+				t.Fatalf("parseSocialURL(%q) = %q, %v; want %q, %v", tt.raw, handle, ok, tt.wantHandle, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestHandleSimilarity(t *testing.T) {
+	tests := []struct {
+		handle, name string
+		wantMatch    bool
+	}{
+		{"greenleafberlin", "Green Leaf", true},
+		{"green-leaf-cafe", "Green Leaf Café", true},
+		{"the_vegan_spot", "The Vegan Spot", true},
+		{"pizzahut", "Green Leaf", false},
+		{"", "Green Leaf", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.handle, func(t *testing.T) {
+			got := handleSimilarity(tt.handle, tt.name) >= socialNameMatchMin
+			if got != tt.wantMatch {
+				t.Fatalf("handleSimilarity(%q, %q) match=%v, want %v", tt.handle, tt.name, got, tt.wantMatch)
+			}
+		})
+	}
+}
+
+func TestClassifySocialResponse(t *testing.T) {
+	login, _ := url.Parse("https://www.instagram.com/accounts/login/?next=/greenleaf/")
+	profile, _ := url.Parse("https://www.instagram.com/greenleaf/")
+	tests := []struct {
+		name  string
+		code  int
+		final *url.URL
+		body  string
+		want  string
+	}{
+		{"404", 404, profile, "", models.SocialNotFound},
+		{"soft 404", 200, profile, "<h2>Sorry, this page isn't available.</h2>", models.SocialNotFound},
+		{"login wall", 200, login, "<form>", models.SocialLoginWall},
+		{"rate limited", 429, profile, "", models.SocialError},
+		{"ok", 200, profile, "<title>Green Leaf (@greenleaf)</title>", models.SocialOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifySocialResponse(tt.code, tt.final, tt.body); got != tt.want {
+				t.Fatalf("status=%s want=%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLatestPost(t *testing.T) {
+	body := `{"taken_at_timestamp":1700000000},{"taken_at_timestamp":1710000000},{"datePublished":"2023-01-02T03:04:05Z"}`
+	got := latestPost(body)
+	if got == nil || !got.Equal(time.Unix(1710000000, 0)) {
+		t.Fatalf("latestPost=%v", got)
+	}
+	if latestPost("<html>no posts</html>") != nil {
+		t.Fatalf("expected nil without timestamps")
+	}
+}
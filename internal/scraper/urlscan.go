@@ -0,0 +1,143 @@
+package scraper
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+const safeBrowsingEndpoint = "https://safebrowsing.googleapis.com/v4/threatMatches:find"
+
+var safeBrowsingThreatTypes = []string{"MALWARE", "SOCIAL_ENGINEERING", "UNWANTED_SOFTWARE", "POTENTIALLY_HARMFUL_APPLICATION"}
+
+// SafeBrowsing looks submitted URLs up in the Google Safe Browsing Lookup API (v4).
+type SafeBrowsing struct {
+	apiKey   string
+	endpoint string
+	client   *http.Client
+}
+
+func NewSafeBrowsing(apiKey string, timeout time.Duration) *SafeBrowsing {
+	return &SafeBrowsing{apiKey: apiKey, endpoint: safeBrowsingEndpoint, client: &http.Client{Timeout: timeout}}
+}
+
+type safeBrowsingEntry struct {
+	URL string `json:"url"`
+}
+
+// ScanURLs returns the URLs Safe Browsing lists as malware, phishing or unwanted software.
+func (s *SafeBrowsing) ScanURLs(ctx context.Context, urls []string) ([]models.URLThreat, error) {
+	if len(urls) == 0 {
+		return nil, nil
+	}
+	entries := make([]safeBrowsingEntry, len(urls))
+	for i, u := range urls {
+		entries[i].URL = u
+	}
+	body, _ := json.Marshal(map[string]interface{}{
+		"client": map[string]string{"clientId": "assisted-venue-approval", "clientVersion": "1.0"},
+		"threatInfo": map[string]interface{}{
+			"threatTypes":      safeBrowsingThreatTypes,
+			"platformTypes":    []string{"ANY_PLATFORM"},
+			"threatEntryTypes": []string{"URL"},
+			"threatEntries":    entries,
+		},
+	})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+"?key="+s.apiKey, bytes.NewReader(body))
+	if err != nil {
+		return nil, errs.NewExternal("scraper.SafeBrowsing", "safebrowsing", "failed to build request", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errs.NewExternalStatus("scraper.SafeBrowsing", "safebrowsing", 0, 0, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, errs.NewExternalStatus("scraper.SafeBrowsing", "safebrowsing", resp.StatusCode,
+			errs.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()), fmt.Errorf("%s", bytes.TrimSpace(msg)))
+	}
+	var out struct {
+		Matches []struct {
+			ThreatType string            `json:"threatType"`
+			Threat     safeBrowsingEntry `json:"threat"`
+		} `json:"matches"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, errs.NewExternal("scraper.SafeBrowsing", "safebrowsing", "invalid response", err)
+	}
+	threats := make([]models.URLThreat, 0, len(out.Matches))
+	for _, m := range out.Matches {
+		threats = append(threats, models.URLThreat{URL: m.Threat.URL, ThreatType: m.ThreatType, Source: "safe_browsing"})
+	}
+	return threats, nil
+}
+
+// DomainBlocklist flags URLs on locally listed domains. An entry matches the domain itself
+// and any subdomain.
+type DomainBlocklist struct {
+	domains []string
+}
+
+func NewDomainBlocklist(domains []string) *DomainBlocklist {
+	b := &DomainBlocklist{}
+	for _, d := range domains {
+		if d = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(d)), "www."); d != "" {
+			b.domains = append(b.domains, d)
+		}
+	}
+	return b
+}
+
+// LoadDomainBlocklist reads one domain per line; blank lines and lines starting with # are skipped.
+func LoadDomainBlocklist(path string) (*DomainBlocklist, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var domains []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if line := strings.TrimSpace(sc.Text()); line != "" && !strings.HasPrefix(line, "#") {
+			domains = append(domains, line)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return NewDomainBlocklist(domains), nil
+}
+
+// Len reports how many domains are listed.
+func (b *DomainBlocklist) Len() int { return len(b.domains) }
+
+// ScanURLs returns the URLs whose host is on the blocklist.
+func (b *DomainBlocklist) ScanURLs(_ context.Context, urls []string) ([]models.URLThreat, error) {
+	var threats []models.URLThreat
+	for _, u := range urls {
+		nu := normalizeWebsiteURL(u)
+		if nu == nil {
+			continue
+		}
+		host := strings.TrimPrefix(strings.ToLower(nu.Hostname()), "www.")
+		for _, d := range b.domains {
+			if host == d || strings.HasSuffix(host, "."+d) {
+				threats = append(threats, models.URLThreat{URL: u, ThreatType: "BLOCKLISTED", Source: "blocklist"})
+				break
+			}
+		}
+	}
+	return threats, nil
+}
@@ -0,0 +1,78 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+func TestSafeBrowsing(t *testing.T) {
+	var gotKey string
+	var gotURLs []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey = r.URL.Query().Get("key")
+		var body struct {
+			ThreatInfo struct {
+				ThreatEntries []struct {
+					URL string `json:"url"`
+				} `json:"threatEntries"`
+			} `json:"threatInfo"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		gotURLs = nil
+		for _, e := range body.ThreatInfo.ThreatEntries {
+			gotURLs = append(gotURLs, e.URL)
+		}
+		if gotKey == "bad" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{"matches":[{"threatType":"SOCIAL_ENGINEERING","threat":{"url":"http://phish.example/login"}}]}`))
+	}))
+	defer srv.Close()
+
+	sb := NewSafeBrowsing("k", time.Second)
+	sb.endpoint = srv.URL
+	threats, err := sb.ScanURLs(context.Background(), []string{"http://ok.example", "http://phish.example/login"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotKey != "k" || len(gotURLs) != 2 {
+		t.Fatalf("request key %q urls %v", gotKey, gotURLs)
+	}
+	if len(threats) != 1 || threats[0].ThreatType != "SOCIAL_ENGINEERING" || threats[0].Source != "safe_browsing" {
+		t.Fatalf("threats = %+v", threats)
+	}
+
+	sb.apiKey = "bad"
+	if _, err := sb.ScanURLs(context.Background(), []string{"http://ok.example"}); !errs.Is(err, errs.ErrExternal) {
+		t.Fatalf("err = %v, want external error", err)
+	}
+}
+
+func TestDomainBlocklist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blocklist.txt")
+	if err := os.WriteFile(path, []byte("# phishing kits\nbad.example\n\nWWW.Malware.test\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	b, err := LoadDomainBlocklist(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Len() != 2 {
+		t.Fatalf("Len = %d, want 2", b.Len())
+	}
+	threats, _ := b.ScanURLs(context.Background(), []string{
+		"http://shop.bad.example/x", "https://notbad.example", "malware.test", "http://good.example",
+	})
+	if len(threats) != 2 || threats[0].URL != "http://shop.bad.example/x" || threats[1].URL != "malware.test" {
+		t.Fatalf("threats = %+v", threats)
+	}
+}
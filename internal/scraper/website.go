@@ -0,0 +1,333 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+)
+
+const (
+	maxWebsiteBytes    = 1 << 20
+	maxWebsiteRedirect = 5
+	evidenceRadius     = 60
+	maxEvidence        = 3
+	maxExcerptRunes    = 4000
+	websiteUserAgent   = "Mozilla/5.0 (compatible; HappyCowVenueCheck/1.0)"
+)
+
+// Website score contributions, recorded under score_breakdown["website_verification"].
+const (
+	websiteDeadPenalty   = -5
+	websiteParkedPenalty = -5
+	websiteVeganBonus    = 3
+	websiteMenuBonus     = 2
+)
+
+// socialHosts are handled by the social profile checks, not the website scraper.
+var socialHosts = []string{"facebook.com", "fb.com", "instagram.com", "twitter.com", "x.com", "tiktok.com", "linktr.ee"}
+
+// parkedPhrases are typical of registrar parking pages and for-sale landers.
+var parkedPhrases = []string{
+	"this domain is for sale", "buy this domain", "domain is parked", "parked free",
+	"this domain may be for sale", "domain has expired", "related searches",
+}
+
+// parkedHosts are parking and domain marketplace services; a site redirecting to one is parked.
+var parkedHosts = []string{"sedoparking.com", "parkingcrew.net", "bodis.com", "hugedomains.com", "dan.com"}
+
+var (
+	scriptStyleRe = regexp.MustCompile(`(?is)<(script|style|noscript)[^>]*>.*?</(script|style|noscript)>`)
+	tagRe         = regexp.MustCompile(`(?s)<[^>]+>`)
+	spaceRe       = regexp.MustCompile(`\s+`)
+
+	// websiteKeywords are counted case-insensitively on word boundaries.
+	websiteKeywords = map[string]*regexp.Regexp{
+		"vegan":       regexp.MustCompile(`(?i)\bvegan\b`),
+		"plant-based": regexp.MustCompile(`(?i)\bplant[- ]based\b`),
+		"vegetarian":  regexp.MustCompile(`(?i)\bvegetarian\b`),
+		"menu":        regexp.MustCompile(`(?i)\bmenu\b`),
+	}
+)
+
+// WebsiteScraper fetches a venue's website and looks for evidence of vegan claims.
+// Only public addresses are dialled so a submitted URL cannot reach internal services.
+type WebsiteScraper struct {
+	client *http.Client
+}
+
+func NewWebsiteScraper(timeout time.Duration) *WebsiteScraper {
+	return &WebsiteScraper{client: newPublicHTTPClient(timeout)}
+}
+
+// newPublicHTTPClient builds a client for fetching user-submitted URLs: public addresses
+// only, bounded redirects, no proxy.
+func newPublicHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: publicAddrOnly}
+	transport := &http.Transport{
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   timeout,
+		ResponseHeaderTimeout: timeout,
+		MaxIdleConns:          20,
+		IdleConnTimeout:       30 * time.Second,
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxWebsiteRedirect {
+				return errors.New("too many redirects")
+			}
+			return nil
+		},
+	}
+}
+
+// nonPublicPrefixes are the IANA special-purpose ranges that are not reachable on the public
+// internet, or that translate to addresses which may not be (NAT64, 6to4, Teredo).
+var nonPublicPrefixes = mustParsePrefixes(
+	// IPv4
+	"0.0.0.0/8",       // "this network"
+	"10.0.0.0/8",      // private
+	"100.64.0.0/10",   // carrier-grade NAT
+	"127.0.0.0/8",     // loopback
+	"169.254.0.0/16",  // link-local, cloud metadata
+	"172.16.0.0/12",   // private
+	"192.0.0.0/24",    // IETF protocol assignments
+	"192.0.2.0/24",    // documentation
+	"192.88.99.0/24",  // 6to4 relay anycast
+	"192.168.0.0/16",  // private
+	"198.18.0.0/15",   // benchmarking
+	"198.51.100.0/24", // documentation
+	"203.0.113.0/24",  // documentation
+	"224.0.0.0/4",     // multicast
+	"240.0.0.0/4",     // reserved, broadcast
+	// IPv6; IPv4-mapped addresses are checked as IPv4
+	"::/128",         // unspecified
+	"::1/128",        // loopback
+	"64:ff9b::/96",   // NAT64
+	"64:ff9b:1::/48", // local-use NAT64
+	"100::/64",       // discard-only
+	"2001::/23",      // IETF protocol assignments, Teredo
+	"2001:db8::/32",  // documentation
+	"2002::/16",      // 6to4
+	"fc00::/7",       // unique local
+	"fe80::/10",      // link-local
+	"ff00::/8",       // multicast
+)
+
+func mustParsePrefixes(list ...string) []netip.Prefix {
+	out := make([]netip.Prefix, len(list))
+	for i, s := range list {
+		out[i] = netip.MustParsePrefix(s)
+	}
+	return out
+}
+
+// publicAddrOnly rejects addresses in nonPublicPrefixes at dial time.
+func publicAddrOnly(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if !isPublicAddr(host) {
+		return fmt.Errorf("refusing to dial non-public address %s", host)
+	}
+	return nil
+}
+
+func isPublicAddr(host string) bool {
+	ip, err := netip.ParseAddr(host)
+	if err != nil || ip.Zone() != "" {
+		return false
+	}
+	ip = ip.Unmap()
+	for _, p := range nonPublicPrefixes {
+		if p.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckWebsite fetches rawURL and reports liveness, parking and keyword evidence.
+// Returns nil when there is nothing to check (empty or social media URL).
+func (w *WebsiteScraper) CheckWebsite(ctx context.Context, rawURL string) *models.WebsiteCheck {
+	u := normalizeWebsiteURL(rawURL)
+	if u == nil || isSocialHost(u.Hostname()) {
+		return nil
+	}
+	check := &models.WebsiteCheck{URL: u.String(), CheckedAt: time.Now()}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		check.Error = err.Error()
+		check.Score = scoreWebsite(check)
+		return check
+	}
+	req.Header.Set("User-Agent", websiteUserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		check.Error = err.Error()
+		check.Score = scoreWebsite(check)
+		return check
+	}
+	defer resp.Body.Close()
+
+	check.StatusCode = resp.StatusCode
+	check.FinalURL = resp.Request.URL.String()
+	check.Live = resp.StatusCode >= 200 && resp.StatusCode < 400
+	if check.Live {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebsiteBytes))
+		analyzeWebsite(check, string(body))
+		check.Parked = check.Parked || redirectedToParking(resp)
+	}
+	check.Score = scoreWebsite(check)
+	return check
+}
+
+// analyzeWebsite fills keyword counts, parking and evidence from an HTML body.
+func analyzeWebsite(check *models.WebsiteCheck, body string) {
+	text := extractText(body)
+	lowerText, lowerBody := strings.ToLower(text), strings.ToLower(body)
+	for _, p := range parkedPhrases {
+		if strings.Contains(lowerText, p) || strings.Contains(lowerBody, p) {
+			check.Parked = true
+			break
+		}
+	}
+
+	check.Keywords = map[string]int{}
+	for k, re := range websiteKeywords {
+		if n := len(re.FindAllStringIndex(text, -1)); n > 0 {
+			check.Keywords[k] = n
+		}
+	}
+	check.HasMenu = check.Keywords["menu"] > 0
+	check.Excerpt = excerpt(text, maxExcerptRunes)
+
+	for _, k := range []string{"vegan", "plant-based"} {
+		for _, loc := range websiteKeywords[k].FindAllStringIndex(text, maxEvidence) {
+			if len(check.Evidence) >= maxEvidence {
+				return
+			}
+			check.Evidence = append(check.Evidence, snippet(text, loc[0], loc[1]))
+		}
+	}
+}
+
+// scoreWebsite turns a check into a score contribution. Evidence only counts on a live, unparked site.
+func scoreWebsite(c *models.WebsiteCheck) int {
+	switch {
+	case c == nil:
+		return 0
+	case !c.Live:
+		return websiteDeadPenalty
+	case c.Parked:
+		return websiteParkedPenalty
+	}
+	score := 0
+	if c.Keywords["vegan"] > 0 || c.Keywords["plant-based"] > 0 {
+		score += websiteVeganBonus
+		if c.HasMenu {
+			score += websiteMenuBonus
+		}
+	}
+	return score
+}
+
+func extractText(body string) string {
+	body = scriptStyleRe.ReplaceAllString(body, " ")
+	body = tagRe.ReplaceAllString(body, " ")
+	body = html.UnescapeString(body)
+	return strings.TrimSpace(spaceRe.ReplaceAllString(body, " "))
+}
+
+// excerpt returns the first n runes of text.
+func excerpt(text string, n int) string {
+	r := []rune(text)
+	if len(r) <= n {
+		return text
+	}
+	return string(r[:n])
+}
+
+func snippet(text string, start, end int) string {
+	from, to := start-evidenceRadius, end+evidenceRadius
+	prefix, suffix := "…", "…"
+	if from <= 0 {
+		from, prefix = 0, ""
+	}
+	if to >= len(text) {
+		to, suffix = len(text), ""
+	}
+	// Avoid cutting multi-byte runes in half
+	for from > 0 && !utf8RuneStart(text[from]) {
+		from--
+	}
+	for to < len(text) && !utf8RuneStart(text[to]) {
+		to++
+	}
+	return prefix + strings.TrimSpace(text[from:to]) + suffix
+}
+
+func utf8RuneStart(b byte) bool { return b&0xC0 != 0x80 }
+
+func normalizeWebsiteURL(raw string) *url.URL {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return nil
+	}
+	return u
+}
+
+func isSocialHost(host string) bool {
+	return hostIn(host, socialHosts)
+}
+
+// redirectedToParking reports whether resp, or a redirect on the way to it, came from a
+// parking service.
+func redirectedToParking(resp *http.Response) bool {
+	for r := resp.Request; r != nil; {
+		if hostIn(r.URL.Hostname(), parkedHosts) {
+			return true
+		}
+		if r.Response == nil {
+			break
+		}
+		r = r.Response.Request
+	}
+	return false
+}
+
+// hostIn reports whether host is one of domains or a subdomain of one.
+func hostIn(host string, domains []string) bool {
+	host = strings.TrimPrefix(strings.ToLower(host), "www.")
+	for _, d := range domains {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package scraper
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"assisted-venue-approval/internal/models"
+)
+
+func TestAnalyzeWebsite(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantParked bool
+		wantMenu   bool
+		wantScore  int
+		wantEvid   int
+	}{
+		{
+			name:      "vegan menu",
+			body:      `<html><script>var vegan=1</script><h1>Green Leaf</h1><p>Our 100% vegan kitchen.</p><a href="/menu">Menu</a></html>`,
+			wantMenu:  true,
+			wantScore: websiteVeganBonus + websiteMenuBonus,
+			wantEvid:  1,
+		},
+		{
+			name:      "plant based without menu",
+			body:      `<p>Plant-based bowls &amp; smoothies</p>`,
+			wantScore: websiteVeganBonus,
+			wantEvid:  1,
+		},
+		{
+			name:      "no claims",
+			body:      `<p>Steakhouse since 1990. See our menu.</p>`,
+			wantMenu:  true,
+			wantScore: 0,
+		},
+		{
+			name:       "parked domain",
+			body:       `<p>This domain is for sale! Vegan related searches</p>`,
+			wantParked: true,
+			wantScore:  websiteParkedPenalty,
+			wantEvid:   1,
+		},
+		{
+			name:      "parking services named in the page",
+			body:      `<p>Vegan bakery. Order at jordan.com or see <a href="https://bodis.com/">our friends</a>.</p>`,
+			wantScore: websiteVeganBonus,
+			wantEvid:  1,
+		},
+		{
+			name:      "script content ignored",
+			body:      `<style>.vegan{}</style><script>menu("vegan")</script><p>Hello</p>`,
+			wantScore: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &models.WebsiteCheck{Live: true}
+			analyzeWebsite(c, tt.body)
+			if c.Parked != tt.wantParked || c.HasMenu != tt.wantMenu {
+				t.Fatalf("parked=%v menu=%v", c.Parked, c.HasMenu)
+			}
+			if got := scoreWebsite(c); got != tt.wantScore {
+				t.Fatalf("score=%d want %d", got, tt.wantScore)
+			}
+			if len(c.Evidence) != tt.wantEvid {
+				t.Fatalf("evidence=%q", c.Evidence)
+			}
+		})
+	}
+}
+
+func TestNormalizeWebsiteURL(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   string
+		social bool
+	}{
+		{"", "", false},
+		{"greenleaf.example", "http://greenleaf.example", false},
+		{"https://www.instagram.com/greenleaf", "https://www.instagram.com/greenleaf", true},
+		{"ftp://greenleaf.example", "", false},
+	}
+	for _, tt := range tests {
+		u := normalizeWebsiteURL(tt.in)
+		got := ""
+		if u != nil {
+			got = u.String()
+			if isSocialHost(u.Hostname()) != tt.social {
+				t.Fatalf("%q social=%v", tt.in, !tt.social)
+			}
+		}
+		if got != tt.want {
+			t.Fatalf("%q -> %q want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPublicAddrOnly(t *testing.T) {
+	tests := []struct {
+		addr   string
+		public bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"100.63.255.255", true},
+		{"198.20.0.1", true},
+		{"0.0.0.0", false},
+		{"0.1.2.3", false},
+		{"10.0.0.1", false},
+		{"100.64.0.1", false},
+		{"100.127.255.254", false},
+		{"127.0.0.1", false},
+		{"169.254.169.254", false},
+		{"172.16.5.4", false},
+		{"192.0.0.8", false},
+		{"192.0.2.10", false},
+		{"192.88.99.1", false},
+		{"192.168.1.1", false},
+		{"198.18.0.1", false},
+		{"198.19.255.255", false},
+		{"198.51.100.7", false},
+		{"203.0.113.9", false},
+		{"224.0.0.251", false},
+		{"240.0.0.1", false},
+		{"255.255.255.255", false},
+		{"::", false},
+		{"::1", false},
+		{"::ffff:100.64.0.1", false},
+		{"::ffff:127.0.0.1", false},
+		{"64:ff9b::a00:1", false},
+		{"100::1", false},
+		{"2001::1", false},
+		{"2001:db8::1", false},
+		{"2002:a00:1::1", false},
+		{"fd00::1", false},
+		{"fe80::1", false},
+		{"ff02::1", false},
+	}
+	for _, tt := range tests {
+		err := publicAddrOnly("tcp", net.JoinHostPort(tt.addr, "443"), nil)
+		if (err == nil) != tt.public {
+			t.Errorf("%s: err = %v, want public %v", tt.addr, err, tt.public)
+		}
+	}
+}
+
+func TestRedirectedToParking(t *testing.T) {
+	hop := func(rawURL string, from *http.Response) *http.Response {
+		u, _ := url.Parse(rawURL)
+		return &http.Response{Request: &http.Request{URL: u, Response: from}}
+	}
+	tests := []struct {
+		name string
+		resp *http.Response
+		want bool
+	}{
+		{"own site", hop("https://greenleaf.example/", nil), false},
+		{"lookalike host", hop("https://jordan.com/", nil), false},
+		{"marketplace", hop("https://dan.com/buy-domain/greenleaf.example", hop("http://greenleaf.example/", nil)), true},
+		{"through a parking hop", hop("https://greenleaf.example/lander", hop("https://ww1.sedoparking.com/", hop("http://greenleaf.example/", nil))), true},
+	}
+	for _, tt := range tests {
+		if got := redirectedToParking(tt.resp); got != tt.want {
+			t.Errorf("%s: parked = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package scraper
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"gopkg.in/yaml.v3"
+
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// Weights is the points table CompareVenueData uses, loaded from SCORE_WEIGHTS_FILE.
+// Fields must add up to 100 because the auto-decision cut-offs (85/50) are on that scale.
+type Weights struct {
+	Profile        string `yaml:"profile" json:"profile"`
+	Name           int    `yaml:"name" json:"name"`
+	Address        int    `yaml:"address" json:"address"`
+	Geolocation    int    `yaml:"geolocation" json:"geolocation"`
+	Phone          int    `yaml:"phone" json:"phone"`
+	Hours          int    `yaml:"hours" json:"hours"`
+	Website        int    `yaml:"website" json:"website"`
+	BusinessStatus int    `yaml:"business_status" json:"business_status"`
+	PostalCode     int    `yaml:"postal_code" json:"postal_code"`
+	VeganRelevance int    `yaml:"vegan_relevance" json:"vegan_relevance"`
+}
+
+// DefaultWeights is the table CompareVenueData always used before it became configurable.
+var DefaultWeights = Weights{
+	Profile:        "default",
+	Name:           25,
+	Address:        20,
+	Geolocation:    15,
+	Phone:          10,
+	Hours:          10,
+	Website:        5,
+	BusinessStatus: 5,
+	PostalCode:     5,
+	VeganRelevance: 5,
+}
+
+var activeWeights atomic.Pointer[Weights]
+
+// ActiveWeights returns the table currently used by CompareVenueData.
+func ActiveWeights() Weights {
+	if w := activeWeights.Load(); w != nil {
+		return *w
+	}
+	return DefaultWeights
+}
+
+// SetWeights swaps the active table. nil restores DefaultWeights.
+// Safe to call while venues are being compared; each comparison reads the table once.
+func SetWeights(w *Weights) {
+	if w == nil {
+		activeWeights.Store(nil)
+		return
+	}
+	cp := *w
+	activeWeights.Store(&cp)
+}
+
+// LoadWeights reads and validates a weights file. Empty path returns (nil, nil).
+func LoadWeights(path string) (*Weights, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("read score weights: %w", err)
+	}
+	return ParseWeights(data)
+}
+
+// ParseWeights decodes YAML and validates it. Every field is required: a missing
+// weight would silently drop a signal, so unlike decision rules there is no merging with defaults.
+func ParseWeights(data []byte) (*Weights, error) {
+	var w Weights
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&w); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errs.NewValidation("scraper.ParseWeights", "empty weights file", nil)
+		}
+		return nil, errs.NewValidation("scraper.ParseWeights", "invalid yaml", err)
+	}
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// Validate checks the profile name, that no weight is negative and that they sum to 100.
+func (w Weights) Validate() error {
+	var problems []string
+	if strings.TrimSpace(w.Profile) == "" {
+		problems = append(problems, "profile is required")
+	}
+	sum := 0
+	for _, f := range w.fields() {
+		if f.points < 0 {
+			problems = append(problems, fmt.Sprintf("%s must not be negative, got %d", f.name, f.points))
+		}
+		sum += f.points
+	}
+	if sum != 100 {
+		problems = append(problems, fmt.Sprintf("weights must sum to 100, got %d", sum))
+	}
+	if len(problems) > 0 {
+		return errs.NewValidation("scraper.Weights.Validate", strings.Join(problems, "; "), nil)
+	}
+	return nil
+}
+
+type weightField struct {
+	name   string
+	points int
+}
+
+func (w Weights) fields() []weightField {
+	return []weightField{
+		{"name", w.Name}, {"address", w.Address}, {"geolocation", w.Geolocation},
+		{"phone", w.Phone}, {"hours", w.Hours}, {"website", w.Website},
+		{"business_status", w.BusinessStatus}, {"postal_code", w.PostalCode},
+		{"vegan_relevance", w.VeganRelevance},
+	}
+}
@@ -0,0 +1,66 @@
+package scraper
+
+import (
+	"os"
+	"testing"
+
+	"assisted-venue-approval/internal/models"
+)
+
+func TestParseWeights_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr bool
+	}{
+		{"sums to 100", "profile: p\nname: 30\naddress: 20\ngeolocation: 10\nphone: 10\nhours: 10\nwebsite: 5\nbusiness_status: 5\npostal_code: 5\nvegan_relevance: 5\n", false},
+		{"sums to 95", "profile: p\nname: 25\naddress: 20\ngeolocation: 10\nphone: 10\nhours: 10\nwebsite: 5\nbusiness_status: 5\npostal_code: 5\nvegan_relevance: 5\n", true},
+		{"negative", "profile: p\nname: 40\naddress: -5\ngeolocation: 15\nphone: 10\nhours: 10\nwebsite: 5\nbusiness_status: 10\npostal_code: 10\nvegan_relevance: 5\n", true},
+		{"missing profile", "name: 25\naddress: 20\ngeolocation: 15\nphone: 10\nhours: 10\nwebsite: 5\nbusiness_status: 5\npostal_code: 5\nvegan_relevance: 5\n", true},
+		{"unknown key", "profile: p\nnmae: 25\n", true},
+		{"empty", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseWeights([]byte(tt.yaml))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseWeights() err=%v, wantErr=%v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseWeights_DistFileMatchesDefaults(t *testing.T) {
+	data, err := os.ReadFile("../../score_weights.yaml.dist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := ParseWeights(data)
+	if err != nil {
+		t.Fatalf("dist file invalid: %v", err)
+	}
+	if *w != DefaultWeights {
+		t.Fatalf("dist file %+v differs from DefaultWeights %+v", *w, DefaultWeights)
+	}
+}
+
+func TestCompareVenueData_UsesActiveWeights(t *testing.T) {
+	defer SetWeights(nil)
+	venue := models.Venue{Name: "Green Leaf Cafe", Location: "1 Main St"}
+	google := models.GooglePlaceData{Name: "Green Leaf Cafe", FormattedAddress: "Somewhere else entirely", BusinessStatus: "OPERATIONAL"}
+
+	got := CompareVenueData(venue, google).ScoreBreakdown
+	if got.WeightProfile != "default" || got.VenueNameMatch != 25 {
+		t.Fatalf("default profile: %+v", got)
+	}
+
+	SetWeights(&Weights{Profile: "name-heavy", Name: 60, Address: 10, Geolocation: 5, Phone: 5, Hours: 5,
+		Website: 5, BusinessStatus: 5, PostalCode: 0, VeganRelevance: 5})
+	got = CompareVenueData(venue, google).ScoreBreakdown
+	if got.WeightProfile != "name-heavy" || got.VenueNameMatch != 60 || got.PostalCode != 0 {
+		t.Fatalf("name-heavy profile: %+v", got)
+	}
+	if got.BusinessStatus != 5 {
+		t.Fatalf("business status points=%d want 5", got.BusinessStatus)
+	}
+}
@@ -0,0 +1,237 @@
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/netip"
+	"net/url"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// Router finds the tenant of a request.
+type Router struct {
+	hosts    map[string]*Tenant
+	prefixes []*Tenant // longest first
+}
+
+// NewRouter indexes validated tenants by host and path prefix.
+func NewRouter(tenants []Tenant) *Router {
+	r := &Router{hosts: map[string]*Tenant{}}
+	for i := range tenants {
+		t := &tenants[i]
+		for _, h := range t.Hosts {
+			r.hosts[h] = t
+		}
+		if t.PathPrefix != "" {
+			r.prefixes = append(r.prefixes, t)
+		}
+	}
+	sort.Slice(r.prefixes, func(i, j int) bool { return len(r.prefixes[i].PathPrefix) > len(r.prefixes[j].PathPrefix) })
+	return r
+}
+
+// Resolve returns the tenant listing the request's host, else the one with the longest path
+// prefix containing its path, or nil.
+func (r *Router) Resolve(req *http.Request) *Tenant {
+	host := req.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if t, ok := r.hosts[strings.ToLower(host)]; ok {
+		return t
+	}
+	for _, t := range r.prefixes {
+		if req.URL.Path == t.PathPrefix || strings.HasPrefix(req.URL.Path, t.PathPrefix+"/") {
+			return t
+		}
+	}
+	return nil
+}
+
+// GatewayConfig configures the tenant processes.
+type GatewayConfig struct {
+	// PortBase is the first local port; tenant i listens on PortBase+3i (HTTP), +1
+	// (pprof/metrics) and +2 (gRPC)
+	PortBase int
+	// RestartDelay is the wait before restarting a tenant process that exited
+	RestartDelay time.Duration
+	// TrustedProxies are the load balancers in front of the gateway. Only their
+	// X-Forwarded-For is read, and only the address they saw the client connect from reaches
+	// the tenants, so a client cannot choose the address admin IP checks see
+	TrustedProxies []netip.Prefix
+}
+
+// ParseTrustedProxies parses addresses and CIDR ranges such as "10.0.0.0/8" or "192.0.2.10".
+func ParseTrustedProxies(list []string) ([]netip.Prefix, error) {
+	var out []netip.Prefix
+	for _, s := range list {
+		if p, err := netip.ParsePrefix(s); err == nil {
+			out = append(out, p.Masked())
+			continue
+		}
+		a, err := netip.ParseAddr(s)
+		if err != nil {
+			return nil, errs.NewValidation("tenant.ParseTrustedProxies", fmt.Sprintf("%q is neither an IP address nor a CIDR range", s), err)
+		}
+		out = append(out, netip.PrefixFrom(a.Unmap(), a.Unmap().BitLen()))
+	}
+	return out, nil
+}
+
+// Gateway runs one app process per tenant and proxies requests to them. Each process is the
+// same binary with the tenant's environment, so tenants share no in-memory state.
+type Gateway struct {
+	tenants []Tenant
+	cfg     GatewayConfig
+	router  *Router
+	proxies map[string]http.Handler
+	ports   map[string]Ports
+}
+
+// NewGateway prepares the proxies; Run starts the tenant processes.
+func NewGateway(tenants []Tenant, cfg GatewayConfig) *Gateway {
+	if cfg.RestartDelay <= 0 {
+		cfg.RestartDelay = 5 * time.Second
+	}
+	g := &Gateway{tenants: tenants, cfg: cfg, router: NewRouter(tenants),
+		proxies: map[string]http.Handler{}, ports: map[string]Ports{}}
+	for i, t := range tenants {
+		p := Ports{HTTP: cfg.PortBase + 3*i, Profiling: cfg.PortBase + 3*i + 1, GRPC: cfg.PortBase + 3*i + 2}
+		g.ports[t.ID] = p
+		g.proxies[t.ID] = newProxy(t.ID, &url.URL{Scheme: "http", Host: fmt.Sprintf("127.0.0.1:%d", p.HTTP)}, cfg.TrustedProxies)
+	}
+	return g
+}
+
+// newProxy forwards to target keeping the original Host and path, which the tenant process
+// serves under its BASE_PATH. From a trusted proxy the client address in the incoming
+// X-Forwarded-For is passed on (see forwardedClient), so admin IP checks see the client behind
+// the load balancer but not an address a client made up.
+func newProxy(id string, target *url.URL, trusted []netip.Prefix) http.Handler {
+	return &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			if prior, ok := pr.In.Header["X-Forwarded-For"]; ok && trustedPeer(pr.In.RemoteAddr, trusted) {
+				if client := forwardedClient(prior, trusted); client != "" {
+					pr.Out.Header.Set("X-Forwarded-For", client)
+				}
+			}
+			pr.SetURL(target)
+			pr.SetXForwarded()
+			pr.Out.Host = pr.In.Host
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			log.Printf("tenant %s: proxy %s: %v", id, r.URL.Path, err)
+			http.Error(w, "Tenant unavailable", http.StatusBadGateway)
+		},
+	}
+}
+
+// forwardedClient returns the address the outermost trusted proxy saw the client connect
+// from. Load balancers append to whatever X-Forwarded-For the client sent, so only the
+// entries added by trusted hops are believed: the chain is walked right to left, skipping
+// trusted addresses, and the first other address is the client. If every entry is trusted
+// the leftmost is used; an entry that is not an IP address ends the walk with no client.
+func forwardedClient(values []string, trusted []netip.Prefix) string {
+	var hops []string
+	for _, v := range values {
+		hops = append(hops, strings.Split(v, ",")...)
+	}
+	client := ""
+	for i := len(hops) - 1; i >= 0; i-- {
+		a, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			return ""
+		}
+		client = a.Unmap().String()
+		if !trustedAddr(a, trusted) {
+			break
+		}
+	}
+	return client
+}
+
+// trustedPeer reports whether the connection's remote address is one of the trusted proxies.
+func trustedPeer(remoteAddr string, trusted []netip.Prefix) bool {
+	ap, err := netip.ParseAddrPort(remoteAddr)
+	if err != nil {
+		return false
+	}
+	return trustedAddr(ap.Addr(), trusted)
+}
+
+// trustedAddr reports whether a is in one of the trusted ranges.
+func trustedAddr(a netip.Addr, trusted []netip.Prefix) bool {
+	for _, p := range trusted {
+		if p.Contains(a.Unmap()) {
+			return true
+		}
+	}
+	return false
+}
+
+// ServeHTTP proxies to the request's tenant; requests matching no tenant get 404.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	t := g.router.Resolve(r)
+	if t == nil {
+		http.Error(w, "Unknown tenant", http.StatusNotFound)
+		return
+	}
+	g.proxies[t.ID].ServeHTTP(w, r)
+}
+
+// Run starts a process per tenant and restarts any that exits, until ctx is cancelled. It
+// then sends the processes SIGTERM, giving each time for its graceful shutdown, and returns
+// when all have exited.
+func (g *Gateway) Run(ctx context.Context) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("tenant gateway: %w", err)
+	}
+	done := make(chan struct{}, len(g.tenants))
+	for _, t := range g.tenants {
+		go func(t Tenant) {
+			g.supervise(ctx, exe, t)
+			done <- struct{}{}
+		}(t)
+	}
+	for range g.tenants {
+		<-done
+	}
+	return nil
+}
+
+func (g *Gateway) supervise(ctx context.Context, exe string, t Tenant) {
+	ports := g.ports[t.ID]
+	for {
+		cmd := exec.CommandContext(ctx, exe, os.Args[1:]...)
+		cmd.Env = t.Environ(os.Environ(), ports)
+		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+		// The app stops its engine and drains requests on SIGTERM
+		cmd.Cancel = func() error { return cmd.Process.Signal(syscall.SIGTERM) }
+		cmd.WaitDelay = 45 * time.Second
+
+		log.Printf("tenant %s: starting on port %d", t.ID, ports.HTTP)
+		err := cmd.Run()
+		if ctx.Err() != nil {
+			log.Printf("tenant %s: stopped", t.ID)
+			return
+		}
+		log.Printf("tenant %s: exited (%v), restarting in %s", t.ID, err, g.cfg.RestartDelay)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(g.cfg.RestartDelay):
+		}
+	}
+}
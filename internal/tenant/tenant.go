@@ -0,0 +1,236 @@
+// Package tenant lets one deployment serve several listing brands or regions with isolated
+// data. TENANTS_FILE lists the tenants, each with its own database and optional prompts,
+// thresholds and budgets. The gateway runs one app process per tenant, with the tenant's
+// settings layered over the shared environment, and routes each request to its tenant by
+// host name or path prefix.
+package tenant
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// Tenant is one brand or region. It is routed by Hosts or by PathPrefix.
+type Tenant struct {
+	ID   string `yaml:"id"`
+	Name string `yaml:"name"`
+	// Hosts are the host names the tenant answers on, such as reviews.brand.example
+	Hosts []string `yaml:"hosts"`
+	// PathPrefix mounts the tenant under a path such as /brand, which becomes its BASE_PATH
+	PathPrefix string `yaml:"path_prefix"`
+
+	DatabaseURL       string `yaml:"database_url"`
+	PromptDir         string `yaml:"prompt_dir"`
+	ApprovalThreshold int    `yaml:"approval_threshold"` // 0 = APPROVAL_THRESHOLD
+	// QuotaShare is the percent of the shared Google and OpenAI rate limits and daily quotas
+	// the tenant may use; 0 lets it use all of them
+	QuotaShare float64 `yaml:"quota_share"`
+	// Env overrides any other setting for this tenant, e.g. PHOTO_CHECK_DAILY_BUDGET_USD
+	Env map[string]string `yaml:"env"`
+}
+
+// BasePath is the BASE_PATH of the tenant's app process.
+func (t Tenant) BasePath() string {
+	return t.PathPrefix + "/"
+}
+
+// reservedEnv are set by the gateway or by the typed fields, so Env may not set them.
+var reservedEnv = map[string]string{
+	"PORT":               "the gateway assigns it",
+	"HTTP_BIND":          "tenants listen on 127.0.0.1 so clients cannot bypass the gateway",
+	"BASE_PATH":          "use path_prefix",
+	"TENANT":             "use id",
+	"TENANT_NAME":        "use name",
+	"TENANTS_FILE":       "tenant processes do not read it",
+	"DATABASE_URL":       "use database_url",
+	"PROMPT_DIR":         "use prompt_dir",
+	"APPROVAL_THRESHOLD": "use approval_threshold",
+	"QUOTA_SHARE":        "use quota_share",
+}
+
+var (
+	idPattern  = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+	envPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+)
+
+// LoadFile reads and validates a tenants file.
+func LoadFile(path string) ([]Tenant, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("read tenants file: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes a tenants file ({tenants: [...]}) and validates it.
+func Parse(data []byte) ([]Tenant, error) {
+	var file struct {
+		Tenants []Tenant `yaml:"tenants"`
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errs.NewValidation("tenant.Parse", "empty tenants file", nil)
+		}
+		return nil, errs.NewValidation("tenant.Parse", "invalid yaml", err)
+	}
+	for i := range file.Tenants {
+		t := &file.Tenants[i]
+		for j, h := range t.Hosts {
+			t.Hosts[j] = strings.ToLower(strings.TrimSpace(h))
+		}
+		if t.PathPrefix != "/" {
+			t.PathPrefix = strings.TrimSuffix(strings.TrimSpace(t.PathPrefix), "/")
+		}
+	}
+	if err := Validate(file.Tenants); err != nil {
+		return nil, err
+	}
+	return file.Tenants, nil
+}
+
+// Validate checks that every tenant is routable and has its own database. It collects all
+// problems so the operator can fix the file in one pass.
+func Validate(tenants []Tenant) error {
+	var problems []string
+	add := func(format string, a ...any) { problems = append(problems, fmt.Sprintf(format, a...)) }
+
+	if len(tenants) == 0 {
+		add("no tenants")
+	}
+	ids, hosts, prefixes, dbs := map[string]bool{}, map[string]string{}, map[string]string{}, map[string]string{}
+	var quotaTotal float64
+	for i, t := range tenants {
+		name := t.ID
+		if !idPattern.MatchString(t.ID) {
+			add("tenant %d: id %q must be lowercase letters, digits, - and _", i+1, t.ID)
+			name = fmt.Sprintf("tenant %d", i+1)
+		} else if ids[t.ID] {
+			add("%s: duplicate id", name)
+		}
+		ids[t.ID] = true
+
+		switch {
+		case len(t.Hosts) == 0 && t.PathPrefix == "":
+			add("%s: set hosts or path_prefix", name)
+		case len(t.Hosts) > 0 && t.PathPrefix != "":
+			add("%s: set hosts or path_prefix, not both", name)
+		}
+		for _, h := range t.Hosts {
+			if h == "" || strings.ContainsAny(h, "/: ") {
+				add("%s: host %q must be a bare host name", name, h)
+			} else if other, dup := hosts[h]; dup {
+				add("%s: host %s is also used by %s", name, h, other)
+			}
+			hosts[h] = name
+		}
+		if p := t.PathPrefix; p != "" {
+			if p == "/" || !strings.HasPrefix(p, "/") || strings.ContainsAny(p, "?# ") {
+				add("%s: path_prefix %q must be a path such as /brand", name, p)
+			} else if other, dup := prefixes[p]; dup {
+				add("%s: path_prefix %s is also used by %s", name, p, other)
+			}
+			prefixes[p] = name
+		}
+
+		if t.DatabaseURL == "" {
+			add("%s: database_url is required", name)
+		} else if other, dup := dbs[t.DatabaseURL]; dup {
+			add("%s: database_url is also used by %s; tenants need their own database", name, other)
+		}
+		dbs[t.DatabaseURL] = name
+		if t.ApprovalThreshold < 0 || t.ApprovalThreshold > 100 {
+			add("%s: approval_threshold must be 0-100, got %d", name, t.ApprovalThreshold)
+		}
+		if t.QuotaShare < 0 || t.QuotaShare > 100 {
+			add("%s: quota_share must be 0-100, got %g", name, t.QuotaShare)
+		}
+		quotaTotal += t.QuotaShare
+		for _, k := range sortedKeys(t.Env) {
+			if why, ok := reservedEnv[k]; ok {
+				add("%s: env cannot set %s: %s", name, k, why)
+			} else if !envPattern.MatchString(k) {
+				add("%s: env name %q is not an environment variable", name, k)
+			}
+		}
+	}
+	if quotaTotal > 100 {
+		add("quota_share of all tenants adds up to %g, more than 100", quotaTotal)
+	}
+
+	if len(problems) > 0 {
+		return errs.NewValidation("tenant.Validate", strings.Join(problems, "; "), nil)
+	}
+	return nil
+}
+
+// Ports are the listeners of one tenant process.
+type Ports struct {
+	HTTP, Profiling, GRPC int
+}
+
+// Environ returns the environment of the tenant's app process: base (the gateway's own
+// environment) with the tenant's settings and ports replacing any existing values. Env may
+// set PROFILING_PORT or GRPC_PORT to pin those listeners.
+func (t Tenant) Environ(base []string, ports Ports) []string {
+	set := map[string]string{
+		"TENANT":                 t.ID,
+		"TENANTS_FILE":           "",
+		"PORT":                   fmt.Sprint(ports.HTTP),
+		"HTTP_BIND":              "127.0.0.1",
+		"BASE_PATH":              t.BasePath(),
+		"DATABASE_URL":           t.DatabaseURL,
+		"PROFILING_PORT":         fmt.Sprint(ports.Profiling),
+		"GRPC_PORT":              fmt.Sprint(ports.GRPC),
+		"TENANT_NAME":            t.Name,
+		"TENANT_PORT_BASE":       "",
+		"TENANT_TRUSTED_PROXIES": "",
+	}
+	if t.PromptDir != "" {
+		set["PROMPT_DIR"] = t.PromptDir
+	}
+	if t.ApprovalThreshold > 0 {
+		set["APPROVAL_THRESHOLD"] = fmt.Sprint(t.ApprovalThreshold)
+	}
+	if t.QuotaShare > 0 {
+		set["QUOTA_SHARE"] = fmt.Sprint(t.QuotaShare)
+	}
+	for k, v := range t.Env {
+		set[k] = v
+	}
+
+	env := make([]string, 0, len(base)+len(set))
+	for _, kv := range base {
+		k, _, _ := strings.Cut(kv, "=")
+		if _, ok := set[k]; !ok {
+			env = append(env, kv)
+		}
+	}
+	for _, k := range sortedKeys(set) {
+		if set[k] != "" {
+			env = append(env, k+"="+set[k])
+		}
+	}
+	return env
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
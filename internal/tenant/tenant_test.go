@@ -0,0 +1,173 @@
+package tenant
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+const sample = `
+tenants:
+  - id: happycow
+    name: HappyCow
+    hosts: [Reviews.HappyCow.example]
+    database_url: "u:p@tcp(db:3306)/hc"
+  - id: asia
+    path_prefix: /asia/
+    database_url: "u:p@tcp(db:3306)/asia"
+    prompt_dir: /prompts/asia
+    approval_threshold: 80
+    quota_share: 25
+    env:
+      RESCORE_DAILY_BUDGET_USD: "1"
+  - id: asia-jp
+    path_prefix: /asia/jp
+    database_url: "u:p@tcp(db:3306)/jp"
+`
+
+func TestParse(t *testing.T) {
+	tenants, err := Parse([]byte(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tenants) != 3 || tenants[0].Hosts[0] != "reviews.happycow.example" || tenants[1].PathPrefix != "/asia" {
+		t.Fatalf("tenants = %+v", tenants)
+	}
+	if tenants[0].BasePath() != "/" || tenants[1].BasePath() != "/asia/" {
+		t.Errorf("base paths = %q, %q", tenants[0].BasePath(), tenants[1].BasePath())
+	}
+
+	for name, tc := range map[string]struct{ yaml, want string }{
+		"empty":          {``, "empty tenants file"},
+		"unknown field":  {"tenants:\n  - id: a\n    databse_url: x\n", "invalid yaml"},
+		"no tenants":     {"tenants: []\n", "no tenants"},
+		"bad id":         {"tenants:\n  - id: Brand\n    hosts: [a]\n    database_url: x\n", `id "Brand"`},
+		"unroutable":     {"tenants:\n  - id: a\n    database_url: x\n", "a: set hosts or path_prefix"},
+		"both routes":    {"tenants:\n  - id: a\n    hosts: [a]\n    path_prefix: /a\n    database_url: x\n", "not both"},
+		"host with port": {"tenants:\n  - id: a\n    hosts: [a.example:80]\n    database_url: x\n", "bare host name"},
+		"root prefix":    {"tenants:\n  - id: a\n    path_prefix: /\n    database_url: x\n", "must be a path"},
+		"shared db": {"tenants:\n  - id: a\n    hosts: [a]\n    database_url: x\n  - id: b\n    hosts: [b]\n    database_url: x\n",
+			"b: database_url is also used by a"},
+		"shared host": {"tenants:\n  - id: a\n    hosts: [a]\n    database_url: x\n  - id: b\n    hosts: [A]\n    database_url: y\n",
+			"b: host a is also used by a"},
+		"reserved env": {"tenants:\n  - id: a\n    hosts: [a]\n    database_url: x\n    env: {BASE_PATH: /x}\n", "env cannot set BASE_PATH"},
+		"threshold":    {"tenants:\n  - id: a\n    hosts: [a]\n    database_url: x\n    approval_threshold: 101\n", "0-100"},
+		"quota shares": {"tenants:\n  - id: a\n    hosts: [a]\n    database_url: x\n    quota_share: 60\n  - id: b\n    hosts: [b]\n    database_url: y\n    quota_share: 50\n",
+			"adds up to 110"},
+	} {
+		_, err := Parse([]byte(tc.yaml))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, want %q", name, err, tc.want)
+		}
+	}
+}
+
+func TestEnviron(t *testing.T) {
+	tenants, err := Parse([]byte(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := []string{"OPENAI_API_KEY=shared", "DATABASE_URL=gateway", "TENANTS_FILE=/etc/tenants.yaml", "RESCORE_DAILY_BUDGET_USD=5", "PORT=8080"}
+	got := strings.Join(tenants[1].Environ(base, Ports{HTTP: 9103, Profiling: 9104, GRPC: 9105}), " ")
+	want := "OPENAI_API_KEY=shared APPROVAL_THRESHOLD=80 BASE_PATH=/asia/ DATABASE_URL=u:p@tcp(db:3306)/asia " +
+		"GRPC_PORT=9105 HTTP_BIND=127.0.0.1 PORT=9103 PROFILING_PORT=9104 PROMPT_DIR=/prompts/asia QUOTA_SHARE=25 RESCORE_DAILY_BUDGET_USD=1 TENANT=asia"
+	if got != want {
+		t.Errorf("environ =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRouter(t *testing.T) {
+	tenants, err := Parse([]byte(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewRouter(tenants)
+	for _, tc := range []struct{ host, path, want string }{
+		{"reviews.happycow.example", "/venues/1", "happycow"},
+		{"REVIEWS.happycow.example:443", "/asia/x", "happycow"},
+		{"gw.example", "/asia", "asia"},
+		{"gw.example", "/asia/venues/1", "asia"},
+		{"gw.example", "/asia/jp/venues/1", "asia-jp"},
+		{"gw.example", "/asian/", ""},
+		{"gw.example", "/", ""},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "http://"+tc.host+tc.path, nil)
+		var got string
+		if tn := r.Resolve(req); tn != nil {
+			got = tn.ID
+		}
+		if got != tc.want {
+			t.Errorf("%s%s: tenant %q, want %q", tc.host, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestProxy(t *testing.T) {
+	var gotHost, gotPath, gotXFF string
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHost, gotPath, gotXFF = r.Host, r.URL.Path, r.Header.Get("X-Forwarded-For")
+	}))
+	defer backend.Close()
+	target, _ := url.Parse(backend.URL)
+
+	trusted, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.0.2.10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy := newProxy("asia", target, trusted)
+
+	// The load balancer's X-Forwarded-For is passed on, with its own address appended
+	req := httptest.NewRequest(http.MethodGet, "http://gw.example/asia/venues/1", nil)
+	req.RemoteAddr = "10.1.2.3:40000"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	rec := httptest.NewRecorder()
+	proxy.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || gotHost != "gw.example" || gotPath != "/asia/venues/1" || gotXFF != "203.0.113.7, 10.1.2.3" {
+		t.Errorf("status %d, backend saw host %q path %q X-Forwarded-For %q", rec.Code, gotHost, gotPath, gotXFF)
+	}
+
+	// Behind a load balancer that appends to the client's header, only the address it saw is
+	// passed on; trusted hops are skipped and anything left of the client is dropped
+	for xff, want := range map[string]string{
+		"192.0.2.1, 198.51.100.4":           "198.51.100.4, 10.1.2.3",
+		"192.0.2.1, 198.51.100.4, 10.9.9.9": "198.51.100.4, 10.1.2.3",
+		"10.9.9.9":                          "10.9.9.9, 10.1.2.3",
+		"bogus, 10.9.9.9":                   "10.1.2.3",
+	} {
+		req = httptest.NewRequest(http.MethodGet, "http://gw.example/asia/venues/1", nil)
+		req.RemoteAddr = "10.1.2.3:40000"
+		req.Header.Set("X-Forwarded-For", xff)
+		proxy.ServeHTTP(httptest.NewRecorder(), req)
+		if gotXFF != want {
+			t.Errorf("X-Forwarded-For %q from the load balancer: backend saw %q, want %q", xff, gotXFF, want)
+		}
+	}
+
+	// A client's own X-Forwarded-For is replaced by its address
+	for remote, want := range map[string]string{"198.51.100.4:5555": "198.51.100.4", "[::ffff:192.0.2.11]:5555": "::ffff:192.0.2.11"} {
+		req = httptest.NewRequest(http.MethodGet, "http://gw.example/asia/venues/1", nil)
+		req.RemoteAddr = remote
+		req.Header.Set("X-Forwarded-For", "192.0.2.1")
+		proxy.ServeHTTP(httptest.NewRecorder(), req)
+		if gotXFF != want {
+			t.Errorf("from %s: backend saw X-Forwarded-For %q, want %q", remote, gotXFF, want)
+		}
+	}
+
+	if _, err := ParseTrustedProxies([]string{"lb.internal"}); err == nil {
+		t.Error("host name accepted as a trusted proxy")
+	}
+
+	// A tenant whose process is down answers 502; a request for no tenant 404
+	tenants, _ := Parse([]byte(sample))
+	gw := NewGateway(tenants, GatewayConfig{PortBase: 1})
+	for path, want := range map[string]int{"/asia/x": http.StatusBadGateway, "/other": http.StatusNotFound} {
+		rec := httptest.NewRecorder()
+		gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://gw.example"+path, nil))
+		if rec.Code != want {
+			t.Errorf("%s: status %d, want %d", path, rec.Code, want)
+		}
+	}
+}
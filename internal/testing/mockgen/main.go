@@ -0,0 +1,337 @@
+// Command mockgen writes func-field mocks for interfaces of one package.
+//
+// Each mock is a struct with a <Method>Func field per method; calling a method whose field is
+// nil panics, so a test only stubs what the code under test should use. Embedded interfaces of
+// the same package are flattened. Standard library only, so it runs with `go generate` offline:
+//
+//	go run ./internal/testing/mockgen -src internal/domain -out internal/testing/repository_mocks.go VenueReader ...
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	src := flag.String("src", ".", "directory of the package declaring the interfaces")
+	out := flag.String("out", "", "output file (stdout if empty)")
+	pkg := flag.String("pkg", "testutil", "package name of the generated file")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		log.Fatal("mockgen: no interfaces given")
+	}
+
+	code, err := generate(*src, *pkg, flag.Args())
+	if err != nil {
+		log.Fatalf("mockgen: %v", err)
+	}
+	if *out == "" {
+		os.Stdout.Write(code)
+		return
+	}
+	if err := os.WriteFile(*out, code, 0o644); err != nil {
+		log.Fatalf("mockgen: %v", err)
+	}
+}
+
+// source is the parsed package the interfaces come from.
+type source struct {
+	fset       *token.FileSet
+	name       string                        // package name, used to qualify its own types
+	importPath string                        // import path of that package
+	ifaces     map[string]*ast.InterfaceType // interface declarations by name
+	types      map[string]bool               // every exported type declared in the package
+	fileOf     map[string]*ast.File          // declaring file per interface, for its imports
+	used       map[string]string             // imports the generated code needs: path -> name
+}
+
+type method struct {
+	name    string
+	params  []string // "name type"
+	args    []string // forwarded arguments ("xs..." for variadics)
+	results []string
+}
+
+func generate(dir, pkg string, names []string) ([]byte, error) {
+	s, err := load(dir)
+	if err != nil {
+		return nil, err
+	}
+	s.used[s.importPath] = s.name // for the interface assertions
+	var body bytes.Buffer
+	for _, name := range names {
+		methods, err := s.methods(name, map[string]bool{})
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(methods, func(i, j int) bool { return methods[i].name < methods[j].name })
+		writeMock(&body, pkg, name, s.name, methods)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by mockgen; DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg)
+	paths := make([]string, 0, len(s.used))
+	for p := range s.used {
+		paths = append(paths, p)
+	}
+	// Standard library first, then the rest, as goimports groups them
+	isStd := func(p string) bool {
+		first := strings.Split(p, "/")[0]
+		return !strings.Contains(first, ".") && first != strings.Split(s.importPath, "/")[0]
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if isStd(paths[i]) != isStd(paths[j]) {
+			return isStd(paths[i])
+		}
+		return paths[i] < paths[j]
+	})
+	for i, p := range paths {
+		if i > 0 && isStd(p) != isStd(paths[i-1]) {
+			buf.WriteString("\n")
+		}
+		if name := s.used[p]; name != path.Base(p) {
+			fmt.Fprintf(&buf, "\t%s %q\n", name, p)
+		} else {
+			fmt.Fprintf(&buf, "\t%q\n", p)
+		}
+	}
+	buf.WriteString(")\n")
+	buf.Write(body.Bytes())
+	return format.Source(buf.Bytes())
+}
+
+func load(dir string) (*source, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("%s: want exactly one package, found %d", dir, len(pkgs))
+	}
+	s := &source{
+		fset:   fset,
+		ifaces: map[string]*ast.InterfaceType{},
+		types:  map[string]bool{},
+		fileOf: map[string]*ast.File{},
+		used:   map[string]string{},
+	}
+	for name, p := range pkgs {
+		s.name = name
+		for _, f := range p.Files {
+			for _, decl := range f.Decls {
+				gd, ok := decl.(*ast.GenDecl)
+				if !ok || gd.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gd.Specs {
+					ts := spec.(*ast.TypeSpec)
+					s.types[ts.Name.Name] = ts.Name.IsExported()
+					if it, ok := ts.Type.(*ast.InterfaceType); ok {
+						s.ifaces[ts.Name.Name] = it
+						s.fileOf[ts.Name.Name] = f
+					}
+				}
+			}
+		}
+	}
+	if s.importPath, err = importPath(dir); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// importPath derives the package import path from the enclosing go.mod.
+func importPath(dir string) (string, error) {
+	abs, err := absDir(dir)
+	if err != nil {
+		return "", err
+	}
+	for d := abs; ; d = path.Dir(d) {
+		data, err := os.ReadFile(path.Join(d, "go.mod"))
+		if err == nil {
+			for _, line := range strings.Split(string(data), "\n") {
+				if mod, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+					return path.Join(strings.TrimSpace(mod), strings.TrimPrefix(abs, d)), nil
+				}
+			}
+			return "", fmt.Errorf("%s/go.mod has no module line", d)
+		}
+		if d == "/" || d == "." {
+			return "", fmt.Errorf("no go.mod above %s", dir)
+		}
+	}
+}
+
+func absDir(dir string) (string, error) {
+	if path.IsAbs(dir) {
+		return path.Clean(dir), nil
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	return path.Join(wd, dir), nil
+}
+
+// methods returns the full method set of the named interface, flattening embedded ones.
+func (s *source) methods(name string, seen map[string]bool) ([]method, error) {
+	it, ok := s.ifaces[name]
+	if !ok {
+		return nil, fmt.Errorf("interface %s not found in package %s", name, s.name)
+	}
+	if seen[name] {
+		return nil, nil
+	}
+	seen[name] = true
+
+	var out []method
+	have := map[string]bool{}
+	add := func(m method) {
+		if !have[m.name] {
+			have[m.name] = true
+			out = append(out, m)
+		}
+	}
+	for _, field := range it.Methods.List {
+		switch t := field.Type.(type) {
+		case *ast.Ident: // embedded interface of this package
+			embedded, err := s.methods(t.Name, seen)
+			if err != nil {
+				return nil, err
+			}
+			for _, m := range embedded {
+				add(m)
+			}
+		case *ast.FuncType:
+			add(s.method(field.Names[0].Name, t, s.fileOf[name]))
+		default:
+			return nil, fmt.Errorf("%s: unsupported embedded type %s", name, s.expr(t, s.fileOf[name]))
+		}
+	}
+	return out, nil
+}
+
+func (s *source) method(name string, ft *ast.FuncType, f *ast.File) method {
+	m := method{name: name}
+	i := 0
+	for _, p := range ft.Params.List {
+		typ := s.expr(p.Type, f)
+		names := p.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{nil}
+		}
+		for _, n := range names {
+			arg := "p" + strconv.Itoa(i)
+			if n != nil && n.Name != "_" && n.Name != "m" {
+				arg = n.Name
+			}
+			i++
+			m.params = append(m.params, arg+" "+typ)
+			if _, variadic := p.Type.(*ast.Ellipsis); variadic {
+				arg += "..."
+			}
+			m.args = append(m.args, arg)
+		}
+	}
+	if ft.Results != nil {
+		for _, r := range ft.Results.List {
+			typ := s.expr(r.Type, f)
+			for range max(len(r.Names), 1) {
+				m.results = append(m.results, typ)
+			}
+		}
+	}
+	return m
+}
+
+// expr renders a type expression as seen from the generated package: the source package's
+// own types get qualified and every import used is recorded.
+func (s *source) expr(e ast.Expr, f *ast.File) string {
+	switch t := e.(type) {
+	case *ast.Ident:
+		if s.types[t.Name] {
+			s.used[s.importPath] = s.name
+			return s.name + "." + t.Name
+		}
+		return t.Name
+	case *ast.SelectorExpr:
+		pkg := t.X.(*ast.Ident).Name
+		for _, imp := range f.Imports {
+			p, _ := strconv.Unquote(imp.Path.Value)
+			if (imp.Name != nil && imp.Name.Name == pkg) || (imp.Name == nil && path.Base(p) == pkg) {
+				s.used[p] = pkg
+			}
+		}
+		return pkg + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + s.expr(t.X, f)
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "[]" + s.expr(t.Elt, f)
+		}
+		return "[" + s.expr(t.Len, f) + "]" + s.expr(t.Elt, f)
+	case *ast.BasicLit:
+		return t.Value
+	case *ast.MapType:
+		return "map[" + s.expr(t.Key, f) + "]" + s.expr(t.Value, f)
+	case *ast.Ellipsis:
+		return "..." + s.expr(t.Elt, f)
+	case *ast.InterfaceType:
+		if len(t.Methods.List) == 0 {
+			return "interface{}"
+		}
+	case *ast.FuncType:
+		m := s.method("", t, f)
+		res := strings.Join(m.results, ", ")
+		if len(m.results) > 1 {
+			res = "(" + res + ")"
+		}
+		return strings.TrimSpace("func(" + strings.Join(m.params, ", ") + ") " + res)
+	}
+	var buf bytes.Buffer
+	_ = format.Node(&buf, s.fset, e)
+	return buf.String()
+}
+
+func writeMock(w *bytes.Buffer, pkg, name, srcPkg string, methods []method) {
+	fmt.Fprintf(w, "\n// %s is a mock of %s.%s; set the Func field of each method the test expects.\n", name, srcPkg, name)
+	fmt.Fprintf(w, "type %s struct {\n", name)
+	for _, m := range methods {
+		fmt.Fprintf(w, "\t%sFunc func(%s)%s\n", m.name, strings.Join(m.params, ", "), results(m.results))
+	}
+	w.WriteString("}\n")
+	fmt.Fprintf(w, "\nvar _ %s.%s = (*%s)(nil)\n", srcPkg, name, name)
+	for _, m := range methods {
+		fmt.Fprintf(w, "\nfunc (m *%s) %s(%s)%s {\n", name, m.name, strings.Join(m.params, ", "), results(m.results))
+		fmt.Fprintf(w, "\tif m.%sFunc == nil {\n\t\tpanic(\"%s.%s: unexpected call to %s\")\n\t}\n", m.name, pkg, name, m.name)
+		ret := ""
+		if len(m.results) > 0 {
+			ret = "return "
+		}
+		fmt.Fprintf(w, "\t%sm.%sFunc(%s)\n}\n", ret, m.name, strings.Join(m.args, ", "))
+	}
+}
+
+func results(rs []string) string {
+	switch len(rs) {
+	case 0:
+		return ""
+	case 1:
+		return " " + rs[0]
+	}
+	return " (" + strings.Join(rs, ", ") + ")"
+}
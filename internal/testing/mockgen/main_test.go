@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+// The committed mocks must match what go generate would write from the current interfaces.
+func TestRepositoryMocksUpToDate(t *testing.T) {
+	src, err := os.ReadFile("../../domain/repository.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, line := range strings.Split(string(src), "\n") {
+		if args, ok := strings.CutPrefix(line, "//go:generate go run ../testing/mockgen "); ok {
+			names = strings.Fields(args)[6:] // after -src . -out FILE -pkg NAME
+		}
+	}
+	if len(names) == 0 {
+		t.Fatal("go:generate directive for mockgen not found in domain/repository.go")
+	}
+
+	got, err := generate("../../domain", "testutil", names)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	want, err := os.ReadFile("../repository_mocks.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatal("internal/testing/repository_mocks.go is stale; run go generate ./internal/domain")
+	}
+}
@@ -0,0 +1,477 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// Scenario scripts how the fake providers of a pipeline test treat one venue.
+type Scenario string
+
+const (
+	// ScenarioApprove: Google matches the venue and the AI scores it 90, approved.
+	ScenarioApprove Scenario = "approve"
+	// ScenarioReject: Google matches the venue and the AI scores it 10. The venue is rejected
+	// when its submitter is below the trust gate (LowTrustVenue) and goes to manual review
+	// otherwise.
+	ScenarioReject Scenario = "reject"
+	// ScenarioNoMatch: Google finds no place, so the venue goes to manual review unscored.
+	ScenarioNoMatch Scenario = "no_match"
+	// ScenarioAPIError: Google Places answers every call with a 503.
+	ScenarioAPIError Scenario = "api_error"
+	// ScenarioMalformedOutput: the AI reply fails the schema, so scoring and the quality
+	// review fall back the way the real providers do.
+	ScenarioMalformedOutput Scenario = "malformed_output"
+	// ScenarioBudgetExceeded: Google matches, then OpenAI reports the quota exhausted.
+	ScenarioBudgetExceeded Scenario = "budget_exceeded"
+)
+
+// MalformedAIOutput is the raw reply the fake scorer stores for ScenarioMalformedOutput.
+const MalformedAIOutput = `{"score": "ninety", "status":`
+
+// Script maps venues to their scenario and counts the calls each fake provider got.
+// Venues without a scenario get ScenarioApprove.
+type Script struct {
+	mu        sync.Mutex
+	scenarios map[int64]Scenario
+	calls     map[string]map[int64]int
+}
+
+// NewScript returns an empty script.
+func NewScript() *Script {
+	return &Script{scenarios: map[int64]Scenario{}, calls: map[string]map[int64]int{}}
+}
+
+// Set scripts venueID to play s.
+func (s *Script) Set(venueID int64, sc Scenario) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scenarios[venueID] = sc
+}
+
+// Calls is how often provider ("google", "scorer" or "quality") was called for venueID.
+func (s *Script) Calls(provider string, venueID int64) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[provider][venueID]
+}
+
+// call records a call of provider for venueID and returns the venue's scenario.
+func (s *Script) call(provider string, venueID int64) Scenario {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.calls[provider] == nil {
+		s.calls[provider] = map[int64]int{}
+	}
+	s.calls[provider][venueID]++
+	if sc, ok := s.scenarios[venueID]; ok {
+		return sc
+	}
+	return ScenarioApprove
+}
+
+// latency sleeps for about d (±50%), like a provider round trip, or until ctx is done.
+func latency(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d/2 + rand.N(d))
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// FakeScraper implements processor.GoogleScraper from a Script. Latency, when set, is the
+// typical duration of a call.
+type FakeScraper struct {
+	Script  *Script
+	Latency time.Duration
+}
+
+func (f *FakeScraper) EnhanceVenueWithValidation(ctx context.Context, v models.Venue) (*models.Venue, error) {
+	sc := f.Script.call("google", v.ID)
+	if err := latency(ctx, f.Latency); err != nil {
+		return nil, err
+	}
+	out := v
+	switch sc {
+	case ScenarioAPIError:
+		return nil, errs.NewExternalStatus("scraper.EnhanceVenueWithValidation", "google", 503, 0, errors.New("backend error"))
+	case ScenarioNoMatch:
+		out.ValidationDetails = &models.ValidationDetails{GooglePlaceFound: false}
+		return &out, nil
+	}
+	lat, lng := 52.52, 13.405
+	if v.Lat != nil && v.Lng != nil {
+		lat, lng = *v.Lat, *v.Lng
+	}
+	out.Lat, out.Lng = &lat, &lng
+	out.GoogleData = &models.GooglePlaceData{
+		PlaceID:          fmt.Sprintf("place-%d", v.ID),
+		Name:             v.Name,
+		FormattedAddress: v.Location,
+		BusinessStatus:   "OPERATIONAL",
+		Geometry:         models.GoogleGeometry{Location: models.GoogleLatLng{Lat: lat, Lng: lng}},
+		Types:            []string{"restaurant"},
+		FetchedAt:        time.Now(),
+		MatchConfidence:  models.MatchConfidenceHigh,
+	}
+	out.ValidationDetails = &models.ValidationDetails{GooglePlaceFound: true}
+	return &out, nil
+}
+
+// FakeScorer implements processor.VenueScorer from a Script. Latency, when set, is the
+// typical duration of a call.
+type FakeScorer struct {
+	Script  *Script
+	Latency time.Duration
+}
+
+func (f *FakeScorer) ScoreVenue(ctx context.Context, v models.Venue, u models.User) (*models.ValidationResult, error) {
+	result := func(score int, status, notes string) *models.ValidationResult {
+		third := score / 3
+		return &models.ValidationResult{
+			VenueID:        v.ID,
+			Score:          score,
+			Status:         status,
+			Notes:          notes,
+			ScoreBreakdown: map[string]int{"legitimacy": third, "completeness": third, "relevance": score - 2*third},
+		}
+	}
+	sc := f.Script.call("scorer", v.ID)
+	if err := latency(ctx, f.Latency); err != nil {
+		return nil, err
+	}
+	switch sc {
+	case ScenarioReject:
+		return result(10, "rejected", "Not a vegan venue"), nil
+	case ScenarioMalformedOutput:
+		// What the scorer returns once the repair request also fails the schema
+		raw := MalformedAIOutput
+		vr := result(50, "manual_review", "AI output invalid - manual review")
+		vr.AIOutputData = &raw
+		return vr, nil
+	case ScenarioBudgetExceeded:
+		return nil, errs.NewBudgetExceeded("scorer.ScoreVenue", "openai quota exhausted",
+			errs.NewExternalClass("scorer.ScoreVenue", "openai", errs.ClassClient, errors.New("insufficient_quota")))
+	}
+	return result(90, "approved", "Fully vegan restaurant confirmed by Google"), nil
+}
+
+func (f *FakeScorer) GetCostStats() (int, int, float64, time.Duration) { return 0, 0, 0, 0 }
+func (f *FakeScorer) GetBufferPoolStats() (int64, int64, int64)        { return -1, -1, -1 }
+
+// FakeQualityReviewer implements processor.QualityReviewer from a Script. Latency, when set,
+// is the typical duration of a call.
+type FakeQualityReviewer struct {
+	Script  *Script
+	Latency time.Duration
+}
+
+func (f *FakeQualityReviewer) ReviewQuality(ctx context.Context, v models.Venue, u models.User, category string, trustLevel float64) (*models.QualitySuggestions, error) {
+	sc := f.Script.call("quality", v.ID)
+	if err := latency(ctx, f.Latency); err != nil {
+		return nil, err
+	}
+	switch sc {
+	case ScenarioMalformedOutput:
+		return nil, errors.New("quality review output invalid after repair")
+	case ScenarioBudgetExceeded:
+		return nil, errs.NewBudgetExceeded("scorer.ReviewQuality", "openai quota exhausted", nil)
+	}
+	return &models.QualitySuggestions{Description: "Vegan restaurant serving bowls and burgers."}, nil
+}
+
+// PipelineVenue is a venue submitted by a trusted member with enough contributions, so it
+// passes the engine's early exits and reaches the Google and AI calls.
+func PipelineVenue(id int64, name string) models.VenueWithUser {
+	url := "https://example.com/" + strings.ToLower(strings.ReplaceAll(name, " ", "-"))
+	phone := "+49 30 1234567"
+	info := "Fully vegan menu"
+	return models.VenueWithUser{
+		Venue: models.Venue{
+			ID:             id,
+			Name:           name,
+			Location:       "Torstraße 1, Berlin",
+			URL:            &url,
+			Phone:          &phone,
+			AdditionalInfo: &info,
+			UserID:         7,
+		},
+		User: models.User{ID: 7, Username: "member", Trusted: true, Contributions: 500},
+	}
+}
+
+// LowTrustVenue is PipelineVenue submitted by a regular member whose contributions get past
+// the early exits, but whose trust stays below the decision engine's trust gate.
+func LowTrustVenue(id int64, name string) models.VenueWithUser {
+	vu := PipelineVenue(id, name)
+	vu.User.Trusted = false
+	vu.User.Contributions = 1500
+	return vu
+}
+
+// MemoryStore is an in-memory venue and validation history store. Repository and
+// UnitOfWorkFactory give the processing pipeline access to it; methods it does not back still
+// panic like the other mocks. Unit of work writes become visible on Commit.
+type MemoryStore struct {
+	mu      sync.Mutex
+	venues  map[int64]models.VenueWithUser
+	history []models.ValidationHistory
+	sandbox []models.ValidationHistory
+	nextID  int64
+}
+
+// NewMemoryStore returns a store holding venues.
+func NewMemoryStore(venues ...models.VenueWithUser) *MemoryStore {
+	s := &MemoryStore{venues: map[int64]models.VenueWithUser{}}
+	for _, v := range venues {
+		s.Put(v)
+	}
+	return s
+}
+
+// Put adds or replaces a venue.
+func (s *MemoryStore) Put(v models.VenueWithUser) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.venues[v.Venue.ID] = v
+}
+
+// Active is the venue's active status, or nil when the venue is unknown or its status was
+// never set.
+func (s *MemoryStore) Active(venueID int64) *int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.venues[venueID]
+	if !ok || v.Venue.Active == nil {
+		return nil
+	}
+	a := *v.Venue.Active
+	return &a
+}
+
+// History is the venue's validation history, newest first.
+func (s *MemoryStore) History(venueID int64) []models.ValidationHistory {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.historyOf(s.history, venueID)
+}
+
+// Sandbox is the venue's dry run results, newest first.
+func (s *MemoryStore) Sandbox(venueID int64) []models.ValidationHistory {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.historyOf(s.sandbox, venueID)
+}
+
+func (s *MemoryStore) historyOf(rows []models.ValidationHistory, venueID int64) []models.ValidationHistory {
+	var out []models.ValidationHistory
+	for _, h := range rows {
+		if h.VenueID == venueID {
+			out = append(out, h)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].ID > out[j].ID })
+	return out
+}
+
+// newHistory converts a result to a history row; callers hold s.mu.
+func (s *MemoryStore) newHistory(r *models.ValidationResult, gd *models.GooglePlaceData) models.ValidationHistory {
+	s.nextID++
+	h := models.ValidationHistory{
+		ID:               s.nextID,
+		VenueID:          r.VenueID,
+		ValidationScore:  r.Score,
+		ValidationStatus: r.Status,
+		ValidationNotes:  r.Notes,
+		ScoreBreakdown:   r.ScoreBreakdown,
+		AIOutputData:     r.AIOutputData,
+		PromptVersion:    r.PromptVersion,
+		RunID:            r.RunID,
+		GooglePlaceData:  gd,
+		ProcessedAt:      time.Now(),
+	}
+	if gd != nil {
+		id := gd.PlaceID
+		h.GooglePlaceID, h.GooglePlaceFound = &id, true
+	}
+	if v, ok := s.venues[r.VenueID]; ok {
+		h.VenueName = v.Venue.Name
+	}
+	return h
+}
+
+// setActive updates a venue's status, if it exists, like an UPDATE; callers hold s.mu.
+func (s *MemoryStore) setActive(venueID int64, active int) {
+	if v, ok := s.venues[venueID]; ok {
+		v.Venue.Active = &active
+		s.venues[venueID] = v
+	}
+}
+
+// Repository returns a mock repository backed by the store.
+func (s *MemoryStore) Repository() *Repository {
+	return &Repository{
+		GetVenueWithUserByIDCtxFunc: func(_ context.Context, venueID int64) (*models.VenueWithUser, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			v, ok := s.venues[venueID]
+			if !ok {
+				return nil, fmt.Errorf("venue %d not found", venueID)
+			}
+			return &v, nil
+		},
+		CountRecentVenuesByUserAndNameCtxFunc: func(_ context.Context, userID uint, name string, _ time.Time, beforeVenueID int64) (int, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			n := 0
+			for id, v := range s.venues {
+				if id < beforeVenueID && v.Venue.UserID == userID && strings.EqualFold(strings.TrimSpace(v.Venue.Name), strings.TrimSpace(name)) {
+					n++
+				}
+			}
+			return n, nil
+		},
+		HasAnyValidationHistoryFunc: func(venueID int64) (bool, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			for _, h := range s.history {
+				if h.VenueID == venueID && h.Reviewed() {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+		CountVenuesByPathCtxFunc: func(_ context.Context, path string, excludeVenueID int64) (int, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			n := 0
+			for id, v := range s.venues {
+				if id != excludeVenueID && v.Venue.Path != nil && *v.Venue.Path == path {
+					n++
+				}
+			}
+			return n, nil
+		},
+		// Matches on name only; the engine measures the distance itself
+		FindDuplicateVenuesByNameAndLocationFunc: func(_ context.Context, name string, _, _ float64, _ int, excludeVenueID int64) ([]models.Venue, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			var out []models.Venue
+			for id, v := range s.venues {
+				if id != excludeVenueID && strings.EqualFold(v.Venue.Name, name) {
+					out = append(out, v.Venue)
+				}
+			}
+			return out, nil
+		},
+		GetVenueValidationHistoryCtxFunc: func(_ context.Context, venueID int64) ([]models.ValidationHistory, error) {
+			return s.History(venueID), nil
+		},
+		GetCachedGooglePlaceDataCtxFunc: func(_ context.Context, venueID int64) (*models.GooglePlaceData, error) {
+			for _, h := range s.History(venueID) {
+				if h.GooglePlaceData != nil {
+					return h.GooglePlaceData, nil
+				}
+			}
+			return nil, nil
+		},
+		SaveValidationResultWithGoogleDataCtxFunc: func(_ context.Context, r *models.ValidationResult, gd *models.GooglePlaceData) error {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.history = append(s.history, s.newHistory(r, gd))
+			return nil
+		},
+		SaveSandboxResultCtxFunc: func(_ context.Context, r *models.ValidationResult, gd *models.GooglePlaceData) error {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.sandbox = append(s.sandbox, s.newHistory(r, gd))
+			return nil
+		},
+		ValidateApprovalEligibilityFunc: func(venueID int64, threshold int) error {
+			h := s.History(venueID)
+			if len(h) == 0 {
+				return fmt.Errorf("no validation history found for venue %d - approval requires validated record", venueID)
+			}
+			if h[0].ValidationStatus != "approved" || h[0].ValidationScore < threshold {
+				return fmt.Errorf("latest validation of venue %d is %s with score %d", venueID, h[0].ValidationStatus, h[0].ValidationScore)
+			}
+			return nil
+		},
+	}
+}
+
+// UnitOfWorkFactory returns a mock factory whose units of work buffer their writes and apply
+// them to the store on Commit.
+func (s *MemoryStore) UnitOfWorkFactory() *UnitOfWorkFactory {
+	return &UnitOfWorkFactory{BeginFunc: func(context.Context) (domain.UnitOfWork, error) {
+		return s.newUnitOfWork(), nil
+	}}
+}
+
+func (s *MemoryStore) newUnitOfWork() *UnitOfWork {
+	var ops []func()
+	done := false
+	saveResult := func(r *models.ValidationResult, gd *models.GooglePlaceData) {
+		ops = append(ops, func() { s.history = append(s.history, s.newHistory(r, gd)) })
+	}
+	setActive := func(venueID int64, active int) {
+		ops = append(ops, func() { s.setActive(venueID, active) })
+	}
+	return &UnitOfWork{
+		BeginFunc: func(context.Context) error { return nil },
+		SaveValidationResultCtxFunc: func(_ context.Context, r *models.ValidationResult) error {
+			saveResult(r, nil)
+			return nil
+		},
+		SaveValidationResultWithGoogleDataCtxFunc: func(_ context.Context, r *models.ValidationResult, gd *models.GooglePlaceData) error {
+			saveResult(r, gd)
+			return nil
+		},
+		SaveValidationResultsCtxFunc: func(_ context.Context, records []domain.HistoryRecord) error {
+			for _, rec := range records {
+				saveResult(rec.Result, rec.GoogleData)
+			}
+			return nil
+		},
+		UpdateVenueActiveCtxFunc: func(_ context.Context, venueID int64, active int) error {
+			setActive(venueID, active)
+			return nil
+		},
+		UpdateVenueStatusCtxFunc: func(_ context.Context, venueID int64, active int, _ string, _ *string) error {
+			setActive(venueID, active)
+			return nil
+		},
+		CommitFunc: func() error {
+			if done {
+				return errors.New("unit of work already finished")
+			}
+			done = true
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			for _, op := range ops {
+				op()
+			}
+			return nil
+		},
+		RollbackFunc: func() error {
+			done = true
+			return nil
+		},
+	}
+}
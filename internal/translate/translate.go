@@ -0,0 +1,117 @@
+// Package translate calls machine translation APIs (DeepL, Google Cloud Translation)
+// to turn venue descriptions into English before scoring.
+package translate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	deeplEndpoint     = "https://api.deepl.com/v2/translate"
+	deeplFreeEndpoint = "https://api-free.deepl.com/v2/translate"
+	googleEndpoint    = "https://translation.googleapis.com/language/translate/v2"
+	maxResponseBytes  = 1 << 20
+)
+
+// DeepL translates with the DeepL API. Free-plan keys (suffix ":fx") use the free endpoint.
+type DeepL struct {
+	apiKey   string
+	endpoint string
+	client   *http.Client
+}
+
+func NewDeepL(apiKey string, timeout time.Duration) *DeepL {
+	ep := deeplEndpoint
+	if strings.HasSuffix(apiKey, ":fx") {
+		ep = deeplFreeEndpoint
+	}
+	return &DeepL{apiKey: apiKey, endpoint: ep, client: &http.Client{Timeout: timeout}}
+}
+
+// Translate returns the English text and the detected source language (lower-case ISO 639-1).
+func (d *DeepL) Translate(ctx context.Context, text string) (string, string, error) {
+	body := map[string]interface{}{"text": []string{text}, "target_lang": "EN-US"}
+	var out struct {
+		Translations []struct {
+			DetectedSourceLanguage string `json:"detected_source_language"`
+			Text                   string `json:"text"`
+		} `json:"translations"`
+	}
+	hdr := http.Header{"Authorization": {"DeepL-Auth-Key " + d.apiKey}}
+	if err := postJSON(ctx, d.client, d.endpoint, hdr, body, &out); err != nil {
+		return "", "", fmt.Errorf("deepl: %w", err)
+	}
+	if len(out.Translations) == 0 {
+		return "", "", fmt.Errorf("deepl: empty response")
+	}
+	t := out.Translations[0]
+	return t.Text, strings.ToLower(t.DetectedSourceLanguage), nil
+}
+
+// Google translates with the Cloud Translation v2 API using an API key.
+type Google struct {
+	apiKey   string
+	endpoint string
+	client   *http.Client
+}
+
+func NewGoogle(apiKey string, timeout time.Duration) *Google {
+	return &Google{apiKey: apiKey, endpoint: googleEndpoint, client: &http.Client{Timeout: timeout}}
+}
+
+// Translate returns the English text and the detected source language (lower-case ISO 639-1).
+func (g *Google) Translate(ctx context.Context, text string) (string, string, error) {
+	body := map[string]string{"q": text, "target": "en", "format": "text"}
+	var out struct {
+		Data struct {
+			Translations []struct {
+				TranslatedText         string `json:"translatedText"`
+				DetectedSourceLanguage string `json:"detectedSourceLanguage"`
+			} `json:"translations"`
+		} `json:"data"`
+	}
+	hdr := http.Header{"X-Goog-Api-Key": {g.apiKey}}
+	if err := postJSON(ctx, g.client, g.endpoint, hdr, body, &out); err != nil {
+		return "", "", fmt.Errorf("google translate: %w", err)
+	}
+	if len(out.Data.Translations) == 0 {
+		return "", "", fmt.Errorf("google translate: empty response")
+	}
+	t := out.Data.Translations[0]
+	return t.TranslatedText, strings.ToLower(t.DetectedSourceLanguage), nil
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, hdr http.Header, in, out interface{}) error {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	for k, v := range hdr {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return json.Unmarshal(data, out)
+}
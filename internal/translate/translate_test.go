@@ -0,0 +1,62 @@
+package translate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDeepL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "DeepL-Auth-Key k:fx" {
+			t.Errorf("Authorization=%q", got)
+		}
+		var body struct {
+			Text       []string `json:"text"`
+			TargetLang string   `json:"target_lang"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if len(body.Text) != 1 || body.TargetLang != "EN-US" {
+			t.Errorf("body=%+v", body)
+		}
+		_, _ = w.Write([]byte(`{"translations":[{"detected_source_language":"JA","text":"Vegan ramen"}]}`))
+	}))
+	defer srv.Close()
+
+	d := NewDeepL("k:fx", time.Second)
+	if d.endpoint != deeplFreeEndpoint {
+		t.Fatalf("free key should use the free endpoint, got %s", d.endpoint)
+	}
+	d.endpoint = srv.URL
+	text, lang, err := d.Translate(context.Background(), "ヴィーガンラーメン")
+	if err != nil || text != "Vegan ramen" || lang != "ja" {
+		t.Fatalf("got %q %q %v", text, lang, err)
+	}
+}
+
+func TestGoogle(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Goog-Api-Key") != "k" {
+			http.Error(w, `{"error":"forbidden"}`, http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"translations":[{"translatedText":"Vegan options","detectedSourceLanguage":"pt"}]}}`))
+	}))
+	defer srv.Close()
+
+	g := NewGoogle("k", time.Second)
+	g.endpoint = srv.URL
+	text, lang, err := g.Translate(context.Background(), "Opções veganas")
+	if err != nil || text != "Vegan options" || lang != "pt" {
+		t.Fatalf("got %q %q %v", text, lang, err)
+	}
+
+	bad := NewGoogle("wrong", time.Second)
+	bad.endpoint = srv.URL
+	if _, _, err := bad.Translate(context.Background(), "x"); err == nil {
+		t.Fatalf("expected error on 403")
+	}
+}
@@ -3,6 +3,7 @@ package trust
 import (
 	"fmt"
 	"strings"
+	"sync/atomic"
 
 	"assisted-venue-approval/internal/constants"
 	"assisted-venue-approval/internal/models"
@@ -12,46 +13,105 @@ import (
 // Trust is 0.0-1.0. Authority is one of: "venue_admin", "high_ambassador", "ambassador", "trusted", "regular".
 // Bonus holds decision bonus points aligned with decision engine expectations.
 // Reason gives a concise human-friendly explanation for logs/debug.
-// Note: Keep this small and stable; expand only with clear need.
-// TODO: Consider exposing JSON tags if we decide to persist assessments.
+// Inputs, Adjustments and RulesVersion record how the result came about; the processor stores
+// them with each decision so it can be explained after the rules have changed.
 type Assessment struct {
-	Trust     float64
-	Authority string
-	Bonus     int
-	Reason    string
+	Trust        float64      `json:"trust"`
+	Authority    string       `json:"authority"`
+	Bonus        int          `json:"bonus"`
+	Reason       string       `json:"reason"`
+	RulesVersion string       `json:"rules_version"`
+	Inputs       Inputs       `json:"inputs"`
+	Adjustments  []Adjustment `json:"adjustments,omitempty"`
+	// PoorHistory is set when the submitter's past submissions trip a reputation rule;
+	// the processor then sends the venue to manual review before any API calls.
+	PoorHistory bool `json:"poor_history,omitempty"`
 }
 
-// Config allows tuning the calculator without code changes.
-// Defaults mirror existing rules used across the codebase.
+// Inputs are the user and venue facts an assessment was based on.
+type Inputs struct {
+	UserID             uint   `json:"user_id"`
+	VenueAdmin         bool   `json:"venue_admin"`
+	Trusted            bool   `json:"trusted"`
+	Contributions      int    `json:"contributions"`
+	ApprovedVenues     int    `json:"approved_venues"`
+	AmbassadorLevel    *int   `json:"ambassador_level,omitempty"`
+	AmbassadorPoints   *int   `json:"ambassador_points,omitempty"`
+	AmbassadorRegion   string `json:"ambassador_region,omitempty"`
+	VenueLocation      string `json:"venue_location,omitempty"`
+	RegionMatch        bool   `json:"region_match"`
+	HighRankAmbassador bool   `json:"high_rank_ambassador"`
+	// History is nil when the submitter's past outcomes were not loaded
+	History *models.SubmissionHistory `json:"history,omitempty"`
+}
+
+// Adjustment is one rule that moved trust away from the authority's base level.
+type Adjustment struct {
+	Rule  string  `json:"rule"`
+	Delta float64 `json:"delta"`
+}
+
+// Config holds the trust rules. Defaults mirror the rules used before they became
+// configurable; TRUST_RULES_FILE overrides any subset of them (see LoadConfig).
 type Config struct {
-	BaseRegularTrust float64
-	TrustedTrust     float64
-	AmbassadorTrust  float64
-	HighAmbTrust     float64
-
-	ContributionBoost1Threshold int
-	ContributionBoost2Threshold int
-	ContributionBoostStep       float64
-
-	ApprovedVenueBoost1Threshold int
-	ApprovedVenueBoost2Threshold int
-	ApprovedVenueBoost3Threshold int
-	ApprovedVenueBoostStep       float64
-
-	BonusVenueAdmin int
-	BonusHighAmb    int
-	BonusAmb        int
-	BonusTrusted    int
-	BonusRegular    int
+	Version string `yaml:"version" json:"version"`
+
+	BaseRegularTrust float64 `yaml:"base_regular_trust" json:"base_regular_trust"`
+	TrustedTrust     float64 `yaml:"trusted_trust" json:"trusted_trust"`
+	AmbassadorTrust  float64 `yaml:"ambassador_trust" json:"ambassador_trust"`
+	HighAmbTrust     float64 `yaml:"high_ambassador_trust" json:"high_ambassador_trust"`
+	VenueAdminTrust  float64 `yaml:"venue_admin_trust" json:"venue_admin_trust"`
+
+	// An ambassador at this level or with this many points ranks high
+	HighAmbLevel  int `yaml:"high_ambassador_level" json:"high_ambassador_level"`
+	HighAmbPoints int `yaml:"high_ambassador_points" json:"high_ambassador_points"`
+	// Trust added for an ambassador submitting in their own region
+	RegionMatchBoost float64 `yaml:"region_match_boost" json:"region_match_boost"`
+
+	ContributionBoost1Threshold int     `yaml:"contribution_boost1_threshold" json:"contribution_boost1_threshold"`
+	ContributionBoost2Threshold int     `yaml:"contribution_boost2_threshold" json:"contribution_boost2_threshold"`
+	ContributionBoostStep       float64 `yaml:"contribution_boost_step" json:"contribution_boost_step"`
+
+	ApprovedVenueBoost1Threshold int     `yaml:"approved_venue_boost1_threshold" json:"approved_venue_boost1_threshold"`
+	ApprovedVenueBoost2Threshold int     `yaml:"approved_venue_boost2_threshold" json:"approved_venue_boost2_threshold"`
+	ApprovedVenueBoost3Threshold int     `yaml:"approved_venue_boost3_threshold" json:"approved_venue_boost3_threshold"`
+	ApprovedVenueBoostStep       float64 `yaml:"approved_venue_boost_step" json:"approved_venue_boost_step"`
+
+	// Trust subtracted for a submitter without any contributions, and for an ambassador
+	// submitting outside their region
+	PenaltyNoContributions float64 `yaml:"penalty_no_contributions" json:"penalty_no_contributions"`
+	PenaltyRegionMismatch  float64 `yaml:"penalty_region_mismatch" json:"penalty_region_mismatch"`
+
+	// Reputation: a submitter whose decided submissions (at least RejectionRatioMinDecided)
+	// were rejected at RejectionRatioThreshold or more, or whose last RejectionStreakThreshold
+	// decisions were all rejections, loses the penalty and has a poor history
+	RejectionRatioThreshold  float64 `yaml:"rejection_ratio_threshold" json:"rejection_ratio_threshold"`
+	RejectionRatioMinDecided int     `yaml:"rejection_ratio_min_decided" json:"rejection_ratio_min_decided"`
+	PenaltyRejectionRatio    float64 `yaml:"penalty_rejection_ratio" json:"penalty_rejection_ratio"`
+	RejectionStreakThreshold int     `yaml:"rejection_streak_threshold" json:"rejection_streak_threshold"`
+	PenaltyRejectionStreak   float64 `yaml:"penalty_rejection_streak" json:"penalty_rejection_streak"`
+
+	BonusVenueAdmin int `yaml:"bonus_venue_admin" json:"bonus_venue_admin"`
+	BonusHighAmb    int `yaml:"bonus_high_ambassador" json:"bonus_high_ambassador"`
+	BonusAmb        int `yaml:"bonus_ambassador" json:"bonus_ambassador"`
+	BonusTrusted    int `yaml:"bonus_trusted" json:"bonus_trusted"`
+	BonusRegular    int `yaml:"bonus_regular" json:"bonus_regular"`
 }
 
+// DefaultVersion names the built-in ruleset in assessments.
+const DefaultVersion = "builtin"
+
 // DefaultConfig returns thresholds that match existing logic.
 func DefaultConfig() Config {
 	return Config{
+		Version:                      DefaultVersion,
 		BaseRegularTrust:             constants.BaseRegularTrust,
 		TrustedTrust:                 constants.TrustedTrust,
 		AmbassadorTrust:              constants.AmbassadorTrust,
 		HighAmbTrust:                 constants.HighAmbTrust,
+		VenueAdminTrust:              1.0,
+		HighAmbLevel:                 constants.AmbHighLevel,
+		HighAmbPoints:                constants.AmbHighPoints,
 		ContributionBoost1Threshold:  constants.ContributionBoost1Threshold,
 		ContributionBoost2Threshold:  constants.ContributionBoost2Threshold,
 		ContributionBoostStep:        constants.ContributionBoostStep,
@@ -59,6 +119,11 @@ func DefaultConfig() Config {
 		ApprovedVenueBoost2Threshold: constants.ApprovedVenueBoost2Threshold,
 		ApprovedVenueBoost3Threshold: constants.ApprovedVenueBoost3Threshold,
 		ApprovedVenueBoostStep:       constants.ApprovedVenueBoostStep,
+		RejectionRatioThreshold:      0.5,
+		RejectionRatioMinDecided:     4,
+		PenaltyRejectionRatio:        0.2,
+		RejectionStreakThreshold:     3,
+		PenaltyRejectionStreak:       0.3,
 		BonusVenueAdmin:              constants.BonusVenueAdmin,
 		BonusHighAmb:                 constants.BonusHighAmb,
 		BonusAmb:                     constants.BonusAmb,
@@ -67,133 +132,162 @@ func DefaultConfig() Config {
 	}
 }
 
+var activeConfig atomic.Pointer[Config]
+
+// ActiveConfig returns the rules used by calculators from NewDefault.
+func ActiveConfig() Config {
+	if c := activeConfig.Load(); c != nil {
+		return *c
+	}
+	return DefaultConfig()
+}
+
+// SetConfig swaps the active rules. nil restores DefaultConfig.
+// Safe to call while venues are assessed; each assessment reads the rules once.
+func SetConfig(c *Config) {
+	if c == nil {
+		activeConfig.Store(nil)
+		return
+	}
+	cp := *c
+	activeConfig.Store(&cp)
+}
+
 // Calculator computes user trust/authority consistently.
 type Calculator struct {
-	cfg Config
+	cfg  Config
+	live bool // follow SetConfig instead of cfg
 }
 
+// NewCalculator returns a calculator with fixed rules.
 func NewCalculator(cfg Config) *Calculator { return &Calculator{cfg: cfg} }
-func NewDefault() *Calculator              { return NewCalculator(DefaultConfig()) }
+
+// NewDefault returns a calculator using the active rules, so a reloaded TRUST_RULES_FILE
+// applies to it without rebuilding the engines.
+func NewDefault() *Calculator { return &Calculator{live: true} }
+
+func (c *Calculator) config() Config {
+	if c.live {
+		return ActiveConfig()
+	}
+	return c.cfg
+}
 
 // Assess computes the trust assessment for a user.
 // venueLocation is optional but recommended for regional ambassador matching.
 func (c *Calculator) Assess(user models.User, venueLocation string) Assessment {
+	cfg := c.config()
+	in := Inputs{
+		UserID:        user.ID,
+		VenueAdmin:    user.IsVenueAdmin,
+		Trusted:       user.Trusted,
+		Contributions: user.Contributions,
+		VenueLocation: venueLocation,
+		History:       user.History,
+	}
+	if user.ApprovedVenueCount != nil {
+		in.ApprovedVenues = *user.ApprovedVenueCount
+	}
+	a := Assessment{RulesVersion: cfg.Version}
+
 	// Venue admin: highest trust and bonus
 	if user.IsVenueAdmin {
-		return Assessment{
-			Trust:     1.0,
-			Authority: "venue_admin",
-			Bonus:     c.cfg.BonusVenueAdmin,
-			Reason:    "venue admin submitted the venue",
-		}
+		a.Inputs = in
+		a.Trust = cfg.VenueAdminTrust
+		a.Authority = "venue_admin"
+		a.Bonus = cfg.BonusVenueAdmin
+		a.Reason = "venue admin submitted the venue"
+		return a
 	}
 
-	// Ambassador logic (level/points may be nil)
-	if user.AmbassadorLevel != nil && user.AmbassadorPoints != nil {
-		isHigh := (*user.AmbassadorLevel >= constants.AmbHighLevel) || (*user.AmbassadorPoints >= constants.AmbHighPoints)
-		regionMatch := c.matchesRegion(user.AmbassadorRegion, venueLocation)
-
-		if isHigh && regionMatch {
-			trust := c.cfg.HighAmbTrust
-			trust = c.applyContributionBoosts(trust, user.Contributions)
-			approvedCount := 0
-			if user.ApprovedVenueCount != nil {
-				approvedCount = *user.ApprovedVenueCount
-			}
-			trust = c.applyApprovedVenueBoosts(trust, approvedCount)
-			return Assessment{
-				Trust:     trust,
-				Authority: "high_ambassador",
-				Bonus:     c.cfg.BonusHighAmb,
-				Reason:    c.buildAmbReason(isHigh, regionMatch, trust, approvedCount),
-			}
-		}
-
-		trust := c.cfg.AmbassadorTrust
-		trust = c.applyContributionBoosts(trust, user.Contributions)
-		approvedCount := 0
-		if user.ApprovedVenueCount != nil {
-			approvedCount = *user.ApprovedVenueCount
+	var base float64
+	isAmbassador := user.AmbassadorLevel != nil && user.AmbassadorPoints != nil
+	switch {
+	case isAmbassador:
+		in.AmbassadorLevel, in.AmbassadorPoints = user.AmbassadorLevel, user.AmbassadorPoints
+		if user.AmbassadorRegion != nil {
+			in.AmbassadorRegion = *user.AmbassadorRegion
 		}
-		trust = c.applyApprovedVenueBoosts(trust, approvedCount)
-		return Assessment{
-			Trust:     trust,
-			Authority: "ambassador",
-			Bonus:     c.cfg.BonusAmb,
-			Reason:    c.buildAmbReason(isHigh, regionMatch, trust, approvedCount),
+		in.HighRankAmbassador = *user.AmbassadorLevel >= cfg.HighAmbLevel || *user.AmbassadorPoints >= cfg.HighAmbPoints
+		in.RegionMatch = matchesRegion(user.AmbassadorRegion, venueLocation)
+		if in.HighRankAmbassador && in.RegionMatch {
+			a.Authority, base, a.Bonus = "high_ambassador", cfg.HighAmbTrust, cfg.BonusHighAmb
+		} else {
+			a.Authority, base, a.Bonus = "ambassador", cfg.AmbassadorTrust, cfg.BonusAmb
 		}
+	case user.Trusted:
+		a.Authority, base, a.Bonus = "trusted", cfg.TrustedTrust, cfg.BonusTrusted
+	default:
+		a.Authority, base, a.Bonus = "regular", cfg.BaseRegularTrust, cfg.BonusRegular
 	}
 
-	// Trusted member baseline
-	if user.Trusted {
-		trust := c.cfg.TrustedTrust
-		trust = c.applyContributionBoosts(trust, user.Contributions)
-		approvedCount := 0
-		if user.ApprovedVenueCount != nil {
-			approvedCount = *user.ApprovedVenueCount
-		}
-		trust = c.applyApprovedVenueBoosts(trust, approvedCount)
-		return Assessment{
-			Trust:     trust,
-			Authority: "trusted",
-			Bonus:     c.cfg.BonusTrusted,
-			Reason:    c.buildTrustedReason(trust, user.Contributions, approvedCount),
-		}
+	adj := adjustments(cfg, in, isAmbassador)
+	trust := base
+	for _, d := range adj {
+		trust += d.Delta
 	}
+	a.Inputs = in
+	a.Adjustments = adj
+	a.PoorHistory = poorHistory(cfg, in.History)
+	a.Trust = clamp01(trust)
+	a.Reason = reason(cfg, a.Authority, in, a.Trust)
+	return a
+}
 
-	// Regular user baseline
-	trust := c.cfg.BaseRegularTrust
-	trust = c.applyContributionBoosts(trust, user.Contributions)
-	approvedCount := 0
-	if user.ApprovedVenueCount != nil {
-		approvedCount = *user.ApprovedVenueCount
+// adjustments lists the boosts and penalties that apply on top of the authority's base trust.
+func adjustments(cfg Config, in Inputs, isAmbassador bool) []Adjustment {
+	var adj []Adjustment
+	add := func(rule string, delta float64) {
+		if delta != 0 {
+			adj = append(adj, Adjustment{Rule: rule, Delta: delta})
+		}
 	}
-	trust = c.applyApprovedVenueBoosts(trust, approvedCount)
-	fmt.Printf("user ID: %d | Location: %s\n", user.ID, venueLocation)
-	fmt.Printf("user is regular: trust=%.2f, contrib=%d, approved=%d\n", trust, user.Contributions, approvedCount)
-	return Assessment{
-		Trust:     trust,
-		Authority: "regular",
-		Bonus:     c.cfg.BonusRegular,
-		Reason:    c.buildRegularReason(trust, user.Contributions, approvedCount),
+	if in.Contributions > cfg.ContributionBoost1Threshold {
+		add("contribution_boost1", cfg.ContributionBoostStep)
 	}
-}
-
-func (c *Calculator) applyContributionBoosts(base float64, contrib int) float64 {
-	trust := base
-	if contrib > c.cfg.ContributionBoost1Threshold {
-		trust += c.cfg.ContributionBoostStep
+	if in.Contributions > cfg.ContributionBoost2Threshold {
+		add("contribution_boost2", cfg.ContributionBoostStep)
 	}
-	if contrib > c.cfg.ContributionBoost2Threshold {
-		trust += c.cfg.ContributionBoostStep
+	if in.ApprovedVenues >= cfg.ApprovedVenueBoost1Threshold {
+		add("approved_venue_boost1", cfg.ApprovedVenueBoostStep)
 	}
-	if trust > 1.0 {
-		trust = 1.0
+	if in.ApprovedVenues >= cfg.ApprovedVenueBoost2Threshold {
+		add("approved_venue_boost2", cfg.ApprovedVenueBoostStep)
 	}
-	if trust < 0.0 {
-		trust = 0.0
+	if in.ApprovedVenues >= cfg.ApprovedVenueBoost3Threshold {
+		add("approved_venue_boost3", cfg.ApprovedVenueBoostStep)
 	}
-	return trust
-}
-
-func (c *Calculator) applyApprovedVenueBoosts(base float64, approvedCount int) float64 {
-	trust := base
-	if approvedCount >= c.cfg.ApprovedVenueBoost1Threshold {
-		trust += c.cfg.ApprovedVenueBoostStep
+	if isAmbassador && in.RegionMatch {
+		add("region_match_boost", cfg.RegionMatchBoost)
 	}
-	if approvedCount >= c.cfg.ApprovedVenueBoost2Threshold {
-		trust += c.cfg.ApprovedVenueBoostStep
+	// Only an ambassador with a region and a known venue location can be out of region
+	if isAmbassador && !in.RegionMatch && in.AmbassadorRegion != "" && in.VenueLocation != "" {
+		add("penalty_region_mismatch", -cfg.PenaltyRegionMismatch)
 	}
-	if approvedCount >= c.cfg.ApprovedVenueBoost3Threshold {
-		trust += c.cfg.ApprovedVenueBoostStep
+	if in.Contributions == 0 {
+		add("penalty_no_contributions", -cfg.PenaltyNoContributions)
 	}
-	if trust > 1.0 {
-		trust = 1.0
+	if h := in.History; h != nil {
+		if rejectionRatioHigh(cfg, *h) {
+			add("penalty_rejection_ratio", -cfg.PenaltyRejectionRatio)
+		}
+		if h.RejectionStreak >= cfg.RejectionStreakThreshold {
+			add("penalty_rejection_streak", -cfg.PenaltyRejectionStreak)
+		}
 	}
-	return trust
+	return adj
+}
+
+func rejectionRatioHigh(cfg Config, h models.SubmissionHistory) bool {
+	return h.Decided() >= cfg.RejectionRatioMinDecided && h.RejectionRatio() >= cfg.RejectionRatioThreshold
+}
+
+// poorHistory reports whether the submitter's past outcomes trip a reputation rule.
+func poorHistory(cfg Config, h *models.SubmissionHistory) bool {
+	return h != nil && (rejectionRatioHigh(cfg, *h) || h.RejectionStreak >= cfg.RejectionStreakThreshold)
 }
 
-func (c *Calculator) matchesRegion(userRegion *string, venueLocation string) bool {
+func matchesRegion(userRegion *string, venueLocation string) bool {
 	if userRegion == nil || *userRegion == "" || venueLocation == "" {
 		return false
 	}
@@ -202,62 +296,73 @@ func (c *Calculator) matchesRegion(userRegion *string, venueLocation string) boo
 	return strings.Contains(vl, ur)
 }
 
+func clamp01(v float64) float64 {
+	return min(max(v, 0), 1)
+}
+
 // describeApproved returns a short descriptor for approved venue counts when
 // they meet configured thresholds. Empty string otherwise.
-func (c *Calculator) describeApproved(approvedCount int) string {
-	if approvedCount >= c.cfg.ApprovedVenueBoost3Threshold {
-		return ">=10 approved"
-	}
-	if approvedCount >= c.cfg.ApprovedVenueBoost2Threshold {
-		return ">=5 approved"
-	}
-	if approvedCount >= c.cfg.ApprovedVenueBoost1Threshold {
-		return ">=2 approved"
+func describeApproved(cfg Config, approvedCount int) string {
+	for _, th := range []int{cfg.ApprovedVenueBoost3Threshold, cfg.ApprovedVenueBoost2Threshold, cfg.ApprovedVenueBoost1Threshold} {
+		if approvedCount >= th {
+			return fmt.Sprintf(">=%d approved", th)
+		}
 	}
 	return ""
 }
 
-func (c *Calculator) buildAmbReason(isHigh, regionMatch bool, trust float64, approvedCount int) string {
-	lvl := "ambassador"
-	if isHigh && regionMatch {
-		lvl = "high_ambassador"
-	}
-	why := []string{lvl}
-	if isHigh {
-		why = append(why, "high ranking")
-	}
-	if regionMatch {
-		why = append(why, "region match")
+func describeContributions(cfg Config, contrib int) string {
+	switch {
+	case contrib > cfg.ContributionBoost2Threshold:
+		return fmt.Sprintf(">%d contrib", cfg.ContributionBoost2Threshold)
+	case contrib > cfg.ContributionBoost1Threshold:
+		return fmt.Sprintf(">%d contrib", cfg.ContributionBoost1Threshold)
 	}
-	// Mention approved venues if significant
-	if ac := c.describeApproved(approvedCount); ac != "" {
-		why = append(why, ac)
-	}
-	return fmt.Sprintf("%s (%s), trust=%.2f", lvl, strings.Join(why[1:], ", "), trust)
+	return ""
 }
 
-func (c *Calculator) buildTrustedReason(trust float64, contrib int, approvedCount int) string {
-	parts := []string{"trusted member"}
-	if contrib > c.cfg.ContributionBoost2Threshold {
-		parts = append(parts, ">500 contrib")
-	} else if contrib > c.cfg.ContributionBoost1Threshold {
-		parts = append(parts, ">100 contrib")
-	}
-	if ac := c.describeApproved(approvedCount); ac != "" {
-		parts = append(parts, ac)
+func describeHistory(cfg Config, h *models.SubmissionHistory) string {
+	switch {
+	case h == nil:
+		return ""
+	case h.RejectionStreak >= cfg.RejectionStreakThreshold:
+		return fmt.Sprintf("last %d rejected", h.RejectionStreak)
+	case rejectionRatioHigh(cfg, *h):
+		return fmt.Sprintf("%d/%d rejected", h.Rejected, h.Decided())
 	}
-	return fmt.Sprintf("%s, trust=%.2f", strings.Join(parts, ", "), trust)
+	return ""
 }
 
-func (c *Calculator) buildRegularReason(trust float64, contrib int, approvedCount int) string {
-	parts := []string{"regular"}
-	if contrib > c.cfg.ContributionBoost2Threshold {
-		parts = append(parts, ">500 contrib")
-	} else if contrib > c.cfg.ContributionBoost1Threshold {
-		parts = append(parts, ">100 contrib")
+func reason(cfg Config, authority string, in Inputs, trust float64) string {
+	var why []string
+	switch authority {
+	case "high_ambassador", "ambassador":
+		if in.HighRankAmbassador {
+			why = append(why, "high ranking")
+		}
+		if in.RegionMatch {
+			why = append(why, "region match")
+		}
+		if ac := describeApproved(cfg, in.ApprovedVenues); ac != "" {
+			why = append(why, ac)
+		}
+		if hd := describeHistory(cfg, in.History); hd != "" {
+			why = append(why, hd)
+		}
+		return fmt.Sprintf("%s (%s), trust=%.2f", authority, strings.Join(why, ", "), trust)
+	case "trusted":
+		why = append(why, "trusted member")
+	default:
+		why = append(why, "regular")
+	}
+	if cd := describeContributions(cfg, in.Contributions); cd != "" {
+		why = append(why, cd)
+	}
+	if ac := describeApproved(cfg, in.ApprovedVenues); ac != "" {
+		why = append(why, ac)
 	}
-	if ac := c.describeApproved(approvedCount); ac != "" {
-		parts = append(parts, ac)
+	if hd := describeHistory(cfg, in.History); hd != "" {
+		why = append(why, hd)
 	}
-	return fmt.Sprintf("%s, trust=%.2f", strings.Join(parts, ", "), trust)
+	return fmt.Sprintf("%s, trust=%.2f", strings.Join(why, ", "), trust)
 }
@@ -1,6 +1,7 @@
 package trust
 
 import (
+	"strings"
 	"testing"
 
 	"assisted-venue-approval/internal/models"
@@ -10,7 +11,7 @@ func TestAssess_Admin(t *testing.T) {
 	c := NewDefault()
 	u := models.User{IsVenueAdmin: true}
 	a := c.Assess(u, "")
-	if a.Trust != 1.0 || a.Authority != "venue_admin" || a.Bonus != c.cfg.BonusVenueAdmin {
+	if a.Trust != 1.0 || a.Authority != "venue_admin" || a.Bonus != DefaultConfig().BonusVenueAdmin {
 		t.Fatalf("unexpected assessment: %+v", a)
 	}
 }
@@ -28,7 +29,7 @@ func TestAssess_Ambassador_High_WithRegion(t *testing.T) {
 	if a.Trust < 0.79 { // allow float rounding
 		t.Fatalf("expected trust around 0.8, got %v", a.Trust)
 	}
-	if a.Bonus != c.cfg.BonusHighAmb {
+	if a.Bonus != DefaultConfig().BonusHighAmb {
 		t.Fatalf("unexpected bonus: %+v", a)
 	}
 }
@@ -46,38 +47,176 @@ func TestAssess_Ambassador_Regular_NoRegion(t *testing.T) {
 	if a.Trust < 0.59 || a.Trust >= 0.7 {
 		t.Fatalf("expected trust around 0.6 with boosts, got %v", a.Trust)
 	}
-	if a.Bonus != c.cfg.BonusAmb {
+	if a.Bonus != DefaultConfig().BonusAmb {
 		t.Fatalf("unexpected bonus: %+v", a)
 	}
 }
 
 func TestAssess_Trusted_WithContribBoosts(t *testing.T) {
 	c := NewDefault()
-	u := models.User{Trusted: true, Contributions: 600}
+	u := models.User{Trusted: true, Contributions: 6000}
 	a := c.Assess(u, "")
-	// 0.7 + 0.1 + 0.1 = 0.9
+	// 0.7 + 0.1 (>1000) + 0.1 (>5000) = 0.9
 	if a.Authority != "trusted" {
 		t.Fatalf("expected trusted, got %+v", a)
 	}
 	if a.Trust < 0.89 || a.Trust > 0.91 {
 		t.Fatalf("expected ~0.9 trust, got %v", a.Trust)
 	}
-	if a.Bonus != c.cfg.BonusTrusted {
+	if a.Bonus != DefaultConfig().BonusTrusted {
 		t.Fatalf("unexpected bonus: %+v", a)
 	}
 }
 
 func TestAssess_Regular_CapAtOne(t *testing.T) {
 	c := NewDefault()
-	u := models.User{Contributions: 10000}
+	approved := 12
+	u := models.User{Contributions: 10000, ApprovedVenueCount: &approved}
 	a := c.Assess(u, "")
 	if a.Authority != "regular" {
 		t.Fatalf("expected regular, got %+v", a)
 	}
-	if a.Trust != 0.5 { // 0.3 + 0.1 + 0.1
-		t.Fatalf("expected 0.5 trust, got %v", a.Trust)
+	if a.Trust != 0.2+0.45 { // 0.0 + 2*0.1 contributions + 3*0.15 approved venues
+		t.Fatalf("expected 0.65 trust, got %v", a.Trust)
 	}
-	if a.Bonus != c.cfg.BonusRegular {
+	if a.Bonus != DefaultConfig().BonusRegular {
 		t.Fatalf("unexpected bonus: %+v", a)
 	}
 }
+
+func TestAssess_RecordsInputsAndAdjustments(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Version = "2026-10"
+	cfg.RegionMatchBoost = 0.05
+	c := NewCalculator(cfg)
+	lvl, pts, reg := 1, 200, "Berlin"
+	u := models.User{ID: 7, Contributions: 1500, AmbassadorLevel: &lvl, AmbassadorPoints: &pts, AmbassadorRegion: &reg}
+
+	a := c.Assess(u, "Kreuzberg, Berlin, Germany")
+	if a.RulesVersion != "2026-10" || a.Authority != "ambassador" {
+		t.Fatalf("unexpected assessment: %+v", a)
+	}
+	if !a.Inputs.RegionMatch || a.Inputs.HighRankAmbassador || a.Inputs.UserID != 7 || a.Inputs.Contributions != 1500 {
+		t.Fatalf("inputs = %+v", a.Inputs)
+	}
+	want := []Adjustment{{"contribution_boost1", 0.1}, {"region_match_boost", 0.05}}
+	if len(a.Adjustments) != len(want) {
+		t.Fatalf("adjustments = %+v, want %+v", a.Adjustments, want)
+	}
+	for i := range want {
+		if a.Adjustments[i] != want[i] {
+			t.Fatalf("adjustments = %+v, want %+v", a.Adjustments, want)
+		}
+	}
+	if a.Trust < 0.749 || a.Trust > 0.751 { // 0.6 + 0.1 + 0.05
+		t.Fatalf("expected 0.75 trust, got %v", a.Trust)
+	}
+}
+
+func TestAssess_Penalties(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PenaltyNoContributions = 0.2
+	cfg.PenaltyRegionMismatch = 0.3
+	c := NewCalculator(cfg)
+	lvl, pts, reg := 1, 200, "Tokyo"
+
+	tests := []struct {
+		name string
+		user models.User
+		loc  string
+		want float64
+	}{
+		{"new trusted member", models.User{Trusted: true}, "", 0.5},
+		{"ambassador out of region", models.User{Contributions: 10, AmbassadorLevel: &lvl, AmbassadorPoints: &pts, AmbassadorRegion: &reg}, "Seoul", 0.3},
+		{"ambassador without venue location", models.User{Contributions: 10, AmbassadorLevel: &lvl, AmbassadorPoints: &pts, AmbassadorRegion: &reg}, "", 0.6},
+		{"regular floors at zero", models.User{}, "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := c.Assess(tt.user, tt.loc)
+			if a.Trust < tt.want-0.001 || a.Trust > tt.want+0.001 {
+				t.Fatalf("trust = %v, want %v (%+v)", a.Trust, tt.want, a.Adjustments)
+			}
+		})
+	}
+}
+
+func TestNewDefault_FollowsActiveConfig(t *testing.T) {
+	defer SetConfig(nil)
+	c := NewDefault()
+	if a := c.Assess(models.User{Trusted: true}, ""); a.RulesVersion != DefaultVersion || a.Bonus != DefaultConfig().BonusTrusted {
+		t.Fatalf("default assessment = %+v", a)
+	}
+	cfg := DefaultConfig()
+	cfg.Version = "v2"
+	cfg.BonusTrusted = 20
+	SetConfig(&cfg)
+	if a := c.Assess(models.User{Trusted: true}, ""); a.RulesVersion != "v2" || a.Bonus != 20 {
+		t.Fatalf("assessment after SetConfig = %+v", a)
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{"partial file keeps defaults", "version: v3\nbonus_trusted: 12\n", ""},
+		{"version required", "bonus_trusted: 12\n", "version is required"},
+		{"builtin version rejected", "version: builtin\n", "version is required"},
+		{"unknown key", "version: v3\nbonus_trustd: 12\n", "invalid yaml"},
+		{"trust out of range", "version: v3\ntrusted_trust: 1.5\n", "trusted_trust must be 0.0-1.0"},
+		{"thresholds out of order", "version: v3\ncontribution_boost2_threshold: 500\n", "contribution boost thresholds"},
+		{"empty", "", "empty trust rules file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseConfig([]byte(tt.yaml))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.Version != "v3" || c.BonusTrusted != 12 || c.TrustedTrust != DefaultConfig().TrustedTrust {
+				t.Fatalf("config = %+v", c)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_DistFileMatchesDefaults(t *testing.T) {
+	c, err := LoadConfig("../../trust_rules.yaml.dist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Version = DefaultVersion
+	if *c != DefaultConfig() {
+		t.Fatalf("trust_rules.yaml.dist = %+v, want the built-in rules", *c)
+	}
+}
+
+func TestAssess_SubmissionHistory(t *testing.T) {
+	c := NewCalculator(DefaultConfig())
+	u := models.User{Trusted: true, Contributions: 50, History: &models.SubmissionHistory{Approved: 1, Rejected: 4, RejectionStreak: 3}}
+
+	a := c.Assess(u, "")
+	if !a.PoorHistory || a.Inputs.History == nil || a.Inputs.History.Rejected != 4 {
+		t.Fatalf("assessment = %+v", a)
+	}
+	if a.Trust < 0.199 || a.Trust > 0.201 { // 0.7 - 0.2 ratio - 0.3 streak
+		t.Fatalf("expected 0.2 trust, got %v (%+v)", a.Trust, a.Adjustments)
+	}
+	if !strings.Contains(a.Reason, "last 3 rejected") {
+		t.Fatalf("reason = %q", a.Reason)
+	}
+
+	u.IsVenueAdmin = true
+	if a := c.Assess(u, ""); a.PoorHistory || a.Trust != 1 {
+		t.Fatalf("venue admin assessment = %+v", a)
+	}
+}
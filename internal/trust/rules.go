@@ -0,0 +1,104 @@
+package trust
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// LoadConfig reads and validates a trust rules file. Empty path returns (nil, nil).
+func LoadConfig(path string) (*Config, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("read trust rules: %w", err)
+	}
+	return ParseConfig(data)
+}
+
+// ParseConfig decodes YAML over DefaultConfig, so a file only lists the rules it changes,
+// and validates the result. version is required: it is stored with every assessment, and
+// "builtin" would misreport a changed ruleset.
+func ParseConfig(data []byte) (*Config, error) {
+	c := DefaultConfig()
+	c.Version = ""
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&c); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errs.NewValidation("trust.ParseConfig", "empty trust rules file", nil)
+		}
+		return nil, errs.NewValidation("trust.ParseConfig", "invalid yaml", err)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Validate checks ranges and threshold order. It collects all problems so the operator can
+// fix the file in one pass.
+func (c Config) Validate() error {
+	var problems []string
+	add := func(format string, a ...any) { problems = append(problems, fmt.Sprintf(format, a...)) }
+
+	if v := strings.TrimSpace(c.Version); v == "" || v == DefaultVersion {
+		add("version is required and must not be %q", DefaultVersion)
+	}
+	for _, f := range []struct {
+		name string
+		v    float64
+	}{
+		{"base_regular_trust", c.BaseRegularTrust}, {"trusted_trust", c.TrustedTrust},
+		{"ambassador_trust", c.AmbassadorTrust}, {"high_ambassador_trust", c.HighAmbTrust},
+		{"venue_admin_trust", c.VenueAdminTrust}, {"region_match_boost", c.RegionMatchBoost},
+		{"contribution_boost_step", c.ContributionBoostStep}, {"approved_venue_boost_step", c.ApprovedVenueBoostStep},
+		{"penalty_no_contributions", c.PenaltyNoContributions}, {"penalty_region_mismatch", c.PenaltyRegionMismatch},
+		{"rejection_ratio_threshold", c.RejectionRatioThreshold}, {"penalty_rejection_ratio", c.PenaltyRejectionRatio},
+		{"penalty_rejection_streak", c.PenaltyRejectionStreak},
+	} {
+		if f.v < 0 || f.v > 1 {
+			add("%s must be 0.0-1.0, got %.2f", f.name, f.v)
+		}
+	}
+	if c.HighAmbLevel < 1 || c.HighAmbPoints < 1 {
+		add("high_ambassador_level and high_ambassador_points must be positive")
+	}
+	if c.RejectionRatioMinDecided < 1 || c.RejectionStreakThreshold < 1 {
+		add("rejection_ratio_min_decided and rejection_streak_threshold must be positive")
+	}
+	if c.ContributionBoost1Threshold < 0 || c.ContributionBoost2Threshold <= c.ContributionBoost1Threshold {
+		add("contribution boost thresholds must be ascending, got %d, %d", c.ContributionBoost1Threshold, c.ContributionBoost2Threshold)
+	}
+	if c.ApprovedVenueBoost1Threshold < 1 || c.ApprovedVenueBoost2Threshold <= c.ApprovedVenueBoost1Threshold ||
+		c.ApprovedVenueBoost3Threshold <= c.ApprovedVenueBoost2Threshold {
+		add("approved venue boost thresholds must be positive and ascending, got %d, %d, %d",
+			c.ApprovedVenueBoost1Threshold, c.ApprovedVenueBoost2Threshold, c.ApprovedVenueBoost3Threshold)
+	}
+	for _, f := range []struct {
+		name string
+		v    int
+	}{
+		{"bonus_venue_admin", c.BonusVenueAdmin}, {"bonus_high_ambassador", c.BonusHighAmb},
+		{"bonus_ambassador", c.BonusAmb}, {"bonus_trusted", c.BonusTrusted}, {"bonus_regular", c.BonusRegular},
+	} {
+		if f.v < 0 || f.v > 100 {
+			add("%s must be 0-100, got %d", f.name, f.v)
+		}
+	}
+
+	if len(problems) > 0 {
+		return errs.NewValidation("trust.Config.Validate", strings.Join(problems, "; "), nil)
+	}
+	return nil
+}
@@ -2,15 +2,19 @@ package validation
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
 	"strings"
 
+	"assisted-venue-approval/internal/approval"
 	"assisted-venue-approval/internal/drafts"
 )
 
 var (
 	// pathRegex enforces pipe-delimited segments comprised of alphanumeric, hyphen, or underscore characters.
 	pathRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+(?:\|[a-zA-Z0-9_-]+)*$`)
+	// phoneRegex allows an optional leading + followed by digits, spaces, dots, hyphens, and parentheses.
+	phoneRegex = regexp.MustCompile(`^\+?[0-9.\-() ]+$`)
 )
 
 // ValidateName validates venue name
@@ -46,10 +50,37 @@ func ValidatePhone(phone string) error {
 	if len(phone) > 50 {
 		return fmt.Errorf("phone must be less than 50 characters")
 	}
-	// Basic validation: allow digits, spaces, +, -, (, )
-	validChars := regexp.MustCompile(`^[0-9+\-() ]+$`)
-	if !validChars.MatchString(phone) {
-		return fmt.Errorf("phone contains invalid characters")
+	if !phoneRegex.MatchString(phone) {
+		return fmt.Errorf("phone can only contain digits, spaces, dots, hyphens, parentheses, and a leading +")
+	}
+	digits := 0
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+	// E.164 allows at most 15 digits; extensions are not stored in the phone field
+	if digits < 6 || digits > 15 {
+		return fmt.Errorf("phone must have between 6 and 15 digits")
+	}
+	return nil
+}
+
+// ValidateWebsite validates venue website URL
+func ValidateWebsite(website string) error {
+	website = strings.TrimSpace(website)
+	if website == "" {
+		return nil // Optional field
+	}
+	if len(website) > 500 {
+		return fmt.Errorf("website must be less than 500 characters")
+	}
+	u, err := url.Parse(website)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("website must be a valid URL starting with http:// or https://")
+	}
+	if u.Hostname() == "" || !strings.Contains(u.Hostname(), ".") || strings.ContainsAny(website, " \t\n") {
+		return fmt.Errorf("website must be a valid URL with a domain, e.g. https://example.com")
 	}
 	return nil
 }
@@ -93,7 +124,9 @@ func ValidateDescription(desc string) error {
 	return nil
 }
 
-// ValidateOpenHours validates open hours array
+// ValidateOpenHours validates open hours array. Every line must be one approval can
+// read (see approval.ParseHours), so that no hours are silently dropped when the venue is
+// approved.
 func ValidateOpenHours(hours []string) error {
 	if len(hours) == 0 {
 		return nil // Optional field
@@ -106,6 +139,9 @@ func ValidateOpenHours(hours []string) error {
 			return fmt.Errorf("individual hours entry too long (max 200 chars)")
 		}
 	}
+	if _, unparsed := approval.ParseHours(hours); len(unparsed) > 0 {
+		return fmt.Errorf("unrecognized hours entry %q (use e.g. \"Mon-09:00-17:00\" or \"Monday: 9:00 AM – 5:00 PM\")", unparsed[0])
+	}
 	return nil
 }
 
@@ -135,6 +171,14 @@ func ValidateVegan(v int) error {
 	return nil
 }
 
+// ValidateVegOnly validates the vegetarian-only flag
+func ValidateVegOnly(v int) error {
+	if v != 0 && v != 1 {
+		return fmt.Errorf("invalid vegetarian-only flag (must be 0 or 1)")
+	}
+	return nil
+}
+
 // ValidateCategory validates venue category
 func ValidateCategory(c int) error {
 	validCategories := map[int]struct{}{
@@ -192,6 +236,13 @@ func ValidateVenueDraft(fields map[string]drafts.DraftField) map[string]string {
 				err = fmt.Errorf("invalid type for phone")
 			}
 
+		case "website":
+			if val, ok := field.Value.(string); ok {
+				err = ValidateWebsite(val)
+			} else {
+				err = fmt.Errorf("invalid type for website")
+			}
+
 		case "lat":
 			if val, ok := field.Value.(float64); ok {
 				err = ValidateLatitude(val)
@@ -258,6 +309,21 @@ func ValidateVenueDraft(fields map[string]drafts.DraftField) map[string]string {
 				err = ValidateVegan(intVal)
 			}
 
+		case "vegonly":
+			var intVal int
+			switch v := field.Value.(type) {
+			case int:
+				intVal = v
+			case float64:
+				intVal = int(v)
+			default:
+				err = fmt.Errorf("invalid type for vegetarian-only flag")
+				break
+			}
+			if err == nil {
+				err = ValidateVegOnly(intVal)
+			}
+
 		case "category":
 			var intVal int
 			switch v := field.Value.(type) {
@@ -290,6 +356,10 @@ func ValidateVenueDraft(fields map[string]drafts.DraftField) map[string]string {
 			} else {
 				err = fmt.Errorf("invalid type for open_hours")
 			}
+
+		default:
+			// Approval only applies the fields above, so anything else would be lost
+			err = fmt.Errorf("unknown field")
 		}
 
 		if err != nil {
@@ -0,0 +1,56 @@
+package validation
+
+import (
+	"testing"
+
+	"assisted-venue-approval/internal/drafts"
+)
+
+func TestValidatePhone(t *testing.T) {
+	for _, phone := range []string{"", "+44 20 7946 0958", "(555) 123-4567", "030.1234.5678"} {
+		if err := ValidatePhone(phone); err != nil {
+			t.Errorf("ValidatePhone(%q) = %v", phone, err)
+		}
+	}
+	for _, phone := range []string{"12345", "+1 555 123 4567 890 123", "555+1234567", "call 5551234"} {
+		if err := ValidatePhone(phone); err == nil {
+			t.Errorf("ValidatePhone(%q) accepted", phone)
+		}
+	}
+}
+
+func TestValidateWebsite(t *testing.T) {
+	for _, site := range []string{"", "https://example.com", "http://cafe.example.co.uk/menu?lang=en"} {
+		if err := ValidateWebsite(site); err != nil {
+			t.Errorf("ValidateWebsite(%q) = %v", site, err)
+		}
+	}
+	for _, site := range []string{"example.com", "ftp://example.com", "https://", "https://localhost", "https://exa mple.com", "javascript:alert(1)"} {
+		if err := ValidateWebsite(site); err == nil {
+			t.Errorf("ValidateWebsite(%q) accepted", site)
+		}
+	}
+}
+
+func TestValidateOpenHours(t *testing.T) {
+	if err := ValidateOpenHours([]string{"Mon-09:00-17:00", "Tuesday: 9:00 AM – 5:00 PM", "Wednesday: Closed", "Thu-Sat: 9am-5pm", " "}); err != nil {
+		t.Fatalf("ValidateOpenHours() = %v", err)
+	}
+	if err := ValidateOpenHours([]string{"Mon-09:00-17:00", "Weekdays 9-5"}); err == nil {
+		t.Fatal("ValidateOpenHours() accepted an unparseable line")
+	}
+}
+
+func TestValidateVenueDraft(t *testing.T) {
+	errs := ValidateVenueDraft(map[string]drafts.DraftField{
+		"name":       {Value: "Green Leaf"},
+		"website":    {Value: "greenleaf.example.com"},
+		"phone":      {Value: "+44 20 7946 0958"},
+		"vegonly":    {Value: float64(2)},
+		"open_hours": {Value: []interface{}{"Mon-09:00-17:00"}},
+		"zip":        {Value: "10115"},
+	})
+	if len(errs) != 3 || errs["website"] == "" || errs["vegonly"] == "" || errs["zip"] == "" {
+		t.Fatalf("errors = %v, want website, vegonly and zip", errs)
+	}
+}
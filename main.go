@@ -5,30 +5,49 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/gorilla/mux"
 	_ "github.com/joho/godotenv/autoload"
+	"google.golang.org/grpc"
 
 	"assisted-venue-approval/internal/admin"
+	"assisted-venue-approval/internal/approval"
+	"assisted-venue-approval/internal/archive"
 	"assisted-venue-approval/internal/auth"
+	"assisted-venue-approval/internal/closure"
+	"assisted-venue-approval/internal/constants"
 	"assisted-venue-approval/internal/decision"
 	"assisted-venue-approval/internal/domain"
 	"assisted-venue-approval/internal/drafts"
+	"assisted-venue-approval/internal/embeddings"
+	"assisted-venue-approval/internal/flags"
+	"assisted-venue-approval/internal/graphqlapi"
+	"assisted-venue-approval/internal/grpcapi"
 	"assisted-venue-approval/internal/infrastructure/repository"
 	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/internal/notify"
+	"assisted-venue-approval/internal/openapi"
+	"assisted-venue-approval/internal/privacy"
 	"assisted-venue-approval/internal/processor"
 	"assisted-venue-approval/internal/prompts"
 	"assisted-venue-approval/internal/scorer"
 	"assisted-venue-approval/internal/scraper"
+	"assisted-venue-approval/internal/tenant"
+	"assisted-venue-approval/internal/translate"
+	"assisted-venue-approval/internal/trust"
 	"assisted-venue-approval/pkg/config"
 	"assisted-venue-approval/pkg/container"
 	"assisted-venue-approval/pkg/database"
+	errs "assisted-venue-approval/pkg/errors"
 	"assisted-venue-approval/pkg/events"
 	metricsPkg "assisted-venue-approval/pkg/metrics"
 	"assisted-venue-approval/pkg/monitoring"
@@ -38,6 +57,13 @@ func main() {
 	// Set embedded config filesystem for prompts package
 	prompts.ConfigFilesFS = ConfigFiles()
 
+	// With TENANTS_FILE this process is the tenant gateway; the app runs in one child process
+	// per tenant, which the gateway starts with TENANT set
+	if gwCfg := config.Load(); gwCfg.TenantsFile != "" && gwCfg.Tenant == "" {
+		runTenantGateway(gwCfg)
+		return
+	}
+
 	// Build container and register providers
 	c := container.New()
 
@@ -51,16 +77,42 @@ func main() {
 	_ = c.Provide(func(db *database.DB) domain.Repository { return repository.NewSQLRepository(db) }, true)
 	_ = c.Provide(func(db *database.DB) domain.UnitOfWorkFactory { return repository.NewSQLUnitOfWorkFactory(db) }, true)
 
+	// Feature flags (singleton); nil unless FEATURE_FLAGS_ENABLED=true
+	_ = c.Provide(func(cfg *config.Config, repo domain.Repository) *flags.Service {
+		fs, ok := repo.(domain.FeatureFlagStore)
+		if !cfg.FeatureFlagsEnabled || !ok {
+			return nil
+		}
+		return flags.New(fs, cfg.FeatureFlagsRefresh)
+	}, true)
+
 	// External clients (singletons)
-	_ = c.Provide(func(cfg *config.Config) (*scraper.GoogleMapsScraper, error) {
-		return scraper.NewGoogleMapsScraper(cfg.GoogleMapsAPIKey)
+	_ = c.Provide(func(cfg *config.Config, ff *flags.Service) (*scraper.GoogleMapsScraper, error) {
+		spec := cfg.GoogleMapsAPIKeys
+		if spec == "" {
+			spec = cfg.GoogleMapsAPIKey
+		}
+		keys, err := scraper.ParseAPIKeys(spec, cfg.GoogleMapsKeyRPS, cfg.GoogleMapsKeyDailyQuota)
+		if err != nil {
+			return nil, err
+		}
+		s, err := scraper.NewGoogleMapsScraper(keys)
+		if err != nil {
+			return nil, err
+		}
+		s.SetReverseGeocode(cfg.GeofenceReverseGeocode)
+		s.SetPlacesAPINewPercent(cfg.PlacesAPINewPercent)
+		s.SetFeatureFlags(ff)
+		return s, nil
 	}, true)
 	// Prompts manager with optional external overrides
 	_ = c.Provide(func(cfg *config.Config) (*prompts.Manager, error) {
 		return prompts.NewManager(cfg.PromptDir)
 	}, true)
 	_ = c.Provide(func(cfg *config.Config, pm *prompts.Manager) *scorer.AIScorer {
-		return scorer.NewAIScorerWithTimeoutAndPrompts(cfg.OpenAIAPIKey, cfg.OpenAITimeout, pm)
+		s := scorer.NewAIScorerWithTimeoutAndPrompts(cfg.OpenAIAPIKey, cfg.OpenAITimeout, pm)
+		s.SetContextBudget(cfg.OpenAIContextBudgetTokens)
+		return s
 	}, true)
 
 	// Quality reviewer (singleton)
@@ -69,7 +121,7 @@ func main() {
 	}, true)
 
 	// Processing engine (singleton)
-	_ = c.Provide(func(repo domain.Repository, uow domain.UnitOfWorkFactory, g *scraper.GoogleMapsScraper, s *scorer.AIScorer, qr *scorer.QualityReviewer, cfg *config.Config) *processor.ProcessingEngine {
+	_ = c.Provide(func(repo domain.Repository, uow domain.UnitOfWorkFactory, g *scraper.GoogleMapsScraper, s *scorer.AIScorer, qr *scorer.QualityReviewer, pm *prompts.Manager, cfg *config.Config, ff *flags.Service) (*processor.ProcessingEngine, error) {
 		pc := processor.DefaultProcessingConfig()
 		if cfg.WorkerCount > 0 {
 			pc.WorkerCount = cfg.WorkerCount
@@ -77,15 +129,173 @@ func main() {
 		// Apply AVA qualification configuration
 		pc.MinUserPointsForAVA = cfg.MinUserPointsForAVA
 		pc.OnlyAmbassadors = cfg.OnlyAmbassadors
+		pc.Prefilter = prefilterConfig(cfg)
+		pc.Retry = retryPolicy(cfg)
+		pc.ResultBatchSize = cfg.ResultBatchSize
+		pc.ResultFlushInterval = cfg.ResultFlushInterval
+		pc.GoogleRPS, pc.GoogleBurst = cfg.RateLimitGoogleRPS, cfg.RateLimitGoogleBurst
+		pc.PhotoRPS, pc.PhotoBurst = cfg.RateLimitGooglePhotosRPS, cfg.RateLimitGooglePhotosBurst
+		pc.OpenAIRPS, pc.OpenAIBurst = cfg.RateLimitOpenAIRPS, cfg.RateLimitOpenAIBurst
+		partitions, err := processor.ParseQuotaPartitions(cfg.QuotaPartitions)
+		if err != nil {
+			return nil, err
+		}
+		pc.Quota = processor.QuotaConfig{
+			Partitions: partitions,
+			Daily: map[string]int64{
+				processor.EndpointGooglePlaces: cfg.QuotaGoogleDailyTokens,
+				processor.EndpointOpenAI:       cfg.QuotaOpenAIDailyTokens,
+			},
+			IdleAfter:    cfg.QuotaIdleAfter,
+			ProcessShare: cfg.QuotaShare / 100,
+		}
+		if pc.StageWeights, err = processor.ParseStageWeights(cfg.StageBudgetWeights); err != nil {
+			return nil, err
+		}
 		dc := decision.DefaultDecisionConfig()
 		if cfg.ApprovalThreshold > 0 {
 			dc.ApprovalThreshold = cfg.ApprovalThreshold
 		}
-		return processor.NewProcessingEngine(repo, uow, g, s, qr, pc, dc)
+		dc.GeofenceForceReview = cfg.GeofenceForceReview
+		pe := processor.NewProcessingEngine(repo, uow, g, s, qr, pc, dc)
+		if cfg.WorkerAutoscale {
+			pe.SetAutoscale(processor.AutoscaleConfig{
+				Enabled:      true,
+				Min:          cfg.WorkerMin,
+				Max:          cfg.WorkerMax,
+				Interval:     cfg.WorkerScaleInterval,
+				MaxErrorRate: cfg.WorkerScaleMaxErrorRate,
+			})
+		}
+		if cfg.CheckpointEnabled {
+			if cs, ok := repo.(domain.CheckpointStore); ok {
+				pe.EnableCheckpoints(cs, cfg.CheckpointMaxAge)
+			}
+		}
+		if cfg.ReputationEnabled {
+			if rs, ok := repo.(domain.ReputationStore); ok {
+				pe.EnableReputation(rs, cfg.ReputationCacheTTL)
+			}
+		}
+		if cfg.SubmitterRulesEnabled {
+			if sr, ok := repo.(domain.SubmitterRuleStore); ok {
+				pe.EnableSubmitterRules(sr, cfg.SubmitterRulesRefresh)
+			}
+		}
+		if cfg.BatchScoringEnabled {
+			if bs, ok := repo.(domain.BatchScoringStore); ok {
+				pe.EnableBatchScoring(bs, s, processor.BatchScoringConfig{
+					PollInterval: cfg.BatchScoringPollInterval,
+					MaxRequests:  cfg.BatchScoringMaxRequests,
+				})
+			}
+		}
+		if cfg.RescoreEnabled && cfg.RescoreInterval > 0 && cfg.RescoreMaxVenues > 0 {
+			if rs, ok := repo.(domain.RescoreStore); ok {
+				pe.EnableRescore(rs, s, processor.RescoreConfig{
+					Interval:       cfg.RescoreInterval,
+					MaxVenues:      cfg.RescoreMaxVenues,
+					DailyBudgetUSD: cfg.RescoreDailyBudgetUSD,
+				})
+			}
+		}
+		if cfg.EmbeddingsEnabled {
+			if es, ok := repo.(domain.EmbeddingStore); ok {
+				emb := embeddings.NewOpenAI(cfg.OpenAIAPIKey, cfg.EmbeddingModel, cfg.EmbeddingDimensions, cfg.OpenAITimeout)
+				pe.EnableEmbeddingCheck(es, emb, processor.EmbeddingCheckConfig{
+					DuplicateThreshold: cfg.EmbeddingDuplicateThreshold,
+					SpamThreshold:      cfg.EmbeddingSpamThreshold,
+					SpamMinMatches:     cfg.EmbeddingSpamMinMatches,
+					Lookback:           cfg.EmbeddingLookback,
+					IndexLimit:         cfg.EmbeddingIndexLimit,
+					Refresh:            cfg.EmbeddingIndexRefresh,
+				})
+			}
+		}
+		if cfg.ProcessingCoordination == "db" {
+			// The SQL repository also implements the shared queue
+			if q, ok := repo.(domain.JobQueue); ok {
+				pe.EnableDistributed(q, distributedConfig(cfg))
+			} else {
+				log.Printf("PROCESSING_COORDINATION=db: repository has no shared queue, processing locally")
+			}
+		}
+		pe.SetQualityReview(processor.QualityReviewConfig{
+			Enabled:       cfg.QualityReviewEnabled,
+			MinScore:      cfg.QualityReviewMinScore,
+			SamplePercent: cfg.QualityReviewSamplePercent,
+		})
+		pe.SetFeatureFlags(ff)
+		// With feature flags the photo_check flag can turn the check on without PHOTO_CHECK_ENABLED
+		if cfg.PhotoCheckEnabled || ff != nil {
+			pcc := processor.DefaultPhotoCheckConfig()
+			pcc.Enabled = cfg.PhotoCheckEnabled
+			pcc.MaxPhotos = cfg.PhotoCheckMaxPhotos
+			pcc.DailyBudgetUSD = cfg.PhotoCheckDailyBudgetUSD
+			pe.SetPhotoCheck(scorer.NewPhotoReviewer(cfg.OpenAIAPIKey, pm, cfg.PhotoCheckModel, cfg.OpenAITimeout), pcc)
+		}
+		if cfg.WebsiteCheckEnabled {
+			pe.SetWebsiteChecker(scraper.NewWebsiteScraper(cfg.WebsiteCheckTimeout))
+		}
+		if cfg.SocialCheckEnabled {
+			pe.SetSocialVerifier(scraper.NewSocialVerifier(cfg.SocialCheckTimeout))
+		}
+		var urlScanners []processor.URLScanner
+		if cfg.URLBlocklistFile != "" {
+			if bl, err := scraper.LoadDomainBlocklist(cfg.URLBlocklistFile); err != nil {
+				log.Printf("URL_BLOCKLIST_FILE: %v (blocklist scanning disabled)", err)
+			} else {
+				log.Printf("URL blocklist: %d domains from %s", bl.Len(), cfg.URLBlocklistFile)
+				urlScanners = append(urlScanners, bl)
+			}
+		}
+		if cfg.SafeBrowsingAPIKey != "" {
+			urlScanners = append(urlScanners, scraper.NewSafeBrowsing(cfg.SafeBrowsingAPIKey, cfg.URLScanTimeout))
+		}
+		pe.EnableURLScan(cfg.URLScanReject, urlScanners...)
+		if cfg.CategorySuggestionsEnabled {
+			pe.EnableCategorySuggestions()
+		}
+		if cfg.VeganCheckEnabled {
+			var reviews processor.ReviewFetcher
+			if cfg.VeganCheckReviews {
+				reviews = g
+			}
+			pe.SetVeganClassifier(scorer.NewVeganClassifier(cfg.OpenAIAPIKey, pm, cfg.VeganCheckModel, cfg.OpenAITimeout), reviews)
+		}
+		switch cfg.TranslationProvider {
+		case "openai":
+			pe.SetTranslator(scorer.NewTranslator(cfg.OpenAIAPIKey, "", cfg.TranslationTimeout), cfg.TranslationProvider)
+		case "deepl":
+			pe.SetTranslator(translate.NewDeepL(cfg.TranslationAPIKey, cfg.TranslationTimeout), cfg.TranslationProvider)
+		case "google":
+			pe.SetTranslator(translate.NewGoogle(cfg.TranslationAPIKey, cfg.TranslationTimeout), cfg.TranslationProvider)
+		}
+		return pe, nil
 	}, true)
 
 	// Event store (singleton)
 	_ = c.Provide(func(db *database.DB) (events.EventStore, error) { return events.NewSQLEventStore(db) }, true)
+	// Outbox dispatcher: copies events committed with admin decisions into the event store (singleton)
+	_ = c.Provide(func(db *database.DB, es events.EventStore) *events.OutboxDispatcher {
+		return events.NewOutboxDispatcher(db, es)
+	}, true)
+	// Read-side projections over venue_events (singleton)
+	_ = c.Provide(func(cfg *config.Config, db *database.DB) *events.Projector {
+		p := events.NewProjector(db)
+		if cfg.EventsWebhookURL != "" {
+			p.EnableWebhook(cfg.EventsWebhookURL, cfg.EventsWebhookSecret, cfg.EventsWebhookTimeout)
+		}
+		return p
+	}, true)
+
+	// Submitter notifications (singleton); disabled unless NOTIFY_ENABLED=true
+	_ = c.Provide(func(cfg *config.Config, repo domain.Repository) (*notify.Notifier, error) {
+		sender := notify.NewSMTPSender(notify.SMTPConfig{
+			Host: cfg.SMTPHost, Port: cfg.SMTPPort, Username: cfg.SMTPUsername, Password: cfg.SMTPPassword,
+		})
+		return notify.New(notify.Config{Enabled: cfg.NotifyEnabled, From: cfg.NotifyFrom, DefaultLang: cfg.NotifyDefaultLang}, sender, repo)
+	}, true)
 
 	// Resolve config early for monitoring setup
 	var cfg *config.Config
@@ -93,6 +303,10 @@ func main() {
 		log.Fatal("config resolve:", err)
 	}
 	monitoring.EnableProfiling(cfg.ProfilingEnabled)
+	if cfg.Tenant != "" {
+		log.SetPrefix("[" + cfg.Tenant + "] ")
+		log.Printf("Serving tenant %s (%s) under %s", cfg.Tenant, cfg.TenantName, cfg.BasePath)
+	}
 	log.Println("Starting venue validation system")
 
 	// Load templates
@@ -100,17 +314,41 @@ func main() {
 		log.Fatal("Failed to load templates:", err)
 	}
 
+	if cfg.TemplateDir != "" {
+		admin.SetTemplateDir(cfg.TemplateDir)
+		log.Printf("Admin templates reload from %s on every request", cfg.TemplateDir)
+	}
+
 	// Set base path for templates
 	admin.SetBasePath(cfg.BasePath)
+	admin.SetSubmitterRulesEnabled(cfg.SubmitterRulesEnabled)
+	admin.SetConfigPageEnabled(cfg.ConfigAPIEnabled)
+	admin.SetHoldsEnabled(cfg.VenueHoldsEnabled)
+	admin.SetCommentsEnabled(cfg.VenueCommentsEnabled)
+	admin.SetSavedFiltersEnabled(cfg.SavedFiltersEnabled)
+	if cfg.VenueClaimsEnabled {
+		admin.SetClaimTimeout(cfg.VenueClaimTimeout)
+	}
 
 	// Wire event store into engine and admin
-	if err := c.Invoke(func(pe *processor.ProcessingEngine, es events.EventStore) {
+	if err := c.Invoke(func(pe *processor.ProcessingEngine, es events.EventStore, uf domain.UnitOfWorkFactory) {
 		pe.SetEventStore(es)
 		admin.SetEventStore(es)
+		admin.SetUnitOfWorkFactory(uf)
 	}); err != nil {
 		log.Printf("Event store init failed: %v", err)
 	}
 
+	// Wire submitter notifications into admin
+	if err := c.Invoke(func(n *notify.Notifier) {
+		admin.SetNotifier(n)
+		if n.Enabled() {
+			log.Printf("Submitter notifications enabled via %s:%d", cfg.SMTPHost, cfg.SMTPPort)
+		}
+	}); err != nil {
+		log.Printf("Notifier init failed: %v", err)
+	}
+
 	// Resolve runtime dependencies
 	var (
 		db   *database.DB
@@ -123,17 +361,71 @@ func main() {
 	if err := c.Resolve(&repo); err != nil {
 		log.Fatal("repo resolve:", err)
 	}
+	// Prompt templates are linted on load; a bad override in PROMPT_DIR stops startup here
+	var pm *prompts.Manager
+	if err := c.Resolve(&pm); err != nil {
+		log.Fatal("prompts:", err)
+	}
 	if err := c.Resolve(&eng); err != nil {
 		log.Fatal("engine resolve:", err)
 	}
 
-	app := &App{db: db, config: cfg, engine: eng}
+	var proj *events.Projector
+	if err := c.Resolve(&proj); err != nil {
+		log.Fatal("projector resolve:", err)
+	}
+	var gmaps *scraper.GoogleMapsScraper
+	if err := c.Resolve(&gmaps); err != nil {
+		log.Fatal("scraper resolve:", err)
+	}
+
+	var ff *flags.Service
+	if err := c.Resolve(&ff); err != nil {
+		log.Fatal("feature flags resolve:", err)
+	}
+	admin.SetFeatureFlags(ff)
+
+	app := &App{db: db, config: cfg, engine: eng, scraper: gmaps, flags: ff}
+
+	// Decision rules file is optional; a bad file at startup is fatal so we never run on surprise defaults
+	if rules, err := decision.LoadRules(cfg.DecisionRulesFile); err != nil {
+		log.Fatal("decision rules:", err)
+	} else if rules != nil {
+		if err := eng.ApplyDecisionRules(rules); err != nil {
+			log.Fatal("decision rules:", err)
+		}
+		log.Printf("Loaded decision rules from %s", cfg.DecisionRulesFile)
+	}
+	// Same for the Google match weights; without a file the built-in table is used
+	if w, err := scraper.LoadWeights(cfg.ScoreWeightsFile); err != nil {
+		log.Fatal("score weights:", err)
+	} else if w != nil {
+		scraper.SetWeights(w)
+		log.Printf("Loaded score weights profile %q from %s", w.Profile, cfg.ScoreWeightsFile)
+	}
+	// And the trust rules; without a file the built-in ruleset is used
+	if tr, err := trust.LoadConfig(cfg.TrustRulesFile); err != nil {
+		log.Fatal("trust rules:", err)
+	} else if tr != nil {
+		trust.SetConfig(tr)
+		log.Printf("Loaded trust rules version %q from %s", tr.Version, cfg.TrustRulesFile)
+	}
+	// And the description style rules; without a file the built-in style guide is used
+	if sr, err := approval.LoadStyleRules(cfg.StyleRulesFile); err != nil {
+		log.Fatal("style rules:", err)
+	} else if sr != nil {
+		approval.SetStyleRules(sr)
+		log.Printf("Loaded description style rules from %s", cfg.StyleRulesFile)
+	}
+
+	// Editor venue drafts, persisted in venue_drafts with optimistic concurrency
+	draftStore := drafts.NewPersistentDraftStore(db)
 
-	// Initialize in-memory draft store for editor venue modifications
-	draftStore := drafts.NewDraftStore()
-	log.Printf("Initialized in-memory draft store")
+	// Endpoints that queue paid Google/OpenAI calls are rate limited per admin (or IP)
+	bulkLimit := auth.NewRateLimiter("validate", cfg.RateLimitBulkPerMinute, cfg.RateLimitBulkBurst)
+	singleLimit := auth.NewRateLimiter("validate_single", cfg.RateLimitSinglePerMinute, cfg.RateLimitSingleBurst)
 
-	// Start config watcher for hot-reload (applies worker count, approval threshold, and AVA config)
+	// Start config watcher for hot-reload (applies worker count, approval threshold, AVA config and decision rules)
 	cw := config.NewWatcher(time.Duration(cfg.ConfigReloadIntervalSeconds) * time.Second)
 	cw.Start()
 	chgCh := cw.Subscribe()
@@ -148,9 +440,71 @@ func main() {
 			if wc <= 0 {
 				wc = cfg.WorkerCount
 			}
-			eng.ApplyConfig(wc, chg.New.ApprovalThreshold)
+			if cfg.WorkerAutoscale {
+				wc = 0 // the autoscaler owns the pool size
+			}
+			if err := eng.ApplyConfig(wc, chg.New.ApprovalThreshold); err != nil {
+				log.Printf("Approval threshold reload refused, keeping the previous threshold: %v", err)
+			}
 			// Apply AVA qualification config updates
 			eng.ApplyAVAConfig(chg.New.MinUserPointsForAVA, chg.New.OnlyAmbassadors)
+			eng.ApplyPrefilterConfig(prefilterConfig(chg.New))
+			// Reload decision rules and score weights on path or mtime change; keep the previous
+			// version if the new file is invalid
+			for _, f := range chg.Fields {
+				switch f {
+				case "DecisionRules":
+					rules, err := decision.LoadRules(chg.New.DecisionRulesFile)
+					if err != nil {
+						log.Printf("Decision rules reload failed, keeping previous rules: %v", err)
+						continue
+					}
+					if err := eng.ApplyDecisionRules(rules); err != nil {
+						log.Printf("Decision rules reload failed, keeping previous rules: %v", err)
+						continue
+					}
+					log.Printf("Decision rules reloaded from %q", chg.New.DecisionRulesFile)
+				case "ScoreWeights":
+					w, err := scraper.LoadWeights(chg.New.ScoreWeightsFile)
+					if err != nil {
+						log.Printf("Score weights reload failed, keeping previous weights: %v", err)
+						continue
+					}
+					scraper.SetWeights(w)
+					log.Printf("Score weights reloaded (profile %q)", scraper.ActiveWeights().Profile)
+				case "TrustRules":
+					tr, err := trust.LoadConfig(chg.New.TrustRulesFile)
+					if err != nil {
+						log.Printf("Trust rules reload failed, keeping previous rules: %v", err)
+						continue
+					}
+					trust.SetConfig(tr)
+					log.Printf("Trust rules reloaded (version %q)", trust.ActiveConfig().Version)
+				case "StyleRules":
+					sr, err := approval.LoadStyleRules(chg.New.StyleRulesFile)
+					if err != nil {
+						log.Printf("Style rules reload failed, keeping previous rules: %v", err)
+						continue
+					}
+					approval.SetStyleRules(sr)
+					log.Printf("Style rules reloaded from %q", chg.New.StyleRulesFile)
+				case "Prompts":
+					if err := pm.Reload(chg.New.PromptDir); err != nil {
+						log.Printf("Prompt templates reload failed, keeping previous templates: %v", err)
+						continue
+					}
+					log.Printf("Prompt templates reloaded from %q", chg.New.PromptDir)
+				case "RateLimits":
+					bulkLimit.SetLimit(chg.New.RateLimitBulkPerMinute, chg.New.RateLimitBulkBurst)
+					singleLimit.SetLimit(chg.New.RateLimitSinglePerMinute, chg.New.RateLimitSingleBurst)
+				case "ExternalRateLimits":
+					eng.SetRateLimit(processor.EndpointGooglePlaces, chg.New.RateLimitGoogleRPS, chg.New.RateLimitGoogleBurst)
+					eng.SetRateLimit(processor.EndpointGooglePhotos, chg.New.RateLimitGooglePhotosRPS, chg.New.RateLimitGooglePhotosBurst)
+					eng.SetRateLimit(processor.EndpointOpenAI, chg.New.RateLimitOpenAIRPS, chg.New.RateLimitOpenAIBurst)
+				case "Budgets":
+					eng.ApplyBudgets(chg.New.PhotoCheckDailyBudgetUSD, chg.New.RescoreDailyBudgetUSD)
+				}
+			}
 			cfg = chg.New
 			log.Printf("Config applied. Changed fields: %v", chg.Fields)
 		}
@@ -170,14 +524,47 @@ func main() {
 		cancel()
 	}()
 
+	// Deliver outbox events, then keep timeline/activity projections caught up with the event store
+	if err := c.Invoke(func(od *events.OutboxDispatcher) {
+		go od.Run(ctx, constants.EventsOutboxIntervalDefault)
+	}); err != nil {
+		log.Printf("Outbox dispatcher init failed: %v", err)
+	}
+	go proj.Run(ctx, constants.EventsProjectionIntervalDefault)
+
+	// Move validation histories past HISTORY_RETENTION_MONTHS to the archive table
+	historyArchiver := archive.New(db, cfg.HistoryRetentionMonths, cfg.HistoryArchiveBatch)
+	go historyArchiver.Run(ctx, cfg.HistoryArchiveInterval)
+
+	// Clear editor feedback IPs older than FEEDBACK_IP_RETENTION_DAYS; the rows stay for the stats
+	go privacy.NewScrubber(db, cfg.FeedbackIPRetentionDays, 1000).Run(ctx, constants.FeedbackIPScrubIntervalDefault)
+
+	// Re-check approved venues against Google every CLOSURE_RECHECK_DAYS and queue closures for review
+	if cfg.ClosureRecheckDays > 0 {
+		go closure.NewSweeper(db, gmaps, cfg.ClosureRecheckDays, cfg.ClosureSweepBatch).Run(ctx, constants.ClosureSweepIntervalDefault)
+	}
+
 	// Initialize admin resolver for IP-based authentication
 	adminResolver := auth.NewAdminResolver()
 
 	// Create admin authentication middleware
 	adminAuthMiddleware := auth.NewAdminAuthMiddleware(adminResolver, admin.RenderUnauthorized)
+	tokens := auth.NewTokenAuthenticator(db, cfg.APITokenPrefixes)
+	if len(cfg.APITokenPrefixes) > 0 {
+		adminAuthMiddleware.UseTokens(tokens)
+	}
+
+	// Incident controls (circuit trip/reset) and data subject requests are limited to SUPERADMIN_IDS
+	supers := auth.NewSuperadmins(cfg.SuperadminIDs)
+	// Key for hashed feedback IPs (FEEDBACK_IP_MODE=hash and erasure requests)
+	if cfg.FeedbackIPMode == privacy.IPModeHash && cfg.PrivacyHashKey == "" {
+		log.Fatal("PRIVACY_HASH_KEY is required when FEEDBACK_IP_MODE=hash")
+	}
+	ipHashKey := []byte(cfg.PrivacyHashKey)
 
 	// HTTP routing
 	router := mux.NewRouter()
+	router.Use(monitoring.RequestID)
 
 	var metrics *monitoring.Metrics
 	if cfg.MetricsEnabled {
@@ -187,37 +574,217 @@ func main() {
 
 	// Apply admin authentication middleware to all routes
 	router.Use(adminAuthMiddleware.Handler)
+	if cfg.CSRFEnabled {
+		csrf := auth.NewCSRFMiddleware(auth.CSRFConfig{
+			CookiePath:     cfg.BasePath,
+			Secure:         cfg.CSRFCookieSecure,
+			ExemptPrefixes: cfg.CSRFExemptPrefixes,
+		})
+		router.Use(csrf.Handler)
+	}
+	// Per-admin UI preferences (UI_PREFERENCES_ENABLED), loaded after authentication
+	ups, upsOK := repo.(domain.UIPreferenceStore)
+	if upsOK && cfg.UIPreferencesEnabled {
+		admin.SetUIPreferencesEnabled(true)
+		router.Use(admin.UIPreferencesMiddleware(ups))
+	}
 
 	router.HandleFunc("/", admin.HomeHandler(repo, eng)).Methods("GET")
-	router.HandleFunc("/analytics", admin.AnalyticsHandler(db, eng)).Methods("GET")
+	router.HandleFunc("/analytics", admin.AnalyticsHandler(db, eng, supers)).Methods("GET")
+	router.HandleFunc("/analytics/admins", admin.AdminActivityHandler(db)).Methods("GET")
+	router.HandleFunc("/audit", admin.AuditLogHandler(repo)).Methods("GET")
 
-	router.HandleFunc("/validate", app.validateHandler).Methods("POST")
-	router.HandleFunc("/validate/batch", app.validateBatchHandler).Methods("POST")
-	router.HandleFunc("/api/stats", admin.APIStatsHandler(db, eng)).Methods("GET")
+	router.Handle("/validate", bulkLimit.Wrap(http.HandlerFunc(app.validateHandler))).Methods("POST")
+	router.Handle("/validate/batch", bulkLimit.Wrap(http.HandlerFunc(app.validateBatchHandler))).Methods("POST")
+	router.Handle("/api/v1/validate/by-filter", bulkLimit.Wrap(http.HandlerFunc(app.validateByFilterHandler))).Methods("POST")
+	// Expected API calls and cost of a run, for confirming before it is started
+	router.HandleFunc("/api/v1/validate/estimate", app.estimateHandler).Methods("POST")
+	router.HandleFunc("/api/validate/sandbox", admin.SandboxResultsHandler(db)).Methods("GET")
+	router.HandleFunc("/api/stats", admin.APIStatsHandler(db, eng, ff)).Methods("GET")
 	// Feedback analytics
 	router.HandleFunc("/api/feedback/stats", admin.APIFeedbackStatsHandler(db)).Methods("GET")
+	// Per-reviewer activity report (JSON or ?format=csv)
+	router.HandleFunc("/api/analytics/admins", admin.APIAdminActivityHandler(db)).Methods("GET")
+	router.HandleFunc("/api/audit", admin.APIAuditLogHandler(repo)).Methods("GET")
+	// AI verdicts vs final admin decisions
+	router.HandleFunc("/api/v1/analytics/agreement", admin.APIAgreementHandler(db, eng)).Methods("GET")
+	// Decision rules: effective policy and dry-run of a candidate file
+	router.HandleFunc("/api/decision/rules", admin.DecisionRulesHandler(eng)).Methods("GET")
+	router.HandleFunc("/api/decision/rules/dry-run", admin.DecisionRulesDryRunHandler(db, eng)).Methods("POST")
 
 	router.HandleFunc("/venues/pending", admin.PendingVenuesHandler(db)).Methods("GET")
 	router.HandleFunc("/venues/manual-review", admin.ManualReviewHandler(db)).Methods("GET")
+	router.HandleFunc("/venues/map", admin.RequireFlag(ff, flags.VenueMap, admin.VenueMapHandler())).Methods("GET")
+	router.HandleFunc("/api/v1/venues/map", admin.RequireFlag(ff, flags.VenueMap, admin.APIVenueMapHandler(db))).Methods("GET")
+	// Saved list filters and default views (SAVED_FILTERS_ENABLED)
+	if fs, ok := repo.(domain.SavedFilterStore); ok && cfg.SavedFiltersEnabled {
+		router.HandleFunc("/saved-filters", admin.SaveFilterHandler(fs)).Methods("POST")
+		router.HandleFunc("/saved-filters/{id}/delete", admin.DeleteSavedFilterHandler(fs)).Methods("POST")
+		router.HandleFunc("/saved-filters/{id}/default", admin.DefaultSavedFilterHandler(fs)).Methods("POST")
+		router.HandleFunc("/api/v1/saved-filters", admin.APISavedFiltersHandler(fs)).Methods("GET")
+	}
+	if upsOK && cfg.UIPreferencesEnabled {
+		router.HandleFunc("/settings/preferences", admin.UIPreferencesHandler(ups)).Methods("GET")
+		router.HandleFunc("/settings/preferences", admin.SaveUIPreferencesFormHandler(ups)).Methods("POST")
+		router.HandleFunc("/api/v1/ui-preferences", admin.APIUIPreferencesHandler(ups)).Methods("GET")
+		router.HandleFunc("/api/v1/ui-preferences", admin.APISetUIPreferencesHandler(ups)).Methods("PUT")
+	}
+	// Full-text search (FULLTEXT_SEARCH_ENABLED); needs the FULLTEXT indexes in db_changes.md
+	if cfg.FullTextSearchEnabled {
+		admin.SetSearchEnabled(true)
+		router.HandleFunc("/search", admin.SearchHandler(db)).Methods("GET")
+		router.HandleFunc("/api/v1/search", admin.APISearchHandler(db)).Methods("GET")
+	}
+	// Closure review queue (CLOSURE_RECHECK_DAYS); registered before /venues/{id} like holds
+	if cs, ok := repo.(domain.ClosureStore); ok && cfg.ClosureRecheckDays > 0 {
+		admin.SetClosuresEnabled(true)
+		router.HandleFunc("/venues/closures", admin.ClosureReviewsHandler(cs)).Methods("GET")
+		router.HandleFunc("/api/v1/closure-reviews", admin.APIClosureReviewsHandler(cs)).Methods("GET")
+		router.HandleFunc("/api/v1/closure-reviews/{id}/resolve", admin.ResolveClosureReviewHandler(cs)).Methods("POST")
+	}
+	// Category suggestions (CATEGORY_SUGGESTIONS_ENABLED): approvals are recorded against them for evaluation
+	if cs, ok := repo.(domain.CategoryReviewStore); ok && cfg.CategorySuggestionsEnabled {
+		admin.SetCategoryReviewStore(cs)
+		router.HandleFunc("/api/v1/analytics/category-suggestions", admin.APICategoryReviewsHandler(cs)).Methods("GET")
+	}
+	// Change requests for approved venues (CHANGE_REQUESTS_ENABLED); the engine supplies the decision rules
+	if crs, ok := repo.(domain.ChangeRequestStore); ok && cfg.ChangeRequestsEnabled {
+		admin.SetChangeRequestsEnabled(true)
+		cr := scorer.NewChangeReviewer(cfg.OpenAIAPIKey, pm, cfg.ChangeRequestModel, cfg.OpenAITimeout)
+		router.HandleFunc("/change-requests", admin.ChangeRequestsHandler(crs)).Methods("GET")
+		router.HandleFunc("/api/v1/change-requests", admin.APIChangeRequestsHandler(crs)).Methods("GET")
+		router.HandleFunc("/api/v1/venues/{id}/change-requests", admin.SubmitChangeRequestHandler(repo, crs)).Methods("POST")
+		router.HandleFunc("/api/v1/change-requests/{id}/validate", admin.ValidateChangeRequestHandler(repo, crs, cr, eng)).Methods("POST")
+		router.HandleFunc("/api/v1/change-requests/{id}/apply", admin.ApplyChangeRequestHandler(repo, crs)).Methods("POST")
+		router.HandleFunc("/api/v1/change-requests/{id}/reject", admin.RejectChangeRequestHandler(repo, crs)).Methods("POST")
+	}
+
+	// Venue holds (VENUE_HOLDS_ENABLED); registered before /venues/{id} so "holds" is not read as an ID
+	if hs, ok := repo.(domain.HoldStore); ok && cfg.VenueHoldsEnabled {
+		router.HandleFunc("/venues/holds", admin.HoldsHandler(hs)).Methods("GET")
+		router.HandleFunc("/venues/{id}/hold", admin.PlaceHoldHandler(repo, hs)).Methods("POST")
+		router.HandleFunc("/venues/{id}/hold/release", admin.ReleaseHoldHandler(hs)).Methods("POST")
+		router.HandleFunc("/api/v1/holds", admin.APIHoldsHandler(hs)).Methods("GET")
+	}
+	// Side-by-side duplicate comparison; registered before /venues/{id}
+	router.HandleFunc("/venues/compare", admin.CompareVenuesHandler(repo)).Methods("GET")
+	router.HandleFunc("/venues/compare/merge", admin.MergeDuplicateHandler(repo, draftStore)).Methods("POST")
 	router.HandleFunc("/venues/{id}", admin.VenueDetailHandler(db, draftStore)).Methods("GET")
+	// Review claims (VENUE_CLAIMS_ENABLED)
+	if cs, ok := repo.(domain.ClaimStore); ok && cfg.VenueClaimsEnabled {
+		router.HandleFunc("/venues/{id}/claim", admin.ClaimVenueHandler(cs)).Methods("POST")
+		router.HandleFunc("/venues/{id}/unclaim", admin.UnclaimVenueHandler(cs)).Methods("POST")
+	}
 	router.HandleFunc("/venues/{id}/approve", admin.ApproveVenueHandler(repo, cfg, draftStore)).Methods("POST")
 	router.HandleFunc("/venues/{id}/reject", admin.RejectVenueHandler(repo, draftStore)).Methods("POST")
-	router.HandleFunc("/venues/{id}/validate", app.validateSingleHandler).Methods("POST")
+	router.HandleFunc("/venues/{id}/unapprove", admin.UnapproveVenueHandler(repo, cfg)).Methods("POST")
+	router.Handle("/venues/{id}/validate", singleLimit.Wrap(http.HandlerFunc(app.validateSingleHandler))).Methods("POST")
+	router.Handle("/venues/{id}/revalidate", singleLimit.Wrap(http.HandlerFunc(app.revalidateHandler))).Methods("POST")
+	// The same under /api/, where API tokens are accepted (the ava CLI uses it)
+	router.Handle("/api/v1/venues/{id}/validate", singleLimit.Wrap(http.HandlerFunc(app.validateSingleHandler))).Methods("POST")
+	router.HandleFunc("/api/venues/{id}/places", admin.PlaceSearchHandler(repo, gmaps)).Methods("GET")
+	router.Handle("/venues/{id}/google-place", singleLimit.Wrap(admin.RelinkPlaceHandler(repo, eng))).Methods("POST")
+	// What changed between two validations of a venue
+	router.HandleFunc("/venues/{id}/history/diff", admin.HistoryDiffHandler(repo)).Methods("GET")
+	router.HandleFunc("/api/v1/venues/{id}/history/diff", admin.APIHistoryDiffHandler(repo)).Methods("GET")
 	// Draft management endpoints
+	router.HandleFunc("/venues/{id}/diff", admin.VenueDiffHandler(db, draftStore)).Methods("GET")
 	router.HandleFunc("/venues/{id}/draft", admin.SaveVenueDraftHandler(draftStore, db)).Methods("POST")
 	router.HandleFunc("/venues/{id}/draft", admin.GetVenueDraftHandler(draftStore, db)).Methods("GET")
 	router.HandleFunc("/venues/{id}/draft", admin.ClearVenueDraftHandler(draftStore)).Methods("DELETE")
+	router.HandleFunc("/venues/{id}/draft/fields/{field}", admin.SetDraftFieldHandler(draftStore)).Methods("PUT")
+	router.HandleFunc("/venues/{id}/draft/fields/{field}", admin.DeleteDraftFieldHandler(draftStore)).Methods("DELETE")
+	router.HandleFunc("/api/v1/hours/parse", admin.ParseHoursHandler()).Methods("POST")
 	// Editor feedback submit/list
-	router.HandleFunc("/venues/{id}/feedback", admin.SubmitFeedbackHandler(db)).Methods("POST")
+	router.HandleFunc("/venues/{id}/feedback", admin.SubmitFeedbackHandler(db, privacy.IPPolicy{Mode: cfg.FeedbackIPMode, Key: ipHashKey})).Methods("POST")
 	router.HandleFunc("/venues/{id}/feedback", admin.VenueFeedbackHandler(db)).Methods("GET")
+	// Reviewer comment threads (VENUE_COMMENTS_ENABLED)
+	if cs, ok := repo.(domain.CommentStore); ok && cfg.VenueCommentsEnabled {
+		router.HandleFunc("/venues/{id}/comments", admin.SubmitCommentHandler(cs)).Methods("POST")
+		router.HandleFunc("/venues/{id}/comments", admin.VenueCommentsHandler(cs)).Methods("GET")
+	}
+
+	// Merged audit timeline (events, validations, audit logs, feedback)
+	router.HandleFunc("/api/v1/venues/{id}/timeline", admin.VenueTimelineHandler(db, proj)).Methods("GET")
+	// Event stream across venues, for `ava events -f`
+	router.HandleFunc("/api/v1/events", admin.EventsHandler(proj)).Methods("GET")
+	// Event replay: rebuild projections / re-send webhooks from a point in time
+	router.HandleFunc("/api/v1/events/replay", admin.EventReplayHandler(proj)).Methods("POST")
+	router.HandleFunc("/api/v1/events/replay/{id}", admin.EventReplayStatusHandler(proj)).Methods("GET")
+	// Replicas sharing the processing queue (PROCESSING_COORDINATION=db)
+	router.HandleFunc("/api/v1/processing/instances", admin.ProcessingInstancesHandler(eng)).Methods("GET")
+	router.HandleFunc("/api/v1/processing/batches", admin.ScoringBatchesHandler(eng)).Methods("GET")
+	// Circuit breakers: states and transitions; manual trip/reset during incidents
+	router.HandleFunc("/api/v1/circuits", admin.CircuitsHandler()).Methods("GET")
+	router.Handle("/api/v1/circuits/{name}/trip", supers.Wrap(admin.CircuitTripHandler())).Methods("POST")
+	router.Handle("/api/v1/circuits/{name}/reset", supers.Wrap(admin.CircuitResetHandler())).Methods("POST")
+	// Schema migrations of db_changes.md: status, and applying the pending ones (superadmins)
+	router.HandleFunc("/api/v1/migrations", admin.MigrationsHandler(db)).Methods("GET")
+	router.Handle("/api/v1/migrations/apply", supers.Wrap(admin.MigrateHandler(db))).Methods("POST")
+	// Data subject requests: export and pseudonymize a member's data (superadmins; tokens need the privacy scope)
+	if ps, ok := repo.(domain.PrivacyStore); ok {
+		router.Handle("/api/v1/members/{id}/data", supers.Wrap(admin.MemberDataExportHandler(ps))).Methods("GET")
+		router.Handle("/api/v1/members/{id}/erase", supers.Wrap(admin.MemberEraseHandler(ps, ipHashKey))).Methods("POST")
+	}
+	router.HandleFunc("/api/venues", admin.APIVenuesHandler(db)).Methods("GET")
+	router.HandleFunc("/api/history", admin.APIHistoryHandler(db)).Methods("GET")
+	router.HandleFunc("/api/history/archive", admin.HistoryArchiveHandler(historyArchiver)).Methods("POST")
+	router.HandleFunc("/api/history/archive", admin.HistoryArchiveStatusHandler(historyArchiver)).Methods("GET")
 
 	router.HandleFunc("/venues/batch-operation", admin.BatchOperationHandler(repo, cfg)).Methods("POST")
 	router.HandleFunc("/validation/history", admin.ValidationHistoryHandler(db)).Methods("GET")
+	// Batch processing runs and the results each one wrote
+	router.HandleFunc("/runs", admin.RunsHandler(repo, eng)).Methods("GET")
+	router.HandleFunc("/runs/{id:[0-9]+}", admin.RunDetailHandler(repo, eng)).Methods("GET")
 	router.HandleFunc("/editorial-feedback", admin.EditorialFeedbackListHandler(db)).Methods("GET")
+	router.HandleFunc("/api/v1/feedback", admin.APIEditorialFeedbackHandler(db)).Methods("GET")
+	router.HandleFunc("/api/v1/graphql", graphqlapi.Handler(repo, eng)).Methods("GET", "POST")
+	// OpenAPI spec of the JSON API and Swagger UI over it
+	router.HandleFunc("/api/openapi.json", openapi.Handler(cfg.BasePath)).Methods("GET")
+	router.HandleFunc("/api/docs", openapi.DocsHandler()).Methods("GET")
 
-	staticPath := cfg.BasePath + "static/"
-	router.PathPrefix(staticPath).Handler(http.StripPrefix(staticPath, http.FileServer(http.FS(Static()))))
-	server := &http.Server{Addr: ":" + cfg.Port, Handler: router}
+	router.HandleFunc("/settings/api-tokens", admin.APITokensHandler(db)).Methods("GET")
+	router.HandleFunc("/settings/api-tokens", admin.CreateAPITokenHandler(db)).Methods("POST")
+	router.HandleFunc("/settings/api-tokens/{id}/revoke", admin.RevokeAPITokenHandler(db)).Methods("POST")
+	// Feature flags, overriding capability settings at runtime (FEATURE_FLAGS_ENABLED)
+	if fs, ok := repo.(domain.FeatureFlagStore); ok && ff != nil {
+		router.HandleFunc("/settings/feature-flags", admin.FeatureFlagsHandler(ff)).Methods("GET")
+		router.HandleFunc("/settings/feature-flags/{name}", admin.UpdateFeatureFlagFormHandler(fs, ff)).Methods("POST")
+		router.HandleFunc("/api/v1/feature-flags", admin.APIFeatureFlagsHandler(ff)).Methods("GET")
+		router.HandleFunc("/api/v1/feature-flags/{name}", admin.APISetFeatureFlagHandler(fs, ff)).Methods("PUT")
+		router.HandleFunc("/api/v1/feature-flags/{name}", admin.APIDeleteFeatureFlagHandler(fs, ff)).Methods("DELETE")
+	}
+	// Runtime config, validated and recorded in config_changes (CONFIG_API_ENABLED)
+	if cs, ok := repo.(domain.ConfigChangeStore); ok && cfg.ConfigAPIEnabled {
+		router.HandleFunc("/settings/config", admin.ConfigPageHandler(cs, cw)).Methods("GET")
+		router.HandleFunc("/settings/config", admin.UpdateConfigFormHandler(cs, cw)).Methods("POST")
+		router.HandleFunc("/api/v1/config", admin.APIConfigHandler(cs, cw)).Methods("GET")
+		router.HandleFunc("/api/v1/config", admin.APIUpdateConfigHandler(cs, cw)).Methods("PUT")
+	}
+	// Submitter block/allow lists, enforced before any API call (SUBMITTER_RULES_ENABLED)
+	if sr, ok := repo.(domain.SubmitterRuleStore); ok && cfg.SubmitterRulesEnabled {
+		router.HandleFunc("/settings/submitter-rules", admin.SubmitterRulesHandler(sr)).Methods("GET")
+		router.HandleFunc("/settings/submitter-rules", admin.CreateSubmitterRuleHandler(sr, eng)).Methods("POST")
+		router.HandleFunc("/settings/submitter-rules/{id}/delete", admin.DeleteSubmitterRuleHandler(sr, eng)).Methods("POST")
+		router.HandleFunc("/api/v1/submitter-rules", admin.APISubmitterRulesHandler(sr)).Methods("GET")
+		router.HandleFunc("/api/v1/submitter-rules", admin.APICreateSubmitterRuleHandler(sr, eng)).Methods("POST")
+		router.HandleFunc("/api/v1/submitter-rules/{id}", admin.APIDeleteSubmitterRuleHandler(sr, eng)).Methods("DELETE")
+	}
+
+	router.PathPrefix("/static/").Handler(http.StripPrefix("/static/", http.FileServer(http.FS(Static()))))
+	// Under a BASE_PATH such as /brand/ (a path-prefix tenant) requests arrive with the prefix
+	var handler http.Handler = router
+	if prefix := strings.TrimSuffix(cfg.BasePath, "/"); prefix != "" {
+		stripped := http.StripPrefix(prefix, router)
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == prefix {
+				http.Redirect(w, r, cfg.BasePath, http.StatusMovedPermanently)
+				return
+			}
+			stripped.ServeHTTP(w, r)
+		})
+	}
+	server := &http.Server{Addr: net.JoinHostPort(cfg.HTTPBind, cfg.Port), Handler: handler}
 
 	var adminServer *http.Server
 	if cfg.ProfilingEnabled || cfg.MetricsEnabled {
@@ -244,7 +811,7 @@ func main() {
 				}, nil
 			}))
 		}
-		adminServer = &http.Server{Addr: ":" + cfg.ProfilingPort, Handler: mux}
+		adminServer = &http.Server{Addr: net.JoinHostPort(cfg.HTTPBind, cfg.ProfilingPort), Handler: mux}
 		go func() {
 			fmt.Printf("Admin server (pprof/metrics) starting on port %s\n", cfg.ProfilingPort)
 			if err := adminServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -257,6 +824,33 @@ func main() {
 	if cfg.AlertsEnabled && cfg.MetricsEnabled && metrics != nil {
 		go monitoring.StartRuntimeMonitor(ctx, cfg, metrics, func(format string, a ...any) { log.Printf(format, a...) })
 	}
+	if cfg.WatchdogEnabled {
+		wd := monitoring.NewWatchdog(monitoring.WatchdogConfig{
+			Interval:        cfg.WatchdogInterval,
+			GoroutineGrowth: cfg.WatchdogGoroutineGrowth,
+			AlertAfter:      cfg.WatchdogAlertAfter,
+		}, monitoring.WatchdogSources{
+			DB:     db.Conn().Stats,
+			Queues: eng.QueueStats,
+		}, func(format string, a ...any) { log.Printf(format, a...) })
+		go wd.Run(ctx)
+	}
+
+	// gRPC service for the platform backend, on its own port
+	var grpcServer *grpc.Server
+	if cfg.GRPCEnabled {
+		lis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+		if err != nil {
+			log.Fatal("gRPC listen:", err)
+		}
+		grpcServer = grpcapi.NewGRPCServer(grpcapi.NewServer(repo, eng, cfg, draftStore), tokens)
+		go func() {
+			fmt.Printf("gRPC server starting on port %s\n", cfg.GRPCPort)
+			if err := grpcServer.Serve(lis); err != nil {
+				log.Printf("gRPC server error: %v", err)
+			}
+		}()
+	}
 
 	go func() {
 		fmt.Printf("Server starting on port %s\n", cfg.Port)
@@ -277,6 +871,16 @@ func main() {
 			log.Printf("Admin HTTP server shutdown error: %v", err)
 		}
 	}
+	if grpcServer != nil {
+		// StreamProgress calls end with their run, so don't wait past the deadline for them
+		stopped := make(chan struct{})
+		go func() { grpcServer.GracefulStop(); close(stopped) }()
+		select {
+		case <-stopped:
+		case <-shutdownCtx.Done():
+			grpcServer.Stop()
+		}
+	}
 	log.Println("Application shutdown complete")
 }
 
@@ -286,10 +890,15 @@ type App struct {
 	scorer  *scorer.AIScorer
 	config  *config.Config
 	engine  *processor.ProcessingEngine
+	flags   *flags.Service
 }
 
 // validateHandler starts concurrent venue processing using the processing engine
 func (app *App) validateHandler(w http.ResponseWriter, r *http.Request) {
+	mode, ok := processingMode(w, r, "")
+	if !ok || !app.batchModeAvailable(w, r, mode) {
+		return
+	}
 	venuesWithUser, err := app.db.GetPendingVenuesWithUser()
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Failed to get pending venues: %v", err), http.StatusInternalServerError)
@@ -301,9 +910,14 @@ func (app *App) validateHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Filter out venues that already have at least one validation history (batch should skip those)
+	// Filter out venues that already have at least one validation history (batch should skip those).
+	// Dry runs keep them: re-scoring already validated venues is how prompts and thresholds get compared.
 	filtered := make([]models.VenueWithUser, 0, len(venuesWithUser))
 	for _, vw := range venuesWithUser {
+		if mode == processor.ModeDryRun {
+			filtered = append(filtered, vw)
+			continue
+		}
 		hasHist, err := app.db.HasAnyValidationHistory(vw.Venue.ID)
 		if err != nil {
 			log.Printf("Error checking validation history for venue %d: %v", vw.Venue.ID, err)
@@ -319,26 +933,39 @@ func (app *App) validateHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Printf("Starting processing of %d venues (filtered from %d)", len(filtered), len(venuesWithUser))
-	fmt.Fprintf(w, "Starting concurrent processing of %d venues...\n", len(filtered))
+	log.Printf("Starting %s processing of %d venues (filtered from %d)", mode, len(filtered), len(venuesWithUser))
+	fmt.Fprintf(w, "Starting concurrent processing of %d venues (%s)...\n", len(filtered), mode)
 
 	// Start processing engine if not already running
 	app.engine.Start()
 
-	// Ensure score-only mode for this run
-	app.engine.SetScoreOnly(true)
-
 	// Add venues to processing queue
-	if err := app.engine.ProcessVenuesWithUsers(filtered); err != nil {
+	run, err := app.engine.StartRun(r.Context(), filtered, mode, newRun(r, map[string]interface{}{"source": "pending"}))
+	if err != nil {
 		http.Error(w, fmt.Sprintf("Failed to queue venues for processing: %v", err), http.StatusInternalServerError)
 		return
 	}
 
 	fmt.Fprintf(w, "Successfully queued %d venues for processing\n", len(filtered))
+	if run.ID != 0 {
+		fmt.Fprintf(w, "Run ID: %d\n", run.ID)
+	}
 }
 
 // validateSingleHandler starts AVA review for a single venue synchronously
 func (app *App) validateSingleHandler(w http.ResponseWriter, r *http.Request) {
+	app.processSingleVenue(w, r, false)
+}
+
+// revalidateHandler handles POST /venues/{id}/revalidate?use_cache=true: like
+// validateSingleHandler, but with use_cache the venue's stored Google data is reused when it
+// is younger than GOOGLE_CACHE_MAX_AGE, so only AI scoring and the decision run again.
+func (app *App) revalidateHandler(w http.ResponseWriter, r *http.Request) {
+	useCache, _ := strconv.ParseBool(r.URL.Query().Get("use_cache"))
+	app.processSingleVenue(w, r, useCache)
+}
+
+func (app *App) processSingleVenue(w http.ResponseWriter, r *http.Request, useCache bool) {
 	vars := mux.Vars(r)
 	idStr, ok := vars["id"]
 	if !ok {
@@ -350,51 +977,136 @@ func (app *App) validateSingleHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "invalid venue id", http.StatusBadRequest)
 		return
 	}
+	mode, ok := processingMode(w, r, "")
+	if !ok {
+		return
+	}
+	if mode == processor.ModeBatch {
+		http.Error(w, "batch mode applies to runs (POST /validate, /validate/batch), not single venues", http.StatusBadRequest)
+		return
+	}
 
 	venueWithUser, err := app.db.GetVenueWithUserByID(id)
-	if err != nil || venueWithUser == nil {
-		http.Error(w, fmt.Sprintf("Venue not found: %v", err), http.StatusNotFound)
+	if err != nil {
+		admin.WriteError(w, r, "Failed to load venue", err)
+		return
+	}
+
+	googleCache := ""
+	if useCache {
+		googleCache = "miss"
+		cached, err := app.db.GetCachedGooglePlaceDataCtx(r.Context(), id)
+		switch {
+		case err != nil:
+			log.Printf("Error loading cached Google data for venue %d: %v", id, err)
+		case cached == nil:
+		case time.Since(cached.FetchedAt) > app.config.GoogleCacheMaxAge:
+			googleCache = "stale"
+		default:
+			googleCache = "hit"
+			venueWithUser.Venue.GoogleData = cached
+		}
+	}
+
+	timeout, ok := syncValidateTimeout(w, r, app.config.SyncValidateTimeout)
+	if !ok {
 		return
 	}
 
 	// Start processing engine if not already running
 	app.engine.Start()
-	// Ensure score-only mode for this run
-	app.engine.SetScoreOnly(true)
 
-	// Create a context with 2-minute timeout for processing
-	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Minute)
+	// The engine splits the timeout between the review stages and leaves out the ones that
+	// would not fit
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
 	defer cancel()
 
+	// With Accept: text/event-stream the AI reply is streamed while it is written, then the
+	// response below follows as a "result" or "error" event
+	var events *admin.EventStream
+	if admin.WantsEventStream(r) {
+		if events, err = admin.NewEventStream(w); err != nil {
+			log.Printf("Venue %d: %v; replying with JSON", id, err)
+		} else {
+			ctx = scorer.WithStream(ctx, scoreStream{events})
+		}
+	}
+
 	// Process the venue synchronously (not using job queue)
-	result, err := app.engine.ProcessSingleVenueSync(ctx, *venueWithUser)
+	result, err := app.engine.ProcessSingleVenueSync(ctx, *venueWithUser, mode)
+	status, response := singleVenueResponse(r, id, mode, googleCache, result, err)
+	if events != nil {
+		event := "result"
+		if status != http.StatusOK {
+			event = "error"
+		}
+		events.Send(event, response)
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
+
+// syncValidateTimeout is the time a single-venue review may take: ?timeout= (a duration
+// such as 30s) when given, at most limit (SYNC_VALIDATE_TIMEOUT).
+func syncValidateTimeout(w http.ResponseWriter, r *http.Request, limit time.Duration) (time.Duration, bool) {
+	if limit <= 0 {
+		limit = 2 * time.Minute
+	}
+	raw := r.URL.Query().Get("timeout")
+	if raw == "" {
+		return limit, true
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		http.Error(w, "invalid timeout: want a positive duration such as 30s", http.StatusBadRequest)
+		return 0, false
+	}
+	return min(d, limit), true
+}
+
+// scoreStream relays the AI scoring reply of a single-venue review as "attempt" and "delta"
+// events.
+type scoreStream struct{ events *admin.EventStream }
+
+func (s scoreStream) Begin()            { s.events.Send("attempt", map[string]interface{}{}) }
+func (s scoreStream) Delta(text string) { s.events.Send("delta", map[string]string{"text": text}) }
 
+// singleVenueResponse is the status and body of a single-venue review.
+func singleVenueResponse(r *http.Request, id int64, mode processor.Mode, googleCache string, result *processor.ProcessingResult, err error) (int, map[string]interface{}) {
 	if err != nil {
-		log.Printf("Error processing venue %d: %v", id, err)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"status":    "error",
-			"message":   fmt.Sprintf("Failed to process venue: %v", err),
-			"venueId":   id,
-			"completed": false,
-		})
-		return
+		status, body := admin.ErrorResponse(r, "Failed to process venue", err)
+		body["venueId"] = id
+		body["completed"] = false
+		return status, body
 	}
 
 	if !result.Success {
-		errorMsg := "Processing failed"
-		if result.Error != nil {
-			errorMsg = result.Error.Error()
+		log.Printf("Processing failed for venue %d: %v", id, result.Error)
+		status, body := admin.ErrorResponse(r, "Processing failed", result.Error)
+		body["venueId"] = id
+		body["completed"] = false
+		if result.Progress != nil {
+			body["progress"] = result.Progress
 		}
-		log.Printf("Processing failed for venue %d: %s", id, errorMsg)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"status":    "error",
-			"message":   errorMsg,
-			"venueId":   id,
-			"completed": false,
-		})
-		return
+		return status, body
+	}
+
+	// With the database write left out to meet the timeout nothing was saved: the review is
+	// a 504 carrying the unsaved result, so neither the page nor a script takes it as stored
+	saved := !slices.Contains(result.Skipped, processor.StageDB)
+	if !saved && mode != processor.ModeDryRun {
+		status, response := admin.ErrorResponse(r, "Review finished but could not be saved in time; nothing was stored",
+			fmt.Errorf("database write skipped: %w", processor.ErrDeadlineBudget))
+		response["venueId"] = id
+		response["completed"] = false
+		response["saved"] = false
+		response["mode"] = mode
+		response["skipped"] = result.Skipped
+		response["result"] = result.ValidationResult
+		return status, response
 	}
 
 	// Success - return detailed result
@@ -403,6 +1115,19 @@ func (app *App) validateSingleHandler(w http.ResponseWriter, r *http.Request) {
 		"message":   "AVA Review completed successfully",
 		"venueId":   id,
 		"completed": true,
+		"saved":     saved,
+		"mode":      mode,
+	}
+	if googleCache != "" {
+		response["googleCache"] = googleCache
+	}
+	if mode == processor.ModeDryRun {
+		// saved is whether the sandbox copy was written
+		response["result"] = result.ValidationResult
+	}
+	if len(result.Skipped) > 0 {
+		// Stages left out to meet the timeout
+		response["skipped"] = result.Skipped
 	}
 
 	if result.ValidationResult != nil {
@@ -415,8 +1140,7 @@ func (app *App) validateSingleHandler(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-
-	json.NewEncoder(w).Encode(response)
+	return http.StatusOK, response
 }
 
 // validateBatchHandler starts AVA review for selected venues
@@ -424,12 +1148,17 @@ func (app *App) validateBatchHandler(w http.ResponseWriter, r *http.Request) {
 	type reqBody struct {
 		VenueIDs []int64 `json:"venue_ids"`
 		Force    bool    `json:"force"`
+		Mode     string  `json:"mode"`
 	}
 	var body reqBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		http.Error(w, "invalid JSON body", http.StatusBadRequest)
 		return
 	}
+	mode, ok := processingMode(w, r, body.Mode)
+	if !ok || !app.batchModeAvailable(w, r, mode) {
+		return
+	}
 	if len(body.VenueIDs) == 0 {
 		http.Error(w, "no venue_ids provided", http.StatusBadRequest)
 		return
@@ -466,14 +1195,358 @@ func (app *App) validateBatchHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	app.engine.Start()
-	app.engine.SetScoreOnly(true)
-	if err := app.engine.ProcessVenuesWithUsers(queue); err != nil {
+	filters := map[string]interface{}{"source": "venue_ids", "venue_ids": body.VenueIDs, "force": body.Force}
+	run, err := app.engine.StartRun(r.Context(), queue, mode, newRun(r, filters))
+	if err != nil {
 		http.Error(w, fmt.Sprintf("Failed to queue venues: %v", err), http.StatusInternalServerError)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
+	resp := map[string]interface{}{
 		"status": "queued",
 		"queued": len(queue),
+		"mode":   mode,
+	}
+	if run.ID != 0 {
+		resp["run_id"] = run.ID
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+const (
+	defaultFilterRunLimit = 1000
+	maxFilterRunLimit     = 5000
+)
+
+// validateByFilterHandler handles POST /api/v1/validate/by-filter
+// Body: {"path_prefix": "", "category": null, "min_score": 0, "max_score": 0,
+// "submitted_after": "2024-01-01", "prompt_version": "", "failed": false, "mode": "",
+// "limit": 1000}. Queues the pending venues matching the filters, oldest first, whether or
+// not they have validation history: requeuing a slice scored under an old prompt, or the
+// dead letters whose latest validation failed, is the point. At least one filter is
+// required; POST /validate covers the whole queue.
+func (app *App) validateByFilterHandler(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		PathPrefix     string `json:"path_prefix"`
+		Category       *int   `json:"category"`
+		MinScore       int    `json:"min_score"`
+		MaxScore       int    `json:"max_score"`
+		SubmittedAfter string `json:"submitted_after"`
+		PromptVersion  string `json:"prompt_version"`
+		Failed         bool   `json:"failed"`
+		Mode           string `json:"mode"`
+		Limit          int    `json:"limit"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	mode, ok := processingMode(w, r, body.Mode)
+	if !ok || !app.batchModeAvailable(w, r, mode) {
+		return
+	}
+
+	f := models.PendingVenueFilter{
+		PathPrefix:    strings.TrimSpace(body.PathPrefix),
+		Category:      body.Category,
+		MinScore:      body.MinScore,
+		MaxScore:      body.MaxScore,
+		PromptVersion: strings.TrimSpace(body.PromptVersion),
+		Failed:        body.Failed,
+	}
+	if f.MinScore < 0 || f.MinScore > 100 || f.MaxScore < 0 || f.MaxScore > 100 || (f.MaxScore > 0 && f.MinScore > f.MaxScore) {
+		http.Error(w, "min_score and max_score must be between 0 and 100, min_score not above max_score", http.StatusBadRequest)
+		return
+	}
+	if s := strings.TrimSpace(body.SubmittedAfter); s != "" {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t, err = time.ParseInLocation("2006-01-02", s, time.Local)
+		}
+		if err != nil {
+			http.Error(w, "submitted_after must be a date (2006-01-02) or an RFC 3339 time", http.StatusBadRequest)
+			return
+		}
+		f.SubmittedAfter = t
+	}
+	if f.IsEmpty() {
+		http.Error(w, "at least one filter is required; use POST /validate for the whole pending queue", http.StatusBadRequest)
+		return
+	}
+	limit := defaultFilterRunLimit
+	if body.Limit != 0 {
+		if body.Limit < 0 || body.Limit > maxFilterRunLimit {
+			http.Error(w, fmt.Sprintf("limit must be between 1 and %d", maxFilterRunLimit), http.StatusBadRequest)
+			return
+		}
+		limit = body.Limit
+	}
+
+	// One extra row tells whether more venues match than were queued
+	ids, err := app.db.GetPendingVenueIDsByFilterCtx(r.Context(), f, limit+1)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to select venues: %v", err), http.StatusInternalServerError)
+		return
+	}
+	truncated := len(ids) > limit
+	if truncated {
+		ids = ids[:limit]
+	}
+
+	var queue []models.VenueWithUser
+	for _, id := range ids {
+		venueWithUser, err := app.db.GetVenueWithUserByID(id)
+		if err != nil || venueWithUser == nil {
+			continue
+		}
+		queue = append(queue, *venueWithUser)
+	}
+
+	if len(queue) == 0 {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": "skipped",
+			"queued": 0,
+			"reason": "no pending venues match the filters",
+		})
+		return
+	}
+
+	filters := map[string]interface{}{"source": "filter"}
+	for key, value := range map[string]interface{}{
+		"path_prefix":     f.PathPrefix,
+		"submitted_after": strings.TrimSpace(body.SubmittedAfter),
+		"prompt_version":  f.PromptVersion,
+		"min_score":       f.MinScore,
+		"max_score":       f.MaxScore,
+	} {
+		if value != "" && value != 0 {
+			filters[key] = value
+		}
+	}
+	if f.Category != nil {
+		filters["category"] = *f.Category
+	}
+	if f.Failed {
+		filters["failed"] = true
+	}
+
+	app.engine.Start()
+	run, err := app.engine.StartRun(r.Context(), queue, mode, newRun(r, filters))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to queue venues: %v", err), http.StatusInternalServerError)
+		return
+	}
+	log.Printf("Queued %d venues by filter %v (%s)", len(queue), filters, mode)
+	resp := map[string]interface{}{
+		"status":    "queued",
+		"queued":    len(queue),
+		"mode":      mode,
+		"truncated": truncated,
+	}
+	if run.ID != 0 {
+		resp["run_id"] = run.ID
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// estimateHandler handles POST /api/v1/validate/estimate
+// Body: {"venue_ids": [...], "force": bool, "mode": ""}. Without venue_ids it estimates the
+// pending queue that POST /validate would process. Venues are selected exactly as the run
+// would select them; nothing is queued and no API calls are made.
+func (app *App) estimateHandler(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		VenueIDs []int64 `json:"venue_ids"`
+		Force    bool    `json:"force"`
+		Mode     string  `json:"mode"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, "invalid JSON body", http.StatusBadRequest)
+			return
+		}
+	}
+	mode, ok := processingMode(w, r, body.Mode)
+	if !ok {
+		return
+	}
+
+	var candidates []models.VenueWithUser
+	source := "venue_ids"
+	if len(body.VenueIDs) == 0 {
+		source = "pending"
+		pending, err := app.db.GetPendingVenuesWithUser()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to get pending venues: %v", err), http.StatusInternalServerError)
+			return
+		}
+		candidates = pending
+	} else {
+		for _, id := range body.VenueIDs {
+			venueWithUser, err := app.db.GetVenueWithUserByID(id)
+			if err != nil || venueWithUser == nil {
+				continue
+			}
+			candidates = append(candidates, *venueWithUser)
+		}
+	}
+
+	// Same history rule as the run: batch runs skip venues already validated unless forced,
+	// dry runs keep them
+	var queue []models.VenueWithUser
+	skipped := 0
+	for _, vw := range candidates {
+		if !body.Force && mode != processor.ModeDryRun {
+			hasHist, err := app.db.HasAnyValidationHistory(vw.Venue.ID)
+			if err != nil {
+				log.Printf("error checking validation history for %d: %v", vw.Venue.ID, err)
+			}
+			if hasHist {
+				skipped++
+				continue
+			}
+		}
+		queue = append(queue, vw)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"source":               source,
+		"mode":                 mode,
+		"skipped_with_history": skipped,
+		"estimate":             app.engine.EstimateRun(r.Context(), queue),
 	})
 }
+
+// newRun describes a batch run started by this request; filters records how its venues
+// were selected.
+func newRun(r *http.Request, filters map[string]interface{}) models.ProcessingRun {
+	run := models.ProcessingRun{Filters: "{}"}
+	if adminID, ok := auth.GetAdminIDFromContext(r.Context()); ok {
+		run.TriggeredBy = &adminID
+	}
+	if b, err := json.Marshal(filters); err == nil {
+		run.Filters = string(b)
+	}
+	return run
+}
+
+// processingMode picks the run mode from the JSON body value, else the ?mode= query parameter;
+// ?dry_run=true is shorthand for mode=dry_run. Unset means processor.DefaultMode (score_only);
+// an unknown mode is a 400.
+func processingMode(w http.ResponseWriter, r *http.Request, fromBody string) (processor.Mode, bool) {
+	raw := fromBody
+	if raw == "" {
+		raw = r.URL.Query().Get("mode")
+	}
+	if dry, _ := strconv.ParseBool(r.URL.Query().Get("dry_run")); dry {
+		raw = string(processor.ModeDryRun)
+	}
+	mode, err := processor.ParseMode(raw)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return "", false
+	}
+	return mode, true
+}
+
+// batchModeAvailable rejects mode=batch with a 400 unless this instance submits batch jobs
+// and the batch_mode flag is not off for the admin.
+func (app *App) batchModeAvailable(w http.ResponseWriter, r *http.Request, mode processor.Mode) bool {
+	if mode != processor.ModeBatch {
+		return true
+	}
+	if !app.engine.BatchScoringEnabled() {
+		http.Error(w, "batch mode needs BATCH_SCORING_ENABLED=true on this instance", http.StatusBadRequest)
+		return false
+	}
+	adminID, _ := auth.GetAdminIDFromContext(r.Context())
+	if !app.flags.Enabled(flags.BatchMode, int64(adminID), true) {
+		http.Error(w, "batch mode is turned off by the batch_mode feature flag", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// retryPolicy maps env config onto the processor's per-error-class retry budgets.
+func distributedConfig(cfg *config.Config) processor.DistributedConfig {
+	host, _ := os.Hostname()
+	id := cfg.InstanceID
+	if id == "" {
+		id = fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+	return processor.DistributedConfig{
+		InstanceID:        id,
+		Hostname:          host,
+		HeartbeatInterval: cfg.InstanceHeartbeatInterval,
+		StaleAfter:        cfg.InstanceStaleAfter,
+		PollInterval:      cfg.QueuePollInterval,
+		MaxAttempts:       cfg.QueueMaxAttempts,
+	}
+}
+
+func retryPolicy(cfg *config.Config) processor.RetryPolicy {
+	return processor.RetryPolicy{
+		BaseDelay: cfg.RetryBaseDelay,
+		MaxDelay:  cfg.RetryMaxDelay,
+		Budgets: map[errs.Class]int{
+			errs.ClassRateLimit: cfg.RetryBudgetRateLimit,
+			errs.ClassTimeout:   cfg.RetryBudgetTimeout,
+			errs.ClassServer:    cfg.RetryBudgetServer,
+			errs.ClassNetwork:   cfg.RetryBudgetNetwork,
+		},
+	}
+}
+
+// prefilterConfig maps env config onto the processor's auto-reject rules.
+func prefilterConfig(cfg *config.Config) processor.PrefilterConfig {
+	return processor.PrefilterConfig{
+		EmptyName:           cfg.PrefilterEmptyName,
+		URLOnlyName:         cfg.PrefilterURLName,
+		BlockedDomains:      cfg.PrefilterBlockedDomains,
+		BlockedDomainList:   cfg.PrefilterBlockedDomainList,
+		Profanity:           cfg.PrefilterProfanity,
+		ProfanityWords:      cfg.PrefilterProfanityWords,
+		DuplicateSubmission: cfg.PrefilterDuplicateSubmission,
+		DuplicateWindowDays: cfg.PrefilterDuplicateDays,
+	}
+}
+
+// runTenantGateway serves the tenants of TENANTS_FILE on PORT, each from its own app process
+// on local ports from TENANT_PORT_BASE, until SIGINT or SIGTERM.
+func runTenantGateway(cfg *config.Config) {
+	tenants, err := tenant.LoadFile(cfg.TenantsFile)
+	if err != nil {
+		log.Fatal("TENANTS_FILE: ", err)
+	}
+	trusted, err := tenant.ParseTrustedProxies(cfg.TenantTrustedProxies)
+	if err != nil {
+		log.Fatal("TENANT_TRUSTED_PROXIES: ", err)
+	}
+	gw := tenant.NewGateway(tenants, tenant.GatewayConfig{PortBase: cfg.TenantPortBase, TrustedProxies: trusted})
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	server := &http.Server{Addr: net.JoinHostPort(cfg.HTTPBind, cfg.Port), Handler: gw}
+	go func() {
+		fmt.Printf("Tenant gateway for %d tenants starting on port %s\n", len(tenants), cfg.Port)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal("HTTP server error:", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("HTTP server shutdown error: %v", err)
+		}
+	}()
+
+	// Returns once every tenant process has shut down
+	if err := gw.Run(ctx); err != nil {
+		log.Fatal(err)
+	}
+	log.Println("Tenant gateway shutdown complete")
+}
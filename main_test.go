@@ -0,0 +1,57 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"assisted-venue-approval/internal/models"
+	"assisted-venue-approval/internal/processor"
+)
+
+func TestProcessingMode(t *testing.T) {
+	tests := []struct {
+		query    string
+		fromBody string
+		want     processor.Mode
+		status   int
+	}{
+		{"", "", processor.DefaultMode, http.StatusOK},
+		{"?mode=auto_decide", "", processor.ModeAutoDecide, http.StatusOK},
+		{"?mode=auto_decide", "score_only", processor.ModeScoreOnly, http.StatusOK},
+		{"?dry_run=true", "", processor.ModeDryRun, http.StatusOK},
+		{"?dry_run=1&mode=auto_decide", "auto_decide", processor.ModeDryRun, http.StatusOK},
+		{"?dry_run=false&mode=auto_decide", "", processor.ModeAutoDecide, http.StatusOK},
+		{"?mode=approve_all", "", "", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		mode, ok := processingMode(rec, httptest.NewRequest("POST", "/validate"+tt.query, nil), tt.fromBody)
+		if mode != tt.want || ok != (tt.status == http.StatusOK) || rec.Code != tt.status {
+			t.Errorf("%s body %q: mode %q ok %v status %d, want %q status %d", tt.query, tt.fromBody, mode, ok, rec.Code, tt.want, tt.status)
+		}
+	}
+}
+
+func TestSingleVenueResponse_UnsavedReview(t *testing.T) {
+	r := httptest.NewRequest("POST", "/venues/7/validate", nil)
+	vr := &models.ValidationResult{VenueID: 7, Score: 80, Status: "approved"}
+	unsaved := &processor.ProcessingResult{VenueID: 7, Success: true, ValidationResult: vr, Skipped: []string{processor.StageQuality, processor.StageDB}}
+
+	status, body := singleVenueResponse(r, 7, processor.ModeAutoDecide, "", unsaved, nil)
+	if status != http.StatusGatewayTimeout || body["completed"] != false || body["saved"] != false || body["result"] != vr {
+		t.Errorf("db skipped: status %d, body %v", status, body)
+	}
+
+	// A dry run saves nothing outside the sandbox anyway and still returns its result
+	status, body = singleVenueResponse(r, 7, processor.ModeDryRun, "", unsaved, nil)
+	if status != http.StatusOK || body["completed"] != true || body["saved"] != false {
+		t.Errorf("dry run with db skipped: status %d, body %v", status, body)
+	}
+
+	saved := &processor.ProcessingResult{VenueID: 7, Success: true, ValidationResult: vr, Skipped: []string{processor.StageQuality}}
+	status, body = singleVenueResponse(r, 7, processor.ModeAutoDecide, "", saved, nil)
+	if status != http.StatusOK || body["completed"] != true || body["saved"] != true {
+		t.Errorf("quality skipped: status %d, body %v", status, body)
+	}
+}
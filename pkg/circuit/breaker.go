@@ -3,9 +3,11 @@ package circuit
 import (
 	"context"
 	"errors"
+	"sort"
 	"sync"
 	"time"
 
+	errs "assisted-venue-approval/pkg/errors"
 	"assisted-venue-approval/pkg/logging"
 	"assisted-venue-approval/pkg/metrics"
 )
@@ -21,6 +23,32 @@ const (
 	HalfOpen
 )
 
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half_open"
+	}
+	return "unknown"
+}
+
+// MarshalText makes states readable in JSON.
+func (s State) MarshalText() ([]byte, error) { return []byte(s.String()), nil }
+
+// maxTransitions is how many state changes a breaker remembers for Status.
+const maxTransitions = 50
+
+// Transition is one state change of a breaker.
+type Transition struct {
+	From   State     `json:"from"`
+	To     State     `json:"to"`
+	At     time.Time `json:"at"`
+	Reason string    `json:"reason"`
+}
+
 // Config tunes a circuit breaker instance.
 type Config struct {
 	Name string
@@ -35,8 +63,9 @@ type Config struct {
 	HalfOpenMaxInFlight int           // usually 1
 }
 
-// ErrOpen indicates the breaker is open and calls are short-circuited.
-var ErrOpen = errors.New("circuit open")
+// ErrOpen indicates the breaker is open and calls are short-circuited. It is an external
+// error so API responses report the dependency, not the server, as failing.
+var ErrOpen = errs.NewExternal("circuit.Do", "", "circuit open", nil)
 
 // result in the ring buffer
 type sample struct {
@@ -56,6 +85,9 @@ type Breaker struct {
 	idx  int
 	used int
 
+	tripped     bool         // opened by Trip; stays open until Reset
+	transitions []Transition // oldest first, at most maxTransitions
+
 	log *logging.Logger
 	// metrics
 	mState    *metrics.Gauge
@@ -91,17 +123,26 @@ func New(cfg Config, log *logging.Logger) *Breaker {
 		mLatency:   metrics.Default.Histogram("cb_"+cfg.Name+"_latency_ms", "Latency of calls (ms)", []float64{10, 25, 50, 100, 200, 500, 1000, 2000, 5000}),
 	}
 	b.mState.SetFloat64(0)
+	register(b)
 	return b
 }
 
+// Name returns the configured breaker name.
+func (b *Breaker) Name() string { return b.cfg.Name }
+
 func (b *Breaker) stateLocked() State { return b.st }
 
-func (b *Breaker) setStateLocked(st State) {
+func (b *Breaker) setStateLocked(st State, reason string) {
 	if b.st == st {
 		return
 	}
+	now := time.Now()
+	if len(b.transitions) == maxTransitions {
+		b.transitions = append(b.transitions[:0], b.transitions[1:]...)
+	}
+	b.transitions = append(b.transitions, Transition{From: b.st, To: st, At: now, Reason: reason})
 	b.st = st
-	b.lastChange = time.Now()
+	b.lastChange = now
 	switch st {
 	case Open:
 		b.mOpen.Inc(1)
@@ -113,7 +154,7 @@ func (b *Breaker) setStateLocked(st State) {
 		b.mState.SetFloat64(0)
 	}
 	if b.log != nil {
-		b.log.WithComponent("circuit").Info("breaker state change", logging.String("name", b.cfg.Name), logging.Int("state", int(st)))
+		b.log.WithComponent("circuit").Info("breaker state change", logging.String("name", b.cfg.Name), logging.Int("state", int(st)), logging.String("reason", reason))
 	}
 }
 
@@ -145,17 +186,17 @@ func (b *Breaker) record(success bool, slow bool) {
 
 	if b.stateLocked() == Closed {
 		if b.cfg.MaxConsecFailures > 0 && b.consecFail >= b.cfg.MaxConsecFailures {
-			b.setStateLocked(Open)
+			b.setStateLocked(Open, "consecutive failures")
 			b.nextProbe = time.Now().Add(b.cfg.OpenFor)
 			return
 		}
 		if b.cfg.FailureRate > 0 && failRate >= b.cfg.FailureRate {
-			b.setStateLocked(Open)
+			b.setStateLocked(Open, "failure rate")
 			b.nextProbe = time.Now().Add(b.cfg.OpenFor)
 			return
 		}
 		if b.cfg.SlowCallRate > 0 && slowRate >= b.cfg.SlowCallRate {
-			b.setStateLocked(Open)
+			b.setStateLocked(Open, "slow call rate")
 			b.nextProbe = time.Now().Add(b.cfg.OpenFor)
 			return
 		}
@@ -169,7 +210,7 @@ func (b *Breaker) Do(ctx context.Context, op func(ctx context.Context) error, fa
 	b.mu.Lock()
 	st := b.stateLocked()
 	if st == Open {
-		if time.Now().Before(b.nextProbe) {
+		if b.tripped || time.Now().Before(b.nextProbe) {
 			b.mu.Unlock()
 			if fallback != nil {
 				return fallback(ctx, ErrOpen)
@@ -177,7 +218,7 @@ func (b *Breaker) Do(ctx context.Context, op func(ctx context.Context) error, fa
 			return ErrOpen
 		}
 		// move to half-open for a probe
-		b.setStateLocked(HalfOpen)
+		b.setStateLocked(HalfOpen, "probe")
 	}
 	b.mu.Unlock()
 
@@ -213,7 +254,7 @@ func (b *Breaker) Do(ctx context.Context, op func(ctx context.Context) error, fa
 		b.record(false, slow)
 		if b.stateLocked() == HalfOpen {
 			// probe failed -> open
-			b.setStateLocked(Open)
+			b.setStateLocked(Open, "probe failed")
 			b.nextProbe = time.Now().Add(b.cfg.OpenFor)
 		}
 		if fallback != nil {
@@ -226,8 +267,102 @@ func (b *Breaker) Do(ctx context.Context, op func(ctx context.Context) error, fa
 	b.consecFail = 0
 	b.mSuccess.Inc(1)
 	b.record(true, slow)
-	if b.stateLocked() == HalfOpen {
-		b.setStateLocked(Closed)
+	if b.stateLocked() == HalfOpen && !b.tripped {
+		b.setStateLocked(Closed, "probe succeeded")
 	}
 	return nil
 }
+
+// Trip opens the breaker until Reset, e.g. while a provider has an incident. Calls fail
+// fast with ErrOpen (or go to their fallback) and no probes are sent.
+func (b *Breaker) Trip(reason string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tripped = true
+	b.setStateLocked(Open, reason)
+}
+
+// Reset closes the breaker and forgets recent calls, so it starts over as if new.
+// It also ends a manual Trip.
+func (b *Breaker) Reset(reason string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tripped = false
+	b.consecFail = 0
+	b.idx, b.used = 0, 0
+	b.setStateLocked(Closed, reason)
+}
+
+// Status is a point-in-time view of a breaker.
+type Status struct {
+	Name                string       `json:"name"`
+	State               State        `json:"state"`
+	Since               time.Time    `json:"since"`
+	Tripped             bool         `json:"tripped"`              // opened manually, held until reset
+	NextProbe           *time.Time   `json:"next_probe,omitempty"` // when an open breaker lets a probe through
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	WindowCalls         int          `json:"window_calls"`
+	WindowFailures      int          `json:"window_failures"`
+	Transitions         []Transition `json:"transitions"` // newest first
+}
+
+// Status returns the breaker's current state and its recent transitions.
+func (b *Breaker) Status() Status {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	st := Status{
+		Name:                b.cfg.Name,
+		State:               b.st,
+		Since:               b.lastChange,
+		Tripped:             b.tripped,
+		ConsecutiveFailures: b.consecFail,
+		WindowCalls:         b.used,
+		Transitions:         make([]Transition, 0, len(b.transitions)),
+	}
+	if b.st == Open && !b.tripped {
+		next := b.nextProbe
+		st.NextProbe = &next
+	}
+	for i := 0; i < b.used; i++ {
+		if !b.win[i].success {
+			st.WindowFailures++
+		}
+	}
+	for i := len(b.transitions) - 1; i >= 0; i-- {
+		st.Transitions = append(st.Transitions, b.transitions[i])
+	}
+	return st
+}
+
+// Breakers are registered by name as they are created, so operators can inspect and
+// override them without threading every instance through to the HTTP layer. A later
+// breaker with the same name replaces the earlier one.
+var registry = struct {
+	sync.RWMutex
+	byName map[string]*Breaker
+}{byName: map[string]*Breaker{}}
+
+func register(b *Breaker) {
+	registry.Lock()
+	registry.byName[b.cfg.Name] = b
+	registry.Unlock()
+}
+
+// Lookup returns the registered breaker called name, or nil.
+func Lookup(name string) *Breaker {
+	registry.RLock()
+	defer registry.RUnlock()
+	return registry.byName[name]
+}
+
+// All returns every registered breaker, sorted by name.
+func All() []*Breaker {
+	registry.RLock()
+	out := make([]*Breaker, 0, len(registry.byName))
+	for _, b := range registry.byName {
+		out = append(out, b)
+	}
+	registry.RUnlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].cfg.Name < out[j].cfg.Name })
+	return out
+}
@@ -0,0 +1,95 @@
+package circuit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBreaker_TripHoldsOpenUntilReset(t *testing.T) {
+	b := New(Config{Name: "test_trip", OpenFor: time.Nanosecond, MaxConsecFailures: 1}, nil)
+	b.Trip("incident")
+
+	called := false
+	op := func(context.Context) error { called = true; return nil }
+	time.Sleep(time.Millisecond) // past OpenFor: an automatic open would probe now
+	if err := b.Do(context.Background(), op, nil); !errors.Is(err, ErrOpen) {
+		t.Fatalf("Do while tripped = %v, want ErrOpen", err)
+	}
+	if called {
+		t.Fatal("tripped breaker let a call through")
+	}
+	if st := b.Status(); st.State != Open || !st.Tripped || st.NextProbe != nil {
+		t.Fatalf("status while tripped = %+v", st)
+	}
+
+	b.Reset("recovered")
+	if err := b.Do(context.Background(), op, nil); err != nil || !called {
+		t.Fatalf("Do after reset = %v (called %v)", err, called)
+	}
+	st := b.Status()
+	if st.State != Closed || st.Tripped {
+		t.Fatalf("status after reset = %+v", st)
+	}
+	want := []Transition{{From: Open, To: Closed, Reason: "recovered"}, {From: Closed, To: Open, Reason: "incident"}}
+	if len(st.Transitions) != len(want) {
+		t.Fatalf("transitions = %+v", st.Transitions)
+	}
+	for i, tr := range st.Transitions {
+		if tr.From != want[i].From || tr.To != want[i].To || tr.Reason != want[i].Reason {
+			t.Errorf("transition %d = %+v, want %+v", i, tr, want[i])
+		}
+	}
+}
+
+func TestBreaker_ResetClearsFailureWindow(t *testing.T) {
+	b := New(Config{Name: "test_reset", OpenFor: time.Hour, MaxConsecFailures: 2}, nil)
+	fail := func(context.Context) error { return errors.New("boom") }
+	_ = b.Do(context.Background(), fail, nil)
+	_ = b.Do(context.Background(), fail, nil)
+	if st := b.Status(); st.State != Open || st.Transitions[0].Reason != "consecutive failures" || st.NextProbe == nil {
+		t.Fatalf("status after failures = %+v", st)
+	}
+
+	b.Reset("manual")
+	st := b.Status()
+	if st.State != Closed || st.ConsecutiveFailures != 0 || st.WindowCalls != 0 || st.WindowFailures != 0 {
+		t.Fatalf("status after reset = %+v", st)
+	}
+}
+
+func TestBreaker_TransitionHistoryIsBounded(t *testing.T) {
+	b := New(Config{Name: "test_bounded"}, nil)
+	for range maxTransitions {
+		b.Trip("t")
+		b.Reset("r")
+	}
+	st := b.Status()
+	if len(st.Transitions) != maxTransitions {
+		t.Fatalf("len(transitions) = %d, want %d", len(st.Transitions), maxTransitions)
+	}
+	if st.Transitions[0].Reason != "r" {
+		t.Fatalf("newest transition = %+v, want the last reset", st.Transitions[0])
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	b := New(Config{Name: "test_registry"}, nil)
+	if Lookup("test_registry") != b {
+		t.Fatal("Lookup did not return the breaker")
+	}
+	if Lookup("missing") != nil {
+		t.Fatal("Lookup of an unknown name should be nil")
+	}
+	replacement := New(Config{Name: "test_registry"}, nil)
+	if Lookup("test_registry") != replacement {
+		t.Fatal("a later breaker with the same name should replace the earlier one")
+	}
+	all := All()
+	for i := 1; i < len(all); i++ {
+		if all[i-1].Name() > all[i].Name() {
+			t.Fatalf("All() not sorted: %s before %s", all[i-1].Name(), all[i].Name())
+		}
+	}
+}
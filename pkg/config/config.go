@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -13,6 +14,7 @@ type Config struct {
 	GoogleMapsAPIKey  string
 	OpenAIAPIKey      string
 	Port              string
+	HTTPBind          string // HTTP and pprof/metrics listen address; empty is every interface
 	ApprovalThreshold int
 	WorkerCount       int
 	// Database performance settings
@@ -22,6 +24,8 @@ type Config struct {
 	DBConnMaxIdleTime int // minutes
 	DBReadTimeout     time.Duration
 	DBWriteTimeout    time.Duration
+	// Queries slower than this are logged with their statement name (0 = don't log; metrics still recorded)
+	DBSlowQueryThreshold time.Duration
 
 	// OpenAI client settings
 	OpenAITimeout time.Duration
@@ -37,7 +41,19 @@ type Config struct {
 	HealthCheckPath string
 
 	// Web interface settings
-	BasePath string
+	BasePath    string
+	TemplateDir string // development: re-read admin templates from disk on every render
+
+	// Multi-tenancy: with TenantsFile set and no Tenant this process is the gateway, running
+	// one app process per tenant on local ports from TenantPortBase. The gateway sets Tenant
+	// and TenantName in those processes.
+	TenantsFile    string
+	Tenant         string
+	TenantName     string
+	TenantPortBase int
+	// TenantTrustedProxies are the addresses or CIDR ranges of the load balancers whose
+	// X-Forwarded-For the gateway passes on
+	TenantTrustedProxies []string
 
 	// Environment & profiling/metrics
 	Env              string // development, staging, production
@@ -54,13 +70,21 @@ type Config struct {
 	AlertGCPauseMs   float64       // trigger when last GC pause exceeds this (ms)
 	AlertSampleEvery time.Duration // sampling interval
 
-	// Prompts templates overrides
-	PromptDir string // path to external templates dir; empty = use embedded only
+	// Resource watchdog: goroutine, DB pool and queue samples on /metrics, with leak alerts
+	WatchdogEnabled         bool
+	WatchdogInterval        time.Duration
+	WatchdogGoroutineGrowth int           // goroutines above the lowest count seen that count as a leak
+	WatchdogAlertAfter      time.Duration // how long an anomaly lasts before it is logged
+
+	// Prompts templates overrides. ModTime (newest template file) lets the watcher notice edits.
+	PromptDir        string // path to external templates dir; empty = use embedded only
+	PromptDirModTime time.Time
 
 	// New AI and prompt management knobs
 	OpenAIModel                 string
 	OpenAITemperature           float64
 	OpenAIMaxTokens             int
+	OpenAIContextBudgetTokens   int // scoring prompt + completion; oversized descriptions are truncated to fit
 	OpenAIRequestTimeoutSeconds int
 	OpenAIMaxBatchSize          int
 	PromptStableOnly            bool
@@ -72,6 +96,292 @@ type Config struct {
 	MinUserPointsForAVA int
 	// OnlyAmbassadors: If true, only ambassador submissions are eligible for automated review
 	OnlyAmbassadors bool
+
+	// Decision rules (YAML). ModTime lets the watcher notice file edits.
+	DecisionRulesFile    string
+	DecisionRulesModTime time.Time
+
+	// Google match weights (YAML). ModTime lets the watcher notice file edits.
+	ScoreWeightsFile    string
+	ScoreWeightsModTime time.Time
+
+	// Trust rules (YAML). ModTime lets the watcher notice file edits.
+	TrustRulesFile    string
+	TrustRulesModTime time.Time
+
+	// Description style rules (YAML). ModTime lets the watcher notice file edits.
+	StyleRulesFile    string
+	StyleRulesModTime time.Time
+
+	// Submitter notifications (opt-in). SMTP settings are only read when enabled.
+	NotifyEnabled     bool
+	NotifyFrom        string
+	NotifyDefaultLang string
+	SMTPHost          string
+	SMTPPort          int
+	SMTPUsername      string
+	SMTPPassword      string
+
+	// Auto-reject pre-filter; each rule is toggled independently
+	PrefilterEmptyName           bool
+	PrefilterURLName             bool
+	PrefilterBlockedDomains      bool
+	PrefilterBlockedDomainList   []string
+	PrefilterProfanity           bool
+	PrefilterProfanityWords      []string
+	PrefilterDuplicateSubmission bool
+	PrefilterDuplicateDays       int
+
+	// Quality reviewer: an extra OpenAI call per scored venue for name/description
+	// suggestions, limited to venues scored at least QualityReviewMinScore and to a
+	// QualityReviewSamplePercent sample of those
+	QualityReviewEnabled       bool
+	QualityReviewMinScore      int
+	QualityReviewSamplePercent int
+
+	// Optional vision check on Google Place photos (extra Google + OpenAI cost per venue)
+	PhotoCheckEnabled        bool
+	PhotoCheckModel          string
+	PhotoCheckMaxPhotos      int
+	PhotoCheckDailyBudgetUSD float64
+
+	// Website verification (fetches the venue URL; no API cost)
+	WebsiteCheckEnabled bool
+	WebsiteCheckTimeout time.Duration
+
+	// Facebook/Instagram profile verification (fetches profile pages; no API cost)
+	SocialCheckEnabled bool
+	SocialCheckTimeout time.Duration
+
+	// Malware/phishing scanning of submitted links: Google Safe Browsing when a key is set,
+	// a local domain blocklist when a file is set; flagged venues are rejected when
+	// URLScanReject, otherwise sent to manual review
+	SafeBrowsingAPIKey string
+	URLBlocklistFile   string
+	URLScanReject      bool
+	URLScanTimeout     time.Duration
+
+	// CSRF protection for state-changing admin requests (double-submit cookie)
+	CSRFEnabled        bool
+	CSRFCookieSecure   bool
+	CSRFExemptPrefixes []string // skipped only when the request carries an Authorization header
+
+	// API tokens: path prefixes where "Authorization: Bearer" tokens replace IP-based admin auth
+	APITokenPrefixes []string
+
+	// gRPC service for the platform backend (proto/ava/v1), authenticated with API tokens
+	GRPCEnabled bool
+	GRPCPort    string
+
+	// Admin IDs allowed to run incident controls such as tripping a circuit breaker, and
+	// data subject export/erasure requests
+	SuperadminIDs []int
+
+	// HMAC key for pseudonymizing feedback IPs on erasure requests and FEEDBACK_IP_MODE=hash;
+	// required by both
+	PrivacyHashKey string
+
+	// Editor feedback IPs: stored raw, truncated or hashed, and cleared after
+	// FeedbackIPRetentionDays (0 = keep)
+	FeedbackIPMode          string
+	FeedbackIPRetentionDays int
+
+	// Closure sweep: approved venues are re-checked against Google every ClosureRecheckDays
+	// (0 = off), ClosureSweepBatch venues per query
+	ClosureRecheckDays int
+	ClosureSweepBatch  int
+
+	// Category suggestions from Google types, name and description, recorded against the
+	// category each venue is approved with
+	CategorySuggestionsEnabled bool
+
+	// Vegan status check: VeganCheckModel classifies each scored venue from its description,
+	// website text and, with VeganCheckReviews, its Google reviews (a Places Atmosphere request)
+	VeganCheckEnabled bool
+	VeganCheckModel   string
+	VeganCheckReviews bool
+
+	// Change requests: editor-proposed edits to approved venues, scored on the changed
+	// fields with ChangeRequestModel
+	ChangeRequestsEnabled bool
+	ChangeRequestModel    string
+
+	// How long after an approval POST /venues/{id}/unapprove may revert it; 0 disables undo
+	UnapproveWindow time.Duration
+
+	// Validation history archival: rows older than HistoryRetentionMonths move to
+	// venue_validation_histories_archive (0 = keep everything, manual runs still allowed)
+	HistoryRetentionMonths int
+	HistoryArchiveInterval time.Duration
+	HistoryArchiveBatch    int
+
+	// Per-caller rate limits on endpoints that queue paid API calls (0 = unlimited).
+	// Bulk covers /validate and /validate/batch; single covers /venues/{id}/validate.
+	RateLimitBulkPerMinute   int
+	RateLimitBulkBurst       int
+	RateLimitSinglePerMinute int
+	RateLimitSingleBurst     int
+
+	// Outgoing rate limits per external endpoint, in weighted tokens per second (0 = unlimited).
+	// A Google Text Search takes 2 tokens and Place Details 1; an OpenAI photo review 2 and
+	// a scoring call 1.
+	RateLimitGoogleRPS         int
+	RateLimitGoogleBurst       int
+	RateLimitGooglePhotosRPS   int
+	RateLimitGooglePhotosBurst int
+	RateLimitOpenAIRPS         int
+	RateLimitOpenAIBurst       int
+	// Shares of the outgoing rate limits per region, e.g. "europe=40,north-america=35,*=25"
+	// (venue path prefix = percent; "" shares nothing), with optional daily token quotas split
+	// the same way. A region idle for QuotaIdleAfter lends its unused rate to the others.
+	QuotaPartitions        string
+	QuotaGoogleDailyTokens int64 // Google Places tokens per UTC day; 0 = unlimited
+	QuotaOpenAIDailyTokens int64 // OpenAI tokens per UTC day; 0 = unlimited
+	QuotaIdleAfter         time.Duration
+	// Percent of all the limits above this process may use when processes share one quota,
+	// as tenants do (set from their quota_share); 0 = all
+	QuotaShare float64
+	// Time a single-venue review (POST /venues/{id}/validate) may take, split between its
+	// stages by StageBudgetWeights ("google=3,openai=4,quality_review=2,db=1"). Stages that
+	// would not fit are left out and the response lists them as skipped.
+	SyncValidateTimeout time.Duration
+	StageBudgetWeights  string
+
+	// Translation of non-English descriptions before scoring: "" (off), openai, deepl or google
+	TranslationProvider string
+	TranslationAPIKey   string // DeepL/Google key; openai uses OPENAI_API_KEY
+	TranslationTimeout  time.Duration
+
+	// Geo-fence: coordinates vs the country/region in the venue path
+	GeofenceForceReview    bool
+	GeofenceReverseGeocode bool // billable Geocoding call when no Google place matched
+
+	// Share of venues (0-100, by venue ID) looked up with the Places API (New) instead of legacy
+	PlacesAPINewPercent int
+
+	// Google API keys to rotate over: comma-separated [name=]key[:rps[:daily_quota]] entries;
+	// empty uses GoogleMapsAPIKey alone. Entries without limits get the defaults below.
+	GoogleMapsAPIKeys       string
+	GoogleMapsKeyRPS        int   // 0 = unlimited
+	GoogleMapsKeyDailyQuota int64 // requests per Pacific day; 0 = unlimited
+
+	// Longest age of stored Google data that POST /venues/{id}/revalidate?use_cache=true reuses
+	GoogleCacheMaxAge time.Duration
+
+	// Retry policy for failed venues: jittered exponential backoff, budgets per error class
+	RetryBaseDelay       time.Duration
+	RetryMaxDelay        time.Duration
+	RetryBudgetRateLimit int
+	RetryBudgetTimeout   int
+	RetryBudgetServer    int
+	RetryBudgetNetwork   int
+
+	// Result batching: validation histories are written in multi-row INSERTs of up to
+	// ResultBatchSize (1 = one transaction per result), flushed at least every ResultFlushInterval
+	ResultBatchSize     int
+	ResultFlushInterval time.Duration
+
+	// Worker autoscaling: every WorkerScaleInterval the pool is resized between WorkerMin and
+	// WorkerMax from queue depth, shrinking while the external API error rate is above
+	// WorkerScaleMaxErrorRate. WorkerCount is then only the starting size.
+	WorkerAutoscale         bool
+	WorkerMin               int
+	WorkerMax               int
+	WorkerScaleInterval     time.Duration
+	WorkerScaleMaxErrorRate float64
+
+	// Distributed processing: "db" makes every replica pull from a shared queue table, claimed
+	// with row locks; "local" (default) keeps the in-memory queue of a single instance.
+	// Instances heartbeat every InstanceHeartbeatInterval; the jobs of one silent for
+	// InstanceStaleAfter go back to the queue. A job claimed more than QueueMaxAttempts times
+	// goes to manual review.
+	ProcessingCoordination    string
+	InstanceID                string // defaults to hostname-pid
+	InstanceHeartbeatInterval time.Duration
+	InstanceStaleAfter        time.Duration
+	QueuePollInterval         time.Duration
+	QueueMaxAttempts          int
+
+	// Shutdown checkpoints: jobs cut off by a shutdown store their finished stages (Google
+	// enrichment, AI scoring) and resume from there if processed within CheckpointMaxAge
+	CheckpointEnabled bool
+	CheckpointMaxAge  time.Duration
+
+	// Reputation: submitters' past approvals, rejections and rejection streaks feed their
+	// trust assessment; each submitter's history is cached for ReputationCacheTTL
+	ReputationEnabled  bool
+	ReputationCacheTTL time.Duration
+
+	// Submitter rules: admin-managed block/allow lists by member ID or email domain,
+	// reloaded by each instance every SubmitterRulesRefresh
+	SubmitterRulesEnabled bool
+	SubmitterRulesRefresh time.Duration
+
+	// Config API: admins change the runtime settings (see RuntimeSettings) through
+	// /settings/config and /api/v1/config, with each change recorded
+	ConfigAPIEnabled bool
+
+	// Feature flags: stored flags gate new capabilities with a rollout percentage; each
+	// instance reloads them every FeatureFlagsRefresh
+	FeatureFlagsEnabled bool
+	FeatureFlagsRefresh time.Duration
+
+	// Batch scoring: runs in mode=batch send their AI scoring calls through the OpenAI Batch
+	// API; stored calls are submitted and finished jobs ingested every BatchScoringPollInterval
+	BatchScoringEnabled      bool
+	BatchScoringPollInterval time.Duration
+	BatchScoringMaxRequests  int
+
+	// Re-scoring: every RescoreInterval, pending venues last scored before a change of the
+	// prompt versions or decision config are queued score-only, RescoreMaxVenues at a time
+	// and within RescoreDailyBudgetUSD of estimated spend
+	RescoreEnabled        bool
+	RescoreInterval       time.Duration
+	RescoreMaxVenues      int
+	RescoreDailyBudgetUSD float64
+
+	// Embedding checks: venue name+description vectors flag near-duplicates of recent
+	// submissions and text templated across several venues. Each instance keeps the vectors
+	// of the last EmbeddingLookback in memory (at most EmbeddingIndexLimit), refreshed every
+	// EmbeddingIndexRefresh
+	EmbeddingsEnabled           bool
+	EmbeddingModel              string
+	EmbeddingDimensions         int
+	EmbeddingDuplicateThreshold float64
+	EmbeddingSpamThreshold      float64
+	EmbeddingSpamMinMatches     int
+	EmbeddingLookback           time.Duration
+	EmbeddingIndexLimit         int
+	EmbeddingIndexRefresh       time.Duration
+
+	// Venue holds: reviewers park venues awaiting outside information, keeping them out of
+	// the manual review queue until released or their reminder date
+	VenueHoldsEnabled bool
+
+	// Venue claims: reviewers claim pending venues so others skip them; a claim lapses after
+	// VenueClaimTimeout without activity on the venue
+	VenueClaimsEnabled bool
+	VenueClaimTimeout  time.Duration
+
+	// Venue comments: internal reviewer comment threads on venues, with @admin mentions
+	VenueCommentsEnabled bool
+
+	// Saved filters: admins name and store filter sets for the pending and manual review
+	// lists, one of which can be their default landing view
+	SavedFiltersEnabled bool
+
+	// UI preferences: per-admin theme, rows per page, default sort and compact tables,
+	// stored in the database
+	UIPreferencesEnabled bool
+
+	// Full-text search: /search and /api/v1/search over venue fields and AI notes, using the
+	// FULLTEXT indexes in db_changes.md
+	FullTextSearchEnabled bool
+
+	// Event webhook: every venue event is POSTed here in order (empty = off)
+	EventsWebhookURL     string
+	EventsWebhookSecret  string
+	EventsWebhookTimeout time.Duration
 }
 
 func Load() *Config {
@@ -90,6 +400,7 @@ func Load() *Config {
 	// Environment and profiling defaults
 	env := strings.ToLower(getEnv("ENV", "development"))
 	profPort := getEnv("PROFILING_PORT", "6060")
+	tenantPortBase, _ := strconv.Atoi(getEnv("TENANT_PORT_BASE", "9100"))
 	metricsPath := getEnv("METRICS_PATH", "/metrics")
 
 	// Default toggles based on env
@@ -106,15 +417,21 @@ func Load() *Config {
 	alertMemAllocMB, _ := strconv.ParseFloat(getEnv("ALERT_MEM_ALLOC_MB", "512"), 64)
 	alertGCPauseMs, _ := strconv.ParseFloat(getEnv("ALERT_GC_PAUSE_MS", "200"), 64)
 	alertSampleEverySec, _ := strconv.Atoi(getEnv("ALERT_SAMPLE_EVERY_SEC", "5"))
+	watchdogEnabled, _ := strconv.ParseBool(getEnv("WATCHDOG_ENABLED", "false"))
+	watchdogInterval, _ := time.ParseDuration(getEnv("WATCHDOG_INTERVAL", "15s"))
+	watchdogGoroutineGrowth, _ := strconv.Atoi(getEnv("WATCHDOG_GOROUTINE_GROWTH", "200"))
+	watchdogAlertAfter, _ := time.ParseDuration(getEnv("WATCHDOG_ALERT_AFTER", "5m"))
 
 	// Timeouts
 	dbReadTO, _ := time.ParseDuration(getEnv("DB_READ_TIMEOUT", "8s"))
 	dbWriteTO, _ := time.ParseDuration(getEnv("DB_WRITE_TIMEOUT", "6s"))
+	dbSlowQuery, _ := time.ParseDuration(getEnv("DB_SLOW_QUERY_THRESHOLD", "500ms"))
 
 	// New OpenAI config
 	openAIModel := getEnv("OPENAI_MODEL", "gpt-4o-mini")
 	openAITemp, _ := strconv.ParseFloat(getEnv("OPENAI_TEMPERATURE", "0.1"), 64)
 	openAIMaxTokens, _ := strconv.Atoi(getEnv("OPENAI_MAX_TOKENS", "250"))
+	openAIContextBudget, _ := strconv.Atoi(getEnv("OPENAI_CONTEXT_BUDGET_TOKENS", "4000"))
 	openAIReqTimeoutSec, _ := strconv.Atoi(getEnv("OPENAI_REQUEST_TIMEOUT_SECONDS", "60"))
 	openAIMaxBatchSize, _ := strconv.Atoi(getEnv("OPENAI_MAX_BATCH_SIZE", "5"))
 
@@ -122,6 +439,15 @@ func Load() *Config {
 	promptDir := getEnv("PROMPT_DIR", "./prompts")
 	promptStableOnly, _ := strconv.ParseBool(getEnv("PROMPT_STABLE_ONLY", "false"))
 	promptWeights := getEnv("PROMPT_WEIGHTS", "")
+	var promptMTime time.Time
+	if promptDir != "" {
+		files, _ := filepath.Glob(filepath.Join(promptDir, "*.txt.tmpl"))
+		for _, f := range files {
+			if fi, err := os.Stat(f); err == nil && fi.ModTime().After(promptMTime) {
+				promptMTime = fi.ModTime()
+			}
+		}
+	}
 
 	// Config reload
 	reloadIntSec, _ := strconv.Atoi(getEnv("CONFIG_RELOAD_INTERVAL_SECONDS", "2"))
@@ -130,6 +456,215 @@ func Load() *Config {
 	minUserPoints, _ := strconv.Atoi(getEnv("MIN_USER_POINTS_FOR_AVA", "150"))
 	onlyAmbassadors, _ := strconv.ParseBool(getEnv("ONLY_AMBASSADORS", "false"))
 
+	// Decision rules file
+	rulesFile := getEnv("DECISION_RULES_FILE", "")
+	var rulesMTime time.Time
+	if rulesFile != "" {
+		if fi, err := os.Stat(rulesFile); err == nil {
+			rulesMTime = fi.ModTime()
+		}
+	}
+
+	// Google match weights file
+	weightsFile := getEnv("SCORE_WEIGHTS_FILE", "")
+	var weightsMTime time.Time
+	if weightsFile != "" {
+		if fi, err := os.Stat(weightsFile); err == nil {
+			weightsMTime = fi.ModTime()
+		}
+	}
+
+	// Trust rules file
+	trustFile := getEnv("TRUST_RULES_FILE", "")
+	var trustMTime time.Time
+	if trustFile != "" {
+		if fi, err := os.Stat(trustFile); err == nil {
+			trustMTime = fi.ModTime()
+		}
+	}
+
+	// Description style rules file
+	styleFile := getEnv("STYLE_RULES_FILE", "")
+	var styleMTime time.Time
+	if styleFile != "" {
+		if fi, err := os.Stat(styleFile); err == nil {
+			styleMTime = fi.ModTime()
+		}
+	}
+
+	// Submitter notifications
+	notifyEnabled, _ := strconv.ParseBool(getEnv("NOTIFY_ENABLED", "false"))
+	smtpPort, _ := strconv.Atoi(getEnv("SMTP_PORT", "587"))
+
+	// Auto-reject pre-filter
+	pfEmptyName, _ := strconv.ParseBool(getEnv("PREFILTER_EMPTY_NAME", "true"))
+	pfURLName, _ := strconv.ParseBool(getEnv("PREFILTER_URL_NAME", "true"))
+	pfBlockedDomains, _ := strconv.ParseBool(getEnv("PREFILTER_BLOCKED_DOMAINS", "false"))
+	pfProfanity, _ := strconv.ParseBool(getEnv("PREFILTER_PROFANITY", "false"))
+	pfDuplicate, _ := strconv.ParseBool(getEnv("PREFILTER_DUPLICATE_SUBMISSION", "false"))
+	pfDuplicateDays, _ := strconv.Atoi(getEnv("PREFILTER_DUPLICATE_DAYS", "7"))
+
+	// Photo check
+	qualityReviewEnabled, _ := strconv.ParseBool(getEnv("QUALITY_REVIEW_ENABLED", "true"))
+	qualityReviewMinScore, _ := strconv.Atoi(getEnv("QUALITY_REVIEW_MIN_SCORE", "0"))
+	qualityReviewSamplePercent, _ := strconv.Atoi(getEnv("QUALITY_REVIEW_SAMPLE_PERCENT", "100"))
+	photoCheckEnabled, _ := strconv.ParseBool(getEnv("PHOTO_CHECK_ENABLED", "false"))
+	photoCheckMaxPhotos, _ := strconv.Atoi(getEnv("PHOTO_CHECK_MAX_PHOTOS", "2"))
+	photoCheckBudget, _ := strconv.ParseFloat(getEnv("PHOTO_CHECK_DAILY_BUDGET_USD", "1.0"), 64)
+
+	// Website check
+	websiteCheckEnabled, _ := strconv.ParseBool(getEnv("WEBSITE_CHECK_ENABLED", "false"))
+	websiteCheckTO, _ := time.ParseDuration(getEnv("WEBSITE_CHECK_TIMEOUT", "8s"))
+
+	// Social profile check
+	socialCheckEnabled, _ := strconv.ParseBool(getEnv("SOCIAL_CHECK_ENABLED", "false"))
+	socialCheckTO, _ := time.ParseDuration(getEnv("SOCIAL_CHECK_TIMEOUT", "8s"))
+
+	// URL scanning
+	urlScanAction := strings.ToLower(strings.TrimSpace(getEnv("URL_SCAN_ACTION", "manual_review")))
+	if urlScanAction != "reject" && urlScanAction != "manual_review" {
+		log.Printf("[Warning] URL_SCAN_ACTION %q is not reject or manual_review, using manual_review", urlScanAction)
+		urlScanAction = "manual_review"
+	}
+	urlScanTO, _ := time.ParseDuration(getEnv("URL_SCAN_TIMEOUT", "5s"))
+
+	// CSRF
+	csrfEnabled, _ := strconv.ParseBool(getEnv("CSRF_ENABLED", "true"))
+	grpcEnabled, _ := strconv.ParseBool(getEnv("GRPC_ENABLED", "false"))
+	csrfSecure, _ := strconv.ParseBool(getEnv("CSRF_COOKIE_SECURE", "false"))
+
+	// Rate limits
+	rlBulkPerMin, _ := strconv.Atoi(getEnv("RATE_LIMIT_VALIDATE_PER_MINUTE", "6"))
+	rlBulkBurst, _ := strconv.Atoi(getEnv("RATE_LIMIT_VALIDATE_BURST", "2"))
+	rlSinglePerMin, _ := strconv.Atoi(getEnv("RATE_LIMIT_VALIDATE_SINGLE_PER_MINUTE", "30"))
+	rlSingleBurst, _ := strconv.Atoi(getEnv("RATE_LIMIT_VALIDATE_SINGLE_BURST", "5"))
+	rlGoogleRPS, _ := strconv.Atoi(getEnv("RATE_LIMIT_GOOGLE_RPS", "15"))
+	rlGoogleBurst, _ := strconv.Atoi(getEnv("RATE_LIMIT_GOOGLE_BURST", "30"))
+	rlPhotosRPS, _ := strconv.Atoi(getEnv("RATE_LIMIT_GOOGLE_PHOTOS_RPS", "5"))
+	rlPhotosBurst, _ := strconv.Atoi(getEnv("RATE_LIMIT_GOOGLE_PHOTOS_BURST", "10"))
+	rlOpenAIRPS, _ := strconv.Atoi(getEnv("RATE_LIMIT_OPENAI_RPS", "8"))
+	rlOpenAIBurst, _ := strconv.Atoi(getEnv("RATE_LIMIT_OPENAI_BURST", "15"))
+	quotaGoogleDaily, _ := strconv.ParseInt(getEnv("QUOTA_GOOGLE_DAILY_TOKENS", "0"), 10, 64)
+	quotaOpenAIDaily, _ := strconv.ParseInt(getEnv("QUOTA_OPENAI_DAILY_TOKENS", "0"), 10, 64)
+	quotaIdleAfter, _ := time.ParseDuration(getEnv("QUOTA_IDLE_AFTER", "30s"))
+	quotaShare, _ := strconv.ParseFloat(getEnv("QUOTA_SHARE", "0"), 64)
+	syncValidateTO, _ := time.ParseDuration(getEnv("SYNC_VALIDATE_TIMEOUT", "2m"))
+
+	// Translation
+	translationTO, _ := time.ParseDuration(getEnv("TRANSLATION_TIMEOUT", "15s"))
+	unapproveWindow, _ := time.ParseDuration(getEnv("UNAPPROVE_WINDOW", "15m"))
+
+	// History archival
+	historyRetentionMonths, _ := strconv.Atoi(getEnv("HISTORY_RETENTION_MONTHS", "0"))
+	historyArchiveInterval, _ := time.ParseDuration(getEnv("HISTORY_ARCHIVE_INTERVAL", "24h"))
+	historyArchiveBatch, _ := strconv.Atoi(getEnv("HISTORY_ARCHIVE_BATCH", "1000"))
+
+	// Geo-fence
+	geofenceForceReview, _ := strconv.ParseBool(getEnv("GEOFENCE_FORCE_REVIEW", "true"))
+	geofenceReverseGeocode, _ := strconv.ParseBool(getEnv("GEOFENCE_REVERSE_GEOCODE", "false"))
+
+	// Places API (New) rollout
+	placesAPINewPercent, _ := strconv.Atoi(getEnv("PLACES_API_NEW_PERCENT", "0"))
+
+	// Google API key rotation
+	googleMapsKeyRPS, _ := strconv.Atoi(getEnv("GOOGLE_MAPS_KEY_RPS", "0"))
+	googleMapsKeyDailyQuota, _ := strconv.ParseInt(getEnv("GOOGLE_MAPS_KEY_DAILY_QUOTA", "0"), 10, 64)
+	googleCacheMaxAge, _ := time.ParseDuration(getEnv("GOOGLE_CACHE_MAX_AGE", "720h"))
+
+	// Retry policy
+	retryBaseDelay, _ := time.ParseDuration(getEnv("RETRY_BASE_DELAY", "2s"))
+	retryMaxDelay, _ := time.ParseDuration(getEnv("RETRY_MAX_DELAY", "30s"))
+	retryRateLimit, _ := strconv.Atoi(getEnv("RETRY_BUDGET_RATE_LIMIT", "5"))
+	retryTimeout, _ := strconv.Atoi(getEnv("RETRY_BUDGET_TIMEOUT", "3"))
+	retryServer, _ := strconv.Atoi(getEnv("RETRY_BUDGET_SERVER", "3"))
+	retryNetwork, _ := strconv.Atoi(getEnv("RETRY_BUDGET_NETWORK", "3"))
+
+	// Result batching
+	resultBatchSize, _ := strconv.Atoi(getEnv("RESULT_BATCH_SIZE", "50"))
+	resultFlushInterval, _ := time.ParseDuration(getEnv("RESULT_FLUSH_INTERVAL", "2s"))
+
+	// Worker autoscaling
+	workerAutoscale, _ := strconv.ParseBool(getEnv("WORKER_AUTOSCALE", "false"))
+	workerMin, _ := strconv.Atoi(getEnv("WORKER_MIN", "2"))
+	workerMax, _ := strconv.Atoi(getEnv("WORKER_MAX", "20"))
+	workerScaleInterval, _ := time.ParseDuration(getEnv("WORKER_SCALE_INTERVAL", "10s"))
+	workerScaleMaxErrorRate, _ := strconv.ParseFloat(getEnv("WORKER_SCALE_MAX_ERROR_RATE", "0.2"), 64)
+
+	// Distributed processing
+	instanceHeartbeatInterval, _ := time.ParseDuration(getEnv("INSTANCE_HEARTBEAT_INTERVAL", "10s"))
+	instanceStaleAfter, _ := time.ParseDuration(getEnv("INSTANCE_STALE_AFTER", "45s"))
+	queuePollInterval, _ := time.ParseDuration(getEnv("QUEUE_POLL_INTERVAL", "2s"))
+	queueMaxAttempts, _ := strconv.Atoi(getEnv("QUEUE_MAX_ATTEMPTS", "3"))
+
+	// Shutdown checkpoints
+	checkpointEnabled, _ := strconv.ParseBool(getEnv("CHECKPOINT_ENABLED", "false"))
+	checkpointMaxAge, _ := time.ParseDuration(getEnv("CHECKPOINT_MAX_AGE", "24h"))
+	reputationEnabled, _ := strconv.ParseBool(getEnv("REPUTATION_ENABLED", "true"))
+	reputationCacheTTL, _ := time.ParseDuration(getEnv("REPUTATION_CACHE_TTL", "15m"))
+	submitterRulesEnabled, _ := strconv.ParseBool(getEnv("SUBMITTER_RULES_ENABLED", "false"))
+	submitterRulesRefresh, _ := time.ParseDuration(getEnv("SUBMITTER_RULES_REFRESH", "1m"))
+	configAPIEnabled, _ := strconv.ParseBool(getEnv("CONFIG_API_ENABLED", "false"))
+	featureFlagsEnabled, _ := strconv.ParseBool(getEnv("FEATURE_FLAGS_ENABLED", "false"))
+	featureFlagsRefresh, _ := time.ParseDuration(getEnv("FEATURE_FLAGS_REFRESH", "1m"))
+	batchScoringEnabled, _ := strconv.ParseBool(getEnv("BATCH_SCORING_ENABLED", "false"))
+	batchScoringPollInterval, _ := time.ParseDuration(getEnv("BATCH_SCORING_POLL_INTERVAL", "5m"))
+	batchScoringMaxRequests, _ := strconv.Atoi(getEnv("BATCH_SCORING_MAX_REQUESTS", "1000"))
+	rescoreEnabled, _ := strconv.ParseBool(getEnv("RESCORE_ENABLED", "false"))
+	rescoreInterval, _ := time.ParseDuration(getEnv("RESCORE_INTERVAL", "15m"))
+	rescoreMaxVenues, _ := strconv.Atoi(getEnv("RESCORE_MAX_VENUES", "200"))
+	rescoreBudget, _ := strconv.ParseFloat(getEnv("RESCORE_DAILY_BUDGET_USD", "2.0"), 64)
+	embeddingsEnabled, _ := strconv.ParseBool(getEnv("EMBEDDINGS_ENABLED", "false"))
+	embeddingDimensions, _ := strconv.Atoi(getEnv("EMBEDDING_DIMENSIONS", "256"))
+	embeddingDuplicateThreshold, _ := strconv.ParseFloat(getEnv("EMBEDDING_DUPLICATE_THRESHOLD", "0.95"), 64)
+	embeddingSpamThreshold, _ := strconv.ParseFloat(getEnv("EMBEDDING_SPAM_THRESHOLD", "0.9"), 64)
+	embeddingSpamMinMatches, _ := strconv.Atoi(getEnv("EMBEDDING_SPAM_MIN_MATCHES", "3"))
+	embeddingLookback, _ := time.ParseDuration(getEnv("EMBEDDING_LOOKBACK", "2160h"))
+	embeddingIndexLimit, _ := strconv.Atoi(getEnv("EMBEDDING_INDEX_LIMIT", "50000"))
+	embeddingIndexRefresh, _ := time.ParseDuration(getEnv("EMBEDDING_INDEX_REFRESH", "5m"))
+	venueHoldsEnabled, _ := strconv.ParseBool(getEnv("VENUE_HOLDS_ENABLED", "false"))
+	venueClaimsEnabled, _ := strconv.ParseBool(getEnv("VENUE_CLAIMS_ENABLED", "false"))
+	venueClaimTimeout, _ := time.ParseDuration(getEnv("VENUE_CLAIM_TIMEOUT", "30m"))
+	venueCommentsEnabled, _ := strconv.ParseBool(getEnv("VENUE_COMMENTS_ENABLED", "false"))
+	savedFiltersEnabled, _ := strconv.ParseBool(getEnv("SAVED_FILTERS_ENABLED", "false"))
+	uiPreferencesEnabled, _ := strconv.ParseBool(getEnv("UI_PREFERENCES_ENABLED", "false"))
+	fullTextSearchEnabled, _ := strconv.ParseBool(getEnv("FULLTEXT_SEARCH_ENABLED", "false"))
+
+	// Event webhook
+	eventsWebhookTimeout, _ := time.ParseDuration(getEnv("EVENTS_WEBHOOK_TIMEOUT", "10s"))
+
+	// Superadmins
+	var superadminIDs []int
+	for _, v := range splitList(getEnv("SUPERADMIN_IDS", "")) {
+		id, err := strconv.Atoi(v)
+		if err != nil || id <= 0 {
+			log.Printf("[Warning] SUPERADMIN_IDS: ignoring invalid admin ID %q", v)
+			continue
+		}
+		superadminIDs = append(superadminIDs, id)
+	}
+
+	// Editor feedback IPs
+	feedbackIPMode := strings.ToLower(strings.TrimSpace(getEnv("FEEDBACK_IP_MODE", "raw")))
+	switch feedbackIPMode {
+	case "raw", "truncate", "hash":
+	default:
+		log.Printf("[Warning] FEEDBACK_IP_MODE %q is not raw, truncate or hash, using raw", feedbackIPMode)
+		feedbackIPMode = "raw"
+	}
+	feedbackIPRetentionDays, _ := strconv.Atoi(getEnv("FEEDBACK_IP_RETENTION_DAYS", "0"))
+	if feedbackIPRetentionDays < 0 {
+		feedbackIPRetentionDays = 0
+	}
+	closureRecheckDays, _ := strconv.Atoi(getEnv("CLOSURE_RECHECK_DAYS", "0"))
+	if closureRecheckDays < 0 {
+		closureRecheckDays = 0
+	}
+	closureSweepBatch, _ := strconv.Atoi(getEnv("CLOSURE_SWEEP_BATCH", "200"))
+	changeRequestsEnabled, _ := strconv.ParseBool(getEnv("CHANGE_REQUESTS_ENABLED", "false"))
+	categorySuggestionsEnabled, _ := strconv.ParseBool(getEnv("CATEGORY_SUGGESTIONS_ENABLED", "false"))
+	veganCheckEnabled, _ := strconv.ParseBool(getEnv("VEGAN_CHECK_ENABLED", "false"))
+	veganCheckReviews, _ := strconv.ParseBool(getEnv("VEGAN_CHECK_REVIEWS", "false"))
+
 	// Validate AVA configuration
 	if minUserPoints < 0 {
 		log.Printf("[Warning] MIN_USER_POINTS_FOR_AVA is negative (%d), using 0 to disable check", minUserPoints)
@@ -137,19 +672,21 @@ func Load() *Config {
 	}
 
 	cfg := &Config{
-		DatabaseURL:       getEnv("DATABASE_URL", ""),
-		GoogleMapsAPIKey:  getEnv("GOOGLE_MAPS_API_KEY", ""),
-		OpenAIAPIKey:      getEnv("OPENAI_API_KEY", ""),
-		Port:              getEnv("PORT", "8080"),
-		ApprovalThreshold: threshold,
-		WorkerCount:       workerCount,
-		DBMaxOpenConns:    dbMaxOpenConns,
-		DBMaxIdleConns:    dbMaxIdleConns,
-		DBConnMaxLifetime: dbConnMaxLifetime,
-		DBConnMaxIdleTime: dbConnMaxIdleTime,
-		DBReadTimeout:     dbReadTO,
-		DBWriteTimeout:    dbWriteTO,
-		OpenAITimeout:     time.Duration(openAIReqTimeoutSec) * time.Second,
+		DatabaseURL:          getEnv("DATABASE_URL", ""),
+		GoogleMapsAPIKey:     getEnv("GOOGLE_MAPS_API_KEY", ""),
+		OpenAIAPIKey:         getEnv("OPENAI_API_KEY", ""),
+		Port:                 getEnv("PORT", "8080"),
+		HTTPBind:             getEnv("HTTP_BIND", ""),
+		ApprovalThreshold:    threshold,
+		WorkerCount:          workerCount,
+		DBMaxOpenConns:       dbMaxOpenConns,
+		DBMaxIdleConns:       dbMaxIdleConns,
+		DBConnMaxLifetime:    dbConnMaxLifetime,
+		DBConnMaxIdleTime:    dbConnMaxIdleTime,
+		DBReadTimeout:        dbReadTO,
+		DBWriteTimeout:       dbWriteTO,
+		DBSlowQueryThreshold: dbSlowQuery,
+		OpenAITimeout:        time.Duration(openAIReqTimeoutSec) * time.Second,
 
 		// Monitoring and logging settings
 		LogLevel:          getEnv("LOG_LEVEL", "info"),
@@ -162,7 +699,15 @@ func Load() *Config {
 		HealthCheckPath: getEnv("HEALTH_CHECK_PATH", "/health"),
 
 		// Web interface settings
-		BasePath: getEnv("BASE_PATH", "/"),
+		BasePath:    getEnv("BASE_PATH", "/"),
+		TemplateDir: getEnv("TEMPLATE_DIR", ""),
+
+		// Multi-tenancy
+		TenantsFile:          getEnv("TENANTS_FILE", ""),
+		Tenant:               getEnv("TENANT", ""),
+		TenantName:           getEnv("TENANT_NAME", ""),
+		TenantPortBase:       tenantPortBase,
+		TenantTrustedProxies: splitList(getEnv("TENANT_TRUSTED_PROXIES", "")),
 
 		// Environment & profiling/metrics
 		Env:              env,
@@ -179,11 +724,19 @@ func Load() *Config {
 		AlertGCPauseMs:   alertGCPauseMs,
 		AlertSampleEvery: time.Duration(alertSampleEverySec) * time.Second,
 
+		// Watchdog
+		WatchdogEnabled:         watchdogEnabled,
+		WatchdogInterval:        watchdogInterval,
+		WatchdogGoroutineGrowth: watchdogGoroutineGrowth,
+		WatchdogAlertAfter:      watchdogAlertAfter,
+
 		// Prompts templates overrides and new knobs
 		PromptDir:                   promptDir,
+		PromptDirModTime:            promptMTime,
 		OpenAIModel:                 openAIModel,
 		OpenAITemperature:           openAITemp,
 		OpenAIMaxTokens:             openAIMaxTokens,
+		OpenAIContextBudgetTokens:   openAIContextBudget,
 		OpenAIRequestTimeoutSeconds: openAIReqTimeoutSec,
 		OpenAIMaxBatchSize:          openAIMaxBatchSize,
 		PromptStableOnly:            promptStableOnly,
@@ -193,11 +746,220 @@ func Load() *Config {
 		// AVA qualification requirements
 		MinUserPointsForAVA: minUserPoints,
 		OnlyAmbassadors:     onlyAmbassadors,
+
+		// Decision rules
+		DecisionRulesFile:    rulesFile,
+		DecisionRulesModTime: rulesMTime,
+
+		// Google match weights
+		ScoreWeightsFile:    weightsFile,
+		ScoreWeightsModTime: weightsMTime,
+
+		// Trust rules
+		TrustRulesFile:    trustFile,
+		TrustRulesModTime: trustMTime,
+		StyleRulesFile:    styleFile,
+		StyleRulesModTime: styleMTime,
+
+		// Notifications
+		NotifyEnabled:     notifyEnabled,
+		NotifyFrom:        getEnv("NOTIFY_FROM", ""),
+		NotifyDefaultLang: getEnv("NOTIFY_DEFAULT_LANG", "en"),
+		SMTPHost:          getEnv("SMTP_HOST", ""),
+		SMTPPort:          smtpPort,
+		SMTPUsername:      getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:      getEnv("SMTP_PASSWORD", ""),
+
+		// Pre-filter
+		PrefilterEmptyName:           pfEmptyName,
+		PrefilterURLName:             pfURLName,
+		PrefilterBlockedDomains:      pfBlockedDomains,
+		PrefilterBlockedDomainList:   splitList(getEnv("PREFILTER_BLOCKED_DOMAIN_LIST", "")),
+		PrefilterProfanity:           pfProfanity,
+		PrefilterProfanityWords:      splitList(getEnv("PREFILTER_PROFANITY_WORDS", "")),
+		PrefilterDuplicateSubmission: pfDuplicate,
+		PrefilterDuplicateDays:       pfDuplicateDays,
+
+		// Quality review
+		QualityReviewEnabled:       qualityReviewEnabled,
+		QualityReviewMinScore:      qualityReviewMinScore,
+		QualityReviewSamplePercent: qualityReviewSamplePercent,
+
+		// Photo check
+		PhotoCheckEnabled:        photoCheckEnabled,
+		PhotoCheckModel:          getEnv("PHOTO_CHECK_MODEL", "gpt-4o-mini"),
+		PhotoCheckMaxPhotos:      photoCheckMaxPhotos,
+		PhotoCheckDailyBudgetUSD: photoCheckBudget,
+
+		// Website check
+		WebsiteCheckEnabled: websiteCheckEnabled,
+		WebsiteCheckTimeout: websiteCheckTO,
+
+		// Social profile check
+		SocialCheckEnabled: socialCheckEnabled,
+		SocialCheckTimeout: socialCheckTO,
+
+		// URL scanning
+		SafeBrowsingAPIKey: getEnv("SAFE_BROWSING_API_KEY", ""),
+		URLBlocklistFile:   getEnv("URL_BLOCKLIST_FILE", ""),
+		URLScanReject:      urlScanAction == "reject",
+		URLScanTimeout:     urlScanTO,
+
+		// CSRF
+		CSRFEnabled:        csrfEnabled,
+		CSRFCookieSecure:   csrfSecure,
+		CSRFExemptPrefixes: splitList(getEnv("CSRF_EXEMPT_PATHS", "/api/")),
+
+		// API tokens
+		APITokenPrefixes: splitList(getEnv("API_TOKEN_PATHS", "/api/")),
+		GRPCEnabled:      grpcEnabled,
+		GRPCPort:         getEnv("GRPC_PORT", "9090"),
+
+		SuperadminIDs:  superadminIDs,
+		PrivacyHashKey: getEnv("PRIVACY_HASH_KEY", ""),
+
+		FeedbackIPMode:             feedbackIPMode,
+		FeedbackIPRetentionDays:    feedbackIPRetentionDays,
+		ClosureRecheckDays:         closureRecheckDays,
+		ClosureSweepBatch:          closureSweepBatch,
+		ChangeRequestsEnabled:      changeRequestsEnabled,
+		CategorySuggestionsEnabled: categorySuggestionsEnabled,
+		VeganCheckEnabled:          veganCheckEnabled,
+		VeganCheckModel:            getEnv("VEGAN_CHECK_MODEL", "gpt-4o-mini"),
+		VeganCheckReviews:          veganCheckReviews,
+		ChangeRequestModel:         getEnv("CHANGE_REQUEST_MODEL", "gpt-4o-mini"),
+
+		UnapproveWindow: unapproveWindow,
+
+		HistoryRetentionMonths: historyRetentionMonths,
+		HistoryArchiveInterval: historyArchiveInterval,
+		HistoryArchiveBatch:    historyArchiveBatch,
+
+		// Rate limits
+		RateLimitBulkPerMinute:   rlBulkPerMin,
+		RateLimitBulkBurst:       rlBulkBurst,
+		RateLimitSinglePerMinute: rlSinglePerMin,
+		RateLimitSingleBurst:     rlSingleBurst,
+
+		RateLimitGoogleRPS:         rlGoogleRPS,
+		RateLimitGoogleBurst:       rlGoogleBurst,
+		RateLimitGooglePhotosRPS:   rlPhotosRPS,
+		RateLimitGooglePhotosBurst: rlPhotosBurst,
+		RateLimitOpenAIRPS:         rlOpenAIRPS,
+		RateLimitOpenAIBurst:       rlOpenAIBurst,
+		QuotaPartitions:            getEnv("QUOTA_PARTITIONS", ""),
+		QuotaGoogleDailyTokens:     quotaGoogleDaily,
+		QuotaOpenAIDailyTokens:     quotaOpenAIDaily,
+		QuotaIdleAfter:             quotaIdleAfter,
+		QuotaShare:                 quotaShare,
+		SyncValidateTimeout:        syncValidateTO,
+		StageBudgetWeights:         getEnv("STAGE_BUDGET_WEIGHTS", ""),
+
+		// Translation
+		TranslationProvider: strings.ToLower(strings.TrimSpace(getEnv("TRANSLATION_PROVIDER", ""))),
+		TranslationAPIKey:   getEnv("TRANSLATION_API_KEY", ""),
+		TranslationTimeout:  translationTO,
+
+		// Geo-fence
+		GeofenceForceReview:    geofenceForceReview,
+		GeofenceReverseGeocode: geofenceReverseGeocode,
+
+		PlacesAPINewPercent: placesAPINewPercent,
+		GoogleCacheMaxAge:   googleCacheMaxAge,
+
+		GoogleMapsAPIKeys:       getEnv("GOOGLE_MAPS_API_KEYS", ""),
+		GoogleMapsKeyRPS:        googleMapsKeyRPS,
+		GoogleMapsKeyDailyQuota: googleMapsKeyDailyQuota,
+
+		// Retry policy
+		RetryBaseDelay:       retryBaseDelay,
+		RetryMaxDelay:        retryMaxDelay,
+		RetryBudgetRateLimit: retryRateLimit,
+		RetryBudgetTimeout:   retryTimeout,
+		RetryBudgetServer:    retryServer,
+		RetryBudgetNetwork:   retryNetwork,
+
+		// Result batching
+		ResultBatchSize:     resultBatchSize,
+		ResultFlushInterval: resultFlushInterval,
+
+		// Worker autoscaling
+		WorkerAutoscale:         workerAutoscale,
+		WorkerMin:               workerMin,
+		WorkerMax:               workerMax,
+		WorkerScaleInterval:     workerScaleInterval,
+		WorkerScaleMaxErrorRate: workerScaleMaxErrorRate,
+
+		ProcessingCoordination:    getEnv("PROCESSING_COORDINATION", "local"),
+		InstanceID:                getEnv("INSTANCE_ID", ""),
+		InstanceHeartbeatInterval: instanceHeartbeatInterval,
+		InstanceStaleAfter:        instanceStaleAfter,
+		QueuePollInterval:         queuePollInterval,
+		QueueMaxAttempts:          queueMaxAttempts,
+
+		CheckpointEnabled: checkpointEnabled,
+		CheckpointMaxAge:  checkpointMaxAge,
+
+		ReputationEnabled:  reputationEnabled,
+		ReputationCacheTTL: reputationCacheTTL,
+
+		SubmitterRulesEnabled: submitterRulesEnabled,
+		SubmitterRulesRefresh: submitterRulesRefresh,
+
+		ConfigAPIEnabled: configAPIEnabled,
+
+		FeatureFlagsEnabled: featureFlagsEnabled,
+		FeatureFlagsRefresh: featureFlagsRefresh,
+
+		BatchScoringEnabled:      batchScoringEnabled,
+		BatchScoringPollInterval: batchScoringPollInterval,
+		BatchScoringMaxRequests:  batchScoringMaxRequests,
+
+		RescoreEnabled:        rescoreEnabled,
+		RescoreInterval:       rescoreInterval,
+		RescoreMaxVenues:      rescoreMaxVenues,
+		RescoreDailyBudgetUSD: rescoreBudget,
+
+		EmbeddingsEnabled:           embeddingsEnabled,
+		EmbeddingModel:              getEnv("EMBEDDING_MODEL", "text-embedding-3-small"),
+		EmbeddingDimensions:         embeddingDimensions,
+		EmbeddingDuplicateThreshold: embeddingDuplicateThreshold,
+		EmbeddingSpamThreshold:      embeddingSpamThreshold,
+		EmbeddingSpamMinMatches:     embeddingSpamMinMatches,
+		EmbeddingLookback:           embeddingLookback,
+		EmbeddingIndexLimit:         embeddingIndexLimit,
+		EmbeddingIndexRefresh:       embeddingIndexRefresh,
+
+		VenueHoldsEnabled: venueHoldsEnabled,
+
+		VenueClaimsEnabled: venueClaimsEnabled,
+		VenueClaimTimeout:  venueClaimTimeout,
+
+		VenueCommentsEnabled:  venueCommentsEnabled,
+		SavedFiltersEnabled:   savedFiltersEnabled,
+		UIPreferencesEnabled:  uiPreferencesEnabled,
+		FullTextSearchEnabled: fullTextSearchEnabled,
+
+		// Event webhook
+		EventsWebhookURL:     getEnv("EVENTS_WEBHOOK_URL", ""),
+		EventsWebhookSecret:  getEnv("EVENTS_WEBHOOK_SECRET", ""),
+		EventsWebhookTimeout: eventsWebhookTimeout,
 	}
 
 	return cfg
 }
 
+// splitList parses a comma-separated env value, dropping blanks.
+func splitList(v string) []string {
+	var out []string
+	for _, p := range strings.Split(v, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -0,0 +1,197 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// Runtime setting value types.
+const (
+	RuntimeInt   = "int"
+	RuntimeFloat = "float"
+	RuntimeBool  = "bool"
+)
+
+// RuntimeSetting is a setting that can be changed while the service runs through
+// PUT /api/v1/config. Key is its environment variable; Min and Max bound numbers.
+type RuntimeSetting struct {
+	Key         string  `json:"key"`
+	Type        string  `json:"type"`
+	Min         float64 `json:"min"`
+	Max         float64 `json:"max"`
+	Description string  `json:"description"`
+
+	get func(*Config) string
+}
+
+// RuntimeSettings lists the settings applied without a restart, in display order.
+var RuntimeSettings = []RuntimeSetting{
+	{Key: "WORKER_COUNT", Type: RuntimeInt, Min: 1, Max: 100, Description: "Processing workers (ignored while the autoscaler is on)",
+		get: func(c *Config) string { return strconv.Itoa(c.WorkerCount) }},
+	{Key: "APPROVAL_THRESHOLD", Type: RuntimeInt, Min: 0, Max: 100, Description: "Score at or above which venues are auto-approved",
+		get: func(c *Config) string { return strconv.Itoa(c.ApprovalThreshold) }},
+	{Key: "MIN_USER_POINTS_FOR_AVA", Type: RuntimeInt, Min: 0, Max: 1000000, Description: "Submitter points needed for automated review (0 = no minimum)",
+		get: func(c *Config) string { return strconv.Itoa(c.MinUserPointsForAVA) }},
+	{Key: "ONLY_AMBASSADORS", Type: RuntimeBool, Description: "Only ambassador submissions get automated review",
+		get: func(c *Config) string { return strconv.FormatBool(c.OnlyAmbassadors) }},
+	{Key: "RATE_LIMIT_VALIDATE_PER_MINUTE", Type: RuntimeInt, Min: 0, Max: 600, Description: "Bulk validation requests per caller per minute (0 = unlimited)",
+		get: func(c *Config) string { return strconv.Itoa(c.RateLimitBulkPerMinute) }},
+	{Key: "RATE_LIMIT_VALIDATE_BURST", Type: RuntimeInt, Min: 1, Max: 100, Description: "Bulk validation burst",
+		get: func(c *Config) string { return strconv.Itoa(c.RateLimitBulkBurst) }},
+	{Key: "RATE_LIMIT_VALIDATE_SINGLE_PER_MINUTE", Type: RuntimeInt, Min: 0, Max: 600, Description: "Single-venue validation requests per caller per minute (0 = unlimited)",
+		get: func(c *Config) string { return strconv.Itoa(c.RateLimitSinglePerMinute) }},
+	{Key: "RATE_LIMIT_VALIDATE_SINGLE_BURST", Type: RuntimeInt, Min: 1, Max: 100, Description: "Single-venue validation burst",
+		get: func(c *Config) string { return strconv.Itoa(c.RateLimitSingleBurst) }},
+	{Key: "RATE_LIMIT_GOOGLE_RPS", Type: RuntimeInt, Min: 0, Max: 1000, Description: "Google Places tokens per second; Text Search takes 2, Place Details 1 (0 = unlimited)",
+		get: func(c *Config) string { return strconv.Itoa(c.RateLimitGoogleRPS) }},
+	{Key: "RATE_LIMIT_GOOGLE_BURST", Type: RuntimeInt, Min: 2, Max: 2000, Description: "Google Places burst",
+		get: func(c *Config) string { return strconv.Itoa(c.RateLimitGoogleBurst) }},
+	{Key: "RATE_LIMIT_GOOGLE_PHOTOS_RPS", Type: RuntimeInt, Min: 0, Max: 1000, Description: "Google photo downloads per second (0 = unlimited)",
+		get: func(c *Config) string { return strconv.Itoa(c.RateLimitGooglePhotosRPS) }},
+	{Key: "RATE_LIMIT_GOOGLE_PHOTOS_BURST", Type: RuntimeInt, Min: 2, Max: 2000, Description: "Google photo burst",
+		get: func(c *Config) string { return strconv.Itoa(c.RateLimitGooglePhotosBurst) }},
+	{Key: "RATE_LIMIT_OPENAI_RPS", Type: RuntimeInt, Min: 0, Max: 1000, Description: "OpenAI tokens per second; a photo review takes 2, scoring 1 (0 = unlimited)",
+		get: func(c *Config) string { return strconv.Itoa(c.RateLimitOpenAIRPS) }},
+	{Key: "RATE_LIMIT_OPENAI_BURST", Type: RuntimeInt, Min: 2, Max: 2000, Description: "OpenAI burst",
+		get: func(c *Config) string { return strconv.Itoa(c.RateLimitOpenAIBurst) }},
+	{Key: "PHOTO_CHECK_DAILY_BUDGET_USD", Type: RuntimeFloat, Min: 0, Max: 1000, Description: "Daily vision spend cap (0 = unlimited)",
+		get: func(c *Config) string { return formatRuntimeFloat(c.PhotoCheckDailyBudgetUSD) }},
+	{Key: "RESCORE_DAILY_BUDGET_USD", Type: RuntimeFloat, Min: 0, Max: 1000, Description: "Daily re-scoring spend cap (0 = unlimited)",
+		get: func(c *Config) string { return formatRuntimeFloat(c.RescoreDailyBudgetUSD) }},
+}
+
+func formatRuntimeFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// RuntimeValues returns the current value of each runtime setting, as its env var would hold it.
+func (c *Config) RuntimeValues() map[string]string {
+	out := make(map[string]string, len(RuntimeSettings))
+	for _, s := range RuntimeSettings {
+		out[s.Key] = s.get(c)
+	}
+	return out
+}
+
+// NormalizeRuntimeValues checks updates against RuntimeSettings and returns them in the
+// form Load parses. Unknown keys and out-of-range values are validation errors.
+func NormalizeRuntimeValues(updates map[string]string) (map[string]string, error) {
+	v := NewConfigValidator()
+	out := make(map[string]string, len(updates))
+	keys := make([]string, 0, len(updates))
+	for k := range updates {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		raw := strings.TrimSpace(updates[k])
+		s, ok := runtimeSetting(k)
+		if !ok {
+			v.AddError(k, raw, "not a runtime setting")
+			continue
+		}
+		switch s.Type {
+		case RuntimeBool:
+			b, err := strconv.ParseBool(raw)
+			if err != nil {
+				v.AddError(k, raw, "must be true or false")
+				continue
+			}
+			out[k] = strconv.FormatBool(b)
+			continue
+		case RuntimeInt:
+			n, err := strconv.Atoi(raw)
+			if err != nil {
+				v.AddError(k, raw, "must be a whole number")
+				continue
+			}
+			out[k] = strconv.Itoa(n)
+		default:
+			f, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				v.AddError(k, raw, "must be a number")
+				continue
+			}
+			out[k] = formatRuntimeFloat(f)
+		}
+		if f, _ := strconv.ParseFloat(out[k], 64); f < s.Min || f > s.Max {
+			v.AddError(k, raw, fmt.Sprintf("out of range (%s-%s)", formatRuntimeFloat(s.Min), formatRuntimeFloat(s.Max)))
+			delete(out, k)
+		}
+	}
+	if v.HasErrors() {
+		return nil, errs.NewValidation("config.NormalizeRuntimeValues", v.GetErrorsAsString(), nil)
+	}
+	return out, nil
+}
+
+func runtimeSetting(key string) (RuntimeSetting, bool) {
+	for _, s := range RuntimeSettings {
+		if s.Key == key {
+			return s, true
+		}
+	}
+	return RuntimeSetting{}, false
+}
+
+// Set applies runtime setting updates right away: the env vars are set, the configuration
+// reloaded and validated, and subscribers notified as for a reload from CONFIG_FILE. On a
+// validation error nothing changes. The returned Change lists the changed fields; its Old
+// and New hold the configurations before and after.
+func (w *Watcher) Set(updates map[string]string) (Change, error) {
+	values, err := NormalizeRuntimeValues(updates)
+	if err != nil {
+		return Change{}, err
+	}
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	prev := make(map[string]*string, len(values))
+	for k, val := range values {
+		if old, ok := os.LookupEnv(k); ok {
+			prev[k] = &old
+		} else {
+			prev[k] = nil
+		}
+		_ = os.Setenv(k, val)
+	}
+	restore := func() {
+		for k, old := range prev {
+			if old == nil {
+				_ = os.Unsetenv(k)
+			} else {
+				_ = os.Setenv(k, *old)
+			}
+		}
+	}
+
+	newCfg := Load()
+	if err := newCfg.Validate(); err != nil {
+		restore()
+		return Change{}, err
+	}
+	w.mu.Lock()
+	oldCfg := w.cur
+	w.cur = newCfg
+	w.mu.Unlock()
+
+	chg := Change{Old: oldCfg, New: newCfg, Fields: diffKeys(oldCfg, newCfg)}
+	if len(chg.Fields) > 0 {
+		w.mReloads.Inc(1)
+		w.notify(chg)
+	}
+	return chg, nil
+}
+
+// Current returns the configuration last loaded.
+func (w *Watcher) Current() *Config {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.cur
+}
@@ -2,9 +2,12 @@ package config
 
 import (
 	"fmt"
+	"net/mail"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	errs "assisted-venue-approval/pkg/errors"
 )
@@ -82,8 +85,8 @@ func (c *Config) validateRequired(v *ConfigValidator) {
 	if c.DatabaseURL == "" {
 		v.AddError("DATABASE_URL", c.DatabaseURL, "required")
 	}
-	if c.GoogleMapsAPIKey == "" {
-		v.AddError("GOOGLE_MAPS_API_KEY", c.GoogleMapsAPIKey, "required")
+	if c.GoogleMapsAPIKey == "" && c.GoogleMapsAPIKeys == "" {
+		v.AddError("GOOGLE_MAPS_API_KEY", c.GoogleMapsAPIKey, "required (or GOOGLE_MAPS_API_KEYS)")
 	}
 	if c.OpenAIAPIKey == "" {
 		v.AddError("OPENAI_API_KEY", c.OpenAIAPIKey, "required")
@@ -122,6 +125,247 @@ func (c *Config) validateFormats(v *ConfigValidator) {
 	if c.LogFormat != "" && c.LogFormat != "json" && c.LogFormat != "text" {
 		v.AddError("LOG_FORMAT", c.LogFormat, "bad log format")
 	}
+	// Rules content is validated by the decision package; here we only check the file is there.
+	if c.DecisionRulesFile != "" && c.DecisionRulesModTime.IsZero() {
+		v.AddError("DECISION_RULES_FILE", c.DecisionRulesFile, "file not found")
+	}
+	if c.ScoreWeightsFile != "" && c.ScoreWeightsModTime.IsZero() {
+		v.AddError("SCORE_WEIGHTS_FILE", c.ScoreWeightsFile, "file not found")
+	}
+	if c.TrustRulesFile != "" && c.TrustRulesModTime.IsZero() {
+		v.AddError("TRUST_RULES_FILE", c.TrustRulesFile, "file not found")
+	}
+	if c.StyleRulesFile != "" && c.StyleRulesModTime.IsZero() {
+		v.AddError("STYLE_RULES_FILE", c.StyleRulesFile, "file not found")
+	}
+	if c.PrefilterBlockedDomains && len(c.PrefilterBlockedDomainList) == 0 {
+		v.AddError("PREFILTER_BLOCKED_DOMAIN_LIST", "", "required when PREFILTER_BLOCKED_DOMAINS=true")
+	}
+	if c.PrefilterDuplicateSubmission && c.PrefilterDuplicateDays <= 0 {
+		v.AddError("PREFILTER_DUPLICATE_DAYS", strconv.Itoa(c.PrefilterDuplicateDays), "must be positive")
+	}
+	if c.QualityReviewEnabled {
+		if c.QualityReviewMinScore < 0 || c.QualityReviewMinScore > 100 {
+			v.AddError("QUALITY_REVIEW_MIN_SCORE", strconv.Itoa(c.QualityReviewMinScore), "out of range (0-100)")
+		}
+		if c.QualityReviewSamplePercent < 0 || c.QualityReviewSamplePercent > 100 {
+			v.AddError("QUALITY_REVIEW_SAMPLE_PERCENT", strconv.Itoa(c.QualityReviewSamplePercent), "out of range (0-100)")
+		}
+	}
+	if c.PhotoCheckEnabled {
+		if c.PhotoCheckMaxPhotos < 1 || c.PhotoCheckMaxPhotos > 5 {
+			v.AddError("PHOTO_CHECK_MAX_PHOTOS", strconv.Itoa(c.PhotoCheckMaxPhotos), "out of range (1-5)")
+		}
+		if c.PhotoCheckDailyBudgetUSD < 0 {
+			v.AddError("PHOTO_CHECK_DAILY_BUDGET_USD", strconv.FormatFloat(c.PhotoCheckDailyBudgetUSD, 'f', 2, 64), "must not be negative")
+		}
+	}
+	// A random key would hash an address differently in every process, defeating the
+	// one-vote-per-IP check and erasure by IP
+	if c.FeedbackIPMode == "hash" && c.PrivacyHashKey == "" {
+		v.AddError("PRIVACY_HASH_KEY", "", "required when FEEDBACK_IP_MODE=hash")
+	}
+	if c.WebsiteCheckEnabled && c.WebsiteCheckTimeout <= 0 {
+		v.AddError("WEBSITE_CHECK_TIMEOUT", c.WebsiteCheckTimeout.String(), "must be a positive duration")
+	}
+	if c.SocialCheckEnabled && c.SocialCheckTimeout <= 0 {
+		v.AddError("SOCIAL_CHECK_TIMEOUT", c.SocialCheckTimeout.String(), "must be a positive duration")
+	}
+	if c.RateLimitBulkPerMinute < 0 {
+		v.AddError("RATE_LIMIT_VALIDATE_PER_MINUTE", strconv.Itoa(c.RateLimitBulkPerMinute), "must not be negative")
+	}
+	if c.RateLimitSinglePerMinute < 0 {
+		v.AddError("RATE_LIMIT_VALIDATE_SINGLE_PER_MINUTE", strconv.Itoa(c.RateLimitSinglePerMinute), "must not be negative")
+	}
+	for key, n := range map[string]int{
+		"RATE_LIMIT_GOOGLE_RPS":        c.RateLimitGoogleRPS,
+		"RATE_LIMIT_GOOGLE_PHOTOS_RPS": c.RateLimitGooglePhotosRPS,
+		"RATE_LIMIT_OPENAI_RPS":        c.RateLimitOpenAIRPS,
+	} {
+		if n < 0 {
+			v.AddError(key, strconv.Itoa(n), "must not be negative")
+		}
+	}
+	// Weighted requests take up to 2 tokens; a smaller burst would cap them at the burst
+	for key, n := range map[string]int{
+		"RATE_LIMIT_GOOGLE_BURST":        c.RateLimitGoogleBurst,
+		"RATE_LIMIT_GOOGLE_PHOTOS_BURST": c.RateLimitGooglePhotosBurst,
+		"RATE_LIMIT_OPENAI_BURST":        c.RateLimitOpenAIBurst,
+	} {
+		if n < 0 || n == 1 {
+			v.AddError(key, strconv.Itoa(n), "must be 0 (the rate) or at least 2")
+		}
+	}
+	switch c.TranslationProvider {
+	case "", "openai":
+	case "deepl", "google":
+		if c.TranslationAPIKey == "" {
+			v.AddError("TRANSLATION_API_KEY", "", "required for TRANSLATION_PROVIDER="+c.TranslationProvider)
+		}
+	default:
+		v.AddError("TRANSLATION_PROVIDER", c.TranslationProvider, "must be openai, deepl or google")
+	}
+	if c.HistoryRetentionMonths < 0 {
+		v.AddError("HISTORY_RETENTION_MONTHS", strconv.Itoa(c.HistoryRetentionMonths), "must not be negative")
+	}
+	if c.HistoryRetentionMonths > 0 && c.HistoryArchiveInterval < time.Minute {
+		v.AddError("HISTORY_ARCHIVE_INTERVAL", c.HistoryArchiveInterval.String(), "must be at least 1m")
+	}
+	if c.PlacesAPINewPercent < 0 || c.PlacesAPINewPercent > 100 {
+		v.AddError("PLACES_API_NEW_PERCENT", strconv.Itoa(c.PlacesAPINewPercent), "must be between 0 and 100")
+	}
+	if c.GoogleMapsKeyRPS < 0 {
+		v.AddError("GOOGLE_MAPS_KEY_RPS", strconv.Itoa(c.GoogleMapsKeyRPS), "must be non-negative")
+	}
+	if c.GoogleMapsKeyDailyQuota < 0 {
+		v.AddError("GOOGLE_MAPS_KEY_DAILY_QUOTA", strconv.FormatInt(c.GoogleMapsKeyDailyQuota, 10), "must be non-negative")
+	}
+	if c.HistoryArchiveBatch <= 0 {
+		v.AddError("HISTORY_ARCHIVE_BATCH", strconv.Itoa(c.HistoryArchiveBatch), "must be positive")
+	}
+	if c.GoogleCacheMaxAge < 0 {
+		v.AddError("GOOGLE_CACHE_MAX_AGE", c.GoogleCacheMaxAge.String(), "must not be negative")
+	}
+	if c.OpenAIContextBudgetTokens < 1000 {
+		v.AddError("OPENAI_CONTEXT_BUDGET_TOKENS", strconv.Itoa(c.OpenAIContextBudgetTokens), "must be at least 1000")
+	}
+	if c.UnapproveWindow < 0 {
+		v.AddError("UNAPPROVE_WINDOW", c.UnapproveWindow.String(), "must not be negative")
+	}
+	if c.TranslationProvider != "" && c.TranslationTimeout <= 0 {
+		v.AddError("TRANSLATION_TIMEOUT", c.TranslationTimeout.String(), "must be a positive duration")
+	}
+	if c.EventsWebhookURL != "" {
+		if u, err := url.Parse(c.EventsWebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			v.AddError("EVENTS_WEBHOOK_URL", c.EventsWebhookURL, "must be an http(s) URL")
+		}
+		if c.EventsWebhookTimeout <= 0 {
+			v.AddError("EVENTS_WEBHOOK_TIMEOUT", c.EventsWebhookTimeout.String(), "must be a positive duration")
+		}
+	}
+	if c.RetryBaseDelay <= 0 {
+		v.AddError("RETRY_BASE_DELAY", c.RetryBaseDelay.String(), "must be a positive duration")
+	}
+	if c.RetryMaxDelay < c.RetryBaseDelay {
+		v.AddError("RETRY_MAX_DELAY", c.RetryMaxDelay.String(), "must not be less than RETRY_BASE_DELAY")
+	}
+	for name, n := range map[string]int{
+		"RETRY_BUDGET_RATE_LIMIT": c.RetryBudgetRateLimit,
+		"RETRY_BUDGET_TIMEOUT":    c.RetryBudgetTimeout,
+		"RETRY_BUDGET_SERVER":     c.RetryBudgetServer,
+		"RETRY_BUDGET_NETWORK":    c.RetryBudgetNetwork,
+	} {
+		if n < 0 || n > 10 {
+			v.AddError(name, strconv.Itoa(n), "out of range (0-10)")
+		}
+	}
+	if c.ResultBatchSize < 1 || c.ResultBatchSize > 500 {
+		v.AddError("RESULT_BATCH_SIZE", strconv.Itoa(c.ResultBatchSize), "out of range (1-500)")
+	}
+	if c.ResultBatchSize > 1 && c.ResultFlushInterval <= 0 {
+		v.AddError("RESULT_FLUSH_INTERVAL", c.ResultFlushInterval.String(), "must be a positive duration")
+	}
+	if c.WorkerAutoscale {
+		if c.WorkerMin < 1 || c.WorkerMin > 100 {
+			v.AddError("WORKER_MIN", strconv.Itoa(c.WorkerMin), "out of range (1-100)")
+		}
+		if c.WorkerMax < c.WorkerMin || c.WorkerMax > 100 {
+			v.AddError("WORKER_MAX", strconv.Itoa(c.WorkerMax), "must be between WORKER_MIN and 100")
+		}
+		if c.WorkerScaleInterval < time.Second {
+			v.AddError("WORKER_SCALE_INTERVAL", c.WorkerScaleInterval.String(), "must be at least 1s")
+		}
+		if c.WorkerScaleMaxErrorRate <= 0 || c.WorkerScaleMaxErrorRate > 1 {
+			v.AddError("WORKER_SCALE_MAX_ERROR_RATE", strconv.FormatFloat(c.WorkerScaleMaxErrorRate, 'f', -1, 64), "out of range (0-1]")
+		}
+	}
+	switch c.ProcessingCoordination {
+	case "", "local":
+	case "db":
+		if c.InstanceHeartbeatInterval < time.Second {
+			v.AddError("INSTANCE_HEARTBEAT_INTERVAL", c.InstanceHeartbeatInterval.String(), "must be at least 1s")
+		}
+		if c.InstanceStaleAfter < 2*c.InstanceHeartbeatInterval {
+			v.AddError("INSTANCE_STALE_AFTER", c.InstanceStaleAfter.String(), "must be at least twice INSTANCE_HEARTBEAT_INTERVAL")
+		}
+		if c.QueuePollInterval < 100*time.Millisecond {
+			v.AddError("QUEUE_POLL_INTERVAL", c.QueuePollInterval.String(), "must be at least 100ms")
+		}
+		if c.QueueMaxAttempts < 1 {
+			v.AddError("QUEUE_MAX_ATTEMPTS", strconv.Itoa(c.QueueMaxAttempts), "must be at least 1")
+		}
+	default:
+		v.AddError("PROCESSING_COORDINATION", c.ProcessingCoordination, "must be local or db")
+	}
+	if c.CheckpointEnabled && c.CheckpointMaxAge < time.Minute {
+		v.AddError("CHECKPOINT_MAX_AGE", c.CheckpointMaxAge.String(), "must be at least 1m")
+	}
+	if c.ReputationEnabled && c.ReputationCacheTTL < time.Second {
+		v.AddError("REPUTATION_CACHE_TTL", c.ReputationCacheTTL.String(), "must be at least 1s")
+	}
+	if c.SubmitterRulesEnabled && c.SubmitterRulesRefresh < time.Second {
+		v.AddError("SUBMITTER_RULES_REFRESH", c.SubmitterRulesRefresh.String(), "must be at least 1s")
+	}
+	if c.WatchdogEnabled {
+		if c.WatchdogInterval < time.Second {
+			v.AddError("WATCHDOG_INTERVAL", c.WatchdogInterval.String(), "must be at least 1s")
+		}
+		if c.WatchdogGoroutineGrowth < 1 {
+			v.AddError("WATCHDOG_GOROUTINE_GROWTH", strconv.Itoa(c.WatchdogGoroutineGrowth), "must be at least 1")
+		}
+		if c.WatchdogAlertAfter < c.WatchdogInterval {
+			v.AddError("WATCHDOG_ALERT_AFTER", c.WatchdogAlertAfter.String(), "must be at least WATCHDOG_INTERVAL")
+		}
+	}
+	if c.VenueClaimsEnabled && c.VenueClaimTimeout < time.Minute {
+		v.AddError("VENUE_CLAIM_TIMEOUT", c.VenueClaimTimeout.String(), "must be at least 1m")
+	}
+	if c.BatchScoringEnabled {
+		if c.BatchScoringPollInterval < 10*time.Second {
+			v.AddError("BATCH_SCORING_POLL_INTERVAL", c.BatchScoringPollInterval.String(), "must be at least 10s")
+		}
+		// The Batch API takes at most 50,000 requests per job
+		if c.BatchScoringMaxRequests < 1 || c.BatchScoringMaxRequests > 50000 {
+			v.AddError("BATCH_SCORING_MAX_REQUESTS", strconv.Itoa(c.BatchScoringMaxRequests), "must be between 1 and 50000")
+		}
+	}
+	if c.EmbeddingsEnabled {
+		if c.EmbeddingModel == "" {
+			v.AddError("EMBEDDING_MODEL", "", "required when EMBEDDINGS_ENABLED=true")
+		}
+		if c.EmbeddingDimensions < 0 {
+			v.AddError("EMBEDDING_DIMENSIONS", strconv.Itoa(c.EmbeddingDimensions), "must not be negative")
+		}
+		if c.EmbeddingDuplicateThreshold <= 0 || c.EmbeddingDuplicateThreshold > 1 {
+			v.AddError("EMBEDDING_DUPLICATE_THRESHOLD", strconv.FormatFloat(c.EmbeddingDuplicateThreshold, 'f', -1, 64), "out of range (0-1]")
+		}
+		if c.EmbeddingSpamThreshold <= 0 || c.EmbeddingSpamThreshold > 1 {
+			v.AddError("EMBEDDING_SPAM_THRESHOLD", strconv.FormatFloat(c.EmbeddingSpamThreshold, 'f', -1, 64), "out of range (0-1]")
+		}
+		if c.EmbeddingSpamMinMatches < 2 {
+			v.AddError("EMBEDDING_SPAM_MIN_MATCHES", strconv.Itoa(c.EmbeddingSpamMinMatches), "must be at least 2")
+		}
+		if c.EmbeddingLookback < 24*time.Hour {
+			v.AddError("EMBEDDING_LOOKBACK", c.EmbeddingLookback.String(), "must be at least 24h")
+		}
+		if c.EmbeddingIndexLimit < 1 {
+			v.AddError("EMBEDDING_INDEX_LIMIT", strconv.Itoa(c.EmbeddingIndexLimit), "must be at least 1")
+		}
+		if c.EmbeddingIndexRefresh < time.Second {
+			v.AddError("EMBEDDING_INDEX_REFRESH", c.EmbeddingIndexRefresh.String(), "must be at least 1s")
+		}
+	}
+	if c.NotifyEnabled {
+		if c.SMTPHost == "" {
+			v.AddError("SMTP_HOST", "", "required when NOTIFY_ENABLED=true")
+		}
+		if c.SMTPPort < 1 || c.SMTPPort > 65535 {
+			v.AddError("SMTP_PORT", strconv.Itoa(c.SMTPPort), "bad smtp port")
+		}
+		if _, err := mail.ParseAddress(c.NotifyFrom); err != nil {
+			v.AddError("NOTIFY_FROM", c.NotifyFrom, "bad sender address")
+		}
+	}
 }
 
 // validateRanges checks value ranges
@@ -138,6 +382,9 @@ func (c *Config) validateRanges(v *ConfigValidator) {
 	if c.DBMaxIdleConns < 0 || c.DBMaxIdleConns > c.DBMaxOpenConns {
 		v.AddError("DB_MAX_IDLE_CONNS", strconv.Itoa(c.DBMaxIdleConns), "must be 0..max_open")
 	}
+	if c.DBSlowQueryThreshold < 0 {
+		v.AddError("DB_SLOW_QUERY_THRESHOLD", c.DBSlowQueryThreshold.String(), "must be >= 0")
+	}
 	if c.DBConnMaxLifetime < 1 || c.DBConnMaxLifetime > 60 {
 		v.AddError("DB_CONN_MAX_LIFETIME_MINUTES", strconv.Itoa(c.DBConnMaxLifetime), "out of range (1-60m)")
 	}
@@ -32,6 +32,7 @@ const subBuf = 4
 // Keep it simple: polling interval-based. TODO: consider fsnotify if needed.
 type Watcher struct {
 	mu        sync.RWMutex
+	reloadMu  sync.Mutex // serializes polling with Set
 	cur       *Config
 	closed    bool
 	intv      time.Duration
@@ -116,6 +117,9 @@ func (w *Watcher) loop(ctx context.Context) {
 }
 
 func (w *Watcher) checkOnce() {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
 	// Optional: reload .env file if changed
 	if w.filePath != "" {
 		if fi, err := os.Stat(w.filePath); err == nil {
@@ -175,11 +179,27 @@ func diffKeys(a, b *Config) []string {
 	}
 	appendIf(a.ApprovalThreshold != b.ApprovalThreshold, "ApprovalThreshold")
 	appendIf(a.WorkerCount != b.WorkerCount, "WorkerCount")
+	appendIf(a.MinUserPointsForAVA != b.MinUserPointsForAVA || a.OnlyAmbassadors != b.OnlyAmbassadors, "AVA")
 	appendIf(a.LogLevel != b.LogLevel, "LogLevel")
 	appendIf(a.LogFormat != b.LogFormat, "LogFormat")
 	appendIf(a.EnableFileLogging != b.EnableFileLogging, "EnableFileLogging")
 	appendIf(a.MetricsEnabled != b.MetricsEnabled || a.MetricsPath != b.MetricsPath, "Metrics")
 	appendIf(a.ProfilingEnabled != b.ProfilingEnabled || a.ProfilingPort != b.ProfilingPort, "Profiling")
+	appendIf(a.DecisionRulesFile != b.DecisionRulesFile || !a.DecisionRulesModTime.Equal(b.DecisionRulesModTime), "DecisionRules")
+	appendIf(a.ScoreWeightsFile != b.ScoreWeightsFile || !a.ScoreWeightsModTime.Equal(b.ScoreWeightsModTime), "ScoreWeights")
+	appendIf(a.TrustRulesFile != b.TrustRulesFile || !a.TrustRulesModTime.Equal(b.TrustRulesModTime), "TrustRules")
+	appendIf(a.StyleRulesFile != b.StyleRulesFile || !a.StyleRulesModTime.Equal(b.StyleRulesModTime), "StyleRules")
+	appendIf(a.PromptDir != b.PromptDir || !a.PromptDirModTime.Equal(b.PromptDirModTime), "Prompts")
+	appendIf(a.RateLimitBulkPerMinute != b.RateLimitBulkPerMinute || a.RateLimitBulkBurst != b.RateLimitBulkBurst ||
+		a.RateLimitSinglePerMinute != b.RateLimitSinglePerMinute || a.RateLimitSingleBurst != b.RateLimitSingleBurst, "RateLimits")
+	appendIf(a.RateLimitGoogleRPS != b.RateLimitGoogleRPS || a.RateLimitGoogleBurst != b.RateLimitGoogleBurst ||
+		a.RateLimitGooglePhotosRPS != b.RateLimitGooglePhotosRPS || a.RateLimitGooglePhotosBurst != b.RateLimitGooglePhotosBurst ||
+		a.RateLimitOpenAIRPS != b.RateLimitOpenAIRPS || a.RateLimitOpenAIBurst != b.RateLimitOpenAIBurst, "ExternalRateLimits")
+	appendIf(a.PhotoCheckDailyBudgetUSD != b.PhotoCheckDailyBudgetUSD || a.RescoreDailyBudgetUSD != b.RescoreDailyBudgetUSD, "Budgets")
+	appendIf(a.PrefilterEmptyName != b.PrefilterEmptyName || a.PrefilterURLName != b.PrefilterURLName ||
+		a.PrefilterBlockedDomains != b.PrefilterBlockedDomains || strings.Join(a.PrefilterBlockedDomainList, ",") != strings.Join(b.PrefilterBlockedDomainList, ",") ||
+		a.PrefilterProfanity != b.PrefilterProfanity || strings.Join(a.PrefilterProfanityWords, ",") != strings.Join(b.PrefilterProfanityWords, ",") ||
+		a.PrefilterDuplicateSubmission != b.PrefilterDuplicateSubmission || a.PrefilterDuplicateDays != b.PrefilterDuplicateDays, "Prefilter")
 	// Add others as needed
 	return f
 }
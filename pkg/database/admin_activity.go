@@ -0,0 +1,59 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// GetAdminActivityCtx aggregates manual approve/reject audit logs per admin for [from, to).
+// Overrides compare the decision with the AI status of the history row it was made on;
+// handling time runs from that row's processed_at to the decision.
+func (db *DB) GetAdminActivityCtx(ctx context.Context, from, to time.Time) ([]models.AdminActivity, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT a.admin_id, COALESCE(m.username, ''),
+	                 SUM(a.status = 'approved'),
+	                 SUM(a.status = 'rejected'),
+	                 SUM((a.status = 'approved' AND h.validation_status = 'rejected')
+	                     OR (a.status = 'rejected' AND h.validation_status = 'approved')),
+	                 SUM(a.reason LIKE 'Batch %'),
+	                 AVG(CASE WHEN a.created_at >= h.processed_at THEN TIMESTAMPDIFF(SECOND, h.processed_at, a.created_at) END),
+	                 MIN(a.created_at), MAX(a.created_at)
+	          FROM venue_validation_audit_logs a
+	          LEFT JOIN venue_validation_histories h ON h.id = a.history_id
+	          LEFT JOIN members m ON m.id = a.admin_id
+	          WHERE a.admin_id IS NOT NULL
+	            AND a.status IN ('approved', 'rejected')
+	            AND a.created_at >= ? AND a.created_at < ?
+	          GROUP BY a.admin_id, m.username
+	          ORDER BY COUNT(*) DESC, a.admin_id ASC`
+	rows, err := db.conn.QueryContext(ctx, query, from, to)
+	if err != nil {
+		return nil, errs.NewDB("GetAdminActivityCtx", "failed to query admin activity", err)
+	}
+	defer rows.Close()
+
+	var out []models.AdminActivity
+	for rows.Next() {
+		var a models.AdminActivity
+		var overrides, batch sql.NullInt64
+		var avg sql.NullFloat64
+		if err := rows.Scan(&a.AdminID, &a.Username, &a.Approvals, &a.Rejections, &overrides, &batch,
+			&avg, &a.FirstAction, &a.LastAction); err != nil {
+			return nil, errs.NewDB("GetAdminActivityCtx", "failed to scan admin activity", err)
+		}
+		a.Overrides = int(overrides.Int64)
+		a.BatchActions = int(batch.Int64)
+		a.AvgHandlingSeconds = avg.Float64
+		out = append(out, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("GetAdminActivityCtx", "failed to iterate admin activity", err)
+	}
+	return out, nil
+}
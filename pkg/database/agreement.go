@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"assisted-venue-approval/internal/analytics"
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// GetDecisionPairsCtx returns, for every venue an admin approved or rejected in [from, to),
+// the latest such decision next to the AI verdict of the history row it was made on.
+// Decisions without a linked history row are skipped: there is no AI verdict to compare.
+func (db *DB) GetDecisionPairsCtx(ctx context.Context, from, to time.Time) ([]analytics.DecisionPair, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT a.venue_id, a.status, h.validation_status, h.validation_score,
+	                 COALESCE(h.prompt_version, ''), COALESCE(v.entrytype, 0), COALESCE(v.category, 0),
+	                 COALESCE(v.path, ''), COALESCE(v.name, '')
+	          FROM venue_validation_audit_logs a
+	          JOIN (SELECT MAX(id) AS id
+	                FROM venue_validation_audit_logs
+	                WHERE admin_id IS NOT NULL
+	                  AND status IN ('approved', 'rejected')
+	                  AND created_at >= ? AND created_at < ?
+	                GROUP BY venue_id) latest ON latest.id = a.id
+	          JOIN venue_validation_histories h ON h.id = a.history_id
+	          LEFT JOIN venues v ON v.id = a.venue_id`
+	rows, err := db.conn.QueryContext(ctx, query, from, to)
+	if err != nil {
+		return nil, errs.NewDB("GetDecisionPairsCtx", "failed to query decision pairs", err)
+	}
+	defer rows.Close()
+
+	var out []analytics.DecisionPair
+	for rows.Next() {
+		var p analytics.DecisionPair
+		var entryType, category sql.NullInt64
+		if err := rows.Scan(&p.VenueID, &p.Human, &p.AIStatus, &p.AIScore, &p.PromptVersion,
+			&entryType, &category, &p.Path, &p.Name); err != nil {
+			return nil, errs.NewDB("GetDecisionPairsCtx", "failed to scan decision pair", err)
+		}
+		p.Category = models.CategoryLabel(int(entryType.Int64), int(category.Int64))
+		out = append(out, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("GetDecisionPairsCtx", "failed to iterate decision pairs", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,132 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+const apiTokenColumns = `id, name, prefix, token_hash, scopes, admin_id, created_at, expires_at, last_used_at, revoked_at`
+
+// CreateAPITokenCtx stores a new token; only its hash is persisted.
+func (db *DB) CreateAPITokenCtx(ctx context.Context, t *models.APIToken) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	query := `INSERT INTO api_tokens (name, prefix, token_hash, scopes, admin_id, created_at, expires_at)
+	          VALUES (?, ?, ?, ?, ?, ?, ?)`
+	res, err := db.conn.ExecContext(ctx, query, t.Name, t.Prefix, t.TokenHash,
+		strings.Join(t.Scopes, ","), t.AdminID, t.CreatedAt, t.ExpiresAt)
+	if err != nil {
+		return errs.NewDB("CreateAPITokenCtx", "failed to insert API token", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return errs.NewDB("CreateAPITokenCtx", "failed to get last insert ID", err)
+	}
+	t.ID = id
+	return nil
+}
+
+// ListAPITokensCtx returns all tokens, newest first, revoked ones included.
+func (db *DB) ListAPITokensCtx(ctx context.Context) ([]models.APIToken, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	rows, err := db.conn.QueryContext(ctx, `SELECT `+apiTokenColumns+` FROM api_tokens ORDER BY id DESC`)
+	if err != nil {
+		return nil, errs.NewDB("ListAPITokensCtx", "failed to query API tokens", err)
+	}
+	defer rows.Close()
+
+	var out []models.APIToken
+	for rows.Next() {
+		t, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, errs.NewDB("ListAPITokensCtx", "failed to scan API token", err)
+		}
+		out = append(out, *t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("ListAPITokensCtx", "failed to iterate API tokens", err)
+	}
+	return out, nil
+}
+
+// GetAPITokenByHashCtx returns the token with the given hash, or nil when there is none.
+func (db *DB) GetAPITokenByHashCtx(ctx context.Context, hash string) (*models.APIToken, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	row := db.conn.QueryRowContext(ctx, `SELECT `+apiTokenColumns+` FROM api_tokens WHERE token_hash = ?`, hash)
+	t, err := scanAPIToken(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errs.NewDB("GetAPITokenByHashCtx", "failed to load API token", err)
+	}
+	return t, nil
+}
+
+// RevokeAPITokenCtx marks a token revoked and reports whether it exists.
+// Revoking twice keeps the first timestamp.
+func (db *DB) RevokeAPITokenCtx(ctx context.Context, id int64, at time.Time) (bool, error) {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	_, err := db.conn.ExecContext(ctx, `UPDATE api_tokens SET revoked_at = COALESCE(revoked_at, ?) WHERE id = ?`, at, id)
+	if err != nil {
+		return false, errs.NewDB("RevokeAPITokenCtx", "failed to revoke API token", err)
+	}
+	var found int
+	// RowsAffected is 0 for an already revoked token too, so check existence separately
+	if err := db.conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM api_tokens WHERE id = ?`, id).Scan(&found); err != nil {
+		return false, errs.NewDB("RevokeAPITokenCtx", "failed to check API token", err)
+	}
+	return found > 0, nil
+}
+
+// TouchAPITokenCtx records when a token was last used.
+func (db *DB) TouchAPITokenCtx(ctx context.Context, id int64, at time.Time) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	if _, err := db.conn.ExecContext(ctx, `UPDATE api_tokens SET last_used_at = ? WHERE id = ?`, at, id); err != nil {
+		return errs.NewDB("TouchAPITokenCtx", "failed to update last use", err)
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAPIToken(s rowScanner) (*models.APIToken, error) {
+	var t models.APIToken
+	var scopes string
+	var expires, used, revoked sql.NullTime
+	if err := s.Scan(&t.ID, &t.Name, &t.Prefix, &t.TokenHash, &scopes, &t.AdminID, &t.CreatedAt,
+		&expires, &used, &revoked); err != nil {
+		return nil, err
+	}
+	if scopes != "" {
+		t.Scopes = strings.Split(scopes, ",")
+	}
+	t.ExpiresAt = nullTimePtr(expires)
+	t.LastUsedAt = nullTimePtr(used)
+	t.RevokedAt = nullTimePtr(revoked)
+	return &t, nil
+}
+
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"strings"
 
 	"assisted-venue-approval/internal/domain"
 	errs "assisted-venue-approval/pkg/errors"
@@ -40,6 +41,27 @@ func (db *DB) CreateAuditLogCtx(ctx context.Context, log *domain.VenueValidation
 	return nil
 }
 
+// CreateAuditLogTx is CreateAuditLogCtx inside an existing transaction.
+func (db *DB) CreateAuditLogTx(ctx context.Context, tx *sql.Tx, log *domain.VenueValidationAuditLog) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	query := `INSERT INTO venue_validation_audit_logs
+	          (venue_id, history_id, admin_id, status, reason, data_replacements, created_at)
+	          VALUES (?, ?, ?, ?, ?, ?, ?)`
+	result, err := tx.ExecContext(ctx, query,
+		log.VenueID, log.HistoryID, log.AdminID, log.Status, log.Reason, log.DataReplacements, log.CreatedAt)
+	if err != nil {
+		return errs.NewDB("CreateAuditLogTx", "failed to insert audit log", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return errs.NewDB("CreateAuditLogTx", "failed to get last insert ID", err)
+	}
+	log.ID = id
+	return nil
+}
+
 // GetAuditLogsByHistoryIDCtx retrieves all audit logs for a specific validation history
 func (db *DB) GetAuditLogsByHistoryIDCtx(ctx context.Context, historyID int64) ([]domain.VenueValidationAuditLog, error) {
 	ctx, cancel := db.withReadTimeout(ctx)
@@ -243,3 +265,83 @@ func (db *DB) GetAuditLogsByVenueIDCtx(ctx context.Context, venueID int64) ([]do
 
 	return logs, nil
 }
+
+// ListAuditLogsCtx returns audit logs across all venues matching f, newest first, and the
+// total number of matches for paging.
+func (db *DB) ListAuditLogsCtx(ctx context.Context, f domain.AuditLogFilter) ([]domain.AuditLogEntry, int, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	where := []string{"1=1"}
+	var args []interface{}
+	if f.AdminID > 0 {
+		where = append(where, "a.admin_id = ?")
+		args = append(args, f.AdminID)
+	}
+	if f.Status != "" {
+		where = append(where, "a.status = ?")
+		args = append(args, f.Status)
+	}
+	if !f.From.IsZero() {
+		where = append(where, "a.created_at >= ?")
+		args = append(args, f.From)
+	}
+	if !f.To.IsZero() {
+		where = append(where, "a.created_at < ?")
+		args = append(args, f.To)
+	}
+	if f.HasReplacements {
+		where = append(where, "a.data_replacements IS NOT NULL AND a.data_replacements NOT IN ('', '{}')")
+	}
+	cond := strings.Join(where, " AND ")
+
+	var total int
+	if err := db.conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM venue_validation_audit_logs a WHERE `+cond, args...).Scan(&total); err != nil {
+		return nil, 0, errs.NewDB("ListAuditLogsCtx", "failed to count audit logs", err)
+	}
+
+	query := `SELECT a.id, a.venue_id, a.history_id, a.admin_id, a.status, a.reason, a.data_replacements, a.created_at,
+	                 COALESCE(v.name, ''), COALESCE(m.username, '')
+	          FROM venue_validation_audit_logs a
+	          LEFT JOIN venues v ON v.id = a.venue_id
+	          LEFT JOIN members m ON m.id = a.admin_id
+	          WHERE ` + cond + `
+	          ORDER BY a.created_at DESC, a.id DESC
+	          LIMIT ? OFFSET ?`
+	rows, err := db.conn.QueryContext(ctx, query, append(args, f.Limit, f.Offset)...)
+	if err != nil {
+		return nil, 0, errs.NewDB("ListAuditLogsCtx", "failed to query audit logs", err)
+	}
+	defer rows.Close()
+
+	var out []domain.AuditLogEntry
+	for rows.Next() {
+		var e domain.AuditLogEntry
+		var historyID sql.NullInt64
+		var adminID sql.NullInt32
+		var reason, dataReplacements sql.NullString
+		if err := rows.Scan(&e.ID, &e.VenueID, &historyID, &adminID, &e.Status, &reason, &dataReplacements,
+			&e.CreatedAt, &e.VenueName, &e.AdminUsername); err != nil {
+			return nil, 0, errs.NewDB("ListAuditLogsCtx", "failed to scan audit log", err)
+		}
+		if historyID.Valid {
+			hid := historyID.Int64
+			e.HistoryID = &hid
+		}
+		if adminID.Valid {
+			id := int(adminID.Int32)
+			e.AdminID = &id
+		}
+		if reason.Valid {
+			e.Reason = &reason.String
+		}
+		if dataReplacements.Valid {
+			e.DataReplacements = &dataReplacements.String
+		}
+		out = append(out, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, errs.NewDB("ListAuditLogsCtx", "row iteration error", err)
+	}
+	return out, total, nil
+}
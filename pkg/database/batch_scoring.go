@@ -0,0 +1,221 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// AddBatchItemCtx stores a venue's scoring call for the next batch submission and sets item.ID.
+func (db *DB) AddBatchItemCtx(ctx context.Context, item *models.BatchScoringItem) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	var runID *int64
+	if item.RunID != 0 {
+		runID = &item.RunID
+	}
+	var usage, translation any
+	if item.TokenUsage != nil {
+		b, err := json.Marshal(item.TokenUsage)
+		if err != nil {
+			return errs.NewValidation("AddBatchItemCtx", "failed to encode token usage", err)
+		}
+		usage = string(b)
+	}
+	if len(item.Translation) > 0 {
+		translation = string(item.Translation)
+	}
+	res, err := db.conn.ExecContext(ctx, `INSERT INTO scoring_batch_items
+		(venue_id, run_id, status, request, prompt_version, token_usage, enriched_venue, translation, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, NOW())`,
+		item.VenueID, runID, models.BatchItemPending, string(item.Request), item.PromptVersion, usage,
+		string(item.EnrichedVenue), translation)
+	if err != nil {
+		return errs.NewDB("AddBatchItemCtx", "failed to store batch scoring item", err)
+	}
+	if item.ID, err = res.LastInsertId(); err != nil {
+		return errs.NewDB("AddBatchItemCtx", "failed to get item id", err)
+	}
+	item.Status = models.BatchItemPending
+	return nil
+}
+
+// ListPendingBatchItemsCtx returns up to limit items not yet submitted, oldest first.
+func (db *DB) ListPendingBatchItemsCtx(ctx context.Context, limit int) ([]models.BatchScoringItem, error) {
+	return db.listBatchItems(ctx, "ListPendingBatchItemsCtx", `WHERE status = ? ORDER BY id LIMIT ?`, models.BatchItemPending, limit)
+}
+
+// GetBatchItemsCtx returns the items submitted in a batch.
+func (db *DB) GetBatchItemsCtx(ctx context.Context, batchID int64) ([]models.BatchScoringItem, error) {
+	return db.listBatchItems(ctx, "GetBatchItemsCtx", `WHERE batch_id = ? ORDER BY id`, batchID)
+}
+
+func (db *DB) listBatchItems(ctx context.Context, op, where string, args ...any) ([]models.BatchScoringItem, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	rows, err := db.conn.QueryContext(ctx, `SELECT id, batch_id, venue_id, COALESCE(run_id, 0), status, request,
+	                                        prompt_version, token_usage, enriched_venue, translation, COALESCE(error, ''), created_at
+	                                        FROM scoring_batch_items `+where, args...)
+	if err != nil {
+		return nil, errs.NewDB(op, "failed to query batch scoring items", err)
+	}
+	defer rows.Close()
+
+	var items []models.BatchScoringItem
+	for rows.Next() {
+		var it models.BatchScoringItem
+		var batchID sql.NullInt64
+		var request, enriched string
+		var usage, translation sql.NullString
+		if err := rows.Scan(&it.ID, &batchID, &it.VenueID, &it.RunID, &it.Status, &request,
+			&it.PromptVersion, &usage, &enriched, &translation, &it.Error, &it.CreatedAt); err != nil {
+			return nil, errs.NewDB(op, "failed to scan batch scoring item", err)
+		}
+		if batchID.Valid {
+			it.BatchID = &batchID.Int64
+		}
+		it.Request = []byte(request)
+		it.EnrichedVenue = []byte(enriched)
+		if usage.Valid {
+			if err := json.Unmarshal([]byte(usage.String), &it.TokenUsage); err != nil {
+				return nil, errs.NewDB(op, fmt.Sprintf("unreadable token usage of item %d", it.ID), err)
+			}
+		}
+		if translation.Valid {
+			it.Translation = []byte(translation.String)
+		}
+		items = append(items, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB(op, "failed to iterate batch scoring items", err)
+	}
+	return items, nil
+}
+
+// CreateScoringBatchCtx records a submitted batch and moves its items from pending to
+// submitted in one transaction. Sets b.ID.
+func (db *DB) CreateScoringBatchCtx(ctx context.Context, b *models.ScoringBatch, itemIDs []int64) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return errs.NewDB("CreateScoringBatchCtx", "failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `INSERT INTO scoring_batches
+		(openai_batch_id, input_file_id, status, item_count, created_at, updated_at)
+		VALUES (?, ?, ?, ?, NOW(), NOW())`, b.OpenAIBatchID, b.InputFileID, b.Status, b.ItemCount)
+	if err != nil {
+		return errs.NewDB("CreateScoringBatchCtx", "failed to insert scoring batch", err)
+	}
+	if b.ID, err = res.LastInsertId(); err != nil {
+		return errs.NewDB("CreateScoringBatchCtx", "failed to get batch id", err)
+	}
+	if len(itemIDs) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(itemIDs)), ", ")
+		args := make([]any, 0, len(itemIDs)+2)
+		args = append(args, b.ID, models.BatchItemSubmitted)
+		for _, id := range itemIDs {
+			args = append(args, id)
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE scoring_batch_items SET batch_id = ?, status = ?
+		                                  WHERE id IN (`+placeholders+`)`, args...); err != nil {
+			return errs.NewDB("CreateScoringBatchCtx", "failed to assign items to batch", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return errs.NewDB("CreateScoringBatchCtx", "failed to commit", err)
+	}
+	return nil
+}
+
+// UpdateScoringBatchCtx stores a batch's status and output files. Ingested batches get
+// ingested_at set.
+func (db *DB) UpdateScoringBatchCtx(ctx context.Context, b *models.ScoringBatch) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	query := `UPDATE scoring_batches
+	          SET status = ?, output_file_id = ?, error_file_id = ?, updated_at = NOW(),
+	              ingested_at = IF(? AND ingested_at IS NULL, NOW(), ingested_at)
+	          WHERE id = ?`
+	if _, err := db.conn.ExecContext(ctx, query, b.Status, b.OutputFileID, b.ErrorFileID,
+		b.Status == models.BatchIngested, b.ID); err != nil {
+		return errs.NewDB("UpdateScoringBatchCtx", "failed to update scoring batch", err)
+	}
+	return nil
+}
+
+// ListScoringBatchesCtx returns up to limit batches newest first; with open, only those whose
+// replies have not been ingested yet.
+func (db *DB) ListScoringBatchesCtx(ctx context.Context, open bool, limit int) ([]models.ScoringBatch, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	where := ""
+	args := []any{}
+	if open {
+		where = "WHERE status <> ?"
+		args = append(args, models.BatchIngested)
+	}
+	args = append(args, limit)
+	rows, err := db.conn.QueryContext(ctx, `SELECT id, openai_batch_id, input_file_id, output_file_id, error_file_id,
+	                                        status, item_count, created_at, updated_at, ingested_at
+	                                        FROM scoring_batches `+where+`
+	                                        ORDER BY id DESC LIMIT ?`, args...)
+	if err != nil {
+		return nil, errs.NewDB("ListScoringBatchesCtx", "failed to query scoring batches", err)
+	}
+	defer rows.Close()
+
+	var batches []models.ScoringBatch
+	for rows.Next() {
+		var b models.ScoringBatch
+		var output, errFile sql.NullString
+		var ingested sql.NullTime
+		if err := rows.Scan(&b.ID, &b.OpenAIBatchID, &b.InputFileID, &output, &errFile,
+			&b.Status, &b.ItemCount, &b.CreatedAt, &b.UpdatedAt, &ingested); err != nil {
+			return nil, errs.NewDB("ListScoringBatchesCtx", "failed to scan scoring batch", err)
+		}
+		if output.Valid {
+			b.OutputFileID = &output.String
+		}
+		if errFile.Valid {
+			b.ErrorFileID = &errFile.String
+		}
+		b.IngestedAt = nullTimePtr(ingested)
+		batches = append(batches, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("ListScoringBatchesCtx", "failed to iterate scoring batches", err)
+	}
+	return batches, nil
+}
+
+// FinishBatchItemCtx records that an item's reply was ingested or could not be used.
+func (db *DB) FinishBatchItemCtx(ctx context.Context, id int64, status, errMsg string) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	var e *string
+	if errMsg != "" {
+		if len(errMsg) > 500 {
+			errMsg = errMsg[:500]
+		}
+		e = &errMsg
+	}
+	if _, err := db.conn.ExecContext(ctx, `UPDATE scoring_batch_items SET status = ?, error = ? WHERE id = ?`,
+		status, e, id); err != nil {
+		return errs.NewDB("FinishBatchItemCtx", "failed to update batch scoring item", err)
+	}
+	return nil
+}
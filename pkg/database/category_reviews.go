@@ -0,0 +1,60 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// RecordCategoryReviewCtx stores r and sets r.ID.
+func (db *DB) RecordCategoryReviewCtx(ctx context.Context, r *models.CategoryReview) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	res, err := db.conn.ExecContext(ctx, `INSERT INTO venue_category_reviews
+		(venue_id, history_id, submitted_category, suggested_category, final_category, confidence, mismatch, source, outcome, admin_id, reviewed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		r.VenueID, r.HistoryID, r.Submitted, r.Suggested, r.Final, r.Confidence, r.Mismatch, r.Source, r.Outcome, r.AdminID, r.ReviewedAt)
+	if err != nil {
+		return errs.NewDB("RecordCategoryReviewCtx", "failed to insert category review", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return errs.NewDB("RecordCategoryReviewCtx", "failed to get inserted id", err)
+	}
+	r.ID = id
+	return nil
+}
+
+// CategoryReviewStatsCtx counts category reviews in [from, to) by classifier source,
+// confidence and mismatch state.
+func (db *DB) CategoryReviewStatsCtx(ctx context.Context, from, to time.Time) ([]models.CategoryReviewStat, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	rows, err := db.conn.QueryContext(ctx, `SELECT source, confidence, mismatch,
+			SUM(outcome = 'accepted'), SUM(outcome = 'overridden')
+		FROM venue_category_reviews
+		WHERE reviewed_at >= ? AND reviewed_at < ?
+		GROUP BY source, confidence, mismatch
+		ORDER BY source, confidence, mismatch`, from, to)
+	if err != nil {
+		return nil, errs.NewDB("CategoryReviewStatsCtx", "failed to query category review stats", err)
+	}
+	defer rows.Close()
+
+	var out []models.CategoryReviewStat
+	for rows.Next() {
+		var s models.CategoryReviewStat
+		if err := rows.Scan(&s.Source, &s.Confidence, &s.Mismatch, &s.Accepted, &s.Overridden); err != nil {
+			return nil, errs.NewDB("CategoryReviewStatsCtx", "failed to scan category review stat", err)
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("CategoryReviewStatsCtx", "failed to iterate category review stats", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,167 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// changeRequestColumns is what scanChangeRequest reads, joined with venues v and members m.
+const changeRequestColumns = `cr.id, cr.venue_id, COALESCE(v.name, ''), cr.user_id, COALESCE(m.username, ''),
+	COALESCE(m.trusted, 0) > 0,
+	EXISTS (SELECT 1 FROM venue_admin va WHERE va.venue_id = cr.venue_id AND va.user_id = cr.user_id),
+	cr.proposed_data, COALESCE(cr.note, ''), cr.status, cr.created_at,
+	cr.ai_score, cr.ai_output, COALESCE(cr.recommendation, ''), COALESCE(cr.recommendation_reason, ''), cr.validated_at,
+	cr.decided_by, cr.decided_at, COALESCE(cr.decision_reason, '')`
+
+const changeRequestFrom = `FROM venue_change_requests cr
+	LEFT JOIN venues v ON v.id = cr.venue_id
+	LEFT JOIN members m ON m.id = cr.user_id`
+
+// CreateChangeRequestCtx stores a pending change request and sets its ID.
+func (db *DB) CreateChangeRequestCtx(ctx context.Context, cr *domain.VenueChangeRequest) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	proposed, err := json.Marshal(cr.Proposed)
+	if err != nil {
+		return errs.NewValidation("CreateChangeRequestCtx", "failed to encode proposed data", err)
+	}
+	res, err := db.conn.ExecContext(ctx, `INSERT INTO venue_change_requests (venue_id, user_id, proposed_data, note, status, created_at)
+		VALUES (?, ?, ?, NULLIF(?, ''), ?, ?)`,
+		cr.VenueID, cr.Editor.ID, proposed, cr.Note, domain.ChangeRequestPending, cr.CreatedAt)
+	if err != nil {
+		return errs.NewDB("CreateChangeRequestCtx", "failed to insert change request", err)
+	}
+	if cr.ID, err = res.LastInsertId(); err != nil {
+		return errs.NewDB("CreateChangeRequestCtx", "failed to get change request id", err)
+	}
+	cr.Status = domain.ChangeRequestPending
+	return nil
+}
+
+// GetChangeRequestCtx returns a change request with its editor.
+func (db *DB) GetChangeRequestCtx(ctx context.Context, id int64) (*domain.VenueChangeRequest, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	row := db.conn.QueryRowContext(ctx, `SELECT `+changeRequestColumns+` `+changeRequestFrom+` WHERE cr.id = ?`, id)
+	cr, err := scanChangeRequest(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, errs.NewNotFound("GetChangeRequestCtx", fmt.Sprintf("change request %d not found", id), err)
+	}
+	if err != nil {
+		return nil, errs.NewDB("GetChangeRequestCtx", "failed to scan change request", err)
+	}
+	return cr, nil
+}
+
+// ListPendingChangeRequestsCtx returns up to limit undecided change requests of approved
+// venues, oldest first.
+func (db *DB) ListPendingChangeRequestsCtx(ctx context.Context, limit int) ([]domain.VenueChangeRequest, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	rows, err := db.conn.QueryContext(ctx, `SELECT `+changeRequestColumns+` `+changeRequestFrom+`
+		WHERE cr.status = ? AND v.active = 1
+		ORDER BY cr.created_at, cr.id
+		LIMIT ?`, domain.ChangeRequestPending, limit)
+	if err != nil {
+		return nil, errs.NewDB("ListPendingChangeRequestsCtx", "failed to query change requests", err)
+	}
+	defer rows.Close()
+
+	var out []domain.VenueChangeRequest
+	for rows.Next() {
+		cr, err := scanChangeRequest(rows.Scan)
+		if err != nil {
+			return nil, errs.NewDB("ListPendingChangeRequestsCtx", "failed to scan change request", err)
+		}
+		out = append(out, *cr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("ListPendingChangeRequestsCtx", "failed to iterate change requests", err)
+	}
+	return out, nil
+}
+
+// SaveChangeAssessmentCtx stores the validation of a pending change request: the AI
+// assessment and the decision engine's recommendation. A decided request is left as is.
+func (db *DB) SaveChangeAssessmentCtx(ctx context.Context, id int64, a *models.ChangeAssessment, recommendation, reason string) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	out, err := json.Marshal(a)
+	if err != nil {
+		return errs.NewValidation("SaveChangeAssessmentCtx", "failed to encode assessment", err)
+	}
+	if _, err := db.conn.ExecContext(ctx, `UPDATE venue_change_requests
+		SET ai_score = ?, ai_output = ?, recommendation = ?, recommendation_reason = ?, validated_at = NOW()
+		WHERE id = ? AND status = ?`,
+		a.Score, out, recommendation, reason, id, domain.ChangeRequestPending); err != nil {
+		return errs.NewDB("SaveChangeAssessmentCtx", "failed to store change assessment", err)
+	}
+	return nil
+}
+
+// DecideChangeRequestCtx closes a pending change request as applied or rejected. It reports
+// false when the request does not exist or was already decided.
+func (db *DB) DecideChangeRequestCtx(ctx context.Context, id int64, adminID int, status, reason string) (bool, error) {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	res, err := db.conn.ExecContext(ctx, `UPDATE venue_change_requests
+		SET status = ?, decided_by = ?, decided_at = NOW(), decision_reason = NULLIF(?, '')
+		WHERE id = ? AND status = ?`,
+		status, adminID, reason, id, domain.ChangeRequestPending)
+	if err != nil {
+		return false, errs.NewDB("DecideChangeRequestCtx", "failed to decide change request", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errs.NewDB("DecideChangeRequestCtx", "failed to get affected rows", err)
+	}
+	return n > 0, nil
+}
+
+func scanChangeRequest(scan func(dest ...any) error) (*domain.VenueChangeRequest, error) {
+	var (
+		cr                     domain.VenueChangeRequest
+		proposed               []byte
+		score, decidedBy       sql.NullInt64
+		aiOutput               []byte
+		validatedAt, decidedAt sql.NullTime
+	)
+	if err := scan(&cr.ID, &cr.VenueID, &cr.VenueName, &cr.Editor.ID, &cr.Editor.Username,
+		&cr.Editor.Trusted, &cr.Editor.IsVenueAdmin,
+		&proposed, &cr.Note, &cr.Status, &cr.CreatedAt,
+		&score, &aiOutput, &cr.Recommendation, &cr.RecommendationReason, &validatedAt,
+		&decidedBy, &decidedAt, &cr.DecisionReason); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(proposed, &cr.Proposed); err != nil {
+		return nil, fmt.Errorf("change request %d: proposed data: %w", cr.ID, err)
+	}
+	if score.Valid {
+		s := int(score.Int64)
+		cr.Score = &s
+	}
+	if len(aiOutput) > 0 {
+		var a models.ChangeAssessment
+		if err := json.Unmarshal(aiOutput, &a); err == nil {
+			cr.Assessment = &a
+		}
+	}
+	if decidedBy.Valid {
+		id := int(decidedBy.Int64)
+		cr.DecidedBy = &id
+	}
+	cr.ValidatedAt = nullTimePtr(validatedAt)
+	cr.DecidedAt = nullTimePtr(decidedAt)
+	return &cr, nil
+}
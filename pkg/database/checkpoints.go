@@ -0,0 +1,99 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// SaveCheckpointsCtx stores job checkpoints, replacing any earlier one for the same venue.
+func (db *DB) SaveCheckpointsCtx(ctx context.Context, cps []models.JobCheckpoint) error {
+	if len(cps) == 0 {
+		return nil
+	}
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	var b strings.Builder
+	b.WriteString(`INSERT INTO processing_checkpoints
+		(venue_id, stage, venue_updated_at, enriched_venue, validation_result, created_at) VALUES `)
+	args := make([]any, 0, len(cps)*6)
+	for i, cp := range cps {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString("(?, ?, ?, ?, ?, ?)")
+		var vr any
+		if len(cp.ValidationResult) > 0 {
+			vr = string(cp.ValidationResult)
+		}
+		args = append(args, cp.VenueID, cp.Stage, cp.VenueUpdated, string(cp.EnrichedVenue), vr, cp.CreatedAt)
+	}
+	b.WriteString(` ON DUPLICATE KEY UPDATE stage = VALUES(stage), venue_updated_at = VALUES(venue_updated_at),
+		enriched_venue = VALUES(enriched_venue), validation_result = VALUES(validation_result),
+		created_at = VALUES(created_at)`)
+	if _, err := db.conn.ExecContext(ctx, b.String(), args...); err != nil {
+		return errs.NewDB("SaveCheckpointsCtx", fmt.Sprintf("failed to save %d checkpoints", len(cps)), err)
+	}
+	return nil
+}
+
+// GetCheckpointCtx returns the venue's checkpoint if it is younger than maxAge, or nil.
+func (db *DB) GetCheckpointCtx(ctx context.Context, venueID int64, maxAge time.Duration) (*models.JobCheckpoint, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	cp := models.JobCheckpoint{VenueID: venueID}
+	var updated sql.NullTime
+	var enriched string
+	var vr sql.NullString
+	err := db.conn.QueryRowContext(ctx, `SELECT stage, venue_updated_at, enriched_venue, validation_result, created_at
+	                                     FROM processing_checkpoints
+	                                     WHERE venue_id = ? AND created_at >= ?`, venueID, time.Now().Add(-maxAge)).
+		Scan(&cp.Stage, &updated, &enriched, &vr, &cp.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errs.NewDB("GetCheckpointCtx", "failed to load checkpoint", err)
+	}
+	cp.VenueUpdated = nullTimePtr(updated)
+	cp.EnrichedVenue = []byte(enriched)
+	if vr.Valid {
+		cp.ValidationResult = []byte(vr.String)
+	}
+	return &cp, nil
+}
+
+// DeleteCheckpointCtx removes a venue's checkpoint once its job has finished.
+func (db *DB) DeleteCheckpointCtx(ctx context.Context, venueID int64) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	if _, err := db.conn.ExecContext(ctx, `DELETE FROM processing_checkpoints WHERE venue_id = ?`, venueID); err != nil {
+		return errs.NewDB("DeleteCheckpointCtx", "failed to delete checkpoint", err)
+	}
+	return nil
+}
+
+// PruneCheckpointsCtx deletes checkpoints older than maxAge and returns how many went.
+func (db *DB) PruneCheckpointsCtx(ctx context.Context, maxAge time.Duration) (int, error) {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	res, err := db.conn.ExecContext(ctx, `DELETE FROM processing_checkpoints WHERE created_at < ?`, time.Now().Add(-maxAge))
+	if err != nil {
+		return 0, errs.NewDB("PruneCheckpointsCtx", "failed to prune checkpoints", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, errs.NewDB("PruneCheckpointsCtx", "failed to get affected rows", err)
+	}
+	return int(n), nil
+}
@@ -0,0 +1,111 @@
+package database
+
+import (
+	"context"
+	"strings"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// claimedClause matches venues with an active claim by one admin; its arguments are the
+// admin ID and the claim timeout in seconds.
+const claimedClause = `EXISTS (SELECT 1 FROM venue_claims vc WHERE vc.venue_id = v.id
+	AND vc.admin_id = ? AND vc.last_active_at >= NOW() - INTERVAL ? SECOND)`
+
+func (db *DB) claimTimeoutSeconds() int {
+	return int(db.claimTimeout.Seconds())
+}
+
+// ClaimVenueCtx claims the venue for adminID when it is unclaimed, its claim has lapsed or
+// adminID already holds it; the latter only refreshes the claim. It returns the venue's
+// claim afterwards and whether it belongs to adminID.
+func (db *DB) ClaimVenueCtx(ctx context.Context, venueID int64, adminID int) (*models.VenueClaim, bool, error) {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	if _, err := db.conn.ExecContext(ctx, `INSERT IGNORE INTO venue_claims (venue_id, admin_id, claimed_at, last_active_at)
+		VALUES (?, ?, NOW(), NOW())`, venueID, adminID); err != nil {
+		return nil, false, errs.NewDB("ClaimVenueCtx", "failed to insert venue claim", err)
+	}
+	// claimed_at is assigned first so it still sees the previous admin_id
+	if _, err := db.conn.ExecContext(ctx, `UPDATE venue_claims
+		SET claimed_at = IF(admin_id = ?, claimed_at, NOW()), admin_id = ?, last_active_at = NOW()
+		WHERE venue_id = ? AND (admin_id = ? OR last_active_at < NOW() - INTERVAL ? SECOND)`,
+		adminID, adminID, venueID, adminID, db.claimTimeoutSeconds()); err != nil {
+		return nil, false, errs.NewDB("ClaimVenueCtx", "failed to update venue claim", err)
+	}
+
+	var c models.VenueClaim
+	if err := db.conn.QueryRowContext(ctx, `SELECT venue_id, admin_id, claimed_at, last_active_at
+		FROM venue_claims WHERE venue_id = ?`, venueID).Scan(&c.VenueID, &c.AdminID, &c.ClaimedAt, &c.LastActiveAt); err != nil {
+		return nil, false, errs.NewDB("ClaimVenueCtx", "failed to read venue claim", err)
+	}
+	return &c, c.AdminID == adminID, nil
+}
+
+// TouchClaimCtx marks adminID active on the venue if they hold an active claim on it.
+func (db *DB) TouchClaimCtx(ctx context.Context, venueID int64, adminID int) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	if _, err := db.conn.ExecContext(ctx, `UPDATE venue_claims SET last_active_at = NOW()
+		WHERE venue_id = ? AND admin_id = ? AND last_active_at >= NOW() - INTERVAL ? SECOND`,
+		venueID, adminID, db.claimTimeoutSeconds()); err != nil {
+		return errs.NewDB("TouchClaimCtx", "failed to refresh venue claim", err)
+	}
+	return nil
+}
+
+// ReleaseClaimCtx drops adminID's claim on the venue. It reports false when adminID held
+// none.
+func (db *DB) ReleaseClaimCtx(ctx context.Context, venueID int64, adminID int) (bool, error) {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	res, err := db.conn.ExecContext(ctx, `DELETE FROM venue_claims WHERE venue_id = ? AND admin_id = ?`, venueID, adminID)
+	if err != nil {
+		return false, errs.NewDB("ReleaseClaimCtx", "failed to delete venue claim", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errs.NewDB("ReleaseClaimCtx", "failed to get affected rows", err)
+	}
+	return n > 0, nil
+}
+
+// GetActiveClaimsCtx returns the claims on venueIDs that have not lapsed, by venue ID.
+func (db *DB) GetActiveClaimsCtx(ctx context.Context, venueIDs []int64) (map[int64]models.VenueClaim, error) {
+	out := make(map[int64]models.VenueClaim)
+	if len(venueIDs) == 0 {
+		return out, nil
+	}
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(venueIDs)), ", ")
+	args := make([]any, 0, len(venueIDs)+1)
+	for _, id := range venueIDs {
+		args = append(args, id)
+	}
+	args = append(args, db.claimTimeoutSeconds())
+	rows, err := db.conn.QueryContext(ctx, `SELECT venue_id, admin_id, claimed_at, last_active_at
+		FROM venue_claims WHERE venue_id IN (`+placeholders+`)
+		AND last_active_at >= NOW() - INTERVAL ? SECOND`, args...)
+	if err != nil {
+		return nil, errs.NewDB("GetActiveClaimsCtx", "failed to query venue claims", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c models.VenueClaim
+		if err := rows.Scan(&c.VenueID, &c.AdminID, &c.ClaimedAt, &c.LastActiveAt); err != nil {
+			return nil, errs.NewDB("GetActiveClaimsCtx", "failed to scan venue claim", err)
+		}
+		out[c.VenueID] = c
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("GetActiveClaimsCtx", "failed to iterate venue claims", err)
+	}
+	return out, nil
+}
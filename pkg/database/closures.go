@@ -0,0 +1,136 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// ListClosureCandidatesCtx returns up to limit approved venues whose latest validation found a
+// Google place and which were not checked for closure since checkedBefore, never-checked
+// venues first.
+func (db *DB) ListClosureCandidatesCtx(ctx context.Context, checkedBefore time.Time, limit int) ([]models.ClosureCandidate, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	rows, err := db.conn.QueryContext(ctx, `SELECT v.id, h.google_place_id,
+			COALESCE(JSON_UNQUOTE(JSON_EXTRACT(h.google_place_data, '$.business_status')), ''), h.processed_at
+		FROM venues v
+		JOIN venue_validation_histories h
+		  ON h.id = (SELECT MAX(id) FROM venue_validation_histories WHERE venue_id = v.id)
+		LEFT JOIN venue_closure_checks c ON c.venue_id = v.id
+		WHERE v.active = 1 AND h.google_place_id IS NOT NULL AND h.google_place_id <> ''
+		  AND (c.checked_at IS NULL OR c.checked_at < ?)
+		ORDER BY c.checked_at IS NOT NULL, c.checked_at, v.id
+		LIMIT ?`, checkedBefore, limit)
+	if err != nil {
+		return nil, errs.NewDB("ListClosureCandidatesCtx", "failed to query closure candidates", err)
+	}
+	defer rows.Close()
+
+	var out []models.ClosureCandidate
+	for rows.Next() {
+		var c models.ClosureCandidate
+		if err := rows.Scan(&c.VenueID, &c.PlaceID, &c.StoredStatus, &c.StoredAt); err != nil {
+			return nil, errs.NewDB("ListClosureCandidatesCtx", "failed to scan closure candidate", err)
+		}
+		out = append(out, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("ListClosureCandidatesCtx", "failed to iterate closure candidates", err)
+	}
+	return out, nil
+}
+
+// RecordClosureCheckCtx stores the outcome of a venue's closure check; status is empty when
+// Google could not answer.
+func (db *DB) RecordClosureCheckCtx(ctx context.Context, venueID int64, placeID, status string, checkedAt time.Time) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	if _, err := db.conn.ExecContext(ctx, `INSERT INTO venue_closure_checks (venue_id, place_id, business_status, checked_at)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE place_id = VALUES(place_id), business_status = VALUES(business_status),
+		  checked_at = VALUES(checked_at)`, venueID, placeID, status, checkedAt); err != nil {
+		return errs.NewDB("RecordClosureCheckCtx", "failed to record closure check", err)
+	}
+	return nil
+}
+
+// OpenClosureReviewCtx stores r and sets r.ID unless the venue already has a review for the
+// same place, open or resolved. It reports whether a review was opened.
+func (db *DB) OpenClosureReviewCtx(ctx context.Context, r *models.ClosureReview) (bool, error) {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	res, err := db.conn.ExecContext(ctx, `INSERT INTO venue_closure_reviews (venue_id, place_id, business_status, created_at)
+		SELECT ?, ?, ?, ? FROM DUAL
+		WHERE NOT EXISTS (SELECT 1 FROM venue_closure_reviews WHERE venue_id = ? AND place_id = ?)`,
+		r.VenueID, r.PlaceID, r.BusinessStatus, r.CreatedAt, r.VenueID, r.PlaceID)
+	if err != nil {
+		return false, errs.NewDB("OpenClosureReviewCtx", "failed to insert closure review", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errs.NewDB("OpenClosureReviewCtx", "failed to get affected rows", err)
+	}
+	if n == 0 {
+		return false, nil
+	}
+	if r.ID, err = res.LastInsertId(); err != nil {
+		return false, errs.NewDB("OpenClosureReviewCtx", "failed to get closure review id", err)
+	}
+	return true, nil
+}
+
+// ListOpenClosureReviewsCtx returns up to limit unresolved closure reviews of venues that are
+// still approved, oldest first.
+func (db *DB) ListOpenClosureReviewsCtx(ctx context.Context, limit int) ([]models.ClosureReview, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	rows, err := db.conn.QueryContext(ctx, `SELECT r.id, r.venue_id, v.name, r.place_id, r.business_status, r.created_at
+		FROM venue_closure_reviews r
+		JOIN venues v ON v.id = r.venue_id
+		WHERE r.resolved_at IS NULL AND v.active = 1
+		ORDER BY r.created_at, r.id
+		LIMIT ?`, limit)
+	if err != nil {
+		return nil, errs.NewDB("ListOpenClosureReviewsCtx", "failed to query closure reviews", err)
+	}
+	defer rows.Close()
+
+	var out []models.ClosureReview
+	for rows.Next() {
+		var r models.ClosureReview
+		if err := rows.Scan(&r.ID, &r.VenueID, &r.VenueName, &r.PlaceID, &r.BusinessStatus, &r.CreatedAt); err != nil {
+			return nil, errs.NewDB("ListOpenClosureReviewsCtx", "failed to scan closure review", err)
+		}
+		out = append(out, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("ListOpenClosureReviewsCtx", "failed to iterate closure reviews", err)
+	}
+	return out, nil
+}
+
+// ResolveClosureReviewCtx records an editor's resolution of an open review. It reports false
+// when the review does not exist or was already resolved.
+func (db *DB) ResolveClosureReviewCtx(ctx context.Context, id int64, adminID int, resolution string) (bool, error) {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	res, err := db.conn.ExecContext(ctx, `UPDATE venue_closure_reviews
+		SET resolution = ?, resolved_by = ?, resolved_at = NOW()
+		WHERE id = ? AND resolved_at IS NULL`, resolution, adminID, id)
+	if err != nil {
+		return false, errs.NewDB("ResolveClosureReviewCtx", "failed to resolve closure review", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errs.NewDB("ResolveClosureReviewCtx", "failed to get affected rows", err)
+	}
+	return n > 0, nil
+}
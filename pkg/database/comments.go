@@ -0,0 +1,83 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// CreateVenueCommentCtx stores a comment and sets c.ID. A reply is only stored when its
+// parent is a comment on the same venue.
+func (db *DB) CreateVenueCommentCtx(ctx context.Context, c *models.VenueComment) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	var mentions any
+	if len(c.Mentions) > 0 {
+		b, err := json.Marshal(c.Mentions)
+		if err != nil {
+			return errs.NewValidation("CreateVenueCommentCtx", "failed to encode mentions", err)
+		}
+		mentions = string(b)
+	}
+	var res sql.Result
+	var err error
+	if c.ParentID == nil {
+		res, err = db.conn.ExecContext(ctx, `INSERT INTO venue_comments (venue_id, parent_id, admin_id, body, mentions, created_at)
+			VALUES (?, NULL, ?, ?, ?, ?)`, c.VenueID, c.AdminID, c.Body, mentions, c.CreatedAt)
+	} else {
+		res, err = db.conn.ExecContext(ctx, `INSERT INTO venue_comments (venue_id, parent_id, admin_id, body, mentions, created_at)
+			SELECT ?, id, ?, ?, ?, ? FROM venue_comments WHERE id = ? AND venue_id = ?`,
+			c.VenueID, c.AdminID, c.Body, mentions, c.CreatedAt, *c.ParentID, c.VenueID)
+	}
+	if err != nil {
+		return errs.NewDB("CreateVenueCommentCtx", "failed to insert venue comment", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return errs.NewValidation("CreateVenueCommentCtx", fmt.Sprintf("comment %d is not on venue %d", *c.ParentID, c.VenueID), nil)
+	}
+	if c.ID, err = res.LastInsertId(); err != nil {
+		return errs.NewDB("CreateVenueCommentCtx", "failed to get comment id", err)
+	}
+	return nil
+}
+
+// ListVenueCommentsCtx returns the venue's comments, oldest first.
+func (db *DB) ListVenueCommentsCtx(ctx context.Context, venueID int64) ([]models.VenueComment, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	rows, err := db.conn.QueryContext(ctx, `SELECT id, venue_id, parent_id, admin_id, body, mentions, created_at
+		FROM venue_comments WHERE venue_id = ? ORDER BY id`, venueID)
+	if err != nil {
+		return nil, errs.NewDB("ListVenueCommentsCtx", "failed to query venue comments", err)
+	}
+	defer rows.Close()
+
+	var out []models.VenueComment
+	for rows.Next() {
+		var c models.VenueComment
+		var parent sql.NullInt64
+		var mentions sql.NullString
+		if err := rows.Scan(&c.ID, &c.VenueID, &parent, &c.AdminID, &c.Body, &mentions, &c.CreatedAt); err != nil {
+			return nil, errs.NewDB("ListVenueCommentsCtx", "failed to scan venue comment", err)
+		}
+		if parent.Valid {
+			c.ParentID = &parent.Int64
+		}
+		if mentions.Valid {
+			if err := json.Unmarshal([]byte(mentions.String), &c.Mentions); err != nil {
+				return nil, errs.NewDB("ListVenueCommentsCtx", fmt.Sprintf("unreadable mentions of comment %d", c.ID), err)
+			}
+		}
+		out = append(out, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("ListVenueCommentsCtx", "failed to iterate venue comments", err)
+	}
+	return out, nil
+}
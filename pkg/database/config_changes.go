@@ -0,0 +1,56 @@
+package database
+
+import (
+	"context"
+	"strings"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// AddConfigChangesCtx stores the settings changed by one config update.
+func (db *DB) AddConfigChangesCtx(ctx context.Context, changes []models.ConfigChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	rows := make([]string, len(changes))
+	args := make([]interface{}, 0, 5*len(changes))
+	for i, c := range changes {
+		rows[i] = "(?, ?, ?, ?, ?)"
+		args = append(args, c.AdminID, c.Setting, c.OldValue, c.NewValue, c.ChangedAt)
+	}
+	if _, err := db.conn.ExecContext(ctx, `INSERT INTO config_changes (admin_id, setting, old_value, new_value, changed_at)
+		VALUES `+strings.Join(rows, ", "), args...); err != nil {
+		return errs.NewDB("AddConfigChangesCtx", "failed to insert config changes", err)
+	}
+	return nil
+}
+
+// ListConfigChangesCtx returns up to limit config changes, newest first.
+func (db *DB) ListConfigChangesCtx(ctx context.Context, limit int) ([]models.ConfigChange, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	rows, err := db.conn.QueryContext(ctx, `SELECT id, admin_id, setting, old_value, new_value, changed_at
+		FROM config_changes ORDER BY id DESC LIMIT ?`, limit)
+	if err != nil {
+		return nil, errs.NewDB("ListConfigChangesCtx", "failed to query config changes", err)
+	}
+	defer rows.Close()
+
+	var out []models.ConfigChange
+	for rows.Next() {
+		var c models.ConfigChange
+		if err := rows.Scan(&c.ID, &c.AdminID, &c.Setting, &c.OldValue, &c.NewValue, &c.ChangedAt); err != nil {
+			return nil, errs.NewDB("ListConfigChangesCtx", "failed to scan config change", err)
+		}
+		out = append(out, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("ListConfigChangesCtx", "failed to iterate config changes", err)
+	}
+	return out, nil
+}
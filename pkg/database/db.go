@@ -13,8 +13,6 @@ import (
 	"assisted-venue-approval/internal/models"
 	"assisted-venue-approval/pkg/config"
 	errs "assisted-venue-approval/pkg/errors"
-
-	_ "github.com/go-sql-driver/mysql"
 )
 
 type DB struct {
@@ -22,10 +20,14 @@ type DB struct {
 	stmts        map[string]*sql.Stmt
 	readTimeout  time.Duration
 	writeTimeout time.Duration
+	// holds keeps venues with an active hold out of the manual review queue
+	holds bool
+	// claimTimeout is how long a review claim lasts without activity (0 = claims off)
+	claimTimeout time.Duration
 }
 
 func New(databaseURL string) (*DB, error) {
-	conn, err := sql.Open("mysql", databaseURL)
+	conn, err := openInstrumented(databaseURL, constants.DBSlowQueryThresholdDefault)
 	if err != nil {
 		return nil, err
 	}
@@ -56,7 +58,7 @@ func New(databaseURL string) (*DB, error) {
 
 // NewWithConfig creates a database connection with custom configuration settings
 func NewWithConfig(databaseURL string, cfg *config.Config) (*DB, error) {
-	conn, err := sql.Open("mysql", databaseURL)
+	conn, err := openInstrumented(databaseURL, cfg.DBSlowQueryThreshold)
 	if err != nil {
 		return nil, err
 	}
@@ -85,6 +87,10 @@ func NewWithConfig(databaseURL string, cfg *config.Config) (*DB, error) {
 		stmts:        make(map[string]*sql.Stmt),
 		readTimeout:  rt,
 		writeTimeout: wt,
+		holds:        cfg.VenueHoldsEnabled,
+	}
+	if cfg.VenueClaimsEnabled {
+		db.claimTimeout = cfg.VenueClaimTimeout
 	}
 
 	if err := db.prepareStatements(); err != nil {
@@ -102,8 +108,8 @@ func (db *DB) prepareStatements() error {
                              WHERE id = ?`,
 		"insertValidationHistory": `INSERT INTO venue_validation_histories 
                                    (venue_id, validation_score, validation_status, validation_notes, 
-                                    score_breakdown, google_place_id, google_place_found, google_place_data, ai_output_data, processed_at) 
-                                   VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, NOW())`,
+                                    score_breakdown, google_place_id, google_place_found, google_place_data, ai_output_data, run_id, processed_at) 
+                                   VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW())`,
 	}
 
 	for name, query := range statements {
@@ -341,6 +347,28 @@ func (db *DB) ApproveVenueWithDataReplacementCtx(ctx context.Context, approvalDa
 	ctx, cancel := db.withWriteTimeout(ctx)
 	defer cancel()
 
+	query, args := approvalUpdate(approvalData)
+	if _, err := db.conn.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to approve venue with data replacement: %w", err)
+	}
+
+	return nil
+}
+
+// ApproveVenueWithDataReplacementTx is ApproveVenueWithDataReplacementCtx inside an existing transaction.
+func (db *DB) ApproveVenueWithDataReplacementTx(ctx context.Context, tx *sql.Tx, approvalData *domain.ApprovalData) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	query, args := approvalUpdate(approvalData)
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to approve venue with data replacement (tx): %w", err)
+	}
+	return nil
+}
+
+// approvalUpdate builds the venue UPDATE for an approval, setting only the replaced fields.
+func approvalUpdate(approvalData *domain.ApprovalData) (string, []interface{}) {
 	// Build dynamic UPDATE query based on which fields need updating
 	setClauses := []string{"active = 1", "admin_last_update = NOW()", "made_active_at = NOW()"}
 	args := []interface{}{}
@@ -350,6 +378,16 @@ func (db *DB) ApproveVenueWithDataReplacementCtx(ctx context.Context, approvalDa
 	args = append(args, approvalData.AdminID)
 
 	// Add fields that have replacement values
+	setClauses, args = appendFieldClauses(setClauses, args, approvalData)
+
+	// Add WHERE clause
+	args = append(args, approvalData.VenueID)
+
+	return fmt.Sprintf("UPDATE venues SET %s WHERE id = ?", strings.Join(setClauses, ", ")), args
+}
+
+// appendFieldClauses adds a SET clause for every venue field approvalData sets.
+func appendFieldClauses(setClauses []string, args []interface{}, approvalData *domain.ApprovalData) ([]string, []interface{}) {
 	if approvalData.Name != nil {
 		setClauses = append(setClauses, "name = ?")
 		args = append(args, *approvalData.Name)
@@ -406,6 +444,11 @@ func (db *DB) ApproveVenueWithDataReplacementCtx(ctx context.Context, approvalDa
 		}
 	}
 
+	if approvalData.Timezone != nil {
+		setClauses = append(setClauses, "timezone = ?")
+		args = append(args, *approvalData.Timezone)
+	}
+
 	if approvalData.EntryType != nil {
 		setClauses = append(setClauses, "entrytype = ?")
 		args = append(args, *approvalData.EntryType)
@@ -431,20 +474,119 @@ func (db *DB) ApproveVenueWithDataReplacementCtx(ctx context.Context, approvalDa
 		args = append(args, *approvalData.Category)
 	}
 
-	// Add WHERE clause
-	args = append(args, approvalData.VenueID)
+	return setClauses, args
+}
 
-	// Build final query
-	query := fmt.Sprintf("UPDATE venues SET %s WHERE id = ?", strings.Join(setClauses, ", "))
+// ApplyVenueChangesCtx writes the fields of an accepted change request to an approved venue.
+// Unlike an approval it leaves the status and approval tracking alone.
+func (db *DB) ApplyVenueChangesCtx(ctx context.Context, changes *domain.ApprovalData) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
 
-	// Execute update
+	query, args := changeUpdate(changes)
 	if _, err := db.conn.ExecContext(ctx, query, args...); err != nil {
-		return fmt.Errorf("failed to approve venue with data replacement: %w", err)
+		return errs.NewDB("database.ApplyVenueChangesCtx", "failed to apply venue changes", err)
+	}
+	return nil
+}
+
+// ApplyVenueChangesTx is ApplyVenueChangesCtx inside an existing transaction.
+func (db *DB) ApplyVenueChangesTx(ctx context.Context, tx *sql.Tx, changes *domain.ApprovalData) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	query, args := changeUpdate(changes)
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return errs.NewDB("database.ApplyVenueChangesTx", "failed to apply venue changes", err)
+	}
+	return nil
+}
+
+// changeUpdate builds the venue UPDATE for an applied change request.
+func changeUpdate(changes *domain.ApprovalData) (string, []interface{}) {
+	setClauses := []string{"admin_last_update = NOW()", "updated_by_id = ?"}
+	args := []interface{}{changes.AdminID}
+
+	setClauses, args = appendFieldClauses(setClauses, args, changes)
+	// A change of only one coordinate still moves the point; appendFieldClauses sets it when both change
+	if (changes.Lat == nil) != (changes.Lng == nil) {
+		// Note: POINT takes (longitude, latitude) - longitude first!
+		setClauses = append(setClauses, "geolocation = POINT(lng, lat)")
 	}
 
+	args = append(args, changes.VenueID)
+	return fmt.Sprintf("UPDATE venues SET %s WHERE id = ?", strings.Join(setClauses, ", ")), args
+}
+
+// RevertVenueApprovalCtx undoes an approval: the venue goes back to pending and the fields
+// the approval replaced get their original values back.
+func (db *DB) RevertVenueApprovalCtx(ctx context.Context, venueID int64, replacements *domain.VenueDataReplacement, notes string) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	query, args := revertUpdate(venueID, replacements, notes)
+	if _, err := db.conn.ExecContext(ctx, query, args...); err != nil {
+		return errs.NewDB("database.RevertVenueApprovalCtx", "failed to revert venue approval", err)
+	}
 	return nil
 }
 
+// RevertVenueApprovalTx is RevertVenueApprovalCtx inside an existing transaction.
+func (db *DB) RevertVenueApprovalTx(ctx context.Context, tx *sql.Tx, venueID int64, replacements *domain.VenueDataReplacement, notes string) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	query, args := revertUpdate(venueID, replacements, notes)
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return errs.NewDB("database.RevertVenueApprovalTx", "failed to revert venue approval", err)
+	}
+	return nil
+}
+
+// revertUpdate builds the venue UPDATE for a reverted approval. Every field the approval
+// replaced is set to its recorded original; a nil original was NULL before the approval.
+func revertUpdate(venueID int64, vdr *domain.VenueDataReplacement, notes string) (string, []interface{}) {
+	setClauses := []string{"active = 0", "admin_note = ?", "admin_last_update = NOW()", "made_active_at = NULL", "made_active_by_id = NULL"}
+	args := []interface{}{notes}
+
+	if vdr.HasReplacements() {
+		orig := vdr.Original
+		if orig == nil {
+			orig = &domain.VenueFieldData{}
+		}
+		r := vdr.Replacement
+		set := func(replaced bool, column string, value interface{}) {
+			if replaced {
+				setClauses = append(setClauses, column+" = ?")
+				args = append(args, value)
+			}
+		}
+		// Assignments run left to right in MySQL, so geolocation below sees the restored lat/lng
+		set(r.Name != nil, "name", orig.Name)
+		set(r.Address != nil, "location", orig.Address)
+		set(r.Description != nil, "additionalinfo", orig.Description)
+		set(r.Lat != nil, "lat", orig.Lat)
+		set(r.Lng != nil, "lng", orig.Lng)
+		if (r.Lat != nil || r.Lng != nil) && (r.Lat == nil || orig.Lat != nil) && (r.Lng == nil || orig.Lng != nil) {
+			// Note: POINT takes (longitude, latitude) - longitude first!
+			setClauses = append(setClauses, "geolocation = POINT(lng, lat)")
+		}
+		set(r.Phone != nil, "phone", orig.Phone)
+		set(r.Website != nil, "url", orig.Website)
+		set(r.OpenHours != nil, "openhours", orig.OpenHours)
+		set(r.OpenHoursNote != nil, "openhours_note", orig.OpenHoursNote)
+		set(r.Timezone != nil, "timezone", orig.Timezone)
+		set(r.EntryType != nil, "entrytype", orig.EntryType)
+		set(r.Path != nil, "path", orig.Path)
+		set(r.VegOnly != nil, "vegonly", orig.VegOnly)
+		set(r.Vegan != nil, "vegan", orig.Vegan)
+		set(r.Category != nil, "category", orig.Category)
+	}
+
+	args = append(args, venueID)
+	return fmt.Sprintf("UPDATE venues SET %s WHERE id = ?", strings.Join(setClauses, ", ")), args
+}
+
 // BatchUpdateVenueStatus updates multiple venues in a single transaction
 func (db *DB) BatchUpdateVenueStatus(venueIDs []int64, active int, notes string, updatedByID *int) error {
 	if len(venueIDs) == 0 {
@@ -509,9 +651,9 @@ func (db *DB) SaveValidationResult(result *models.ValidationResult) error {
 
 	historyQuery := `INSERT INTO venue_validation_histories 
 	    (venue_id, validation_score, validation_status, validation_notes, 
-	     score_breakdown, ai_output_data, prompt_version, processed_at) 
-	    VALUES (?, ?, ?, ?, ?, ?, ?, NOW())`
-	args := []any{result.VenueID, result.Score, result.Status, result.Notes, string(scoreBreakdownJSON), result.AIOutputData, result.PromptVersion}
+	     score_breakdown, ai_output_data, prompt_version, run_id, processed_at) 
+	    VALUES (?, ?, ?, ?, ?, ?, ?, ?, NOW())`
+	args := []any{result.VenueID, result.Score, result.Status, result.Notes, string(scoreBreakdownJSON), result.AIOutputData, result.PromptVersion, result.RunID}
 
 	if _, err = tx.Exec(historyQuery, args...); err != nil {
 		return fmt.Errorf("failed to insert validation history: %w", err)
@@ -543,9 +685,9 @@ func (db *DB) SaveValidationResultCtx(ctx context.Context, result *models.Valida
 
 	historyQuery := `INSERT INTO venue_validation_histories 
 	    (venue_id, validation_score, validation_status, validation_notes, 
-	     score_breakdown, ai_output_data, prompt_version, processed_at) 
-	    VALUES (?, ?, ?, ?, ?, ?, ?, NOW())`
-	args := []any{result.VenueID, result.Score, result.Status, result.Notes, string(scoreBreakdownJSON), result.AIOutputData, result.PromptVersion}
+	     score_breakdown, ai_output_data, prompt_version, run_id, processed_at) 
+	    VALUES (?, ?, ?, ?, ?, ?, ?, ?, NOW())`
+	args := []any{result.VenueID, result.Score, result.Status, result.Notes, string(scoreBreakdownJSON), result.AIOutputData, result.PromptVersion, result.RunID}
 
 	if _, err = tx.ExecContext(ctx, historyQuery, args...); err != nil {
 		return fmt.Errorf("failed to insert validation history: %w", err)
@@ -731,8 +873,9 @@ func (db *DB) GetRecentValidationResults(limit int) ([]models.ValidationResult,
 	return results, nil
 }
 
-// GetVenuesFiltered returns filtered venues with pagination
-func (db *DB) GetVenuesFiltered(status, search string, limit, offset int) ([]models.VenueWithUser, int, error) {
+// GetVenuesFiltered returns filtered venues with pagination. A non-empty pathPrefix keeps
+// venues whose region path starts with it.
+func (db *DB) GetVenuesFiltered(status, search, pathPrefix string, limit, offset int) ([]models.VenueWithUser, int, error) {
 	// Build WHERE clause based on filters
 	whereClause := "WHERE 1=1"
 	args := []interface{}{}
@@ -757,6 +900,11 @@ func (db *DB) GetVenuesFiltered(status, search string, limit, offset int) ([]mod
 		args = append(args, searchPattern, searchPattern, searchPattern)
 	}
 
+	if pathPrefix != "" {
+		whereClause += " AND v.path LIKE ?"
+		args = append(args, pathPrefixPattern(pathPrefix))
+	}
+
 	// Get total count for pagination
 	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM venues v 
         LEFT JOIN members m ON v.user_id = m.id 
@@ -916,6 +1064,9 @@ func (db *DB) GetVenueWithUserByID(venueID int64) (*models.VenueWithUser, error)
 		// Authority fields
 		&isVenueAdmin, &ambassadorLevel, &ambassadorPoints, &ambassadorRegion, &approvedVenueCount,
 	)
+	if err == sql.ErrNoRows {
+		return nil, errs.NewNotFound("database.GetVenueWithUserByID", fmt.Sprintf("venue %d not found", venueID), err)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get venue with user by ID: %w", err)
 	}
@@ -1069,6 +1220,25 @@ func (db *DB) CountVenuesByPathCtx(ctx context.Context, path string, excludeVenu
 	return count, nil
 }
 
+// CountRecentVenuesByUserAndNameCtx counts the user's pending/active venues with the same name
+// submitted before beforeVenueID and added since the given time. Only earlier submissions count,
+// so the original is never the one rejected, whatever order the pair is processed in. Rejected
+// venues (active=-1) are ignored so resubmissions are allowed.
+func (db *DB) CountRecentVenuesByUserAndNameCtx(ctx context.Context, userID uint, name string, since time.Time, beforeVenueID int64) (int, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT COUNT(*) FROM venues
+		WHERE user_id = ? AND id < ? AND active IN (0, 1)
+		  AND LOWER(TRIM(name)) = LOWER(TRIM(?))
+		  AND date_added >= ?`
+	var count int
+	if err := db.conn.QueryRowContext(ctx, query, userID, beforeVenueID, name, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count recent venues by user: %w", err)
+	}
+	return count, nil
+}
+
 // FindDuplicateVenuesByNameAndLocation finds venues with similar names within a geographic radius
 // Uses name similarity (SOUNDEX + fuzzy matching) and Haversine distance formula
 func (db *DB) FindDuplicateVenuesByNameAndLocation(ctx context.Context, name string, lat, lng float64, radiusMeters int, excludeVenueID int64) ([]models.Venue, error) {
@@ -1258,7 +1428,7 @@ func (db *DB) SaveValidationResultWithGoogleData(result *models.ValidationResult
 	}
 
 	_, err = tx.Stmt(stmt).Exec(result.VenueID, result.Score, result.Status,
-		result.Notes, string(scoreBreakdownJSON), googlePlaceID, googlePlaceFound, googlePlaceDataJSON, result.AIOutputData)
+		result.Notes, string(scoreBreakdownJSON), googlePlaceID, googlePlaceFound, googlePlaceDataJSON, result.AIOutputData, result.RunID)
 	if err != nil {
 		return fmt.Errorf("failed to insert validation history: %w", err)
 	}
@@ -1311,9 +1481,15 @@ func (db *DB) HasRecentValidationHistory(venueID int64, hours int) (bool, error)
 	return count > 0, nil
 }
 
-// HasAnyValidationHistory checks if venue has at least one validation history record
+// reviewedHistory matches the history rows h that record a result; see
+// models.ValidationHistory.Reviewed. A venue whose only rows are failures that gathered nothing
+// is still pending.
+const reviewedHistory = "COALESCE(JSON_CONTAINS_PATH(h.score_breakdown, 'one', '$." + models.ProcessingFailedKey + "'), 0) = 0"
+
+// HasAnyValidationHistory checks if venue has at least one validation history record with a
+// result (reviewedHistory)
 func (db *DB) HasAnyValidationHistory(venueID int64) (bool, error) {
-	query := `SELECT COUNT(*) FROM venue_validation_histories WHERE venue_id = ?`
+	query := `SELECT COUNT(*) FROM venue_validation_histories h WHERE h.venue_id = ? AND ` + reviewedHistory
 	var count int
 	err := db.conn.QueryRow(query, venueID).Scan(&count)
 	if err != nil {
@@ -1325,7 +1501,7 @@ func (db *DB) HasAnyValidationHistory(venueID int64) (bool, error) {
 // GetManualReviewVenues returns pending venues (active=0) that have validation history
 // along with their latest validation score. Supports optional search and pagination.
 func (db *DB) GetManualReviewVenues(search string, limit, offset int) ([]models.VenueWithUser, []int, int, error) {
-	where := "WHERE v.active = 0 AND EXISTS (SELECT 1 FROM venue_validation_histories h WHERE h.venue_id = v.id)"
+	where := "WHERE v.active = 0 AND EXISTS (SELECT 1 FROM venue_validation_histories h WHERE h.venue_id = v.id AND " + reviewedHistory + ")"
 	args := []interface{}{}
 	if search != "" {
 		where += " AND (v.name LIKE ? OR v.location LIKE ? OR m.username LIKE ?)"
@@ -1497,7 +1673,7 @@ func (db *DB) SaveValidationResultWithGoogleDataCtx(ctx context.Context, result
 		return fmt.Errorf("prepared statement insertValidationHistory not initialized")
 	}
 	if _, err = tx.StmtContext(ctx, stmt).ExecContext(ctx, result.VenueID, result.Score, result.Status,
-		result.Notes, string(scoreBreakdownJSON), googlePlaceID, googlePlaceFound, googlePlaceDataJSON, result.AIOutputData); err != nil {
+		result.Notes, string(scoreBreakdownJSON), googlePlaceID, googlePlaceFound, googlePlaceDataJSON, result.AIOutputData, result.RunID); err != nil {
 		return fmt.Errorf("failed to insert validation history: %w", err)
 	}
 
@@ -1624,16 +1800,44 @@ func (db *DB) GetPendingVenuesWithUserCtx(ctx context.Context) ([]models.VenueWi
 	return venues, nil
 }
 
-// GetVenuesFilteredCtx returns filtered venues with pagination and context.
-func (db *DB) GetVenuesFilteredCtx(ctx context.Context, status, search string, limit, offset int) ([]models.VenueWithUser, int, error) {
+// GetVenuesFilteredCtx returns filtered venues with pagination and context. A non-empty
+// pathPrefix keeps venues whose region path starts with it.
+func (db *DB) GetVenuesFilteredCtx(ctx context.Context, status, search, pathPrefix string, limit, offset int) ([]models.VenueWithUser, int, error) {
 	ctx, cancel := db.withReadTimeout(ctx)
 	defer cancel()
+	whereClause, args := venueFilter(status, search, pathPrefix)
+	total, err := db.countVenuesFiltered(ctx, whereClause, args)
+	if err != nil {
+		return nil, 0, err
+	}
+	query := fmt.Sprintf(`SELECT %s %s %s
+        ORDER BY v.admin_last_update DESC, v.created_at DESC
+        LIMIT ? OFFSET ?`, venueWithUserColumns, venueWithUserJoins, whereClause)
+	args = append(args, limit, offset)
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query filtered venues: %w", err)
+	}
+	defer rows.Close()
+	var venues []models.VenueWithUser
+	for rows.Next() {
+		vu, err := scanVenueWithUser(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		venues = append(venues, vu)
+	}
+	return venues, total, nil
+}
+
+// venueFilter builds the WHERE clause shared by the filtered venue listings.
+func venueFilter(status, search, pathPrefix string) (string, []interface{}) {
 	whereClause := "WHERE 1=1"
 	args := []interface{}{}
 	if status != "" {
 		switch status {
 		case "pending":
-			whereClause += " AND v.active = ? AND NOT EXISTS (SELECT 1 FROM venue_validation_histories h WHERE h.venue_id = v.id)"
+			whereClause += " AND v.active = ? AND NOT EXISTS (SELECT 1 FROM venue_validation_histories h WHERE h.venue_id = v.id AND " + reviewedHistory + ")"
 			args = append(args, 0)
 		case "approved":
 			whereClause += " AND v.active = ?"
@@ -1648,15 +1852,30 @@ func (db *DB) GetVenuesFilteredCtx(ctx context.Context, status, search string, l
 		searchPattern := "%" + search + "%"
 		args = append(args, searchPattern, searchPattern, searchPattern)
 	}
-	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM venues v 
-        LEFT JOIN members m ON v.user_id = m.id 
-        LEFT JOIN venue_admin va ON v.id = va.venue_id AND m.id = va.user_id
-        LEFT JOIN ambassadors a ON m.id = a.user_id %s`, whereClause)
+	if pathPrefix != "" {
+		whereClause += " AND v.path LIKE ?"
+		args = append(args, pathPrefixPattern(pathPrefix))
+	}
+	return whereClause, args
+}
+
+// pathPrefixPattern is the LIKE pattern matching region paths that start with prefix, such
+// as "europe|germany" for the venues in Germany. LIKE wildcards in prefix match only
+// themselves, so the pattern is a plain prefix and can use an index on path.
+func pathPrefixPattern(prefix string) string {
+	r := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	return r.Replace(prefix) + "%"
+}
+
+func (db *DB) countVenuesFiltered(ctx context.Context, whereClause string, args []interface{}) (int, error) {
 	var total int
-	if err := db.conn.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
-		return nil, 0, fmt.Errorf("failed to get filtered venues count: %w", err)
+	if err := db.conn.QueryRowContext(ctx, "SELECT COUNT(*) "+venueWithUserJoins+" "+whereClause, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to get filtered venues count: %w", err)
 	}
-	query := fmt.Sprintf(`SELECT v.id, v.path, v.entrytype, v.name, v.url, v.fburl, v.instagram_url, 
+	return total, nil
+}
+
+const venueWithUserColumns = `v.id, v.path, v.entrytype, v.name, v.url, v.fburl, v.instagram_url, 
         v.location, v.zipcode, v.phone, v.other_food_type, v.price, v.additionalinfo, 
         v.vdetails, v.openhours, v.openhours_note, v.timezone, v.hash, v.email, 
         v.ownername, v.sentby, v.user_id, v.active, v.vegonly, v.vegan, v.sponsor_level, 
@@ -1667,75 +1886,66 @@ func (db *DB) GetVenuesFilteredCtx(ctx context.Context, status, search string, l
         v.request_excellent_decal_at, v.source,
         m.id as member_id, m.username, m.trusted,
         va.venue_id IS NOT NULL as is_venue_admin,
-        a.level as ambassador_level, a.points as ambassador_points, a.path as ambassador_path
-        FROM venues v 
+        a.level as ambassador_level, a.points as ambassador_points, a.path as ambassador_path`
+
+const venueWithUserJoins = `FROM venues v 
         LEFT JOIN members m ON v.user_id = m.id 
         LEFT JOIN venue_admin va ON v.id = va.venue_id AND m.id = va.user_id
-        LEFT JOIN ambassadors a ON m.id = a.user_id
-        %s
-        ORDER BY v.admin_last_update DESC, v.created_at DESC
-        LIMIT ? OFFSET ?`, whereClause)
-	args = append(args, limit, offset)
-	rows, err := db.conn.QueryContext(ctx, query, args...)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to query filtered venues: %w", err)
+        LEFT JOIN ambassadors a ON m.id = a.user_id`
+
+// scanVenueWithUser scans one row selected with venueWithUserColumns.
+func scanVenueWithUser(s rowScanner) (models.VenueWithUser, error) {
+	var venueWithUser models.VenueWithUser
+	var venue models.Venue
+	var user models.User
+	var isVenueAdmin bool
+	var ambassadorLevel, ambassadorPoints sql.NullInt64
+	var ambassadorPath sql.NullString
+	var memberID sql.NullInt64
+	var username sql.NullString
+	var trusted sql.NullInt64
+	if err := s.Scan(
+		&venue.ID, &venue.Path, &venue.EntryType, &venue.Name, &venue.URL,
+		&venue.FBUrl, &venue.InstagramUrl, &venue.Location, &venue.Zipcode,
+		&venue.Phone, &venue.OtherFoodType, &venue.Price, &venue.AdditionalInfo,
+		&venue.VDetails, &venue.OpenHours, &venue.OpenHoursNote, &venue.Timezone,
+		&venue.Hash, &venue.Email, &venue.OwnerName, &venue.SentBy, &venue.UserID,
+		&venue.Active, &venue.VegOnly, &venue.Vegan, &venue.SponsorLevel,
+		&venue.CrossStreet, &venue.Lat, &venue.Lng, &venue.CreatedAt,
+		&venue.DateAdded, &venue.DateUpdated, &venue.AdminLastUpdate,
+		&venue.AdminNote, &venue.AdminHold, &venue.AdminHoldEmailNote,
+		&venue.UpdatedByID, &venue.MadeActiveByID, &venue.MadeActiveAt,
+		&venue.ShowPremium, &venue.Category, &venue.PrettyUrl, &venue.EditLock,
+		&venue.RequestVeganDecalAt, &venue.RequestExcellentDecalAt, &venue.Source,
+		&memberID, &username, &trusted,
+		&isVenueAdmin, &ambassadorLevel, &ambassadorPoints, &ambassadorPath,
+	); err != nil {
+		return venueWithUser, fmt.Errorf("failed to scan venue with user row: %w", err)
 	}
-	defer rows.Close()
-	var venues []models.VenueWithUser
-	for rows.Next() {
-		var venueWithUser models.VenueWithUser
-		var venue models.Venue
-		var user models.User
-		var isVenueAdmin bool
-		var ambassadorLevel, ambassadorPoints sql.NullInt64
-		var ambassadorPath sql.NullString
-		var memberID sql.NullInt64
-		var username sql.NullString
-		var trusted sql.NullInt64
-		if err := rows.Scan(
-			&venue.ID, &venue.Path, &venue.EntryType, &venue.Name, &venue.URL,
-			&venue.FBUrl, &venue.InstagramUrl, &venue.Location, &venue.Zipcode,
-			&venue.Phone, &venue.OtherFoodType, &venue.Price, &venue.AdditionalInfo,
-			&venue.VDetails, &venue.OpenHours, &venue.OpenHoursNote, &venue.Timezone,
-			&venue.Hash, &venue.Email, &venue.OwnerName, &venue.SentBy, &venue.UserID,
-			&venue.Active, &venue.VegOnly, &venue.Vegan, &venue.SponsorLevel,
-			&venue.CrossStreet, &venue.Lat, &venue.Lng, &venue.CreatedAt,
-			&venue.DateAdded, &venue.DateUpdated, &venue.AdminLastUpdate,
-			&venue.AdminNote, &venue.AdminHold, &venue.AdminHoldEmailNote,
-			&venue.UpdatedByID, &venue.MadeActiveByID, &venue.MadeActiveAt,
-			&venue.ShowPremium, &venue.Category, &venue.PrettyUrl, &venue.EditLock,
-			&venue.RequestVeganDecalAt, &venue.RequestExcellentDecalAt, &venue.Source,
-			&memberID, &username, &trusted,
-			&isVenueAdmin, &ambassadorLevel, &ambassadorPoints, &ambassadorPath,
-		); err != nil {
-			return nil, 0, fmt.Errorf("failed to scan venue with user row: %w", err)
-		}
-		if memberID.Valid {
-			user.ID = uint(memberID.Int64)
-		} else {
-			user.ID = venue.UserID
-		}
-		if username.Valid {
-			user.Username = username.String
-		}
-		if trusted.Valid {
-			user.Trusted = trusted.Int64 > 0
-		}
-		venueWithUser.Venue = venue
-		venueWithUser.User = user
-		venueWithUser.IsVenueAdmin = isVenueAdmin
-		if ambassadorLevel.Valid {
-			venueWithUser.AmbassadorLevel = &ambassadorLevel.Int64
-		}
-		if ambassadorPoints.Valid {
-			venueWithUser.AmbassadorPoints = &ambassadorPoints.Int64
-		}
-		if ambassadorPath.Valid {
-			venueWithUser.AmbassadorPath = &ambassadorPath.String
-		}
-		venues = append(venues, venueWithUser)
+	if memberID.Valid {
+		user.ID = uint(memberID.Int64)
+	} else {
+		user.ID = venue.UserID
 	}
-	return venues, total, nil
+	if username.Valid {
+		user.Username = username.String
+	}
+	if trusted.Valid {
+		user.Trusted = trusted.Int64 > 0
+	}
+	venueWithUser.Venue = venue
+	venueWithUser.User = user
+	venueWithUser.IsVenueAdmin = isVenueAdmin
+	if ambassadorLevel.Valid {
+		venueWithUser.AmbassadorLevel = &ambassadorLevel.Int64
+	}
+	if ambassadorPoints.Valid {
+		venueWithUser.AmbassadorPoints = &ambassadorPoints.Int64
+	}
+	if ambassadorPath.Valid {
+		venueWithUser.AmbassadorPath = &ambassadorPath.String
+	}
+	return venueWithUser, nil
 }
 
 // GetVenueWithUserByIDCtx fetches a single venue with user info by ID.
@@ -1787,7 +1997,9 @@ func (db *DB) GetVenueWithUserByIDCtx(ctx context.Context, venueID int64) (*mode
 		&venue.RequestVeganDecalAt, &venue.RequestExcellentDecalAt, &venue.Source,
 		&username, &email, &trusted, &contributions,
 		&isVenueAdmin, &ambassadorLevel, &ambassadorPoints, &ambassadorRegion, &approvedVenueCount,
-	); err != nil {
+	); err == sql.ErrNoRows {
+		return nil, errs.NewNotFound("database.GetVenueWithUserByIDCtx", fmt.Sprintf("venue %d not found", venueID), err)
+	} else if err != nil {
 		return nil, fmt.Errorf("failed to scan venue with user row: %w", err)
 	}
 	if username.Valid {
@@ -1833,7 +2045,8 @@ func (db *DB) GetVenueValidationHistoryCtx(ctx context.Context, venueID int64) (
 	defer cancel()
 	query := `SELECT 
         id, venue_id, validation_score, validation_status, validation_notes,
-        score_breakdown, google_place_data, ai_output_data, prompt_version, processed_at
+        score_breakdown, google_place_id, google_place_found, google_place_data, ai_output_data,
+        prompt_version, run_id, processed_at
         FROM venue_validation_histories 
         WHERE venue_id = ? 
         ORDER BY processed_at DESC`
@@ -1848,15 +2061,27 @@ func (db *DB) GetVenueValidationHistoryCtx(ctx context.Context, venueID int64) (
 		var scoreBreakdownJSON string
 		var googlePlaceDataJSON *string
 		var aiOutput sql.NullString
-		var pv sql.NullString
+		var pv, placeID sql.NullString
+		var placeFound sql.NullBool
+		var runID sql.NullInt64
 		if err := rows.Scan(&h.ID, &h.VenueID, &h.ValidationScore, &h.ValidationStatus,
-			&h.ValidationNotes, &scoreBreakdownJSON, &googlePlaceDataJSON, &aiOutput, &pv, &h.ProcessedAt); err != nil {
+			&h.ValidationNotes, &scoreBreakdownJSON, &placeID, &placeFound, &googlePlaceDataJSON, &aiOutput,
+			&pv, &runID, &h.ProcessedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan validation history row: %w", err)
 		}
 		if pv.Valid {
 			val := pv.String
 			h.PromptVersion = &val
 		}
+		if placeID.Valid && placeID.String != "" {
+			val := placeID.String
+			h.GooglePlaceID = &val
+		}
+		h.GooglePlaceFound = placeFound.Bool
+		if runID.Valid {
+			val := runID.Int64
+			h.RunID = &val
+		}
 		if err := json.Unmarshal([]byte(scoreBreakdownJSON), &h.ScoreBreakdown); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal score breakdown: %w", err)
 		}
@@ -1907,8 +2132,7 @@ func (db *DB) GetValidationHistoryPaginatedCtx(ctx context.Context, limit, offse
 	if err := db.conn.QueryRowContext(ctx, countQuery).Scan(&total); err != nil {
 		return nil, 0, fmt.Errorf("failed to count validation histories: %w", err)
 	}
-	query := `SELECT id, venue_id, validation_score, validation_status, validation_notes,
-	             score_breakdown, ai_output_data, prompt_version, processed_at 
+	query := `SELECT ` + historyListColumns + `
 	             FROM venue_validation_histories 
 	             ORDER BY processed_at DESC
 	             LIMIT ? OFFSET ?`
@@ -1919,52 +2143,87 @@ func (db *DB) GetValidationHistoryPaginatedCtx(ctx context.Context, limit, offse
 	defer rows.Close()
 	var history []models.ValidationHistory
 	for rows.Next() {
-		var h models.ValidationHistory
-		var scoreBreakdownJSON string
-		var aiOutput sql.NullString
-		var pv sql.NullString
-		if err := rows.Scan(&h.ID, &h.VenueID, &h.ValidationScore, &h.ValidationStatus,
-			&h.ValidationNotes, &scoreBreakdownJSON, &aiOutput, &pv, &h.ProcessedAt); err != nil {
-			return nil, 0, fmt.Errorf("failed to scan validation history row: %w", err)
-		}
-		if pv.Valid {
-			val := pv.String
-			h.PromptVersion = &val
-		}
-		if err := json.Unmarshal([]byte(scoreBreakdownJSON), &h.ScoreBreakdown); err != nil {
-			return nil, 0, fmt.Errorf("failed to unmarshal score breakdown: %w", err)
-		}
-		if aiOutput.Valid {
-			val := aiOutput.String
-			h.AIOutputData = &val
+		h, err := scanHistoryListRow(rows)
+		if err != nil {
+			return nil, 0, err
 		}
 		history = append(history, h)
 	}
 	return history, total, nil
 }
 
+const historyListColumns = `id, venue_id, validation_score, validation_status, validation_notes,
+	             score_breakdown, ai_output_data, prompt_version, processed_at`
+
+// scanHistoryListRow scans one row selected with historyListColumns.
+func scanHistoryListRow(s rowScanner) (models.ValidationHistory, error) {
+	var h models.ValidationHistory
+	var scoreBreakdownJSON string
+	var aiOutput sql.NullString
+	var pv sql.NullString
+	if err := s.Scan(&h.ID, &h.VenueID, &h.ValidationScore, &h.ValidationStatus,
+		&h.ValidationNotes, &scoreBreakdownJSON, &aiOutput, &pv, &h.ProcessedAt); err != nil {
+		return h, fmt.Errorf("failed to scan validation history row: %w", err)
+	}
+	if pv.Valid {
+		val := pv.String
+		h.PromptVersion = &val
+	}
+	if err := json.Unmarshal([]byte(scoreBreakdownJSON), &h.ScoreBreakdown); err != nil {
+		return h, fmt.Errorf("failed to unmarshal score breakdown: %w", err)
+	}
+	if aiOutput.Valid {
+		val := aiOutput.String
+		h.AIOutputData = &val
+	}
+	return h, nil
+}
+
 // GetManualReviewVenuesCtx returns pending venues with validation history (search/pagination) with context.
-// If minScore > 0, only returns venues with validation score >= minScore.
-// sort parameter determines ordering: created_at, last_updated, venue_id_asc, venue_id_desc, score_asc, score_desc
-func (db *DB) GetManualReviewVenuesCtx(ctx context.Context, search string, minScore int, trustedOnly bool, sort string, limit, offset int) ([]models.VenueWithUser, []int, int, error) {
+// Score bounds apply to the latest validation score.
+// f.Sort determines ordering: created_at, last_updated, venue_id_asc, venue_id_desc, score_asc, score_desc
+// With VENUE_HOLDS_ENABLED, venues on hold are left out. If f.ClaimedBy > 0 and claims are
+// enabled, only venues that admin has an active claim on are returned.
+func (db *DB) GetManualReviewVenuesCtx(ctx context.Context, f models.ManualReviewFilter, limit, offset int) ([]models.VenueWithUser, []int, int, error) {
 	ctx, cancel := db.withReadTimeout(ctx)
 	defer cancel()
-	where := "WHERE v.active = 0 AND EXISTS (SELECT 1 FROM venue_validation_histories h WHERE h.venue_id = v.id)"
+	where := "WHERE v.active = 0 AND EXISTS (SELECT 1 FROM venue_validation_histories h WHERE h.venue_id = v.id AND " + reviewedHistory + ")"
 	args := []interface{}{}
-	if search != "" {
+	if f.Search != "" {
 		where += " AND (v.name LIKE ? OR v.location LIKE ? OR m.username LIKE ?)"
-		pat := "%" + search + "%"
+		pat := "%" + f.Search + "%"
 		args = append(args, pat, pat, pat)
 	}
-	// Filter by minimum score if specified (only check the latest validation history)
-	if minScore > 0 {
+	// Filter by score range (only check the latest validation history)
+	if f.MinScore > 0 {
 		where += " AND (SELECT h.validation_score FROM venue_validation_histories h WHERE h.venue_id = v.id ORDER BY h.processed_at DESC LIMIT 1) >= ?"
-		args = append(args, minScore)
+		args = append(args, f.MinScore)
+	}
+	if f.MaxScore > 0 {
+		where += " AND (SELECT h.validation_score FROM venue_validation_histories h WHERE h.venue_id = v.id ORDER BY h.processed_at DESC LIMIT 1) <= ?"
+		args = append(args, f.MaxScore)
+	}
+	if f.PathPrefix != "" {
+		where += " AND v.path LIKE ?"
+		args = append(args, pathPrefixPattern(f.PathPrefix))
 	}
 	// Filter by trusted users only
-	if trustedOnly {
+	if f.TrustedOnly {
 		where += " AND m.trusted > 0"
 	}
+	if f.Category != nil {
+		where += " AND v.category = ?"
+		args = append(args, *f.Category)
+	}
+	// Held venues come back once released or on their reminder date
+	if db.holds {
+		where += " AND NOT " + heldClause
+	}
+	// "My queue": venues claimed by this admin
+	if f.ClaimedBy > 0 && db.claimTimeout > 0 {
+		where += " AND " + claimedClause
+		args = append(args, f.ClaimedBy, db.claimTimeoutSeconds())
+	}
 	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM venues v
         LEFT JOIN members m ON v.user_id = m.id
         %s`, where)
@@ -1975,7 +2234,7 @@ func (db *DB) GetManualReviewVenuesCtx(ctx context.Context, search string, minSc
 
 	// Build ORDER BY clause based on sort parameter (validated whitelist)
 	var orderBy string
-	switch sort {
+	switch f.Sort {
 	case "last_updated":
 		orderBy = "(SELECT h.processed_at FROM venue_validation_histories h WHERE h.venue_id = v.id ORDER BY h.processed_at DESC LIMIT 1) DESC"
 	case "venue_id_asc":
@@ -2082,6 +2341,17 @@ func (db *DB) GetVenueStatisticsCtx(ctx context.Context) (*models.VenueStats, er
 // Only infrastructure code should use this.
 func (db *DB) Conn() *sql.DB { return db.conn }
 
+// UpdateVenueStatusTx is UpdateVenueStatusCtx inside an existing transaction.
+func (db *DB) UpdateVenueStatusTx(ctx context.Context, tx *sql.Tx, venueID int64, active int, notes string) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+	query := `UPDATE venues SET active = ?, admin_note = ?, admin_last_update = NOW() WHERE id = ?`
+	if _, err := tx.ExecContext(ctx, query, active, notes, venueID); err != nil {
+		return fmt.Errorf("failed to update venue status (tx): %w", err)
+	}
+	return nil
+}
+
 // UpdateVenueActiveTx updates the active status within an existing transaction.
 func (db *DB) UpdateVenueActiveTx(ctx context.Context, tx *sql.Tx, venueID int64, active int) error {
 	ctx, cancel := db.withWriteTimeout(ctx)
@@ -2099,15 +2369,15 @@ func (db *DB) SaveValidationResultTx(ctx context.Context, tx *sql.Tx, result *mo
 	defer cancel()
 
 	insert := `INSERT INTO venue_validation_histories 
-		(venue_id, validation_score, validation_status, validation_notes, score_breakdown, ai_output_data, processed_at)
-		VALUES (?, ?, ?, ?, ?, ?, NOW())`
+		(venue_id, validation_score, validation_status, validation_notes, score_breakdown, ai_output_data, run_id, processed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, NOW())`
 
 	scoreBreakdownJSON, err := json.Marshal(result.ScoreBreakdown)
 	if err != nil {
 		return fmt.Errorf("failed to marshal score breakdown: %w", err)
 	}
 
-	if _, err := tx.ExecContext(ctx, insert, result.VenueID, result.Score, result.Status, result.Notes, string(scoreBreakdownJSON), result.AIOutputData); err != nil {
+	if _, err := tx.ExecContext(ctx, insert, result.VenueID, result.Score, result.Status, result.Notes, string(scoreBreakdownJSON), result.AIOutputData, result.RunID); err != nil {
 		return fmt.Errorf("failed to insert validation history (tx): %w", err)
 	}
 	return nil
@@ -2142,7 +2412,7 @@ func (db *DB) SaveValidationResultWithGoogleDataTx(ctx context.Context, tx *sql.
 		return fmt.Errorf("prepared statement insertValidationHistory not initialized")
 	}
 	if _, err = tx.StmtContext(ctx, stmt).ExecContext(ctx, result.VenueID, result.Score, result.Status,
-		result.Notes, string(scoreBreakdownJSON), googlePlaceID, googlePlaceFound, googlePlaceDataJSON, result.AIOutputData); err != nil {
+		result.Notes, string(scoreBreakdownJSON), googlePlaceID, googlePlaceFound, googlePlaceDataJSON, result.AIOutputData, result.RunID); err != nil {
 		return fmt.Errorf("failed to insert validation history (tx): %w", err)
 	}
 	return nil
@@ -0,0 +1,83 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"assisted-venue-approval/internal/drafts"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// GetDraftCtx returns the editor draft of a venue, or nil when there is none.
+func (db *DB) GetDraftCtx(ctx context.Context, venueID int64) (*drafts.VenueDraft, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	d := drafts.VenueDraft{VenueID: venueID}
+	var fields []byte
+	err := db.conn.QueryRowContext(ctx,
+		`SELECT editor_id, version, fields, updated_at FROM venue_drafts WHERE venue_id = ?`, venueID).
+		Scan(&d.EditorID, &d.Version, &fields, &d.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errs.NewDB("GetDraftCtx", "failed to load draft", err)
+	}
+	if err := json.Unmarshal(fields, &d.Fields); err != nil {
+		return nil, errs.NewDB("GetDraftCtx", "failed to decode draft fields", err)
+	}
+	return &d, nil
+}
+
+// SaveDraftCtx writes d if the stored version is still expectVersion (0 = no row yet).
+// Otherwise it returns drafts.ErrVersionConflict and changes nothing.
+func (db *DB) SaveDraftCtx(ctx context.Context, d *drafts.VenueDraft, expectVersion int) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	fields, err := json.Marshal(d.Fields)
+	if err != nil {
+		return errs.NewDB("SaveDraftCtx", "failed to encode draft fields", err)
+	}
+
+	var res sql.Result
+	if expectVersion == 0 {
+		// IGNORE turns a concurrent insert (duplicate venue_id) into 0 affected rows
+		res, err = db.conn.ExecContext(ctx,
+			`INSERT IGNORE INTO venue_drafts (venue_id, editor_id, version, fields, updated_at) VALUES (?, ?, ?, ?, ?)`,
+			d.VenueID, d.EditorID, d.Version, string(fields), d.UpdatedAt)
+	} else {
+		res, err = db.conn.ExecContext(ctx,
+			`UPDATE venue_drafts SET editor_id = ?, version = ?, fields = ?, updated_at = ? WHERE venue_id = ? AND version = ?`,
+			d.EditorID, d.Version, string(fields), d.UpdatedAt, d.VenueID, expectVersion)
+	}
+	if err != nil {
+		return errs.NewDB("SaveDraftCtx", "failed to save draft", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return errs.NewDB("SaveDraftCtx", "failed to get affected rows", err)
+	} else if n == 0 {
+		return drafts.ErrVersionConflict
+	}
+	return nil
+}
+
+// DeleteDraftCtx deletes the draft if the stored version is still expectVersion
+// (drafts.AnyVersion = unconditionally).
+func (db *DB) DeleteDraftCtx(ctx context.Context, venueID int64, expectVersion int) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	res, err := db.conn.ExecContext(ctx,
+		`DELETE FROM venue_drafts WHERE venue_id = ? AND (? < 0 OR version = ?)`, venueID, expectVersion, expectVersion)
+	if err != nil {
+		return errs.NewDB("DeleteDraftCtx", "failed to delete draft", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 && expectVersion > 0 {
+		return drafts.ErrVersionConflict
+	}
+	return nil
+}
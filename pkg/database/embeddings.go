@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// GetVenueEmbeddingCtx returns the venue's stored vector for model, or nil when there is none.
+func (db *DB) GetVenueEmbeddingCtx(ctx context.Context, venueID int64, model string) (*models.VenueEmbedding, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	var emb models.VenueEmbedding
+	var vec []byte
+	err := db.conn.QueryRowContext(ctx, `SELECT venue_id, model, text_hash, vector, updated_at
+	                                     FROM venue_embeddings WHERE venue_id = ? AND model = ?`, venueID, model).
+		Scan(&emb.VenueID, &emb.Model, &emb.TextHash, &vec, &emb.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errs.NewDB("GetVenueEmbeddingCtx", "failed to query venue embedding", err)
+	}
+	if emb.Vector, err = decodeVector(vec); err != nil {
+		return nil, errs.NewDB("GetVenueEmbeddingCtx", fmt.Sprintf("unreadable vector of venue %d", venueID), err)
+	}
+	return &emb, nil
+}
+
+// SaveVenueEmbeddingCtx stores the venue's vector for its model, replacing an older one.
+func (db *DB) SaveVenueEmbeddingCtx(ctx context.Context, emb *models.VenueEmbedding) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	if _, err := db.conn.ExecContext(ctx, `INSERT INTO venue_embeddings (venue_id, model, text_hash, vector, updated_at)
+		VALUES (?, ?, ?, ?, NOW())
+		ON DUPLICATE KEY UPDATE text_hash = VALUES(text_hash), vector = VALUES(vector), updated_at = NOW()`,
+		emb.VenueID, emb.Model, emb.TextHash, encodeVector(emb.Vector)); err != nil {
+		return errs.NewDB("SaveVenueEmbeddingCtx", "failed to store venue embedding", err)
+	}
+	return nil
+}
+
+// ListVenueEmbeddingsCtx returns up to limit vectors for model updated after since, newest first.
+func (db *DB) ListVenueEmbeddingsCtx(ctx context.Context, model string, since time.Time, limit int) ([]models.VenueEmbedding, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	rows, err := db.conn.QueryContext(ctx, `SELECT venue_id, model, text_hash, vector, updated_at
+	                                        FROM venue_embeddings
+	                                        WHERE model = ? AND updated_at > ?
+	                                        ORDER BY updated_at DESC LIMIT ?`, model, since, limit)
+	if err != nil {
+		return nil, errs.NewDB("ListVenueEmbeddingsCtx", "failed to query venue embeddings", err)
+	}
+	defer rows.Close()
+
+	var out []models.VenueEmbedding
+	for rows.Next() {
+		var emb models.VenueEmbedding
+		var vec []byte
+		if err := rows.Scan(&emb.VenueID, &emb.Model, &emb.TextHash, &vec, &emb.UpdatedAt); err != nil {
+			return nil, errs.NewDB("ListVenueEmbeddingsCtx", "failed to scan venue embedding", err)
+		}
+		if emb.Vector, err = decodeVector(vec); err != nil {
+			return nil, errs.NewDB("ListVenueEmbeddingsCtx", fmt.Sprintf("unreadable vector of venue %d", emb.VenueID), err)
+		}
+		out = append(out, emb)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("ListVenueEmbeddingsCtx", "failed to iterate venue embeddings", err)
+	}
+	return out, nil
+}
+
+// encodeVector packs a vector as little-endian float32s, 4 bytes per dimension.
+func encodeVector(v []float32) []byte {
+	b := make([]byte, 4*len(v))
+	for i, f := range v {
+		binary.LittleEndian.PutUint32(b[4*i:], math.Float32bits(f))
+	}
+	return b
+}
+
+func decodeVector(b []byte) ([]float32, error) {
+	if len(b)%4 != 0 {
+		return nil, fmt.Errorf("length %d is not a multiple of 4", len(b))
+	}
+	v := make([]float32, len(b)/4)
+	for i := range v {
+		v[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[4*i:]))
+	}
+	return v, nil
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// ListFeatureFlagsCtx returns every stored feature flag.
+func (db *DB) ListFeatureFlagsCtx(ctx context.Context) ([]models.FeatureFlag, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	rows, err := db.conn.QueryContext(ctx, `SELECT name, enabled, rollout_percent, updated_by, updated_at
+		FROM feature_flags ORDER BY name`)
+	if err != nil {
+		return nil, errs.NewDB("ListFeatureFlagsCtx", "failed to query feature flags", err)
+	}
+	defer rows.Close()
+
+	var out []models.FeatureFlag
+	for rows.Next() {
+		var f models.FeatureFlag
+		if err := rows.Scan(&f.Name, &f.Enabled, &f.RolloutPercent, &f.UpdatedBy, &f.UpdatedAt); err != nil {
+			return nil, errs.NewDB("ListFeatureFlagsCtx", "failed to scan feature flag", err)
+		}
+		out = append(out, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("ListFeatureFlagsCtx", "failed to iterate feature flags", err)
+	}
+	return out, nil
+}
+
+// SetFeatureFlagCtx creates or replaces a feature flag.
+func (db *DB) SetFeatureFlagCtx(ctx context.Context, f models.FeatureFlag) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	if _, err := db.conn.ExecContext(ctx, `INSERT INTO feature_flags (name, enabled, rollout_percent, updated_by, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE enabled = VALUES(enabled), rollout_percent = VALUES(rollout_percent),
+			updated_by = VALUES(updated_by), updated_at = VALUES(updated_at)`,
+		f.Name, f.Enabled, f.RolloutPercent, f.UpdatedBy, f.UpdatedAt); err != nil {
+		return errs.NewDB("SetFeatureFlagCtx", "failed to save feature flag", err)
+	}
+	return nil
+}
+
+// DeleteFeatureFlagCtx removes a feature flag and reports whether it was stored.
+func (db *DB) DeleteFeatureFlagCtx(ctx context.Context, name string) (bool, error) {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	res, err := db.conn.ExecContext(ctx, `DELETE FROM feature_flags WHERE name = ?`, name)
+	if err != nil {
+		return false, errs.NewDB("DeleteFeatureFlagCtx", "failed to delete feature flag", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errs.NewDB("DeleteFeatureFlagCtx", "failed to get affected rows", err)
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// Columns copied to venue_validation_histories_archive; keep in sync with db_changes.md §14 and §16.
+const historyArchiveColumns = `id, venue_id, validation_score, validation_status, validation_notes, score_breakdown,
+	google_place_id, google_place_found, google_place_data, ai_output_data, prompt_version, run_id, processed_at`
+
+// archivableHistories matches rows processed before the cutoff, except each venue's latest
+// row: pending venues need it to be approvable and the review pages read it.
+const archivableHistories = `FROM venue_validation_histories h
+	WHERE h.processed_at < ?
+	  AND EXISTS (SELECT 1 FROM venue_validation_histories n
+	              WHERE n.venue_id = h.venue_id AND (n.processed_at > h.processed_at OR (n.processed_at = h.processed_at AND n.id > h.id)))`
+
+// CountArchivableValidationHistoriesCtx counts the rows ArchiveValidationHistoriesCtx would move.
+func (db *DB) CountArchivableValidationHistoriesCtx(ctx context.Context, before time.Time) (int, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	var n int
+	if err := db.conn.QueryRowContext(ctx, `SELECT COUNT(*) `+archivableHistories, before).Scan(&n); err != nil {
+		return 0, errs.NewDB("database.CountArchivableValidationHistoriesCtx", "failed to count archivable histories", err)
+	}
+	return n, nil
+}
+
+// ArchiveValidationHistoriesCtx moves up to limit archivable rows (oldest first) into
+// venue_validation_histories_archive in one transaction and returns how many it moved.
+// Archived rows keep their ids, so audit log history_id references can still be resolved.
+func (db *DB) ArchiveValidationHistoriesCtx(ctx context.Context, before time.Time, limit int) (int, error) {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, errs.NewDB("database.ArchiveValidationHistoriesCtx", "failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `SELECT h.id `+archivableHistories+` ORDER BY h.processed_at, h.id LIMIT ? FOR UPDATE`, before, limit)
+	if err != nil {
+		return 0, errs.NewDB("database.ArchiveValidationHistoriesCtx", "failed to select histories", err)
+	}
+	var ids []interface{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, errs.NewDB("database.ArchiveValidationHistoriesCtx", "failed to scan history id", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, errs.NewDB("database.ArchiveValidationHistoriesCtx", "failed to read history ids", err)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	in := "(" + strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",") + ")"
+	insert := `INSERT INTO venue_validation_histories_archive (` + historyArchiveColumns + `, archived_at)
+	           SELECT ` + historyArchiveColumns + `, NOW() FROM venue_validation_histories WHERE id IN ` + in
+	if _, err := tx.ExecContext(ctx, insert, ids...); err != nil {
+		return 0, errs.NewDB("database.ArchiveValidationHistoriesCtx", "failed to copy histories to archive", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM venue_validation_histories WHERE id IN `+in, ids...); err != nil {
+		return 0, errs.NewDB("database.ArchiveValidationHistoriesCtx", "failed to delete archived histories", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, errs.NewDB("database.ArchiveValidationHistoriesCtx", "failed to commit", err)
+	}
+	return len(ids), nil
+}
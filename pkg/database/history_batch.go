@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"assisted-venue-approval/internal/domain"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// SaveValidationResultsCtx writes records with one multi-row INSERT in its own transaction.
+func (db *DB) SaveValidationResultsCtx(ctx context.Context, records []domain.HistoryRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return errs.NewDB("database.SaveValidationResultsCtx", "failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+	if err := db.SaveValidationResultsTx(ctx, tx, records); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return errs.NewDB("database.SaveValidationResultsCtx", "failed to commit", err)
+	}
+	return nil
+}
+
+// SaveValidationResultsTx writes records with one multi-row INSERT inside an existing transaction.
+// All rows share the same processed_at.
+func (db *DB) SaveValidationResultsTx(ctx context.Context, tx *sql.Tx, records []domain.HistoryRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	query, args, err := historyBatchInsert(records)
+	if err != nil {
+		return errs.NewDB("database.SaveValidationResultsTx", "failed to build insert", err)
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return errs.NewDB("database.SaveValidationResultsTx", fmt.Sprintf("failed to insert %d validation histories", len(records)), err)
+	}
+	return nil
+}
+
+// historyBatchInsert builds the INSERT for records, with the same columns the single-row
+// writes fill (google_place_* stay NULL/0 for records without Google data).
+func historyBatchInsert(records []domain.HistoryRecord) (string, []any, error) {
+	const cols = 11
+	var b strings.Builder
+	b.WriteString(`INSERT INTO venue_validation_histories
+		(venue_id, validation_score, validation_status, validation_notes, score_breakdown,
+		 google_place_id, google_place_found, google_place_data, ai_output_data, prompt_version, run_id, processed_at)
+		VALUES `)
+	args := make([]any, 0, len(records)*cols)
+	for i, rec := range records {
+		r := rec.Result
+		if r == nil {
+			return "", nil, fmt.Errorf("record %d has no validation result", i)
+		}
+		breakdown, err := json.Marshal(r.ScoreBreakdown)
+		if err != nil {
+			return "", nil, fmt.Errorf("venue %d: marshal score breakdown: %w", r.VenueID, err)
+		}
+		var placeID, placeData *string
+		found := false
+		if g := rec.GoogleData; g != nil {
+			data, err := json.Marshal(g)
+			if err != nil {
+				return "", nil, fmt.Errorf("venue %d: marshal Google Places data: %w", r.VenueID, err)
+			}
+			s := string(data)
+			placeID, placeData, found = &g.PlaceID, &s, true
+		}
+
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString("(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW())")
+		args = append(args, r.VenueID, r.Score, r.Status, r.Notes, string(breakdown),
+			placeID, found, placeData, r.AIOutputData, r.PromptVersion, r.RunID)
+	}
+	return b.String(), args, nil
+}
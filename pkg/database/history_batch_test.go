@@ -0,0 +1,41 @@
+package database
+
+import (
+	"strings"
+	"testing"
+
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/internal/models"
+)
+
+func TestHistoryBatchInsert(t *testing.T) {
+	pv := "v3"
+	runID := int64(42)
+	records := []domain.HistoryRecord{
+		{Result: &models.ValidationResult{VenueID: 1, Score: 90, Status: "approved", PromptVersion: &pv, RunID: &runID}},
+		{Result: &models.ValidationResult{VenueID: 2, Status: "manual_review"}, GoogleData: &models.GooglePlaceData{PlaceID: "abc"}},
+	}
+	query, args, err := historyBatchInsert(records)
+	if err != nil {
+		t.Fatalf("historyBatchInsert: %v", err)
+	}
+	if n := strings.Count(query, "NOW()"); n != 2 {
+		t.Fatalf("query has %d value rows, want 2:\n%s", n, query)
+	}
+	if ph := strings.Count(query, "?"); len(args) != ph || ph != 22 {
+		t.Fatalf("args=%d placeholders=%d, want 22", len(args), ph)
+	}
+	if args[0] != int64(1) || args[9] != &pv || args[10] != &runID {
+		t.Errorf("first row args = %v", args[:11])
+	}
+	if found := args[17]; found != true {
+		t.Errorf("google_place_found for second row = %v, want true", found)
+	}
+	if id := args[16].(*string); *id != "abc" {
+		t.Errorf("google_place_id = %q", *id)
+	}
+
+	if _, _, err := historyBatchInsert([]domain.HistoryRecord{{}}); err == nil {
+		t.Error("record without a result must be rejected")
+	}
+}
@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// heldClause matches venues with an active hold; it is appended to the manual review
+// queue's WHERE clause negated when holds are enabled.
+const heldClause = `EXISTS (SELECT 1 FROM venue_holds vh WHERE vh.venue_id = v.id
+	AND vh.released_at IS NULL AND (vh.remind_at IS NULL OR vh.remind_at > NOW()))`
+
+// GetActiveHoldCtx returns the venue's unreleased hold, or nil when there is none. A hold
+// past its reminder date is still returned; it no longer keeps the venue out of the queue.
+func (db *DB) GetActiveHoldCtx(ctx context.Context, venueID int64) (*models.VenueHold, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	row := db.conn.QueryRowContext(ctx, `SELECT id, venue_id, admin_id, reason, remind_at, created_at
+		FROM venue_holds WHERE venue_id = ? AND released_at IS NULL ORDER BY id DESC LIMIT 1`, venueID)
+	var h models.VenueHold
+	var remind sql.NullTime
+	if err := row.Scan(&h.ID, &h.VenueID, &h.AdminID, &h.Reason, &remind, &h.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errs.NewDB("GetActiveHoldCtx", "failed to query venue hold", err)
+	}
+	h.RemindAt = nullTimePtr(remind)
+	return &h, nil
+}
+
+// PlaceHoldCtx stores h and sets h.ID. The venue's previous hold, if any, is released by
+// the same admin in the same transaction, so a venue has at most one active hold.
+func (db *DB) PlaceHoldCtx(ctx context.Context, h *models.VenueHold) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return errs.NewDB("PlaceHoldCtx", "failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE venue_holds SET released_at = ?, released_by = ?
+		WHERE venue_id = ? AND released_at IS NULL`, h.CreatedAt, h.AdminID, h.VenueID); err != nil {
+		return errs.NewDB("PlaceHoldCtx", "failed to release previous hold", err)
+	}
+	res, err := tx.ExecContext(ctx, `INSERT INTO venue_holds (venue_id, admin_id, reason, remind_at, created_at)
+		VALUES (?, ?, ?, ?, ?)`, h.VenueID, h.AdminID, h.Reason, h.RemindAt, h.CreatedAt)
+	if err != nil {
+		return errs.NewDB("PlaceHoldCtx", "failed to insert venue hold", err)
+	}
+	if h.ID, err = res.LastInsertId(); err != nil {
+		return errs.NewDB("PlaceHoldCtx", "failed to get hold id", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return errs.NewDB("PlaceHoldCtx", "failed to commit", err)
+	}
+	return nil
+}
+
+// ReleaseHoldCtx ends the venue's active hold. It reports false when there was none.
+func (db *DB) ReleaseHoldCtx(ctx context.Context, venueID int64, adminID int) (bool, error) {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	res, err := db.conn.ExecContext(ctx, `UPDATE venue_holds SET released_at = NOW(), released_by = ?
+		WHERE venue_id = ? AND released_at IS NULL`, adminID, venueID)
+	if err != nil {
+		return false, errs.NewDB("ReleaseHoldCtx", "failed to release venue hold", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errs.NewDB("ReleaseHoldCtx", "failed to get affected rows", err)
+	}
+	return n > 0, nil
+}
+
+// ListActiveHoldsCtx returns up to limit unreleased holds of venues still pending, earliest
+// reminder first; holds without a reminder date come last.
+func (db *DB) ListActiveHoldsCtx(ctx context.Context, limit int) ([]models.VenueHold, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	rows, err := db.conn.QueryContext(ctx, `SELECT vh.id, vh.venue_id, v.name, vh.admin_id, vh.reason, vh.remind_at, vh.created_at
+		FROM venue_holds vh
+		JOIN venues v ON v.id = vh.venue_id
+		WHERE vh.released_at IS NULL AND v.active = 0
+		ORDER BY vh.remind_at IS NULL, vh.remind_at, vh.id
+		LIMIT ?`, limit)
+	if err != nil {
+		return nil, errs.NewDB("ListActiveHoldsCtx", "failed to query venue holds", err)
+	}
+	defer rows.Close()
+
+	var out []models.VenueHold
+	for rows.Next() {
+		var h models.VenueHold
+		var remind sql.NullTime
+		if err := rows.Scan(&h.ID, &h.VenueID, &h.VenueName, &h.AdminID, &h.Reason, &remind, &h.CreatedAt); err != nil {
+			return nil, errs.NewDB("ListActiveHoldsCtx", "failed to scan venue hold", err)
+		}
+		h.RemindAt = nullTimePtr(remind)
+		out = append(out, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("ListActiveHoldsCtx", "failed to iterate venue holds", err)
+	}
+	return out, nil
+}
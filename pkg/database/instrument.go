@@ -0,0 +1,218 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"log"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/pkg/metrics"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// Per-query instrumentation at the driver level, so every query is covered whether it runs on
+// the pool, in a transaction or through a prepared statement. Queries are labelled with the
+// name of the DB method that issued them (e.g. "GetVenuesFilteredCtx"), never with SQL text:
+// that keeps label cardinality bounded and arguments out of logs. Durations cover the round
+// trip until the first result, not the time spent iterating rows.
+
+var (
+	mQueryDuration = metrics.Default.HistogramVec("db_query_duration_seconds", "Database query latency by statement",
+		[]float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}, "statement")
+	mSlowQueries   = metrics.Default.CounterVec("db_slow_queries_total", "Queries slower than DB_SLOW_QUERY_THRESHOLD", "statement")
+	mQueryTimeouts = metrics.Default.CounterVec("db_query_timeouts_total", "Queries that hit their context deadline", "statement")
+)
+
+// dbPkg is this package's import path, used to recognise our own frames in the call stack.
+var dbPkg = reflect.TypeOf(DB{}).PkgPath()
+
+// closureSuffix matches the compiler's names for closures (".func1", ".func2.1", ...).
+var closureSuffix = regexp.MustCompile(`(\.func\d+)+(\.\d+)*$`)
+
+// openInstrumented opens a pool whose connections time every query; slow <= 0 disables the
+// slow-query log (durations are still recorded).
+func openInstrumented(dsn string, slow time.Duration) (*sql.DB, error) {
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return sql.OpenDB(&instrumentedConnector{Connector: connector, obs: &queryObserver{slow: slow}}), nil
+}
+
+// queryObserver records one query's duration and reports slow ones.
+type queryObserver struct {
+	slow time.Duration
+}
+
+// observe records a finished query and reports whether it was slow.
+func (o *queryObserver) observe(op, statement string, took time.Duration, err error) bool {
+	mQueryDuration.With(statement).Observe(took.Seconds())
+	if errors.Is(err, context.DeadlineExceeded) {
+		mQueryTimeouts.With(statement).Inc()
+	}
+	if o.slow <= 0 || took < o.slow {
+		return false
+	}
+	mSlowQueries.With(statement).Inc()
+	log.Printf("database: slow %s %s took %s (threshold %s)", op, statement, took.Round(time.Millisecond), o.slow)
+	return true
+}
+
+// done observes a query started at start, unless the driver skipped it (database/sql then
+// retries through a prepared statement, which is timed on its own).
+func (o *queryObserver) done(op string, start time.Time, err error) {
+	if err == driver.ErrSkip {
+		return
+	}
+	o.observe(op, statementName(), time.Since(start), err)
+}
+
+// statementName names the query being run after its caller: the innermost function of this
+// package outside the instrumentation (receiver and closure suffix dropped), or else the first
+// caller outside database/sql as "pkg.Func".
+func statementName() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		f, more := frames.Next()
+		if name, ok := callerName(f.Function); ok {
+			return name
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+// callerName maps a runtime function name to a statement name; ok is false for frames that
+// belong to the instrumentation, database/sql or the runtime.
+func callerName(fn string) (string, bool) {
+	if fn == "" || strings.HasPrefix(fn, "database/sql.") || strings.HasPrefix(fn, "runtime.") {
+		return "", false
+	}
+	name := fn
+	if rest, ok := strings.CutPrefix(fn, dbPkg+"."); ok {
+		if strings.HasPrefix(rest, "(*instrumented") || strings.HasPrefix(rest, "(*queryObserver)") || rest == "statementName" {
+			return "", false
+		}
+		name = rest
+	} else if i := strings.LastIndex(fn, "/"); i >= 0 {
+		name = fn[i+1:]
+	}
+	name = closureSuffix.ReplaceAllString(name, "")
+	if i := strings.Index(name, "["); i >= 0 { // generic instantiation
+		name = name[:i]
+	}
+	// Drop the receiver: "(*DB).GetVenueCtx" -> "GetVenueCtx", "events.(*Store).list" -> "events.list"
+	if i := strings.Index(name, "("); i >= 0 {
+		if j := strings.Index(name[i:], ")."); j >= 0 {
+			name = name[:i] + name[i+j+2:]
+		}
+	}
+	return name, true
+}
+
+type instrumentedConnector struct {
+	driver.Connector
+	obs *queryObserver
+}
+
+func (c *instrumentedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dc, ok := conn.(driverConn)
+	if !ok {
+		// A driver without the context interfaces gets no instrumentation rather than
+		// a wrapper that hides the capabilities it does have.
+		return conn, nil
+	}
+	return &instrumentedConn{driverConn: dc, obs: c.obs}, nil
+}
+
+// driverConn is the set of connection interfaces the MySQL driver implements; the wrapper
+// must expose all of them so database/sql keeps its fast paths.
+type driverConn interface {
+	driver.Conn
+	driver.ConnBeginTx
+	driver.ConnPrepareContext
+	driver.ExecerContext
+	driver.QueryerContext
+	driver.Pinger
+	driver.SessionResetter
+	driver.Validator
+	driver.NamedValueChecker
+}
+
+type instrumentedConn struct {
+	driverConn
+	obs *queryObserver
+}
+
+func (c *instrumentedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	rows, err := c.driverConn.QueryContext(ctx, query, args)
+	c.obs.done("query", start, err)
+	return rows, err
+}
+
+func (c *instrumentedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	res, err := c.driverConn.ExecContext(ctx, query, args)
+	c.obs.done("exec", start, err)
+	return res, err
+}
+
+func (c *instrumentedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := c.driverConn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	ds, ok := stmt.(driverStmt)
+	if !ok {
+		return stmt, nil
+	}
+	return &instrumentedStmt{driverStmt: ds, obs: c.obs}, nil
+}
+
+type driverStmt interface {
+	driver.Stmt
+	driver.StmtExecContext
+	driver.StmtQueryContext
+	driver.NamedValueChecker
+	driver.ColumnConverter
+}
+
+// instrumentedStmt times executions of a prepared statement; the name comes from the caller
+// of each execution, not from where the statement was prepared.
+type instrumentedStmt struct {
+	driverStmt
+	obs *queryObserver
+}
+
+func (s *instrumentedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	rows, err := s.driverStmt.QueryContext(ctx, args)
+	s.obs.done("query", start, err)
+	return rows, err
+}
+
+func (s *instrumentedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	res, err := s.driverStmt.ExecContext(ctx, args)
+	s.obs.done("exec", start, err)
+	return res, err
+}
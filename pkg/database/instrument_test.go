@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCallerName(t *testing.T) {
+	tests := []struct {
+		fn   string
+		want string
+		ok   bool
+	}{
+		{dbPkg + ".(*DB).GetVenuesFilteredCtx", "GetVenuesFilteredCtx", true},
+		{dbPkg + ".(*DB).ArchiveValidationHistoriesCtx.func1", "ArchiveValidationHistoriesCtx", true},
+		{dbPkg + ".keysetPage[...]", "keysetPage", true},
+		{dbPkg + ".(*SQLUnitOfWork).commit.func2.1", "commit", true},
+		{"assisted-venue-approval/pkg/events.(*SQLStore).listAfter", "events.listAfter", true},
+		{"assisted-venue-approval/internal/archive.run", "archive.run", true},
+		{dbPkg + ".(*instrumentedConn).QueryContext", "", false},
+		{dbPkg + ".(*instrumentedStmt).ExecContext", "", false},
+		{dbPkg + ".(*queryObserver).done", "", false},
+		{dbPkg + ".statementName", "", false},
+		{"database/sql.(*DB).queryDC", "", false},
+		{"runtime.goexit", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := callerName(tt.fn)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("callerName(%q) = %q, %v; want %q, %v", tt.fn, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestStatementName_FromCaller(t *testing.T) {
+	var got string
+	func() { got = statementName() }()
+	// statementName skips its direct caller (done, in production), here the closure
+	if got != "TestStatementName_FromCaller" {
+		t.Fatalf("statementName() = %q", got)
+	}
+}
+
+func TestQueryObserver_Observe(t *testing.T) {
+	tests := []struct {
+		name     string
+		slow     time.Duration
+		took     time.Duration
+		err      error
+		wantSlow bool
+		timeouts int64
+	}{
+		{"fast", 100 * time.Millisecond, 10 * time.Millisecond, nil, false, 0},
+		{"slow", 100 * time.Millisecond, 150 * time.Millisecond, nil, true, 0},
+		{"at threshold", 100 * time.Millisecond, 100 * time.Millisecond, nil, true, 0},
+		{"logging off", 0, time.Minute, nil, false, 0},
+		{"deadline", 100 * time.Millisecond, 200 * time.Millisecond, fmt.Errorf("read: %w", context.DeadlineExceeded), true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt := "test_observe_" + tt.name
+			o := &queryObserver{slow: tt.slow}
+			if got := o.observe("query", stmt, tt.took, tt.err); got != tt.wantSlow {
+				t.Fatalf("observe() slow = %v, want %v", got, tt.wantSlow)
+			}
+			var slowCount int64
+			if tt.wantSlow {
+				slowCount = 1
+			}
+			if got := mSlowQueries.With(stmt).Get(); int64(got) != slowCount {
+				t.Errorf("slow counter = %v, want %d", got, slowCount)
+			}
+			if got := mQueryTimeouts.With(stmt).Get(); int64(got) != tt.timeouts {
+				t.Errorf("timeout counter = %v, want %d", got, tt.timeouts)
+			}
+		})
+	}
+}
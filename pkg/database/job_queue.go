@@ -0,0 +1,216 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// EnqueueJobsCtx adds jobs to the shared processing queue. A venue already in the queue is
+// skipped, so two instances queuing the same venue process it once. Returns how many jobs
+// were added.
+func (db *DB) EnqueueJobsCtx(ctx context.Context, jobs []models.QueuedJob) (int, error) {
+	if len(jobs) == 0 {
+		return 0, nil
+	}
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	var b strings.Builder
+	b.WriteString(`INSERT INTO processing_queue (venue_id, mode, run_id, priority, created_at) VALUES `)
+	args := make([]any, 0, len(jobs)*4)
+	for i, j := range jobs {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString("(?, ?, ?, ?, NOW())")
+		var runID *int64
+		if j.RunID != 0 {
+			runID = &j.RunID
+		}
+		args = append(args, j.VenueID, j.Mode, runID, j.Priority)
+	}
+	// A no-op update leaves duplicates untouched and counts them as 0 affected rows
+	b.WriteString(` ON DUPLICATE KEY UPDATE id = id`)
+
+	res, err := db.conn.ExecContext(ctx, b.String(), args...)
+	if err != nil {
+		return 0, errs.NewDB("EnqueueJobsCtx", fmt.Sprintf("failed to queue %d jobs", len(jobs)), err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, errs.NewDB("EnqueueJobsCtx", "failed to get affected rows", err)
+	}
+	return int(n), nil
+}
+
+// ClaimJobsCtx claims up to limit waiting jobs for instanceID, highest priority first.
+// Rows locked by another instance's claim are skipped rather than waited for, so concurrent
+// claims never return the same job.
+func (db *DB) ClaimJobsCtx(ctx context.Context, instanceID string, limit int) ([]models.QueuedJob, error) {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errs.NewDB("ClaimJobsCtx", "failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `SELECT id, venue_id, mode, COALESCE(run_id, 0), priority, attempts, created_at
+	                                   FROM processing_queue
+	                                   WHERE claimed_by IS NULL
+	                                   ORDER BY priority DESC, id
+	                                   LIMIT ?
+	                                   FOR UPDATE SKIP LOCKED`, limit)
+	if err != nil {
+		return nil, errs.NewDB("ClaimJobsCtx", "failed to select jobs", err)
+	}
+	var jobs []models.QueuedJob
+	for rows.Next() {
+		var j models.QueuedJob
+		if err := rows.Scan(&j.ID, &j.VenueID, &j.Mode, &j.RunID, &j.Priority, &j.Attempts, &j.CreatedAt); err != nil {
+			rows.Close()
+			return nil, errs.NewDB("ClaimJobsCtx", "failed to scan job", err)
+		}
+		jobs = append(jobs, j)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("ClaimJobsCtx", "failed to iterate jobs", err)
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(jobs)), ", ")
+	args := make([]any, 0, len(jobs)+1)
+	args = append(args, instanceID)
+	for i := range jobs {
+		args = append(args, jobs[i].ID)
+		jobs[i].ClaimedBy = instanceID
+		jobs[i].Attempts++
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE processing_queue
+	                                  SET claimed_by = ?, claimed_at = NOW(), attempts = attempts + 1
+	                                  WHERE id IN (`+placeholders+`)`, args...); err != nil {
+		return nil, errs.NewDB("ClaimJobsCtx", "failed to claim jobs", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, errs.NewDB("ClaimJobsCtx", "failed to commit", err)
+	}
+	return jobs, nil
+}
+
+// CompleteJobCtx removes a processed job from the queue.
+func (db *DB) CompleteJobCtx(ctx context.Context, id int64) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	if _, err := db.conn.ExecContext(ctx, `DELETE FROM processing_queue WHERE id = ?`, id); err != nil {
+		return errs.NewDB("CompleteJobCtx", "failed to delete job", err)
+	}
+	return nil
+}
+
+// CountWaitingJobsCtx returns how many jobs no instance has claimed yet.
+func (db *DB) CountWaitingJobsCtx(ctx context.Context) (int, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	var n int
+	if err := db.conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM processing_queue WHERE claimed_by IS NULL`).Scan(&n); err != nil {
+		return 0, errs.NewDB("CountWaitingJobsCtx", "failed to count jobs", err)
+	}
+	return n, nil
+}
+
+// HeartbeatCtx registers instanceID or refreshes its heartbeat. Heartbeats use the database
+// clock, so instances with skewed clocks still agree on which ones are stale.
+func (db *DB) HeartbeatCtx(ctx context.Context, instanceID, hostname string) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	query := `INSERT INTO processing_instances (id, hostname, started_at, last_heartbeat)
+	          VALUES (?, ?, NOW(), NOW())
+	          ON DUPLICATE KEY UPDATE last_heartbeat = NOW()`
+	if _, err := db.conn.ExecContext(ctx, query, instanceID, hostname); err != nil {
+		return errs.NewDB("HeartbeatCtx", "failed to record heartbeat", err)
+	}
+	return nil
+}
+
+// RecoverOrphanedJobsCtx returns jobs claimed by instances without a heartbeat for
+// staleAfter to the queue, and forgets those instances. Returns how many jobs were released.
+func (db *DB) RecoverOrphanedJobsCtx(ctx context.Context, staleAfter time.Duration) (int, error) {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	secs := int(staleAfter.Seconds())
+	res, err := db.conn.ExecContext(ctx, `UPDATE processing_queue q
+	                                      LEFT JOIN processing_instances i ON i.id = q.claimed_by
+	                                      SET q.claimed_by = NULL, q.claimed_at = NULL
+	                                      WHERE q.claimed_by IS NOT NULL
+	                                        AND (i.id IS NULL OR i.last_heartbeat < NOW() - INTERVAL ? SECOND)`, secs)
+	if err != nil {
+		return 0, errs.NewDB("RecoverOrphanedJobsCtx", "failed to release orphaned jobs", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, errs.NewDB("RecoverOrphanedJobsCtx", "failed to get affected rows", err)
+	}
+	if _, err := db.conn.ExecContext(ctx, `DELETE FROM processing_instances WHERE last_heartbeat < NOW() - INTERVAL ? SECOND`, secs); err != nil {
+		return int(n), errs.NewDB("RecoverOrphanedJobsCtx", "failed to remove stale instances", err)
+	}
+	return int(n), nil
+}
+
+// ReleaseInstanceCtx returns instanceID's claimed jobs to the queue and removes the instance.
+// Called on shutdown so other instances pick the jobs up without waiting for the heartbeat
+// to go stale.
+func (db *DB) ReleaseInstanceCtx(ctx context.Context, instanceID string) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	if _, err := db.conn.ExecContext(ctx, `UPDATE processing_queue SET claimed_by = NULL, claimed_at = NULL
+	                                       WHERE claimed_by = ?`, instanceID); err != nil {
+		return errs.NewDB("ReleaseInstanceCtx", "failed to release jobs", err)
+	}
+	if _, err := db.conn.ExecContext(ctx, `DELETE FROM processing_instances WHERE id = ?`, instanceID); err != nil {
+		return errs.NewDB("ReleaseInstanceCtx", "failed to remove instance", err)
+	}
+	return nil
+}
+
+// ListProcessingInstancesCtx returns the registered instances with their claimed job counts.
+func (db *DB) ListProcessingInstancesCtx(ctx context.Context) ([]models.ProcessingInstance, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	rows, err := db.conn.QueryContext(ctx, `SELECT i.id, i.hostname, i.started_at, i.last_heartbeat, COUNT(q.id)
+	                                        FROM processing_instances i
+	                                        LEFT JOIN processing_queue q ON q.claimed_by = i.id
+	                                        GROUP BY i.id, i.hostname, i.started_at, i.last_heartbeat
+	                                        ORDER BY i.started_at`)
+	if err != nil {
+		return nil, errs.NewDB("ListProcessingInstancesCtx", "failed to query instances", err)
+	}
+	defer rows.Close()
+
+	var out []models.ProcessingInstance
+	for rows.Next() {
+		var in models.ProcessingInstance
+		if err := rows.Scan(&in.ID, &in.Hostname, &in.StartedAt, &in.LastHeartbeat, &in.ClaimedJobs); err != nil {
+			return nil, errs.NewDB("ListProcessingInstancesCtx", "failed to scan instance", err)
+		}
+		out = append(out, in)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("ListProcessingInstancesCtx", "failed to iterate instances", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,183 @@
+package database
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+)
+
+// Keyset pagination: instead of OFFSET, each page starts after the last row of the previous
+// one, so deep pages cost the same as the first and rows inserted meanwhile do not shift
+// later pages. Listings order by immutable keys (id, processed_at) for the same reason.
+
+// ErrInvalidCursor is returned for cursors that were tampered with or belong to another listing.
+var ErrInvalidCursor = errors.New("invalid page cursor")
+
+const (
+	cursorVenues  = "venues"
+	cursorHistory = "history"
+)
+
+// cursor is a position in a listing, encoded as base64url JSON.
+type cursor struct {
+	Kind string    `json:"k"`
+	At   time.Time `json:"t,omitempty"`
+	ID   int64     `json:"i"`
+	Back bool      `json:"b,omitempty"` // rows before this position (previous page)
+}
+
+func encodeCursor(c cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeCursor parses s for the given listing; "" means the first page.
+func decodeCursor(s, kind string) (*cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.Kind != kind || c.ID <= 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// keysetPage trims the look-ahead row (queries fetch limit+1), restores display order for
+// backward pages and works out the neighbouring cursors. pos gives a row's position.
+func keysetPage[T any](rows []T, limit int, c *cursor, pos func(T) cursor) ([]T, models.PageCursors) {
+	var pc models.PageCursors
+	more := len(rows) > limit
+	if more {
+		rows = rows[:limit]
+	}
+	back := c != nil && c.Back
+	if back {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+	if len(rows) == 0 {
+		return rows, pc
+	}
+	first, last := pos(rows[0]), pos(rows[len(rows)-1])
+	first.Back = true
+	if (back && more) || (!back && c != nil) {
+		pc.Prev = encodeCursor(first)
+	}
+	if back || more {
+		pc.Next = encodeCursor(last)
+	}
+	return rows, pc
+}
+
+// GetVenuesFilteredKeysetCtx is GetVenuesFilteredCtx with cursor pagination, newest venue
+// first. after is a cursor from a previous page ("" for the first page).
+func (db *DB) GetVenuesFilteredKeysetCtx(ctx context.Context, status, search, pathPrefix, after string, limit int) ([]models.VenueWithUser, models.PageCursors, int, error) {
+	c, err := decodeCursor(after, cursorVenues)
+	if err != nil {
+		return nil, models.PageCursors{}, 0, err
+	}
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	whereClause, args := venueFilter(status, search, pathPrefix)
+	total, err := db.countVenuesFiltered(ctx, whereClause, args)
+	if err != nil {
+		return nil, models.PageCursors{}, 0, err
+	}
+	order := "v.id DESC"
+	switch {
+	case c == nil:
+	case c.Back:
+		whereClause += " AND v.id > ?"
+		args = append(args, c.ID)
+		order = "v.id ASC"
+	default:
+		whereClause += " AND v.id < ?"
+		args = append(args, c.ID)
+	}
+	query := fmt.Sprintf(`SELECT %s %s %s ORDER BY %s LIMIT ?`, venueWithUserColumns, venueWithUserJoins, whereClause, order)
+	args = append(args, limit+1)
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, models.PageCursors{}, 0, fmt.Errorf("failed to query filtered venues: %w", err)
+	}
+	defer rows.Close()
+	var venues []models.VenueWithUser
+	for rows.Next() {
+		vu, err := scanVenueWithUser(rows)
+		if err != nil {
+			return nil, models.PageCursors{}, 0, err
+		}
+		venues = append(venues, vu)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, models.PageCursors{}, 0, fmt.Errorf("failed to read filtered venues: %w", err)
+	}
+	venues, pc := keysetPage(venues, limit, c, func(v models.VenueWithUser) cursor {
+		return cursor{Kind: cursorVenues, ID: v.Venue.ID}
+	})
+	return venues, pc, total, nil
+}
+
+// GetValidationHistoryKeysetCtx is GetValidationHistoryPaginatedCtx with cursor pagination,
+// newest first (processed_at, then id).
+func (db *DB) GetValidationHistoryKeysetCtx(ctx context.Context, after string, limit int) ([]models.ValidationHistory, models.PageCursors, int, error) {
+	c, err := decodeCursor(after, cursorHistory)
+	if err != nil {
+		return nil, models.PageCursors{}, 0, err
+	}
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	var total int
+	if err := db.conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM venue_validation_histories`).Scan(&total); err != nil {
+		return nil, models.PageCursors{}, 0, fmt.Errorf("failed to count validation histories: %w", err)
+	}
+	where, order := "", "processed_at DESC, id DESC"
+	var args []interface{}
+	switch {
+	case c == nil:
+	case c.Back:
+		where = "WHERE processed_at > ? OR (processed_at = ? AND id > ?)"
+		order = "processed_at ASC, id ASC"
+		args = append(args, c.At, c.At, c.ID)
+	default:
+		where = "WHERE processed_at < ? OR (processed_at = ? AND id < ?)"
+		args = append(args, c.At, c.At, c.ID)
+	}
+	query := fmt.Sprintf(`SELECT %s FROM venue_validation_histories %s ORDER BY %s LIMIT ?`, historyListColumns, where, order)
+	args = append(args, limit+1)
+
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, models.PageCursors{}, 0, fmt.Errorf("failed to query validation histories: %w", err)
+	}
+	defer rows.Close()
+	var history []models.ValidationHistory
+	for rows.Next() {
+		h, err := scanHistoryListRow(rows)
+		if err != nil {
+			return nil, models.PageCursors{}, 0, err
+		}
+		history = append(history, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, models.PageCursors{}, 0, fmt.Errorf("failed to read validation histories: %w", err)
+	}
+	history, pc := keysetPage(history, limit, c, func(h models.ValidationHistory) cursor {
+		return cursor{Kind: cursorHistory, At: h.ProcessedAt, ID: h.ID}
+	})
+	return history, pc, total, nil
+}
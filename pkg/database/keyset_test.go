@@ -0,0 +1,91 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDecodeCursor(t *testing.T) {
+	at := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	valid := encodeCursor(cursor{Kind: cursorHistory, At: at, ID: 42, Back: true})
+
+	tests := []struct {
+		name    string
+		in      string
+		kind    string
+		wantNil bool
+		wantErr bool
+	}{
+		{"first page", "", cursorHistory, true, false},
+		{"round trip", valid, cursorHistory, false, false},
+		{"other listing", valid, cursorVenues, true, true},
+		{"not base64", "%%%", cursorHistory, true, true},
+		{"not json", "bm9wZQ", cursorHistory, true, true},
+		{"missing id", encodeCursor(cursor{Kind: cursorVenues}), cursorVenues, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := decodeCursor(tt.in, tt.kind)
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrInvalidCursor)) {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (c == nil) != tt.wantNil {
+				t.Fatalf("cursor = %+v, want nil=%v", c, tt.wantNil)
+			}
+			if c != nil && (c.ID != 42 || !c.At.Equal(at) || !c.Back) {
+				t.Fatalf("decoded %+v", c)
+			}
+		})
+	}
+}
+
+func TestKeysetPage(t *testing.T) {
+	pos := func(id int64) cursor { return cursor{Kind: cursorVenues, ID: id} }
+	ids := func(n ...int64) []int64 { return n }
+
+	tests := []struct {
+		name     string
+		rows     []int64 // as returned by the query (limit+1 look-ahead)
+		c        *cursor
+		want     []int64
+		wantNext bool
+		wantPrev bool
+	}{
+		{"first page with more", ids(9, 8, 7), nil, ids(9, 8), true, false},
+		{"only page", ids(9, 8), nil, ids(9, 8), false, false},
+		{"middle page", ids(7, 6, 5), &cursor{ID: 8}, ids(7, 6), true, true},
+		{"last page", ids(2, 1), &cursor{ID: 3}, ids(2, 1), false, true},
+		{"back with more", ids(4, 5, 6), &cursor{ID: 3, Back: true}, ids(5, 4), true, true},
+		{"back to first page", ids(8, 9), &cursor{ID: 7, Back: true}, ids(9, 8), true, false},
+		{"empty", nil, &cursor{ID: 1}, nil, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, pc := keysetPage(tt.rows, 2, tt.c, pos)
+			if len(got) != len(tt.want) {
+				t.Fatalf("rows = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("rows = %v, want %v", got, tt.want)
+				}
+			}
+			if (pc.Next != "") != tt.wantNext || (pc.Prev != "") != tt.wantPrev {
+				t.Fatalf("cursors = %+v, want next=%v prev=%v", pc, tt.wantNext, tt.wantPrev)
+			}
+			if pc.Next != "" {
+				c, err := decodeCursor(pc.Next, cursorVenues)
+				if err != nil || c.Back || c.ID != got[len(got)-1] {
+					t.Fatalf("next cursor = %+v, %v", c, err)
+				}
+			}
+			if pc.Prev != "" {
+				c, err := decodeCursor(pc.Prev, cursorVenues)
+				if err != nil || !c.Back || c.ID != got[0] {
+					t.Fatalf("prev cursor = %+v, %v", c, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// MySQL error numbers of DDL that finds its change already made, as on databases migrated by
+// hand from db_changes.md before the migration runner existed.
+const (
+	mysqlTableExists    = 1050
+	mysqlDuplicateField = 1060
+	mysqlDuplicateKey   = 1061
+	mysqlNoSuchTable    = 1146
+)
+
+// migrationLock is the named lock (GET_LOCK) held while migrations are applied, so two
+// replicas never run them at the same time. It is suffixed with the database name so
+// tenants sharing a MySQL server migrate independently.
+const migrationLock = "ava_migrations."
+
+// migration is one schema change. With enum set it only runs on an ENUM column that lacks
+// the value it adds: VARCHAR columns need no change, and a column already extended by hand
+// is left alone.
+type migration struct {
+	version int
+	name    string
+	enum    *enumChange
+	stmts   []string
+}
+
+type enumChange struct {
+	table, column, value string
+}
+
+// enumLacks reports whether a column of type columnType (information_schema COLUMN_TYPE) is
+// an ENUM without value.
+func enumLacks(columnType, value string) bool {
+	return strings.HasPrefix(strings.ToLower(columnType), "enum(") && !strings.Contains(columnType, "'"+value+"'")
+}
+
+// alreadyApplied reports whether err is DDL finding its table, column or index in place.
+func alreadyApplied(err error) bool {
+	var me *mysql.MySQLError
+	if !errors.As(err, &me) {
+		return false
+	}
+	switch me.Number {
+	case mysqlTableExists, mysqlDuplicateField, mysqlDuplicateKey:
+		return true
+	}
+	return false
+}
+
+// MigrationsCtx lists every migration in order, with when it was applied; pending ones have
+// no AppliedAt.
+func (db *DB) MigrationsCtx(ctx context.Context) ([]models.Migration, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	applied, err := appliedMigrations(ctx, db.conn)
+	if err != nil {
+		return nil, errs.NewDB("MigrationsCtx", "failed to query applied migrations", err)
+	}
+	out := make([]models.Migration, 0, len(migrations))
+	for _, m := range migrations {
+		mm := models.Migration{Version: m.version, Name: m.name}
+		if at, ok := applied[m.version]; ok {
+			mm.AppliedAt = &at
+		}
+		out = append(out, mm)
+	}
+	return out, nil
+}
+
+// MigrateCtx applies the pending migrations in order and returns the ones it applied. DDL is
+// not transactional in MySQL, so a failed migration stays pending with its earlier statements
+// applied; those are skipped as already applied when it is run again. No read or write
+// timeout applies: building an index on a large table takes a while.
+func (db *DB) MigrateCtx(ctx context.Context) ([]models.Migration, error) {
+	conn, err := db.conn.Conn(ctx)
+	if err != nil {
+		return nil, errs.NewDB("MigrateCtx", "failed to get a connection", err)
+	}
+	defer conn.Close()
+
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(CONCAT(?, DATABASE()), 0)", migrationLock).Scan(&locked); err != nil {
+		return nil, errs.NewDB("MigrateCtx", "failed to take the migration lock", err)
+	}
+	if locked.Int64 != 1 {
+		return nil, errs.NewConflict("MigrateCtx", "another instance is applying migrations", nil)
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), "DO RELEASE_LOCK(CONCAT(?, DATABASE()))", migrationLock)
+
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INT NOT NULL,
+		name VARCHAR(100) NOT NULL,
+		applied_at DATETIME NOT NULL,
+		PRIMARY KEY (version)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`); err != nil {
+		return nil, errs.NewDB("MigrateCtx", "failed to create schema_migrations", err)
+	}
+	applied, err := appliedMigrations(ctx, conn)
+	if err != nil {
+		return nil, errs.NewDB("MigrateCtx", "failed to query applied migrations", err)
+	}
+
+	var done []models.Migration
+	for _, m := range migrations {
+		if _, ok := applied[m.version]; ok {
+			continue
+		}
+		if err := applyMigration(ctx, conn, m); err != nil {
+			return done, errs.NewDB("MigrateCtx", fmt.Sprintf("migration %d (%s) failed", m.version, m.name), err)
+		}
+		at := time.Now()
+		if _, err := conn.ExecContext(ctx, "INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+			m.version, m.name, at); err != nil {
+			return done, errs.NewDB("MigrateCtx", fmt.Sprintf("failed to record migration %d", m.version), err)
+		}
+		done = append(done, models.Migration{Version: m.version, Name: m.name, AppliedAt: &at})
+	}
+	return done, nil
+}
+
+// applyMigration runs m's statements, skipping those whose change is already in place.
+func applyMigration(ctx context.Context, conn *sql.Conn, m migration) error {
+	if m.enum != nil {
+		var columnType string
+		err := conn.QueryRowContext(ctx, `SELECT COLUMN_TYPE FROM information_schema.COLUMNS
+			WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?`, m.enum.table, m.enum.column).Scan(&columnType)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if !enumLacks(columnType, m.enum.value) {
+			return nil
+		}
+	}
+	for _, stmt := range m.stmts {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil && !alreadyApplied(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// appliedMigrations returns when each applied migration was applied. Before the first run
+// there is no schema_migrations table and nothing is applied.
+func appliedMigrations(ctx context.Context, q interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}) (map[int]time.Time, error) {
+	applied := map[int]time.Time{}
+	rows, err := q.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		var me *mysql.MySQLError
+		if errors.As(err, &me) && me.Number == mysqlNoSuchTable {
+			return applied, nil
+		}
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var version int
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		applied[version] = at
+	}
+	return applied, rows.Err()
+}
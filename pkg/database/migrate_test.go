@@ -0,0 +1,126 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestMigrationsMatchDBChanges(t *testing.T) {
+	doc, err := os.ReadFile("../../db_changes.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The "-- Up" statements of each "## N." section. Section 3's index is optional and
+	// section 38 is the runner's own table. Section 2's heading is not a "##" heading, and it
+	// names the table editor_feedback where the code has always used
+	// venue_validation_editor_feedback.
+	want := map[int][]string{}
+	if i := strings.Index(string(doc), "2. New table"); i >= 0 {
+		sec2 := strings.Replace(string(doc[i:]), "EXISTS editor_feedback (", "EXISTS venue_validation_editor_feedback (", 1)
+		want[2] = upStatements(strings.Replace(sec2, "-- Up", "\n-- Up\n", 1))
+	}
+	versions := []int{1, 2}
+	for _, sec := range regexp.MustCompile(`(?m)^## `).Split(string(doc), -1) {
+		n, err := strconv.Atoi(strings.SplitN(sec, ".", 2)[0])
+		if err != nil || n == 2 || n == 3 || n == 38 {
+			continue
+		}
+		if stmts := upStatements(sec); len(stmts) > 0 {
+			want[n] = stmts
+			if n != 1 {
+				versions = append(versions, n)
+			}
+		}
+	}
+	var got []int
+	for _, m := range migrations {
+		got = append(got, m.version)
+	}
+	if fmt.Sprint(got) != fmt.Sprint(versions) {
+		t.Fatalf("migrations %v, want one per db_changes.md section %v", got, versions)
+	}
+
+	names := map[string]bool{}
+	for _, m := range migrations {
+		if names[m.name] || len(m.name) > 100 || len(m.stmts) == 0 {
+			t.Errorf("migration %d: bad name %q or no statements", m.version, m.name)
+		}
+		names[m.name] = true
+		for _, s := range m.stmts {
+			if strings.Contains(s, ";") {
+				t.Errorf("migration %d: one statement per entry, got %q", m.version, s)
+			}
+		}
+		var stmts []string
+		for _, s := range m.stmts {
+			stmts = append(stmts, normalizeSQL(s))
+		}
+		if fmt.Sprint(stmts) != fmt.Sprint(want[m.version]) {
+			t.Errorf("migration %d differs from db_changes.md section %d:\n got %q\nwant %q", m.version, m.version, stmts, want[m.version])
+		}
+	}
+}
+
+// upStatements returns the statements of a section's "-- Up" block, whitespace normalised.
+// The block ends at the next comment line ("-- Down", "-- Restore ...") or the fence.
+func upStatements(section string) []string {
+	i := strings.Index(section, "\n-- Up")
+	if i < 0 {
+		return nil
+	}
+	block := section[i+1:]
+	block = block[strings.Index(block, "\n")+1:]
+	for _, end := range []string{"\n-- ", "\n```"} {
+		if j := strings.Index(block, end); j >= 0 {
+			block = block[:j]
+		}
+	}
+	var out []string
+	for _, s := range strings.Split(block, ";") {
+		if s = normalizeSQL(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// normalizeSQL collapses whitespace so indentation differences do not count.
+func normalizeSQL(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func TestEnumLacks(t *testing.T) {
+	tests := []struct {
+		columnType string
+		want       bool
+	}{
+		{"enum('approved','rejected')", true},
+		{"enum('approved','rejected','reverted')", false},
+		{"varchar(32)", false},
+		{"", false}, // no such column
+	}
+	for _, tt := range tests {
+		if got := enumLacks(tt.columnType, "reverted"); got != tt.want {
+			t.Errorf("enumLacks(%q) = %v, want %v", tt.columnType, got, tt.want)
+		}
+	}
+}
+
+func TestAlreadyApplied(t *testing.T) {
+	for number, want := range map[uint16]bool{mysqlDuplicateField: true, mysqlDuplicateKey: true, mysqlTableExists: true, mysqlNoSuchTable: false} {
+		err := fmt.Errorf("exec: %w", &mysql.MySQLError{Number: number})
+		if got := alreadyApplied(err); got != want {
+			t.Errorf("error %d: alreadyApplied = %v, want %v", number, got, want)
+		}
+	}
+	if alreadyApplied(errors.New("connection refused")) {
+		t.Error("non-MySQL error counted as applied")
+	}
+}
@@ -0,0 +1,601 @@
+package database
+
+// migrations are the schema changes of db_changes.md that MigrateCtx applies, numbered as
+// their sections there. Sections without SQL, and the optional index of section 3, have
+// none. Add a migration here with every new section; TestMigrationsMatchDBChanges compares
+// the statements with the section's Up SQL, so an edit to either must be made to both.
+var migrations = []migration{
+	{
+		version: 1,
+		name:    "prompt_version",
+		stmts: []string{
+			`ALTER TABLE venue_validation_histories
+				ADD COLUMN prompt_version VARCHAR(32) NULL AFTER ai_output_data,
+				ADD INDEX idx_vvh_prompt_version (prompt_version)`,
+		},
+	},
+	{
+		version: 2,
+		name:    "editor_feedback",
+		stmts: []string{
+			// Section 2 names the table editor_feedback; the code has always used this name
+			`CREATE TABLE IF NOT EXISTS venue_validation_editor_feedback (
+				id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+				venue_id BIGINT NOT NULL,
+				prompt_version VARCHAR(32) NULL,
+				feedback_type ENUM('thumbs_up','thumbs_down') NOT NULL,
+				comment TEXT NULL,
+				ip VARBINARY(16) NULL,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (id),
+				KEY idx_editor_feedback_venue_id (venue_id),
+				KEY idx_editor_feedback_prompt_version (prompt_version),
+				KEY idx_editor_feedback_created_at (created_at)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+		},
+	},
+	{
+		version: 7,
+		name:    "audit_status_notified",
+		enum:    &enumChange{table: "venue_validation_audit_logs", column: "status", value: "notify_failed"},
+		stmts: []string{
+			`ALTER TABLE venue_validation_audit_logs
+				MODIFY COLUMN status ENUM('approved','rejected','notified','notify_failed') NOT NULL`,
+		},
+	},
+	{
+		version: 8,
+		name:    "sandbox",
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS venue_validation_sandbox (
+				id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+				venue_id BIGINT NOT NULL,
+				validation_score INT NOT NULL,
+				validation_status VARCHAR(32) NOT NULL,
+				validation_notes TEXT NULL,
+				score_breakdown JSON NULL,
+				google_place_id VARCHAR(255) NULL,
+				google_place_found TINYINT(1) NOT NULL DEFAULT 0,
+				google_place_data JSON NULL,
+				ai_output_data LONGTEXT NULL,
+				prompt_version VARCHAR(32) NULL,
+				processed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (id),
+				KEY idx_vvs_venue_processed (venue_id, processed_at),
+				KEY idx_vvs_processed_at (processed_at)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+		},
+	},
+	{
+		version: 9,
+		name:    "event_projections",
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS event_projection_offsets (
+				name VARCHAR(64) NOT NULL,
+				last_seq BIGINT NOT NULL DEFAULT 0,
+				updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (name)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+			`CREATE TABLE IF NOT EXISTS venue_timeline (
+				seq BIGINT NOT NULL,
+				venue_id BIGINT NOT NULL,
+				type VARCHAR(64) NOT NULL,
+				ts TIMESTAMP(6) NOT NULL,
+				admin VARCHAR(255) NULL,
+				summary VARCHAR(1024) NOT NULL,
+				score INT NULL,
+				PRIMARY KEY (seq),
+				KEY idx_vt_venue_seq (venue_id, seq)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+			`CREATE TABLE IF NOT EXISTS admin_activity_daily (
+				admin VARCHAR(255) NOT NULL,
+				day DATE NOT NULL,
+				approved INT NOT NULL DEFAULT 0,
+				rejected INT NOT NULL DEFAULT 0,
+				manual_review INT NOT NULL DEFAULT 0,
+				last_action_at TIMESTAMP(6) NULL,
+				PRIMARY KEY (admin, day),
+				KEY idx_aad_day (day)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+			`CREATE TABLE IF NOT EXISTS decision_counts_daily (
+				day DATE NOT NULL,
+				source VARCHAR(16) NOT NULL,
+				approved INT NOT NULL DEFAULT 0,
+				rejected INT NOT NULL DEFAULT 0,
+				manual_review INT NOT NULL DEFAULT 0,
+				PRIMARY KEY (day, source)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+		},
+	},
+	{
+		version: 10,
+		name:    "event_outbox",
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS event_outbox (
+				id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+				venue_id BIGINT NOT NULL,
+				type VARCHAR(64) NOT NULL,
+				ts TIMESTAMP(6) NOT NULL,
+				admin VARCHAR(255) NULL,
+				payload JSON NOT NULL,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				dispatched_at TIMESTAMP NULL,
+				attempts INT NOT NULL DEFAULT 0,
+				last_error VARCHAR(512) NULL,
+				PRIMARY KEY (id),
+				KEY idx_eo_pending (dispatched_at, id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+		},
+	},
+	{
+		version: 11,
+		name:    "api_tokens",
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS api_tokens (
+				id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+				name VARCHAR(100) NOT NULL,
+				prefix VARCHAR(16) NOT NULL,
+				token_hash CHAR(64) NOT NULL,
+				scopes VARCHAR(255) NOT NULL,
+				admin_id INT NOT NULL,
+				created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				expires_at TIMESTAMP NULL,
+				last_used_at TIMESTAMP NULL,
+				revoked_at TIMESTAMP NULL,
+				PRIMARY KEY (id),
+				UNIQUE KEY uq_api_tokens_hash (token_hash)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+		},
+	},
+	{
+		version: 12,
+		name:    "venue_drafts",
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS venue_drafts (
+				venue_id BIGINT NOT NULL,
+				editor_id INT NOT NULL,
+				version INT NOT NULL,
+				fields JSON NOT NULL,
+				updated_at TIMESTAMP NOT NULL,
+				PRIMARY KEY (venue_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+		},
+	},
+	{
+		version: 13,
+		name:    "audit_status_reverted",
+		enum:    &enumChange{table: "venue_validation_audit_logs", column: "status", value: "reverted"},
+		stmts: []string{
+			`ALTER TABLE venue_validation_audit_logs
+				MODIFY COLUMN status ENUM('approved','rejected','notified','notify_failed','reverted') NOT NULL`,
+		},
+	},
+	{
+		version: 14,
+		name:    "history_archive",
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS venue_validation_histories_archive (
+				id BIGINT NOT NULL,
+				venue_id BIGINT NOT NULL,
+				validation_score INT NOT NULL,
+				validation_status VARCHAR(32) NOT NULL,
+				validation_notes TEXT NULL,
+				score_breakdown JSON NULL,
+				google_place_id VARCHAR(255) NULL,
+				google_place_found TINYINT(1) NOT NULL DEFAULT 0,
+				google_place_data JSON NULL,
+				ai_output_data LONGTEXT NULL,
+				prompt_version VARCHAR(32) NULL,
+				processed_at TIMESTAMP NOT NULL,
+				archived_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (id),
+				KEY idx_vvha_venue_processed (venue_id, processed_at)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+		},
+	},
+	{
+		version: 15,
+		name:    "audit_status_place_relinked",
+		enum:    &enumChange{table: "venue_validation_audit_logs", column: "status", value: "place_relinked"},
+		stmts: []string{
+			`ALTER TABLE venue_validation_audit_logs
+				MODIFY COLUMN status ENUM('approved','rejected','notified','notify_failed','reverted','place_relinked') NOT NULL`,
+		},
+	},
+	{
+		version: 16,
+		name:    "processing_runs",
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS processing_runs (
+				id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+				triggered_by INT NULL,
+				mode VARCHAR(16) NOT NULL,
+				filters JSON NOT NULL,
+				venue_count INT NOT NULL,
+				status VARCHAR(16) NOT NULL,
+				started_at TIMESTAMP NOT NULL,
+				finished_at TIMESTAMP NULL,
+				approved INT NOT NULL DEFAULT 0,
+				rejected INT NOT NULL DEFAULT 0,
+				manual_review INT NOT NULL DEFAULT 0,
+				failed INT NOT NULL DEFAULT 0,
+				estimated_cost_usd DECIMAL(10,4) NOT NULL DEFAULT 0,
+				openai_cost_usd DECIMAL(10,4) NOT NULL DEFAULT 0,
+				PRIMARY KEY (id),
+				KEY idx_pr_started (started_at)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+			`ALTER TABLE venue_validation_histories
+				ADD COLUMN run_id BIGINT UNSIGNED NULL AFTER prompt_version,
+				ADD KEY idx_vvh_run (run_id)`,
+			`ALTER TABLE venue_validation_histories_archive
+				ADD COLUMN run_id BIGINT UNSIGNED NULL AFTER prompt_version`,
+		},
+	},
+	{
+		version: 17,
+		name:    "processing_queue",
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS processing_queue (
+				id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+				venue_id BIGINT UNSIGNED NOT NULL,
+				mode VARCHAR(16) NOT NULL,
+				run_id BIGINT UNSIGNED NULL,
+				priority INT NOT NULL DEFAULT 0,
+				claimed_by VARCHAR(128) NULL,
+				claimed_at TIMESTAMP NULL,
+				attempts INT NOT NULL DEFAULT 0,
+				created_at TIMESTAMP NOT NULL,
+				PRIMARY KEY (id),
+				UNIQUE KEY uq_pq_venue (venue_id),
+				KEY idx_pq_waiting (claimed_by, priority, id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+			`CREATE TABLE IF NOT EXISTS processing_instances (
+				id VARCHAR(128) NOT NULL,
+				hostname VARCHAR(255) NOT NULL,
+				started_at TIMESTAMP NOT NULL,
+				last_heartbeat TIMESTAMP NOT NULL,
+				PRIMARY KEY (id),
+				KEY idx_pi_heartbeat (last_heartbeat)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+		},
+	},
+	{
+		version: 18,
+		name:    "processing_checkpoints",
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS processing_checkpoints (
+				venue_id BIGINT UNSIGNED NOT NULL,
+				stage VARCHAR(16) NOT NULL,
+				venue_updated_at TIMESTAMP NULL,
+				enriched_venue JSON NOT NULL,
+				validation_result JSON NULL,
+				created_at TIMESTAMP NOT NULL,
+				PRIMARY KEY (venue_id),
+				KEY idx_pc_created (created_at)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+		},
+	},
+	{
+		version: 19,
+		name:    "submitter_rules",
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS submitter_rules (
+				id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+				kind VARCHAR(16) NOT NULL,
+				value VARCHAR(255) NOT NULL,
+				action VARCHAR(16) NOT NULL,
+				note VARCHAR(255) NOT NULL DEFAULT '',
+				admin_id INT NOT NULL,
+				created_at TIMESTAMP NOT NULL,
+				PRIMARY KEY (id),
+				UNIQUE KEY uq_submitter_rules_kind_value (kind, value)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+		},
+	},
+	{
+		version: 20,
+		name:    "prompt_version_96",
+		stmts: []string{
+			`ALTER TABLE venue_validation_histories MODIFY COLUMN prompt_version VARCHAR(96) NULL`,
+			`ALTER TABLE venue_validation_histories_archive MODIFY COLUMN prompt_version VARCHAR(96) NULL`,
+			`ALTER TABLE venue_validation_editor_feedback MODIFY COLUMN prompt_version VARCHAR(96) NULL`,
+			`ALTER TABLE venue_validation_sandbox MODIFY COLUMN prompt_version VARCHAR(96) NULL`,
+		},
+	},
+	{
+		version: 21,
+		name:    "batch_scoring",
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS scoring_batches (
+				id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+				openai_batch_id VARCHAR(64) NOT NULL,
+				input_file_id VARCHAR(64) NOT NULL,
+				output_file_id VARCHAR(64) NULL,
+				error_file_id VARCHAR(64) NULL,
+				status VARCHAR(16) NOT NULL,
+				item_count INT NOT NULL,
+				created_at TIMESTAMP NOT NULL,
+				updated_at TIMESTAMP NOT NULL,
+				ingested_at TIMESTAMP NULL,
+				PRIMARY KEY (id),
+				UNIQUE KEY uq_scoring_batches_openai (openai_batch_id),
+				KEY idx_scoring_batches_status (status)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+			`CREATE TABLE IF NOT EXISTS scoring_batch_items (
+				id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+				batch_id BIGINT UNSIGNED NULL,
+				venue_id BIGINT NOT NULL,
+				run_id BIGINT UNSIGNED NULL,
+				status VARCHAR(16) NOT NULL,
+				request JSON NOT NULL,
+				prompt_version VARCHAR(96) NOT NULL,
+				token_usage JSON NULL,
+				enriched_venue JSON NOT NULL,
+				translation JSON NULL,
+				error VARCHAR(500) NULL,
+				created_at TIMESTAMP NOT NULL,
+				PRIMARY KEY (id),
+				KEY idx_scoring_batch_items_status (status, id),
+				KEY idx_scoring_batch_items_batch (batch_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+		},
+	},
+	{
+		version: 22,
+		name:    "venue_embeddings",
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS venue_embeddings (
+				venue_id BIGINT NOT NULL,
+				model VARCHAR(64) NOT NULL,
+				text_hash CHAR(64) NOT NULL,
+				vector BLOB NOT NULL,
+				updated_at TIMESTAMP NOT NULL,
+				PRIMARY KEY (venue_id, model),
+				KEY idx_venue_embeddings_model_updated (model, updated_at)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+		},
+	},
+	{
+		version: 23,
+		name:    "venue_holds",
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS venue_holds (
+				id BIGINT NOT NULL AUTO_INCREMENT,
+				venue_id BIGINT NOT NULL,
+				admin_id INT NOT NULL,
+				reason VARCHAR(500) NOT NULL,
+				remind_at DATETIME NULL,
+				created_at DATETIME NOT NULL,
+				released_at DATETIME NULL,
+				released_by INT NULL,
+				PRIMARY KEY (id),
+				KEY idx_venue_holds_venue_released (venue_id, released_at),
+				KEY idx_venue_holds_released_remind (released_at, remind_at)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+		},
+	},
+	{
+		version: 24,
+		name:    "venue_claims",
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS venue_claims (
+				venue_id BIGINT NOT NULL,
+				admin_id INT NOT NULL,
+				claimed_at DATETIME NOT NULL,
+				last_active_at DATETIME NOT NULL,
+				PRIMARY KEY (venue_id),
+				KEY idx_venue_claims_admin_active (admin_id, last_active_at)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+		},
+	},
+	{
+		version: 25,
+		name:    "venue_comments",
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS venue_comments (
+				id BIGINT NOT NULL AUTO_INCREMENT,
+				venue_id BIGINT NOT NULL,
+				parent_id BIGINT NULL,
+				admin_id INT NOT NULL,
+				body TEXT NOT NULL,
+				mentions JSON NULL,
+				created_at DATETIME NOT NULL,
+				PRIMARY KEY (id),
+				KEY idx_venue_comments_venue (venue_id, id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+		},
+	},
+	{
+		version: 26,
+		name:    "saved_filters",
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS saved_filters (
+				id BIGINT NOT NULL AUTO_INCREMENT,
+				admin_id INT NOT NULL,
+				list VARCHAR(32) NOT NULL,
+				name VARCHAR(100) NOT NULL,
+				query VARCHAR(1000) NOT NULL,
+				is_default TINYINT(1) NOT NULL DEFAULT 0,
+				created_at DATETIME NOT NULL,
+				PRIMARY KEY (id),
+				UNIQUE KEY uq_saved_filters_admin_list_name (admin_id, list, name)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+		},
+	},
+	{
+		version: 27,
+		name:    "venue_path_index",
+		stmts: []string{
+			`CREATE INDEX idx_venues_active_path ON venues (active, path)`,
+		},
+	},
+	{
+		version: 28,
+		name:    "scoring_versions",
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS scoring_versions (
+				kind VARCHAR(32) NOT NULL,
+				version VARCHAR(64) NOT NULL,
+				since DATETIME NULL,
+				PRIMARY KEY (kind)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+		},
+	},
+	{
+		version: 29,
+		name:    "config_changes",
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS config_changes (
+				id BIGINT NOT NULL AUTO_INCREMENT,
+				admin_id INT NOT NULL,
+				setting VARCHAR(64) NOT NULL,
+				old_value VARCHAR(255) NOT NULL,
+				new_value VARCHAR(255) NOT NULL,
+				changed_at DATETIME NOT NULL,
+				PRIMARY KEY (id),
+				KEY idx_config_changes_setting (setting, changed_at)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+		},
+	},
+	{
+		version: 30,
+		name:    "feature_flags",
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS feature_flags (
+				name VARCHAR(64) NOT NULL,
+				enabled TINYINT(1) NOT NULL DEFAULT 0,
+				rollout_percent TINYINT UNSIGNED NOT NULL DEFAULT 100,
+				updated_by INT NOT NULL,
+				updated_at DATETIME NOT NULL,
+				PRIMARY KEY (name)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+		},
+	},
+	{
+		version: 31,
+		name:    "audit_log_index",
+		stmts: []string{
+			`ALTER TABLE venue_validation_audit_logs
+				ADD INDEX idx_audit_logs_created (created_at),
+				ADD INDEX idx_audit_logs_admin_created (admin_id, created_at)`,
+		},
+	},
+	{
+		version: 32,
+		name:    "closure_reviews",
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS venue_closure_checks (
+				venue_id BIGINT NOT NULL,
+				place_id VARCHAR(255) NOT NULL,
+				business_status VARCHAR(32) NOT NULL DEFAULT '',
+				checked_at DATETIME NOT NULL,
+				PRIMARY KEY (venue_id),
+				KEY idx_venue_closure_checks_checked (checked_at)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+			`CREATE TABLE IF NOT EXISTS venue_closure_reviews (
+				id BIGINT NOT NULL AUTO_INCREMENT,
+				venue_id BIGINT NOT NULL,
+				place_id VARCHAR(255) NOT NULL,
+				business_status VARCHAR(32) NOT NULL,
+				created_at DATETIME NOT NULL,
+				resolution VARCHAR(16) NULL,
+				resolved_by INT NULL,
+				resolved_at DATETIME NULL,
+				PRIMARY KEY (id),
+				KEY idx_venue_closure_reviews_venue_place (venue_id, place_id),
+				KEY idx_venue_closure_reviews_resolved (resolved_at, created_at)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+		},
+	},
+	{
+		version: 33,
+		name:    "change_requests",
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS venue_change_requests (
+				id BIGINT NOT NULL AUTO_INCREMENT,
+				venue_id BIGINT NOT NULL,
+				user_id INT NOT NULL,
+				proposed_data JSON NOT NULL,
+				note TEXT NULL,
+				status VARCHAR(16) NOT NULL DEFAULT 'pending',
+				created_at DATETIME NOT NULL,
+				ai_score INT NULL,
+				ai_output JSON NULL,
+				recommendation VARCHAR(32) NULL,
+				recommendation_reason TEXT NULL,
+				validated_at DATETIME NULL,
+				decided_by INT NULL,
+				decided_at DATETIME NULL,
+				decision_reason TEXT NULL,
+				PRIMARY KEY (id),
+				KEY idx_venue_change_requests_status (status, created_at),
+				KEY idx_venue_change_requests_venue (venue_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+		},
+	},
+	{
+		version: 34,
+		name:    "category_reviews",
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS venue_category_reviews (
+				id BIGINT NOT NULL AUTO_INCREMENT,
+				venue_id BIGINT NOT NULL,
+				history_id BIGINT NULL,
+				submitted_category INT NOT NULL,
+				suggested_category INT NOT NULL,
+				final_category INT NOT NULL,
+				confidence VARCHAR(8) NOT NULL,
+				mismatch TINYINT(1) NOT NULL,
+				source VARCHAR(32) NOT NULL,
+				outcome VARCHAR(16) NOT NULL,
+				admin_id INT NOT NULL,
+				reviewed_at DATETIME NOT NULL,
+				PRIMARY KEY (id),
+				KEY idx_venue_category_reviews_reviewed (reviewed_at),
+				KEY idx_venue_category_reviews_venue (venue_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+		},
+	},
+	{
+		version: 35,
+		name:    "ui_preferences",
+		stmts: []string{
+			`CREATE TABLE IF NOT EXISTS admin_ui_preferences (
+				admin_id INT NOT NULL,
+				theme VARCHAR(16) NOT NULL,
+				rows_per_page INT NOT NULL,
+				default_sort VARCHAR(32) NOT NULL,
+				compact_tables TINYINT(1) NOT NULL DEFAULT 0,
+				updated_at DATETIME NOT NULL,
+				PRIMARY KEY (admin_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`,
+		},
+	},
+	{
+		version: 36,
+		name:    "audit_status_duplicate_rejected",
+		enum:    &enumChange{table: "venue_validation_audit_logs", column: "status", value: "duplicate_rejected"},
+		stmts: []string{
+			`ALTER TABLE venue_validation_audit_logs
+				MODIFY COLUMN status ENUM('approved','rejected','notified','notify_failed','reverted','place_relinked','change_applied','change_rejected','duplicate_rejected') NOT NULL`,
+		},
+	},
+	{
+		version: 37,
+		name:    "fulltext_search",
+		stmts: []string{
+			`ALTER TABLE venues
+				ADD FULLTEXT INDEX ft_venues_search (name, location, vdetails, additionalinfo, admin_note)`,
+			`ALTER TABLE venue_validation_histories
+				ADD FULLTEXT INDEX ft_histories_notes (validation_notes)`,
+		},
+	},
+	{
+		version: 39,
+		name:    "outbox_failed_at",
+		stmts: []string{
+			`ALTER TABLE event_outbox
+				ADD COLUMN failed_at TIMESTAMP NULL AFTER last_error`,
+		},
+	},
+}
@@ -0,0 +1,23 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"assisted-venue-approval/internal/domain"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// EnqueueEventTx writes an event to event_outbox within the caller's transaction.
+// The outbox dispatcher (pkg/events) moves it to the event store after commit.
+func (db *DB) EnqueueEventTx(ctx context.Context, tx *sql.Tx, ev domain.OutboxEvent) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	query := `INSERT INTO event_outbox (venue_id, type, ts, admin, payload) VALUES (?, ?, ?, ?, ?)`
+	if _, err := tx.ExecContext(ctx, query, ev.VenueID, ev.Type, ev.Ts, ev.Admin, json.RawMessage(ev.Payload)); err != nil {
+		return errs.NewDB("EnqueueEventTx", "failed to insert outbox event", err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// failedHistory holds for a history row h written for a failed validation: only those carry
+// the models.PipelineProgress of how far it got. ai_output_data is LONGTEXT, so rows that are
+// not JSON count as not failed instead of failing the query.
+const failedHistory = "COALESCE(JSON_CONTAINS_PATH(IF(JSON_VALID(h.ai_output_data), h.ai_output_data, NULL), 'one', '$.progress'), 0) = 1"
+
+// pendingVenueFilter builds the WHERE clause selecting pending venues by f. Score, prompt
+// version and failure conditions use the alias h for the venue's latest validation history.
+func pendingVenueFilter(f models.PendingVenueFilter, holds bool) (string, []interface{}) {
+	where := "WHERE v.active = 0"
+	args := []interface{}{}
+	if f.PathPrefix != "" {
+		where += " AND v.path LIKE ?"
+		args = append(args, pathPrefixPattern(f.PathPrefix))
+	}
+	if f.Category != nil {
+		where += " AND v.category = ?"
+		args = append(args, *f.Category)
+	}
+	if f.MinScore > 0 {
+		where += " AND h.validation_score >= ?"
+		args = append(args, f.MinScore)
+	}
+	if f.MaxScore > 0 {
+		where += " AND h.validation_score <= ?"
+		args = append(args, f.MaxScore)
+	}
+	if !f.SubmittedAfter.IsZero() {
+		where += " AND v.created_at >= ?"
+		args = append(args, f.SubmittedAfter)
+	}
+	if f.PromptVersion != "" {
+		where += " AND h.prompt_version = ?"
+		args = append(args, f.PromptVersion)
+	}
+	if f.Failed {
+		where += " AND " + failedHistory
+	}
+	// Held venues stay out until released, as in the manual review queue
+	if holds {
+		where += " AND NOT " + heldClause
+	}
+	return where, args
+}
+
+// GetPendingVenueIDsByFilterCtx returns the IDs of up to limit pending venues matching f,
+// oldest submission first.
+func (db *DB) GetPendingVenueIDsByFilterCtx(ctx context.Context, f models.PendingVenueFilter, limit int) ([]int64, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	where, args := pendingVenueFilter(f, db.holds)
+	args = append(args, limit)
+	rows, err := db.conn.QueryContext(ctx, `SELECT v.id
+		FROM venues v
+		LEFT JOIN venue_validation_histories h ON h.id = (
+			SELECT h2.id FROM venue_validation_histories h2 WHERE h2.venue_id = v.id
+			ORDER BY h2.processed_at DESC, h2.id DESC LIMIT 1)
+		`+where+`
+		ORDER BY v.created_at ASC, v.id ASC LIMIT ?`, args...)
+	if err != nil {
+		return nil, errs.NewDB("GetPendingVenueIDsByFilterCtx", "failed to query pending venues by filter", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, errs.NewDB("GetPendingVenueIDsByFilterCtx", "failed to scan venue id", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("GetPendingVenueIDsByFilterCtx", "failed to iterate venue ids", err)
+	}
+	return ids, nil
+}
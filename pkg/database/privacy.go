@@ -0,0 +1,208 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// memberVenueIDs selects the venues a member submitted; used as a subquery with one user_id arg.
+const memberVenueIDs = `SELECT id FROM venues WHERE user_id = ?`
+
+// ExportMemberDataCtx collects everything stored about a member for a data subject access
+// request: their venues, the validation histories (live and archived) and editor feedback
+// recorded against those venues.
+func (db *DB) ExportMemberDataCtx(ctx context.Context, memberID int64) (*models.MemberDataExport, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	out := &models.MemberDataExport{MemberID: memberID, GeneratedAt: time.Now().UTC()}
+	var email sql.NullString
+	err := db.conn.QueryRowContext(ctx, `SELECT username, email FROM members WHERE id = ?`, memberID).Scan(&out.Username, &email)
+	if err == sql.ErrNoRows {
+		return nil, errs.NewNotFound("ExportMemberDataCtx", fmt.Sprintf("member %d", memberID), err)
+	}
+	if err != nil {
+		return nil, errs.NewDB("ExportMemberDataCtx", "failed to load member", err)
+	}
+	out.Email = email.String
+
+	rows, err := db.conn.QueryContext(ctx, `SELECT id, name, location, phone, url, email, ownername, sentby, active, created_at
+		FROM venues WHERE user_id = ? ORDER BY id`, memberID)
+	if err != nil {
+		return nil, errs.NewDB("ExportMemberDataCtx", "failed to query venues", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var v models.MemberVenue
+		if err := rows.Scan(&v.ID, &v.Name, &v.Location, &v.Phone, &v.URL, &v.Email, &v.OwnerName, &v.SentBy,
+			&v.Active, &v.CreatedAt); err != nil {
+			return nil, errs.NewDB("ExportMemberDataCtx", "failed to scan venue", err)
+		}
+		out.Venues = append(out.Venues, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("ExportMemberDataCtx", "failed to iterate venues", err)
+	}
+
+	for _, t := range []struct {
+		table string
+		dst   *[]models.ValidationHistory
+	}{
+		{"venue_validation_histories", &out.Histories},
+		{"venue_validation_histories_archive", &out.ArchivedHistories},
+	} {
+		hs, err := db.memberHistories(ctx, t.table, memberID)
+		if err != nil {
+			return nil, err
+		}
+		*t.dst = hs
+	}
+
+	frows, err := db.conn.QueryContext(ctx, `SELECT id, venue_id, feedback_type, comment, ip, created_at
+		FROM venue_validation_editor_feedback WHERE venue_id IN (`+memberVenueIDs+`) ORDER BY id`, memberID)
+	if err != nil {
+		return nil, errs.NewDB("ExportMemberDataCtx", "failed to query editor feedback", err)
+	}
+	defer frows.Close()
+	for frows.Next() {
+		var f models.MemberFeedbackRecord
+		var ip []byte
+		if err := frows.Scan(&f.ID, &f.VenueID, &f.FeedbackType, &f.Comment, &ip, &f.CreatedAt); err != nil {
+			return nil, errs.NewDB("ExportMemberDataCtx", "failed to scan editor feedback", err)
+		}
+		f.IP = models.FormatStoredIP(ip)
+		out.Feedback = append(out.Feedback, f)
+	}
+	if err := frows.Err(); err != nil {
+		return nil, errs.NewDB("ExportMemberDataCtx", "failed to iterate editor feedback", err)
+	}
+	return out, nil
+}
+
+func (db *DB) memberHistories(ctx context.Context, table string, memberID int64) ([]models.ValidationHistory, error) {
+	rows, err := db.conn.QueryContext(ctx, `SELECT `+historyListColumns+` FROM `+table+`
+		WHERE venue_id IN (`+memberVenueIDs+`) ORDER BY processed_at, id`, memberID)
+	if err != nil {
+		return nil, errs.NewDB("ExportMemberDataCtx", "failed to query "+table, err)
+	}
+	defer rows.Close()
+	var out []models.ValidationHistory
+	for rows.Next() {
+		h, err := scanHistoryListRow(rows)
+		if err != nil {
+			return nil, errs.NewDB("ExportMemberDataCtx", "failed to scan "+table, err)
+		}
+		out = append(out, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("ExportMemberDataCtx", "failed to iterate "+table, err)
+	}
+	return out, nil
+}
+
+// PseudonymizeMemberDataCtx handles an erasure request in one transaction: feedback IPs on
+// the member's venues are replaced by PseudonymizeIP(ipKey, ip) and the member's email is
+// replaced by models.RedactedPlaceholder in validation notes and AI output, live and archived.
+// Rows already processed are left alone, so repeating a request is harmless. The members and
+// venues tables belong to the main site and are not modified here.
+func (db *DB) PseudonymizeMemberDataCtx(ctx context.Context, memberID int64, ipKey []byte) (*models.MemberErasure, error) {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errs.NewDB("PseudonymizeMemberDataCtx", "failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	var email sql.NullString
+	err = tx.QueryRowContext(ctx, `SELECT email FROM members WHERE id = ?`, memberID).Scan(&email)
+	if err == sql.ErrNoRows {
+		return nil, errs.NewNotFound("PseudonymizeMemberDataCtx", fmt.Sprintf("member %d", memberID), err)
+	}
+	if err != nil {
+		return nil, errs.NewDB("PseudonymizeMemberDataCtx", "failed to load member", err)
+	}
+	res := &models.MemberErasure{MemberID: memberID}
+
+	rows, err := tx.QueryContext(ctx, `SELECT id, ip FROM venue_validation_editor_feedback
+		WHERE venue_id IN (`+memberVenueIDs+`) AND ip IS NOT NULL AND LENGTH(ip) <> ? FOR UPDATE`,
+		memberID, models.PseudonymizedIPLen)
+	if err != nil {
+		return nil, errs.NewDB("PseudonymizeMemberDataCtx", "failed to select feedback IPs", err)
+	}
+	type feedbackIP struct {
+		id int64
+		ip []byte
+	}
+	var ips []feedbackIP
+	for rows.Next() {
+		var f feedbackIP
+		if err := rows.Scan(&f.id, &f.ip); err != nil {
+			rows.Close()
+			return nil, errs.NewDB("PseudonymizeMemberDataCtx", "failed to scan feedback IP", err)
+		}
+		ips = append(ips, f)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("PseudonymizeMemberDataCtx", "failed to read feedback IPs", err)
+	}
+	for _, f := range ips {
+		if _, err := tx.ExecContext(ctx, `UPDATE venue_validation_editor_feedback SET ip = ? WHERE id = ?`,
+			models.PseudonymizeIP(ipKey, f.ip), f.id); err != nil {
+			return nil, errs.NewDB("PseudonymizeMemberDataCtx", "failed to hash feedback IP", err)
+		}
+	}
+	res.FeedbackIPsHashed = int64(len(ips))
+
+	if email.String != "" {
+		for _, t := range []struct {
+			table string
+			n     *int64
+		}{
+			{"venue_validation_histories", &res.HistoriesRedacted},
+			{"venue_validation_histories_archive", &res.ArchivedRedacted},
+		} {
+			r, err := tx.ExecContext(ctx, `UPDATE `+t.table+`
+				SET validation_notes = REPLACE(validation_notes, ?, ?),
+				    ai_output_data = REPLACE(ai_output_data, ?, ?)
+				WHERE venue_id IN (`+memberVenueIDs+`)
+				  AND (LOCATE(?, validation_notes) > 0 OR LOCATE(?, ai_output_data) > 0)`,
+				email.String, models.RedactedPlaceholder, email.String, models.RedactedPlaceholder,
+				memberID, email.String, email.String)
+			if err != nil {
+				return nil, errs.NewDB("PseudonymizeMemberDataCtx", "failed to redact "+t.table, err)
+			}
+			*t.n, _ = r.RowsAffected()
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errs.NewDB("PseudonymizeMemberDataCtx", "failed to commit", err)
+	}
+	return res, nil
+}
+
+// ScrubFeedbackIPsCtx sets ip to NULL on up to limit editor feedback rows created before the
+// cutoff and returns how many it changed. The rows themselves stay for the statistics.
+func (db *DB) ScrubFeedbackIPsCtx(ctx context.Context, before time.Time, limit int) (int, error) {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	res, err := db.conn.ExecContext(ctx, `UPDATE venue_validation_editor_feedback SET ip = NULL
+		WHERE created_at < ? AND ip IS NOT NULL LIMIT ?`, before, limit)
+	if err != nil {
+		return 0, errs.NewDB("ScrubFeedbackIPsCtx", "failed to clear feedback IPs", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, errs.NewDB("ScrubFeedbackIPsCtx", "failed to read affected rows", err)
+	}
+	return int(n), nil
+}
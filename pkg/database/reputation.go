@@ -0,0 +1,72 @@
+package database
+
+import (
+	"context"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// GetSubmissionHistoryCtx returns how a member's venues were decided. The counts come from the
+// venues' current status. The streak walks the latest approve, reject or revert audit entry
+// of the member's venues, newest first, looking at up to recent venues; venues decided outside
+// this service have no audit entry and do not count towards it.
+func (db *DB) GetSubmissionHistoryCtx(ctx context.Context, userID uint, recent int) (*models.SubmissionHistory, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	h := &models.SubmissionHistory{}
+	err := db.conn.QueryRowContext(ctx, `SELECT
+		COUNT(CASE WHEN active = 1 THEN 1 END),
+		COUNT(CASE WHEN active = -1 THEN 1 END)
+		FROM venues WHERE user_id = ?`, userID).Scan(&h.Approved, &h.Rejected)
+	if err != nil {
+		return nil, errs.NewDB("GetSubmissionHistoryCtx", "failed to count submissions", err)
+	}
+	if h.Rejected == 0 || recent <= 0 {
+		return h, nil
+	}
+
+	rows, err := db.conn.QueryContext(ctx, `SELECT a.status
+		FROM venue_validation_audit_logs a
+		JOIN (SELECT MAX(a2.id) AS id
+		      FROM venue_validation_audit_logs a2
+		      JOIN venues v ON v.id = a2.venue_id
+		      WHERE v.user_id = ? AND a2.status IN ('approved', 'rejected', 'reverted')
+		      GROUP BY a2.venue_id) latest ON latest.id = a.id
+		ORDER BY a.id DESC
+		LIMIT ?`, userID, recent)
+	if err != nil {
+		return nil, errs.NewDB("GetSubmissionHistoryCtx", "failed to query recent decisions", err)
+	}
+	defer rows.Close()
+
+	var statuses []string
+	for rows.Next() {
+		var s string
+		if err := rows.Scan(&s); err != nil {
+			return nil, errs.NewDB("GetSubmissionHistoryCtx", "failed to scan decision", err)
+		}
+		statuses = append(statuses, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("GetSubmissionHistoryCtx", "failed to read decisions", err)
+	}
+	h.RejectionStreak = rejectionStreak(statuses)
+	return h, nil
+}
+
+// rejectionStreak counts the leading rejections in statuses (newest first). Reverted
+// decisions are skipped; the first approval ends the streak.
+func rejectionStreak(statuses []string) int {
+	n := 0
+	for _, s := range statuses {
+		switch s {
+		case "rejected":
+			n++
+		case "approved":
+			return n
+		}
+	}
+	return n
+}
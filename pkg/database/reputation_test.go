@@ -0,0 +1,24 @@
+package database
+
+import "testing"
+
+func TestRejectionStreak(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []string
+		want     int
+	}{
+		{"none", nil, 0},
+		{"latest approved", []string{"approved", "rejected", "rejected"}, 0},
+		{"streak ends at approval", []string{"rejected", "rejected", "approved", "rejected"}, 2},
+		{"reverted skipped", []string{"rejected", "reverted", "rejected"}, 2},
+		{"all rejected", []string{"rejected", "rejected", "rejected"}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rejectionStreak(tt.statuses); got != tt.want {
+				t.Errorf("rejectionStreak(%v) = %d, want %d", tt.statuses, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// SetScoringVersionCtx records version as the current one of kind and returns since when it
+// has been. The first version recorded for a kind is the baseline: since stays NULL, so
+// histories written before re-scoring was enabled are not stale.
+func (db *DB) SetScoringVersionCtx(ctx context.Context, kind, version string) (time.Time, error) {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	if _, err := db.conn.ExecContext(ctx, `INSERT IGNORE INTO scoring_versions (kind, version, since)
+		VALUES (?, ?, NULL)`, kind, version); err != nil {
+		return time.Time{}, errs.NewDB("SetScoringVersionCtx", "failed to record scoring version", err)
+	}
+	// Only the first instance to see a new version moves since
+	if _, err := db.conn.ExecContext(ctx, `UPDATE scoring_versions SET version = ?, since = NOW()
+		WHERE kind = ? AND version <> ?`, version, kind, version); err != nil {
+		return time.Time{}, errs.NewDB("SetScoringVersionCtx", "failed to update scoring version", err)
+	}
+	var since sql.NullTime
+	if err := db.conn.QueryRowContext(ctx, `SELECT since FROM scoring_versions WHERE kind = ?`, kind).Scan(&since); err != nil {
+		return time.Time{}, errs.NewDB("SetScoringVersionCtx", "failed to read scoring version", err)
+	}
+	return since.Time, nil
+}
+
+// staleScoreFilter builds the WHERE clause for ListStaleScoredVenuesCtx; h is the venue's
+// latest validation history. Returns "" when nothing can be stale.
+func staleScoreFilter(q models.StaleScoreQuery, holds bool) (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	if !q.DecisionSince.IsZero() {
+		conds = append(conds, "h.processed_at < ?")
+		args = append(args, q.DecisionSince)
+	}
+	// prompt_version is "<system>+<user>"; a history is stale when either template it
+	// names is no longer loaded at that version
+	if !q.PromptsSince.IsZero() && len(q.SystemPrompts) > 0 && len(q.UserPrompts) > 0 {
+		in := func(n int) string { return strings.TrimSuffix(strings.Repeat("?, ", n), ", ") }
+		conds = append(conds, "(h.processed_at < ? AND (SUBSTRING_INDEX(h.prompt_version, '+', 1) NOT IN ("+
+			in(len(q.SystemPrompts))+") OR SUBSTRING_INDEX(h.prompt_version, '+', -1) NOT IN ("+
+			in(len(q.UserPrompts))+")))")
+		args = append(args, q.PromptsSince)
+		for _, v := range q.SystemPrompts {
+			args = append(args, v)
+		}
+		for _, v := range q.UserPrompts {
+			args = append(args, v)
+		}
+	}
+	if len(conds) == 0 {
+		return "", nil
+	}
+	where := "WHERE v.active = 0 AND (" + strings.Join(conds, " OR ") + ")"
+	if holds {
+		where += " AND NOT " + heldClause
+	}
+	return where, args
+}
+
+// ListStaleScoredVenuesCtx returns up to limit pending venues whose latest validation
+// predates a change of the decision config, or predates a change of the prompts and used a
+// template version no longer loaded. Least recently validated first.
+func (db *DB) ListStaleScoredVenuesCtx(ctx context.Context, q models.StaleScoreQuery, limit int) ([]int64, error) {
+	where, args := staleScoreFilter(q, db.holds)
+	if where == "" {
+		return nil, nil
+	}
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	args = append(args, limit)
+	rows, err := db.conn.QueryContext(ctx, `SELECT v.id
+		FROM venues v
+		JOIN venue_validation_histories h ON h.id = (
+			SELECT h2.id FROM venue_validation_histories h2 WHERE h2.venue_id = v.id
+			ORDER BY h2.processed_at DESC, h2.id DESC LIMIT 1)
+		`+where+`
+		ORDER BY h.processed_at ASC, v.id ASC LIMIT ?`, args...)
+	if err != nil {
+		return nil, errs.NewDB("ListStaleScoredVenuesCtx", "failed to query stale scored venues", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, errs.NewDB("ListStaleScoredVenuesCtx", "failed to scan venue id", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("ListStaleScoredVenuesCtx", "failed to iterate stale scored venues", err)
+	}
+	return ids, nil
+}
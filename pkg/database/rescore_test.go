@@ -0,0 +1,34 @@
+package database
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+)
+
+func TestStaleScoreFilter(t *testing.T) {
+	if where, _ := staleScoreFilter(models.StaleScoreQuery{}, false); where != "" {
+		t.Fatalf("baseline versions filtered: %q", where)
+	}
+
+	since := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	where, args := staleScoreFilter(models.StaleScoreQuery{DecisionSince: since}, true)
+	if !strings.Contains(where, "h.processed_at < ?") || !strings.Contains(where, heldClause) || len(args) != 1 {
+		t.Fatalf("decision: where = %q, args = %v", where, args)
+	}
+
+	q := models.StaleScoreQuery{
+		PromptsSince:  since,
+		SystemPrompts: []string{"system@v2"},
+		UserPrompts:   []string{"unified_user@v3", "unified_user.short@v1"},
+	}
+	where, args = staleScoreFilter(q, false)
+	if strings.Count(where, "NOT IN (?)") != 1 || strings.Count(where, "NOT IN (?, ?)") != 1 || len(args) != 4 {
+		t.Fatalf("prompts: where = %q, args = %v", where, args)
+	}
+	if args[1] != "system@v2" || args[3] != "unified_user.short@v1" {
+		t.Fatalf("prompts args = %v", args)
+	}
+}
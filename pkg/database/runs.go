@@ -0,0 +1,207 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+const processingRunColumns = `id, triggered_by, mode, filters, venue_count, status, started_at, finished_at,
+	approved, rejected, manual_review, failed, estimated_cost_usd, openai_cost_usd`
+
+// CreateProcessingRunCtx inserts a run and sets its ID.
+func (db *DB) CreateProcessingRunCtx(ctx context.Context, run *models.ProcessingRun) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	query := `INSERT INTO processing_runs (triggered_by, mode, filters, venue_count, status, started_at, estimated_cost_usd)
+	          VALUES (?, ?, ?, ?, ?, ?, ?)`
+	res, err := db.conn.ExecContext(ctx, query, run.TriggeredBy, run.Mode, run.Filters, run.VenueCount,
+		run.Status, run.StartedAt, run.EstimatedCostUSD)
+	if err != nil {
+		return errs.NewDB("CreateProcessingRunCtx", "failed to insert processing run", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return errs.NewDB("CreateProcessingRunCtx", "failed to get last insert ID", err)
+	}
+	run.ID = id
+	return nil
+}
+
+// UpdateProcessingRunCtx stores a run's venue count, status, outcome counts and cost.
+func (db *DB) UpdateProcessingRunCtx(ctx context.Context, run *models.ProcessingRun) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	query := `UPDATE processing_runs
+	          SET venue_count = ?, status = ?, finished_at = ?, approved = ?, rejected = ?,
+	              manual_review = ?, failed = ?, openai_cost_usd = ?
+	          WHERE id = ?`
+	if _, err := db.conn.ExecContext(ctx, query, run.VenueCount, run.Status, run.FinishedAt, run.Approved,
+		run.Rejected, run.ManualReview, run.Failed, run.OpenAICostUSD, run.ID); err != nil {
+		return errs.NewDB("UpdateProcessingRunCtx", "failed to update processing run", err)
+	}
+	return nil
+}
+
+// runOutcomeColumns maps an outcome to the processing_runs counter it increments.
+var runOutcomeColumns = map[string]string{
+	models.RunOutcomeApproved:     "approved",
+	models.RunOutcomeRejected:     "rejected",
+	models.RunOutcomeManualReview: "manual_review",
+	models.RunOutcomeFailed:       "failed",
+}
+
+// RecordRunOutcomeCtx counts one venue outcome against a run in the database and completes
+// the run with its last venue. Used when the run's venues are spread over several instances,
+// so no single one holds the counts.
+func (db *DB) RecordRunOutcomeCtx(ctx context.Context, runID int64, outcome string) error {
+	col, ok := runOutcomeColumns[outcome]
+	if !ok {
+		return errs.NewValidation("RecordRunOutcomeCtx", fmt.Sprintf("unknown run outcome %q", outcome), nil)
+	}
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	// MySQL applies single-table SET assignments left to right, so status and finished_at
+	// see the incremented counter
+	query := `UPDATE processing_runs
+	          SET ` + col + ` = ` + col + ` + 1,
+	              status = IF(approved + rejected + manual_review + failed >= venue_count, ?, status),
+	              finished_at = IF(status = ? AND finished_at IS NULL, NOW(), finished_at)
+	          WHERE id = ?`
+	if _, err := db.conn.ExecContext(ctx, query, models.RunStatusCompleted, models.RunStatusCompleted, runID); err != nil {
+		return errs.NewDB("RecordRunOutcomeCtx", "failed to record run outcome", err)
+	}
+	return nil
+}
+
+// SetRunVenueCountCtx corrects a run's venue count after fewer venues than planned were
+// queued, completing it if every queued venue already has an outcome.
+func (db *DB) SetRunVenueCountCtx(ctx context.Context, runID int64, venueCount int) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	query := `UPDATE processing_runs
+	          SET venue_count = ?,
+	              status = IF(approved + rejected + manual_review + failed >= venue_count, ?, status),
+	              finished_at = IF(status = ? AND finished_at IS NULL, NOW(), finished_at)
+	          WHERE id = ?`
+	if _, err := db.conn.ExecContext(ctx, query, venueCount, models.RunStatusCompleted, models.RunStatusCompleted, runID); err != nil {
+		return errs.NewDB("SetRunVenueCountCtx", "failed to update run venue count", err)
+	}
+	return nil
+}
+
+// ListProcessingRunsCtx returns runs newest first, plus the total count.
+func (db *DB) ListProcessingRunsCtx(ctx context.Context, limit, offset int) ([]models.ProcessingRun, int, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	var total int
+	if err := db.conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM processing_runs`).Scan(&total); err != nil {
+		return nil, 0, errs.NewDB("ListProcessingRunsCtx", "failed to count processing runs", err)
+	}
+	rows, err := db.conn.QueryContext(ctx, `SELECT `+processingRunColumns+` FROM processing_runs
+	                                        ORDER BY id DESC LIMIT ? OFFSET ?`, limit, offset)
+	if err != nil {
+		return nil, 0, errs.NewDB("ListProcessingRunsCtx", "failed to query processing runs", err)
+	}
+	defer rows.Close()
+
+	var out []models.ProcessingRun
+	for rows.Next() {
+		run, err := scanProcessingRun(rows)
+		if err != nil {
+			return nil, 0, errs.NewDB("ListProcessingRunsCtx", "failed to scan processing run", err)
+		}
+		out = append(out, *run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, errs.NewDB("ListProcessingRunsCtx", "failed to iterate processing runs", err)
+	}
+	return out, total, nil
+}
+
+// GetProcessingRunCtx returns the run with the given ID, or nil when there is none.
+func (db *DB) GetProcessingRunCtx(ctx context.Context, id int64) (*models.ProcessingRun, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	row := db.conn.QueryRowContext(ctx, `SELECT `+processingRunColumns+` FROM processing_runs WHERE id = ?`, id)
+	run, err := scanProcessingRun(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errs.NewDB("GetProcessingRunCtx", "failed to load processing run", err)
+	}
+	return run, nil
+}
+
+// GetRunValidationHistoryCtx returns the validation histories a run wrote, newest first.
+// Dry runs write none; their results are in the sandbox table.
+func (db *DB) GetRunValidationHistoryCtx(ctx context.Context, runID int64) ([]models.ValidationHistory, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT h.id, h.venue_id, h.validation_score, h.validation_status, h.validation_notes,
+	                 h.score_breakdown, h.google_place_id, h.google_place_found, h.prompt_version,
+	                 h.processed_at, COALESCE(v.name, '')
+	          FROM venue_validation_histories h
+	          LEFT JOIN venues v ON v.id = h.venue_id
+	          WHERE h.run_id = ?
+	          ORDER BY h.processed_at DESC, h.id DESC`
+	rows, err := db.conn.QueryContext(ctx, query, runID)
+	if err != nil {
+		return nil, errs.NewDB("GetRunValidationHistoryCtx", "failed to query run histories", err)
+	}
+	defer rows.Close()
+
+	var out []models.ValidationHistory
+	for rows.Next() {
+		h := models.ValidationHistory{RunID: &runID}
+		var scoreBreakdownJSON string
+		var placeID, pv sql.NullString
+		if err := rows.Scan(&h.ID, &h.VenueID, &h.ValidationScore, &h.ValidationStatus, &h.ValidationNotes,
+			&scoreBreakdownJSON, &placeID, &h.GooglePlaceFound, &pv, &h.ProcessedAt, &h.VenueName); err != nil {
+			return nil, errs.NewDB("GetRunValidationHistoryCtx", "failed to scan run history", err)
+		}
+		if err := json.Unmarshal([]byte(scoreBreakdownJSON), &h.ScoreBreakdown); err != nil {
+			return nil, errs.NewDB("GetRunValidationHistoryCtx", "failed to unmarshal score breakdown", err)
+		}
+		if placeID.Valid {
+			h.GooglePlaceID = &placeID.String
+		}
+		if pv.Valid {
+			h.PromptVersion = &pv.String
+		}
+		out = append(out, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("GetRunValidationHistoryCtx", "failed to iterate run histories", err)
+	}
+	return out, nil
+}
+
+func scanProcessingRun(s rowScanner) (*models.ProcessingRun, error) {
+	var r models.ProcessingRun
+	var triggeredBy sql.NullInt64
+	var finished sql.NullTime
+	if err := s.Scan(&r.ID, &triggeredBy, &r.Mode, &r.Filters, &r.VenueCount, &r.Status, &r.StartedAt, &finished,
+		&r.Approved, &r.Rejected, &r.ManualReview, &r.Failed, &r.EstimatedCostUSD, &r.OpenAICostUSD); err != nil {
+		return nil, err
+	}
+	if triggeredBy.Valid {
+		id := int(triggeredBy.Int64)
+		r.TriggeredBy = &id
+	}
+	r.FinishedAt = nullTimePtr(finished)
+	return &r, nil
+}
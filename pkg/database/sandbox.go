@@ -0,0 +1,90 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// SaveSandboxResultCtx stores a dry-run result in venue_validation_sandbox. Same shape as
+// venue_validation_histories, but nothing else reads it: venues and history stay untouched.
+func (db *DB) SaveSandboxResultCtx(ctx context.Context, result *models.ValidationResult, googleData *models.GooglePlaceData) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	var googlePlaceID, googlePlaceDataJSON *string
+	if googleData != nil {
+		googlePlaceID = &googleData.PlaceID
+		data, err := json.Marshal(googleData)
+		if err != nil {
+			return errs.NewDB("SaveSandboxResultCtx", "failed to marshal Google Places data", err)
+		}
+		s := string(data)
+		googlePlaceDataJSON = &s
+	}
+	scoreBreakdownJSON, err := json.Marshal(result.ScoreBreakdown)
+	if err != nil {
+		return errs.NewDB("SaveSandboxResultCtx", "failed to marshal score breakdown", err)
+	}
+
+	query := `INSERT INTO venue_validation_sandbox
+	          (venue_id, validation_score, validation_status, validation_notes, score_breakdown,
+	           google_place_id, google_place_found, google_place_data, ai_output_data, prompt_version, processed_at)
+	          VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW())`
+	if _, err := db.conn.ExecContext(ctx, query, result.VenueID, result.Score, result.Status, result.Notes,
+		string(scoreBreakdownJSON), googlePlaceID, googleData != nil, googlePlaceDataJSON, result.AIOutputData, result.PromptVersion); err != nil {
+		return errs.NewDB("SaveSandboxResultCtx", "failed to insert sandbox result", err)
+	}
+	return nil
+}
+
+// GetSandboxResultsCtx returns the newest dry-run results, optionally for one venue (venueID 0 = all).
+func (db *DB) GetSandboxResultsCtx(ctx context.Context, venueID int64, limit int) ([]models.ValidationHistory, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT s.id, s.venue_id, s.validation_score, s.validation_status, s.validation_notes,
+	                 s.score_breakdown, s.google_place_id, s.google_place_found, s.ai_output_data,
+	                 s.prompt_version, s.processed_at, COALESCE(v.name, '')
+	          FROM venue_validation_sandbox s
+	          LEFT JOIN venues v ON v.id = s.venue_id
+	          WHERE (? = 0 OR s.venue_id = ?)
+	          ORDER BY s.processed_at DESC, s.id DESC
+	          LIMIT ?`
+	rows, err := db.conn.QueryContext(ctx, query, venueID, venueID, limit)
+	if err != nil {
+		return nil, errs.NewDB("GetSandboxResultsCtx", "failed to query sandbox results", err)
+	}
+	defer rows.Close()
+
+	var out []models.ValidationHistory
+	for rows.Next() {
+		var h models.ValidationHistory
+		var scoreBreakdownJSON string
+		var placeID, aiOutput, pv sql.NullString
+		if err := rows.Scan(&h.ID, &h.VenueID, &h.ValidationScore, &h.ValidationStatus, &h.ValidationNotes,
+			&scoreBreakdownJSON, &placeID, &h.GooglePlaceFound, &aiOutput, &pv, &h.ProcessedAt, &h.VenueName); err != nil {
+			return nil, errs.NewDB("GetSandboxResultsCtx", "failed to scan sandbox row", err)
+		}
+		if err := json.Unmarshal([]byte(scoreBreakdownJSON), &h.ScoreBreakdown); err != nil {
+			return nil, errs.NewDB("GetSandboxResultsCtx", "failed to unmarshal score breakdown", err)
+		}
+		if placeID.Valid {
+			h.GooglePlaceID = &placeID.String
+		}
+		if aiOutput.Valid {
+			h.AIOutputData = &aiOutput.String
+		}
+		if pv.Valid {
+			h.PromptVersion = &pv.String
+		}
+		out = append(out, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("GetSandboxResultsCtx", "failed to iterate sandbox rows", err)
+	}
+	return out, nil
+}
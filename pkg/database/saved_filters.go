@@ -0,0 +1,120 @@
+package database
+
+import (
+	"context"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// ListSavedFiltersCtx returns the admin's saved filters for a list, by name.
+func (db *DB) ListSavedFiltersCtx(ctx context.Context, adminID int, list string) ([]models.SavedFilter, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	rows, err := db.conn.QueryContext(ctx, `SELECT id, admin_id, list, name, query, is_default, created_at
+		FROM saved_filters WHERE admin_id = ? AND list = ? ORDER BY name`, adminID, list)
+	if err != nil {
+		return nil, errs.NewDB("ListSavedFiltersCtx", "failed to query saved filters", err)
+	}
+	defer rows.Close()
+
+	var out []models.SavedFilter
+	for rows.Next() {
+		var f models.SavedFilter
+		if err := rows.Scan(&f.ID, &f.AdminID, &f.List, &f.Name, &f.Query, &f.IsDefault, &f.CreatedAt); err != nil {
+			return nil, errs.NewDB("ListSavedFiltersCtx", "failed to scan saved filter", err)
+		}
+		out = append(out, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("ListSavedFiltersCtx", "failed to iterate saved filters", err)
+	}
+	return out, nil
+}
+
+// SaveFilterCtx stores f and sets f.ID. A filter with the same name on the same list is
+// replaced. When f is the default, the admin's other filters for the list stop being it.
+func (db *DB) SaveFilterCtx(ctx context.Context, f *models.SavedFilter) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return errs.NewDB("SaveFilterCtx", "failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	if f.IsDefault {
+		if _, err := tx.ExecContext(ctx, `UPDATE saved_filters SET is_default = 0
+			WHERE admin_id = ? AND list = ? AND name <> ?`, f.AdminID, f.List, f.Name); err != nil {
+			return errs.NewDB("SaveFilterCtx", "failed to clear default filter", err)
+		}
+	}
+	res, err := tx.ExecContext(ctx, `INSERT INTO saved_filters (admin_id, list, name, query, is_default, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id), query = VALUES(query), is_default = VALUES(is_default)`,
+		f.AdminID, f.List, f.Name, f.Query, f.IsDefault, f.CreatedAt)
+	if err != nil {
+		return errs.NewDB("SaveFilterCtx", "failed to save filter", err)
+	}
+	if f.ID, err = res.LastInsertId(); err != nil {
+		return errs.NewDB("SaveFilterCtx", "failed to get filter id", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return errs.NewDB("SaveFilterCtx", "failed to commit", err)
+	}
+	return nil
+}
+
+// DeleteSavedFilterCtx removes one of the admin's saved filters and reports whether it existed.
+func (db *DB) DeleteSavedFilterCtx(ctx context.Context, adminID int, id int64) (bool, error) {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	res, err := db.conn.ExecContext(ctx, `DELETE FROM saved_filters WHERE id = ? AND admin_id = ?`, id, adminID)
+	if err != nil {
+		return false, errs.NewDB("DeleteSavedFilterCtx", "failed to delete saved filter", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errs.NewDB("DeleteSavedFilterCtx", "failed to get affected rows", err)
+	}
+	return n > 0, nil
+}
+
+// SetDefaultSavedFilterCtx makes filter id the admin's default for a list, or clears the
+// default when id is 0. Reports false when id is not one of the admin's filters for the list.
+func (db *DB) SetDefaultSavedFilterCtx(ctx context.Context, adminID int, list string, id int64) (bool, error) {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return false, errs.NewDB("SetDefaultSavedFilterCtx", "failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE saved_filters SET is_default = 0 WHERE admin_id = ? AND list = ?`,
+		adminID, list); err != nil {
+		return false, errs.NewDB("SetDefaultSavedFilterCtx", "failed to clear default filter", err)
+	}
+	if id != 0 {
+		res, err := tx.ExecContext(ctx, `UPDATE saved_filters SET is_default = 1
+			WHERE id = ? AND admin_id = ? AND list = ?`, id, adminID, list)
+		if err != nil {
+			return false, errs.NewDB("SetDefaultSavedFilterCtx", "failed to set default filter", err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return false, errs.NewDB("SetDefaultSavedFilterCtx", "failed to get affected rows", err)
+		}
+		if n == 0 {
+			return false, nil
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return false, errs.NewDB("SetDefaultSavedFilterCtx", "failed to commit", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// searchVenueMatch and searchNotesMatch must name the columns of the FULLTEXT indexes
+// ft_venues_search and ft_histories_notes exactly, or MySQL refuses the MATCH.
+const (
+	searchVenueMatch = "MATCH(v.name, v.location, v.vdetails, v.additionalinfo, v.admin_note) AGAINST (? IN NATURAL LANGUAGE MODE)"
+	searchNotesMatch = "MATCH(h.validation_notes) AGAINST (? IN NATURAL LANGUAGE MODE)"
+)
+
+// SearchVenuesCtx returns up to limit venues matching query in their name, location,
+// description or admin note, or in the AI notes of any of their validations, most relevant
+// first. A venue's relevance is its own plus that of its best matching validation notes.
+func (db *DB) SearchVenuesCtx(ctx context.Context, query string, limit int) ([]models.SearchHit, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	rows, err := db.conn.QueryContext(ctx, `SELECT v.id, v.name, v.location, COALESCE(v.path, ''), COALESCE(v.active, 0),
+			COALESCE(v.vdetails, ''), COALESCE(v.additionalinfo, ''), COALESCE(v.admin_note, ''), m.relevance,
+			(SELECT h.validation_notes FROM venue_validation_histories h
+				WHERE h.venue_id = v.id AND `+searchNotesMatch+`
+				ORDER BY `+searchNotesMatch+` DESC LIMIT 1)
+		FROM (
+			SELECT venue_id, SUM(relevance) AS relevance FROM (
+				SELECT v.id AS venue_id, `+searchVenueMatch+` AS relevance
+				FROM venues v WHERE `+searchVenueMatch+`
+				UNION ALL
+				SELECT h.venue_id, MAX(`+searchNotesMatch+`)
+				FROM venue_validation_histories h WHERE `+searchNotesMatch+`
+				GROUP BY h.venue_id
+			) matches GROUP BY venue_id
+		) m
+		JOIN venues v ON v.id = m.venue_id
+		ORDER BY m.relevance DESC, v.id DESC
+		LIMIT ?`,
+		query, query, query, query, query, query, limit)
+	if err != nil {
+		return nil, errs.NewDB("SearchVenuesCtx", "failed to search venues", err)
+	}
+	defer rows.Close()
+
+	var out []models.SearchHit
+	for rows.Next() {
+		var h models.SearchHit
+		var notes sql.NullString
+		if err := rows.Scan(&h.VenueID, &h.Name, &h.Location, &h.Path, &h.Active,
+			&h.Description, &h.AdditionalInfo, &h.AdminNote, &h.Relevance, &notes); err != nil {
+			return nil, errs.NewDB("SearchVenuesCtx", "failed to scan search hit", err)
+		}
+		h.AINotes = notes.String
+		out = append(out, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("SearchVenuesCtx", "failed to iterate search hits", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// mysqlDuplicateEntry is MySQL's error number for a unique key violation.
+const mysqlDuplicateEntry = 1062
+
+// ListSubmitterRulesCtx returns all submitter rules, newest first.
+func (db *DB) ListSubmitterRulesCtx(ctx context.Context) ([]models.SubmitterRule, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	rows, err := db.conn.QueryContext(ctx, `SELECT id, kind, value, action, note, admin_id, created_at
+		FROM submitter_rules ORDER BY id DESC`)
+	if err != nil {
+		return nil, errs.NewDB("ListSubmitterRulesCtx", "failed to query submitter rules", err)
+	}
+	defer rows.Close()
+
+	var out []models.SubmitterRule
+	for rows.Next() {
+		var r models.SubmitterRule
+		if err := rows.Scan(&r.ID, &r.Kind, &r.Value, &r.Action, &r.Note, &r.AdminID, &r.CreatedAt); err != nil {
+			return nil, errs.NewDB("ListSubmitterRulesCtx", "failed to scan submitter rule", err)
+		}
+		out = append(out, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("ListSubmitterRulesCtx", "failed to iterate submitter rules", err)
+	}
+	return out, nil
+}
+
+// CreateSubmitterRuleCtx stores a rule. A second rule for the same kind and value is rejected.
+func (db *DB) CreateSubmitterRuleCtx(ctx context.Context, r *models.SubmitterRule) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	res, err := db.conn.ExecContext(ctx, `INSERT INTO submitter_rules (kind, value, action, note, admin_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`, r.Kind, r.Value, r.Action, r.Note, r.AdminID, r.CreatedAt)
+	if err != nil {
+		var me *mysql.MySQLError
+		if errors.As(err, &me) && me.Number == mysqlDuplicateEntry {
+			return errs.NewValidation("CreateSubmitterRuleCtx", "a rule for this "+r.Kind+" already exists", err)
+		}
+		return errs.NewDB("CreateSubmitterRuleCtx", "failed to insert submitter rule", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return errs.NewDB("CreateSubmitterRuleCtx", "failed to get last insert ID", err)
+	}
+	r.ID = id
+	return nil
+}
+
+// DeleteSubmitterRuleCtx removes a rule and reports whether it existed.
+func (db *DB) DeleteSubmitterRuleCtx(ctx context.Context, id int64) (bool, error) {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	res, err := db.conn.ExecContext(ctx, `DELETE FROM submitter_rules WHERE id = ?`, id)
+	if err != nil {
+		return false, errs.NewDB("DeleteSubmitterRuleCtx", "failed to delete submitter rule", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errs.NewDB("DeleteSubmitterRuleCtx", "failed to get affected rows", err)
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,44 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// GetUIPreferencesCtx returns the admin's UI preferences, or nil when they never saved any.
+func (db *DB) GetUIPreferencesCtx(ctx context.Context, adminID int) (*models.UIPreferences, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	var p models.UIPreferences
+	err := db.conn.QueryRowContext(ctx, `SELECT admin_id, theme, rows_per_page, default_sort, compact_tables, updated_at
+		FROM admin_ui_preferences WHERE admin_id = ?`, adminID).
+		Scan(&p.AdminID, &p.Theme, &p.RowsPerPage, &p.DefaultSort, &p.CompactTables, &p.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errs.NewDB("GetUIPreferencesCtx", "failed to query ui preferences", err)
+	}
+	return &p, nil
+}
+
+// SaveUIPreferencesCtx stores p, replacing the admin's previous preferences.
+func (db *DB) SaveUIPreferencesCtx(ctx context.Context, p models.UIPreferences) error {
+	ctx, cancel := db.withWriteTimeout(ctx)
+	defer cancel()
+
+	_, err := db.conn.ExecContext(ctx, `INSERT INTO admin_ui_preferences (admin_id, theme, rows_per_page, default_sort, compact_tables, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE theme = VALUES(theme), rows_per_page = VALUES(rows_per_page),
+			default_sort = VALUES(default_sort), compact_tables = VALUES(compact_tables), updated_at = VALUES(updated_at)`,
+		p.AdminID, p.Theme, p.RowsPerPage, p.DefaultSort, p.CompactTables, p.UpdatedAt)
+	if err != nil {
+		return errs.NewDB("SaveUIPreferencesCtx", "failed to save ui preferences", err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/models"
+)
+
+func TestPathPrefixPattern(t *testing.T) {
+	tests := []struct {
+		prefix string
+		want   string
+	}{
+		{"europe|germany", "europe|germany%"},
+		{"north_america", `north\_america%`},
+		{"100%", `100\%%`},
+		{`a\b`, `a\\b%`},
+	}
+	for _, tt := range tests {
+		if got := pathPrefixPattern(tt.prefix); got != tt.want {
+			t.Errorf("pathPrefixPattern(%q) = %q, want %q", tt.prefix, got, tt.want)
+		}
+	}
+}
+
+func TestVenueFilterPathPrefix(t *testing.T) {
+	where, args := venueFilter("pending", "", "asia|japan")
+	if !strings.Contains(where, "v.path LIKE ?") {
+		t.Fatalf("where = %q, want a path prefix condition", where)
+	}
+	if got := args[len(args)-1]; got != "asia|japan%" {
+		t.Fatalf("last arg = %v, want the path pattern", got)
+	}
+	if where, _ := venueFilter("pending", "", ""); strings.Contains(where, "v.path") {
+		t.Fatalf("empty prefix filtered on path: %q", where)
+	}
+}
+
+func TestVenueFilterPendingIgnoresFailures(t *testing.T) {
+	// A failure that gathered nothing leaves the venue pending
+	where, _ := venueFilter("pending", "", "")
+	if !strings.Contains(where, "NOT EXISTS (SELECT 1 FROM venue_validation_histories h WHERE h.venue_id = v.id AND "+reviewedHistory+")") {
+		t.Fatalf("where = %q, want failure rows ignored", where)
+	}
+	if !strings.Contains(reviewedHistory, "$."+models.ProcessingFailedKey) {
+		t.Fatalf("reviewedHistory = %q", reviewedHistory)
+	}
+}
+
+func TestPendingVenueFilter(t *testing.T) {
+	category := 0
+	after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	where, args := pendingVenueFilter(models.PendingVenueFilter{
+		PathPrefix:     "europe|germany",
+		Category:       &category,
+		MaxScore:       60,
+		SubmittedAfter: after,
+		PromptVersion:  "v3",
+	}, true)
+	for _, cond := range []string{"v.active = 0", "v.path LIKE ?", "v.category = ?", "h.validation_score <= ?", "v.created_at >= ?", "h.prompt_version = ?", "NOT EXISTS (SELECT 1 FROM venue_holds"} {
+		if !strings.Contains(where, cond) {
+			t.Errorf("where = %q, missing %q", where, cond)
+		}
+	}
+	if strings.Contains(where, "h.validation_score >= ?") {
+		t.Errorf("unset min score filtered: %q", where)
+	}
+	want := []interface{}{"europe|germany%", 0, 60, after, "v3"}
+	if len(args) != len(want) {
+		t.Fatalf("args = %v, want %v", args, want)
+	}
+	for i := range want {
+		if args[i] != want[i] {
+			t.Fatalf("args = %v, want %v", args, want)
+		}
+	}
+
+	if where, args := pendingVenueFilter(models.PendingVenueFilter{}, false); where != "WHERE v.active = 0" || len(args) != 0 {
+		t.Fatalf("empty filter: %q %v", where, args)
+	}
+
+	// Dead letters: venues whose latest validation failed
+	if where, args := pendingVenueFilter(models.PendingVenueFilter{Failed: true}, false); where != "WHERE v.active = 0 AND "+failedHistory || len(args) != 0 {
+		t.Fatalf("failed filter: %q %v", where, args)
+	}
+}
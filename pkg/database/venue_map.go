@@ -0,0 +1,59 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"assisted-venue-approval/internal/models"
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+// GetPendingVenueLocationsCtx returns up to limit pending venues that have coordinates,
+// newest first, with their latest AI score and status. A non-empty pathPrefix keeps venues
+// whose region path starts with it.
+func (db *DB) GetPendingVenueLocationsCtx(ctx context.Context, pathPrefix string, limit int) ([]models.VenueMapPoint, error) {
+	ctx, cancel := db.withReadTimeout(ctx)
+	defer cancel()
+
+	where := "WHERE v.active = 0 AND v.lat IS NOT NULL AND v.lng IS NOT NULL"
+	args := []interface{}{}
+	if pathPrefix != "" {
+		where += " AND v.path LIKE ?"
+		args = append(args, pathPrefixPattern(pathPrefix))
+	}
+	args = append(args, limit)
+	rows, err := db.conn.QueryContext(ctx, `SELECT v.id, v.name, COALESCE(v.path, ''), v.location, v.lat, v.lng,
+		h.validation_score, h.validation_status
+		FROM venues v
+		LEFT JOIN venue_validation_histories h ON h.id = (
+			SELECT h2.id FROM venue_validation_histories h2 WHERE h2.venue_id = v.id
+			ORDER BY h2.processed_at DESC, h2.id DESC LIMIT 1)
+		`+where+`
+		ORDER BY v.id DESC LIMIT ?`, args...)
+	if err != nil {
+		return nil, errs.NewDB("GetPendingVenueLocationsCtx", "failed to query pending venue locations", err)
+	}
+	defer rows.Close()
+
+	var out []models.VenueMapPoint
+	for rows.Next() {
+		var p models.VenueMapPoint
+		var score sql.NullInt64
+		var status sql.NullString
+		if err := rows.Scan(&p.VenueID, &p.Name, &p.Path, &p.Location, &p.Lat, &p.Lng, &score, &status); err != nil {
+			return nil, errs.NewDB("GetPendingVenueLocationsCtx", "failed to scan venue location", err)
+		}
+		p.Status = models.MapStatusNew
+		if score.Valid {
+			s := int(score.Int64)
+			p.Score = &s
+			p.AIStatus = status.String
+			p.Status = models.MapStatusManualReview
+		}
+		out = append(out, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.NewDB("GetPendingVenueLocationsCtx", "failed to iterate venue locations", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,130 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Class groups external failures by how a caller should react to them.
+type Class string
+
+const (
+	ClassUnknown   Class = "unknown"
+	ClassRateLimit Class = "rate_limit" // 429 / quota exceeded
+	ClassTimeout   Class = "timeout"    // deadline exceeded, 408, 504
+	ClassServer    Class = "server"     // other 5xx
+	ClassNetwork   Class = "network"    // connection refused/reset, DNS
+	ClassAuth      Class = "auth"       // 401/403, invalid API key
+	ClassClient    Class = "client"     // other 4xx; the request itself is wrong
+	ClassCanceled  Class = "canceled"   // caller gave up
+)
+
+// Transient reports whether the same request may succeed if sent again later.
+func (c Class) Transient() bool {
+	switch c {
+	case ClassRateLimit, ClassTimeout, ClassServer, ClassNetwork:
+		return true
+	}
+	return false
+}
+
+// NewExternalStatus builds an ExternalAPIError classified from an HTTP status code.
+// With status 0 the class is derived from err (timeouts, network errors).
+// retryAfter is the server's Retry-After hint, 0 if none was sent.
+func NewExternalStatus(op, system string, status int, retryAfter time.Duration, err error) error {
+	class := ClassForStatus(status)
+	msg := http.StatusText(status)
+	if status == 0 {
+		class = Classify(err)
+		msg = string(class)
+	}
+	return &ExternalAPIError{Op: op, System: system, Msg: msg, Err: err, Class: class, StatusCode: status, RetryAfter: retryAfter}
+}
+
+// NewExternalClass builds an ExternalAPIError with an explicit class, for SDKs that report
+// failures as status strings rather than HTTP codes.
+func NewExternalClass(op, system string, class Class, err error) error {
+	return &ExternalAPIError{Op: op, System: system, Msg: string(class), Err: err, Class: class}
+}
+
+// ClassForStatus maps an HTTP status code to a Class.
+func ClassForStatus(status int) Class {
+	switch {
+	case status == http.StatusTooManyRequests:
+		return ClassRateLimit
+	case status == http.StatusRequestTimeout || status == http.StatusGatewayTimeout:
+		return ClassTimeout
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return ClassAuth
+	case status >= 500:
+		return ClassServer
+	case status >= 400:
+		return ClassClient
+	}
+	return ClassUnknown
+}
+
+// Classify returns the class of err. Classified ExternalAPIErrors win; otherwise
+// context, net and syscall errors are recognised. Everything else is ClassUnknown.
+func Classify(err error) Class {
+	if err == nil {
+		return ClassUnknown
+	}
+	var ex *ExternalAPIError
+	if errors.As(err, &ex) && ex.Class != "" && ex.Class != ClassUnknown {
+		return ex.Class
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ClassCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return ClassTimeout
+	case errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.EPIPE), errors.Is(err, syscall.ECONNABORTED):
+		return ClassNetwork
+	}
+	var ne net.Error
+	if errors.As(err, &ne) && ne.Timeout() {
+		return ClassTimeout
+	}
+	var dnsErr *net.DNSError
+	var opErr *net.OpError
+	if errors.As(err, &dnsErr) || errors.As(err, &opErr) {
+		return ClassNetwork
+	}
+	return ClassUnknown
+}
+
+// RetryAfterOf returns the Retry-After hint carried by err, or 0.
+func RetryAfterOf(err error) time.Duration {
+	var ex *ExternalAPIError
+	if errors.As(err, &ex) {
+		return ex.RetryAfter
+	}
+	return 0
+}
+
+// ParseRetryAfter reads a Retry-After header value: delay seconds or an HTTP date.
+// Returns 0 for empty, malformed or past values.
+func ParseRetryAfter(v string, now time.Time) time.Duration {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.ParseFloat(v, 64); err == nil {
+		if secs <= 0 {
+			return 0
+		}
+		return time.Duration(secs * float64(time.Second))
+	}
+	if t, err := http.ParseTime(v); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,66 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Class
+	}{
+		{"429", NewExternalStatus("op", "openai", 429, 0, errors.New("slow down")), ClassRateLimit},
+		{"503", NewExternalStatus("op", "openai", 503, 0, errors.New("unavailable")), ClassServer},
+		{"504", NewExternalStatus("op", "openai", 504, 0, errors.New("gateway")), ClassTimeout},
+		{"401", NewExternalStatus("op", "openai", 401, 0, errors.New("bad key")), ClassAuth},
+		{"400", NewExternalStatus("op", "openai", 400, 0, errors.New("bad request")), ClassClient},
+		{"wrapped explicit", fmt.Errorf("enhance: %w", NewExternalClass("op", "google", ClassRateLimit, errors.New("OVER_QUERY_LIMIT"))), ClassRateLimit},
+		{"status 0 from cause", NewExternalStatus("op", "openai", 0, 0, context.DeadlineExceeded), ClassTimeout},
+		{"deadline", fmt.Errorf("call: %w", context.DeadlineExceeded), ClassTimeout},
+		{"canceled", context.Canceled, ClassCanceled},
+		{"conn refused", &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, ClassNetwork},
+		{"dns", &net.DNSError{Err: "no such host", Name: "api.openai.com"}, ClassNetwork},
+		{"plain string", errors.New("timeout"), ClassUnknown},
+		{"nil", nil, ClassUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Classify(tt.err); got != tt.want {
+				t.Fatalf("Classify(%v) = %s, want %s", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"", 0},
+		{"7", 7 * time.Second},
+		{"0.5", 500 * time.Millisecond},
+		{"-1", 0},
+		{"Wed, 01 May 2024 12:00:30 GMT", 30 * time.Second},
+		{"Wed, 01 May 2024 11:59:00 GMT", 0},
+		{"soon", 0},
+	}
+	for _, tt := range tests {
+		if got := ParseRetryAfter(tt.in, now); got != tt.want {
+			t.Errorf("ParseRetryAfter(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	err := fmt.Errorf("score: %w", NewExternalStatus("op", "openai", 429, 3*time.Second, errors.New("x")))
+	if got := RetryAfterOf(err); got != 3*time.Second {
+		t.Fatalf("RetryAfterOf = %v", got)
+	}
+}
@@ -0,0 +1,67 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Code is the machine-readable error code in API error responses.
+type Code string
+
+const (
+	CodeValidationFailed   Code = "validation_failed"
+	CodeNotFound           Code = "not_found"
+	CodeConflict           Code = "conflict"
+	CodeBudgetExceeded     Code = "budget_exceeded"
+	CodeExternalDependency Code = "external_dependency" // Google, OpenAI, translation
+	CodeDeadlineExceeded   Code = "deadline_exceeded"   // the request's time ran out
+	CodeInternal           Code = "internal"
+)
+
+// CodeOf returns the code of the outermost typed error in err's chain, so a NotFound
+// wrapping a DBError reports not_found. A spent budget wins wherever it sits, since a
+// caller cannot fix it by retrying, and so does a passed deadline, which is the caller's
+// timeout rather than a fault of the dependency that was running. Untyped and DB errors
+// are internal.
+func CodeOf(err error) Code {
+	var b *BudgetExceededError
+	if errors.As(err, &b) {
+		return CodeBudgetExceeded
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return CodeDeadlineExceeded
+	}
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		switch e.(type) {
+		case *ValidationError:
+			return CodeValidationFailed
+		case *NotFoundError:
+			return CodeNotFound
+		case *ConflictError:
+			return CodeConflict
+		case *ExternalAPIError:
+			return CodeExternalDependency
+		}
+	}
+	return CodeInternal
+}
+
+// HTTPStatus is the response status for an error with code c.
+func (c Code) HTTPStatus() int {
+	switch c {
+	case CodeValidationFailed:
+		return http.StatusBadRequest
+	case CodeNotFound:
+		return http.StatusNotFound
+	case CodeConflict:
+		return http.StatusConflict
+	case CodeBudgetExceeded:
+		return http.StatusPaymentRequired
+	case CodeExternalDependency:
+		return http.StatusBadGateway
+	case CodeDeadlineExceeded:
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,43 @@
+package errors
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestCodeOf(t *testing.T) {
+	quota := NewBudgetExceeded("op", "openai quota exhausted", NewExternalClass("op", "openai", ClassClient, errors.New("insufficient_quota")))
+	tests := []struct {
+		name string
+		err  error
+		want Code
+	}{
+		{"validation", NewValidation("op", "bad id", nil), CodeValidationFailed},
+		{"not found over db", NewNotFound("op", "venue 1", NewDB("op", "scan", sql.ErrNoRows)), CodeNotFound},
+		{"wrapped conflict", fmt.Errorf("start: %w", NewConflict("op", "already running", nil)), CodeConflict},
+		{"external", NewExternalStatus("op", "google", 503, 0, errors.New("down")), CodeExternalDependency},
+		{"budget under external", NewExternal("op", "openai", "AI scoring failed", quota), CodeBudgetExceeded},
+		{"deadline under external", NewExternal("op", "google", "lookup failed", fmt.Errorf("stage: %w", context.DeadlineExceeded)), CodeDeadlineExceeded},
+		{"db", NewDB("op", "insert", errors.New("deadlock")), CodeInternal},
+		{"plain", errors.New("boom"), CodeInternal},
+		{"nil", nil, CodeInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CodeOf(tt.err); got != tt.want {
+				t.Fatalf("CodeOf(%v) = %s, want %s", tt.err, got, tt.want)
+			}
+		})
+	}
+
+	if Classify(quota).Transient() {
+		t.Fatalf("exhausted quota must not be retried")
+	}
+	if CodeNotFound.HTTPStatus() != http.StatusNotFound || CodeInternal.HTTPStatus() != http.StatusInternalServerError {
+		t.Fatalf("unexpected status mapping")
+	}
+}
@@ -6,6 +6,7 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 // ValidationError indicates invalid input/config/state provided by a caller/user.
@@ -65,6 +66,10 @@ type ExternalAPIError struct {
 	Msg    string
 	Err    error
 	System string // optional system name e.g. "google" / "openai"
+	// Set by NewExternalStatus / NewExternalClass; zero for unclassified errors
+	Class      Class
+	StatusCode int
+	RetryAfter time.Duration
 }
 
 func (e *ExternalAPIError) Error() string {
@@ -85,7 +90,14 @@ func (e *ExternalAPIError) Unwrap() error     { return e.Err }
 func (e *ExternalAPIError) Operation() string { return e.Op }
 func (e *ExternalAPIError) Message() string   { return e.Msg }
 func (e *ExternalAPIError) Context() map[string]any {
-	return map[string]any{"op": e.Op, "msg": e.Msg, "system": e.System}
+	ctx := map[string]any{"op": e.Op, "msg": e.Msg, "system": e.System}
+	if e.Class != "" {
+		ctx["class"] = string(e.Class)
+	}
+	if e.StatusCode != 0 {
+		ctx["status"] = e.StatusCode
+	}
+	return ctx
 }
 
 func NewExternal(op, system, msg string, err error) error {
@@ -116,6 +128,84 @@ func (e *BizError) Context() map[string]any { return map[string]any{"op": e.Op,
 
 func NewBiz(op, msg string, err error) error { return &BizError{Op: op, Msg: msg, Err: err} }
 
+// NotFoundError indicates the requested entity does not exist.
+type NotFoundError struct {
+	Op  string
+	Msg string // what was looked up, e.g. "venue 42"
+	Err error
+}
+
+func (e *NotFoundError) Error() string {
+	if e == nil {
+		return "<nil>"
+	}
+	if e.Err != nil {
+		return fmt.Sprintf("not found: %s: %s: %v", e.Op, e.Msg, e.Err)
+	}
+	return fmt.Sprintf("not found: %s: %s", e.Op, e.Msg)
+}
+
+func (e *NotFoundError) Unwrap() error           { return e.Err }
+func (e *NotFoundError) Operation() string       { return e.Op }
+func (e *NotFoundError) Message() string         { return e.Msg }
+func (e *NotFoundError) Context() map[string]any { return map[string]any{"op": e.Op, "msg": e.Msg} }
+
+func NewNotFound(op, msg string, err error) error { return &NotFoundError{Op: op, Msg: msg, Err: err} }
+
+// ConflictError indicates the request clashes with the current state: a stale version, a
+// job already running, a venue claimed by someone else.
+type ConflictError struct {
+	Op  string
+	Msg string
+	Err error
+}
+
+func (e *ConflictError) Error() string {
+	if e == nil {
+		return "<nil>"
+	}
+	if e.Err != nil {
+		return fmt.Sprintf("conflict: %s: %s: %v", e.Op, e.Msg, e.Err)
+	}
+	return fmt.Sprintf("conflict: %s: %s", e.Op, e.Msg)
+}
+
+func (e *ConflictError) Unwrap() error           { return e.Err }
+func (e *ConflictError) Operation() string       { return e.Op }
+func (e *ConflictError) Message() string         { return e.Msg }
+func (e *ConflictError) Context() map[string]any { return map[string]any{"op": e.Op, "msg": e.Msg} }
+
+func NewConflict(op, msg string, err error) error { return &ConflictError{Op: op, Msg: msg, Err: err} }
+
+// BudgetExceededError indicates a spend cap or paid quota is used up; retrying before it
+// resets will not help.
+type BudgetExceededError struct {
+	Op  string
+	Msg string
+	Err error
+}
+
+func (e *BudgetExceededError) Error() string {
+	if e == nil {
+		return "<nil>"
+	}
+	if e.Err != nil {
+		return fmt.Sprintf("budget exceeded: %s: %s: %v", e.Op, e.Msg, e.Err)
+	}
+	return fmt.Sprintf("budget exceeded: %s: %s", e.Op, e.Msg)
+}
+
+func (e *BudgetExceededError) Unwrap() error     { return e.Err }
+func (e *BudgetExceededError) Operation() string { return e.Op }
+func (e *BudgetExceededError) Message() string   { return e.Msg }
+func (e *BudgetExceededError) Context() map[string]any {
+	return map[string]any{"op": e.Op, "msg": e.Msg}
+}
+
+func NewBudgetExceeded(op, msg string, err error) error {
+	return &BudgetExceededError{Op: op, Msg: msg, Err: err}
+}
+
 // IsKind helpers: allow callers to check error kind without type assertions.
 // Example: if errors.Is(err, errors.ErrValidation) { ... }
 var (
@@ -123,6 +213,9 @@ var (
 	ErrDB         = &DBError{}
 	ErrExternal   = &ExternalAPIError{}
 	ErrBiz        = &BizError{}
+	ErrNotFound   = &NotFoundError{}
+	ErrConflict   = &ConflictError{}
+	ErrBudget     = &BudgetExceededError{}
 )
 
 // Is enables errors.Is(err, ErrValidation) via errors.As semantics.
@@ -144,6 +237,15 @@ func Is(err, target error) bool {
 	case *BizError:
 		var b *BizError
 		return errors.As(err, &b)
+	case *NotFoundError:
+		var n *NotFoundError
+		return errors.As(err, &n)
+	case *ConflictError:
+		var c *ConflictError
+		return errors.As(err, &c)
+	case *BudgetExceededError:
+		var b *BudgetExceededError
+		return errors.As(err, &b)
 	default:
 		return errors.Is(err, target)
 	}
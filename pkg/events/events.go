@@ -39,6 +39,11 @@ const (
 	TypeApproved          = "venue.approved"
 	TypeRejected          = "venue.rejected"
 	TypeManualReview      = "venue.manual_review"
+	TypeApprovalReverted  = "venue.approval.reverted"
+	TypeHeld              = "venue.held"
+	TypeHoldReleased      = "venue.hold.released"
+	TypeCommentAdded      = "venue.comment.added"
+	TypeChangeApplied     = "venue.change.applied"
 )
 
 // VenueValidationStarted is emitted when processing for a venue begins.
@@ -106,6 +111,65 @@ type VenueRequiresManualReview struct {
 func (e VenueRequiresManualReview) Type() string                 { return TypeManualReview }
 func (e VenueRequiresManualReview) MarshalData() ([]byte, error) { return json.Marshal(e) }
 
+// VenueApprovalReverted is emitted when an admin undoes an approval within the grace window.
+// Restored lists the fields put back to their pre-approval values.
+type VenueApprovalReverted struct {
+	Base
+	Reason     string    `json:"reason"`
+	ApprovedAt time.Time `json:"approved_at"`
+	AuditLogID int64     `json:"audit_log_id"`
+	Restored   []string  `json:"restored,omitempty"`
+}
+
+func (e VenueApprovalReverted) Type() string                 { return TypeApprovalReverted }
+func (e VenueApprovalReverted) MarshalData() ([]byte, error) { return json.Marshal(e) }
+
+// VenueHeld is emitted when a reviewer puts a pending venue on hold while waiting for
+// outside information. RemindAt is nil for a hold without a reminder date.
+type VenueHeld struct {
+	Base
+	Reason   string     `json:"reason"`
+	RemindAt *time.Time `json:"remind_at,omitempty"`
+}
+
+func (e VenueHeld) Type() string                 { return TypeHeld }
+func (e VenueHeld) MarshalData() ([]byte, error) { return json.Marshal(e) }
+
+// VenueHoldReleased is emitted when a reviewer ends a venue's hold before or after its
+// reminder date.
+type VenueHoldReleased struct {
+	Base
+}
+
+func (e VenueHoldReleased) Type() string                 { return TypeHoldReleased }
+func (e VenueHoldReleased) MarshalData() ([]byte, error) { return json.Marshal(e) }
+
+// VenueCommentAdded is emitted when a reviewer comments on a venue or replies to a comment.
+// Mentions lists the admin IDs the comment mentions, for notifying them downstream.
+type VenueCommentAdded struct {
+	Base
+	CommentID int64  `json:"comment_id"`
+	ParentID  *int64 `json:"parent_id,omitempty"`
+	Body      string `json:"body"`
+	Mentions  []int  `json:"mentions,omitempty"`
+}
+
+func (e VenueCommentAdded) Type() string                 { return TypeCommentAdded }
+func (e VenueCommentAdded) MarshalData() ([]byte, error) { return json.Marshal(e) }
+
+// VenueChangeApplied is emitted when an editor applies a change request to an approved venue.
+// Fields lists the venue fields that changed, in VenueFieldData JSON names.
+type VenueChangeApplied struct {
+	Base
+	RequestID int64    `json:"request_id"`
+	Reason    string   `json:"reason"`
+	Score     *int     `json:"score,omitempty"`
+	Fields    []string `json:"fields"`
+}
+
+func (e VenueChangeApplied) Type() string                 { return TypeChangeApplied }
+func (e VenueChangeApplied) MarshalData() ([]byte, error) { return json.Marshal(e) }
+
 // EventStore defines persistence and replay.
 // Implementations must guarantee ordering per venue.
 type EventStore interface {
@@ -177,6 +241,12 @@ func Replay(events []StoredEvent) *RebuiltState {
 			st.ManualReview = true
 			st.LastReason = ev.Reason
 			st.LastScore = ev.Score
+		case TypeApprovalReverted:
+			var ev VenueApprovalReverted
+			_ = json.Unmarshal(se.Payload, &ev)
+			st.Status = 0
+			st.LastReason = ev.Reason
+			st.LastApproved = nil
 		}
 	}
 	return st
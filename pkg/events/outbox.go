@@ -0,0 +1,226 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"assisted-venue-approval/internal/constants"
+	"assisted-venue-approval/internal/domain"
+	"assisted-venue-approval/pkg/database"
+	"assisted-venue-approval/pkg/metrics"
+)
+
+const (
+	outboxBatchSize = 100
+	// outboxLock is the named lock (GET_LOCK) held for a dispatch pass, suffixed with the
+	// database name so tenants sharing a MySQL server do not wait on each other.
+	outboxLock = "outbox_dispatch."
+	// Dispatched rows are kept a week for debugging, then deleted.
+	outboxRetention = 7 * 24 * time.Hour
+	// A row whose append fails this many times is parked: failed_at is set and passes skip it.
+	outboxMaxAttempts = 10
+	// A pass stops after this many failed appends in a row. The store is then likely down, and
+	// going on would only use up the attempts of the rows behind.
+	outboxMaxFailureRun = 3
+)
+
+var (
+	mOutboxDispatched = metrics.Default.Counter("events_outbox_dispatched_total", "Outbox events delivered to the event store")
+	mOutboxFailures   = metrics.Default.Counter("events_outbox_failures_total", "Failed outbox deliveries (retried on the next pass until parked)")
+	mOutboxParked     = metrics.Default.Counter("events_outbox_parked_total", "Outbox rows parked after failing every delivery attempt")
+)
+
+// Enqueuer is the slice of domain.UnitOfWork that accepts outbox events.
+type Enqueuer interface {
+	EnqueueEventCtx(ctx context.Context, ev domain.OutboxEvent) error
+}
+
+// Enqueue records e in the outbox of the caller's transaction.
+func Enqueue(ctx context.Context, uow Enqueuer, e Event) error {
+	data, err := e.MarshalData()
+	if err != nil {
+		return fmt.Errorf("marshal event %s: %w", e.Type(), err)
+	}
+	return uow.EnqueueEventCtx(ctx, domain.OutboxEvent{
+		VenueID: e.VenueID(), Type: e.Type(), Ts: e.Timestamp(), Admin: e.Admin(), Payload: data,
+	})
+}
+
+// outboxEvent replays an outbox row through EventStore.Append unchanged.
+type outboxEvent struct{ se StoredEvent }
+
+func (o outboxEvent) Type() string                 { return o.se.Type }
+func (o outboxEvent) VenueID() int64               { return o.se.VenueID }
+func (o outboxEvent) Timestamp() time.Time         { return o.se.Ts }
+func (o outboxEvent) Admin() *string               { return o.se.Admin }
+func (o outboxEvent) MarshalData() ([]byte, error) { return o.se.Payload, nil }
+
+// OutboxDispatcher moves committed outbox rows into the event store in id order. Several
+// instances may run it, but a pass holds a named lock so only one dispatches at a time.
+// Ids are assigned at insert, not at commit, so a transaction holding a lower id can commit
+// after a higher one was dispatched; consumers must not rely on event order across venues
+// or transactions. Delivery is at-least-once: if marking a row fails after a successful
+// append, it is appended again on the next pass. A row that keeps failing is parked after
+// outboxMaxAttempts and no longer holds up the rows behind it.
+type OutboxDispatcher struct {
+	db    *database.DB
+	store EventStore
+}
+
+func NewOutboxDispatcher(db *database.DB, store EventStore) *OutboxDispatcher {
+	return &OutboxDispatcher{db: db, store: store}
+}
+
+// Run dispatches every interval until ctx is cancelled.
+func (d *OutboxDispatcher) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		for {
+			n, err := d.Dispatch(ctx)
+			if err != nil {
+				log.Printf("events: outbox dispatch failed after %d events: %v", n, err)
+			}
+			if err != nil || n < outboxBatchSize {
+				break
+			}
+		}
+		d.purge(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// Dispatch delivers one batch and returns how many events reached the store. It delivers
+// nothing while another instance holds the dispatch lock.
+func (d *OutboxDispatcher) Dispatch(ctx context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 4*constants.EventsSQLTimeoutDefault)
+	defer cancel()
+
+	// The lock belongs to the session, so the pass runs on one connection
+	conn, err := d.db.Conn().Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(CONCAT(?, DATABASE()), 0)", outboxLock).Scan(&locked); err != nil {
+		return 0, fmt.Errorf("take outbox lock: %w", err)
+	}
+	if locked.Int64 != 1 {
+		return 0, nil
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), "DO RELEASE_LOCK(CONCAT(?, DATABASE()))", outboxLock)
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `SELECT id, venue_id, type, ts, admin, payload, attempts FROM event_outbox
+		WHERE dispatched_at IS NULL AND failed_at IS NULL ORDER BY id ASC LIMIT ? FOR UPDATE`, outboxBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("select outbox: %w", err)
+	}
+	var pending []outboxRow
+	for rows.Next() {
+		var row outboxRow
+		var admin sql.NullString
+		if err := rows.Scan(&row.Seq, &row.VenueID, &row.Type, &row.Ts, &admin, &row.Payload, &row.attempts); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scan outbox: %w", err)
+		}
+		if admin.Valid {
+			row.Admin = &admin.String
+		}
+		pending = append(pending, row)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	sent, appendErr, err := d.deliver(ctx, tx, pending)
+	if err != nil {
+		return sent, err
+	}
+	if err := tx.Commit(); err != nil {
+		return sent, err
+	}
+	mOutboxDispatched.Inc(int64(sent))
+	if appendErr != nil {
+		return sent, fmt.Errorf("append outbox event: %w", appendErr)
+	}
+	return sent, nil
+}
+
+// outboxRow is a pending outbox row with its failed delivery count.
+type outboxRow struct {
+	StoredEvent
+	attempts int
+}
+
+// execer is the part of *sql.Tx a dispatch pass records its progress with.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// deliver appends rows to the store in order and records each outcome through ex. A failed
+// row counts an attempt, or is parked on its last one, and the pass moves on to the next row
+// until outboxMaxFailureRun appends fail in a row. It returns how many rows reached the store
+// and the last append error; err is a failure to record an outcome.
+func (d *OutboxDispatcher) deliver(ctx context.Context, ex execer, rows []outboxRow) (sent int, appendErr, err error) {
+	failureRun := 0
+	for _, row := range rows {
+		if aerr := d.store.Append(ctx, outboxEvent{row.StoredEvent}); aerr != nil {
+			appendErr = aerr
+			mOutboxFailures.Inc(1)
+			if row.attempts+1 >= outboxMaxAttempts {
+				if _, err := ex.ExecContext(ctx, `UPDATE event_outbox SET attempts = attempts + 1, last_error = ?, failed_at = NOW() WHERE id = ?`,
+					truncateError(aerr), row.Seq); err != nil {
+					return sent, appendErr, fmt.Errorf("park outbox %d: %w", row.Seq, err)
+				}
+				mOutboxParked.Inc(1)
+				log.Printf("events: outbox row %d (%s, venue %d) parked after %d failed deliveries: %v",
+					row.Seq, row.Type, row.VenueID, row.attempts+1, aerr)
+			} else if _, err := ex.ExecContext(ctx, `UPDATE event_outbox SET attempts = attempts + 1, last_error = ? WHERE id = ?`,
+				truncateError(aerr), row.Seq); err != nil {
+				return sent, appendErr, fmt.Errorf("record outbox failure: %w", err)
+			}
+			if failureRun++; failureRun >= outboxMaxFailureRun {
+				break
+			}
+			continue
+		}
+		failureRun = 0
+		if _, err := ex.ExecContext(ctx, `UPDATE event_outbox SET dispatched_at = NOW(), attempts = attempts + 1, last_error = NULL WHERE id = ?`, row.Seq); err != nil {
+			return sent, appendErr, fmt.Errorf("mark outbox %d: %w", row.Seq, err)
+		}
+		sent++
+	}
+	return sent, appendErr, nil
+}
+
+func (d *OutboxDispatcher) purge(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, constants.EventsSQLTimeoutDefault)
+	defer cancel()
+	if _, err := d.db.Conn().ExecContext(ctx, `DELETE FROM event_outbox WHERE dispatched_at < ? LIMIT 1000`,
+		time.Now().Add(-outboxRetention)); err != nil {
+		log.Printf("events: outbox purge failed: %v", err)
+	}
+}
+
+func truncateError(err error) string {
+	s := err.Error()
+	if len(s) > 500 {
+		return s[:500]
+	}
+	return s
+}
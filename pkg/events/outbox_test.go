@@ -0,0 +1,96 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"assisted-venue-approval/internal/domain"
+)
+
+type captureEnqueuer struct{ got []domain.OutboxEvent }
+
+func (c *captureEnqueuer) EnqueueEventCtx(_ context.Context, ev domain.OutboxEvent) error {
+	c.got = append(c.got, ev)
+	return nil
+}
+
+func TestEnqueue_RoundTrip(t *testing.T) {
+	admin := "admin_3"
+	ts := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	in := VenueApproved{Base: Base{Ts: ts, VID: 11, Adm: &admin}, Reason: "ok", Score: 88}
+
+	var c captureEnqueuer
+	if err := Enqueue(context.Background(), &c, in); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.got) != 1 {
+		t.Fatalf("enqueued %d events", len(c.got))
+	}
+	ev := c.got[0]
+	if ev.Type != TypeApproved || ev.VenueID != 11 || !ev.Ts.Equal(ts) || ev.Admin == nil || *ev.Admin != admin {
+		t.Fatalf("outbox row = %+v", ev)
+	}
+
+	// The dispatcher hands the row to the store unchanged.
+	out := outboxEvent{StoredEvent{Seq: 1, VenueID: ev.VenueID, Type: ev.Type, Ts: ev.Ts, Admin: ev.Admin, Payload: ev.Payload}}
+	data, _ := out.MarshalData()
+	var back VenueApproved
+	if err := json.Unmarshal(data, &back); err != nil || back.Score != 88 || back.Reason != "ok" {
+		t.Fatalf("payload = %s (%v)", data, err)
+	}
+}
+
+// failingStore fails appends of the venues in fail and records the others.
+type failingStore struct {
+	EventStore
+	fail     map[int64]bool
+	appended []int64
+}
+
+func (f *failingStore) Append(_ context.Context, e Event) error {
+	if f.fail[e.VenueID()] {
+		return errors.New("rejected by store")
+	}
+	f.appended = append(f.appended, e.VenueID())
+	return nil
+}
+
+// recordExec records the statements of a dispatch pass.
+type recordExec struct{ stmts []string }
+
+func (r *recordExec) ExecContext(_ context.Context, query string, args ...any) (sql.Result, error) {
+	r.stmts = append(r.stmts, fmt.Sprintf("%s %v", query, args[len(args)-1]))
+	return nil, nil
+}
+
+func TestDeliver_ParksUndeliverableRowAndKeepsGoing(t *testing.T) {
+	row := func(id int64, attempts int) outboxRow {
+		return outboxRow{StoredEvent: StoredEvent{Seq: id, VenueID: id, Type: TypeApproved}, attempts: attempts}
+	}
+	store := &failingStore{fail: map[int64]bool{1: true, 2: true}}
+	d := &OutboxDispatcher{store: store}
+	var ex recordExec
+
+	sent, appendErr, err := d.deliver(context.Background(), &ex, []outboxRow{row(1, outboxMaxAttempts-1), row(2, 0), row(3, 0)})
+	if err != nil || appendErr == nil || sent != 1 || len(store.appended) != 1 || store.appended[0] != 3 {
+		t.Fatalf("sent %d, appended %v, appendErr %v, err %v", sent, store.appended, appendErr, err)
+	}
+	if len(ex.stmts) != 3 || !strings.Contains(ex.stmts[0], "failed_at = NOW()") || strings.Contains(ex.stmts[1], "failed_at") ||
+		!strings.Contains(ex.stmts[2], "dispatched_at = NOW()") {
+		t.Fatalf("statements = %q", ex.stmts)
+	}
+
+	// A run of failures stops the pass: the store itself is likely down
+	store.fail = map[int64]bool{4: true, 5: true, 6: true}
+	ex.stmts = nil
+	rows := []outboxRow{row(4, 0), row(5, 0), row(6, 0), row(7, 0)}
+	if sent, _, _ := d.deliver(context.Background(), &ex, rows); sent != 0 || len(ex.stmts) != outboxMaxFailureRun {
+		t.Fatalf("sent %d with statements %q", sent, ex.stmts)
+	}
+}
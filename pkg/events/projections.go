@@ -0,0 +1,487 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"assisted-venue-approval/internal/constants"
+	"assisted-venue-approval/pkg/database"
+)
+
+// Projection names; also the keys in event_projection_offsets.
+const (
+	ProjectionVenueTimeline  = "venue_timeline"
+	ProjectionAdminActivity  = "admin_activity"
+	ProjectionDailyDecisions = "daily_decisions"
+)
+
+// Decision outcomes counted by the activity projections.
+const (
+	OutcomeApproved     = "approved"
+	OutcomeRejected     = "rejected"
+	OutcomeManualReview = "manual_review"
+)
+
+const projectionBatchSize = 500
+
+// TimelineEntry is one row of the venue_timeline projection: an event reduced to a readable line.
+type TimelineEntry struct {
+	Seq     int64     `json:"seq"`
+	VenueID int64     `json:"venue_id"`
+	Type    string    `json:"type"`
+	Ts      time.Time `json:"ts"`
+	Admin   *string   `json:"admin,omitempty"`
+	Summary string    `json:"summary"`
+	Score   *int      `json:"score,omitempty"`
+}
+
+// projection materialises events into one table (or delivers them elsewhere). apply runs
+// inside the transaction that also advances the projection's offset, so table projections
+// see each event exactly once. clear drops whatever was derived from events after seq, or
+// on/after cutoff for daily counters; nil means nothing is stored locally.
+type projection struct {
+	name    string
+	batch   int
+	timeout time.Duration
+	daily   bool
+	apply   func(ctx context.Context, tx *sql.Tx, se StoredEvent) error
+	clear   func(ctx context.Context, tx *sql.Tx, afterSeq int64, cutoff time.Time) error
+}
+
+// Projector keeps the read-side tables in step with venue_events. Projections are pulled
+// from the event table rather than fed on Append, so events written by any instance (or
+// before a projection existed) are picked up.
+type Projector struct {
+	db          *database.DB
+	projections []projection
+
+	mu      sync.Mutex
+	replays map[string]*ReplayJob
+	nextID  int
+}
+
+func NewProjector(db *database.DB) *Projector {
+	return &Projector{db: db, replays: map[string]*ReplayJob{}, projections: []projection{
+		{name: ProjectionVenueTimeline, apply: applyVenueTimeline, clear: clearTable("venue_timeline")},
+		{name: ProjectionAdminActivity, daily: true, apply: applyAdminActivity, clear: clearTable("admin_activity_daily")},
+		{name: ProjectionDailyDecisions, daily: true, apply: applyDailyDecisions, clear: clearTable("decision_counts_daily")},
+	}}
+}
+
+// Projections lists the registered projection names in sync order.
+func (p *Projector) Projections() []string {
+	names := make([]string, 0, len(p.projections))
+	for _, pr := range p.projections {
+		names = append(names, pr.name)
+	}
+	return names
+}
+
+func (p *Projector) find(name string) (projection, bool) {
+	for _, pr := range p.projections {
+		if pr.name == name {
+			return pr, true
+		}
+	}
+	return projection{}, false
+}
+
+// Run syncs every interval until ctx is cancelled.
+func (p *Projector) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		if n, err := p.Sync(ctx); err != nil {
+			log.Printf("events: projection sync failed after %d events: %v", n, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// Sync applies all events not yet seen by each projection and returns how many were applied.
+// A failing projection does not hold back the others.
+func (p *Projector) Sync(ctx context.Context) (int, error) {
+	total := 0
+	var errs []error
+	for _, pr := range p.projections {
+		n, err := p.catchUp(ctx, pr, nil)
+		total += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("projection %s: %w", pr.name, err))
+		}
+	}
+	return total, errors.Join(errs...)
+}
+
+// catchUp syncs one projection until it reaches the end of the event store.
+func (p *Projector) catchUp(ctx context.Context, pr projection, progress func(int)) (int, error) {
+	total := 0
+	for {
+		n, err := p.syncBatch(ctx, pr)
+		total += n
+		if progress != nil && n > 0 {
+			progress(n)
+		}
+		if err != nil || n < pr.batchSize() {
+			return total, err
+		}
+	}
+}
+
+func (pr projection) batchSize() int {
+	if pr.batch > 0 {
+		return pr.batch
+	}
+	return projectionBatchSize
+}
+
+func (p *Projector) syncBatch(ctx context.Context, pr projection) (int, error) {
+	timeout := pr.timeout
+	if timeout <= 0 {
+		timeout = constants.EventsSQLTimeoutDefault
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	tx, err := p.db.Conn().BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	offset, err := lockOffset(ctx, tx, pr.name)
+	if err != nil {
+		return 0, err
+	}
+	evts, err := listAfter(ctx, tx, offset, pr.batchSize())
+	if err != nil || len(evts) == 0 {
+		return 0, err
+	}
+	for _, se := range evts {
+		if err := pr.apply(ctx, tx, se); err != nil {
+			return 0, fmt.Errorf("apply event %d: %w", se.Seq, err)
+		}
+	}
+	if err := setOffset(ctx, tx, pr.name, evts[len(evts)-1].Seq); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(evts), nil
+}
+
+// lockOffset reads (creating if needed) a projection's offset row and holds it for the
+// transaction, so a sync and a replay of the same projection never interleave.
+func lockOffset(ctx context.Context, tx *sql.Tx, name string) (int64, error) {
+	var offset int64
+	err := tx.QueryRowContext(ctx, `SELECT last_seq FROM event_projection_offsets WHERE name = ? FOR UPDATE`, name).Scan(&offset)
+	if err == sql.ErrNoRows {
+		if _, err = tx.ExecContext(ctx, `INSERT INTO event_projection_offsets (name, last_seq) VALUES (?, 0)`, name); err != nil {
+			return 0, fmt.Errorf("init offset: %w", err)
+		}
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("read offset: %w", err)
+	}
+	return offset, nil
+}
+
+func setOffset(ctx context.Context, tx *sql.Tx, name string, seq int64) error {
+	if _, err := tx.ExecContext(ctx, `UPDATE event_projection_offsets SET last_seq = ?, updated_at = NOW() WHERE name = ?`, seq, name); err != nil {
+		return fmt.Errorf("advance offset: %w", err)
+	}
+	return nil
+}
+
+// clearTable returns a clear func for the stock projection tables: the timeline is keyed by
+// seq, the daily counters by day.
+func clearTable(table string) func(ctx context.Context, tx *sql.Tx, afterSeq int64, cutoff time.Time) error {
+	return func(ctx context.Context, tx *sql.Tx, afterSeq int64, cutoff time.Time) error {
+		var err error
+		switch {
+		case afterSeq == 0:
+			_, err = tx.ExecContext(ctx, `DELETE FROM `+table)
+		case table == "venue_timeline":
+			_, err = tx.ExecContext(ctx, `DELETE FROM venue_timeline WHERE seq > ?`, afterSeq)
+		default:
+			_, err = tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE day >= ?`, cutoff.Format(time.DateOnly))
+		}
+		if err != nil {
+			return fmt.Errorf("clear %s: %w", table, err)
+		}
+		return nil
+	}
+}
+
+// Timeline returns the projected events for a venue, oldest first.
+func (p *Projector) Timeline(ctx context.Context, venueID int64) ([]TimelineEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.EventsSQLTimeoutDefault)
+	defer cancel()
+	rows, err := p.db.Conn().QueryContext(ctx, `SELECT seq, venue_id, type, ts, admin, summary, score
+		FROM venue_timeline WHERE venue_id = ? ORDER BY seq ASC`, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("select timeline: %w", err)
+	}
+	defer rows.Close()
+	var out []TimelineEntry
+	for rows.Next() {
+		var te TimelineEntry
+		var admin sql.NullString
+		var score sql.NullInt64
+		if err := rows.Scan(&te.Seq, &te.VenueID, &te.Type, &te.Ts, &admin, &te.Summary, &score); err != nil {
+			return nil, fmt.Errorf("scan timeline: %w", err)
+		}
+		if admin.Valid {
+			te.Admin = &admin.String
+		}
+		if score.Valid {
+			s := int(score.Int64)
+			te.Score = &s
+		}
+		out = append(out, te)
+	}
+	return out, rows.Err()
+}
+
+// Events returns up to limit events after seq, oldest first, summarised as on the venue
+// timeline. A negative seq returns the latest limit events. It reads the event store rather
+// than a projection, so events show up before the projections catch up.
+func (p *Projector) Events(ctx context.Context, seq int64, limit int) ([]TimelineEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.EventsSQLTimeoutDefault)
+	defer cancel()
+	var evts []StoredEvent
+	var err error
+	if seq < 0 {
+		evts, err = scanEvents(p.db.Conn().QueryContext(ctx, `SELECT id, venue_id, type, ts, admin, payload FROM
+			(SELECT id, venue_id, type, ts, admin, payload FROM venue_events ORDER BY id DESC LIMIT ?) latest ORDER BY id ASC`, limit))
+	} else {
+		evts, err = scanEvents(p.db.Conn().QueryContext(ctx, `SELECT id, venue_id, type, ts, admin, payload FROM venue_events WHERE id > ? ORDER BY id ASC LIMIT ?`, seq, limit))
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := make([]TimelineEntry, len(evts))
+	for i, se := range evts {
+		out[i] = Summarize(se)
+	}
+	return out, nil
+}
+
+func listAfter(ctx context.Context, tx *sql.Tx, seq int64, limit int) ([]StoredEvent, error) {
+	return scanEvents(tx.QueryContext(ctx, `SELECT id, venue_id, type, ts, admin, payload FROM venue_events WHERE id > ? ORDER BY id ASC LIMIT ?`, seq, limit))
+}
+
+// scanEvents reads venue_events rows selected as id, venue_id, type, ts, admin, payload.
+func scanEvents(rows *sql.Rows, err error) ([]StoredEvent, error) {
+	if err != nil {
+		return nil, fmt.Errorf("select events: %w", err)
+	}
+	defer rows.Close()
+	var out []StoredEvent
+	for rows.Next() {
+		var se StoredEvent
+		var admin sql.NullString
+		if err := rows.Scan(&se.Seq, &se.VenueID, &se.Type, &se.Ts, &admin, &se.Payload); err != nil {
+			return nil, fmt.Errorf("scan event: %w", err)
+		}
+		if admin.Valid {
+			se.Admin = &admin.String
+		}
+		out = append(out, se)
+	}
+	return out, rows.Err()
+}
+
+func applyVenueTimeline(ctx context.Context, tx *sql.Tx, se StoredEvent) error {
+	te := Summarize(se)
+	_, err := tx.ExecContext(ctx, `INSERT IGNORE INTO venue_timeline (seq, venue_id, type, ts, admin, summary, score) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		te.Seq, te.VenueID, te.Type, te.Ts, te.Admin, te.Summary, te.Score)
+	return err
+}
+
+func applyAdminActivity(ctx context.Context, tx *sql.Tx, se StoredEvent) error {
+	outcome, ok := Decision(se)
+	if !ok || se.Admin == nil || *se.Admin == "" {
+		return nil
+	}
+	a, r, m := outcomeCounts(outcome)
+	_, err := tx.ExecContext(ctx, `INSERT INTO admin_activity_daily (admin, day, approved, rejected, manual_review, last_action_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE approved = approved + VALUES(approved), rejected = rejected + VALUES(rejected),
+			manual_review = manual_review + VALUES(manual_review), last_action_at = GREATEST(last_action_at, VALUES(last_action_at))`,
+		*se.Admin, se.Ts.UTC().Format(time.DateOnly), a, r, m, se.Ts)
+	return err
+}
+
+func applyDailyDecisions(ctx context.Context, tx *sql.Tx, se StoredEvent) error {
+	outcome, ok := Decision(se)
+	if !ok {
+		return nil
+	}
+	source := "auto"
+	if se.Admin != nil && *se.Admin != "" {
+		source = "admin"
+	}
+	a, r, m := outcomeCounts(outcome)
+	_, err := tx.ExecContext(ctx, `INSERT INTO decision_counts_daily (day, source, approved, rejected, manual_review)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE approved = approved + VALUES(approved), rejected = rejected + VALUES(rejected),
+			manual_review = manual_review + VALUES(manual_review)`,
+		se.Ts.UTC().Format(time.DateOnly), source, a, r, m)
+	return err
+}
+
+func outcomeCounts(outcome string) (approved, rejected, manual int) {
+	switch outcome {
+	case OutcomeApproved:
+		return 1, 0, 0
+	case OutcomeRejected:
+		return 0, 1, 0
+	default:
+		return 0, 0, 1
+	}
+}
+
+// Decision reports the outcome an event records, if it is a decision at all.
+// Completed validations carry the engine's status; started events are not decisions.
+func Decision(se StoredEvent) (string, bool) {
+	switch se.Type {
+	case TypeApproved:
+		return OutcomeApproved, true
+	case TypeRejected:
+		return OutcomeRejected, true
+	case TypeManualReview:
+		return OutcomeManualReview, true
+	case TypeValidationDone:
+		var ev VenueValidationCompleted
+		if err := json.Unmarshal(se.Payload, &ev); err != nil {
+			return "", false
+		}
+		return statusOutcome(ev.Status), true
+	}
+	return "", false
+}
+
+// statusOutcome maps the venue active status stored in completed events (1/-1/0).
+func statusOutcome(status int) string {
+	switch status {
+	case 1:
+		return OutcomeApproved
+	case -1:
+		return OutcomeRejected
+	default:
+		return OutcomeManualReview
+	}
+}
+
+// Summarize turns a stored event into a timeline line.
+func Summarize(se StoredEvent) TimelineEntry {
+	te := TimelineEntry{Seq: se.Seq, VenueID: se.VenueID, Type: se.Type, Ts: se.Ts, Admin: se.Admin}
+	switch se.Type {
+	case TypeValidationStarted:
+		var ev VenueValidationStarted
+		_ = json.Unmarshal(se.Payload, &ev)
+		te.Summary = "Validation started"
+		if ev.Triggered != "" {
+			te.Summary += " (" + ev.Triggered + ")"
+		}
+	case TypeValidationDone:
+		var ev VenueValidationCompleted
+		_ = json.Unmarshal(se.Payload, &ev)
+		te.Score = &ev.Score
+		te.Summary = fmt.Sprintf("Validation completed: %s, score %d", strings.ReplaceAll(statusOutcome(ev.Status), "_", " "), ev.Score)
+		if !ev.GoogleFound {
+			te.Summary += ", no Google match"
+		}
+	case TypeApproved:
+		var ev VenueApproved
+		_ = json.Unmarshal(se.Payload, &ev)
+		te.Score = nonZero(ev.Score)
+		te.Summary = withReason("Approved", ev.Reason)
+	case TypeRejected:
+		var ev VenueRejected
+		_ = json.Unmarshal(se.Payload, &ev)
+		te.Score = nonZero(ev.Score)
+		te.Summary = withReason("Rejected", ev.Reason)
+	case TypeManualReview:
+		var ev VenueRequiresManualReview
+		_ = json.Unmarshal(se.Payload, &ev)
+		te.Score = nonZero(ev.Score)
+		te.Summary = withReason("Sent to manual review", ev.Reason)
+	case TypeApprovalReverted:
+		var ev VenueApprovalReverted
+		_ = json.Unmarshal(se.Payload, &ev)
+		te.Summary = "Approval reverted"
+		if len(ev.Restored) > 0 {
+			te.Summary += fmt.Sprintf(" (restored %s)", strings.Join(ev.Restored, ", "))
+		}
+		te.Summary = withReason(te.Summary, ev.Reason)
+	case TypeHeld:
+		var ev VenueHeld
+		_ = json.Unmarshal(se.Payload, &ev)
+		te.Summary = "Put on hold"
+		if ev.RemindAt != nil {
+			te.Summary += " until " + ev.RemindAt.Format("2006-01-02")
+		}
+		te.Summary = withReason(te.Summary, ev.Reason)
+	case TypeHoldReleased:
+		te.Summary = "Hold released"
+	case TypeCommentAdded:
+		var ev VenueCommentAdded
+		_ = json.Unmarshal(se.Payload, &ev)
+		te.Summary = "Commented"
+		if ev.ParentID != nil {
+			te.Summary = "Replied to a comment"
+		}
+		te.Summary = withReason(te.Summary, truncateRunes(ev.Body, commentSummaryLen))
+	case TypeChangeApplied:
+		var ev VenueChangeApplied
+		_ = json.Unmarshal(se.Payload, &ev)
+		te.Score = ev.Score
+		te.Summary = fmt.Sprintf("Change request #%d applied", ev.RequestID)
+		if len(ev.Fields) > 0 {
+			te.Summary += fmt.Sprintf(" (%s)", strings.Join(ev.Fields, ", "))
+		}
+	default:
+		te.Summary = se.Type
+	}
+	return te
+}
+
+// commentSummaryLen bounds the comment text shown in a timeline line.
+const commentSummaryLen = 120
+
+func truncateRunes(s string, n int) string {
+	r := []rune(strings.TrimSpace(s))
+	if len(r) <= n {
+		return string(r)
+	}
+	return string(r[:n]) + "…"
+}
+
+func withReason(s, reason string) string {
+	if reason = strings.TrimSpace(reason); reason != "" {
+		return s + ": " + reason
+	}
+	return s
+}
+
+func nonZero(n int) *int {
+	if n == 0 {
+		return nil
+	}
+	return &n
+}
@@ -0,0 +1,79 @@
+package events
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func stored(t *testing.T, e Event) StoredEvent {
+	t.Helper()
+	b, err := e.MarshalData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return StoredEvent{Seq: 1, VenueID: e.VenueID(), Type: e.Type(), Ts: e.Timestamp(), Admin: e.Admin(), Payload: b}
+}
+
+func TestDecision(t *testing.T) {
+	admin := "alice"
+	now := time.Now()
+	tests := []struct {
+		name string
+		se   StoredEvent
+		want string
+		ok   bool
+	}{
+		{"started is not a decision", stored(t, VenueValidationStarted{Base: Base{Ts: now, VID: 1}, Triggered: "system"}), "", false},
+		{"completed approved", stored(t, VenueValidationCompleted{Base: Base{Ts: now, VID: 1}, Status: 1}), OutcomeApproved, true},
+		{"completed rejected", stored(t, VenueValidationCompleted{Base: Base{Ts: now, VID: 1}, Status: -1}), OutcomeRejected, true},
+		{"completed pending", stored(t, VenueValidationCompleted{Base: Base{Ts: now, VID: 1}, Status: 0}), OutcomeManualReview, true},
+		{"admin approval", stored(t, VenueApproved{Base: Base{Ts: now, VID: 1, Adm: &admin}}), OutcomeApproved, true},
+		{"admin rejection", stored(t, VenueRejected{Base: Base{Ts: now, VID: 1, Adm: &admin}}), OutcomeRejected, true},
+		{"manual review", stored(t, VenueRequiresManualReview{Base: Base{Ts: now, VID: 1}}), OutcomeManualReview, true},
+		{"reverted approval is not a decision", stored(t, VenueApprovalReverted{Base: Base{Ts: now, VID: 1, Adm: &admin}}), "", false},
+		{"bad payload", StoredEvent{Type: TypeValidationDone, Payload: []byte("{")}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Decision(tt.se)
+			if got != tt.want || ok != tt.ok {
+				t.Fatalf("Decision = (%q, %v), want (%q, %v)", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	admin := "bob"
+	now := time.Now()
+	tests := []struct {
+		name      string
+		se        StoredEvent
+		summary   string
+		wantScore bool
+	}{
+		{"started", stored(t, VenueValidationStarted{Base: Base{Ts: now, VID: 7}, Triggered: "api"}), "Validation started (api)", false},
+		{"completed", stored(t, VenueValidationCompleted{Base: Base{Ts: now, VID: 7}, Score: 82, Status: 0}), "Validation completed: manual review, score 82, no Google match", true},
+		{"rejected with reason", stored(t, VenueRejected{Base: Base{Ts: now, VID: 7, Adm: &admin}, Reason: " closed "}), "Rejected: closed", false},
+		{"approval reverted", stored(t, VenueApprovalReverted{Base: Base{Ts: now, VID: 7, Adm: &admin}, Reason: "wrong venue", Restored: []string{"name", "phone"}}), "Approval reverted (restored name, phone): wrong venue", false},
+		{"held", stored(t, VenueHeld{Base: Base{Ts: now, VID: 7, Adm: &admin}, Reason: "called owner", RemindAt: &now}), "Put on hold until " + now.Format("2006-01-02") + ": called owner", false},
+		{"hold released", stored(t, VenueHoldReleased{Base: Base{Ts: now, VID: 7, Adm: &admin}}), "Hold released", false},
+		{"comment", stored(t, VenueCommentAdded{Base: Base{Ts: now, VID: 7, Adm: &admin}, CommentID: 3, Body: "@4 owner confirmed hours"}), "Commented: @4 owner confirmed hours", false},
+		{"reply", stored(t, VenueCommentAdded{Base: Base{Ts: now, VID: 7, Adm: &admin}, CommentID: 4, ParentID: new(int64), Body: strings.Repeat("x", 130)}), "Replied to a comment: " + strings.Repeat("x", 120) + "…", false},
+		{"change applied", stored(t, VenueChangeApplied{Base: Base{Ts: now, VID: 7, Adm: &admin}, RequestID: 9, Score: new(int), Fields: []string{"phone", "openhours"}}), "Change request #9 applied (phone, openhours)", true},
+		{"unknown type", StoredEvent{Type: "venue.other", Payload: json.RawMessage(`{}`)}, "venue.other", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			te := Summarize(tt.se)
+			if te.Summary != tt.summary {
+				t.Fatalf("summary = %q, want %q", te.Summary, tt.summary)
+			}
+			if (te.Score != nil) != tt.wantScore {
+				t.Fatalf("score = %v, want set=%v", te.Score, tt.wantScore)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	errs "assisted-venue-approval/pkg/errors"
+)
+
+var (
+	ErrUnknownProjection = errs.NewValidation("events.StartReplay", "unknown projection", nil)
+	ErrReplayRunning     = errs.NewConflict("events.StartReplay", "replay already running for projection", nil)
+)
+
+// replayTimeout bounds one replay job; a full rebuild reads the whole event store.
+const replayTimeout = 30 * time.Minute
+
+// ReplayJob reports the progress of an asynchronous replay.
+type ReplayJob struct {
+	ID          string     `json:"id"`
+	Projections []string   `json:"projections"`
+	From        *time.Time `json:"from,omitempty"`
+	Current     string     `json:"current,omitempty"`
+	Total       int        `json:"total"`
+	Applied     int        `json:"applied"`
+	StartedAt   time.Time  `json:"started_at"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+	Done        bool       `json:"done"`
+	Error       string     `json:"error,omitempty"`
+}
+
+// Replay rewinds one projection to the first event at or after from (the whole store when
+// from is zero), drops what it had derived from those events and re-applies them.
+// Daily counters rewind to the start of from's UTC day so no day is half counted.
+// Replaying the same range twice yields the same tables; delivery consumers (webhooks)
+// re-send the events with their original ids, so receivers can dedupe.
+// progress, if set, is called with the number of events to replay and applied so far.
+func (p *Projector) Replay(ctx context.Context, name string, from time.Time, progress func(total, applied int)) (int, error) {
+	pr, ok := p.find(name)
+	if !ok {
+		return 0, fmt.Errorf("%w %q", ErrUnknownProjection, name)
+	}
+	cutoff := from.UTC()
+	if pr.daily && !from.IsZero() {
+		cutoff = cutoff.Truncate(24 * time.Hour)
+	}
+
+	total, err := p.rewind(ctx, pr, from.IsZero(), cutoff)
+	if err != nil {
+		return 0, err
+	}
+	if progress != nil {
+		progress(total, 0)
+	}
+	applied := 0
+	return p.catchUp(ctx, pr, func(n int) {
+		applied += n
+		if progress != nil {
+			progress(total, applied)
+		}
+	})
+}
+
+// rewind moves the offset back and clears derived rows in one transaction, returning how
+// many events are now pending. The offset never moves forward: a lagging projection keeps
+// the events it has not seen yet.
+func (p *Projector) rewind(ctx context.Context, pr projection, full bool, cutoff time.Time) (int, error) {
+	tx, err := p.db.Conn().BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	offset, err := lockOffset(ctx, tx, pr.name)
+	if err != nil {
+		return 0, err
+	}
+	var after int64
+	if !full {
+		var first int64
+		if err := tx.QueryRowContext(ctx, `SELECT COALESCE(MIN(id), 0) FROM venue_events WHERE ts >= ?`, cutoff).Scan(&first); err != nil {
+			return 0, fmt.Errorf("find replay start: %w", err)
+		}
+		if first > 0 {
+			after = first - 1
+		} else {
+			after = offset // nothing at or after cutoff
+		}
+		if after > offset {
+			after = offset
+		}
+	}
+	if pr.clear != nil {
+		if err := pr.clear(ctx, tx, after, cutoff); err != nil {
+			return 0, err
+		}
+	}
+	var total int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM venue_events WHERE id > ?`, after).Scan(&total); err != nil {
+		return 0, fmt.Errorf("count replay events: %w", err)
+	}
+	if err := setOffset(ctx, tx, pr.name, after); err != nil {
+		return 0, err
+	}
+	return total, tx.Commit()
+}
+
+// StartReplay replays the named projections one after another in the background and
+// returns the job to poll with ReplayStatus. At most one job may touch a projection.
+func (p *Projector) StartReplay(names []string, from time.Time) (ReplayJob, error) {
+	if len(names) == 0 {
+		names = p.Projections()
+	}
+	for _, n := range names {
+		if _, ok := p.find(n); !ok {
+			return ReplayJob{}, fmt.Errorf("%w %q", ErrUnknownProjection, n)
+		}
+	}
+
+	p.mu.Lock()
+	for _, j := range p.replays {
+		if j.Done {
+			continue
+		}
+		for _, a := range j.Projections {
+			for _, b := range names {
+				if a == b {
+					p.mu.Unlock()
+					return ReplayJob{}, fmt.Errorf("%w %q (job %s)", ErrReplayRunning, a, j.ID)
+				}
+			}
+		}
+	}
+	p.pruneReplaysLocked()
+	p.nextID++
+	job := &ReplayJob{ID: strconv.Itoa(p.nextID), Projections: names, StartedAt: time.Now()}
+	if !from.IsZero() {
+		f := from.UTC()
+		job.From = &f
+	}
+	p.replays[job.ID] = job
+	snap := *job
+	p.mu.Unlock()
+
+	go p.runReplay(job, from)
+	return snap, nil
+}
+
+func (p *Projector) runReplay(job *ReplayJob, from time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), replayTimeout)
+	defer cancel()
+
+	var err error
+	base := 0
+	for _, name := range job.Projections {
+		p.mu.Lock()
+		job.Current = name
+		p.mu.Unlock()
+		_, err = p.Replay(ctx, name, from, func(total, applied int) {
+			p.mu.Lock()
+			job.Total, job.Applied = base+total, base+applied
+			p.mu.Unlock()
+		})
+		if err != nil {
+			err = fmt.Errorf("%s: %w", name, err)
+			break
+		}
+		p.mu.Lock()
+		base = job.Total
+		p.mu.Unlock()
+	}
+
+	p.mu.Lock()
+	now := time.Now()
+	job.Done, job.FinishedAt, job.Current = true, &now, ""
+	if err != nil {
+		job.Error = err.Error()
+	}
+	p.mu.Unlock()
+	if err != nil {
+		log.Printf("events: replay %s failed: %v", job.ID, err)
+	} else {
+		log.Printf("events: replay %s of %v done, %d events", job.ID, job.Projections, job.Applied)
+	}
+}
+
+// ReplayStatus returns a snapshot of a replay job.
+func (p *Projector) ReplayStatus(id string) (ReplayJob, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	j, ok := p.replays[id]
+	if !ok {
+		return ReplayJob{}, false
+	}
+	return *j, true
+}
+
+// pruneReplaysLocked forgets jobs that finished more than a day ago.
+func (p *Projector) pruneReplaysLocked() {
+	for id, j := range p.replays {
+		if j.Done && j.FinishedAt != nil && time.Since(*j.FinishedAt) > 24*time.Hour {
+			delete(p.replays, id)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package events
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStartReplay_Guards(t *testing.T) {
+	p := NewProjector(nil)
+	if _, err := p.StartReplay([]string{"nope"}, time.Time{}); !errors.Is(err, ErrUnknownProjection) {
+		t.Fatalf("unknown projection: err = %v", err)
+	}
+
+	p.replays["1"] = &ReplayJob{ID: "1", Projections: []string{ProjectionDailyDecisions}}
+	if _, err := p.StartReplay([]string{ProjectionAdminActivity, ProjectionDailyDecisions}, time.Time{}); !errors.Is(err, ErrReplayRunning) {
+		t.Fatalf("overlapping replay: err = %v", err)
+	}
+	if _, err := p.StartReplay(nil, time.Time{}); !errors.Is(err, ErrReplayRunning) {
+		t.Fatalf("replay of all projections should overlap: err = %v", err)
+	}
+
+	job, ok := p.ReplayStatus("1")
+	if !ok || job.Done {
+		t.Fatalf("status = %+v, %v", job, ok)
+	}
+	if _, ok := p.ReplayStatus("2"); ok {
+		t.Fatal("unexpected job 2")
+	}
+}
+
+func TestProjections_WebhookAppended(t *testing.T) {
+	p := NewProjector(nil)
+	p.EnableWebhook("http://example.invalid/hook", "", time.Second)
+	got := p.Projections()
+	want := []string{ProjectionVenueTimeline, ProjectionAdminActivity, ProjectionDailyDecisions, ConsumerWebhook}
+	if len(got) != len(want) {
+		t.Fatalf("projections = %v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("projections = %v, want %v", got, want)
+		}
+	}
+}
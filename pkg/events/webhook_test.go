@@ -0,0 +1,44 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhookDeliver(t *testing.T) {
+	var got http.Header
+	var body []byte
+	status := http.StatusNoContent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	w := &webhook{url: srv.URL, secret: "s3cret", client: srv.Client()}
+	se := StoredEvent{Seq: 42, VenueID: 7, Type: TypeApproved, Ts: time.Now(), Payload: []byte(`{"reason":"ok"}`)}
+	if err := w.deliver(context.Background(), nil, se); err != nil {
+		t.Fatalf("deliver: %v", err)
+	}
+	if got.Get("X-Event-Id") != "42" || got.Get("X-Event-Type") != TypeApproved {
+		t.Fatalf("headers = %v", got)
+	}
+	if want := "sha256=" + sign("s3cret", body); got.Get("X-Signature") != want {
+		t.Fatalf("signature = %q, want %q", got.Get("X-Signature"), want)
+	}
+	var msg webhookMessage
+	if err := json.Unmarshal(body, &msg); err != nil || msg.ID != 42 || string(msg.Data) != `{"reason":"ok"}` {
+		t.Fatalf("body = %s (%v)", body, err)
+	}
+
+	status = http.StatusBadGateway
+	if err := w.deliver(context.Background(), nil, se); err == nil {
+		t.Fatal("expected error on 502 so the offset does not advance")
+	}
+}
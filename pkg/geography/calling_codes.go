@@ -0,0 +1,57 @@
+package geography
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+)
+
+// calling_codes.json maps the same country names as countries.json to their ITU calling code.
+// NANP islands carry their area code ("1242" for the Bahamas) so they are not confused with the US.
+//
+//go:embed calling_codes.json
+var callingCodesJSON []byte
+
+var (
+	countryToCallingCode map[string]string
+	knownCallingCodes    map[string]bool
+)
+
+func init() {
+	if err := json.Unmarshal(callingCodesJSON, &countryToCallingCode); err != nil {
+		panic("failed to load calling_codes.json: " + err.Error())
+	}
+	knownCallingCodes = make(map[string]bool, len(countryToCallingCode))
+	for _, c := range countryToCallingCode {
+		knownCallingCodes[c] = true
+	}
+}
+
+// GetCallingCode returns the calling code (digits, no "+") for a country name.
+// Accepts path-style names ("united_states"). Returns empty string if unknown.
+func GetCallingCode(country string) string {
+	normalized := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(country)), "_", " ")
+	return countryToCallingCode[normalized]
+}
+
+// CallingCodeOf returns the longest known calling code that prefixes an E.164 number.
+func CallingCodeOf(e164 string) string {
+	digits := strings.TrimPrefix(e164, "+")
+	for n := 4; n > 0; n-- {
+		if len(digits) >= n && knownCallingCodes[digits[:n]] {
+			return digits[:n]
+		}
+	}
+	return ""
+}
+
+// CountryFromPath returns the first segment of a venue path that is a known country,
+// e.g. "europe|germany|berlin" -> "germany". Returns empty string if none is found.
+func CountryFromPath(path string) string {
+	for _, seg := range strings.Split(path, "|") {
+		if GetCallingCode(seg) != "" {
+			return strings.ToLower(strings.TrimSpace(seg))
+		}
+	}
+	return ""
+}
@@ -0,0 +1,126 @@
+package geography
+
+import (
+	"strings"
+
+	"googlemaps.github.io/maps"
+)
+
+// countryAliases maps alternative spellings (ours and Google's) to the name used in countries.json.
+var countryAliases = map[string]string{
+	"usa":                                   "united states",
+	"united states of america":              "united states",
+	"uk":                                    "united kingdom",
+	"great britain":                         "united kingdom",
+	"russian federation":                    "russia",
+	"czech republic":                        "czechia",
+	"côte d'ivoire":                         "ivory coast",
+	"cote d'ivoire":                         "ivory coast",
+	"türkiye":                               "turkey",
+	"myanmar (burma)":                       "myanmar",
+	"cape verde":                            "cabo verde",
+	"congo - kinshasa":                      "democratic republic of the congo",
+	"congo - brazzaville":                   "congo",
+	"macedonia":                             "north macedonia",
+	"swaziland":                             "eswatini",
+	"são tomé and príncipe":                 "sao tome and principe",
+	"palestinian territories":               "palestine",
+	"vatican":                               "vatican city",
+	"st kitts and nevis":                    "saint kitts and nevis",
+	"st. kitts & nevis":                     "saint kitts and nevis",
+	"st. lucia":                             "saint lucia",
+	"st. vincent & grenadines":              "saint vincent and the grenadines",
+	"trinidad & tobago":                     "trinidad and tobago",
+	"bosnia & herzegovina":                  "bosnia and herzegovina",
+	"antigua & barbuda":                     "antigua and barbuda",
+	"korea, republic of":                    "south korea",
+	"republic of korea":                     "south korea",
+	"democratic people's republic of korea": "north korea",
+}
+
+// CanonicalCountry normalises a country name from a path segment or Google address component.
+func CanonicalCountry(name string) string {
+	n := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "_", " ")
+	if a, ok := countryAliases[n]; ok {
+		return a
+	}
+	return n
+}
+
+// RegionMatch compares a venue path with the address components found at its coordinates.
+// Country is checked strictly; deeper segments only need one of them to appear among the
+// components, since paths and Google often name regions differently ("munich" vs "München").
+type RegionMatch struct {
+	PathCountry   string   // country taken from the path, empty if none recognised
+	PlaceCountry  string   // country at the coordinates, empty if unknown
+	CountryMatch  bool     // false only when both countries are known and differ
+	RegionChecked bool     // path had segments below the country and components had names to compare
+	RegionMatch   bool     // at least one path segment below the country matched a component
+	PathRegions   []string // path segments below the country
+	PlaceRegions  []string // admin areas and localities at the coordinates
+}
+
+// regionComponentTypes are compared against path segments below the country.
+var regionComponentTypes = map[string]bool{
+	"administrative_area_level_1": true,
+	"administrative_area_level_2": true,
+	"administrative_area_level_3": true,
+	"locality":                    true,
+	"postal_town":                 true,
+	"sublocality":                 true,
+	"sublocality_level_1":         true,
+	"neighborhood":                true,
+}
+
+// CompareRegion checks that address components (from a place lookup or reverse geocode)
+// agree with the hierarchical venue path, e.g. "europe|germany|berlin".
+func CompareRegion(path string, comps []maps.AddressComponent) RegionMatch {
+	m := RegionMatch{CountryMatch: true}
+
+	segs := strings.Split(strings.ToLower(strings.TrimSpace(path)), "|")
+	countryIdx := -1
+	for i, s := range segs {
+		if GetCallingCode(CanonicalCountry(s)) != "" {
+			m.PathCountry = CanonicalCountry(s)
+			countryIdx = i
+			break
+		}
+	}
+	if countryIdx >= 0 {
+		for _, s := range segs[countryIdx+1:] {
+			if s = strings.TrimSpace(s); s != "" {
+				m.PathRegions = append(m.PathRegions, s)
+			}
+		}
+	}
+
+	names := map[string]bool{}
+	for _, c := range comps {
+		for _, t := range c.Types {
+			if t == "country" {
+				m.PlaceCountry = CanonicalCountry(c.LongName)
+				break
+			}
+			if regionComponentTypes[t] {
+				m.PlaceRegions = append(m.PlaceRegions, c.LongName)
+				names[NormalizeName(c.LongName)] = true
+				names[NormalizeName(c.ShortName)] = true
+				break
+			}
+		}
+	}
+
+	if m.PathCountry != "" && m.PlaceCountry != "" && GetCallingCode(m.PlaceCountry) != "" {
+		m.CountryMatch = m.PathCountry == m.PlaceCountry
+	}
+	if len(m.PathRegions) > 0 && len(names) > 0 {
+		m.RegionChecked = true
+		for _, s := range m.PathRegions {
+			if names[s] {
+				m.RegionMatch = true
+				break
+			}
+		}
+	}
+	return m
+}
@@ -221,6 +221,136 @@ func TestCountryToContinent_AllCountries(t *testing.T) {
 	}
 }
 
+func TestCallingCodes_CoverAllCountries(t *testing.T) {
+	for country := range countryToContinentMap {
+		if countryToCallingCode[country] == "" {
+			t.Errorf("Country %q has no calling code", country)
+		}
+	}
+}
+
+func TestCountryFromPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		country  string
+		wantCode string
+	}{
+		{"europe|germany|berlin", "germany", "49"},
+		{"north_america|united_states|georgia|atlanta", "united_states", "1"},
+		{"europe|united_kingdom|england|london", "united_kingdom", "44"},
+		{"north_america|bahamas|nassau", "bahamas", "1242"},
+		{"unknown|place", "", ""},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got := CountryFromPath(tt.path)
+			if got != tt.country || GetCallingCode(got) != tt.wantCode {
+				t.Errorf("CountryFromPath(%q) = %q (code %q), want %q (code %q)", tt.path, got, GetCallingCode(got), tt.country, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestCallingCodeOf(t *testing.T) {
+	tests := map[string]string{
+		"+49301234567":  "49",
+		"+12425551234":  "1242",
+		"+12125550123":  "1",
+		"+442079460958": "44",
+		"+3531234567":   "353",
+	}
+	for number, want := range tests {
+		if got := CallingCodeOf(number); got != want {
+			t.Errorf("CallingCodeOf(%q) = %q, want %q", number, got, want)
+		}
+	}
+}
+
+func TestTimezones_CoverAllCountries(t *testing.T) {
+	for country := range countryToContinentMap {
+		zone := countryTimezones[country]
+		regions := countryRegions[country]
+		if zone == "" && len(regions) == 0 {
+			t.Errorf("Country %q has no time zone", country)
+		}
+		if zone != "" && !ValidTimezone(zone) {
+			t.Errorf("Country %q has unknown time zone %q", country, zone)
+		}
+		for _, r := range regions {
+			if !ValidTimezone(r.Zone) {
+				t.Errorf("Country %q has unknown time zone %q", country, r.Zone)
+			}
+		}
+	}
+}
+
+func TestInferTimezone(t *testing.T) {
+	tests := []struct {
+		country   string
+		lat, lng  float64
+		hasCoords bool
+		want      string
+	}{
+		{"germany", 0, 0, false, "Europe/Berlin"},
+		{"United_Kingdom", 51.5, -0.1, true, "Europe/London"},
+		{"united_states", 40.71, -74.0, true, "America/New_York"},
+		{"united_states", 41.88, -87.63, true, "America/Chicago"},
+		{"usa", 39.74, -104.99, true, "America/Denver"},
+		{"united_states", 33.45, -112.07, true, "America/Phoenix"},
+		{"united_states", 34.05, -118.24, true, "America/Los_Angeles"},
+		{"united_states", 21.31, -157.86, true, "Pacific/Honolulu"},
+		{"canada", 49.28, -123.12, true, "America/Vancouver"},
+		{"australia", -31.95, 115.86, true, "Australia/Perth"},
+		{"australia", -33.87, 151.21, true, "Australia/Sydney"},
+		{"spain", 28.12, -15.43, true, "Atlantic/Canary"},
+		{"united_states", 0, 0, false, ""},
+		{"atlantis", 10, 10, true, ""},
+	}
+	for _, tt := range tests {
+		if got := InferTimezone(tt.country, tt.lat, tt.lng, tt.hasCoords); got != tt.want {
+			t.Errorf("InferTimezone(%q, %v, %v) = %q, want %q", tt.country, tt.lat, tt.lng, got, tt.want)
+		}
+	}
+}
+
+func TestCompareRegion(t *testing.T) {
+	berlin := []maps.AddressComponent{
+		{LongName: "Mitte", ShortName: "Mitte", Types: []string{"sublocality_level_1", "sublocality", "political"}},
+		{LongName: "Berlin", ShortName: "Berlin", Types: []string{"locality", "political"}},
+		{LongName: "Berlin", ShortName: "BE", Types: []string{"administrative_area_level_1", "political"}},
+		{LongName: "Germany", ShortName: "DE", Types: []string{"country", "political"}},
+	}
+	nyc := []maps.AddressComponent{
+		{LongName: "New York", ShortName: "New York", Types: []string{"locality", "political"}},
+		{LongName: "New York", ShortName: "NY", Types: []string{"administrative_area_level_1", "political"}},
+		{LongName: "United States", ShortName: "US", Types: []string{"country", "political"}},
+	}
+	tests := []struct {
+		name         string
+		path         string
+		comps        []maps.AddressComponent
+		countryMatch bool
+		regionCheck  bool
+		regionMatch  bool
+	}{
+		{"match", "europe|germany|berlin", berlin, true, true, true},
+		{"wrong city", "europe|germany|munich", berlin, true, true, false},
+		{"wrong country", "europe|austria|vienna", berlin, false, true, false},
+		{"country only path", "europe|germany", berlin, true, false, false},
+		{"alias and multi-word", "north_america|usa|new_york|new_york_city", nyc, true, true, true},
+		{"unknown path country", "somewhere|atlantis", berlin, true, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := CompareRegion(tt.path, tt.comps)
+			if m.CountryMatch != tt.countryMatch || m.RegionChecked != tt.regionCheck || m.RegionMatch != tt.regionMatch {
+				t.Errorf("CompareRegion(%q) = %+v", tt.path, m)
+			}
+		})
+	}
+}
+
 func BenchmarkGetContinent(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		GetContinent("united states")
@@ -0,0 +1,76 @@
+package geography
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+	"time"
+	_ "time/tzdata" // zone checks must not depend on the host's zoneinfo
+)
+
+// timezones.json maps the same country names as countries.json to an IANA time zone.
+// Countries spanning several zones list regions instead, matched in order against the
+// venue's coordinates; a region without bounds matches anywhere. The regions follow zone
+// borders only roughly, so near a border the inferred zone can be a neighbour's.
+//
+//go:embed timezones.json
+var timezonesJSON []byte
+
+type timezoneRegion struct {
+	Zone   string   `json:"zone"`
+	MinLat *float64 `json:"min_lat"`
+	MaxLat *float64 `json:"max_lat"`
+	MinLng *float64 `json:"min_lng"`
+	MaxLng *float64 `json:"max_lng"`
+}
+
+func (r timezoneRegion) contains(lat, lng float64) bool {
+	return (r.MinLat == nil || lat >= *r.MinLat) && (r.MaxLat == nil || lat < *r.MaxLat) &&
+		(r.MinLng == nil || lng >= *r.MinLng) && (r.MaxLng == nil || lng < *r.MaxLng)
+}
+
+var (
+	countryTimezones map[string]string
+	countryRegions   map[string][]timezoneRegion
+)
+
+func init() {
+	var data struct {
+		Zones   map[string]string           `json:"zones"`
+		Regions map[string][]timezoneRegion `json:"regions"`
+	}
+	if err := json.Unmarshal(timezonesJSON, &data); err != nil {
+		panic("failed to load timezones.json: " + err.Error())
+	}
+	countryTimezones, countryRegions = data.Zones, data.Regions
+}
+
+// InferTimezone returns the IANA time zone of a place in a country (a countries.json name,
+// path style accepted). The coordinates pick the zone in countries with several; hasCoords
+// false means they are unknown, and such countries return "". Returns "" for unknown
+// countries.
+func InferTimezone(country string, lat, lng float64, hasCoords bool) string {
+	normalized := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(country)), "_", " ")
+	if zone, ok := countryTimezones[normalized]; ok {
+		return zone
+	}
+	if !hasCoords {
+		return ""
+	}
+	for _, r := range countryRegions[normalized] {
+		if r.contains(lat, lng) {
+			return r.Zone
+		}
+	}
+	return ""
+}
+
+// ValidTimezone reports whether name is an IANA time zone, e.g. "Europe/Berlin".
+func ValidTimezone(name string) bool {
+	name = strings.TrimSpace(name)
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
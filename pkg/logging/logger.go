@@ -385,11 +385,7 @@ func (l *Logger) logWithContext(ctx context.Context, level LogLevel, msg, errorS
 	}
 
 	// Extract context values
-	if requestID := ctx.Value("request_id"); requestID != nil {
-		if id, ok := requestID.(string); ok {
-			entry.RequestID = id
-		}
-	}
+	entry.RequestID = RequestID(ctx)
 
 	if venueID := ctx.Value("venue_id"); venueID != nil {
 		if id, ok := venueID.(int64); ok {
@@ -0,0 +1,16 @@
+package logging
+
+import "context"
+
+type requestIDKey struct{}
+
+// WithRequestID returns ctx carrying the correlation ID of the HTTP request it serves.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the correlation ID stored by WithRequestID, or "".
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
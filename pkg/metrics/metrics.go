@@ -86,12 +86,105 @@ func (h *Histogram) Observe(v float64) {
 	}
 }
 
+// HistogramVec is a family of histograms with shared buckets partitioned by label values,
+// e.g. db_query_duration_seconds{statement="GetVenueWithUserByIDCtx"}. Keep label values bounded.
+type HistogramVec struct {
+	name     string
+	help     string
+	labels   []string
+	buckets  []float64
+	mu       sync.RWMutex
+	children map[string]*vecHistogram
+}
+
+type vecHistogram struct {
+	values []string
+	h      *Histogram
+}
+
+// With returns the histogram for the given label values (in label order), creating it on first use.
+func (v *HistogramVec) With(values ...string) *Histogram {
+	vals := make([]string, len(v.labels))
+	copy(vals, values)
+	key := strings.Join(vals, "\xff")
+
+	v.mu.RLock()
+	c, ok := v.children[key]
+	v.mu.RUnlock()
+	if ok {
+		return c.h
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if c, ok = v.children[key]; !ok {
+		c = &vecHistogram{values: vals, h: &Histogram{name: v.name, buckets: v.buckets, counts: make([]uint64, len(v.buckets))}}
+		v.children[key] = c
+	}
+	return c.h
+}
+
+// CounterVec is a family of float counters partitioned by label values, e.g.
+// openai_cost_usd_total{model="gpt-4o-mini",call_type="scoring"}. Keep label values bounded.
+type CounterVec struct {
+	name     string
+	help     string
+	labels   []string
+	mu       sync.RWMutex
+	children map[string]*VecCounter
+}
+
+// VecCounter is one labeled member of a CounterVec.
+type VecCounter struct {
+	values []string
+	f64    uint64 // float64 bits, atomic
+}
+
+// With returns the counter for the given label values (in label order), creating it on first use.
+// Missing values are treated as empty strings; extra values are ignored.
+func (v *CounterVec) With(values ...string) *VecCounter {
+	vals := make([]string, len(v.labels))
+	copy(vals, values)
+	key := strings.Join(vals, "\xff")
+
+	v.mu.RLock()
+	c, ok := v.children[key]
+	v.mu.RUnlock()
+	if ok {
+		return c
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if c, ok = v.children[key]; !ok {
+		c = &VecCounter{values: vals}
+		v.children[key] = c
+	}
+	return c
+}
+
+// Add increases the counter; negative deltas are ignored since counters only go up.
+func (c *VecCounter) Add(delta float64) {
+	if delta <= 0 {
+		return
+	}
+	for {
+		old := atomic.LoadUint64(&c.f64)
+		if atomic.CompareAndSwapUint64(&c.f64, old, mathFloat64bits(mathFloat64frombits(old)+delta)) {
+			return
+		}
+	}
+}
+
+func (c *VecCounter) Inc()         { c.Add(1) }
+func (c *VecCounter) Get() float64 { return mathFloat64frombits(atomic.LoadUint64(&c.f64)) }
+
 // Registry holds all metrics.
 type Registry struct {
 	mu         sync.RWMutex
 	counters   map[string]*Counter
 	gauges     map[string]*Gauge
 	histograms map[string]*Histogram
+	vecs       map[string]*CounterVec
+	histVecs   map[string]*HistogramVec
 }
 
 func NewRegistry() *Registry {
@@ -99,6 +192,8 @@ func NewRegistry() *Registry {
 		counters:   make(map[string]*Counter),
 		gauges:     make(map[string]*Gauge),
 		histograms: make(map[string]*Histogram),
+		vecs:       make(map[string]*CounterVec),
+		histVecs:   make(map[string]*HistogramVec),
 	}
 }
 
@@ -126,6 +221,21 @@ func (r *Registry) Gauge(name, help string) *Gauge {
 	return g
 }
 
+func (r *Registry) CounterVec(name, help string, labels ...string) *CounterVec {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if v, ok := r.vecs[name]; ok {
+		return v
+	}
+	ls := make([]string, len(labels))
+	for i, l := range labels {
+		ls[i] = sanitize(l)
+	}
+	v := &CounterVec{name: sanitize(name), help: help, labels: ls, children: make(map[string]*VecCounter)}
+	r.vecs[name] = v
+	return v
+}
+
 func (r *Registry) Histogram(name, help string, buckets []float64) *Histogram {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -143,6 +253,26 @@ func (r *Registry) Histogram(name, help string, buckets []float64) *Histogram {
 	return h
 }
 
+func (r *Registry) HistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if v, ok := r.histVecs[name]; ok {
+		return v
+	}
+	if len(buckets) == 0 || buckets[len(buckets)-1] != +Inf {
+		buckets = append(append([]float64{}, buckets...), +Inf)
+	}
+	sorted := append([]float64{}, buckets...)
+	sort.Float64s(sorted)
+	ls := make([]string, len(labels))
+	for i, l := range labels {
+		ls[i] = sanitize(l)
+	}
+	v := &HistogramVec{name: sanitize(name), help: help, labels: ls, buckets: sorted, children: make(map[string]*vecHistogram)}
+	r.histVecs[name] = v
+	return v
+}
+
 // Handler returns an http.Handler that exposes metrics in Prometheus text format.
 func (r *Registry) Handler() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
@@ -154,6 +284,8 @@ func (r *Registry) Handler() http.Handler {
 		cn := keys(r.counters)
 		gn := keys(r.gauges)
 		hn := keys(r.histograms)
+		vn := keys(r.vecs)
+		hvn := keys(r.histVecs)
 		r.mu.RUnlock()
 
 		for _, name := range cn {
@@ -167,6 +299,23 @@ func (r *Registry) Handler() http.Handler {
 			fmt.Fprintf(w, "# TYPE %s counter\n", c.name)
 			fmt.Fprintf(w, "%s %d\n", c.name, c.Get())
 		}
+		for _, name := range vn {
+			r.mu.RLock()
+			v := r.vecs[name]
+			r.mu.RUnlock()
+			if v == nil {
+				continue
+			}
+			fmt.Fprintf(w, "# HELP %s %s\n", v.name, escapeHelp(v.help))
+			fmt.Fprintf(w, "# TYPE %s counter\n", v.name)
+			v.mu.RLock()
+			ck := keys(v.children)
+			for _, k := range ck {
+				c := v.children[k]
+				fmt.Fprintf(w, "%s%s %g\n", v.name, formatLabels(v.labels, c.values), c.Get())
+			}
+			v.mu.RUnlock()
+		}
 		for _, name := range gn {
 			r.mu.RLock()
 			g := r.gauges[name]
@@ -202,6 +351,37 @@ func (r *Registry) Handler() http.Handler {
 			fmt.Fprintf(w, "%s_sum %g\n", h.name, sum)
 			fmt.Fprintf(w, "%s_count %d\n", h.name, atomic.LoadUint64(&h.count))
 		}
+		for _, name := range hvn {
+			r.mu.RLock()
+			v := r.histVecs[name]
+			r.mu.RUnlock()
+			if v == nil {
+				continue
+			}
+			fmt.Fprintf(w, "# HELP %s %s\n", v.name, escapeHelp(v.help))
+			fmt.Fprintf(w, "# TYPE %s histogram\n", v.name)
+			v.mu.RLock()
+			for _, k := range keys(v.children) {
+				c := v.children[k]
+				lbl := formatLabels(v.labels, c.values)
+				inner := strings.TrimSuffix(strings.TrimPrefix(lbl, "{"), "}")
+				if inner != "" {
+					inner += ","
+				}
+				var cum uint64
+				for i, ub := range c.h.buckets {
+					cum += atomic.LoadUint64(&c.h.counts[i])
+					le := fmt.Sprintf("%g", ub)
+					if isInf(ub) {
+						le = "+Inf"
+					}
+					fmt.Fprintf(w, "%s_bucket{%sle=\"%s\"} %d\n", v.name, inner, le, cum)
+				}
+				fmt.Fprintf(w, "%s_sum%s %g\n", v.name, lbl, mathFloat64frombits(atomic.LoadUint64(&c.h.sum)))
+				fmt.Fprintf(w, "%s_count%s %d\n", v.name, lbl, atomic.LoadUint64(&c.h.count))
+			}
+			v.mu.RUnlock()
+		}
 	})
 }
 
@@ -225,6 +405,28 @@ func sanitize(s string) string {
 	return s
 }
 
+// formatLabels renders {a="x",b="y"} with Prometheus label-value escaping.
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, n := range names {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(n)
+		b.WriteString(`="`)
+		b.WriteString(labelEscaper.Replace(values[i]))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
 func escapeHelp(s string) string {
 	return strings.ReplaceAll(s, "\n", " ")
 }
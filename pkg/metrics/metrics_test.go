@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCounterVec_Exposition(t *testing.T) {
+	r := NewRegistry()
+	v := r.CounterVec("openai_cost_usd_total", "Estimated spend", "model", "call_type")
+	v.With("gpt-4o-mini", "scoring").Add(0.25)
+	v.With("gpt-4o-mini", "scoring").Add(0.5)
+	v.With("gpt-4o", `quo"te`).Inc()
+	v.With("gpt-4o", "scoring").Add(-1) // ignored: counters never decrease
+
+	if got := v.With("gpt-4o-mini", "scoring").Get(); got != 0.75 {
+		t.Fatalf("Get()=%v want 0.75", got)
+	}
+	if r.CounterVec("openai_cost_usd_total", "dup") != v {
+		t.Fatalf("re-registering must return the same vector")
+	}
+
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+	for _, want := range []string{
+		"# TYPE openai_cost_usd_total counter",
+		`openai_cost_usd_total{model="gpt-4o-mini",call_type="scoring"} 0.75`,
+		`openai_cost_usd_total{model="gpt-4o",call_type="quo\"te"} 1`,
+		`openai_cost_usd_total{model="gpt-4o",call_type="scoring"} 0`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("missing %q in:\n%s", want, body)
+		}
+	}
+}
+
+func TestHistogramVec_Exposition(t *testing.T) {
+	r := NewRegistry()
+	v := r.HistogramVec("db_query_duration_seconds", "Query latency", []float64{0.1, 1}, "statement")
+	v.With("GetVenueCtx").Observe(0.05)
+	v.With("GetVenueCtx").Observe(0.5)
+	v.With("ListCtx").Observe(3)
+
+	if r.HistogramVec("db_query_duration_seconds", "dup", nil) != v {
+		t.Fatalf("re-registering must return the same vector")
+	}
+
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+	for _, want := range []string{
+		"# TYPE db_query_duration_seconds histogram",
+		`db_query_duration_seconds_bucket{statement="GetVenueCtx",le="0.1"} 1`,
+		`db_query_duration_seconds_bucket{statement="GetVenueCtx",le="1"} 2`,
+		`db_query_duration_seconds_bucket{statement="GetVenueCtx",le="+Inf"} 2`,
+		`db_query_duration_seconds_count{statement="GetVenueCtx"} 2`,
+		`db_query_duration_seconds_bucket{statement="ListCtx",le="1"} 0`,
+		`db_query_duration_seconds_bucket{statement="ListCtx",le="+Inf"} 1`,
+		`db_query_duration_seconds_sum{statement="ListCtx"} 3`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("missing %q in:\n%s", want, body)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package monitoring
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -12,6 +14,7 @@ import (
 	"time"
 
 	"assisted-venue-approval/pkg/config"
+	"assisted-venue-approval/pkg/logging"
 	pp "net/http/pprof"
 )
 
@@ -82,6 +85,9 @@ func (sw *statusWriter) WriteHeader(statusCode int) {
 	sw.w.WriteHeader(statusCode)
 }
 
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to flush a stream.
+func (sw *statusWriter) Unwrap() http.ResponseWriter { return sw.w }
+
 // Middleware returns a standard http middleware that measures request duration
 // and records it into Metrics. Keep it simple; we don't track labels.
 func Middleware(m *Metrics) func(http.Handler) http.Handler {
@@ -96,6 +102,41 @@ func Middleware(m *Metrics) func(http.Handler) http.Handler {
 	}
 }
 
+// RequestIDHeader carries the correlation ID of a request in both directions.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID gives every request a correlation ID: the caller's X-Request-ID when it is a
+// plausible ID, otherwise a new random one. The ID is echoed in the response header and
+// stored in the request context (logging.RequestID) so logs and error bodies can quote it.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(logging.WithRequestID(r.Context(), id)))
+	})
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 64 {
+		return false
+	}
+	for _, c := range id {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.') {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
 // MetricsHandler exposes runtime and request metrics in JSON for quick consumption.
 func MetricsHandler(m *Metrics) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
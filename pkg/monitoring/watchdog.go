@@ -0,0 +1,183 @@
+package monitoring
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"runtime"
+	"time"
+
+	"assisted-venue-approval/pkg/metrics"
+)
+
+var (
+	mGoroutines        = metrics.Default.Gauge("runtime_goroutines", "Goroutines at the last watchdog sample")
+	mGoroutineBaseline = metrics.Default.Gauge("runtime_goroutines_baseline", "Lowest goroutine count the watchdog has seen")
+	mDBOpen            = metrics.Default.Gauge("db_pool_open_connections", "Open database connections, in use or idle")
+	mDBInUse           = metrics.Default.Gauge("db_pool_in_use_connections", "Database connections in use")
+	mDBIdle            = metrics.Default.Gauge("db_pool_idle_connections", "Idle database connections")
+	mDBMaxOpen         = metrics.Default.Gauge("db_pool_max_open_connections", "Database connection limit (0 is unlimited)")
+	mDBWaits           = metrics.Default.Gauge("db_pool_wait_count", "Queries that waited for a free database connection since start")
+	mAnomalies         = metrics.Default.CounterVec("watchdog_anomalies_total", "Resource anomalies the watchdog logged, by kind", "kind")
+)
+
+// Anomaly kinds in watchdog_anomalies_total.
+const (
+	AnomalyGoroutineGrowth = "goroutine_growth"
+	AnomalyDBPoolExhausted = "db_pool_exhausted"
+	AnomalyQueueStalled    = "queue_stalled"
+)
+
+// WatchdogConfig tunes the resource watchdog.
+type WatchdogConfig struct {
+	Interval        time.Duration // between samples
+	GoroutineGrowth int           // goroutines above the lowest count seen that count as a leak
+	AlertAfter      time.Duration // how long an anomaly must last before it is logged
+}
+
+// QueueStat is an in-process queue as sampled by the watchdog. Taken counts the items ever
+// taken off the queue: a queue holding items whose Taken does not move is stalled.
+type QueueStat struct {
+	Name  string
+	Len   int
+	Cap   int
+	Taken int64
+}
+
+// WatchdogSources are sampled alongside the goroutine count; nil sources are skipped.
+type WatchdogSources struct {
+	DB     func() sql.DBStats
+	Queues func() []QueueStat
+}
+
+// Watchdog samples goroutines, the database pool and queues, exports them on /metrics and
+// logs anomalies that last: goroutines staying above their baseline, every database
+// connection staying in use, and queues holding items nobody takes. They point at leaks
+// (goroutines or connections not released) and stuck consumers well before an OOM.
+type Watchdog struct {
+	cfg    WatchdogConfig
+	src    WatchdogSources
+	logger func(string, ...any)
+
+	baseline   int       // lowest goroutine count seen; 0 before the first sample
+	aboveSince time.Time // when goroutines last rose above baseline+GoroutineGrowth
+	dbFullFrom time.Time // when every connection was last found in use
+	queues     map[string]*queueWatch
+	alerting   map[string]bool // anomaly key -> logged and not yet recovered
+}
+
+type queueWatch struct {
+	taken int64
+	since time.Time // last time the queue was empty or had an item taken
+	len   *metrics.Gauge
+	cap   *metrics.Gauge
+}
+
+// NewWatchdog returns a watchdog logging through logger (fmt.Printf-compatible).
+func NewWatchdog(cfg WatchdogConfig, src WatchdogSources, logger func(string, ...any)) *Watchdog {
+	if logger == nil {
+		logger = func(format string, a ...any) { fmt.Printf(format+"\n", a...) }
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = 15 * time.Second
+	}
+	return &Watchdog{cfg: cfg, src: src, logger: logger, queues: map[string]*queueWatch{}, alerting: map[string]bool{}}
+}
+
+// Run samples every cfg.Interval until ctx is done.
+func (w *Watchdog) Run(ctx context.Context) {
+	t := time.NewTicker(w.cfg.Interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-t.C:
+			w.sample(now, runtime.NumGoroutine())
+		}
+	}
+}
+
+func (w *Watchdog) sample(now time.Time, goroutines int) {
+	w.checkGoroutines(now, goroutines)
+	if w.src.DB != nil {
+		w.checkDB(now, w.src.DB())
+	}
+	if w.src.Queues != nil {
+		for _, q := range w.src.Queues() {
+			w.checkQueue(now, q)
+		}
+	}
+}
+
+func (w *Watchdog) checkGoroutines(now time.Time, n int) {
+	if w.baseline == 0 || n < w.baseline {
+		w.baseline = n
+	}
+	mGoroutines.SetFloat64(float64(n))
+	mGoroutineBaseline.SetFloat64(float64(w.baseline))
+
+	above := n-w.baseline >= w.cfg.GoroutineGrowth
+	if !above {
+		w.aboveSince = time.Time{}
+	} else if w.aboveSince.IsZero() {
+		w.aboveSince = now
+	}
+	w.flag("goroutines", AnomalyGoroutineGrowth, above && now.Sub(w.aboveSince) >= w.cfg.AlertAfter,
+		fmt.Sprintf("goroutines at %d, %d above the baseline of %d for %s; a goroutine may be leaking, see /debug/pprof/goroutine",
+			n, n-w.baseline, w.baseline, now.Sub(w.aboveSince).Round(time.Second)))
+}
+
+func (w *Watchdog) checkDB(now time.Time, st sql.DBStats) {
+	mDBOpen.SetFloat64(float64(st.OpenConnections))
+	mDBInUse.SetFloat64(float64(st.InUse))
+	mDBIdle.SetFloat64(float64(st.Idle))
+	mDBMaxOpen.SetFloat64(float64(st.MaxOpenConnections))
+	mDBWaits.SetFloat64(float64(st.WaitCount))
+
+	full := st.MaxOpenConnections > 0 && st.InUse >= st.MaxOpenConnections
+	if !full {
+		w.dbFullFrom = time.Time{}
+	} else if w.dbFullFrom.IsZero() {
+		w.dbFullFrom = now
+	}
+	w.flag("db_pool", AnomalyDBPoolExhausted, full && now.Sub(w.dbFullFrom) >= w.cfg.AlertAfter,
+		fmt.Sprintf("all %d database connections in use for %s (%d waits so far); rows or transactions may not be closed",
+			st.MaxOpenConnections, now.Sub(w.dbFullFrom).Round(time.Second), st.WaitCount))
+}
+
+func (w *Watchdog) checkQueue(now time.Time, q QueueStat) {
+	qw := w.queues[q.Name]
+	if qw == nil {
+		qw = &queueWatch{
+			taken: q.Taken,
+			since: now,
+			len:   metrics.Default.Gauge("queue_length_"+q.Name, "Items waiting in the "+q.Name+" queue"),
+			cap:   metrics.Default.Gauge("queue_capacity_"+q.Name, "Capacity of the "+q.Name+" queue"),
+		}
+		w.queues[q.Name] = qw
+	}
+	qw.len.SetFloat64(float64(q.Len))
+	qw.cap.SetFloat64(float64(q.Cap))
+
+	if q.Len == 0 || q.Taken != qw.taken {
+		qw.taken, qw.since = q.Taken, now
+	}
+	w.flag("queue:"+q.Name, AnomalyQueueStalled, now.Sub(qw.since) >= w.cfg.AlertAfter,
+		fmt.Sprintf("%s queue holds %d of %d items and none was taken for %s; its consumer may be stuck",
+			q.Name, q.Len, q.Cap, now.Sub(qw.since).Round(time.Second)))
+}
+
+// flag logs an ALERT when anomaly key starts and a RECOVERY when it ends, like
+// StartRuntimeMonitor. msg describes the anomaly and is only logged when it starts.
+func (w *Watchdog) flag(key, kind string, bad bool, msg string) {
+	switch {
+	case bad && !w.alerting[key]:
+		w.alerting[key] = true
+		mAnomalies.With(kind).Inc()
+		w.logger("ALERT: watchdog: %s", msg)
+	case !bad && w.alerting[key]:
+		delete(w.alerting, key)
+		w.logger("RECOVERY: watchdog: %s back to normal", key)
+	}
+}
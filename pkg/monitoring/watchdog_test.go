@@ -0,0 +1,65 @@
+package monitoring
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type logLines []string
+
+func (l *logLines) printf(format string, a ...any) { *l = append(*l, fmt.Sprintf(format, a...)) }
+
+func TestWatchdogGoroutineGrowth(t *testing.T) {
+	var logs logLines
+	w := NewWatchdog(WatchdogConfig{Interval: time.Second, GoroutineGrowth: 50, AlertAfter: 3 * time.Second}, WatchdogSources{}, logs.printf)
+	start := time.Now()
+	counts := []int{40, 30, 100, 110, 120, 130, 135, 30}
+	for i, n := range counts {
+		w.sample(start.Add(time.Duration(i)*time.Second), n)
+	}
+	if w.baseline != 30 {
+		t.Errorf("baseline = %d, want 30", w.baseline)
+	}
+	if len(logs) != 2 || !strings.HasPrefix(logs[0], "ALERT: watchdog: goroutines at 130, 100 above the baseline of 30 for 3s") ||
+		!strings.HasPrefix(logs[1], "RECOVERY: watchdog: goroutines") {
+		t.Errorf("logs = %q", logs)
+	}
+}
+
+func TestWatchdogDBPoolAndQueues(t *testing.T) {
+	var logs logLines
+	db := sql.DBStats{MaxOpenConnections: 4, OpenConnections: 4, InUse: 4, WaitCount: 12}
+	results := QueueStat{Name: "results", Len: 3, Cap: 10, Taken: 5}
+	jobs := QueueStat{Name: "jobs", Len: 8, Cap: 10, Taken: 1}
+	w := NewWatchdog(WatchdogConfig{Interval: time.Minute, GoroutineGrowth: 1000, AlertAfter: 2 * time.Minute}, WatchdogSources{
+		DB:     func() sql.DBStats { return db },
+		Queues: func() []QueueStat { return []QueueStat{jobs, results} },
+	}, logs.printf)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		jobs.Taken++ // workers keep taking jobs; nobody takes results
+		w.sample(start.Add(time.Duration(i)*time.Minute), 20)
+	}
+	want := []string{
+		"ALERT: watchdog: all 4 database connections in use for 2m0s (12 waits so far); rows or transactions may not be closed",
+		"ALERT: watchdog: results queue holds 3 of 10 items and none was taken for 2m0s; its consumer may be stuck",
+	}
+	if strings.Join(logs, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("logs = %q", logs)
+	}
+
+	// Draining the results queue and releasing connections recovers both
+	logs = nil
+	db.InUse, results.Len = 1, 0
+	w.sample(start.Add(3*time.Minute), 20)
+	if len(logs) != 2 || !strings.Contains(logs[0], "db_pool back to normal") || !strings.Contains(logs[1], "queue:results back to normal") {
+		t.Errorf("logs = %q", logs)
+	}
+	if got := mAnomalies.With(AnomalyQueueStalled).Get(); got < 1 {
+		t.Errorf("queue_stalled anomalies = %v", got)
+	}
+}
@@ -0,0 +1,22 @@
+package utils
+
+import "math"
+
+// HaversineMeters computes the great-circle distance between two lat/lng points in meters
+func HaversineMeters(lat1, lng1, lat2, lng2 float64) float64 {
+	const earthRadiusMeters = 6371000
+
+	// Convert to radians
+	lat1Rad := lat1 * (math.Pi / 180)
+	lat2Rad := lat2 * (math.Pi / 180)
+	deltaLatRad := (lat2 - lat1) * (math.Pi / 180)
+	deltaLngRad := (lng2 - lng1) * (math.Pi / 180)
+
+	// Haversine formula
+	a := math.Sin(deltaLatRad/2)*math.Sin(deltaLatRad/2) +
+		math.Cos(lat1Rad)*math.Cos(lat2Rad)*
+			math.Sin(deltaLngRad/2)*math.Sin(deltaLngRad/2)
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+
+	return earthRadiusMeters * c
+}
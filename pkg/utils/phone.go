@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 )
@@ -75,3 +76,75 @@ func ComparePhoneNumbers(p1, p2 string) float64 {
 
 	return 0.0
 }
+
+// E.164 allows at most 15 digits including the country code; anything under 8 is not a
+// dialable subscriber number in any plan we care about.
+const (
+	e164MaxDigits = 15
+	e164MinDigits = 8
+)
+
+// NormalizeE164 converts a phone number to E.164 ("+4930123456").
+// Numbers already in international form ("+", "00", or NANP "011" prefix) keep their own
+// country code; national numbers get callingCode (digits, e.g. "49") with the trunk prefix removed.
+// Returns an error when the result cannot be a valid E.164 number.
+func NormalizeE164(phone, callingCode string) (string, error) {
+	raw := strings.TrimSpace(phone)
+	if raw == "" {
+		return "", fmt.Errorf("empty phone number")
+	}
+	// Drop extensions ("ext. 12", "x12") before extracting digits
+	if loc := phoneExtRe.FindStringIndex(raw); loc != nil {
+		raw = raw[:loc[0]]
+	}
+	digits := ExtractPhoneDigits(raw)
+
+	var full string
+	switch {
+	case strings.HasPrefix(raw, "+"):
+		full = digits
+	case strings.HasPrefix(digits, "00"):
+		full = digits[2:]
+	case strings.HasPrefix(digits, "011") && strings.HasPrefix(callingCode, "1"):
+		full = digits[3:]
+	case callingCode == "":
+		return "", fmt.Errorf("national number %q without a country", phone)
+	default:
+		full = nationalToInternational(digits, callingCode)
+	}
+
+	if len(full) < e164MinDigits || len(full) > e164MaxDigits || full[0] == '0' {
+		return "", fmt.Errorf("%q is not a valid international number", phone)
+	}
+	return "+" + full, nil
+}
+
+var phoneExtRe = regexp.MustCompile(`(?i)\s*(ext\.?|x|#)\s*\d+\s*$`)
+
+// nationalToInternational strips the national trunk prefix and prepends the calling code.
+func nationalToInternational(digits, callingCode string) string {
+	switch {
+	case strings.HasPrefix(callingCode, "1"):
+		// NANP: 10-digit national numbers, optionally written with a leading 1; islands
+		// publish 7-digit local numbers under their area code
+		if len(digits) == 11 && digits[0] == '1' {
+			return digits
+		}
+		if len(digits) == 10 {
+			return "1" + digits
+		}
+		return callingCode + digits
+	case callingCode == "39" || callingCode == "378":
+		// Italy and San Marino keep the leading 0 after the country code
+		return callingCode + digits
+	case callingCode == "7" || callingCode == "375":
+		// Russia/Kazakhstan and Belarus still use 8 as the trunk prefix
+		digits = strings.TrimPrefix(digits, "8")
+	}
+	// Already includes the country code without "+" (e.g. "4930123456"); national numbers
+	// are never this long after the code
+	if strings.HasPrefix(digits, callingCode) && len(digits) >= len(callingCode)+8 {
+		return digits
+	}
+	return callingCode + strings.TrimPrefix(digits, "0")
+}
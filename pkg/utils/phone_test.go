@@ -0,0 +1,38 @@
+package utils
+
+import "testing"
+
+func TestNormalizeE164(t *testing.T) {
+	tests := []struct {
+		name    string
+		phone   string
+		code    string
+		want    string
+		wantErr bool
+	}{
+		{"international", "+49 30 1234567", "49", "+49301234567", false},
+		{"international other country", "+33 1 42 68 53 00", "49", "+33142685300", false},
+		{"00 prefix", "0044 20 7946 0958", "49", "+442079460958", false},
+		{"german national", "030 1234567", "49", "+49301234567", false},
+		{"uk national", "020 7946 0958", "44", "+442079460958", false},
+		{"us national", "(212) 555-0123", "1", "+12125550123", false},
+		{"us with leading 1", "1-212-555-0123", "1", "+12125550123", false},
+		{"us 011", "011 44 20 7946 0958", "1", "+442079460958", false},
+		{"italy keeps zero", "06 1234 5678", "39", "+390612345678", false},
+		{"russia trunk 8", "8 495 123-45-67", "7", "+74951234567", false},
+		{"code without plus", "49 30 1234567", "49", "+49301234567", false},
+		{"extension dropped", "+49 30 1234567 ext. 12", "49", "+49301234567", false},
+		{"national without country", "030 1234567", "", "", true},
+		{"too short", "+49 30", "49", "", true},
+		{"too long", "+49 30 1234567890123", "49", "", true},
+		{"empty", "  ", "49", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeE164(tt.phone, tt.code)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Fatalf("NormalizeE164(%q, %q) = %q, %v; want %q, err=%v", tt.phone, tt.code, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}